@@ -211,6 +211,7 @@ type CbuRelationship struct {
 	RoleId        string                 `protobuf:"bytes,6,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`                    // Associated role ID
 	IsBeneficial  bool                   `protobuf:"varint,7,opt,name=is_beneficial,json=isBeneficial,proto3" json:"is_beneficial,omitempty"` // True if beneficial ownership
 	EffectiveDate *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=effective_date,json=effectiveDate,proto3" json:"effective_date,omitempty"`
+	EndDate       *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"` // Expiry of the relationship (optional)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -301,6 +302,13 @@ func (x *CbuRelationship) GetEffectiveDate() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *CbuRelationship) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
 // CbuGraph represents the complete organizational graph for a Client Business Unit
 type CbuGraph struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
@@ -422,6 +430,7 @@ func (x *CbuGraph) GetRelationshipCount() int32 {
 type GetCbuRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	CbuId         string                 `protobuf:"bytes,1,opt,name=cbu_id,json=cbuId,proto3" json:"cbu_id,omitempty"`
+	AsOf          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"` // GetGraph/ListEntities: graph as of this time; unset is now
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -463,29 +472,37 @@ func (x *GetCbuRequest) GetCbuId() string {
 	return ""
 }
 
-// GetEntityRequest requests a specific entity
-type GetEntityRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	CbuId         string                 `protobuf:"bytes,1,opt,name=cbu_id,json=cbuId,proto3" json:"cbu_id,omitempty"`
-	EntityId      string                 `protobuf:"bytes,2,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *GetCbuRequest) GetAsOf() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AsOf
+	}
+	return nil
 }
 
-func (x *GetEntityRequest) Reset() {
-	*x = GetEntityRequest{}
+// ValidateGraphRequest requests validation of a CBU graph. Field 1 matches
+// GetCbuRequest so older clients stay wire compatible.
+type ValidateGraphRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	CbuId              string                 `protobuf:"bytes,1,opt,name=cbu_id,json=cbuId,proto3" json:"cbu_id,omitempty"`
+	OwnershipTolerance float32                `protobuf:"fixed32,2,opt,name=ownership_tolerance,json=ownershipTolerance,proto3" json:"ownership_tolerance,omitempty"` // percentage points allowed above 100%; 0 uses 0.01
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ValidateGraphRequest) Reset() {
+	*x = ValidateGraphRequest{}
 	mi := &file_api_proto_cbu_graph_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetEntityRequest) String() string {
+func (x *ValidateGraphRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetEntityRequest) ProtoMessage() {}
+func (*ValidateGraphRequest) ProtoMessage() {}
 
-func (x *GetEntityRequest) ProtoReflect() protoreflect.Message {
+func (x *ValidateGraphRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_cbu_graph_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -497,49 +514,48 @@ func (x *GetEntityRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetEntityRequest.ProtoReflect.Descriptor instead.
-func (*GetEntityRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ValidateGraphRequest.ProtoReflect.Descriptor instead.
+func (*ValidateGraphRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_cbu_graph_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *GetEntityRequest) GetCbuId() string {
+func (x *ValidateGraphRequest) GetCbuId() string {
 	if x != nil {
 		return x.CbuId
 	}
 	return ""
 }
 
-func (x *GetEntityRequest) GetEntityId() string {
+func (x *ValidateGraphRequest) GetOwnershipTolerance() float32 {
 	if x != nil {
-		return x.EntityId
+		return x.OwnershipTolerance
 	}
-	return ""
+	return 0
 }
 
-// RelationshipResponse contains relationships for an entity
-type RelationshipResponse struct {
+// ListCbusRequest pages through CBUs
+type ListCbusRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	EntityId      string                 `protobuf:"bytes,1,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
-	Inbound       []*CbuRelationship     `protobuf:"bytes,2,rep,name=inbound,proto3" json:"inbound,omitempty"`   // Relationships pointing to this entity
-	Outbound      []*CbuRelationship     `protobuf:"bytes,3,rep,name=outbound,proto3" json:"outbound,omitempty"` // Relationships from this entity
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RelationshipResponse) Reset() {
-	*x = RelationshipResponse{}
+func (x *ListCbusRequest) Reset() {
+	*x = ListCbusRequest{}
 	mi := &file_api_proto_cbu_graph_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RelationshipResponse) String() string {
+func (x *ListCbusRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RelationshipResponse) ProtoMessage() {}
+func (*ListCbusRequest) ProtoMessage() {}
 
-func (x *RelationshipResponse) ProtoReflect() protoreflect.Message {
+func (x *ListCbusRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_cbu_graph_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -551,56 +567,52 @@ func (x *RelationshipResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RelationshipResponse.ProtoReflect.Descriptor instead.
-func (*RelationshipResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListCbusRequest.ProtoReflect.Descriptor instead.
+func (*ListCbusRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_cbu_graph_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *RelationshipResponse) GetEntityId() string {
-	if x != nil {
-		return x.EntityId
-	}
-	return ""
-}
-
-func (x *RelationshipResponse) GetInbound() []*CbuRelationship {
+func (x *ListCbusRequest) GetLimit() int32 {
 	if x != nil {
-		return x.Inbound
+		return x.Limit
 	}
-	return nil
+	return 0
 }
 
-func (x *RelationshipResponse) GetOutbound() []*CbuRelationship {
+func (x *ListCbusRequest) GetOffset() int32 {
 	if x != nil {
-		return x.Outbound
+		return x.Offset
 	}
-	return nil
+	return 0
 }
 
-// ValidationResponse contains graph validation results
-type ValidationResponse struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	Valid           bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
-	Issues          []*CbuValidationIssue  `protobuf:"bytes,4,rep,name=issues,proto3" json:"issues,omitempty"`
-	TotalControlPct float32                `protobuf:"fixed32,3,opt,name=total_control_pct,json=totalControlPct,proto3" json:"total_control_pct,omitempty"` // Sum of all direct ownership
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+// CbuSummary describes a CBU without its graph
+type CbuSummary struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	CbuId             string                 `protobuf:"bytes,1,opt,name=cbu_id,json=cbuId,proto3" json:"cbu_id,omitempty"`
+	Name              string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Code              string                 `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+	Domicile          string                 `protobuf:"bytes,4,opt,name=domicile,proto3" json:"domicile,omitempty"`
+	EntityCount       int32                  `protobuf:"varint,5,opt,name=entity_count,json=entityCount,proto3" json:"entity_count,omitempty"`
+	RelationshipCount int32                  `protobuf:"varint,6,opt,name=relationship_count,json=relationshipCount,proto3" json:"relationship_count,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
-func (x *ValidationResponse) Reset() {
-	*x = ValidationResponse{}
+func (x *CbuSummary) Reset() {
+	*x = CbuSummary{}
 	mi := &file_api_proto_cbu_graph_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ValidationResponse) String() string {
+func (x *CbuSummary) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ValidationResponse) ProtoMessage() {}
+func (*CbuSummary) ProtoMessage() {}
 
-func (x *ValidationResponse) ProtoReflect() protoreflect.Message {
+func (x *CbuSummary) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_cbu_graph_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -612,57 +624,76 @@ func (x *ValidationResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ValidationResponse.ProtoReflect.Descriptor instead.
-func (*ValidationResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CbuSummary.ProtoReflect.Descriptor instead.
+func (*CbuSummary) Descriptor() ([]byte, []int) {
 	return file_api_proto_cbu_graph_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *ValidationResponse) GetValid() bool {
+func (x *CbuSummary) GetCbuId() string {
 	if x != nil {
-		return x.Valid
+		return x.CbuId
 	}
-	return false
+	return ""
 }
 
-func (x *ValidationResponse) GetIssues() []*CbuValidationIssue {
+func (x *CbuSummary) GetName() string {
 	if x != nil {
-		return x.Issues
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-func (x *ValidationResponse) GetTotalControlPct() float32 {
+func (x *CbuSummary) GetCode() string {
 	if x != nil {
-		return x.TotalControlPct
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CbuSummary) GetDomicile() string {
+	if x != nil {
+		return x.Domicile
+	}
+	return ""
+}
+
+func (x *CbuSummary) GetEntityCount() int32 {
+	if x != nil {
+		return x.EntityCount
 	}
 	return 0
 }
 
-// ValidationIssue represents a single validation problem
-type CbuValidationIssue struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Severity       string                 `protobuf:"bytes,1,opt,name=severity,proto3" json:"severity,omitempty"` // error, warning, info
-	Message        string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	EntityId       string                 `protobuf:"bytes,3,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
-	RelationshipId string                 `protobuf:"bytes,4,opt,name=relationship_id,json=relationshipId,proto3" json:"relationship_id,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (x *CbuSummary) GetRelationshipCount() int32 {
+	if x != nil {
+		return x.RelationshipCount
+	}
+	return 0
 }
 
-func (x *CbuValidationIssue) Reset() {
-	*x = CbuValidationIssue{}
+// CbuList is a page of CBUs
+type CbuList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cbus          []*CbuSummary          `protobuf:"bytes,1,rep,name=cbus,proto3" json:"cbus,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CbuList) Reset() {
+	*x = CbuList{}
 	mi := &file_api_proto_cbu_graph_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CbuValidationIssue) String() string {
+func (x *CbuList) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CbuValidationIssue) ProtoMessage() {}
+func (*CbuList) ProtoMessage() {}
 
-func (x *CbuValidationIssue) ProtoReflect() protoreflect.Message {
+func (x *CbuList) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_cbu_graph_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -674,63 +705,51 @@ func (x *CbuValidationIssue) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CbuValidationIssue.ProtoReflect.Descriptor instead.
-func (*CbuValidationIssue) Descriptor() ([]byte, []int) {
+// Deprecated: Use CbuList.ProtoReflect.Descriptor instead.
+func (*CbuList) Descriptor() ([]byte, []int) {
 	return file_api_proto_cbu_graph_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *CbuValidationIssue) GetSeverity() string {
-	if x != nil {
-		return x.Severity
-	}
-	return ""
-}
-
-func (x *CbuValidationIssue) GetMessage() string {
-	if x != nil {
-		return x.Message
-	}
-	return ""
-}
-
-func (x *CbuValidationIssue) GetEntityId() string {
+func (x *CbuList) GetCbus() []*CbuSummary {
 	if x != nil {
-		return x.EntityId
+		return x.Cbus
 	}
-	return ""
+	return nil
 }
 
-func (x *CbuValidationIssue) GetRelationshipId() string {
+func (x *CbuList) GetTotalCount() int32 {
 	if x != nil {
-		return x.RelationshipId
+		return x.TotalCount
 	}
-	return ""
+	return 0
 }
 
-// ControlChainResponse traces ownership from root to target
-type ControlChainResponse struct {
-	state               protoimpl.MessageState `protogen:"open.v1"`
-	TargetEntityId      string                 `protobuf:"bytes,1,opt,name=target_entity_id,json=targetEntityId,proto3" json:"target_entity_id,omitempty"`
-	Chain               []*ControlLink         `protobuf:"bytes,2,rep,name=chain,proto3" json:"chain,omitempty"`
-	EffectiveControlPct float32                `protobuf:"fixed32,3,opt,name=effective_control_pct,json=effectiveControlPct,proto3" json:"effective_control_pct,omitempty"` // Product of all control percentages in chain
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+// CreateCbuRequest creates a CBU
+type CreateCbuRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Name            string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Code            string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Description     string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Domicile        string                 `protobuf:"bytes,4,opt,name=domicile,proto3" json:"domicile,omitempty"`
+	SponsorEntityId string                 `protobuf:"bytes,5,opt,name=sponsor_entity_id,json=sponsorEntityId,proto3" json:"sponsor_entity_id,omitempty"` // Optional existing entity sponsoring the CBU
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *ControlChainResponse) Reset() {
-	*x = ControlChainResponse{}
+func (x *CreateCbuRequest) Reset() {
+	*x = CreateCbuRequest{}
 	mi := &file_api_proto_cbu_graph_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ControlChainResponse) String() string {
+func (x *CreateCbuRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ControlChainResponse) ProtoMessage() {}
+func (*CreateCbuRequest) ProtoMessage() {}
 
-func (x *ControlChainResponse) ProtoReflect() protoreflect.Message {
+func (x *CreateCbuRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_cbu_graph_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -742,60 +761,71 @@ func (x *ControlChainResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ControlChainResponse.ProtoReflect.Descriptor instead.
-func (*ControlChainResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateCbuRequest.ProtoReflect.Descriptor instead.
+func (*CreateCbuRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_cbu_graph_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *ControlChainResponse) GetTargetEntityId() string {
+func (x *CreateCbuRequest) GetName() string {
 	if x != nil {
-		return x.TargetEntityId
+		return x.Name
 	}
 	return ""
 }
 
-func (x *ControlChainResponse) GetChain() []*ControlLink {
+func (x *CreateCbuRequest) GetCode() string {
 	if x != nil {
-		return x.Chain
+		return x.Code
 	}
-	return nil
+	return ""
 }
 
-func (x *ControlChainResponse) GetEffectiveControlPct() float32 {
+func (x *CreateCbuRequest) GetDescription() string {
 	if x != nil {
-		return x.EffectiveControlPct
+		return x.Description
 	}
-	return 0
+	return ""
 }
 
-// ControlLink represents one hop in the control chain
-type ControlLink struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	FromEntityId   string                 `protobuf:"bytes,1,opt,name=from_entity_id,json=fromEntityId,proto3" json:"from_entity_id,omitempty"`
-	FromEntityName string                 `protobuf:"bytes,2,opt,name=from_entity_name,json=fromEntityName,proto3" json:"from_entity_name,omitempty"`
-	ToEntityId     string                 `protobuf:"bytes,3,opt,name=to_entity_id,json=toEntityId,proto3" json:"to_entity_id,omitempty"`
-	ToEntityName   string                 `protobuf:"bytes,4,opt,name=to_entity_name,json=toEntityName,proto3" json:"to_entity_name,omitempty"`
-	RelationType   string                 `protobuf:"bytes,5,opt,name=relation_type,json=relationType,proto3" json:"relation_type,omitempty"`
-	ControlPct     float32                `protobuf:"fixed32,6,opt,name=control_pct,json=controlPct,proto3" json:"control_pct,omitempty"`
-	RoleId         string                 `protobuf:"bytes,7,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (x *CreateCbuRequest) GetDomicile() string {
+	if x != nil {
+		return x.Domicile
+	}
+	return ""
 }
 
-func (x *ControlLink) Reset() {
-	*x = ControlLink{}
+func (x *CreateCbuRequest) GetSponsorEntityId() string {
+	if x != nil {
+		return x.SponsorEntityId
+	}
+	return ""
+}
+
+// UpsertEntityRequest creates or updates an entity within a CBU
+type UpsertEntityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CbuId         string                 `protobuf:"bytes,1,opt,name=cbu_id,json=cbuId,proto3" json:"cbu_id,omitempty"`
+	Entity        *CbuEntity             `protobuf:"bytes,2,opt,name=entity,proto3" json:"entity,omitempty"`                         // entity.id is ignored on create and required on update
+	RoleId        string                 `protobuf:"bytes,3,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`           // Role code (CbuRole.id), e.g. FUND, MANCO; required on create
+	IsPrimary     bool                   `protobuf:"varint,4,opt,name=is_primary,json=isPrimary,proto3" json:"is_primary,omitempty"` // Primary entity of the CBU (e.g. the fund under review)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertEntityRequest) Reset() {
+	*x = UpsertEntityRequest{}
 	mi := &file_api_proto_cbu_graph_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ControlLink) String() string {
+func (x *UpsertEntityRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ControlLink) ProtoMessage() {}
+func (*UpsertEntityRequest) ProtoMessage() {}
 
-func (x *ControlLink) ProtoReflect() protoreflect.Message {
+func (x *UpsertEntityRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_cbu_graph_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -807,62 +837,1052 @@ func (x *ControlLink) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ControlLink.ProtoReflect.Descriptor instead.
-func (*ControlLink) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpsertEntityRequest.ProtoReflect.Descriptor instead.
+func (*UpsertEntityRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_cbu_graph_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *ControlLink) GetFromEntityId() string {
+func (x *UpsertEntityRequest) GetCbuId() string {
 	if x != nil {
-		return x.FromEntityId
+		return x.CbuId
 	}
 	return ""
 }
 
-func (x *ControlLink) GetFromEntityName() string {
+func (x *UpsertEntityRequest) GetEntity() *CbuEntity {
 	if x != nil {
-		return x.FromEntityName
+		return x.Entity
 	}
-	return ""
+	return nil
 }
 
-func (x *ControlLink) GetToEntityId() string {
+func (x *UpsertEntityRequest) GetRoleId() string {
 	if x != nil {
-		return x.ToEntityId
+		return x.RoleId
 	}
 	return ""
 }
 
-func (x *ControlLink) GetToEntityName() string {
+func (x *UpsertEntityRequest) GetIsPrimary() bool {
 	if x != nil {
-		return x.ToEntityName
+		return x.IsPrimary
 	}
-	return ""
+	return false
 }
 
-func (x *ControlLink) GetRelationType() string {
-	if x != nil {
-		return x.RelationType
-	}
-	return ""
+// CreateRelationshipRequest adds an edge to a CBU graph
+type CreateRelationshipRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CbuId         string                 `protobuf:"bytes,1,opt,name=cbu_id,json=cbuId,proto3" json:"cbu_id,omitempty"`
+	Relationship  *CbuRelationship       `protobuf:"bytes,2,opt,name=relationship,proto3" json:"relationship,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ControlLink) GetControlPct() float32 {
+func (x *CreateRelationshipRequest) Reset() {
+	*x = CreateRelationshipRequest{}
+	mi := &file_api_proto_cbu_graph_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRelationshipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRelationshipRequest) ProtoMessage() {}
+
+func (x *CreateRelationshipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_cbu_graph_proto_msgTypes[11]
 	if x != nil {
-		return x.ControlPct
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *ControlLink) GetRoleId() string {
+// Deprecated: Use CreateRelationshipRequest.ProtoReflect.Descriptor instead.
+func (*CreateRelationshipRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_cbu_graph_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CreateRelationshipRequest) GetCbuId() string {
 	if x != nil {
-		return x.RoleId
+		return x.CbuId
 	}
 	return ""
 }
 
-var File_api_proto_cbu_graph_proto protoreflect.FileDescriptor
-
+func (x *CreateRelationshipRequest) GetRelationship() *CbuRelationship {
+	if x != nil {
+		return x.Relationship
+	}
+	return nil
+}
+
+// DeleteRelationshipRequest removes an edge from a CBU graph
+type DeleteRelationshipRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	CbuId          string                 `protobuf:"bytes,1,opt,name=cbu_id,json=cbuId,proto3" json:"cbu_id,omitempty"`
+	RelationshipId string                 `protobuf:"bytes,2,opt,name=relationship_id,json=relationshipId,proto3" json:"relationship_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DeleteRelationshipRequest) Reset() {
+	*x = DeleteRelationshipRequest{}
+	mi := &file_api_proto_cbu_graph_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRelationshipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRelationshipRequest) ProtoMessage() {}
+
+func (x *DeleteRelationshipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_cbu_graph_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRelationshipRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRelationshipRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_cbu_graph_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeleteRelationshipRequest) GetCbuId() string {
+	if x != nil {
+		return x.CbuId
+	}
+	return ""
+}
+
+func (x *DeleteRelationshipRequest) GetRelationshipId() string {
+	if x != nil {
+		return x.RelationshipId
+	}
+	return ""
+}
+
+// DeleteResponse reports the outcome of a delete
+type DeleteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Deleted       bool                   `protobuf:"varint,2,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteResponse) Reset() {
+	*x = DeleteResponse{}
+	mi := &file_api_proto_cbu_graph_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteResponse) ProtoMessage() {}
+
+func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_cbu_graph_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteResponse.ProtoReflect.Descriptor instead.
+func (*DeleteResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_cbu_graph_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeleteResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteResponse) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+// GetEntityRequest requests a specific entity
+type GetEntityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CbuId         string                 `protobuf:"bytes,1,opt,name=cbu_id,json=cbuId,proto3" json:"cbu_id,omitempty"`
+	EntityId      string                 `protobuf:"bytes,2,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEntityRequest) Reset() {
+	*x = GetEntityRequest{}
+	mi := &file_api_proto_cbu_graph_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEntityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEntityRequest) ProtoMessage() {}
+
+func (x *GetEntityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_cbu_graph_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEntityRequest.ProtoReflect.Descriptor instead.
+func (*GetEntityRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_cbu_graph_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetEntityRequest) GetCbuId() string {
+	if x != nil {
+		return x.CbuId
+	}
+	return ""
+}
+
+func (x *GetEntityRequest) GetEntityId() string {
+	if x != nil {
+		return x.EntityId
+	}
+	return ""
+}
+
+// RelationshipResponse contains relationships for an entity
+type RelationshipResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntityId      string                 `protobuf:"bytes,1,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	Inbound       []*CbuRelationship     `protobuf:"bytes,2,rep,name=inbound,proto3" json:"inbound,omitempty"`   // Relationships pointing to this entity
+	Outbound      []*CbuRelationship     `protobuf:"bytes,3,rep,name=outbound,proto3" json:"outbound,omitempty"` // Relationships from this entity
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RelationshipResponse) Reset() {
+	*x = RelationshipResponse{}
+	mi := &file_api_proto_cbu_graph_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelationshipResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelationshipResponse) ProtoMessage() {}
+
+func (x *RelationshipResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_cbu_graph_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelationshipResponse.ProtoReflect.Descriptor instead.
+func (*RelationshipResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_cbu_graph_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RelationshipResponse) GetEntityId() string {
+	if x != nil {
+		return x.EntityId
+	}
+	return ""
+}
+
+func (x *RelationshipResponse) GetInbound() []*CbuRelationship {
+	if x != nil {
+		return x.Inbound
+	}
+	return nil
+}
+
+func (x *RelationshipResponse) GetOutbound() []*CbuRelationship {
+	if x != nil {
+		return x.Outbound
+	}
+	return nil
+}
+
+// ValidationResponse contains graph validation results
+type ValidationResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Valid           bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	Issues          []*CbuValidationIssue  `protobuf:"bytes,4,rep,name=issues,proto3" json:"issues,omitempty"`
+	TotalControlPct float32                `protobuf:"fixed32,3,opt,name=total_control_pct,json=totalControlPct,proto3" json:"total_control_pct,omitempty"` // Sum of all direct ownership
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ValidationResponse) Reset() {
+	*x = ValidationResponse{}
+	mi := &file_api_proto_cbu_graph_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidationResponse) ProtoMessage() {}
+
+func (x *ValidationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_cbu_graph_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidationResponse.ProtoReflect.Descriptor instead.
+func (*ValidationResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_cbu_graph_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ValidationResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ValidationResponse) GetIssues() []*CbuValidationIssue {
+	if x != nil {
+		return x.Issues
+	}
+	return nil
+}
+
+func (x *ValidationResponse) GetTotalControlPct() float32 {
+	if x != nil {
+		return x.TotalControlPct
+	}
+	return 0
+}
+
+// ValidationIssue represents a single validation problem
+type CbuValidationIssue struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Severity       string                 `protobuf:"bytes,1,opt,name=severity,proto3" json:"severity,omitempty"` // error, warning, info
+	Message        string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	EntityId       string                 `protobuf:"bytes,3,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	RelationshipId string                 `protobuf:"bytes,4,opt,name=relationship_id,json=relationshipId,proto3" json:"relationship_id,omitempty"`
+	Code           string                 `protobuf:"bytes,5,opt,name=code,proto3" json:"code,omitempty"` // ownership_exceeded, cycle, orphan, no_relationships, no_primary
+	Path           []string               `protobuf:"bytes,6,rep,name=path,proto3" json:"path,omitempty"` // cycle: entity ids in holding order, first id repeated at the end
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CbuValidationIssue) Reset() {
+	*x = CbuValidationIssue{}
+	mi := &file_api_proto_cbu_graph_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CbuValidationIssue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CbuValidationIssue) ProtoMessage() {}
+
+func (x *CbuValidationIssue) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_cbu_graph_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CbuValidationIssue.ProtoReflect.Descriptor instead.
+func (*CbuValidationIssue) Descriptor() ([]byte, []int) {
+	return file_api_proto_cbu_graph_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CbuValidationIssue) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *CbuValidationIssue) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CbuValidationIssue) GetEntityId() string {
+	if x != nil {
+		return x.EntityId
+	}
+	return ""
+}
+
+func (x *CbuValidationIssue) GetRelationshipId() string {
+	if x != nil {
+		return x.RelationshipId
+	}
+	return ""
+}
+
+func (x *CbuValidationIssue) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CbuValidationIssue) GetPath() []string {
+	if x != nil {
+		return x.Path
+	}
+	return nil
+}
+
+// ControlChainResponse traces ownership from root to target
+type ControlChainResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	TargetEntityId      string                 `protobuf:"bytes,1,opt,name=target_entity_id,json=targetEntityId,proto3" json:"target_entity_id,omitempty"`
+	Chain               []*ControlLink         `protobuf:"bytes,2,rep,name=chain,proto3" json:"chain,omitempty"`
+	EffectiveControlPct float32                `protobuf:"fixed32,3,opt,name=effective_control_pct,json=effectiveControlPct,proto3" json:"effective_control_pct,omitempty"` // Product of all control percentages in chain
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ControlChainResponse) Reset() {
+	*x = ControlChainResponse{}
+	mi := &file_api_proto_cbu_graph_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ControlChainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ControlChainResponse) ProtoMessage() {}
+
+func (x *ControlChainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_cbu_graph_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ControlChainResponse.ProtoReflect.Descriptor instead.
+func (*ControlChainResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_cbu_graph_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ControlChainResponse) GetTargetEntityId() string {
+	if x != nil {
+		return x.TargetEntityId
+	}
+	return ""
+}
+
+func (x *ControlChainResponse) GetChain() []*ControlLink {
+	if x != nil {
+		return x.Chain
+	}
+	return nil
+}
+
+func (x *ControlChainResponse) GetEffectiveControlPct() float32 {
+	if x != nil {
+		return x.EffectiveControlPct
+	}
+	return 0
+}
+
+// ControlLink represents one hop in the control chain
+type ControlLink struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	FromEntityId   string                 `protobuf:"bytes,1,opt,name=from_entity_id,json=fromEntityId,proto3" json:"from_entity_id,omitempty"`
+	FromEntityName string                 `protobuf:"bytes,2,opt,name=from_entity_name,json=fromEntityName,proto3" json:"from_entity_name,omitempty"`
+	ToEntityId     string                 `protobuf:"bytes,3,opt,name=to_entity_id,json=toEntityId,proto3" json:"to_entity_id,omitempty"`
+	ToEntityName   string                 `protobuf:"bytes,4,opt,name=to_entity_name,json=toEntityName,proto3" json:"to_entity_name,omitempty"`
+	RelationType   string                 `protobuf:"bytes,5,opt,name=relation_type,json=relationType,proto3" json:"relation_type,omitempty"`
+	ControlPct     float32                `protobuf:"fixed32,6,opt,name=control_pct,json=controlPct,proto3" json:"control_pct,omitempty"`
+	RoleId         string                 `protobuf:"bytes,7,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ControlLink) Reset() {
+	*x = ControlLink{}
+	mi := &file_api_proto_cbu_graph_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ControlLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ControlLink) ProtoMessage() {}
+
+func (x *ControlLink) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_cbu_graph_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ControlLink.ProtoReflect.Descriptor instead.
+func (*ControlLink) Descriptor() ([]byte, []int) {
+	return file_api_proto_cbu_graph_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ControlLink) GetFromEntityId() string {
+	if x != nil {
+		return x.FromEntityId
+	}
+	return ""
+}
+
+func (x *ControlLink) GetFromEntityName() string {
+	if x != nil {
+		return x.FromEntityName
+	}
+	return ""
+}
+
+func (x *ControlLink) GetToEntityId() string {
+	if x != nil {
+		return x.ToEntityId
+	}
+	return ""
+}
+
+func (x *ControlLink) GetToEntityName() string {
+	if x != nil {
+		return x.ToEntityName
+	}
+	return ""
+}
+
+func (x *ControlLink) GetRelationType() string {
+	if x != nil {
+		return x.RelationType
+	}
+	return ""
+}
+
+func (x *ControlLink) GetControlPct() float32 {
+	if x != nil {
+		return x.ControlPct
+	}
+	return 0
+}
+
+func (x *ControlLink) GetRoleId() string {
+	if x != nil {
+		return x.RoleId
+	}
+	return ""
+}
+
+// RelationshipHistoryRequest requests the versions of an edge
+type RelationshipHistoryRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	CbuId          string                 `protobuf:"bytes,1,opt,name=cbu_id,json=cbuId,proto3" json:"cbu_id,omitempty"`
+	RelationshipId string                 `protobuf:"bytes,2,opt,name=relationship_id,json=relationshipId,proto3" json:"relationship_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RelationshipHistoryRequest) Reset() {
+	*x = RelationshipHistoryRequest{}
+	mi := &file_api_proto_cbu_graph_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelationshipHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelationshipHistoryRequest) ProtoMessage() {}
+
+func (x *RelationshipHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_cbu_graph_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelationshipHistoryRequest.ProtoReflect.Descriptor instead.
+func (*RelationshipHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_cbu_graph_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RelationshipHistoryRequest) GetCbuId() string {
+	if x != nil {
+		return x.CbuId
+	}
+	return ""
+}
+
+func (x *RelationshipHistoryRequest) GetRelationshipId() string {
+	if x != nil {
+		return x.RelationshipId
+	}
+	return ""
+}
+
+// RelationshipVersion is an edge as it was on record for a period
+type RelationshipVersion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Relationship  *CbuRelationship       `protobuf:"bytes,1,opt,name=relationship,proto3" json:"relationship,omitempty"`
+	Operation     string                 `protobuf:"bytes,2,opt,name=operation,proto3" json:"operation,omitempty"` // INSERT, UPDATE, DELETE
+	RecordedAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=recorded_at,json=recordedAt,proto3" json:"recorded_at,omitempty"`
+	SupersededAt  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=superseded_at,json=supersededAt,proto3" json:"superseded_at,omitempty"` // unset for the current version
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RelationshipVersion) Reset() {
+	*x = RelationshipVersion{}
+	mi := &file_api_proto_cbu_graph_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelationshipVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelationshipVersion) ProtoMessage() {}
+
+func (x *RelationshipVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_cbu_graph_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelationshipVersion.ProtoReflect.Descriptor instead.
+func (*RelationshipVersion) Descriptor() ([]byte, []int) {
+	return file_api_proto_cbu_graph_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RelationshipVersion) GetRelationship() *CbuRelationship {
+	if x != nil {
+		return x.Relationship
+	}
+	return nil
+}
+
+func (x *RelationshipVersion) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *RelationshipVersion) GetRecordedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RecordedAt
+	}
+	return nil
+}
+
+func (x *RelationshipVersion) GetSupersededAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SupersededAt
+	}
+	return nil
+}
+
+// RelationshipHistory lists the versions of an edge
+type RelationshipHistory struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	RelationshipId string                 `protobuf:"bytes,1,opt,name=relationship_id,json=relationshipId,proto3" json:"relationship_id,omitempty"`
+	Versions       []*RelationshipVersion `protobuf:"bytes,2,rep,name=versions,proto3" json:"versions,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RelationshipHistory) Reset() {
+	*x = RelationshipHistory{}
+	mi := &file_api_proto_cbu_graph_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelationshipHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelationshipHistory) ProtoMessage() {}
+
+func (x *RelationshipHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_cbu_graph_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelationshipHistory.ProtoReflect.Descriptor instead.
+func (*RelationshipHistory) Descriptor() ([]byte, []int) {
+	return file_api_proto_cbu_graph_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RelationshipHistory) GetRelationshipId() string {
+	if x != nil {
+		return x.RelationshipId
+	}
+	return ""
+}
+
+func (x *RelationshipHistory) GetVersions() []*RelationshipVersion {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+// DiffGraphRequest compares a CBU graph at two points in time
+type DiffGraphRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CbuId         string                 `protobuf:"bytes,1,opt,name=cbu_id,json=cbuId,proto3" json:"cbu_id,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"` // unset is now
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffGraphRequest) Reset() {
+	*x = DiffGraphRequest{}
+	mi := &file_api_proto_cbu_graph_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffGraphRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffGraphRequest) ProtoMessage() {}
+
+func (x *DiffGraphRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_cbu_graph_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffGraphRequest.ProtoReflect.Descriptor instead.
+func (*DiffGraphRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_cbu_graph_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *DiffGraphRequest) GetCbuId() string {
+	if x != nil {
+		return x.CbuId
+	}
+	return ""
+}
+
+func (x *DiffGraphRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *DiffGraphRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+// OwnershipChange is an edge added, removed or changed between two dates
+type OwnershipChange struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ChangeType     string                 `protobuf:"bytes,1,opt,name=change_type,json=changeType,proto3" json:"change_type,omitempty"` // added, removed, changed
+	RelationshipId string                 `protobuf:"bytes,2,opt,name=relationship_id,json=relationshipId,proto3" json:"relationship_id,omitempty"`
+	FromId         string                 `protobuf:"bytes,3,opt,name=from_id,json=fromId,proto3" json:"from_id,omitempty"`
+	FromName       string                 `protobuf:"bytes,4,opt,name=from_name,json=fromName,proto3" json:"from_name,omitempty"`
+	ToId           string                 `protobuf:"bytes,5,opt,name=to_id,json=toId,proto3" json:"to_id,omitempty"`
+	ToName         string                 `protobuf:"bytes,6,opt,name=to_name,json=toName,proto3" json:"to_name,omitempty"`
+	RelationType   string                 `protobuf:"bytes,7,opt,name=relation_type,json=relationType,proto3" json:"relation_type,omitempty"`
+	IsBeneficial   bool                   `protobuf:"varint,8,opt,name=is_beneficial,json=isBeneficial,proto3" json:"is_beneficial,omitempty"`
+	OldPct         float32                `protobuf:"fixed32,9,opt,name=old_pct,json=oldPct,proto3" json:"old_pct,omitempty"`
+	NewPct         float32                `protobuf:"fixed32,10,opt,name=new_pct,json=newPct,proto3" json:"new_pct,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *OwnershipChange) Reset() {
+	*x = OwnershipChange{}
+	mi := &file_api_proto_cbu_graph_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OwnershipChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OwnershipChange) ProtoMessage() {}
+
+func (x *OwnershipChange) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_cbu_graph_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OwnershipChange.ProtoReflect.Descriptor instead.
+func (*OwnershipChange) Descriptor() ([]byte, []int) {
+	return file_api_proto_cbu_graph_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *OwnershipChange) GetChangeType() string {
+	if x != nil {
+		return x.ChangeType
+	}
+	return ""
+}
+
+func (x *OwnershipChange) GetRelationshipId() string {
+	if x != nil {
+		return x.RelationshipId
+	}
+	return ""
+}
+
+func (x *OwnershipChange) GetFromId() string {
+	if x != nil {
+		return x.FromId
+	}
+	return ""
+}
+
+func (x *OwnershipChange) GetFromName() string {
+	if x != nil {
+		return x.FromName
+	}
+	return ""
+}
+
+func (x *OwnershipChange) GetToId() string {
+	if x != nil {
+		return x.ToId
+	}
+	return ""
+}
+
+func (x *OwnershipChange) GetToName() string {
+	if x != nil {
+		return x.ToName
+	}
+	return ""
+}
+
+func (x *OwnershipChange) GetRelationType() string {
+	if x != nil {
+		return x.RelationType
+	}
+	return ""
+}
+
+func (x *OwnershipChange) GetIsBeneficial() bool {
+	if x != nil {
+		return x.IsBeneficial
+	}
+	return false
+}
+
+func (x *OwnershipChange) GetOldPct() float32 {
+	if x != nil {
+		return x.OldPct
+	}
+	return 0
+}
+
+func (x *OwnershipChange) GetNewPct() float32 {
+	if x != nil {
+		return x.NewPct
+	}
+	return 0
+}
+
+// GraphDiff lists the changes of a CBU graph between two dates
+type GraphDiff struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	CbuId           string                 `protobuf:"bytes,1,opt,name=cbu_id,json=cbuId,proto3" json:"cbu_id,omitempty"`
+	From            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To              *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Changes         []*OwnershipChange     `protobuf:"bytes,4,rep,name=changes,proto3" json:"changes,omitempty"`
+	AddedEntities   []*CbuEntity           `protobuf:"bytes,5,rep,name=added_entities,json=addedEntities,proto3" json:"added_entities,omitempty"`
+	RemovedEntities []*CbuEntity           `protobuf:"bytes,6,rep,name=removed_entities,json=removedEntities,proto3" json:"removed_entities,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GraphDiff) Reset() {
+	*x = GraphDiff{}
+	mi := &file_api_proto_cbu_graph_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GraphDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GraphDiff) ProtoMessage() {}
+
+func (x *GraphDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_cbu_graph_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GraphDiff.ProtoReflect.Descriptor instead.
+func (*GraphDiff) Descriptor() ([]byte, []int) {
+	return file_api_proto_cbu_graph_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GraphDiff) GetCbuId() string {
+	if x != nil {
+		return x.CbuId
+	}
+	return ""
+}
+
+func (x *GraphDiff) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GraphDiff) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GraphDiff) GetChanges() []*OwnershipChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *GraphDiff) GetAddedEntities() []*CbuEntity {
+	if x != nil {
+		return x.AddedEntities
+	}
+	return nil
+}
+
+func (x *GraphDiff) GetRemovedEntities() []*CbuEntity {
+	if x != nil {
+		return x.RemovedEntities
+	}
+	return nil
+}
+
+var File_api_proto_cbu_graph_proto protoreflect.FileDescriptor
+
 const file_api_proto_cbu_graph_proto_rawDesc = "" +
 	"\n" +
 	"\x19api/proto/cbu_graph.proto\x12\akyc.cbu\x1a\x1fgoogle/protobuf/timestamp.proto\"\xfd\x01\n" +
@@ -882,7 +1902,7 @@ const file_api_proto_cbu_graph_proto_rawDesc = "" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x03 \x01(\tR\vdescription\x12;\n" +
-	"\x19regulatory_classification\x18\x04 \x01(\tR\x18regulatoryClassification\"\x96\x02\n" +
+	"\x19regulatory_classification\x18\x04 \x01(\tR\x18regulatoryClassification\"\xcd\x02\n" +
 	"\x0fCbuRelationship\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\afrom_id\x18\x02 \x01(\tR\x06fromId\x12\x13\n" +
@@ -892,7 +1912,8 @@ const file_api_proto_cbu_graph_proto_rawDesc = "" +
 	"controlPct\x12\x17\n" +
 	"\arole_id\x18\x06 \x01(\tR\x06roleId\x12#\n" +
 	"\ris_beneficial\x18\a \x01(\bR\fisBeneficial\x12A\n" +
-	"\x0eeffective_date\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\reffectiveDate\"\xb7\x03\n" +
+	"\x0eeffective_date\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\reffectiveDate\x125\n" +
+	"\bend_date\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\"\xb7\x03\n" +
 	"\bCbuGraph\x12\x15\n" +
 	"\x06cbu_id\x18\x01 \x01(\tR\x05cbuId\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -906,9 +1927,49 @@ const file_api_proto_cbu_graph_proto_rawDesc = "" +
 	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12!\n" +
 	"\fentity_count\x18\t \x01(\x05R\ventityCount\x12-\n" +
 	"\x12relationship_count\x18\n" +
-	" \x01(\x05R\x11relationshipCount\"&\n" +
+	" \x01(\x05R\x11relationshipCount\"W\n" +
 	"\rGetCbuRequest\x12\x15\n" +
-	"\x06cbu_id\x18\x01 \x01(\tR\x05cbuId\"F\n" +
+	"\x06cbu_id\x18\x01 \x01(\tR\x05cbuId\x12/\n" +
+	"\x05as_of\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04asOf\"^\n" +
+	"\x14ValidateGraphRequest\x12\x15\n" +
+	"\x06cbu_id\x18\x01 \x01(\tR\x05cbuId\x12/\n" +
+	"\x13ownership_tolerance\x18\x02 \x01(\x02R\x12ownershipTolerance\"?\n" +
+	"\x0fListCbusRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\"\xb9\x01\n" +
+	"\n" +
+	"CbuSummary\x12\x15\n" +
+	"\x06cbu_id\x18\x01 \x01(\tR\x05cbuId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\tR\x04code\x12\x1a\n" +
+	"\bdomicile\x18\x04 \x01(\tR\bdomicile\x12!\n" +
+	"\fentity_count\x18\x05 \x01(\x05R\ventityCount\x12-\n" +
+	"\x12relationship_count\x18\x06 \x01(\x05R\x11relationshipCount\"S\n" +
+	"\aCbuList\x12'\n" +
+	"\x04cbus\x18\x01 \x03(\v2\x13.kyc.cbu.CbuSummaryR\x04cbus\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x05R\n" +
+	"totalCount\"\xa4\x01\n" +
+	"\x10CreateCbuRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bdomicile\x18\x04 \x01(\tR\bdomicile\x12*\n" +
+	"\x11sponsor_entity_id\x18\x05 \x01(\tR\x0fsponsorEntityId\"\x90\x01\n" +
+	"\x13UpsertEntityRequest\x12\x15\n" +
+	"\x06cbu_id\x18\x01 \x01(\tR\x05cbuId\x12*\n" +
+	"\x06entity\x18\x02 \x01(\v2\x12.kyc.cbu.CbuEntityR\x06entity\x12\x17\n" +
+	"\arole_id\x18\x03 \x01(\tR\x06roleId\x12\x1d\n" +
+	"\n" +
+	"is_primary\x18\x04 \x01(\bR\tisPrimary\"p\n" +
+	"\x19CreateRelationshipRequest\x12\x15\n" +
+	"\x06cbu_id\x18\x01 \x01(\tR\x05cbuId\x12<\n" +
+	"\frelationship\x18\x02 \x01(\v2\x18.kyc.cbu.CbuRelationshipR\frelationship\"[\n" +
+	"\x19DeleteRelationshipRequest\x12\x15\n" +
+	"\x06cbu_id\x18\x01 \x01(\tR\x05cbuId\x12'\n" +
+	"\x0frelationship_id\x18\x02 \x01(\tR\x0erelationshipId\":\n" +
+	"\x0eDeleteResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\adeleted\x18\x02 \x01(\bR\adeleted\"F\n" +
 	"\x10GetEntityRequest\x12\x15\n" +
 	"\x06cbu_id\x18\x01 \x01(\tR\x05cbuId\x12\x1b\n" +
 	"\tentity_id\x18\x02 \x01(\tR\bentityId\"\x9d\x01\n" +
@@ -919,12 +1980,14 @@ const file_api_proto_cbu_graph_proto_rawDesc = "" +
 	"\x12ValidationResponse\x12\x14\n" +
 	"\x05valid\x18\x01 \x01(\bR\x05valid\x123\n" +
 	"\x06issues\x18\x04 \x03(\v2\x1b.kyc.cbu.CbuValidationIssueR\x06issues\x12*\n" +
-	"\x11total_control_pct\x18\x03 \x01(\x02R\x0ftotalControlPct\"\x90\x01\n" +
+	"\x11total_control_pct\x18\x03 \x01(\x02R\x0ftotalControlPct\"\xb8\x01\n" +
 	"\x12CbuValidationIssue\x12\x1a\n" +
 	"\bseverity\x18\x01 \x01(\tR\bseverity\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1b\n" +
 	"\tentity_id\x18\x03 \x01(\tR\bentityId\x12'\n" +
-	"\x0frelationship_id\x18\x04 \x01(\tR\x0erelationshipId\"\xa0\x01\n" +
+	"\x0frelationship_id\x18\x04 \x01(\tR\x0erelationshipId\x12\x12\n" +
+	"\x04code\x18\x05 \x01(\tR\x04code\x12\x12\n" +
+	"\x04path\x18\x06 \x03(\tR\x04path\"\xa0\x01\n" +
 	"\x14ControlChainResponse\x12(\n" +
 	"\x10target_entity_id\x18\x01 \x01(\tR\x0etargetEntityId\x12*\n" +
 	"\x05chain\x18\x02 \x03(\v2\x14.kyc.cbu.ControlLinkR\x05chain\x122\n" +
@@ -938,14 +2001,60 @@ const file_api_proto_cbu_graph_proto_rawDesc = "" +
 	"\rrelation_type\x18\x05 \x01(\tR\frelationType\x12\x1f\n" +
 	"\vcontrol_pct\x18\x06 \x01(\x02R\n" +
 	"controlPct\x12\x17\n" +
-	"\arole_id\x18\a \x01(\tR\x06roleId2\xa3\x03\n" +
+	"\arole_id\x18\a \x01(\tR\x06roleId\"\\\n" +
+	"\x1aRelationshipHistoryRequest\x12\x15\n" +
+	"\x06cbu_id\x18\x01 \x01(\tR\x05cbuId\x12'\n" +
+	"\x0frelationship_id\x18\x02 \x01(\tR\x0erelationshipId\"\xef\x01\n" +
+	"\x13RelationshipVersion\x12<\n" +
+	"\frelationship\x18\x01 \x01(\v2\x18.kyc.cbu.CbuRelationshipR\frelationship\x12\x1c\n" +
+	"\toperation\x18\x02 \x01(\tR\toperation\x12;\n" +
+	"\vrecorded_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"recordedAt\x12?\n" +
+	"\rsuperseded_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\fsupersededAt\"x\n" +
+	"\x13RelationshipHistory\x12'\n" +
+	"\x0frelationship_id\x18\x01 \x01(\tR\x0erelationshipId\x128\n" +
+	"\bversions\x18\x02 \x03(\v2\x1c.kyc.cbu.RelationshipVersionR\bversions\"\x85\x01\n" +
+	"\x10DiffGraphRequest\x12\x15\n" +
+	"\x06cbu_id\x18\x01 \x01(\tR\x05cbuId\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"\xbb\x02\n" +
+	"\x0fOwnershipChange\x12\x1f\n" +
+	"\vchange_type\x18\x01 \x01(\tR\n" +
+	"changeType\x12'\n" +
+	"\x0frelationship_id\x18\x02 \x01(\tR\x0erelationshipId\x12\x17\n" +
+	"\afrom_id\x18\x03 \x01(\tR\x06fromId\x12\x1b\n" +
+	"\tfrom_name\x18\x04 \x01(\tR\bfromName\x12\x13\n" +
+	"\x05to_id\x18\x05 \x01(\tR\x04toId\x12\x17\n" +
+	"\ato_name\x18\x06 \x01(\tR\x06toName\x12#\n" +
+	"\rrelation_type\x18\a \x01(\tR\frelationType\x12#\n" +
+	"\ris_beneficial\x18\b \x01(\bR\fisBeneficial\x12\x17\n" +
+	"\aold_pct\x18\t \x01(\x02R\x06oldPct\x12\x17\n" +
+	"\anew_pct\x18\n" +
+	" \x01(\x02R\x06newPct\"\xac\x02\n" +
+	"\tGraphDiff\x12\x15\n" +
+	"\x06cbu_id\x18\x01 \x01(\tR\x05cbuId\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x122\n" +
+	"\achanges\x18\x04 \x03(\v2\x18.kyc.cbu.OwnershipChangeR\achanges\x129\n" +
+	"\x0eadded_entities\x18\x05 \x03(\v2\x12.kyc.cbu.CbuEntityR\raddedEntities\x12=\n" +
+	"\x10removed_entities\x18\x06 \x03(\v2\x12.kyc.cbu.CbuEntityR\x0fremovedEntities2\xe3\b\n" +
 	"\x0fCbuGraphService\x125\n" +
 	"\bGetGraph\x12\x16.kyc.cbu.GetCbuRequest\x1a\x11.kyc.cbu.CbuGraph\x12:\n" +
 	"\tGetEntity\x12\x19.kyc.cbu.GetEntityRequest\x1a\x12.kyc.cbu.CbuEntity\x12<\n" +
 	"\fListEntities\x12\x16.kyc.cbu.GetCbuRequest\x1a\x12.kyc.cbu.CbuEntity0\x01\x12L\n" +
-	"\x10GetRelationships\x12\x19.kyc.cbu.GetEntityRequest\x1a\x1d.kyc.cbu.RelationshipResponse\x12D\n" +
-	"\rValidateGraph\x12\x16.kyc.cbu.GetCbuRequest\x1a\x1b.kyc.cbu.ValidationResponse\x12K\n" +
-	"\x0fGetControlChain\x12\x19.kyc.cbu.GetEntityRequest\x1a\x1d.kyc.cbu.ControlChainResponseB(Z&github.com/adamtc007/KYC-DSL/api/pb;pbb\x06proto3"
+	"\x10GetRelationships\x12\x19.kyc.cbu.GetEntityRequest\x1a\x1d.kyc.cbu.RelationshipResponse\x12K\n" +
+	"\rValidateGraph\x12\x1d.kyc.cbu.ValidateGraphRequest\x1a\x1b.kyc.cbu.ValidationResponse\x12K\n" +
+	"\x0fGetControlChain\x12\x19.kyc.cbu.GetEntityRequest\x1a\x1d.kyc.cbu.ControlChainResponse\x126\n" +
+	"\bListCbus\x12\x18.kyc.cbu.ListCbusRequest\x1a\x10.kyc.cbu.CbuList\x129\n" +
+	"\tCreateCbu\x12\x19.kyc.cbu.CreateCbuRequest\x1a\x11.kyc.cbu.CbuGraph\x12<\n" +
+	"\tDeleteCbu\x12\x16.kyc.cbu.GetCbuRequest\x1a\x17.kyc.cbu.DeleteResponse\x12@\n" +
+	"\fCreateEntity\x12\x1c.kyc.cbu.UpsertEntityRequest\x1a\x12.kyc.cbu.CbuEntity\x12@\n" +
+	"\fUpdateEntity\x12\x1c.kyc.cbu.UpsertEntityRequest\x1a\x12.kyc.cbu.CbuEntity\x12B\n" +
+	"\fDeleteEntity\x12\x19.kyc.cbu.GetEntityRequest\x1a\x17.kyc.cbu.DeleteResponse\x12R\n" +
+	"\x12CreateRelationship\x12\".kyc.cbu.CreateRelationshipRequest\x1a\x18.kyc.cbu.CbuRelationship\x12Q\n" +
+	"\x12DeleteRelationship\x12\".kyc.cbu.DeleteRelationshipRequest\x1a\x17.kyc.cbu.DeleteResponse\x12[\n" +
+	"\x16GetRelationshipHistory\x12#.kyc.cbu.RelationshipHistoryRequest\x1a\x1c.kyc.cbu.RelationshipHistory\x12:\n" +
+	"\tDiffGraph\x12\x19.kyc.cbu.DiffGraphRequest\x1a\x12.kyc.cbu.GraphDiffB(Z&github.com/adamtc007/KYC-DSL/api/pb;pbb\x06proto3"
 
 var (
 	file_api_proto_cbu_graph_proto_rawDescOnce sync.Once
@@ -959,50 +2068,101 @@ func file_api_proto_cbu_graph_proto_rawDescGZIP() []byte {
 	return file_api_proto_cbu_graph_proto_rawDescData
 }
 
-var file_api_proto_cbu_graph_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_api_proto_cbu_graph_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
 var file_api_proto_cbu_graph_proto_goTypes = []any{
-	(*CbuEntity)(nil),             // 0: kyc.cbu.CbuEntity
-	(*CbuRole)(nil),               // 1: kyc.cbu.CbuRole
-	(*CbuRelationship)(nil),       // 2: kyc.cbu.CbuRelationship
-	(*CbuGraph)(nil),              // 3: kyc.cbu.CbuGraph
-	(*GetCbuRequest)(nil),         // 4: kyc.cbu.GetCbuRequest
-	(*GetEntityRequest)(nil),      // 5: kyc.cbu.GetEntityRequest
-	(*RelationshipResponse)(nil),  // 6: kyc.cbu.RelationshipResponse
-	(*ValidationResponse)(nil),    // 7: kyc.cbu.ValidationResponse
-	(*CbuValidationIssue)(nil),    // 8: kyc.cbu.CbuValidationIssue
-	(*ControlChainResponse)(nil),  // 9: kyc.cbu.ControlChainResponse
-	(*ControlLink)(nil),           // 10: kyc.cbu.ControlLink
-	(*timestamppb.Timestamp)(nil), // 11: google.protobuf.Timestamp
+	(*CbuEntity)(nil),                  // 0: kyc.cbu.CbuEntity
+	(*CbuRole)(nil),                    // 1: kyc.cbu.CbuRole
+	(*CbuRelationship)(nil),            // 2: kyc.cbu.CbuRelationship
+	(*CbuGraph)(nil),                   // 3: kyc.cbu.CbuGraph
+	(*GetCbuRequest)(nil),              // 4: kyc.cbu.GetCbuRequest
+	(*ValidateGraphRequest)(nil),       // 5: kyc.cbu.ValidateGraphRequest
+	(*ListCbusRequest)(nil),            // 6: kyc.cbu.ListCbusRequest
+	(*CbuSummary)(nil),                 // 7: kyc.cbu.CbuSummary
+	(*CbuList)(nil),                    // 8: kyc.cbu.CbuList
+	(*CreateCbuRequest)(nil),           // 9: kyc.cbu.CreateCbuRequest
+	(*UpsertEntityRequest)(nil),        // 10: kyc.cbu.UpsertEntityRequest
+	(*CreateRelationshipRequest)(nil),  // 11: kyc.cbu.CreateRelationshipRequest
+	(*DeleteRelationshipRequest)(nil),  // 12: kyc.cbu.DeleteRelationshipRequest
+	(*DeleteResponse)(nil),             // 13: kyc.cbu.DeleteResponse
+	(*GetEntityRequest)(nil),           // 14: kyc.cbu.GetEntityRequest
+	(*RelationshipResponse)(nil),       // 15: kyc.cbu.RelationshipResponse
+	(*ValidationResponse)(nil),         // 16: kyc.cbu.ValidationResponse
+	(*CbuValidationIssue)(nil),         // 17: kyc.cbu.CbuValidationIssue
+	(*ControlChainResponse)(nil),       // 18: kyc.cbu.ControlChainResponse
+	(*ControlLink)(nil),                // 19: kyc.cbu.ControlLink
+	(*RelationshipHistoryRequest)(nil), // 20: kyc.cbu.RelationshipHistoryRequest
+	(*RelationshipVersion)(nil),        // 21: kyc.cbu.RelationshipVersion
+	(*RelationshipHistory)(nil),        // 22: kyc.cbu.RelationshipHistory
+	(*DiffGraphRequest)(nil),           // 23: kyc.cbu.DiffGraphRequest
+	(*OwnershipChange)(nil),            // 24: kyc.cbu.OwnershipChange
+	(*GraphDiff)(nil),                  // 25: kyc.cbu.GraphDiff
+	(*timestamppb.Timestamp)(nil),      // 26: google.protobuf.Timestamp
 }
 var file_api_proto_cbu_graph_proto_depIdxs = []int32{
-	11, // 0: kyc.cbu.CbuEntity.created_at:type_name -> google.protobuf.Timestamp
-	11, // 1: kyc.cbu.CbuRelationship.effective_date:type_name -> google.protobuf.Timestamp
-	0,  // 2: kyc.cbu.CbuGraph.entities:type_name -> kyc.cbu.CbuEntity
-	1,  // 3: kyc.cbu.CbuGraph.roles:type_name -> kyc.cbu.CbuRole
-	2,  // 4: kyc.cbu.CbuGraph.relationships:type_name -> kyc.cbu.CbuRelationship
-	11, // 5: kyc.cbu.CbuGraph.created_at:type_name -> google.protobuf.Timestamp
-	11, // 6: kyc.cbu.CbuGraph.updated_at:type_name -> google.protobuf.Timestamp
-	2,  // 7: kyc.cbu.RelationshipResponse.inbound:type_name -> kyc.cbu.CbuRelationship
-	2,  // 8: kyc.cbu.RelationshipResponse.outbound:type_name -> kyc.cbu.CbuRelationship
-	8,  // 9: kyc.cbu.ValidationResponse.issues:type_name -> kyc.cbu.CbuValidationIssue
-	10, // 10: kyc.cbu.ControlChainResponse.chain:type_name -> kyc.cbu.ControlLink
-	4,  // 11: kyc.cbu.CbuGraphService.GetGraph:input_type -> kyc.cbu.GetCbuRequest
-	5,  // 12: kyc.cbu.CbuGraphService.GetEntity:input_type -> kyc.cbu.GetEntityRequest
-	4,  // 13: kyc.cbu.CbuGraphService.ListEntities:input_type -> kyc.cbu.GetCbuRequest
-	5,  // 14: kyc.cbu.CbuGraphService.GetRelationships:input_type -> kyc.cbu.GetEntityRequest
-	4,  // 15: kyc.cbu.CbuGraphService.ValidateGraph:input_type -> kyc.cbu.GetCbuRequest
-	5,  // 16: kyc.cbu.CbuGraphService.GetControlChain:input_type -> kyc.cbu.GetEntityRequest
-	3,  // 17: kyc.cbu.CbuGraphService.GetGraph:output_type -> kyc.cbu.CbuGraph
-	0,  // 18: kyc.cbu.CbuGraphService.GetEntity:output_type -> kyc.cbu.CbuEntity
-	0,  // 19: kyc.cbu.CbuGraphService.ListEntities:output_type -> kyc.cbu.CbuEntity
-	6,  // 20: kyc.cbu.CbuGraphService.GetRelationships:output_type -> kyc.cbu.RelationshipResponse
-	7,  // 21: kyc.cbu.CbuGraphService.ValidateGraph:output_type -> kyc.cbu.ValidationResponse
-	9,  // 22: kyc.cbu.CbuGraphService.GetControlChain:output_type -> kyc.cbu.ControlChainResponse
-	17, // [17:23] is the sub-list for method output_type
-	11, // [11:17] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	26, // 0: kyc.cbu.CbuEntity.created_at:type_name -> google.protobuf.Timestamp
+	26, // 1: kyc.cbu.CbuRelationship.effective_date:type_name -> google.protobuf.Timestamp
+	26, // 2: kyc.cbu.CbuRelationship.end_date:type_name -> google.protobuf.Timestamp
+	0,  // 3: kyc.cbu.CbuGraph.entities:type_name -> kyc.cbu.CbuEntity
+	1,  // 4: kyc.cbu.CbuGraph.roles:type_name -> kyc.cbu.CbuRole
+	2,  // 5: kyc.cbu.CbuGraph.relationships:type_name -> kyc.cbu.CbuRelationship
+	26, // 6: kyc.cbu.CbuGraph.created_at:type_name -> google.protobuf.Timestamp
+	26, // 7: kyc.cbu.CbuGraph.updated_at:type_name -> google.protobuf.Timestamp
+	26, // 8: kyc.cbu.GetCbuRequest.as_of:type_name -> google.protobuf.Timestamp
+	7,  // 9: kyc.cbu.CbuList.cbus:type_name -> kyc.cbu.CbuSummary
+	0,  // 10: kyc.cbu.UpsertEntityRequest.entity:type_name -> kyc.cbu.CbuEntity
+	2,  // 11: kyc.cbu.CreateRelationshipRequest.relationship:type_name -> kyc.cbu.CbuRelationship
+	2,  // 12: kyc.cbu.RelationshipResponse.inbound:type_name -> kyc.cbu.CbuRelationship
+	2,  // 13: kyc.cbu.RelationshipResponse.outbound:type_name -> kyc.cbu.CbuRelationship
+	17, // 14: kyc.cbu.ValidationResponse.issues:type_name -> kyc.cbu.CbuValidationIssue
+	19, // 15: kyc.cbu.ControlChainResponse.chain:type_name -> kyc.cbu.ControlLink
+	2,  // 16: kyc.cbu.RelationshipVersion.relationship:type_name -> kyc.cbu.CbuRelationship
+	26, // 17: kyc.cbu.RelationshipVersion.recorded_at:type_name -> google.protobuf.Timestamp
+	26, // 18: kyc.cbu.RelationshipVersion.superseded_at:type_name -> google.protobuf.Timestamp
+	21, // 19: kyc.cbu.RelationshipHistory.versions:type_name -> kyc.cbu.RelationshipVersion
+	26, // 20: kyc.cbu.DiffGraphRequest.from:type_name -> google.protobuf.Timestamp
+	26, // 21: kyc.cbu.DiffGraphRequest.to:type_name -> google.protobuf.Timestamp
+	26, // 22: kyc.cbu.GraphDiff.from:type_name -> google.protobuf.Timestamp
+	26, // 23: kyc.cbu.GraphDiff.to:type_name -> google.protobuf.Timestamp
+	24, // 24: kyc.cbu.GraphDiff.changes:type_name -> kyc.cbu.OwnershipChange
+	0,  // 25: kyc.cbu.GraphDiff.added_entities:type_name -> kyc.cbu.CbuEntity
+	0,  // 26: kyc.cbu.GraphDiff.removed_entities:type_name -> kyc.cbu.CbuEntity
+	4,  // 27: kyc.cbu.CbuGraphService.GetGraph:input_type -> kyc.cbu.GetCbuRequest
+	14, // 28: kyc.cbu.CbuGraphService.GetEntity:input_type -> kyc.cbu.GetEntityRequest
+	4,  // 29: kyc.cbu.CbuGraphService.ListEntities:input_type -> kyc.cbu.GetCbuRequest
+	14, // 30: kyc.cbu.CbuGraphService.GetRelationships:input_type -> kyc.cbu.GetEntityRequest
+	5,  // 31: kyc.cbu.CbuGraphService.ValidateGraph:input_type -> kyc.cbu.ValidateGraphRequest
+	14, // 32: kyc.cbu.CbuGraphService.GetControlChain:input_type -> kyc.cbu.GetEntityRequest
+	6,  // 33: kyc.cbu.CbuGraphService.ListCbus:input_type -> kyc.cbu.ListCbusRequest
+	9,  // 34: kyc.cbu.CbuGraphService.CreateCbu:input_type -> kyc.cbu.CreateCbuRequest
+	4,  // 35: kyc.cbu.CbuGraphService.DeleteCbu:input_type -> kyc.cbu.GetCbuRequest
+	10, // 36: kyc.cbu.CbuGraphService.CreateEntity:input_type -> kyc.cbu.UpsertEntityRequest
+	10, // 37: kyc.cbu.CbuGraphService.UpdateEntity:input_type -> kyc.cbu.UpsertEntityRequest
+	14, // 38: kyc.cbu.CbuGraphService.DeleteEntity:input_type -> kyc.cbu.GetEntityRequest
+	11, // 39: kyc.cbu.CbuGraphService.CreateRelationship:input_type -> kyc.cbu.CreateRelationshipRequest
+	12, // 40: kyc.cbu.CbuGraphService.DeleteRelationship:input_type -> kyc.cbu.DeleteRelationshipRequest
+	20, // 41: kyc.cbu.CbuGraphService.GetRelationshipHistory:input_type -> kyc.cbu.RelationshipHistoryRequest
+	23, // 42: kyc.cbu.CbuGraphService.DiffGraph:input_type -> kyc.cbu.DiffGraphRequest
+	3,  // 43: kyc.cbu.CbuGraphService.GetGraph:output_type -> kyc.cbu.CbuGraph
+	0,  // 44: kyc.cbu.CbuGraphService.GetEntity:output_type -> kyc.cbu.CbuEntity
+	0,  // 45: kyc.cbu.CbuGraphService.ListEntities:output_type -> kyc.cbu.CbuEntity
+	15, // 46: kyc.cbu.CbuGraphService.GetRelationships:output_type -> kyc.cbu.RelationshipResponse
+	16, // 47: kyc.cbu.CbuGraphService.ValidateGraph:output_type -> kyc.cbu.ValidationResponse
+	18, // 48: kyc.cbu.CbuGraphService.GetControlChain:output_type -> kyc.cbu.ControlChainResponse
+	8,  // 49: kyc.cbu.CbuGraphService.ListCbus:output_type -> kyc.cbu.CbuList
+	3,  // 50: kyc.cbu.CbuGraphService.CreateCbu:output_type -> kyc.cbu.CbuGraph
+	13, // 51: kyc.cbu.CbuGraphService.DeleteCbu:output_type -> kyc.cbu.DeleteResponse
+	0,  // 52: kyc.cbu.CbuGraphService.CreateEntity:output_type -> kyc.cbu.CbuEntity
+	0,  // 53: kyc.cbu.CbuGraphService.UpdateEntity:output_type -> kyc.cbu.CbuEntity
+	13, // 54: kyc.cbu.CbuGraphService.DeleteEntity:output_type -> kyc.cbu.DeleteResponse
+	2,  // 55: kyc.cbu.CbuGraphService.CreateRelationship:output_type -> kyc.cbu.CbuRelationship
+	13, // 56: kyc.cbu.CbuGraphService.DeleteRelationship:output_type -> kyc.cbu.DeleteResponse
+	22, // 57: kyc.cbu.CbuGraphService.GetRelationshipHistory:output_type -> kyc.cbu.RelationshipHistory
+	25, // 58: kyc.cbu.CbuGraphService.DiffGraph:output_type -> kyc.cbu.GraphDiff
+	43, // [43:59] is the sub-list for method output_type
+	27, // [27:43] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_cbu_graph_proto_init() }
@@ -1016,7 +2176,7 @@ func file_api_proto_cbu_graph_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_cbu_graph_proto_rawDesc), len(file_api_proto_cbu_graph_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   26,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
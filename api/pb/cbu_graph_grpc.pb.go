@@ -19,12 +19,22 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	CbuGraphService_GetGraph_FullMethodName         = "/kyc.cbu.CbuGraphService/GetGraph"
-	CbuGraphService_GetEntity_FullMethodName        = "/kyc.cbu.CbuGraphService/GetEntity"
-	CbuGraphService_ListEntities_FullMethodName     = "/kyc.cbu.CbuGraphService/ListEntities"
-	CbuGraphService_GetRelationships_FullMethodName = "/kyc.cbu.CbuGraphService/GetRelationships"
-	CbuGraphService_ValidateGraph_FullMethodName    = "/kyc.cbu.CbuGraphService/ValidateGraph"
-	CbuGraphService_GetControlChain_FullMethodName  = "/kyc.cbu.CbuGraphService/GetControlChain"
+	CbuGraphService_GetGraph_FullMethodName               = "/kyc.cbu.CbuGraphService/GetGraph"
+	CbuGraphService_GetEntity_FullMethodName              = "/kyc.cbu.CbuGraphService/GetEntity"
+	CbuGraphService_ListEntities_FullMethodName           = "/kyc.cbu.CbuGraphService/ListEntities"
+	CbuGraphService_GetRelationships_FullMethodName       = "/kyc.cbu.CbuGraphService/GetRelationships"
+	CbuGraphService_ValidateGraph_FullMethodName          = "/kyc.cbu.CbuGraphService/ValidateGraph"
+	CbuGraphService_GetControlChain_FullMethodName        = "/kyc.cbu.CbuGraphService/GetControlChain"
+	CbuGraphService_ListCbus_FullMethodName               = "/kyc.cbu.CbuGraphService/ListCbus"
+	CbuGraphService_CreateCbu_FullMethodName              = "/kyc.cbu.CbuGraphService/CreateCbu"
+	CbuGraphService_DeleteCbu_FullMethodName              = "/kyc.cbu.CbuGraphService/DeleteCbu"
+	CbuGraphService_CreateEntity_FullMethodName           = "/kyc.cbu.CbuGraphService/CreateEntity"
+	CbuGraphService_UpdateEntity_FullMethodName           = "/kyc.cbu.CbuGraphService/UpdateEntity"
+	CbuGraphService_DeleteEntity_FullMethodName           = "/kyc.cbu.CbuGraphService/DeleteEntity"
+	CbuGraphService_CreateRelationship_FullMethodName     = "/kyc.cbu.CbuGraphService/CreateRelationship"
+	CbuGraphService_DeleteRelationship_FullMethodName     = "/kyc.cbu.CbuGraphService/DeleteRelationship"
+	CbuGraphService_GetRelationshipHistory_FullMethodName = "/kyc.cbu.CbuGraphService/GetRelationshipHistory"
+	CbuGraphService_DiffGraph_FullMethodName              = "/kyc.cbu.CbuGraphService/DiffGraph"
 )
 
 // CbuGraphServiceClient is the client API for CbuGraphService service.
@@ -33,7 +43,8 @@ const (
 //
 // CbuGraphService provides operations for retrieving and managing CBU organizational graphs
 type CbuGraphServiceClient interface {
-	// GetGraph retrieves the complete organizational graph for a CBU
+	// GetGraph retrieves the complete organizational graph for a CBU, as it
+	// stands or as it was on record at GetCbuRequest.as_of
 	GetGraph(ctx context.Context, in *GetCbuRequest, opts ...grpc.CallOption) (*CbuGraph, error)
 	// GetEntity retrieves a single entity by ID
 	GetEntity(ctx context.Context, in *GetEntityRequest, opts ...grpc.CallOption) (*CbuEntity, error)
@@ -42,9 +53,32 @@ type CbuGraphServiceClient interface {
 	// GetRelationships retrieves relationships for a specific entity
 	GetRelationships(ctx context.Context, in *GetEntityRequest, opts ...grpc.CallOption) (*RelationshipResponse, error)
 	// ValidateGraph validates the graph structure and control percentages
-	ValidateGraph(ctx context.Context, in *GetCbuRequest, opts ...grpc.CallOption) (*ValidationResponse, error)
+	// against the ownership stored in the database, reporting exact cycle paths
+	// and entities not connected to the primary entity
+	ValidateGraph(ctx context.Context, in *ValidateGraphRequest, opts ...grpc.CallOption) (*ValidationResponse, error)
 	// GetControlChain traces the control chain from root to a specific entity
 	GetControlChain(ctx context.Context, in *GetEntityRequest, opts ...grpc.CallOption) (*ControlChainResponse, error)
+	// ListCbus lists the CBUs with their entity and relationship counts
+	ListCbus(ctx context.Context, in *ListCbusRequest, opts ...grpc.CallOption) (*CbuList, error)
+	// CreateCbu creates an empty CBU graph
+	CreateCbu(ctx context.Context, in *CreateCbuRequest, opts ...grpc.CallOption) (*CbuGraph, error)
+	// DeleteCbu deletes a CBU and its role assignments (entities are kept)
+	DeleteCbu(ctx context.Context, in *GetCbuRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	// CreateEntity creates an entity and adds it to the CBU with a role
+	CreateEntity(ctx context.Context, in *UpsertEntityRequest, opts ...grpc.CallOption) (*CbuEntity, error)
+	// UpdateEntity updates an entity's details and, if given, its role in the CBU
+	UpdateEntity(ctx context.Context, in *UpsertEntityRequest, opts ...grpc.CallOption) (*CbuEntity, error)
+	// DeleteEntity removes an entity from the CBU; the entity record is
+	// deleted once no CBU references it
+	DeleteEntity(ctx context.Context, in *GetEntityRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	// CreateRelationship adds an ownership or control edge between two entities of the CBU
+	CreateRelationship(ctx context.Context, in *CreateRelationshipRequest, opts ...grpc.CallOption) (*CbuRelationship, error)
+	// DeleteRelationship removes an ownership or control edge
+	DeleteRelationship(ctx context.Context, in *DeleteRelationshipRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	// GetRelationshipHistory lists every recorded version of an edge, oldest first
+	GetRelationshipHistory(ctx context.Context, in *RelationshipHistoryRequest, opts ...grpc.CallOption) (*RelationshipHistory, error)
+	// DiffGraph reports the ownership and control changes of a CBU between two dates
+	DiffGraph(ctx context.Context, in *DiffGraphRequest, opts ...grpc.CallOption) (*GraphDiff, error)
 }
 
 type cbuGraphServiceClient struct {
@@ -104,7 +138,7 @@ func (c *cbuGraphServiceClient) GetRelationships(ctx context.Context, in *GetEnt
 	return out, nil
 }
 
-func (c *cbuGraphServiceClient) ValidateGraph(ctx context.Context, in *GetCbuRequest, opts ...grpc.CallOption) (*ValidationResponse, error) {
+func (c *cbuGraphServiceClient) ValidateGraph(ctx context.Context, in *ValidateGraphRequest, opts ...grpc.CallOption) (*ValidationResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ValidationResponse)
 	err := c.cc.Invoke(ctx, CbuGraphService_ValidateGraph_FullMethodName, in, out, cOpts...)
@@ -124,13 +158,114 @@ func (c *cbuGraphServiceClient) GetControlChain(ctx context.Context, in *GetEnti
 	return out, nil
 }
 
+func (c *cbuGraphServiceClient) ListCbus(ctx context.Context, in *ListCbusRequest, opts ...grpc.CallOption) (*CbuList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CbuList)
+	err := c.cc.Invoke(ctx, CbuGraphService_ListCbus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cbuGraphServiceClient) CreateCbu(ctx context.Context, in *CreateCbuRequest, opts ...grpc.CallOption) (*CbuGraph, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CbuGraph)
+	err := c.cc.Invoke(ctx, CbuGraphService_CreateCbu_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cbuGraphServiceClient) DeleteCbu(ctx context.Context, in *GetCbuRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, CbuGraphService_DeleteCbu_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cbuGraphServiceClient) CreateEntity(ctx context.Context, in *UpsertEntityRequest, opts ...grpc.CallOption) (*CbuEntity, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CbuEntity)
+	err := c.cc.Invoke(ctx, CbuGraphService_CreateEntity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cbuGraphServiceClient) UpdateEntity(ctx context.Context, in *UpsertEntityRequest, opts ...grpc.CallOption) (*CbuEntity, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CbuEntity)
+	err := c.cc.Invoke(ctx, CbuGraphService_UpdateEntity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cbuGraphServiceClient) DeleteEntity(ctx context.Context, in *GetEntityRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, CbuGraphService_DeleteEntity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cbuGraphServiceClient) CreateRelationship(ctx context.Context, in *CreateRelationshipRequest, opts ...grpc.CallOption) (*CbuRelationship, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CbuRelationship)
+	err := c.cc.Invoke(ctx, CbuGraphService_CreateRelationship_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cbuGraphServiceClient) DeleteRelationship(ctx context.Context, in *DeleteRelationshipRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, CbuGraphService_DeleteRelationship_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cbuGraphServiceClient) GetRelationshipHistory(ctx context.Context, in *RelationshipHistoryRequest, opts ...grpc.CallOption) (*RelationshipHistory, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RelationshipHistory)
+	err := c.cc.Invoke(ctx, CbuGraphService_GetRelationshipHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cbuGraphServiceClient) DiffGraph(ctx context.Context, in *DiffGraphRequest, opts ...grpc.CallOption) (*GraphDiff, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GraphDiff)
+	err := c.cc.Invoke(ctx, CbuGraphService_DiffGraph_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CbuGraphServiceServer is the server API for CbuGraphService service.
 // All implementations must embed UnimplementedCbuGraphServiceServer
 // for forward compatibility.
 //
 // CbuGraphService provides operations for retrieving and managing CBU organizational graphs
 type CbuGraphServiceServer interface {
-	// GetGraph retrieves the complete organizational graph for a CBU
+	// GetGraph retrieves the complete organizational graph for a CBU, as it
+	// stands or as it was on record at GetCbuRequest.as_of
 	GetGraph(context.Context, *GetCbuRequest) (*CbuGraph, error)
 	// GetEntity retrieves a single entity by ID
 	GetEntity(context.Context, *GetEntityRequest) (*CbuEntity, error)
@@ -139,9 +274,32 @@ type CbuGraphServiceServer interface {
 	// GetRelationships retrieves relationships for a specific entity
 	GetRelationships(context.Context, *GetEntityRequest) (*RelationshipResponse, error)
 	// ValidateGraph validates the graph structure and control percentages
-	ValidateGraph(context.Context, *GetCbuRequest) (*ValidationResponse, error)
+	// against the ownership stored in the database, reporting exact cycle paths
+	// and entities not connected to the primary entity
+	ValidateGraph(context.Context, *ValidateGraphRequest) (*ValidationResponse, error)
 	// GetControlChain traces the control chain from root to a specific entity
 	GetControlChain(context.Context, *GetEntityRequest) (*ControlChainResponse, error)
+	// ListCbus lists the CBUs with their entity and relationship counts
+	ListCbus(context.Context, *ListCbusRequest) (*CbuList, error)
+	// CreateCbu creates an empty CBU graph
+	CreateCbu(context.Context, *CreateCbuRequest) (*CbuGraph, error)
+	// DeleteCbu deletes a CBU and its role assignments (entities are kept)
+	DeleteCbu(context.Context, *GetCbuRequest) (*DeleteResponse, error)
+	// CreateEntity creates an entity and adds it to the CBU with a role
+	CreateEntity(context.Context, *UpsertEntityRequest) (*CbuEntity, error)
+	// UpdateEntity updates an entity's details and, if given, its role in the CBU
+	UpdateEntity(context.Context, *UpsertEntityRequest) (*CbuEntity, error)
+	// DeleteEntity removes an entity from the CBU; the entity record is
+	// deleted once no CBU references it
+	DeleteEntity(context.Context, *GetEntityRequest) (*DeleteResponse, error)
+	// CreateRelationship adds an ownership or control edge between two entities of the CBU
+	CreateRelationship(context.Context, *CreateRelationshipRequest) (*CbuRelationship, error)
+	// DeleteRelationship removes an ownership or control edge
+	DeleteRelationship(context.Context, *DeleteRelationshipRequest) (*DeleteResponse, error)
+	// GetRelationshipHistory lists every recorded version of an edge, oldest first
+	GetRelationshipHistory(context.Context, *RelationshipHistoryRequest) (*RelationshipHistory, error)
+	// DiffGraph reports the ownership and control changes of a CBU between two dates
+	DiffGraph(context.Context, *DiffGraphRequest) (*GraphDiff, error)
 	mustEmbedUnimplementedCbuGraphServiceServer()
 }
 
@@ -164,12 +322,42 @@ func (UnimplementedCbuGraphServiceServer) ListEntities(*GetCbuRequest, grpc.Serv
 func (UnimplementedCbuGraphServiceServer) GetRelationships(context.Context, *GetEntityRequest) (*RelationshipResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetRelationships not implemented")
 }
-func (UnimplementedCbuGraphServiceServer) ValidateGraph(context.Context, *GetCbuRequest) (*ValidationResponse, error) {
+func (UnimplementedCbuGraphServiceServer) ValidateGraph(context.Context, *ValidateGraphRequest) (*ValidationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ValidateGraph not implemented")
 }
 func (UnimplementedCbuGraphServiceServer) GetControlChain(context.Context, *GetEntityRequest) (*ControlChainResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetControlChain not implemented")
 }
+func (UnimplementedCbuGraphServiceServer) ListCbus(context.Context, *ListCbusRequest) (*CbuList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCbus not implemented")
+}
+func (UnimplementedCbuGraphServiceServer) CreateCbu(context.Context, *CreateCbuRequest) (*CbuGraph, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCbu not implemented")
+}
+func (UnimplementedCbuGraphServiceServer) DeleteCbu(context.Context, *GetCbuRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCbu not implemented")
+}
+func (UnimplementedCbuGraphServiceServer) CreateEntity(context.Context, *UpsertEntityRequest) (*CbuEntity, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateEntity not implemented")
+}
+func (UnimplementedCbuGraphServiceServer) UpdateEntity(context.Context, *UpsertEntityRequest) (*CbuEntity, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateEntity not implemented")
+}
+func (UnimplementedCbuGraphServiceServer) DeleteEntity(context.Context, *GetEntityRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteEntity not implemented")
+}
+func (UnimplementedCbuGraphServiceServer) CreateRelationship(context.Context, *CreateRelationshipRequest) (*CbuRelationship, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRelationship not implemented")
+}
+func (UnimplementedCbuGraphServiceServer) DeleteRelationship(context.Context, *DeleteRelationshipRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRelationship not implemented")
+}
+func (UnimplementedCbuGraphServiceServer) GetRelationshipHistory(context.Context, *RelationshipHistoryRequest) (*RelationshipHistory, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRelationshipHistory not implemented")
+}
+func (UnimplementedCbuGraphServiceServer) DiffGraph(context.Context, *DiffGraphRequest) (*GraphDiff, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffGraph not implemented")
+}
 func (UnimplementedCbuGraphServiceServer) mustEmbedUnimplementedCbuGraphServiceServer() {}
 func (UnimplementedCbuGraphServiceServer) testEmbeddedByValue()                         {}
 
@@ -257,7 +445,7 @@ func _CbuGraphService_GetRelationships_Handler(srv interface{}, ctx context.Cont
 }
 
 func _CbuGraphService_ValidateGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetCbuRequest)
+	in := new(ValidateGraphRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -269,7 +457,7 @@ func _CbuGraphService_ValidateGraph_Handler(srv interface{}, ctx context.Context
 		FullMethod: CbuGraphService_ValidateGraph_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CbuGraphServiceServer).ValidateGraph(ctx, req.(*GetCbuRequest))
+		return srv.(CbuGraphServiceServer).ValidateGraph(ctx, req.(*ValidateGraphRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -292,6 +480,186 @@ func _CbuGraphService_GetControlChain_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CbuGraphService_ListCbus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCbusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CbuGraphServiceServer).ListCbus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CbuGraphService_ListCbus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CbuGraphServiceServer).ListCbus(ctx, req.(*ListCbusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CbuGraphService_CreateCbu_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCbuRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CbuGraphServiceServer).CreateCbu(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CbuGraphService_CreateCbu_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CbuGraphServiceServer).CreateCbu(ctx, req.(*CreateCbuRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CbuGraphService_DeleteCbu_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCbuRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CbuGraphServiceServer).DeleteCbu(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CbuGraphService_DeleteCbu_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CbuGraphServiceServer).DeleteCbu(ctx, req.(*GetCbuRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CbuGraphService_CreateEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpsertEntityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CbuGraphServiceServer).CreateEntity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CbuGraphService_CreateEntity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CbuGraphServiceServer).CreateEntity(ctx, req.(*UpsertEntityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CbuGraphService_UpdateEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpsertEntityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CbuGraphServiceServer).UpdateEntity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CbuGraphService_UpdateEntity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CbuGraphServiceServer).UpdateEntity(ctx, req.(*UpsertEntityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CbuGraphService_DeleteEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEntityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CbuGraphServiceServer).DeleteEntity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CbuGraphService_DeleteEntity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CbuGraphServiceServer).DeleteEntity(ctx, req.(*GetEntityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CbuGraphService_CreateRelationship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRelationshipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CbuGraphServiceServer).CreateRelationship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CbuGraphService_CreateRelationship_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CbuGraphServiceServer).CreateRelationship(ctx, req.(*CreateRelationshipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CbuGraphService_DeleteRelationship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRelationshipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CbuGraphServiceServer).DeleteRelationship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CbuGraphService_DeleteRelationship_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CbuGraphServiceServer).DeleteRelationship(ctx, req.(*DeleteRelationshipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CbuGraphService_GetRelationshipHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RelationshipHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CbuGraphServiceServer).GetRelationshipHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CbuGraphService_GetRelationshipHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CbuGraphServiceServer).GetRelationshipHistory(ctx, req.(*RelationshipHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CbuGraphService_DiffGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CbuGraphServiceServer).DiffGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CbuGraphService_DiffGraph_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CbuGraphServiceServer).DiffGraph(ctx, req.(*DiffGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CbuGraphService_ServiceDesc is the grpc.ServiceDesc for CbuGraphService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -319,6 +687,46 @@ var CbuGraphService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetControlChain",
 			Handler:    _CbuGraphService_GetControlChain_Handler,
 		},
+		{
+			MethodName: "ListCbus",
+			Handler:    _CbuGraphService_ListCbus_Handler,
+		},
+		{
+			MethodName: "CreateCbu",
+			Handler:    _CbuGraphService_CreateCbu_Handler,
+		},
+		{
+			MethodName: "DeleteCbu",
+			Handler:    _CbuGraphService_DeleteCbu_Handler,
+		},
+		{
+			MethodName: "CreateEntity",
+			Handler:    _CbuGraphService_CreateEntity_Handler,
+		},
+		{
+			MethodName: "UpdateEntity",
+			Handler:    _CbuGraphService_UpdateEntity_Handler,
+		},
+		{
+			MethodName: "DeleteEntity",
+			Handler:    _CbuGraphService_DeleteEntity_Handler,
+		},
+		{
+			MethodName: "CreateRelationship",
+			Handler:    _CbuGraphService_CreateRelationship_Handler,
+		},
+		{
+			MethodName: "DeleteRelationship",
+			Handler:    _CbuGraphService_DeleteRelationship_Handler,
+		},
+		{
+			MethodName: "GetRelationshipHistory",
+			Handler:    _CbuGraphService_GetRelationshipHistory_Handler,
+		},
+		{
+			MethodName: "DiffGraph",
+			Handler:    _CbuGraphService_DiffGraph_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
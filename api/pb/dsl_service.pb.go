@@ -475,20 +475,25 @@ func (x *ParseResponse) GetErrors() []string {
 
 // ParsedCase represents a parsed KYC case structure
 type ParsedCase struct {
-	state                protoimpl.MessageState `protogen:"open.v1"`
-	Name                 string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Nature               string                 `protobuf:"bytes,2,opt,name=nature,proto3" json:"nature,omitempty"`
-	Purpose              string                 `protobuf:"bytes,3,opt,name=purpose,proto3" json:"purpose,omitempty"`
-	ClientBusinessUnit   string                 `protobuf:"bytes,4,opt,name=client_business_unit,json=clientBusinessUnit,proto3" json:"client_business_unit,omitempty"`
-	Policy               string                 `protobuf:"bytes,5,opt,name=policy,proto3" json:"policy,omitempty"`
-	Function             string                 `protobuf:"bytes,6,opt,name=function,proto3" json:"function,omitempty"`
-	Obligation           string                 `protobuf:"bytes,7,opt,name=obligation,proto3" json:"obligation,omitempty"`
-	KycToken             string                 `protobuf:"bytes,8,opt,name=kyc_token,json=kycToken,proto3" json:"kyc_token,omitempty"`
-	Ownership            *OwnershipStructure    `protobuf:"bytes,9,opt,name=ownership,proto3" json:"ownership,omitempty"`
-	DataDictionary       *DataDictionary        `protobuf:"bytes,10,opt,name=data_dictionary,json=dataDictionary,proto3" json:"data_dictionary,omitempty"`
-	DocumentRequirements *DocumentRequirements  `protobuf:"bytes,11,opt,name=document_requirements,json=documentRequirements,proto3" json:"document_requirements,omitempty"`
-	unknownFields        protoimpl.UnknownFields
-	sizeCache            protoimpl.SizeCache
+	state                   protoimpl.MessageState        `protogen:"open.v1"`
+	Name                    string                        `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Nature                  string                        `protobuf:"bytes,2,opt,name=nature,proto3" json:"nature,omitempty"`
+	Purpose                 string                        `protobuf:"bytes,3,opt,name=purpose,proto3" json:"purpose,omitempty"`
+	ClientBusinessUnit      string                        `protobuf:"bytes,4,opt,name=client_business_unit,json=clientBusinessUnit,proto3" json:"client_business_unit,omitempty"`
+	Policy                  string                        `protobuf:"bytes,5,opt,name=policy,proto3" json:"policy,omitempty"`         // First of policies, for older clients
+	Function                string                        `protobuf:"bytes,6,opt,name=function,proto3" json:"function,omitempty"`     // Action of the first of functions, for older clients
+	Obligation              string                        `protobuf:"bytes,7,opt,name=obligation,proto3" json:"obligation,omitempty"` // First of obligations, for older clients
+	KycToken                string                        `protobuf:"bytes,8,opt,name=kyc_token,json=kycToken,proto3" json:"kyc_token,omitempty"`
+	Ownership               *OwnershipStructure           `protobuf:"bytes,9,opt,name=ownership,proto3" json:"ownership,omitempty"`
+	DataDictionary          *DataDictionary               `protobuf:"bytes,10,opt,name=data_dictionary,json=dataDictionary,proto3" json:"data_dictionary,omitempty"`
+	DocumentRequirements    *DocumentRequirements         `protobuf:"bytes,11,opt,name=document_requirements,json=documentRequirements,proto3" json:"document_requirements,omitempty"` // First of document_requirement_sets
+	Functions               []*CaseFunction               `protobuf:"bytes,12,rep,name=functions,proto3" json:"functions,omitempty"`
+	Policies                []string                      `protobuf:"bytes,13,rep,name=policies,proto3" json:"policies,omitempty"`
+	Obligations             []string                      `protobuf:"bytes,14,rep,name=obligations,proto3" json:"obligations,omitempty"`
+	DocumentRequirementSets []*DocumentRequirements       `protobuf:"bytes,15,rep,name=document_requirement_sets,json=documentRequirementSets,proto3" json:"document_requirement_sets,omitempty"`
+	DerivedAttributes       []*DerivedAttributeDefinition `protobuf:"bytes,16,rep,name=derived_attributes,json=derivedAttributes,proto3" json:"derived_attributes,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
 }
 
 func (x *ParsedCase) Reset() {
@@ -598,6 +603,94 @@ func (x *ParsedCase) GetDocumentRequirements() *DocumentRequirements {
 	return nil
 }
 
+func (x *ParsedCase) GetFunctions() []*CaseFunction {
+	if x != nil {
+		return x.Functions
+	}
+	return nil
+}
+
+func (x *ParsedCase) GetPolicies() []string {
+	if x != nil {
+		return x.Policies
+	}
+	return nil
+}
+
+func (x *ParsedCase) GetObligations() []string {
+	if x != nil {
+		return x.Obligations
+	}
+	return nil
+}
+
+func (x *ParsedCase) GetDocumentRequirementSets() []*DocumentRequirements {
+	if x != nil {
+		return x.DocumentRequirementSets
+	}
+	return nil
+}
+
+func (x *ParsedCase) GetDerivedAttributes() []*DerivedAttributeDefinition {
+	if x != nil {
+		return x.DerivedAttributes
+	}
+	return nil
+}
+
+// CaseFunction represents a (function ACTION [STATUS]) section
+type CaseFunction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Action        string                 `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // pending, complete or failed; empty when not written
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CaseFunction) Reset() {
+	*x = CaseFunction{}
+	mi := &file_api_proto_dsl_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaseFunction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaseFunction) ProtoMessage() {}
+
+func (x *CaseFunction) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_dsl_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaseFunction.ProtoReflect.Descriptor instead.
+func (*CaseFunction) Descriptor() ([]byte, []int) {
+	return file_api_proto_dsl_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CaseFunction) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *CaseFunction) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
 // OwnershipStructure represents ownership details
 type OwnershipStructure struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
@@ -611,7 +704,7 @@ type OwnershipStructure struct {
 
 func (x *OwnershipStructure) Reset() {
 	*x = OwnershipStructure{}
-	mi := &file_api_proto_dsl_service_proto_msgTypes[8]
+	mi := &file_api_proto_dsl_service_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -623,7 +716,7 @@ func (x *OwnershipStructure) String() string {
 func (*OwnershipStructure) ProtoMessage() {}
 
 func (x *OwnershipStructure) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_dsl_service_proto_msgTypes[8]
+	mi := &file_api_proto_dsl_service_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -636,7 +729,7 @@ func (x *OwnershipStructure) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OwnershipStructure.ProtoReflect.Descriptor instead.
 func (*OwnershipStructure) Descriptor() ([]byte, []int) {
-	return file_api_proto_dsl_service_proto_rawDescGZIP(), []int{8}
+	return file_api_proto_dsl_service_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *OwnershipStructure) GetEntityName() string {
@@ -678,7 +771,7 @@ type Owner struct {
 
 func (x *Owner) Reset() {
 	*x = Owner{}
-	mi := &file_api_proto_dsl_service_proto_msgTypes[9]
+	mi := &file_api_proto_dsl_service_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -690,7 +783,7 @@ func (x *Owner) String() string {
 func (*Owner) ProtoMessage() {}
 
 func (x *Owner) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_dsl_service_proto_msgTypes[9]
+	mi := &file_api_proto_dsl_service_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -703,7 +796,7 @@ func (x *Owner) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Owner.ProtoReflect.Descriptor instead.
 func (*Owner) Descriptor() ([]byte, []int) {
-	return file_api_proto_dsl_service_proto_rawDescGZIP(), []int{9}
+	return file_api_proto_dsl_service_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *Owner) GetName() string {
@@ -731,7 +824,7 @@ type BeneficialOwner struct {
 
 func (x *BeneficialOwner) Reset() {
 	*x = BeneficialOwner{}
-	mi := &file_api_proto_dsl_service_proto_msgTypes[10]
+	mi := &file_api_proto_dsl_service_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -743,7 +836,7 @@ func (x *BeneficialOwner) String() string {
 func (*BeneficialOwner) ProtoMessage() {}
 
 func (x *BeneficialOwner) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_dsl_service_proto_msgTypes[10]
+	mi := &file_api_proto_dsl_service_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -756,7 +849,7 @@ func (x *BeneficialOwner) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BeneficialOwner.ProtoReflect.Descriptor instead.
 func (*BeneficialOwner) Descriptor() ([]byte, []int) {
-	return file_api_proto_dsl_service_proto_rawDescGZIP(), []int{10}
+	return file_api_proto_dsl_service_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *BeneficialOwner) GetName() string {
@@ -784,7 +877,7 @@ type Controller struct {
 
 func (x *Controller) Reset() {
 	*x = Controller{}
-	mi := &file_api_proto_dsl_service_proto_msgTypes[11]
+	mi := &file_api_proto_dsl_service_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -796,7 +889,7 @@ func (x *Controller) String() string {
 func (*Controller) ProtoMessage() {}
 
 func (x *Controller) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_dsl_service_proto_msgTypes[11]
+	mi := &file_api_proto_dsl_service_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -809,7 +902,7 @@ func (x *Controller) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Controller.ProtoReflect.Descriptor instead.
 func (*Controller) Descriptor() ([]byte, []int) {
-	return file_api_proto_dsl_service_proto_rawDescGZIP(), []int{11}
+	return file_api_proto_dsl_service_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *Controller) GetName() string {
@@ -836,7 +929,7 @@ type DataDictionary struct {
 
 func (x *DataDictionary) Reset() {
 	*x = DataDictionary{}
-	mi := &file_api_proto_dsl_service_proto_msgTypes[12]
+	mi := &file_api_proto_dsl_service_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -848,7 +941,7 @@ func (x *DataDictionary) String() string {
 func (*DataDictionary) ProtoMessage() {}
 
 func (x *DataDictionary) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_dsl_service_proto_msgTypes[12]
+	mi := &file_api_proto_dsl_service_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -861,7 +954,7 @@ func (x *DataDictionary) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DataDictionary.ProtoReflect.Descriptor instead.
 func (*DataDictionary) Descriptor() ([]byte, []int) {
-	return file_api_proto_dsl_service_proto_rawDescGZIP(), []int{12}
+	return file_api_proto_dsl_service_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *DataDictionary) GetAttributes() []*AttributeDefinition {
@@ -884,7 +977,7 @@ type AttributeDefinition struct {
 
 func (x *AttributeDefinition) Reset() {
 	*x = AttributeDefinition{}
-	mi := &file_api_proto_dsl_service_proto_msgTypes[13]
+	mi := &file_api_proto_dsl_service_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -896,7 +989,7 @@ func (x *AttributeDefinition) String() string {
 func (*AttributeDefinition) ProtoMessage() {}
 
 func (x *AttributeDefinition) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_dsl_service_proto_msgTypes[13]
+	mi := &file_api_proto_dsl_service_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -909,7 +1002,7 @@ func (x *AttributeDefinition) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AttributeDefinition.ProtoReflect.Descriptor instead.
 func (*AttributeDefinition) Descriptor() ([]byte, []int) {
-	return file_api_proto_dsl_service_proto_rawDescGZIP(), []int{13}
+	return file_api_proto_dsl_service_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *AttributeDefinition) GetCode() string {
@@ -940,6 +1033,83 @@ func (x *AttributeDefinition) GetTertiarySources() []string {
 	return nil
 }
 
+// DerivedAttributeDefinition represents a derived attribute with its lineage
+type DerivedAttributeDefinition struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Code             string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	SourceAttributes []string               `protobuf:"bytes,2,rep,name=source_attributes,json=sourceAttributes,proto3" json:"source_attributes,omitempty"`
+	RuleExpression   string                 `protobuf:"bytes,3,opt,name=rule_expression,json=ruleExpression,proto3" json:"rule_expression,omitempty"`
+	Jurisdiction     string                 `protobuf:"bytes,4,opt,name=jurisdiction,proto3" json:"jurisdiction,omitempty"`
+	RegulationCode   string                 `protobuf:"bytes,5,opt,name=regulation_code,json=regulationCode,proto3" json:"regulation_code,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *DerivedAttributeDefinition) Reset() {
+	*x = DerivedAttributeDefinition{}
+	mi := &file_api_proto_dsl_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DerivedAttributeDefinition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DerivedAttributeDefinition) ProtoMessage() {}
+
+func (x *DerivedAttributeDefinition) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_dsl_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DerivedAttributeDefinition.ProtoReflect.Descriptor instead.
+func (*DerivedAttributeDefinition) Descriptor() ([]byte, []int) {
+	return file_api_proto_dsl_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DerivedAttributeDefinition) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *DerivedAttributeDefinition) GetSourceAttributes() []string {
+	if x != nil {
+		return x.SourceAttributes
+	}
+	return nil
+}
+
+func (x *DerivedAttributeDefinition) GetRuleExpression() string {
+	if x != nil {
+		return x.RuleExpression
+	}
+	return ""
+}
+
+func (x *DerivedAttributeDefinition) GetJurisdiction() string {
+	if x != nil {
+		return x.Jurisdiction
+	}
+	return ""
+}
+
+func (x *DerivedAttributeDefinition) GetRegulationCode() string {
+	if x != nil {
+		return x.RegulationCode
+	}
+	return ""
+}
+
 // DocumentRequirements represents required documents
 type DocumentRequirements struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -951,7 +1121,7 @@ type DocumentRequirements struct {
 
 func (x *DocumentRequirements) Reset() {
 	*x = DocumentRequirements{}
-	mi := &file_api_proto_dsl_service_proto_msgTypes[14]
+	mi := &file_api_proto_dsl_service_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -963,7 +1133,7 @@ func (x *DocumentRequirements) String() string {
 func (*DocumentRequirements) ProtoMessage() {}
 
 func (x *DocumentRequirements) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_dsl_service_proto_msgTypes[14]
+	mi := &file_api_proto_dsl_service_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -976,7 +1146,7 @@ func (x *DocumentRequirements) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DocumentRequirements.ProtoReflect.Descriptor instead.
 func (*DocumentRequirements) Descriptor() ([]byte, []int) {
-	return file_api_proto_dsl_service_proto_rawDescGZIP(), []int{14}
+	return file_api_proto_dsl_service_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *DocumentRequirements) GetJurisdiction() string {
@@ -1004,7 +1174,7 @@ type DocumentRequirement struct {
 
 func (x *DocumentRequirement) Reset() {
 	*x = DocumentRequirement{}
-	mi := &file_api_proto_dsl_service_proto_msgTypes[15]
+	mi := &file_api_proto_dsl_service_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1016,7 +1186,7 @@ func (x *DocumentRequirement) String() string {
 func (*DocumentRequirement) ProtoMessage() {}
 
 func (x *DocumentRequirement) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_dsl_service_proto_msgTypes[15]
+	mi := &file_api_proto_dsl_service_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1029,7 +1199,7 @@ func (x *DocumentRequirement) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DocumentRequirement.ProtoReflect.Descriptor instead.
 func (*DocumentRequirement) Descriptor() ([]byte, []int) {
-	return file_api_proto_dsl_service_proto_rawDescGZIP(), []int{15}
+	return file_api_proto_dsl_service_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *DocumentRequirement) GetCode() string {
@@ -1056,7 +1226,7 @@ type SerializeRequest struct {
 
 func (x *SerializeRequest) Reset() {
 	*x = SerializeRequest{}
-	mi := &file_api_proto_dsl_service_proto_msgTypes[16]
+	mi := &file_api_proto_dsl_service_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1068,7 +1238,7 @@ func (x *SerializeRequest) String() string {
 func (*SerializeRequest) ProtoMessage() {}
 
 func (x *SerializeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_dsl_service_proto_msgTypes[16]
+	mi := &file_api_proto_dsl_service_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1081,7 +1251,7 @@ func (x *SerializeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SerializeRequest.ProtoReflect.Descriptor instead.
 func (*SerializeRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_dsl_service_proto_rawDescGZIP(), []int{16}
+	return file_api_proto_dsl_service_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *SerializeRequest) GetCase() *ParsedCase {
@@ -1103,7 +1273,7 @@ type SerializeResponse struct {
 
 func (x *SerializeResponse) Reset() {
 	*x = SerializeResponse{}
-	mi := &file_api_proto_dsl_service_proto_msgTypes[17]
+	mi := &file_api_proto_dsl_service_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1115,7 +1285,7 @@ func (x *SerializeResponse) String() string {
 func (*SerializeResponse) ProtoMessage() {}
 
 func (x *SerializeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_dsl_service_proto_msgTypes[17]
+	mi := &file_api_proto_dsl_service_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1128,7 +1298,7 @@ func (x *SerializeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SerializeResponse.ProtoReflect.Descriptor instead.
 func (*SerializeResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_dsl_service_proto_rawDescGZIP(), []int{17}
+	return file_api_proto_dsl_service_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *SerializeResponse) GetSuccess() bool {
@@ -1152,6 +1322,136 @@ func (x *SerializeResponse) GetMessage() string {
 	return ""
 }
 
+// CompileRequest contains the DSL case to compile
+type CompileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Dsl           string                 `protobuf:"bytes,1,opt,name=dsl,proto3" json:"dsl,omitempty"`
+	Format        string                 `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"` // json (default) or yaml
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompileRequest) Reset() {
+	*x = CompileRequest{}
+	mi := &file_api_proto_dsl_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompileRequest) ProtoMessage() {}
+
+func (x *CompileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_dsl_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompileRequest.ProtoReflect.Descriptor instead.
+func (*CompileRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_dsl_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CompileRequest) GetDsl() string {
+	if x != nil {
+		return x.Dsl
+	}
+	return ""
+}
+
+func (x *CompileRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+// CompileResponse contains the case in its interchange form
+type CompileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Output        string                 `protobuf:"bytes,2,opt,name=output,proto3" json:"output,omitempty"`
+	Format        string                 `protobuf:"bytes,3,opt,name=format,proto3" json:"format,omitempty"`
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Errors        []string               `protobuf:"bytes,5,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompileResponse) Reset() {
+	*x = CompileResponse{}
+	mi := &file_api_proto_dsl_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompileResponse) ProtoMessage() {}
+
+func (x *CompileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_dsl_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompileResponse.ProtoReflect.Descriptor instead.
+func (*CompileResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_dsl_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CompileResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CompileResponse) GetOutput() string {
+	if x != nil {
+		return x.Output
+	}
+	return ""
+}
+
+func (x *CompileResponse) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *CompileResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CompileResponse) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
 // AmendRequest contains amendment to apply
 type AmendRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1164,7 +1464,7 @@ type AmendRequest struct {
 
 func (x *AmendRequest) Reset() {
 	*x = AmendRequest{}
-	mi := &file_api_proto_dsl_service_proto_msgTypes[18]
+	mi := &file_api_proto_dsl_service_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1176,7 +1476,7 @@ func (x *AmendRequest) String() string {
 func (*AmendRequest) ProtoMessage() {}
 
 func (x *AmendRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_dsl_service_proto_msgTypes[18]
+	mi := &file_api_proto_dsl_service_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1189,7 +1489,7 @@ func (x *AmendRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AmendRequest.ProtoReflect.Descriptor instead.
 func (*AmendRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_dsl_service_proto_rawDescGZIP(), []int{18}
+	return file_api_proto_dsl_service_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *AmendRequest) GetCaseName() string {
@@ -1227,7 +1527,7 @@ type AmendResponse struct {
 
 func (x *AmendResponse) Reset() {
 	*x = AmendResponse{}
-	mi := &file_api_proto_dsl_service_proto_msgTypes[19]
+	mi := &file_api_proto_dsl_service_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1239,7 +1539,7 @@ func (x *AmendResponse) String() string {
 func (*AmendResponse) ProtoMessage() {}
 
 func (x *AmendResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_dsl_service_proto_msgTypes[19]
+	mi := &file_api_proto_dsl_service_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1252,7 +1552,7 @@ func (x *AmendResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AmendResponse.ProtoReflect.Descriptor instead.
 func (*AmendResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_dsl_service_proto_rawDescGZIP(), []int{19}
+	return file_api_proto_dsl_service_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *AmendResponse) GetSuccess() bool {
@@ -1299,7 +1599,7 @@ type ListAmendmentsRequest struct {
 
 func (x *ListAmendmentsRequest) Reset() {
 	*x = ListAmendmentsRequest{}
-	mi := &file_api_proto_dsl_service_proto_msgTypes[20]
+	mi := &file_api_proto_dsl_service_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1311,7 +1611,7 @@ func (x *ListAmendmentsRequest) String() string {
 func (*ListAmendmentsRequest) ProtoMessage() {}
 
 func (x *ListAmendmentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_dsl_service_proto_msgTypes[20]
+	mi := &file_api_proto_dsl_service_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1324,7 +1624,7 @@ func (x *ListAmendmentsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListAmendmentsRequest.ProtoReflect.Descriptor instead.
 func (*ListAmendmentsRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_dsl_service_proto_rawDescGZIP(), []int{20}
+	return file_api_proto_dsl_service_proto_rawDescGZIP(), []int{24}
 }
 
 // ListAmendmentsResponse contains available amendments
@@ -1337,7 +1637,7 @@ type ListAmendmentsResponse struct {
 
 func (x *ListAmendmentsResponse) Reset() {
 	*x = ListAmendmentsResponse{}
-	mi := &file_api_proto_dsl_service_proto_msgTypes[21]
+	mi := &file_api_proto_dsl_service_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1349,7 +1649,7 @@ func (x *ListAmendmentsResponse) String() string {
 func (*ListAmendmentsResponse) ProtoMessage() {}
 
 func (x *ListAmendmentsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_dsl_service_proto_msgTypes[21]
+	mi := &file_api_proto_dsl_service_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1362,7 +1662,7 @@ func (x *ListAmendmentsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListAmendmentsResponse.ProtoReflect.Descriptor instead.
 func (*ListAmendmentsResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_dsl_service_proto_rawDescGZIP(), []int{21}
+	return file_api_proto_dsl_service_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *ListAmendmentsResponse) GetAmendments() []*AmendmentType {
@@ -1384,7 +1684,7 @@ type AmendmentType struct {
 
 func (x *AmendmentType) Reset() {
 	*x = AmendmentType{}
-	mi := &file_api_proto_dsl_service_proto_msgTypes[22]
+	mi := &file_api_proto_dsl_service_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1396,7 +1696,7 @@ func (x *AmendmentType) String() string {
 func (*AmendmentType) ProtoMessage() {}
 
 func (x *AmendmentType) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_dsl_service_proto_msgTypes[22]
+	mi := &file_api_proto_dsl_service_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1409,7 +1709,7 @@ func (x *AmendmentType) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AmendmentType.ProtoReflect.Descriptor instead.
 func (*AmendmentType) Descriptor() ([]byte, []int) {
-	return file_api_proto_dsl_service_proto_rawDescGZIP(), []int{22}
+	return file_api_proto_dsl_service_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *AmendmentType) GetName() string {
@@ -1442,7 +1742,7 @@ type GetGrammarRequest struct {
 
 func (x *GetGrammarRequest) Reset() {
 	*x = GetGrammarRequest{}
-	mi := &file_api_proto_dsl_service_proto_msgTypes[23]
+	mi := &file_api_proto_dsl_service_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1454,7 +1754,7 @@ func (x *GetGrammarRequest) String() string {
 func (*GetGrammarRequest) ProtoMessage() {}
 
 func (x *GetGrammarRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_dsl_service_proto_msgTypes[23]
+	mi := &file_api_proto_dsl_service_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1467,7 +1767,7 @@ func (x *GetGrammarRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetGrammarRequest.ProtoReflect.Descriptor instead.
 func (*GetGrammarRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_dsl_service_proto_rawDescGZIP(), []int{23}
+	return file_api_proto_dsl_service_proto_rawDescGZIP(), []int{27}
 }
 
 // GrammarResponse contains the DSL grammar
@@ -1482,7 +1782,7 @@ type GrammarResponse struct {
 
 func (x *GrammarResponse) Reset() {
 	*x = GrammarResponse{}
-	mi := &file_api_proto_dsl_service_proto_msgTypes[24]
+	mi := &file_api_proto_dsl_service_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1494,7 +1794,7 @@ func (x *GrammarResponse) String() string {
 func (*GrammarResponse) ProtoMessage() {}
 
 func (x *GrammarResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_dsl_service_proto_msgTypes[24]
+	mi := &file_api_proto_dsl_service_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1507,7 +1807,7 @@ func (x *GrammarResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GrammarResponse.ProtoReflect.Descriptor instead.
 func (*GrammarResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_dsl_service_proto_rawDescGZIP(), []int{24}
+	return file_api_proto_dsl_service_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *GrammarResponse) GetEbnf() string {
@@ -1571,7 +1871,7 @@ const file_api_proto_dsl_service_proto_rawDesc = "" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12)\n" +
 	"\x05cases\x18\x03 \x03(\v2\x13.kyc.dsl.ParsedCaseR\x05cases\x12\x16\n" +
-	"\x06errors\x18\x04 \x03(\tR\x06errors\"\xc6\x03\n" +
+	"\x06errors\x18\x04 \x03(\tR\x06errors\"\xe8\x05\n" +
 	"\n" +
 	"ParsedCase\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
@@ -1587,7 +1887,15 @@ const file_api_proto_dsl_service_proto_rawDesc = "" +
 	"\townership\x18\t \x01(\v2\x1b.kyc.dsl.OwnershipStructureR\townership\x12@\n" +
 	"\x0fdata_dictionary\x18\n" +
 	" \x01(\v2\x17.kyc.dsl.DataDictionaryR\x0edataDictionary\x12R\n" +
-	"\x15document_requirements\x18\v \x01(\v2\x1d.kyc.dsl.DocumentRequirementsR\x14documentRequirements\"\xdb\x01\n" +
+	"\x15document_requirements\x18\v \x01(\v2\x1d.kyc.dsl.DocumentRequirementsR\x14documentRequirements\x123\n" +
+	"\tfunctions\x18\f \x03(\v2\x15.kyc.dsl.CaseFunctionR\tfunctions\x12\x1a\n" +
+	"\bpolicies\x18\r \x03(\tR\bpolicies\x12 \n" +
+	"\vobligations\x18\x0e \x03(\tR\vobligations\x12Y\n" +
+	"\x19document_requirement_sets\x18\x0f \x03(\v2\x1d.kyc.dsl.DocumentRequirementsR\x17documentRequirementSets\x12R\n" +
+	"\x12derived_attributes\x18\x10 \x03(\v2#.kyc.dsl.DerivedAttributeDefinitionR\x11derivedAttributes\">\n" +
+	"\fCaseFunction\x12\x16\n" +
+	"\x06action\x18\x01 \x01(\tR\x06action\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"\xdb\x01\n" +
 	"\x12OwnershipStructure\x12\x1f\n" +
 	"\ventity_name\x18\x01 \x01(\tR\n" +
 	"entityName\x12&\n" +
@@ -1616,7 +1924,13 @@ const file_api_proto_dsl_service_proto_rawDesc = "" +
 	"\x04code\x18\x01 \x01(\tR\x04code\x12'\n" +
 	"\x0fprimary_sources\x18\x02 \x03(\tR\x0eprimarySources\x12+\n" +
 	"\x11secondary_sources\x18\x03 \x03(\tR\x10secondarySources\x12)\n" +
-	"\x10tertiary_sources\x18\x04 \x03(\tR\x0ftertiarySources\"t\n" +
+	"\x10tertiary_sources\x18\x04 \x03(\tR\x0ftertiarySources\"\xd3\x01\n" +
+	"\x1aDerivedAttributeDefinition\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12+\n" +
+	"\x11source_attributes\x18\x02 \x03(\tR\x10sourceAttributes\x12'\n" +
+	"\x0frule_expression\x18\x03 \x01(\tR\x0eruleExpression\x12\"\n" +
+	"\fjurisdiction\x18\x04 \x01(\tR\fjurisdiction\x12'\n" +
+	"\x0fregulation_code\x18\x05 \x01(\tR\x0eregulationCode\"t\n" +
 	"\x14DocumentRequirements\x12\"\n" +
 	"\fjurisdiction\x18\x01 \x01(\tR\fjurisdiction\x128\n" +
 	"\brequired\x18\x02 \x03(\v2\x1c.kyc.dsl.DocumentRequirementR\brequired\"=\n" +
@@ -1628,7 +1942,16 @@ const file_api_proto_dsl_service_proto_rawDesc = "" +
 	"\x11SerializeResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x10\n" +
 	"\x03dsl\x18\x02 \x01(\tR\x03dsl\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage\"\xd8\x01\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\":\n" +
+	"\x0eCompileRequest\x12\x10\n" +
+	"\x03dsl\x18\x01 \x01(\tR\x03dsl\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\tR\x06format\"\x8d\x01\n" +
+	"\x0fCompileResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x16\n" +
+	"\x06output\x18\x02 \x01(\tR\x06output\x12\x16\n" +
+	"\x06format\x18\x03 \x01(\tR\x06format\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12\x16\n" +
+	"\x06errors\x18\x05 \x03(\tR\x06errors\"\xd8\x01\n" +
 	"\fAmendRequest\x12\x1b\n" +
 	"\tcase_name\x18\x01 \x01(\tR\bcaseName\x12%\n" +
 	"\x0eamendment_type\x18\x02 \x01(\tR\ramendmentType\x12E\n" +
@@ -1663,13 +1986,14 @@ const file_api_proto_dsl_service_proto_rawDesc = "" +
 	"\x04ebnf\x18\x01 \x01(\tR\x04ebnf\x12\x18\n" +
 	"\aversion\x18\x02 \x01(\tR\aversion\x129\n" +
 	"\n" +
-	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt2\xd6\x03\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt2\x94\x04\n" +
 	"\n" +
 	"DslService\x12<\n" +
 	"\aExecute\x12\x17.kyc.dsl.ExecuteRequest\x1a\x18.kyc.dsl.ExecuteResponse\x12?\n" +
 	"\bValidate\x12\x18.kyc.dsl.ValidateRequest\x1a\x19.kyc.dsl.ValidationResult\x126\n" +
 	"\x05Parse\x12\x15.kyc.dsl.ParseRequest\x1a\x16.kyc.dsl.ParseResponse\x12B\n" +
-	"\tSerialize\x12\x19.kyc.dsl.SerializeRequest\x1a\x1a.kyc.dsl.SerializeResponse\x126\n" +
+	"\tSerialize\x12\x19.kyc.dsl.SerializeRequest\x1a\x1a.kyc.dsl.SerializeResponse\x12<\n" +
+	"\aCompile\x12\x17.kyc.dsl.CompileRequest\x1a\x18.kyc.dsl.CompileResponse\x126\n" +
 	"\x05Amend\x12\x15.kyc.dsl.AmendRequest\x1a\x16.kyc.dsl.AmendResponse\x12Q\n" +
 	"\x0eListAmendments\x12\x1e.kyc.dsl.ListAmendmentsRequest\x1a\x1f.kyc.dsl.ListAmendmentsResponse\x12B\n" +
 	"\n" +
@@ -1687,72 +2011,81 @@ func file_api_proto_dsl_service_proto_rawDescGZIP() []byte {
 	return file_api_proto_dsl_service_proto_rawDescData
 }
 
-var file_api_proto_dsl_service_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
+var file_api_proto_dsl_service_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
 var file_api_proto_dsl_service_proto_goTypes = []any{
-	(*ExecuteRequest)(nil),         // 0: kyc.dsl.ExecuteRequest
-	(*ExecuteResponse)(nil),        // 1: kyc.dsl.ExecuteResponse
-	(*ValidateRequest)(nil),        // 2: kyc.dsl.ValidateRequest
-	(*ValidationResult)(nil),       // 3: kyc.dsl.ValidationResult
-	(*ValidationIssue)(nil),        // 4: kyc.dsl.ValidationIssue
-	(*ParseRequest)(nil),           // 5: kyc.dsl.ParseRequest
-	(*ParseResponse)(nil),          // 6: kyc.dsl.ParseResponse
-	(*ParsedCase)(nil),             // 7: kyc.dsl.ParsedCase
-	(*OwnershipStructure)(nil),     // 8: kyc.dsl.OwnershipStructure
-	(*Owner)(nil),                  // 9: kyc.dsl.Owner
-	(*BeneficialOwner)(nil),        // 10: kyc.dsl.BeneficialOwner
-	(*Controller)(nil),             // 11: kyc.dsl.Controller
-	(*DataDictionary)(nil),         // 12: kyc.dsl.DataDictionary
-	(*AttributeDefinition)(nil),    // 13: kyc.dsl.AttributeDefinition
-	(*DocumentRequirements)(nil),   // 14: kyc.dsl.DocumentRequirements
-	(*DocumentRequirement)(nil),    // 15: kyc.dsl.DocumentRequirement
-	(*SerializeRequest)(nil),       // 16: kyc.dsl.SerializeRequest
-	(*SerializeResponse)(nil),      // 17: kyc.dsl.SerializeResponse
-	(*AmendRequest)(nil),           // 18: kyc.dsl.AmendRequest
-	(*AmendResponse)(nil),          // 19: kyc.dsl.AmendResponse
-	(*ListAmendmentsRequest)(nil),  // 20: kyc.dsl.ListAmendmentsRequest
-	(*ListAmendmentsResponse)(nil), // 21: kyc.dsl.ListAmendmentsResponse
-	(*AmendmentType)(nil),          // 22: kyc.dsl.AmendmentType
-	(*GetGrammarRequest)(nil),      // 23: kyc.dsl.GetGrammarRequest
-	(*GrammarResponse)(nil),        // 24: kyc.dsl.GrammarResponse
-	nil,                            // 25: kyc.dsl.ExecuteRequest.ArgumentsEntry
-	nil,                            // 26: kyc.dsl.AmendRequest.ParametersEntry
-	(*timestamppb.Timestamp)(nil),  // 27: google.protobuf.Timestamp
+	(*ExecuteRequest)(nil),             // 0: kyc.dsl.ExecuteRequest
+	(*ExecuteResponse)(nil),            // 1: kyc.dsl.ExecuteResponse
+	(*ValidateRequest)(nil),            // 2: kyc.dsl.ValidateRequest
+	(*ValidationResult)(nil),           // 3: kyc.dsl.ValidationResult
+	(*ValidationIssue)(nil),            // 4: kyc.dsl.ValidationIssue
+	(*ParseRequest)(nil),               // 5: kyc.dsl.ParseRequest
+	(*ParseResponse)(nil),              // 6: kyc.dsl.ParseResponse
+	(*ParsedCase)(nil),                 // 7: kyc.dsl.ParsedCase
+	(*CaseFunction)(nil),               // 8: kyc.dsl.CaseFunction
+	(*OwnershipStructure)(nil),         // 9: kyc.dsl.OwnershipStructure
+	(*Owner)(nil),                      // 10: kyc.dsl.Owner
+	(*BeneficialOwner)(nil),            // 11: kyc.dsl.BeneficialOwner
+	(*Controller)(nil),                 // 12: kyc.dsl.Controller
+	(*DataDictionary)(nil),             // 13: kyc.dsl.DataDictionary
+	(*AttributeDefinition)(nil),        // 14: kyc.dsl.AttributeDefinition
+	(*DerivedAttributeDefinition)(nil), // 15: kyc.dsl.DerivedAttributeDefinition
+	(*DocumentRequirements)(nil),       // 16: kyc.dsl.DocumentRequirements
+	(*DocumentRequirement)(nil),        // 17: kyc.dsl.DocumentRequirement
+	(*SerializeRequest)(nil),           // 18: kyc.dsl.SerializeRequest
+	(*SerializeResponse)(nil),          // 19: kyc.dsl.SerializeResponse
+	(*CompileRequest)(nil),             // 20: kyc.dsl.CompileRequest
+	(*CompileResponse)(nil),            // 21: kyc.dsl.CompileResponse
+	(*AmendRequest)(nil),               // 22: kyc.dsl.AmendRequest
+	(*AmendResponse)(nil),              // 23: kyc.dsl.AmendResponse
+	(*ListAmendmentsRequest)(nil),      // 24: kyc.dsl.ListAmendmentsRequest
+	(*ListAmendmentsResponse)(nil),     // 25: kyc.dsl.ListAmendmentsResponse
+	(*AmendmentType)(nil),              // 26: kyc.dsl.AmendmentType
+	(*GetGrammarRequest)(nil),          // 27: kyc.dsl.GetGrammarRequest
+	(*GrammarResponse)(nil),            // 28: kyc.dsl.GrammarResponse
+	nil,                                // 29: kyc.dsl.ExecuteRequest.ArgumentsEntry
+	nil,                                // 30: kyc.dsl.AmendRequest.ParametersEntry
+	(*timestamppb.Timestamp)(nil),      // 31: google.protobuf.Timestamp
 }
 var file_api_proto_dsl_service_proto_depIdxs = []int32{
-	25, // 0: kyc.dsl.ExecuteRequest.arguments:type_name -> kyc.dsl.ExecuteRequest.ArgumentsEntry
+	29, // 0: kyc.dsl.ExecuteRequest.arguments:type_name -> kyc.dsl.ExecuteRequest.ArgumentsEntry
 	4,  // 1: kyc.dsl.ValidationResult.issues:type_name -> kyc.dsl.ValidationIssue
 	7,  // 2: kyc.dsl.ParseResponse.cases:type_name -> kyc.dsl.ParsedCase
-	8,  // 3: kyc.dsl.ParsedCase.ownership:type_name -> kyc.dsl.OwnershipStructure
-	12, // 4: kyc.dsl.ParsedCase.data_dictionary:type_name -> kyc.dsl.DataDictionary
-	14, // 5: kyc.dsl.ParsedCase.document_requirements:type_name -> kyc.dsl.DocumentRequirements
-	9,  // 6: kyc.dsl.OwnershipStructure.owners:type_name -> kyc.dsl.Owner
-	10, // 7: kyc.dsl.OwnershipStructure.beneficial_owners:type_name -> kyc.dsl.BeneficialOwner
-	11, // 8: kyc.dsl.OwnershipStructure.controllers:type_name -> kyc.dsl.Controller
-	13, // 9: kyc.dsl.DataDictionary.attributes:type_name -> kyc.dsl.AttributeDefinition
-	15, // 10: kyc.dsl.DocumentRequirements.required:type_name -> kyc.dsl.DocumentRequirement
-	7,  // 11: kyc.dsl.SerializeRequest.case:type_name -> kyc.dsl.ParsedCase
-	26, // 12: kyc.dsl.AmendRequest.parameters:type_name -> kyc.dsl.AmendRequest.ParametersEntry
-	22, // 13: kyc.dsl.ListAmendmentsResponse.amendments:type_name -> kyc.dsl.AmendmentType
-	27, // 14: kyc.dsl.GrammarResponse.created_at:type_name -> google.protobuf.Timestamp
-	0,  // 15: kyc.dsl.DslService.Execute:input_type -> kyc.dsl.ExecuteRequest
-	2,  // 16: kyc.dsl.DslService.Validate:input_type -> kyc.dsl.ValidateRequest
-	5,  // 17: kyc.dsl.DslService.Parse:input_type -> kyc.dsl.ParseRequest
-	16, // 18: kyc.dsl.DslService.Serialize:input_type -> kyc.dsl.SerializeRequest
-	18, // 19: kyc.dsl.DslService.Amend:input_type -> kyc.dsl.AmendRequest
-	20, // 20: kyc.dsl.DslService.ListAmendments:input_type -> kyc.dsl.ListAmendmentsRequest
-	23, // 21: kyc.dsl.DslService.GetGrammar:input_type -> kyc.dsl.GetGrammarRequest
-	1,  // 22: kyc.dsl.DslService.Execute:output_type -> kyc.dsl.ExecuteResponse
-	3,  // 23: kyc.dsl.DslService.Validate:output_type -> kyc.dsl.ValidationResult
-	6,  // 24: kyc.dsl.DslService.Parse:output_type -> kyc.dsl.ParseResponse
-	17, // 25: kyc.dsl.DslService.Serialize:output_type -> kyc.dsl.SerializeResponse
-	19, // 26: kyc.dsl.DslService.Amend:output_type -> kyc.dsl.AmendResponse
-	21, // 27: kyc.dsl.DslService.ListAmendments:output_type -> kyc.dsl.ListAmendmentsResponse
-	24, // 28: kyc.dsl.DslService.GetGrammar:output_type -> kyc.dsl.GrammarResponse
-	22, // [22:29] is the sub-list for method output_type
-	15, // [15:22] is the sub-list for method input_type
-	15, // [15:15] is the sub-list for extension type_name
-	15, // [15:15] is the sub-list for extension extendee
-	0,  // [0:15] is the sub-list for field type_name
+	9,  // 3: kyc.dsl.ParsedCase.ownership:type_name -> kyc.dsl.OwnershipStructure
+	13, // 4: kyc.dsl.ParsedCase.data_dictionary:type_name -> kyc.dsl.DataDictionary
+	16, // 5: kyc.dsl.ParsedCase.document_requirements:type_name -> kyc.dsl.DocumentRequirements
+	8,  // 6: kyc.dsl.ParsedCase.functions:type_name -> kyc.dsl.CaseFunction
+	16, // 7: kyc.dsl.ParsedCase.document_requirement_sets:type_name -> kyc.dsl.DocumentRequirements
+	15, // 8: kyc.dsl.ParsedCase.derived_attributes:type_name -> kyc.dsl.DerivedAttributeDefinition
+	10, // 9: kyc.dsl.OwnershipStructure.owners:type_name -> kyc.dsl.Owner
+	11, // 10: kyc.dsl.OwnershipStructure.beneficial_owners:type_name -> kyc.dsl.BeneficialOwner
+	12, // 11: kyc.dsl.OwnershipStructure.controllers:type_name -> kyc.dsl.Controller
+	14, // 12: kyc.dsl.DataDictionary.attributes:type_name -> kyc.dsl.AttributeDefinition
+	17, // 13: kyc.dsl.DocumentRequirements.required:type_name -> kyc.dsl.DocumentRequirement
+	7,  // 14: kyc.dsl.SerializeRequest.case:type_name -> kyc.dsl.ParsedCase
+	30, // 15: kyc.dsl.AmendRequest.parameters:type_name -> kyc.dsl.AmendRequest.ParametersEntry
+	26, // 16: kyc.dsl.ListAmendmentsResponse.amendments:type_name -> kyc.dsl.AmendmentType
+	31, // 17: kyc.dsl.GrammarResponse.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 18: kyc.dsl.DslService.Execute:input_type -> kyc.dsl.ExecuteRequest
+	2,  // 19: kyc.dsl.DslService.Validate:input_type -> kyc.dsl.ValidateRequest
+	5,  // 20: kyc.dsl.DslService.Parse:input_type -> kyc.dsl.ParseRequest
+	18, // 21: kyc.dsl.DslService.Serialize:input_type -> kyc.dsl.SerializeRequest
+	20, // 22: kyc.dsl.DslService.Compile:input_type -> kyc.dsl.CompileRequest
+	22, // 23: kyc.dsl.DslService.Amend:input_type -> kyc.dsl.AmendRequest
+	24, // 24: kyc.dsl.DslService.ListAmendments:input_type -> kyc.dsl.ListAmendmentsRequest
+	27, // 25: kyc.dsl.DslService.GetGrammar:input_type -> kyc.dsl.GetGrammarRequest
+	1,  // 26: kyc.dsl.DslService.Execute:output_type -> kyc.dsl.ExecuteResponse
+	3,  // 27: kyc.dsl.DslService.Validate:output_type -> kyc.dsl.ValidationResult
+	6,  // 28: kyc.dsl.DslService.Parse:output_type -> kyc.dsl.ParseResponse
+	19, // 29: kyc.dsl.DslService.Serialize:output_type -> kyc.dsl.SerializeResponse
+	21, // 30: kyc.dsl.DslService.Compile:output_type -> kyc.dsl.CompileResponse
+	23, // 31: kyc.dsl.DslService.Amend:output_type -> kyc.dsl.AmendResponse
+	25, // 32: kyc.dsl.DslService.ListAmendments:output_type -> kyc.dsl.ListAmendmentsResponse
+	28, // 33: kyc.dsl.DslService.GetGrammar:output_type -> kyc.dsl.GrammarResponse
+	26, // [26:34] is the sub-list for method output_type
+	18, // [18:26] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_dsl_service_proto_init() }
@@ -1766,7 +2099,7 @@ func file_api_proto_dsl_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_dsl_service_proto_rawDesc), len(file_api_proto_dsl_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   27,
+			NumMessages:   31,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
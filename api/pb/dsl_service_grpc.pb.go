@@ -23,6 +23,7 @@ const (
 	DslService_Validate_FullMethodName       = "/kyc.dsl.DslService/Validate"
 	DslService_Parse_FullMethodName          = "/kyc.dsl.DslService/Parse"
 	DslService_Serialize_FullMethodName      = "/kyc.dsl.DslService/Serialize"
+	DslService_Compile_FullMethodName        = "/kyc.dsl.DslService/Compile"
 	DslService_Amend_FullMethodName          = "/kyc.dsl.DslService/Amend"
 	DslService_ListAmendments_FullMethodName = "/kyc.dsl.DslService/ListAmendments"
 	DslService_GetGrammar_FullMethodName     = "/kyc.dsl.DslService/GetGrammar"
@@ -42,6 +43,8 @@ type DslServiceClient interface {
 	Parse(ctx context.Context, in *ParseRequest, opts ...grpc.CallOption) (*ParseResponse, error)
 	// Serialize converts structured case back to DSL
 	Serialize(ctx context.Context, in *SerializeRequest, opts ...grpc.CallOption) (*SerializeResponse, error)
+	// Compile converts a DSL case to its JSON or YAML interchange form
+	Compile(ctx context.Context, in *CompileRequest, opts ...grpc.CallOption) (*CompileResponse, error)
 	// Amend applies a predefined amendment to a case
 	Amend(ctx context.Context, in *AmendRequest, opts ...grpc.CallOption) (*AmendResponse, error)
 	// ListAmendments returns available amendment types
@@ -98,6 +101,16 @@ func (c *dslServiceClient) Serialize(ctx context.Context, in *SerializeRequest,
 	return out, nil
 }
 
+func (c *dslServiceClient) Compile(ctx context.Context, in *CompileRequest, opts ...grpc.CallOption) (*CompileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CompileResponse)
+	err := c.cc.Invoke(ctx, DslService_Compile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dslServiceClient) Amend(ctx context.Context, in *AmendRequest, opts ...grpc.CallOption) (*AmendResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AmendResponse)
@@ -142,6 +155,8 @@ type DslServiceServer interface {
 	Parse(context.Context, *ParseRequest) (*ParseResponse, error)
 	// Serialize converts structured case back to DSL
 	Serialize(context.Context, *SerializeRequest) (*SerializeResponse, error)
+	// Compile converts a DSL case to its JSON or YAML interchange form
+	Compile(context.Context, *CompileRequest) (*CompileResponse, error)
 	// Amend applies a predefined amendment to a case
 	Amend(context.Context, *AmendRequest) (*AmendResponse, error)
 	// ListAmendments returns available amendment types
@@ -170,6 +185,9 @@ func (UnimplementedDslServiceServer) Parse(context.Context, *ParseRequest) (*Par
 func (UnimplementedDslServiceServer) Serialize(context.Context, *SerializeRequest) (*SerializeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Serialize not implemented")
 }
+func (UnimplementedDslServiceServer) Compile(context.Context, *CompileRequest) (*CompileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Compile not implemented")
+}
 func (UnimplementedDslServiceServer) Amend(context.Context, *AmendRequest) (*AmendResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Amend not implemented")
 }
@@ -272,6 +290,24 @@ func _DslService_Serialize_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DslService_Compile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DslServiceServer).Compile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DslService_Compile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DslServiceServer).Compile(ctx, req.(*CompileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DslService_Amend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AmendRequest)
 	if err := dec(in); err != nil {
@@ -349,6 +385,10 @@ var DslService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Serialize",
 			Handler:    _DslService_Serialize_Handler,
 		},
+		{
+			MethodName: "Compile",
+			Handler:    _DslService_Compile_Handler,
+		},
 		{
 			MethodName: "Amend",
 			Handler:    _DslService_Amend_Handler,
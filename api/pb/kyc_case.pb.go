@@ -377,6 +377,230 @@ func (x *GetCaseVersionsRequest) GetCaseName() string {
 	return ""
 }
 
+// GetCaseVersionDiffRequest names the case and the two versions to compare
+type GetCaseVersionDiffRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CaseName      string                 `protobuf:"bytes,1,opt,name=case_name,json=caseName,proto3" json:"case_name,omitempty"`
+	FromVersion   int32                  `protobuf:"varint,2,opt,name=from_version,json=fromVersion,proto3" json:"from_version,omitempty"`
+	ToVersion     int32                  `protobuf:"varint,3,opt,name=to_version,json=toVersion,proto3" json:"to_version,omitempty"` // Defaults to the latest version
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCaseVersionDiffRequest) Reset() {
+	*x = GetCaseVersionDiffRequest{}
+	mi := &file_api_proto_kyc_case_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCaseVersionDiffRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCaseVersionDiffRequest) ProtoMessage() {}
+
+func (x *GetCaseVersionDiffRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kyc_case_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCaseVersionDiffRequest.ProtoReflect.Descriptor instead.
+func (*GetCaseVersionDiffRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kyc_case_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetCaseVersionDiffRequest) GetCaseName() string {
+	if x != nil {
+		return x.CaseName
+	}
+	return ""
+}
+
+func (x *GetCaseVersionDiffRequest) GetFromVersion() int32 {
+	if x != nil {
+		return x.FromVersion
+	}
+	return 0
+}
+
+func (x *GetCaseVersionDiffRequest) GetToVersion() int32 {
+	if x != nil {
+		return x.ToVersion
+	}
+	return 0
+}
+
+// CaseSectionChange is a document, attribute, policy, ownership entry or
+// other case section added, removed or changed between two versions
+type CaseSectionChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChangeType    string                 `protobuf:"bytes,1,opt,name=change_type,json=changeType,proto3" json:"change_type,omitempty"` // added, removed or changed
+	Section       string                 `protobuf:"bytes,2,opt,name=section,proto3" json:"section,omitempty"`                         // documents, attributes, policies, ownership or case
+	Key           string                 `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`                                 // e.g. "owner BLACKROCK-PLC" or "UK CERT-INC"
+	Before        string                 `protobuf:"bytes,4,opt,name=before,proto3" json:"before,omitempty"`                           // DSL form in the from version
+	After         string                 `protobuf:"bytes,5,opt,name=after,proto3" json:"after,omitempty"`                             // DSL form in the to version
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CaseSectionChange) Reset() {
+	*x = CaseSectionChange{}
+	mi := &file_api_proto_kyc_case_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaseSectionChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaseSectionChange) ProtoMessage() {}
+
+func (x *CaseSectionChange) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kyc_case_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaseSectionChange.ProtoReflect.Descriptor instead.
+func (*CaseSectionChange) Descriptor() ([]byte, []int) {
+	return file_api_proto_kyc_case_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CaseSectionChange) GetChangeType() string {
+	if x != nil {
+		return x.ChangeType
+	}
+	return ""
+}
+
+func (x *CaseSectionChange) GetSection() string {
+	if x != nil {
+		return x.Section
+	}
+	return ""
+}
+
+func (x *CaseSectionChange) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *CaseSectionChange) GetBefore() string {
+	if x != nil {
+		return x.Before
+	}
+	return ""
+}
+
+func (x *CaseSectionChange) GetAfter() string {
+	if x != nil {
+		return x.After
+	}
+	return ""
+}
+
+// CaseVersionDiff is the semantic difference between two versions of a case
+type CaseVersionDiff struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CaseName      string                 `protobuf:"bytes,1,opt,name=case_name,json=caseName,proto3" json:"case_name,omitempty"`
+	FromVersion   int32                  `protobuf:"varint,2,opt,name=from_version,json=fromVersion,proto3" json:"from_version,omitempty"`
+	ToVersion     int32                  `protobuf:"varint,3,opt,name=to_version,json=toVersion,proto3" json:"to_version,omitempty"`
+	FromHash      string                 `protobuf:"bytes,4,opt,name=from_hash,json=fromHash,proto3" json:"from_hash,omitempty"`
+	ToHash        string                 `protobuf:"bytes,5,opt,name=to_hash,json=toHash,proto3" json:"to_hash,omitempty"`
+	Changes       []*CaseSectionChange   `protobuf:"bytes,6,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CaseVersionDiff) Reset() {
+	*x = CaseVersionDiff{}
+	mi := &file_api_proto_kyc_case_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaseVersionDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaseVersionDiff) ProtoMessage() {}
+
+func (x *CaseVersionDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kyc_case_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaseVersionDiff.ProtoReflect.Descriptor instead.
+func (*CaseVersionDiff) Descriptor() ([]byte, []int) {
+	return file_api_proto_kyc_case_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CaseVersionDiff) GetCaseName() string {
+	if x != nil {
+		return x.CaseName
+	}
+	return ""
+}
+
+func (x *CaseVersionDiff) GetFromVersion() int32 {
+	if x != nil {
+		return x.FromVersion
+	}
+	return 0
+}
+
+func (x *CaseVersionDiff) GetToVersion() int32 {
+	if x != nil {
+		return x.ToVersion
+	}
+	return 0
+}
+
+func (x *CaseVersionDiff) GetFromHash() string {
+	if x != nil {
+		return x.FromHash
+	}
+	return ""
+}
+
+func (x *CaseVersionDiff) GetToHash() string {
+	if x != nil {
+		return x.ToHash
+	}
+	return ""
+}
+
+func (x *CaseVersionDiff) GetChanges() []*CaseSectionChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
 // KycCase represents a complete KYC case
 type KycCase struct {
 	state              protoimpl.MessageState `protogen:"open.v1"`
@@ -399,7 +623,7 @@ type KycCase struct {
 
 func (x *KycCase) Reset() {
 	*x = KycCase{}
-	mi := &file_api_proto_kyc_case_proto_msgTypes[7]
+	mi := &file_api_proto_kyc_case_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -411,7 +635,7 @@ func (x *KycCase) String() string {
 func (*KycCase) ProtoMessage() {}
 
 func (x *KycCase) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_kyc_case_proto_msgTypes[7]
+	mi := &file_api_proto_kyc_case_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -424,7 +648,7 @@ func (x *KycCase) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KycCase.ProtoReflect.Descriptor instead.
 func (*KycCase) Descriptor() ([]byte, []int) {
-	return file_api_proto_kyc_case_proto_rawDescGZIP(), []int{7}
+	return file_api_proto_kyc_case_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *KycCase) GetId() string {
@@ -534,7 +758,7 @@ type KycCaseVersion struct {
 
 func (x *KycCaseVersion) Reset() {
 	*x = KycCaseVersion{}
-	mi := &file_api_proto_kyc_case_proto_msgTypes[8]
+	mi := &file_api_proto_kyc_case_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -546,7 +770,7 @@ func (x *KycCaseVersion) String() string {
 func (*KycCaseVersion) ProtoMessage() {}
 
 func (x *KycCaseVersion) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_kyc_case_proto_msgTypes[8]
+	mi := &file_api_proto_kyc_case_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -559,7 +783,7 @@ func (x *KycCaseVersion) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KycCaseVersion.ProtoReflect.Descriptor instead.
 func (*KycCaseVersion) Descriptor() ([]byte, []int) {
-	return file_api_proto_kyc_case_proto_rawDescGZIP(), []int{8}
+	return file_api_proto_kyc_case_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *KycCaseVersion) GetCaseName() string {
@@ -611,74 +835,907 @@ func (x *KycCaseVersion) GetAmendmentType() string {
 	return ""
 }
 
-var File_api_proto_kyc_case_proto protoreflect.FileDescriptor
+// AssignCaseRequest assigns a case; empty fields keep their current value
+type AssignCaseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CaseName      string                 `protobuf:"bytes,1,opt,name=case_name,json=caseName,proto3" json:"case_name,omitempty"`
+	Assignee      string                 `protobuf:"bytes,2,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	Team          string                 `protobuf:"bytes,3,opt,name=team,proto3" json:"team,omitempty"`
+	RiskRating    string                 `protobuf:"bytes,4,opt,name=risk_rating,json=riskRating,proto3" json:"risk_rating,omitempty"` // LOW, MEDIUM, HIGH or CRITICAL
+	SlaDueAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=sla_due_at,json=slaDueAt,proto3" json:"sla_due_at,omitempty"`
+	Reason        string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_api_proto_kyc_case_proto_rawDesc = "" +
-	"\n" +
-	"\x18api/proto/kyc_case.proto\x12\x03kyc\x1a\x1fgoogle/protobuf/timestamp.proto\" \n" +
-	"\x0eGetCaseRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\x9e\x01\n" +
-	"\x11UpdateCaseRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12=\n" +
-	"\aupdates\x18\x02 \x03(\v2#.kyc.UpdateCaseRequest.UpdatesEntryR\aupdates\x1a:\n" +
-	"\fUpdatesEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"|\n" +
-	"\x10ListCasesRequest\x12\x14\n" +
-	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\"\n" +
-	"\fjurisdiction\x18\x03 \x01(\tR\fjurisdiction\x12\x16\n" +
-	"\x06policy\x18\x04 \x01(\tR\x06policy\"%\n" +
-	"\x11CreateCaseRequest\x12\x10\n" +
-	"\x03dsl\x18\x01 \x01(\tR\x03dsl\"#\n" +
-	"\x11DeleteCaseRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"H\n" +
-	"\x12DeleteCaseResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"5\n" +
-	"\x16GetCaseVersionsRequest\x12\x1b\n" +
-	"\tcase_name\x18\x01 \x01(\tR\bcaseName\"\xb7\x03\n" +
-	"\aKycCase\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12\"\n" +
-	"\fjurisdiction\x18\x03 \x01(\tR\fjurisdiction\x12\x10\n" +
-	"\x03dsl\x18\x04 \x01(\tR\x03dsl\x12\x16\n" +
-	"\x06policy\x18\x05 \x01(\tR\x06policy\x129\n" +
-	"\n" +
-	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
-	"\n" +
-	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x120\n" +
-	"\x14client_business_unit\x18\b \x01(\tR\x12clientBusinessUnit\x12\x1a\n" +
-	"\bfunction\x18\t \x01(\tR\bfunction\x12\x1e\n" +
-	"\n" +
-	"obligation\x18\n" +
-	" \x01(\tR\n" +
-	"obligation\x12\x1b\n" +
-	"\tkyc_token\x18\v \x01(\tR\bkycToken\x12\x18\n" +
-	"\aversion\x18\f \x01(\x05R\aversion\x12\x1f\n" +
-	"\vsha256_hash\x18\r \x01(\tR\n" +
-	"sha256Hash\"\x8c\x02\n" +
-	"\x0eKycCaseVersion\x12\x1b\n" +
-	"\tcase_name\x18\x01 \x01(\tR\bcaseName\x12\x18\n" +
-	"\aversion\x18\x02 \x01(\x05R\aversion\x12!\n" +
-	"\fdsl_snapshot\x18\x03 \x01(\tR\vdslSnapshot\x12\x1f\n" +
-	"\vsha256_hash\x18\x04 \x01(\tR\n" +
-	"sha256Hash\x129\n" +
-	"\n" +
-	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1d\n" +
-	"\n" +
-	"created_by\x18\x06 \x01(\tR\tcreatedBy\x12%\n" +
-	"\x0eamendment_type\x18\a \x01(\tR\ramendmentType2\xe0\x02\n" +
-	"\x0eKycCaseService\x12,\n" +
-	"\aGetCase\x12\x13.kyc.GetCaseRequest\x1a\f.kyc.KycCase\x122\n" +
-	"\n" +
-	"UpdateCase\x12\x16.kyc.UpdateCaseRequest\x1a\f.kyc.KycCase\x122\n" +
-	"\tListCases\x12\x15.kyc.ListCasesRequest\x1a\f.kyc.KycCase0\x01\x122\n" +
-	"\n" +
-	"CreateCase\x12\x16.kyc.CreateCaseRequest\x1a\f.kyc.KycCase\x12=\n" +
-	"\n" +
-	"DeleteCase\x12\x16.kyc.DeleteCaseRequest\x1a\x17.kyc.DeleteCaseResponse\x12E\n" +
-	"\x0fGetCaseVersions\x12\x1b.kyc.GetCaseVersionsRequest\x1a\x13.kyc.KycCaseVersion0\x01B(Z&github.com/adamtc007/KYC-DSL/api/pb;pbb\x06proto3"
+func (x *AssignCaseRequest) Reset() {
+	*x = AssignCaseRequest{}
+	mi := &file_api_proto_kyc_case_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignCaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignCaseRequest) ProtoMessage() {}
+
+func (x *AssignCaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kyc_case_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignCaseRequest.ProtoReflect.Descriptor instead.
+func (*AssignCaseRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kyc_case_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AssignCaseRequest) GetCaseName() string {
+	if x != nil {
+		return x.CaseName
+	}
+	return ""
+}
+
+func (x *AssignCaseRequest) GetAssignee() string {
+	if x != nil {
+		return x.Assignee
+	}
+	return ""
+}
+
+func (x *AssignCaseRequest) GetTeam() string {
+	if x != nil {
+		return x.Team
+	}
+	return ""
+}
+
+func (x *AssignCaseRequest) GetRiskRating() string {
+	if x != nil {
+		return x.RiskRating
+	}
+	return ""
+}
+
+func (x *AssignCaseRequest) GetSlaDueAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SlaDueAt
+	}
+	return nil
+}
+
+func (x *AssignCaseRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// CaseAssignment is the current owner of a case in the work queue
+type CaseAssignment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CaseName      string                 `protobuf:"bytes,1,opt,name=case_name,json=caseName,proto3" json:"case_name,omitempty"`
+	Assignee      string                 `protobuf:"bytes,2,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	Team          string                 `protobuf:"bytes,3,opt,name=team,proto3" json:"team,omitempty"`
+	RiskRating    string                 `protobuf:"bytes,4,opt,name=risk_rating,json=riskRating,proto3" json:"risk_rating,omitempty"`
+	SlaDueAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=sla_due_at,json=slaDueAt,proto3" json:"sla_due_at,omitempty"`
+	AssignedBy    string                 `protobuf:"bytes,6,opt,name=assigned_by,json=assignedBy,proto3" json:"assigned_by,omitempty"`
+	AssignedAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=assigned_at,json=assignedAt,proto3" json:"assigned_at,omitempty"`
+	Status        string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"` // Lifecycle state of the case
+	Overdue       bool                   `protobuf:"varint,9,opt,name=overdue,proto3" json:"overdue,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CaseAssignment) Reset() {
+	*x = CaseAssignment{}
+	mi := &file_api_proto_kyc_case_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaseAssignment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaseAssignment) ProtoMessage() {}
+
+func (x *CaseAssignment) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kyc_case_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaseAssignment.ProtoReflect.Descriptor instead.
+func (*CaseAssignment) Descriptor() ([]byte, []int) {
+	return file_api_proto_kyc_case_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CaseAssignment) GetCaseName() string {
+	if x != nil {
+		return x.CaseName
+	}
+	return ""
+}
+
+func (x *CaseAssignment) GetAssignee() string {
+	if x != nil {
+		return x.Assignee
+	}
+	return ""
+}
+
+func (x *CaseAssignment) GetTeam() string {
+	if x != nil {
+		return x.Team
+	}
+	return ""
+}
+
+func (x *CaseAssignment) GetRiskRating() string {
+	if x != nil {
+		return x.RiskRating
+	}
+	return ""
+}
+
+func (x *CaseAssignment) GetSlaDueAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SlaDueAt
+	}
+	return nil
+}
+
+func (x *CaseAssignment) GetAssignedBy() string {
+	if x != nil {
+		return x.AssignedBy
+	}
+	return ""
+}
+
+func (x *CaseAssignment) GetAssignedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AssignedAt
+	}
+	return nil
+}
+
+func (x *CaseAssignment) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CaseAssignment) GetOverdue() bool {
+	if x != nil {
+		return x.Overdue
+	}
+	return false
+}
+
+// CaseQueueRequest filters the work queue; empty fields match every case
+type CaseQueueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Assignee      string                 `protobuf:"bytes,1,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	Team          string                 `protobuf:"bytes,2,opt,name=team,proto3" json:"team,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"` // Defaults to cases still open
+	RiskRating    string                 `protobuf:"bytes,4,opt,name=risk_rating,json=riskRating,proto3" json:"risk_rating,omitempty"`
+	DueBefore     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=due_before,json=dueBefore,proto3" json:"due_before,omitempty"`
+	OverdueOnly   bool                   `protobuf:"varint,6,opt,name=overdue_only,json=overdueOnly,proto3" json:"overdue_only,omitempty"`
+	Limit         int32                  `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CaseQueueRequest) Reset() {
+	*x = CaseQueueRequest{}
+	mi := &file_api_proto_kyc_case_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaseQueueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaseQueueRequest) ProtoMessage() {}
+
+func (x *CaseQueueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kyc_case_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaseQueueRequest.ProtoReflect.Descriptor instead.
+func (*CaseQueueRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kyc_case_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CaseQueueRequest) GetAssignee() string {
+	if x != nil {
+		return x.Assignee
+	}
+	return ""
+}
+
+func (x *CaseQueueRequest) GetTeam() string {
+	if x != nil {
+		return x.Team
+	}
+	return ""
+}
+
+func (x *CaseQueueRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CaseQueueRequest) GetRiskRating() string {
+	if x != nil {
+		return x.RiskRating
+	}
+	return ""
+}
+
+func (x *CaseQueueRequest) GetDueBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueBefore
+	}
+	return nil
+}
+
+func (x *CaseQueueRequest) GetOverdueOnly() bool {
+	if x != nil {
+		return x.OverdueOnly
+	}
+	return false
+}
+
+func (x *CaseQueueRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// CaseQueue lists assigned cases, soonest SLA due date and highest risk first
+type CaseQueue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cases         []*CaseAssignment      `protobuf:"bytes,1,rep,name=cases,proto3" json:"cases,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CaseQueue) Reset() {
+	*x = CaseQueue{}
+	mi := &file_api_proto_kyc_case_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaseQueue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaseQueue) ProtoMessage() {}
+
+func (x *CaseQueue) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kyc_case_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaseQueue.ProtoReflect.Descriptor instead.
+func (*CaseQueue) Descriptor() ([]byte, []int) {
+	return file_api_proto_kyc_case_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CaseQueue) GetCases() []*CaseAssignment {
+	if x != nil {
+		return x.Cases
+	}
+	return nil
+}
+
+// GetAssignmentHistoryRequest names the case whose assignments to return
+type GetAssignmentHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CaseName      string                 `protobuf:"bytes,1,opt,name=case_name,json=caseName,proto3" json:"case_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAssignmentHistoryRequest) Reset() {
+	*x = GetAssignmentHistoryRequest{}
+	mi := &file_api_proto_kyc_case_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAssignmentHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAssignmentHistoryRequest) ProtoMessage() {}
+
+func (x *GetAssignmentHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kyc_case_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAssignmentHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetAssignmentHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kyc_case_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetAssignmentHistoryRequest) GetCaseName() string {
+	if x != nil {
+		return x.CaseName
+	}
+	return ""
+}
+
+// CaseAssignmentChange is one assignment or reassignment of a case
+type CaseAssignmentChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CaseName      string                 `protobuf:"bytes,2,opt,name=case_name,json=caseName,proto3" json:"case_name,omitempty"`
+	FromAssignee  string                 `protobuf:"bytes,3,opt,name=from_assignee,json=fromAssignee,proto3" json:"from_assignee,omitempty"` // Empty for the first assignment
+	FromTeam      string                 `protobuf:"bytes,4,opt,name=from_team,json=fromTeam,proto3" json:"from_team,omitempty"`
+	ToAssignee    string                 `protobuf:"bytes,5,opt,name=to_assignee,json=toAssignee,proto3" json:"to_assignee,omitempty"`
+	ToTeam        string                 `protobuf:"bytes,6,opt,name=to_team,json=toTeam,proto3" json:"to_team,omitempty"`
+	RiskRating    string                 `protobuf:"bytes,7,opt,name=risk_rating,json=riskRating,proto3" json:"risk_rating,omitempty"`
+	SlaDueAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=sla_due_at,json=slaDueAt,proto3" json:"sla_due_at,omitempty"`
+	Actor         string                 `protobuf:"bytes,9,opt,name=actor,proto3" json:"actor,omitempty"`
+	Reason        string                 `protobuf:"bytes,10,opt,name=reason,proto3" json:"reason,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CaseAssignmentChange) Reset() {
+	*x = CaseAssignmentChange{}
+	mi := &file_api_proto_kyc_case_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaseAssignmentChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaseAssignmentChange) ProtoMessage() {}
+
+func (x *CaseAssignmentChange) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kyc_case_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaseAssignmentChange.ProtoReflect.Descriptor instead.
+func (*CaseAssignmentChange) Descriptor() ([]byte, []int) {
+	return file_api_proto_kyc_case_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CaseAssignmentChange) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CaseAssignmentChange) GetCaseName() string {
+	if x != nil {
+		return x.CaseName
+	}
+	return ""
+}
+
+func (x *CaseAssignmentChange) GetFromAssignee() string {
+	if x != nil {
+		return x.FromAssignee
+	}
+	return ""
+}
+
+func (x *CaseAssignmentChange) GetFromTeam() string {
+	if x != nil {
+		return x.FromTeam
+	}
+	return ""
+}
+
+func (x *CaseAssignmentChange) GetToAssignee() string {
+	if x != nil {
+		return x.ToAssignee
+	}
+	return ""
+}
+
+func (x *CaseAssignmentChange) GetToTeam() string {
+	if x != nil {
+		return x.ToTeam
+	}
+	return ""
+}
+
+func (x *CaseAssignmentChange) GetRiskRating() string {
+	if x != nil {
+		return x.RiskRating
+	}
+	return ""
+}
+
+func (x *CaseAssignmentChange) GetSlaDueAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SlaDueAt
+	}
+	return nil
+}
+
+func (x *CaseAssignmentChange) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *CaseAssignmentChange) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CaseAssignmentChange) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// AssignmentHistory lists the assignments of a case
+type AssignmentHistory struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	CaseName      string                  `protobuf:"bytes,1,opt,name=case_name,json=caseName,proto3" json:"case_name,omitempty"`
+	Changes       []*CaseAssignmentChange `protobuf:"bytes,2,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignmentHistory) Reset() {
+	*x = AssignmentHistory{}
+	mi := &file_api_proto_kyc_case_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignmentHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignmentHistory) ProtoMessage() {}
+
+func (x *AssignmentHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kyc_case_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignmentHistory.ProtoReflect.Descriptor instead.
+func (*AssignmentHistory) Descriptor() ([]byte, []int) {
+	return file_api_proto_kyc_case_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AssignmentHistory) GetCaseName() string {
+	if x != nil {
+		return x.CaseName
+	}
+	return ""
+}
+
+func (x *AssignmentHistory) GetChanges() []*CaseAssignmentChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+// VerifyCaseIntegrityRequest names the case whose version chain to verify
+type VerifyCaseIntegrityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CaseName      string                 `protobuf:"bytes,1,opt,name=case_name,json=caseName,proto3" json:"case_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyCaseIntegrityRequest) Reset() {
+	*x = VerifyCaseIntegrityRequest{}
+	mi := &file_api_proto_kyc_case_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyCaseIntegrityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyCaseIntegrityRequest) ProtoMessage() {}
+
+func (x *VerifyCaseIntegrityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kyc_case_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyCaseIntegrityRequest.ProtoReflect.Descriptor instead.
+func (*VerifyCaseIntegrityRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_kyc_case_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *VerifyCaseIntegrityRequest) GetCaseName() string {
+	if x != nil {
+		return x.CaseName
+	}
+	return ""
+}
+
+// CaseIntegrityIssue is a version where the chain breaks
+type CaseIntegrityIssue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       int32                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Problem       string                 `protobuf:"bytes,2,opt,name=problem,proto3" json:"problem,omitempty"` // unchained, version_gap, broken_link or tampered
+	Detail        string                 `protobuf:"bytes,3,opt,name=detail,proto3" json:"detail,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CaseIntegrityIssue) Reset() {
+	*x = CaseIntegrityIssue{}
+	mi := &file_api_proto_kyc_case_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaseIntegrityIssue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaseIntegrityIssue) ProtoMessage() {}
+
+func (x *CaseIntegrityIssue) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kyc_case_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaseIntegrityIssue.ProtoReflect.Descriptor instead.
+func (*CaseIntegrityIssue) Descriptor() ([]byte, []int) {
+	return file_api_proto_kyc_case_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CaseIntegrityIssue) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *CaseIntegrityIssue) GetProblem() string {
+	if x != nil {
+		return x.Problem
+	}
+	return ""
+}
+
+func (x *CaseIntegrityIssue) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+// CaseIntegrityReport is the outcome of re-computing a case's version chain
+type CaseIntegrityReport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CaseName      string                 `protobuf:"bytes,1,opt,name=case_name,json=caseName,proto3" json:"case_name,omitempty"`
+	Versions      int32                  `protobuf:"varint,2,opt,name=versions,proto3" json:"versions,omitempty"`
+	Valid         bool                   `protobuf:"varint,3,opt,name=valid,proto3" json:"valid,omitempty"`
+	HeadHash      string                 `protobuf:"bytes,4,opt,name=head_hash,json=headHash,proto3" json:"head_hash,omitempty"` // Chain hash of the latest version
+	Issues        []*CaseIntegrityIssue  `protobuf:"bytes,5,rep,name=issues,proto3" json:"issues,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CaseIntegrityReport) Reset() {
+	*x = CaseIntegrityReport{}
+	mi := &file_api_proto_kyc_case_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaseIntegrityReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaseIntegrityReport) ProtoMessage() {}
+
+func (x *CaseIntegrityReport) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_kyc_case_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaseIntegrityReport.ProtoReflect.Descriptor instead.
+func (*CaseIntegrityReport) Descriptor() ([]byte, []int) {
+	return file_api_proto_kyc_case_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CaseIntegrityReport) GetCaseName() string {
+	if x != nil {
+		return x.CaseName
+	}
+	return ""
+}
+
+func (x *CaseIntegrityReport) GetVersions() int32 {
+	if x != nil {
+		return x.Versions
+	}
+	return 0
+}
+
+func (x *CaseIntegrityReport) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *CaseIntegrityReport) GetHeadHash() string {
+	if x != nil {
+		return x.HeadHash
+	}
+	return ""
+}
+
+func (x *CaseIntegrityReport) GetIssues() []*CaseIntegrityIssue {
+	if x != nil {
+		return x.Issues
+	}
+	return nil
+}
+
+var File_api_proto_kyc_case_proto protoreflect.FileDescriptor
+
+const file_api_proto_kyc_case_proto_rawDesc = "" +
+	"\n" +
+	"\x18api/proto/kyc_case.proto\x12\x03kyc\x1a\x1fgoogle/protobuf/timestamp.proto\" \n" +
+	"\x0eGetCaseRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x9e\x01\n" +
+	"\x11UpdateCaseRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12=\n" +
+	"\aupdates\x18\x02 \x03(\v2#.kyc.UpdateCaseRequest.UpdatesEntryR\aupdates\x1a:\n" +
+	"\fUpdatesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"|\n" +
+	"\x10ListCasesRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\"\n" +
+	"\fjurisdiction\x18\x03 \x01(\tR\fjurisdiction\x12\x16\n" +
+	"\x06policy\x18\x04 \x01(\tR\x06policy\"%\n" +
+	"\x11CreateCaseRequest\x12\x10\n" +
+	"\x03dsl\x18\x01 \x01(\tR\x03dsl\"#\n" +
+	"\x11DeleteCaseRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"H\n" +
+	"\x12DeleteCaseResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"5\n" +
+	"\x16GetCaseVersionsRequest\x12\x1b\n" +
+	"\tcase_name\x18\x01 \x01(\tR\bcaseName\"z\n" +
+	"\x19GetCaseVersionDiffRequest\x12\x1b\n" +
+	"\tcase_name\x18\x01 \x01(\tR\bcaseName\x12!\n" +
+	"\ffrom_version\x18\x02 \x01(\x05R\vfromVersion\x12\x1d\n" +
+	"\n" +
+	"to_version\x18\x03 \x01(\x05R\ttoVersion\"\x8e\x01\n" +
+	"\x11CaseSectionChange\x12\x1f\n" +
+	"\vchange_type\x18\x01 \x01(\tR\n" +
+	"changeType\x12\x18\n" +
+	"\asection\x18\x02 \x01(\tR\asection\x12\x10\n" +
+	"\x03key\x18\x03 \x01(\tR\x03key\x12\x16\n" +
+	"\x06before\x18\x04 \x01(\tR\x06before\x12\x14\n" +
+	"\x05after\x18\x05 \x01(\tR\x05after\"\xd8\x01\n" +
+	"\x0fCaseVersionDiff\x12\x1b\n" +
+	"\tcase_name\x18\x01 \x01(\tR\bcaseName\x12!\n" +
+	"\ffrom_version\x18\x02 \x01(\x05R\vfromVersion\x12\x1d\n" +
+	"\n" +
+	"to_version\x18\x03 \x01(\x05R\ttoVersion\x12\x1b\n" +
+	"\tfrom_hash\x18\x04 \x01(\tR\bfromHash\x12\x17\n" +
+	"\ato_hash\x18\x05 \x01(\tR\x06toHash\x120\n" +
+	"\achanges\x18\x06 \x03(\v2\x16.kyc.CaseSectionChangeR\achanges\"\xb7\x03\n" +
+	"\aKycCase\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\"\n" +
+	"\fjurisdiction\x18\x03 \x01(\tR\fjurisdiction\x12\x10\n" +
+	"\x03dsl\x18\x04 \x01(\tR\x03dsl\x12\x16\n" +
+	"\x06policy\x18\x05 \x01(\tR\x06policy\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x120\n" +
+	"\x14client_business_unit\x18\b \x01(\tR\x12clientBusinessUnit\x12\x1a\n" +
+	"\bfunction\x18\t \x01(\tR\bfunction\x12\x1e\n" +
+	"\n" +
+	"obligation\x18\n" +
+	" \x01(\tR\n" +
+	"obligation\x12\x1b\n" +
+	"\tkyc_token\x18\v \x01(\tR\bkycToken\x12\x18\n" +
+	"\aversion\x18\f \x01(\x05R\aversion\x12\x1f\n" +
+	"\vsha256_hash\x18\r \x01(\tR\n" +
+	"sha256Hash\"\x8c\x02\n" +
+	"\x0eKycCaseVersion\x12\x1b\n" +
+	"\tcase_name\x18\x01 \x01(\tR\bcaseName\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x12!\n" +
+	"\fdsl_snapshot\x18\x03 \x01(\tR\vdslSnapshot\x12\x1f\n" +
+	"\vsha256_hash\x18\x04 \x01(\tR\n" +
+	"sha256Hash\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x06 \x01(\tR\tcreatedBy\x12%\n" +
+	"\x0eamendment_type\x18\a \x01(\tR\ramendmentType\"\xd3\x01\n" +
+	"\x11AssignCaseRequest\x12\x1b\n" +
+	"\tcase_name\x18\x01 \x01(\tR\bcaseName\x12\x1a\n" +
+	"\bassignee\x18\x02 \x01(\tR\bassignee\x12\x12\n" +
+	"\x04team\x18\x03 \x01(\tR\x04team\x12\x1f\n" +
+	"\vrisk_rating\x18\x04 \x01(\tR\n" +
+	"riskRating\x128\n" +
+	"\n" +
+	"sla_due_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\bslaDueAt\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\"\xc8\x02\n" +
+	"\x0eCaseAssignment\x12\x1b\n" +
+	"\tcase_name\x18\x01 \x01(\tR\bcaseName\x12\x1a\n" +
+	"\bassignee\x18\x02 \x01(\tR\bassignee\x12\x12\n" +
+	"\x04team\x18\x03 \x01(\tR\x04team\x12\x1f\n" +
+	"\vrisk_rating\x18\x04 \x01(\tR\n" +
+	"riskRating\x128\n" +
+	"\n" +
+	"sla_due_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\bslaDueAt\x12\x1f\n" +
+	"\vassigned_by\x18\x06 \x01(\tR\n" +
+	"assignedBy\x12;\n" +
+	"\vassigned_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"assignedAt\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x12\x18\n" +
+	"\aoverdue\x18\t \x01(\bR\aoverdue\"\xef\x01\n" +
+	"\x10CaseQueueRequest\x12\x1a\n" +
+	"\bassignee\x18\x01 \x01(\tR\bassignee\x12\x12\n" +
+	"\x04team\x18\x02 \x01(\tR\x04team\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1f\n" +
+	"\vrisk_rating\x18\x04 \x01(\tR\n" +
+	"riskRating\x129\n" +
+	"\n" +
+	"due_before\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tdueBefore\x12!\n" +
+	"\foverdue_only\x18\x06 \x01(\bR\voverdueOnly\x12\x14\n" +
+	"\x05limit\x18\a \x01(\x05R\x05limit\"6\n" +
+	"\tCaseQueue\x12)\n" +
+	"\x05cases\x18\x01 \x03(\v2\x13.kyc.CaseAssignmentR\x05cases\":\n" +
+	"\x1bGetAssignmentHistoryRequest\x12\x1b\n" +
+	"\tcase_name\x18\x01 \x01(\tR\bcaseName\"\x83\x03\n" +
+	"\x14CaseAssignmentChange\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1b\n" +
+	"\tcase_name\x18\x02 \x01(\tR\bcaseName\x12#\n" +
+	"\rfrom_assignee\x18\x03 \x01(\tR\ffromAssignee\x12\x1b\n" +
+	"\tfrom_team\x18\x04 \x01(\tR\bfromTeam\x12\x1f\n" +
+	"\vto_assignee\x18\x05 \x01(\tR\n" +
+	"toAssignee\x12\x17\n" +
+	"\ato_team\x18\x06 \x01(\tR\x06toTeam\x12\x1f\n" +
+	"\vrisk_rating\x18\a \x01(\tR\n" +
+	"riskRating\x128\n" +
+	"\n" +
+	"sla_due_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\bslaDueAt\x12\x14\n" +
+	"\x05actor\x18\t \x01(\tR\x05actor\x12\x16\n" +
+	"\x06reason\x18\n" +
+	" \x01(\tR\x06reason\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"e\n" +
+	"\x11AssignmentHistory\x12\x1b\n" +
+	"\tcase_name\x18\x01 \x01(\tR\bcaseName\x123\n" +
+	"\achanges\x18\x02 \x03(\v2\x19.kyc.CaseAssignmentChangeR\achanges\"9\n" +
+	"\x1aVerifyCaseIntegrityRequest\x12\x1b\n" +
+	"\tcase_name\x18\x01 \x01(\tR\bcaseName\"`\n" +
+	"\x12CaseIntegrityIssue\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x05R\aversion\x12\x18\n" +
+	"\aproblem\x18\x02 \x01(\tR\aproblem\x12\x16\n" +
+	"\x06detail\x18\x03 \x01(\tR\x06detail\"\xb2\x01\n" +
+	"\x13CaseIntegrityReport\x12\x1b\n" +
+	"\tcase_name\x18\x01 \x01(\tR\bcaseName\x12\x1a\n" +
+	"\bversions\x18\x02 \x01(\x05R\bversions\x12\x14\n" +
+	"\x05valid\x18\x03 \x01(\bR\x05valid\x12\x1b\n" +
+	"\thead_hash\x18\x04 \x01(\tR\bheadHash\x12/\n" +
+	"\x06issues\x18\x05 \x03(\v2\x17.kyc.CaseIntegrityIssueR\x06issues2\xc2\x05\n" +
+	"\x0eKycCaseService\x12,\n" +
+	"\aGetCase\x12\x13.kyc.GetCaseRequest\x1a\f.kyc.KycCase\x122\n" +
+	"\n" +
+	"UpdateCase\x12\x16.kyc.UpdateCaseRequest\x1a\f.kyc.KycCase\x122\n" +
+	"\tListCases\x12\x15.kyc.ListCasesRequest\x1a\f.kyc.KycCase0\x01\x122\n" +
+	"\n" +
+	"CreateCase\x12\x16.kyc.CreateCaseRequest\x1a\f.kyc.KycCase\x12=\n" +
+	"\n" +
+	"DeleteCase\x12\x16.kyc.DeleteCaseRequest\x1a\x17.kyc.DeleteCaseResponse\x12E\n" +
+	"\x0fGetCaseVersions\x12\x1b.kyc.GetCaseVersionsRequest\x1a\x13.kyc.KycCaseVersion0\x01\x12J\n" +
+	"\x12GetCaseVersionDiff\x12\x1e.kyc.GetCaseVersionDiffRequest\x1a\x14.kyc.CaseVersionDiff\x129\n" +
+	"\n" +
+	"AssignCase\x12\x16.kyc.AssignCaseRequest\x1a\x13.kyc.CaseAssignment\x125\n" +
+	"\fGetCaseQueue\x12\x15.kyc.CaseQueueRequest\x1a\x0e.kyc.CaseQueue\x12P\n" +
+	"\x14GetAssignmentHistory\x12 .kyc.GetAssignmentHistoryRequest\x1a\x16.kyc.AssignmentHistory\x12P\n" +
+	"\x13VerifyCaseIntegrity\x12\x1f.kyc.VerifyCaseIntegrityRequest\x1a\x18.kyc.CaseIntegrityReportB(Z&github.com/adamtc007/KYC-DSL/api/pb;pbb\x06proto3"
 
 var (
 	file_api_proto_kyc_case_proto_rawDescOnce sync.Once
@@ -692,42 +1749,75 @@ func file_api_proto_kyc_case_proto_rawDescGZIP() []byte {
 	return file_api_proto_kyc_case_proto_rawDescData
 }
 
-var file_api_proto_kyc_case_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_api_proto_kyc_case_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
 var file_api_proto_kyc_case_proto_goTypes = []any{
-	(*GetCaseRequest)(nil),         // 0: kyc.GetCaseRequest
-	(*UpdateCaseRequest)(nil),      // 1: kyc.UpdateCaseRequest
-	(*ListCasesRequest)(nil),       // 2: kyc.ListCasesRequest
-	(*CreateCaseRequest)(nil),      // 3: kyc.CreateCaseRequest
-	(*DeleteCaseRequest)(nil),      // 4: kyc.DeleteCaseRequest
-	(*DeleteCaseResponse)(nil),     // 5: kyc.DeleteCaseResponse
-	(*GetCaseVersionsRequest)(nil), // 6: kyc.GetCaseVersionsRequest
-	(*KycCase)(nil),                // 7: kyc.KycCase
-	(*KycCaseVersion)(nil),         // 8: kyc.KycCaseVersion
-	nil,                            // 9: kyc.UpdateCaseRequest.UpdatesEntry
-	(*timestamppb.Timestamp)(nil),  // 10: google.protobuf.Timestamp
+	(*GetCaseRequest)(nil),              // 0: kyc.GetCaseRequest
+	(*UpdateCaseRequest)(nil),           // 1: kyc.UpdateCaseRequest
+	(*ListCasesRequest)(nil),            // 2: kyc.ListCasesRequest
+	(*CreateCaseRequest)(nil),           // 3: kyc.CreateCaseRequest
+	(*DeleteCaseRequest)(nil),           // 4: kyc.DeleteCaseRequest
+	(*DeleteCaseResponse)(nil),          // 5: kyc.DeleteCaseResponse
+	(*GetCaseVersionsRequest)(nil),      // 6: kyc.GetCaseVersionsRequest
+	(*GetCaseVersionDiffRequest)(nil),   // 7: kyc.GetCaseVersionDiffRequest
+	(*CaseSectionChange)(nil),           // 8: kyc.CaseSectionChange
+	(*CaseVersionDiff)(nil),             // 9: kyc.CaseVersionDiff
+	(*KycCase)(nil),                     // 10: kyc.KycCase
+	(*KycCaseVersion)(nil),              // 11: kyc.KycCaseVersion
+	(*AssignCaseRequest)(nil),           // 12: kyc.AssignCaseRequest
+	(*CaseAssignment)(nil),              // 13: kyc.CaseAssignment
+	(*CaseQueueRequest)(nil),            // 14: kyc.CaseQueueRequest
+	(*CaseQueue)(nil),                   // 15: kyc.CaseQueue
+	(*GetAssignmentHistoryRequest)(nil), // 16: kyc.GetAssignmentHistoryRequest
+	(*CaseAssignmentChange)(nil),        // 17: kyc.CaseAssignmentChange
+	(*AssignmentHistory)(nil),           // 18: kyc.AssignmentHistory
+	(*VerifyCaseIntegrityRequest)(nil),  // 19: kyc.VerifyCaseIntegrityRequest
+	(*CaseIntegrityIssue)(nil),          // 20: kyc.CaseIntegrityIssue
+	(*CaseIntegrityReport)(nil),         // 21: kyc.CaseIntegrityReport
+	nil,                                 // 22: kyc.UpdateCaseRequest.UpdatesEntry
+	(*timestamppb.Timestamp)(nil),       // 23: google.protobuf.Timestamp
 }
 var file_api_proto_kyc_case_proto_depIdxs = []int32{
-	9,  // 0: kyc.UpdateCaseRequest.updates:type_name -> kyc.UpdateCaseRequest.UpdatesEntry
-	10, // 1: kyc.KycCase.created_at:type_name -> google.protobuf.Timestamp
-	10, // 2: kyc.KycCase.updated_at:type_name -> google.protobuf.Timestamp
-	10, // 3: kyc.KycCaseVersion.created_at:type_name -> google.protobuf.Timestamp
-	0,  // 4: kyc.KycCaseService.GetCase:input_type -> kyc.GetCaseRequest
-	1,  // 5: kyc.KycCaseService.UpdateCase:input_type -> kyc.UpdateCaseRequest
-	2,  // 6: kyc.KycCaseService.ListCases:input_type -> kyc.ListCasesRequest
-	3,  // 7: kyc.KycCaseService.CreateCase:input_type -> kyc.CreateCaseRequest
-	4,  // 8: kyc.KycCaseService.DeleteCase:input_type -> kyc.DeleteCaseRequest
-	6,  // 9: kyc.KycCaseService.GetCaseVersions:input_type -> kyc.GetCaseVersionsRequest
-	7,  // 10: kyc.KycCaseService.GetCase:output_type -> kyc.KycCase
-	7,  // 11: kyc.KycCaseService.UpdateCase:output_type -> kyc.KycCase
-	7,  // 12: kyc.KycCaseService.ListCases:output_type -> kyc.KycCase
-	7,  // 13: kyc.KycCaseService.CreateCase:output_type -> kyc.KycCase
-	5,  // 14: kyc.KycCaseService.DeleteCase:output_type -> kyc.DeleteCaseResponse
-	8,  // 15: kyc.KycCaseService.GetCaseVersions:output_type -> kyc.KycCaseVersion
-	10, // [10:16] is the sub-list for method output_type
-	4,  // [4:10] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	22, // 0: kyc.UpdateCaseRequest.updates:type_name -> kyc.UpdateCaseRequest.UpdatesEntry
+	8,  // 1: kyc.CaseVersionDiff.changes:type_name -> kyc.CaseSectionChange
+	23, // 2: kyc.KycCase.created_at:type_name -> google.protobuf.Timestamp
+	23, // 3: kyc.KycCase.updated_at:type_name -> google.protobuf.Timestamp
+	23, // 4: kyc.KycCaseVersion.created_at:type_name -> google.protobuf.Timestamp
+	23, // 5: kyc.AssignCaseRequest.sla_due_at:type_name -> google.protobuf.Timestamp
+	23, // 6: kyc.CaseAssignment.sla_due_at:type_name -> google.protobuf.Timestamp
+	23, // 7: kyc.CaseAssignment.assigned_at:type_name -> google.protobuf.Timestamp
+	23, // 8: kyc.CaseQueueRequest.due_before:type_name -> google.protobuf.Timestamp
+	13, // 9: kyc.CaseQueue.cases:type_name -> kyc.CaseAssignment
+	23, // 10: kyc.CaseAssignmentChange.sla_due_at:type_name -> google.protobuf.Timestamp
+	23, // 11: kyc.CaseAssignmentChange.created_at:type_name -> google.protobuf.Timestamp
+	17, // 12: kyc.AssignmentHistory.changes:type_name -> kyc.CaseAssignmentChange
+	20, // 13: kyc.CaseIntegrityReport.issues:type_name -> kyc.CaseIntegrityIssue
+	0,  // 14: kyc.KycCaseService.GetCase:input_type -> kyc.GetCaseRequest
+	1,  // 15: kyc.KycCaseService.UpdateCase:input_type -> kyc.UpdateCaseRequest
+	2,  // 16: kyc.KycCaseService.ListCases:input_type -> kyc.ListCasesRequest
+	3,  // 17: kyc.KycCaseService.CreateCase:input_type -> kyc.CreateCaseRequest
+	4,  // 18: kyc.KycCaseService.DeleteCase:input_type -> kyc.DeleteCaseRequest
+	6,  // 19: kyc.KycCaseService.GetCaseVersions:input_type -> kyc.GetCaseVersionsRequest
+	7,  // 20: kyc.KycCaseService.GetCaseVersionDiff:input_type -> kyc.GetCaseVersionDiffRequest
+	12, // 21: kyc.KycCaseService.AssignCase:input_type -> kyc.AssignCaseRequest
+	14, // 22: kyc.KycCaseService.GetCaseQueue:input_type -> kyc.CaseQueueRequest
+	16, // 23: kyc.KycCaseService.GetAssignmentHistory:input_type -> kyc.GetAssignmentHistoryRequest
+	19, // 24: kyc.KycCaseService.VerifyCaseIntegrity:input_type -> kyc.VerifyCaseIntegrityRequest
+	10, // 25: kyc.KycCaseService.GetCase:output_type -> kyc.KycCase
+	10, // 26: kyc.KycCaseService.UpdateCase:output_type -> kyc.KycCase
+	10, // 27: kyc.KycCaseService.ListCases:output_type -> kyc.KycCase
+	10, // 28: kyc.KycCaseService.CreateCase:output_type -> kyc.KycCase
+	5,  // 29: kyc.KycCaseService.DeleteCase:output_type -> kyc.DeleteCaseResponse
+	11, // 30: kyc.KycCaseService.GetCaseVersions:output_type -> kyc.KycCaseVersion
+	9,  // 31: kyc.KycCaseService.GetCaseVersionDiff:output_type -> kyc.CaseVersionDiff
+	13, // 32: kyc.KycCaseService.AssignCase:output_type -> kyc.CaseAssignment
+	15, // 33: kyc.KycCaseService.GetCaseQueue:output_type -> kyc.CaseQueue
+	18, // 34: kyc.KycCaseService.GetAssignmentHistory:output_type -> kyc.AssignmentHistory
+	21, // 35: kyc.KycCaseService.VerifyCaseIntegrity:output_type -> kyc.CaseIntegrityReport
+	25, // [25:36] is the sub-list for method output_type
+	14, // [14:25] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_kyc_case_proto_init() }
@@ -741,7 +1831,7 @@ func file_api_proto_kyc_case_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_kyc_case_proto_rawDesc), len(file_api_proto_kyc_case_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   23,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,12 +19,17 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	KycCaseService_GetCase_FullMethodName         = "/kyc.KycCaseService/GetCase"
-	KycCaseService_UpdateCase_FullMethodName      = "/kyc.KycCaseService/UpdateCase"
-	KycCaseService_ListCases_FullMethodName       = "/kyc.KycCaseService/ListCases"
-	KycCaseService_CreateCase_FullMethodName      = "/kyc.KycCaseService/CreateCase"
-	KycCaseService_DeleteCase_FullMethodName      = "/kyc.KycCaseService/DeleteCase"
-	KycCaseService_GetCaseVersions_FullMethodName = "/kyc.KycCaseService/GetCaseVersions"
+	KycCaseService_GetCase_FullMethodName              = "/kyc.KycCaseService/GetCase"
+	KycCaseService_UpdateCase_FullMethodName           = "/kyc.KycCaseService/UpdateCase"
+	KycCaseService_ListCases_FullMethodName            = "/kyc.KycCaseService/ListCases"
+	KycCaseService_CreateCase_FullMethodName           = "/kyc.KycCaseService/CreateCase"
+	KycCaseService_DeleteCase_FullMethodName           = "/kyc.KycCaseService/DeleteCase"
+	KycCaseService_GetCaseVersions_FullMethodName      = "/kyc.KycCaseService/GetCaseVersions"
+	KycCaseService_GetCaseVersionDiff_FullMethodName   = "/kyc.KycCaseService/GetCaseVersionDiff"
+	KycCaseService_AssignCase_FullMethodName           = "/kyc.KycCaseService/AssignCase"
+	KycCaseService_GetCaseQueue_FullMethodName         = "/kyc.KycCaseService/GetCaseQueue"
+	KycCaseService_GetAssignmentHistory_FullMethodName = "/kyc.KycCaseService/GetAssignmentHistory"
+	KycCaseService_VerifyCaseIntegrity_FullMethodName  = "/kyc.KycCaseService/VerifyCaseIntegrity"
 )
 
 // KycCaseServiceClient is the client API for KycCaseService service.
@@ -45,6 +50,16 @@ type KycCaseServiceClient interface {
 	DeleteCase(ctx context.Context, in *DeleteCaseRequest, opts ...grpc.CallOption) (*DeleteCaseResponse, error)
 	// GetCaseVersions retrieves all versions of a case
 	GetCaseVersions(ctx context.Context, in *GetCaseVersionsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[KycCaseVersion], error)
+	// GetCaseVersionDiff compares two versions of a case section by section
+	GetCaseVersionDiff(ctx context.Context, in *GetCaseVersionDiffRequest, opts ...grpc.CallOption) (*CaseVersionDiff, error)
+	// AssignCase assigns or reassigns a case to an analyst and/or team
+	AssignCase(ctx context.Context, in *AssignCaseRequest, opts ...grpc.CallOption) (*CaseAssignment, error)
+	// GetCaseQueue returns the work queue of assigned cases matching a filter
+	GetCaseQueue(ctx context.Context, in *CaseQueueRequest, opts ...grpc.CallOption) (*CaseQueue, error)
+	// GetAssignmentHistory returns every assignment of a case, oldest first
+	GetAssignmentHistory(ctx context.Context, in *GetAssignmentHistoryRequest, opts ...grpc.CallOption) (*AssignmentHistory, error)
+	// VerifyCaseIntegrity re-validates the hash chain of a case's versions
+	VerifyCaseIntegrity(ctx context.Context, in *VerifyCaseIntegrityRequest, opts ...grpc.CallOption) (*CaseIntegrityReport, error)
 }
 
 type kycCaseServiceClient struct {
@@ -133,6 +148,56 @@ func (c *kycCaseServiceClient) GetCaseVersions(ctx context.Context, in *GetCaseV
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type KycCaseService_GetCaseVersionsClient = grpc.ServerStreamingClient[KycCaseVersion]
 
+func (c *kycCaseServiceClient) GetCaseVersionDiff(ctx context.Context, in *GetCaseVersionDiffRequest, opts ...grpc.CallOption) (*CaseVersionDiff, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CaseVersionDiff)
+	err := c.cc.Invoke(ctx, KycCaseService_GetCaseVersionDiff_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kycCaseServiceClient) AssignCase(ctx context.Context, in *AssignCaseRequest, opts ...grpc.CallOption) (*CaseAssignment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CaseAssignment)
+	err := c.cc.Invoke(ctx, KycCaseService_AssignCase_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kycCaseServiceClient) GetCaseQueue(ctx context.Context, in *CaseQueueRequest, opts ...grpc.CallOption) (*CaseQueue, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CaseQueue)
+	err := c.cc.Invoke(ctx, KycCaseService_GetCaseQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kycCaseServiceClient) GetAssignmentHistory(ctx context.Context, in *GetAssignmentHistoryRequest, opts ...grpc.CallOption) (*AssignmentHistory, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AssignmentHistory)
+	err := c.cc.Invoke(ctx, KycCaseService_GetAssignmentHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kycCaseServiceClient) VerifyCaseIntegrity(ctx context.Context, in *VerifyCaseIntegrityRequest, opts ...grpc.CallOption) (*CaseIntegrityReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CaseIntegrityReport)
+	err := c.cc.Invoke(ctx, KycCaseService_VerifyCaseIntegrity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // KycCaseServiceServer is the server API for KycCaseService service.
 // All implementations must embed UnimplementedKycCaseServiceServer
 // for forward compatibility.
@@ -151,6 +216,16 @@ type KycCaseServiceServer interface {
 	DeleteCase(context.Context, *DeleteCaseRequest) (*DeleteCaseResponse, error)
 	// GetCaseVersions retrieves all versions of a case
 	GetCaseVersions(*GetCaseVersionsRequest, grpc.ServerStreamingServer[KycCaseVersion]) error
+	// GetCaseVersionDiff compares two versions of a case section by section
+	GetCaseVersionDiff(context.Context, *GetCaseVersionDiffRequest) (*CaseVersionDiff, error)
+	// AssignCase assigns or reassigns a case to an analyst and/or team
+	AssignCase(context.Context, *AssignCaseRequest) (*CaseAssignment, error)
+	// GetCaseQueue returns the work queue of assigned cases matching a filter
+	GetCaseQueue(context.Context, *CaseQueueRequest) (*CaseQueue, error)
+	// GetAssignmentHistory returns every assignment of a case, oldest first
+	GetAssignmentHistory(context.Context, *GetAssignmentHistoryRequest) (*AssignmentHistory, error)
+	// VerifyCaseIntegrity re-validates the hash chain of a case's versions
+	VerifyCaseIntegrity(context.Context, *VerifyCaseIntegrityRequest) (*CaseIntegrityReport, error)
 	mustEmbedUnimplementedKycCaseServiceServer()
 }
 
@@ -179,6 +254,21 @@ func (UnimplementedKycCaseServiceServer) DeleteCase(context.Context, *DeleteCase
 func (UnimplementedKycCaseServiceServer) GetCaseVersions(*GetCaseVersionsRequest, grpc.ServerStreamingServer[KycCaseVersion]) error {
 	return status.Errorf(codes.Unimplemented, "method GetCaseVersions not implemented")
 }
+func (UnimplementedKycCaseServiceServer) GetCaseVersionDiff(context.Context, *GetCaseVersionDiffRequest) (*CaseVersionDiff, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCaseVersionDiff not implemented")
+}
+func (UnimplementedKycCaseServiceServer) AssignCase(context.Context, *AssignCaseRequest) (*CaseAssignment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssignCase not implemented")
+}
+func (UnimplementedKycCaseServiceServer) GetCaseQueue(context.Context, *CaseQueueRequest) (*CaseQueue, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCaseQueue not implemented")
+}
+func (UnimplementedKycCaseServiceServer) GetAssignmentHistory(context.Context, *GetAssignmentHistoryRequest) (*AssignmentHistory, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAssignmentHistory not implemented")
+}
+func (UnimplementedKycCaseServiceServer) VerifyCaseIntegrity(context.Context, *VerifyCaseIntegrityRequest) (*CaseIntegrityReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyCaseIntegrity not implemented")
+}
 func (UnimplementedKycCaseServiceServer) mustEmbedUnimplementedKycCaseServiceServer() {}
 func (UnimplementedKycCaseServiceServer) testEmbeddedByValue()                        {}
 
@@ -294,6 +384,96 @@ func _KycCaseService_GetCaseVersions_Handler(srv interface{}, stream grpc.Server
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type KycCaseService_GetCaseVersionsServer = grpc.ServerStreamingServer[KycCaseVersion]
 
+func _KycCaseService_GetCaseVersionDiff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCaseVersionDiffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KycCaseServiceServer).GetCaseVersionDiff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KycCaseService_GetCaseVersionDiff_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KycCaseServiceServer).GetCaseVersionDiff(ctx, req.(*GetCaseVersionDiffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KycCaseService_AssignCase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignCaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KycCaseServiceServer).AssignCase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KycCaseService_AssignCase_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KycCaseServiceServer).AssignCase(ctx, req.(*AssignCaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KycCaseService_GetCaseQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CaseQueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KycCaseServiceServer).GetCaseQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KycCaseService_GetCaseQueue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KycCaseServiceServer).GetCaseQueue(ctx, req.(*CaseQueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KycCaseService_GetAssignmentHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAssignmentHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KycCaseServiceServer).GetAssignmentHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KycCaseService_GetAssignmentHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KycCaseServiceServer).GetAssignmentHistory(ctx, req.(*GetAssignmentHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KycCaseService_VerifyCaseIntegrity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyCaseIntegrityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KycCaseServiceServer).VerifyCaseIntegrity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KycCaseService_VerifyCaseIntegrity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KycCaseServiceServer).VerifyCaseIntegrity(ctx, req.(*VerifyCaseIntegrityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // KycCaseService_ServiceDesc is the grpc.ServiceDesc for KycCaseService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -317,6 +497,26 @@ var KycCaseService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteCase",
 			Handler:    _KycCaseService_DeleteCase_Handler,
 		},
+		{
+			MethodName: "GetCaseVersionDiff",
+			Handler:    _KycCaseService_GetCaseVersionDiff_Handler,
+		},
+		{
+			MethodName: "AssignCase",
+			Handler:    _KycCaseService_AssignCase_Handler,
+		},
+		{
+			MethodName: "GetCaseQueue",
+			Handler:    _KycCaseService_GetCaseQueue_Handler,
+		},
+		{
+			MethodName: "GetAssignmentHistory",
+			Handler:    _KycCaseService_GetAssignmentHistory_Handler,
+		},
+		{
+			MethodName: "VerifyCaseIntegrity",
+			Handler:    _KycCaseService_VerifyCaseIntegrity_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
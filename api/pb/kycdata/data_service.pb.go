@@ -32,6 +32,7 @@ type Attribute struct {
 	AttrType      string                 `protobuf:"bytes,4,opt,name=attr_type,json=attrType,proto3" json:"attr_type,omitempty"`
 	Jurisdiction  string                 `protobuf:"bytes,5,opt,name=jurisdiction,proto3" json:"jurisdiction,omitempty"`
 	Regulation    string                 `protobuf:"bytes,6,opt,name=regulation,proto3" json:"regulation,omitempty"`
+	Provenance    *Provenance            `protobuf:"bytes,7,opt,name=provenance,proto3" json:"provenance,omitempty"` // Set when the request asks for it
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -108,16 +109,93 @@ func (x *Attribute) GetRegulation() string {
 	return ""
 }
 
+func (x *Attribute) GetProvenance() *Provenance {
+	if x != nil {
+		return x.Provenance
+	}
+	return nil
+}
+
+// Provenance of a record, returned when a read sets include_provenance
+type Provenance struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	SourceTable     string                 `protobuf:"bytes,1,opt,name=source_table,json=sourceTable,proto3" json:"source_table,omitempty"`
+	LastModified    string                 `protobuf:"bytes,2,opt,name=last_modified,json=lastModified,proto3" json:"last_modified,omitempty"`          // RFC 3339
+	ModifiedBy      string                 `protobuf:"bytes,3,opt,name=modified_by,json=modifiedBy,proto3" json:"modified_by,omitempty"`                // Empty for seeded or system changes
+	OntologyVersion string                 `protobuf:"bytes,4,opt,name=ontology_version,json=ontologyVersion,proto3" json:"ontology_version,omitempty"` // Changes whenever the ontology does
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Provenance) Reset() {
+	*x = Provenance{}
+	mi := &file_proto_shared_data_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Provenance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Provenance) ProtoMessage() {}
+
+func (x *Provenance) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_data_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Provenance.ProtoReflect.Descriptor instead.
+func (*Provenance) Descriptor() ([]byte, []int) {
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Provenance) GetSourceTable() string {
+	if x != nil {
+		return x.SourceTable
+	}
+	return ""
+}
+
+func (x *Provenance) GetLastModified() string {
+	if x != nil {
+		return x.LastModified
+	}
+	return ""
+}
+
+func (x *Provenance) GetModifiedBy() string {
+	if x != nil {
+		return x.ModifiedBy
+	}
+	return ""
+}
+
+func (x *Provenance) GetOntologyVersion() string {
+	if x != nil {
+		return x.OntologyVersion
+	}
+	return ""
+}
+
 type GetAttributeRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	IncludeProvenance bool                   `protobuf:"varint,2,opt,name=include_provenance,json=includeProvenance,proto3" json:"include_provenance,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *GetAttributeRequest) Reset() {
 	*x = GetAttributeRequest{}
-	mi := &file_proto_shared_data_service_proto_msgTypes[1]
+	mi := &file_proto_shared_data_service_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -129,7 +207,7 @@ func (x *GetAttributeRequest) String() string {
 func (*GetAttributeRequest) ProtoMessage() {}
 
 func (x *GetAttributeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_data_service_proto_msgTypes[1]
+	mi := &file_proto_shared_data_service_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -142,7 +220,7 @@ func (x *GetAttributeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAttributeRequest.ProtoReflect.Descriptor instead.
 func (*GetAttributeRequest) Descriptor() ([]byte, []int) {
-	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{1}
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *GetAttributeRequest) GetId() string {
@@ -152,17 +230,26 @@ func (x *GetAttributeRequest) GetId() string {
 	return ""
 }
 
+func (x *GetAttributeRequest) GetIncludeProvenance() bool {
+	if x != nil {
+		return x.IncludeProvenance
+	}
+	return false
+}
+
 type ListAttributesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Limit             int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset            int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"` // Deprecated: ignored when cursor is set
+	Cursor            string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`  // next_cursor of the previous page
+	IncludeProvenance bool                   `protobuf:"varint,4,opt,name=include_provenance,json=includeProvenance,proto3" json:"include_provenance,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *ListAttributesRequest) Reset() {
 	*x = ListAttributesRequest{}
-	mi := &file_proto_shared_data_service_proto_msgTypes[2]
+	mi := &file_proto_shared_data_service_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -174,7 +261,7 @@ func (x *ListAttributesRequest) String() string {
 func (*ListAttributesRequest) ProtoMessage() {}
 
 func (x *ListAttributesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_data_service_proto_msgTypes[2]
+	mi := &file_proto_shared_data_service_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -187,7 +274,7 @@ func (x *ListAttributesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListAttributesRequest.ProtoReflect.Descriptor instead.
 func (*ListAttributesRequest) Descriptor() ([]byte, []int) {
-	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{2}
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *ListAttributesRequest) GetLimit() int32 {
@@ -204,17 +291,32 @@ func (x *ListAttributesRequest) GetOffset() int32 {
 	return 0
 }
 
+func (x *ListAttributesRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListAttributesRequest) GetIncludeProvenance() bool {
+	if x != nil {
+		return x.IncludeProvenance
+	}
+	return false
+}
+
 type AttributeList struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Attributes    []*Attribute           `protobuf:"bytes,1,rep,name=attributes,proto3" json:"attributes,omitempty"`
 	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // Empty on the last page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *AttributeList) Reset() {
 	*x = AttributeList{}
-	mi := &file_proto_shared_data_service_proto_msgTypes[3]
+	mi := &file_proto_shared_data_service_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -226,7 +328,7 @@ func (x *AttributeList) String() string {
 func (*AttributeList) ProtoMessage() {}
 
 func (x *AttributeList) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_data_service_proto_msgTypes[3]
+	mi := &file_proto_shared_data_service_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -239,7 +341,7 @@ func (x *AttributeList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AttributeList.ProtoReflect.Descriptor instead.
 func (*AttributeList) Descriptor() ([]byte, []int) {
-	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{3}
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *AttributeList) GetAttributes() []*Attribute {
@@ -256,6 +358,13 @@ func (x *AttributeList) GetTotalCount() int32 {
 	return 0
 }
 
+func (x *AttributeList) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
 // ----------------------
 // Messages - Documents
 // ----------------------
@@ -267,13 +376,14 @@ type Document struct {
 	Category      string                 `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
 	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
 	Url           string                 `protobuf:"bytes,6,opt,name=url,proto3" json:"url,omitempty"`
+	Provenance    *Provenance            `protobuf:"bytes,7,opt,name=provenance,proto3" json:"provenance,omitempty"` // Set when the request asks for it
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Document) Reset() {
 	*x = Document{}
-	mi := &file_proto_shared_data_service_proto_msgTypes[4]
+	mi := &file_proto_shared_data_service_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -285,7 +395,7 @@ func (x *Document) String() string {
 func (*Document) ProtoMessage() {}
 
 func (x *Document) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_data_service_proto_msgTypes[4]
+	mi := &file_proto_shared_data_service_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -298,7 +408,7 @@ func (x *Document) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Document.ProtoReflect.Descriptor instead.
 func (*Document) Descriptor() ([]byte, []int) {
-	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{4}
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *Document) GetId() string {
@@ -343,16 +453,24 @@ func (x *Document) GetUrl() string {
 	return ""
 }
 
+func (x *Document) GetProvenance() *Provenance {
+	if x != nil {
+		return x.Provenance
+	}
+	return nil
+}
+
 type GetDocumentRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	IncludeProvenance bool                   `protobuf:"varint,2,opt,name=include_provenance,json=includeProvenance,proto3" json:"include_provenance,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *GetDocumentRequest) Reset() {
 	*x = GetDocumentRequest{}
-	mi := &file_proto_shared_data_service_proto_msgTypes[5]
+	mi := &file_proto_shared_data_service_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -364,7 +482,7 @@ func (x *GetDocumentRequest) String() string {
 func (*GetDocumentRequest) ProtoMessage() {}
 
 func (x *GetDocumentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_data_service_proto_msgTypes[5]
+	mi := &file_proto_shared_data_service_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -377,7 +495,7 @@ func (x *GetDocumentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDocumentRequest.ProtoReflect.Descriptor instead.
 func (*GetDocumentRequest) Descriptor() ([]byte, []int) {
-	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{5}
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetDocumentRequest) GetId() string {
@@ -387,18 +505,27 @@ func (x *GetDocumentRequest) GetId() string {
 	return ""
 }
 
+func (x *GetDocumentRequest) GetIncludeProvenance() bool {
+	if x != nil {
+		return x.IncludeProvenance
+	}
+	return false
+}
+
 type ListDocumentsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
-	Jurisdiction  string                 `protobuf:"bytes,3,opt,name=jurisdiction,proto3" json:"jurisdiction,omitempty"` // optional filter
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Limit             int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset            int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`            // Deprecated: ignored when cursor is set
+	Jurisdiction      string                 `protobuf:"bytes,3,opt,name=jurisdiction,proto3" json:"jurisdiction,omitempty"` // optional filter
+	Cursor            string                 `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`             // next_cursor of the previous page
+	IncludeProvenance bool                   `protobuf:"varint,5,opt,name=include_provenance,json=includeProvenance,proto3" json:"include_provenance,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *ListDocumentsRequest) Reset() {
 	*x = ListDocumentsRequest{}
-	mi := &file_proto_shared_data_service_proto_msgTypes[6]
+	mi := &file_proto_shared_data_service_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -410,7 +537,7 @@ func (x *ListDocumentsRequest) String() string {
 func (*ListDocumentsRequest) ProtoMessage() {}
 
 func (x *ListDocumentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_data_service_proto_msgTypes[6]
+	mi := &file_proto_shared_data_service_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -423,7 +550,7 @@ func (x *ListDocumentsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListDocumentsRequest.ProtoReflect.Descriptor instead.
 func (*ListDocumentsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{6}
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ListDocumentsRequest) GetLimit() int32 {
@@ -447,17 +574,32 @@ func (x *ListDocumentsRequest) GetJurisdiction() string {
 	return ""
 }
 
+func (x *ListDocumentsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListDocumentsRequest) GetIncludeProvenance() bool {
+	if x != nil {
+		return x.IncludeProvenance
+	}
+	return false
+}
+
 type DocumentList struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Documents     []*Document            `protobuf:"bytes,1,rep,name=documents,proto3" json:"documents,omitempty"`
 	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // Empty on the last page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DocumentList) Reset() {
 	*x = DocumentList{}
-	mi := &file_proto_shared_data_service_proto_msgTypes[7]
+	mi := &file_proto_shared_data_service_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -469,7 +611,7 @@ func (x *DocumentList) String() string {
 func (*DocumentList) ProtoMessage() {}
 
 func (x *DocumentList) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_data_service_proto_msgTypes[7]
+	mi := &file_proto_shared_data_service_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -482,7 +624,7 @@ func (x *DocumentList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DocumentList.ProtoReflect.Descriptor instead.
 func (*DocumentList) Descriptor() ([]byte, []int) {
-	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{7}
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *DocumentList) GetDocuments() []*Document {
@@ -499,6 +641,13 @@ func (x *DocumentList) GetTotalCount() int32 {
 	return 0
 }
 
+func (x *DocumentList) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
 // ----------------------
 // Messages - Cases
 // ----------------------
@@ -516,7 +665,7 @@ type CaseVersion struct {
 
 func (x *CaseVersion) Reset() {
 	*x = CaseVersion{}
-	mi := &file_proto_shared_data_service_proto_msgTypes[8]
+	mi := &file_proto_shared_data_service_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -528,7 +677,7 @@ func (x *CaseVersion) String() string {
 func (*CaseVersion) ProtoMessage() {}
 
 func (x *CaseVersion) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_data_service_proto_msgTypes[8]
+	mi := &file_proto_shared_data_service_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -541,7 +690,7 @@ func (x *CaseVersion) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CaseVersion.ProtoReflect.Descriptor instead.
 func (*CaseVersion) Descriptor() ([]byte, []int) {
-	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{8}
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *CaseVersion) GetId() string {
@@ -598,7 +747,7 @@ type CaseVersionRequest struct {
 
 func (x *CaseVersionRequest) Reset() {
 	*x = CaseVersionRequest{}
-	mi := &file_proto_shared_data_service_proto_msgTypes[9]
+	mi := &file_proto_shared_data_service_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -610,7 +759,7 @@ func (x *CaseVersionRequest) String() string {
 func (*CaseVersionRequest) ProtoMessage() {}
 
 func (x *CaseVersionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_data_service_proto_msgTypes[9]
+	mi := &file_proto_shared_data_service_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -623,7 +772,7 @@ func (x *CaseVersionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CaseVersionRequest.ProtoReflect.Descriptor instead.
 func (*CaseVersionRequest) Descriptor() ([]byte, []int) {
-	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{9}
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *CaseVersionRequest) GetCaseId() string {
@@ -665,7 +814,7 @@ type CaseVersionResponse struct {
 
 func (x *CaseVersionResponse) Reset() {
 	*x = CaseVersionResponse{}
-	mi := &file_proto_shared_data_service_proto_msgTypes[10]
+	mi := &file_proto_shared_data_service_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -677,7 +826,7 @@ func (x *CaseVersionResponse) String() string {
 func (*CaseVersionResponse) ProtoMessage() {}
 
 func (x *CaseVersionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_data_service_proto_msgTypes[10]
+	mi := &file_proto_shared_data_service_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -690,7 +839,7 @@ func (x *CaseVersionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CaseVersionResponse.ProtoReflect.Descriptor instead.
 func (*CaseVersionResponse) Descriptor() ([]byte, []int) {
-	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{10}
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *CaseVersionResponse) GetSuccess() bool {
@@ -723,7 +872,7 @@ type GetCaseRequest struct {
 
 func (x *GetCaseRequest) Reset() {
 	*x = GetCaseRequest{}
-	mi := &file_proto_shared_data_service_proto_msgTypes[11]
+	mi := &file_proto_shared_data_service_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -735,7 +884,7 @@ func (x *GetCaseRequest) String() string {
 func (*GetCaseRequest) ProtoMessage() {}
 
 func (x *GetCaseRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_data_service_proto_msgTypes[11]
+	mi := &file_proto_shared_data_service_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -748,7 +897,7 @@ func (x *GetCaseRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCaseRequest.ProtoReflect.Descriptor instead.
 func (*GetCaseRequest) Descriptor() ([]byte, []int) {
-	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{11}
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GetCaseRequest) GetCaseId() string {
@@ -769,7 +918,7 @@ type ListCaseVersionsRequest struct {
 
 func (x *ListCaseVersionsRequest) Reset() {
 	*x = ListCaseVersionsRequest{}
-	mi := &file_proto_shared_data_service_proto_msgTypes[12]
+	mi := &file_proto_shared_data_service_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -781,7 +930,7 @@ func (x *ListCaseVersionsRequest) String() string {
 func (*ListCaseVersionsRequest) ProtoMessage() {}
 
 func (x *ListCaseVersionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_data_service_proto_msgTypes[12]
+	mi := &file_proto_shared_data_service_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -794,7 +943,7 @@ func (x *ListCaseVersionsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListCaseVersionsRequest.ProtoReflect.Descriptor instead.
 func (*ListCaseVersionsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{12}
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ListCaseVersionsRequest) GetCaseId() string {
@@ -828,7 +977,7 @@ type CaseVersionList struct {
 
 func (x *CaseVersionList) Reset() {
 	*x = CaseVersionList{}
-	mi := &file_proto_shared_data_service_proto_msgTypes[13]
+	mi := &file_proto_shared_data_service_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -840,7 +989,7 @@ func (x *CaseVersionList) String() string {
 func (*CaseVersionList) ProtoMessage() {}
 
 func (x *CaseVersionList) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_data_service_proto_msgTypes[13]
+	mi := &file_proto_shared_data_service_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -853,7 +1002,7 @@ func (x *CaseVersionList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CaseVersionList.ProtoReflect.Descriptor instead.
 func (*CaseVersionList) Descriptor() ([]byte, []int) {
-	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{13}
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *CaseVersionList) GetVersions() []*CaseVersion {
@@ -881,7 +1030,7 @@ type ListAllCasesRequest struct {
 
 func (x *ListAllCasesRequest) Reset() {
 	*x = ListAllCasesRequest{}
-	mi := &file_proto_shared_data_service_proto_msgTypes[14]
+	mi := &file_proto_shared_data_service_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -893,7 +1042,7 @@ func (x *ListAllCasesRequest) String() string {
 func (*ListAllCasesRequest) ProtoMessage() {}
 
 func (x *ListAllCasesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_data_service_proto_msgTypes[14]
+	mi := &file_proto_shared_data_service_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -906,7 +1055,7 @@ func (x *ListAllCasesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListAllCasesRequest.ProtoReflect.Descriptor instead.
 func (*ListAllCasesRequest) Descriptor() ([]byte, []int) {
-	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{14}
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *ListAllCasesRequest) GetLimit() int32 {
@@ -936,13 +1085,15 @@ type CaseSummary struct {
 	VersionCount  int32                  `protobuf:"varint,2,opt,name=version_count,json=versionCount,proto3" json:"version_count,omitempty"`
 	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
 	LastUpdated   string                 `protobuf:"bytes,4,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+	LockedBy      string                 `protobuf:"bytes,5,opt,name=locked_by,json=lockedBy,proto3" json:"locked_by,omitempty"` // Holder of a live case lock; empty when unlocked
+	LockExpiresAt string                 `protobuf:"bytes,6,opt,name=lock_expires_at,json=lockExpiresAt,proto3" json:"lock_expires_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CaseSummary) Reset() {
 	*x = CaseSummary{}
-	mi := &file_proto_shared_data_service_proto_msgTypes[15]
+	mi := &file_proto_shared_data_service_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -954,7 +1105,7 @@ func (x *CaseSummary) String() string {
 func (*CaseSummary) ProtoMessage() {}
 
 func (x *CaseSummary) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_data_service_proto_msgTypes[15]
+	mi := &file_proto_shared_data_service_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -967,7 +1118,7 @@ func (x *CaseSummary) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CaseSummary.ProtoReflect.Descriptor instead.
 func (*CaseSummary) Descriptor() ([]byte, []int) {
-	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{15}
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *CaseSummary) GetCaseId() string {
@@ -998,6 +1149,20 @@ func (x *CaseSummary) GetLastUpdated() string {
 	return ""
 }
 
+func (x *CaseSummary) GetLockedBy() string {
+	if x != nil {
+		return x.LockedBy
+	}
+	return ""
+}
+
+func (x *CaseSummary) GetLockExpiresAt() string {
+	if x != nil {
+		return x.LockExpiresAt
+	}
+	return ""
+}
+
 type CaseList struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Cases         []*CaseSummary         `protobuf:"bytes,1,rep,name=cases,proto3" json:"cases,omitempty"`
@@ -1008,7 +1173,7 @@ type CaseList struct {
 
 func (x *CaseList) Reset() {
 	*x = CaseList{}
-	mi := &file_proto_shared_data_service_proto_msgTypes[16]
+	mi := &file_proto_shared_data_service_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1020,7 +1185,7 @@ func (x *CaseList) String() string {
 func (*CaseList) ProtoMessage() {}
 
 func (x *CaseList) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_data_service_proto_msgTypes[16]
+	mi := &file_proto_shared_data_service_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1033,7 +1198,7 @@ func (x *CaseList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CaseList.ProtoReflect.Descriptor instead.
 func (*CaseList) Descriptor() ([]byte, []int) {
-	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{16}
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *CaseList) GetCases() []*CaseSummary {
@@ -1050,51 +1215,2384 @@ func (x *CaseList) GetTotalCount() int32 {
 	return 0
 }
 
-var File_proto_shared_data_service_proto protoreflect.FileDescriptor
+type GenerateNarrativeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CaseId        string                 `protobuf:"bytes,1,opt,name=case_id,json=caseId,proto3" json:"case_id,omitempty"`
+	VersionId     string                 `protobuf:"bytes,2,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"` // Optional; defaults to the latest version
+	Template      string                 `protobuf:"bytes,3,opt,name=template,proto3" json:"template,omitempty"`                    // committee (default) or brief
+	Polish        bool                   `protobuf:"varint,4,opt,name=polish,proto3" json:"polish,omitempty"`                       // Rewrite the draft with the configured chat model
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_proto_shared_data_service_proto_rawDesc = "" +
-	"\n" +
-	"\x1fproto_shared/data_service.proto\x12\bkyc.data\"\xb2\x01\n" +
-	"\tAttribute\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1b\n" +
-	"\tattr_type\x18\x04 \x01(\tR\battrType\x12\"\n" +
-	"\fjurisdiction\x18\x05 \x01(\tR\fjurisdiction\x12\x1e\n" +
-	"\n" +
-	"regulation\x18\x06 \x01(\tR\n" +
-	"regulation\"%\n" +
-	"\x13GetAttributeRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"E\n" +
-	"\x15ListAttributesRequest\x12\x14\n" +
-	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x02 \x01(\x05R\x06offset\"e\n" +
-	"\rAttributeList\x123\n" +
-	"\n" +
-	"attributes\x18\x01 \x03(\v2\x13.kyc.data.AttributeR\n" +
-	"attributes\x12\x1f\n" +
-	"\vtotal_count\x18\x02 \x01(\x05R\n" +
-	"totalCount\"\xa4\x01\n" +
-	"\bDocument\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
-	"\x05title\x18\x02 \x01(\tR\x05title\x12\"\n" +
-	"\fjurisdiction\x18\x03 \x01(\tR\fjurisdiction\x12\x1a\n" +
-	"\bcategory\x18\x04 \x01(\tR\bcategory\x12 \n" +
-	"\vdescription\x18\x05 \x01(\tR\vdescription\x12\x10\n" +
-	"\x03url\x18\x06 \x01(\tR\x03url\"$\n" +
-	"\x12GetDocumentRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"h\n" +
-	"\x14ListDocumentsRequest\x12\x14\n" +
-	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\"\n" +
-	"\fjurisdiction\x18\x03 \x01(\tR\fjurisdiction\"a\n" +
-	"\fDocumentList\x120\n" +
-	"\tdocuments\x18\x01 \x03(\v2\x12.kyc.data.DocumentR\tdocuments\x12\x1f\n" +
-	"\vtotal_count\x18\x02 \x01(\x05R\n" +
-	"totalCount\"\xb1\x01\n" +
-	"\vCaseVersion\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
-	"\acase_id\x18\x02 \x01(\tR\x06caseId\x12\x1d\n" +
+func (x *GenerateNarrativeRequest) Reset() {
+	*x = GenerateNarrativeRequest{}
+	mi := &file_proto_shared_data_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateNarrativeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateNarrativeRequest) ProtoMessage() {}
+
+func (x *GenerateNarrativeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_data_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateNarrativeRequest.ProtoReflect.Descriptor instead.
+func (*GenerateNarrativeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GenerateNarrativeRequest) GetCaseId() string {
+	if x != nil {
+		return x.CaseId
+	}
+	return ""
+}
+
+func (x *GenerateNarrativeRequest) GetVersionId() string {
+	if x != nil {
+		return x.VersionId
+	}
+	return ""
+}
+
+func (x *GenerateNarrativeRequest) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+func (x *GenerateNarrativeRequest) GetPolish() bool {
+	if x != nil {
+		return x.Polish
+	}
+	return false
+}
+
+// CaseNarrative is a due-diligence summary stored with the version it describes
+type CaseNarrative struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CaseId        string                 `protobuf:"bytes,2,opt,name=case_id,json=caseId,proto3" json:"case_id,omitempty"`
+	VersionId     string                 `protobuf:"bytes,3,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`
+	Template      string                 `protobuf:"bytes,4,opt,name=template,proto3" json:"template,omitempty"`
+	Narrative     string                 `protobuf:"bytes,5,opt,name=narrative,proto3" json:"narrative,omitempty"` // Polished text, or the draft when not polished
+	Draft         string                 `protobuf:"bytes,6,opt,name=draft,proto3" json:"draft,omitempty"`         // Template output built from structured data only
+	Polished      bool                   `protobuf:"varint,7,opt,name=polished,proto3" json:"polished,omitempty"`
+	Model         string                 `protobuf:"bytes,8,opt,name=model,proto3" json:"model,omitempty"`
+	PolishError   string                 `protobuf:"bytes,9,opt,name=polish_error,json=polishError,proto3" json:"polish_error,omitempty"` // Why polishing was skipped, if it was requested
+	CreatedAt     string                 `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CaseNarrative) Reset() {
+	*x = CaseNarrative{}
+	mi := &file_proto_shared_data_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaseNarrative) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaseNarrative) ProtoMessage() {}
+
+func (x *CaseNarrative) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_data_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaseNarrative.ProtoReflect.Descriptor instead.
+func (*CaseNarrative) Descriptor() ([]byte, []int) {
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CaseNarrative) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CaseNarrative) GetCaseId() string {
+	if x != nil {
+		return x.CaseId
+	}
+	return ""
+}
+
+func (x *CaseNarrative) GetVersionId() string {
+	if x != nil {
+		return x.VersionId
+	}
+	return ""
+}
+
+func (x *CaseNarrative) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+func (x *CaseNarrative) GetNarrative() string {
+	if x != nil {
+		return x.Narrative
+	}
+	return ""
+}
+
+func (x *CaseNarrative) GetDraft() string {
+	if x != nil {
+		return x.Draft
+	}
+	return ""
+}
+
+func (x *CaseNarrative) GetPolished() bool {
+	if x != nil {
+		return x.Polished
+	}
+	return false
+}
+
+func (x *CaseNarrative) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *CaseNarrative) GetPolishError() string {
+	if x != nil {
+		return x.PolishError
+	}
+	return ""
+}
+
+func (x *CaseNarrative) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type GenerateReviewPackRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CaseId        string                 `protobuf:"bytes,1,opt,name=case_id,json=caseId,proto3" json:"case_id,omitempty"`
+	VersionId     string                 `protobuf:"bytes,2,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`     // Optional; defaults to the latest version
+	AllowDraft    bool                   `protobuf:"varint,3,opt,name=allow_draft,json=allowDraft,proto3" json:"allow_draft,omitempty"` // Render a version that is not approved, marked DRAFT
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateReviewPackRequest) Reset() {
+	*x = GenerateReviewPackRequest{}
+	mi := &file_proto_shared_data_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateReviewPackRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateReviewPackRequest) ProtoMessage() {}
+
+func (x *GenerateReviewPackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_data_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateReviewPackRequest.ProtoReflect.Descriptor instead.
+func (*GenerateReviewPackRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GenerateReviewPackRequest) GetCaseId() string {
+	if x != nil {
+		return x.CaseId
+	}
+	return ""
+}
+
+func (x *GenerateReviewPackRequest) GetVersionId() string {
+	if x != nil {
+		return x.VersionId
+	}
+	return ""
+}
+
+func (x *GenerateReviewPackRequest) GetAllowDraft() bool {
+	if x != nil {
+		return x.AllowDraft
+	}
+	return false
+}
+
+// ReviewPack is a PDF rendering of a case version for committees and regulators
+type ReviewPack struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CaseId        string                 `protobuf:"bytes,1,opt,name=case_id,json=caseId,proto3" json:"case_id,omitempty"`
+	VersionId     string                 `protobuf:"bytes,2,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	VersionHash   string                 `protobuf:"bytes,4,opt,name=version_hash,json=versionHash,proto3" json:"version_hash,omitempty"` // Canonical SHA-256 of the version's DSL, printed on every page
+	Filename      string                 `protobuf:"bytes,5,opt,name=filename,proto3" json:"filename,omitempty"`
+	Pdf           []byte                 `protobuf:"bytes,6,opt,name=pdf,proto3" json:"pdf,omitempty"`
+	Pages         int32                  `protobuf:"varint,7,opt,name=pages,proto3" json:"pages,omitempty"`
+	Draft         bool                   `protobuf:"varint,8,opt,name=draft,proto3" json:"draft,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewPack) Reset() {
+	*x = ReviewPack{}
+	mi := &file_proto_shared_data_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewPack) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewPack) ProtoMessage() {}
+
+func (x *ReviewPack) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_data_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewPack.ProtoReflect.Descriptor instead.
+func (*ReviewPack) Descriptor() ([]byte, []int) {
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ReviewPack) GetCaseId() string {
+	if x != nil {
+		return x.CaseId
+	}
+	return ""
+}
+
+func (x *ReviewPack) GetVersionId() string {
+	if x != nil {
+		return x.VersionId
+	}
+	return ""
+}
+
+func (x *ReviewPack) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ReviewPack) GetVersionHash() string {
+	if x != nil {
+		return x.VersionHash
+	}
+	return ""
+}
+
+func (x *ReviewPack) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *ReviewPack) GetPdf() []byte {
+	if x != nil {
+		return x.Pdf
+	}
+	return nil
+}
+
+func (x *ReviewPack) GetPages() int32 {
+	if x != nil {
+		return x.Pages
+	}
+	return 0
+}
+
+func (x *ReviewPack) GetDraft() bool {
+	if x != nil {
+		return x.Draft
+	}
+	return false
+}
+
+func (x *ReviewPack) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// Case lifecycle: draft -> validated -> in-review -> approved | declined -> archived
+type TransitionCaseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CaseId        string                 `protobuf:"bytes,1,opt,name=case_id,json=caseId,proto3" json:"case_id,omitempty"`
+	ToStatus      string                 `protobuf:"bytes,2,opt,name=to_status,json=toStatus,proto3" json:"to_status,omitempty"`
+	Actor         string                 `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"` // Who made the move; defaults to the caller (x-actor)
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransitionCaseRequest) Reset() {
+	*x = TransitionCaseRequest{}
+	mi := &file_proto_shared_data_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransitionCaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransitionCaseRequest) ProtoMessage() {}
+
+func (x *TransitionCaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_data_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransitionCaseRequest.ProtoReflect.Descriptor instead.
+func (*TransitionCaseRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *TransitionCaseRequest) GetCaseId() string {
+	if x != nil {
+		return x.CaseId
+	}
+	return ""
+}
+
+func (x *TransitionCaseRequest) GetToStatus() string {
+	if x != nil {
+		return x.ToStatus
+	}
+	return ""
+}
+
+func (x *TransitionCaseRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *TransitionCaseRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// CaseTransition is one recorded move of a case between lifecycle states
+type CaseTransition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CaseId        string                 `protobuf:"bytes,2,opt,name=case_id,json=caseId,proto3" json:"case_id,omitempty"`
+	FromStatus    string                 `protobuf:"bytes,3,opt,name=from_status,json=fromStatus,proto3" json:"from_status,omitempty"` // Empty for the transition that created the case
+	ToStatus      string                 `protobuf:"bytes,4,opt,name=to_status,json=toStatus,proto3" json:"to_status,omitempty"`
+	Actor         string                 `protobuf:"bytes,5,opt,name=actor,proto3" json:"actor,omitempty"`
+	Reason        string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CaseTransition) Reset() {
+	*x = CaseTransition{}
+	mi := &file_proto_shared_data_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaseTransition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaseTransition) ProtoMessage() {}
+
+func (x *CaseTransition) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_data_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaseTransition.ProtoReflect.Descriptor instead.
+func (*CaseTransition) Descriptor() ([]byte, []int) {
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CaseTransition) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CaseTransition) GetCaseId() string {
+	if x != nil {
+		return x.CaseId
+	}
+	return ""
+}
+
+func (x *CaseTransition) GetFromStatus() string {
+	if x != nil {
+		return x.FromStatus
+	}
+	return ""
+}
+
+func (x *CaseTransition) GetToStatus() string {
+	if x != nil {
+		return x.ToStatus
+	}
+	return ""
+}
+
+func (x *CaseTransition) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *CaseTransition) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CaseTransition) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type GetCaseTimelineRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CaseId        string                 `protobuf:"bytes,1,opt,name=case_id,json=caseId,proto3" json:"case_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCaseTimelineRequest) Reset() {
+	*x = GetCaseTimelineRequest{}
+	mi := &file_proto_shared_data_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCaseTimelineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCaseTimelineRequest) ProtoMessage() {}
+
+func (x *GetCaseTimelineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_data_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCaseTimelineRequest.ProtoReflect.Descriptor instead.
+func (*GetCaseTimelineRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetCaseTimelineRequest) GetCaseId() string {
+	if x != nil {
+		return x.CaseId
+	}
+	return ""
+}
+
+type CaseTimeline struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	CaseId             string                 `protobuf:"bytes,1,opt,name=case_id,json=caseId,proto3" json:"case_id,omitempty"`
+	Status             string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	AllowedTransitions []string               `protobuf:"bytes,3,rep,name=allowed_transitions,json=allowedTransitions,proto3" json:"allowed_transitions,omitempty"`
+	Transitions        []*CaseTransition      `protobuf:"bytes,4,rep,name=transitions,proto3" json:"transitions,omitempty"` // Oldest first
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *CaseTimeline) Reset() {
+	*x = CaseTimeline{}
+	mi := &file_proto_shared_data_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaseTimeline) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaseTimeline) ProtoMessage() {}
+
+func (x *CaseTimeline) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_data_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaseTimeline.ProtoReflect.Descriptor instead.
+func (*CaseTimeline) Descriptor() ([]byte, []int) {
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CaseTimeline) GetCaseId() string {
+	if x != nil {
+		return x.CaseId
+	}
+	return ""
+}
+
+func (x *CaseTimeline) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CaseTimeline) GetAllowedTransitions() []string {
+	if x != nil {
+		return x.AllowedTransitions
+	}
+	return nil
+}
+
+func (x *CaseTimeline) GetTransitions() []*CaseTransition {
+	if x != nil {
+		return x.Transitions
+	}
+	return nil
+}
+
+// ----------------------
+// Messages - Lineage Evaluation Runs
+// ----------------------
+type GetLineageHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CaseId        string                 `protobuf:"bytes,1,opt,name=case_id,json=caseId,proto3" json:"case_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // 0 = every run
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLineageHistoryRequest) Reset() {
+	*x = GetLineageHistoryRequest{}
+	mi := &file_proto_shared_data_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLineageHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLineageHistoryRequest) ProtoMessage() {}
+
+func (x *GetLineageHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_data_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLineageHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetLineageHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetLineageHistoryRequest) GetCaseId() string {
+	if x != nil {
+		return x.CaseId
+	}
+	return ""
+}
+
+func (x *GetLineageHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type LineageHistory struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CaseId        string                 `protobuf:"bytes,1,opt,name=case_id,json=caseId,proto3" json:"case_id,omitempty"`
+	Runs          []*LineageRun          `protobuf:"bytes,2,rep,name=runs,proto3" json:"runs,omitempty"` // Newest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LineageHistory) Reset() {
+	*x = LineageHistory{}
+	mi := &file_proto_shared_data_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LineageHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LineageHistory) ProtoMessage() {}
+
+func (x *LineageHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_data_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LineageHistory.ProtoReflect.Descriptor instead.
+func (*LineageHistory) Descriptor() ([]byte, []int) {
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *LineageHistory) GetCaseId() string {
+	if x != nil {
+		return x.CaseId
+	}
+	return ""
+}
+
+func (x *LineageHistory) GetRuns() []*LineageRun {
+	if x != nil {
+		return x.Runs
+	}
+	return nil
+}
+
+type LineageRun struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CaseId        string                 `protobuf:"bytes,2,opt,name=case_id,json=caseId,proto3" json:"case_id,omitempty"`
+	CaseVersion   int32                  `protobuf:"varint,3,opt,name=case_version,json=caseVersion,proto3" json:"case_version,omitempty"` // 0 when the case had no stored version
+	InputsHash    string                 `protobuf:"bytes,4,opt,name=inputs_hash,json=inputsHash,proto3" json:"inputs_hash,omitempty"`     // Empty for runs recorded before hashing
+	Source        string                 `protobuf:"bytes,5,opt,name=source,proto3" json:"source,omitempty"`
+	Actor         string                 `protobuf:"bytes,6,opt,name=actor,proto3" json:"actor,omitempty"`
+	Evaluations   int32                  `protobuf:"varint,7,opt,name=evaluations,proto3" json:"evaluations,omitempty"`
+	Failed        int32                  `protobuf:"varint,8,opt,name=failed,proto3" json:"failed,omitempty"`
+	StartedAt     string                 `protobuf:"bytes,9,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	Results       []*DerivationResult    `protobuf:"bytes,10,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LineageRun) Reset() {
+	*x = LineageRun{}
+	mi := &file_proto_shared_data_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LineageRun) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LineageRun) ProtoMessage() {}
+
+func (x *LineageRun) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_data_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LineageRun.ProtoReflect.Descriptor instead.
+func (*LineageRun) Descriptor() ([]byte, []int) {
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *LineageRun) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *LineageRun) GetCaseId() string {
+	if x != nil {
+		return x.CaseId
+	}
+	return ""
+}
+
+func (x *LineageRun) GetCaseVersion() int32 {
+	if x != nil {
+		return x.CaseVersion
+	}
+	return 0
+}
+
+func (x *LineageRun) GetInputsHash() string {
+	if x != nil {
+		return x.InputsHash
+	}
+	return ""
+}
+
+func (x *LineageRun) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *LineageRun) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *LineageRun) GetEvaluations() int32 {
+	if x != nil {
+		return x.Evaluations
+	}
+	return 0
+}
+
+func (x *LineageRun) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *LineageRun) GetStartedAt() string {
+	if x != nil {
+		return x.StartedAt
+	}
+	return ""
+}
+
+func (x *LineageRun) GetResults() []*DerivationResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type DerivationResult struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	EvaluationId   int64                  `protobuf:"varint,1,opt,name=evaluation_id,json=evaluationId,proto3" json:"evaluation_id,omitempty"`
+	DerivedCode    string                 `protobuf:"bytes,2,opt,name=derived_code,json=derivedCode,proto3" json:"derived_code,omitempty"`
+	Value          string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	ValueType      string                 `protobuf:"bytes,4,opt,name=value_type,json=valueType,proto3" json:"value_type,omitempty"`
+	Success        bool                   `protobuf:"varint,5,opt,name=success,proto3" json:"success,omitempty"`
+	Error          string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	Rule           string                 `protobuf:"bytes,7,opt,name=rule,proto3" json:"rule,omitempty"`
+	InputsJson     string                 `protobuf:"bytes,8,opt,name=inputs_json,json=inputsJson,proto3" json:"inputs_json,omitempty"`
+	Jurisdiction   string                 `protobuf:"bytes,9,opt,name=jurisdiction,proto3" json:"jurisdiction,omitempty"`
+	RegulationCode string                 `protobuf:"bytes,10,opt,name=regulation_code,json=regulationCode,proto3" json:"regulation_code,omitempty"`
+	EvaluatedAt    string                 `protobuf:"bytes,11,opt,name=evaluated_at,json=evaluatedAt,proto3" json:"evaluated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DerivationResult) Reset() {
+	*x = DerivationResult{}
+	mi := &file_proto_shared_data_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DerivationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DerivationResult) ProtoMessage() {}
+
+func (x *DerivationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_data_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DerivationResult.ProtoReflect.Descriptor instead.
+func (*DerivationResult) Descriptor() ([]byte, []int) {
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *DerivationResult) GetEvaluationId() int64 {
+	if x != nil {
+		return x.EvaluationId
+	}
+	return 0
+}
+
+func (x *DerivationResult) GetDerivedCode() string {
+	if x != nil {
+		return x.DerivedCode
+	}
+	return ""
+}
+
+func (x *DerivationResult) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *DerivationResult) GetValueType() string {
+	if x != nil {
+		return x.ValueType
+	}
+	return ""
+}
+
+func (x *DerivationResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DerivationResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *DerivationResult) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *DerivationResult) GetInputsJson() string {
+	if x != nil {
+		return x.InputsJson
+	}
+	return ""
+}
+
+func (x *DerivationResult) GetJurisdiction() string {
+	if x != nil {
+		return x.Jurisdiction
+	}
+	return ""
+}
+
+func (x *DerivationResult) GetRegulationCode() string {
+	if x != nil {
+		return x.RegulationCode
+	}
+	return ""
+}
+
+func (x *DerivationResult) GetEvaluatedAt() string {
+	if x != nil {
+		return x.EvaluatedAt
+	}
+	return ""
+}
+
+// ----------------------
+// Messages - Risk Scoring
+// ----------------------
+type ScoreCaseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CaseId        string                 `protobuf:"bytes,1,opt,name=case_id,json=caseId,proto3" json:"case_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScoreCaseRequest) Reset() {
+	*x = ScoreCaseRequest{}
+	mi := &file_proto_shared_data_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScoreCaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScoreCaseRequest) ProtoMessage() {}
+
+func (x *ScoreCaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_data_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScoreCaseRequest.ProtoReflect.Descriptor instead.
+func (*ScoreCaseRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ScoreCaseRequest) GetCaseId() string {
+	if x != nil {
+		return x.CaseId
+	}
+	return ""
+}
+
+type GetRiskScoreRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CaseId        string                 `protobuf:"bytes,1,opt,name=case_id,json=caseId,proto3" json:"case_id,omitempty"` // Score of the latest scored version
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRiskScoreRequest) Reset() {
+	*x = GetRiskScoreRequest{}
+	mi := &file_proto_shared_data_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRiskScoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRiskScoreRequest) ProtoMessage() {}
+
+func (x *GetRiskScoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_data_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRiskScoreRequest.ProtoReflect.Descriptor instead.
+func (*GetRiskScoreRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetRiskScoreRequest) GetCaseId() string {
+	if x != nil {
+		return x.CaseId
+	}
+	return ""
+}
+
+type RiskScore struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CaseId        string                 `protobuf:"bytes,2,opt,name=case_id,json=caseId,proto3" json:"case_id,omitempty"`
+	CaseVersion   int32                  `protobuf:"varint,3,opt,name=case_version,json=caseVersion,proto3" json:"case_version,omitempty"` // 0 when the case had no stored version
+	Score         float64                `protobuf:"fixed64,4,opt,name=score,proto3" json:"score,omitempty"`                               // 0-100
+	Rating        string                 `protobuf:"bytes,5,opt,name=rating,proto3" json:"rating,omitempty"`                               // e.g. LOW, MEDIUM, HIGH, CRITICAL
+	Actor         string                 `protobuf:"bytes,6,opt,name=actor,proto3" json:"actor,omitempty"`
+	ScoredAt      string                 `protobuf:"bytes,7,opt,name=scored_at,json=scoredAt,proto3" json:"scored_at,omitempty"`
+	Factors       []*RiskFactor          `protobuf:"bytes,8,rep,name=factors,proto3" json:"factors,omitempty"` // Heaviest weight first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RiskScore) Reset() {
+	*x = RiskScore{}
+	mi := &file_proto_shared_data_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RiskScore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RiskScore) ProtoMessage() {}
+
+func (x *RiskScore) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_data_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RiskScore.ProtoReflect.Descriptor instead.
+func (*RiskScore) Descriptor() ([]byte, []int) {
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *RiskScore) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RiskScore) GetCaseId() string {
+	if x != nil {
+		return x.CaseId
+	}
+	return ""
+}
+
+func (x *RiskScore) GetCaseVersion() int32 {
+	if x != nil {
+		return x.CaseVersion
+	}
+	return 0
+}
+
+func (x *RiskScore) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *RiskScore) GetRating() string {
+	if x != nil {
+		return x.Rating
+	}
+	return ""
+}
+
+func (x *RiskScore) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *RiskScore) GetScoredAt() string {
+	if x != nil {
+		return x.ScoredAt
+	}
+	return ""
+}
+
+func (x *RiskScore) GetFactors() []*RiskFactor {
+	if x != nil {
+		return x.Factors
+	}
+	return nil
+}
+
+type RiskFactor struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attribute     string                 `protobuf:"bytes,1,opt,name=attribute,proto3" json:"attribute,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Weight        float64                `protobuf:"fixed64,3,opt,name=weight,proto3" json:"weight,omitempty"`
+	Contribution  float64                `protobuf:"fixed64,4,opt,name=contribution,proto3" json:"contribution,omitempty"`                    // Points of the score; contributions add up to it
+	Source        string                 `protobuf:"bytes,5,opt,name=source,proto3" json:"source,omitempty"`                                  // evaluation, captured or missing
+	EvaluationId  int64                  `protobuf:"varint,6,opt,name=evaluation_id,json=evaluationId,proto3" json:"evaluation_id,omitempty"` // 0 unless source is evaluation
+	Note          string                 `protobuf:"bytes,7,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RiskFactor) Reset() {
+	*x = RiskFactor{}
+	mi := &file_proto_shared_data_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RiskFactor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RiskFactor) ProtoMessage() {}
+
+func (x *RiskFactor) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_data_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RiskFactor.ProtoReflect.Descriptor instead.
+func (*RiskFactor) Descriptor() ([]byte, []int) {
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *RiskFactor) GetAttribute() string {
+	if x != nil {
+		return x.Attribute
+	}
+	return ""
+}
+
+func (x *RiskFactor) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *RiskFactor) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *RiskFactor) GetContribution() float64 {
+	if x != nil {
+		return x.Contribution
+	}
+	return 0
+}
+
+func (x *RiskFactor) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *RiskFactor) GetEvaluationId() int64 {
+	if x != nil {
+		return x.EvaluationId
+	}
+	return 0
+}
+
+func (x *RiskFactor) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+// ----------------------
+// Messages - Document Evidence
+// ----------------------
+type Evidence struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CaseId        string                 `protobuf:"bytes,2,opt,name=case_id,json=caseId,proto3" json:"case_id,omitempty"`
+	DocumentCode  string                 `protobuf:"bytes,3,opt,name=document_code,json=documentCode,proto3" json:"document_code,omitempty"`
+	AttributeCode string                 `protobuf:"bytes,4,opt,name=attribute_code,json=attributeCode,proto3" json:"attribute_code,omitempty"` // Attribute the document evidences, if any
+	FileName      string                 `protobuf:"bytes,5,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	ContentType   string                 `protobuf:"bytes,6,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	SizeBytes     int64                  `protobuf:"varint,7,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	Sha256        string                 `protobuf:"bytes,8,opt,name=sha256,proto3" json:"sha256,omitempty"`                                    // Hex digest of the content
+	StorageDriver string                 `protobuf:"bytes,9,opt,name=storage_driver,json=storageDriver,proto3" json:"storage_driver,omitempty"` // local, s3, minio
+	IssuedAt      string                 `protobuf:"bytes,10,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`               // YYYY-MM-DD
+	ExpiresAt     string                 `protobuf:"bytes,11,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`            // YYYY-MM-DD
+	Description   string                 `protobuf:"bytes,12,opt,name=description,proto3" json:"description,omitempty"`
+	Actor         string                 `protobuf:"bytes,13,opt,name=actor,proto3" json:"actor,omitempty"`
+	UploadedAt    string                 `protobuf:"bytes,14,opt,name=uploaded_at,json=uploadedAt,proto3" json:"uploaded_at,omitempty"`
+	ValidityDays  int32                  `protobuf:"varint,15,opt,name=validity_days,json=validityDays,proto3" json:"validity_days,omitempty"` // Validity applied to derive expires_at, if in days
+	TextStatus    string                 `protobuf:"bytes,16,opt,name=text_status,json=textStatus,proto3" json:"text_status,omitempty"`        // Text extraction: pending, extracting, extracted, unsupported, failed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Evidence) Reset() {
+	*x = Evidence{}
+	mi := &file_proto_shared_data_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Evidence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Evidence) ProtoMessage() {}
+
+func (x *Evidence) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_data_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Evidence.ProtoReflect.Descriptor instead.
+func (*Evidence) Descriptor() ([]byte, []int) {
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *Evidence) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Evidence) GetCaseId() string {
+	if x != nil {
+		return x.CaseId
+	}
+	return ""
+}
+
+func (x *Evidence) GetDocumentCode() string {
+	if x != nil {
+		return x.DocumentCode
+	}
+	return ""
+}
+
+func (x *Evidence) GetAttributeCode() string {
+	if x != nil {
+		return x.AttributeCode
+	}
+	return ""
+}
+
+func (x *Evidence) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *Evidence) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *Evidence) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *Evidence) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *Evidence) GetStorageDriver() string {
+	if x != nil {
+		return x.StorageDriver
+	}
+	return ""
+}
+
+func (x *Evidence) GetIssuedAt() string {
+	if x != nil {
+		return x.IssuedAt
+	}
+	return ""
+}
+
+func (x *Evidence) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *Evidence) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Evidence) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *Evidence) GetUploadedAt() string {
+	if x != nil {
+		return x.UploadedAt
+	}
+	return ""
+}
+
+func (x *Evidence) GetValidityDays() int32 {
+	if x != nil {
+		return x.ValidityDays
+	}
+	return 0
+}
+
+func (x *Evidence) GetTextStatus() string {
+	if x != nil {
+		return x.TextStatus
+	}
+	return ""
+}
+
+// EvidenceUpload is the metadata of an uploaded file
+type EvidenceUpload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CaseId        string                 `protobuf:"bytes,1,opt,name=case_id,json=caseId,proto3" json:"case_id,omitempty"`
+	DocumentCode  string                 `protobuf:"bytes,2,opt,name=document_code,json=documentCode,proto3" json:"document_code,omitempty"`    // Optional: kyc_documents code
+	AttributeCode string                 `protobuf:"bytes,3,opt,name=attribute_code,json=attributeCode,proto3" json:"attribute_code,omitempty"` // Optional
+	FileName      string                 `protobuf:"bytes,4,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	ContentType   string                 `protobuf:"bytes,5,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"` // Optional: sniffed from the content
+	IssuedAt      string                 `protobuf:"bytes,6,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`          // Optional: YYYY-MM-DD
+	ExpiresAt     string                 `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`       // Optional: YYYY-MM-DD, default issued_at plus the validity
+	Description   string                 `protobuf:"bytes,8,opt,name=description,proto3" json:"description,omitempty"`
+	ValidityDays  int32                  `protobuf:"varint,9,opt,name=validity_days,json=validityDays,proto3" json:"validity_days,omitempty"` // Optional: how long the document is accepted, e.g. 90
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvidenceUpload) Reset() {
+	*x = EvidenceUpload{}
+	mi := &file_proto_shared_data_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvidenceUpload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvidenceUpload) ProtoMessage() {}
+
+func (x *EvidenceUpload) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_data_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvidenceUpload.ProtoReflect.Descriptor instead.
+func (*EvidenceUpload) Descriptor() ([]byte, []int) {
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *EvidenceUpload) GetCaseId() string {
+	if x != nil {
+		return x.CaseId
+	}
+	return ""
+}
+
+func (x *EvidenceUpload) GetDocumentCode() string {
+	if x != nil {
+		return x.DocumentCode
+	}
+	return ""
+}
+
+func (x *EvidenceUpload) GetAttributeCode() string {
+	if x != nil {
+		return x.AttributeCode
+	}
+	return ""
+}
+
+func (x *EvidenceUpload) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *EvidenceUpload) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *EvidenceUpload) GetIssuedAt() string {
+	if x != nil {
+		return x.IssuedAt
+	}
+	return ""
+}
+
+func (x *EvidenceUpload) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *EvidenceUpload) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *EvidenceUpload) GetValidityDays() int32 {
+	if x != nil {
+		return x.ValidityDays
+	}
+	return 0
+}
+
+// UploadEvidenceRequest is one message of an upload stream: the first
+// carries the metadata, and each carries the next chunk of content
+type UploadEvidenceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *EvidenceUpload        `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Chunk         []byte                 `protobuf:"bytes,2,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadEvidenceRequest) Reset() {
+	*x = UploadEvidenceRequest{}
+	mi := &file_proto_shared_data_service_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadEvidenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadEvidenceRequest) ProtoMessage() {}
+
+func (x *UploadEvidenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_data_service_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadEvidenceRequest.ProtoReflect.Descriptor instead.
+func (*UploadEvidenceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *UploadEvidenceRequest) GetMetadata() *EvidenceUpload {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *UploadEvidenceRequest) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+type GetEvidenceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEvidenceRequest) Reset() {
+	*x = GetEvidenceRequest{}
+	mi := &file_proto_shared_data_service_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEvidenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEvidenceRequest) ProtoMessage() {}
+
+func (x *GetEvidenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_data_service_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEvidenceRequest.ProtoReflect.Descriptor instead.
+func (*GetEvidenceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetEvidenceRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// EvidenceChunk is one message of a download stream: the first carries the
+// metadata, and each carries the next chunk of content
+type EvidenceChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *Evidence              `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvidenceChunk) Reset() {
+	*x = EvidenceChunk{}
+	mi := &file_proto_shared_data_service_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvidenceChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvidenceChunk) ProtoMessage() {}
+
+func (x *EvidenceChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_data_service_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvidenceChunk.ProtoReflect.Descriptor instead.
+func (*EvidenceChunk) Descriptor() ([]byte, []int) {
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *EvidenceChunk) GetMetadata() *Evidence {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *EvidenceChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ListEvidenceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CaseId        string                 `protobuf:"bytes,1,opt,name=case_id,json=caseId,proto3" json:"case_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEvidenceRequest) Reset() {
+	*x = ListEvidenceRequest{}
+	mi := &file_proto_shared_data_service_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEvidenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEvidenceRequest) ProtoMessage() {}
+
+func (x *ListEvidenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_data_service_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEvidenceRequest.ProtoReflect.Descriptor instead.
+func (*ListEvidenceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ListEvidenceRequest) GetCaseId() string {
+	if x != nil {
+		return x.CaseId
+	}
+	return ""
+}
+
+type EvidenceList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Evidence      []*Evidence            `protobuf:"bytes,1,rep,name=evidence,proto3" json:"evidence,omitempty"` // Newest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvidenceList) Reset() {
+	*x = EvidenceList{}
+	mi := &file_proto_shared_data_service_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvidenceList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvidenceList) ProtoMessage() {}
+
+func (x *EvidenceList) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_data_service_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvidenceList.ProtoReflect.Descriptor instead.
+func (*EvidenceList) Descriptor() ([]byte, []int) {
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *EvidenceList) GetEvidence() []*Evidence {
+	if x != nil {
+		return x.Evidence
+	}
+	return nil
+}
+
+// ----------------------
+// Messages - Case Attribute Values
+// ----------------------
+// CaseAttributeValue is a public attribute value captured for a case
+// version; a version sees the latest value captured at or before it
+type CaseAttributeValue struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CaseId         string                 `protobuf:"bytes,2,opt,name=case_id,json=caseId,proto3" json:"case_id,omitempty"`
+	CaseVersion    int32                  `protobuf:"varint,3,opt,name=case_version,json=caseVersion,proto3" json:"case_version,omitempty"`
+	AttributeCode  string                 `protobuf:"bytes,4,opt,name=attribute_code,json=attributeCode,proto3" json:"attribute_code,omitempty"`
+	Value          string                 `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`                                         // Canonical text of the typed value
+	ValueType      string                 `protobuf:"bytes,6,opt,name=value_type,json=valueType,proto3" json:"value_type,omitempty"`                // string, date, boolean, integer, float, array
+	SourceDocument string                 `protobuf:"bytes,7,opt,name=source_document,json=sourceDocument,proto3" json:"source_document,omitempty"` // kyc_documents code the value was read from
+	EvidenceId     int64                  `protobuf:"varint,8,opt,name=evidence_id,json=evidenceId,proto3" json:"evidence_id,omitempty"`            // 0 when not read from evidence
+	CapturedBy     string                 `protobuf:"bytes,9,opt,name=captured_by,json=capturedBy,proto3" json:"captured_by,omitempty"`
+	CapturedAt     string                 `protobuf:"bytes,10,opt,name=captured_at,json=capturedAt,proto3" json:"captured_at,omitempty"`
+	UpdatedAt      string                 `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CaseAttributeValue) Reset() {
+	*x = CaseAttributeValue{}
+	mi := &file_proto_shared_data_service_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaseAttributeValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaseAttributeValue) ProtoMessage() {}
+
+func (x *CaseAttributeValue) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_data_service_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaseAttributeValue.ProtoReflect.Descriptor instead.
+func (*CaseAttributeValue) Descriptor() ([]byte, []int) {
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *CaseAttributeValue) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CaseAttributeValue) GetCaseId() string {
+	if x != nil {
+		return x.CaseId
+	}
+	return ""
+}
+
+func (x *CaseAttributeValue) GetCaseVersion() int32 {
+	if x != nil {
+		return x.CaseVersion
+	}
+	return 0
+}
+
+func (x *CaseAttributeValue) GetAttributeCode() string {
+	if x != nil {
+		return x.AttributeCode
+	}
+	return ""
+}
+
+func (x *CaseAttributeValue) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *CaseAttributeValue) GetValueType() string {
+	if x != nil {
+		return x.ValueType
+	}
+	return ""
+}
+
+func (x *CaseAttributeValue) GetSourceDocument() string {
+	if x != nil {
+		return x.SourceDocument
+	}
+	return ""
+}
+
+func (x *CaseAttributeValue) GetEvidenceId() int64 {
+	if x != nil {
+		return x.EvidenceId
+	}
+	return 0
+}
+
+func (x *CaseAttributeValue) GetCapturedBy() string {
+	if x != nil {
+		return x.CapturedBy
+	}
+	return ""
+}
+
+func (x *CaseAttributeValue) GetCapturedAt() string {
+	if x != nil {
+		return x.CapturedAt
+	}
+	return ""
+}
+
+func (x *CaseAttributeValue) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type SetCaseAttributeValueRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	CaseId         string                 `protobuf:"bytes,1,opt,name=case_id,json=caseId,proto3" json:"case_id,omitempty"`
+	CaseVersion    int32                  `protobuf:"varint,2,opt,name=case_version,json=caseVersion,proto3" json:"case_version,omitempty"` // 0 = current version
+	AttributeCode  string                 `protobuf:"bytes,3,opt,name=attribute_code,json=attributeCode,proto3" json:"attribute_code,omitempty"`
+	Value          string                 `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`                                         // Validated against the attribute's data type
+	SourceDocument string                 `protobuf:"bytes,5,opt,name=source_document,json=sourceDocument,proto3" json:"source_document,omitempty"` // Optional
+	EvidenceId     int64                  `protobuf:"varint,6,opt,name=evidence_id,json=evidenceId,proto3" json:"evidence_id,omitempty"`            // Optional: evidence of the case
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SetCaseAttributeValueRequest) Reset() {
+	*x = SetCaseAttributeValueRequest{}
+	mi := &file_proto_shared_data_service_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCaseAttributeValueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCaseAttributeValueRequest) ProtoMessage() {}
+
+func (x *SetCaseAttributeValueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_data_service_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCaseAttributeValueRequest.ProtoReflect.Descriptor instead.
+func (*SetCaseAttributeValueRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *SetCaseAttributeValueRequest) GetCaseId() string {
+	if x != nil {
+		return x.CaseId
+	}
+	return ""
+}
+
+func (x *SetCaseAttributeValueRequest) GetCaseVersion() int32 {
+	if x != nil {
+		return x.CaseVersion
+	}
+	return 0
+}
+
+func (x *SetCaseAttributeValueRequest) GetAttributeCode() string {
+	if x != nil {
+		return x.AttributeCode
+	}
+	return ""
+}
+
+func (x *SetCaseAttributeValueRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *SetCaseAttributeValueRequest) GetSourceDocument() string {
+	if x != nil {
+		return x.SourceDocument
+	}
+	return ""
+}
+
+func (x *SetCaseAttributeValueRequest) GetEvidenceId() int64 {
+	if x != nil {
+		return x.EvidenceId
+	}
+	return 0
+}
+
+type ListCaseAttributeValuesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CaseId        string                 `protobuf:"bytes,1,opt,name=case_id,json=caseId,proto3" json:"case_id,omitempty"`
+	CaseVersion   int32                  `protobuf:"varint,2,opt,name=case_version,json=caseVersion,proto3" json:"case_version,omitempty"` // 0 = current version
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCaseAttributeValuesRequest) Reset() {
+	*x = ListCaseAttributeValuesRequest{}
+	mi := &file_proto_shared_data_service_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCaseAttributeValuesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCaseAttributeValuesRequest) ProtoMessage() {}
+
+func (x *ListCaseAttributeValuesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_data_service_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCaseAttributeValuesRequest.ProtoReflect.Descriptor instead.
+func (*ListCaseAttributeValuesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListCaseAttributeValuesRequest) GetCaseId() string {
+	if x != nil {
+		return x.CaseId
+	}
+	return ""
+}
+
+func (x *ListCaseAttributeValuesRequest) GetCaseVersion() int32 {
+	if x != nil {
+		return x.CaseVersion
+	}
+	return 0
+}
+
+type CaseAttributeValueList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []*CaseAttributeValue  `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"` // By attribute code
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CaseAttributeValueList) Reset() {
+	*x = CaseAttributeValueList{}
+	mi := &file_proto_shared_data_service_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaseAttributeValueList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaseAttributeValueList) ProtoMessage() {}
+
+func (x *CaseAttributeValueList) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_data_service_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaseAttributeValueList.ProtoReflect.Descriptor instead.
+func (*CaseAttributeValueList) Descriptor() ([]byte, []int) {
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *CaseAttributeValueList) GetValues() []*CaseAttributeValue {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type DeleteCaseAttributeValueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CaseId        string                 `protobuf:"bytes,1,opt,name=case_id,json=caseId,proto3" json:"case_id,omitempty"`
+	CaseVersion   int32                  `protobuf:"varint,2,opt,name=case_version,json=caseVersion,proto3" json:"case_version,omitempty"` // 0 = current version
+	AttributeCode string                 `protobuf:"bytes,3,opt,name=attribute_code,json=attributeCode,proto3" json:"attribute_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCaseAttributeValueRequest) Reset() {
+	*x = DeleteCaseAttributeValueRequest{}
+	mi := &file_proto_shared_data_service_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCaseAttributeValueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCaseAttributeValueRequest) ProtoMessage() {}
+
+func (x *DeleteCaseAttributeValueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_data_service_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCaseAttributeValueRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCaseAttributeValueRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *DeleteCaseAttributeValueRequest) GetCaseId() string {
+	if x != nil {
+		return x.CaseId
+	}
+	return ""
+}
+
+func (x *DeleteCaseAttributeValueRequest) GetCaseVersion() int32 {
+	if x != nil {
+		return x.CaseVersion
+	}
+	return 0
+}
+
+func (x *DeleteCaseAttributeValueRequest) GetAttributeCode() string {
+	if x != nil {
+		return x.AttributeCode
+	}
+	return ""
+}
+
+type DeleteCaseAttributeValueResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       bool                   `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCaseAttributeValueResponse) Reset() {
+	*x = DeleteCaseAttributeValueResponse{}
+	mi := &file_proto_shared_data_service_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCaseAttributeValueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCaseAttributeValueResponse) ProtoMessage() {}
+
+func (x *DeleteCaseAttributeValueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_data_service_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCaseAttributeValueResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCaseAttributeValueResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *DeleteCaseAttributeValueResponse) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+// ----------------------
+// Messages - Regions
+// ----------------------
+type ResolveEndpointsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RegionHint    string                 `protobuf:"bytes,1,opt,name=region_hint,json=regionHint,proto3" json:"region_hint,omitempty"` // e.g. "eu", "apac"; empty = server's own region
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveEndpointsRequest) Reset() {
+	*x = ResolveEndpointsRequest{}
+	mi := &file_proto_shared_data_service_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveEndpointsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveEndpointsRequest) ProtoMessage() {}
+
+func (x *ResolveEndpointsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_data_service_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveEndpointsRequest.ProtoReflect.Descriptor instead.
+func (*ResolveEndpointsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ResolveEndpointsRequest) GetRegionHint() string {
+	if x != nil {
+		return x.RegionHint
+	}
+	return ""
+}
+
+type RegionEndpoints struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Region        string                 `protobuf:"bytes,1,opt,name=region,proto3" json:"region,omitempty"`                              // region serving reads for this client
+	ReadAddress   string                 `protobuf:"bytes,2,opt,name=read_address,json=readAddress,proto3" json:"read_address,omitempty"` // nearest read endpoint
+	PrimaryRegion string                 `protobuf:"bytes,3,opt,name=primary_region,json=primaryRegion,proto3" json:"primary_region,omitempty"`
+	WriteAddress  string                 `protobuf:"bytes,4,opt,name=write_address,json=writeAddress,proto3" json:"write_address,omitempty"` // primary region endpoint for writes
+	ServerRegion  string                 `protobuf:"bytes,5,opt,name=server_region,json=serverRegion,proto3" json:"server_region,omitempty"` // region of the server that answered
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegionEndpoints) Reset() {
+	*x = RegionEndpoints{}
+	mi := &file_proto_shared_data_service_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegionEndpoints) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegionEndpoints) ProtoMessage() {}
+
+func (x *RegionEndpoints) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_data_service_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegionEndpoints.ProtoReflect.Descriptor instead.
+func (*RegionEndpoints) Descriptor() ([]byte, []int) {
+	return file_proto_shared_data_service_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *RegionEndpoints) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *RegionEndpoints) GetReadAddress() string {
+	if x != nil {
+		return x.ReadAddress
+	}
+	return ""
+}
+
+func (x *RegionEndpoints) GetPrimaryRegion() string {
+	if x != nil {
+		return x.PrimaryRegion
+	}
+	return ""
+}
+
+func (x *RegionEndpoints) GetWriteAddress() string {
+	if x != nil {
+		return x.WriteAddress
+	}
+	return ""
+}
+
+func (x *RegionEndpoints) GetServerRegion() string {
+	if x != nil {
+		return x.ServerRegion
+	}
+	return ""
+}
+
+var File_proto_shared_data_service_proto protoreflect.FileDescriptor
+
+const file_proto_shared_data_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1fproto_shared/data_service.proto\x12\bkyc.data\"\xe8\x01\n" +
+	"\tAttribute\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1b\n" +
+	"\tattr_type\x18\x04 \x01(\tR\battrType\x12\"\n" +
+	"\fjurisdiction\x18\x05 \x01(\tR\fjurisdiction\x12\x1e\n" +
+	"\n" +
+	"regulation\x18\x06 \x01(\tR\n" +
+	"regulation\x124\n" +
+	"\n" +
+	"provenance\x18\a \x01(\v2\x14.kyc.data.ProvenanceR\n" +
+	"provenance\"\xa0\x01\n" +
+	"\n" +
+	"Provenance\x12!\n" +
+	"\fsource_table\x18\x01 \x01(\tR\vsourceTable\x12#\n" +
+	"\rlast_modified\x18\x02 \x01(\tR\flastModified\x12\x1f\n" +
+	"\vmodified_by\x18\x03 \x01(\tR\n" +
+	"modifiedBy\x12)\n" +
+	"\x10ontology_version\x18\x04 \x01(\tR\x0fontologyVersion\"T\n" +
+	"\x13GetAttributeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12-\n" +
+	"\x12include_provenance\x18\x02 \x01(\bR\x11includeProvenance\"\x8c\x01\n" +
+	"\x15ListAttributesRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\x12-\n" +
+	"\x12include_provenance\x18\x04 \x01(\bR\x11includeProvenance\"\x86\x01\n" +
+	"\rAttributeList\x123\n" +
+	"\n" +
+	"attributes\x18\x01 \x03(\v2\x13.kyc.data.AttributeR\n" +
+	"attributes\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x05R\n" +
+	"totalCount\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\"\xda\x01\n" +
+	"\bDocument\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\"\n" +
+	"\fjurisdiction\x18\x03 \x01(\tR\fjurisdiction\x12\x1a\n" +
+	"\bcategory\x18\x04 \x01(\tR\bcategory\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x12\x10\n" +
+	"\x03url\x18\x06 \x01(\tR\x03url\x124\n" +
+	"\n" +
+	"provenance\x18\a \x01(\v2\x14.kyc.data.ProvenanceR\n" +
+	"provenance\"S\n" +
+	"\x12GetDocumentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12-\n" +
+	"\x12include_provenance\x18\x02 \x01(\bR\x11includeProvenance\"\xaf\x01\n" +
+	"\x14ListDocumentsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\"\n" +
+	"\fjurisdiction\x18\x03 \x01(\tR\fjurisdiction\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\x12-\n" +
+	"\x12include_provenance\x18\x05 \x01(\bR\x11includeProvenance\"\x82\x01\n" +
+	"\fDocumentList\x120\n" +
+	"\tdocuments\x18\x01 \x03(\v2\x12.kyc.data.DocumentR\tdocuments\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x05R\n" +
+	"totalCount\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\"\xb1\x01\n" +
+	"\vCaseVersion\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\acase_id\x18\x02 \x01(\tR\x06caseId\x12\x1d\n" +
 	"\n" +
 	"dsl_source\x18\x03 \x01(\tR\tdslSource\x12#\n" +
 	"\rcompiled_json\x18\x04 \x01(\tR\fcompiledJson\x12\x16\n" +
@@ -1125,26 +3623,254 @@ const file_proto_shared_data_service_proto_rawDesc = "" +
 	"\x13ListAllCasesRequest\x12\x14\n" +
 	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
 	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12#\n" +
-	"\rstatus_filter\x18\x03 \x01(\tR\fstatusFilter\"\x86\x01\n" +
+	"\rstatus_filter\x18\x03 \x01(\tR\fstatusFilter\"\xcb\x01\n" +
 	"\vCaseSummary\x12\x17\n" +
 	"\acase_id\x18\x01 \x01(\tR\x06caseId\x12#\n" +
 	"\rversion_count\x18\x02 \x01(\x05R\fversionCount\x12\x16\n" +
 	"\x06status\x18\x03 \x01(\tR\x06status\x12!\n" +
-	"\flast_updated\x18\x04 \x01(\tR\vlastUpdated\"X\n" +
+	"\flast_updated\x18\x04 \x01(\tR\vlastUpdated\x12\x1b\n" +
+	"\tlocked_by\x18\x05 \x01(\tR\blockedBy\x12&\n" +
+	"\x0flock_expires_at\x18\x06 \x01(\tR\rlockExpiresAt\"X\n" +
 	"\bCaseList\x12+\n" +
 	"\x05cases\x18\x01 \x03(\v2\x15.kyc.data.CaseSummaryR\x05cases\x12\x1f\n" +
 	"\vtotal_count\x18\x02 \x01(\x05R\n" +
-	"totalCount2\xad\x02\n" +
+	"totalCount\"\x86\x01\n" +
+	"\x18GenerateNarrativeRequest\x12\x17\n" +
+	"\acase_id\x18\x01 \x01(\tR\x06caseId\x12\x1d\n" +
+	"\n" +
+	"version_id\x18\x02 \x01(\tR\tversionId\x12\x1a\n" +
+	"\btemplate\x18\x03 \x01(\tR\btemplate\x12\x16\n" +
+	"\x06polish\x18\x04 \x01(\bR\x06polish\"\x9b\x02\n" +
+	"\rCaseNarrative\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\acase_id\x18\x02 \x01(\tR\x06caseId\x12\x1d\n" +
+	"\n" +
+	"version_id\x18\x03 \x01(\tR\tversionId\x12\x1a\n" +
+	"\btemplate\x18\x04 \x01(\tR\btemplate\x12\x1c\n" +
+	"\tnarrative\x18\x05 \x01(\tR\tnarrative\x12\x14\n" +
+	"\x05draft\x18\x06 \x01(\tR\x05draft\x12\x1a\n" +
+	"\bpolished\x18\a \x01(\bR\bpolished\x12\x14\n" +
+	"\x05model\x18\b \x01(\tR\x05model\x12!\n" +
+	"\fpolish_error\x18\t \x01(\tR\vpolishError\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\tR\tcreatedAt\"t\n" +
+	"\x19GenerateReviewPackRequest\x12\x17\n" +
+	"\acase_id\x18\x01 \x01(\tR\x06caseId\x12\x1d\n" +
+	"\n" +
+	"version_id\x18\x02 \x01(\tR\tversionId\x12\x1f\n" +
+	"\vallow_draft\x18\x03 \x01(\bR\n" +
+	"allowDraft\"\xf8\x01\n" +
+	"\n" +
+	"ReviewPack\x12\x17\n" +
+	"\acase_id\x18\x01 \x01(\tR\x06caseId\x12\x1d\n" +
+	"\n" +
+	"version_id\x18\x02 \x01(\tR\tversionId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12!\n" +
+	"\fversion_hash\x18\x04 \x01(\tR\vversionHash\x12\x1a\n" +
+	"\bfilename\x18\x05 \x01(\tR\bfilename\x12\x10\n" +
+	"\x03pdf\x18\x06 \x01(\fR\x03pdf\x12\x14\n" +
+	"\x05pages\x18\a \x01(\x05R\x05pages\x12\x14\n" +
+	"\x05draft\x18\b \x01(\bR\x05draft\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\tR\tcreatedAt\"{\n" +
+	"\x15TransitionCaseRequest\x12\x17\n" +
+	"\acase_id\x18\x01 \x01(\tR\x06caseId\x12\x1b\n" +
+	"\tto_status\x18\x02 \x01(\tR\btoStatus\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"\xc4\x01\n" +
+	"\x0eCaseTransition\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x17\n" +
+	"\acase_id\x18\x02 \x01(\tR\x06caseId\x12\x1f\n" +
+	"\vfrom_status\x18\x03 \x01(\tR\n" +
+	"fromStatus\x12\x1b\n" +
+	"\tto_status\x18\x04 \x01(\tR\btoStatus\x12\x14\n" +
+	"\x05actor\x18\x05 \x01(\tR\x05actor\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\"1\n" +
+	"\x16GetCaseTimelineRequest\x12\x17\n" +
+	"\acase_id\x18\x01 \x01(\tR\x06caseId\"\xac\x01\n" +
+	"\fCaseTimeline\x12\x17\n" +
+	"\acase_id\x18\x01 \x01(\tR\x06caseId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12/\n" +
+	"\x13allowed_transitions\x18\x03 \x03(\tR\x12allowedTransitions\x12:\n" +
+	"\vtransitions\x18\x04 \x03(\v2\x18.kyc.data.CaseTransitionR\vtransitions\"I\n" +
+	"\x18GetLineageHistoryRequest\x12\x17\n" +
+	"\acase_id\x18\x01 \x01(\tR\x06caseId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"S\n" +
+	"\x0eLineageHistory\x12\x17\n" +
+	"\acase_id\x18\x01 \x01(\tR\x06caseId\x12(\n" +
+	"\x04runs\x18\x02 \x03(\v2\x14.kyc.data.LineageRunR\x04runs\"\xb6\x02\n" +
+	"\n" +
+	"LineageRun\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\acase_id\x18\x02 \x01(\tR\x06caseId\x12!\n" +
+	"\fcase_version\x18\x03 \x01(\x05R\vcaseVersion\x12\x1f\n" +
+	"\vinputs_hash\x18\x04 \x01(\tR\n" +
+	"inputsHash\x12\x16\n" +
+	"\x06source\x18\x05 \x01(\tR\x06source\x12\x14\n" +
+	"\x05actor\x18\x06 \x01(\tR\x05actor\x12 \n" +
+	"\vevaluations\x18\a \x01(\x05R\vevaluations\x12\x16\n" +
+	"\x06failed\x18\b \x01(\x05R\x06failed\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\t \x01(\tR\tstartedAt\x124\n" +
+	"\aresults\x18\n" +
+	" \x03(\v2\x1a.kyc.data.DerivationResultR\aresults\"\xe4\x02\n" +
+	"\x10DerivationResult\x12#\n" +
+	"\revaluation_id\x18\x01 \x01(\x03R\fevaluationId\x12!\n" +
+	"\fderived_code\x18\x02 \x01(\tR\vderivedCode\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\x12\x1d\n" +
+	"\n" +
+	"value_type\x18\x04 \x01(\tR\tvalueType\x12\x18\n" +
+	"\asuccess\x18\x05 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\x12\x12\n" +
+	"\x04rule\x18\a \x01(\tR\x04rule\x12\x1f\n" +
+	"\vinputs_json\x18\b \x01(\tR\n" +
+	"inputsJson\x12\"\n" +
+	"\fjurisdiction\x18\t \x01(\tR\fjurisdiction\x12'\n" +
+	"\x0fregulation_code\x18\n" +
+	" \x01(\tR\x0eregulationCode\x12!\n" +
+	"\fevaluated_at\x18\v \x01(\tR\vevaluatedAt\"+\n" +
+	"\x10ScoreCaseRequest\x12\x17\n" +
+	"\acase_id\x18\x01 \x01(\tR\x06caseId\".\n" +
+	"\x13GetRiskScoreRequest\x12\x17\n" +
+	"\acase_id\x18\x01 \x01(\tR\x06caseId\"\xe8\x01\n" +
+	"\tRiskScore\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\acase_id\x18\x02 \x01(\tR\x06caseId\x12!\n" +
+	"\fcase_version\x18\x03 \x01(\x05R\vcaseVersion\x12\x14\n" +
+	"\x05score\x18\x04 \x01(\x01R\x05score\x12\x16\n" +
+	"\x06rating\x18\x05 \x01(\tR\x06rating\x12\x14\n" +
+	"\x05actor\x18\x06 \x01(\tR\x05actor\x12\x1b\n" +
+	"\tscored_at\x18\a \x01(\tR\bscoredAt\x12.\n" +
+	"\afactors\x18\b \x03(\v2\x14.kyc.data.RiskFactorR\afactors\"\xcd\x01\n" +
+	"\n" +
+	"RiskFactor\x12\x1c\n" +
+	"\tattribute\x18\x01 \x01(\tR\tattribute\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12\x16\n" +
+	"\x06weight\x18\x03 \x01(\x01R\x06weight\x12\"\n" +
+	"\fcontribution\x18\x04 \x01(\x01R\fcontribution\x12\x16\n" +
+	"\x06source\x18\x05 \x01(\tR\x06source\x12#\n" +
+	"\revaluation_id\x18\x06 \x01(\x03R\fevaluationId\x12\x12\n" +
+	"\x04note\x18\a \x01(\tR\x04note\"\xf8\x03\n" +
+	"\bEvidence\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\acase_id\x18\x02 \x01(\tR\x06caseId\x12#\n" +
+	"\rdocument_code\x18\x03 \x01(\tR\fdocumentCode\x12%\n" +
+	"\x0eattribute_code\x18\x04 \x01(\tR\rattributeCode\x12\x1b\n" +
+	"\tfile_name\x18\x05 \x01(\tR\bfileName\x12!\n" +
+	"\fcontent_type\x18\x06 \x01(\tR\vcontentType\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\a \x01(\x03R\tsizeBytes\x12\x16\n" +
+	"\x06sha256\x18\b \x01(\tR\x06sha256\x12%\n" +
+	"\x0estorage_driver\x18\t \x01(\tR\rstorageDriver\x12\x1b\n" +
+	"\tissued_at\x18\n" +
+	" \x01(\tR\bissuedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\v \x01(\tR\texpiresAt\x12 \n" +
+	"\vdescription\x18\f \x01(\tR\vdescription\x12\x14\n" +
+	"\x05actor\x18\r \x01(\tR\x05actor\x12\x1f\n" +
+	"\vuploaded_at\x18\x0e \x01(\tR\n" +
+	"uploadedAt\x12#\n" +
+	"\rvalidity_days\x18\x0f \x01(\x05R\fvalidityDays\x12\x1f\n" +
+	"\vtext_status\x18\x10 \x01(\tR\n" +
+	"textStatus\"\xb8\x02\n" +
+	"\x0eEvidenceUpload\x12\x17\n" +
+	"\acase_id\x18\x01 \x01(\tR\x06caseId\x12#\n" +
+	"\rdocument_code\x18\x02 \x01(\tR\fdocumentCode\x12%\n" +
+	"\x0eattribute_code\x18\x03 \x01(\tR\rattributeCode\x12\x1b\n" +
+	"\tfile_name\x18\x04 \x01(\tR\bfileName\x12!\n" +
+	"\fcontent_type\x18\x05 \x01(\tR\vcontentType\x12\x1b\n" +
+	"\tissued_at\x18\x06 \x01(\tR\bissuedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\tR\texpiresAt\x12 \n" +
+	"\vdescription\x18\b \x01(\tR\vdescription\x12#\n" +
+	"\rvalidity_days\x18\t \x01(\x05R\fvalidityDays\"c\n" +
+	"\x15UploadEvidenceRequest\x124\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x18.kyc.data.EvidenceUploadR\bmetadata\x12\x14\n" +
+	"\x05chunk\x18\x02 \x01(\fR\x05chunk\"$\n" +
+	"\x12GetEvidenceRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"S\n" +
+	"\rEvidenceChunk\x12.\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x12.kyc.data.EvidenceR\bmetadata\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\".\n" +
+	"\x13ListEvidenceRequest\x12\x17\n" +
+	"\acase_id\x18\x01 \x01(\tR\x06caseId\">\n" +
+	"\fEvidenceList\x12.\n" +
+	"\bevidence\x18\x01 \x03(\v2\x12.kyc.data.EvidenceR\bevidence\"\xe7\x02\n" +
+	"\x12CaseAttributeValue\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\acase_id\x18\x02 \x01(\tR\x06caseId\x12!\n" +
+	"\fcase_version\x18\x03 \x01(\x05R\vcaseVersion\x12%\n" +
+	"\x0eattribute_code\x18\x04 \x01(\tR\rattributeCode\x12\x14\n" +
+	"\x05value\x18\x05 \x01(\tR\x05value\x12\x1d\n" +
+	"\n" +
+	"value_type\x18\x06 \x01(\tR\tvalueType\x12'\n" +
+	"\x0fsource_document\x18\a \x01(\tR\x0esourceDocument\x12\x1f\n" +
+	"\vevidence_id\x18\b \x01(\x03R\n" +
+	"evidenceId\x12\x1f\n" +
+	"\vcaptured_by\x18\t \x01(\tR\n" +
+	"capturedBy\x12\x1f\n" +
+	"\vcaptured_at\x18\n" +
+	" \x01(\tR\n" +
+	"capturedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\v \x01(\tR\tupdatedAt\"\xe1\x01\n" +
+	"\x1cSetCaseAttributeValueRequest\x12\x17\n" +
+	"\acase_id\x18\x01 \x01(\tR\x06caseId\x12!\n" +
+	"\fcase_version\x18\x02 \x01(\x05R\vcaseVersion\x12%\n" +
+	"\x0eattribute_code\x18\x03 \x01(\tR\rattributeCode\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\tR\x05value\x12'\n" +
+	"\x0fsource_document\x18\x05 \x01(\tR\x0esourceDocument\x12\x1f\n" +
+	"\vevidence_id\x18\x06 \x01(\x03R\n" +
+	"evidenceId\"\\\n" +
+	"\x1eListCaseAttributeValuesRequest\x12\x17\n" +
+	"\acase_id\x18\x01 \x01(\tR\x06caseId\x12!\n" +
+	"\fcase_version\x18\x02 \x01(\x05R\vcaseVersion\"N\n" +
+	"\x16CaseAttributeValueList\x124\n" +
+	"\x06values\x18\x01 \x03(\v2\x1c.kyc.data.CaseAttributeValueR\x06values\"\x84\x01\n" +
+	"\x1fDeleteCaseAttributeValueRequest\x12\x17\n" +
+	"\acase_id\x18\x01 \x01(\tR\x06caseId\x12!\n" +
+	"\fcase_version\x18\x02 \x01(\x05R\vcaseVersion\x12%\n" +
+	"\x0eattribute_code\x18\x03 \x01(\tR\rattributeCode\"<\n" +
+	" DeleteCaseAttributeValueResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\bR\adeleted\":\n" +
+	"\x17ResolveEndpointsRequest\x12\x1f\n" +
+	"\vregion_hint\x18\x01 \x01(\tR\n" +
+	"regionHint\"\xbd\x01\n" +
+	"\x0fRegionEndpoints\x12\x16\n" +
+	"\x06region\x18\x01 \x01(\tR\x06region\x12!\n" +
+	"\fread_address\x18\x02 \x01(\tR\vreadAddress\x12%\n" +
+	"\x0eprimary_region\x18\x03 \x01(\tR\rprimaryRegion\x12#\n" +
+	"\rwrite_address\x18\x04 \x01(\tR\fwriteAddress\x12#\n" +
+	"\rserver_region\x18\x05 \x01(\tR\fserverRegion2\xad\x02\n" +
 	"\x11DictionaryService\x12B\n" +
 	"\fGetAttribute\x12\x1d.kyc.data.GetAttributeRequest\x1a\x13.kyc.data.Attribute\x12J\n" +
 	"\x0eListAttributes\x12\x1f.kyc.data.ListAttributesRequest\x1a\x17.kyc.data.AttributeList\x12?\n" +
 	"\vGetDocument\x12\x1c.kyc.data.GetDocumentRequest\x1a\x12.kyc.data.Document\x12G\n" +
-	"\rListDocuments\x12\x1e.kyc.data.ListDocumentsRequest\x1a\x16.kyc.data.DocumentList2\xb5\x02\n" +
+	"\rListDocuments\x12\x1e.kyc.data.ListDocumentsRequest\x1a\x16.kyc.data.DocumentList2\xe1\n" +
+	"\n" +
 	"\vCaseService\x12N\n" +
 	"\x0fSaveCaseVersion\x12\x1c.kyc.data.CaseVersionRequest\x1a\x1d.kyc.data.CaseVersionResponse\x12A\n" +
 	"\x0eGetCaseVersion\x12\x18.kyc.data.GetCaseRequest\x1a\x15.kyc.data.CaseVersion\x12P\n" +
 	"\x10ListCaseVersions\x12!.kyc.data.ListCaseVersionsRequest\x1a\x19.kyc.data.CaseVersionList\x12A\n" +
-	"\fListAllCases\x12\x1d.kyc.data.ListAllCasesRequest\x1a\x12.kyc.data.CaseListB/P\x01Z+github.com/adamtc007/KYC-DSL/api/pb/kycdatab\x06proto3"
+	"\fListAllCases\x12\x1d.kyc.data.ListAllCasesRequest\x1a\x12.kyc.data.CaseList\x12T\n" +
+	"\x15GenerateCaseNarrative\x12\".kyc.data.GenerateNarrativeRequest\x1a\x17.kyc.data.CaseNarrative\x12O\n" +
+	"\x12GenerateReviewPack\x12#.kyc.data.GenerateReviewPackRequest\x1a\x14.kyc.data.ReviewPack\x12K\n" +
+	"\x0eTransitionCase\x12\x1f.kyc.data.TransitionCaseRequest\x1a\x18.kyc.data.CaseTransition\x12K\n" +
+	"\x0fGetCaseTimeline\x12 .kyc.data.GetCaseTimelineRequest\x1a\x16.kyc.data.CaseTimeline\x12Q\n" +
+	"\x11GetLineageHistory\x12\".kyc.data.GetLineageHistoryRequest\x1a\x18.kyc.data.LineageHistory\x12<\n" +
+	"\tScoreCase\x12\x1a.kyc.data.ScoreCaseRequest\x1a\x13.kyc.data.RiskScore\x12B\n" +
+	"\fGetRiskScore\x12\x1d.kyc.data.GetRiskScoreRequest\x1a\x13.kyc.data.RiskScore\x12G\n" +
+	"\x0eUploadEvidence\x12\x1f.kyc.data.UploadEvidenceRequest\x1a\x12.kyc.data.Evidence(\x01\x12K\n" +
+	"\x10DownloadEvidence\x12\x1c.kyc.data.GetEvidenceRequest\x1a\x17.kyc.data.EvidenceChunk0\x01\x12E\n" +
+	"\fListEvidence\x12\x1d.kyc.data.ListEvidenceRequest\x1a\x16.kyc.data.EvidenceList\x12]\n" +
+	"\x15SetCaseAttributeValue\x12&.kyc.data.SetCaseAttributeValueRequest\x1a\x1c.kyc.data.CaseAttributeValue\x12e\n" +
+	"\x17ListCaseAttributeValues\x12(.kyc.data.ListCaseAttributeValuesRequest\x1a .kyc.data.CaseAttributeValueList\x12q\n" +
+	"\x18DeleteCaseAttributeValue\x12).kyc.data.DeleteCaseAttributeValueRequest\x1a*.kyc.data.DeleteCaseAttributeValueResponse2a\n" +
+	"\rRegionService\x12P\n" +
+	"\x10ResolveEndpoints\x12!.kyc.data.ResolveEndpointsRequest\x1a\x19.kyc.data.RegionEndpointsB/P\x01Z+github.com/adamtc007/KYC-DSL/api/pb/kycdatab\x06proto3"
 
 var (
 	file_proto_shared_data_service_proto_rawDescOnce sync.Once
@@ -1158,52 +3884,122 @@ func file_proto_shared_data_service_proto_rawDescGZIP() []byte {
 	return file_proto_shared_data_service_proto_rawDescData
 }
 
-var file_proto_shared_data_service_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_proto_shared_data_service_proto_msgTypes = make([]protoimpl.MessageInfo, 49)
 var file_proto_shared_data_service_proto_goTypes = []any{
-	(*Attribute)(nil),               // 0: kyc.data.Attribute
-	(*GetAttributeRequest)(nil),     // 1: kyc.data.GetAttributeRequest
-	(*ListAttributesRequest)(nil),   // 2: kyc.data.ListAttributesRequest
-	(*AttributeList)(nil),           // 3: kyc.data.AttributeList
-	(*Document)(nil),                // 4: kyc.data.Document
-	(*GetDocumentRequest)(nil),      // 5: kyc.data.GetDocumentRequest
-	(*ListDocumentsRequest)(nil),    // 6: kyc.data.ListDocumentsRequest
-	(*DocumentList)(nil),            // 7: kyc.data.DocumentList
-	(*CaseVersion)(nil),             // 8: kyc.data.CaseVersion
-	(*CaseVersionRequest)(nil),      // 9: kyc.data.CaseVersionRequest
-	(*CaseVersionResponse)(nil),     // 10: kyc.data.CaseVersionResponse
-	(*GetCaseRequest)(nil),          // 11: kyc.data.GetCaseRequest
-	(*ListCaseVersionsRequest)(nil), // 12: kyc.data.ListCaseVersionsRequest
-	(*CaseVersionList)(nil),         // 13: kyc.data.CaseVersionList
-	(*ListAllCasesRequest)(nil),     // 14: kyc.data.ListAllCasesRequest
-	(*CaseSummary)(nil),             // 15: kyc.data.CaseSummary
-	(*CaseList)(nil),                // 16: kyc.data.CaseList
+	(*Attribute)(nil),                        // 0: kyc.data.Attribute
+	(*Provenance)(nil),                       // 1: kyc.data.Provenance
+	(*GetAttributeRequest)(nil),              // 2: kyc.data.GetAttributeRequest
+	(*ListAttributesRequest)(nil),            // 3: kyc.data.ListAttributesRequest
+	(*AttributeList)(nil),                    // 4: kyc.data.AttributeList
+	(*Document)(nil),                         // 5: kyc.data.Document
+	(*GetDocumentRequest)(nil),               // 6: kyc.data.GetDocumentRequest
+	(*ListDocumentsRequest)(nil),             // 7: kyc.data.ListDocumentsRequest
+	(*DocumentList)(nil),                     // 8: kyc.data.DocumentList
+	(*CaseVersion)(nil),                      // 9: kyc.data.CaseVersion
+	(*CaseVersionRequest)(nil),               // 10: kyc.data.CaseVersionRequest
+	(*CaseVersionResponse)(nil),              // 11: kyc.data.CaseVersionResponse
+	(*GetCaseRequest)(nil),                   // 12: kyc.data.GetCaseRequest
+	(*ListCaseVersionsRequest)(nil),          // 13: kyc.data.ListCaseVersionsRequest
+	(*CaseVersionList)(nil),                  // 14: kyc.data.CaseVersionList
+	(*ListAllCasesRequest)(nil),              // 15: kyc.data.ListAllCasesRequest
+	(*CaseSummary)(nil),                      // 16: kyc.data.CaseSummary
+	(*CaseList)(nil),                         // 17: kyc.data.CaseList
+	(*GenerateNarrativeRequest)(nil),         // 18: kyc.data.GenerateNarrativeRequest
+	(*CaseNarrative)(nil),                    // 19: kyc.data.CaseNarrative
+	(*GenerateReviewPackRequest)(nil),        // 20: kyc.data.GenerateReviewPackRequest
+	(*ReviewPack)(nil),                       // 21: kyc.data.ReviewPack
+	(*TransitionCaseRequest)(nil),            // 22: kyc.data.TransitionCaseRequest
+	(*CaseTransition)(nil),                   // 23: kyc.data.CaseTransition
+	(*GetCaseTimelineRequest)(nil),           // 24: kyc.data.GetCaseTimelineRequest
+	(*CaseTimeline)(nil),                     // 25: kyc.data.CaseTimeline
+	(*GetLineageHistoryRequest)(nil),         // 26: kyc.data.GetLineageHistoryRequest
+	(*LineageHistory)(nil),                   // 27: kyc.data.LineageHistory
+	(*LineageRun)(nil),                       // 28: kyc.data.LineageRun
+	(*DerivationResult)(nil),                 // 29: kyc.data.DerivationResult
+	(*ScoreCaseRequest)(nil),                 // 30: kyc.data.ScoreCaseRequest
+	(*GetRiskScoreRequest)(nil),              // 31: kyc.data.GetRiskScoreRequest
+	(*RiskScore)(nil),                        // 32: kyc.data.RiskScore
+	(*RiskFactor)(nil),                       // 33: kyc.data.RiskFactor
+	(*Evidence)(nil),                         // 34: kyc.data.Evidence
+	(*EvidenceUpload)(nil),                   // 35: kyc.data.EvidenceUpload
+	(*UploadEvidenceRequest)(nil),            // 36: kyc.data.UploadEvidenceRequest
+	(*GetEvidenceRequest)(nil),               // 37: kyc.data.GetEvidenceRequest
+	(*EvidenceChunk)(nil),                    // 38: kyc.data.EvidenceChunk
+	(*ListEvidenceRequest)(nil),              // 39: kyc.data.ListEvidenceRequest
+	(*EvidenceList)(nil),                     // 40: kyc.data.EvidenceList
+	(*CaseAttributeValue)(nil),               // 41: kyc.data.CaseAttributeValue
+	(*SetCaseAttributeValueRequest)(nil),     // 42: kyc.data.SetCaseAttributeValueRequest
+	(*ListCaseAttributeValuesRequest)(nil),   // 43: kyc.data.ListCaseAttributeValuesRequest
+	(*CaseAttributeValueList)(nil),           // 44: kyc.data.CaseAttributeValueList
+	(*DeleteCaseAttributeValueRequest)(nil),  // 45: kyc.data.DeleteCaseAttributeValueRequest
+	(*DeleteCaseAttributeValueResponse)(nil), // 46: kyc.data.DeleteCaseAttributeValueResponse
+	(*ResolveEndpointsRequest)(nil),          // 47: kyc.data.ResolveEndpointsRequest
+	(*RegionEndpoints)(nil),                  // 48: kyc.data.RegionEndpoints
 }
 var file_proto_shared_data_service_proto_depIdxs = []int32{
-	0,  // 0: kyc.data.AttributeList.attributes:type_name -> kyc.data.Attribute
-	4,  // 1: kyc.data.DocumentList.documents:type_name -> kyc.data.Document
-	8,  // 2: kyc.data.CaseVersionList.versions:type_name -> kyc.data.CaseVersion
-	15, // 3: kyc.data.CaseList.cases:type_name -> kyc.data.CaseSummary
-	1,  // 4: kyc.data.DictionaryService.GetAttribute:input_type -> kyc.data.GetAttributeRequest
-	2,  // 5: kyc.data.DictionaryService.ListAttributes:input_type -> kyc.data.ListAttributesRequest
-	5,  // 6: kyc.data.DictionaryService.GetDocument:input_type -> kyc.data.GetDocumentRequest
-	6,  // 7: kyc.data.DictionaryService.ListDocuments:input_type -> kyc.data.ListDocumentsRequest
-	9,  // 8: kyc.data.CaseService.SaveCaseVersion:input_type -> kyc.data.CaseVersionRequest
-	11, // 9: kyc.data.CaseService.GetCaseVersion:input_type -> kyc.data.GetCaseRequest
-	12, // 10: kyc.data.CaseService.ListCaseVersions:input_type -> kyc.data.ListCaseVersionsRequest
-	14, // 11: kyc.data.CaseService.ListAllCases:input_type -> kyc.data.ListAllCasesRequest
-	0,  // 12: kyc.data.DictionaryService.GetAttribute:output_type -> kyc.data.Attribute
-	3,  // 13: kyc.data.DictionaryService.ListAttributes:output_type -> kyc.data.AttributeList
-	4,  // 14: kyc.data.DictionaryService.GetDocument:output_type -> kyc.data.Document
-	7,  // 15: kyc.data.DictionaryService.ListDocuments:output_type -> kyc.data.DocumentList
-	10, // 16: kyc.data.CaseService.SaveCaseVersion:output_type -> kyc.data.CaseVersionResponse
-	8,  // 17: kyc.data.CaseService.GetCaseVersion:output_type -> kyc.data.CaseVersion
-	13, // 18: kyc.data.CaseService.ListCaseVersions:output_type -> kyc.data.CaseVersionList
-	16, // 19: kyc.data.CaseService.ListAllCases:output_type -> kyc.data.CaseList
-	12, // [12:20] is the sub-list for method output_type
-	4,  // [4:12] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	1,  // 0: kyc.data.Attribute.provenance:type_name -> kyc.data.Provenance
+	0,  // 1: kyc.data.AttributeList.attributes:type_name -> kyc.data.Attribute
+	1,  // 2: kyc.data.Document.provenance:type_name -> kyc.data.Provenance
+	5,  // 3: kyc.data.DocumentList.documents:type_name -> kyc.data.Document
+	9,  // 4: kyc.data.CaseVersionList.versions:type_name -> kyc.data.CaseVersion
+	16, // 5: kyc.data.CaseList.cases:type_name -> kyc.data.CaseSummary
+	23, // 6: kyc.data.CaseTimeline.transitions:type_name -> kyc.data.CaseTransition
+	28, // 7: kyc.data.LineageHistory.runs:type_name -> kyc.data.LineageRun
+	29, // 8: kyc.data.LineageRun.results:type_name -> kyc.data.DerivationResult
+	33, // 9: kyc.data.RiskScore.factors:type_name -> kyc.data.RiskFactor
+	35, // 10: kyc.data.UploadEvidenceRequest.metadata:type_name -> kyc.data.EvidenceUpload
+	34, // 11: kyc.data.EvidenceChunk.metadata:type_name -> kyc.data.Evidence
+	34, // 12: kyc.data.EvidenceList.evidence:type_name -> kyc.data.Evidence
+	41, // 13: kyc.data.CaseAttributeValueList.values:type_name -> kyc.data.CaseAttributeValue
+	2,  // 14: kyc.data.DictionaryService.GetAttribute:input_type -> kyc.data.GetAttributeRequest
+	3,  // 15: kyc.data.DictionaryService.ListAttributes:input_type -> kyc.data.ListAttributesRequest
+	6,  // 16: kyc.data.DictionaryService.GetDocument:input_type -> kyc.data.GetDocumentRequest
+	7,  // 17: kyc.data.DictionaryService.ListDocuments:input_type -> kyc.data.ListDocumentsRequest
+	10, // 18: kyc.data.CaseService.SaveCaseVersion:input_type -> kyc.data.CaseVersionRequest
+	12, // 19: kyc.data.CaseService.GetCaseVersion:input_type -> kyc.data.GetCaseRequest
+	13, // 20: kyc.data.CaseService.ListCaseVersions:input_type -> kyc.data.ListCaseVersionsRequest
+	15, // 21: kyc.data.CaseService.ListAllCases:input_type -> kyc.data.ListAllCasesRequest
+	18, // 22: kyc.data.CaseService.GenerateCaseNarrative:input_type -> kyc.data.GenerateNarrativeRequest
+	20, // 23: kyc.data.CaseService.GenerateReviewPack:input_type -> kyc.data.GenerateReviewPackRequest
+	22, // 24: kyc.data.CaseService.TransitionCase:input_type -> kyc.data.TransitionCaseRequest
+	24, // 25: kyc.data.CaseService.GetCaseTimeline:input_type -> kyc.data.GetCaseTimelineRequest
+	26, // 26: kyc.data.CaseService.GetLineageHistory:input_type -> kyc.data.GetLineageHistoryRequest
+	30, // 27: kyc.data.CaseService.ScoreCase:input_type -> kyc.data.ScoreCaseRequest
+	31, // 28: kyc.data.CaseService.GetRiskScore:input_type -> kyc.data.GetRiskScoreRequest
+	36, // 29: kyc.data.CaseService.UploadEvidence:input_type -> kyc.data.UploadEvidenceRequest
+	37, // 30: kyc.data.CaseService.DownloadEvidence:input_type -> kyc.data.GetEvidenceRequest
+	39, // 31: kyc.data.CaseService.ListEvidence:input_type -> kyc.data.ListEvidenceRequest
+	42, // 32: kyc.data.CaseService.SetCaseAttributeValue:input_type -> kyc.data.SetCaseAttributeValueRequest
+	43, // 33: kyc.data.CaseService.ListCaseAttributeValues:input_type -> kyc.data.ListCaseAttributeValuesRequest
+	45, // 34: kyc.data.CaseService.DeleteCaseAttributeValue:input_type -> kyc.data.DeleteCaseAttributeValueRequest
+	47, // 35: kyc.data.RegionService.ResolveEndpoints:input_type -> kyc.data.ResolveEndpointsRequest
+	0,  // 36: kyc.data.DictionaryService.GetAttribute:output_type -> kyc.data.Attribute
+	4,  // 37: kyc.data.DictionaryService.ListAttributes:output_type -> kyc.data.AttributeList
+	5,  // 38: kyc.data.DictionaryService.GetDocument:output_type -> kyc.data.Document
+	8,  // 39: kyc.data.DictionaryService.ListDocuments:output_type -> kyc.data.DocumentList
+	11, // 40: kyc.data.CaseService.SaveCaseVersion:output_type -> kyc.data.CaseVersionResponse
+	9,  // 41: kyc.data.CaseService.GetCaseVersion:output_type -> kyc.data.CaseVersion
+	14, // 42: kyc.data.CaseService.ListCaseVersions:output_type -> kyc.data.CaseVersionList
+	17, // 43: kyc.data.CaseService.ListAllCases:output_type -> kyc.data.CaseList
+	19, // 44: kyc.data.CaseService.GenerateCaseNarrative:output_type -> kyc.data.CaseNarrative
+	21, // 45: kyc.data.CaseService.GenerateReviewPack:output_type -> kyc.data.ReviewPack
+	23, // 46: kyc.data.CaseService.TransitionCase:output_type -> kyc.data.CaseTransition
+	25, // 47: kyc.data.CaseService.GetCaseTimeline:output_type -> kyc.data.CaseTimeline
+	27, // 48: kyc.data.CaseService.GetLineageHistory:output_type -> kyc.data.LineageHistory
+	32, // 49: kyc.data.CaseService.ScoreCase:output_type -> kyc.data.RiskScore
+	32, // 50: kyc.data.CaseService.GetRiskScore:output_type -> kyc.data.RiskScore
+	34, // 51: kyc.data.CaseService.UploadEvidence:output_type -> kyc.data.Evidence
+	38, // 52: kyc.data.CaseService.DownloadEvidence:output_type -> kyc.data.EvidenceChunk
+	40, // 53: kyc.data.CaseService.ListEvidence:output_type -> kyc.data.EvidenceList
+	41, // 54: kyc.data.CaseService.SetCaseAttributeValue:output_type -> kyc.data.CaseAttributeValue
+	44, // 55: kyc.data.CaseService.ListCaseAttributeValues:output_type -> kyc.data.CaseAttributeValueList
+	46, // 56: kyc.data.CaseService.DeleteCaseAttributeValue:output_type -> kyc.data.DeleteCaseAttributeValueResponse
+	48, // 57: kyc.data.RegionService.ResolveEndpoints:output_type -> kyc.data.RegionEndpoints
+	36, // [36:58] is the sub-list for method output_type
+	14, // [14:36] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_proto_shared_data_service_proto_init() }
@@ -1217,9 +4013,9 @@ func file_proto_shared_data_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_shared_data_service_proto_rawDesc), len(file_proto_shared_data_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   17,
+			NumMessages:   49,
 			NumExtensions: 0,
-			NumServices:   2,
+			NumServices:   3,
 		},
 		GoTypes:           file_proto_shared_data_service_proto_goTypes,
 		DependencyIndexes: file_proto_shared_data_service_proto_depIdxs,
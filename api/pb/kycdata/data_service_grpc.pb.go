@@ -243,10 +243,23 @@ var DictionaryService_ServiceDesc = grpc.ServiceDesc{
 }
 
 const (
-	CaseService_SaveCaseVersion_FullMethodName  = "/kyc.data.CaseService/SaveCaseVersion"
-	CaseService_GetCaseVersion_FullMethodName   = "/kyc.data.CaseService/GetCaseVersion"
-	CaseService_ListCaseVersions_FullMethodName = "/kyc.data.CaseService/ListCaseVersions"
-	CaseService_ListAllCases_FullMethodName     = "/kyc.data.CaseService/ListAllCases"
+	CaseService_SaveCaseVersion_FullMethodName          = "/kyc.data.CaseService/SaveCaseVersion"
+	CaseService_GetCaseVersion_FullMethodName           = "/kyc.data.CaseService/GetCaseVersion"
+	CaseService_ListCaseVersions_FullMethodName         = "/kyc.data.CaseService/ListCaseVersions"
+	CaseService_ListAllCases_FullMethodName             = "/kyc.data.CaseService/ListAllCases"
+	CaseService_GenerateCaseNarrative_FullMethodName    = "/kyc.data.CaseService/GenerateCaseNarrative"
+	CaseService_GenerateReviewPack_FullMethodName       = "/kyc.data.CaseService/GenerateReviewPack"
+	CaseService_TransitionCase_FullMethodName           = "/kyc.data.CaseService/TransitionCase"
+	CaseService_GetCaseTimeline_FullMethodName          = "/kyc.data.CaseService/GetCaseTimeline"
+	CaseService_GetLineageHistory_FullMethodName        = "/kyc.data.CaseService/GetLineageHistory"
+	CaseService_ScoreCase_FullMethodName                = "/kyc.data.CaseService/ScoreCase"
+	CaseService_GetRiskScore_FullMethodName             = "/kyc.data.CaseService/GetRiskScore"
+	CaseService_UploadEvidence_FullMethodName           = "/kyc.data.CaseService/UploadEvidence"
+	CaseService_DownloadEvidence_FullMethodName         = "/kyc.data.CaseService/DownloadEvidence"
+	CaseService_ListEvidence_FullMethodName             = "/kyc.data.CaseService/ListEvidence"
+	CaseService_SetCaseAttributeValue_FullMethodName    = "/kyc.data.CaseService/SetCaseAttributeValue"
+	CaseService_ListCaseAttributeValues_FullMethodName  = "/kyc.data.CaseService/ListCaseAttributeValues"
+	CaseService_DeleteCaseAttributeValue_FullMethodName = "/kyc.data.CaseService/DeleteCaseAttributeValue"
 )
 
 // CaseServiceClient is the client API for CaseService service.
@@ -261,6 +274,19 @@ type CaseServiceClient interface {
 	GetCaseVersion(ctx context.Context, in *GetCaseRequest, opts ...grpc.CallOption) (*CaseVersion, error)
 	ListCaseVersions(ctx context.Context, in *ListCaseVersionsRequest, opts ...grpc.CallOption) (*CaseVersionList, error)
 	ListAllCases(ctx context.Context, in *ListAllCasesRequest, opts ...grpc.CallOption) (*CaseList, error)
+	GenerateCaseNarrative(ctx context.Context, in *GenerateNarrativeRequest, opts ...grpc.CallOption) (*CaseNarrative, error)
+	GenerateReviewPack(ctx context.Context, in *GenerateReviewPackRequest, opts ...grpc.CallOption) (*ReviewPack, error)
+	TransitionCase(ctx context.Context, in *TransitionCaseRequest, opts ...grpc.CallOption) (*CaseTransition, error)
+	GetCaseTimeline(ctx context.Context, in *GetCaseTimelineRequest, opts ...grpc.CallOption) (*CaseTimeline, error)
+	GetLineageHistory(ctx context.Context, in *GetLineageHistoryRequest, opts ...grpc.CallOption) (*LineageHistory, error)
+	ScoreCase(ctx context.Context, in *ScoreCaseRequest, opts ...grpc.CallOption) (*RiskScore, error)
+	GetRiskScore(ctx context.Context, in *GetRiskScoreRequest, opts ...grpc.CallOption) (*RiskScore, error)
+	UploadEvidence(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadEvidenceRequest, Evidence], error)
+	DownloadEvidence(ctx context.Context, in *GetEvidenceRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[EvidenceChunk], error)
+	ListEvidence(ctx context.Context, in *ListEvidenceRequest, opts ...grpc.CallOption) (*EvidenceList, error)
+	SetCaseAttributeValue(ctx context.Context, in *SetCaseAttributeValueRequest, opts ...grpc.CallOption) (*CaseAttributeValue, error)
+	ListCaseAttributeValues(ctx context.Context, in *ListCaseAttributeValuesRequest, opts ...grpc.CallOption) (*CaseAttributeValueList, error)
+	DeleteCaseAttributeValue(ctx context.Context, in *DeleteCaseAttributeValueRequest, opts ...grpc.CallOption) (*DeleteCaseAttributeValueResponse, error)
 }
 
 type caseServiceClient struct {
@@ -311,6 +337,148 @@ func (c *caseServiceClient) ListAllCases(ctx context.Context, in *ListAllCasesRe
 	return out, nil
 }
 
+func (c *caseServiceClient) GenerateCaseNarrative(ctx context.Context, in *GenerateNarrativeRequest, opts ...grpc.CallOption) (*CaseNarrative, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CaseNarrative)
+	err := c.cc.Invoke(ctx, CaseService_GenerateCaseNarrative_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *caseServiceClient) GenerateReviewPack(ctx context.Context, in *GenerateReviewPackRequest, opts ...grpc.CallOption) (*ReviewPack, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReviewPack)
+	err := c.cc.Invoke(ctx, CaseService_GenerateReviewPack_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *caseServiceClient) TransitionCase(ctx context.Context, in *TransitionCaseRequest, opts ...grpc.CallOption) (*CaseTransition, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CaseTransition)
+	err := c.cc.Invoke(ctx, CaseService_TransitionCase_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *caseServiceClient) GetCaseTimeline(ctx context.Context, in *GetCaseTimelineRequest, opts ...grpc.CallOption) (*CaseTimeline, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CaseTimeline)
+	err := c.cc.Invoke(ctx, CaseService_GetCaseTimeline_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *caseServiceClient) GetLineageHistory(ctx context.Context, in *GetLineageHistoryRequest, opts ...grpc.CallOption) (*LineageHistory, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LineageHistory)
+	err := c.cc.Invoke(ctx, CaseService_GetLineageHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *caseServiceClient) ScoreCase(ctx context.Context, in *ScoreCaseRequest, opts ...grpc.CallOption) (*RiskScore, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RiskScore)
+	err := c.cc.Invoke(ctx, CaseService_ScoreCase_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *caseServiceClient) GetRiskScore(ctx context.Context, in *GetRiskScoreRequest, opts ...grpc.CallOption) (*RiskScore, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RiskScore)
+	err := c.cc.Invoke(ctx, CaseService_GetRiskScore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *caseServiceClient) UploadEvidence(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadEvidenceRequest, Evidence], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CaseService_ServiceDesc.Streams[0], CaseService_UploadEvidence_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UploadEvidenceRequest, Evidence]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CaseService_UploadEvidenceClient = grpc.ClientStreamingClient[UploadEvidenceRequest, Evidence]
+
+func (c *caseServiceClient) DownloadEvidence(ctx context.Context, in *GetEvidenceRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[EvidenceChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CaseService_ServiceDesc.Streams[1], CaseService_DownloadEvidence_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetEvidenceRequest, EvidenceChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CaseService_DownloadEvidenceClient = grpc.ServerStreamingClient[EvidenceChunk]
+
+func (c *caseServiceClient) ListEvidence(ctx context.Context, in *ListEvidenceRequest, opts ...grpc.CallOption) (*EvidenceList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EvidenceList)
+	err := c.cc.Invoke(ctx, CaseService_ListEvidence_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *caseServiceClient) SetCaseAttributeValue(ctx context.Context, in *SetCaseAttributeValueRequest, opts ...grpc.CallOption) (*CaseAttributeValue, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CaseAttributeValue)
+	err := c.cc.Invoke(ctx, CaseService_SetCaseAttributeValue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *caseServiceClient) ListCaseAttributeValues(ctx context.Context, in *ListCaseAttributeValuesRequest, opts ...grpc.CallOption) (*CaseAttributeValueList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CaseAttributeValueList)
+	err := c.cc.Invoke(ctx, CaseService_ListCaseAttributeValues_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *caseServiceClient) DeleteCaseAttributeValue(ctx context.Context, in *DeleteCaseAttributeValueRequest, opts ...grpc.CallOption) (*DeleteCaseAttributeValueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCaseAttributeValueResponse)
+	err := c.cc.Invoke(ctx, CaseService_DeleteCaseAttributeValue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CaseServiceServer is the server API for CaseService service.
 // All implementations must embed UnimplementedCaseServiceServer
 // for forward compatibility.
@@ -323,6 +491,19 @@ type CaseServiceServer interface {
 	GetCaseVersion(context.Context, *GetCaseRequest) (*CaseVersion, error)
 	ListCaseVersions(context.Context, *ListCaseVersionsRequest) (*CaseVersionList, error)
 	ListAllCases(context.Context, *ListAllCasesRequest) (*CaseList, error)
+	GenerateCaseNarrative(context.Context, *GenerateNarrativeRequest) (*CaseNarrative, error)
+	GenerateReviewPack(context.Context, *GenerateReviewPackRequest) (*ReviewPack, error)
+	TransitionCase(context.Context, *TransitionCaseRequest) (*CaseTransition, error)
+	GetCaseTimeline(context.Context, *GetCaseTimelineRequest) (*CaseTimeline, error)
+	GetLineageHistory(context.Context, *GetLineageHistoryRequest) (*LineageHistory, error)
+	ScoreCase(context.Context, *ScoreCaseRequest) (*RiskScore, error)
+	GetRiskScore(context.Context, *GetRiskScoreRequest) (*RiskScore, error)
+	UploadEvidence(grpc.ClientStreamingServer[UploadEvidenceRequest, Evidence]) error
+	DownloadEvidence(*GetEvidenceRequest, grpc.ServerStreamingServer[EvidenceChunk]) error
+	ListEvidence(context.Context, *ListEvidenceRequest) (*EvidenceList, error)
+	SetCaseAttributeValue(context.Context, *SetCaseAttributeValueRequest) (*CaseAttributeValue, error)
+	ListCaseAttributeValues(context.Context, *ListCaseAttributeValuesRequest) (*CaseAttributeValueList, error)
+	DeleteCaseAttributeValue(context.Context, *DeleteCaseAttributeValueRequest) (*DeleteCaseAttributeValueResponse, error)
 	mustEmbedUnimplementedCaseServiceServer()
 }
 
@@ -345,6 +526,45 @@ func (UnimplementedCaseServiceServer) ListCaseVersions(context.Context, *ListCas
 func (UnimplementedCaseServiceServer) ListAllCases(context.Context, *ListAllCasesRequest) (*CaseList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListAllCases not implemented")
 }
+func (UnimplementedCaseServiceServer) GenerateCaseNarrative(context.Context, *GenerateNarrativeRequest) (*CaseNarrative, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateCaseNarrative not implemented")
+}
+func (UnimplementedCaseServiceServer) GenerateReviewPack(context.Context, *GenerateReviewPackRequest) (*ReviewPack, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateReviewPack not implemented")
+}
+func (UnimplementedCaseServiceServer) TransitionCase(context.Context, *TransitionCaseRequest) (*CaseTransition, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransitionCase not implemented")
+}
+func (UnimplementedCaseServiceServer) GetCaseTimeline(context.Context, *GetCaseTimelineRequest) (*CaseTimeline, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCaseTimeline not implemented")
+}
+func (UnimplementedCaseServiceServer) GetLineageHistory(context.Context, *GetLineageHistoryRequest) (*LineageHistory, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLineageHistory not implemented")
+}
+func (UnimplementedCaseServiceServer) ScoreCase(context.Context, *ScoreCaseRequest) (*RiskScore, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScoreCase not implemented")
+}
+func (UnimplementedCaseServiceServer) GetRiskScore(context.Context, *GetRiskScoreRequest) (*RiskScore, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRiskScore not implemented")
+}
+func (UnimplementedCaseServiceServer) UploadEvidence(grpc.ClientStreamingServer[UploadEvidenceRequest, Evidence]) error {
+	return status.Errorf(codes.Unimplemented, "method UploadEvidence not implemented")
+}
+func (UnimplementedCaseServiceServer) DownloadEvidence(*GetEvidenceRequest, grpc.ServerStreamingServer[EvidenceChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadEvidence not implemented")
+}
+func (UnimplementedCaseServiceServer) ListEvidence(context.Context, *ListEvidenceRequest) (*EvidenceList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEvidence not implemented")
+}
+func (UnimplementedCaseServiceServer) SetCaseAttributeValue(context.Context, *SetCaseAttributeValueRequest) (*CaseAttributeValue, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCaseAttributeValue not implemented")
+}
+func (UnimplementedCaseServiceServer) ListCaseAttributeValues(context.Context, *ListCaseAttributeValuesRequest) (*CaseAttributeValueList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCaseAttributeValues not implemented")
+}
+func (UnimplementedCaseServiceServer) DeleteCaseAttributeValue(context.Context, *DeleteCaseAttributeValueRequest) (*DeleteCaseAttributeValueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCaseAttributeValue not implemented")
+}
 func (UnimplementedCaseServiceServer) mustEmbedUnimplementedCaseServiceServer() {}
 func (UnimplementedCaseServiceServer) testEmbeddedByValue()                     {}
 
@@ -438,6 +658,222 @@ func _CaseService_ListAllCases_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CaseService_GenerateCaseNarrative_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateNarrativeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CaseServiceServer).GenerateCaseNarrative(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CaseService_GenerateCaseNarrative_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CaseServiceServer).GenerateCaseNarrative(ctx, req.(*GenerateNarrativeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CaseService_GenerateReviewPack_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateReviewPackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CaseServiceServer).GenerateReviewPack(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CaseService_GenerateReviewPack_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CaseServiceServer).GenerateReviewPack(ctx, req.(*GenerateReviewPackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CaseService_TransitionCase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransitionCaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CaseServiceServer).TransitionCase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CaseService_TransitionCase_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CaseServiceServer).TransitionCase(ctx, req.(*TransitionCaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CaseService_GetCaseTimeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCaseTimelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CaseServiceServer).GetCaseTimeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CaseService_GetCaseTimeline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CaseServiceServer).GetCaseTimeline(ctx, req.(*GetCaseTimelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CaseService_GetLineageHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLineageHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CaseServiceServer).GetLineageHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CaseService_GetLineageHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CaseServiceServer).GetLineageHistory(ctx, req.(*GetLineageHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CaseService_ScoreCase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScoreCaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CaseServiceServer).ScoreCase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CaseService_ScoreCase_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CaseServiceServer).ScoreCase(ctx, req.(*ScoreCaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CaseService_GetRiskScore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRiskScoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CaseServiceServer).GetRiskScore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CaseService_GetRiskScore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CaseServiceServer).GetRiskScore(ctx, req.(*GetRiskScoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CaseService_UploadEvidence_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CaseServiceServer).UploadEvidence(&grpc.GenericServerStream[UploadEvidenceRequest, Evidence]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CaseService_UploadEvidenceServer = grpc.ClientStreamingServer[UploadEvidenceRequest, Evidence]
+
+func _CaseService_DownloadEvidence_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetEvidenceRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CaseServiceServer).DownloadEvidence(m, &grpc.GenericServerStream[GetEvidenceRequest, EvidenceChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CaseService_DownloadEvidenceServer = grpc.ServerStreamingServer[EvidenceChunk]
+
+func _CaseService_ListEvidence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEvidenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CaseServiceServer).ListEvidence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CaseService_ListEvidence_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CaseServiceServer).ListEvidence(ctx, req.(*ListEvidenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CaseService_SetCaseAttributeValue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCaseAttributeValueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CaseServiceServer).SetCaseAttributeValue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CaseService_SetCaseAttributeValue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CaseServiceServer).SetCaseAttributeValue(ctx, req.(*SetCaseAttributeValueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CaseService_ListCaseAttributeValues_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCaseAttributeValuesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CaseServiceServer).ListCaseAttributeValues(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CaseService_ListCaseAttributeValues_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CaseServiceServer).ListCaseAttributeValues(ctx, req.(*ListCaseAttributeValuesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CaseService_DeleteCaseAttributeValue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCaseAttributeValueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CaseServiceServer).DeleteCaseAttributeValue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CaseService_DeleteCaseAttributeValue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CaseServiceServer).DeleteCaseAttributeValue(ctx, req.(*DeleteCaseAttributeValueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CaseService_ServiceDesc is the grpc.ServiceDesc for CaseService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -461,6 +897,175 @@ var CaseService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListAllCases",
 			Handler:    _CaseService_ListAllCases_Handler,
 		},
+		{
+			MethodName: "GenerateCaseNarrative",
+			Handler:    _CaseService_GenerateCaseNarrative_Handler,
+		},
+		{
+			MethodName: "GenerateReviewPack",
+			Handler:    _CaseService_GenerateReviewPack_Handler,
+		},
+		{
+			MethodName: "TransitionCase",
+			Handler:    _CaseService_TransitionCase_Handler,
+		},
+		{
+			MethodName: "GetCaseTimeline",
+			Handler:    _CaseService_GetCaseTimeline_Handler,
+		},
+		{
+			MethodName: "GetLineageHistory",
+			Handler:    _CaseService_GetLineageHistory_Handler,
+		},
+		{
+			MethodName: "ScoreCase",
+			Handler:    _CaseService_ScoreCase_Handler,
+		},
+		{
+			MethodName: "GetRiskScore",
+			Handler:    _CaseService_GetRiskScore_Handler,
+		},
+		{
+			MethodName: "ListEvidence",
+			Handler:    _CaseService_ListEvidence_Handler,
+		},
+		{
+			MethodName: "SetCaseAttributeValue",
+			Handler:    _CaseService_SetCaseAttributeValue_Handler,
+		},
+		{
+			MethodName: "ListCaseAttributeValues",
+			Handler:    _CaseService_ListCaseAttributeValues_Handler,
+		},
+		{
+			MethodName: "DeleteCaseAttributeValue",
+			Handler:    _CaseService_DeleteCaseAttributeValue_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadEvidence",
+			Handler:       _CaseService_UploadEvidence_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DownloadEvidence",
+			Handler:       _CaseService_DownloadEvidence_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto_shared/data_service.proto",
+}
+
+const (
+	RegionService_ResolveEndpoints_FullMethodName = "/kyc.data.RegionService/ResolveEndpoints"
+)
+
+// RegionServiceClient is the client API for RegionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ----------------------
+// Region Service
+// ----------------------
+// Directs clients to the nearest read endpoint; writes always go to the
+// primary region (non-primary servers forward them).
+type RegionServiceClient interface {
+	ResolveEndpoints(ctx context.Context, in *ResolveEndpointsRequest, opts ...grpc.CallOption) (*RegionEndpoints, error)
+}
+
+type regionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRegionServiceClient(cc grpc.ClientConnInterface) RegionServiceClient {
+	return &regionServiceClient{cc}
+}
+
+func (c *regionServiceClient) ResolveEndpoints(ctx context.Context, in *ResolveEndpointsRequest, opts ...grpc.CallOption) (*RegionEndpoints, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegionEndpoints)
+	err := c.cc.Invoke(ctx, RegionService_ResolveEndpoints_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RegionServiceServer is the server API for RegionService service.
+// All implementations must embed UnimplementedRegionServiceServer
+// for forward compatibility.
+//
+// ----------------------
+// Region Service
+// ----------------------
+// Directs clients to the nearest read endpoint; writes always go to the
+// primary region (non-primary servers forward them).
+type RegionServiceServer interface {
+	ResolveEndpoints(context.Context, *ResolveEndpointsRequest) (*RegionEndpoints, error)
+	mustEmbedUnimplementedRegionServiceServer()
+}
+
+// UnimplementedRegionServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRegionServiceServer struct{}
+
+func (UnimplementedRegionServiceServer) ResolveEndpoints(context.Context, *ResolveEndpointsRequest) (*RegionEndpoints, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveEndpoints not implemented")
+}
+func (UnimplementedRegionServiceServer) mustEmbedUnimplementedRegionServiceServer() {}
+func (UnimplementedRegionServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeRegionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RegionServiceServer will
+// result in compilation errors.
+type UnsafeRegionServiceServer interface {
+	mustEmbedUnimplementedRegionServiceServer()
+}
+
+func RegisterRegionServiceServer(s grpc.ServiceRegistrar, srv RegionServiceServer) {
+	// If the following call pancis, it indicates UnimplementedRegionServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RegionService_ServiceDesc, srv)
+}
+
+func _RegionService_ResolveEndpoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveEndpointsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegionServiceServer).ResolveEndpoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RegionService_ResolveEndpoints_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegionServiceServer).ResolveEndpoints(ctx, req.(*ResolveEndpointsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RegionService_ServiceDesc is the grpc.ServiceDesc for RegionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RegionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "kyc.data.RegionService",
+	HandlerType: (*RegionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ResolveEndpoints",
+			Handler:    _RegionService_ResolveEndpoints_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto_shared/data_service.proto",
@@ -37,6 +37,7 @@ type Entity struct {
 	Metadata           string                 `protobuf:"bytes,12,opt,name=metadata,proto3" json:"metadata,omitempty"` // JSON string
 	CreatedAt          string                 `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt          string                 `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	MatchRank          float64                `protobuf:"fixed64,15,opt,name=match_rank,json=matchRank,proto3" json:"match_rank,omitempty"` // SearchEntities: how well the entity matched (0-1)
 	unknownFields      protoimpl.UnknownFields
 	sizeCache          protoimpl.SizeCache
 }
@@ -169,10 +170,18 @@ func (x *Entity) GetUpdatedAt() string {
 	return ""
 }
 
+func (x *Entity) GetMatchRank() float64 {
+	if x != nil {
+		return x.MatchRank
+	}
+	return 0
+}
+
 type EntityList struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Entities      []*Entity              `protobuf:"bytes,1,rep,name=entities,proto3" json:"entities,omitempty"`
 	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // Empty on the last page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -221,6 +230,13 @@ func (x *EntityList) GetTotalCount() int32 {
 	return 0
 }
 
+func (x *EntityList) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
 type EntityResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -1081,6 +1097,236 @@ func (x *ControlChain) GetTotalEffectivePercentage() float64 {
 	return 0
 }
 
+// UboRollup is the effective ownership of an entity per ultimate owner,
+// multiplied along every ownership path
+type UboRollup struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	EntityId               string                 `protobuf:"bytes,1,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	Threshold              float64                `protobuf:"fixed64,2,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	Owners                 []*UboOwner            `protobuf:"bytes,3,rep,name=owners,proto3" json:"owners,omitempty"` // Strongest first
+	AttributedPercentage   float64                `protobuf:"fixed64,4,opt,name=attributed_percentage,json=attributedPercentage,proto3" json:"attributed_percentage,omitempty"`
+	UnattributedPercentage float64                `protobuf:"fixed64,5,opt,name=unattributed_percentage,json=unattributedPercentage,proto3" json:"unattributed_percentage,omitempty"` // Not traced to an ultimate owner
+	Truncated              bool                   `protobuf:"varint,6,opt,name=truncated,proto3" json:"truncated,omitempty"`                                                          // Depth or path limit reached
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *UboRollup) Reset() {
+	*x = UboRollup{}
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UboRollup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UboRollup) ProtoMessage() {}
+
+func (x *UboRollup) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UboRollup.ProtoReflect.Descriptor instead.
+func (*UboRollup) Descriptor() ([]byte, []int) {
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UboRollup) GetEntityId() string {
+	if x != nil {
+		return x.EntityId
+	}
+	return ""
+}
+
+func (x *UboRollup) GetThreshold() float64 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *UboRollup) GetOwners() []*UboOwner {
+	if x != nil {
+		return x.Owners
+	}
+	return nil
+}
+
+func (x *UboRollup) GetAttributedPercentage() float64 {
+	if x != nil {
+		return x.AttributedPercentage
+	}
+	return 0
+}
+
+func (x *UboRollup) GetUnattributedPercentage() float64 {
+	if x != nil {
+		return x.UnattributedPercentage
+	}
+	return 0
+}
+
+func (x *UboRollup) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+type UboOwner struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	EntityId            string                 `protobuf:"bytes,1,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	Name                string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	EntityType          string                 `protobuf:"bytes,3,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	EffectivePercentage float64                `protobuf:"fixed64,4,opt,name=effective_percentage,json=effectivePercentage,proto3" json:"effective_percentage,omitempty"`
+	IsUbo               bool                   `protobuf:"varint,5,opt,name=is_ubo,json=isUbo,proto3" json:"is_ubo,omitempty"` // At or above the threshold
+	Circular            bool                   `protobuf:"varint,6,opt,name=circular,proto3" json:"circular,omitempty"`        // A path ended at a cross-holding
+	Paths               []*OwnershipPath       `protobuf:"bytes,7,rep,name=paths,proto3" json:"paths,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *UboOwner) Reset() {
+	*x = UboOwner{}
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UboOwner) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UboOwner) ProtoMessage() {}
+
+func (x *UboOwner) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UboOwner.ProtoReflect.Descriptor instead.
+func (*UboOwner) Descriptor() ([]byte, []int) {
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UboOwner) GetEntityId() string {
+	if x != nil {
+		return x.EntityId
+	}
+	return ""
+}
+
+func (x *UboOwner) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UboOwner) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *UboOwner) GetEffectivePercentage() float64 {
+	if x != nil {
+		return x.EffectivePercentage
+	}
+	return 0
+}
+
+func (x *UboOwner) GetIsUbo() bool {
+	if x != nil {
+		return x.IsUbo
+	}
+	return false
+}
+
+func (x *UboOwner) GetCircular() bool {
+	if x != nil {
+		return x.Circular
+	}
+	return false
+}
+
+func (x *UboOwner) GetPaths() []*OwnershipPath {
+	if x != nil {
+		return x.Paths
+	}
+	return nil
+}
+
+type OwnershipPath struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntityIds     []string               `protobuf:"bytes,1,rep,name=entity_ids,json=entityIds,proto3" json:"entity_ids,omitempty"` // Ultimate owner first, target last
+	Percentage    float64                `protobuf:"fixed64,2,opt,name=percentage,proto3" json:"percentage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OwnershipPath) Reset() {
+	*x = OwnershipPath{}
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OwnershipPath) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OwnershipPath) ProtoMessage() {}
+
+func (x *OwnershipPath) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OwnershipPath.ProtoReflect.Descriptor instead.
+func (*OwnershipPath) Descriptor() ([]byte, []int) {
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *OwnershipPath) GetEntityIds() []string {
+	if x != nil {
+		return x.EntityIds
+	}
+	return nil
+}
+
+func (x *OwnershipPath) GetPercentage() float64 {
+	if x != nil {
+		return x.Percentage
+	}
+	return 0
+}
+
 type ControlResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -1092,7 +1338,7 @@ type ControlResponse struct {
 
 func (x *ControlResponse) Reset() {
 	*x = ControlResponse{}
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[13]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1104,7 +1350,7 @@ func (x *ControlResponse) String() string {
 func (*ControlResponse) ProtoMessage() {}
 
 func (x *ControlResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[13]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1117,7 +1363,7 @@ func (x *ControlResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ControlResponse.ProtoReflect.Descriptor instead.
 func (*ControlResponse) Descriptor() ([]byte, []int) {
-	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{13}
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *ControlResponse) GetSuccess() bool {
@@ -1161,7 +1407,7 @@ type KycProfile struct {
 
 func (x *KycProfile) Reset() {
 	*x = KycProfile{}
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[14]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1173,7 +1419,7 @@ func (x *KycProfile) String() string {
 func (*KycProfile) ProtoMessage() {}
 
 func (x *KycProfile) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[14]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1186,7 +1432,7 @@ func (x *KycProfile) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KycProfile.ProtoReflect.Descriptor instead.
 func (*KycProfile) Descriptor() ([]byte, []int) {
-	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{14}
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *KycProfile) GetEntityId() string {
@@ -1284,7 +1530,7 @@ type KycProfileResponse struct {
 
 func (x *KycProfileResponse) Reset() {
 	*x = KycProfileResponse{}
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[15]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1296,7 +1542,7 @@ func (x *KycProfileResponse) String() string {
 func (*KycProfileResponse) ProtoMessage() {}
 
 func (x *KycProfileResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[15]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1309,7 +1555,7 @@ func (x *KycProfileResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KycProfileResponse.ProtoReflect.Descriptor instead.
 func (*KycProfileResponse) Descriptor() ([]byte, []int) {
-	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{15}
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *KycProfileResponse) GetSuccess() bool {
@@ -1333,37 +1579,37 @@ func (x *KycProfileResponse) GetEntityId() string {
 	return ""
 }
 
-type Regulation struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"` // FATCA, CRS, AMLD5, etc.
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Jurisdiction  string                 `protobuf:"bytes,4,opt,name=jurisdiction,proto3" json:"jurisdiction,omitempty"`
-	Authority     string                 `protobuf:"bytes,5,opt,name=authority,proto3" json:"authority,omitempty"`
-	EffectiveDate string                 `protobuf:"bytes,6,opt,name=effective_date,json=effectiveDate,proto3" json:"effective_date,omitempty"`
-	Description   string                 `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
-	Url           string                 `protobuf:"bytes,8,opt,name=url,proto3" json:"url,omitempty"`
-	Status        string                 `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
-	Metadata      string                 `protobuf:"bytes,10,opt,name=metadata,proto3" json:"metadata,omitempty"` // JSON string
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// Entity360 aggregates everything known about an entity into one response
+type Entity360 struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Entity            *Entity                `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
+	CbuRoles          []*CbuRole             `protobuf:"bytes,2,rep,name=cbu_roles,json=cbuRoles,proto3" json:"cbu_roles,omitempty"`       // Current roles, with the CBU they belong to
+	Cbus              []*Cbu                 `protobuf:"bytes,3,rep,name=cbus,proto3" json:"cbus,omitempty"`                               // CBUs referenced by cbu_roles
+	Controllers       []*EntityControl       `protobuf:"bytes,4,rep,name=controllers,proto3" json:"controllers,omitempty"`                 // Current inbound edges (who owns/controls this entity)
+	Controlled        []*EntityControl       `protobuf:"bytes,5,rep,name=controlled,proto3" json:"controlled,omitempty"`                   // Current outbound edges (what this entity owns/controls)
+	KycProfile        *KycProfile            `protobuf:"bytes,6,opt,name=kyc_profile,json=kycProfile,proto3" json:"kyc_profile,omitempty"` // Unset when no profile exists
+	Screening         *ScreeningSummary      `protobuf:"bytes,7,opt,name=screening,proto3" json:"screening,omitempty"`
+	OpenCases         []*OpenCase            `protobuf:"bytes,8,rep,name=open_cases,json=openCases,proto3" json:"open_cases,omitempty"`
+	RecentEvaluations []*RuleEvaluation      `protobuf:"bytes,9,rep,name=recent_evaluations,json=recentEvaluations,proto3" json:"recent_evaluations,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
-func (x *Regulation) Reset() {
-	*x = Regulation{}
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[16]
+func (x *Entity360) Reset() {
+	*x = Entity360{}
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Regulation) String() string {
+func (x *Entity360) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Regulation) ProtoMessage() {}
+func (*Entity360) ProtoMessage() {}
 
-func (x *Regulation) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[16]
+func (x *Entity360) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1374,77 +1620,1023 @@ func (x *Regulation) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Regulation.ProtoReflect.Descriptor instead.
-func (*Regulation) Descriptor() ([]byte, []int) {
-	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use Entity360.ProtoReflect.Descriptor instead.
+func (*Entity360) Descriptor() ([]byte, []int) {
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *Regulation) GetId() string {
+func (x *Entity360) GetEntity() *Entity {
 	if x != nil {
-		return x.Id
+		return x.Entity
 	}
-	return ""
+	return nil
 }
 
-func (x *Regulation) GetCode() string {
+func (x *Entity360) GetCbuRoles() []*CbuRole {
 	if x != nil {
-		return x.Code
+		return x.CbuRoles
 	}
-	return ""
+	return nil
 }
 
-func (x *Regulation) GetName() string {
+func (x *Entity360) GetCbus() []*Cbu {
 	if x != nil {
-		return x.Name
+		return x.Cbus
 	}
-	return ""
+	return nil
 }
 
-func (x *Regulation) GetJurisdiction() string {
+func (x *Entity360) GetControllers() []*EntityControl {
 	if x != nil {
-		return x.Jurisdiction
+		return x.Controllers
 	}
-	return ""
+	return nil
 }
 
-func (x *Regulation) GetAuthority() string {
+func (x *Entity360) GetControlled() []*EntityControl {
 	if x != nil {
-		return x.Authority
+		return x.Controlled
 	}
-	return ""
+	return nil
 }
 
-func (x *Regulation) GetEffectiveDate() string {
+func (x *Entity360) GetKycProfile() *KycProfile {
 	if x != nil {
-		return x.EffectiveDate
+		return x.KycProfile
 	}
-	return ""
+	return nil
 }
 
-func (x *Regulation) GetDescription() string {
+func (x *Entity360) GetScreening() *ScreeningSummary {
 	if x != nil {
-		return x.Description
+		return x.Screening
 	}
-	return ""
+	return nil
 }
 
-func (x *Regulation) GetUrl() string {
+func (x *Entity360) GetOpenCases() []*OpenCase {
 	if x != nil {
-		return x.Url
+		return x.OpenCases
 	}
-	return ""
+	return nil
 }
 
-func (x *Regulation) GetStatus() string {
+func (x *Entity360) GetRecentEvaluations() []*RuleEvaluation {
 	if x != nil {
-		return x.Status
+		return x.RecentEvaluations
 	}
-	return ""
+	return nil
 }
 
-func (x *Regulation) GetMetadata() string {
-	if x != nil {
-		return x.Metadata
+type ScreeningSummary struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	SanctionsCheckStatus string                 `protobuf:"bytes,1,opt,name=sanctions_check_status,json=sanctionsCheckStatus,proto3" json:"sanctions_check_status,omitempty"` // CLEAR, HIT, PENDING, ... ('' = never screened)
+	PepStatus            bool                   `protobuf:"varint,2,opt,name=pep_status,json=pepStatus,proto3" json:"pep_status,omitempty"`
+	AdverseMediaStatus   string                 `protobuf:"bytes,3,opt,name=adverse_media_status,json=adverseMediaStatus,proto3" json:"adverse_media_status,omitempty"`
+	LastReviewDate       string                 `protobuf:"bytes,4,opt,name=last_review_date,json=lastReviewDate,proto3" json:"last_review_date,omitempty"`
+	NextReviewDate       string                 `protobuf:"bytes,5,opt,name=next_review_date,json=nextReviewDate,proto3" json:"next_review_date,omitempty"`
+	ReviewOverdue        bool                   `protobuf:"varint,6,opt,name=review_overdue,json=reviewOverdue,proto3" json:"review_overdue,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ScreeningSummary) Reset() {
+	*x = ScreeningSummary{}
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScreeningSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScreeningSummary) ProtoMessage() {}
+
+func (x *ScreeningSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScreeningSummary.ProtoReflect.Descriptor instead.
+func (*ScreeningSummary) Descriptor() ([]byte, []int) {
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ScreeningSummary) GetSanctionsCheckStatus() string {
+	if x != nil {
+		return x.SanctionsCheckStatus
+	}
+	return ""
+}
+
+func (x *ScreeningSummary) GetPepStatus() bool {
+	if x != nil {
+		return x.PepStatus
+	}
+	return false
+}
+
+func (x *ScreeningSummary) GetAdverseMediaStatus() string {
+	if x != nil {
+		return x.AdverseMediaStatus
+	}
+	return ""
+}
+
+func (x *ScreeningSummary) GetLastReviewDate() string {
+	if x != nil {
+		return x.LastReviewDate
+	}
+	return ""
+}
+
+func (x *ScreeningSummary) GetNextReviewDate() string {
+	if x != nil {
+		return x.NextReviewDate
+	}
+	return ""
+}
+
+func (x *ScreeningSummary) GetReviewOverdue() bool {
+	if x != nil {
+		return x.ReviewOverdue
+	}
+	return false
+}
+
+// OpenCase is a case not yet approved or declined whose DSL names one of
+// the entity's CBUs as (client-business-unit CODE)
+type OpenCase struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CaseName      string                 `protobuf:"bytes,1,opt,name=case_name,json=caseName,proto3" json:"case_name,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Version       int32                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	CbuCode       string                 `protobuf:"bytes,4,opt,name=cbu_code,json=cbuCode,proto3" json:"cbu_code,omitempty"`
+	LastUpdated   string                 `protobuf:"bytes,5,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OpenCase) Reset() {
+	*x = OpenCase{}
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenCase) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenCase) ProtoMessage() {}
+
+func (x *OpenCase) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenCase.ProtoReflect.Descriptor instead.
+func (*OpenCase) Descriptor() ([]byte, []int) {
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *OpenCase) GetCaseName() string {
+	if x != nil {
+		return x.CaseName
+	}
+	return ""
+}
+
+func (x *OpenCase) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *OpenCase) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *OpenCase) GetCbuCode() string {
+	if x != nil {
+		return x.CbuCode
+	}
+	return ""
+}
+
+func (x *OpenCase) GetLastUpdated() string {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return ""
+}
+
+type RuleEvaluation struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	CaseName       string                 `protobuf:"bytes,1,opt,name=case_name,json=caseName,proto3" json:"case_name,omitempty"`
+	DerivedCode    string                 `protobuf:"bytes,2,opt,name=derived_code,json=derivedCode,proto3" json:"derived_code,omitempty"`
+	Value          string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Success        bool                   `protobuf:"varint,4,opt,name=success,proto3" json:"success,omitempty"`
+	Error          string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	RegulationCode string                 `protobuf:"bytes,6,opt,name=regulation_code,json=regulationCode,proto3" json:"regulation_code,omitempty"`
+	EvaluatedAt    string                 `protobuf:"bytes,7,opt,name=evaluated_at,json=evaluatedAt,proto3" json:"evaluated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RuleEvaluation) Reset() {
+	*x = RuleEvaluation{}
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RuleEvaluation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuleEvaluation) ProtoMessage() {}
+
+func (x *RuleEvaluation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuleEvaluation.ProtoReflect.Descriptor instead.
+func (*RuleEvaluation) Descriptor() ([]byte, []int) {
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RuleEvaluation) GetCaseName() string {
+	if x != nil {
+		return x.CaseName
+	}
+	return ""
+}
+
+func (x *RuleEvaluation) GetDerivedCode() string {
+	if x != nil {
+		return x.DerivedCode
+	}
+	return ""
+}
+
+func (x *RuleEvaluation) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *RuleEvaluation) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RuleEvaluation) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *RuleEvaluation) GetRegulationCode() string {
+	if x != nil {
+		return x.RegulationCode
+	}
+	return ""
+}
+
+func (x *RuleEvaluation) GetEvaluatedAt() string {
+	if x != nil {
+		return x.EvaluatedAt
+	}
+	return ""
+}
+
+// LineageGraph is the derivation DAG around an attribute: its sources,
+// transitively, and the attributes derived from it
+type LineageGraph struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attribute     string                 `protobuf:"bytes,1,opt,name=attribute,proto3" json:"attribute,omitempty"`
+	Nodes         []*LineageNode         `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Edges         []*LineageEdge         `protobuf:"bytes,3,rep,name=edges,proto3" json:"edges,omitempty"`
+	Truncated     bool                   `protobuf:"varint,4,opt,name=truncated,proto3" json:"truncated,omitempty"` // Depth limit reached
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LineageGraph) Reset() {
+	*x = LineageGraph{}
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LineageGraph) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LineageGraph) ProtoMessage() {}
+
+func (x *LineageGraph) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LineageGraph.ProtoReflect.Descriptor instead.
+func (*LineageGraph) Descriptor() ([]byte, []int) {
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *LineageGraph) GetAttribute() string {
+	if x != nil {
+		return x.Attribute
+	}
+	return ""
+}
+
+func (x *LineageGraph) GetNodes() []*LineageNode {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *LineageGraph) GetEdges() []*LineageEdge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+func (x *LineageGraph) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+type LineageNode struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Code           string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	AttributeClass string                 `protobuf:"bytes,3,opt,name=attribute_class,json=attributeClass,proto3" json:"attribute_class,omitempty"` // Public, Private
+	Role           string                 `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`                                           // root, upstream, downstream
+	Depth          int32                  `protobuf:"varint,5,opt,name=depth,proto3" json:"depth,omitempty"`                                        // Derivation steps from the root
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *LineageNode) Reset() {
+	*x = LineageNode{}
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LineageNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LineageNode) ProtoMessage() {}
+
+func (x *LineageNode) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LineageNode.ProtoReflect.Descriptor instead.
+func (*LineageNode) Descriptor() ([]byte, []int) {
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *LineageNode) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *LineageNode) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LineageNode) GetAttributeClass() string {
+	if x != nil {
+		return x.AttributeClass
+	}
+	return ""
+}
+
+func (x *LineageNode) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *LineageNode) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+// LineageEdge is a derivation rule reading source to produce target
+type LineageEdge struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Source         string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	Target         string                 `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"`
+	Rule           string                 `protobuf:"bytes,4,opt,name=rule,proto3" json:"rule,omitempty"`
+	RuleType       string                 `protobuf:"bytes,5,opt,name=rule_type,json=ruleType,proto3" json:"rule_type,omitempty"`
+	Jurisdiction   string                 `protobuf:"bytes,6,opt,name=jurisdiction,proto3" json:"jurisdiction,omitempty"`
+	RegulationCode string                 `protobuf:"bytes,7,opt,name=regulation_code,json=regulationCode,proto3" json:"regulation_code,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *LineageEdge) Reset() {
+	*x = LineageEdge{}
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LineageEdge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LineageEdge) ProtoMessage() {}
+
+func (x *LineageEdge) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LineageEdge.ProtoReflect.Descriptor instead.
+func (*LineageEdge) Descriptor() ([]byte, []int) {
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *LineageEdge) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *LineageEdge) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *LineageEdge) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *LineageEdge) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *LineageEdge) GetRuleType() string {
+	if x != nil {
+		return x.RuleType
+	}
+	return ""
+}
+
+func (x *LineageEdge) GetJurisdiction() string {
+	if x != nil {
+		return x.Jurisdiction
+	}
+	return ""
+}
+
+func (x *LineageEdge) GetRegulationCode() string {
+	if x != nil {
+		return x.RegulationCode
+	}
+	return ""
+}
+
+// RuleValidation is the outcome of checking a candidate derivation rule
+// against the attribute types before it is stored
+type RuleValidation struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Rule              string                 `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	Valid             bool                   `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
+	ResultType        string                 `protobuf:"bytes,3,opt,name=result_type,json=resultType,proto3" json:"result_type,omitempty"` // boolean, numeric, string, array, any
+	CompileErrors     []string               `protobuf:"bytes,4,rep,name=compile_errors,json=compileErrors,proto3" json:"compile_errors,omitempty"`
+	TypeErrors        []string               `protobuf:"bytes,5,rep,name=type_errors,json=typeErrors,proto3" json:"type_errors,omitempty"`
+	UnknownAttributes []string               `protobuf:"bytes,6,rep,name=unknown_attributes,json=unknownAttributes,proto3" json:"unknown_attributes,omitempty"`
+	UnknownLists      []string               `protobuf:"bytes,7,rep,name=unknown_lists,json=unknownLists,proto3" json:"unknown_lists,omitempty"`
+	UndeclaredSources []string               `protobuf:"bytes,8,rep,name=undeclared_sources,json=undeclaredSources,proto3" json:"undeclared_sources,omitempty"` // Read but not declared; not recorded as inputs
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RuleValidation) Reset() {
+	*x = RuleValidation{}
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RuleValidation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuleValidation) ProtoMessage() {}
+
+func (x *RuleValidation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuleValidation.ProtoReflect.Descriptor instead.
+func (*RuleValidation) Descriptor() ([]byte, []int) {
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RuleValidation) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *RuleValidation) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *RuleValidation) GetResultType() string {
+	if x != nil {
+		return x.ResultType
+	}
+	return ""
+}
+
+func (x *RuleValidation) GetCompileErrors() []string {
+	if x != nil {
+		return x.CompileErrors
+	}
+	return nil
+}
+
+func (x *RuleValidation) GetTypeErrors() []string {
+	if x != nil {
+		return x.TypeErrors
+	}
+	return nil
+}
+
+func (x *RuleValidation) GetUnknownAttributes() []string {
+	if x != nil {
+		return x.UnknownAttributes
+	}
+	return nil
+}
+
+func (x *RuleValidation) GetUnknownLists() []string {
+	if x != nil {
+		return x.UnknownLists
+	}
+	return nil
+}
+
+func (x *RuleValidation) GetUndeclaredSources() []string {
+	if x != nil {
+		return x.UndeclaredSources
+	}
+	return nil
+}
+
+// Screening is a sanctions, PEP or adverse media screening of an entity and
+// its beneficial owners
+type Screening struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	EntityId      string                 `protobuf:"bytes,2,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	EntityName    string                 `protobuf:"bytes,3,opt,name=entity_name,json=entityName,proto3" json:"entity_name,omitempty"`
+	CaseId        string                 `protobuf:"bytes,4,opt,name=case_id,json=caseId,proto3" json:"case_id,omitempty"` // Case the status was written to, if any
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`               // Clear, Potential Match, Match, Under Review
+	Subjects      int32                  `protobuf:"varint,6,opt,name=subjects,proto3" json:"subjects,omitempty"`          // The entity and its UBOs
+	Providers     []string               `protobuf:"bytes,7,rep,name=providers,proto3" json:"providers,omitempty"`
+	Errors        string                 `protobuf:"bytes,8,opt,name=errors,proto3" json:"errors,omitempty"` // Providers that failed
+	Actor         string                 `protobuf:"bytes,9,opt,name=actor,proto3" json:"actor,omitempty"`
+	ScreenedAt    string                 `protobuf:"bytes,10,opt,name=screened_at,json=screenedAt,proto3" json:"screened_at,omitempty"`
+	Hits          []*ScreeningHit        `protobuf:"bytes,11,rep,name=hits,proto3" json:"hits,omitempty"` // Best match first
+	Kind          string                 `protobuf:"bytes,12,opt,name=kind,proto3" json:"kind,omitempty"` // sanctions, pep, adverse_media
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Screening) Reset() {
+	*x = Screening{}
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Screening) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Screening) ProtoMessage() {}
+
+func (x *Screening) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Screening.ProtoReflect.Descriptor instead.
+func (*Screening) Descriptor() ([]byte, []int) {
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *Screening) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Screening) GetEntityId() string {
+	if x != nil {
+		return x.EntityId
+	}
+	return ""
+}
+
+func (x *Screening) GetEntityName() string {
+	if x != nil {
+		return x.EntityName
+	}
+	return ""
+}
+
+func (x *Screening) GetCaseId() string {
+	if x != nil {
+		return x.CaseId
+	}
+	return ""
+}
+
+func (x *Screening) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Screening) GetSubjects() int32 {
+	if x != nil {
+		return x.Subjects
+	}
+	return 0
+}
+
+func (x *Screening) GetProviders() []string {
+	if x != nil {
+		return x.Providers
+	}
+	return nil
+}
+
+func (x *Screening) GetErrors() string {
+	if x != nil {
+		return x.Errors
+	}
+	return ""
+}
+
+func (x *Screening) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *Screening) GetScreenedAt() string {
+	if x != nil {
+		return x.ScreenedAt
+	}
+	return ""
+}
+
+func (x *Screening) GetHits() []*ScreeningHit {
+	if x != nil {
+		return x.Hits
+	}
+	return nil
+}
+
+func (x *Screening) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+type ScreeningHit struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	SubjectEntityId string                 `protobuf:"bytes,1,opt,name=subject_entity_id,json=subjectEntityId,proto3" json:"subject_entity_id,omitempty"`
+	SubjectName     string                 `protobuf:"bytes,2,opt,name=subject_name,json=subjectName,proto3" json:"subject_name,omitempty"`
+	Provider        string                 `protobuf:"bytes,3,opt,name=provider,proto3" json:"provider,omitempty"`
+	Kind            string                 `protobuf:"bytes,4,opt,name=kind,proto3" json:"kind,omitempty"`
+	Reference       string                 `protobuf:"bytes,5,opt,name=reference,proto3" json:"reference,omitempty"`
+	MatchedName     string                 `protobuf:"bytes,6,opt,name=matched_name,json=matchedName,proto3" json:"matched_name,omitempty"`
+	Score           float64                `protobuf:"fixed64,7,opt,name=score,proto3" json:"score,omitempty"` // Name similarity 0-1
+	Detail          string                 `protobuf:"bytes,8,opt,name=detail,proto3" json:"detail,omitempty"`
+	Url             string                 `protobuf:"bytes,9,opt,name=url,proto3" json:"url,omitempty"` // Evidence: the source record or article
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ScreeningHit) Reset() {
+	*x = ScreeningHit{}
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScreeningHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScreeningHit) ProtoMessage() {}
+
+func (x *ScreeningHit) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScreeningHit.ProtoReflect.Descriptor instead.
+func (*ScreeningHit) Descriptor() ([]byte, []int) {
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ScreeningHit) GetSubjectEntityId() string {
+	if x != nil {
+		return x.SubjectEntityId
+	}
+	return ""
+}
+
+func (x *ScreeningHit) GetSubjectName() string {
+	if x != nil {
+		return x.SubjectName
+	}
+	return ""
+}
+
+func (x *ScreeningHit) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *ScreeningHit) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ScreeningHit) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *ScreeningHit) GetMatchedName() string {
+	if x != nil {
+		return x.MatchedName
+	}
+	return ""
+}
+
+func (x *ScreeningHit) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *ScreeningHit) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+func (x *ScreeningHit) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type Regulation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"` // FATCA, CRS, AMLD5, etc.
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Jurisdiction  string                 `protobuf:"bytes,4,opt,name=jurisdiction,proto3" json:"jurisdiction,omitempty"`
+	Authority     string                 `protobuf:"bytes,5,opt,name=authority,proto3" json:"authority,omitempty"`
+	EffectiveDate string                 `protobuf:"bytes,6,opt,name=effective_date,json=effectiveDate,proto3" json:"effective_date,omitempty"`
+	Description   string                 `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+	Url           string                 `protobuf:"bytes,8,opt,name=url,proto3" json:"url,omitempty"`
+	Status        string                 `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
+	Metadata      string                 `protobuf:"bytes,10,opt,name=metadata,proto3" json:"metadata,omitempty"` // JSON string
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Regulation) Reset() {
+	*x = Regulation{}
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Regulation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Regulation) ProtoMessage() {}
+
+func (x *Regulation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Regulation.ProtoReflect.Descriptor instead.
+func (*Regulation) Descriptor() ([]byte, []int) {
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *Regulation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Regulation) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Regulation) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Regulation) GetJurisdiction() string {
+	if x != nil {
+		return x.Jurisdiction
+	}
+	return ""
+}
+
+func (x *Regulation) GetAuthority() string {
+	if x != nil {
+		return x.Authority
+	}
+	return ""
+}
+
+func (x *Regulation) GetEffectiveDate() string {
+	if x != nil {
+		return x.EffectiveDate
+	}
+	return ""
+}
+
+func (x *Regulation) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Regulation) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Regulation) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Regulation) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
 	}
 	return ""
 }
@@ -1453,13 +2645,14 @@ type RegulationList struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Regulations   []*Regulation          `protobuf:"bytes,1,rep,name=regulations,proto3" json:"regulations,omitempty"`
 	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // Empty on the last page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RegulationList) Reset() {
 	*x = RegulationList{}
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[17]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1471,7 +2664,7 @@ func (x *RegulationList) String() string {
 func (*RegulationList) ProtoMessage() {}
 
 func (x *RegulationList) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[17]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1484,7 +2677,7 @@ func (x *RegulationList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegulationList.ProtoReflect.Descriptor instead.
 func (*RegulationList) Descriptor() ([]byte, []int) {
-	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{17}
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *RegulationList) GetRegulations() []*Regulation {
@@ -1501,6 +2694,13 @@ func (x *RegulationList) GetTotalCount() int32 {
 	return 0
 }
 
+func (x *RegulationList) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
 type Document struct {
 	state              protoimpl.MessageState `protogen:"open.v1"`
 	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -1520,7 +2720,7 @@ type Document struct {
 
 func (x *Document) Reset() {
 	*x = Document{}
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[18]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1532,7 +2732,7 @@ func (x *Document) String() string {
 func (*Document) ProtoMessage() {}
 
 func (x *Document) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[18]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1545,7 +2745,7 @@ func (x *Document) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Document.ProtoReflect.Descriptor instead.
 func (*Document) Descriptor() ([]byte, []int) {
-	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{18}
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *Document) GetId() string {
@@ -1629,13 +2829,14 @@ type DocumentList struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Documents     []*Document            `protobuf:"bytes,1,rep,name=documents,proto3" json:"documents,omitempty"`
 	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // Empty on the last page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DocumentList) Reset() {
 	*x = DocumentList{}
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[19]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1647,7 +2848,7 @@ func (x *DocumentList) String() string {
 func (*DocumentList) ProtoMessage() {}
 
 func (x *DocumentList) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[19]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1660,7 +2861,7 @@ func (x *DocumentList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DocumentList.ProtoReflect.Descriptor instead.
 func (*DocumentList) Descriptor() ([]byte, []int) {
-	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{19}
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *DocumentList) GetDocuments() []*Document {
@@ -1677,6 +2878,13 @@ func (x *DocumentList) GetTotalCount() int32 {
 	return 0
 }
 
+func (x *DocumentList) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
 type Concept struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -1693,7 +2901,7 @@ type Concept struct {
 
 func (x *Concept) Reset() {
 	*x = Concept{}
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[20]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1705,7 +2913,7 @@ func (x *Concept) String() string {
 func (*Concept) ProtoMessage() {}
 
 func (x *Concept) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[20]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1718,7 +2926,7 @@ func (x *Concept) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Concept.ProtoReflect.Descriptor instead.
 func (*Concept) Descriptor() ([]byte, []int) {
-	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{20}
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *Concept) GetId() string {
@@ -1787,7 +2995,7 @@ type ConceptList struct {
 
 func (x *ConceptList) Reset() {
 	*x = ConceptList{}
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[21]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1799,7 +3007,7 @@ func (x *ConceptList) String() string {
 func (*ConceptList) ProtoMessage() {}
 
 func (x *ConceptList) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[21]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1812,7 +3020,7 @@ func (x *ConceptList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConceptList.ProtoReflect.Descriptor instead.
 func (*ConceptList) Descriptor() ([]byte, []int) {
-	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{21}
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *ConceptList) GetConcepts() []*Concept {
@@ -1845,14 +3053,15 @@ type Attribute struct {
 	ValidationRules string                 `protobuf:"bytes,12,opt,name=validation_rules,json=validationRules,proto3" json:"validation_rules,omitempty"` // JSON string
 	IsPii           bool                   `protobuf:"varint,13,opt,name=is_pii,json=isPii,proto3" json:"is_pii,omitempty"`
 	IsRequired      bool                   `protobuf:"varint,14,opt,name=is_required,json=isRequired,proto3" json:"is_required,omitempty"`
-	Metadata        string                 `protobuf:"bytes,15,opt,name=metadata,proto3" json:"metadata,omitempty"` // JSON string
+	Metadata        string                 `protobuf:"bytes,15,opt,name=metadata,proto3" json:"metadata,omitempty"`                      // JSON string
+	MatchRank       float64                `protobuf:"fixed64,16,opt,name=match_rank,json=matchRank,proto3" json:"match_rank,omitempty"` // SearchAttributes: how well the attribute matched (0-1)
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
 
 func (x *Attribute) Reset() {
 	*x = Attribute{}
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[22]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1864,7 +3073,7 @@ func (x *Attribute) String() string {
 func (*Attribute) ProtoMessage() {}
 
 func (x *Attribute) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[22]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1877,7 +3086,7 @@ func (x *Attribute) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Attribute.ProtoReflect.Descriptor instead.
 func (*Attribute) Descriptor() ([]byte, []int) {
-	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{22}
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *Attribute) GetId() string {
@@ -1985,17 +3194,25 @@ func (x *Attribute) GetMetadata() string {
 	return ""
 }
 
+func (x *Attribute) GetMatchRank() float64 {
+	if x != nil {
+		return x.MatchRank
+	}
+	return 0
+}
+
 type AttributeList struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Attributes    []*Attribute           `protobuf:"bytes,1,rep,name=attributes,proto3" json:"attributes,omitempty"`
 	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // Empty on the last page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *AttributeList) Reset() {
 	*x = AttributeList{}
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[23]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2007,7 +3224,7 @@ func (x *AttributeList) String() string {
 func (*AttributeList) ProtoMessage() {}
 
 func (x *AttributeList) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[23]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2020,7 +3237,7 @@ func (x *AttributeList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AttributeList.ProtoReflect.Descriptor instead.
 func (*AttributeList) Descriptor() ([]byte, []int) {
-	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{23}
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *AttributeList) GetAttributes() []*Attribute {
@@ -2037,6 +3254,13 @@ func (x *AttributeList) GetTotalCount() int32 {
 	return 0
 }
 
+func (x *AttributeList) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
 // Entity Requests
 type GetEntityRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -2047,7 +3271,7 @@ type GetEntityRequest struct {
 
 func (x *GetEntityRequest) Reset() {
 	*x = GetEntityRequest{}
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[24]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2059,7 +3283,7 @@ func (x *GetEntityRequest) String() string {
 func (*GetEntityRequest) ProtoMessage() {}
 
 func (x *GetEntityRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[24]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2072,7 +3296,7 @@ func (x *GetEntityRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetEntityRequest.ProtoReflect.Descriptor instead.
 func (*GetEntityRequest) Descriptor() ([]byte, []int) {
-	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{24}
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *GetEntityRequest) GetId() string {
@@ -2085,17 +3309,18 @@ func (x *GetEntityRequest) GetId() string {
 type ListEntitiesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`                          // Deprecated: ignored when cursor is set
 	EntityType    string                 `protobuf:"bytes,3,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"` // Optional filter
 	Jurisdiction  string                 `protobuf:"bytes,4,opt,name=jurisdiction,proto3" json:"jurisdiction,omitempty"`               // Optional filter
 	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`                           // Optional filter
+	Cursor        string                 `protobuf:"bytes,6,opt,name=cursor,proto3" json:"cursor,omitempty"`                           // next_cursor of the previous page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListEntitiesRequest) Reset() {
 	*x = ListEntitiesRequest{}
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[25]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2107,7 +3332,7 @@ func (x *ListEntitiesRequest) String() string {
 func (*ListEntitiesRequest) ProtoMessage() {}
 
 func (x *ListEntitiesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[25]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2120,7 +3345,7 @@ func (x *ListEntitiesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListEntitiesRequest.ProtoReflect.Descriptor instead.
 func (*ListEntitiesRequest) Descriptor() ([]byte, []int) {
-	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{25}
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *ListEntitiesRequest) GetLimit() int32 {
@@ -2158,6 +3383,13 @@ func (x *ListEntitiesRequest) GetStatus() string {
 	return ""
 }
 
+func (x *ListEntitiesRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
 type CreateEntityRequest struct {
 	state              protoimpl.MessageState `protogen:"open.v1"`
 	Name               string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -2175,7 +3407,7 @@ type CreateEntityRequest struct {
 
 func (x *CreateEntityRequest) Reset() {
 	*x = CreateEntityRequest{}
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[26]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2187,7 +3419,7 @@ func (x *CreateEntityRequest) String() string {
 func (*CreateEntityRequest) ProtoMessage() {}
 
 func (x *CreateEntityRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[26]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2200,7 +3432,7 @@ func (x *CreateEntityRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateEntityRequest.ProtoReflect.Descriptor instead.
 func (*CreateEntityRequest) Descriptor() ([]byte, []int) {
-	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{26}
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *CreateEntityRequest) GetName() string {
@@ -2279,7 +3511,7 @@ type UpdateEntityRequest struct {
 
 func (x *UpdateEntityRequest) Reset() {
 	*x = UpdateEntityRequest{}
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[27]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2291,7 +3523,7 @@ func (x *UpdateEntityRequest) String() string {
 func (*UpdateEntityRequest) ProtoMessage() {}
 
 func (x *UpdateEntityRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[27]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2304,7 +3536,7 @@ func (x *UpdateEntityRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateEntityRequest.ProtoReflect.Descriptor instead.
 func (*UpdateEntityRequest) Descriptor() ([]byte, []int) {
-	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{27}
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *UpdateEntityRequest) GetId() string {
@@ -2352,7 +3584,7 @@ type GetCbuRequest struct {
 
 func (x *GetCbuRequest) Reset() {
 	*x = GetCbuRequest{}
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[28]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2364,7 +3596,7 @@ func (x *GetCbuRequest) String() string {
 func (*GetCbuRequest) ProtoMessage() {}
 
 func (x *GetCbuRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[28]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2377,7 +3609,7 @@ func (x *GetCbuRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCbuRequest.ProtoReflect.Descriptor instead.
 func (*GetCbuRequest) Descriptor() ([]byte, []int) {
-	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{28}
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *GetCbuRequest) GetId() string {
@@ -2399,7 +3631,7 @@ type ListCbusRequest struct {
 
 func (x *ListCbusRequest) Reset() {
 	*x = ListCbusRequest{}
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[29]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2411,7 +3643,7 @@ func (x *ListCbusRequest) String() string {
 func (*ListCbusRequest) ProtoMessage() {}
 
 func (x *ListCbusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[29]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2424,7 +3656,7 @@ func (x *ListCbusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListCbusRequest.ProtoReflect.Descriptor instead.
 func (*ListCbusRequest) Descriptor() ([]byte, []int) {
-	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{29}
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{42}
 }
 
 func (x *ListCbusRequest) GetLimit() int32 {
@@ -2469,7 +3701,7 @@ type CreateCbuRequest struct {
 
 func (x *CreateCbuRequest) Reset() {
 	*x = CreateCbuRequest{}
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[30]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2481,7 +3713,7 @@ func (x *CreateCbuRequest) String() string {
 func (*CreateCbuRequest) ProtoMessage() {}
 
 func (x *CreateCbuRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[30]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2494,7 +3726,7 @@ func (x *CreateCbuRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateCbuRequest.ProtoReflect.Descriptor instead.
 func (*CreateCbuRequest) Descriptor() ([]byte, []int) {
-	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{30}
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{43}
 }
 
 func (x *CreateCbuRequest) GetName() string {
@@ -2550,7 +3782,7 @@ type GetCbuRolesRequest struct {
 
 func (x *GetCbuRolesRequest) Reset() {
 	*x = GetCbuRolesRequest{}
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[31]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2562,7 +3794,7 @@ func (x *GetCbuRolesRequest) String() string {
 func (*GetCbuRolesRequest) ProtoMessage() {}
 
 func (x *GetCbuRolesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[31]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2575,7 +3807,7 @@ func (x *GetCbuRolesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCbuRolesRequest.ProtoReflect.Descriptor instead.
 func (*GetCbuRolesRequest) Descriptor() ([]byte, []int) {
-	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{31}
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{44}
 }
 
 func (x *GetCbuRolesRequest) GetCbuId() string {
@@ -2614,7 +3846,7 @@ type AssignCbuRoleRequest struct {
 
 func (x *AssignCbuRoleRequest) Reset() {
 	*x = AssignCbuRoleRequest{}
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[32]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2626,7 +3858,7 @@ func (x *AssignCbuRoleRequest) String() string {
 func (*AssignCbuRoleRequest) ProtoMessage() {}
 
 func (x *AssignCbuRoleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[32]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2639,7 +3871,7 @@ func (x *AssignCbuRoleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AssignCbuRoleRequest.ProtoReflect.Descriptor instead.
 func (*AssignCbuRoleRequest) Descriptor() ([]byte, []int) {
-	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{32}
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{45}
 }
 
 func (x *AssignCbuRoleRequest) GetCbuId() string {
@@ -2703,7 +3935,7 @@ type GetEntityControlRequest struct {
 
 func (x *GetEntityControlRequest) Reset() {
 	*x = GetEntityControlRequest{}
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[33]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2715,7 +3947,7 @@ func (x *GetEntityControlRequest) String() string {
 func (*GetEntityControlRequest) ProtoMessage() {}
 
 func (x *GetEntityControlRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[33]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2728,7 +3960,7 @@ func (x *GetEntityControlRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetEntityControlRequest.ProtoReflect.Descriptor instead.
 func (*GetEntityControlRequest) Descriptor() ([]byte, []int) {
-	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{33}
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{46}
 }
 
 func (x *GetEntityControlRequest) GetEntityId() string {
@@ -2770,7 +4002,7 @@ type CreateControlRequest struct {
 
 func (x *CreateControlRequest) Reset() {
 	*x = CreateControlRequest{}
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[34]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2782,7 +4014,7 @@ func (x *CreateControlRequest) String() string {
 func (*CreateControlRequest) ProtoMessage() {}
 
 func (x *CreateControlRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[34]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2795,7 +4027,7 @@ func (x *CreateControlRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateControlRequest.ProtoReflect.Descriptor instead.
 func (*CreateControlRequest) Descriptor() ([]byte, []int) {
-	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{34}
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{47}
 }
 
 func (x *CreateControlRequest) GetControllerEntityId() string {
@@ -2856,42 +4088,101 @@ func (x *CreateControlRequest) GetIndirectViaEntityId() string {
 
 func (x *CreateControlRequest) GetRemarks() string {
 	if x != nil {
-		return x.Remarks
+		return x.Remarks
+	}
+	return ""
+}
+
+func (x *CreateControlRequest) GetSourceDocument() string {
+	if x != nil {
+		return x.SourceDocument
+	}
+	return ""
+}
+
+type GetControlChainRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartEntityId string                 `protobuf:"bytes,1,opt,name=start_entity_id,json=startEntityId,proto3" json:"start_entity_id,omitempty"`
+	EndEntityId   string                 `protobuf:"bytes,2,opt,name=end_entity_id,json=endEntityId,proto3" json:"end_entity_id,omitempty"`
+	ControlType   string                 `protobuf:"bytes,3,opt,name=control_type,json=controlType,proto3" json:"control_type,omitempty"` // Optional filter
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetControlChainRequest) Reset() {
+	*x = GetControlChainRequest{}
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetControlChainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetControlChainRequest) ProtoMessage() {}
+
+func (x *GetControlChainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetControlChainRequest.ProtoReflect.Descriptor instead.
+func (*GetControlChainRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *GetControlChainRequest) GetStartEntityId() string {
+	if x != nil {
+		return x.StartEntityId
+	}
+	return ""
+}
+
+func (x *GetControlChainRequest) GetEndEntityId() string {
+	if x != nil {
+		return x.EndEntityId
 	}
 	return ""
 }
 
-func (x *CreateControlRequest) GetSourceDocument() string {
+func (x *GetControlChainRequest) GetControlType() string {
 	if x != nil {
-		return x.SourceDocument
+		return x.ControlType
 	}
 	return ""
 }
 
-type GetControlChainRequest struct {
+type ComputeUboRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	StartEntityId string                 `protobuf:"bytes,1,opt,name=start_entity_id,json=startEntityId,proto3" json:"start_entity_id,omitempty"`
-	EndEntityId   string                 `protobuf:"bytes,2,opt,name=end_entity_id,json=endEntityId,proto3" json:"end_entity_id,omitempty"`
-	ControlType   string                 `protobuf:"bytes,3,opt,name=control_type,json=controlType,proto3" json:"control_type,omitempty"` // Optional filter
+	EntityId      string                 `protobuf:"bytes,1,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	Threshold     float64                `protobuf:"fixed64,2,opt,name=threshold,proto3" json:"threshold,omitempty"` // Default 25
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetControlChainRequest) Reset() {
-	*x = GetControlChainRequest{}
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[35]
+func (x *ComputeUboRequest) Reset() {
+	*x = ComputeUboRequest{}
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetControlChainRequest) String() string {
+func (x *ComputeUboRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetControlChainRequest) ProtoMessage() {}
+func (*ComputeUboRequest) ProtoMessage() {}
 
-func (x *GetControlChainRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[35]
+func (x *ComputeUboRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2902,30 +4193,23 @@ func (x *GetControlChainRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetControlChainRequest.ProtoReflect.Descriptor instead.
-func (*GetControlChainRequest) Descriptor() ([]byte, []int) {
-	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{35}
-}
-
-func (x *GetControlChainRequest) GetStartEntityId() string {
-	if x != nil {
-		return x.StartEntityId
-	}
-	return ""
+// Deprecated: Use ComputeUboRequest.ProtoReflect.Descriptor instead.
+func (*ComputeUboRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *GetControlChainRequest) GetEndEntityId() string {
+func (x *ComputeUboRequest) GetEntityId() string {
 	if x != nil {
-		return x.EndEntityId
+		return x.EntityId
 	}
 	return ""
 }
 
-func (x *GetControlChainRequest) GetControlType() string {
+func (x *ComputeUboRequest) GetThreshold() float64 {
 	if x != nil {
-		return x.ControlType
+		return x.Threshold
 	}
-	return ""
+	return 0
 }
 
 // KYC Profile Requests
@@ -2938,7 +4222,7 @@ type GetKycProfileRequest struct {
 
 func (x *GetKycProfileRequest) Reset() {
 	*x = GetKycProfileRequest{}
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[36]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2950,7 +4234,7 @@ func (x *GetKycProfileRequest) String() string {
 func (*GetKycProfileRequest) ProtoMessage() {}
 
 func (x *GetKycProfileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[36]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2963,7 +4247,7 @@ func (x *GetKycProfileRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetKycProfileRequest.ProtoReflect.Descriptor instead.
 func (*GetKycProfileRequest) Descriptor() ([]byte, []int) {
-	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{36}
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{50}
 }
 
 func (x *GetKycProfileRequest) GetEntityId() string {
@@ -2991,7 +4275,7 @@ type UpdateKycProfileRequest struct {
 
 func (x *UpdateKycProfileRequest) Reset() {
 	*x = UpdateKycProfileRequest{}
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[37]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3003,7 +4287,7 @@ func (x *UpdateKycProfileRequest) String() string {
 func (*UpdateKycProfileRequest) ProtoMessage() {}
 
 func (x *UpdateKycProfileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[37]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3016,7 +4300,7 @@ func (x *UpdateKycProfileRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateKycProfileRequest.ProtoReflect.Descriptor instead.
 func (*UpdateKycProfileRequest) Descriptor() ([]byte, []int) {
-	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{37}
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{51}
 }
 
 func (x *UpdateKycProfileRequest) GetEntityId() string {
@@ -3089,6 +4373,241 @@ func (x *UpdateKycProfileRequest) GetMetadata() string {
 	return ""
 }
 
+// Entity 360 Requests
+type GetEntity360Request struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	EntityId        string                 `protobuf:"bytes,1,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	EvaluationLimit int32                  `protobuf:"varint,2,opt,name=evaluation_limit,json=evaluationLimit,proto3" json:"evaluation_limit,omitempty"` // Recent evaluations to include (default 20)
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetEntity360Request) Reset() {
+	*x = GetEntity360Request{}
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEntity360Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEntity360Request) ProtoMessage() {}
+
+func (x *GetEntity360Request) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEntity360Request.ProtoReflect.Descriptor instead.
+func (*GetEntity360Request) Descriptor() ([]byte, []int) {
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *GetEntity360Request) GetEntityId() string {
+	if x != nil {
+		return x.EntityId
+	}
+	return ""
+}
+
+func (x *GetEntity360Request) GetEvaluationLimit() int32 {
+	if x != nil {
+		return x.EvaluationLimit
+	}
+	return 0
+}
+
+// Lineage Requests
+type GetLineageGraphRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attribute     string                 `protobuf:"bytes,1,opt,name=attribute,proto3" json:"attribute,omitempty"`
+	MaxDepth      int32                  `protobuf:"varint,2,opt,name=max_depth,json=maxDepth,proto3" json:"max_depth,omitempty"` // Steps in each direction (default 10)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLineageGraphRequest) Reset() {
+	*x = GetLineageGraphRequest{}
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLineageGraphRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLineageGraphRequest) ProtoMessage() {}
+
+func (x *GetLineageGraphRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLineageGraphRequest.ProtoReflect.Descriptor instead.
+func (*GetLineageGraphRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *GetLineageGraphRequest) GetAttribute() string {
+	if x != nil {
+		return x.Attribute
+	}
+	return ""
+}
+
+func (x *GetLineageGraphRequest) GetMaxDepth() int32 {
+	if x != nil {
+		return x.MaxDepth
+	}
+	return 0
+}
+
+type ValidateRuleRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Rule             string                 `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	SourceAttributes []string               `protobuf:"bytes,2,rep,name=source_attributes,json=sourceAttributes,proto3" json:"source_attributes,omitempty"` // Optional: checked against the rule
+	RuleType         string                 `protobuf:"bytes,3,opt,name=rule_type,json=ruleType,proto3" json:"rule_type,omitempty"`                         // Optional: Boolean, Numeric, String
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ValidateRuleRequest) Reset() {
+	*x = ValidateRuleRequest{}
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateRuleRequest) ProtoMessage() {}
+
+func (x *ValidateRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateRuleRequest.ProtoReflect.Descriptor instead.
+func (*ValidateRuleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ValidateRuleRequest) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *ValidateRuleRequest) GetSourceAttributes() []string {
+	if x != nil {
+		return x.SourceAttributes
+	}
+	return nil
+}
+
+func (x *ValidateRuleRequest) GetRuleType() string {
+	if x != nil {
+		return x.RuleType
+	}
+	return ""
+}
+
+// Screening Requests
+type ScreenEntityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntityId      string                 `protobuf:"bytes,1,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	CaseId        string                 `protobuf:"bytes,2,opt,name=case_id,json=caseId,proto3" json:"case_id,omitempty"` // Optional: write the outcome to this case
+	IncludeUbos   bool                   `protobuf:"varint,3,opt,name=include_ubos,json=includeUbos,proto3" json:"include_ubos,omitempty"`
+	Kind          string                 `protobuf:"bytes,4,opt,name=kind,proto3" json:"kind,omitempty"` // sanctions (default), pep, adverse_media
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScreenEntityRequest) Reset() {
+	*x = ScreenEntityRequest{}
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScreenEntityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScreenEntityRequest) ProtoMessage() {}
+
+func (x *ScreenEntityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScreenEntityRequest.ProtoReflect.Descriptor instead.
+func (*ScreenEntityRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ScreenEntityRequest) GetEntityId() string {
+	if x != nil {
+		return x.EntityId
+	}
+	return ""
+}
+
+func (x *ScreenEntityRequest) GetCaseId() string {
+	if x != nil {
+		return x.CaseId
+	}
+	return ""
+}
+
+func (x *ScreenEntityRequest) GetIncludeUbos() bool {
+	if x != nil {
+		return x.IncludeUbos
+	}
+	return false
+}
+
+func (x *ScreenEntityRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
 // Dictionary Requests
 type GetAttributeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -3099,7 +4618,7 @@ type GetAttributeRequest struct {
 
 func (x *GetAttributeRequest) Reset() {
 	*x = GetAttributeRequest{}
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[38]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3111,7 +4630,7 @@ func (x *GetAttributeRequest) String() string {
 func (*GetAttributeRequest) ProtoMessage() {}
 
 func (x *GetAttributeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[38]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3124,7 +4643,7 @@ func (x *GetAttributeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAttributeRequest.ProtoReflect.Descriptor instead.
 func (*GetAttributeRequest) Descriptor() ([]byte, []int) {
-	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{38}
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{56}
 }
 
 func (x *GetAttributeRequest) GetId() string {
@@ -3137,17 +4656,18 @@ func (x *GetAttributeRequest) GetId() string {
 type ListAttributesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`                           // Deprecated: ignored when cursor is set
 	Jurisdiction  string                 `protobuf:"bytes,3,opt,name=jurisdiction,proto3" json:"jurisdiction,omitempty"`                // Optional filter
 	AttrType      string                 `protobuf:"bytes,4,opt,name=attr_type,json=attrType,proto3" json:"attr_type,omitempty"`        // Optional filter
 	IsRequired    bool                   `protobuf:"varint,5,opt,name=is_required,json=isRequired,proto3" json:"is_required,omitempty"` // Optional filter
+	Cursor        string                 `protobuf:"bytes,6,opt,name=cursor,proto3" json:"cursor,omitempty"`                            // next_cursor of the previous page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListAttributesRequest) Reset() {
 	*x = ListAttributesRequest{}
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[39]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3159,7 +4679,7 @@ func (x *ListAttributesRequest) String() string {
 func (*ListAttributesRequest) ProtoMessage() {}
 
 func (x *ListAttributesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[39]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3172,7 +4692,7 @@ func (x *ListAttributesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListAttributesRequest.ProtoReflect.Descriptor instead.
 func (*ListAttributesRequest) Descriptor() ([]byte, []int) {
-	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{39}
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{57}
 }
 
 func (x *ListAttributesRequest) GetLimit() int32 {
@@ -3210,6 +4730,13 @@ func (x *ListAttributesRequest) GetIsRequired() bool {
 	return false
 }
 
+func (x *ListAttributesRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
 type GetConceptRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -3219,7 +4746,7 @@ type GetConceptRequest struct {
 
 func (x *GetConceptRequest) Reset() {
 	*x = GetConceptRequest{}
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[40]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3231,7 +4758,7 @@ func (x *GetConceptRequest) String() string {
 func (*GetConceptRequest) ProtoMessage() {}
 
 func (x *GetConceptRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[40]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3244,7 +4771,7 @@ func (x *GetConceptRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetConceptRequest.ProtoReflect.Descriptor instead.
 func (*GetConceptRequest) Descriptor() ([]byte, []int) {
-	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{40}
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{58}
 }
 
 func (x *GetConceptRequest) GetId() string {
@@ -3265,7 +4792,7 @@ type ListConceptsRequest struct {
 
 func (x *ListConceptsRequest) Reset() {
 	*x = ListConceptsRequest{}
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[41]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3277,7 +4804,7 @@ func (x *ListConceptsRequest) String() string {
 func (*ListConceptsRequest) ProtoMessage() {}
 
 func (x *ListConceptsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[41]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3290,7 +4817,7 @@ func (x *ListConceptsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListConceptsRequest.ProtoReflect.Descriptor instead.
 func (*ListConceptsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{41}
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{59}
 }
 
 func (x *ListConceptsRequest) GetLimit() int32 {
@@ -3323,7 +4850,7 @@ type GetRegulationRequest struct {
 
 func (x *GetRegulationRequest) Reset() {
 	*x = GetRegulationRequest{}
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[42]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3335,7 +4862,7 @@ func (x *GetRegulationRequest) String() string {
 func (*GetRegulationRequest) ProtoMessage() {}
 
 func (x *GetRegulationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[42]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3348,7 +4875,7 @@ func (x *GetRegulationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRegulationRequest.ProtoReflect.Descriptor instead.
 func (*GetRegulationRequest) Descriptor() ([]byte, []int) {
-	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{42}
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{60}
 }
 
 func (x *GetRegulationRequest) GetId() string {
@@ -3361,16 +4888,17 @@ func (x *GetRegulationRequest) GetId() string {
 type ListRegulationsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`            // Deprecated: ignored when cursor is set
 	Jurisdiction  string                 `protobuf:"bytes,3,opt,name=jurisdiction,proto3" json:"jurisdiction,omitempty"` // Optional filter
 	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`             // Optional filter
+	Cursor        string                 `protobuf:"bytes,5,opt,name=cursor,proto3" json:"cursor,omitempty"`             // next_cursor of the previous page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListRegulationsRequest) Reset() {
 	*x = ListRegulationsRequest{}
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[43]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3382,7 +4910,7 @@ func (x *ListRegulationsRequest) String() string {
 func (*ListRegulationsRequest) ProtoMessage() {}
 
 func (x *ListRegulationsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[43]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3395,7 +4923,7 @@ func (x *ListRegulationsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListRegulationsRequest.ProtoReflect.Descriptor instead.
 func (*ListRegulationsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{43}
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{61}
 }
 
 func (x *ListRegulationsRequest) GetLimit() int32 {
@@ -3426,6 +4954,13 @@ func (x *ListRegulationsRequest) GetStatus() string {
 	return ""
 }
 
+func (x *ListRegulationsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
 type GetDocumentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -3435,7 +4970,7 @@ type GetDocumentRequest struct {
 
 func (x *GetDocumentRequest) Reset() {
 	*x = GetDocumentRequest{}
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[44]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3447,7 +4982,7 @@ func (x *GetDocumentRequest) String() string {
 func (*GetDocumentRequest) ProtoMessage() {}
 
 func (x *GetDocumentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[44]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3460,7 +4995,7 @@ func (x *GetDocumentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDocumentRequest.ProtoReflect.Descriptor instead.
 func (*GetDocumentRequest) Descriptor() ([]byte, []int) {
-	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{44}
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{62}
 }
 
 func (x *GetDocumentRequest) GetId() string {
@@ -3473,17 +5008,18 @@ func (x *GetDocumentRequest) GetId() string {
 type ListDocumentsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`                              // Deprecated: ignored when cursor is set
 	Jurisdiction  string                 `protobuf:"bytes,3,opt,name=jurisdiction,proto3" json:"jurisdiction,omitempty"`                   // Optional filter
 	Category      string                 `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`                           // Optional filter
 	IsMandatory   bool                   `protobuf:"varint,5,opt,name=is_mandatory,json=isMandatory,proto3" json:"is_mandatory,omitempty"` // Optional filter
+	Cursor        string                 `protobuf:"bytes,6,opt,name=cursor,proto3" json:"cursor,omitempty"`                               // next_cursor of the previous page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListDocumentsRequest) Reset() {
 	*x = ListDocumentsRequest{}
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[45]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3495,7 +5031,7 @@ func (x *ListDocumentsRequest) String() string {
 func (*ListDocumentsRequest) ProtoMessage() {}
 
 func (x *ListDocumentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[45]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3508,7 +5044,7 @@ func (x *ListDocumentsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListDocumentsRequest.ProtoReflect.Descriptor instead.
 func (*ListDocumentsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{45}
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{63}
 }
 
 func (x *ListDocumentsRequest) GetLimit() int32 {
@@ -3546,6 +5082,13 @@ func (x *ListDocumentsRequest) GetIsMandatory() bool {
 	return false
 }
 
+func (x *ListDocumentsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
 // Search Request (unified for semantic search)
 type SearchRequest struct {
 	state               protoimpl.MessageState `protogen:"open.v1"`
@@ -3553,14 +5096,14 @@ type SearchRequest struct {
 	Limit               int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
 	Offset              int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
 	Domain              string                 `protobuf:"bytes,4,opt,name=domain,proto3" json:"domain,omitempty"`                                                        // Optional domain filter
-	SimilarityThreshold float64                `protobuf:"fixed64,5,opt,name=similarity_threshold,json=similarityThreshold,proto3" json:"similarity_threshold,omitempty"` // Minimum similarity score (0.0-1.0)
+	SimilarityThreshold float64                `protobuf:"fixed64,5,opt,name=similarity_threshold,json=similarityThreshold,proto3" json:"similarity_threshold,omitempty"` // Minimum similarity score (0.0-1.0); search.match_threshold when 0
 	unknownFields       protoimpl.UnknownFields
 	sizeCache           protoimpl.SizeCache
 }
 
 func (x *SearchRequest) Reset() {
 	*x = SearchRequest{}
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[46]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3572,7 +5115,7 @@ func (x *SearchRequest) String() string {
 func (*SearchRequest) ProtoMessage() {}
 
 func (x *SearchRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shared_ontology_service_proto_msgTypes[46]
+	mi := &file_proto_shared_ontology_service_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3585,7 +5128,7 @@ func (x *SearchRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
 func (*SearchRequest) Descriptor() ([]byte, []int) {
-	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{46}
+	return file_proto_shared_ontology_service_proto_rawDescGZIP(), []int{64}
 }
 
 func (x *SearchRequest) GetQuery() string {
@@ -3627,7 +5170,7 @@ var File_proto_shared_ontology_service_proto protoreflect.FileDescriptor
 
 const file_proto_shared_ontology_service_proto_rawDesc = "" +
 	"\n" +
-	"#proto_shared/ontology_service.proto\x12\fkyc.ontology\"\xca\x03\n" +
+	"#proto_shared/ontology_service.proto\x12\fkyc.ontology\"\xe9\x03\n" +
 	"\x06Entity\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1f\n" +
@@ -3647,12 +5190,16 @@ const file_proto_shared_ontology_service_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\r \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\x0e \x01(\tR\tupdatedAt\"_\n" +
+	"updated_at\x18\x0e \x01(\tR\tupdatedAt\x12\x1d\n" +
+	"\n" +
+	"match_rank\x18\x0f \x01(\x01R\tmatchRank\"\x80\x01\n" +
 	"\n" +
 	"EntityList\x120\n" +
 	"\bentities\x18\x01 \x03(\v2\x14.kyc.ontology.EntityR\bentities\x12\x1f\n" +
 	"\vtotal_count\x18\x02 \x01(\x05R\n" +
-	"totalCount\"]\n" +
+	"totalCount\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\"]\n" +
 	"\x0eEntityResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
 	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1b\n" +
@@ -3735,7 +5282,29 @@ const file_proto_shared_ontology_service_proto_rawDesc = "" +
 	"totalEdges\"\x7f\n" +
 	"\fControlChain\x121\n" +
 	"\x05chain\x18\x01 \x03(\v2\x1b.kyc.ontology.EntityControlR\x05chain\x12<\n" +
-	"\x1atotal_effective_percentage\x18\x02 \x01(\x01R\x18totalEffectivePercentage\"`\n" +
+	"\x1atotal_effective_percentage\x18\x02 \x01(\x01R\x18totalEffectivePercentage\"\x82\x02\n" +
+	"\tUboRollup\x12\x1b\n" +
+	"\tentity_id\x18\x01 \x01(\tR\bentityId\x12\x1c\n" +
+	"\tthreshold\x18\x02 \x01(\x01R\tthreshold\x12.\n" +
+	"\x06owners\x18\x03 \x03(\v2\x16.kyc.ontology.UboOwnerR\x06owners\x123\n" +
+	"\x15attributed_percentage\x18\x04 \x01(\x01R\x14attributedPercentage\x127\n" +
+	"\x17unattributed_percentage\x18\x05 \x01(\x01R\x16unattributedPercentage\x12\x1c\n" +
+	"\ttruncated\x18\x06 \x01(\bR\ttruncated\"\xf5\x01\n" +
+	"\bUboOwner\x12\x1b\n" +
+	"\tentity_id\x18\x01 \x01(\tR\bentityId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1f\n" +
+	"\ventity_type\x18\x03 \x01(\tR\n" +
+	"entityType\x121\n" +
+	"\x14effective_percentage\x18\x04 \x01(\x01R\x13effectivePercentage\x12\x15\n" +
+	"\x06is_ubo\x18\x05 \x01(\bR\x05isUbo\x12\x1a\n" +
+	"\bcircular\x18\x06 \x01(\bR\bcircular\x121\n" +
+	"\x05paths\x18\a \x03(\v2\x1b.kyc.ontology.OwnershipPathR\x05paths\"N\n" +
+	"\rOwnershipPath\x12\x1d\n" +
+	"\n" +
+	"entity_ids\x18\x01 \x03(\tR\tentityIds\x12\x1e\n" +
+	"\n" +
+	"percentage\x18\x02 \x01(\x01R\n" +
+	"percentage\"`\n" +
 	"\x0fControlResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
 	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
@@ -3762,7 +5331,99 @@ const file_proto_shared_ontology_service_proto_rawDesc = "" +
 	"\x12KycProfileResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
 	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1b\n" +
-	"\tentity_id\x18\x03 \x01(\tR\bentityId\"\x95\x02\n" +
+	"\tentity_id\x18\x03 \x01(\tR\bentityId\"\x8d\x04\n" +
+	"\tEntity360\x12,\n" +
+	"\x06entity\x18\x01 \x01(\v2\x14.kyc.ontology.EntityR\x06entity\x122\n" +
+	"\tcbu_roles\x18\x02 \x03(\v2\x15.kyc.ontology.CbuRoleR\bcbuRoles\x12%\n" +
+	"\x04cbus\x18\x03 \x03(\v2\x11.kyc.ontology.CbuR\x04cbus\x12=\n" +
+	"\vcontrollers\x18\x04 \x03(\v2\x1b.kyc.ontology.EntityControlR\vcontrollers\x12;\n" +
+	"\n" +
+	"controlled\x18\x05 \x03(\v2\x1b.kyc.ontology.EntityControlR\n" +
+	"controlled\x129\n" +
+	"\vkyc_profile\x18\x06 \x01(\v2\x18.kyc.ontology.KycProfileR\n" +
+	"kycProfile\x12<\n" +
+	"\tscreening\x18\a \x01(\v2\x1e.kyc.ontology.ScreeningSummaryR\tscreening\x125\n" +
+	"\n" +
+	"open_cases\x18\b \x03(\v2\x16.kyc.ontology.OpenCaseR\topenCases\x12K\n" +
+	"\x12recent_evaluations\x18\t \x03(\v2\x1c.kyc.ontology.RuleEvaluationR\x11recentEvaluations\"\x94\x02\n" +
+	"\x10ScreeningSummary\x124\n" +
+	"\x16sanctions_check_status\x18\x01 \x01(\tR\x14sanctionsCheckStatus\x12\x1d\n" +
+	"\n" +
+	"pep_status\x18\x02 \x01(\bR\tpepStatus\x120\n" +
+	"\x14adverse_media_status\x18\x03 \x01(\tR\x12adverseMediaStatus\x12(\n" +
+	"\x10last_review_date\x18\x04 \x01(\tR\x0elastReviewDate\x12(\n" +
+	"\x10next_review_date\x18\x05 \x01(\tR\x0enextReviewDate\x12%\n" +
+	"\x0ereview_overdue\x18\x06 \x01(\bR\rreviewOverdue\"\x97\x01\n" +
+	"\bOpenCase\x12\x1b\n" +
+	"\tcase_name\x18\x01 \x01(\tR\bcaseName\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x05R\aversion\x12\x19\n" +
+	"\bcbu_code\x18\x04 \x01(\tR\acbuCode\x12!\n" +
+	"\flast_updated\x18\x05 \x01(\tR\vlastUpdated\"\xe2\x01\n" +
+	"\x0eRuleEvaluation\x12\x1b\n" +
+	"\tcase_name\x18\x01 \x01(\tR\bcaseName\x12!\n" +
+	"\fderived_code\x18\x02 \x01(\tR\vderivedCode\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\x12\x18\n" +
+	"\asuccess\x18\x04 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x12'\n" +
+	"\x0fregulation_code\x18\x06 \x01(\tR\x0eregulationCode\x12!\n" +
+	"\fevaluated_at\x18\a \x01(\tR\vevaluatedAt\"\xac\x01\n" +
+	"\fLineageGraph\x12\x1c\n" +
+	"\tattribute\x18\x01 \x01(\tR\tattribute\x12/\n" +
+	"\x05nodes\x18\x02 \x03(\v2\x19.kyc.ontology.LineageNodeR\x05nodes\x12/\n" +
+	"\x05edges\x18\x03 \x03(\v2\x19.kyc.ontology.LineageEdgeR\x05edges\x12\x1c\n" +
+	"\ttruncated\x18\x04 \x01(\bR\ttruncated\"\x88\x01\n" +
+	"\vLineageNode\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12'\n" +
+	"\x0fattribute_class\x18\x03 \x01(\tR\x0eattributeClass\x12\x12\n" +
+	"\x04role\x18\x04 \x01(\tR\x04role\x12\x14\n" +
+	"\x05depth\x18\x05 \x01(\x05R\x05depth\"\xcb\x01\n" +
+	"\vLineageEdge\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x12\x16\n" +
+	"\x06target\x18\x03 \x01(\tR\x06target\x12\x12\n" +
+	"\x04rule\x18\x04 \x01(\tR\x04rule\x12\x1b\n" +
+	"\trule_type\x18\x05 \x01(\tR\bruleType\x12\"\n" +
+	"\fjurisdiction\x18\x06 \x01(\tR\fjurisdiction\x12'\n" +
+	"\x0fregulation_code\x18\a \x01(\tR\x0eregulationCode\"\xa6\x02\n" +
+	"\x0eRuleValidation\x12\x12\n" +
+	"\x04rule\x18\x01 \x01(\tR\x04rule\x12\x14\n" +
+	"\x05valid\x18\x02 \x01(\bR\x05valid\x12\x1f\n" +
+	"\vresult_type\x18\x03 \x01(\tR\n" +
+	"resultType\x12%\n" +
+	"\x0ecompile_errors\x18\x04 \x03(\tR\rcompileErrors\x12\x1f\n" +
+	"\vtype_errors\x18\x05 \x03(\tR\n" +
+	"typeErrors\x12-\n" +
+	"\x12unknown_attributes\x18\x06 \x03(\tR\x11unknownAttributes\x12#\n" +
+	"\runknown_lists\x18\a \x03(\tR\funknownLists\x12-\n" +
+	"\x12undeclared_sources\x18\b \x03(\tR\x11undeclaredSources\"\xd7\x02\n" +
+	"\tScreening\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1b\n" +
+	"\tentity_id\x18\x02 \x01(\tR\bentityId\x12\x1f\n" +
+	"\ventity_name\x18\x03 \x01(\tR\n" +
+	"entityName\x12\x17\n" +
+	"\acase_id\x18\x04 \x01(\tR\x06caseId\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x1a\n" +
+	"\bsubjects\x18\x06 \x01(\x05R\bsubjects\x12\x1c\n" +
+	"\tproviders\x18\a \x03(\tR\tproviders\x12\x16\n" +
+	"\x06errors\x18\b \x01(\tR\x06errors\x12\x14\n" +
+	"\x05actor\x18\t \x01(\tR\x05actor\x12\x1f\n" +
+	"\vscreened_at\x18\n" +
+	" \x01(\tR\n" +
+	"screenedAt\x12.\n" +
+	"\x04hits\x18\v \x03(\v2\x1a.kyc.ontology.ScreeningHitR\x04hits\x12\x12\n" +
+	"\x04kind\x18\f \x01(\tR\x04kind\"\x8e\x02\n" +
+	"\fScreeningHit\x12*\n" +
+	"\x11subject_entity_id\x18\x01 \x01(\tR\x0fsubjectEntityId\x12!\n" +
+	"\fsubject_name\x18\x02 \x01(\tR\vsubjectName\x12\x1a\n" +
+	"\bprovider\x18\x03 \x01(\tR\bprovider\x12\x12\n" +
+	"\x04kind\x18\x04 \x01(\tR\x04kind\x12\x1c\n" +
+	"\treference\x18\x05 \x01(\tR\treference\x12!\n" +
+	"\fmatched_name\x18\x06 \x01(\tR\vmatchedName\x12\x14\n" +
+	"\x05score\x18\a \x01(\x01R\x05score\x12\x16\n" +
+	"\x06detail\x18\b \x01(\tR\x06detail\x12\x10\n" +
+	"\x03url\x18\t \x01(\tR\x03url\"\x95\x02\n" +
 	"\n" +
 	"Regulation\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
@@ -3775,11 +5436,13 @@ const file_proto_shared_ontology_service_proto_rawDesc = "" +
 	"\x03url\x18\b \x01(\tR\x03url\x12\x16\n" +
 	"\x06status\x18\t \x01(\tR\x06status\x12\x1a\n" +
 	"\bmetadata\x18\n" +
-	" \x01(\tR\bmetadata\"m\n" +
+	" \x01(\tR\bmetadata\"\x8e\x01\n" +
 	"\x0eRegulationList\x12:\n" +
 	"\vregulations\x18\x01 \x03(\v2\x18.kyc.ontology.RegulationR\vregulations\x12\x1f\n" +
 	"\vtotal_count\x18\x02 \x01(\x05R\n" +
-	"totalCount\"\xce\x02\n" +
+	"totalCount\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\"\xce\x02\n" +
 	"\bDocument\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04code\x18\x02 \x01(\tR\x04code\x12\x14\n" +
@@ -3792,11 +5455,13 @@ const file_proto_shared_ontology_service_proto_rawDesc = "" +
 	"\x14validity_period_days\x18\t \x01(\x05R\x12validityPeriodDays\x12!\n" +
 	"\fis_mandatory\x18\n" +
 	" \x01(\bR\visMandatory\x12\x1a\n" +
-	"\bmetadata\x18\v \x01(\tR\bmetadata\"e\n" +
+	"\bmetadata\x18\v \x01(\tR\bmetadata\"\x86\x01\n" +
 	"\fDocumentList\x124\n" +
 	"\tdocuments\x18\x01 \x03(\v2\x16.kyc.ontology.DocumentR\tdocuments\x12\x1f\n" +
 	"\vtotal_count\x18\x02 \x01(\x05R\n" +
-	"totalCount\"\xdf\x01\n" +
+	"totalCount\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\"\xdf\x01\n" +
 	"\aConcept\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04code\x18\x02 \x01(\tR\x04code\x12\x12\n" +
@@ -3809,7 +5474,7 @@ const file_proto_shared_ontology_service_proto_rawDesc = "" +
 	"\vConceptList\x121\n" +
 	"\bconcepts\x18\x01 \x03(\v2\x15.kyc.ontology.ConceptR\bconcepts\x12\x1f\n" +
 	"\vtotal_count\x18\x02 \x01(\x05R\n" +
-	"totalCount\"\xd2\x03\n" +
+	"totalCount\"\xf1\x03\n" +
 	"\tAttribute\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04code\x18\x02 \x01(\tR\x04code\x12\x12\n" +
@@ -3830,22 +5495,27 @@ const file_proto_shared_ontology_service_proto_rawDesc = "" +
 	"\x06is_pii\x18\r \x01(\bR\x05isPii\x12\x1f\n" +
 	"\vis_required\x18\x0e \x01(\bR\n" +
 	"isRequired\x12\x1a\n" +
-	"\bmetadata\x18\x0f \x01(\tR\bmetadata\"i\n" +
+	"\bmetadata\x18\x0f \x01(\tR\bmetadata\x12\x1d\n" +
+	"\n" +
+	"match_rank\x18\x10 \x01(\x01R\tmatchRank\"\x8a\x01\n" +
 	"\rAttributeList\x127\n" +
 	"\n" +
 	"attributes\x18\x01 \x03(\v2\x17.kyc.ontology.AttributeR\n" +
 	"attributes\x12\x1f\n" +
 	"\vtotal_count\x18\x02 \x01(\x05R\n" +
-	"totalCount\"\"\n" +
+	"totalCount\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\"\"\n" +
 	"\x10GetEntityRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\xa0\x01\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xb8\x01\n" +
 	"\x13ListEntitiesRequest\x12\x14\n" +
 	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
 	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\x1f\n" +
 	"\ventity_type\x18\x03 \x01(\tR\n" +
 	"entityType\x12\"\n" +
 	"\fjurisdiction\x18\x04 \x01(\tR\fjurisdiction\x12\x16\n" +
-	"\x06status\x18\x05 \x01(\tR\x06status\"\xc6\x02\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x16\n" +
+	"\x06cursor\x18\x06 \x01(\tR\x06cursor\"\xc6\x02\n" +
 	"\x13CreateEntityRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1f\n" +
 	"\ventity_type\x18\x02 \x01(\tR\n" +
@@ -3914,7 +5584,10 @@ const file_proto_shared_ontology_service_proto_rawDesc = "" +
 	"\x16GetControlChainRequest\x12&\n" +
 	"\x0fstart_entity_id\x18\x01 \x01(\tR\rstartEntityId\x12\"\n" +
 	"\rend_entity_id\x18\x02 \x01(\tR\vendEntityId\x12!\n" +
-	"\fcontrol_type\x18\x03 \x01(\tR\vcontrolType\"3\n" +
+	"\fcontrol_type\x18\x03 \x01(\tR\vcontrolType\"N\n" +
+	"\x11ComputeUboRequest\x12\x1b\n" +
+	"\tentity_id\x18\x01 \x01(\tR\bentityId\x12\x1c\n" +
+	"\tthreshold\x18\x02 \x01(\x01R\tthreshold\"3\n" +
 	"\x14GetKycProfileRequest\x12\x1b\n" +
 	"\tentity_id\x18\x01 \x01(\tR\bentityId\"\xd9\x02\n" +
 	"\x17UpdateKycProfileRequest\x12\x1b\n" +
@@ -3931,16 +5604,32 @@ const file_proto_shared_ontology_service_proto_rawDesc = "" +
 	"pep_status\x18\b \x01(\bR\tpepStatus\x12\x18\n" +
 	"\aremarks\x18\t \x01(\tR\aremarks\x12\x1a\n" +
 	"\bmetadata\x18\n" +
-	" \x01(\tR\bmetadata\"%\n" +
+	" \x01(\tR\bmetadata\"]\n" +
+	"\x13GetEntity360Request\x12\x1b\n" +
+	"\tentity_id\x18\x01 \x01(\tR\bentityId\x12)\n" +
+	"\x10evaluation_limit\x18\x02 \x01(\x05R\x0fevaluationLimit\"S\n" +
+	"\x16GetLineageGraphRequest\x12\x1c\n" +
+	"\tattribute\x18\x01 \x01(\tR\tattribute\x12\x1b\n" +
+	"\tmax_depth\x18\x02 \x01(\x05R\bmaxDepth\"s\n" +
+	"\x13ValidateRuleRequest\x12\x12\n" +
+	"\x04rule\x18\x01 \x01(\tR\x04rule\x12+\n" +
+	"\x11source_attributes\x18\x02 \x03(\tR\x10sourceAttributes\x12\x1b\n" +
+	"\trule_type\x18\x03 \x01(\tR\bruleType\"\x82\x01\n" +
+	"\x13ScreenEntityRequest\x12\x1b\n" +
+	"\tentity_id\x18\x01 \x01(\tR\bentityId\x12\x17\n" +
+	"\acase_id\x18\x02 \x01(\tR\x06caseId\x12!\n" +
+	"\finclude_ubos\x18\x03 \x01(\bR\vincludeUbos\x12\x12\n" +
+	"\x04kind\x18\x04 \x01(\tR\x04kind\"%\n" +
 	"\x13GetAttributeRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\xa7\x01\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xbf\x01\n" +
 	"\x15ListAttributesRequest\x12\x14\n" +
 	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
 	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\"\n" +
 	"\fjurisdiction\x18\x03 \x01(\tR\fjurisdiction\x12\x1b\n" +
 	"\tattr_type\x18\x04 \x01(\tR\battrType\x12\x1f\n" +
 	"\vis_required\x18\x05 \x01(\bR\n" +
-	"isRequired\"#\n" +
+	"isRequired\x12\x16\n" +
+	"\x06cursor\x18\x06 \x01(\tR\x06cursor\"#\n" +
 	"\x11GetConceptRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"[\n" +
 	"\x13ListConceptsRequest\x12\x14\n" +
@@ -3948,26 +5637,28 @@ const file_proto_shared_ontology_service_proto_rawDesc = "" +
 	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\x16\n" +
 	"\x06domain\x18\x03 \x01(\tR\x06domain\"&\n" +
 	"\x14GetRegulationRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\x82\x01\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x9a\x01\n" +
 	"\x16ListRegulationsRequest\x12\x14\n" +
 	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
 	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\"\n" +
 	"\fjurisdiction\x18\x03 \x01(\tR\fjurisdiction\x12\x16\n" +
-	"\x06status\x18\x04 \x01(\tR\x06status\"$\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x16\n" +
+	"\x06cursor\x18\x05 \x01(\tR\x06cursor\"$\n" +
 	"\x12GetDocumentRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\xa7\x01\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xbf\x01\n" +
 	"\x14ListDocumentsRequest\x12\x14\n" +
 	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
 	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\"\n" +
 	"\fjurisdiction\x18\x03 \x01(\tR\fjurisdiction\x12\x1a\n" +
 	"\bcategory\x18\x04 \x01(\tR\bcategory\x12!\n" +
-	"\fis_mandatory\x18\x05 \x01(\bR\visMandatory\"\x9e\x01\n" +
+	"\fis_mandatory\x18\x05 \x01(\bR\visMandatory\x12\x16\n" +
+	"\x06cursor\x18\x06 \x01(\tR\x06cursor\"\x9e\x01\n" +
 	"\rSearchRequest\x12\x14\n" +
 	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
 	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12\x16\n" +
 	"\x06domain\x18\x04 \x01(\tR\x06domain\x121\n" +
-	"\x14similarity_threshold\x18\x05 \x01(\x01R\x13similarityThreshold2\xb3\x0f\n" +
+	"\x14similarity_threshold\x18\x05 \x01(\x01R\x13similarityThreshold2\xb9\x12\n" +
 	"\x0fOntologyService\x12A\n" +
 	"\tGetEntity\x12\x1e.kyc.ontology.GetEntityRequest\x1a\x14.kyc.ontology.Entity\x12K\n" +
 	"\fListEntities\x12!.kyc.ontology.ListEntitiesRequest\x1a\x18.kyc.ontology.EntityList\x12O\n" +
@@ -3992,9 +5683,15 @@ const file_proto_shared_ontology_service_proto_rawDesc = "" +
 	"\rListDocuments\x12\".kyc.ontology.ListDocumentsRequest\x1a\x1a.kyc.ontology.DocumentList\x12`\n" +
 	"\x15GetEntityControlGraph\x12%.kyc.ontology.GetEntityControlRequest\x1a .kyc.ontology.EntityControlGraph\x12R\n" +
 	"\rCreateControl\x12\".kyc.ontology.CreateControlRequest\x1a\x1d.kyc.ontology.ControlResponse\x12S\n" +
-	"\x0fGetControlChain\x12$.kyc.ontology.GetControlChainRequest\x1a\x1a.kyc.ontology.ControlChain\x12M\n" +
+	"\x0fGetControlChain\x12$.kyc.ontology.GetControlChainRequest\x1a\x1a.kyc.ontology.ControlChain\x12F\n" +
+	"\n" +
+	"ComputeUbo\x12\x1f.kyc.ontology.ComputeUboRequest\x1a\x17.kyc.ontology.UboRollup\x12M\n" +
 	"\rGetKycProfile\x12\".kyc.ontology.GetKycProfileRequest\x1a\x18.kyc.ontology.KycProfile\x12[\n" +
-	"\x10UpdateKycProfile\x12%.kyc.ontology.UpdateKycProfileRequest\x1a .kyc.ontology.KycProfileResponseBH\n" +
+	"\x10UpdateKycProfile\x12%.kyc.ontology.UpdateKycProfileRequest\x1a .kyc.ontology.KycProfileResponse\x12J\n" +
+	"\fGetEntity360\x12!.kyc.ontology.GetEntity360Request\x1a\x17.kyc.ontology.Entity360\x12S\n" +
+	"\x0fGetLineageGraph\x12$.kyc.ontology.GetLineageGraphRequest\x1a\x1a.kyc.ontology.LineageGraph\x12O\n" +
+	"\fValidateRule\x12!.kyc.ontology.ValidateRuleRequest\x1a\x1c.kyc.ontology.RuleValidation\x12J\n" +
+	"\fScreenEntity\x12!.kyc.ontology.ScreenEntityRequest\x1a\x17.kyc.ontology.ScreeningBH\n" +
 	"\x13com.kycdsl.ontologyP\x01Z/github.com/adamtc007/KYC-DSL/api/pb/kycontologyb\x06proto3"
 
 var (
@@ -4009,7 +5706,7 @@ func file_proto_shared_ontology_service_proto_rawDescGZIP() []byte {
 	return file_proto_shared_ontology_service_proto_rawDescData
 }
 
-var file_proto_shared_ontology_service_proto_msgTypes = make([]protoimpl.MessageInfo, 47)
+var file_proto_shared_ontology_service_proto_msgTypes = make([]protoimpl.MessageInfo, 65)
 var file_proto_shared_ontology_service_proto_goTypes = []any{
 	(*Entity)(nil),                  // 0: kyc.ontology.Entity
 	(*EntityList)(nil),              // 1: kyc.ontology.EntityList
@@ -4024,40 +5721,58 @@ var file_proto_shared_ontology_service_proto_goTypes = []any{
 	(*EntityControl)(nil),           // 10: kyc.ontology.EntityControl
 	(*EntityControlGraph)(nil),      // 11: kyc.ontology.EntityControlGraph
 	(*ControlChain)(nil),            // 12: kyc.ontology.ControlChain
-	(*ControlResponse)(nil),         // 13: kyc.ontology.ControlResponse
-	(*KycProfile)(nil),              // 14: kyc.ontology.KycProfile
-	(*KycProfileResponse)(nil),      // 15: kyc.ontology.KycProfileResponse
-	(*Regulation)(nil),              // 16: kyc.ontology.Regulation
-	(*RegulationList)(nil),          // 17: kyc.ontology.RegulationList
-	(*Document)(nil),                // 18: kyc.ontology.Document
-	(*DocumentList)(nil),            // 19: kyc.ontology.DocumentList
-	(*Concept)(nil),                 // 20: kyc.ontology.Concept
-	(*ConceptList)(nil),             // 21: kyc.ontology.ConceptList
-	(*Attribute)(nil),               // 22: kyc.ontology.Attribute
-	(*AttributeList)(nil),           // 23: kyc.ontology.AttributeList
-	(*GetEntityRequest)(nil),        // 24: kyc.ontology.GetEntityRequest
-	(*ListEntitiesRequest)(nil),     // 25: kyc.ontology.ListEntitiesRequest
-	(*CreateEntityRequest)(nil),     // 26: kyc.ontology.CreateEntityRequest
-	(*UpdateEntityRequest)(nil),     // 27: kyc.ontology.UpdateEntityRequest
-	(*GetCbuRequest)(nil),           // 28: kyc.ontology.GetCbuRequest
-	(*ListCbusRequest)(nil),         // 29: kyc.ontology.ListCbusRequest
-	(*CreateCbuRequest)(nil),        // 30: kyc.ontology.CreateCbuRequest
-	(*GetCbuRolesRequest)(nil),      // 31: kyc.ontology.GetCbuRolesRequest
-	(*AssignCbuRoleRequest)(nil),    // 32: kyc.ontology.AssignCbuRoleRequest
-	(*GetEntityControlRequest)(nil), // 33: kyc.ontology.GetEntityControlRequest
-	(*CreateControlRequest)(nil),    // 34: kyc.ontology.CreateControlRequest
-	(*GetControlChainRequest)(nil),  // 35: kyc.ontology.GetControlChainRequest
-	(*GetKycProfileRequest)(nil),    // 36: kyc.ontology.GetKycProfileRequest
-	(*UpdateKycProfileRequest)(nil), // 37: kyc.ontology.UpdateKycProfileRequest
-	(*GetAttributeRequest)(nil),     // 38: kyc.ontology.GetAttributeRequest
-	(*ListAttributesRequest)(nil),   // 39: kyc.ontology.ListAttributesRequest
-	(*GetConceptRequest)(nil),       // 40: kyc.ontology.GetConceptRequest
-	(*ListConceptsRequest)(nil),     // 41: kyc.ontology.ListConceptsRequest
-	(*GetRegulationRequest)(nil),    // 42: kyc.ontology.GetRegulationRequest
-	(*ListRegulationsRequest)(nil),  // 43: kyc.ontology.ListRegulationsRequest
-	(*GetDocumentRequest)(nil),      // 44: kyc.ontology.GetDocumentRequest
-	(*ListDocumentsRequest)(nil),    // 45: kyc.ontology.ListDocumentsRequest
-	(*SearchRequest)(nil),           // 46: kyc.ontology.SearchRequest
+	(*UboRollup)(nil),               // 13: kyc.ontology.UboRollup
+	(*UboOwner)(nil),                // 14: kyc.ontology.UboOwner
+	(*OwnershipPath)(nil),           // 15: kyc.ontology.OwnershipPath
+	(*ControlResponse)(nil),         // 16: kyc.ontology.ControlResponse
+	(*KycProfile)(nil),              // 17: kyc.ontology.KycProfile
+	(*KycProfileResponse)(nil),      // 18: kyc.ontology.KycProfileResponse
+	(*Entity360)(nil),               // 19: kyc.ontology.Entity360
+	(*ScreeningSummary)(nil),        // 20: kyc.ontology.ScreeningSummary
+	(*OpenCase)(nil),                // 21: kyc.ontology.OpenCase
+	(*RuleEvaluation)(nil),          // 22: kyc.ontology.RuleEvaluation
+	(*LineageGraph)(nil),            // 23: kyc.ontology.LineageGraph
+	(*LineageNode)(nil),             // 24: kyc.ontology.LineageNode
+	(*LineageEdge)(nil),             // 25: kyc.ontology.LineageEdge
+	(*RuleValidation)(nil),          // 26: kyc.ontology.RuleValidation
+	(*Screening)(nil),               // 27: kyc.ontology.Screening
+	(*ScreeningHit)(nil),            // 28: kyc.ontology.ScreeningHit
+	(*Regulation)(nil),              // 29: kyc.ontology.Regulation
+	(*RegulationList)(nil),          // 30: kyc.ontology.RegulationList
+	(*Document)(nil),                // 31: kyc.ontology.Document
+	(*DocumentList)(nil),            // 32: kyc.ontology.DocumentList
+	(*Concept)(nil),                 // 33: kyc.ontology.Concept
+	(*ConceptList)(nil),             // 34: kyc.ontology.ConceptList
+	(*Attribute)(nil),               // 35: kyc.ontology.Attribute
+	(*AttributeList)(nil),           // 36: kyc.ontology.AttributeList
+	(*GetEntityRequest)(nil),        // 37: kyc.ontology.GetEntityRequest
+	(*ListEntitiesRequest)(nil),     // 38: kyc.ontology.ListEntitiesRequest
+	(*CreateEntityRequest)(nil),     // 39: kyc.ontology.CreateEntityRequest
+	(*UpdateEntityRequest)(nil),     // 40: kyc.ontology.UpdateEntityRequest
+	(*GetCbuRequest)(nil),           // 41: kyc.ontology.GetCbuRequest
+	(*ListCbusRequest)(nil),         // 42: kyc.ontology.ListCbusRequest
+	(*CreateCbuRequest)(nil),        // 43: kyc.ontology.CreateCbuRequest
+	(*GetCbuRolesRequest)(nil),      // 44: kyc.ontology.GetCbuRolesRequest
+	(*AssignCbuRoleRequest)(nil),    // 45: kyc.ontology.AssignCbuRoleRequest
+	(*GetEntityControlRequest)(nil), // 46: kyc.ontology.GetEntityControlRequest
+	(*CreateControlRequest)(nil),    // 47: kyc.ontology.CreateControlRequest
+	(*GetControlChainRequest)(nil),  // 48: kyc.ontology.GetControlChainRequest
+	(*ComputeUboRequest)(nil),       // 49: kyc.ontology.ComputeUboRequest
+	(*GetKycProfileRequest)(nil),    // 50: kyc.ontology.GetKycProfileRequest
+	(*UpdateKycProfileRequest)(nil), // 51: kyc.ontology.UpdateKycProfileRequest
+	(*GetEntity360Request)(nil),     // 52: kyc.ontology.GetEntity360Request
+	(*GetLineageGraphRequest)(nil),  // 53: kyc.ontology.GetLineageGraphRequest
+	(*ValidateRuleRequest)(nil),     // 54: kyc.ontology.ValidateRuleRequest
+	(*ScreenEntityRequest)(nil),     // 55: kyc.ontology.ScreenEntityRequest
+	(*GetAttributeRequest)(nil),     // 56: kyc.ontology.GetAttributeRequest
+	(*ListAttributesRequest)(nil),   // 57: kyc.ontology.ListAttributesRequest
+	(*GetConceptRequest)(nil),       // 58: kyc.ontology.GetConceptRequest
+	(*ListConceptsRequest)(nil),     // 59: kyc.ontology.ListConceptsRequest
+	(*GetRegulationRequest)(nil),    // 60: kyc.ontology.GetRegulationRequest
+	(*ListRegulationsRequest)(nil),  // 61: kyc.ontology.ListRegulationsRequest
+	(*GetDocumentRequest)(nil),      // 62: kyc.ontology.GetDocumentRequest
+	(*ListDocumentsRequest)(nil),    // 63: kyc.ontology.ListDocumentsRequest
+	(*SearchRequest)(nil),           // 64: kyc.ontology.SearchRequest
 }
 var file_proto_shared_ontology_service_proto_depIdxs = []int32{
 	0,  // 0: kyc.ontology.EntityList.entities:type_name -> kyc.ontology.Entity
@@ -4067,65 +5782,89 @@ var file_proto_shared_ontology_service_proto_depIdxs = []int32{
 	10, // 4: kyc.ontology.EntityControlGraph.edges:type_name -> kyc.ontology.EntityControl
 	0,  // 5: kyc.ontology.EntityControlGraph.nodes:type_name -> kyc.ontology.Entity
 	10, // 6: kyc.ontology.ControlChain.chain:type_name -> kyc.ontology.EntityControl
-	16, // 7: kyc.ontology.RegulationList.regulations:type_name -> kyc.ontology.Regulation
-	18, // 8: kyc.ontology.DocumentList.documents:type_name -> kyc.ontology.Document
-	20, // 9: kyc.ontology.ConceptList.concepts:type_name -> kyc.ontology.Concept
-	22, // 10: kyc.ontology.AttributeList.attributes:type_name -> kyc.ontology.Attribute
-	24, // 11: kyc.ontology.OntologyService.GetEntity:input_type -> kyc.ontology.GetEntityRequest
-	25, // 12: kyc.ontology.OntologyService.ListEntities:input_type -> kyc.ontology.ListEntitiesRequest
-	26, // 13: kyc.ontology.OntologyService.CreateEntity:input_type -> kyc.ontology.CreateEntityRequest
-	27, // 14: kyc.ontology.OntologyService.UpdateEntity:input_type -> kyc.ontology.UpdateEntityRequest
-	46, // 15: kyc.ontology.OntologyService.SearchEntities:input_type -> kyc.ontology.SearchRequest
-	28, // 16: kyc.ontology.OntologyService.GetCbu:input_type -> kyc.ontology.GetCbuRequest
-	29, // 17: kyc.ontology.OntologyService.ListCbus:input_type -> kyc.ontology.ListCbusRequest
-	30, // 18: kyc.ontology.OntologyService.CreateCbu:input_type -> kyc.ontology.CreateCbuRequest
-	31, // 19: kyc.ontology.OntologyService.GetCbuRoles:input_type -> kyc.ontology.GetCbuRolesRequest
-	32, // 20: kyc.ontology.OntologyService.AssignCbuRole:input_type -> kyc.ontology.AssignCbuRoleRequest
-	38, // 21: kyc.ontology.OntologyService.GetAttribute:input_type -> kyc.ontology.GetAttributeRequest
-	39, // 22: kyc.ontology.OntologyService.ListAttributes:input_type -> kyc.ontology.ListAttributesRequest
-	46, // 23: kyc.ontology.OntologyService.SearchAttributes:input_type -> kyc.ontology.SearchRequest
-	40, // 24: kyc.ontology.OntologyService.GetConcept:input_type -> kyc.ontology.GetConceptRequest
-	41, // 25: kyc.ontology.OntologyService.ListConcepts:input_type -> kyc.ontology.ListConceptsRequest
-	46, // 26: kyc.ontology.OntologyService.SearchConcepts:input_type -> kyc.ontology.SearchRequest
-	42, // 27: kyc.ontology.OntologyService.GetRegulation:input_type -> kyc.ontology.GetRegulationRequest
-	43, // 28: kyc.ontology.OntologyService.ListRegulations:input_type -> kyc.ontology.ListRegulationsRequest
-	44, // 29: kyc.ontology.OntologyService.GetDocument:input_type -> kyc.ontology.GetDocumentRequest
-	45, // 30: kyc.ontology.OntologyService.ListDocuments:input_type -> kyc.ontology.ListDocumentsRequest
-	33, // 31: kyc.ontology.OntologyService.GetEntityControlGraph:input_type -> kyc.ontology.GetEntityControlRequest
-	34, // 32: kyc.ontology.OntologyService.CreateControl:input_type -> kyc.ontology.CreateControlRequest
-	35, // 33: kyc.ontology.OntologyService.GetControlChain:input_type -> kyc.ontology.GetControlChainRequest
-	36, // 34: kyc.ontology.OntologyService.GetKycProfile:input_type -> kyc.ontology.GetKycProfileRequest
-	37, // 35: kyc.ontology.OntologyService.UpdateKycProfile:input_type -> kyc.ontology.UpdateKycProfileRequest
-	0,  // 36: kyc.ontology.OntologyService.GetEntity:output_type -> kyc.ontology.Entity
-	1,  // 37: kyc.ontology.OntologyService.ListEntities:output_type -> kyc.ontology.EntityList
-	2,  // 38: kyc.ontology.OntologyService.CreateEntity:output_type -> kyc.ontology.EntityResponse
-	2,  // 39: kyc.ontology.OntologyService.UpdateEntity:output_type -> kyc.ontology.EntityResponse
-	1,  // 40: kyc.ontology.OntologyService.SearchEntities:output_type -> kyc.ontology.EntityList
-	3,  // 41: kyc.ontology.OntologyService.GetCbu:output_type -> kyc.ontology.Cbu
-	4,  // 42: kyc.ontology.OntologyService.ListCbus:output_type -> kyc.ontology.CbuList
-	5,  // 43: kyc.ontology.OntologyService.CreateCbu:output_type -> kyc.ontology.CbuResponse
-	8,  // 44: kyc.ontology.OntologyService.GetCbuRoles:output_type -> kyc.ontology.CbuRoleList
-	9,  // 45: kyc.ontology.OntologyService.AssignCbuRole:output_type -> kyc.ontology.CbuRoleResponse
-	22, // 46: kyc.ontology.OntologyService.GetAttribute:output_type -> kyc.ontology.Attribute
-	23, // 47: kyc.ontology.OntologyService.ListAttributes:output_type -> kyc.ontology.AttributeList
-	23, // 48: kyc.ontology.OntologyService.SearchAttributes:output_type -> kyc.ontology.AttributeList
-	20, // 49: kyc.ontology.OntologyService.GetConcept:output_type -> kyc.ontology.Concept
-	21, // 50: kyc.ontology.OntologyService.ListConcepts:output_type -> kyc.ontology.ConceptList
-	21, // 51: kyc.ontology.OntologyService.SearchConcepts:output_type -> kyc.ontology.ConceptList
-	16, // 52: kyc.ontology.OntologyService.GetRegulation:output_type -> kyc.ontology.Regulation
-	17, // 53: kyc.ontology.OntologyService.ListRegulations:output_type -> kyc.ontology.RegulationList
-	18, // 54: kyc.ontology.OntologyService.GetDocument:output_type -> kyc.ontology.Document
-	19, // 55: kyc.ontology.OntologyService.ListDocuments:output_type -> kyc.ontology.DocumentList
-	11, // 56: kyc.ontology.OntologyService.GetEntityControlGraph:output_type -> kyc.ontology.EntityControlGraph
-	13, // 57: kyc.ontology.OntologyService.CreateControl:output_type -> kyc.ontology.ControlResponse
-	12, // 58: kyc.ontology.OntologyService.GetControlChain:output_type -> kyc.ontology.ControlChain
-	14, // 59: kyc.ontology.OntologyService.GetKycProfile:output_type -> kyc.ontology.KycProfile
-	15, // 60: kyc.ontology.OntologyService.UpdateKycProfile:output_type -> kyc.ontology.KycProfileResponse
-	36, // [36:61] is the sub-list for method output_type
-	11, // [11:36] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	14, // 7: kyc.ontology.UboRollup.owners:type_name -> kyc.ontology.UboOwner
+	15, // 8: kyc.ontology.UboOwner.paths:type_name -> kyc.ontology.OwnershipPath
+	0,  // 9: kyc.ontology.Entity360.entity:type_name -> kyc.ontology.Entity
+	7,  // 10: kyc.ontology.Entity360.cbu_roles:type_name -> kyc.ontology.CbuRole
+	3,  // 11: kyc.ontology.Entity360.cbus:type_name -> kyc.ontology.Cbu
+	10, // 12: kyc.ontology.Entity360.controllers:type_name -> kyc.ontology.EntityControl
+	10, // 13: kyc.ontology.Entity360.controlled:type_name -> kyc.ontology.EntityControl
+	17, // 14: kyc.ontology.Entity360.kyc_profile:type_name -> kyc.ontology.KycProfile
+	20, // 15: kyc.ontology.Entity360.screening:type_name -> kyc.ontology.ScreeningSummary
+	21, // 16: kyc.ontology.Entity360.open_cases:type_name -> kyc.ontology.OpenCase
+	22, // 17: kyc.ontology.Entity360.recent_evaluations:type_name -> kyc.ontology.RuleEvaluation
+	24, // 18: kyc.ontology.LineageGraph.nodes:type_name -> kyc.ontology.LineageNode
+	25, // 19: kyc.ontology.LineageGraph.edges:type_name -> kyc.ontology.LineageEdge
+	28, // 20: kyc.ontology.Screening.hits:type_name -> kyc.ontology.ScreeningHit
+	29, // 21: kyc.ontology.RegulationList.regulations:type_name -> kyc.ontology.Regulation
+	31, // 22: kyc.ontology.DocumentList.documents:type_name -> kyc.ontology.Document
+	33, // 23: kyc.ontology.ConceptList.concepts:type_name -> kyc.ontology.Concept
+	35, // 24: kyc.ontology.AttributeList.attributes:type_name -> kyc.ontology.Attribute
+	37, // 25: kyc.ontology.OntologyService.GetEntity:input_type -> kyc.ontology.GetEntityRequest
+	38, // 26: kyc.ontology.OntologyService.ListEntities:input_type -> kyc.ontology.ListEntitiesRequest
+	39, // 27: kyc.ontology.OntologyService.CreateEntity:input_type -> kyc.ontology.CreateEntityRequest
+	40, // 28: kyc.ontology.OntologyService.UpdateEntity:input_type -> kyc.ontology.UpdateEntityRequest
+	64, // 29: kyc.ontology.OntologyService.SearchEntities:input_type -> kyc.ontology.SearchRequest
+	41, // 30: kyc.ontology.OntologyService.GetCbu:input_type -> kyc.ontology.GetCbuRequest
+	42, // 31: kyc.ontology.OntologyService.ListCbus:input_type -> kyc.ontology.ListCbusRequest
+	43, // 32: kyc.ontology.OntologyService.CreateCbu:input_type -> kyc.ontology.CreateCbuRequest
+	44, // 33: kyc.ontology.OntologyService.GetCbuRoles:input_type -> kyc.ontology.GetCbuRolesRequest
+	45, // 34: kyc.ontology.OntologyService.AssignCbuRole:input_type -> kyc.ontology.AssignCbuRoleRequest
+	56, // 35: kyc.ontology.OntologyService.GetAttribute:input_type -> kyc.ontology.GetAttributeRequest
+	57, // 36: kyc.ontology.OntologyService.ListAttributes:input_type -> kyc.ontology.ListAttributesRequest
+	64, // 37: kyc.ontology.OntologyService.SearchAttributes:input_type -> kyc.ontology.SearchRequest
+	58, // 38: kyc.ontology.OntologyService.GetConcept:input_type -> kyc.ontology.GetConceptRequest
+	59, // 39: kyc.ontology.OntologyService.ListConcepts:input_type -> kyc.ontology.ListConceptsRequest
+	64, // 40: kyc.ontology.OntologyService.SearchConcepts:input_type -> kyc.ontology.SearchRequest
+	60, // 41: kyc.ontology.OntologyService.GetRegulation:input_type -> kyc.ontology.GetRegulationRequest
+	61, // 42: kyc.ontology.OntologyService.ListRegulations:input_type -> kyc.ontology.ListRegulationsRequest
+	62, // 43: kyc.ontology.OntologyService.GetDocument:input_type -> kyc.ontology.GetDocumentRequest
+	63, // 44: kyc.ontology.OntologyService.ListDocuments:input_type -> kyc.ontology.ListDocumentsRequest
+	46, // 45: kyc.ontology.OntologyService.GetEntityControlGraph:input_type -> kyc.ontology.GetEntityControlRequest
+	47, // 46: kyc.ontology.OntologyService.CreateControl:input_type -> kyc.ontology.CreateControlRequest
+	48, // 47: kyc.ontology.OntologyService.GetControlChain:input_type -> kyc.ontology.GetControlChainRequest
+	49, // 48: kyc.ontology.OntologyService.ComputeUbo:input_type -> kyc.ontology.ComputeUboRequest
+	50, // 49: kyc.ontology.OntologyService.GetKycProfile:input_type -> kyc.ontology.GetKycProfileRequest
+	51, // 50: kyc.ontology.OntologyService.UpdateKycProfile:input_type -> kyc.ontology.UpdateKycProfileRequest
+	52, // 51: kyc.ontology.OntologyService.GetEntity360:input_type -> kyc.ontology.GetEntity360Request
+	53, // 52: kyc.ontology.OntologyService.GetLineageGraph:input_type -> kyc.ontology.GetLineageGraphRequest
+	54, // 53: kyc.ontology.OntologyService.ValidateRule:input_type -> kyc.ontology.ValidateRuleRequest
+	55, // 54: kyc.ontology.OntologyService.ScreenEntity:input_type -> kyc.ontology.ScreenEntityRequest
+	0,  // 55: kyc.ontology.OntologyService.GetEntity:output_type -> kyc.ontology.Entity
+	1,  // 56: kyc.ontology.OntologyService.ListEntities:output_type -> kyc.ontology.EntityList
+	2,  // 57: kyc.ontology.OntologyService.CreateEntity:output_type -> kyc.ontology.EntityResponse
+	2,  // 58: kyc.ontology.OntologyService.UpdateEntity:output_type -> kyc.ontology.EntityResponse
+	1,  // 59: kyc.ontology.OntologyService.SearchEntities:output_type -> kyc.ontology.EntityList
+	3,  // 60: kyc.ontology.OntologyService.GetCbu:output_type -> kyc.ontology.Cbu
+	4,  // 61: kyc.ontology.OntologyService.ListCbus:output_type -> kyc.ontology.CbuList
+	5,  // 62: kyc.ontology.OntologyService.CreateCbu:output_type -> kyc.ontology.CbuResponse
+	8,  // 63: kyc.ontology.OntologyService.GetCbuRoles:output_type -> kyc.ontology.CbuRoleList
+	9,  // 64: kyc.ontology.OntologyService.AssignCbuRole:output_type -> kyc.ontology.CbuRoleResponse
+	35, // 65: kyc.ontology.OntologyService.GetAttribute:output_type -> kyc.ontology.Attribute
+	36, // 66: kyc.ontology.OntologyService.ListAttributes:output_type -> kyc.ontology.AttributeList
+	36, // 67: kyc.ontology.OntologyService.SearchAttributes:output_type -> kyc.ontology.AttributeList
+	33, // 68: kyc.ontology.OntologyService.GetConcept:output_type -> kyc.ontology.Concept
+	34, // 69: kyc.ontology.OntologyService.ListConcepts:output_type -> kyc.ontology.ConceptList
+	34, // 70: kyc.ontology.OntologyService.SearchConcepts:output_type -> kyc.ontology.ConceptList
+	29, // 71: kyc.ontology.OntologyService.GetRegulation:output_type -> kyc.ontology.Regulation
+	30, // 72: kyc.ontology.OntologyService.ListRegulations:output_type -> kyc.ontology.RegulationList
+	31, // 73: kyc.ontology.OntologyService.GetDocument:output_type -> kyc.ontology.Document
+	32, // 74: kyc.ontology.OntologyService.ListDocuments:output_type -> kyc.ontology.DocumentList
+	11, // 75: kyc.ontology.OntologyService.GetEntityControlGraph:output_type -> kyc.ontology.EntityControlGraph
+	16, // 76: kyc.ontology.OntologyService.CreateControl:output_type -> kyc.ontology.ControlResponse
+	12, // 77: kyc.ontology.OntologyService.GetControlChain:output_type -> kyc.ontology.ControlChain
+	13, // 78: kyc.ontology.OntologyService.ComputeUbo:output_type -> kyc.ontology.UboRollup
+	17, // 79: kyc.ontology.OntologyService.GetKycProfile:output_type -> kyc.ontology.KycProfile
+	18, // 80: kyc.ontology.OntologyService.UpdateKycProfile:output_type -> kyc.ontology.KycProfileResponse
+	19, // 81: kyc.ontology.OntologyService.GetEntity360:output_type -> kyc.ontology.Entity360
+	23, // 82: kyc.ontology.OntologyService.GetLineageGraph:output_type -> kyc.ontology.LineageGraph
+	26, // 83: kyc.ontology.OntologyService.ValidateRule:output_type -> kyc.ontology.RuleValidation
+	27, // 84: kyc.ontology.OntologyService.ScreenEntity:output_type -> kyc.ontology.Screening
+	55, // [55:85] is the sub-list for method output_type
+	25, // [25:55] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
 }
 
 func init() { file_proto_shared_ontology_service_proto_init() }
@@ -4139,7 +5878,7 @@ func file_proto_shared_ontology_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_shared_ontology_service_proto_rawDesc), len(file_proto_shared_ontology_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   47,
+			NumMessages:   65,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
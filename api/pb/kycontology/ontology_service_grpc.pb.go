@@ -42,8 +42,13 @@ const (
 	OntologyService_GetEntityControlGraph_FullMethodName = "/kyc.ontology.OntologyService/GetEntityControlGraph"
 	OntologyService_CreateControl_FullMethodName         = "/kyc.ontology.OntologyService/CreateControl"
 	OntologyService_GetControlChain_FullMethodName       = "/kyc.ontology.OntologyService/GetControlChain"
+	OntologyService_ComputeUbo_FullMethodName            = "/kyc.ontology.OntologyService/ComputeUbo"
 	OntologyService_GetKycProfile_FullMethodName         = "/kyc.ontology.OntologyService/GetKycProfile"
 	OntologyService_UpdateKycProfile_FullMethodName      = "/kyc.ontology.OntologyService/UpdateKycProfile"
+	OntologyService_GetEntity360_FullMethodName          = "/kyc.ontology.OntologyService/GetEntity360"
+	OntologyService_GetLineageGraph_FullMethodName       = "/kyc.ontology.OntologyService/GetLineageGraph"
+	OntologyService_ValidateRule_FullMethodName          = "/kyc.ontology.OntologyService/ValidateRule"
+	OntologyService_ScreenEntity_FullMethodName          = "/kyc.ontology.OntologyService/ScreenEntity"
 )
 
 // OntologyServiceClient is the client API for OntologyService service.
@@ -83,9 +88,15 @@ type OntologyServiceClient interface {
 	GetEntityControlGraph(ctx context.Context, in *GetEntityControlRequest, opts ...grpc.CallOption) (*EntityControlGraph, error)
 	CreateControl(ctx context.Context, in *CreateControlRequest, opts ...grpc.CallOption) (*ControlResponse, error)
 	GetControlChain(ctx context.Context, in *GetControlChainRequest, opts ...grpc.CallOption) (*ControlChain, error)
+	ComputeUbo(ctx context.Context, in *ComputeUboRequest, opts ...grpc.CallOption) (*UboRollup, error)
 	// KYC Profile operations
 	GetKycProfile(ctx context.Context, in *GetKycProfileRequest, opts ...grpc.CallOption) (*KycProfile, error)
 	UpdateKycProfile(ctx context.Context, in *UpdateKycProfileRequest, opts ...grpc.CallOption) (*KycProfileResponse, error)
+	// Relationship-manager view
+	GetEntity360(ctx context.Context, in *GetEntity360Request, opts ...grpc.CallOption) (*Entity360, error)
+	GetLineageGraph(ctx context.Context, in *GetLineageGraphRequest, opts ...grpc.CallOption) (*LineageGraph, error)
+	ValidateRule(ctx context.Context, in *ValidateRuleRequest, opts ...grpc.CallOption) (*RuleValidation, error)
+	ScreenEntity(ctx context.Context, in *ScreenEntityRequest, opts ...grpc.CallOption) (*Screening, error)
 }
 
 type ontologyServiceClient struct {
@@ -326,6 +337,16 @@ func (c *ontologyServiceClient) GetControlChain(ctx context.Context, in *GetCont
 	return out, nil
 }
 
+func (c *ontologyServiceClient) ComputeUbo(ctx context.Context, in *ComputeUboRequest, opts ...grpc.CallOption) (*UboRollup, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UboRollup)
+	err := c.cc.Invoke(ctx, OntologyService_ComputeUbo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *ontologyServiceClient) GetKycProfile(ctx context.Context, in *GetKycProfileRequest, opts ...grpc.CallOption) (*KycProfile, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(KycProfile)
@@ -346,6 +367,46 @@ func (c *ontologyServiceClient) UpdateKycProfile(ctx context.Context, in *Update
 	return out, nil
 }
 
+func (c *ontologyServiceClient) GetEntity360(ctx context.Context, in *GetEntity360Request, opts ...grpc.CallOption) (*Entity360, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Entity360)
+	err := c.cc.Invoke(ctx, OntologyService_GetEntity360_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ontologyServiceClient) GetLineageGraph(ctx context.Context, in *GetLineageGraphRequest, opts ...grpc.CallOption) (*LineageGraph, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LineageGraph)
+	err := c.cc.Invoke(ctx, OntologyService_GetLineageGraph_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ontologyServiceClient) ValidateRule(ctx context.Context, in *ValidateRuleRequest, opts ...grpc.CallOption) (*RuleValidation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RuleValidation)
+	err := c.cc.Invoke(ctx, OntologyService_ValidateRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ontologyServiceClient) ScreenEntity(ctx context.Context, in *ScreenEntityRequest, opts ...grpc.CallOption) (*Screening, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Screening)
+	err := c.cc.Invoke(ctx, OntologyService_ScreenEntity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OntologyServiceServer is the server API for OntologyService service.
 // All implementations must embed UnimplementedOntologyServiceServer
 // for forward compatibility.
@@ -383,9 +444,15 @@ type OntologyServiceServer interface {
 	GetEntityControlGraph(context.Context, *GetEntityControlRequest) (*EntityControlGraph, error)
 	CreateControl(context.Context, *CreateControlRequest) (*ControlResponse, error)
 	GetControlChain(context.Context, *GetControlChainRequest) (*ControlChain, error)
+	ComputeUbo(context.Context, *ComputeUboRequest) (*UboRollup, error)
 	// KYC Profile operations
 	GetKycProfile(context.Context, *GetKycProfileRequest) (*KycProfile, error)
 	UpdateKycProfile(context.Context, *UpdateKycProfileRequest) (*KycProfileResponse, error)
+	// Relationship-manager view
+	GetEntity360(context.Context, *GetEntity360Request) (*Entity360, error)
+	GetLineageGraph(context.Context, *GetLineageGraphRequest) (*LineageGraph, error)
+	ValidateRule(context.Context, *ValidateRuleRequest) (*RuleValidation, error)
+	ScreenEntity(context.Context, *ScreenEntityRequest) (*Screening, error)
 	mustEmbedUnimplementedOntologyServiceServer()
 }
 
@@ -465,12 +532,27 @@ func (UnimplementedOntologyServiceServer) CreateControl(context.Context, *Create
 func (UnimplementedOntologyServiceServer) GetControlChain(context.Context, *GetControlChainRequest) (*ControlChain, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetControlChain not implemented")
 }
+func (UnimplementedOntologyServiceServer) ComputeUbo(context.Context, *ComputeUboRequest) (*UboRollup, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ComputeUbo not implemented")
+}
 func (UnimplementedOntologyServiceServer) GetKycProfile(context.Context, *GetKycProfileRequest) (*KycProfile, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetKycProfile not implemented")
 }
 func (UnimplementedOntologyServiceServer) UpdateKycProfile(context.Context, *UpdateKycProfileRequest) (*KycProfileResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateKycProfile not implemented")
 }
+func (UnimplementedOntologyServiceServer) GetEntity360(context.Context, *GetEntity360Request) (*Entity360, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEntity360 not implemented")
+}
+func (UnimplementedOntologyServiceServer) GetLineageGraph(context.Context, *GetLineageGraphRequest) (*LineageGraph, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLineageGraph not implemented")
+}
+func (UnimplementedOntologyServiceServer) ValidateRule(context.Context, *ValidateRuleRequest) (*RuleValidation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateRule not implemented")
+}
+func (UnimplementedOntologyServiceServer) ScreenEntity(context.Context, *ScreenEntityRequest) (*Screening, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScreenEntity not implemented")
+}
 func (UnimplementedOntologyServiceServer) mustEmbedUnimplementedOntologyServiceServer() {}
 func (UnimplementedOntologyServiceServer) testEmbeddedByValue()                         {}
 
@@ -906,6 +988,24 @@ func _OntologyService_GetControlChain_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OntologyService_ComputeUbo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ComputeUboRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OntologyServiceServer).ComputeUbo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OntologyService_ComputeUbo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OntologyServiceServer).ComputeUbo(ctx, req.(*ComputeUboRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _OntologyService_GetKycProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetKycProfileRequest)
 	if err := dec(in); err != nil {
@@ -942,6 +1042,78 @@ func _OntologyService_UpdateKycProfile_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OntologyService_GetEntity360_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEntity360Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OntologyServiceServer).GetEntity360(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OntologyService_GetEntity360_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OntologyServiceServer).GetEntity360(ctx, req.(*GetEntity360Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OntologyService_GetLineageGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLineageGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OntologyServiceServer).GetLineageGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OntologyService_GetLineageGraph_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OntologyServiceServer).GetLineageGraph(ctx, req.(*GetLineageGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OntologyService_ValidateRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OntologyServiceServer).ValidateRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OntologyService_ValidateRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OntologyServiceServer).ValidateRule(ctx, req.(*ValidateRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OntologyService_ScreenEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScreenEntityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OntologyServiceServer).ScreenEntity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OntologyService_ScreenEntity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OntologyServiceServer).ScreenEntity(ctx, req.(*ScreenEntityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // OntologyService_ServiceDesc is the grpc.ServiceDesc for OntologyService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1041,6 +1213,10 @@ var OntologyService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetControlChain",
 			Handler:    _OntologyService_GetControlChain_Handler,
 		},
+		{
+			MethodName: "ComputeUbo",
+			Handler:    _OntologyService_ComputeUbo_Handler,
+		},
 		{
 			MethodName: "GetKycProfile",
 			Handler:    _OntologyService_GetKycProfile_Handler,
@@ -1049,6 +1225,22 @@ var OntologyService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateKycProfile",
 			Handler:    _OntologyService_UpdateKycProfile_Handler,
 		},
+		{
+			MethodName: "GetEntity360",
+			Handler:    _OntologyService_GetEntity360_Handler,
+		},
+		{
+			MethodName: "GetLineageGraph",
+			Handler:    _OntologyService_GetLineageGraph_Handler,
+		},
+		{
+			MethodName: "ValidateRule",
+			Handler:    _OntologyService_ValidateRule_Handler,
+		},
+		{
+			MethodName: "ScreenEntity",
+			Handler:    _OntologyService_ScreenEntity_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto_shared/ontology_service.proto",
@@ -654,6 +654,7 @@ type RagFeedbackResponse struct {
 	Feedback      string                 `protobuf:"bytes,3,opt,name=feedback,proto3" json:"feedback,omitempty"`
 	AgentName     string                 `protobuf:"bytes,4,opt,name=agent_name,json=agentName,proto3" json:"agent_name,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Outcome       *FeedbackOutcome       `protobuf:"bytes,6,opt,name=outcome,proto3" json:"outcome,omitempty"` // unset when the feedback policy could not run
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -723,6 +724,82 @@ func (x *RagFeedbackResponse) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *RagFeedbackResponse) GetOutcome() *FeedbackOutcome {
+	if x != nil {
+		return x.Outcome
+	}
+	return nil
+}
+
+// FeedbackOutcome is what the tenant's feedback policy did with a feedback
+type FeedbackOutcome struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tenant        string                 `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	Gated         bool                   `protobuf:"varint,2,opt,name=gated,proto3" json:"gated,omitempty"` // passed the confidence and trust gates
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Actions       []string               `protobuf:"bytes,4,rep,name=actions,proto3" json:"actions,omitempty"` // demote, flag
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeedbackOutcome) Reset() {
+	*x = FeedbackOutcome{}
+	mi := &file_api_proto_rag_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeedbackOutcome) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeedbackOutcome) ProtoMessage() {}
+
+func (x *FeedbackOutcome) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_rag_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeedbackOutcome.ProtoReflect.Descriptor instead.
+func (*FeedbackOutcome) Descriptor() ([]byte, []int) {
+	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *FeedbackOutcome) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *FeedbackOutcome) GetGated() bool {
+	if x != nil {
+		return x.Gated
+	}
+	return false
+}
+
+func (x *FeedbackOutcome) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *FeedbackOutcome) GetActions() []string {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
 // GetRecentFeedbackRequest retrieves recent feedback
 type GetRecentFeedbackRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -733,7 +810,7 @@ type GetRecentFeedbackRequest struct {
 
 func (x *GetRecentFeedbackRequest) Reset() {
 	*x = GetRecentFeedbackRequest{}
-	mi := &file_api_proto_rag_service_proto_msgTypes[9]
+	mi := &file_api_proto_rag_service_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -745,7 +822,7 @@ func (x *GetRecentFeedbackRequest) String() string {
 func (*GetRecentFeedbackRequest) ProtoMessage() {}
 
 func (x *GetRecentFeedbackRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_rag_service_proto_msgTypes[9]
+	mi := &file_api_proto_rag_service_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -758,7 +835,7 @@ func (x *GetRecentFeedbackRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRecentFeedbackRequest.ProtoReflect.Descriptor instead.
 func (*GetRecentFeedbackRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{9}
+	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *GetRecentFeedbackRequest) GetLimit() int32 {
@@ -787,7 +864,7 @@ type RagFeedback struct {
 
 func (x *RagFeedback) Reset() {
 	*x = RagFeedback{}
-	mi := &file_api_proto_rag_service_proto_msgTypes[10]
+	mi := &file_api_proto_rag_service_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -799,7 +876,7 @@ func (x *RagFeedback) String() string {
 func (*RagFeedback) ProtoMessage() {}
 
 func (x *RagFeedback) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_rag_service_proto_msgTypes[10]
+	mi := &file_api_proto_rag_service_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -812,7 +889,7 @@ func (x *RagFeedback) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RagFeedback.ProtoReflect.Descriptor instead.
 func (*RagFeedback) Descriptor() ([]byte, []int) {
-	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{10}
+	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *RagFeedback) GetId() int32 {
@@ -895,7 +972,7 @@ type GetFeedbackAnalyticsRequest struct {
 
 func (x *GetFeedbackAnalyticsRequest) Reset() {
 	*x = GetFeedbackAnalyticsRequest{}
-	mi := &file_api_proto_rag_service_proto_msgTypes[11]
+	mi := &file_api_proto_rag_service_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -907,7 +984,7 @@ func (x *GetFeedbackAnalyticsRequest) String() string {
 func (*GetFeedbackAnalyticsRequest) ProtoMessage() {}
 
 func (x *GetFeedbackAnalyticsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_rag_service_proto_msgTypes[11]
+	mi := &file_api_proto_rag_service_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -920,7 +997,7 @@ func (x *GetFeedbackAnalyticsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFeedbackAnalyticsRequest.ProtoReflect.Descriptor instead.
 func (*GetFeedbackAnalyticsRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{11}
+	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GetFeedbackAnalyticsRequest) GetTop() int32 {
@@ -947,7 +1024,7 @@ type FeedbackAnalytics struct {
 
 func (x *FeedbackAnalytics) Reset() {
 	*x = FeedbackAnalytics{}
-	mi := &file_api_proto_rag_service_proto_msgTypes[12]
+	mi := &file_api_proto_rag_service_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -959,7 +1036,7 @@ func (x *FeedbackAnalytics) String() string {
 func (*FeedbackAnalytics) ProtoMessage() {}
 
 func (x *FeedbackAnalytics) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_rag_service_proto_msgTypes[12]
+	mi := &file_api_proto_rag_service_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -972,7 +1049,7 @@ func (x *FeedbackAnalytics) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FeedbackAnalytics.ProtoReflect.Descriptor instead.
 func (*FeedbackAnalytics) Descriptor() ([]byte, []int) {
-	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{12}
+	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *FeedbackAnalytics) GetTotalFeedback() int32 {
@@ -1045,7 +1122,7 @@ type AttributeFeedbackSummary struct {
 
 func (x *AttributeFeedbackSummary) Reset() {
 	*x = AttributeFeedbackSummary{}
-	mi := &file_api_proto_rag_service_proto_msgTypes[13]
+	mi := &file_api_proto_rag_service_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1057,7 +1134,7 @@ func (x *AttributeFeedbackSummary) String() string {
 func (*AttributeFeedbackSummary) ProtoMessage() {}
 
 func (x *AttributeFeedbackSummary) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_rag_service_proto_msgTypes[13]
+	mi := &file_api_proto_rag_service_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1070,7 +1147,7 @@ func (x *AttributeFeedbackSummary) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AttributeFeedbackSummary.ProtoReflect.Descriptor instead.
 func (*AttributeFeedbackSummary) Descriptor() ([]byte, []int) {
-	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{13}
+	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *AttributeFeedbackSummary) GetAttributeCode() string {
@@ -1117,7 +1194,7 @@ type GetMetadataStatsRequest struct {
 
 func (x *GetMetadataStatsRequest) Reset() {
 	*x = GetMetadataStatsRequest{}
-	mi := &file_api_proto_rag_service_proto_msgTypes[14]
+	mi := &file_api_proto_rag_service_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1129,7 +1206,7 @@ func (x *GetMetadataStatsRequest) String() string {
 func (*GetMetadataStatsRequest) ProtoMessage() {}
 
 func (x *GetMetadataStatsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_rag_service_proto_msgTypes[14]
+	mi := &file_api_proto_rag_service_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1142,7 +1219,7 @@ func (x *GetMetadataStatsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetMetadataStatsRequest.ProtoReflect.Descriptor instead.
 func (*GetMetadataStatsRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{14}
+	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{15}
 }
 
 // MetadataStats contains repository statistics
@@ -1158,7 +1235,7 @@ type MetadataStats struct {
 
 func (x *MetadataStats) Reset() {
 	*x = MetadataStats{}
-	mi := &file_api_proto_rag_service_proto_msgTypes[15]
+	mi := &file_api_proto_rag_service_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1170,7 +1247,7 @@ func (x *MetadataStats) String() string {
 func (*MetadataStats) ProtoMessage() {}
 
 func (x *MetadataStats) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_rag_service_proto_msgTypes[15]
+	mi := &file_api_proto_rag_service_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1183,7 +1260,7 @@ func (x *MetadataStats) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MetadataStats.ProtoReflect.Descriptor instead.
 func (*MetadataStats) Descriptor() ([]byte, []int) {
-	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{15}
+	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *MetadataStats) GetTotalAttributes() int32 {
@@ -1225,7 +1302,7 @@ type RiskDistribution struct {
 
 func (x *RiskDistribution) Reset() {
 	*x = RiskDistribution{}
-	mi := &file_api_proto_rag_service_proto_msgTypes[16]
+	mi := &file_api_proto_rag_service_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1237,7 +1314,7 @@ func (x *RiskDistribution) String() string {
 func (*RiskDistribution) ProtoMessage() {}
 
 func (x *RiskDistribution) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_rag_service_proto_msgTypes[16]
+	mi := &file_api_proto_rag_service_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1250,7 +1327,7 @@ func (x *RiskDistribution) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RiskDistribution.ProtoReflect.Descriptor instead.
 func (*RiskDistribution) Descriptor() ([]byte, []int) {
-	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{16}
+	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *RiskDistribution) GetRiskLevel() string {
@@ -1280,7 +1357,7 @@ type EnrichedSearchResponse struct {
 
 func (x *EnrichedSearchResponse) Reset() {
 	*x = EnrichedSearchResponse{}
-	mi := &file_api_proto_rag_service_proto_msgTypes[17]
+	mi := &file_api_proto_rag_service_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1292,7 +1369,7 @@ func (x *EnrichedSearchResponse) String() string {
 func (*EnrichedSearchResponse) ProtoMessage() {}
 
 func (x *EnrichedSearchResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_rag_service_proto_msgTypes[17]
+	mi := &file_api_proto_rag_service_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1305,7 +1382,7 @@ func (x *EnrichedSearchResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EnrichedSearchResponse.ProtoReflect.Descriptor instead.
 func (*EnrichedSearchResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{17}
+	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *EnrichedSearchResponse) GetQuery() string {
@@ -1348,7 +1425,7 @@ type EnrichedResult struct {
 
 func (x *EnrichedResult) Reset() {
 	*x = EnrichedResult{}
-	mi := &file_api_proto_rag_service_proto_msgTypes[18]
+	mi := &file_api_proto_rag_service_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1360,7 +1437,7 @@ func (x *EnrichedResult) String() string {
 func (*EnrichedResult) ProtoMessage() {}
 
 func (x *EnrichedResult) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_rag_service_proto_msgTypes[18]
+	mi := &file_api_proto_rag_service_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1373,7 +1450,7 @@ func (x *EnrichedResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EnrichedResult.ProtoReflect.Descriptor instead.
 func (*EnrichedResult) Descriptor() ([]byte, []int) {
-	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{18}
+	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *EnrichedResult) GetAttribute() *RagResult {
@@ -1411,7 +1488,7 @@ type DocumentInfo struct {
 
 func (x *DocumentInfo) Reset() {
 	*x = DocumentInfo{}
-	mi := &file_api_proto_rag_service_proto_msgTypes[19]
+	mi := &file_api_proto_rag_service_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1423,7 +1500,7 @@ func (x *DocumentInfo) String() string {
 func (*DocumentInfo) ProtoMessage() {}
 
 func (x *DocumentInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_rag_service_proto_msgTypes[19]
+	mi := &file_api_proto_rag_service_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1436,7 +1513,7 @@ func (x *DocumentInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DocumentInfo.ProtoReflect.Descriptor instead.
 func (*DocumentInfo) Descriptor() ([]byte, []int) {
-	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{19}
+	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *DocumentInfo) GetCode() string {
@@ -1488,7 +1565,7 @@ type RegulationInfo struct {
 
 func (x *RegulationInfo) Reset() {
 	*x = RegulationInfo{}
-	mi := &file_api_proto_rag_service_proto_msgTypes[20]
+	mi := &file_api_proto_rag_service_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1500,7 +1577,7 @@ func (x *RegulationInfo) String() string {
 func (*RegulationInfo) ProtoMessage() {}
 
 func (x *RegulationInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_rag_service_proto_msgTypes[20]
+	mi := &file_api_proto_rag_service_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1513,7 +1590,7 @@ func (x *RegulationInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegulationInfo.ProtoReflect.Descriptor instead.
 func (*RegulationInfo) Descriptor() ([]byte, []int) {
-	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{20}
+	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *RegulationInfo) GetCode() string {
@@ -1551,28 +1628,31 @@ func (x *RegulationInfo) GetRegion() string {
 	return ""
 }
 
-// HealthCheckRequest checks system health
-type HealthCheckRequest struct {
+// SectionSearchRequest contains parameters for section search
+type SectionSearchRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	CaseId        string                 `protobuf:"bytes,3,opt,name=case_id,json=caseId,proto3" json:"case_id,omitempty"` // Optional: search the text extracted from the case's evidence instead
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *HealthCheckRequest) Reset() {
-	*x = HealthCheckRequest{}
-	mi := &file_api_proto_rag_service_proto_msgTypes[21]
+func (x *SectionSearchRequest) Reset() {
+	*x = SectionSearchRequest{}
+	mi := &file_api_proto_rag_service_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *HealthCheckRequest) String() string {
+func (x *SectionSearchRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HealthCheckRequest) ProtoMessage() {}
+func (*SectionSearchRequest) ProtoMessage() {}
 
-func (x *HealthCheckRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_rag_service_proto_msgTypes[21]
+func (x *SectionSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_rag_service_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1583,39 +1663,58 @@ func (x *HealthCheckRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HealthCheckRequest.ProtoReflect.Descriptor instead.
-func (*HealthCheckRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use SectionSearchRequest.ProtoReflect.Descriptor instead.
+func (*SectionSearchRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{22}
 }
 
-// HealthCheckResponse contains health status
-type HealthCheckResponse struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Status         string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
-	Model          string                 `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
-	Dimensions     int32                  `protobuf:"varint,3,opt,name=dimensions,proto3" json:"dimensions,omitempty"`
-	Timestamp      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	DatabaseStatus string                 `protobuf:"bytes,5,opt,name=database_status,json=databaseStatus,proto3" json:"database_status,omitempty"`
-	EmbedderStatus string                 `protobuf:"bytes,6,opt,name=embedder_status,json=embedderStatus,proto3" json:"embedder_status,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (x *SectionSearchRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
 }
 
-func (x *HealthCheckResponse) Reset() {
-	*x = HealthCheckResponse{}
-	mi := &file_api_proto_rag_service_proto_msgTypes[22]
+func (x *SectionSearchRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SectionSearchRequest) GetCaseId() string {
+	if x != nil {
+		return x.CaseId
+	}
+	return ""
+}
+
+// SectionSearchResponse contains matching document sections
+type SectionSearchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Count         int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Results       []*SectionResult       `protobuf:"bytes,4,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SectionSearchResponse) Reset() {
+	*x = SectionSearchResponse{}
+	mi := &file_api_proto_rag_service_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *HealthCheckResponse) String() string {
+func (x *SectionSearchResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HealthCheckResponse) ProtoMessage() {}
+func (*SectionSearchResponse) ProtoMessage() {}
 
-func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_rag_service_proto_msgTypes[22]
+func (x *SectionSearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_rag_service_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1626,77 +1725,849 @@ func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HealthCheckResponse.ProtoReflect.Descriptor instead.
-func (*HealthCheckResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use SectionSearchResponse.ProtoReflect.Descriptor instead.
+func (*SectionSearchResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *HealthCheckResponse) GetStatus() string {
+func (x *SectionSearchResponse) GetQuery() string {
 	if x != nil {
-		return x.Status
+		return x.Query
 	}
 	return ""
 }
 
-func (x *HealthCheckResponse) GetModel() string {
+func (x *SectionSearchResponse) GetLimit() int32 {
 	if x != nil {
-		return x.Model
+		return x.Limit
 	}
-	return ""
+	return 0
 }
 
-func (x *HealthCheckResponse) GetDimensions() int32 {
+func (x *SectionSearchResponse) GetCount() int32 {
 	if x != nil {
-		return x.Dimensions
+		return x.Count
 	}
 	return 0
 }
 
-func (x *HealthCheckResponse) GetTimestamp() *timestamppb.Timestamp {
+func (x *SectionSearchResponse) GetResults() []*SectionResult {
 	if x != nil {
-		return x.Timestamp
+		return x.Results
 	}
 	return nil
 }
 
-func (x *HealthCheckResponse) GetDatabaseStatus() string {
+// SectionResult is a document section with its document and regulation
+type SectionResult struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	SectionId       int32                  `protobuf:"varint,1,opt,name=section_id,json=sectionId,proto3" json:"section_id,omitempty"`
+	SectionNumber   string                 `protobuf:"bytes,2,opt,name=section_number,json=sectionNumber,proto3" json:"section_number,omitempty"`
+	SectionTitle    string                 `protobuf:"bytes,3,opt,name=section_title,json=sectionTitle,proto3" json:"section_title,omitempty"`
+	TextExcerpt     string                 `protobuf:"bytes,4,opt,name=text_excerpt,json=textExcerpt,proto3" json:"text_excerpt,omitempty"`
+	PageNumber      int32                  `protobuf:"varint,5,opt,name=page_number,json=pageNumber,proto3" json:"page_number,omitempty"`
+	DocumentCode    string                 `protobuf:"bytes,6,opt,name=document_code,json=documentCode,proto3" json:"document_code,omitempty"`
+	DocumentTitle   string                 `protobuf:"bytes,7,opt,name=document_title,json=documentTitle,proto3" json:"document_title,omitempty"`
+	Jurisdiction    string                 `protobuf:"bytes,8,opt,name=jurisdiction,proto3" json:"jurisdiction,omitempty"`
+	DocType         string                 `protobuf:"bytes,9,opt,name=doc_type,json=docType,proto3" json:"doc_type,omitempty"`
+	RegulationCode  string                 `protobuf:"bytes,10,opt,name=regulation_code,json=regulationCode,proto3" json:"regulation_code,omitempty"`
+	RegulationTitle string                 `protobuf:"bytes,11,opt,name=regulation_title,json=regulationTitle,proto3" json:"regulation_title,omitempty"`
+	SimilarityScore float32                `protobuf:"fixed32,12,opt,name=similarity_score,json=similarityScore,proto3" json:"similarity_score,omitempty"`
+	Distance        float32                `protobuf:"fixed32,13,opt,name=distance,proto3" json:"distance,omitempty"`
+	EvidenceId      int64                  `protobuf:"varint,14,opt,name=evidence_id,json=evidenceId,proto3" json:"evidence_id,omitempty"` // Set when the text was extracted from uploaded evidence
+	CaseId          string                 `protobuf:"bytes,15,opt,name=case_id,json=caseId,proto3" json:"case_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SectionResult) Reset() {
+	*x = SectionResult{}
+	mi := &file_api_proto_rag_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SectionResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SectionResult) ProtoMessage() {}
+
+func (x *SectionResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_rag_service_proto_msgTypes[24]
 	if x != nil {
-		return x.DatabaseStatus
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SectionResult.ProtoReflect.Descriptor instead.
+func (*SectionResult) Descriptor() ([]byte, []int) {
+	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SectionResult) GetSectionId() int32 {
+	if x != nil {
+		return x.SectionId
+	}
+	return 0
+}
+
+func (x *SectionResult) GetSectionNumber() string {
+	if x != nil {
+		return x.SectionNumber
 	}
 	return ""
 }
 
-func (x *HealthCheckResponse) GetEmbedderStatus() string {
+func (x *SectionResult) GetSectionTitle() string {
 	if x != nil {
-		return x.EmbedderStatus
+		return x.SectionTitle
 	}
 	return ""
 }
 
-var File_api_proto_rag_service_proto protoreflect.FileDescriptor
+func (x *SectionResult) GetTextExcerpt() string {
+	if x != nil {
+		return x.TextExcerpt
+	}
+	return ""
+}
 
-const file_api_proto_rag_service_proto_rawDesc = "" +
-	"\n" +
-	"\x1bapi/proto/rag_service.proto\x12\akyc.rag\x1a\x1fgoogle/protobuf/timestamp.proto\">\n" +
-	"\x10RagSearchRequest\x12\x14\n" +
-	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\x83\x01\n" +
-	"\x11RagSearchResponse\x12\x14\n" +
-	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x14\n" +
-	"\x05count\x18\x03 \x01(\x05R\x05count\x12,\n" +
-	"\aresults\x18\x04 \x03(\v2\x12.kyc.rag.RagResultR\aresults\"\xcd\x02\n" +
-	"\tRagResult\x12%\n" +
-	"\x0eattribute_code\x18\x01 \x01(\tR\rattributeCode\x12\x1d\n" +
-	"\n" +
-	"risk_level\x18\x02 \x01(\tR\triskLevel\x12\x1b\n" +
-	"\tdata_type\x18\x03 \x01(\tR\bdataType\x12 \n" +
-	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x1a\n" +
-	"\bsynonyms\x18\x05 \x03(\tR\bsynonyms\x121\n" +
-	"\x14regulatory_citations\x18\x06 \x03(\tR\x13regulatoryCitations\x12%\n" +
-	"\x0eexample_values\x18\a \x03(\tR\rexampleValues\x12)\n" +
-	"\x10similarity_score\x18\b \x01(\x02R\x0fsimilarityScore\x12\x1a\n" +
-	"\bdistance\x18\t \x01(\x02R\bdistance\"W\n" +
+func (x *SectionResult) GetPageNumber() int32 {
+	if x != nil {
+		return x.PageNumber
+	}
+	return 0
+}
+
+func (x *SectionResult) GetDocumentCode() string {
+	if x != nil {
+		return x.DocumentCode
+	}
+	return ""
+}
+
+func (x *SectionResult) GetDocumentTitle() string {
+	if x != nil {
+		return x.DocumentTitle
+	}
+	return ""
+}
+
+func (x *SectionResult) GetJurisdiction() string {
+	if x != nil {
+		return x.Jurisdiction
+	}
+	return ""
+}
+
+func (x *SectionResult) GetDocType() string {
+	if x != nil {
+		return x.DocType
+	}
+	return ""
+}
+
+func (x *SectionResult) GetRegulationCode() string {
+	if x != nil {
+		return x.RegulationCode
+	}
+	return ""
+}
+
+func (x *SectionResult) GetRegulationTitle() string {
+	if x != nil {
+		return x.RegulationTitle
+	}
+	return ""
+}
+
+func (x *SectionResult) GetSimilarityScore() float32 {
+	if x != nil {
+		return x.SimilarityScore
+	}
+	return 0
+}
+
+func (x *SectionResult) GetDistance() float32 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+func (x *SectionResult) GetEvidenceId() int64 {
+	if x != nil {
+		return x.EvidenceId
+	}
+	return 0
+}
+
+func (x *SectionResult) GetCaseId() string {
+	if x != nil {
+		return x.CaseId
+	}
+	return ""
+}
+
+// ClusterRecommendRequest contains parameters for cluster recommendation
+type ClusterRecommendRequest struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Query                string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Limit                int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`                                                             // Clusters to return (default 3)
+	AttributesPerCluster int32                  `protobuf:"varint,3,opt,name=attributes_per_cluster,json=attributesPerCluster,proto3" json:"attributes_per_cluster,omitempty"` // Closest members of each cluster to include (0 = none)
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ClusterRecommendRequest) Reset() {
+	*x = ClusterRecommendRequest{}
+	mi := &file_api_proto_rag_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClusterRecommendRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClusterRecommendRequest) ProtoMessage() {}
+
+func (x *ClusterRecommendRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_rag_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClusterRecommendRequest.ProtoReflect.Descriptor instead.
+func (*ClusterRecommendRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ClusterRecommendRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ClusterRecommendRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ClusterRecommendRequest) GetAttributesPerCluster() int32 {
+	if x != nil {
+		return x.AttributesPerCluster
+	}
+	return 0
+}
+
+// ClusterRecommendResponse contains the recommended clusters, closest first
+type ClusterRecommendResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Query         string                   `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Count         int32                    `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Clusters      []*ClusterRecommendation `protobuf:"bytes,3,rep,name=clusters,proto3" json:"clusters,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClusterRecommendResponse) Reset() {
+	*x = ClusterRecommendResponse{}
+	mi := &file_api_proto_rag_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClusterRecommendResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClusterRecommendResponse) ProtoMessage() {}
+
+func (x *ClusterRecommendResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_rag_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClusterRecommendResponse.ProtoReflect.Descriptor instead.
+func (*ClusterRecommendResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ClusterRecommendResponse) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ClusterRecommendResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *ClusterRecommendResponse) GetClusters() []*ClusterRecommendation {
+	if x != nil {
+		return x.Clusters
+	}
+	return nil
+}
+
+// ClusterRecommendation is a cluster with its similarity to the query
+type ClusterRecommendation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClusterCode   string                 `protobuf:"bytes,1,opt,name=cluster_code,json=clusterCode,proto3" json:"cluster_code,omitempty"`
+	ClusterName   string                 `protobuf:"bytes,2,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	Similarity    float32                `protobuf:"fixed32,3,opt,name=similarity,proto3" json:"similarity,omitempty"`
+	MemberCount   int32                  `protobuf:"varint,4,opt,name=member_count,json=memberCount,proto3" json:"member_count,omitempty"`
+	Attributes    []*RagResult           `protobuf:"bytes,5,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClusterRecommendation) Reset() {
+	*x = ClusterRecommendation{}
+	mi := &file_api_proto_rag_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClusterRecommendation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClusterRecommendation) ProtoMessage() {}
+
+func (x *ClusterRecommendation) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_rag_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClusterRecommendation.ProtoReflect.Descriptor instead.
+func (*ClusterRecommendation) Descriptor() ([]byte, []int) {
+	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ClusterRecommendation) GetClusterCode() string {
+	if x != nil {
+		return x.ClusterCode
+	}
+	return ""
+}
+
+func (x *ClusterRecommendation) GetClusterName() string {
+	if x != nil {
+		return x.ClusterName
+	}
+	return ""
+}
+
+func (x *ClusterRecommendation) GetSimilarity() float32 {
+	if x != nil {
+		return x.Similarity
+	}
+	return 0
+}
+
+func (x *ClusterRecommendation) GetMemberCount() int32 {
+	if x != nil {
+		return x.MemberCount
+	}
+	return 0
+}
+
+func (x *ClusterRecommendation) GetAttributes() []*RagResult {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+// GetAuditStatsRequest retrieves audit statistics
+type GetAuditStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Top           int32                  `protobuf:"varint,1,opt,name=top,proto3" json:"top,omitempty"` // Top N popular queries to include
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAuditStatsRequest) Reset() {
+	*x = GetAuditStatsRequest{}
+	mi := &file_api_proto_rag_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAuditStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAuditStatsRequest) ProtoMessage() {}
+
+func (x *GetAuditStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_rag_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAuditStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetAuditStatsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetAuditStatsRequest) GetTop() int32 {
+	if x != nil {
+		return x.Top
+	}
+	return 0
+}
+
+// AuditStats contains RAG query audit statistics
+type AuditStats struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	TotalQueries     int32                  `protobuf:"varint,1,opt,name=total_queries,json=totalQueries,proto3" json:"total_queries,omitempty"`
+	QueriesToday     int32                  `protobuf:"varint,2,opt,name=queries_today,json=queriesToday,proto3" json:"queries_today,omitempty"`
+	ErrorCount       int32                  `protobuf:"varint,3,opt,name=error_count,json=errorCount,proto3" json:"error_count,omitempty"`
+	ErrorRatePercent float32                `protobuf:"fixed32,4,opt,name=error_rate_percent,json=errorRatePercent,proto3" json:"error_rate_percent,omitempty"`
+	AvgLatencyMs     float32                `protobuf:"fixed32,5,opt,name=avg_latency_ms,json=avgLatencyMs,proto3" json:"avg_latency_ms,omitempty"`
+	UniqueAgents     int32                  `protobuf:"varint,6,opt,name=unique_agents,json=uniqueAgents,proto3" json:"unique_agents,omitempty"`
+	PopularQueries   []*PopularQuery        `protobuf:"bytes,7,rep,name=popular_queries,json=popularQueries,proto3" json:"popular_queries,omitempty"`
+	Agents           []*AgentPerformance    `protobuf:"bytes,8,rep,name=agents,proto3" json:"agents,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *AuditStats) Reset() {
+	*x = AuditStats{}
+	mi := &file_api_proto_rag_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditStats) ProtoMessage() {}
+
+func (x *AuditStats) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_rag_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditStats.ProtoReflect.Descriptor instead.
+func (*AuditStats) Descriptor() ([]byte, []int) {
+	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *AuditStats) GetTotalQueries() int32 {
+	if x != nil {
+		return x.TotalQueries
+	}
+	return 0
+}
+
+func (x *AuditStats) GetQueriesToday() int32 {
+	if x != nil {
+		return x.QueriesToday
+	}
+	return 0
+}
+
+func (x *AuditStats) GetErrorCount() int32 {
+	if x != nil {
+		return x.ErrorCount
+	}
+	return 0
+}
+
+func (x *AuditStats) GetErrorRatePercent() float32 {
+	if x != nil {
+		return x.ErrorRatePercent
+	}
+	return 0
+}
+
+func (x *AuditStats) GetAvgLatencyMs() float32 {
+	if x != nil {
+		return x.AvgLatencyMs
+	}
+	return 0
+}
+
+func (x *AuditStats) GetUniqueAgents() int32 {
+	if x != nil {
+		return x.UniqueAgents
+	}
+	return 0
+}
+
+func (x *AuditStats) GetPopularQueries() []*PopularQuery {
+	if x != nil {
+		return x.PopularQueries
+	}
+	return nil
+}
+
+func (x *AuditStats) GetAgents() []*AgentPerformance {
+	if x != nil {
+		return x.Agents
+	}
+	return nil
+}
+
+// PopularQuery is a frequently asked query
+type PopularQuery struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	QueryText     string                 `protobuf:"bytes,1,opt,name=query_text,json=queryText,proto3" json:"query_text,omitempty"`
+	QueryCount    int32                  `protobuf:"varint,2,opt,name=query_count,json=queryCount,proto3" json:"query_count,omitempty"`
+	AvgLatencyMs  float32                `protobuf:"fixed32,3,opt,name=avg_latency_ms,json=avgLatencyMs,proto3" json:"avg_latency_ms,omitempty"`
+	AvgResults    float32                `protobuf:"fixed32,4,opt,name=avg_results,json=avgResults,proto3" json:"avg_results,omitempty"`
+	LastQueried   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_queried,json=lastQueried,proto3" json:"last_queried,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PopularQuery) Reset() {
+	*x = PopularQuery{}
+	mi := &file_api_proto_rag_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PopularQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PopularQuery) ProtoMessage() {}
+
+func (x *PopularQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_rag_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PopularQuery.ProtoReflect.Descriptor instead.
+func (*PopularQuery) Descriptor() ([]byte, []int) {
+	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *PopularQuery) GetQueryText() string {
+	if x != nil {
+		return x.QueryText
+	}
+	return ""
+}
+
+func (x *PopularQuery) GetQueryCount() int32 {
+	if x != nil {
+		return x.QueryCount
+	}
+	return 0
+}
+
+func (x *PopularQuery) GetAvgLatencyMs() float32 {
+	if x != nil {
+		return x.AvgLatencyMs
+	}
+	return 0
+}
+
+func (x *PopularQuery) GetAvgResults() float32 {
+	if x != nil {
+		return x.AvgResults
+	}
+	return 0
+}
+
+func (x *PopularQuery) GetLastQueried() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastQueried
+	}
+	return nil
+}
+
+// AgentPerformance contains query metrics for an agent
+type AgentPerformance struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentName     string                 `protobuf:"bytes,1,opt,name=agent_name,json=agentName,proto3" json:"agent_name,omitempty"`
+	Sessions      int32                  `protobuf:"varint,2,opt,name=sessions,proto3" json:"sessions,omitempty"`
+	TotalQueries  int32                  `protobuf:"varint,3,opt,name=total_queries,json=totalQueries,proto3" json:"total_queries,omitempty"`
+	AvgLatencyMs  float32                `protobuf:"fixed32,4,opt,name=avg_latency_ms,json=avgLatencyMs,proto3" json:"avg_latency_ms,omitempty"`
+	ErrorCount    int32                  `protobuf:"varint,5,opt,name=error_count,json=errorCount,proto3" json:"error_count,omitempty"`
+	SuccessRate   float32                `protobuf:"fixed32,6,opt,name=success_rate,json=successRate,proto3" json:"success_rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentPerformance) Reset() {
+	*x = AgentPerformance{}
+	mi := &file_api_proto_rag_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentPerformance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentPerformance) ProtoMessage() {}
+
+func (x *AgentPerformance) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_rag_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentPerformance.ProtoReflect.Descriptor instead.
+func (*AgentPerformance) Descriptor() ([]byte, []int) {
+	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *AgentPerformance) GetAgentName() string {
+	if x != nil {
+		return x.AgentName
+	}
+	return ""
+}
+
+func (x *AgentPerformance) GetSessions() int32 {
+	if x != nil {
+		return x.Sessions
+	}
+	return 0
+}
+
+func (x *AgentPerformance) GetTotalQueries() int32 {
+	if x != nil {
+		return x.TotalQueries
+	}
+	return 0
+}
+
+func (x *AgentPerformance) GetAvgLatencyMs() float32 {
+	if x != nil {
+		return x.AvgLatencyMs
+	}
+	return 0
+}
+
+func (x *AgentPerformance) GetErrorCount() int32 {
+	if x != nil {
+		return x.ErrorCount
+	}
+	return 0
+}
+
+func (x *AgentPerformance) GetSuccessRate() float32 {
+	if x != nil {
+		return x.SuccessRate
+	}
+	return 0
+}
+
+// HealthCheckRequest checks system health
+type HealthCheckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HealthCheckRequest) Reset() {
+	*x = HealthCheckRequest{}
+	mi := &file_api_proto_rag_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthCheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthCheckRequest) ProtoMessage() {}
+
+func (x *HealthCheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_rag_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthCheckRequest.ProtoReflect.Descriptor instead.
+func (*HealthCheckRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{32}
+}
+
+// HealthCheckResponse contains health status
+type HealthCheckResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Status         string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Model          string                 `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	Dimensions     int32                  `protobuf:"varint,3,opt,name=dimensions,proto3" json:"dimensions,omitempty"`
+	Timestamp      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	DatabaseStatus string                 `protobuf:"bytes,5,opt,name=database_status,json=databaseStatus,proto3" json:"database_status,omitempty"`
+	EmbedderStatus string                 `protobuf:"bytes,6,opt,name=embedder_status,json=embedderStatus,proto3" json:"embedder_status,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *HealthCheckResponse) Reset() {
+	*x = HealthCheckResponse{}
+	mi := &file_api_proto_rag_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthCheckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthCheckResponse) ProtoMessage() {}
+
+func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_rag_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthCheckResponse.ProtoReflect.Descriptor instead.
+func (*HealthCheckResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_rag_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *HealthCheckResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *HealthCheckResponse) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *HealthCheckResponse) GetDimensions() int32 {
+	if x != nil {
+		return x.Dimensions
+	}
+	return 0
+}
+
+func (x *HealthCheckResponse) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *HealthCheckResponse) GetDatabaseStatus() string {
+	if x != nil {
+		return x.DatabaseStatus
+	}
+	return ""
+}
+
+func (x *HealthCheckResponse) GetEmbedderStatus() string {
+	if x != nil {
+		return x.EmbedderStatus
+	}
+	return ""
+}
+
+var File_api_proto_rag_service_proto protoreflect.FileDescriptor
+
+const file_api_proto_rag_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1bapi/proto/rag_service.proto\x12\akyc.rag\x1a\x1fgoogle/protobuf/timestamp.proto\">\n" +
+	"\x10RagSearchRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\x83\x01\n" +
+	"\x11RagSearchResponse\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\x12,\n" +
+	"\aresults\x18\x04 \x03(\v2\x12.kyc.rag.RagResultR\aresults\"\xcd\x02\n" +
+	"\tRagResult\x12%\n" +
+	"\x0eattribute_code\x18\x01 \x01(\tR\rattributeCode\x12\x1d\n" +
+	"\n" +
+	"risk_level\x18\x02 \x01(\tR\triskLevel\x12\x1b\n" +
+	"\tdata_type\x18\x03 \x01(\tR\bdataType\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bsynonyms\x18\x05 \x03(\tR\bsynonyms\x121\n" +
+	"\x14regulatory_citations\x18\x06 \x03(\tR\x13regulatoryCitations\x12%\n" +
+	"\x0eexample_values\x18\a \x03(\tR\rexampleValues\x12)\n" +
+	"\x10similarity_score\x18\b \x01(\x02R\x0fsimilarityScore\x12\x1a\n" +
+	"\bdistance\x18\t \x01(\x02R\bdistance\"W\n" +
 	"\x18SimilarAttributesRequest\x12%\n" +
 	"\x0eattribute_code\x18\x01 \x01(\tR\rattributeCode\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\"=\n" +
@@ -1736,7 +2607,7 @@ const file_api_proto_rag_service_proto_rawDesc = "" +
 	"\n" +
 	"agent_name\x18\a \x01(\tR\tagentName\x12\x1d\n" +
 	"\n" +
-	"agent_type\x18\b \x01(\tR\tagentType\"\xb3\x01\n" +
+	"agent_type\x18\b \x01(\tR\tagentType\"\xe7\x01\n" +
 	"\x13RagFeedbackResponse\x12\x16\n" +
 	"\x06status\x18\x01 \x01(\tR\x06status\x12\x0e\n" +
 	"\x02id\x18\x02 \x01(\x05R\x02id\x12\x1a\n" +
@@ -1744,7 +2615,13 @@ const file_api_proto_rag_service_proto_rawDesc = "" +
 	"\n" +
 	"agent_name\x18\x04 \x01(\tR\tagentName\x129\n" +
 	"\n" +
-	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"0\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x122\n" +
+	"\aoutcome\x18\x06 \x01(\v2\x18.kyc.rag.FeedbackOutcomeR\aoutcome\"q\n" +
+	"\x0fFeedbackOutcome\x12\x16\n" +
+	"\x06tenant\x18\x01 \x01(\tR\x06tenant\x12\x14\n" +
+	"\x05gated\x18\x02 \x01(\bR\x05gated\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x18\n" +
+	"\aactions\x18\x04 \x03(\tR\aactions\"0\n" +
 	"\x18GetRecentFeedbackRequest\x12\x14\n" +
 	"\x05limit\x18\x01 \x01(\x05R\x05limit\"\xe6\x02\n" +
 	"\vRagFeedback\x12\x0e\n" +
@@ -1816,7 +2693,85 @@ const file_api_proto_rag_service_proto_rawDesc = "" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x1a\n" +
 	"\bcitation\x18\x03 \x01(\tR\bcitation\x12\x18\n" +
 	"\asummary\x18\x04 \x01(\tR\asummary\x12\x16\n" +
-	"\x06region\x18\x05 \x01(\tR\x06region\"\x14\n" +
+	"\x06region\x18\x05 \x01(\tR\x06region\"[\n" +
+	"\x14SectionSearchRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x17\n" +
+	"\acase_id\x18\x03 \x01(\tR\x06caseId\"\x8b\x01\n" +
+	"\x15SectionSearchResponse\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\x120\n" +
+	"\aresults\x18\x04 \x03(\v2\x16.kyc.rag.SectionResultR\aresults\"\x9e\x04\n" +
+	"\rSectionResult\x12\x1d\n" +
+	"\n" +
+	"section_id\x18\x01 \x01(\x05R\tsectionId\x12%\n" +
+	"\x0esection_number\x18\x02 \x01(\tR\rsectionNumber\x12#\n" +
+	"\rsection_title\x18\x03 \x01(\tR\fsectionTitle\x12!\n" +
+	"\ftext_excerpt\x18\x04 \x01(\tR\vtextExcerpt\x12\x1f\n" +
+	"\vpage_number\x18\x05 \x01(\x05R\n" +
+	"pageNumber\x12#\n" +
+	"\rdocument_code\x18\x06 \x01(\tR\fdocumentCode\x12%\n" +
+	"\x0edocument_title\x18\a \x01(\tR\rdocumentTitle\x12\"\n" +
+	"\fjurisdiction\x18\b \x01(\tR\fjurisdiction\x12\x19\n" +
+	"\bdoc_type\x18\t \x01(\tR\adocType\x12'\n" +
+	"\x0fregulation_code\x18\n" +
+	" \x01(\tR\x0eregulationCode\x12)\n" +
+	"\x10regulation_title\x18\v \x01(\tR\x0fregulationTitle\x12)\n" +
+	"\x10similarity_score\x18\f \x01(\x02R\x0fsimilarityScore\x12\x1a\n" +
+	"\bdistance\x18\r \x01(\x02R\bdistance\x12\x1f\n" +
+	"\vevidence_id\x18\x0e \x01(\x03R\n" +
+	"evidenceId\x12\x17\n" +
+	"\acase_id\x18\x0f \x01(\tR\x06caseId\"{\n" +
+	"\x17ClusterRecommendRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x124\n" +
+	"\x16attributes_per_cluster\x18\x03 \x01(\x05R\x14attributesPerCluster\"\x82\x01\n" +
+	"\x18ClusterRecommendResponse\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12:\n" +
+	"\bclusters\x18\x03 \x03(\v2\x1e.kyc.rag.ClusterRecommendationR\bclusters\"\xd4\x01\n" +
+	"\x15ClusterRecommendation\x12!\n" +
+	"\fcluster_code\x18\x01 \x01(\tR\vclusterCode\x12!\n" +
+	"\fcluster_name\x18\x02 \x01(\tR\vclusterName\x12\x1e\n" +
+	"\n" +
+	"similarity\x18\x03 \x01(\x02R\n" +
+	"similarity\x12!\n" +
+	"\fmember_count\x18\x04 \x01(\x05R\vmemberCount\x122\n" +
+	"\n" +
+	"attributes\x18\x05 \x03(\v2\x12.kyc.rag.RagResultR\n" +
+	"attributes\"(\n" +
+	"\x14GetAuditStatsRequest\x12\x10\n" +
+	"\x03top\x18\x01 \x01(\x05R\x03top\"\xe3\x02\n" +
+	"\n" +
+	"AuditStats\x12#\n" +
+	"\rtotal_queries\x18\x01 \x01(\x05R\ftotalQueries\x12#\n" +
+	"\rqueries_today\x18\x02 \x01(\x05R\fqueriesToday\x12\x1f\n" +
+	"\verror_count\x18\x03 \x01(\x05R\n" +
+	"errorCount\x12,\n" +
+	"\x12error_rate_percent\x18\x04 \x01(\x02R\x10errorRatePercent\x12$\n" +
+	"\x0eavg_latency_ms\x18\x05 \x01(\x02R\favgLatencyMs\x12#\n" +
+	"\runique_agents\x18\x06 \x01(\x05R\funiqueAgents\x12>\n" +
+	"\x0fpopular_queries\x18\a \x03(\v2\x15.kyc.rag.PopularQueryR\x0epopularQueries\x121\n" +
+	"\x06agents\x18\b \x03(\v2\x19.kyc.rag.AgentPerformanceR\x06agents\"\xd4\x01\n" +
+	"\fPopularQuery\x12\x1d\n" +
+	"\n" +
+	"query_text\x18\x01 \x01(\tR\tqueryText\x12\x1f\n" +
+	"\vquery_count\x18\x02 \x01(\x05R\n" +
+	"queryCount\x12$\n" +
+	"\x0eavg_latency_ms\x18\x03 \x01(\x02R\favgLatencyMs\x12\x1f\n" +
+	"\vavg_results\x18\x04 \x01(\x02R\n" +
+	"avgResults\x12=\n" +
+	"\flast_queried\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vlastQueried\"\xdc\x01\n" +
+	"\x10AgentPerformance\x12\x1d\n" +
+	"\n" +
+	"agent_name\x18\x01 \x01(\tR\tagentName\x12\x1a\n" +
+	"\bsessions\x18\x02 \x01(\x05R\bsessions\x12#\n" +
+	"\rtotal_queries\x18\x03 \x01(\x05R\ftotalQueries\x12$\n" +
+	"\x0eavg_latency_ms\x18\x04 \x01(\x02R\favgLatencyMs\x12\x1f\n" +
+	"\verror_count\x18\x05 \x01(\x05R\n" +
+	"errorCount\x12!\n" +
+	"\fsuccess_rate\x18\x06 \x01(\x02R\vsuccessRate\"\x14\n" +
 	"\x12HealthCheckRequest\"\xef\x01\n" +
 	"\x13HealthCheckResponse\x12\x16\n" +
 	"\x06status\x18\x01 \x01(\tR\x06status\x12\x14\n" +
@@ -1826,10 +2781,11 @@ const file_api_proto_rag_service_proto_rawDesc = "" +
 	"dimensions\x128\n" +
 	"\ttimestamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12'\n" +
 	"\x0fdatabase_status\x18\x05 \x01(\tR\x0edatabaseStatus\x12'\n" +
-	"\x0fembedder_status\x18\x06 \x01(\tR\x0eembedderStatus2\xa3\x06\n" +
+	"\x0fembedder_status\x18\x06 \x01(\tR\x0eembedderStatus2\xdc\b\n" +
 	"\n" +
 	"RagService\x12H\n" +
-	"\x0fAttributeSearch\x12\x19.kyc.rag.RagSearchRequest\x1a\x1a.kyc.rag.RagSearchResponse\x12R\n" +
+	"\x0fAttributeSearch\x12\x19.kyc.rag.RagSearchRequest\x1a\x1a.kyc.rag.RagSearchResponse\x12I\n" +
+	"\x16SearchAttributesStream\x12\x19.kyc.rag.RagSearchRequest\x1a\x12.kyc.rag.RagResult0\x01\x12R\n" +
 	"\x11SimilarAttributes\x12!.kyc.rag.SimilarAttributesRequest\x1a\x1a.kyc.rag.RagSearchResponse\x12D\n" +
 	"\n" +
 	"TextSearch\x12\x1a.kyc.rag.TextSearchRequest\x1a\x1a.kyc.rag.RagSearchResponse\x12K\n" +
@@ -1838,7 +2794,10 @@ const file_api_proto_rag_service_proto_rawDesc = "" +
 	"\x11GetRecentFeedback\x12!.kyc.rag.GetRecentFeedbackRequest\x1a\x14.kyc.rag.RagFeedback0\x01\x12X\n" +
 	"\x14GetFeedbackAnalytics\x12$.kyc.rag.GetFeedbackAnalyticsRequest\x1a\x1a.kyc.rag.FeedbackAnalytics\x12L\n" +
 	"\x10GetMetadataStats\x12 .kyc.rag.GetMetadataStatsRequest\x1a\x16.kyc.rag.MetadataStats\x12U\n" +
-	"\x17EnrichedAttributeSearch\x12\x19.kyc.rag.RagSearchRequest\x1a\x1f.kyc.rag.EnrichedSearchResponse\x12H\n" +
+	"\x17EnrichedAttributeSearch\x12\x19.kyc.rag.RagSearchRequest\x1a\x1f.kyc.rag.EnrichedSearchResponse\x12N\n" +
+	"\rSectionSearch\x12\x1d.kyc.rag.SectionSearchRequest\x1a\x1e.kyc.rag.SectionSearchResponse\x12W\n" +
+	"\x10ClusterRecommend\x12 .kyc.rag.ClusterRecommendRequest\x1a!.kyc.rag.ClusterRecommendResponse\x12C\n" +
+	"\rGetAuditStats\x12\x1d.kyc.rag.GetAuditStatsRequest\x1a\x13.kyc.rag.AuditStats\x12H\n" +
 	"\vHealthCheck\x12\x1b.kyc.rag.HealthCheckRequest\x1a\x1c.kyc.rag.HealthCheckResponseB(Z&github.com/adamtc007/KYC-DSL/api/pb;pbb\x06proto3"
 
 var (
@@ -1853,7 +2812,7 @@ func file_api_proto_rag_service_proto_rawDescGZIP() []byte {
 	return file_api_proto_rag_service_proto_rawDescData
 }
 
-var file_api_proto_rag_service_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_api_proto_rag_service_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
 var file_api_proto_rag_service_proto_goTypes = []any{
 	(*RagSearchRequest)(nil),            // 0: kyc.rag.RagSearchRequest
 	(*RagSearchResponse)(nil),           // 1: kyc.rag.RagSearchResponse
@@ -1864,63 +2823,89 @@ var file_api_proto_rag_service_proto_goTypes = []any{
 	(*AttributeMetadata)(nil),           // 6: kyc.rag.AttributeMetadata
 	(*RagFeedbackRequest)(nil),          // 7: kyc.rag.RagFeedbackRequest
 	(*RagFeedbackResponse)(nil),         // 8: kyc.rag.RagFeedbackResponse
-	(*GetRecentFeedbackRequest)(nil),    // 9: kyc.rag.GetRecentFeedbackRequest
-	(*RagFeedback)(nil),                 // 10: kyc.rag.RagFeedback
-	(*GetFeedbackAnalyticsRequest)(nil), // 11: kyc.rag.GetFeedbackAnalyticsRequest
-	(*FeedbackAnalytics)(nil),           // 12: kyc.rag.FeedbackAnalytics
-	(*AttributeFeedbackSummary)(nil),    // 13: kyc.rag.AttributeFeedbackSummary
-	(*GetMetadataStatsRequest)(nil),     // 14: kyc.rag.GetMetadataStatsRequest
-	(*MetadataStats)(nil),               // 15: kyc.rag.MetadataStats
-	(*RiskDistribution)(nil),            // 16: kyc.rag.RiskDistribution
-	(*EnrichedSearchResponse)(nil),      // 17: kyc.rag.EnrichedSearchResponse
-	(*EnrichedResult)(nil),              // 18: kyc.rag.EnrichedResult
-	(*DocumentInfo)(nil),                // 19: kyc.rag.DocumentInfo
-	(*RegulationInfo)(nil),              // 20: kyc.rag.RegulationInfo
-	(*HealthCheckRequest)(nil),          // 21: kyc.rag.HealthCheckRequest
-	(*HealthCheckResponse)(nil),         // 22: kyc.rag.HealthCheckResponse
-	nil,                                 // 23: kyc.rag.FeedbackAnalytics.ByAgentTypeEntry
-	(*timestamppb.Timestamp)(nil),       // 24: google.protobuf.Timestamp
+	(*FeedbackOutcome)(nil),             // 9: kyc.rag.FeedbackOutcome
+	(*GetRecentFeedbackRequest)(nil),    // 10: kyc.rag.GetRecentFeedbackRequest
+	(*RagFeedback)(nil),                 // 11: kyc.rag.RagFeedback
+	(*GetFeedbackAnalyticsRequest)(nil), // 12: kyc.rag.GetFeedbackAnalyticsRequest
+	(*FeedbackAnalytics)(nil),           // 13: kyc.rag.FeedbackAnalytics
+	(*AttributeFeedbackSummary)(nil),    // 14: kyc.rag.AttributeFeedbackSummary
+	(*GetMetadataStatsRequest)(nil),     // 15: kyc.rag.GetMetadataStatsRequest
+	(*MetadataStats)(nil),               // 16: kyc.rag.MetadataStats
+	(*RiskDistribution)(nil),            // 17: kyc.rag.RiskDistribution
+	(*EnrichedSearchResponse)(nil),      // 18: kyc.rag.EnrichedSearchResponse
+	(*EnrichedResult)(nil),              // 19: kyc.rag.EnrichedResult
+	(*DocumentInfo)(nil),                // 20: kyc.rag.DocumentInfo
+	(*RegulationInfo)(nil),              // 21: kyc.rag.RegulationInfo
+	(*SectionSearchRequest)(nil),        // 22: kyc.rag.SectionSearchRequest
+	(*SectionSearchResponse)(nil),       // 23: kyc.rag.SectionSearchResponse
+	(*SectionResult)(nil),               // 24: kyc.rag.SectionResult
+	(*ClusterRecommendRequest)(nil),     // 25: kyc.rag.ClusterRecommendRequest
+	(*ClusterRecommendResponse)(nil),    // 26: kyc.rag.ClusterRecommendResponse
+	(*ClusterRecommendation)(nil),       // 27: kyc.rag.ClusterRecommendation
+	(*GetAuditStatsRequest)(nil),        // 28: kyc.rag.GetAuditStatsRequest
+	(*AuditStats)(nil),                  // 29: kyc.rag.AuditStats
+	(*PopularQuery)(nil),                // 30: kyc.rag.PopularQuery
+	(*AgentPerformance)(nil),            // 31: kyc.rag.AgentPerformance
+	(*HealthCheckRequest)(nil),          // 32: kyc.rag.HealthCheckRequest
+	(*HealthCheckResponse)(nil),         // 33: kyc.rag.HealthCheckResponse
+	nil,                                 // 34: kyc.rag.FeedbackAnalytics.ByAgentTypeEntry
+	(*timestamppb.Timestamp)(nil),       // 35: google.protobuf.Timestamp
 }
 var file_api_proto_rag_service_proto_depIdxs = []int32{
 	2,  // 0: kyc.rag.RagSearchResponse.results:type_name -> kyc.rag.RagResult
-	24, // 1: kyc.rag.AttributeMetadata.created_at:type_name -> google.protobuf.Timestamp
-	24, // 2: kyc.rag.AttributeMetadata.updated_at:type_name -> google.protobuf.Timestamp
-	24, // 3: kyc.rag.RagFeedbackResponse.created_at:type_name -> google.protobuf.Timestamp
-	24, // 4: kyc.rag.RagFeedback.created_at:type_name -> google.protobuf.Timestamp
-	23, // 5: kyc.rag.FeedbackAnalytics.by_agent_type:type_name -> kyc.rag.FeedbackAnalytics.ByAgentTypeEntry
-	13, // 6: kyc.rag.FeedbackAnalytics.top_attributes:type_name -> kyc.rag.AttributeFeedbackSummary
-	10, // 7: kyc.rag.FeedbackAnalytics.recent_feedback:type_name -> kyc.rag.RagFeedback
-	16, // 8: kyc.rag.MetadataStats.risk_distribution:type_name -> kyc.rag.RiskDistribution
-	18, // 9: kyc.rag.EnrichedSearchResponse.results:type_name -> kyc.rag.EnrichedResult
-	2,  // 10: kyc.rag.EnrichedResult.attribute:type_name -> kyc.rag.RagResult
-	19, // 11: kyc.rag.EnrichedResult.documents:type_name -> kyc.rag.DocumentInfo
-	20, // 12: kyc.rag.EnrichedResult.regulations:type_name -> kyc.rag.RegulationInfo
-	24, // 13: kyc.rag.HealthCheckResponse.timestamp:type_name -> google.protobuf.Timestamp
-	0,  // 14: kyc.rag.RagService.AttributeSearch:input_type -> kyc.rag.RagSearchRequest
-	3,  // 15: kyc.rag.RagService.SimilarAttributes:input_type -> kyc.rag.SimilarAttributesRequest
-	4,  // 16: kyc.rag.RagService.TextSearch:input_type -> kyc.rag.TextSearchRequest
-	5,  // 17: kyc.rag.RagService.GetAttribute:input_type -> kyc.rag.RagGetAttributeRequest
-	7,  // 18: kyc.rag.RagService.SubmitFeedback:input_type -> kyc.rag.RagFeedbackRequest
-	9,  // 19: kyc.rag.RagService.GetRecentFeedback:input_type -> kyc.rag.GetRecentFeedbackRequest
-	11, // 20: kyc.rag.RagService.GetFeedbackAnalytics:input_type -> kyc.rag.GetFeedbackAnalyticsRequest
-	14, // 21: kyc.rag.RagService.GetMetadataStats:input_type -> kyc.rag.GetMetadataStatsRequest
-	0,  // 22: kyc.rag.RagService.EnrichedAttributeSearch:input_type -> kyc.rag.RagSearchRequest
-	21, // 23: kyc.rag.RagService.HealthCheck:input_type -> kyc.rag.HealthCheckRequest
-	1,  // 24: kyc.rag.RagService.AttributeSearch:output_type -> kyc.rag.RagSearchResponse
-	1,  // 25: kyc.rag.RagService.SimilarAttributes:output_type -> kyc.rag.RagSearchResponse
-	1,  // 26: kyc.rag.RagService.TextSearch:output_type -> kyc.rag.RagSearchResponse
-	6,  // 27: kyc.rag.RagService.GetAttribute:output_type -> kyc.rag.AttributeMetadata
-	8,  // 28: kyc.rag.RagService.SubmitFeedback:output_type -> kyc.rag.RagFeedbackResponse
-	10, // 29: kyc.rag.RagService.GetRecentFeedback:output_type -> kyc.rag.RagFeedback
-	12, // 30: kyc.rag.RagService.GetFeedbackAnalytics:output_type -> kyc.rag.FeedbackAnalytics
-	15, // 31: kyc.rag.RagService.GetMetadataStats:output_type -> kyc.rag.MetadataStats
-	17, // 32: kyc.rag.RagService.EnrichedAttributeSearch:output_type -> kyc.rag.EnrichedSearchResponse
-	22, // 33: kyc.rag.RagService.HealthCheck:output_type -> kyc.rag.HealthCheckResponse
-	24, // [24:34] is the sub-list for method output_type
-	14, // [14:24] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	35, // 1: kyc.rag.AttributeMetadata.created_at:type_name -> google.protobuf.Timestamp
+	35, // 2: kyc.rag.AttributeMetadata.updated_at:type_name -> google.protobuf.Timestamp
+	35, // 3: kyc.rag.RagFeedbackResponse.created_at:type_name -> google.protobuf.Timestamp
+	9,  // 4: kyc.rag.RagFeedbackResponse.outcome:type_name -> kyc.rag.FeedbackOutcome
+	35, // 5: kyc.rag.RagFeedback.created_at:type_name -> google.protobuf.Timestamp
+	34, // 6: kyc.rag.FeedbackAnalytics.by_agent_type:type_name -> kyc.rag.FeedbackAnalytics.ByAgentTypeEntry
+	14, // 7: kyc.rag.FeedbackAnalytics.top_attributes:type_name -> kyc.rag.AttributeFeedbackSummary
+	11, // 8: kyc.rag.FeedbackAnalytics.recent_feedback:type_name -> kyc.rag.RagFeedback
+	17, // 9: kyc.rag.MetadataStats.risk_distribution:type_name -> kyc.rag.RiskDistribution
+	19, // 10: kyc.rag.EnrichedSearchResponse.results:type_name -> kyc.rag.EnrichedResult
+	2,  // 11: kyc.rag.EnrichedResult.attribute:type_name -> kyc.rag.RagResult
+	20, // 12: kyc.rag.EnrichedResult.documents:type_name -> kyc.rag.DocumentInfo
+	21, // 13: kyc.rag.EnrichedResult.regulations:type_name -> kyc.rag.RegulationInfo
+	24, // 14: kyc.rag.SectionSearchResponse.results:type_name -> kyc.rag.SectionResult
+	27, // 15: kyc.rag.ClusterRecommendResponse.clusters:type_name -> kyc.rag.ClusterRecommendation
+	2,  // 16: kyc.rag.ClusterRecommendation.attributes:type_name -> kyc.rag.RagResult
+	30, // 17: kyc.rag.AuditStats.popular_queries:type_name -> kyc.rag.PopularQuery
+	31, // 18: kyc.rag.AuditStats.agents:type_name -> kyc.rag.AgentPerformance
+	35, // 19: kyc.rag.PopularQuery.last_queried:type_name -> google.protobuf.Timestamp
+	35, // 20: kyc.rag.HealthCheckResponse.timestamp:type_name -> google.protobuf.Timestamp
+	0,  // 21: kyc.rag.RagService.AttributeSearch:input_type -> kyc.rag.RagSearchRequest
+	0,  // 22: kyc.rag.RagService.SearchAttributesStream:input_type -> kyc.rag.RagSearchRequest
+	3,  // 23: kyc.rag.RagService.SimilarAttributes:input_type -> kyc.rag.SimilarAttributesRequest
+	4,  // 24: kyc.rag.RagService.TextSearch:input_type -> kyc.rag.TextSearchRequest
+	5,  // 25: kyc.rag.RagService.GetAttribute:input_type -> kyc.rag.RagGetAttributeRequest
+	7,  // 26: kyc.rag.RagService.SubmitFeedback:input_type -> kyc.rag.RagFeedbackRequest
+	10, // 27: kyc.rag.RagService.GetRecentFeedback:input_type -> kyc.rag.GetRecentFeedbackRequest
+	12, // 28: kyc.rag.RagService.GetFeedbackAnalytics:input_type -> kyc.rag.GetFeedbackAnalyticsRequest
+	15, // 29: kyc.rag.RagService.GetMetadataStats:input_type -> kyc.rag.GetMetadataStatsRequest
+	0,  // 30: kyc.rag.RagService.EnrichedAttributeSearch:input_type -> kyc.rag.RagSearchRequest
+	22, // 31: kyc.rag.RagService.SectionSearch:input_type -> kyc.rag.SectionSearchRequest
+	25, // 32: kyc.rag.RagService.ClusterRecommend:input_type -> kyc.rag.ClusterRecommendRequest
+	28, // 33: kyc.rag.RagService.GetAuditStats:input_type -> kyc.rag.GetAuditStatsRequest
+	32, // 34: kyc.rag.RagService.HealthCheck:input_type -> kyc.rag.HealthCheckRequest
+	1,  // 35: kyc.rag.RagService.AttributeSearch:output_type -> kyc.rag.RagSearchResponse
+	2,  // 36: kyc.rag.RagService.SearchAttributesStream:output_type -> kyc.rag.RagResult
+	1,  // 37: kyc.rag.RagService.SimilarAttributes:output_type -> kyc.rag.RagSearchResponse
+	1,  // 38: kyc.rag.RagService.TextSearch:output_type -> kyc.rag.RagSearchResponse
+	6,  // 39: kyc.rag.RagService.GetAttribute:output_type -> kyc.rag.AttributeMetadata
+	8,  // 40: kyc.rag.RagService.SubmitFeedback:output_type -> kyc.rag.RagFeedbackResponse
+	11, // 41: kyc.rag.RagService.GetRecentFeedback:output_type -> kyc.rag.RagFeedback
+	13, // 42: kyc.rag.RagService.GetFeedbackAnalytics:output_type -> kyc.rag.FeedbackAnalytics
+	16, // 43: kyc.rag.RagService.GetMetadataStats:output_type -> kyc.rag.MetadataStats
+	18, // 44: kyc.rag.RagService.EnrichedAttributeSearch:output_type -> kyc.rag.EnrichedSearchResponse
+	23, // 45: kyc.rag.RagService.SectionSearch:output_type -> kyc.rag.SectionSearchResponse
+	26, // 46: kyc.rag.RagService.ClusterRecommend:output_type -> kyc.rag.ClusterRecommendResponse
+	29, // 47: kyc.rag.RagService.GetAuditStats:output_type -> kyc.rag.AuditStats
+	33, // 48: kyc.rag.RagService.HealthCheck:output_type -> kyc.rag.HealthCheckResponse
+	35, // [35:49] is the sub-list for method output_type
+	21, // [21:35] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_rag_service_proto_init() }
@@ -1934,7 +2919,7 @@ func file_api_proto_rag_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_rag_service_proto_rawDesc), len(file_api_proto_rag_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   24,
+			NumMessages:   35,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -20,6 +20,7 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	RagService_AttributeSearch_FullMethodName         = "/kyc.rag.RagService/AttributeSearch"
+	RagService_SearchAttributesStream_FullMethodName  = "/kyc.rag.RagService/SearchAttributesStream"
 	RagService_SimilarAttributes_FullMethodName       = "/kyc.rag.RagService/SimilarAttributes"
 	RagService_TextSearch_FullMethodName              = "/kyc.rag.RagService/TextSearch"
 	RagService_GetAttribute_FullMethodName            = "/kyc.rag.RagService/GetAttribute"
@@ -28,6 +29,9 @@ const (
 	RagService_GetFeedbackAnalytics_FullMethodName    = "/kyc.rag.RagService/GetFeedbackAnalytics"
 	RagService_GetMetadataStats_FullMethodName        = "/kyc.rag.RagService/GetMetadataStats"
 	RagService_EnrichedAttributeSearch_FullMethodName = "/kyc.rag.RagService/EnrichedAttributeSearch"
+	RagService_SectionSearch_FullMethodName           = "/kyc.rag.RagService/SectionSearch"
+	RagService_ClusterRecommend_FullMethodName        = "/kyc.rag.RagService/ClusterRecommend"
+	RagService_GetAuditStats_FullMethodName           = "/kyc.rag.RagService/GetAuditStats"
 	RagService_HealthCheck_FullMethodName             = "/kyc.rag.RagService/HealthCheck"
 )
 
@@ -40,6 +44,9 @@ const (
 type RagServiceClient interface {
 	// AttributeSearch performs semantic vector search on attributes
 	AttributeSearch(ctx context.Context, in *RagSearchRequest, opts ...grpc.CallOption) (*RagSearchResponse, error)
+	// SearchAttributesStream performs the same search, streaming each result
+	// as it is read, closest first, so large result sets render incrementally
+	SearchAttributesStream(ctx context.Context, in *RagSearchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RagResult], error)
 	// SimilarAttributes finds attributes similar to a given attribute
 	SimilarAttributes(ctx context.Context, in *SimilarAttributesRequest, opts ...grpc.CallOption) (*RagSearchResponse, error)
 	// TextSearch performs traditional text-based search
@@ -56,6 +63,13 @@ type RagServiceClient interface {
 	GetMetadataStats(ctx context.Context, in *GetMetadataStatsRequest, opts ...grpc.CallOption) (*MetadataStats, error)
 	// EnrichedAttributeSearch performs search with full context (docs + regulations)
 	EnrichedAttributeSearch(ctx context.Context, in *RagSearchRequest, opts ...grpc.CallOption) (*EnrichedSearchResponse, error)
+	// SectionSearch performs semantic search over document sections with their
+	// document and regulation context
+	SectionSearch(ctx context.Context, in *SectionSearchRequest, opts ...grpc.CallOption) (*SectionSearchResponse, error)
+	// ClusterRecommend returns the attribute clusters closest to a query
+	ClusterRecommend(ctx context.Context, in *ClusterRecommendRequest, opts ...grpc.CallOption) (*ClusterRecommendResponse, error)
+	// GetAuditStats retrieves RAG query audit statistics
+	GetAuditStats(ctx context.Context, in *GetAuditStatsRequest, opts ...grpc.CallOption) (*AuditStats, error)
 	// HealthCheck verifies RAG system health
 	HealthCheck(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error)
 }
@@ -78,6 +92,25 @@ func (c *ragServiceClient) AttributeSearch(ctx context.Context, in *RagSearchReq
 	return out, nil
 }
 
+func (c *ragServiceClient) SearchAttributesStream(ctx context.Context, in *RagSearchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RagResult], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RagService_ServiceDesc.Streams[0], RagService_SearchAttributesStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[RagSearchRequest, RagResult]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RagService_SearchAttributesStreamClient = grpc.ServerStreamingClient[RagResult]
+
 func (c *ragServiceClient) SimilarAttributes(ctx context.Context, in *SimilarAttributesRequest, opts ...grpc.CallOption) (*RagSearchResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(RagSearchResponse)
@@ -120,7 +153,7 @@ func (c *ragServiceClient) SubmitFeedback(ctx context.Context, in *RagFeedbackRe
 
 func (c *ragServiceClient) GetRecentFeedback(ctx context.Context, in *GetRecentFeedbackRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RagFeedback], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &RagService_ServiceDesc.Streams[0], RagService_GetRecentFeedback_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &RagService_ServiceDesc.Streams[1], RagService_GetRecentFeedback_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -167,6 +200,36 @@ func (c *ragServiceClient) EnrichedAttributeSearch(ctx context.Context, in *RagS
 	return out, nil
 }
 
+func (c *ragServiceClient) SectionSearch(ctx context.Context, in *SectionSearchRequest, opts ...grpc.CallOption) (*SectionSearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SectionSearchResponse)
+	err := c.cc.Invoke(ctx, RagService_SectionSearch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ragServiceClient) ClusterRecommend(ctx context.Context, in *ClusterRecommendRequest, opts ...grpc.CallOption) (*ClusterRecommendResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClusterRecommendResponse)
+	err := c.cc.Invoke(ctx, RagService_ClusterRecommend_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ragServiceClient) GetAuditStats(ctx context.Context, in *GetAuditStatsRequest, opts ...grpc.CallOption) (*AuditStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuditStats)
+	err := c.cc.Invoke(ctx, RagService_GetAuditStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *ragServiceClient) HealthCheck(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(HealthCheckResponse)
@@ -186,6 +249,9 @@ func (c *ragServiceClient) HealthCheck(ctx context.Context, in *HealthCheckReque
 type RagServiceServer interface {
 	// AttributeSearch performs semantic vector search on attributes
 	AttributeSearch(context.Context, *RagSearchRequest) (*RagSearchResponse, error)
+	// SearchAttributesStream performs the same search, streaming each result
+	// as it is read, closest first, so large result sets render incrementally
+	SearchAttributesStream(*RagSearchRequest, grpc.ServerStreamingServer[RagResult]) error
 	// SimilarAttributes finds attributes similar to a given attribute
 	SimilarAttributes(context.Context, *SimilarAttributesRequest) (*RagSearchResponse, error)
 	// TextSearch performs traditional text-based search
@@ -202,6 +268,13 @@ type RagServiceServer interface {
 	GetMetadataStats(context.Context, *GetMetadataStatsRequest) (*MetadataStats, error)
 	// EnrichedAttributeSearch performs search with full context (docs + regulations)
 	EnrichedAttributeSearch(context.Context, *RagSearchRequest) (*EnrichedSearchResponse, error)
+	// SectionSearch performs semantic search over document sections with their
+	// document and regulation context
+	SectionSearch(context.Context, *SectionSearchRequest) (*SectionSearchResponse, error)
+	// ClusterRecommend returns the attribute clusters closest to a query
+	ClusterRecommend(context.Context, *ClusterRecommendRequest) (*ClusterRecommendResponse, error)
+	// GetAuditStats retrieves RAG query audit statistics
+	GetAuditStats(context.Context, *GetAuditStatsRequest) (*AuditStats, error)
 	// HealthCheck verifies RAG system health
 	HealthCheck(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error)
 	mustEmbedUnimplementedRagServiceServer()
@@ -217,6 +290,9 @@ type UnimplementedRagServiceServer struct{}
 func (UnimplementedRagServiceServer) AttributeSearch(context.Context, *RagSearchRequest) (*RagSearchResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AttributeSearch not implemented")
 }
+func (UnimplementedRagServiceServer) SearchAttributesStream(*RagSearchRequest, grpc.ServerStreamingServer[RagResult]) error {
+	return status.Errorf(codes.Unimplemented, "method SearchAttributesStream not implemented")
+}
 func (UnimplementedRagServiceServer) SimilarAttributes(context.Context, *SimilarAttributesRequest) (*RagSearchResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SimilarAttributes not implemented")
 }
@@ -241,6 +317,15 @@ func (UnimplementedRagServiceServer) GetMetadataStats(context.Context, *GetMetad
 func (UnimplementedRagServiceServer) EnrichedAttributeSearch(context.Context, *RagSearchRequest) (*EnrichedSearchResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method EnrichedAttributeSearch not implemented")
 }
+func (UnimplementedRagServiceServer) SectionSearch(context.Context, *SectionSearchRequest) (*SectionSearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SectionSearch not implemented")
+}
+func (UnimplementedRagServiceServer) ClusterRecommend(context.Context, *ClusterRecommendRequest) (*ClusterRecommendResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClusterRecommend not implemented")
+}
+func (UnimplementedRagServiceServer) GetAuditStats(context.Context, *GetAuditStatsRequest) (*AuditStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAuditStats not implemented")
+}
 func (UnimplementedRagServiceServer) HealthCheck(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method HealthCheck not implemented")
 }
@@ -283,6 +368,17 @@ func _RagService_AttributeSearch_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RagService_SearchAttributesStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RagSearchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RagServiceServer).SearchAttributesStream(m, &grpc.GenericServerStream[RagSearchRequest, RagResult]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RagService_SearchAttributesStreamServer = grpc.ServerStreamingServer[RagResult]
+
 func _RagService_SimilarAttributes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SimilarAttributesRequest)
 	if err := dec(in); err != nil {
@@ -420,6 +516,60 @@ func _RagService_EnrichedAttributeSearch_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RagService_SectionSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SectionSearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RagServiceServer).SectionSearch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RagService_SectionSearch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RagServiceServer).SectionSearch(ctx, req.(*SectionSearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RagService_ClusterRecommend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClusterRecommendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RagServiceServer).ClusterRecommend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RagService_ClusterRecommend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RagServiceServer).ClusterRecommend(ctx, req.(*ClusterRecommendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RagService_GetAuditStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAuditStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RagServiceServer).GetAuditStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RagService_GetAuditStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RagServiceServer).GetAuditStats(ctx, req.(*GetAuditStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RagService_HealthCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(HealthCheckRequest)
 	if err := dec(in); err != nil {
@@ -477,12 +627,29 @@ var RagService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "EnrichedAttributeSearch",
 			Handler:    _RagService_EnrichedAttributeSearch_Handler,
 		},
+		{
+			MethodName: "SectionSearch",
+			Handler:    _RagService_SectionSearch_Handler,
+		},
+		{
+			MethodName: "ClusterRecommend",
+			Handler:    _RagService_ClusterRecommend_Handler,
+		},
+		{
+			MethodName: "GetAuditStats",
+			Handler:    _RagService_GetAuditStats_Handler,
+		},
 		{
 			MethodName: "HealthCheck",
 			Handler:    _RagService_HealthCheck_Handler,
 		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SearchAttributesStream",
+			Handler:       _RagService_SearchAttributesStream_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "GetRecentFeedback",
 			Handler:       _RagService_GetRecentFeedback_Handler,
@@ -1,42 +1,197 @@
 package main
 
 import (
+	"context"
 	"log"
-	"net"
+	"log/slog"
 	"os"
-	"os/signal"
-	"syscall"
+	"time"
 
+	pbCbu "github.com/adamtc007/KYC-DSL/api/pb"
 	pb "github.com/adamtc007/KYC-DSL/api/pb/kycdata"
 	pbOntology "github.com/adamtc007/KYC-DSL/api/pb/kycontology"
+	"github.com/adamtc007/KYC-DSL/internal/actor"
+	"github.com/adamtc007/KYC-DSL/internal/auth"
+	"github.com/adamtc007/KYC-DSL/internal/config"
 	"github.com/adamtc007/KYC-DSL/internal/dataservice"
+	"github.com/adamtc007/KYC-DSL/internal/drain"
+	"github.com/adamtc007/KYC-DSL/internal/evidence"
+	"github.com/adamtc007/KYC-DSL/internal/grpcserver"
+	kychealth "github.com/adamtc007/KYC-DSL/internal/health"
+	"github.com/adamtc007/KYC-DSL/internal/logging"
+	"github.com/adamtc007/KYC-DSL/internal/metrics"
+	"github.com/adamtc007/KYC-DSL/internal/ontology"
+	"github.com/adamtc007/KYC-DSL/internal/rag"
+	"github.com/adamtc007/KYC-DSL/internal/ragservice"
+	"github.com/adamtc007/KYC-DSL/internal/reeval"
+	"github.com/adamtc007/KYC-DSL/internal/region"
+	"github.com/adamtc007/KYC-DSL/internal/screening"
+	"github.com/adamtc007/KYC-DSL/internal/tracing"
+	"github.com/adamtc007/KYC-DSL/internal/triggers"
+	"github.com/adamtc007/KYC-DSL/internal/watchlist"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
 func main() {
-	log.Println("🚀 Starting KYC Data Service...")
-	log.Println()
+	// Load configuration (defaults, config file, environment, flags)
+	cfg, _, err := config.Load("dataserver", os.Args[1:])
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+
+	// Structured logging; the standard log package is routed through slog
+	logging.Init("dataserver", cfg.Log)
+	slog.Info("🚀 Starting KYC Data Service...")
+	if cfg.File != "" {
+		slog.Info("⚙️  Configuration loaded", "file", cfg.File)
+	}
+
+	// Client IPs come from forwarded headers only behind a trusted proxy
+	if err := actor.TrustProxies(cfg.Server.TrustedProxies); err != nil {
+		fatal("❌ Invalid server configuration", err)
+	}
+
+	// Initialize OpenTelemetry tracing (exports only when an OTLP endpoint is set)
+	shutdownTracing, err := tracing.Init(context.Background(), "kyc-dataserver")
+	if err != nil {
+		fatal("❌ Failed to initialize tracing", err)
+	}
+	if tracing.Enabled() {
+		slog.Info("🔭 OpenTelemetry tracing enabled")
+	}
 
 	// Initialize database connection pool
-	log.Println("📊 Initializing database connection pool...")
+	slog.Info("📊 Initializing database connection pool...")
 	if err := dataservice.InitDB(); err != nil {
-		log.Fatalf("❌ Failed to initialize database: %v", err)
+		fatal("❌ Failed to initialize database", err)
+	}
+	metrics.RegisterPgxPoolStats("dataserver", dataservice.DB)
+
+	// Load multi-region topology
+	topology, err := region.FromConfig(cfg.Region)
+	if err != nil {
+		fatal("❌ Invalid region configuration", err)
+	}
+	slog.Info("🌍 Region topology loaded", "region", topology.Self, "primary", topology.Primary)
+
+	// Initialize authentication (JWT/OIDC bearer tokens and API keys)
+	authCfg, err := auth.ConfigFrom(cfg.Auth)
+	if err != nil {
+		fatal("❌ Invalid auth configuration", err)
+	}
+	authCtx, cancelAuth := context.WithCancel(context.Background())
+	defer cancelAuth()
+	authn, err := auth.NewAuthenticator(authCtx, authCfg)
+	if err != nil {
+		fatal("❌ Failed to initialize authentication", err)
+	}
+	if authn.Enabled() {
+		slog.Info("🔐 Authentication enabled (credentials required on every call)")
+	} else {
+		slog.Warn("⚠️  Authentication disabled: set AUTH_JWKS_URL or AUTH_API_KEYS to enable")
 	}
-	defer dataservice.CloseDB()
 
-	// Create gRPC server
-	grpcServer := grpc.NewServer()
+	// Create gRPC server with the shared interceptor chain
+	grpcServer := grpcserver.New("dataserver", cfg.GRPC, authn)
 
 	// Create and register Data Service (implements both Dictionary and Case services)
 	dataService := dataservice.NewDataService()
 	pb.RegisterDictionaryServiceServer(grpcServer, dataService)
 	pb.RegisterCaseServiceServer(grpcServer, dataService)
 
+	// Register KycCaseService (case assignment and the analyst work queue)
+	kycCaseService := dataservice.NewKycCaseService()
+	pbCbu.RegisterKycCaseServiceServer(grpcServer, kycCaseService)
+
 	// Create and register Ontology Service (entities, CBUs, attributes, control graph)
 	ontologyService := dataservice.NewOntologyService()
 	pbOntology.RegisterOntologyServiceServer(grpcServer, ontologyService)
 
+	// Register CBU Graph Service (entities, roles and control edges per CBU)
+	cbuGraphService := dataservice.NewCbuGraphService()
+	pbCbu.RegisterCbuGraphServiceServer(grpcServer, cbuGraphService)
+
+	// Read replicas forward case, screening and CBU graph writes to the
+	// primary region
+	if !topology.IsPrimary() && topology.PrimaryAddress() != "" {
+		primaryConn, err := grpc.NewClient(topology.PrimaryAddress(),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+			grpc.WithChainUnaryInterceptor(logging.UnaryClientInterceptor(), actor.UnaryClientInterceptor()),
+			grpc.WithChainStreamInterceptor(actor.StreamClientInterceptor()),
+			grpc.WithPerRPCCredentials(auth.APIKeyCredentials(cfg.DataService.APIKey)))
+		if err != nil {
+			fatal("❌ Failed to configure primary region client", err)
+		}
+		defer primaryConn.Close()
+		dataService.ForwardWritesTo(pb.NewCaseServiceClient(primaryConn))
+		kycCaseService.ForwardWritesTo(pbCbu.NewKycCaseServiceClient(primaryConn))
+		ontologyService.ForwardWritesTo(pbOntology.NewOntologyServiceClient(primaryConn))
+		cbuGraphService.ForwardWritesTo(pbCbu.NewCbuGraphServiceClient(primaryConn))
+		slog.Info("↪️  Forwarding writes to primary region", "primary", topology.Primary, "addr", topology.PrimaryAddress())
+	}
+
+	// Register Region Service (nearest read endpoint discovery)
+	pb.RegisterRegionServiceServer(grpcServer, dataservice.NewRegionService(topology))
+
+	// Register RAG Service (semantic search, sections, clusters, feedback, audit);
+	// queries are embedded with the model of the primary embedding space
+	ragEnabled := cfg.OpenAI.APIKey != ""
+	var embedder *rag.Embedder
+	if ragEnabled {
+		embedder = rag.NewEmbedderWithConfig(rag.EmbedderConfig{APIKey: cfg.OpenAI.APIKey})
+		if primary, err := ontology.NewSpaceRepo(dataservice.DBX).Primary(context.Background()); err != nil {
+			slog.Warn("⚠️  Primary embedding space unavailable", "error", err)
+		} else if primary.Model != string(embedder.GetModel()) || primary.Dimensions != embedder.GetDimensions() {
+			embedder = embedder.ForSpace(primary.Model, primary.Dimensions)
+		}
+		pbCbu.RegisterRagServiceServer(grpcServer, ragservice.NewServer(dataservice.DBX, embedder))
+		slog.Info("🧠 RAG service enabled", "model", embedder.GetModel(), "dimensions", embedder.GetDimensions())
+	} else {
+		slog.Warn("⚠️  OPENAI_API_KEY not set; kyc.rag.RagService disabled")
+	}
+
+	// Background jobs run in the primary region only (they write)
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	defer stopScheduler()
+
+	// Re-screen watched entities and raise alerts on new hits
+	if cfg.Watchlist.Enabled && topology.IsPrimary() {
+		scheduler := watchlist.NewScheduler(dataservice.DBX, cfg.Watchlist)
+		for _, p := range screening.ExternalProviders(cfg.Screening) {
+			scheduler.AddProvider(p)
+		}
+		go scheduler.Run(schedulerCtx)
+		slog.Info("👁️  Watchlist scheduler started", "interval", cfg.Watchlist.Interval,
+			"default_cadence", cfg.Watchlist.DefaultCadence)
+	}
+
+	// Open review amendments on cases affected by material entity changes
+	if cfg.MaterialChange.Enabled && topology.IsPrimary() {
+		go triggers.NewMonitor(dataservice.DBX, cfg.MaterialChange).Run(schedulerCtx)
+		slog.Info("🔔 Material change detectors started", "interval", cfg.MaterialChange.Interval,
+			"ownership_threshold", cfg.MaterialChange.OwnershipThreshold)
+	}
+
+	// Re-evaluate derived attributes queued when their inputs, rules or lists change
+	if cfg.Reevaluation.Enabled && topology.IsPrimary() {
+		go reeval.NewWorker(dataservice.DBX, cfg.Reevaluation).Run(schedulerCtx)
+		slog.Info("🔁 Re-evaluation worker started", "interval", cfg.Reevaluation.Interval,
+			"batch_size", cfg.Reevaluation.BatchSize)
+	}
+
+	// Flag expiring document evidence and send the daily expiry summary
+	if cfg.Evidence.Expiry.Enabled && topology.IsPrimary() {
+		go evidence.NewChecker(dataservice.DBX, cfg.Evidence.Expiry).Run(schedulerCtx)
+		slog.Info("📅 Evidence expiry checker started", "interval", cfg.Evidence.Expiry.Interval,
+			"warning_days", cfg.Evidence.Expiry.WarningDays)
+	}
+
 	// TODO: Dictionary and DocMaster services temporarily disabled for debugging
 	// They are causing the gRPC server to hang/block on initialization
 	//
@@ -51,10 +206,36 @@ func main() {
 	// Enable gRPC reflection for grpcurl/grpcui
 	reflection.Register(grpcServer)
 
-	// Listen on port 50070
-	lis, err := net.Listen("tcp", ":50070") //nolint:gosec
+	// Readiness checks the database, pgvector, the embeddings provider (when
+	// the RAG service is enabled) and the Rust DSL service (health)
+	checker := kychealth.New("dataserver", cfg.Health)
+	checker.Add(kychealth.CheckDatabase, kychealth.Database())
+	checker.Add(kychealth.CheckPGVector, kychealth.PGVector(dataservice.DBX))
+	if embedder != nil {
+		checker.Add(kychealth.CheckEmbeddings, kychealth.Embeddings(embedder))
+	}
+	checker.Add(kychealth.CheckRustDSL, kychealth.RustDSL(cfg.RustDSL.Addr))
+
+	// Standard gRPC health checks follow readiness; NOT_SERVING once
+	// draining begins so load balancers stop routing new RPCs here
+	drainer := drain.New(cfg.Drain)
+	checker.Draining = drainer.Draining
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	drainer.OnDraining(healthServer.Shutdown)
+	go checker.Watch(schedulerCtx, 10*time.Second, func(ready bool) {
+		status := healthpb.HealthCheckResponse_SERVING
+		if !ready {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		healthServer.SetServingStatus("", status)
+	})
+
+	// Listen on the configured gRPC address (default :50070)
+	listenAddr := cfg.DataService.ListenAddr
+	lis, err := drainer.Listen(context.Background(), listenAddr)
 	if err != nil {
-		log.Fatalf("❌ Failed to listen on :50070: %v", err)
+		fatal("❌ Failed to listen on "+listenAddr, err)
 	}
 
 	log.Println("✅ Data Service initialized successfully")
@@ -62,17 +243,28 @@ func main() {
 	log.Println("📋 Available services:")
 	log.Println("   • kyc.data.DictionaryService - Ontology data (attributes, documents)")
 	log.Println("   • kyc.data.CaseService - Case version management")
+	log.Println("   • kyc.KycCaseService - Case assignment, analyst work queue and version integrity")
+	log.Println("   • kyc.data.RegionService - Nearest read endpoint / primary write region")
 	log.Println("   • kyc.ontology.OntologyService - Full ontology API (entities, CBUs, control graph)")
+	log.Println("   • kyc.cbu.CbuGraphService - CBU graphs (entities, roles, ownership/control)")
+	if ragEnabled {
+		log.Println("   • kyc.rag.RagService - Semantic search, sections, clusters, feedback, audit stats")
+	} else {
+		log.Println("   • kyc.rag.RagService - [DISABLED - OPENAI_API_KEY not set]")
+	}
+	log.Println("   • grpc.health.v1.Health - Health checks (NOT_SERVING while draining or unready)")
 	log.Println("   • kyc.dictionary.DictionaryService - [DISABLED - debugging]")
 	log.Println("   • kyc.docmaster.DocMasterService - [DISABLED - debugging]")
 	log.Println()
-	log.Println("🌐 gRPC server listening on :50070")
+	slog.Info("🌐 gRPC server listening", "addr", listenAddr)
 	log.Println()
 	log.Println("💡 Test with grpcurl:")
 	log.Println("   grpcurl -plaintext localhost:50070 list")
 	log.Println("   grpcurl -plaintext localhost:50070 kyc.ontology.OntologyService/SearchAttributes -d '{\"query\":\"ownership\",\"limit\":10}'")
 	log.Println("   grpcurl -plaintext localhost:50070 kyc.ontology.OntologyService/ListEntities -d '{\"limit\":5}'")
 	log.Println("   grpcurl -plaintext localhost:50070 kyc.data.DictionaryService/ListAttributes -d '{\"limit\":5}'")
+	log.Println("   grpcurl -plaintext -H 'x-actor: alice' localhost:50070 kyc.KycCaseService/GetCaseQueue -d '{\"assignee\":\"alice\"}'")
+	log.Println("   grpcurl -plaintext localhost:50070 kyc.rag.RagService/SectionSearch -d '{\"query\":\"beneficial owner\",\"limit\":5}'")
 	log.Println()
 	log.Println("🔗 Consumer clients:")
 	log.Println("   • Go CLI (kycctl) - connects to this service for data operations")
@@ -80,18 +272,37 @@ func main() {
 	log.Println("   • UI/Frontend - case and dictionary data access")
 	log.Println()
 
-	// Handle graceful shutdown
+	// Serve Prometheus metrics and the health probes on a separate HTTP port
+	metricsAddr := cfg.DataService.MetricsAddr
 	go func() {
-		sigChan := make(chan os.Signal, 1)
-		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-		<-sigChan
-		log.Println()
-		log.Println("🛑 Shutting down gracefully...")
-		grpcServer.GracefulStop()
+		slog.Info("📈 Metrics available", "url", "http://localhost"+metricsAddr+"/metrics")
+		slog.Info("🩺 Health probes available", "liveness", "http://localhost"+metricsAddr+"/healthz",
+			"readiness", "http://localhost"+metricsAddr+"/readyz")
+		if err := metrics.ListenAndServe(metricsAddr, checker.Register); err != nil {
+			slog.Warn("⚠️  Metrics server stopped", "error", err)
+		}
 	}()
 
 	// Start serving
-	if err := grpcServer.Serve(lis); err != nil {
-		log.Fatalf("❌ Server failed: %v", err)
-	}
+	go func() {
+		if err := grpcServer.Serve(lis); err != nil {
+			fatal("❌ Server failed", err)
+		}
+	}()
+
+	// Rolling deploys: finish in-flight RPCs and streams (ListCases, graph
+	// streams) before stopping the schedulers and closing the database
+	drainer.OnDrain("grpc", drain.GRPC(grpcServer))
+	drainer.OnDrain("schedulers", drain.Func(stopScheduler))
+	drainer.OnDrain("tracing", shutdownTracing)
+	drainer.OnDrain("database", drain.Func(dataservice.CloseDB))
+	drainer.WaitForSignal()
+
+	slog.Info("✅ Data Service stopped gracefully")
+}
+
+// fatal logs a startup failure and exits
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
 }
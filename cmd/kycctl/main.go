@@ -1,11 +1,24 @@
 package main
 
 import (
+	"errors"
+	"flag"
+	"log"
 	"os"
 
 	"github.com/adamtc007/KYC-DSL/internal/cli"
+	"github.com/adamtc007/KYC-DSL/internal/config"
 )
 
 func main() {
-	cli.Run(os.Args[1:])
+	// Global flags (e.g. -config=kyc.yaml) come before the command
+	_, args, err := config.Load("kycctl", os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		cli.ShowUsage()
+		return
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	cli.Run(args)
 }
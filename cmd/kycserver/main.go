@@ -4,132 +4,554 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"log/slog"
 	"net/http"
 	"os"
-	"os/signal"
-	"syscall"
+	"strconv"
 	"time"
 
+	"github.com/adamtc007/KYC-DSL/internal/actor"
+	"github.com/adamtc007/KYC-DSL/internal/agents"
 	"github.com/adamtc007/KYC-DSL/internal/api"
+	"github.com/adamtc007/KYC-DSL/internal/auth"
+	"github.com/adamtc007/KYC-DSL/internal/clustering"
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	kycdb "github.com/adamtc007/KYC-DSL/internal/db"
+	"github.com/adamtc007/KYC-DSL/internal/drain"
+	"github.com/adamtc007/KYC-DSL/internal/engine"
+	"github.com/adamtc007/KYC-DSL/internal/events"
+	"github.com/adamtc007/KYC-DSL/internal/evidence"
+	"github.com/adamtc007/KYC-DSL/internal/health"
+	"github.com/adamtc007/KYC-DSL/internal/logging"
+	"github.com/adamtc007/KYC-DSL/internal/metrics"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/modellog"
+	"github.com/adamtc007/KYC-DSL/internal/ontology"
 	"github.com/adamtc007/KYC-DSL/internal/rag"
+	"github.com/adamtc007/KYC-DSL/internal/ragaudit"
+	"github.com/adamtc007/KYC-DSL/internal/ratelimit"
+	"github.com/adamtc007/KYC-DSL/internal/reembed"
+	"github.com/adamtc007/KYC-DSL/internal/respcache"
+	"github.com/adamtc007/KYC-DSL/internal/retention"
+	"github.com/adamtc007/KYC-DSL/internal/shadow"
 	"github.com/adamtc007/KYC-DSL/internal/storage"
-)
-
-const (
-	defaultPort         = "8080"
-	defaultReadTimeout  = 15 * time.Second
-	defaultWriteTimeout = 15 * time.Second
-	defaultIdleTimeout  = 60 * time.Second
+	"github.com/adamtc007/KYC-DSL/internal/tracing"
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
 func main() {
-	log.Println("🚀 Starting KYC-DSL RAG API Server...")
+	// Load configuration (defaults, config file, environment, flags)
+	cfg, _, err := config.Load("kycserver", os.Args[1:])
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
 
-	// Get configuration from environment
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = defaultPort
+	// Structured logging; the standard log package is routed through slog
+	logging.Init("kycserver", cfg.Log)
+	slog.Info("🚀 Starting KYC-DSL RAG API Server...")
+	if cfg.File != "" {
+		slog.Info("⚙️  Configuration loaded", "file", cfg.File)
 	}
+	port := strconv.Itoa(cfg.Server.Port)
 
-	// Check OpenAI API key
-	if os.Getenv("OPENAI_API_KEY") == "" {
-		log.Fatal("❌ OPENAI_API_KEY environment variable not set")
+	// Client IPs come from forwarded headers only behind a trusted proxy
+	if err := actor.TrustProxies(cfg.Server.TrustedProxies); err != nil {
+		fatal("❌ Invalid server configuration", err)
+	}
+
+	// Initialize OpenTelemetry tracing (exports only when an OTLP endpoint is set)
+	shutdownTracing, err := tracing.Init(context.Background(), "kyc-rag-api")
+	if err != nil {
+		fatal("❌ Failed to initialize tracing", err)
+	}
+	if tracing.Enabled() {
+		slog.Info("🔭 OpenTelemetry tracing enabled")
 	}
 
 	// Connect to database
-	log.Println("📊 Connecting to PostgreSQL...")
+	slog.Info("📊 Connecting to PostgreSQL...")
 	db, err := storage.ConnectPostgres()
 	if err != nil {
-		log.Fatalf("❌ Failed to connect to database: %v", err)
+		fatal("❌ Failed to connect to database", err)
 	}
-	defer db.Close()
 
-	// Test database connection
-	if err := db.Ping(); err != nil {
-		log.Fatalf("❌ Database ping failed: %v", err)
+	// Every handler shares the one pgx pool behind db
+	pool, err := kycdb.Shared(context.Background())
+	if err != nil {
+		fatal("❌ Database pool unavailable", err)
 	}
-	log.Println("✅ Database connected successfully")
+	slog.Info("✅ Database connected successfully")
+	metrics.RegisterPgxPoolStats("kycserver", pool.Pool)
 
-	// Initialize embedder
-	log.Println("🧠 Initializing OpenAI embedder...")
-	embedder := rag.NewEmbedder()
-	log.Printf("   Model: %s\n", embedder.GetModel())
-	log.Printf("   Dimensions: %d\n", embedder.GetDimensions())
+	// Initialize embedder; without an API key the server runs degraded:
+	// attribute search falls back to text search, semantic-only searches
+	// answer 503 and embedding work waits for a key
+	var embedder *rag.Embedder
+	if cfg.OpenAI.APIKey == "" {
+		slog.Warn("⚠️  OPENAI_API_KEY not set; running degraded (text search only, embedding jobs queued)")
+	} else {
+		slog.Info("🧠 Initializing OpenAI embedder...")
+		embedder = rag.NewEmbedderWithConfig(rag.EmbedderConfig{APIKey: cfg.OpenAI.APIKey})
+		// Queries must be embedded like the primary space they are compared with
+		if primary, err := ontology.NewSpaceRepo(db).Primary(context.Background()); err != nil {
+			slog.Warn("⚠️  Primary embedding space unavailable", "error", err)
+		} else if primary.Model != string(embedder.GetModel()) || primary.Dimensions != embedder.GetDimensions() {
+			slog.Warn("⚠️  OpenAI embedding config differs from the primary embedding space; using the space",
+				"space", primary.Name, "model", primary.Model, "dimensions", primary.Dimensions,
+				"configured_model", embedder.GetModel(), "configured_dimensions", embedder.GetDimensions())
+			embedder = embedder.ForSpace(primary.Model, primary.Dimensions)
+		}
+		slog.Info("🧠 Embedder ready", "model", embedder.GetModel(), "dimensions", embedder.GetDimensions())
+	}
+
+	// Repeated queries reuse their embedding instead of calling the API
+	if cfg.EmbeddingCache.Enabled && embedder != nil {
+		embedder.WithCache(rag.NewEmbeddingCache(db, cfg.EmbeddingCache))
+		slog.Info("🗃️  Query embedding cache enabled", "size", cfg.EmbeddingCache.Size,
+			"ttl", cfg.EmbeddingCache.TTL, "persist", cfg.EmbeddingCache.Persist)
+	}
 
 	// Initialize RAG handler
 	ragHandler := api.NewRagHandler(db, embedder)
 
+	// Optional shadow (canary) ranking experiment
+	if candidate := cfg.Shadow.Ranker; candidate != "" && candidate != shadow.RankerLexicalBoost {
+		slog.Warn("⚠️  Unknown SHADOW_RANKER, shadow ranking disabled", "ranker", candidate, "supported", shadow.RankerLexicalBoost)
+	} else if candidate != "" && cfg.Shadow.SampleRate == 0 {
+		slog.Warn("⚠️  SHADOW_SAMPLE_RATE is 0, shadow ranking disabled", "ranker", candidate)
+	} else if candidate != "" {
+		ragHandler.Shadow = shadow.NewRunner(shadow.Experiment{
+			Name:             "attribute_search:" + candidate,
+			Kind:             model.ShadowKindRanking,
+			PrimaryVersion:   shadow.RankerVector,
+			CandidateVersion: candidate,
+			SampleRate:       cfg.Shadow.SampleRate,
+		}, ontology.NewShadowRepo(db))
+		slog.Info("🌓 Shadow ranking enabled", "primary", shadow.RankerVector, "candidate", candidate,
+			"sample_rate", cfg.Shadow.SampleRate)
+	}
+
+	// Initialize authentication (JWT/OIDC bearer tokens and API keys)
+	authCfg, err := auth.ConfigFrom(cfg.Auth)
+	if err != nil {
+		fatal("❌ Invalid auth configuration", err)
+	}
+	authCtx, cancelAuth := context.WithCancel(context.Background())
+	defer cancelAuth()
+	authn, err := auth.NewAuthenticator(authCtx, authCfg)
+	if err != nil {
+		fatal("❌ Failed to initialize authentication", err)
+	}
+	if authn.Enabled() {
+		slog.Info("🔐 Authentication enabled (role-based access on write endpoints)")
+	} else {
+		slog.Warn("⚠️  Authentication disabled: set AUTH_JWKS_URL or AUTH_API_KEYS to enable")
+	}
+	// Authenticated requests acting as an agent are checked against the registry
+	agentVerifier := agents.NewVerifier(agents.NewRepo(db))
+	authn.WithAgents(agentVerifier)
+	ragHandler.Agents = agentVerifier
+	ragHandler.FeedbackWeight = cfg.Ranking.FeedbackWeight
+
+	// Limit how often each API key or client IP calls the RAG endpoints
+	limiter := ratelimit.New(cfg.RateLimit)
+	limiter.APIKeys = authn.APIKeySubject
+	ragHandler.Limits = limiter
+	if limiter.Enabled() {
+		slog.Info("🚦 Rate limiting enabled", "requests_per_minute", cfg.RateLimit.RequestsPerMinute,
+			"burst", cfg.RateLimit.Burst, "daily_quota", cfg.RateLimit.DailyQuota, "paths", cfg.RateLimit.Paths)
+	}
+
+	// Case lifecycle; hooks run inside each transition's transaction
+	lifecycle := engine.NewLifecycle(db)
+	lifecycle.OnTransition(func(ctx context.Context, _ *sqlx.Tx, t model.CaseTransition) error {
+		logging.FromContext(ctx).Info("🔀 Case transitioned", "case", t.CaseName, "from", t.From, "to", t.To, "actor", t.Actor)
+		return nil
+	})
+	ragHandler.Lifecycle = lifecycle
+
+	// Background jobs stop when the server shuts down
+	jobsCtx, cancelJobs := context.WithCancel(context.Background())
+	defer cancelJobs()
+
+	// Expire logged model I/O (model_log.retention)
+	go modellog.RunPurger(jobsCtx, db, time.Hour)
+	if cfg.ModelLog.Enabled {
+		slog.Info("🔏 Model I/O logging enabled", "responses", cfg.ModelLog.Responses,
+			"redact", cfg.ModelLog.Redact, "retention", cfg.ModelLog.Retention)
+	}
+
+	// Log search requests to rag_audit_log in background batches (audit_log)
+	ragHandler.Audit = ragaudit.NewWriter(db, cfg.AuditLog)
+	go ragHandler.Audit.Run(jobsCtx)
+	slog.Info("🧾 Search audit logging enabled", "sample_rate", cfg.AuditLog.QuerySampleRate,
+		"batch_size", cfg.AuditLog.BatchSize, "flush_interval", cfg.AuditLog.FlushInterval)
+
+	// Compact old audit query embeddings (audit_log.compact_after)
+	if cfg.AuditLog.CompactAfter > 0 {
+		go ontology.NewEnhancementsRepo(db).RunAuditCompactor(jobsCtx, cfg.AuditLog.CompactAfter, time.Hour)
+		slog.Info("🗜️  Audit embedding compaction enabled", "storage", cfg.AuditLog.EmbeddingStorage,
+			"after", cfg.AuditLog.CompactAfter)
+	}
+
+	// Archive and delete expired audit rows (retention)
+	if cfg.Retention.Enabled {
+		job, err := retention.NewJob(db, cfg.Retention)
+		if err != nil {
+			fatal("Failed to start retention job", err)
+		}
+		go job.Run(jobsCtx)
+		slog.Info("🗄️  Retention job started", "interval", cfg.Retention.Interval, "dry_run", cfg.Retention.DryRun,
+			"archive", cfg.Retention.Archive.Enabled, "audit_log_max_age", cfg.Retention.Policies.AuditLog.MaxAge)
+	}
+
+	// Archive unused attribute embeddings and promote reused ones (embedding_tiers)
+	if cfg.EmbeddingTiers.ArchiveAfter > 0 {
+		go ontology.NewTierRepo(db).RunTiering(jobsCtx, cfg.EmbeddingTiers)
+		slog.Info("🧊 Embedding tiering enabled", "archive_after", cfg.EmbeddingTiers.ArchiveAfter,
+			"promote_hits", cfg.EmbeddingTiers.PromoteHits)
+	}
+
+	// Publish queued events to webhook endpoints, Kafka and NATS (webhooks, events)
+	if events.Enabled(cfg) {
+		go events.NewDispatcher(db, cfg.Webhooks, cfg.Events).Run(jobsCtx)
+		slog.Info("📨 Event dispatcher started", "sinks", cfg.Events.Sinks,
+			"webhook_endpoints", len(cfg.Webhooks.Endpoints), "max_attempts", cfg.Webhooks.MaxAttempts)
+	}
+
+	// Expire persisted query embeddings (embedding_cache.ttl)
+	if cache := embedder.Cache(); cache != nil {
+		go cache.RunPurger(jobsCtx, time.Hour)
+	}
+
+	// Recompute automatic attribute clusters on a schedule
+	if cfg.Clustering.Enabled {
+		go clustering.NewJob(db, cfg.Clustering).Run(jobsCtx)
+		slog.Info("🧩 Clustering job started", "interval", cfg.Clustering.Interval,
+			"k", cfg.Clustering.K, "max_k", cfg.Clustering.MaxK, "llm_labels", cfg.Clustering.LLMLabels)
+	}
+
+	// Re-generate ontology embeddings whose text changed since they were embedded
+	if cfg.Reembedding.Enabled {
+		go reembed.NewWorker(db, embedder, cfg.Reembedding).WithDualWrite(cfg.EmbeddingSpaces.DualWrite).Run(jobsCtx)
+		slog.Info("♻️  Re-embedding worker started", "interval", cfg.Reembedding.Interval,
+			"batch_size", cfg.Reembedding.BatchSize, "unversioned", cfg.Reembedding.Unversioned,
+			"dual_write", cfg.EmbeddingSpaces.DualWrite)
+	}
+
+	// Extract the text of uploaded evidence and embed it for section search
+	if cfg.Evidence.Extraction.Enabled {
+		worker, err := evidence.NewTextWorker(db, embedder, cfg.Evidence, cfg.Ingestion)
+		if err != nil {
+			fatal("Failed to start evidence text extraction", err)
+		}
+		go worker.Run(jobsCtx)
+		slog.Info("📄 Evidence text extraction started", "interval", cfg.Evidence.Extraction.Interval,
+			"extractors", cfg.Evidence.Extraction.Extractors, "batch_size", cfg.Evidence.Extraction.BatchSize)
+	}
+
+	// Serve repeated attribute searches and stats from a cache (response_cache)
+	respCache, err := respcache.New(cfg.ResponseCache)
+	if err != nil {
+		fatal("Failed to create the response cache", err)
+	}
+	if respCache != nil {
+		slog.Info("🗃️  Response cache enabled", "driver", cfg.ResponseCache.Driver, "ttl", cfg.ResponseCache.TTL)
+	}
+
+	// Liveness and readiness probes (health); readiness checks the database,
+	// pgvector, the embeddings provider and the Rust DSL service. An
+	// unavailable embeddings provider reports degraded (health.optional).
+	checker := health.New("kycserver", cfg.Health)
+	checker.Add(health.CheckDatabase, health.Database())
+	checker.Add(health.CheckPGVector, health.PGVector(db))
+	checker.Add(health.CheckEmbeddings, health.Embeddings(embedder))
+	checker.Add(health.CheckRustDSL, health.RustDSL(cfg.RustDSL.Addr))
+
+	requireAnalyst := authn.Require(auth.RoleAnalyst)
+	requireReviewer := authn.Require(auth.RoleReviewer)
+	requireAdmin := authn.Require(auth.RoleAdmin)
+	identify := authn.Identify
+
 	// Create HTTP router
 	mux := http.NewServeMux()
 
-	// RAG endpoints
-	mux.HandleFunc("/rag/attribute_search", corsMiddleware(ragHandler.HandleAttributeSearch))
-	mux.HandleFunc("/rag/attribute_search_enriched", corsMiddleware(ragHandler.HandleEnrichedAttributeSearch))
-	mux.HandleFunc("/rag/similar_attributes", corsMiddleware(ragHandler.HandleSimilarAttributes))
-	mux.HandleFunc("/rag/text_search", corsMiddleware(ragHandler.HandleTextSearch))
-	mux.HandleFunc("/rag/stats", corsMiddleware(ragHandler.HandleMetadataStats))
+	// RAG endpoints; searches are logged to rag_audit_log. Attribute results
+	// identify callers sending credentials: the example values of sensitive
+	// attributes are redacted without the pii:read scope
+	mux.HandleFunc("/rag/attribute_search", corsMiddleware(identify(ragHandler.AuditSearch(respCache.Wrap("attribute_search", ragHandler.HandleAttributeSearch)))))
+	mux.HandleFunc("/rag/attribute_search_enriched", corsMiddleware(identify(ragHandler.AuditSearch(ragHandler.HandleEnrichedAttributeSearch))))
+	mux.HandleFunc("/rag/similar_attributes", corsMiddleware(identify(ragHandler.AuditSearch(ragHandler.HandleSimilarAttributes))))
+	mux.HandleFunc("/rag/text_search", corsMiddleware(identify(ragHandler.AuditSearch(ragHandler.HandleTextSearch))))
+	mux.HandleFunc("/rag/stats", corsMiddleware(respCache.Wrap("stats", ragHandler.HandleMetadataStats)))
 	mux.HandleFunc("/rag/health", corsMiddleware(ragHandler.HandleHealth))
-	mux.HandleFunc("/rag/attribute/", corsMiddleware(ragHandler.HandleGetAttribute))
+	mux.HandleFunc("/rag/attribute/", corsMiddleware(identify(ragHandler.HandleGetAttribute)))
+	mux.HandleFunc("/rag/cluster_search", corsMiddleware(identify(ragHandler.AuditSearch(ragHandler.HandleClusterSearch))))
+	// Searching a case's evidence text (case=) requires analyst
+	mux.HandleFunc("/rag/section_search", corsMiddleware(withParam("case", requireAnalyst, ragHandler.AuditSearch(ragHandler.HandleSectionSearch))))
+	mux.HandleFunc("/rag/document/", corsMiddleware(ragHandler.HandleDocumentSections))
+	mux.HandleFunc("/rag/documents", corsMiddleware(ragHandler.HandleGetDocuments))
+	mux.HandleFunc("/rag/regulations", corsMiddleware(ragHandler.HandleGetRegulations))
+
+	// ISO 20022 / FIBO mappings of attribute codes (changes require reviewer)
+	mux.HandleFunc("/rag/mappings", corsMiddleware(requireAnalyst(ragHandler.HandleExternalMappings)))
+	mux.HandleFunc("/rag/mappings/export", corsMiddleware(requireAnalyst(ragHandler.HandleExternalMappingsExport)))
 
 	// RAG Feedback endpoints
-	mux.HandleFunc("/rag/feedback", corsMiddleware(ragHandler.HandleFeedback))
+	mux.HandleFunc("/rag/feedback", corsMiddleware(requireAnalyst(ragHandler.HandleFeedback)))
 	mux.HandleFunc("/rag/feedback/recent", corsMiddleware(ragHandler.HandleRecentFeedback))
 	mux.HandleFunc("/rag/feedback/analytics", corsMiddleware(ragHandler.HandleFeedbackAnalytics))
 	mux.HandleFunc("/rag/feedback/attribute/", corsMiddleware(ragHandler.HandleFeedbackByAttribute))
 	mux.HandleFunc("/rag/feedback/summary", corsMiddleware(ragHandler.HandleFeedbackSummary))
 
+	// Metadata review feed (field-level diff approvals)
+	mux.HandleFunc("/rag/metadata/proposals", corsMiddleware(requireAnalyst(ragHandler.HandleSubmitMetadataProposals)))
+	mux.HandleFunc("/rag/metadata/review", corsMiddleware(requireReviewer(ragHandler.HandleMetadataReviewFeed)))
+	mux.HandleFunc("/rag/metadata/review/", corsMiddleware(requireReviewer(ragHandler.HandleMetadataReviewDecision)))
+	mux.HandleFunc("/rag/metadata/batches", corsMiddleware(requireReviewer(ragHandler.HandleMetadataReviewBatches)))
+
+	// Shadow (canary) review endpoints
+	mux.HandleFunc("/rag/shadow/divergences", corsMiddleware(requireReviewer(ragHandler.HandleShadowDivergences)))
+	mux.HandleFunc("/rag/shadow/summary", corsMiddleware(requireReviewer(ragHandler.HandleShadowSummary)))
+
+	// Ontology usage analytics, and the caller's rate limit and quota usage
+	mux.HandleFunc("/rag/usage", corsMiddleware(ragHandler.HandleQuotaUsage))
+	mux.HandleFunc("/rag/usage/report", corsMiddleware(requireReviewer(ragHandler.HandleUsageReport)))
+	mux.HandleFunc("/rag/usage/terms", corsMiddleware(requireReviewer(ragHandler.HandleTermUsage)))
+
+	// Ownership graph endpoints (UBO names and percentages require analyst)
+	registerGraphRoutes(mux, ragHandler, requireAnalyst)
+
+	// Watchlist (heightened monitoring with scheduled re-screening)
+	mux.HandleFunc("/watchlist", corsMiddleware(requireAnalyst(ragHandler.HandleWatchlist)))
+	mux.HandleFunc("/watchlist/", corsMiddleware(requireAnalyst(ragHandler.HandleWatchlistUnpin)))
+	mux.HandleFunc("/watchlist/alerts", corsMiddleware(requireAnalyst(ragHandler.HandleWatchlistAlerts)))
+	mux.HandleFunc("/watchlist/alerts/", corsMiddleware(requireAnalyst(ragHandler.HandleWatchlistAlertDecision)))
+
+	// Event-driven review (material changes to the entity graph)
+	mux.HandleFunc("/review/material-changes", corsMiddleware(requireReviewer(ragHandler.HandleMaterialChanges)))
+
+	// Managed lists referenced by derivation rules (updates require reviewer)
+	mux.HandleFunc("/lists", corsMiddleware(ragHandler.HandleLists))
+	mux.HandleFunc("/lists/", corsMiddleware(requireAnalyst(ragHandler.HandleList)))
+	mux.HandleFunc("/country-risk", corsMiddleware(ragHandler.HandleCountryRiskLists))
+	mux.HandleFunc("/country-risk/", corsMiddleware(requireAnalyst(ragHandler.HandleCountryRiskList)))
+
+	// Jurisdiction policy packs read by the discovery amendments (changes require reviewer)
+	mux.HandleFunc("/policy-packs", corsMiddleware(requireAnalyst(ragHandler.HandlePolicyPacks)))
+	mux.HandleFunc("/policy-packs/", corsMiddleware(requireAnalyst(ragHandler.HandlePolicyPack)))
+
+	// Per-tenant ontology overlays (changes require admin)
+	mux.HandleFunc("/ontology/overlay", corsMiddleware(requireAnalyst(ragHandler.HandleOntologyOverlay)))
+
+	// Regulation record versions and change impact (updates require reviewer)
+	mux.HandleFunc("/regulations/", corsMiddleware(requireAnalyst(ragHandler.HandleRegulation)))
+
+	// Document evidence (uploads, downloads and views are audited)
+	mux.HandleFunc("/evidence", corsMiddleware(requireAnalyst(ragHandler.HandleEvidence)))
+	mux.HandleFunc("/evidence/", corsMiddleware(requireAnalyst(ragHandler.HandleEvidenceItem)))
+	mux.HandleFunc("/attribute-candidates/", corsMiddleware(requireAnalyst(ragHandler.HandleAttributeCandidate)))
+
+	// Re-evaluation of derived attributes when their inputs change
+	mux.HandleFunc("/lineage/attribute-change", corsMiddleware(requireAnalyst(ragHandler.HandleAttributeChange)))
+	mux.HandleFunc("/lineage/queue", corsMiddleware(requireAnalyst(ragHandler.HandleReevaluationQueue)))
+	mux.HandleFunc("/lineage/simulate", corsMiddleware(requireAnalyst(ragHandler.HandleLineageSimulation)))
+	mux.HandleFunc("/lineage/graph", corsMiddleware(ragHandler.HandleLineageGraph))
+	mux.HandleFunc("/lineage/validate-rule", corsMiddleware(requireAnalyst(ragHandler.HandleValidateRule)))
+
+	// Case lifecycle timeline and transitions (approve/decline require reviewer),
+	// advisory case locks, case assignment and the work queue (reassigning
+	// another analyst's case requires reviewer) and case data dictionary
+	// (derived values materialized from lineage evaluations)
+	mux.HandleFunc("/cases/", corsMiddleware(requireAnalyst(ragHandler.HandleCases)))
+
+	// Agent registry (changes require admin)
+	mux.HandleFunc("/agents", corsMiddleware(requireAnalyst(ragHandler.HandleAgents)))
+	mux.HandleFunc("/agents/analytics", corsMiddleware(requireReviewer(ragHandler.HandleAgentAnalytics)))
+	mux.HandleFunc("/agents/", corsMiddleware(requireAnalyst(ragHandler.HandleAgent)))
+
+	// Confidence-gated feedback actions (policy changes require admin)
+	mux.HandleFunc("/rag/feedback/policy", corsMiddleware(requireReviewer(ragHandler.HandleFeedbackPolicy)))
+	mux.HandleFunc("/rag/feedback/actions", corsMiddleware(requireReviewer(ragHandler.HandleFeedbackActions)))
+	mux.HandleFunc("/rag/feedback/actions/", corsMiddleware(requireReviewer(ragHandler.HandleResolveFeedbackAction)))
+
+	// Logged prompts and model responses (admin; refused without authentication)
+	mux.HandleFunc("/rag/model_log", corsMiddleware(requireAdmin(ragHandler.HandleModelLog)))
+
+	// DSL grammar documentation for editor hovers
+	mux.HandleFunc("/dsl/grammar/help", corsMiddleware(ragHandler.HandleGrammarHelp))
+
+	// Browser DSL editor: live checks, formatting and amendment proposals
+	mux.HandleFunc("/ui/editor", ragHandler.HandleEditor)
+	mux.HandleFunc("/dsl/check", corsMiddleware(ragHandler.HandleDslCheck))
+	mux.HandleFunc("/dsl/format", corsMiddleware(ragHandler.HandleDslFormat))
+	mux.HandleFunc("/dsl/source", corsMiddleware(requireAnalyst(ragHandler.HandleDslSource)))
+	mux.HandleFunc("/dsl/proposals", corsMiddleware(requireAnalyst(ragHandler.HandleAmendmentProposals)))
+	mux.HandleFunc("/dsl/proposals/", corsMiddleware(requireReviewer(ragHandler.HandleAmendmentProposalReview)))
+
+	// Prometheus metrics and health probes
+	mux.Handle("/metrics", metrics.Handler())
+	checker.Register(mux)
+
 	// Root endpoint
 	mux.HandleFunc("/", corsMiddleware(handleRoot))
 
 	// Create server
+	// Rolling deploys: report unhealthy, stop accepting, finish in-flight
+	// requests, then stop jobs and close the database
+	drainer := drain.New(cfg.Drain)
+	ragHandler.Draining = drainer.Draining
+	checker.Draining = drainer.Draining
+	lis, err := drainer.Listen(context.Background(), ":"+port)
+	if err != nil {
+		fatal("❌ Failed to listen on :"+port, err)
+	}
+
+	// The metrics middleware must hold the *Request the mux sets Pattern on,
+	// so nothing between them may replace it (actor attaches a new context)
 	srv := &http.Server{
 		Addr:         ":" + port,
-		Handler:      loggingMiddleware(mux),
-		ReadTimeout:  defaultReadTimeout,
-		WriteTimeout: defaultWriteTimeout,
-		IdleTimeout:  defaultIdleTimeout,
+		Handler:      logging.HTTPMiddleware(otelhttp.NewHandler(actor.HTTPMiddleware(metrics.HTTPMiddleware("kycserver", limiter.Middleware(mux))), "kycserver")),
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		WriteTimeout: cfg.Server.WriteTimeout,
+		IdleTimeout:  cfg.Server.IdleTimeout,
 	}
 
 	// Start server in goroutine
 	go func() {
-		log.Printf("🌐 Server listening on http://localhost:%s\n", port)
+		slog.Info("🌐 Server listening", "url", "http://localhost:"+port)
 		log.Println("\n📋 Available endpoints:")
 		log.Println("   GET  /                                   - API documentation")
+		log.Println("   GET  /healthz                            - Liveness probe")
+		log.Println("   GET  /readyz                             - Readiness probe (dependency checks)")
 		log.Println("   GET  /rag/health                         - Health check")
+		log.Println("   GET  /metrics                            - Prometheus metrics")
 		log.Println("   GET  /rag/stats                          - Metadata statistics")
 		log.Println("   GET  /rag/attribute_search?q=<query>     - Semantic search")
 		log.Println("   GET  /rag/attribute_search_enriched?q=<query> - Enriched search with docs & regs")
 		log.Println("   GET  /rag/similar_attributes?code=<code> - Similar attributes")
 		log.Println("   GET  /rag/text_search?term=<term>        - Text search")
 		log.Println("   GET  /rag/attribute/<code>               - Get attribute metadata")
-		log.Println("   POST /rag/feedback                       - Submit feedback")
+		log.Println("   GET  /rag/attribute/<code>/profile       - Attribute profile card")
+		log.Println("   GET  /rag/cluster_search?q=<query>       - Search within the closest clusters")
+		log.Println("   GET  /rag/section_search?q=<query>       - Semantic search over document sections")
+		log.Println("   GET  /rag/section_search?q=<q>&case=<name> - Search a case's evidence text (analyst)")
+		log.Println("   GET  /rag/document/<code>/sections       - Sections of a document")
+		log.Println("   GET  /rag/documents?cursor=<cursor>      - Documents by code, a page at a time")
+		log.Println("   GET  /rag/regulations?cursor=<cursor>    - Regulations by code, a page at a time")
+		log.Println("   GET  /rag/mappings?attribute=<code>      - ISO 20022 / FIBO mappings (analyst)")
+		log.Println("   POST /rag/mappings                       - Save external mappings (reviewer)")
+		log.Println("   GET  /rag/mappings/export?format=csv     - Export mappings for a catalog (analyst)")
+		log.Println("   POST /rag/feedback                       - Submit feedback (analyst)")
 		log.Println("   GET  /rag/feedback/recent                - Recent feedback")
 		log.Println("   GET  /rag/feedback/analytics             - Feedback analytics")
 		log.Println("   GET  /rag/feedback/attribute/<code>      - Feedback by attribute")
 		log.Println("   GET  /rag/feedback/summary               - Feedback summary")
+		log.Println("   POST /rag/metadata/proposals             - Queue metadata changes (analyst)")
+		log.Println("   GET  /rag/metadata/review                - Pending metadata diffs (reviewer)")
+		log.Println("   POST /rag/metadata/review/<id>/decisions - Accept/reject fields (reviewer)")
+		log.Println("   GET  /rag/metadata/batches               - Review progress per batch (reviewer)")
+		log.Println("   GET  /rag/shadow/divergences             - Shadow ranking divergences (reviewer)")
+		log.Println("   GET  /rag/shadow/summary                 - Shadow experiment summary (reviewer)")
+		log.Println("   GET  /rag/usage                          - Your rate limit and daily quota usage")
+		log.Println("   GET  /rag/usage/report                   - Ontology hot spots & dead entries (reviewer)")
+		log.Println("   GET  /rag/usage/terms?type=<type>        - Per-term usage (reviewer)")
+		log.Println("   GET  /graph/ubo?entity=<id>              - Effective beneficial ownership rollup (analyst)")
+		log.Println("   GET  /watchlist                          - Watched entities (analyst)")
+		log.Println("   POST /watchlist                          - Pin an entity for re-screening (analyst)")
+		log.Println("   POST /watchlist/<id>/unpin               - Stop monitoring an entity (analyst)")
+		log.Println("   GET  /watchlist/alerts                   - Alerts raised on new hits (analyst)")
+		log.Println("   POST /watchlist/alerts/<id>              - Acknowledge/dismiss an alert (analyst)")
+		log.Println("   GET  /review/material-changes?case=<id>  - Reviews opened by material changes (reviewer)")
+		log.Println("   GET  /lists                              - Managed lists used by rules (in_list)")
+		log.Println("   GET  /lists/<name>?history=true          - A managed list and its versions (analyst)")
+		log.Println("   POST /lists/<name>                       - Update a list, re-evaluate rules (reviewer)")
+		log.Println("   GET  /country-risk                       - Jurisdiction risk lists (in_sanctioned_list)")
+		log.Println("   GET  /country-risk/<code>?history=true   - A risk list and its listings (analyst)")
+		log.Println("   POST /country-risk/<code>/countries      - List a country, re-evaluate rules (reviewer)")
+		log.Println("   POST /country-risk/<code>/countries/<cc>/end - De-list a country (reviewer)")
+		log.Println("   GET  /policy-packs?jurisdiction=<code>   - Active jurisdiction policy packs (analyst)")
+		log.Println("   POST /policy-packs                       - Draft a new policy pack version (reviewer)")
+		log.Println("   GET  /policy-packs/<code>[?version=N]    - A policy pack and its requirements (analyst)")
+		log.Println("   GET  /policy-packs/<code>/versions       - Versions of a policy pack (analyst)")
+		log.Println("   POST /policy-packs/<code>/versions/<n>/activate|retire - Change a version's status (reviewer)")
+		log.Println("   GET  /ontology/overlay?tenant=<tenant>   - A tenant's ontology overlay (analyst)")
+		log.Println("   PUT  /ontology/overlay                   - Add or update overlay entries (admin)")
+		log.Println("   POST /regulations/<code>                 - Update a regulation's citation/summary (reviewer)")
+		log.Println("   GET  /regulations/<code>/versions        - Versions of a regulation record (analyst)")
+		log.Println("   GET  /regulations/<code>/impact          - Documents, attributes, clusters, cases to refresh (analyst)")
+		log.Println("   POST /evidence                           - Upload a document file, multipart (analyst)")
+		log.Println("   GET  /evidence?case=<name>               - Evidence collected for a case (analyst)")
+		log.Println("   GET  /evidence/<id>[/content]            - Evidence metadata or file (analyst)")
+		log.Println("   GET  /evidence/<id>/text                 - Text extracted from evidence, by page (analyst)")
+		log.Println("   GET  /evidence/<id>/access               - Evidence access log (reviewer)")
+		log.Println("   GET  /cases/<name>/expiring-documents    - Expiring or expired evidence (analyst)")
+		log.Println("   POST /evidence/<id>/attributes           - Propose attribute values from evidence text (analyst)")
+		log.Println("   GET  /cases/<name>/attribute-candidates  - Proposed attribute values of a case (analyst)")
+		log.Println("   POST /attribute-candidates/<id>/confirm|reject - Decide a proposed value (analyst)")
+		log.Println("   GET  /cases/<name>/attributes?version=<n> - Captured attribute values of a case (analyst)")
+		log.Println("   POST /cases/<name>/attributes            - Capture a typed attribute value (analyst)")
+		log.Println("   GET|PUT|DELETE /cases/<name>/attributes/<code> - One captured value (analyst)")
+		log.Println("   GET  /cases/<name>/completeness?version=<n> - Required attributes present, missing or stale (analyst)")
+		log.Println("   POST /lineage/attribute-change           - Queue re-evaluation of dependents (analyst)")
+		log.Println("   GET  /lineage/queue?status=<status>      - Re-evaluation queue (analyst)")
+		log.Println("   POST /lineage/simulate                   - What-if evaluation with overridden values (analyst)")
+		log.Println("   GET  /lineage/graph?attribute=<code>     - Derivation DAG around an attribute")
+		log.Println("   POST /lineage/validate-rule              - Type-check a candidate derivation rule (analyst)")
+		log.Println("   GET  /cases/<name>/timeline              - Case lifecycle state and transitions (analyst)")
+		log.Println("   POST /cases/<name>/transition            - Move a case to another state (analyst)")
+		log.Println("   GET  /cases/<name>/lock                  - Advisory lock on a case (analyst)")
+		log.Println("   POST /cases/<name>/lock                  - Acquire or take over the lock (analyst)")
+		log.Println("   POST /cases/<name>/lock/heartbeat        - Keep holding the lock (analyst)")
+		log.Println("   DELETE /cases/<name>/lock?force=<bool>   - Release the lock (force: reviewer)")
+		log.Println("   GET  /cases/queue?assignee=me            - Work queue of assigned cases (analyst)")
+		log.Println("   GET  /cases/<name>/assignment            - Case assignment and history (analyst)")
+		log.Println("   POST /cases/<name>/assignment            - Assign or reassign a case (analyst; others': reviewer)")
+		log.Println("   GET  /cases/<name>/diff?from=&to=        - Section diff between two versions (analyst)")
+		log.Println("   GET  /cases/<name>/signatures?version=   - Verify approval signatures (analyst)")
+		log.Println("   GET  /cases/<name>/dictionary            - Case data dictionary values (analyst)")
+		log.Println("   POST /cases/<name>/dictionary/materialize - Copy derived results into it (analyst)")
+		log.Println("   GET  /agents                             - Registered agents (analyst)")
+		log.Println("   POST /agents                             - Register an agent (admin)")
+		log.Println("   GET  /agents/<name>                      - An agent and its activity (analyst)")
+		log.Println("   PUT  /agents/<name>                      - Update or suspend an agent (admin)")
+		log.Println("   DELETE /agents/<name>                    - Remove an agent from the registry (admin)")
+		log.Println("   GET  /agents/analytics                   - Feedback & audit activity per agent (reviewer)")
+		log.Println("   GET  /rag/feedback/policy?tenant=<tenant> - Feedback action policy (reviewer)")
+		log.Println("   PUT  /rag/feedback/policy                - Set a tenant's thresholds & actions (admin)")
+		log.Println("   GET  /rag/feedback/actions?status=open   - Demotions & review flags (reviewer)")
+		log.Println("   POST /rag/feedback/actions/<id>/resolve  - Resolve a flag or lift a demotion (reviewer)")
+		log.Println("   GET  /rag/model_log                      - Logged prompts & model responses (admin)")
+		log.Println("   GET  /dsl/grammar/help?keyword=<form>    - Grammar construct docs & hover text")
+		log.Println("   GET  /ui/editor?case=<name>              - Browser DSL editor")
+		log.Println("   POST /dsl/check                          - Parse & validate DSL with diagnostics")
+		log.Println("   POST /dsl/format                         - Canonical formatting of DSL")
+		log.Println("   GET  /dsl/source?case=<name>             - Latest DSL of a case (analyst)")
+		log.Println("   POST /dsl/proposals                      - Propose an amendment (analyst)")
+		log.Println("   GET  /dsl/proposals?status=pending       - Amendment proposals (analyst)")
+		log.Println("   POST /dsl/proposals/<id>/accept|reject   - Review a proposal (reviewer)")
 		log.Println()
 
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("❌ Server failed: %v", err)
+		if err := srv.Serve(lis); err != nil && err != http.ErrServerClosed {
+			fatal("❌ Server failed", err)
 		}
 	}()
 
-	// Wait for interrupt signal
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
-
-	log.Println("\n🛑 Shutting down server...")
-
-	// Graceful shutdown with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+	drainer.OnDrain("http", drain.HTTP(srv))
+	// Let in-flight shadow comparisons finish recording
+	drainer.OnDrain("shadow", drain.Func(ragHandler.Shadow.Wait))
+	drainer.OnDrain("jobs", drain.Func(cancelJobs))
+	// Write the searches still queued for the audit log
+	drainer.OnDrain("audit", drain.Func(ragHandler.Audit.Wait))
+	drainer.OnDrain("tracing", shutdownTracing)
+	drainer.OnDrain("database", drain.Func(func() {
+		db.Close()
+		kycdb.CloseShared()
+	}))
+	drainer.WaitForSignal()
 
-	if err := srv.Shutdown(ctx); err != nil {
-		log.Printf("⚠️  Server forced to shutdown: %v", err)
-	}
-
-	log.Println("✅ Server stopped gracefully")
+	slog.Info("✅ Server stopped gracefully")
 }
 
 // handleRoot returns API documentation
@@ -164,12 +586,30 @@ func handleRoot(w http.ResponseWriter, r *http.Request) {
 
     <h2>📊 Health & Monitoring</h2>
 
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/healthz</span>
+        <div class="description">Liveness probe. Returns 200 while the process is up; checks no dependencies.</div>
+        <div class="example">curl http://localhost:8080/healthz</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/readyz</span>
+        <div class="description">Readiness probe. Checks the database, pgvector, the embeddings provider and the Rust DSL service; 503 while draining or when a required check is down, "degraded" when only optional checks are.</div>
+        <div class="example">curl http://localhost:8080/readyz</div>
+    </div>
+
     <div class="endpoint">
         <span class="method">GET</span><span class="path">/rag/health</span>
         <div class="description">Health check endpoint. Returns server status and embedding configuration.</div>
         <div class="example">curl http://localhost:8080/rag/health</div>
     </div>
 
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/metrics</span>
+        <div class="description">Prometheus metrics: request counts and latency, embedding call durations, DB pool stats, vector search result counts.</div>
+        <div class="example">curl http://localhost:8080/metrics</div>
+    </div>
+
     <div class="endpoint">
         <span class="method">GET</span><span class="path">/rag/stats</span>
         <div class="description">Get metadata repository statistics including embedding coverage and risk distribution.</div>
@@ -181,12 +621,19 @@ func handleRoot(w http.ResponseWriter, r *http.Request) {
     <div class="endpoint">
         <span class="method">GET</span><span class="path">/rag/attribute_search</span>
         <div class="description">
-            Semantic search for attributes using vector embeddings.
+            Semantic search for attributes using vector embeddings. Results are ordered by
+            <span class="param">blended_score</span>: similarity blended with the net feedback on each
+            attribute for queries in the same cluster (<span class="param">feedback_score</span>, -1 to 1),
+            less any demotion for this query. While the embeddings provider is unavailable it falls back
+            to text search over the query terms and returns <span class="param">"degraded": true</span>.
             <br><strong>Parameters:</strong>
             <br>• <span class="param">q</span> (required) - Search query
             <br>• <span class="param">limit</span> (optional) - Max results (default: 10)
+            <br>• <span class="param">feedback_weight</span> (optional) - Weight of feedback, 0-1 (default: ranking.feedback_weight; 0 = similarity only)
+            <br>• <span class="param">explain</span> (optional) - true adds per result the matched tokens and synonyms, distance, cluster memberships and score contributions
         </div>
         <div class="example">curl "http://localhost:8080/rag/attribute_search?q=tax%20reporting%20requirements&limit=5"</div>
+        <div class="example">curl "http://localhost:8080/rag/attribute_search?q=beneficial%20owner&limit=3&explain=true"</div>
     </div>
 
     <div class="endpoint">
@@ -212,8 +659,84 @@ func handleRoot(w http.ResponseWriter, r *http.Request) {
 
     <div class="endpoint">
         <span class="method">GET</span><span class="path">/rag/attribute/{code}</span>
-        <div class="description">Get complete metadata for a specific attribute code.</div>
-        <div class="example">curl http://localhost:8080/rag/attribute/TAX_RESIDENCY_COUNTRY</div>
+        <div class="description">
+            Get complete metadata for a specific attribute code. With
+            <span class="param">include_provenance=true</span> (also accepted by the search,
+            documents and regulations endpoints) the result carries its source table, last
+            modified time, modifying actor and ontology version, also sent as
+            <span class="param">Last-Modified</span> and <span class="param">X-Provenance-*</span> headers.
+        </div>
+        <div class="example">curl -i "http://localhost:8080/rag/attribute/TAX_RESIDENCY_COUNTRY?include_provenance=true"</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/rag/attribute/{code}/profile</span>
+        <div class="description">
+            Profile card for an attribute in one call: metadata, linked documents and regulations,
+            derivations, cluster membership, feedback stats and usage counts.
+        </div>
+        <div class="example">curl http://localhost:8080/rag/attribute/TAX_RESIDENCY_COUNTRY/profile</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/rag/mappings</span>
+        <div class="description">
+            Links of attribute codes to ISO 20022 message elements and FIBO concepts, with a SKOS
+            match type (exact, close, broad, narrow, related). Attribute search and attribute
+            lookups include them as <span class="param">external_mappings</span>. POST a JSON array
+            of mappings to save them and DELETE with <span class="param">attribute</span>,
+            <span class="param">standard</span> and <span class="param">external_id</span> to remove
+            one (reviewer). <span class="param">/rag/mappings/export?standard=fibo&amp;format=csv</span>
+            downloads them for a data catalog.
+        </div>
+        <div class="example">curl -X POST http://localhost:8080/rag/mappings -d '[{"attribute_code":"REGISTERED_NAME","standard":"iso20022","external_id":"PartyIdentification135/Nm","external_name":"Name"}]'</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/rag/cluster_search</span>
+        <div class="description">
+            Cluster-routed semantic search: finds the clusters closest to the query and searches
+            only their members, attributing each result to its cluster. Broad queries such as
+            "ownership" stay within the relevant area; with no matching cluster it searches globally.
+            <br><strong>Parameters:</strong>
+            <br>• <span class="param">q</span> (required) - Search query
+            <br>• <span class="param">limit</span> (optional) - Max results (default: 10)
+            <br>• <span class="param">clusters</span> (optional) - Clusters to search (default: 1, max: 5)
+            <br>• <span class="param">min_similarity</span> (optional) - Minimum query/centroid similarity for a cluster (0-1)
+            <br>• <span class="param">feedback_weight</span> (optional) - Weight of feedback in the ranking, 0-1
+        </div>
+        <div class="example">curl "http://localhost:8080/rag/cluster_search?q=ownership&clusters=2"</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/rag/section_search</span>
+        <div class="description">
+            Snippet-level semantic search over document sections (e.g. a specific FATCA clause).
+            Each result carries its document, jurisdiction and regulation.
+            <br><strong>Parameters:</strong>
+            <br>• <span class="param">q</span> (required) - Search query
+            <br>• <span class="param">limit</span> (optional) - Max results (default: 10)
+            <br>• <span class="param">case</span> (optional) - search the text extracted from the case's evidence instead; results carry <span class="param">evidence_id</span>. Requires the <span class="param">analyst</span> role
+        </div>
+        <div class="example">curl "http://localhost:8080/rag/section_search?q=substantial%20US%20owners&limit=5"</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/rag/document/{code}/sections</span>
+        <div class="description">All sections of a document in order, with the document title and regulation it belongs to.</div>
+        <div class="example">curl http://localhost:8080/rag/document/W8BENE/sections</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/rag/documents</span>
+        <div class="description">
+            Documents linked to an <span class="param">attribute</span>, or all documents by code a
+            page at a time (<span class="param">limit</span>, default 50, max 100). Pass the
+            <span class="param">next_cursor</span> of a page as <span class="param">cursor</span> for
+            the next one; it is empty on the last page. <span class="path">/rag/regulations</span>
+            pages regulations the same way.
+        </div>
+        <div class="example">curl "http://localhost:8080/rag/documents?limit=20&amp;cursor=WyJXOEJFTiJd"</div>
     </div>
 
     <h2>🔄 Feedback Endpoints</h2>
@@ -222,6 +745,8 @@ func handleRoot(w http.ResponseWriter, r *http.Request) {
         <span class="method">POST</span><span class="path">/rag/feedback</span>
         <div class="description">
             Submit feedback on search results to improve relevance scores.
+            Requires the <span class="param">analyst</span> role when authentication is enabled
+            (<span class="param">Authorization: Bearer &lt;jwt&gt;</span> or <span class="param">X-API-Key</span>).
             <br><strong>Body Parameters (JSON):</strong>
             <br>• <span class="param">query_text</span> (required) - Original search query
             <br>• <span class="param">attribute_code</span> (optional) - Attribute being rated
@@ -229,7 +754,7 @@ func handleRoot(w http.ResponseWriter, r *http.Request) {
             <br>• <span class="param">regulation_code</span> (optional) - Regulation being rated
             <br>• <span class="param">feedback</span> (optional) - positive/negative/neutral (default: positive)
             <br>• <span class="param">confidence</span> (optional) - 0.0-1.0 weight (default: 1.0)
-            <br>• <span class="param">agent_name</span> (optional) - Name of feedback provider
+            <br>• <span class="param">agent_name</span> (optional) - Name of feedback provider (must match a registered agent the request acts as)
             <br>• <span class="param">agent_type</span> (optional) - human/ai/automated (default: human)
         </div>
         <div class="example">curl -X POST http://localhost:8080/rag/feedback \
@@ -273,6 +798,600 @@ func handleRoot(w http.ResponseWriter, r *http.Request) {
         <div class="example">curl http://localhost:8080/rag/feedback/summary</div>
     </div>
 
+    <h2>📝 Metadata Review</h2>
+
+    <div class="endpoint">
+        <span class="method">POST</span><span class="path">/rag/metadata/proposals</span>
+        <div class="description">
+            Queue proposed attribute metadata changes (LLM enrichment or imports) for review. Requires the <span class="param">analyst</span> role.
+            <br><strong>Body Parameters (JSON):</strong>
+            <br>• <span class="param">batch_id</span> (required) - Enrichment/import batch identifier
+            <br>• <span class="param">source</span> (optional) - llm_enrichment/import/manual (default: import)
+            <br>• <span class="param">proposals</span> (required) - List of {attribute_code, proposed: {synonyms, risk_level, business_context, ...}}
+        </div>
+        <div class="example">curl -X POST http://localhost:8080/rag/metadata/proposals \
+  -H "Content-Type: application/json" \
+  -d '{"batch_id":"enrich-2024-06","source":"llm_enrichment","proposals":[{"attribute_code":"UBO_NAME","proposed":{"risk_level":"HIGH"}}]}'</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/rag/metadata/review</span>
+        <div class="description">
+            Pending proposals presented as field-level diffs (old value vs new value). Requires the <span class="param">reviewer</span> role.
+            <br><strong>Parameters:</strong>
+            <br>• <span class="param">batch</span> (optional) - Filter by batch
+            <br>• <span class="param">limit</span> (optional) - Max proposals (default: 50)
+        </div>
+        <div class="example">curl "http://localhost:8080/rag/metadata/review?batch=enrich-2024-06"</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">POST</span><span class="path">/rag/metadata/review/{id}/decisions</span>
+        <div class="description">Accept or reject each changed field. Accepted fields are applied and the attribute is re-embedded.</div>
+        <div class="example">curl -X POST http://localhost:8080/rag/metadata/review/42/decisions \
+  -H "Content-Type: application/json" \
+  -d '{"decisions":[{"field":"risk_level","accept":true},{"field":"synonyms","accept":false,"comment":"too broad"}]}'</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/rag/metadata/batches</span>
+        <div class="description">Review progress (pending/applied/rejected counts) per batch.</div>
+        <div class="example">curl http://localhost:8080/rag/metadata/batches</div>
+    </div>
+
+    <h2>🌓 Shadow Evaluation</h2>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/rag/shadow/divergences</span>
+        <div class="description">
+            Divergences between the live ranking and a candidate ranking run in shadow mode (enable with SHADOW_RANKER and SHADOW_SAMPLE_RATE).
+            <br><strong>Parameters:</strong>
+            <br>• <span class="param">experiment</span> (optional) - Filter by experiment name
+            <br>• <span class="param">limit</span> (optional) - Max results (default: 50)
+        </div>
+        <div class="example">curl "http://localhost:8080/rag/shadow/divergences?limit=20"</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/rag/shadow/summary</span>
+        <div class="description">Per-experiment divergence counts and scores, plus the active experiment. Shadow endpoints require the <span class="param">reviewer</span> role when authentication is enabled.</div>
+        <div class="example">curl http://localhost:8080/rag/shadow/summary</div>
+    </div>
+
+    <h2>📊 Ontology Usage</h2>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/rag/usage</span>
+        <div class="description">
+            The caller's rate limit and daily quota usage on the RAG endpoints: tokens available, requests used and refused today, and when the quota resets. Callers are identified by API key, or else by client IP. Limited requests are answered 429 with <span class="param">Retry-After</span>.
+        </div>
+        <div class="example">curl -H "X-API-Key: $KEY" "http://localhost:8080/rag/usage"</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/rag/usage/report</span>
+        <div class="description">
+            Hot spots (terms most referenced by cases and returned to agents), dead entries (never referenced or searched) and embedding refresh priorities (hot attributes with missing or stale embeddings).
+            <br><strong>Parameters:</strong>
+            <br>• <span class="param">limit</span> (optional) - Max entries per section (default: 25)
+            <br>• <span class="param">stale_days</span> (optional) - Metadata age that counts as stale (default: 90)
+        </div>
+        <div class="example">curl "http://localhost:8080/rag/usage/report?limit=10"</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/rag/usage/terms</span>
+        <div class="description">
+            Case references and search hits per ontology term. Search endpoints record the terms they return; send <span class="param">X-Agent-Name</span> to attribute hits to an agent.
+            <br><strong>Parameters:</strong>
+            <br>• <span class="param">type</span> (optional) - attribute, document or regulation
+            <br>• <span class="param">limit</span> (optional) - Max results (default: 100)
+        </div>
+        <div class="example">curl "http://localhost:8080/rag/usage/terms?type=document"</div>
+    </div>
+
+    <h2>🕸️ Ownership Graph</h2>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/graph/ubo</span>
+        <div class="description">
+            Effective beneficial ownership: percentages are multiplied along every ownership path and summed per ultimate owner. Owners at or above the threshold are flagged as UBOs. Requires the analyst role.
+            <br><strong>Parameters:</strong>
+            <br>• <span class="param">entity</span> (required) - Entity id
+            <br>• <span class="param">threshold</span> (optional) - UBO threshold percentage (default: 25)
+        </div>
+        <div class="example">curl -H "X-API-Key: $KEY" "http://localhost:8080/graph/ubo?entity=&lt;entity-id&gt;"</div>
+    </div>
+
+    <h2>👁️ Watchlist</h2>
+
+    <div class="endpoint">
+        <span class="method">POST</span><span class="path">/watchlist</span>
+        <div class="description">
+            Pin an entity for heightened monitoring. dataserver re-screens it (and optionally its UBOs) against the sanctions, PEP and adverse media lists at the given cadence and raises an alert for every new hit.
+            Watchlist endpoints require the <span class="param">analyst</span> role when authentication is enabled.
+            <br><strong>Body:</strong> <span class="param">entity_id</span>, <span class="param">reason</span>, <span class="param">cadence</span> (optional, e.g. 6h), <span class="param">include_ubos</span> (optional)
+        </div>
+        <div class="example">curl -X POST http://localhost:8080/watchlist -d '{"entity_id":"&lt;entity-id&gt;","reason":"adverse press","cadence":"6h","include_ubos":true}'</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/watchlist</span>
+        <div class="description">Watched entities with cadence, last/next screening and open alert counts. <span class="param">all=true</span> includes unpinned entries.</div>
+        <div class="example">curl http://localhost:8080/watchlist</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">POST</span><span class="path">/watchlist/{id}/unpin</span>
+        <div class="description">Stop monitoring an entry. Its hits and alerts are kept.</div>
+        <div class="example">curl -X POST http://localhost:8080/watchlist/1/unpin</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/watchlist/alerts</span>
+        <div class="description">
+            Alerts raised by re-screening, newest first.
+            <br><strong>Parameters:</strong>
+            <br>• <span class="param">status</span> (optional) - OPEN (default), ACKNOWLEDGED, DISMISSED or all
+            <br>• <span class="param">limit</span> (optional) - Max results (default: 50)
+        </div>
+        <div class="example">curl http://localhost:8080/watchlist/alerts</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">POST</span><span class="path">/watchlist/alerts/{id}</span>
+        <div class="description">Acknowledge or dismiss an alert.</div>
+        <div class="example">curl -X POST http://localhost:8080/watchlist/alerts/1 -d '{"status":"ACKNOWLEDGED"}'</div>
+    </div>
+
+    <h2>🔔 Event-driven Review</h2>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/review/material-changes</span>
+        <div class="description">
+            Review amendments opened automatically on open cases when an ownership stake crosses the threshold, an entity changes jurisdiction, a director is appointed or an adverse media hit is raised. Each event carries the triggering evidence. Requires the <span class="param">reviewer</span> role.
+            <br><strong>Parameters:</strong>
+            <br>• <span class="param">case</span> (optional) - Case id
+            <br>• <span class="param">limit</span> (optional) - Max results (default: 50)
+        </div>
+        <div class="example">curl "http://localhost:8080/review/material-changes?case=BLACKROCK-GLOBAL-EQUITY"</div>
+    </div>
+
+    <h2>🌍 Managed Lists</h2>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/lists</span>
+        <div class="description">Managed lists (sanctioned and high-risk jurisdictions...) that derivation rules reference as <span class="param">in_list(TAX_RESIDENCY_COUNTRY, "EU_HIGH_RISK")</span>, with their source and effective dates</div>
+        <div class="example">curl http://localhost:8080/lists</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/lists/{name}</span>
+        <div class="description">
+            A managed list. Requires the <span class="param">analyst</span> role.
+            <br><strong>Parameters:</strong>
+            <br>• <span class="param">history</span> (optional) - true to return every version
+        </div>
+        <div class="example">curl "http://localhost:8080/lists/EU_HIGH_RISK?history=true"</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">POST</span><span class="path">/lists/{name}</span>
+        <div class="description">Creates or replaces a list, then re-evaluates every derived attribute whose rule references it against the latest recorded inputs of each case; POST /lists/{name}/reevaluate only re-evaluates. Requires the <span class="param">reviewer</span> role.</div>
+        <div class="example">curl -X POST http://localhost:8080/lists/EU_HIGH_RISK -d '{"members":["AF","IR","KP","MM"],"source":"Delegated Regulation (EU) 2016/1675","effective_from":"2025-08-05"}'</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/country-risk</span>
+        <div class="description">Jurisdiction risk lists (FATF black and grey lists, EU high-risk third countries) with the countries listed today. Rules read them as <span class="param">in_sanctioned_list(TAX_RESIDENCY_COUNTRY, "FATF_GREY_LIST")</span>; each listing has its own effective dates.</div>
+        <div class="example">curl http://localhost:8080/country-risk</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/country-risk/{code}</span>
+        <div class="description">
+            A jurisdiction risk list. Requires the <span class="param">analyst</span> role.
+            <br><strong>Parameters:</strong>
+            <br>• <span class="param">history</span> (optional) - true to return every listing, past and future
+        </div>
+        <div class="example">curl "http://localhost:8080/country-risk/FATF_GREY_LIST?history=true"</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">POST</span><span class="path">/country-risk/{code}/countries</span>
+        <div class="description">Lists a country from <span class="param">effective_from</span> (default today), optionally until <span class="param">effective_to</span>, then re-evaluates the derived attributes reading the list. <span class="param">POST /country-risk/{code}/countries/{country}/end</span> de-lists a country after <span class="param">effective_to</span> (default today), and <span class="param">POST /country-risk/{code}</span> creates a list or sets its name, authority and description. Requires the <span class="param">reviewer</span> role.</div>
+        <div class="example">curl -X POST http://localhost:8080/country-risk/FATF_GREY_LIST/countries -d '{"country":"PA","effective_from":"2025-10-24","note":"FATF plenary October 2025"}'</div>
+    </div>
+
+    <h2>⚖️ Regulation Changes</h2>
+
+    <div class="endpoint">
+        <span class="method">POST</span><span class="path">/regulations/{code}</span>
+        <div class="description">Updates the <span class="param">citation</span>, <span class="param">summary</span>, title, name, description or effective dates of a regulation, recording a new version with an optional <span class="param">note</span> and queueing a <span class="param">regulation.changed</span> event. <span class="param">GET /regulations/{code}/versions</span> lists the versions. Requires the <span class="param">reviewer</span> role.</div>
+        <div class="example">curl -X POST http://localhost:8080/regulations/AMLD5 -d '{"citation":"Directive (EU) 2018/843, as amended","note":"Consolidated text"}'</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/regulations/{code}/impact</span>
+        <div class="description">
+            What a change to a regulation affects: the fields changed from the previous version, the documents and attributes governed by the regulation, the attribute clusters holding them, its active policy packs, and the open cases (not declined or archived) whose latest version references any of them, each with its reasons. Requires the <span class="param">analyst</span> role.
+            <br><strong>Parameters:</strong>
+            <br>• <span class="param">version</span> (optional) - the version whose change to report, default the current one
+        </div>
+        <div class="example">curl http://localhost:8080/regulations/MAS626/impact</div>
+    </div>
+
+    <h2>📜 Policy Packs</h2>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/policy-packs</span>
+        <div class="description">
+            Active jurisdiction policy packs (AMLD5, MAS 626, BSA): the policy and obligations policy-discovery adds, the documents document-discovery requires and the attributes and thresholds that apply. Requires the <span class="param">analyst</span> role.
+            <br><strong>Parameters:</strong>
+            <br>• <span class="param">jurisdiction</span> (optional) - e.g. EU, SG, US
+        </div>
+        <div class="example">curl "http://localhost:8080/policy-packs?jurisdiction=SG"</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/policy-packs/{code}</span>
+        <div class="description">
+            The active version of a pack, or <span class="param">?version=N</span>; <span class="param">GET /policy-packs/{code}/versions</span> lists every version with its status. Requires the <span class="param">analyst</span> role.
+        </div>
+        <div class="example">curl http://localhost:8080/policy-packs/MAS626/versions</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">POST</span><span class="path">/policy-packs</span>
+        <div class="description">Drafts the next version of a pack. Versions are never edited: <span class="param">POST /policy-packs/{code}/versions/{n}/activate</span> makes a draft the active version, retiring the previous one, and <span class="param">.../retire</span> withdraws a version. Requires the <span class="param">reviewer</span> role.</div>
+        <div class="example">curl -X POST http://localhost:8080/policy-packs -d '{"code":"MAS626","name":"MAS Notice 626","jurisdiction":"SG","regulation_code":"MAS626","policy_code":"KYCPOL-SG-2025","obligations":["OBL-UBO-DECLARATION"],"documents":[{"code":"ACRA-PROFILE","mandatory":true}],"attributes":[{"code":"UBO_NAME","mandatory":true}],"thresholds":[{"name":"UBO_OWNERSHIP_PERCENT","value":25,"unit":"percent"}]}'</div>
+    </div>
+
+    <h2>🧩 Ontology Overlays</h2>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/ontology/overlay</span>
+        <div class="description">
+            The attributes, documents, attribute-document links and derivation rules a tenant adds to the global ontology. Validation, discovery amendments and attribute search look a code up in the tenant's overlay first, so an entry with a global code overrides it for that tenant only; searches use the tenant of the token or the <span class="param">X-Tenant-ID</span> header, cases the tenant they are assigned to (<span class="param">kycctl overlay assign</span>). PUT adds or updates entries and requires the <span class="param">admin</span> role; new attributes are searchable once embedded (<span class="param">kycctl overlay embed</span>).
+            <br><strong>Parameters:</strong>
+            <br>• <span class="param">tenant</span> (optional) - Tenant (default: the caller's)
+        </div>
+        <div class="example">curl -X PUT http://localhost:8080/ontology/overlay -H "Content-Type: application/json" -d '{"tenant":"private-bank","attributes":[{"code":"PB_WEALTH_BAND","name":"Wealth band","attribute_class":"Public","data_type":"enum","domain_values":["HNW","UHNW"]}],"links":[{"attribute_code":"PB_WEALTH_BAND","document_code":"BANK-STATEMENT","source_tier":"Primary","is_mandatory":true}]}'</div>
+    </div>
+
+    <h2>📎 Document Evidence</h2>
+
+    <div class="endpoint">
+        <span class="method">POST</span><span class="path">/evidence</span>
+        <div class="description">
+            Uploads a collected document as multipart/form-data. The file is hashed (sha256) and stored once per hash by the configured driver (local, s3 or minio). Requires the <span class="param">analyst</span> role.
+            <br><strong>Fields:</strong>
+            <br>• <span class="param">file</span> - the document file
+            <br>• <span class="param">case</span> - the case it was collected for
+            <br>• <span class="param">document_code</span> (optional) - the document type, e.g. W8BENE
+            <br>• <span class="param">attribute_code</span> (optional) - the attribute it evidences
+            <br>• <span class="param">issued_at</span>, <span class="param">expires_at</span> (optional) - YYYY-MM-DD; expiry defaults to issue date (or today) plus the validity
+            <br>• <span class="param">validity_days</span> (optional) - how long the document is accepted, e.g. 90 for a proof of address; defaults to the document's validity
+            <br>• <span class="param">description</span> (optional)
+        </div>
+        <div class="example">curl -X POST http://localhost:8080/evidence -F case=BLACKROCK-GLOBAL-EQUITY -F document_code=W8BENE -F issued_at=2025-01-15 -F file=@w8bene.pdf</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/evidence/{id}/content</span>
+        <div class="description">Downloads an evidence file; <span class="param">GET /evidence/{id}</span> returns its metadata and <span class="param">GET /evidence?case={name}</span> lists a case's evidence. Views and downloads are recorded with their actor; <span class="param">GET /evidence/{id}/access</span> returns the access log (reviewer). Requires the <span class="param">analyst</span> role.</div>
+        <div class="example">curl -OJ http://localhost:8080/evidence/42/content</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/evidence/{id}/text</span>
+        <div class="description">Text extracted from an evidence file, page by page, with its <span class="param">text_status</span> (pending, extracting, extracted, unsupported or failed) and extractor. With <span class="param">evidence.extraction.enabled</span>, kycserver extracts uploads with the configured extractors (builtin, pdftotext, tesseract, api) and embeds the text of evidence with a document code for <span class="param">/rag/section_search?case=</span>. Recorded as a view. Requires the <span class="param">analyst</span> role.</div>
+        <div class="example">curl http://localhost:8080/evidence/42/text</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/cases/{name}/expiring-documents?days=30</span>
+        <div class="description">Evidence of a case expiring within <span class="param">days</span> (default the expiry checker's warning period) or already expired, soonest first, with its <span class="param">status</span> (EXPIRING or EXPIRED), <span class="param">days_left</span> and when the checker flagged it. Evidence refreshed by a later upload of the same document is left out. Requires the <span class="param">analyst</span> role.</div>
+        <div class="example">curl "http://localhost:8080/cases/BLACKROCK-GLOBAL-EQUITY/expiring-documents?days=60"</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">POST</span><span class="path">/evidence/{id}/attributes</span>
+        <div class="description">
+            Proposes attribute values from the extracted text of evidence: the excerpts closest to each attribute are retrieved and <span class="param">openai.chat_model</span> reads them, answering with a value, a confidence and the excerpts it cited. Values whose quote is not found in the cited excerpts keep at most 0.5 confidence; uncited values are dropped. The values are stored as pending candidates, replacing the pending candidates of the evidence, and nothing is written to the case until an analyst confirms them. Requires the <span class="param">analyst</span> role.
+            <br><strong>Body:</strong>
+            <br>• <span class="param">attributes</span> (optional) - attribute codes, default the attributes the evidence's document evidences
+        </div>
+        <div class="example">curl -X POST http://localhost:8080/evidence/42/attributes -d '{"attributes":["REGISTERED_NAME","INCORPORATION_DATE"]}'</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">POST</span><span class="path">/attribute-candidates/{id}/confirm</span>
+        <div class="description">Confirms a pending candidate, optionally correcting its <span class="param">value</span>, with a <span class="param">note</span>; <span class="param">/reject</span> rejects it. <span class="param">GET /cases/{name}/attribute-candidates?status=pending</span> lists a case's candidates with their citations. Requires the <span class="param">analyst</span> role.</div>
+        <div class="example">curl -X POST http://localhost:8080/attribute-candidates/7/confirm -d '{"value":"BlackRock Global Equity Fund","note":"checked against the certificate"}'</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">POST</span><span class="path">/cases/{name}/attributes</span>
+        <div class="description">
+            Captures a public attribute value for a case version. The value is validated against the attribute's <span class="param">data_type</span> (string, date as YYYY-MM-DD, boolean, integer, float, array as JSON) and stored in canonical form; private attributes are derived by lineage rules and cannot be captured. A version sees the latest value captured at or before it. Values of the current version are written to the case data dictionary and queue their dependent derived attributes for re-evaluation. <span class="param">PUT /cases/{name}/attributes/{code}</span> captures one attribute, <span class="param">GET</span> lists or reads values (<span class="param">?version=</span>, default current) and <span class="param">DELETE</span> removes the value captured at a version. Requires the <span class="param">analyst</span> role.
+            <br><strong>Body:</strong>
+            <br>• <span class="param">attribute</span> (required) - attribute code
+            <br>• <span class="param">value</span> (required) - value as text
+            <br>• <span class="param">version</span> (optional) - case version, default current
+            <br>• <span class="param">source_document</span> (optional) - document code the value was read from
+            <br>• <span class="param">evidence_id</span> (optional) - evidence of the case the value was read from
+        </div>
+        <div class="example">curl -X POST http://localhost:8080/cases/BLACKROCK-GLOBAL-EQUITY-FUND/attributes -d '{"attribute":"INCORPORATION_DATE","value":"2011-03-14","source_document":"CERT-INC","evidence_id":42}'</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/cases/{name}/completeness</span>
+        <div class="description">
+            Compares the attributes a case version requires with its captured values. Required attributes are the mandatory public attributes evidenced by the documents of its <span class="param">document-requirements</span>, for each requirement's jurisdiction. Each is reported <span class="param">present</span>, <span class="param">missing</span> or <span class="param">stale</span> (its evidence, or its source document's validity since capture, has expired) with what requires it, and <span class="param">completeness_percent</span> is the share present. <span class="param">?version=</span> selects a case version (default current). Requires the <span class="param">analyst</span> role.
+        </div>
+        <div class="example">curl http://localhost:8080/cases/BLACKROCK-GLOBAL-EQUITY-FUND/completeness</div>
+    </div>
+
+    <h2>🔁 Derived Attribute Re-evaluation</h2>
+
+    <div class="endpoint">
+        <span class="method">POST</span><span class="path">/lineage/attribute-change</span>
+        <div class="description">Records a new value of a case attribute and queues the derived attributes depending on it. dataserver re-evaluates them, records fresh lineage results and cascades to attributes derived from values that changed. Changes to managed lists and derivation rules are queued automatically. Requires the <span class="param">analyst</span> role.</div>
+        <div class="example">curl -X POST http://localhost:8080/lineage/attribute-change -d '{"case":"BLACKROCK-GLOBAL-EQUITY","attribute":"TAX_RESIDENCY_COUNTRY","value":"KY"}'</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/lineage/queue</span>
+        <div class="description">
+            Derived attributes queued for re-evaluation. Requires the <span class="param">analyst</span> role.
+            <br><strong>Parameters:</strong>
+            <br>• <span class="param">status</span> (optional) - PENDING (default), DONE, FAILED or all
+            <br>• <span class="param">limit</span> (optional) - Max results (default: 50)
+        </div>
+        <div class="example">curl "http://localhost:8080/lineage/queue?status=all"</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">POST</span><span class="path">/lineage/simulate</span>
+        <div class="description">What-if evaluation: evaluates every derivation of a case against its current values and again with the given attribute values overridden, and flags the results that change. Nothing is recorded or queued. Requires the <span class="param">analyst</span> role.</div>
+        <div class="example">curl -X POST http://localhost:8080/lineage/simulate -d '{"case":"BLACKROCK-GLOBAL-EQUITY","overrides":{"TAX_RESIDENCY_COUNTRY":"KY"}}'</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/lineage/graph</span>
+        <div class="description">
+            Derivation DAG around an attribute for provenance views: the attributes it is derived from (upstream) and those derived from it (downstream), transitively, with the rule expression, jurisdiction and regulation code of every edge.
+            <br><strong>Parameters:</strong>
+            <br>• <span class="param">attribute</span> (required) - Attribute code
+            <br>• <span class="param">depth</span> (optional) - Steps in each direction (default: 10)
+        </div>
+        <div class="example">curl "http://localhost:8080/lineage/graph?attribute=HIGH_RISK_JURISDICTION_FLAG"</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">POST</span><span class="path">/lineage/validate-rule</span>
+        <div class="description">Compiles a candidate derivation rule against the attribute types (attribute metadata <span class="param">data_type</span>) and the managed lists in effect before it is stored. Returns compile errors, type mismatches, a result that does not match <span class="param">rule_type</span>, unknown attributes and lists, and attributes read but missing from <span class="param">source_attributes</span>. Requires the <span class="param">analyst</span> role.</div>
+        <div class="example">curl -X POST http://localhost:8080/lineage/validate-rule -d '{"rule":"TAX_RESIDENCY_COUNTRY > 5","source_attributes":["TAX_RESIDENCY_COUNTRY"],"rule_type":"Boolean"}'</div>
+    </div>
+
+    <h2>🔀 Case Lifecycle</h2>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/cases/{name}/timeline</span>
+        <div class="description">Lifecycle state of a case (draft, validated, in-review, approved, declined, archived), the states it may move to and every transition with its actor, reason and time. Requires the <span class="param">analyst</span> role.</div>
+        <div class="example">curl http://localhost:8080/cases/BLACKROCK-GLOBAL-EQUITY/timeline</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">POST</span><span class="path">/cases/{name}/transition</span>
+        <div class="description">Moves a case to another lifecycle state. Moves the lifecycle does not allow are refused with 409. Approving or declining requires the <span class="param">reviewer</span> role.
+            <br><strong>Body:</strong> <span class="param">status</span>, <span class="param">reason</span> (optional)
+        </div>
+        <div class="example">curl -X POST http://localhost:8080/cases/BLACKROCK-GLOBAL-EQUITY/transition -d '{"status":"in-review","reason":"ready for committee"}'</div>
+    </div>
+
+    <h2>🔒 Case Locks</h2>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/cases/{name}/lock</span>
+        <div class="description">Advisory lock held on a case while it is amended: holder, note, heartbeat and expiry. Expired locks are shown with <span class="param">stale</span> set. Requires the <span class="param">analyst</span> role.</div>
+        <div class="example">curl http://localhost:8080/cases/BLACKROCK-GLOBAL-EQUITY/lock</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">POST</span><span class="path">/cases/{name}/lock</span>
+        <div class="description">Acquires the lock for the caller (or <span class="param">holder</span> without authentication), renewing it if already held and taking it over if expired. A live lock of someone else is refused with 409. While locked, amendments and proposal acceptance by anyone else are refused. Locks last <span class="param">case_lock.ttl</span> after the last heartbeat.
+            <br><strong>Body:</strong> <span class="param">holder</span> (optional), <span class="param">note</span> (optional)
+        </div>
+        <div class="example">curl -X POST http://localhost:8080/cases/BLACKROCK-GLOBAL-EQUITY/lock -d '{"holder":"jdoe","note":"adding UBO evidence"}'</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">POST</span><span class="path">/cases/{name}/lock/heartbeat</span>
+        <div class="description">Extends the caller's lock by the TTL. Returns 409 when the lock was released or taken over.</div>
+        <div class="example">curl -X POST "http://localhost:8080/cases/BLACKROCK-GLOBAL-EQUITY/lock/heartbeat?holder=jdoe"</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">DELETE</span><span class="path">/cases/{name}/lock</span>
+        <div class="description">Releases the caller's lock. <span class="param">force=true</span> releases someone else's live lock and requires the <span class="param">reviewer</span> role.</div>
+        <div class="example">curl -X DELETE "http://localhost:8080/cases/BLACKROCK-GLOBAL-EQUITY/lock?holder=jdoe"</div>
+    </div>
+
+    <h2>🗂️ Case Assignment & Work Queue</h2>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/cases/queue</span>
+        <div class="description">Assigned cases, soonest SLA due date and highest risk first. Filter with <span class="param">assignee</span> (<span class="param">me</span> for the caller), <span class="param">team</span>, <span class="param">status</span>, <span class="param">risk</span>, <span class="param">due_before</span> or <span class="param">due_within</span>, and <span class="param">overdue=true</span>. Closed cases are left out unless a status is given. Requires the <span class="param">analyst</span> role.</div>
+        <div class="example">curl "http://localhost:8080/cases/queue?assignee=me&due_within=48h"</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/cases/{name}/assignment</span>
+        <div class="description">Current assignee, team, risk rating and SLA of a case, with every (re)assignment it went through.</div>
+        <div class="example">curl http://localhost:8080/cases/BLACKROCK-GLOBAL-EQUITY/assignment</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">POST</span><span class="path">/cases/{name}/assignment</span>
+        <div class="description">Assigns or reassigns a case; fields left out keep their value. <span class="param">sla</span> sets the due date relative to now. Taking a case from another analyst requires the <span class="param">reviewer</span> role.</div>
+        <div class="example">curl -X POST http://localhost:8080/cases/BLACKROCK-GLOBAL-EQUITY/assignment -d '{"assignee":"jdoe","team":"onboarding-emea","risk_rating":"HIGH","sla":"72h"}'</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/cases/{name}/diff?from=3&amp;to=5</span>
+        <div class="description">Semantic diff between two versions of a case: documents, attributes, policies and ownership entries added, removed or changed, each with its DSL form before and after. <span class="param">to</span> defaults to the latest version and <span class="param">from</span> to the one before it. Requires the <span class="param">analyst</span> role.</div>
+        <div class="example">curl "http://localhost:8080/cases/BLACKROCK-GLOBAL-EQUITY/diff?from=3&amp;to=5"</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/cases/{name}/signatures?version=2</span>
+        <div class="description">Verifies the signatures made when a case was approved (<span class="param">signing.enabled</span>): each must match its signed statement, come from a trusted key and still describe the stored snapshot. <span class="param">version</span> limits them to one version. Requires the <span class="param">analyst</span> role.</div>
+        <div class="example">curl "http://localhost:8080/cases/BLACKROCK-GLOBAL-EQUITY/signatures"</div>
+    </div>
+
+    <h2>📒 Case Data Dictionary</h2>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/cases/{name}/dictionary</span>
+        <div class="description">Attribute values of a case. Entries with <span class="param">derived</span> set were materialized from a lineage evaluation and carry its <span class="param">evaluation_id</span>, rule and regulation. Requires the <span class="param">analyst</span> role.</div>
+        <div class="example">curl http://localhost:8080/cases/BLACKROCK-GLOBAL-EQUITY/dictionary</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">POST</span><span class="path">/cases/{name}/dictionary/materialize</span>
+        <div class="description">Copies the latest successful evaluation of each derived attribute of the case into its data dictionary. Values captured directly are never overwritten. Set <span class="param">reevaluation.materialize</span> to do this on every re-evaluation. Requires the <span class="param">analyst</span> role.</div>
+        <div class="example">curl -X POST http://localhost:8080/cases/BLACKROCK-GLOBAL-EQUITY/dictionary/materialize</div>
+    </div>
+
+    <h2>🤖 Agent Registry</h2>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/agents</span>
+        <div class="description">Registered agents with their type, owner, model, quota tier, bound principal and allowed endpoint prefixes. Requires the <span class="param">analyst</span> role.</div>
+        <div class="example">curl http://localhost:8080/agents</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">POST</span><span class="path">/agents</span>
+        <div class="description">
+            Registers an agent. When authentication is enabled, requests sending <span class="param">X-Agent-Name</span> must name an active registered agent, bound to the caller if it has a <span class="param">subject</span>, and may only call its <span class="param">allowed_endpoints</span>; callers authenticating as a bound subject act as that agent. Requires the <span class="param">admin</span> role.
+            <br><strong>Body Parameters (JSON):</strong>
+            <br>• <span class="param">name</span>, <span class="param">owner</span> (required)
+            <br>• <span class="param">agent_type</span> (optional) - human/ai/automated (default: ai)
+            <br>• <span class="param">subject</span> (optional) - JWT sub or api-key:&lt;fingerprint&gt; allowed to act as the agent
+            <br>• <span class="param">allowed_endpoints</span> (optional) - Path prefixes (default: all)
+            <br>• <span class="param">model_provider</span>, <span class="param">model_name</span>, <span class="param">model_version</span> (optional)
+            <br>• <span class="param">quota_tier</span> (optional) - basic/standard/premium/unlimited (default: standard)
+        </div>
+        <div class="example">curl -X POST http://localhost:8080/agents -d '{"name":"kyc-copilot","owner":"onboarding-team","model_provider":"openai","model_name":"gpt-4o","allowed_endpoints":["/rag/"],"quota_tier":"premium"}'</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/agents/{name}</span>
+        <div class="description">An agent with its feedback, audit and search activity. PUT replaces its details (set <span class="param">status</span> to suspended to block it); DELETE removes it from the registry, keeping the rows recorded under its name. Changes require the <span class="param">admin</span> role.</div>
+        <div class="example">curl http://localhost:8080/agents/kyc-copilot</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/agents/analytics</span>
+        <div class="description">Feedback counts and confidence, audited queries, errors and latency, and search hits per agent name, joined with the registry. <span class="param">registered=true</span> drops unregistered names. Requires the <span class="param">reviewer</span> role.</div>
+        <div class="example">curl "http://localhost:8080/agents/analytics?registered=true"</div>
+    </div>
+
+    <h2>🎚️ Feedback Policy</h2>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/rag/feedback/policy</span>
+        <div class="description">
+            The policy that decides what feedback does, per tenant. Negative feedback at or above <span class="param">min_confidence</span> from a registered, active agent (listed in <span class="param">trusted_agents</span> when that list is not empty) demotes the attribute for that query by <span class="param">demote_penalty</span>, up to <span class="param">max_penalty</span>, and flags its metadata for review after <span class="param">flag_after</span> such feedbacks. Other feedback only accumulates. Tenants without a policy inherit the <span class="param">default</span> one. The tenant comes from the token's tenant claim or the <span class="param">X-Tenant-ID</span> header. PUT replaces a tenant's policy and requires the <span class="param">admin</span> role.
+            <br><strong>Parameters:</strong>
+            <br>• <span class="param">tenant</span> (optional) - Tenant (default: the caller's); * lists every policy
+        </div>
+        <div class="example">curl -X PUT http://localhost:8080/rag/feedback/policy -H "Content-Type: application/json" -d '{"tenant":"acme","min_confidence":0.9,"trusted_agents":["kyc-copilot"],"demote":true,"demote_penalty":0.05,"max_penalty":0.3,"flag":true,"flag_after":3}'</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/rag/feedback/actions</span>
+        <div class="description">
+            Demotions and review flags raised by gated feedback, newest first. Requires the <span class="param">reviewer</span> role.
+            <br><strong>Parameters:</strong>
+            <br>• <span class="param">tenant</span> (optional) - Tenant
+            <br>• <span class="param">action</span> (optional) - demote, flag
+            <br>• <span class="param">status</span> (optional) - open, resolved
+            <br>• <span class="param">limit</span> (optional) - Max actions (default: 50)
+        </div>
+        <div class="example">curl "http://localhost:8080/rag/feedback/actions?action=flag&status=open"</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">POST</span><span class="path">/rag/feedback/actions/{id}/resolve</span>
+        <div class="description">Resolves an action. Resolving a demotion lifts the attribute's penalty for that query; resolving a flag restarts the count towards the next one.</div>
+        <div class="example">curl -X POST http://localhost:8080/rag/feedback/actions/12/resolve</div>
+    </div>
+
+    <h2>🔏 Model I/O Log</h2>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/rag/model_log</span>
+        <div class="description">
+            Prompts and model responses of the generation features (narrative polishing, cluster labels), recorded when <span class="param">model_log.enabled</span> is set. Entries are redacted before they are stored and expire after <span class="param">model_log.retention</span>. Since model I/O may contain client data, this endpoint requires authentication and the <span class="param">admin</span> role, and every read is logged.
+            <br><strong>Parameters:</strong>
+            <br>• <span class="param">feature</span> (optional) - narrative_polish, cluster_labels
+            <br>• <span class="param">agent</span> (optional) - Agent the exchange was made for
+            <br>• <span class="param">since</span> (optional) - RFC 3339 timestamp
+            <br>• <span class="param">limit</span> (optional) - Max entries (default: 50, max: 500)
+        </div>
+        <div class="example">curl -H "X-API-Key: $ADMIN_KEY" "http://localhost:8080/rag/model_log?feature=narrative_polish&limit=10"</div>
+    </div>
+
+    <h2>📘 DSL Grammar</h2>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path">/dsl/grammar/help</span>
+        <div class="description">
+            Documentation of every grammar construct: its EBNF production, purpose, fields, where it may appear, examples and a Markdown <span class="param">hover</span> text for editors. Generated from the grammar stored by <span class="param">kycctl grammar</span> (or the Rust engine) plus the annotations in internal/grammar/annotations.yaml.
+            <br><strong>Parameters:</strong>
+            <br>• <span class="param">keyword</span> (optional) - A single construct, e.g. ownership-structure
+        </div>
+        <div class="example">curl "http://localhost:8080/dsl/grammar/help?keyword=ownership-structure"</div>
+    </div>
+
+    <h2>✏️ DSL Editor</h2>
+
+    <div class="endpoint">
+        <span class="method">GET</span><span class="path"><a href="/ui/editor">/ui/editor</a></span>
+        <div class="description">
+            Browser editor for case DSL. Edits are parsed and validated as you type with inline diagnostics; Format re-serializes through the Rust engine; a valid edit can be proposed as an amendment for review.
+            <br><strong>Parameters:</strong>
+            <br>• <span class="param">case</span> (optional) - Case to load for editing
+        </div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">POST</span><span class="path">/dsl/check</span>
+        <div class="description">
+            Parse and validate DSL; returns the case names and diagnostics with severity, code, line and column.
+        </div>
+        <div class="example">curl -X POST http://localhost:8080/dsl/check -d '{"dsl": "(kyc-case DEMO (kyc-token \"pending\"))"}'</div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">POST</span><span class="path">/dsl/format</span>
+        <div class="description">
+            Canonical layout of DSL via the Rust engine's Parse/Serialize. DSL with forms the engine cannot serialize is returned unchanged with a FORMAT_LOSSY warning.
+        </div>
+    </div>
+
+    <div class="endpoint">
+        <span class="method">POST</span><span class="path">/dsl/proposals</span>
+        <div class="description">
+            Propose edited DSL as an amendment of a case (analyst). It must parse and validate. A reviewer accepts it with <span class="param">POST /dsl/proposals/{id}/accept</span>, saving it as the next case version, or rejects it with <span class="param">/reject</span>. Accepting fails with 409 when the case changed since the proposal was made. <span class="param">GET /dsl/proposals?status=pending</span> lists proposals.
+        </div>
+        <div class="example">curl -X POST http://localhost:8080/dsl/proposals -d '{"case": "AVIVA-EU-EQUITY-FUND", "dsl": "...", "note": "Add UBO declaration"}'</div>
+    </div>
+
     <h2>📖 Example Queries</h2>
 </text>
 
@@ -313,34 +1432,14 @@ func handleRoot(w http.ResponseWriter, r *http.Request) {
 </html>`)
 }
 
-// loggingMiddleware logs HTTP requests
-func loggingMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		start := time.Now()
-
-		// Create a custom response writer to capture status code
-		rw := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
-
-		next.ServeHTTP(rw, r)
-
-		duration := time.Since(start)
-		log.Printf("%s %s %d %v %s",
-			r.Method,
-			r.RequestURI,
-			rw.statusCode,
-			duration,
-			r.RemoteAddr,
-		)
-	})
-}
-
 // corsMiddleware adds CORS headers
 func corsMiddleware(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Set CORS headers
 		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key, X-Request-ID")
+		w.Header().Set("Access-Control-Expose-Headers", "X-Request-ID")
 
 		// Handle preflight requests
 		if r.Method == "OPTIONS" {
@@ -352,22 +1451,31 @@ func corsMiddleware(next http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
-// responseWriter wraps http.ResponseWriter to capture status code
-type responseWriter struct {
-	http.ResponseWriter
-	statusCode int
+// registerGraphRoutes registers the ownership graph endpoints. The UBO
+// rollup names owners and their percentages, so callers need analyst.
+func registerGraphRoutes(mux *http.ServeMux, ragHandler *api.RagHandler, requireAnalyst func(http.HandlerFunc) http.HandlerFunc) {
+	mux.HandleFunc("/graph/ubo", corsMiddleware(requireAnalyst(ragHandler.HandleUboRollup)))
 }
 
-// WriteHeader captures the status code
-func (rw *responseWriter) WriteHeader(code int) {
-	rw.statusCode = code
-	rw.ResponseWriter.WriteHeader(code)
+// withParam guards requests carrying a query parameter; others go straight
+// to next
+func withParam(param string, guard func(http.HandlerFunc) http.HandlerFunc, next http.HandlerFunc) http.HandlerFunc {
+	guarded := guard(next)
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get(param) != "" {
+			guarded(w, r)
+			return
+		}
+		next(w, r)
+	}
 }
 
-// Write ensures status code is captured even if WriteHeader isn't called
-func (rw *responseWriter) Write(b []byte) (int, error) {
-	if rw.statusCode == 0 {
-		rw.statusCode = http.StatusOK
+// fatal logs a startup failure and exits
+func fatal(msg string, err error) {
+	if err != nil {
+		slog.Error(msg, "error", err)
+	} else {
+		slog.Error(msg)
 	}
-	return rw.ResponseWriter.Write(b)
+	os.Exit(1)
 }
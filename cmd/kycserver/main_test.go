@@ -0,0 +1,28 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/adamtc007/KYC-DSL/internal/api"
+	"github.com/adamtc007/KYC-DSL/internal/auth"
+)
+
+func TestUboRollupRequiresAnalyst(t *testing.T) {
+	authn, err := auth.NewAuthenticator(context.Background(), auth.Config{
+		APIKeys: map[string]auth.Role{"analyst-key": auth.RoleAnalyst},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	registerGraphRoutes(mux, &api.RagHandler{}, authn.Require(auth.RoleAnalyst))
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/graph/ubo?entity=ent-1", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("anonymous GET /graph/ubo: status %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
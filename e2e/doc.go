@@ -0,0 +1,16 @@
+// Package e2e holds the end-to-end scenario tests. They build kycctl,
+// dataserver and kycserver, boot the servers against a throwaway database,
+// drive one case through creation (gRPC), amendment, validation, assignment
+// and lifecycle, run RAG searches and feedback (HTTP), and assert on the
+// stored versions and audit records in Postgres.
+//
+// The tests only build with the e2e tag:
+//
+//	go test -tags e2e -count=1 ./e2e [-keep-db]
+//
+// They need Postgres (PGHOST, PGPORT, PGUSER, PGPASSWORD) and the Rust DSL
+// service, running on RUST_DSL_SERVICE_ADDR or built with make rust-build.
+// E2E_DATABASE names the test database, dropped and recreated (kyc_dsl_e2e);
+// E2E_HTTP_PORT and E2E_GRPC_PORT the ports of kycserver (18080) and
+// dataserver (50170). Semantic search only runs when OPENAI_API_KEY is set.
+package e2e
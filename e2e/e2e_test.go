@@ -0,0 +1,548 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/jmoiron/sqlx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/adamtc007/KYC-DSL/api/pb"
+	pbdata "github.com/adamtc007/KYC-DSL/api/pb/kycdata"
+	"github.com/adamtc007/KYC-DSL/internal/actor"
+)
+
+var keepDB = flag.Bool("keep-db", false, "keep the test database after the run")
+
+// Identities the scenarios act as
+const (
+	runner   = "e2e-runner"
+	agent    = "e2e-agent"
+	analyst  = "e2e-analyst"
+	intruder = "someone-else"
+)
+
+// The test environment, set up once by TestMain
+var (
+	root     string // repository root
+	work     string // binaries and server logs
+	database string
+	httpURL  string
+	grpcAddr string
+	rustAddr string
+	semantic bool
+	procEnv  []string
+	procs    []*exec.Cmd
+	db       *sqlx.DB
+)
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	os.Exit(run(m))
+}
+
+// run sets up the environment, runs the tests and tears the environment
+// down, keeping the server logs when a test failed
+func run(m *testing.M) (code int) {
+	var err error
+	if root, err = filepath.Abs(".."); err != nil {
+		fmt.Fprintln(os.Stderr, "❌", err)
+		return 1
+	}
+	if work, err = os.MkdirTemp("", "kyc-e2e-"); err != nil {
+		fmt.Fprintln(os.Stderr, "❌", err)
+		return 1
+	}
+	defer func() {
+		teardown()
+		if code != 0 {
+			fmt.Fprintln(os.Stderr, "Server logs:", work)
+			return
+		}
+		os.RemoveAll(work)
+	}()
+
+	if err := setup(); err != nil {
+		fmt.Fprintln(os.Stderr, "❌", err)
+		return 1
+	}
+	return m.Run()
+}
+
+func setup() error {
+	database = envOr("E2E_DATABASE", "kyc_dsl_e2e")
+	httpPort := envOr("E2E_HTTP_PORT", "18080")
+	grpcPort, err := strconv.Atoi(envOr("E2E_GRPC_PORT", "50170"))
+	if err != nil {
+		return fmt.Errorf("invalid E2E_GRPC_PORT: %w", err)
+	}
+	httpURL = "http://localhost:" + httpPort
+	grpcAddr = fmt.Sprintf("localhost:%d", grpcPort)
+	rustAddr = envOr("RUST_DSL_SERVICE_ADDR", "localhost:50060")
+
+	// Every process runs against the test database only
+	for _, kv := range os.Environ() {
+		if !strings.HasPrefix(kv, "KYC_CONFIG=") {
+			procEnv = append(procEnv, kv)
+		}
+	}
+	procEnv = append(procEnv,
+		"DATABASE_URL="+databaseURL(database),
+		"RUST_DSL_SERVICE_ADDR="+rustAddr,
+		fmt.Sprintf("DATA_SERVICE_LISTEN_ADDR=:%d", grpcPort),
+		"DATA_SERVICE_ADDR="+grpcAddr,
+		fmt.Sprintf("METRICS_ADDR=:%d", grpcPort+1),
+		"PORT="+httpPort,
+	)
+	semantic = os.Getenv("OPENAI_API_KEY") != ""
+	if !semantic {
+		// kycserver refuses to start without a key; only semantic search uses it
+		procEnv = append(procEnv, "OPENAI_API_KEY=e2e-no-key")
+	}
+
+	for _, name := range []string{"kycctl", "dataserver", "kycserver"} {
+		build := exec.Command("go", "build", "-o", filepath.Join(work, name), "./cmd/"+name)
+		build.Dir = root
+		if out, err := build.CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to build %s: %w\n%s", name, err, out)
+		}
+	}
+
+	if err := recreateDatabase(); err != nil {
+		return err
+	}
+	demo := exec.Command(filepath.Join(work, "kycctl"), "demo", "up", "--skip-embeddings")
+	demo.Dir, demo.Env = root, procEnv
+	if out, err := demo.CombinedOutput(); err != nil {
+		return fmt.Errorf("schema and ontology setup failed: %w\n%s", err, out)
+	}
+
+	if waitForPort(rustAddr, time.Second) != nil {
+		binary := filepath.Join(root, "rust", "target", "release", "kyc_dsl_service")
+		if _, err := os.Stat(binary); err != nil {
+			return fmt.Errorf("Rust DSL service not running on %s and not built (make rust-build)", rustAddr)
+		}
+		if err := start(binary, filepath.Join(root, "rust"), rustAddr); err != nil {
+			return err
+		}
+	}
+	if err := start(filepath.Join(work, "dataserver"), root, grpcAddr); err != nil {
+		return err
+	}
+	if err := start(filepath.Join(work, "kycserver"), root, "localhost:"+httpPort); err != nil {
+		return err
+	}
+
+	db, err = sqlx.Connect("pgx", databaseURL(database))
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", database, err)
+	}
+	return nil
+}
+
+// teardown stops the servers and drops the test database
+func teardown() {
+	for _, cmd := range procs {
+		cmd.Process.Signal(os.Interrupt)
+		cmd.Wait()
+	}
+	if db != nil {
+		db.Close()
+	}
+	if !*keepDB {
+		if maint, err := sqlx.Connect("pgx", databaseURL("postgres")); err == nil {
+			maint.Exec("DROP DATABASE IF EXISTS " + pgx.Identifier{database}.Sanitize())
+			maint.Close()
+		}
+	}
+}
+
+// recreateDatabase drops and creates the test database
+func recreateDatabase() error {
+	maint, err := sqlx.Connect("pgx", databaseURL("postgres"))
+	if err != nil {
+		return fmt.Errorf("failed to connect to postgres: %w", err)
+	}
+	defer maint.Close()
+	name := pgx.Identifier{database}.Sanitize()
+	if _, err := maint.Exec("DROP DATABASE IF EXISTS " + name); err != nil {
+		return fmt.Errorf("failed to drop %s: %w", database, err)
+	}
+	if _, err := maint.Exec("CREATE DATABASE " + name); err != nil {
+		return fmt.Errorf("failed to create %s: %w", database, err)
+	}
+	return nil
+}
+
+// databaseURL addresses a database of the server named by the PG*
+// variables; the password, if any, comes from PGPASSWORD
+func databaseURL(name string) string {
+	return fmt.Sprintf("postgres://%s@%s:%s/%s?sslmode=disable",
+		envOr("PGUSER", os.Getenv("USER")), envOr("PGHOST", "localhost"), envOr("PGPORT", "5432"), name)
+}
+
+// start runs a server with its output in work and waits for it to listen on addr
+func start(binary, dir, addr string) error {
+	name := filepath.Base(binary)
+	log, err := os.Create(filepath.Join(work, name+".log"))
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(binary)
+	cmd.Dir, cmd.Env, cmd.Stdout, cmd.Stderr = dir, procEnv, log, log
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start %s: %w", name, err)
+	}
+	procs = append(procs, cmd)
+	if err := waitForPort(addr, 30*time.Second); err != nil {
+		return fmt.Errorf("%s failed to start, see %s: %w", name, log.Name(), err)
+	}
+	return nil
+}
+
+// waitForPort waits until addr accepts connections
+func waitForPort(addr string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		conn, err := net.DialTimeout("tcp", addr, time.Second)
+		if err == nil {
+			return conn.Close()
+		}
+		if time.Now().After(deadline) {
+			return err
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
+
+func envOr(name, def string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return def
+}
+
+// kycctl runs kycctl as the test runner and returns its combined output
+func kycctl(args ...string) (string, error) {
+	cmd := exec.Command(filepath.Join(work, "kycctl"), args...)
+	cmd.Dir, cmd.Env = root, append(slices.Clone(procEnv), "USER="+runner)
+	out, err := cmd.CombinedOutput()
+	return string(out), err
+}
+
+// call returns a context for one gRPC call made by the test runner
+func call(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	t.Cleanup(cancel)
+	return metadata.AppendToOutgoingContext(ctx, actor.MetadataKey, runner)
+}
+
+// request makes an HTTP request as the test runner and returns the status
+// and body of the response
+func request(t *testing.T, method, path string, body any) (int, string) {
+	t.Helper()
+	var payload io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		payload = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, httpURL+path, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(actor.Header, runner)
+	req.Header.Set("X-Agent-Name", agent)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, string(data)
+}
+
+// expectOK fails the test unless the response succeeded and its body
+// contains want
+func expectOK(t *testing.T, code int, body, want string) {
+	t.Helper()
+	if code != http.StatusOK {
+		t.Fatalf("status %d: %s", code, body)
+	}
+	if !strings.Contains(body, want) {
+		t.Fatalf("response lacks %q: %s", want, body)
+	}
+}
+
+// expectOutput fails the test unless a kycctl run succeeded (or, with
+// fails, failed) and its output contains want
+func expectOutput(t *testing.T, out string, err error, fails bool, want string) {
+	t.Helper()
+	if fails != (err != nil) {
+		t.Fatalf("kycctl error = %v, want failure %t:\n%s", err, fails, out)
+	}
+	if !strings.Contains(out, want) {
+		t.Fatalf("kycctl output lacks %q:\n%s", want, out)
+	}
+}
+
+// TestCaseLifecycle drives one case from creation to review over gRPC,
+// kycctl and HTTP, then checks what was stored. The steps build on each
+// other and run in order.
+func TestCaseLifecycle(t *testing.T) {
+	caseName := fmt.Sprintf("E2E-CASE-%d", time.Now().Unix())
+	sample, err := os.ReadFile(filepath.Join(root, "sample_case.dsl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dsl := strings.Replace(string(sample), "(kyc-case AVIVA-EU-EQUITY-FUND", "(kyc-case "+caseName, 1)
+
+	conn, err := grpc.NewClient(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	cases := pb.NewKycCaseServiceClient(conn)
+	lifecycle := pbdata.NewCaseServiceClient(conn)
+
+	// Case creation (gRPC)
+
+	t.Run("CreateCase stores version 1", func(t *testing.T) {
+		created, err := cases.CreateCase(call(t), &pb.CreateCaseRequest{Dsl: dsl})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if created.Version != 1 {
+			t.Fatalf("version = %d, want 1", created.Version)
+		}
+	})
+
+	t.Run("CreateCase refuses an existing case", func(t *testing.T) {
+		_, err := cases.CreateCase(call(t), &pb.CreateCaseRequest{Dsl: dsl})
+		if status.Code(err) != codes.AlreadyExists {
+			t.Fatalf("error = %v, want AlreadyExists", err)
+		}
+	})
+
+	t.Run("New case starts as a draft", func(t *testing.T) {
+		timeline, err := lifecycle.GetCaseTimeline(call(t), &pbdata.GetCaseTimelineRequest{CaseId: caseName})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if timeline.Status != "draft" {
+			t.Fatalf("status = %q, want draft", timeline.Status)
+		}
+	})
+
+	// Amendments and validation
+
+	t.Run("Amend: policy-discovery (Rust)", func(t *testing.T) {
+		out, err := kycctl("amend", caseName, "--step=policy-discovery", "--holder="+runner)
+		expectOutput(t, out, err, false, "applied successfully")
+	})
+
+	t.Run("Amend: document-discovery (ontology)", func(t *testing.T) {
+		out, err := kycctl("amend", caseName, "--step=document-discovery", "--holder="+runner)
+		expectOutput(t, out, err, false, "applied successfully")
+	})
+
+	t.Run("Amendment refused while another holder locks the case", func(t *testing.T) {
+		if out, err := kycctl("lock", "acquire", caseName, "--holder="+intruder); err != nil {
+			t.Fatalf("lock acquire: %v\n%s", err, out)
+		}
+		t.Cleanup(func() {
+			if out, err := kycctl("lock", "release", caseName, "--holder="+intruder, "--force"); err != nil {
+				t.Errorf("lock release: %v\n%s", err, out)
+			}
+		})
+		out, err := kycctl("amend", caseName, "--step=risk-assessment", "--holder="+runner)
+		expectOutput(t, out, err, true, "amendment refused")
+	})
+
+	t.Run("Validate moves the draft to validated", func(t *testing.T) {
+		out, err := kycctl("validate", caseName)
+		expectOutput(t, out, err, false, "now validated")
+	})
+
+	t.Run("GetCaseVersions streams the amended versions", func(t *testing.T) {
+		stream, err := cases.GetCaseVersions(call(t), &pb.GetCaseVersionsRequest{CaseName: caseName})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var versions []int32
+		for {
+			v, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			versions = append(versions, v.Version)
+		}
+		if !slices.Contains(versions, 2) {
+			t.Fatalf("versions = %v, want version 2", versions)
+		}
+	})
+
+	// Assignment and lifecycle
+
+	t.Run("AssignCase (gRPC)", func(t *testing.T) {
+		assignment, err := cases.AssignCase(call(t), &pb.AssignCaseRequest{
+			CaseName:   caseName,
+			Assignee:   analyst,
+			RiskRating: "HIGH",
+			Reason:     "e2e",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if assignment.Assignee != analyst {
+			t.Fatalf("assignee = %q, want %q", assignment.Assignee, analyst)
+		}
+	})
+
+	t.Run("Case appears in the analyst's queue (HTTP)", func(t *testing.T) {
+		code, body := request(t, http.MethodGet, "/cases/queue?assignee="+analyst, nil)
+		expectOK(t, code, body, caseName)
+	})
+
+	t.Run("Transition to in-review (HTTP)", func(t *testing.T) {
+		code, body := request(t, http.MethodPost, "/cases/"+caseName+"/transition",
+			map[string]string{"status": "in-review", "reason": "e2e"})
+		expectOK(t, code, body, "in-review")
+	})
+
+	t.Run("Timeline records every transition (gRPC)", func(t *testing.T) {
+		timeline, err := lifecycle.GetCaseTimeline(call(t), &pbdata.GetCaseTimelineRequest{CaseId: caseName})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var statuses []string
+		for _, tr := range timeline.Transitions {
+			statuses = append(statuses, tr.ToStatus)
+		}
+		if want := []string{"draft", "validated", "in-review"}; !slices.Equal(statuses, want) {
+			t.Fatalf("transitions = %v, want %v", statuses, want)
+		}
+	})
+
+	// RAG search and feedback (HTTP)
+
+	t.Run("Health", func(t *testing.T) {
+		code, body := request(t, http.MethodGet, "/rag/health", nil)
+		expectOK(t, code, body, "healthy")
+	})
+
+	t.Run("Text search", func(t *testing.T) {
+		code, body := request(t, http.MethodGet, "/rag/text_search?term=REGISTERED", nil)
+		expectOK(t, code, body, "REGISTERED_NAME")
+	})
+
+	t.Run("Attribute lookup", func(t *testing.T) {
+		code, body := request(t, http.MethodGet, "/rag/attribute/REGISTERED_NAME", nil)
+		expectOK(t, code, body, "REGISTERED_NAME")
+	})
+
+	t.Run("Semantic attribute search", func(t *testing.T) {
+		if !semantic {
+			t.Skip("OPENAI_API_KEY not set")
+		}
+		code, body := request(t, http.MethodGet, "/rag/attribute_search?q=company+legal+name&limit=5", nil)
+		expectOK(t, code, body, "results")
+	})
+
+	t.Run("Submit feedback", func(t *testing.T) {
+		code, body := request(t, http.MethodPost, "/rag/feedback", map[string]any{
+			"query_text":     caseName + " legal name",
+			"attribute_code": "REGISTERED_NAME",
+			"feedback":       "positive",
+			"confidence":     0.9,
+		})
+		expectOK(t, code, body, `"status"`)
+	})
+
+	// Stored versions and audit records (Postgres)
+
+	for _, check := range []struct {
+		name  string
+		query string
+		args  []any
+	}{
+		{"Case row created as recorded by the gRPC caller",
+			`SELECT actor = $2 AND actor_source = 'grpc' FROM kyc_cases WHERE name = $1`,
+			[]any{caseName, runner}},
+		{"Version 1 is the DSL submitted over gRPC",
+			`SELECT dsl_snapshot LIKE '%' || $1 || '%' AND actor_source = 'grpc'
+			   FROM kyc_case_versions WHERE case_name = $1 AND version = 1`,
+			[]any{caseName}},
+		{"Amendments stored new versions",
+			`SELECT COUNT(*) >= 2 FROM kyc_case_versions WHERE case_name = $1`,
+			[]any{caseName}},
+		{"Amendments logged with their actor",
+			`SELECT COUNT(*) >= 1 AND bool_and(actor = $2) FROM kyc_case_amendments WHERE case_name = $1`,
+			[]any{caseName, runner}},
+		{"No amendment recorded for the refused step",
+			`SELECT COUNT(*) = 0 FROM kyc_case_amendments WHERE case_name = $1 AND step = 'risk-assessment'`,
+			[]any{caseName}},
+		{"Lifecycle transitions recorded",
+			`SELECT array_agg(to_status ORDER BY id) = ARRAY['draft', 'validated', 'in-review']
+			   FROM kyc_case_transitions WHERE case_name = $1`,
+			[]any{caseName}},
+		{"Assignment history recorded",
+			`SELECT COUNT(*) = 1 FROM kyc_case_assignment_history WHERE case_name = $1 AND to_assignee = $2`,
+			[]any{caseName, analyst}},
+		{"Search hits recorded for usage analytics",
+			`SELECT COUNT(*) >= 2 FROM kyc_ontology_search_hits WHERE term_code = 'REGISTERED_NAME' AND agent_name = $1`,
+			[]any{agent}},
+		{"Feedback stored",
+			`SELECT COUNT(*) = 1 FROM rag_feedback WHERE query_text = $1 AND attribute_code = 'REGISTERED_NAME'`,
+			[]any{caseName + " legal name"}},
+	} {
+		t.Run(check.name, func(t *testing.T) {
+			// Search hits are recorded asynchronously, so allow a moment
+			deadline := time.Now().Add(5 * time.Second)
+			for {
+				var ok bool
+				if err := db.Get(&ok, check.query, check.args...); err != nil {
+					t.Fatal(err)
+				}
+				if ok {
+					return
+				}
+				if time.Now().After(deadline) {
+					t.Fatal("stored records do not match")
+				}
+				time.Sleep(250 * time.Millisecond)
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+// Package actor identifies who makes a change, through which channel and
+// from where. The HTTP middleware, the gRPC interceptors and the CLI put an
+// Actor in the request context, and mutating repository calls store it
+// next to the row they write (actor, actor_source, client_ip), so every
+// mutation can be attributed the same way whatever path it came through.
+package actor
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/adamtc007/KYC-DSL/internal/auth"
+)
+
+// Channels a change can come through
+const (
+	SourceCLI    = "cli"
+	SourceGRPC   = "grpc"
+	SourceHTTP   = "http"
+	SourceSystem = "system"
+)
+
+// Header and metadata keys naming the actor of an unauthenticated request
+const (
+	Header      = "X-Actor"
+	MetadataKey = "x-actor"
+)
+
+// Actor is who made a change, how and from where
+type Actor struct {
+	Name     string
+	Source   string
+	ClientIP string
+}
+
+// System is the actor of background jobs and other unattended changes
+func System() Actor {
+	return Actor{Name: "system", Source: SourceSystem}
+}
+
+// CLI is the actor of kycctl commands: the operating system user
+func CLI() Actor {
+	name := os.Getenv("USER")
+	if name == "" {
+		name = "cli"
+	}
+	return Actor{Name: name, Source: SourceCLI}
+}
+
+type actorKey struct{}
+
+// With attaches an actor to the context
+func With(ctx context.Context, a Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, a)
+}
+
+// FromContext returns the actor of a request. The subject of an
+// authenticated principal (internal/auth) takes precedence over the name
+// recorded when the request arrived, since authentication runs later in the
+// handler chain. Contexts without an actor belong to System.
+func FromContext(ctx context.Context) Actor {
+	a, ok := ctx.Value(actorKey{}).(Actor)
+	if !ok {
+		a = System()
+	}
+	if p, ok := auth.PrincipalFromContext(ctx); ok && p.Subject != "" {
+		a.Name = p.Subject
+	}
+	return a
+}
+
+// HTTPMiddleware records the actor of each request: the X-Actor header (or
+// "anonymous") until authentication names the principal, and the client IP
+// (ClientIP)
+func HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimSpace(r.Header.Get(Header))
+		if name == "" {
+			name = "anonymous"
+		}
+		ctx := With(r.Context(), Actor{Name: name, Source: SourceHTTP, ClientIP: ClientIP(r)})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// UnaryServerInterceptor records the actor of each call: the x-actor
+// metadata sent by the client (or "anonymous") and the peer address, or the
+// client named in x-forwarded-for when the peer is a trusted proxy
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(grpcContext(ctx), req)
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of UnaryServerInterceptor
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &actorStream{ServerStream: ss, ctx: grpcContext(ss.Context())})
+	}
+}
+
+// UnaryClientInterceptor forwards the name of the actor in ctx to the called
+// service
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if a, ok := ctx.Value(actorKey{}).(Actor); ok && a.Name != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, MetadataKey, a.Name)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor is the streaming counterpart of
+// UnaryClientInterceptor
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if a, ok := ctx.Value(actorKey{}).(Actor); ok && a.Name != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, MetadataKey, a.Name)
+		}
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
+
+func grpcContext(ctx context.Context) context.Context {
+	a := Actor{Name: "anonymous", Source: SourceGRPC}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(MetadataKey); len(values) > 0 && strings.TrimSpace(values[0]) != "" {
+		a.Name = strings.TrimSpace(values[0])
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		a.ClientIP = hostOf(p.Addr.String())
+	}
+	if trustedProxy(a.ClientIP) {
+		if values := md.Get("x-forwarded-for"); len(values) > 0 {
+			a.ClientIP = clientOf(strings.Join(values, ","), a.ClientIP)
+		}
+	}
+	return With(ctx, a)
+}
+
+// ClientIP returns the client address of a request: the connection's peer,
+// or, when the peer is a trusted proxy, the client it names in
+// X-Forwarded-For or X-Real-IP
+func ClientIP(r *http.Request) string {
+	ip := hostOf(r.RemoteAddr)
+	if !trustedProxy(ip) {
+		return ip
+	}
+	if forwarded := r.Header.Values("X-Forwarded-For"); len(forwarded) > 0 {
+		return clientOf(strings.Join(forwarded, ","), ip)
+	}
+	if real := strings.TrimSpace(r.Header.Get("X-Real-IP")); net.ParseIP(real) != nil {
+		return real
+	}
+	return ip
+}
+
+// clientOf returns the client of an X-Forwarded-For list sent by a trusted
+// proxy: the rightmost entry that is not itself a trusted proxy, since the
+// entries to its left were written by the client and can be forged. peer is
+// returned when no entry qualifies.
+func clientOf(list, peer string) string {
+	hops := strings.Split(list, ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if net.ParseIP(hop) == nil {
+			return peer
+		}
+		if !trustedProxy(hop) {
+			return hop
+		}
+	}
+	return peer
+}
+
+// trusted holds the proxies set by TrustProxies
+var trusted atomic.Pointer[[]*net.IPNet]
+
+// TrustProxies sets the proxies (IPs or CIDRs, server.trusted_proxies) whose
+// forwarded headers and metadata name the client. Without any, forwarded
+// headers are ignored.
+func TrustProxies(proxies []string) error {
+	nets := make([]*net.IPNet, 0, len(proxies))
+	for _, proxy := range proxies {
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return fmt.Errorf("trusted proxy %q is not an IP or CIDR", proxy)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, n, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return fmt.Errorf("trusted proxy %q is not an IP or CIDR", proxy)
+		}
+		nets = append(nets, n)
+	}
+	trusted.Store(&nets)
+	return nil
+}
+
+func trustedProxy(addr string) bool {
+	nets := trusted.Load()
+	ip := net.ParseIP(addr)
+	if nets == nil || ip == nil {
+		return false
+	}
+	for _, n := range *nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func hostOf(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
+
+// actorStream overrides the stream context to carry the actor
+type actorStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *actorStream) Context() context.Context {
+	return s.ctx
+}
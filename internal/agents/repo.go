@@ -0,0 +1,279 @@
+// Package agents is the registry of API agents: who owns them, what kind of
+// caller they are, which model they run, their quota tier and the endpoints
+// they may call. Authenticated requests acting as an agent are checked
+// against it, and agent analytics join feedback and audit rows on its names.
+package agents
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+var (
+	// ErrNotFound is returned for an unregistered agent
+	ErrNotFound = errors.New("agent not found")
+	// ErrInvalid is returned for an agent that cannot be saved
+	ErrInvalid = errors.New("invalid agent")
+	// ErrExists is returned when registering a name twice
+	ErrExists = errors.New("agent already registered")
+)
+
+// validName matches agent names (also sent in the X-Agent-Name header)
+var validName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._:@-]{0,99}$`)
+
+// Repo stores registered agents
+type Repo struct {
+	db *sqlx.DB
+}
+
+// NewRepo creates a new agent repository
+func NewRepo(db *sqlx.DB) *Repo {
+	return &Repo{db: db}
+}
+
+// agentRow scans a kyc_agents row
+type agentRow struct {
+	ID               int            `db:"id"`
+	Name             string         `db:"name"`
+	AgentType        string         `db:"agent_type"`
+	Owner            string         `db:"owner"`
+	Description      string         `db:"description"`
+	Subject          string         `db:"subject"`
+	AllowedEndpoints pq.StringArray `db:"allowed_endpoints"`
+	ModelProvider    string         `db:"model_provider"`
+	ModelName        string         `db:"model_name"`
+	ModelVersion     string         `db:"model_version"`
+	QuotaTier        string         `db:"quota_tier"`
+	Status           string         `db:"status"`
+	RegisteredBy     string         `db:"registered_by"`
+	CreatedAt        time.Time      `db:"created_at"`
+	UpdatedAt        time.Time      `db:"updated_at"`
+	LastSeenAt       *time.Time     `db:"last_seen_at"`
+}
+
+func (r agentRow) toModel() model.Agent {
+	endpoints := []string(r.AllowedEndpoints)
+	if endpoints == nil {
+		endpoints = []string{}
+	}
+	return model.Agent{
+		ID:               r.ID,
+		Name:             r.Name,
+		Type:             model.AgentType(r.AgentType),
+		Owner:            r.Owner,
+		Description:      r.Description,
+		Subject:          r.Subject,
+		AllowedEndpoints: endpoints,
+		ModelProvider:    r.ModelProvider,
+		ModelName:        r.ModelName,
+		ModelVersion:     r.ModelVersion,
+		QuotaTier:        r.QuotaTier,
+		Status:           r.Status,
+		RegisteredBy:     r.RegisteredBy,
+		CreatedAt:        r.CreatedAt,
+		UpdatedAt:        r.UpdatedAt,
+		LastSeenAt:       r.LastSeenAt,
+	}
+}
+
+const agentColumns = `
+	id, name, agent_type, owner, COALESCE(description, '') AS description,
+	COALESCE(subject, '') AS subject, allowed_endpoints,
+	COALESCE(model_provider, '') AS model_provider, COALESCE(model_name, '') AS model_name,
+	COALESCE(model_version, '') AS model_version, quota_tier, status,
+	COALESCE(registered_by, '') AS registered_by, created_at, updated_at, last_seen_at
+`
+
+// Validate checks and normalizes an agent before it is saved
+func Validate(a *model.Agent) error {
+	a.Name = strings.TrimSpace(a.Name)
+	a.Owner = strings.TrimSpace(a.Owner)
+	if !validName.MatchString(a.Name) {
+		return fmt.Errorf("%w: name must be 1-100 letters, digits or ._:@- characters", ErrInvalid)
+	}
+	if a.Owner == "" {
+		return fmt.Errorf("%w: owner is required", ErrInvalid)
+	}
+	switch a.Type {
+	case "":
+		a.Type = model.AgentTypeAI
+	case model.AgentTypeHuman, model.AgentTypeAI, model.AgentTypeAutomated:
+	default:
+		return fmt.Errorf("%w: agent_type must be human, ai or automated", ErrInvalid)
+	}
+	switch a.QuotaTier {
+	case "":
+		a.QuotaTier = model.QuotaTierStandard
+	case model.QuotaTierBasic, model.QuotaTierStandard, model.QuotaTierPremium, model.QuotaTierUnlimited:
+	default:
+		return fmt.Errorf("%w: quota_tier must be basic, standard, premium or unlimited", ErrInvalid)
+	}
+	switch a.Status {
+	case "":
+		a.Status = model.AgentStatusActive
+	case model.AgentStatusActive, model.AgentStatusSuspended:
+	default:
+		return fmt.Errorf("%w: status must be active or suspended", ErrInvalid)
+	}
+	endpoints := make([]string, 0, len(a.AllowedEndpoints))
+	for _, e := range a.AllowedEndpoints {
+		e = strings.TrimSpace(e)
+		if !strings.HasPrefix(e, "/") {
+			return fmt.Errorf("%w: allowed endpoint %q must start with /", ErrInvalid, e)
+		}
+		endpoints = append(endpoints, e)
+	}
+	a.AllowedEndpoints = endpoints
+	return nil
+}
+
+// List returns all registered agents by name
+func (r *Repo) List(ctx context.Context) ([]model.Agent, error) {
+	var rows []agentRow
+	if err := r.db.SelectContext(ctx, &rows, `SELECT `+agentColumns+` FROM kyc_agents ORDER BY name`); err != nil {
+		return nil, fmt.Errorf("failed to list agents: %w", err)
+	}
+	agents := make([]model.Agent, 0, len(rows))
+	for _, row := range rows {
+		agents = append(agents, row.toModel())
+	}
+	return agents, nil
+}
+
+// Get returns a registered agent by name
+func (r *Repo) Get(ctx context.Context, name string) (*model.Agent, error) {
+	return r.getBy(ctx, "name", name)
+}
+
+// GetBySubject returns the agent bound to an authenticated subject, or
+// ErrNotFound when none is
+func (r *Repo) GetBySubject(ctx context.Context, subject string) (*model.Agent, error) {
+	return r.getBy(ctx, "subject", subject)
+}
+
+func (r *Repo) getBy(ctx context.Context, column, value string) (*model.Agent, error) {
+	var row agentRow
+	err := r.db.GetContext(ctx, &row,
+		`SELECT `+agentColumns+` FROM kyc_agents WHERE `+column+` = $1 ORDER BY id LIMIT 1`, value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, value)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get agent: %w", err)
+	}
+	agent := row.toModel()
+	return &agent, nil
+}
+
+// Register adds an agent
+func (r *Repo) Register(ctx context.Context, a model.Agent) (*model.Agent, error) {
+	if err := Validate(&a); err != nil {
+		return nil, err
+	}
+	var row agentRow
+	err := r.db.GetContext(ctx, &row, `
+		INSERT INTO kyc_agents
+			(name, agent_type, owner, description, subject, allowed_endpoints,
+			 model_provider, model_name, model_version, quota_tier, status, registered_by)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6, NULLIF($7, ''), NULLIF($8, ''),
+		        NULLIF($9, ''), $10, $11, NULLIF($12, ''))
+		ON CONFLICT (name) DO NOTHING
+		RETURNING `+agentColumns,
+		a.Name, string(a.Type), a.Owner, a.Description, a.Subject, pq.Array(a.AllowedEndpoints),
+		a.ModelProvider, a.ModelName, a.ModelVersion, a.QuotaTier, a.Status, a.RegisteredBy)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ErrExists, a.Name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to register agent: %w", err)
+	}
+	agent := row.toModel()
+	return &agent, nil
+}
+
+// Update replaces the details of a registered agent; the name, creation and
+// registration fields are kept
+func (r *Repo) Update(ctx context.Context, a model.Agent) (*model.Agent, error) {
+	if err := Validate(&a); err != nil {
+		return nil, err
+	}
+	var row agentRow
+	err := r.db.GetContext(ctx, &row, `
+		UPDATE kyc_agents
+		   SET agent_type = $2, owner = $3, description = NULLIF($4, ''), subject = NULLIF($5, ''),
+		       allowed_endpoints = $6, model_provider = NULLIF($7, ''), model_name = NULLIF($8, ''),
+		       model_version = NULLIF($9, ''), quota_tier = $10, status = $11,
+		       updated_at = CURRENT_TIMESTAMP
+		 WHERE name = $1
+		RETURNING `+agentColumns,
+		a.Name, string(a.Type), a.Owner, a.Description, a.Subject, pq.Array(a.AllowedEndpoints),
+		a.ModelProvider, a.ModelName, a.ModelVersion, a.QuotaTier, a.Status)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, a.Name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update agent: %w", err)
+	}
+	agent := row.toModel()
+	return &agent, nil
+}
+
+// Delete removes an agent from the registry; rows recorded under its name
+// are kept and show up as unregistered in analytics
+func (r *Repo) Delete(ctx context.Context, name string) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM kyc_agents WHERE name = $1`, name)
+	if err != nil {
+		return fmt.Errorf("failed to delete agent: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	return nil
+}
+
+// Touch records that an agent made a request
+func (r *Repo) Touch(ctx context.Context, name string, at time.Time) error {
+	if _, err := r.db.ExecContext(ctx, `UPDATE kyc_agents SET last_seen_at = $2 WHERE name = $1`, name, at); err != nil {
+		return fmt.Errorf("failed to record agent activity: %w", err)
+	}
+	return nil
+}
+
+// Analytics returns the activity of every agent name seen in feedback, audit
+// and search hit rows, joined with the registry; registeredOnly drops names
+// that are not registered
+func (r *Repo) Analytics(ctx context.Context, registeredOnly bool) ([]model.AgentAnalytics, error) {
+	query := `SELECT * FROM v_agent_analytics`
+	if registeredOnly {
+		query += ` WHERE registered`
+	}
+	query += ` ORDER BY last_activity_at DESC NULLS LAST, agent_name`
+	var rows []model.AgentAnalytics
+	if err := r.db.SelectContext(ctx, &rows, query); err != nil {
+		return nil, fmt.Errorf("failed to load agent analytics: %w", err)
+	}
+	return rows, nil
+}
+
+// AnalyticsFor returns the activity of one agent name
+func (r *Repo) AnalyticsFor(ctx context.Context, name string) (*model.AgentAnalytics, error) {
+	var row model.AgentAnalytics
+	err := r.db.GetContext(ctx, &row, `SELECT * FROM v_agent_analytics WHERE agent_name = $1`, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &model.AgentAnalytics{AgentName: name}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load agent analytics: %w", err)
+	}
+	return &row, nil
+}
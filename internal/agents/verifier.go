@@ -0,0 +1,153 @@
+package agents
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/adamtc007/KYC-DSL/internal/auth"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+// Registry lookups are cached briefly so enforcement adds no query to most
+// requests; registry changes take effect within cacheTTL
+const (
+	cacheTTL      = 30 * time.Second
+	touchInterval = time.Minute
+)
+
+// Verifier enforces the registry on authenticated requests. It implements
+// auth.AgentVerifier.
+type Verifier struct {
+	repo *Repo
+
+	mu       sync.Mutex
+	byName   map[string]cached
+	bySubj   map[string]cached
+	lastSeen map[string]time.Time
+}
+
+type cached struct {
+	agent   *model.Agent // nil when not registered
+	fetched time.Time
+}
+
+// NewVerifier creates a verifier over the registry
+func NewVerifier(repo *Repo) *Verifier {
+	return &Verifier{
+		repo:     repo,
+		byName:   map[string]cached{},
+		bySubj:   map[string]cached{},
+		lastSeen: map[string]time.Time{},
+	}
+}
+
+// VerifyAgent checks that the principal may act as the named agent and that
+// the agent may call endpoint. Without a name, a principal bound to an agent
+// acts as that agent; other principals act as no agent. A named agent must be
+// registered and active, and bound to the principal if it is bound at all.
+func (v *Verifier) VerifyAgent(ctx context.Context, p *auth.Principal, name, endpoint string) (string, error) {
+	var agent *model.Agent
+	var err error
+	if name = strings.TrimSpace(name); name != "" {
+		agent, err = v.lookup(ctx, v.byName, name, v.repo.Get)
+		if err != nil {
+			return "", err
+		}
+		if agent == nil {
+			return "", fmt.Errorf("%w: agent %q is not registered", auth.ErrAgentDenied, name)
+		}
+		if agent.Subject != "" && agent.Subject != p.Subject {
+			return "", fmt.Errorf("%w: agent %q is bound to another principal", auth.ErrAgentDenied, name)
+		}
+	} else {
+		if p.Subject == "" {
+			return "", nil
+		}
+		agent, err = v.lookup(ctx, v.bySubj, p.Subject, v.repo.GetBySubject)
+		if err != nil || agent == nil {
+			return "", err
+		}
+	}
+
+	if agent.Status != model.AgentStatusActive {
+		return "", fmt.Errorf("%w: agent %q is %s", auth.ErrAgentDenied, agent.Name, agent.Status)
+	}
+	if !Allows(agent, endpoint) {
+		return "", fmt.Errorf("%w: agent %q may not call %s", auth.ErrAgentDenied, agent.Name, endpoint)
+	}
+	v.touch(agent.Name)
+	return agent.Name, nil
+}
+
+// Allows reports whether an endpoint is within the prefixes an agent may call
+func Allows(a *model.Agent, endpoint string) bool {
+	if len(a.AllowedEndpoints) == 0 {
+		return true
+	}
+	for _, prefix := range a.AllowedEndpoints {
+		if endpoint == prefix || strings.HasPrefix(endpoint, strings.TrimSuffix(prefix, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// Invalidate drops cached lookups so registry changes apply immediately in
+// this process
+func (v *Verifier) Invalidate() {
+	if v == nil {
+		return
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	clear(v.byName)
+	clear(v.bySubj)
+}
+
+func (v *Verifier) lookup(ctx context.Context, cache map[string]cached, key string,
+	get func(context.Context, string) (*model.Agent, error)) (*model.Agent, error) {
+	v.mu.Lock()
+	c, ok := cache[key]
+	v.mu.Unlock()
+	if ok && time.Since(c.fetched) < cacheTTL {
+		return c.agent, nil
+	}
+
+	agent, err := get(ctx, key)
+	if errors.Is(err, ErrNotFound) {
+		agent, err = nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("agent registry unavailable: %w", err)
+	}
+	v.mu.Lock()
+	cache[key] = cached{agent: agent, fetched: time.Now()}
+	v.mu.Unlock()
+	return agent, nil
+}
+
+// touch records agent activity at most once per touchInterval, in the
+// background
+func (v *Verifier) touch(name string) {
+	now := time.Now()
+	v.mu.Lock()
+	if now.Sub(v.lastSeen[name]) < touchInterval {
+		v.mu.Unlock()
+		return
+	}
+	v.lastSeen[name] = now
+	v.mu.Unlock()
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := v.repo.Touch(ctx, name, now); err != nil {
+			slog.Warn("⚠️  Failed to record agent activity", "agent", name, "error", err)
+		}
+	}()
+}
@@ -1,10 +1,11 @@
 package amend
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
-	pb "github.com/adamtc007/KYC-DSL/api/pb"
+	"github.com/adamtc007/KYC-DSL/internal/events"
 	"github.com/adamtc007/KYC-DSL/internal/model"
 	"github.com/adamtc007/KYC-DSL/internal/rustclient"
 	"github.com/adamtc007/KYC-DSL/internal/storage"
@@ -13,7 +14,10 @@ import (
 
 // ApplyAmendment loads the latest case version, applies a mutation, and saves the new version.
 // For most amendments, this delegates to the Rust DSL service via gRPC.
-// For ontology-aware amendments (like document-discovery), it uses local mutation functions.
+// For ontology-aware amendments (policy-discovery, document-discovery), it uses local mutation functions.
+//
+// The actor in ctx (internal/actor) is recorded with the new version and
+// the amendment.
 //
 // Flow:
 //  1. Load latest serialized DSL from database
@@ -21,7 +25,7 @@ import (
 //  3. Validate the result
 //  4. Save as next version
 //  5. Log amendment
-func ApplyAmendment(db *sqlx.DB, caseName string, step string, mutationFn func(*model.KycCase)) error {
+func ApplyAmendment(ctx context.Context, db *sqlx.DB, caseName string, step string, mutationFn func(*model.KycCase)) error {
 	// Step 1: Load latest version
 	latestVersion, err := getLatestVersion(db, caseName)
 	if err != nil {
@@ -44,19 +48,25 @@ func ApplyAmendment(db *sqlx.DB, caseName string, step string, mutationFn func(*
 		if err != nil || !parseResp.Success {
 			return fmt.Errorf("failed to parse DSL: %w", err)
 		}
+		// The Go parser's cases hold the typed sections only; serializing a
+		// mutation of one would drop the rest of the case
+		if rustclient.ParsedLocally(parseResp) {
+			return fmt.Errorf("failed to parse DSL: %w", rustclient.ErrUnavailable)
+		}
 
 		if len(parseResp.Cases) == 0 {
 			return fmt.Errorf("no cases found in DSL")
 		}
 
-		// Convert proto case to model (simplified - in real impl would need full conversion)
-		kycCase := protoToModelCase(parseResp.Cases[0])
+		kycCase := CaseFromProto(parseResp.Cases[0])
 
 		// Apply local mutation
 		mutationFn(kycCase)
 
-		// Serialize back via Rust
-		serializeResp, err := rustClient.SerializeCase(parseResp.Cases[0])
+		// Serialize the mutated case back via Rust
+		mutated := CaseToProto(kycCase)
+		keepAttributeSources(parseResp.Cases[0], mutated)
+		serializeResp, err := rustClient.SerializeCase(mutated)
 		if err != nil || !serializeResp.Success {
 			return fmt.Errorf("failed to serialize case: %w", err)
 		}
@@ -65,7 +75,23 @@ func ApplyAmendment(db *sqlx.DB, caseName string, step string, mutationFn func(*
 
 		// Validate
 		valResult, err := rustClient.ValidateDSL(newSnapshot)
+		if err == nil && rustclient.ValidatedLocally(valResult) {
+			return fmt.Errorf("validation failed after amendment: %w", rustclient.ErrUnavailable)
+		}
 		if err != nil || !valResult.Valid {
+			if err == nil {
+				events.Notify(ctx, db, events.Event{
+					Type:     events.ValidationFailed,
+					CaseName: caseName,
+					Version:  latestVersion.Version,
+					Hash:     latestVersion.Hash,
+					Data: map[string]interface{}{
+						"step":          step,
+						"errors":        valResult.Errors,
+						"rejected_hash": storage.CanonicalHash(newSnapshot),
+					},
+				})
+			}
 			return fmt.Errorf("validation failed after amendment: %v", valResult.Errors)
 		}
 
@@ -73,13 +99,13 @@ func ApplyAmendment(db *sqlx.DB, caseName string, step string, mutationFn func(*
 		diff := generateSimpleDiff(oldSnapshot, newSnapshot)
 
 		// Save new version
-		if err := storage.SaveCaseVersion(db, caseName, newSnapshot); err != nil {
+		if err := storage.SaveCaseVersion(ctx, db, caseName, newSnapshot); err != nil {
 			return fmt.Errorf("failed to save new version: %w", err)
 		}
 
 		// Log amendment
 		changeType := detectChangeType(kycCase, step)
-		if err := storage.InsertAmendment(db, caseName, step, changeType, diff); err != nil {
+		if err := storage.InsertAmendment(ctx, db, caseName, step, changeType, diff); err != nil {
 			return fmt.Errorf("failed to log amendment: %w", err)
 		}
 
@@ -108,13 +134,13 @@ func ApplyAmendment(db *sqlx.DB, caseName string, step string, mutationFn func(*
 	diff := generateSimpleDiff(oldSnapshot, newSnapshot)
 
 	// Save new version
-	if err := storage.SaveCaseVersion(db, caseName, newSnapshot); err != nil {
+	if err := storage.SaveCaseVersion(ctx, db, caseName, newSnapshot); err != nil {
 		return fmt.Errorf("failed to save new version: %w", err)
 	}
 
 	// Log amendment
 	changeType := step // Use step as change type for Rust-applied amendments
-	if err := storage.InsertAmendment(db, caseName, step, changeType, diff); err != nil {
+	if err := storage.InsertAmendment(ctx, db, caseName, step, changeType, diff); err != nil {
 		return fmt.Errorf("failed to log amendment: %w", err)
 	}
 
@@ -212,16 +238,3 @@ func detectChangeType(kycCase *model.KycCase, step string) string {
 		return "generic-amendment"
 	}
 }
-
-// protoToModelCase converts a proto ParsedCase to internal KycCase model
-// This is a simplified conversion - in production would need full field mapping
-func protoToModelCase(protoCase *pb.ParsedCase) *model.KycCase {
-	kycCase := &model.KycCase{
-		Name:    protoCase.Name,
-		Nature:  protoCase.Nature,
-		Purpose: protoCase.Purpose,
-	}
-
-	// Add more field mappings as needed
-	return kycCase
-}
@@ -2,51 +2,99 @@ package amend
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/adamtc007/KYC-DSL/internal/model"
 	"github.com/adamtc007/KYC-DSL/internal/ontology"
 )
 
-// AddDocumentDiscovery performs ontology-aware document discovery
-// This function queries the regulatory ontology database to automatically
-// populate document requirements and data dictionary mappings based on
-// jurisdiction and applicable regulations.
-//
-// This is the only Go-side mutation function still used. All other amendments
-// (policy-discovery, document-solicitation, ownership-discovery, risk-assessment,
-// approve, decline, review) are now handled by the Rust DSL service.
-func AddDocumentDiscovery(c *model.KycCase, repo *ontology.Repository) error {
-	fmt.Println("🔍 Performing document discovery based on jurisdiction and regulation...")
-
-	// Pull documents from ontology DB based on regulation
-	docs, err := repo.ListDocumentsByRegulation("AMLD5")
-	if err != nil {
-		return fmt.Errorf("failed to retrieve documents from ontology: %w", err)
+// SourceFinder finds the documents that evidence an attribute, by tier:
+// the global ontology (ontology.Repository) or a tenant's overlay over it
+// (overlay.Resolver)
+type SourceFinder interface {
+	GetDocumentSources(attributeCode string) ([]ontology.AttributeDocumentLink, error)
+}
+
+// AddPolicyDiscovery performs policy discovery from jurisdiction policy
+// packs (internal/policypack): it adds the DISCOVER-POLICIES function and,
+// for each pack, its policy and obligations, skipping those the case
+// already has.
+func AddPolicyDiscovery(c *model.KycCase, packs []model.PolicyPack) error {
+	if len(packs) == 0 {
+		return fmt.Errorf("no active policy packs apply to case %s", c.Name)
 	}
+	fmt.Println("🔍 Performing policy discovery from jurisdiction policy packs...")
+
+	if !hasFunction(c, "DISCOVER-POLICIES") {
+		c.Functions = append(c.Functions, model.Function{Action: "DISCOVER-POLICIES"})
+	}
+	policies, obligations := 0, 0
+	for _, p := range packs {
+		if p.PolicyCode != "" && !hasPolicy(c, p.PolicyCode) {
+			c.Policies = append(c.Policies, model.KycPolicy{Code: p.PolicyCode})
+			policies++
+		}
+		for _, o := range p.Obligations {
+			if !hasObligation(c, o) {
+				c.Obligations = append(c.Obligations, model.KycObligation{PolicyCode: o})
+				obligations++
+			}
+		}
+		fmt.Printf("   📦 %s v%d (%s)\n", p.Code, p.Version, p.Jurisdiction)
+	}
+
+	fmt.Printf("✅ Added %d policies and %d obligations\n", policies, obligations)
+	return nil
+}
 
-	// Create document requirement for EU jurisdiction
-	dr := model.DocumentRequirement{Jurisdiction: "EU"}
-	for _, d := range docs {
-		dr.Documents = append(dr.Documents, model.DocumentRef{
-			Code: d.Code,
-			Name: d.Name,
-		})
+// AddDocumentDiscovery performs ontology-aware document discovery from
+// jurisdiction policy packs (internal/policypack): each pack's mandatory
+// documents become document requirements for its jurisdiction, and its
+// attributes data dictionary entries sourced from the documents the
+// regulatory ontology, as resolved by sources, links them to. Documents and attributes the case
+// already has are kept as they are.
+//
+// Policy and document discovery are the Go-side mutation functions, as
+// they read the database. All other amendments (document-solicitation,
+// ownership-discovery, risk-assessment, approve, decline, review) are
+// handled by the Rust DSL service.
+func AddDocumentDiscovery(c *model.KycCase, sources SourceFinder, packs []model.PolicyPack) error {
+	if len(packs) == 0 {
+		return fmt.Errorf("no active policy packs apply to case %s", c.Name)
 	}
-	c.DocumentRequirements = append(c.DocumentRequirements, dr)
-
-	// Add sample data dictionary links from ontology for key attributes
-	// Query the ontology for attribute-document mappings
-	attrCodes := []string{"UBO_NAME", "REGISTERED_NAME", "TAX_RESIDENCY_COUNTRY"}
-	for _, attrCode := range attrCodes {
-		attrDocs, err := repo.GetDocumentSources(attrCode)
-		if err != nil {
-			fmt.Printf("Warning: failed to get document sources for %s: %v\n", attrCode, err)
-			continue
+	fmt.Println("🔍 Performing document discovery from jurisdiction policy packs...")
+
+	documents, entries := 0, 0
+	for _, p := range packs {
+		i := requirementFor(c, p.Jurisdiction)
+		for _, d := range p.Documents {
+			if !d.Mandatory || hasDocument(c.DocumentRequirements[i], d.Code) {
+				continue
+			}
+			name := d.Name
+			if name == "" {
+				name = d.Code
+			}
+			c.DocumentRequirements[i].Documents = append(c.DocumentRequirements[i].Documents,
+				model.DocumentRef{Code: d.Code, Name: name})
+			documents++
 		}
 
-		if len(attrDocs) > 0 {
+		for _, a := range p.Attributes {
+			if hasAttribute(c, a.Code) {
+				continue
+			}
+			// Query the ontology for attribute-document mappings
+			attrDocs, err := sources.GetDocumentSources(a.Code)
+			if err != nil {
+				fmt.Printf("Warning: failed to get document sources for %s: %v\n", a.Code, err)
+				continue
+			}
+			if len(attrDocs) == 0 {
+				continue
+			}
 			src := model.AttributeSource{
-				AttributeCode: attrCode,
+				AttributeCode: a.Code,
 			}
 			// Assign sources based on tier
 			for _, link := range attrDocs {
@@ -66,11 +114,68 @@ func AddDocumentDiscovery(c *model.KycCase, repo *ontology.Repository) error {
 				}
 			}
 			c.DataDictionary = append(c.DataDictionary, src)
+			entries++
 		}
+		fmt.Printf("   📦 %s v%d (%s)\n", p.Code, p.Version, p.Jurisdiction)
 	}
 
-	fmt.Printf("✅ Added %d document requirements and %d data dictionary entries\n",
-		len(dr.Documents), len(c.DataDictionary))
-
+	fmt.Printf("✅ Added %d document requirements and %d data dictionary entries\n", documents, entries)
 	return nil
 }
+
+// requirementFor returns the index of the case's document requirements
+// for a jurisdiction, adding empty ones when it has none
+func requirementFor(c *model.KycCase, jurisdiction string) int {
+	for i, dr := range c.DocumentRequirements {
+		if strings.EqualFold(dr.Jurisdiction, jurisdiction) {
+			return i
+		}
+	}
+	c.DocumentRequirements = append(c.DocumentRequirements, model.DocumentRequirement{Jurisdiction: jurisdiction})
+	return len(c.DocumentRequirements) - 1
+}
+
+func hasDocument(dr model.DocumentRequirement, code string) bool {
+	for _, d := range dr.Documents {
+		if d.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAttribute(c *model.KycCase, code string) bool {
+	for _, a := range c.DataDictionary {
+		if a.AttributeCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+func hasFunction(c *model.KycCase, action string) bool {
+	for _, f := range c.Functions {
+		if f.Action == action {
+			return true
+		}
+	}
+	return false
+}
+
+func hasPolicy(c *model.KycCase, code string) bool {
+	for _, p := range c.Policies {
+		if p.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+func hasObligation(c *model.KycCase, code string) bool {
+	for _, o := range c.Obligations {
+		if o.PolicyCode == code {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+package amend
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/adamtc007/KYC-DSL/internal/engine"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+var (
+	// ErrProposalNotFound is returned for an unknown proposal id
+	ErrProposalNotFound = errors.New("amendment proposal not found")
+	// ErrProposalReviewed is returned when a proposal was already accepted or rejected
+	ErrProposalReviewed = errors.New("amendment proposal already reviewed")
+	// ErrProposalStale is returned when the case gained a version after the
+	// proposal was made; the editor must reload and propose again
+	ErrProposalStale = errors.New("case changed since the proposal was made")
+	// ErrNoChanges is returned when the proposed DSL matches the latest version
+	ErrNoChanges = errors.New("proposed DSL is unchanged from the latest version")
+)
+
+const proposalColumns = `id, case_name, base_version, dsl, COALESCE(diff, '') AS diff,
+	COALESCE(note, '') AS note, status, COALESCE(proposed_by, '') AS proposed_by,
+	COALESCE(reviewed_by, '') AS reviewed_by, COALESCE(review_comment, '') AS review_comment,
+	accepted_version, created_at, reviewed_at`
+
+// Propose records edited DSL for a case as a pending amendment against the
+// case's latest version. The DSL is not applied until a reviewer accepts it.
+func Propose(ctx context.Context, db *sqlx.DB, caseName, dsl, note, proposedBy string) (*model.AmendmentProposal, error) {
+	if caseName == "" {
+		return nil, fmt.Errorf("case name is required")
+	}
+	if dsl == "" {
+		return nil, fmt.Errorf("dsl is required")
+	}
+
+	baseVersion, oldSnapshot := 0, ""
+	latest, err := getLatestVersion(db, caseName)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		return nil, err
+	default:
+		if storage.CanonicalHash(latest.DslSnapshot) == storage.CanonicalHash(dsl) {
+			return nil, ErrNoChanges
+		}
+		baseVersion, oldSnapshot = latest.Version, latest.DslSnapshot
+	}
+
+	var p model.AmendmentProposal
+	err = db.GetContext(ctx, &p, `
+		INSERT INTO case_amendment_proposals (case_name, base_version, dsl, diff, note, proposed_by)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''))
+		RETURNING `+proposalColumns,
+		caseName, baseVersion, dsl, generateSimpleDiff(oldSnapshot, dsl), note, proposedBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record amendment proposal: %w", err)
+	}
+	return &p, nil
+}
+
+// ListProposals returns proposals with status (all when empty), optionally
+// for one case, newest first
+func ListProposals(ctx context.Context, db *sqlx.DB, caseName string, status model.AmendmentProposalStatus, limit int) ([]model.AmendmentProposal, error) {
+	proposals := []model.AmendmentProposal{}
+	err := db.SelectContext(ctx, &proposals, `
+		SELECT `+proposalColumns+`
+		FROM case_amendment_proposals
+		WHERE ($1 = '' OR case_name = $1) AND ($2 = '' OR status = $2)
+		ORDER BY created_at DESC
+		LIMIT $3`, caseName, string(status), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list amendment proposals: %w", err)
+	}
+	return proposals, nil
+}
+
+// GetProposal returns a proposal by id
+func GetProposal(ctx context.Context, db *sqlx.DB, id int) (*model.AmendmentProposal, error) {
+	var p model.AmendmentProposal
+	err := db.GetContext(ctx, &p, `SELECT `+proposalColumns+` FROM case_amendment_proposals WHERE id = $1`, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %d", ErrProposalNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get amendment proposal %d: %w", id, err)
+	}
+	return &p, nil
+}
+
+// AcceptProposal saves a pending proposal's DSL as the next case version and
+// logs it as an editor amendment. It fails with ErrProposalStale when the
+// case has moved past the version the proposal was based on, and with
+// engine.ErrCaseLocked when someone else holds the case lock.
+func AcceptProposal(ctx context.Context, db *sqlx.DB, id int, reviewer, comment string) (*model.AmendmentProposal, error) {
+	p, err := GetProposal(ctx, db, id)
+	if err != nil {
+		return nil, err
+	}
+	if p.Status != model.AmendmentPending {
+		return nil, fmt.Errorf("%w: %d is %s", ErrProposalReviewed, id, p.Status)
+	}
+	next, err := storage.GetNextVersion(db, p.CaseName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next version: %w", err)
+	}
+	if next != p.BaseVersion+1 {
+		return nil, fmt.Errorf("%w: proposal %d is based on version %d, the case is at version %d",
+			ErrProposalStale, id, p.BaseVersion, next-1)
+	}
+
+	// The case may be locked (internal/engine) by the reviewer or by the
+	// analyst who proposed the change, but by no one else
+	locks := engine.NewLocks(db)
+	if err := locks.Check(ctx, p.CaseName, reviewer); err != nil {
+		if err := locks.Check(ctx, p.CaseName, p.ProposedBy); err != nil {
+			return nil, err
+		}
+	}
+
+	// Claim the proposal first so two reviewers cannot both apply it
+	res, err := db.ExecContext(ctx, `
+		UPDATE case_amendment_proposals
+		SET status = 'accepted', reviewed_by = NULLIF($2, ''), review_comment = NULLIF($3, ''),
+		    accepted_version = $4, reviewed_at = NOW()
+		WHERE id = $1 AND status = 'pending'`, id, reviewer, comment, next)
+	if err != nil {
+		return nil, fmt.Errorf("failed to accept amendment proposal %d: %w", id, err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, fmt.Errorf("%w: %d", ErrProposalReviewed, id)
+	}
+
+	if p.BaseVersion == 0 {
+		if err := storage.InsertCase(ctx, db, p.CaseName); err != nil {
+			return nil, fmt.Errorf("failed to create case %s: %w", p.CaseName, err)
+		}
+	}
+	if err := storage.InsertVersion(ctx, db, p.CaseName, next, p.DSL); err != nil {
+		return nil, fmt.Errorf("failed to save version %d of %s: %w", next, p.CaseName, err)
+	}
+	if err := storage.InsertAmendment(ctx, db, p.CaseName, "editor", "editor-proposal", p.Diff); err != nil {
+		return nil, fmt.Errorf("failed to log amendment: %w", err)
+	}
+
+	return GetProposal(ctx, db, id)
+}
+
+// RejectProposal closes a pending proposal without applying it
+func RejectProposal(ctx context.Context, db *sqlx.DB, id int, reviewer, comment string) (*model.AmendmentProposal, error) {
+	res, err := db.ExecContext(ctx, `
+		UPDATE case_amendment_proposals
+		SET status = 'rejected', reviewed_by = NULLIF($2, ''), review_comment = NULLIF($3, ''),
+		    reviewed_at = NOW()
+		WHERE id = $1 AND status = 'pending'`, id, reviewer, comment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reject amendment proposal %d: %w", id, err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		if _, err := GetProposal(ctx, db, id); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %d", ErrProposalReviewed, id)
+	}
+	return GetProposal(ctx, db, id)
+}
@@ -0,0 +1,227 @@
+package amend
+
+import (
+	pb "github.com/adamtc007/KYC-DSL/api/pb"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+// CaseFromProto converts a case parsed by the Rust service into the model.
+// The repeated fields are read when set; cases from older services only
+// carry the singular policy, function, obligation and document
+// requirements. A data-dictionary attribute keeps its first source of each
+// tier, as the model holds one.
+func CaseFromProto(p *pb.ParsedCase) *model.KycCase {
+	c := &model.KycCase{
+		Name:    p.Name,
+		Nature:  p.Nature,
+		Purpose: p.Purpose,
+		CBU:     model.ClientBusinessUnit{Name: p.ClientBusinessUnit},
+	}
+
+	policies := p.Policies
+	if len(policies) == 0 && p.Policy != "" {
+		policies = []string{p.Policy}
+	}
+	for _, code := range policies {
+		c.Policies = append(c.Policies, model.KycPolicy{Code: code})
+	}
+
+	obligations := p.Obligations
+	if len(obligations) == 0 && p.Obligation != "" {
+		obligations = []string{p.Obligation}
+	}
+	for _, code := range obligations {
+		c.Obligations = append(c.Obligations, model.KycObligation{PolicyCode: code})
+	}
+
+	functions := p.Functions
+	if len(functions) == 0 && p.Function != "" {
+		functions = []*pb.CaseFunction{{Action: p.Function}}
+	}
+	for _, f := range functions {
+		c.Functions = append(c.Functions, model.Function{Action: f.Action, Status: model.CaseStatus(f.Status)})
+	}
+
+	if p.KycToken != "" {
+		c.Token = &model.KycToken{Status: p.KycToken}
+	}
+
+	if o := p.Ownership; o != nil {
+		c.Ownership = ownershipFromProto(o)
+	}
+
+	for _, a := range p.DataDictionary.GetAttributes() {
+		c.DataDictionary = append(c.DataDictionary, model.AttributeSource{
+			AttributeCode:   a.Code,
+			PrimarySource:   first(a.PrimarySources),
+			SecondarySource: first(a.SecondarySources),
+			TertiarySource:  first(a.TertiarySources),
+		})
+	}
+
+	requirements := p.DocumentRequirementSets
+	if len(requirements) == 0 && p.DocumentRequirements != nil {
+		requirements = []*pb.DocumentRequirements{p.DocumentRequirements}
+	}
+	for _, r := range requirements {
+		req := model.DocumentRequirement{Jurisdiction: r.Jurisdiction}
+		for _, d := range r.Required {
+			req.Documents = append(req.Documents, model.DocumentRef{Code: d.Code, Name: d.Name})
+		}
+		c.DocumentRequirements = append(c.DocumentRequirements, req)
+	}
+
+	for _, d := range p.DerivedAttributes {
+		c.DerivedAttributes = append(c.DerivedAttributes, model.DerivedAttribute{
+			DerivedAttribute: d.Code,
+			SourceAttributes: d.SourceAttributes,
+			RuleExpression:   d.RuleExpression,
+			Jurisdiction:     d.Jurisdiction,
+			RegulationCode:   d.RegulationCode,
+		})
+	}
+	return c
+}
+
+// CaseToProto converts a model case into the structure the Rust service
+// serializes. The singular fields are filled from the first element of the
+// repeated ones so older services still see them.
+func CaseToProto(c *model.KycCase) *pb.ParsedCase {
+	p := &pb.ParsedCase{
+		Name:               c.Name,
+		Nature:             c.Nature,
+		Purpose:            c.Purpose,
+		ClientBusinessUnit: c.CBU.Name,
+	}
+
+	for _, pol := range c.Policies {
+		p.Policies = append(p.Policies, pol.Code)
+	}
+	p.Policy = first(p.Policies)
+
+	for _, obl := range c.Obligations {
+		p.Obligations = append(p.Obligations, obl.PolicyCode)
+	}
+	p.Obligation = first(p.Obligations)
+
+	for _, f := range c.Functions {
+		p.Functions = append(p.Functions, &pb.CaseFunction{Action: f.Action, Status: string(f.Status)})
+	}
+	if len(p.Functions) > 0 {
+		p.Function = p.Functions[0].Action
+	}
+
+	if c.Token != nil {
+		p.KycToken = c.Token.Status
+	}
+
+	if len(c.Ownership) > 0 {
+		p.Ownership = ownershipToProto(c.Ownership)
+	}
+
+	if len(c.DataDictionary) > 0 {
+		p.DataDictionary = &pb.DataDictionary{}
+		for _, a := range c.DataDictionary {
+			p.DataDictionary.Attributes = append(p.DataDictionary.Attributes, &pb.AttributeDefinition{
+				Code:             a.AttributeCode,
+				PrimarySources:   optional(a.PrimarySource),
+				SecondarySources: optional(a.SecondarySource),
+				TertiarySources:  optional(a.TertiarySource),
+			})
+		}
+	}
+
+	for _, r := range c.DocumentRequirements {
+		req := &pb.DocumentRequirements{Jurisdiction: r.Jurisdiction}
+		for _, d := range r.Documents {
+			req.Required = append(req.Required, &pb.DocumentRequirement{Code: d.Code, Name: d.Name})
+		}
+		p.DocumentRequirementSets = append(p.DocumentRequirementSets, req)
+	}
+	if len(p.DocumentRequirementSets) > 0 {
+		p.DocumentRequirements = p.DocumentRequirementSets[0]
+	}
+
+	for _, d := range c.DerivedAttributes {
+		p.DerivedAttributes = append(p.DerivedAttributes, &pb.DerivedAttributeDefinition{
+			Code:             d.DerivedAttribute,
+			SourceAttributes: d.SourceAttributes,
+			RuleExpression:   d.RuleExpression,
+			Jurisdiction:     d.Jurisdiction,
+			RegulationCode:   d.RegulationCode,
+		})
+	}
+	return p
+}
+
+// keepAttributeSources restores the sources beyond the first of each tier,
+// which the model drops, on the attributes of updated whose first sources
+// still match those in original
+func keepAttributeSources(original, updated *pb.ParsedCase) {
+	before := make(map[string]*pb.AttributeDefinition)
+	for _, a := range original.DataDictionary.GetAttributes() {
+		before[a.Code] = a
+	}
+	for i, a := range updated.DataDictionary.GetAttributes() {
+		old, ok := before[a.Code]
+		if ok && first(old.PrimarySources) == first(a.PrimarySources) &&
+			first(old.SecondarySources) == first(a.SecondarySources) &&
+			first(old.TertiarySources) == first(a.TertiarySources) {
+			updated.DataDictionary.Attributes[i] = old
+		}
+	}
+}
+
+// ownershipFromProto flattens an ownership structure into one node per
+// owner, beneficial owner and controller. A structure naming only its
+// entity becomes a single node.
+func ownershipFromProto(o *pb.OwnershipStructure) []model.OwnershipNode {
+	var nodes []model.OwnershipNode
+	for _, owner := range o.Owners {
+		nodes = append(nodes, model.OwnershipNode{Entity: o.EntityName, Owner: owner.Name, OwnershipPercent: float64(owner.Percentage)})
+	}
+	for _, bo := range o.BeneficialOwners {
+		nodes = append(nodes, model.OwnershipNode{Entity: o.EntityName, BeneficialOwner: bo.Name, OwnershipPercent: float64(bo.Percentage)})
+	}
+	for _, ctl := range o.Controllers {
+		nodes = append(nodes, model.OwnershipNode{Entity: o.EntityName, Controller: ctl.Name, Role: ctl.Role})
+	}
+	if len(nodes) == 0 && o.EntityName != "" {
+		nodes = append(nodes, model.OwnershipNode{Entity: o.EntityName})
+	}
+	return nodes
+}
+
+// ownershipToProto groups ownership nodes back into one structure; the
+// entity is the first one named
+func ownershipToProto(nodes []model.OwnershipNode) *pb.OwnershipStructure {
+	o := &pb.OwnershipStructure{}
+	for _, n := range nodes {
+		if o.EntityName == "" {
+			o.EntityName = n.Entity
+		}
+		switch {
+		case n.Owner != "":
+			o.Owners = append(o.Owners, &pb.Owner{Name: n.Owner, Percentage: float32(n.OwnershipPercent)})
+		case n.BeneficialOwner != "":
+			o.BeneficialOwners = append(o.BeneficialOwners, &pb.BeneficialOwner{Name: n.BeneficialOwner, Percentage: float32(n.OwnershipPercent)})
+		case n.Controller != "":
+			o.Controllers = append(o.Controllers, &pb.Controller{Name: n.Controller, Role: n.Role})
+		}
+	}
+	return o
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func optional(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return []string{value}
+}
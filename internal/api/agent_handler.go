@@ -0,0 +1,208 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/agents"
+	"github.com/adamtc007/KYC-DSL/internal/auth"
+	"github.com/adamtc007/KYC-DSL/internal/events"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+// AgentRegistration registers or updates an agent
+type AgentRegistration struct {
+	Name             string          `json:"name"`
+	AgentType        model.AgentType `json:"agent_type,omitempty"` // human, ai, automated (default: ai)
+	Owner            string          `json:"owner"`
+	Description      string          `json:"description,omitempty"`
+	Subject          string          `json:"subject,omitempty"`
+	AllowedEndpoints []string        `json:"allowed_endpoints,omitempty"`
+	ModelProvider    string          `json:"model_provider,omitempty"`
+	ModelName        string          `json:"model_name,omitempty"`
+	ModelVersion     string          `json:"model_version,omitempty"`
+	QuotaTier        string          `json:"quota_tier,omitempty"` // basic, standard, premium, unlimited
+	Status           string          `json:"status,omitempty"`     // active, suspended
+}
+
+func (reg AgentRegistration) toModel() model.Agent {
+	return model.Agent{
+		Name:             reg.Name,
+		Type:             reg.AgentType,
+		Owner:            reg.Owner,
+		Description:      reg.Description,
+		Subject:          reg.Subject,
+		AllowedEndpoints: reg.AllowedEndpoints,
+		ModelProvider:    reg.ModelProvider,
+		ModelName:        reg.ModelName,
+		ModelVersion:     reg.ModelVersion,
+		QuotaTier:        reg.QuotaTier,
+		Status:           reg.Status,
+	}
+}
+
+// HandleAgents lists registered agents or registers one (admin)
+// GET /agents | POST /agents
+func (h *RagHandler) HandleAgents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	repo := agents.NewRepo(h.DB)
+
+	switch r.Method {
+	case http.MethodGet:
+		all, err := repo.List(ctx)
+		if err != nil {
+			h.sendError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		h.sendJSON(w, http.StatusOK, map[string]interface{}{
+			"count":  len(all),
+			"agents": all,
+		})
+
+	case http.MethodPost:
+		registeredBy, ok := h.requireAgentAdmin(w, r)
+		if !ok {
+			return
+		}
+		var req AgentRegistration
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.sendError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+			return
+		}
+		agent := req.toModel()
+		agent.RegisteredBy = registeredBy
+		saved, err := repo.Register(ctx, agent)
+		if err != nil {
+			h.sendAgentError(w, err)
+			return
+		}
+		h.Agents.Invalidate()
+		events.NotifyAdminAction(ctx, h.DB, "agent.register", saved.Name, nil)
+		h.sendJSON(w, http.StatusCreated, saved)
+
+	default:
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// HandleAgent returns an agent with its activity, updates it or removes it
+// from the registry (admin)
+// GET /agents/<name> | PUT /agents/<name> | DELETE /agents/<name>
+func (h *RagHandler) HandleAgent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	repo := agents.NewRepo(h.DB)
+
+	name := strings.TrimPrefix(r.URL.Path, "/agents/")
+	if name == "" || strings.Contains(name, "/") {
+		h.sendError(w, http.StatusBadRequest, "expected /agents/<name>")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		agent, err := repo.Get(ctx, name)
+		if err != nil {
+			h.sendAgentError(w, err)
+			return
+		}
+		activity, err := repo.AnalyticsFor(ctx, name)
+		if err != nil {
+			h.sendError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		h.sendJSON(w, http.StatusOK, map[string]interface{}{
+			"agent":    agent,
+			"activity": activity,
+		})
+
+	case http.MethodPut:
+		if _, ok := h.requireAgentAdmin(w, r); !ok {
+			return
+		}
+		var req AgentRegistration
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.sendError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+			return
+		}
+		if req.Name != "" && req.Name != name {
+			h.sendError(w, http.StatusBadRequest, "agents cannot be renamed; register a new agent instead")
+			return
+		}
+		req.Name = name
+		saved, err := repo.Update(ctx, req.toModel())
+		if err != nil {
+			h.sendAgentError(w, err)
+			return
+		}
+		h.Agents.Invalidate()
+		events.NotifyAdminAction(ctx, h.DB, "agent.update", name, nil)
+		h.sendJSON(w, http.StatusOK, saved)
+
+	case http.MethodDelete:
+		if _, ok := h.requireAgentAdmin(w, r); !ok {
+			return
+		}
+		if err := repo.Delete(ctx, name); err != nil {
+			h.sendAgentError(w, err)
+			return
+		}
+		h.Agents.Invalidate()
+		events.NotifyAdminAction(ctx, h.DB, "agent.delete", name, nil)
+		h.sendJSON(w, http.StatusOK, map[string]string{"status": "deleted", "name": name})
+
+	default:
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// HandleAgentAnalytics returns feedback, audit and search activity per agent
+// name joined with the registry; registered=true drops unregistered names
+// GET /agents/analytics?registered=true
+func (h *RagHandler) HandleAgentAnalytics(w http.ResponseWriter, r *http.Request) {
+	rows, err := agents.NewRepo(h.DB).Analytics(r.Context(), r.URL.Query().Get("registered") == "true")
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	registered := 0
+	for _, row := range rows {
+		if row.Registered {
+			registered++
+		}
+	}
+	h.sendJSON(w, http.StatusOK, map[string]interface{}{
+		"count":        len(rows),
+		"registered":   registered,
+		"unregistered": len(rows) - registered,
+		"agents":       rows,
+	})
+}
+
+// requireAgentAdmin allows registry changes to admins when authentication is
+// enabled and returns the caller to record
+func (h *RagHandler) requireAgentAdmin(w http.ResponseWriter, r *http.Request) (string, bool) {
+	p, ok := auth.PrincipalFromContext(r.Context())
+	if !ok {
+		return "", true
+	}
+	if !p.HasRole(auth.RoleAdmin) {
+		h.sendError(w, http.StatusForbidden, "managing agents requires the admin role")
+		return "", false
+	}
+	return p.Subject, true
+}
+
+func (h *RagHandler) sendAgentError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, agents.ErrNotFound):
+		h.sendError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, agents.ErrInvalid):
+		h.sendError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, agents.ErrExists):
+		h.sendError(w, http.StatusConflict, err.Error())
+	default:
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/evidence"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/modellog"
+)
+
+// ProposeAttributesRequest names the attributes to extract from evidence;
+// empty means the attributes its document evidences
+type ProposeAttributesRequest struct {
+	Attributes []string `json:"attributes"`
+}
+
+// CandidateDecisionRequest confirms or rejects a proposed value. Value
+// corrects the proposed value on confirmation.
+type CandidateDecisionRequest struct {
+	Value string `json:"value"`
+	Note  string `json:"note"`
+}
+
+// HandleEvidenceAttributes proposes attribute values from the extracted
+// text of evidence with the chat model, citing the excerpts each value was
+// read from. The values are stored as pending candidates for an analyst to
+// confirm; nothing is written to the case.
+// POST /evidence/<id>/attributes
+func (h *RagHandler) HandleEvidenceAttributes(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	idStr, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/evidence/"), "/attributes")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if !ok || err != nil {
+		h.sendError(w, http.StatusBadRequest, "expected /evidence/<id>/attributes")
+		return
+	}
+	var req ProposeAttributesRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.sendError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+			return
+		}
+	}
+
+	extractor, err := evidence.NewAttributeExtractor(h.DB, h.Embedder)
+	if err != nil {
+		h.sendError(w, http.StatusServiceUnavailable, "attribute extraction unavailable: "+err.Error())
+		return
+	}
+	extractor.WithLog(modellog.NewLogger(h.DB, config.Current().ModelLog))
+
+	proposal, err := extractor.Propose(r.Context(), id, req.Attributes)
+	if err != nil {
+		h.sendEvidenceError(w, err)
+		return
+	}
+	h.sendJSON(w, http.StatusOK, proposal)
+}
+
+// HandleCaseAttributeCandidates lists the attribute values proposed from a
+// case's evidence, optionally with one status (pending, confirmed, rejected,
+// superseded)
+// GET /cases/<name>/attribute-candidates?status=
+func (h *RagHandler) HandleCaseAttributeCandidates(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	name := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/cases/"), "/attribute-candidates")
+	if name == "" || strings.Contains(name, "/") {
+		h.sendError(w, http.StatusBadRequest, "expected /cases/<name>/attribute-candidates")
+		return
+	}
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "", model.CandidatePending, model.CandidateConfirmed, model.CandidateRejected, model.CandidateSuperseded:
+	default:
+		h.sendError(w, http.StatusBadRequest, "status must be pending, confirmed, rejected or superseded")
+		return
+	}
+
+	svc, err := evidence.NewService(h.DB, config.Current().Evidence)
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	candidates, err := svc.Candidates(r.Context(), name, status)
+	if err != nil {
+		h.sendEvidenceError(w, err)
+		return
+	}
+	h.sendJSON(w, http.StatusOK, map[string]interface{}{
+		"case":       name,
+		"count":      len(candidates),
+		"candidates": candidates,
+	})
+}
+
+// HandleAttributeCandidate confirms (optionally correcting the value) or
+// rejects a pending candidate
+// POST /attribute-candidates/<id>/confirm | POST /attribute-candidates/<id>/reject
+func (h *RagHandler) HandleAttributeCandidate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	idStr, action, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/attribute-candidates/"), "/")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || (action != "confirm" && action != "reject") {
+		h.sendError(w, http.StatusBadRequest, "expected /attribute-candidates/<id>/confirm or /attribute-candidates/<id>/reject")
+		return
+	}
+	var req CandidateDecisionRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.sendError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+			return
+		}
+	}
+
+	svc, err := evidence.NewService(h.DB, config.Current().Evidence)
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	candidate, err := svc.DecideCandidate(r.Context(), id, action == "confirm", req.Value, req.Note)
+	if err != nil {
+		h.sendEvidenceError(w, err)
+		return
+	}
+	h.sendJSON(w, http.StatusOK, candidate)
+}
@@ -0,0 +1,177 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adamtc007/KYC-DSL/internal/actor"
+	"github.com/adamtc007/KYC-DSL/internal/auth"
+	"github.com/adamtc007/KYC-DSL/internal/engine"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+// CaseAssignmentRequest assigns or reassigns a case. Fields left empty keep
+// their current value.
+type CaseAssignmentRequest struct {
+	Assignee   string     `json:"assignee,omitempty"`
+	Team       string     `json:"team,omitempty"`
+	RiskRating string     `json:"risk_rating,omitempty"`
+	SLADueAt   *time.Time `json:"sla_due_at,omitempty"`
+	// SLA sets the due date relative to now (e.g. "72h") when SLADueAt is unset
+	SLA    string `json:"sla,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// HandleCaseQueue returns the work queue of assigned cases, soonest SLA due
+// date and highest risk first. assignee=me selects the caller's own queue;
+// without status, cases already approved, declined or archived are left out.
+// GET /cases/queue?assignee=&team=&status=&risk=&due_before=|due_within=&overdue=&limit=
+func (h *RagHandler) HandleCaseQueue(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	q := r.URL.Query()
+	filter := model.CaseQueueFilter{
+		Assignee:    q.Get("assignee"),
+		Team:        q.Get("team"),
+		RiskRating:  q.Get("risk"),
+		OverdueOnly: q.Get("overdue") == "true",
+	}
+	if filter.Assignee == "me" {
+		filter.Assignee = actor.FromContext(r.Context()).Name
+	}
+	if s := q.Get("status"); s != "" {
+		st, err := engine.ParseStatus(s)
+		if err != nil {
+			h.sendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		filter.Status = st
+	}
+	if s := q.Get("due_before"); s != "" {
+		due, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			h.sendError(w, http.StatusBadRequest, "due_before must be an RFC 3339 time")
+			return
+		}
+		filter.DueBefore = &due
+	} else if s := q.Get("due_within"); s != "" {
+		within, err := time.ParseDuration(s)
+		if err != nil {
+			h.sendError(w, http.StatusBadRequest, "due_within must be a duration such as 24h")
+			return
+		}
+		due := time.Now().Add(within)
+		filter.DueBefore = &due
+	}
+	if l, err := strconv.Atoi(q.Get("limit")); err == nil && l > 0 {
+		filter.Limit = l
+	}
+
+	queue, err := engine.NewAssignments(h.DB).Queue(r.Context(), filter)
+	if err != nil {
+		h.sendAssignmentError(w, err)
+		return
+	}
+	h.sendJSON(w, http.StatusOK, map[string]interface{}{
+		"filter": map[string]interface{}{
+			"assignee": filter.Assignee,
+			"team":     filter.Team,
+			"status":   filter.Status,
+			"risk":     filter.RiskRating,
+			"overdue":  filter.OverdueOnly,
+		},
+		"count": len(queue),
+		"cases": queue,
+	})
+}
+
+// HandleCaseAssignment returns the assignment and assignment history of a
+// case, or assigns it. Reassigning a case held by another analyst requires
+// the reviewer role.
+// GET|POST /cases/<name>/assignment
+func (h *RagHandler) HandleCaseAssignment(w http.ResponseWriter, r *http.Request) {
+	name, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/cases/"), "/assignment")
+	if !ok || name == "" || strings.Contains(name, "/") {
+		h.sendError(w, http.StatusBadRequest, "expected /cases/<name>/assignment")
+		return
+	}
+
+	assignments := engine.NewAssignments(h.DB)
+	switch r.Method {
+	case http.MethodGet:
+		current, err := assignments.Get(r.Context(), name)
+		if err != nil {
+			h.sendError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		history, err := assignments.History(r.Context(), name)
+		if err != nil {
+			h.sendError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		h.sendJSON(w, http.StatusOK, map[string]interface{}{
+			"case":       name,
+			"assignment": current,
+			"history":    history,
+		})
+
+	case http.MethodPost:
+		var req CaseAssignmentRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.sendError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+			return
+		}
+		to := model.CaseAssignment{Assignee: req.Assignee, Team: req.Team, RiskRating: req.RiskRating, SLADueAt: req.SLADueAt}
+		if to.SLADueAt == nil && req.SLA != "" {
+			sla, err := time.ParseDuration(req.SLA)
+			if err != nil {
+				h.sendError(w, http.StatusBadRequest, "sla must be a duration such as 72h")
+				return
+			}
+			due := time.Now().Add(sla)
+			to.SLADueAt = &due
+		}
+
+		if p, ok := auth.PrincipalFromContext(r.Context()); ok && !p.HasRole(auth.RoleReviewer) {
+			current, err := assignments.Get(r.Context(), name)
+			if err != nil {
+				h.sendError(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+			if current != nil && current.Assignee != "" && current.Assignee != p.Subject &&
+				req.Assignee != "" && req.Assignee != current.Assignee {
+				h.sendError(w, http.StatusForbidden, "reassigning another analyst's case requires the reviewer role")
+				return
+			}
+		}
+
+		assignment, err := assignments.Assign(r.Context(), name, to, req.Reason)
+		if err != nil {
+			h.sendAssignmentError(w, err)
+			return
+		}
+		h.sendJSON(w, http.StatusOK, assignment)
+
+	default:
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// sendAssignmentError maps case assignment errors to HTTP status codes
+func (h *RagHandler) sendAssignmentError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, engine.ErrCaseNotFound):
+		h.sendError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, engine.ErrInvalidAssignment):
+		h.sendError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+	}
+}
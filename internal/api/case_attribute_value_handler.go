@@ -0,0 +1,192 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/attrvalue"
+	"github.com/adamtc007/KYC-DSL/internal/casedata"
+	"github.com/adamtc007/KYC-DSL/internal/logging"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/redact"
+)
+
+// CaseAttributeValueRequest captures a public attribute value for a case.
+// Version 0 means the case's current version; Attribute is taken from the
+// path of PUT /cases/<name>/attributes/<code>.
+type CaseAttributeValueRequest struct {
+	Attribute      string `json:"attribute"`
+	Value          string `json:"value"`
+	Version        int    `json:"version,omitempty"`
+	SourceDocument string `json:"source_document,omitempty"`
+	EvidenceID     *int64 `json:"evidence_id,omitempty"`
+}
+
+// HandleCaseAttributeValues lists, captures, reads and deletes the public
+// attribute values of a case. Values are validated against the data type and
+// domain values of their attribute, and refused values answer 400 with the
+// broken rule in "validation"; ?version= selects a case version (default:
+// current). Values of sensitive attributes read as redact.Marker for
+// callers without the pii:read scope.
+// GET|POST /cases/<name>/attributes | GET|PUT|DELETE /cases/<name>/attributes/<code>
+func (h *RagHandler) HandleCaseAttributeValues(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/cases/")
+	name, code, found := strings.Cut(path, "/attributes")
+	code = strings.TrimPrefix(code, "/")
+	if !found || name == "" || strings.Contains(name, "/") || strings.Contains(code, "/") {
+		h.sendError(w, http.StatusBadRequest, "expected /cases/<name>/attributes or /cases/<name>/attributes/<code>")
+		return
+	}
+	version := 0
+	if v := r.URL.Query().Get("version"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			h.sendError(w, http.StatusBadRequest, "version must be a positive integer")
+			return
+		}
+		version = n
+	}
+
+	repo := casedata.NewRepo(h.DB)
+	switch {
+	case r.Method == http.MethodGet && code == "":
+		values, err := repo.List(r.Context(), name, version)
+		if err != nil {
+			h.sendCaseDataError(w, err)
+			return
+		}
+		if err := h.redactCaseValues(r, name, values); err != nil {
+			h.sendError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		h.sendJSON(w, http.StatusOK, map[string]interface{}{
+			"case":   name,
+			"count":  len(values),
+			"values": values,
+		})
+
+	case r.Method == http.MethodGet:
+		value, err := repo.Get(r.Context(), name, version, code)
+		if err != nil {
+			h.sendCaseDataError(w, err)
+			return
+		}
+		values := []model.CaseAttributeValue{*value}
+		if err := h.redactCaseValues(r, name, values); err != nil {
+			h.sendError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		h.sendJSON(w, http.StatusOK, values[0])
+
+	case (r.Method == http.MethodPost && code == "") || (r.Method == http.MethodPut && code != ""):
+		var req CaseAttributeValueRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.sendError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+			return
+		}
+		if code != "" {
+			req.Attribute = code
+		}
+		if req.Attribute == "" {
+			h.sendError(w, http.StatusBadRequest, "attribute is required")
+			return
+		}
+		if version != 0 {
+			req.Version = version
+		}
+		value, err := repo.Set(r.Context(), casedata.Capture{
+			CaseName:       name,
+			CaseVersion:    req.Version,
+			AttributeCode:  req.Attribute,
+			Value:          req.Value,
+			SourceDocument: req.SourceDocument,
+			EvidenceID:     req.EvidenceID,
+		})
+		if err != nil {
+			h.sendCaseDataError(w, err)
+			return
+		}
+		h.sendJSON(w, http.StatusOK, value)
+
+	case r.Method == http.MethodDelete && code != "":
+		if err := repo.Delete(r.Context(), name, version, code); err != nil {
+			h.sendCaseDataError(w, err)
+			return
+		}
+		h.sendJSON(w, http.StatusOK, map[string]interface{}{
+			"case":      name,
+			"attribute": strings.ToUpper(code),
+			"deleted":   true,
+		})
+
+	default:
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// HandleCaseCompleteness reports each attribute the document requirements
+// of a case call for as present, missing or stale (its evidence expired),
+// with the share present; ?version= selects a case version (default:
+// current).
+// GET /cases/<name>/completeness
+func (h *RagHandler) HandleCaseCompleteness(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	name := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/cases/"), "/completeness")
+	if name == "" || strings.Contains(name, "/") {
+		h.sendError(w, http.StatusBadRequest, "expected /cases/<name>/completeness")
+		return
+	}
+	version := 0
+	if v := r.URL.Query().Get("version"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			h.sendError(w, http.StatusBadRequest, "version must be a positive integer")
+			return
+		}
+		version = n
+	}
+
+	report, err := casedata.NewRepo(h.DB).Completeness(r.Context(), name, version)
+	if err != nil {
+		h.sendCaseDataError(w, err)
+		return
+	}
+	codes := make([]string, len(report.Attributes))
+	for i, a := range report.Attributes {
+		if a.Value != "" {
+			codes[i] = a.Attribute
+		}
+	}
+	if err := h.redactAttributes(r, name, codes, func(i int) { report.Attributes[i].Value = redact.Marker }); err != nil {
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.sendJSON(w, http.StatusOK, report)
+}
+
+func (h *RagHandler) sendCaseDataError(w http.ResponseWriter, err error) {
+	var invalid *attrvalue.Error
+	switch {
+	case errors.As(err, &invalid):
+		h.sendJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error:      http.StatusText(http.StatusBadRequest),
+			Message:    invalid.Error(),
+			RequestID:  w.Header().Get(logging.RequestIDHeader),
+			Validation: invalid,
+		})
+	case errors.Is(err, casedata.ErrCaseNotFound), errors.Is(err, casedata.ErrVersionNotFound),
+		errors.Is(err, casedata.ErrValueNotFound):
+		h.sendError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, casedata.ErrUnknownAttribute), errors.Is(err, casedata.ErrDerivedAttribute),
+		errors.Is(err, casedata.ErrUnknownDocument), errors.Is(err, casedata.ErrUnknownEvidence):
+		h.sendError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/casedict"
+)
+
+// HandleCaseDictionary returns the data dictionary values of a case, or
+// materializes its latest successful derived attribute results into it
+// GET /cases/<name>/dictionary | POST /cases/<name>/dictionary/materialize
+func (h *RagHandler) HandleCaseDictionary(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/cases/")
+	path, materialize := strings.CutSuffix(path, "/materialize")
+	name, ok := strings.CutSuffix(path, "/dictionary")
+	if !ok || name == "" || strings.Contains(name, "/") {
+		h.sendError(w, http.StatusBadRequest, "expected /cases/<name>/dictionary")
+		return
+	}
+
+	repo := casedict.NewRepo(h.DB)
+	switch {
+	case r.Method == http.MethodGet && !materialize:
+		entries, err := repo.Entries(r.Context(), name)
+		if err != nil {
+			h.sendError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if err := h.redactEntries(r, name, entries); err != nil {
+			h.sendError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		h.sendJSON(w, http.StatusOK, map[string]interface{}{
+			"case":    name,
+			"count":   len(entries),
+			"entries": entries,
+		})
+
+	case r.Method == http.MethodPost && materialize:
+		entries, err := repo.MaterializeDerived(r.Context(), name)
+		if err != nil {
+			h.sendError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if err := h.redactEntries(r, name, entries); err != nil {
+			h.sendError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		h.sendJSON(w, http.StatusOK, map[string]interface{}{
+			"case":         name,
+			"materialized": len(entries),
+			"entries":      entries,
+		})
+
+	default:
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/casediff"
+)
+
+// HandleCaseDiff compares two versions of a case section by section,
+// reporting added, removed and changed documents, attributes, policies and
+// ownership entries. to defaults to the latest version and from to the one
+// before it.
+// GET /cases/<name>/diff?from=&to=
+func (h *RagHandler) HandleCaseDiff(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	name := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/cases/"), "/diff")
+	if name == "" || strings.Contains(name, "/") {
+		h.sendError(w, http.StatusBadRequest, "expected /cases/<name>/diff")
+		return
+	}
+
+	versions := map[string]int{}
+	for _, param := range []string{"from", "to"} {
+		s := r.URL.Query().Get(param)
+		if s == "" {
+			continue
+		}
+		v, err := strconv.Atoi(s)
+		if err != nil || v <= 0 {
+			h.sendError(w, http.StatusBadRequest, param+" must be a positive version number")
+			return
+		}
+		versions[param] = v
+	}
+
+	diff, err := casediff.Versions(h.DB, name, versions["from"], versions["to"])
+	if errors.Is(err, casediff.ErrVersionNotFound) {
+		h.sendError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.sendJSON(w, http.StatusOK, diff)
+}
@@ -0,0 +1,224 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/auth"
+	"github.com/adamtc007/KYC-DSL/internal/engine"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+// CaseTransitionRequest moves a case to another lifecycle state
+type CaseTransitionRequest struct {
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+	// Actor is used when the request is not authenticated
+	Actor string `json:"actor,omitempty"`
+}
+
+// HandleCases routes /cases/queue to the work queue and /cases/<name>/... to
+// the case lifecycle, case locks, case assignment, version signatures,
+// captured attribute values or the case data dictionary
+func (h *RagHandler) HandleCases(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/cases/")
+	switch {
+	case path == "queue":
+		h.HandleCaseQueue(w, r)
+		return
+	case strings.HasSuffix(path, "/assignment"):
+		h.HandleCaseAssignment(w, r)
+		return
+	case strings.HasSuffix(path, "/diff"):
+		h.HandleCaseDiff(w, r)
+		return
+	case strings.HasSuffix(path, "/signatures"):
+		h.HandleCaseSignatures(w, r)
+		return
+	case strings.HasSuffix(path, "/expiring-documents"):
+		h.HandleCaseExpiringDocuments(w, r)
+		return
+	case strings.HasSuffix(path, "/attribute-candidates"):
+		h.HandleCaseAttributeCandidates(w, r)
+		return
+	case strings.HasSuffix(path, "/completeness"):
+		h.HandleCaseCompleteness(w, r)
+		return
+	case strings.HasSuffix(path, "/attributes"), strings.Contains(path, "/attributes/"):
+		h.HandleCaseAttributeValues(w, r)
+		return
+	case strings.HasSuffix(path, "/timeline"), strings.HasSuffix(path, "/transition"):
+		h.HandleCaseLifecycle(w, r)
+		return
+	case strings.HasSuffix(path, "/lock"), strings.HasSuffix(path, "/lock/heartbeat"):
+		h.HandleCaseLock(w, r)
+		return
+	}
+	h.HandleCaseDictionary(w, r)
+}
+
+// HandleCaseLifecycle returns the lifecycle timeline of a case, or moves it
+// to another state. Approving or declining a case requires the reviewer role.
+// GET /cases/<name>/timeline | POST /cases/<name>/transition
+func (h *RagHandler) HandleCaseLifecycle(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/cases/")
+	name, action, _ := strings.Cut(path, "/")
+	if name == "" || (action != "timeline" && action != "transition") {
+		h.sendError(w, http.StatusBadRequest, "expected /cases/<name>/timeline or /cases/<name>/transition")
+		return
+	}
+
+	lifecycle := h.Lifecycle
+	if lifecycle == nil {
+		lifecycle = engine.NewLifecycle(h.DB)
+	}
+
+	switch {
+	case r.Method == http.MethodGet && action == "timeline":
+		timeline, err := lifecycle.Timeline(r.Context(), name)
+		if err != nil {
+			h.sendLifecycleError(w, err)
+			return
+		}
+		h.sendJSON(w, http.StatusOK, timeline)
+
+	case r.Method == http.MethodPost && action == "transition":
+		var req CaseTransitionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.sendError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+			return
+		}
+		to, err := engine.ParseStatus(req.Status)
+		if err != nil {
+			h.sendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		actor := req.Actor
+		if p, ok := auth.PrincipalFromContext(r.Context()); ok {
+			if (to == model.CaseApproved || to == model.CaseDeclined) && !p.HasRole(auth.RoleReviewer) {
+				h.sendError(w, http.StatusForbidden, "approving or declining a case requires the reviewer role")
+				return
+			}
+			if p.Subject != "" {
+				actor = p.Subject
+			}
+		}
+
+		transition, err := lifecycle.Transition(r.Context(), name, to, actor, req.Reason)
+		if err != nil {
+			h.sendLifecycleError(w, err)
+			return
+		}
+		h.sendJSON(w, http.StatusOK, transition)
+
+	default:
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// CaseLockRequest acquires a case lock
+type CaseLockRequest struct {
+	// Holder is used when the request is not authenticated
+	Holder string `json:"holder,omitempty"`
+	Note   string `json:"note,omitempty"`
+}
+
+// HandleCaseLock shows, acquires, heartbeats or releases the advisory lock
+// held on a case while it is amended. Force-releasing another holder's live
+// lock requires the reviewer role.
+// GET|POST|DELETE /cases/<name>/lock | POST /cases/<name>/lock/heartbeat
+func (h *RagHandler) HandleCaseLock(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/cases/")
+	path, heartbeat := strings.CutSuffix(path, "/heartbeat")
+	name, ok := strings.CutSuffix(path, "/lock")
+	if !ok || name == "" || strings.Contains(name, "/") {
+		h.sendError(w, http.StatusBadRequest, "expected /cases/<name>/lock or /cases/<name>/lock/heartbeat")
+		return
+	}
+
+	var req CaseLockRequest
+	if r.Method == http.MethodPost && r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.sendError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+			return
+		}
+	}
+	holder := req.Holder
+	if holder == "" {
+		holder = r.URL.Query().Get("holder")
+	}
+	principal, authenticated := auth.PrincipalFromContext(r.Context())
+	if authenticated && principal.Subject != "" {
+		holder = principal.Subject
+	}
+
+	locks := engine.NewLocks(h.DB)
+	switch {
+	case r.Method == http.MethodGet && !heartbeat:
+		lock, err := locks.Get(r.Context(), name)
+		if err != nil {
+			h.sendError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		h.sendJSON(w, http.StatusOK, map[string]interface{}{
+			"case":   name,
+			"locked": lock != nil && !lock.Stale,
+			"lock":   lock,
+		})
+
+	case r.Method == http.MethodPost && !heartbeat:
+		if holder == "" {
+			h.sendError(w, http.StatusBadRequest, "holder is required")
+			return
+		}
+		lock, err := locks.Acquire(r.Context(), name, holder, req.Note)
+		if errors.Is(err, engine.ErrCaseLocked) {
+			h.sendJSON(w, http.StatusConflict, map[string]interface{}{"error": err.Error(), "lock": lock})
+			return
+		}
+		if err != nil {
+			h.sendError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		h.sendJSON(w, http.StatusOK, lock)
+
+	case r.Method == http.MethodPost && heartbeat:
+		lock, err := locks.Heartbeat(r.Context(), name, holder)
+		if err != nil {
+			h.sendLifecycleError(w, err)
+			return
+		}
+		h.sendJSON(w, http.StatusOK, lock)
+
+	case r.Method == http.MethodDelete && !heartbeat:
+		force := r.URL.Query().Get("force") == "true"
+		if force && authenticated && !principal.HasRole(auth.RoleReviewer) {
+			h.sendError(w, http.StatusForbidden, "force-releasing a case lock requires the reviewer role")
+			return
+		}
+		if err := locks.Release(r.Context(), name, holder, force); err != nil {
+			h.sendLifecycleError(w, err)
+			return
+		}
+		h.sendJSON(w, http.StatusOK, map[string]interface{}{"case": name, "released": true})
+
+	default:
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// sendLifecycleError maps case lifecycle and lock errors to HTTP status codes
+func (h *RagHandler) sendLifecycleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, engine.ErrCaseNotFound):
+		h.sendError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, engine.ErrInvalidTransition), errors.Is(err, engine.ErrCaseLocked),
+		errors.Is(err, engine.ErrLockNotHeld):
+		h.sendError(w, http.StatusConflict, err.Error())
+	default:
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+	}
+}
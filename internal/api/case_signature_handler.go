@@ -0,0 +1,65 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/signing"
+)
+
+// CaseSignaturesResponse lists the verified signatures of a case
+type CaseSignaturesResponse struct {
+	CaseName string `json:"case_name"`
+	// Valid is set when every signature verifies
+	Valid      bool                   `json:"valid"`
+	Signatures []signing.Verification `json:"signatures"`
+}
+
+// HandleCaseSignatures verifies the signatures made when a case was
+// approved: each must match its statement, come from a trusted key and
+// still describe the stored snapshot. version limits them to one version.
+// GET /cases/<name>/signatures?version=
+func (h *RagHandler) HandleCaseSignatures(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	name := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/cases/"), "/signatures")
+	if name == "" || strings.Contains(name, "/") {
+		h.sendError(w, http.StatusBadRequest, "expected /cases/<name>/signatures")
+		return
+	}
+	version := 0
+	if s := r.URL.Query().Get("version"); s != "" {
+		v, err := strconv.Atoi(s)
+		if err != nil || v <= 0 {
+			h.sendError(w, http.StatusBadRequest, "version must be a positive version number")
+			return
+		}
+		version = v
+	}
+
+	trusted, err := signing.TrustedKeys(config.Current().Signing)
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	verifications, err := signing.VerifyCase(r.Context(), h.DB, name, version, trusted)
+	if errors.Is(err, signing.ErrNotSigned) {
+		h.sendError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	resp := CaseSignaturesResponse{CaseName: name, Valid: true, Signatures: verifications}
+	for _, v := range verifications {
+		resp.Valid = resp.Valid && v.Valid
+	}
+	h.sendJSON(w, http.StatusOK, resp)
+}
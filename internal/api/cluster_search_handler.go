@@ -0,0 +1,201 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/ontology"
+)
+
+// maxRoutedClusters bounds how many clusters a cluster search fans out to
+const maxRoutedClusters = 5
+
+// Cluster search routing outcomes
+const (
+	RoutingCluster = "cluster" // searched within the recommended clusters
+	RoutingGlobal  = "global"  // no cluster matched well enough; searched everything
+)
+
+// ClusterSearchResponse is the result of a cluster-routed attribute search
+type ClusterSearchResponse struct {
+	Query          string                        `json:"query"`
+	Limit          int                           `json:"limit"`
+	Count          int                           `json:"count"`
+	FeedbackWeight float64                       `json:"feedback_weight"`
+	Routing        string                        `json:"routing"`
+	Clusters       []model.ClusterRecommendation `json:"clusters"`
+	Results        []ClusterAttributeResult      `json:"results"`
+}
+
+// ClusterAttributeResult is an attribute result with the cluster it was found in
+type ClusterAttributeResult struct {
+	AttributeResult
+	ClusterCode string `json:"cluster_code,omitempty"`
+	ClusterName string `json:"cluster_name,omitempty"`
+}
+
+// HandleClusterSearch routes a semantic attribute search through the clusters
+// closest to the query: it searches within the top clusters and attributes
+// each result to its cluster, which keeps broad queries from matching
+// attributes scattered across unrelated areas. Without a cluster at or above
+// min_similarity it falls back to a global search. Results are ranked by
+// blended score, as in attribute search.
+// GET /rag/cluster_search?q=<query>&limit=<limit>&clusters=<n>&min_similarity=<0..1>&feedback_weight=<0..1>
+func (h *RagHandler) HandleClusterSearch(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("q")
+	if query == "" {
+		h.sendError(w, http.StatusBadRequest, "missing 'q' query parameter")
+		return
+	}
+
+	limit := 10
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+	clusters := 1
+	if s := r.URL.Query().Get("clusters"); s != "" {
+		if n, err := strconv.Atoi(s); err == nil && n > 0 {
+			clusters = min(n, maxRoutedClusters)
+		}
+	}
+	minSimilarity := 0.0
+	if s := r.URL.Query().Get("min_similarity"); s != "" {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil || f < 0 || f > 1 {
+			h.sendError(w, http.StatusBadRequest, "min_similarity must be between 0 and 1")
+			return
+		}
+		minSimilarity = f
+	}
+	weight, err := h.feedbackWeight(r)
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx := r.Context()
+
+	queryEmbedding, err := h.Embedder.GenerateEmbeddingFromText(ctx, query)
+	if err != nil {
+		h.sendEmbeddingError(w, err)
+		return
+	}
+	noteQueryEmbedding(ctx, queryEmbedding)
+
+	repo := ontology.NewEnhancementsRepo(h.DB)
+	recommended, err := repo.RecommendClusters(ctx, queryEmbedding, clusters)
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	routed := make([]model.ClusterRecommendation, 0, len(recommended))
+	for _, c := range recommended {
+		if c.Similarity >= minSimilarity && c.MemberCount > 0 {
+			routed = append(routed, c)
+		}
+	}
+
+	response := ClusterSearchResponse{
+		Query:          query,
+		Limit:          limit,
+		FeedbackWeight: weight,
+		Routing:        RoutingCluster,
+		Clusters:       routed,
+		Results:        []ClusterAttributeResult{},
+	}
+	pool := limit
+	if weight > 0 {
+		pool = limit * rerankPool
+	}
+
+	if len(routed) == 0 {
+		results, err := ontology.NewMetadataRepo(h.DB).SearchByVector(ctx, queryEmbedding, pool)
+		if err != nil {
+			h.sendError(w, http.StatusInternalServerError, "failed to search: "+err.Error())
+			return
+		}
+		response.Routing = RoutingGlobal
+		for _, res := range results {
+			response.Results = append(response.Results, ClusterAttributeResult{AttributeResult: toAttributeResult(res)})
+		}
+	} else {
+		// An attribute in several routed clusters is attributed to the
+		// closest cluster (they are searched in order of similarity)
+		seen := make(map[string]bool)
+		for _, c := range routed {
+			results, err := repo.SearchWithinCluster(ctx, c.ClusterCode, queryEmbedding, pool)
+			if err != nil {
+				h.sendError(w, http.StatusInternalServerError, "failed to search: "+err.Error())
+				return
+			}
+			for _, res := range results {
+				if seen[res.AttributeCode] {
+					continue
+				}
+				seen[res.AttributeCode] = true
+				response.Results = append(response.Results, ClusterAttributeResult{
+					AttributeResult: toAttributeResult(res),
+					ClusterCode:     c.ClusterCode,
+					ClusterName:     c.ClusterName,
+				})
+			}
+		}
+	}
+
+	// Feedback is grouped by the closest cluster, routed or not
+	queryCluster := ""
+	if len(recommended) > 0 {
+		queryCluster = recommended[0].ClusterCode
+	}
+	codes := make([]string, len(response.Results))
+	for i, res := range response.Results {
+		codes[i] = res.Code
+	}
+	signals := h.rankSignals(r, query, queryCluster, weight, codes)
+	for i := range response.Results {
+		signals.score(&response.Results[i].AttributeResult)
+	}
+	sort.SliceStable(response.Results, func(i, j int) bool {
+		return response.Results[i].BlendedScore > response.Results[j].BlendedScore
+	})
+	if len(response.Results) > limit {
+		response.Results = response.Results[:limit]
+	}
+	response.Count = len(response.Results)
+
+	codes = codes[:0]
+	for _, res := range response.Results {
+		codes = append(codes, res.Code)
+	}
+	h.recordSearchHits(r, query, attributeHits(codes))
+
+	attrs := make([]*AttributeResult, len(response.Results))
+	for i := range response.Results {
+		attrs[i] = &response.Results[i].AttributeResult
+	}
+	if err := h.redactExamples(r, attrs); err != nil {
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, response)
+}
+
+func toAttributeResult(r model.AttributeSearchResult) AttributeResult {
+	return AttributeResult{
+		Code:                r.AttributeCode,
+		RiskLevel:           r.RiskLevel,
+		DataType:            r.DataType,
+		Description:         strings.TrimSpace(r.BusinessContext),
+		Synonyms:            r.Synonyms,
+		RegulatoryCitations: r.RegulatoryCitations,
+		ExampleValues:       r.ExampleValues,
+		SimilarityScore:     r.SimilarityScore,
+		Distance:            r.Distance,
+	}
+}
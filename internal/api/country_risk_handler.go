@@ -0,0 +1,199 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/adamtc007/KYC-DSL/internal/auth"
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/lists"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/reeval"
+)
+
+// CountryRiskListUpdate creates a jurisdiction risk list or updates its
+// name, authority and description
+type CountryRiskListUpdate struct {
+	Name        string `json:"name,omitempty"`
+	Authority   string `json:"authority,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// CountryRiskListing lists a country on a jurisdiction risk list
+type CountryRiskListing struct {
+	Country       string `json:"country"`
+	EffectiveFrom string `json:"effective_from,omitempty"` // YYYY-MM-DD, default today
+	EffectiveTo   string `json:"effective_to,omitempty"`   // YYYY-MM-DD, open-ended if empty
+	Note          string `json:"note,omitempty"`
+}
+
+// HandleCountryRiskLists returns the jurisdiction risk lists with the
+// countries listed today
+// GET /country-risk
+func (h *RagHandler) HandleCountryRiskLists(w http.ResponseWriter, r *http.Request) {
+	all, err := lists.NewRepo(h.DB).CountryRiskLists(r.Context())
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.sendJSON(w, http.StatusOK, map[string]interface{}{
+		"count": len(all),
+		"lists": all,
+	})
+}
+
+// HandleCountryRiskList returns a jurisdiction risk list (every listing
+// when history=true), creates or describes it, lists a country on it, or
+// de-lists one. Changes to listings re-evaluate the derived attributes
+// reading the list; changes require the reviewer role.
+// GET /country-risk/<code>?history=true | POST /country-risk/<code> |
+// POST /country-risk/<code>/countries | POST /country-risk/<code>/countries/<country>/end
+func (h *RagHandler) HandleCountryRiskList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	repo := lists.NewRepo(h.DB)
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/country-risk/"), "/")
+	code := parts[0]
+	valid := code != "" && (len(parts) == 1 ||
+		(len(parts) == 2 && parts[1] == "countries") ||
+		(len(parts) == 4 && parts[1] == "countries" && parts[3] == "end"))
+	if !valid {
+		h.sendError(w, http.StatusBadRequest, "expected /country-risk/<code>[/countries[/<country>/end]]")
+		return
+	}
+
+	if r.Method == http.MethodGet && len(parts) == 1 {
+		list, err := repo.CountryRiskList(ctx, code, r.URL.Query().Get("history") == "true")
+		if err != nil {
+			h.sendCountryRiskError(w, err)
+			return
+		}
+		h.sendJSON(w, http.StatusOK, list)
+		return
+	}
+	if r.Method != http.MethodPost {
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	changedBy := ""
+	if p, ok := auth.PrincipalFromContext(ctx); ok {
+		if !p.HasRole(auth.RoleReviewer) {
+			h.sendError(w, http.StatusForbidden, "updating country risk lists requires the reviewer role")
+			return
+		}
+		changedBy = p.Subject
+	}
+
+	var entry *model.CountryRiskEntry
+	switch len(parts) {
+	case 1:
+		var req CountryRiskListUpdate
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.sendError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+			return
+		}
+		list, err := repo.PutCountryRiskList(ctx, model.CountryRiskList{
+			Code:        code,
+			Name:        req.Name,
+			Authority:   req.Authority,
+			Description: req.Description,
+		}, changedBy)
+		if err != nil {
+			h.sendCountryRiskError(w, err)
+			return
+		}
+		h.sendJSON(w, http.StatusOK, list)
+		return
+
+	case 2:
+		var req CountryRiskListing
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.sendError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+			return
+		}
+		listing := model.CountryRiskEntry{ListCode: code, CountryCode: req.Country, Note: req.Note}
+		if req.EffectiveFrom != "" {
+			t, err := time.Parse("2006-01-02", req.EffectiveFrom)
+			if err != nil {
+				h.sendError(w, http.StatusBadRequest, "effective_from must be YYYY-MM-DD")
+				return
+			}
+			listing.EffectiveFrom = t
+		}
+		if req.EffectiveTo != "" {
+			t, err := time.Parse("2006-01-02", req.EffectiveTo)
+			if err != nil {
+				h.sendError(w, http.StatusBadRequest, "effective_to must be YYYY-MM-DD")
+				return
+			}
+			listing.EffectiveTo = &t
+		}
+		e, err := repo.PutCountryRisk(ctx, listing, changedBy)
+		if err != nil {
+			h.sendCountryRiskError(w, err)
+			return
+		}
+		entry = e
+
+	case 4:
+		var req struct {
+			EffectiveTo string `json:"effective_to,omitempty"` // YYYY-MM-DD, default today
+		}
+		if r.ContentLength != 0 {
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				h.sendError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+				return
+			}
+		}
+		var on time.Time
+		if req.EffectiveTo != "" {
+			t, err := time.Parse("2006-01-02", req.EffectiveTo)
+			if err != nil {
+				h.sendError(w, http.StatusBadRequest, "effective_to must be YYYY-MM-DD")
+				return
+			}
+			on = t
+		}
+		e, err := repo.EndCountryRisk(ctx, code, parts[2], on, changedBy)
+		if err != nil {
+			h.sendCountryRiskError(w, err)
+			return
+		}
+		entry = e
+	}
+
+	// The listing change queued the rules reading the list; process them
+	// now so the caller sees the impact
+	results, err := reeval.NewWorker(h.DB, config.Current().Reevaluation).RunFor(ctx, reeval.ReasonList, code)
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, "re-evaluation failed: "+err.Error())
+		return
+	}
+	changed := 0
+	for _, res := range results {
+		if res.Changed {
+			changed++
+		}
+	}
+	h.sendJSON(w, http.StatusOK, map[string]interface{}{
+		"listing":      entry,
+		"reevaluated":  len(results),
+		"changed":      changed,
+		"reevaluation": results,
+	})
+}
+
+func (h *RagHandler) sendCountryRiskError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, lists.ErrNotFound):
+		h.sendError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, lists.ErrInvalid):
+		h.sendError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+	}
+}
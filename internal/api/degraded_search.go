@@ -0,0 +1,74 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/overlay"
+	"github.com/adamtc007/KYC-DSL/internal/rag"
+)
+
+// maxLexicalTerms caps the query terms text-searched by a degraded search
+const maxLexicalTerms = 8
+
+// lexicalSearch is attribute search without a query embedding, used while
+// the embeddings provider is unavailable: attributes matching any query term
+// (of three letters or more) in their code, synonyms or business context,
+// scored by the share of terms they match
+func lexicalSearch(ctx context.Context, resolver *overlay.Resolver, query string, limit int) ([]model.AttributeSearchResult, error) {
+	var terms []string
+	seen := map[string]bool{}
+	for _, t := range strings.Fields(strings.ToLower(rag.NormalizeQuery(query))) {
+		if len(t) > 2 && !seen[t] && len(terms) < maxLexicalTerms {
+			seen[t] = true
+			terms = append(terms, t)
+		}
+	}
+	if len(terms) == 0 {
+		terms = []string{strings.TrimSpace(query)}
+	}
+
+	hits := map[string]int{}
+	var results []model.AttributeSearchResult
+	for _, term := range terms {
+		matches, err := resolver.SearchByText(ctx, term)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range matches {
+			if hits[m.AttributeCode] == 0 {
+				results = append(results, model.AttributeSearchResult{AttributeMetadata: m})
+			}
+			hits[m.AttributeCode]++
+		}
+	}
+	for i := range results {
+		score := float64(hits[results[i].AttributeCode]) / float64(len(terms))
+		results[i].SimilarityScore = score
+		results[i].Distance = 1 - score
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].SimilarityScore != results[j].SimilarityScore {
+			return results[i].SimilarityScore > results[j].SimilarityScore
+		}
+		return results[i].AttributeCode < results[j].AttributeCode
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// sendEmbeddingError answers a request whose query could not be embedded:
+// 503 while the embeddings provider is unavailable
+func (h *RagHandler) sendEmbeddingError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	if errors.Is(err, rag.ErrUnavailable) || errors.Is(err, rag.ErrSpendLimit) {
+		status = http.StatusServiceUnavailable
+	}
+	h.sendError(w, status, "failed to generate query embedding: "+err.Error())
+}
@@ -0,0 +1,455 @@
+package api
+
+import (
+	"database/sql"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	pb "github.com/adamtc007/KYC-DSL/api/pb"
+	"github.com/adamtc007/KYC-DSL/internal/amend"
+	"github.com/adamtc007/KYC-DSL/internal/auth"
+	"github.com/adamtc007/KYC-DSL/internal/engine"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/rustclient"
+)
+
+//go:embed ui/editor.html
+var editorPage []byte
+
+// DslRequest carries DSL text from the editor
+type DslRequest struct {
+	DSL string `json:"dsl"`
+}
+
+// Diagnostic is a parse or validation issue positioned for inline display;
+// line and column are 1-based, 0 when the engine gave no position
+type Diagnostic struct {
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	Code     string `json:"code,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+}
+
+// DslCheckResponse is the outcome of parsing and validating editor DSL
+type DslCheckResponse struct {
+	Parsed      bool         `json:"parsed"`
+	Valid       bool         `json:"valid"`
+	Cases       []string     `json:"cases"`
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+// AmendmentProposalRequest proposes edited DSL as an amendment of a case
+type AmendmentProposalRequest struct {
+	Case string `json:"case"`
+	DSL  string `json:"dsl"`
+	Note string `json:"note,omitempty"`
+}
+
+// AmendmentReviewRequest accepts or rejects a proposal
+type AmendmentReviewRequest struct {
+	Reviewer string `json:"reviewer,omitempty"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+// HandleEditor serves the browser DSL editor
+// GET /ui/editor[?case=<name>]
+func (h *RagHandler) HandleEditor(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(editorPage) //nolint:errcheck
+}
+
+// HandleDslCheck parses and validates DSL through the Rust engine and
+// returns every issue as a positioned diagnostic
+// POST /dsl/check
+func (h *RagHandler) HandleDslCheck(w http.ResponseWriter, r *http.Request) {
+	dsl, ok := h.decodeDsl(w, r)
+	if !ok {
+		return
+	}
+	client, err := rustclient.NewDslClient("")
+	if err != nil {
+		h.sendError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	defer client.Close()
+
+	resp, err := checkDsl(client, dsl)
+	if err != nil {
+		h.sendRustError(w, err)
+		return
+	}
+	h.sendJSON(w, http.StatusOK, resp)
+}
+
+// HandleDslFormat re-serializes DSL into the engine's canonical layout. DSL
+// that does not parse, or holds forms the engine cannot serialize, is
+// returned unchanged with diagnostics rather than losing those forms.
+// POST /dsl/format
+func (h *RagHandler) HandleDslFormat(w http.ResponseWriter, r *http.Request) {
+	dsl, ok := h.decodeDsl(w, r)
+	if !ok {
+		return
+	}
+	client, err := rustclient.NewDslClient("")
+	if err != nil {
+		h.sendError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	defer client.Close()
+
+	parsed, err := client.ParseDSL(dsl)
+	if err != nil {
+		h.sendRustError(w, err)
+		return
+	}
+	// Cases parsed by the Go parser hold the typed sections only; serializing
+	// them would drop the rest
+	if rustclient.ParsedLocally(parsed) {
+		h.sendError(w, http.StatusServiceUnavailable, "formatting needs the Rust DSL service, which is unavailable")
+		return
+	}
+	if !parsed.Success || len(parsed.Cases) == 0 {
+		h.sendJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{
+			"formatted":   false,
+			"dsl":         dsl,
+			"diagnostics": parseDiagnostics(parsed),
+		})
+		return
+	}
+
+	cases := make([]string, 0, len(parsed.Cases))
+	for _, c := range parsed.Cases {
+		resp, err := client.SerializeCase(c)
+		if err != nil {
+			h.sendRustError(w, err)
+			return
+		}
+		if !resp.Success {
+			h.sendError(w, http.StatusUnprocessableEntity, "failed to format case "+c.Name+": "+resp.Message)
+			return
+		}
+		cases = append(cases, strings.TrimSpace(resp.Dsl))
+	}
+	formatted := strings.Join(cases, "\n\n") + "\n"
+
+	if dropped := droppedForms(dsl, formatted); len(dropped) > 0 {
+		h.sendJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{
+			"formatted": false,
+			"dsl":       dsl,
+			"diagnostics": []Diagnostic{{
+				Severity: "warning",
+				Code:     "FORMAT_LOSSY",
+				Message:  "formatting would drop (" + strings.Join(dropped, "), (") + "); the DSL engine cannot serialize them yet",
+			}},
+		})
+		return
+	}
+	h.sendJSON(w, http.StatusOK, map[string]interface{}{"formatted": true, "dsl": formatted})
+}
+
+// HandleDslSource returns the latest stored DSL of a case for editing
+// GET /dsl/source?case=<name>
+func (h *RagHandler) HandleDslSource(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("case")
+	if name == "" {
+		h.sendError(w, http.StatusBadRequest, "missing 'case' query parameter")
+		return
+	}
+	var latest struct {
+		Version int    `db:"version"`
+		DSL     string `db:"dsl_snapshot"`
+	}
+	err := h.DB.GetContext(r.Context(), &latest, `
+		SELECT version, dsl_snapshot FROM kyc_case_versions
+		WHERE case_name = $1 ORDER BY version DESC LIMIT 1`, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		h.sendError(w, http.StatusNotFound, "case not found: "+name)
+		return
+	}
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.sendJSON(w, http.StatusOK, map[string]interface{}{
+		"case":    name,
+		"version": latest.Version,
+		"dsl":     latest.DSL,
+	})
+}
+
+// HandleAmendmentProposals proposes edited DSL as a case amendment (POST),
+// after it parses and validates, or lists proposals (GET)
+// POST /dsl/proposals
+// GET  /dsl/proposals?case=<name>&status=<pending|accepted|rejected>&limit=<n>
+func (h *RagHandler) HandleAmendmentProposals(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		limit := 50
+		if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && l > 0 {
+			limit = l
+		}
+		status := model.AmendmentProposalStatus(r.URL.Query().Get("status"))
+		proposals, err := amend.ListProposals(r.Context(), h.DB, r.URL.Query().Get("case"), status, limit)
+		if err != nil {
+			h.sendError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		h.sendJSON(w, http.StatusOK, map[string]interface{}{"count": len(proposals), "proposals": proposals})
+
+	case http.MethodPost:
+		var req AmendmentProposalRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.sendError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+			return
+		}
+		if strings.TrimSpace(req.DSL) == "" {
+			h.sendError(w, http.StatusBadRequest, "dsl is required")
+			return
+		}
+
+		client, err := rustclient.NewDslClient("")
+		if err != nil {
+			h.sendError(w, http.StatusServiceUnavailable, err.Error())
+			return
+		}
+		defer client.Close()
+		check, err := checkDsl(client, req.DSL)
+		if err != nil {
+			h.sendRustError(w, err)
+			return
+		}
+		if !check.Valid {
+			h.sendJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{
+				"error":       "the DSL must parse and validate before it can be proposed",
+				"diagnostics": check.Diagnostics,
+			})
+			return
+		}
+		if req.Case == "" && len(check.Cases) == 1 {
+			req.Case = check.Cases[0]
+		}
+		if len(check.Cases) != 1 || check.Cases[0] != req.Case {
+			h.sendError(w, http.StatusBadRequest, "the DSL must hold exactly the one case being amended")
+			return
+		}
+
+		proposedBy := ""
+		if p, ok := auth.PrincipalFromContext(r.Context()); ok {
+			proposedBy = p.Subject
+		}
+		proposal, err := amend.Propose(r.Context(), h.DB, req.Case, req.DSL, req.Note, proposedBy)
+		if errors.Is(err, amend.ErrNoChanges) {
+			h.sendError(w, http.StatusConflict, err.Error())
+			return
+		}
+		if err != nil {
+			h.sendError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		h.sendJSON(w, http.StatusCreated, proposal)
+
+	default:
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// HandleAmendmentProposalReview accepts a proposal, saving its DSL as the
+// next case version, or rejects it
+// POST /dsl/proposals/<id>/accept
+// POST /dsl/proposals/<id>/reject
+func (h *RagHandler) HandleAmendmentProposalReview(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	idStr, action, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/dsl/proposals/"), "/")
+	id, err := strconv.Atoi(idStr)
+	if err != nil || (action != "accept" && action != "reject") {
+		h.sendError(w, http.StatusBadRequest, "expected /dsl/proposals/<id>/accept or /dsl/proposals/<id>/reject")
+		return
+	}
+
+	var req AmendmentReviewRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.sendError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+			return
+		}
+	}
+	reviewer := req.Reviewer
+	if p, ok := auth.PrincipalFromContext(r.Context()); ok && p.Subject != "" {
+		reviewer = p.Subject
+	}
+
+	var proposal *model.AmendmentProposal
+	if action == "accept" {
+		proposal, err = amend.AcceptProposal(r.Context(), h.DB, id, reviewer, req.Comment)
+	} else {
+		proposal, err = amend.RejectProposal(r.Context(), h.DB, id, reviewer, req.Comment)
+	}
+	switch {
+	case errors.Is(err, amend.ErrProposalNotFound):
+		h.sendError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, amend.ErrProposalReviewed), errors.Is(err, amend.ErrProposalStale),
+		errors.Is(err, engine.ErrCaseLocked):
+		h.sendError(w, http.StatusConflict, err.Error())
+	case err != nil:
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+	default:
+		h.sendJSON(w, http.StatusOK, proposal)
+	}
+}
+
+// decodeDsl reads the DSL of a POST /dsl/* request
+func (h *RagHandler) decodeDsl(w http.ResponseWriter, r *http.Request) (string, bool) {
+	if r.Method != http.MethodPost {
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return "", false
+	}
+	var req DslRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+		return "", false
+	}
+	if strings.TrimSpace(req.DSL) == "" {
+		h.sendError(w, http.StatusBadRequest, "dsl is required")
+		return "", false
+	}
+	return req.DSL, true
+}
+
+// sendRustError answers a failed Rust DSL service call: 503 while the
+// service is unavailable, otherwise 502
+func (h *RagHandler) sendRustError(w http.ResponseWriter, err error) {
+	status := http.StatusBadGateway
+	if errors.Is(err, rustclient.ErrUnavailable) {
+		status = http.StatusServiceUnavailable
+	}
+	h.sendError(w, status, err.Error())
+}
+
+// checkDsl parses dsl and, when it parses, validates it
+func checkDsl(client *rustclient.DslClient, dsl string) (*DslCheckResponse, error) {
+	resp := &DslCheckResponse{Cases: []string{}, Diagnostics: []Diagnostic{}}
+
+	parsed, err := client.ParseDSL(dsl)
+	if err != nil {
+		return nil, err
+	}
+	if !parsed.Success {
+		resp.Diagnostics = parseDiagnostics(parsed)
+		return resp, nil
+	}
+	resp.Parsed = true
+	for _, c := range parsed.Cases {
+		resp.Cases = append(resp.Cases, c.Name)
+	}
+
+	result, err := client.ValidateDSL(dsl)
+	if err != nil {
+		return nil, err
+	}
+	resp.Valid = result.Valid
+	resp.Diagnostics = validationDiagnostics(result)
+	return resp, nil
+}
+
+// positionPattern finds the position the engine writes into error text,
+// e.g. "at line 3, column 14" or "3:14"
+var positionPattern = regexp.MustCompile(`(?i)line (\d+)(?:,? col(?:umn)? (\d+))?|\b(\d+):(\d+)\b`)
+
+func parseDiagnostics(parsed *pb.ParseResponse) []Diagnostic {
+	messages := parsed.Errors
+	if len(messages) == 0 && parsed.Message != "" {
+		messages = []string{parsed.Message}
+	}
+	diagnostics := make([]Diagnostic, 0, len(messages))
+	for _, msg := range messages {
+		d := Diagnostic{Severity: "error", Code: "PARSE_ERROR", Message: msg}
+		if m := positionPattern.FindStringSubmatch(msg); m != nil {
+			if m[1] != "" {
+				d.Line, _ = strconv.Atoi(m[1])
+				d.Column, _ = strconv.Atoi(m[2])
+			} else {
+				d.Line, _ = strconv.Atoi(m[3])
+				d.Column, _ = strconv.Atoi(m[4])
+			}
+		}
+		diagnostics = append(diagnostics, d)
+	}
+	return diagnostics
+}
+
+// validationDiagnostics prefers the engine's positioned issues, falling back
+// to its plain error and warning lists
+func validationDiagnostics(result *pb.ValidationResult) []Diagnostic {
+	diagnostics := make([]Diagnostic, 0, len(result.Issues)+len(result.Errors)+len(result.Warnings))
+	for _, issue := range result.Issues {
+		diagnostics = append(diagnostics, Diagnostic{
+			Severity: issue.Severity,
+			Message:  issue.Message,
+			Code:     issue.Code,
+			Line:     int(issue.Line),
+			Column:   int(issue.Column),
+		})
+	}
+	if len(result.Issues) > 0 {
+		return diagnostics
+	}
+	for _, msg := range result.Errors {
+		diagnostics = append(diagnostics, Diagnostic{Severity: "error", Message: msg})
+	}
+	for _, msg := range result.Warnings {
+		diagnostics = append(diagnostics, Diagnostic{Severity: "warning", Message: msg})
+	}
+	return diagnostics
+}
+
+var formPattern = regexp.MustCompile(`\(\s*([a-z][a-z0-9-]*)`)
+
+// droppedForms returns the form keywords that occur fewer times in after
+// than in before, ignoring string contents
+func droppedForms(before, after string) []string {
+	count := func(dsl string) map[string]int {
+		counts := map[string]int{}
+		for _, m := range formPattern.FindAllStringSubmatch(stripStrings(dsl), -1) {
+			counts[m[1]]++
+		}
+		return counts
+	}
+	got := count(after)
+	var dropped []string
+	for keyword, n := range count(before) {
+		if got[keyword] < n {
+			dropped = append(dropped, keyword)
+		}
+	}
+	slices.Sort(dropped)
+	return dropped
+}
+
+func stripStrings(dsl string) string {
+	var b strings.Builder
+	inString := false
+	for i := 0; i < len(dsl); i++ {
+		c := dsl[i]
+		switch {
+		case c == '"' && (i == 0 || dsl[i-1] != '\\'):
+			inString = !inString
+		case !inString:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,251 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adamtc007/KYC-DSL/internal/auth"
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/evidence"
+)
+
+// evidenceFormMemory is how much of a multipart upload is kept in memory;
+// larger files are buffered on disk
+const evidenceFormMemory = 8 << 20
+
+// HandleEvidence lists the evidence of a case or uploads a document file as
+// multipart/form-data: the file in "file" and the metadata in the fields
+// case, document_code, attribute_code, issued_at, expires_at (YYYY-MM-DD),
+// validity_days and description
+// GET /evidence?case=<name> | POST /evidence
+func (h *RagHandler) HandleEvidence(w http.ResponseWriter, r *http.Request) {
+	svc, err := evidence.NewService(h.DB, config.Current().Evidence)
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		caseName := r.URL.Query().Get("case")
+		if caseName == "" {
+			h.sendError(w, http.StatusBadRequest, "case is required")
+			return
+		}
+		all, err := svc.List(r.Context(), caseName)
+		if err != nil {
+			h.sendError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		h.sendJSON(w, http.StatusOK, map[string]interface{}{
+			"case":     caseName,
+			"count":    len(all),
+			"evidence": all,
+		})
+
+	case http.MethodPost:
+		// Allow for the multipart framing and metadata fields around the file
+		r.Body = http.MaxBytesReader(w, r.Body, svc.MaxSize()+1<<20)
+		if err := r.ParseMultipartForm(evidenceFormMemory); err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				h.sendError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("evidence file too large: over %d MB", svc.MaxSize()>>20))
+				return
+			}
+			h.sendError(w, http.StatusBadRequest, "expected multipart/form-data: "+err.Error())
+			return
+		}
+		defer r.MultipartForm.RemoveAll() //nolint:errcheck
+
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			h.sendError(w, http.StatusBadRequest, "file is required")
+			return
+		}
+		defer file.Close()
+
+		upload := evidence.Upload{
+			CaseName:      r.FormValue("case"),
+			DocumentCode:  r.FormValue("document_code"),
+			AttributeCode: r.FormValue("attribute_code"),
+			FileName:      header.Filename,
+			Description:   r.FormValue("description"),
+		}
+		if ct := header.Header.Get("Content-Type"); ct != "" && ct != "application/octet-stream" {
+			upload.ContentType = ct
+		}
+		for _, d := range []struct {
+			field string
+			dst   **time.Time
+		}{{"issued_at", &upload.IssuedAt}, {"expires_at", &upload.ExpiresAt}} {
+			if v := r.FormValue(d.field); v != "" {
+				t, err := time.Parse("2006-01-02", v)
+				if err != nil {
+					h.sendError(w, http.StatusBadRequest, d.field+" must be YYYY-MM-DD")
+					return
+				}
+				*d.dst = &t
+			}
+		}
+		if v := r.FormValue("validity_days"); v != "" {
+			days, err := strconv.Atoi(v)
+			if err != nil || days <= 0 {
+				h.sendError(w, http.StatusBadRequest, "validity_days must be a positive number of days")
+				return
+			}
+			upload.ValidityDays = days
+		}
+
+		e, err := svc.Upload(r.Context(), upload, file)
+		if err != nil {
+			h.sendEvidenceError(w, err)
+			return
+		}
+		h.sendJSON(w, http.StatusCreated, e)
+
+	default:
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// HandleEvidenceItem returns the metadata of evidence, its content, the text
+// extracted from it, or its access log (reviewer). Views and downloads are
+// recorded in the access log. POST /evidence/<id>/attributes goes to
+// HandleEvidenceAttributes.
+// GET /evidence/<id> | GET /evidence/<id>/content | GET /evidence/<id>/text | GET /evidence/<id>/access
+func (h *RagHandler) HandleEvidenceItem(w http.ResponseWriter, r *http.Request) {
+	if strings.HasSuffix(r.URL.Path, "/attributes") {
+		h.HandleEvidenceAttributes(w, r)
+		return
+	}
+	if r.Method != http.MethodGet {
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/evidence/"), "/")
+	id, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || len(parts) > 2 || (len(parts) == 2 && parts[1] != "content" && parts[1] != "text" && parts[1] != "access") {
+		h.sendError(w, http.StatusBadRequest, "expected /evidence/<id>[/content|/text|/access]")
+		return
+	}
+	svc, err := evidence.NewService(h.DB, config.Current().Evidence)
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx := r.Context()
+
+	switch {
+	case len(parts) == 1:
+		e, err := svc.Get(ctx, id)
+		if err != nil {
+			h.sendEvidenceError(w, err)
+			return
+		}
+		h.sendJSON(w, http.StatusOK, e)
+
+	case parts[1] == "text":
+		e, pages, err := svc.Pages(ctx, id)
+		if err != nil {
+			h.sendEvidenceError(w, err)
+			return
+		}
+		h.sendJSON(w, http.StatusOK, map[string]interface{}{
+			"evidence_id": id,
+			"text_status": e.TextStatus,
+			"extractor":   e.TextExtractor,
+			"error":       e.TextError,
+			"count":       len(pages),
+			"pages":       pages,
+		})
+
+	case parts[1] == "access":
+		if p, ok := auth.PrincipalFromContext(ctx); ok && !p.HasRole(auth.RoleReviewer) {
+			h.sendError(w, http.StatusForbidden, "the evidence access log requires the reviewer role")
+			return
+		}
+		accesses, err := svc.AccessLog(ctx, id)
+		if err != nil {
+			h.sendEvidenceError(w, err)
+			return
+		}
+		h.sendJSON(w, http.StatusOK, map[string]interface{}{
+			"evidence_id": id,
+			"count":       len(accesses),
+			"access":      accesses,
+		})
+
+	default:
+		e, content, err := svc.Open(ctx, id)
+		if err != nil {
+			h.sendEvidenceError(w, err)
+			return
+		}
+		defer content.Close()
+		w.Header().Set("Content-Type", e.ContentType)
+		w.Header().Set("Content-Length", strconv.FormatInt(e.SizeBytes, 10))
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": e.FileName}))
+		w.Header().Set("X-Content-SHA256", e.SHA256)
+		w.WriteHeader(http.StatusOK)
+		io.Copy(w, content) //nolint:errcheck // the client went away
+	}
+}
+
+// HandleCaseExpiringDocuments lists the evidence of a case that expires
+// within days (default the expiry checker's warning period) or has expired
+// and has not been refreshed by a later upload of the same document
+// GET /cases/<name>/expiring-documents?days=
+func (h *RagHandler) HandleCaseExpiringDocuments(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	name := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/cases/"), "/expiring-documents")
+	if name == "" || strings.Contains(name, "/") {
+		h.sendError(w, http.StatusBadRequest, "expected /cases/<name>/expiring-documents")
+		return
+	}
+	days := config.Current().Evidence.Expiry.WarningDays
+	if v := r.URL.Query().Get("days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			h.sendError(w, http.StatusBadRequest, "days must be a non-negative number")
+			return
+		}
+		days = n
+	}
+
+	expiring, err := evidence.Expiring(r.Context(), h.DB, name, days)
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.sendJSON(w, http.StatusOK, map[string]interface{}{
+		"case":      name,
+		"days":      days,
+		"count":     len(expiring),
+		"documents": expiring,
+	})
+}
+
+func (h *RagHandler) sendEvidenceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, evidence.ErrNotFound), errors.Is(err, evidence.ErrCaseNotFound), errors.Is(err, evidence.ErrObjectNotFound),
+		errors.Is(err, evidence.ErrCandidateNotFound):
+		h.sendError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, evidence.ErrNoText), errors.Is(err, evidence.ErrExtracting), errors.Is(err, evidence.ErrCandidateDecided):
+		h.sendError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, evidence.ErrInvalid):
+		h.sendError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, evidence.ErrTooLarge):
+		h.sendError(w, http.StatusRequestEntityTooLarge, err.Error())
+	default:
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+	}
+}
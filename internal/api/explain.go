@@ -0,0 +1,143 @@
+package api
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/adamtc007/KYC-DSL/internal/logging"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/ontology"
+	"github.com/adamtc007/KYC-DSL/internal/ranking"
+)
+
+// Fields of an attribute a query token can match in
+const (
+	MatchFieldCode            = "code"
+	MatchFieldSynonym         = "synonym"
+	MatchFieldBusinessContext = "business_context"
+)
+
+// MatchExplanation says why an attribute matched a query (explain=true)
+type MatchExplanation struct {
+	// MatchedTokens are the query tokens found in the attribute
+	MatchedTokens []TokenMatch `json:"matched_tokens"`
+	// MatchedSynonyms are the synonyms sharing a token with the query or
+	// contained in it
+	MatchedSynonyms []string `json:"matched_synonyms"`
+	Distance        float64  `json:"distance"`
+	// Clusters are the curated and semantic clusters containing the attribute
+	Clusters []model.ClusterMembership `json:"clusters"`
+	// InQueryCluster is set when the attribute is a member of the cluster
+	// closest to the query, whose feedback it is scored with
+	InQueryCluster bool               `json:"in_query_cluster"`
+	Score          ScoreContributions `json:"score"`
+}
+
+// TokenMatch is a query token and the fields it was found in
+type TokenMatch struct {
+	Token  string   `json:"token"`
+	Fields []string `json:"fields"`
+}
+
+// ScoreContributions breaks the blended score into its parts:
+// blended = similarity + feedback - penalty
+type ScoreContributions struct {
+	Weight     float64 `json:"feedback_weight"`
+	Similarity float64 `json:"similarity"` // (1-weight) * similarity_score
+	Feedback   float64 `json:"feedback"`   // weight * feedback_score
+	Penalty    float64 `json:"penalty"`
+	Blended    float64 `json:"blended"`
+	// FeedbackDetail is the feedback aggregated for the query cluster
+	FeedbackDetail ranking.FeedbackScore `json:"feedback_detail"`
+}
+
+// explainStopwords are query tokens too common to explain a match
+var explainStopwords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "from": true, "that": true,
+	"this": true, "are": true, "was": true, "what": true, "which": true, "who": true,
+}
+
+// queryTokens splits a query into distinct lower-case tokens worth matching
+func queryTokens(query string) []string {
+	seen := make(map[string]bool)
+	var tokens []string
+	for _, t := range strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len(t) > 2 && !explainStopwords[t] && !seen[t] {
+			seen[t] = true
+			tokens = append(tokens, t)
+		}
+	}
+	return tokens
+}
+
+// explain builds the explanation of a scored result. Cluster memberships are
+// looked up per result, which is why explanations are opt-in.
+func (h *RagHandler) explain(ctx context.Context, query string, signals rankSignals, res *AttributeResult) {
+	tokens := queryTokens(query)
+	lowerQuery := strings.ToLower(query)
+	code := strings.ToLower(strings.ReplaceAll(res.Code, "_", " "))
+	businessContext := strings.ToLower(res.Description)
+
+	e := &MatchExplanation{
+		MatchedTokens:   []TokenMatch{},
+		MatchedSynonyms: []string{},
+		Distance:        res.Distance,
+		Clusters:        []model.ClusterMembership{},
+	}
+	for _, t := range tokens {
+		m := TokenMatch{Token: t}
+		if strings.Contains(code, t) {
+			m.Fields = append(m.Fields, MatchFieldCode)
+		}
+		for _, s := range res.Synonyms {
+			if strings.Contains(strings.ToLower(s), t) {
+				m.Fields = append(m.Fields, MatchFieldSynonym)
+				break
+			}
+		}
+		if strings.Contains(businessContext, t) {
+			m.Fields = append(m.Fields, MatchFieldBusinessContext)
+		}
+		if len(m.Fields) > 0 {
+			e.MatchedTokens = append(e.MatchedTokens, m)
+		}
+	}
+	for _, s := range res.Synonyms {
+		lower := strings.ToLower(s)
+		if strings.Contains(lowerQuery, lower) {
+			e.MatchedSynonyms = append(e.MatchedSynonyms, s)
+			continue
+		}
+		for _, t := range tokens {
+			if strings.Contains(lower, t) {
+				e.MatchedSynonyms = append(e.MatchedSynonyms, s)
+				break
+			}
+		}
+	}
+
+	clusters, err := ontology.NewProfileRepo(h.DB).GetClusterMemberships(ctx, res.Code)
+	if err != nil {
+		logging.FromContext(ctx).Warn("⚠️  Cluster memberships unavailable", "attribute", res.Code, "error", err)
+	} else if clusters != nil {
+		e.Clusters = clusters
+	}
+	for _, c := range e.Clusters {
+		if c.Source == "semantic" && c.ClusterCode == signals.queryCluster {
+			e.InQueryCluster = true
+		}
+	}
+
+	e.Score = ScoreContributions{
+		Weight:         signals.weight,
+		Similarity:     (1 - signals.weight) * res.SimilarityScore,
+		Feedback:       signals.weight * res.FeedbackScore,
+		Penalty:        res.Penalty,
+		Blended:        res.BlendedScore,
+		FeedbackDetail: signals.scores[res.Code],
+	}
+	res.Explanation = e
+}
@@ -0,0 +1,143 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/auth"
+	"github.com/adamtc007/KYC-DSL/internal/events"
+	"github.com/adamtc007/KYC-DSL/internal/feedbackpolicy"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+// HandleFeedbackPolicy returns the feedback policy of the caller's tenant (or
+// ?tenant=, or all with ?tenant=*) and replaces a tenant's policy (admin)
+// GET /rag/feedback/policy | PUT /rag/feedback/policy
+func (h *RagHandler) HandleFeedbackPolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	repo := feedbackpolicy.NewRepo(h.DB)
+
+	switch r.Method {
+	case http.MethodGet:
+		tenant := r.URL.Query().Get("tenant")
+		if tenant == "*" {
+			policies, err := repo.ListPolicies(ctx)
+			if err != nil {
+				h.sendError(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+			h.sendJSON(w, http.StatusOK, map[string]interface{}{
+				"count":    len(policies),
+				"policies": policies,
+			})
+			return
+		}
+		if tenant == "" {
+			tenant = auth.Tenant(ctx, r.Header.Get(auth.TenantHeader))
+		}
+		policy, err := repo.Policy(ctx, tenant)
+		if err != nil {
+			h.sendError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		h.sendJSON(w, http.StatusOK, policy)
+
+	case http.MethodPut:
+		updatedBy := ""
+		if p, ok := auth.PrincipalFromContext(ctx); ok {
+			if !p.HasRole(auth.RoleAdmin) {
+				h.sendError(w, http.StatusForbidden, "changing a feedback policy requires the admin role")
+				return
+			}
+			updatedBy = p.Subject
+		}
+		var req model.FeedbackPolicy
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.sendError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+			return
+		}
+		if req.Tenant == "" {
+			req.Tenant = auth.Tenant(ctx, r.Header.Get(auth.TenantHeader))
+		}
+		req.UpdatedBy = updatedBy
+		saved, err := repo.SavePolicy(ctx, req)
+		if err != nil {
+			h.sendFeedbackPolicyError(w, err)
+			return
+		}
+		events.NotifyAdminAction(ctx, h.DB, "feedback_policy.update", saved.Tenant, nil)
+		h.sendJSON(w, http.StatusOK, saved)
+
+	default:
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// HandleFeedbackActions lists the demotions and review flags raised by
+// confidence-gated feedback
+// GET /rag/feedback/actions?tenant=<tenant>&action=demote|flag&status=open|resolved&limit=<limit>
+func (h *RagHandler) HandleFeedbackActions(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := feedbackpolicy.ActionFilter{
+		Tenant: q.Get("tenant"),
+		Action: q.Get("action"),
+		Status: q.Get("status"),
+		Limit:  50,
+	}
+	if limitStr := q.Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			filter.Limit = l
+		}
+	}
+
+	actions, err := feedbackpolicy.NewRepo(h.DB).ListActions(r.Context(), filter)
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.sendJSON(w, http.StatusOK, map[string]interface{}{
+		"count":   len(actions),
+		"actions": actions,
+	})
+}
+
+// HandleResolveFeedbackAction closes a review flag, or lifts a demotion
+// POST /rag/feedback/actions/<id>/resolve
+func (h *RagHandler) HandleResolveFeedbackAction(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	rest := strings.TrimPrefix(r.URL.Path, "/rag/feedback/actions/")
+	idStr, ok := strings.CutSuffix(rest, "/resolve")
+	id, err := strconv.Atoi(idStr)
+	if !ok || err != nil {
+		h.sendError(w, http.StatusBadRequest, "expected /rag/feedback/actions/<id>/resolve")
+		return
+	}
+
+	resolvedBy := ""
+	if p, ok := auth.PrincipalFromContext(r.Context()); ok {
+		resolvedBy = p.Subject
+	}
+	action, err := feedbackpolicy.NewRepo(h.DB).Resolve(r.Context(), id, resolvedBy)
+	if err != nil {
+		h.sendFeedbackPolicyError(w, err)
+		return
+	}
+	h.sendJSON(w, http.StatusOK, action)
+}
+
+func (h *RagHandler) sendFeedbackPolicyError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, feedbackpolicy.ErrNotFound):
+		h.sendError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, feedbackpolicy.ErrInvalid):
+		h.sendError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+	}
+}
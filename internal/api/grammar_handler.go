@@ -0,0 +1,76 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/adamtc007/KYC-DSL/internal/grammar"
+	"github.com/adamtc007/KYC-DSL/internal/rustclient"
+)
+
+// HandleGrammarHelp documents the DSL grammar constructs: the EBNF of each
+// with its purpose, fields, examples and Markdown hover text. keyword
+// returns a single construct for editor hovers. The grammar stored by
+// `kycctl grammar` is used, falling back to the Rust engine.
+// GET /dsl/grammar/help[?keyword=<construct>]
+func (h *RagHandler) HandleGrammarHelp(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	ebnf, version, err := h.loadGrammar(r)
+	if err != nil {
+		h.sendError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	ref, err := grammar.Build(ebnf, version)
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if keyword := r.URL.Query().Get("keyword"); keyword != "" {
+		construct, ok := ref.Construct(keyword)
+		if !ok {
+			h.sendError(w, http.StatusNotFound, "unknown grammar construct: "+keyword)
+			return
+		}
+		h.sendJSON(w, http.StatusOK, map[string]interface{}{
+			"grammar":   ref.Grammar,
+			"version":   ref.Version,
+			"construct": construct,
+		})
+		return
+	}
+	h.sendJSON(w, http.StatusOK, ref)
+}
+
+// loadGrammar returns the stored grammar, or asks the Rust engine when none
+// has been stored yet
+func (h *RagHandler) loadGrammar(r *http.Request) (string, string, error) {
+	var stored struct {
+		EBNF    string `db:"ebnf"`
+		Version string `db:"version"`
+	}
+	err := h.DB.GetContext(r.Context(), &stored,
+		`SELECT ebnf, COALESCE(version, '') AS version FROM kyc_grammar WHERE name = $1`, grammar.Name)
+	if err == nil {
+		return stored.EBNF, stored.Version, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return "", "", err
+	}
+
+	client, err := rustclient.NewDslClient("")
+	if err != nil {
+		return "", "", err
+	}
+	defer client.Close()
+	resp, err := client.GetGrammar()
+	if err != nil {
+		return "", "", err
+	}
+	return resp.Ebnf, resp.Version, nil
+}
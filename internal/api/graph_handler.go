@@ -0,0 +1,45 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/graph"
+)
+
+// HandleUboRollup computes effective beneficial ownership of an entity per
+// ultimate owner, flagging owners at or above the threshold (default 25)
+// GET /graph/ubo?entity=<id>&threshold=<pct>
+func (h *RagHandler) HandleUboRollup(w http.ResponseWriter, r *http.Request) {
+	entityID := strings.TrimSpace(r.URL.Query().Get("entity"))
+	if entityID == "" {
+		h.sendError(w, http.StatusBadRequest, "missing required parameter: entity")
+		return
+	}
+
+	threshold := graph.DefaultUBOThreshold
+	if thresholdStr := r.URL.Query().Get("threshold"); thresholdStr != "" {
+		t, err := strconv.ParseFloat(thresholdStr, 64)
+		if err != nil || t <= 0 || t > 100 {
+			h.sendError(w, http.StatusBadRequest, "threshold must be a percentage between 0 and 100")
+			return
+		}
+		threshold = t
+	}
+
+	ctx := r.Context()
+
+	rollup, err := graph.NewRepo(h.DB).ComputeUbo(ctx, entityID, threshold)
+	if err != nil {
+		if errors.Is(err, graph.ErrEntityNotFound) {
+			h.sendError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		h.sendError(w, http.StatusInternalServerError, "failed to compute UBO rollup: "+err.Error())
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, rollup)
+}
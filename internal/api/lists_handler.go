@@ -0,0 +1,163 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/adamtc007/KYC-DSL/internal/auth"
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/lists"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/reeval"
+)
+
+// ManagedListUpdate replaces the members, source and effective dates of a list
+type ManagedListUpdate struct {
+	Description   string   `json:"description,omitempty"`
+	Members       []string `json:"members"`
+	Source        string   `json:"source,omitempty"`
+	EffectiveFrom string   `json:"effective_from,omitempty"` // YYYY-MM-DD, default today
+	EffectiveTo   string   `json:"effective_to,omitempty"`   // YYYY-MM-DD, open-ended if empty
+}
+
+// HandleLists returns all managed lists referenced by rules with in_list
+// GET /lists
+func (h *RagHandler) HandleLists(w http.ResponseWriter, r *http.Request) {
+	all, err := lists.NewRepo(h.DB).List(r.Context())
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.sendJSON(w, http.StatusOK, map[string]interface{}{
+		"count": len(all),
+		"lists": all,
+	})
+}
+
+// HandleList returns a managed list (with its versions when history=true),
+// updates it, or re-evaluates the derived attributes referencing it. An
+// update re-evaluates them too; updates require the reviewer role.
+// GET /lists/<name>?history=true | POST /lists/<name> | POST /lists/<name>/reevaluate
+func (h *RagHandler) HandleList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	repo := lists.NewRepo(h.DB)
+
+	name, reevaluate := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/lists/"), "/reevaluate")
+	if name == "" || strings.Contains(name, "/") {
+		h.sendError(w, http.StatusBadRequest, "expected /lists/<name>")
+		return
+	}
+
+	if r.Method == http.MethodGet && !reevaluate {
+		var result interface{}
+		var err error
+		if r.URL.Query().Get("history") == "true" {
+			result, err = repo.History(ctx, name)
+		} else {
+			result, err = repo.Get(ctx, name)
+		}
+		if err != nil {
+			if errors.Is(err, lists.ErrNotFound) {
+				h.sendError(w, http.StatusNotFound, err.Error())
+				return
+			}
+			h.sendError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		h.sendJSON(w, http.StatusOK, result)
+		return
+	}
+	if r.Method != http.MethodPost {
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	changedBy := ""
+	if p, ok := auth.PrincipalFromContext(ctx); ok {
+		if !p.HasRole(auth.RoleReviewer) {
+			h.sendError(w, http.StatusForbidden, "updating lists requires the reviewer role")
+			return
+		}
+		changedBy = p.Subject
+	}
+
+	var list *model.ManagedList
+	if reevaluate {
+		l, err := repo.Get(ctx, name)
+		if err != nil {
+			if errors.Is(err, lists.ErrNotFound) {
+				h.sendError(w, http.StatusNotFound, err.Error())
+				return
+			}
+			h.sendError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if _, err := reeval.NewQueue(h.DB).ListChanged(ctx, name); err != nil {
+			h.sendError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		list = l
+	} else {
+		var req ManagedListUpdate
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.sendError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+			return
+		}
+		update := model.ManagedList{
+			Name:        name,
+			Description: req.Description,
+			Members:     req.Members,
+			Source:      req.Source,
+		}
+		if req.EffectiveFrom != "" {
+			t, err := time.Parse("2006-01-02", req.EffectiveFrom)
+			if err != nil {
+				h.sendError(w, http.StatusBadRequest, "effective_from must be YYYY-MM-DD")
+				return
+			}
+			update.EffectiveFrom = t
+		}
+		if req.EffectiveTo != "" {
+			t, err := time.Parse("2006-01-02", req.EffectiveTo)
+			if err != nil {
+				h.sendError(w, http.StatusBadRequest, "effective_to must be YYYY-MM-DD")
+				return
+			}
+			update.EffectiveTo = &t
+		}
+
+		l, err := repo.Put(ctx, update, changedBy)
+		if err != nil {
+			if errors.Is(err, lists.ErrInvalid) {
+				h.sendError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			h.sendError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		list = l
+	}
+
+	// Saving the list queued its dependents; process them now so the
+	// caller sees the impact
+	results, err := reeval.NewWorker(h.DB, config.Current().Reevaluation).RunFor(ctx, reeval.ReasonList, name)
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, "re-evaluation failed: "+err.Error())
+		return
+	}
+	changed := 0
+	for _, res := range results {
+		if res.Changed {
+			changed++
+		}
+	}
+	h.sendJSON(w, http.StatusOK, map[string]interface{}{
+		"list":         list,
+		"reevaluated":  len(results),
+		"changed":      changed,
+		"reevaluation": results,
+	})
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/adamtc007/KYC-DSL/internal/auth"
+	"github.com/adamtc007/KYC-DSL/internal/logging"
+	"github.com/adamtc007/KYC-DSL/internal/mappings"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+// HandleExternalMappings lists the ISO 20022 and FIBO mappings of attribute
+// codes, saves mappings, or deletes one. Changes require the reviewer role.
+// GET /rag/mappings?attribute=<code>&standard=<iso20022|fibo>
+// POST /rag/mappings (JSON array of mappings)
+// DELETE /rag/mappings?attribute=<code>&standard=<standard>&external_id=<id>
+func (h *RagHandler) HandleExternalMappings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	repo := mappings.NewRepo(h.DB)
+	q := r.URL.Query()
+
+	if r.Method == http.MethodGet {
+		all, err := repo.List(ctx, q.Get("attribute"), q.Get("standard"))
+		if err != nil {
+			h.sendError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		h.sendJSON(w, http.StatusOK, map[string]interface{}{
+			"count":    len(all),
+			"mappings": all,
+		})
+		return
+	}
+	if r.Method != http.MethodPost && r.Method != http.MethodDelete {
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	updatedBy := ""
+	if p, ok := auth.PrincipalFromContext(ctx); ok {
+		if !p.HasRole(auth.RoleReviewer) {
+			h.sendError(w, http.StatusForbidden, "changing external mappings requires the reviewer role")
+			return
+		}
+		updatedBy = p.Subject
+	}
+
+	if r.Method == http.MethodDelete {
+		err := repo.Delete(ctx, q.Get("attribute"), q.Get("standard"), q.Get("external_id"))
+		if errors.Is(err, mappings.ErrNotFound) {
+			h.sendError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		if err != nil {
+			h.sendError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		h.sendJSON(w, http.StatusOK, map[string]interface{}{"status": "deleted"})
+		return
+	}
+
+	var req []model.ExternalMapping
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+		return
+	}
+	if len(req) == 0 {
+		h.sendError(w, http.StatusBadRequest, "expected a JSON array of mappings")
+		return
+	}
+	if err := repo.Put(ctx, req, updatedBy); err != nil {
+		if errors.Is(err, mappings.ErrInvalid) {
+			h.sendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.sendJSON(w, http.StatusOK, map[string]interface{}{
+		"status": "saved",
+		"count":  len(req),
+	})
+}
+
+// HandleExternalMappingsExport downloads the mappings of a standard (all
+// when omitted) for a data catalog or integration, as CSV or JSON
+// GET /rag/mappings/export?standard=<iso20022|fibo>&format=<csv|json>
+func (h *RagHandler) HandleExternalMappingsExport(w http.ResponseWriter, r *http.Request) {
+	standard := r.URL.Query().Get("standard")
+	all, err := mappings.NewRepo(h.DB).List(r.Context(), "", standard)
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	name := "attribute_mappings"
+	if standard != "" {
+		name += "_" + standard
+	}
+	switch r.URL.Query().Get("format") {
+	case "", "csv":
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", `attachment; filename="`+name+`.csv"`)
+		if err := mappings.WriteCSV(w, all); err != nil {
+			logging.FromContext(r.Context()).Warn("⚠️  Mapping export interrupted", "error", err)
+		}
+	case "json":
+		w.Header().Set("Content-Disposition", `attachment; filename="`+name+`.json"`)
+		h.sendJSON(w, http.StatusOK, all)
+	default:
+		h.sendError(w, http.StatusBadRequest, "format must be csv or json")
+	}
+}
+
+// attachMappings sets the external mappings of attribute results. Mappings
+// are informational, so a lookup failure is logged and leaves them unset.
+func (h *RagHandler) attachMappings(ctx context.Context, results []AttributeResult) {
+	codes := make([]string, len(results))
+	for i, res := range results {
+		codes[i] = res.Code
+	}
+	byCode, err := mappings.NewRepo(h.DB).ForAttributes(ctx, codes)
+	if err != nil {
+		logging.FromContext(ctx).Warn("⚠️  External mappings unavailable", "error", err)
+		return
+	}
+	for i := range results {
+		results[i].ExternalMappings = byCode[results[i].Code]
+	}
+}
@@ -0,0 +1,33 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/adamtc007/KYC-DSL/internal/triggers"
+)
+
+// HandleMaterialChanges lists the review amendments opened on cases by
+// material changes to the entity graph, with their evidence
+// GET /review/material-changes?case=<case_id>&limit=<limit>
+func (h *RagHandler) HandleMaterialChanges(w http.ResponseWriter, r *http.Request) {
+	caseID := r.URL.Query().Get("case")
+
+	limit := 50
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+
+	events, err := triggers.NewRepo(h.DB).Events(r.Context(), caseID, limit)
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, "failed to list material changes: "+err.Error())
+		return
+	}
+	h.sendJSON(w, http.StatusOK, map[string]interface{}{
+		"case":   caseID,
+		"count":  len(events),
+		"events": events,
+	})
+}
@@ -0,0 +1,282 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/auth"
+	"github.com/adamtc007/KYC-DSL/internal/logging"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/ontology"
+	"github.com/adamtc007/KYC-DSL/internal/rag"
+)
+
+// MetadataProposalSubmitRequest submits a batch of proposed metadata changes
+type MetadataProposalSubmitRequest struct {
+	BatchID   string                        `json:"batch_id"`
+	Source    model.MetadataProposalSource  `json:"source"`
+	Proposals []MetadataProposalSubmitEntry `json:"proposals"`
+}
+
+// MetadataProposalSubmitEntry is one attribute's proposed fields
+type MetadataProposalSubmitEntry struct {
+	AttributeCode string               `json:"attribute_code"`
+	Proposed      model.MetadataFields `json:"proposed"`
+}
+
+// MetadataDecisionRequest carries reviewer decisions for one proposal
+type MetadataDecisionRequest struct {
+	Reviewer  string                `json:"reviewer,omitempty"`
+	Decisions []model.FieldDecision `json:"decisions"`
+}
+
+// HandleSubmitMetadataProposals queues proposed metadata changes for review
+// POST /rag/metadata/proposals
+func (h *RagHandler) HandleSubmitMetadataProposals(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req MetadataProposalSubmitRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+		return
+	}
+	if req.BatchID == "" {
+		h.sendError(w, http.StatusBadRequest, "batch_id is required")
+		return
+	}
+	if len(req.Proposals) == 0 {
+		h.sendError(w, http.StatusBadRequest, "at least one proposal is required")
+		return
+	}
+	if req.Source == "" {
+		req.Source = model.ProposalSourceImport
+	}
+
+	submittedBy := ""
+	if p, ok := auth.PrincipalFromContext(r.Context()); ok {
+		submittedBy = p.Subject
+	}
+
+	ctx := r.Context()
+	repo := ontology.NewMetadataReviewRepo(h.DB)
+
+	ids := make([]int, 0, len(req.Proposals))
+	for _, entry := range req.Proposals {
+		if entry.AttributeCode == "" {
+			h.sendError(w, http.StatusBadRequest, "attribute_code is required for every proposal")
+			return
+		}
+		id, err := repo.CreateProposal(ctx, model.MetadataProposal{
+			BatchID:       req.BatchID,
+			AttributeCode: entry.AttributeCode,
+			Source:        req.Source,
+			Proposed:      entry.Proposed,
+			SubmittedBy:   submittedBy,
+		})
+		if err != nil {
+			h.sendError(w, http.StatusInternalServerError, "failed to queue proposal: "+err.Error())
+			return
+		}
+		ids = append(ids, id)
+	}
+
+	h.sendJSON(w, http.StatusOK, map[string]interface{}{
+		"status":       "ok",
+		"batch_id":     req.BatchID,
+		"count":        len(ids),
+		"proposal_ids": ids,
+	})
+}
+
+// HandleMetadataReviewFeed lists pending proposals as field-level diffs
+// GET /rag/metadata/review?batch=<batch_id>&limit=<limit>
+func (h *RagHandler) HandleMetadataReviewFeed(w http.ResponseWriter, r *http.Request) {
+	batchID := r.URL.Query().Get("batch")
+
+	limit := 50
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+
+	ctx := r.Context()
+	repo := ontology.NewMetadataReviewRepo(h.DB)
+
+	proposals, err := repo.ListPendingProposals(ctx, batchID, limit)
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, "failed to list proposals: "+err.Error())
+		return
+	}
+
+	items := make([]model.MetadataReviewItem, 0, len(proposals))
+	for _, p := range proposals {
+		diffs, err := h.pendingDiffs(ctx, p)
+		if err != nil {
+			h.sendError(w, http.StatusInternalServerError, "failed to compute diffs: "+err.Error())
+			return
+		}
+		items = append(items, model.MetadataReviewItem{MetadataProposal: p, Diffs: diffs})
+	}
+
+	h.sendJSON(w, http.StatusOK, map[string]interface{}{
+		"batch": batchID,
+		"count": len(items),
+		"items": items,
+	})
+}
+
+// HandleMetadataReviewBatches returns review progress per batch
+// GET /rag/metadata/batches
+func (h *RagHandler) HandleMetadataReviewBatches(w http.ResponseWriter, r *http.Request) {
+	repo := ontology.NewMetadataReviewRepo(h.DB)
+	batches, err := repo.ListBatches(r.Context())
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, "failed to list batches: "+err.Error())
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, map[string]interface{}{
+		"count":   len(batches),
+		"batches": batches,
+	})
+}
+
+// HandleMetadataReviewDecision records accept/reject decisions per field and
+// applies accepted fields (re-embedding the attribute when needed)
+// POST /rag/metadata/review/<proposal_id>/decisions
+func (h *RagHandler) HandleMetadataReviewDecision(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/rag/metadata/review/")
+	idStr, ok := strings.CutSuffix(path, "/decisions")
+	proposalID, err := strconv.Atoi(idStr)
+	if !ok || err != nil {
+		h.sendError(w, http.StatusBadRequest, "expected /rag/metadata/review/<proposal_id>/decisions")
+		return
+	}
+
+	var req MetadataDecisionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+		return
+	}
+	if len(req.Decisions) == 0 {
+		h.sendError(w, http.StatusBadRequest, "at least one decision is required")
+		return
+	}
+
+	reviewer := req.Reviewer
+	if p, ok := auth.PrincipalFromContext(r.Context()); ok && p.Subject != "" {
+		reviewer = p.Subject
+	}
+	if reviewer == "" {
+		h.sendError(w, http.StatusBadRequest, "reviewer is required")
+		return
+	}
+
+	ctx := r.Context()
+	repo := ontology.NewMetadataReviewRepo(h.DB)
+
+	proposal, err := repo.GetProposal(ctx, proposalID)
+	if err != nil {
+		h.sendError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if proposal.Status != model.ProposalPending {
+		h.sendError(w, http.StatusConflict, "proposal already reviewed: "+string(proposal.Status))
+		return
+	}
+
+	diffs, err := h.pendingDiffs(ctx, *proposal)
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, "failed to compute diffs: "+err.Error())
+		return
+	}
+
+	// Build the updated metadata from the accepted fields
+	var updated *model.AttributeMetadata
+	for _, dec := range req.Decisions {
+		if !dec.Accept {
+			continue
+		}
+		if updated == nil {
+			updated = h.currentMetadata(ctx, proposal.AttributeCode)
+			updated.Embedding = nil
+		}
+		proposal.Proposed.ApplyField(dec.Field, updated)
+	}
+
+	// Accepted changes alter the embedding text, so regenerate the embedding.
+	// While the embeddings provider is unavailable the old embedding is kept;
+	// its content hash no longer matches, so the re-embedding worker
+	// regenerates it once the provider is back.
+	embedding := "unchanged"
+	if updated != nil {
+		vec, err := h.Embedder.GenerateEmbedding(ctx, *updated)
+		switch {
+		case errors.Is(err, rag.ErrUnavailable):
+			logging.FromContext(ctx).Warn("⚠️  Embedding queued for re-embedding", "attribute", proposal.AttributeCode, "error", err)
+			embedding = "queued"
+		case err != nil:
+			h.sendError(w, http.StatusInternalServerError, "failed to regenerate embedding: "+err.Error())
+			return
+		default:
+			updated.Embedding, embedding = vec, "regenerated"
+		}
+	}
+
+	status, err := repo.ApplyDecisions(ctx, proposalID, diffs, req.Decisions, reviewer, updated)
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "failed to apply decisions: "+err.Error())
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, map[string]interface{}{
+		"status":         "ok",
+		"proposal_id":    proposalID,
+		"attribute_code": proposal.AttributeCode,
+		"review_status":  status,
+		"reviewer":       reviewer,
+		"embedding":      embedding,
+	})
+}
+
+// pendingDiffs returns the field diffs of a proposal that have no decision yet
+func (h *RagHandler) pendingDiffs(ctx context.Context, p model.MetadataProposal) ([]model.FieldDiff, error) {
+	decided, err := ontology.NewMetadataReviewRepo(h.DB).GetDecidedFields(ctx, p.ID)
+	if err != nil {
+		return nil, err
+	}
+	skip := make(map[string]bool, len(decided))
+	for _, f := range decided {
+		skip[f] = true
+	}
+
+	diffs := make([]model.FieldDiff, 0)
+	for _, d := range p.Proposed.Diff(h.currentMetadata(ctx, p.AttributeCode)) {
+		if !skip[d.Field] {
+			diffs = append(diffs, d)
+		}
+	}
+	return diffs, nil
+}
+
+// currentMetadata returns the stored metadata, or an empty record for new attributes
+func (h *RagHandler) currentMetadata(ctx context.Context, attributeCode string) *model.AttributeMetadata {
+	current, err := ontology.NewMetadataRepo(h.DB).GetMetadata(ctx, attributeCode)
+	if err != nil {
+		return &model.AttributeMetadata{AttributeCode: attributeCode}
+	}
+	return current
+}
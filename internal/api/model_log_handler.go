@@ -0,0 +1,63 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/adamtc007/KYC-DSL/internal/auth"
+	"github.com/adamtc007/KYC-DSL/internal/events"
+	"github.com/adamtc007/KYC-DSL/internal/modellog"
+)
+
+// HandleModelLog lists logged prompts and model responses. Model I/O may
+// contain client data, so unlike other endpoints it is refused when
+// authentication is not configured; with authentication it needs the admin
+// role, and every read is logged.
+// GET /rag/model_log?feature=<feature>&agent=<name>&since=<RFC3339>&limit=<limit>
+func (h *RagHandler) HandleModelLog(w http.ResponseWriter, r *http.Request) {
+	p, ok := auth.PrincipalFromContext(r.Context())
+	if !ok {
+		h.sendError(w, http.StatusForbidden, "the model log is only served with authentication enabled")
+		return
+	}
+	if !p.HasRole(auth.RoleAdmin) {
+		h.sendError(w, http.StatusForbidden, "reading the model log requires the admin role")
+		return
+	}
+
+	q := r.URL.Query()
+	filter := modellog.Filter{Feature: q.Get("feature"), Agent: q.Get("agent"), Limit: 50}
+	if limitStr := q.Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			filter.Limit = min(l, 500)
+		}
+	}
+	if s := q.Get("since"); s != "" {
+		since, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			h.sendError(w, http.StatusBadRequest, "since must be an RFC 3339 timestamp")
+			return
+		}
+		filter.Since = &since
+	}
+
+	entries, err := modellog.List(r.Context(), h.DB, filter)
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	slog.Info("🔏 Model log read", "subject", p.Subject, "agent", p.Agent,
+		"feature", filter.Feature, "filter_agent", filter.Agent, "count", len(entries))
+	events.NotifyAdminAction(r.Context(), h.DB, "model_log.read", "model_io_log", map[string]interface{}{
+		"feature": filter.Feature,
+		"agent":   filter.Agent,
+		"count":   len(entries),
+	})
+
+	h.sendJSON(w, http.StatusOK, map[string]interface{}{
+		"count":   len(entries),
+		"entries": entries,
+	})
+}
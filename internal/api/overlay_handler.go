@@ -0,0 +1,66 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/adamtc007/KYC-DSL/internal/auth"
+	"github.com/adamtc007/KYC-DSL/internal/events"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/overlay"
+)
+
+// HandleOntologyOverlay returns the ontology overlay of the caller's tenant
+// (or ?tenant=) and adds or updates entries of a tenant's overlay (admin)
+// GET /ontology/overlay | PUT /ontology/overlay
+func (h *RagHandler) HandleOntologyOverlay(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	repo := overlay.NewRepo(h.DB)
+
+	switch r.Method {
+	case http.MethodGet:
+		tenant := r.URL.Query().Get("tenant")
+		if tenant == "" {
+			tenant = auth.Tenant(ctx, r.Header.Get(auth.TenantHeader))
+		}
+		o, err := repo.Get(ctx, tenant)
+		if err != nil {
+			h.sendError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		h.sendJSON(w, http.StatusOK, o)
+
+	case http.MethodPut:
+		updatedBy := ""
+		if p, ok := auth.PrincipalFromContext(ctx); ok {
+			if !p.HasRole(auth.RoleAdmin) {
+				h.sendError(w, http.StatusForbidden, "changing an ontology overlay requires the admin role")
+				return
+			}
+			updatedBy = p.Subject
+		}
+		var req model.OntologyOverlay
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.sendError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+			return
+		}
+		if req.Tenant == "" {
+			req.Tenant = auth.Tenant(ctx, r.Header.Get(auth.TenantHeader))
+		}
+		saved, err := repo.Apply(ctx, req, updatedBy)
+		if errors.Is(err, overlay.ErrInvalid) {
+			h.sendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if err != nil {
+			h.sendError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		events.NotifyAdminAction(ctx, h.DB, "ontology_overlay.apply", saved.Tenant, nil)
+		h.sendJSON(w, http.StatusOK, saved)
+
+	default:
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
@@ -0,0 +1,159 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/auth"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/policypack"
+)
+
+// HandlePolicyPacks returns the active jurisdiction policy packs
+// (?jurisdiction= filters them) or drafts a new version of a pack from the
+// posted pack; drafting requires the reviewer role.
+// GET /policy-packs | POST /policy-packs
+func (h *RagHandler) HandlePolicyPacks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	repo := policypack.NewRepo(h.DB)
+
+	switch r.Method {
+	case http.MethodGet:
+		packs, err := repo.List(ctx, r.URL.Query().Get("jurisdiction"))
+		if err != nil {
+			h.sendError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		h.sendJSON(w, http.StatusOK, map[string]interface{}{
+			"count": len(packs),
+			"packs": packs,
+		})
+
+	case http.MethodPost:
+		changedBy, ok := h.policyPackReviewer(w, r)
+		if !ok {
+			return
+		}
+		var pack model.PolicyPack
+		if err := json.NewDecoder(r.Body).Decode(&pack); err != nil {
+			h.sendError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+			return
+		}
+		draft, err := repo.Draft(ctx, pack, changedBy)
+		if err != nil {
+			h.sendPolicyPackError(w, err)
+			return
+		}
+		h.sendJSON(w, http.StatusCreated, draft)
+
+	default:
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// HandlePolicyPack returns a pack (its active version, or ?version=N),
+// lists its versions, or activates or retires a version. Activating a
+// draft retires the version that was active; status changes require the
+// reviewer role.
+// GET /policy-packs/<code> | GET /policy-packs/<code>/versions |
+// POST /policy-packs/<code>/versions/<n>/activate | POST /policy-packs/<code>/versions/<n>/retire
+func (h *RagHandler) HandlePolicyPack(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	repo := policypack.NewRepo(h.DB)
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/policy-packs/"), "/")
+	code := strings.ToUpper(parts[0])
+	valid := code != "" && (len(parts) == 1 ||
+		(len(parts) == 2 && parts[1] == "versions") ||
+		(len(parts) == 4 && parts[1] == "versions" && (parts[3] == "activate" || parts[3] == "retire")))
+	if !valid {
+		h.sendError(w, http.StatusBadRequest, "expected /policy-packs/<code>[/versions[/<n>/activate|retire]]")
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && len(parts) == 1:
+		version := 0
+		if v := r.URL.Query().Get("version"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 {
+				h.sendError(w, http.StatusBadRequest, "version must be a positive integer")
+				return
+			}
+			version = n
+		}
+		pack, err := repo.Get(ctx, code, version)
+		if err != nil {
+			h.sendPolicyPackError(w, err)
+			return
+		}
+		h.sendJSON(w, http.StatusOK, pack)
+
+	case r.Method == http.MethodGet && len(parts) == 2:
+		versions, err := repo.Versions(ctx, code)
+		if err != nil {
+			h.sendPolicyPackError(w, err)
+			return
+		}
+		h.sendJSON(w, http.StatusOK, map[string]interface{}{
+			"code":     code,
+			"count":    len(versions),
+			"versions": versions,
+		})
+
+	case r.Method == http.MethodPost && len(parts) == 4:
+		version, err := strconv.Atoi(parts[2])
+		if err != nil || version < 1 {
+			h.sendError(w, http.StatusBadRequest, "version must be a positive integer")
+			return
+		}
+		changedBy, ok := h.policyPackReviewer(w, r)
+		if !ok {
+			return
+		}
+		var pack *model.PolicyPack
+		if parts[3] == "activate" {
+			pack, err = repo.Activate(ctx, code, version, changedBy)
+		} else {
+			pack, err = repo.Retire(ctx, code, version, changedBy)
+		}
+		if err != nil {
+			h.sendPolicyPackError(w, err)
+			return
+		}
+		h.sendJSON(w, http.StatusOK, pack)
+
+	default:
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// policyPackReviewer returns who is changing policy packs, refusing callers
+// without the reviewer role
+func (h *RagHandler) policyPackReviewer(w http.ResponseWriter, r *http.Request) (string, bool) {
+	p, ok := auth.PrincipalFromContext(r.Context())
+	if !ok {
+		return "", true
+	}
+	if !p.HasRole(auth.RoleReviewer) {
+		h.sendError(w, http.StatusForbidden, "changing policy packs requires the reviewer role")
+		return "", false
+	}
+	return p.Subject, true
+}
+
+func (h *RagHandler) sendPolicyPackError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, policypack.ErrNotFound):
+		h.sendError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, policypack.ErrInvalid):
+		h.sendError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, policypack.ErrStatus):
+		h.sendError(w, http.StatusConflict, err.Error())
+	default:
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+	}
+}
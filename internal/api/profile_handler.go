@@ -0,0 +1,134 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/ontology"
+)
+
+// AttributeProfileResponse is everything the UI shows on an attribute's
+// profile card, in one response
+type AttributeProfileResponse struct {
+	Attribute   AttributeResult                 `json:"attribute"`
+	Documents   []DocumentResult                `json:"documents"`
+	Regulations []RegulationResult              `json:"regulations"`
+	DerivedFrom []model.AttributeDerivationLink `json:"derived_from"`
+	Feeds       []model.AttributeDerivationLink `json:"feeds"`
+	Clusters    []model.ClusterMembership       `json:"clusters"`
+	Feedback    *model.AttributeFeedbackStats   `json:"feedback"`
+	Usage       *model.TermUsage                `json:"usage,omitempty"`
+}
+
+// HandleAttributeProfile combines metadata, linked documents and regulations,
+// derivations, cluster membership, feedback stats and usage counts
+// GET /rag/attribute/<code>/profile
+func (h *RagHandler) HandleAttributeProfile(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/rag/attribute/")
+	attributeCode := strings.TrimSpace(strings.TrimSuffix(path, "/profile"))
+
+	if attributeCode == "" {
+		h.sendError(w, http.StatusBadRequest, "missing attribute code in path")
+		return
+	}
+
+	ctx := r.Context()
+
+	metadata, err := ontology.NewMetadataRepo(h.DB).GetMetadata(ctx, attributeCode)
+	if err != nil {
+		h.sendError(w, http.StatusNotFound, "attribute not found: "+attributeCode)
+		return
+	}
+
+	response := AttributeProfileResponse{
+		Attribute: AttributeResult{
+			Code:                metadata.AttributeCode,
+			RiskLevel:           metadata.RiskLevel,
+			DataType:            metadata.DataType,
+			Description:         strings.TrimSpace(metadata.BusinessContext),
+			Synonyms:            metadata.Synonyms,
+			RegulatoryCitations: metadata.RegulatoryCitations,
+			ExampleValues:       metadata.ExampleValues,
+		},
+		Documents:   []DocumentResult{},
+		Regulations: []RegulationResult{},
+		DerivedFrom: []model.AttributeDerivationLink{},
+		Feeds:       []model.AttributeDerivationLink{},
+	}
+
+	multiModal := ontology.NewMultiModalRepo(h.DB)
+	docs, err := multiModal.GetDocumentsByAttribute(ctx, attributeCode)
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, "failed to fetch documents: "+err.Error())
+		return
+	}
+	for _, doc := range docs {
+		response.Documents = append(response.Documents, DocumentResult{
+			Code:         doc.Code,
+			Title:        doc.Title,
+			Jurisdiction: doc.Jurisdiction,
+			Description:  strings.TrimSpace(doc.Description),
+			DocType:      doc.DocType,
+		})
+	}
+
+	regs, err := multiModal.GetRegulationsByAttribute(ctx, attributeCode)
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, "failed to fetch regulations: "+err.Error())
+		return
+	}
+	for _, reg := range regs {
+		response.Regulations = append(response.Regulations, RegulationResult{
+			Code:     reg.Code,
+			Title:    reg.Title,
+			Citation: reg.Citation,
+			Summary:  strings.TrimSpace(reg.Summary),
+			Region:   reg.Region,
+		})
+	}
+
+	profiles := ontology.NewProfileRepo(h.DB)
+	links, err := profiles.GetDerivationLinks(ctx, attributeCode)
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, "failed to fetch derivations: "+err.Error())
+		return
+	}
+	for _, link := range links {
+		if link.DerivedAttributeCode == attributeCode {
+			response.DerivedFrom = append(response.DerivedFrom, link)
+		} else {
+			response.Feeds = append(response.Feeds, link)
+		}
+	}
+
+	if response.Clusters, err = profiles.GetClusterMemberships(ctx, attributeCode); err != nil {
+		h.sendError(w, http.StatusInternalServerError, "failed to fetch clusters: "+err.Error())
+		return
+	}
+	if response.Clusters == nil {
+		response.Clusters = []model.ClusterMembership{}
+	}
+
+	if response.Feedback, err = profiles.GetFeedbackStats(ctx, attributeCode); err != nil {
+		h.sendError(w, http.StatusInternalServerError, "failed to fetch feedback stats: "+err.Error())
+		return
+	}
+
+	// Metadata can exist before the attribute itself is in the dictionary
+	usage, err := ontology.NewUsageRepo(h.DB).GetTermUsage(ctx, model.TermTypeAttribute, attributeCode)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		h.sendError(w, http.StatusInternalServerError, "failed to fetch usage: "+err.Error())
+		return
+	}
+	response.Usage = usage
+
+	if err := h.redactExamples(r, []*AttributeResult{&response.Attribute}); err != nil {
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/adamtc007/KYC-DSL/internal/logging"
+	"github.com/adamtc007/KYC-DSL/internal/provenance"
+)
+
+// provenanceOf looks up the provenance of records by code when the request
+// asks for it (include_provenance=true), and reports the ontology version in
+// the X-Ontology-Version header. Provenance is informational, so a lookup
+// failure is logged and returns nil.
+func (h *RagHandler) provenanceOf(w http.ResponseWriter, r *http.Request, table string, codes []string) map[string]provenance.Record {
+	if !provenance.Requested(r) {
+		return nil
+	}
+	records, err := provenance.Lookup(r.Context(), h.DB, table, codes)
+	if err != nil {
+		logging.FromContext(r.Context()).Warn("⚠️  Provenance unavailable", "table", table, "error", err)
+		return nil
+	}
+	for _, rec := range records {
+		w.Header().Set("X-Ontology-Version", rec.OntologyVersion)
+		break
+	}
+	return records
+}
+
+// attachProvenance sets the provenance of attribute results when the
+// request asks for it
+func (h *RagHandler) attachProvenance(w http.ResponseWriter, r *http.Request, results []AttributeResult) {
+	records := h.provenanceOf(w, r, provenance.TableAttributeMetadata, resultCodes(results))
+	for i := range results {
+		if rec, ok := records[results[i].Code]; ok {
+			results[i].Provenance = &rec
+		}
+	}
+}
@@ -1,23 +1,56 @@
 package api
 
 import (
-	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/jmoiron/sqlx"
 
+	"github.com/adamtc007/KYC-DSL/internal/agents"
+	"github.com/adamtc007/KYC-DSL/internal/attrvalue"
+	"github.com/adamtc007/KYC-DSL/internal/auth"
+	"github.com/adamtc007/KYC-DSL/internal/cursor"
+	kycdb "github.com/adamtc007/KYC-DSL/internal/db"
+	"github.com/adamtc007/KYC-DSL/internal/engine"
+	"github.com/adamtc007/KYC-DSL/internal/events"
+	"github.com/adamtc007/KYC-DSL/internal/feedbackpolicy"
+	"github.com/adamtc007/KYC-DSL/internal/logging"
 	"github.com/adamtc007/KYC-DSL/internal/model"
 	"github.com/adamtc007/KYC-DSL/internal/ontology"
+	"github.com/adamtc007/KYC-DSL/internal/overlay"
+	"github.com/adamtc007/KYC-DSL/internal/provenance"
 	"github.com/adamtc007/KYC-DSL/internal/rag"
+	"github.com/adamtc007/KYC-DSL/internal/ragaudit"
+	"github.com/adamtc007/KYC-DSL/internal/ratelimit"
+	"github.com/adamtc007/KYC-DSL/internal/shadow"
 )
 
 // RagHandler handles RAG and vector search API endpoints
 type RagHandler struct {
 	DB       *sqlx.DB
 	Embedder *rag.Embedder
+	// Shadow optionally runs a candidate ranking alongside attribute search
+	Shadow *shadow.Runner
+	// Agents enforces the agent registry; registry changes invalidate its cache
+	Agents *agents.Verifier
+	// FeedbackWeight is the default weight of feedback in attribute ranking
+	FeedbackWeight float64
+	// Draining reports a shutdown in progress; the health check then fails
+	// so load balancers stop routing here
+	Draining func() bool
+	// Lifecycle moves cases between lifecycle states, running its hooks
+	Lifecycle *engine.Lifecycle
+	// Audit writes the search requests wrapped by AuditSearch to
+	// rag_audit_log; nil disables search auditing
+	Audit *ragaudit.Writer
+	// Limits is the rate limiter of the RAG endpoints, whose usage
+	// /rag/usage reports; nil when limiting is not set up
+	Limits *ratelimit.Limiter
 }
 
 // NewRagHandler creates a new RAG handler with OpenAI client
@@ -30,10 +63,16 @@ func NewRagHandler(db *sqlx.DB, embedder *rag.Embedder) *RagHandler {
 
 // AttributeSearchResponse represents the API response
 type AttributeSearchResponse struct {
-	Query   string            `json:"query"`
-	Limit   int               `json:"limit"`
-	Count   int               `json:"count"`
-	Results []AttributeResult `json:"results"`
+	Query          string  `json:"query"`
+	Limit          int     `json:"limit"`
+	Count          int     `json:"count"`
+	FeedbackWeight float64 `json:"feedback_weight"`
+	QueryCluster   string  `json:"query_cluster,omitempty"`
+	Space          string  `json:"space,omitempty"`
+	// Degraded is set when the query could not be embedded and the results
+	// come from text search
+	Degraded bool              `json:"degraded,omitempty"`
+	Results  []AttributeResult `json:"results"`
 }
 
 // AttributeResult represents a single search result
@@ -47,6 +86,20 @@ type AttributeResult struct {
 	ExampleValues       []string `json:"example_values,omitempty"`
 	SimilarityScore     float64  `json:"similarity_score"`
 	Distance            float64  `json:"distance"`
+	// FeedbackScore is the net feedback (-1..1) on the attribute for queries
+	// in the query's cluster
+	FeedbackScore float64 `json:"feedback_score"`
+	// Penalty is the demotion from trusted negative feedback on this query
+	Penalty float64 `json:"penalty,omitempty"`
+	// BlendedScore orders the results: similarity and feedback blended by the
+	// feedback weight, less the penalty
+	BlendedScore float64 `json:"blended_score"`
+	// Explanation is set for explain=true
+	Explanation *MatchExplanation `json:"explanation,omitempty"`
+	// ExternalMappings link the attribute to ISO 20022 elements and FIBO concepts
+	ExternalMappings []model.ExternalMapping `json:"external_mappings,omitempty"`
+	// Provenance is set for include_provenance=true
+	Provenance *provenance.Record `json:"provenance,omitempty"`
 }
 
 // SimilarAttributesResponse represents similar attributes API response
@@ -80,8 +133,11 @@ type RiskDistributionItem struct {
 
 // ErrorResponse represents an API error
 type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message,omitempty"`
+	Error     string `json:"error"`
+	Message   string `json:"message,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+	// Validation details a value refused by attribute validation
+	Validation *attrvalue.Error `json:"validation,omitempty"`
 }
 
 // MultiModalResponse represents enriched search results with documents and regulations
@@ -108,24 +164,31 @@ type AttributeResultSimple struct {
 
 // DocumentResult represents a document in search results
 type DocumentResult struct {
-	Code         string `json:"code"`
-	Title        string `json:"title"`
-	Jurisdiction string `json:"jurisdiction"`
-	Description  string `json:"description"`
-	DocType      string `json:"doc_type,omitempty"`
+	Code         string             `json:"code"`
+	Title        string             `json:"title"`
+	Jurisdiction string             `json:"jurisdiction"`
+	Description  string             `json:"description"`
+	DocType      string             `json:"doc_type,omitempty"`
+	Provenance   *provenance.Record `json:"provenance,omitempty"`
 }
 
 // RegulationResult represents a regulation in search results
 type RegulationResult struct {
-	Code     string `json:"code"`
-	Title    string `json:"title"`
-	Citation string `json:"citation"`
-	Summary  string `json:"summary"`
-	Region   string `json:"region,omitempty"`
+	Code       string             `json:"code"`
+	Title      string             `json:"title"`
+	Citation   string             `json:"citation"`
+	Summary    string             `json:"summary"`
+	Region     string             `json:"region,omitempty"`
+	Provenance *provenance.Record `json:"provenance,omitempty"`
 }
 
-// HandleAttributeSearch performs semantic search on attributes
-// GET /rag/attribute_search?q=<query>&limit=<limit>
+// HandleAttributeSearch performs semantic search on attributes, re-ranked by
+// the feedback given on them for similar queries. With explain=true each
+// result says why it matched. space searches a secondary embedding space
+// (see kycctl embeddings) to compare embedding models. While the embeddings
+// provider is unavailable the primary space degrades to text search over the
+// query terms, with "degraded": true.
+// GET /rag/attribute_search?q=<query>&limit=<limit>&feedback_weight=<0..1>&explain=true&space=<name>&include_provenance=true
 func (h *RagHandler) HandleAttributeSearch(w http.ResponseWriter, r *http.Request) {
 	// Parse query parameters
 	query := r.URL.Query().Get("q")
@@ -140,19 +203,66 @@ func (h *RagHandler) HandleAttributeSearch(w http.ResponseWriter, r *http.Reques
 			limit = l
 		}
 	}
-
-	ctx := context.Background()
-
-	// Generate embedding for query
-	queryEmbedding, err := h.Embedder.GenerateEmbeddingFromText(ctx, query)
+	weight, err := h.feedbackWeight(r)
 	if err != nil {
-		h.sendError(w, http.StatusInternalServerError, "failed to generate query embedding: "+err.Error())
+		h.sendError(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	explain := r.URL.Query().Get("explain") == "true"
 
-	// Perform vector search
-	repo := ontology.NewMetadataRepo(h.DB)
-	results, err := repo.SearchByVector(ctx, queryEmbedding, limit)
+	ctx := r.Context()
+
+	// A secondary space embeds the query with its own model
+	embedder := h.Embedder
+	var space *model.EmbeddingSpace
+	if name := r.URL.Query().Get("space"); name != "" {
+		space, err = ontology.NewSpaceRepo(h.DB).Get(ctx, name)
+		if errors.Is(err, ontology.ErrSpaceNotFound) {
+			h.sendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if err != nil {
+			h.sendError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if space.IsPrimary {
+			space = nil
+		} else {
+			embedder = h.Embedder.ForSpace(space.Model, space.Dimensions)
+		}
+	}
+
+	// Generate embedding for query; a secondary space cannot degrade
+	queryEmbedding, err := embedder.GenerateEmbeddingFromText(ctx, query)
+	degraded := false
+	switch {
+	case err != nil && (space != nil || ctx.Err() != nil):
+		h.sendEmbeddingError(w, err)
+		return
+	case err != nil:
+		logging.FromContext(ctx).Warn("⚠️  Attribute search degraded to text search", "error", err)
+		degraded = true
+	case space == nil:
+		noteQueryEmbedding(ctx, queryEmbedding)
+	}
+
+	// Perform vector search; feedback can promote attributes from a wider pool
+	pool := limit
+	if weight > 0 {
+		pool = limit * rerankPool
+	}
+	// The primary space includes the attributes of the caller's tenant
+	// overlay, which replace global attributes with the same code
+	resolver := overlay.NewResolver(h.DB, auth.Tenant(ctx, r.Header.Get(auth.TenantHeader)))
+	var results []model.AttributeSearchResult
+	switch {
+	case degraded:
+		results, err = lexicalSearch(ctx, resolver, query, pool)
+	case space != nil:
+		results, err = ontology.NewSpaceRepo(h.DB).SearchAttributes(ctx, *space, queryEmbedding, pool)
+	default:
+		results, err = resolver.SearchByVector(ctx, queryEmbedding, pool)
+	}
 	if err != nil {
 		h.sendError(w, http.StatusInternalServerError, "failed to search: "+err.Error())
 		return
@@ -160,10 +270,14 @@ func (h *RagHandler) HandleAttributeSearch(w http.ResponseWriter, r *http.Reques
 
 	// Format response
 	response := AttributeSearchResponse{
-		Query:   query,
-		Limit:   limit,
-		Count:   len(results),
-		Results: make([]AttributeResult, 0, len(results)),
+		Query:          query,
+		Limit:          limit,
+		FeedbackWeight: weight,
+		Degraded:       degraded,
+		Results:        make([]AttributeResult, 0, len(results)),
+	}
+	if space != nil {
+		response.Space = space.Name
 	}
 
 	for _, r := range results {
@@ -180,6 +294,54 @@ func (h *RagHandler) HandleAttributeSearch(w http.ResponseWriter, r *http.Reques
 		})
 	}
 
+	// Blend in feedback for similar queries and demotions for this query;
+	// query clusters have primary space centroids
+	if (weight > 0 || explain) && space == nil && !degraded {
+		response.QueryCluster = h.queryCluster(ctx, queryEmbedding)
+	}
+	codes := make([]string, len(response.Results))
+	for i, res := range response.Results {
+		codes[i] = res.Code
+	}
+	signals := h.rankSignals(r, query, response.QueryCluster, weight, codes)
+	for i := range response.Results {
+		signals.score(&response.Results[i])
+	}
+	sort.SliceStable(response.Results, func(i, j int) bool {
+		return response.Results[i].BlendedScore > response.Results[j].BlendedScore
+	})
+	if len(response.Results) > limit {
+		response.Results = response.Results[:limit]
+	}
+	response.Count = len(response.Results)
+	if explain {
+		for i := range response.Results {
+			h.explain(ctx, query, signals, &response.Results[i])
+		}
+	}
+
+	h.attachMappings(ctx, response.Results)
+	h.attachProvenance(w, r, response.Results)
+	if err := h.redactExamples(r, attributeRefs(response.Results)); err != nil {
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	primary := make([]string, 0, len(response.Results))
+	for _, r := range response.Results {
+		primary = append(primary, r.Code)
+	}
+	h.recordSearchHits(r, query, attributeHits(primary))
+
+	// Shadow-run the candidate ranking; never affects this response
+	if h.Shadow != nil && space == nil && !degraded {
+		h.Shadow.ShadowRanking(query, primary, shadow.LexicalBoostRanker(resolver, query, queryEmbedding, limit))
+	}
+
+	// Degraded results are not worth caching once the provider is back
+	if degraded {
+		w.Header().Set("Cache-Control", "no-store")
+	}
 	h.sendJSON(w, http.StatusOK, response)
 }
 
@@ -200,7 +362,7 @@ func (h *RagHandler) HandleSimilarAttributes(w http.ResponseWriter, r *http.Requ
 		}
 	}
 
-	ctx := context.Background()
+	ctx := r.Context()
 
 	// Find similar attributes
 	repo := ontology.NewMetadataRepo(h.DB)
@@ -232,6 +394,13 @@ func (h *RagHandler) HandleSimilarAttributes(w http.ResponseWriter, r *http.Requ
 		})
 	}
 
+	h.attachProvenance(w, r, response.Results)
+	h.recordSearchHits(r, "", attributeHits(resultCodes(response.Results)))
+	if err := h.redactExamples(r, attributeRefs(response.Results)); err != nil {
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
 	h.sendJSON(w, http.StatusOK, response)
 }
 
@@ -245,11 +414,12 @@ func (h *RagHandler) HandleTextSearch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ctx := context.Background()
+	ctx := r.Context()
 
-	// Perform text search
-	repo := ontology.NewMetadataRepo(h.DB)
-	results, err := repo.SearchByText(ctx, searchTerm)
+	// Perform text search over the caller's tenant overlay and the global
+	// metadata
+	resolver := overlay.NewResolver(h.DB, auth.Tenant(ctx, r.Header.Get(auth.TenantHeader)))
+	results, err := resolver.SearchByText(ctx, searchTerm)
 	if err != nil {
 		h.sendError(w, http.StatusInternalServerError, "failed to search: "+err.Error())
 		return
@@ -274,13 +444,20 @@ func (h *RagHandler) HandleTextSearch(w http.ResponseWriter, r *http.Request) {
 		})
 	}
 
+	h.attachProvenance(w, r, response.Results)
+	h.recordSearchHits(r, searchTerm, attributeHits(resultCodes(response.Results)))
+	if err := h.redactExamples(r, attributeRefs(response.Results)); err != nil {
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
 	h.sendJSON(w, http.StatusOK, response)
 }
 
 // HandleMetadataStats returns metadata repository statistics
 // GET /rag/stats
 func (h *RagHandler) HandleMetadataStats(w http.ResponseWriter, r *http.Request) {
-	ctx := context.Background()
+	ctx := r.Context()
 
 	// Get stats
 	repo := ontology.NewMetadataRepo(h.DB)
@@ -314,9 +491,16 @@ func (h *RagHandler) HandleMetadataStats(w http.ResponseWriter, r *http.Request)
 	h.sendJSON(w, http.StatusOK, response)
 }
 
-// HandleGetAttribute retrieves metadata for a specific attribute
-// GET /rag/attribute/<code>
+// HandleGetAttribute retrieves metadata for a specific attribute. With
+// include_provenance=true the provenance is also returned as X-Provenance-*
+// and Last-Modified headers.
+// GET /rag/attribute/<code>?include_provenance=true
 func (h *RagHandler) HandleGetAttribute(w http.ResponseWriter, r *http.Request) {
+	if strings.HasSuffix(r.URL.Path, "/profile") {
+		h.HandleAttributeProfile(w, r)
+		return
+	}
+
 	// Extract attribute code from URL path
 	path := strings.TrimPrefix(r.URL.Path, "/rag/attribute/")
 	attributeCode := strings.TrimSpace(path)
@@ -326,7 +510,7 @@ func (h *RagHandler) HandleGetAttribute(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	ctx := context.Background()
+	ctx := r.Context()
 
 	// Get metadata
 	repo := ontology.NewMetadataRepo(h.DB)
@@ -346,18 +530,41 @@ func (h *RagHandler) HandleGetAttribute(w http.ResponseWriter, r *http.Request)
 		RegulatoryCitations: metadata.RegulatoryCitations,
 		ExampleValues:       metadata.ExampleValues,
 	}
+	results := []AttributeResult{result}
+	h.attachMappings(ctx, results)
+	h.attachProvenance(w, r, results)
+	if err := h.redactExamples(r, attributeRefs(results)); err != nil {
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if results[0].Provenance != nil {
+		provenance.SetHeaders(w.Header(), *results[0].Provenance)
+	}
+	// A lookup by code is usage too: it promotes archived embeddings
+	h.recordSearchHits(r, "", attributeHits([]string{metadata.AttributeCode}))
 
-	h.sendJSON(w, http.StatusOK, result)
+	h.sendJSON(w, http.StatusOK, results[0])
 }
 
 // HandleHealth is a health check endpoint
 // GET /rag/health
 func (h *RagHandler) HandleHealth(w http.ResponseWriter, r *http.Request) {
-	ctx := context.Background()
+	ctx := r.Context()
+
+	if h.Draining != nil && h.Draining() {
+		h.sendJSON(w, http.StatusServiceUnavailable, map[string]interface{}{
+			"status": "draining",
+		})
+		return
+	}
 
-	// Check database connection
-	if err := h.DB.PingContext(ctx); err != nil {
-		h.sendError(w, http.StatusServiceUnavailable, "database connection failed")
+	// Check the shared connection pool
+	dbHealth := kycdb.CheckShared(ctx)
+	if !dbHealth.OK() {
+		h.sendJSON(w, http.StatusServiceUnavailable, map[string]interface{}{
+			"status":   "unhealthy",
+			"database": dbHealth,
+		})
 		return
 	}
 
@@ -369,12 +576,21 @@ func (h *RagHandler) HandleHealth(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.sendJSON(w, http.StatusOK, map[string]interface{}{
-		"status":               "healthy",
-		"embeddings_count":     count,
-		"embedding_model":      string(h.Embedder.GetModel()),
-		"embedding_dimensions": h.Embedder.GetDimensions(),
-	})
+	// Degraded: queries cannot be embedded, so searches fall back to text
+	response := map[string]interface{}{
+		"status":           "healthy",
+		"database":         dbHealth,
+		"embeddings_count": count,
+		"degraded":         !h.Embedder.Available(),
+	}
+	if h.Embedder != nil {
+		response["embedding_model"] = string(h.Embedder.GetModel())
+		response["embedding_dimensions"] = h.Embedder.GetDimensions()
+	}
+	if cache := h.Embedder.Cache(); cache != nil {
+		response["embedding_cache"] = cache.Stats()
+	}
+	h.sendJSON(w, http.StatusOK, response)
 }
 
 // HandleEnrichedAttributeSearch performs multi-modal semantic search with documents and regulations
@@ -394,14 +610,15 @@ func (h *RagHandler) HandleEnrichedAttributeSearch(w http.ResponseWriter, r *htt
 		}
 	}
 
-	ctx := context.Background()
+	ctx := r.Context()
 
 	// Generate embedding for query
 	queryEmbedding, err := h.Embedder.GenerateEmbeddingFromText(ctx, query)
 	if err != nil {
-		h.sendError(w, http.StatusInternalServerError, "failed to generate query embedding: "+err.Error())
+		h.sendEmbeddingError(w, err)
 		return
 	}
+	noteQueryEmbedding(ctx, queryEmbedding)
 
 	// Perform multi-modal search
 	repo := ontology.NewMultiModalRepo(h.DB)
@@ -479,6 +696,15 @@ func (h *RagHandler) HandleEnrichedAttributeSearch(w http.ResponseWriter, r *htt
 		})
 	}
 
+	attrs := make([]*AttributeResult, len(enrichedResults))
+	for i := range enrichedResults {
+		attrs[i] = &enrichedResults[i].Attribute
+	}
+	if err := h.redactExamples(r, attrs); err != nil {
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
 	response := map[string]interface{}{
 		"query":   query,
 		"limit":   limit,
@@ -486,6 +712,8 @@ func (h *RagHandler) HandleEnrichedAttributeSearch(w http.ResponseWriter, r *htt
 		"results": enrichedResults,
 	}
 
+	h.recordSearchHits(r, query, multiModalHits(results))
+
 	h.sendJSON(w, http.StatusOK, response)
 }
 
@@ -504,8 +732,9 @@ func (h *RagHandler) sendError(w http.ResponseWriter, statusCode int, message st
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
 	json.NewEncoder(w).Encode(ErrorResponse{
-		Error:   http.StatusText(statusCode),
-		Message: message,
+		Error:     http.StatusText(statusCode),
+		Message:   message,
+		RequestID: w.Header().Get(logging.RequestIDHeader),
 	})
 }
 
@@ -526,14 +755,15 @@ func (h *RagHandler) HandleMultiModalSearch(w http.ResponseWriter, r *http.Reque
 		}
 	}
 
-	ctx := context.Background()
+	ctx := r.Context()
 
 	// Generate embedding for query
 	queryEmbedding, err := h.Embedder.GenerateEmbeddingFromText(ctx, query)
 	if err != nil {
-		h.sendError(w, http.StatusInternalServerError, "failed to generate query embedding: "+err.Error())
+		h.sendEmbeddingError(w, err)
 		return
 	}
+	noteQueryEmbedding(ctx, queryEmbedding)
 
 	// Perform multi-modal search
 	repo := ontology.NewMultiModalRepo(h.DB)
@@ -590,13 +820,17 @@ func (h *RagHandler) HandleMultiModalSearch(w http.ResponseWriter, r *http.Reque
 		})
 	}
 
+	h.recordSearchHits(r, query, multiModalHits(results))
+
 	h.sendJSON(w, http.StatusOK, response)
 }
 
-// HandleGetDocuments returns all documents with optional filtering
-// GET /rag/documents?attribute=<code>
+// HandleGetDocuments returns the documents linked to an attribute, or a page
+// of all documents by code; next_cursor is passed as cursor for the next page
+// GET /rag/documents?attribute=<code>&include_provenance=true
+// GET /rag/documents?limit=50&cursor=<next_cursor>
 func (h *RagHandler) HandleGetDocuments(w http.ResponseWriter, r *http.Request) {
-	ctx := context.Background()
+	ctx := r.Context()
 	repo := ontology.NewMultiModalRepo(h.DB)
 
 	// Check if filtering by attribute
@@ -604,13 +838,22 @@ func (h *RagHandler) HandleGetDocuments(w http.ResponseWriter, r *http.Request)
 
 	var docs []model.Document
 	var err error
+	nextCursor := ""
 
 	if attributeCode != "" {
 		docs, err = repo.GetDocumentsByAttribute(ctx, attributeCode)
 	} else {
-		// For now, return error - full list could be large
-		h.sendError(w, http.StatusBadRequest, "attribute parameter required")
-		return
+		after, limit, perr := pageParams(r)
+		if perr != nil {
+			h.sendError(w, http.StatusBadRequest, perr.Error())
+			return
+		}
+		// One extra row tells whether there is a next page
+		docs, err = repo.ListDocuments(ctx, after, limit+1)
+		if len(docs) > limit {
+			docs = docs[:limit]
+			nextCursor = cursor.Encode(docs[limit-1].Code)
+		}
 	}
 
 	if err != nil {
@@ -618,29 +861,43 @@ func (h *RagHandler) HandleGetDocuments(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	codes := make([]string, len(docs))
+	for i, doc := range docs {
+		codes[i] = doc.Code
+	}
+	records := h.provenanceOf(w, r, provenance.TableDocuments, codes)
+
 	// Format response
 	results := make([]DocumentResult, 0, len(docs))
 	for _, doc := range docs {
-		results = append(results, DocumentResult{
+		result := DocumentResult{
 			Code:         doc.Code,
 			Title:        doc.Title,
 			Jurisdiction: doc.Jurisdiction,
 			Description:  strings.TrimSpace(doc.Description),
 			DocType:      doc.DocType,
-		})
+		}
+		if rec, ok := records[doc.Code]; ok {
+			result.Provenance = &rec
+		}
+		results = append(results, result)
 	}
 
 	h.sendJSON(w, http.StatusOK, map[string]interface{}{
-		"attribute": attributeCode,
-		"count":     len(results),
-		"documents": results,
+		"attribute":   attributeCode,
+		"count":       len(results),
+		"documents":   results,
+		"next_cursor": nextCursor,
 	})
 }
 
-// HandleGetRegulations returns all regulations with optional filtering
-// GET /rag/regulations?attribute=<code>
+// HandleGetRegulations returns the regulations linked to an attribute, or a
+// page of all regulations by code; next_cursor is passed as cursor for the
+// next page
+// GET /rag/regulations?attribute=<code>&include_provenance=true
+// GET /rag/regulations?limit=50&cursor=<next_cursor>
 func (h *RagHandler) HandleGetRegulations(w http.ResponseWriter, r *http.Request) {
-	ctx := context.Background()
+	ctx := r.Context()
 	repo := ontology.NewMultiModalRepo(h.DB)
 
 	// Check if filtering by attribute
@@ -648,13 +905,21 @@ func (h *RagHandler) HandleGetRegulations(w http.ResponseWriter, r *http.Request
 
 	var regs []model.Regulation
 	var err error
+	nextCursor := ""
 
 	if attributeCode != "" {
 		regs, err = repo.GetRegulationsByAttribute(ctx, attributeCode)
 	} else {
-		// For now, return error - full list could be large
-		h.sendError(w, http.StatusBadRequest, "attribute parameter required")
-		return
+		after, limit, perr := pageParams(r)
+		if perr != nil {
+			h.sendError(w, http.StatusBadRequest, perr.Error())
+			return
+		}
+		regs, err = repo.ListRegulations(ctx, after, limit+1)
+		if len(regs) > limit {
+			regs = regs[:limit]
+			nextCursor = cursor.Encode(regs[limit-1].Code)
+		}
 	}
 
 	if err != nil {
@@ -662,25 +927,53 @@ func (h *RagHandler) HandleGetRegulations(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	codes := make([]string, len(regs))
+	for i, reg := range regs {
+		codes[i] = reg.Code
+	}
+	records := h.provenanceOf(w, r, provenance.TableRegulations, codes)
+
 	// Format response
 	results := make([]RegulationResult, 0, len(regs))
 	for _, reg := range regs {
-		results = append(results, RegulationResult{
+		result := RegulationResult{
 			Code:     reg.Code,
 			Title:    reg.Title,
 			Citation: reg.Citation,
 			Summary:  strings.TrimSpace(reg.Summary),
 			Region:   reg.Region,
-		})
+		}
+		if rec, ok := records[reg.Code]; ok {
+			result.Provenance = &rec
+		}
+		results = append(results, result)
 	}
 
 	h.sendJSON(w, http.StatusOK, map[string]interface{}{
 		"attribute":   attributeCode,
 		"count":       len(results),
 		"regulations": results,
+		"next_cursor": nextCursor,
 	})
 }
 
+// pageParams reads the cursor and limit (50 by default, at most 100) of a
+// list request keyed on code
+func pageParams(r *http.Request) (string, int, error) {
+	limit := 50
+	if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+	keys, err := cursor.Decode(r.URL.Query().Get("cursor"), 1)
+	if err != nil {
+		return "", 0, err
+	}
+	if keys == nil {
+		return "", limit, nil
+	}
+	return keys[0], limit, nil
+}
+
 // HandleFeedback handles POST /rag/feedback - submit feedback on search results
 func (h *RagHandler) HandleFeedback(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -706,6 +999,26 @@ func (h *RagHandler) HandleFeedback(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Feedback from a registered agent is recorded under that agent; otherwise
+	// attribute it to the caller when no agent name is given
+	if p, ok := auth.PrincipalFromContext(r.Context()); ok && p.Agent != "" {
+		if req.AgentName != nil && *req.AgentName != p.Agent {
+			h.sendError(w, http.StatusForbidden, fmt.Sprintf("agent_name %q does not match the request agent %q", *req.AgentName, p.Agent))
+			return
+		}
+		agentName := p.Agent
+		req.AgentName = &agentName
+		if req.AgentType == "" {
+			if a, err := agents.NewRepo(h.DB).Get(r.Context(), p.Agent); err == nil {
+				req.AgentType = a.Type
+			}
+		}
+	} else if req.AgentName == nil {
+		if agentName := auth.AgentName(r.Context(), r.Header.Get(auth.AgentHeader)); agentName != "" {
+			req.AgentName = &agentName
+		}
+	}
+
 	// Set defaults
 	if req.Feedback == "" {
 		req.Feedback = model.FeedbackSentimentPositive
@@ -724,6 +1037,7 @@ func (h *RagHandler) HandleFeedback(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Create feedback entry
+	tenant := auth.Tenant(r.Context(), r.Header.Get(auth.TenantHeader))
 	feedback := model.Feedback{
 		QueryText:      req.QueryText,
 		AttributeCode:  req.AttributeCode,
@@ -733,6 +1047,16 @@ func (h *RagHandler) HandleFeedback(w http.ResponseWriter, r *http.Request) {
 		Confidence:     req.Confidence,
 		AgentName:      req.AgentName,
 		AgentType:      req.AgentType,
+		Tenant:         tenant,
+	}
+
+	// Group attribute feedback by the cluster of its query for ranking
+	if req.AttributeCode != nil {
+		if vec, err := h.Embedder.GenerateEmbeddingFromText(r.Context(), req.QueryText); err != nil {
+			logging.FromContext(r.Context()).Warn("⚠️  Feedback stored without a query cluster", "error", err)
+		} else if cluster := h.queryCluster(r.Context(), vec); cluster != "" {
+			feedback.QueryCluster = &cluster
+		}
 	}
 
 	// Insert feedback
@@ -742,6 +1066,7 @@ func (h *RagHandler) HandleFeedback(w http.ResponseWriter, r *http.Request) {
 		h.sendError(w, http.StatusInternalServerError, "Failed to save feedback: "+err.Error())
 		return
 	}
+	events.NotifyFeedback(r.Context(), h.DB, id, feedback)
 
 	// Return response
 	response := model.FeedbackResponse{
@@ -752,6 +1077,14 @@ func (h *RagHandler) HandleFeedback(w http.ResponseWriter, r *http.Request) {
 		CreatedAt: feedback.CreatedAt,
 	}
 
+	// Confident negative feedback from trusted agents acts on ranking and
+	// review; the feedback itself is stored either way
+	if outcome, err := feedbackpolicy.NewRepo(h.DB).Apply(r.Context(), id, feedback); err != nil {
+		logging.FromContext(r.Context()).Warn("⚠️  Feedback policy not applied", "feedback_id", id, "error", err)
+	} else {
+		response.Outcome = &outcome
+	}
+
 	h.sendJSON(w, http.StatusOK, response)
 }
 
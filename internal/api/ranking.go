@@ -0,0 +1,75 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/adamtc007/KYC-DSL/internal/auth"
+	"github.com/adamtc007/KYC-DSL/internal/feedbackpolicy"
+	"github.com/adamtc007/KYC-DSL/internal/logging"
+	"github.com/adamtc007/KYC-DSL/internal/ranking"
+)
+
+// rerankPool widens the vector candidates that feedback may re-order
+const rerankPool = 3
+
+// feedbackWeight returns the feedback_weight parameter, or the configured weight
+func (h *RagHandler) feedbackWeight(r *http.Request) (float64, error) {
+	s := r.URL.Query().Get("feedback_weight")
+	if s == "" {
+		return h.FeedbackWeight, nil
+	}
+	weight, err := strconv.ParseFloat(s, 64)
+	if err != nil || weight < 0 || weight > 1 {
+		return 0, errors.New("feedback_weight must be between 0 and 1")
+	}
+	return weight, nil
+}
+
+// queryCluster returns the cluster closest to a query, or "" if there is none
+// or it cannot be determined (feedback then falls in the unclustered group)
+func (h *RagHandler) queryCluster(ctx context.Context, vec []float32) string {
+	cluster, err := ranking.QueryCluster(ctx, h.DB, vec)
+	if err != nil {
+		logging.FromContext(ctx).Warn("⚠️  Query cluster unavailable", "error", err)
+	}
+	return cluster
+}
+
+// rankSignals are the feedback scores and demotions that re-rank a search
+type rankSignals struct {
+	weight       float64
+	queryCluster string
+	scores       map[string]ranking.FeedbackScore
+	penalties    map[string]float64
+}
+
+// rankSignals loads the feedback scores and demotions of the caller's tenant
+// for the candidate attributes. Missing signals are logged and count as none,
+// so ranking degrades to similarity order.
+func (h *RagHandler) rankSignals(r *http.Request, query, queryCluster string, weight float64, codes []string) rankSignals {
+	ctx := r.Context()
+	log := logging.FromContext(ctx)
+	tenant := auth.Tenant(ctx, r.Header.Get(auth.TenantHeader))
+	s := rankSignals{weight: weight, queryCluster: queryCluster}
+
+	var err error
+	if s.penalties, err = feedbackpolicy.NewRepo(h.DB).Penalties(ctx, tenant, query); err != nil {
+		log.Warn("⚠️  Ranking penalties unavailable", "error", err)
+	}
+	if weight > 0 {
+		if s.scores, err = ranking.FeedbackScores(ctx, h.DB, tenant, queryCluster, codes); err != nil {
+			log.Warn("⚠️  Feedback scores unavailable", "error", err)
+		}
+	}
+	return s
+}
+
+// score sets a result's feedback score, penalty and blended score
+func (s rankSignals) score(res *AttributeResult) {
+	res.FeedbackScore = s.scores[res.Code].Score
+	res.Penalty = s.penalties[res.Code]
+	res.BlendedScore = ranking.Blend(res.SimilarityScore, res.FeedbackScore, s.weight) - res.Penalty
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/adamtc007/KYC-DSL/internal/auth"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/redact"
+)
+
+// redactAttributes masks the values of sensitive attributes in a response
+// for callers without the pii:read scope and audits them otherwise. codes
+// holds the attribute of each value ("" for no value); mask replaces value
+// i with redact.Marker. caseName is the case the values belong to, if any.
+func (h *RagHandler) redactAttributes(r *http.Request, caseName string, codes []string, mask func(i int)) error {
+	sensitive, err := h.sensitiveAttributes(r, caseName, codes)
+	if err != nil {
+		return err
+	}
+	x := redact.New(h.DB, r, caseName)
+	for i, code := range codes {
+		if sensitive[code] && !x.Field(code) {
+			mask(i)
+		}
+	}
+	return x.Audit(r.Context())
+}
+
+// sensitiveAttributes looks up which attributes are sensitive in the
+// overlay of the case's tenant, or without a case of the caller's tenant
+func (h *RagHandler) sensitiveAttributes(r *http.Request, caseName string, codes []string) (map[string]bool, error) {
+	ctx := r.Context()
+	tenant := auth.Tenant(ctx, r.Header.Get(auth.TenantHeader))
+	if caseName != "" {
+		var err error
+		if tenant, err = redact.CaseTenant(ctx, h.DB, caseName); err != nil {
+			return nil, err
+		}
+	}
+	return redact.Sensitive(ctx, h.DB, tenant, codes)
+}
+
+// redactExamples masks the example values of sensitive attributes in
+// attribute search results, including those an overlay rates CRITICAL
+func (h *RagHandler) redactExamples(r *http.Request, results []*AttributeResult) error {
+	codes := make([]string, len(results))
+	for i, res := range results {
+		if len(res.ExampleValues) > 0 {
+			codes[i] = res.Code
+		}
+	}
+	sensitive, err := h.sensitiveAttributes(r, "", codes)
+	if err != nil {
+		return err
+	}
+	x := redact.New(h.DB, r, "")
+	for i, res := range results {
+		if codes[i] == "" || !(sensitive[res.Code] || res.RiskLevel == "CRITICAL") {
+			continue
+		}
+		if !x.Field(res.Code) {
+			res.ExampleValues = maskAll(res.ExampleValues)
+		}
+	}
+	return x.Audit(r.Context())
+}
+
+// attributeRefs points at each attribute search result
+func attributeRefs(results []AttributeResult) []*AttributeResult {
+	refs := make([]*AttributeResult, len(results))
+	for i := range results {
+		refs[i] = &results[i]
+	}
+	return refs
+}
+
+// redactCaseValues masks the captured values of sensitive attributes
+func (h *RagHandler) redactCaseValues(r *http.Request, caseName string, values []model.CaseAttributeValue) error {
+	codes := make([]string, len(values))
+	for i, v := range values {
+		if v.Value != "" {
+			codes[i] = v.AttributeCode
+		}
+	}
+	return h.redactAttributes(r, caseName, codes, func(i int) {
+		values[i].Value = redact.Marker
+	})
+}
+
+// redactEntries masks the data dictionary values of sensitive attributes
+func (h *RagHandler) redactEntries(r *http.Request, caseName string, entries []model.DictionaryEntry) error {
+	codes := make([]string, len(entries))
+	for i, e := range entries {
+		if e.Value != "" {
+			codes[i] = e.AttributeCode
+		}
+	}
+	return h.redactAttributes(r, caseName, codes, func(i int) {
+		entries[i].Value = redact.Marker
+	})
+}
+
+// redactSections masks the text extracted from case evidence in section
+// search results; regulatory text is never masked
+func (h *RagHandler) redactSections(r *http.Request, caseName string, results []model.SectionContextSearchResult) error {
+	x := redact.New(h.DB, r, caseName)
+	for i := range results {
+		if results[i].EvidenceID == 0 {
+			continue
+		}
+		if !x.Field("evidence:" + strconv.FormatInt(results[i].EvidenceID, 10)) {
+			results[i].TextExcerpt = redact.Marker
+		}
+	}
+	return x.Audit(r.Context())
+}
+
+// maskAll replaces every value with redact.Marker
+func maskAll(values []string) []string {
+	masked := make([]string, len(values))
+	for i := range masked {
+		masked[i] = redact.Marker
+	}
+	return masked
+}
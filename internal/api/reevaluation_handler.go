@@ -0,0 +1,191 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adamtc007/KYC-DSL/internal/lineage"
+	"github.com/adamtc007/KYC-DSL/internal/lists"
+	"github.com/adamtc007/KYC-DSL/internal/reeval"
+)
+
+// AttributeChangeRequest records a new value of a case attribute
+type AttributeChangeRequest struct {
+	Case      string      `json:"case"`
+	Attribute string      `json:"attribute"`
+	Value     interface{} `json:"value"`
+}
+
+// HandleAttributeChange queues the derived attributes of a case that depend on
+// a changed attribute; dataserver re-evaluates them with the new value
+// POST /lineage/attribute-change
+func (h *RagHandler) HandleAttributeChange(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req AttributeChangeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+		return
+	}
+	if req.Case == "" || req.Attribute == "" {
+		h.sendError(w, http.StatusBadRequest, "case and attribute are required")
+		return
+	}
+
+	queued, err := reeval.NewQueue(h.DB).AttributeChanged(r.Context(), req.Case, strings.ToUpper(req.Attribute), req.Value)
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.sendJSON(w, http.StatusOK, map[string]interface{}{
+		"case":      req.Case,
+		"attribute": strings.ToUpper(req.Attribute),
+		"queued":    queued,
+	})
+}
+
+// SimulationRequest asks what a case's derived attributes would be with some
+// attribute values overridden
+type SimulationRequest struct {
+	Case      string                 `json:"case"`
+	Overrides map[string]interface{} `json:"overrides"`
+}
+
+// HandleLineageSimulation evaluates every derivation of a case against its
+// current values and against the overridden ones, without recording
+// anything, and flags the results that change
+// POST /lineage/simulate
+func (h *RagHandler) HandleLineageSimulation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req SimulationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+		return
+	}
+	if req.Case == "" {
+		h.sendError(w, http.StatusBadRequest, "case is required")
+		return
+	}
+
+	sim, err := reeval.Simulate(r.Context(), h.DB, req.Case, req.Overrides)
+	if errors.Is(err, reeval.ErrUnknownCase) {
+		h.sendError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.sendJSON(w, http.StatusOK, sim)
+}
+
+// HandleLineageGraph returns the derivation DAG around an attribute: the
+// attributes it is derived from and those derived from it, with the rule,
+// jurisdiction and regulation of every edge
+// GET /lineage/graph?attribute=<code>&depth=<steps>
+func (h *RagHandler) HandleLineageGraph(w http.ResponseWriter, r *http.Request) {
+	attribute := strings.ToUpper(r.URL.Query().Get("attribute"))
+	if attribute == "" {
+		h.sendError(w, http.StatusBadRequest, "attribute is required")
+		return
+	}
+	depth := 0
+	if depthStr := r.URL.Query().Get("depth"); depthStr != "" {
+		if d, err := strconv.Atoi(depthStr); err == nil && d > 0 {
+			depth = d
+		}
+	}
+
+	graph, err := lineage.Graph(r.Context(), h.DB, attribute, depth)
+	if errors.Is(err, lineage.ErrUnknownAttribute) {
+		h.sendError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.sendJSON(w, http.StatusOK, graph)
+}
+
+// RuleValidationRequest is a candidate derivation rule to check before it is
+// stored
+type RuleValidationRequest struct {
+	Rule             string   `json:"rule"`
+	SourceAttributes []string `json:"source_attributes,omitempty"`
+	RuleType         string   `json:"rule_type,omitempty"`
+}
+
+// HandleValidateRule compiles a candidate rule against the attribute types
+// and managed lists in effect, returning compile errors, type mismatches and
+// unknown attributes or lists; an invalid rule is still a 200 response
+// POST /lineage/validate-rule
+func (h *RagHandler) HandleValidateRule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req RuleValidationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+		return
+	}
+	if strings.TrimSpace(req.Rule) == "" {
+		h.sendError(w, http.StatusBadRequest, "rule is required")
+		return
+	}
+
+	types, err := lineage.AttributeTypes(r.Context(), h.DB)
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	inEffect, err := lists.NewRepo(h.DB).InEffect(r.Context(), time.Now().UTC())
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.sendJSON(w, http.StatusOK, lineage.ValidateRule(req.Rule, types, inEffect, req.SourceAttributes, req.RuleType))
+}
+
+// HandleReevaluationQueue lists derived attributes queued for re-evaluation,
+// pending ones by default
+// GET /lineage/queue?status=<PENDING|DONE|FAILED|all>&limit=<limit>
+func (h *RagHandler) HandleReevaluationQueue(w http.ResponseWriter, r *http.Request) {
+	status := strings.ToUpper(r.URL.Query().Get("status"))
+	switch status {
+	case "":
+		status = reeval.StatusPending
+	case "ALL":
+		status = ""
+	}
+
+	limit := 50
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+
+	items, err := reeval.NewQueue(h.DB).List(r.Context(), status, limit)
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.sendJSON(w, http.StatusOK, map[string]interface{}{
+		"count": len(items),
+		"items": items,
+	})
+}
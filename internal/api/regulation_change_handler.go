@@ -0,0 +1,136 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adamtc007/KYC-DSL/internal/auth"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/regchange"
+)
+
+// RegulationChange is a change to a regulation record; omitted fields are
+// left as they are
+type RegulationChange struct {
+	Name          *string `json:"name,omitempty"`
+	Title         *string `json:"title,omitempty"`
+	Citation      *string `json:"citation,omitempty"`
+	Summary       *string `json:"summary,omitempty"`
+	Description   *string `json:"description,omitempty"`
+	EffectiveFrom string  `json:"effective_from,omitempty"` // YYYY-MM-DD
+	EffectiveTo   string  `json:"effective_to,omitempty"`   // YYYY-MM-DD
+	Note          string  `json:"note,omitempty"`
+}
+
+// HandleRegulation updates a regulation record, recording a new version,
+// lists its versions, or reports what references it: the documents,
+// attributes, clusters, policy packs and open cases to triage after a
+// change. Updates require the reviewer role.
+// POST /regulations/<code> | GET /regulations/<code>/versions |
+// GET /regulations/<code>/impact?version=N
+func (h *RagHandler) HandleRegulation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	repo := regchange.NewRepo(h.DB)
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/regulations/"), "/")
+	code := parts[0]
+	if code == "" || len(parts) > 2 || (len(parts) == 2 && parts[1] != "versions" && parts[1] != "impact") {
+		h.sendError(w, http.StatusBadRequest, "expected /regulations/<code>[/versions|/impact]")
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodPost && len(parts) == 1:
+		changedBy := ""
+		if p, ok := auth.PrincipalFromContext(ctx); ok {
+			if !p.HasRole(auth.RoleReviewer) {
+				h.sendError(w, http.StatusForbidden, "updating regulations requires the reviewer role")
+				return
+			}
+			changedBy = p.Subject
+		}
+		var req RegulationChange
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.sendError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+			return
+		}
+		update := model.RegulationUpdate{
+			Name:        req.Name,
+			Title:       req.Title,
+			Citation:    req.Citation,
+			Summary:     req.Summary,
+			Description: req.Description,
+			Note:        req.Note,
+		}
+		for _, d := range []struct {
+			field, value string
+			dst          **time.Time
+		}{
+			{"effective_from", req.EffectiveFrom, &update.EffectiveFrom},
+			{"effective_to", req.EffectiveTo, &update.EffectiveTo},
+		} {
+			if d.value == "" {
+				continue
+			}
+			t, err := time.Parse("2006-01-02", d.value)
+			if err != nil {
+				h.sendError(w, http.StatusBadRequest, d.field+" must be YYYY-MM-DD")
+				return
+			}
+			*d.dst = &t
+		}
+		v, err := repo.Update(ctx, code, update, changedBy)
+		if err != nil {
+			h.sendRegulationError(w, err)
+			return
+		}
+		h.sendJSON(w, http.StatusOK, v)
+
+	case r.Method == http.MethodGet && len(parts) == 2 && parts[1] == "versions":
+		versions, err := repo.Versions(ctx, code)
+		if err != nil {
+			h.sendRegulationError(w, err)
+			return
+		}
+		h.sendJSON(w, http.StatusOK, map[string]interface{}{
+			"regulation_code": code,
+			"count":           len(versions),
+			"versions":        versions,
+		})
+
+	case r.Method == http.MethodGet && len(parts) == 2 && parts[1] == "impact":
+		version := 0
+		if v := r.URL.Query().Get("version"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 {
+				h.sendError(w, http.StatusBadRequest, "version must be a positive integer")
+				return
+			}
+			version = n
+		}
+		impact, err := repo.Impact(ctx, code, version)
+		if err != nil {
+			h.sendRegulationError(w, err)
+			return
+		}
+		h.sendJSON(w, http.StatusOK, impact)
+
+	default:
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *RagHandler) sendRegulationError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, regchange.ErrNotFound):
+		h.sendError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, regchange.ErrInvalid):
+		h.sendError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+	}
+}
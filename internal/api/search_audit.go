@@ -0,0 +1,128 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/adamtc007/KYC-DSL/internal/actor"
+	"github.com/adamtc007/KYC-DSL/internal/auth"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+// maxAuditResponse is the largest response body kept in the audit log;
+// larger ones are summarised by their size
+const maxAuditResponse = 64 << 10
+
+// SessionHeader carries the agent session a search belongs to
+const SessionHeader = "X-Session-ID"
+
+// auditKey carries the audit entry of a search in its request context
+type auditKey struct{}
+
+// AuditSearch logs each request to a search handler in the RAG audit log:
+// the query, latency, result count, agent, session and any error. Entries
+// are handed to h.Audit, which writes them in the background; nothing is
+// logged when it is nil. Successful searches are sampled, failures are
+// always logged.
+func (h *RagHandler) AuditSearch(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if h.Audit == nil {
+			next(w, r)
+			return
+		}
+
+		start := time.Now()
+		entry := &model.RAGAuditLog{}
+		rec := &auditRecorder{ResponseWriter: w, status: http.StatusOK}
+		next(rec, r.WithContext(context.WithValue(r.Context(), auditKey{}, entry)))
+
+		if rec.status < http.StatusBadRequest && !h.Audit.Sampled() {
+			return
+		}
+
+		ctx := r.Context()
+		q := r.URL.Query()
+		entry.QueryText = q.Get("q")
+		for _, param := range []string{"term", "code"} {
+			if entry.QueryText == "" {
+				entry.QueryText = q.Get(param)
+			}
+		}
+		entry.Endpoint = r.URL.Path
+		entry.LatencyMs = int(time.Since(start).Milliseconds())
+		entry.AgentName = auth.AgentName(ctx, r.Header.Get(auth.AgentHeader))
+		entry.SessionID = r.Header.Get(SessionHeader)
+		entry.UserAgent = r.UserAgent()
+		if ip := actor.FromContext(ctx).ClientIP; net.ParseIP(ip) != nil {
+			entry.IPAddress = ip
+		}
+
+		var body struct {
+			Count   *int              `json:"count"`
+			Results []json.RawMessage `json:"results"`
+			Message string            `json:"message"`
+		}
+		parsed := !rec.truncated && json.Unmarshal(rec.body.Bytes(), &body) == nil
+		switch {
+		case parsed && body.Count != nil:
+			entry.ResultCount = *body.Count
+		case parsed:
+			entry.ResultCount = len(body.Results)
+		}
+		if rec.status >= http.StatusBadRequest {
+			entry.ErrorMessage = body.Message
+			if entry.ErrorMessage == "" {
+				entry.ErrorMessage = http.StatusText(rec.status)
+			}
+		}
+		if parsed {
+			entry.Response = rec.body.String()
+		} else {
+			summary, _ := json.Marshal(map[string]interface{}{"status": rec.status, "bytes": rec.size, "truncated": rec.truncated})
+			entry.Response = string(summary)
+		}
+
+		h.Audit.Record(*entry)
+	}
+}
+
+// noteQueryEmbedding records the embedding a search generated for its query
+// in the search's audit entry, if it is audited. Only primary space
+// embeddings are kept.
+func noteQueryEmbedding(ctx context.Context, vec []float32) {
+	if entry, ok := ctx.Value(auditKey{}).(*model.RAGAuditLog); ok {
+		entry.QueryEmbedding = vec
+	}
+}
+
+// auditRecorder passes a response through, keeping its status and the
+// start of its body
+type auditRecorder struct {
+	http.ResponseWriter
+	status    int
+	body      bytes.Buffer
+	size      int
+	truncated bool
+}
+
+func (a *auditRecorder) WriteHeader(code int) {
+	a.status = code
+	a.ResponseWriter.WriteHeader(code)
+}
+
+func (a *auditRecorder) Write(p []byte) (int, error) {
+	a.size += len(p)
+	if !a.truncated {
+		if a.body.Len()+len(p) > maxAuditResponse {
+			a.truncated = true
+			a.body.Reset()
+		} else {
+			a.body.Write(p)
+		}
+	}
+	return a.ResponseWriter.Write(p)
+}
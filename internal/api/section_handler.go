@@ -0,0 +1,93 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/ontology"
+)
+
+// HandleSectionSearch performs semantic search over document sections and
+// returns each matching snippet with its document and regulation. With case
+// it searches the text extracted from the case's evidence instead, which
+// requires the analyst role (see kycserver's routes).
+// GET /rag/section_search?q=<query>&limit=<limit>&case=<name>
+func (h *RagHandler) HandleSectionSearch(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("q")
+	if query == "" {
+		h.sendError(w, http.StatusBadRequest, "missing 'q' query parameter")
+		return
+	}
+
+	limit := 10
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+
+	caseName := r.URL.Query().Get("case")
+	ctx := r.Context()
+
+	queryEmbedding, err := h.Embedder.GenerateEmbeddingFromText(ctx, query)
+	if err != nil {
+		h.sendEmbeddingError(w, err)
+		return
+	}
+	noteQueryEmbedding(ctx, queryEmbedding)
+
+	results, err := ontology.NewEnhancementsRepo(h.DB).SearchSectionsWithContext(ctx, queryEmbedding, limit, caseName)
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, "failed to search: "+err.Error())
+		return
+	}
+
+	if err := h.redactSections(r, caseName, results); err != nil {
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response := map[string]interface{}{
+		"query":   query,
+		"limit":   limit,
+		"count":   len(results),
+		"results": results,
+	}
+	if caseName != "" {
+		response["case"] = caseName
+	}
+	h.sendJSON(w, http.StatusOK, response)
+}
+
+// HandleDocumentSections returns the sections of a document with its
+// regulation context
+// GET /rag/document/<code>/sections
+func (h *RagHandler) HandleDocumentSections(w http.ResponseWriter, r *http.Request) {
+	code, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/rag/document/"), "/sections")
+	if !ok || code == "" || strings.Contains(code, "/") {
+		h.sendError(w, http.StatusBadRequest, "expected /rag/document/<code>/sections")
+		return
+	}
+
+	sections, err := ontology.NewEnhancementsRepo(h.DB).GetSectionContextsByDocument(r.Context(), code)
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, "failed to fetch sections: "+err.Error())
+		return
+	}
+	if len(sections) == 0 {
+		h.sendError(w, http.StatusNotFound, "no sections found for document: "+code)
+		return
+	}
+
+	first := sections[0]
+	h.sendJSON(w, http.StatusOK, map[string]interface{}{
+		"document_code":    first.DocumentCode,
+		"document_title":   first.DocumentTitle,
+		"jurisdiction":     first.Jurisdiction,
+		"regulation_code":  first.RegulationCode,
+		"regulation_title": first.RegulationTitle,
+		"count":            len(sections),
+		"sections":         sections,
+	})
+}
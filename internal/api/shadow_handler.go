@@ -0,0 +1,65 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/adamtc007/KYC-DSL/internal/ontology"
+)
+
+// HandleShadowDivergences lists divergences recorded by shadow execution
+// GET /rag/shadow/divergences?experiment=<name>&limit=<limit>
+func (h *RagHandler) HandleShadowDivergences(w http.ResponseWriter, r *http.Request) {
+	experiment := r.URL.Query().Get("experiment")
+
+	limit := 50
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+
+	ctx := r.Context()
+
+	repo := ontology.NewShadowRepo(h.DB)
+	divergences, err := repo.ListDivergences(ctx, experiment, limit)
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, "failed to list shadow divergences: "+err.Error())
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, map[string]interface{}{
+		"experiment":  experiment,
+		"count":       len(divergences),
+		"divergences": divergences,
+	})
+}
+
+// HandleShadowSummary returns per-experiment divergence rollups and the active experiment
+// GET /rag/shadow/summary
+func (h *RagHandler) HandleShadowSummary(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	repo := ontology.NewShadowRepo(h.DB)
+	summaries, err := repo.GetExperimentSummaries(ctx)
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, "failed to get shadow summary: "+err.Error())
+		return
+	}
+
+	response := map[string]interface{}{
+		"experiments": summaries,
+	}
+	if h.Shadow != nil {
+		exp := h.Shadow.Experiment()
+		response["active"] = map[string]interface{}{
+			"name":              exp.Name,
+			"kind":              exp.Kind,
+			"primary_version":   exp.PrimaryVersion,
+			"candidate_version": exp.CandidateVersion,
+			"sample_rate":       exp.SampleRate,
+		}
+	}
+
+	h.sendJSON(w, http.StatusOK, response)
+}
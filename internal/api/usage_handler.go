@@ -0,0 +1,161 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/adamtc007/KYC-DSL/internal/auth"
+	"github.com/adamtc007/KYC-DSL/internal/logging"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/ontology"
+)
+
+// defaultStaleEmbeddingAge is how old attribute metadata may get before a
+// frequently used attribute is flagged for embedding refresh
+const defaultStaleEmbeddingAge = 90 * 24 * time.Hour
+
+// HandleQuotaUsage reports the caller's rate limit and daily quota usage
+// on the RAG endpoints; checking it spends none
+// GET /rag/usage
+func (h *RagHandler) HandleQuotaUsage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	h.sendJSON(w, http.StatusOK, h.Limits.Usage(r))
+}
+
+// HandleUsageReport returns ontology hot spots, dead entries and embedding refresh priorities
+// GET /rag/usage/report?limit=<limit>&stale_days=<days>
+func (h *RagHandler) HandleUsageReport(w http.ResponseWriter, r *http.Request) {
+	limit := 25
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+
+	staleAfter := defaultStaleEmbeddingAge
+	if daysStr := r.URL.Query().Get("stale_days"); daysStr != "" {
+		if d, err := strconv.Atoi(daysStr); err == nil && d > 0 {
+			staleAfter = time.Duration(d) * 24 * time.Hour
+		}
+	}
+
+	ctx := r.Context()
+
+	repo := ontology.NewUsageRepo(h.DB)
+	report, err := repo.GetUsageReport(ctx, limit, staleAfter)
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, "failed to build usage report: "+err.Error())
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, report)
+}
+
+// HandleTermUsage lists per-term usage, optionally for a single term type
+// GET /rag/usage/terms?type=<attribute|document|regulation>&limit=<limit>
+func (h *RagHandler) HandleTermUsage(w http.ResponseWriter, r *http.Request) {
+	termType := model.OntologyTermType(r.URL.Query().Get("type"))
+	switch termType {
+	case "", model.TermTypeAttribute, model.TermTypeDocument, model.TermTypeRegulation:
+	default:
+		h.sendError(w, http.StatusBadRequest, "type must be attribute, document or regulation")
+		return
+	}
+
+	limit := 100
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+
+	ctx := r.Context()
+
+	repo := ontology.NewUsageRepo(h.DB)
+	usage, err := repo.ListUsage(ctx, termType, limit)
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, "failed to list term usage: "+err.Error())
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, map[string]interface{}{
+		"type":  termType,
+		"count": len(usage),
+		"terms": usage,
+	})
+}
+
+// recordSearchHits stores the terms returned by a search in the background so
+// usage tracking never delays or fails the response
+func (h *RagHandler) recordSearchHits(r *http.Request, query string, hits []model.SearchHit) {
+	if len(hits) == 0 {
+		return
+	}
+
+	agent := auth.AgentName(r.Context(), r.Header.Get(auth.AgentHeader))
+
+	for i := range hits {
+		hits[i].Endpoint = r.URL.Path
+		if agent != "" {
+			hits[i].AgentName = &agent
+		}
+		if query != "" {
+			hits[i].QueryText = &query
+		}
+	}
+
+	requestID := logging.RequestIDFromContext(r.Context())
+	go func() {
+		ctx, cancel := context.WithTimeout(logging.WithRequestID(context.Background(), requestID), 5*time.Second)
+		defer cancel()
+		if err := ontology.NewUsageRepo(h.DB).RecordSearchHits(ctx, hits); err != nil {
+			logging.FromContext(ctx).Warn("⚠️  usage tracking failed", "error", err)
+		}
+	}()
+}
+
+// attributeHits converts ranked attribute codes to search hits
+func attributeHits(codes []string) []model.SearchHit {
+	hits := make([]model.SearchHit, 0, len(codes))
+	for i, code := range codes {
+		hits = append(hits, model.SearchHit{TermType: model.TermTypeAttribute, TermCode: code, Rank: i + 1})
+	}
+	return hits
+}
+
+// multiModalHits converts enriched search results to search hits, counting each
+// linked document and regulation once per response
+func multiModalHits(results []model.MultiModalResult) []model.SearchHit {
+	hits := make([]model.SearchHit, 0, len(results))
+	seen := make(map[string]bool)
+	for i, res := range results {
+		hits = append(hits, model.SearchHit{TermType: model.TermTypeAttribute, TermCode: res.Attribute.AttributeCode, Rank: i + 1})
+		for _, d := range res.Documents {
+			if key := "document:" + d.Code; !seen[key] {
+				seen[key] = true
+				hits = append(hits, model.SearchHit{TermType: model.TermTypeDocument, TermCode: d.Code, Rank: i + 1})
+			}
+		}
+		for _, reg := range res.Regulations {
+			if key := "regulation:" + reg.Code; !seen[key] {
+				seen[key] = true
+				hits = append(hits, model.SearchHit{TermType: model.TermTypeRegulation, TermCode: reg.Code, Rank: i + 1})
+			}
+		}
+	}
+	return hits
+}
+
+// resultCodes returns the attribute codes of formatted search results in rank order
+func resultCodes(results []AttributeResult) []string {
+	codes := make([]string, 0, len(results))
+	for _, res := range results {
+		codes = append(codes, res.Code)
+	}
+	return codes
+}
@@ -0,0 +1,186 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adamtc007/KYC-DSL/internal/auth"
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/graph"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/watchlist"
+)
+
+// WatchlistPinRequest pins an entity for heightened monitoring
+type WatchlistPinRequest struct {
+	EntityID    string `json:"entity_id"`
+	Reason      string `json:"reason"`
+	Cadence     string `json:"cadence,omitempty"` // Go duration, e.g. "6h"; default from config
+	IncludeUBOs bool   `json:"include_ubos,omitempty"`
+}
+
+// WatchlistAlertDecision acknowledges or dismisses an alert
+type WatchlistAlertDecision struct {
+	Status model.WatchlistAlertStatus `json:"status"`
+}
+
+// HandleWatchlist lists watched entities or pins one; pinned entities are
+// re-screened by dataserver at their cadence
+// GET /watchlist?all=true | POST /watchlist
+func (h *RagHandler) HandleWatchlist(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	repo := watchlist.NewRepo(h.DB)
+
+	switch r.Method {
+	case http.MethodGet:
+		entries, err := repo.List(ctx, r.URL.Query().Get("all") == "true")
+		if err != nil {
+			h.sendError(w, http.StatusInternalServerError, "failed to list watchlist: "+err.Error())
+			return
+		}
+		h.sendJSON(w, http.StatusOK, map[string]interface{}{
+			"count":   len(entries),
+			"entries": entries,
+		})
+
+	case http.MethodPost:
+		var req WatchlistPinRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.sendError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+			return
+		}
+		if req.EntityID == "" || strings.TrimSpace(req.Reason) == "" {
+			h.sendError(w, http.StatusBadRequest, "entity_id and reason are required")
+			return
+		}
+		cadence := config.Current().Watchlist.DefaultCadence
+		if req.Cadence != "" {
+			d, err := time.ParseDuration(req.Cadence)
+			if err != nil || d < time.Minute {
+				h.sendError(w, http.StatusBadRequest, "cadence must be a duration of at least 1m")
+				return
+			}
+			cadence = d
+		}
+
+		pinnedBy := ""
+		if p, ok := auth.PrincipalFromContext(ctx); ok {
+			pinnedBy = p.Subject
+		}
+
+		entry, err := repo.Pin(ctx, req.EntityID, req.Reason, pinnedBy, cadence, req.IncludeUBOs)
+		if err != nil {
+			if errors.Is(err, graph.ErrEntityNotFound) {
+				h.sendError(w, http.StatusNotFound, err.Error())
+				return
+			}
+			h.sendError(w, http.StatusInternalServerError, "failed to pin entity: "+err.Error())
+			return
+		}
+		h.sendJSON(w, http.StatusOK, entry)
+
+	default:
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// HandleWatchlistUnpin stops monitoring a watchlist entry; its hits and
+// alerts are kept
+// POST /watchlist/<id>/unpin
+func (h *RagHandler) HandleWatchlistUnpin(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/watchlist/")
+	idStr, ok := strings.CutSuffix(path, "/unpin")
+	id, err := strconv.Atoi(idStr)
+	if !ok || err != nil {
+		h.sendError(w, http.StatusBadRequest, "expected /watchlist/<id>/unpin")
+		return
+	}
+
+	if err := watchlist.NewRepo(h.DB).Unpin(r.Context(), id); err != nil {
+		if errors.Is(err, watchlist.ErrNotFound) {
+			h.sendError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.sendJSON(w, http.StatusOK, map[string]interface{}{"status": "ok", "id": id})
+}
+
+// HandleWatchlistAlerts lists alerts raised by re-screening, open ones by default
+// GET /watchlist/alerts?status=<OPEN|ACKNOWLEDGED|DISMISSED|all>&limit=<limit>
+func (h *RagHandler) HandleWatchlistAlerts(w http.ResponseWriter, r *http.Request) {
+	status := model.WatchlistAlertStatus(strings.ToUpper(r.URL.Query().Get("status")))
+	switch status {
+	case "":
+		status = model.WatchlistAlertOpen
+	case "ALL":
+		status = ""
+	}
+
+	limit := 50
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+
+	alerts, err := watchlist.NewRepo(h.DB).ListAlerts(r.Context(), status, limit)
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, "failed to list alerts: "+err.Error())
+		return
+	}
+	h.sendJSON(w, http.StatusOK, map[string]interface{}{
+		"count":  len(alerts),
+		"alerts": alerts,
+	})
+}
+
+// HandleWatchlistAlertDecision acknowledges or dismisses an alert
+// POST /watchlist/alerts/<id>
+func (h *RagHandler) HandleWatchlistAlertDecision(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	id, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/watchlist/alerts/"))
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "expected /watchlist/alerts/<id>")
+		return
+	}
+
+	var req WatchlistAlertDecision
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+		return
+	}
+	if req.Status != model.WatchlistAlertAcknowledged && req.Status != model.WatchlistAlertDismissed {
+		h.sendError(w, http.StatusBadRequest, "status must be ACKNOWLEDGED or DISMISSED")
+		return
+	}
+
+	resolvedBy := ""
+	if p, ok := auth.PrincipalFromContext(r.Context()); ok {
+		resolvedBy = p.Subject
+	}
+
+	if err := watchlist.NewRepo(h.DB).ResolveAlert(r.Context(), id, req.Status, resolvedBy); err != nil {
+		if errors.Is(err, watchlist.ErrNotFound) {
+			h.sendError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.sendJSON(w, http.StatusOK, map[string]interface{}{"status": "ok", "id": id, "alert_status": req.Status})
+}
@@ -0,0 +1,283 @@
+// Package attrvalue validates attribute values against the data type and
+// domain values of their dictionary metadata (kyc_attribute_metadata). It
+// is shared by value capture (internal/casedata), rule type checking
+// (internal/lineage) and the ontology checks of DSL literals
+// (parser.ValidateOntologyRefs), so a value is accepted or refused the
+// same way wherever it is written.
+package attrvalue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// ErrInvalid is wrapped by every *Error
+var ErrInvalid = errors.New("invalid attribute value")
+
+// Kinds of value a data type describes. Metadata data types are free text
+// ("enum(ISO 3166-1 Alpha-2)", "float"); Kind maps them to one of these.
+const (
+	KindString     = "string"
+	KindDate       = "date"
+	KindBoolean    = "boolean"
+	KindInteger    = "integer"
+	KindFloat      = "float"
+	KindPercentage = "percentage"
+	KindCountry    = "country"
+	KindEnum       = "enum"
+	KindArray      = "array"
+)
+
+// Rules a value can break, reported in Error.Rule
+const (
+	RuleRequired = "required"
+	RuleType     = "type"
+	RuleFormat   = "format"
+	RuleRange    = "range"
+	RuleDomain   = "domain"
+)
+
+// DateLayout is the format of date values
+const DateLayout = "2006-01-02"
+
+// Spec is what a value of an attribute is checked against
+type Spec struct {
+	Code         string         `db:"code" json:"code"`
+	Class        string         `db:"attribute_class" json:"attribute_class"`
+	DataType     string         `db:"data_type" json:"data_type"`
+	DomainValues pq.StringArray `db:"domain_values" json:"domain_values,omitempty"`
+}
+
+// Error is a structured validation failure: which attribute and value,
+// the rule broken and, for domain failures, the allowed values
+type Error struct {
+	Attribute string   `json:"attribute"`
+	Value     string   `json:"value"`
+	DataType  string   `json:"data_type,omitempty"`
+	Rule      string   `json:"rule"`
+	Message   string   `json:"message"`
+	Allowed   []string `json:"allowed,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Attribute, e.Message)
+}
+
+// Unwrap makes errors.Is(err, ErrInvalid) hold
+func (e *Error) Unwrap() error {
+	return ErrInvalid
+}
+
+// Specs returns the spec of every dictionary attribute by code
+func Specs(ctx context.Context, db sqlx.QueryerContext) (map[string]Spec, error) {
+	var rows []Spec
+	if err := sqlx.SelectContext(ctx, db, &rows, `
+		SELECT a.code, COALESCE(a.attribute_class, '') AS attribute_class,
+		       COALESCE(m.data_type, '') AS data_type, COALESCE(m.domain_values, '{}') AS domain_values
+		  FROM kyc_attributes a
+		  LEFT JOIN kyc_attribute_metadata m ON m.attribute_code = a.code`); err != nil {
+		return nil, fmt.Errorf("failed to load attribute specs: %w", err)
+	}
+	specs := make(map[string]Spec, len(rows))
+	for _, s := range rows {
+		specs[s.Code] = s
+	}
+	return specs, nil
+}
+
+// Kind maps a metadata data type to the kind of value it describes;
+// unknown and empty data types are strings
+func Kind(dataType string) string {
+	t := strings.ToLower(strings.TrimSpace(dataType))
+	switch {
+	case t == "country", strings.Contains(t, "3166"):
+		return KindCountry
+	case t == "percentage", t == "percent":
+		return KindPercentage
+	case t == "enum", strings.HasPrefix(t, "enum("):
+		return KindEnum
+	case t == "numeric", t == "decimal", t == "number":
+		return KindFloat
+	case t == KindDate, t == KindBoolean, t == KindInteger, t == KindFloat, t == KindArray:
+		return t
+	}
+	return KindString
+}
+
+// Validate checks the text of a value against an attribute's spec and
+// returns the typed value the lineage evaluator expects with its canonical
+// text. Failures are *Error.
+func Validate(spec Spec, raw string) (any, string, error) {
+	fail := func(rule, msg string) (any, string, error) {
+		return nil, "", &Error{Attribute: spec.Code, Value: raw, DataType: spec.DataType, Rule: rule, Message: msg}
+	}
+	text := strings.TrimSpace(raw)
+	if text == "" {
+		return fail(RuleRequired, "value is empty")
+	}
+
+	kind := Kind(spec.DataType)
+	typed, canonical, err := parse(kind, text)
+	if err != nil {
+		return fail(RuleType, err.Error())
+	}
+	switch kind {
+	case KindPercentage:
+		if p := typed.(float64); p < 0 || p > 100 {
+			return fail(RuleRange, fmt.Sprintf("%s is not between 0 and 100", canonical))
+		}
+	case KindCountry:
+		if !IsCountry(canonical) {
+			return fail(RuleFormat, fmt.Sprintf("%q is not an ISO 3166-1 alpha-2 country code", raw))
+		}
+	}
+
+	if len(spec.DomainValues) == 0 {
+		return typed, canonical, nil
+	}
+	if kind == KindArray {
+		for _, item := range typed.([]any) {
+			s, ok := item.(string)
+			if !ok {
+				continue
+			}
+			if _, found := inDomain(spec.DomainValues, s); !found {
+				e := &Error{Attribute: spec.Code, Value: raw, DataType: spec.DataType, Rule: RuleDomain,
+					Message: fmt.Sprintf("%q is not an allowed value", s), Allowed: spec.DomainValues}
+				return nil, "", e
+			}
+		}
+		return typed, canonical, nil
+	}
+	match, found := inDomain(spec.DomainValues, canonical)
+	if !found {
+		e := &Error{Attribute: spec.Code, Value: raw, DataType: spec.DataType, Rule: RuleDomain,
+			Message: fmt.Sprintf("%q is not an allowed value", text), Allowed: spec.DomainValues}
+		return nil, "", e
+	}
+	if kind == KindString || kind == KindEnum || kind == KindCountry {
+		return match, match, nil
+	}
+	return typed, canonical, nil
+}
+
+// Typed converts the canonical text of a stored value back to its typed
+// value, without checking ranges or domain values that may have changed
+// since it was stored
+func Typed(dataType, text string) (any, error) {
+	typed, _, err := parse(Kind(dataType), strings.TrimSpace(text))
+	return typed, err
+}
+
+// Zero returns a stand-in value of a data type for type checking rules, or
+// nil when values of the type are not type-checked
+func Zero(dataType string) any {
+	switch Kind(dataType) {
+	case KindString:
+		// Unknown data types map to strings but are left unchecked
+		if t := strings.ToLower(strings.TrimSpace(dataType)); t == "string" || t == "text" {
+			return ""
+		}
+		return nil
+	case KindDate, KindCountry, KindEnum:
+		return ""
+	case KindInteger:
+		return 0
+	case KindFloat, KindPercentage:
+		return 0.0
+	case KindBoolean:
+		return false
+	case KindArray:
+		return []any{}
+	}
+	return nil
+}
+
+// parse parses text as a kind of value, returning the typed value and its
+// canonical text
+func parse(kind, text string) (any, string, error) {
+	switch kind {
+	case KindDate:
+		t, err := time.Parse(DateLayout, text)
+		if err != nil {
+			return nil, "", fmt.Errorf("%q is not a date (YYYY-MM-DD)", text)
+		}
+		return t.Format(DateLayout), t.Format(DateLayout), nil
+	case KindBoolean:
+		b, err := strconv.ParseBool(strings.ToLower(text))
+		if err != nil {
+			return nil, "", fmt.Errorf("%q is not a boolean", text)
+		}
+		return b, strconv.FormatBool(b), nil
+	case KindInteger:
+		n, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			return nil, "", fmt.Errorf("%q is not an integer", text)
+		}
+		return int(n), strconv.FormatInt(n, 10), nil
+	case KindFloat, KindPercentage:
+		f, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(text, "%")), 64)
+		if err != nil || (kind == KindFloat && strings.HasSuffix(text, "%")) {
+			return nil, "", fmt.Errorf("%q is not a number", text)
+		}
+		return f, strconv.FormatFloat(f, 'f', -1, 64), nil
+	case KindCountry:
+		code := strings.ToUpper(text)
+		return code, code, nil
+	case KindArray:
+		var items []any
+		if err := json.Unmarshal([]byte(text), &items); err != nil {
+			return nil, "", fmt.Errorf("%q is not a JSON array", text)
+		}
+		canonical, _ := json.Marshal(items)
+		return items, string(canonical), nil
+	}
+	return text, text, nil
+}
+
+// inDomain returns the domain value text matches, ignoring case
+func inDomain(domain []string, text string) (string, bool) {
+	for _, v := range domain {
+		if strings.EqualFold(v, text) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// isoCountries are the ISO 3166-1 alpha-2 codes of countries and territories
+const isoCountries = "" +
+	"AD AE AF AG AI AL AM AO AQ AR AS AT AU AW AX AZ " +
+	"BA BB BD BE BF BG BH BI BJ BL BM BN BO BQ BR BS BT BV BW BY BZ " +
+	"CA CC CD CF CG CH CI CK CL CM CN CO CR CU CV CW CX CY CZ " +
+	"DE DJ DK DM DO DZ EC EE EG EH ER ES ET FI FJ FK FM FO FR " +
+	"GA GB GD GE GF GG GH GI GL GM GN GP GQ GR GS GT GU GW GY " +
+	"HK HM HN HR HT HU ID IE IL IM IN IO IQ IR IS IT JE JM JO JP " +
+	"KE KG KH KI KM KN KP KR KW KY KZ LA LB LC LI LK LR LS LT LU LV LY " +
+	"MA MC MD ME MF MG MH MK ML MM MN MO MP MQ MR MS MT MU MV MW MX MY MZ " +
+	"NA NC NE NF NG NI NL NO NP NR NU NZ OM " +
+	"PA PE PF PG PH PK PL PM PN PR PS PT PW PY QA RE RO RS RU RW " +
+	"SA SB SC SD SE SG SH SI SJ SK SL SM SN SO SR SS ST SV SX SY SZ " +
+	"TC TD TF TG TH TJ TK TL TM TN TO TR TT TV TW TZ UA UG UM US UY UZ " +
+	"VA VC VE VG VI VN VU WF WS YE YT ZA ZM ZW"
+
+var countries = func() map[string]bool {
+	m := map[string]bool{}
+	for _, code := range strings.Fields(isoCountries) {
+		m[code] = true
+	}
+	return m
+}()
+
+// IsCountry reports whether code is an ISO 3166-1 alpha-2 country code
+func IsCountry(code string) bool {
+	return countries[strings.ToUpper(code)]
+}
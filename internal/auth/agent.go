@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"context"
+	"errors"
+)
+
+// AgentHeader names the agent a request acts as
+const AgentHeader = "X-Agent-Name"
+
+// ErrAgentDenied is returned when a principal may not act as the requested
+// agent or the agent may not call an endpoint
+var ErrAgentDenied = errors.New("agent not allowed")
+
+// AgentVerifier checks the agent an authenticated request acts as. name is
+// the X-Agent-Name header (may be empty); it returns the agent name to record
+// for the request, or an error wrapping ErrAgentDenied.
+type AgentVerifier interface {
+	VerifyAgent(ctx context.Context, p *Principal, name, endpoint string) (string, error)
+}
+
+// WithAgents enforces the agent registry on authenticated requests
+func (a *Authenticator) WithAgents(v AgentVerifier) *Authenticator {
+	a.agents = v
+	return a
+}
+
+// AgentName returns the agent a request acts as: the verified agent of the
+// principal, else the X-Agent-Name header, else the principal subject
+func AgentName(ctx context.Context, header string) string {
+	p, ok := PrincipalFromContext(ctx)
+	if ok && p.Agent != "" {
+		return p.Agent
+	}
+	if header != "" {
+		return header
+	}
+	if ok {
+		return p.Subject
+	}
+	return ""
+}
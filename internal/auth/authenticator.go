@@ -0,0 +1,190 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/MicahParks/keyfunc/v3"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrNoCredentials is returned when a request carries no credentials
+var ErrNoCredentials = errors.New("no credentials provided")
+
+// Authenticator validates bearer tokens and API keys
+type Authenticator struct {
+	cfg     Config
+	keyfunc keyfunc.Keyfunc
+	agents  AgentVerifier
+}
+
+// NewAuthenticator creates an authenticator. When a JWKS URL is configured
+// the key set is fetched immediately and refreshed in the background until
+// ctx is cancelled.
+func NewAuthenticator(ctx context.Context, cfg Config) (*Authenticator, error) {
+	a := &Authenticator{cfg: cfg}
+
+	if cfg.JWKSURL != "" {
+		kf, err := keyfunc.NewDefaultCtx(ctx, []string{cfg.JWKSURL})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load JWKS from %s: %w", cfg.JWKSURL, err)
+		}
+		a.keyfunc = kf
+	}
+
+	enforced.Store(cfg.Enabled())
+	return a, nil
+}
+
+// Enabled reports whether authentication is enforced
+func (a *Authenticator) Enabled() bool {
+	return a != nil && a.cfg.Enabled()
+}
+
+// Authenticate resolves request credentials to a principal. authorization is
+// the Authorization header ("Bearer <jwt>"); apiKey is the X-API-Key header.
+func (a *Authenticator) Authenticate(ctx context.Context, authorization, apiKey string) (*Principal, error) {
+	if token, ok := strings.CutPrefix(authorization, "Bearer "); ok && strings.TrimSpace(token) != "" {
+		return a.verifyJWT(ctx, strings.TrimSpace(token))
+	}
+	if apiKey != "" {
+		return a.verifyAPIKey(apiKey)
+	}
+	return nil, ErrNoCredentials
+}
+
+func (a *Authenticator) verifyJWT(ctx context.Context, raw string) (*Principal, error) {
+	if a.keyfunc == nil {
+		return nil, errors.New("bearer tokens are not accepted: no JWKS configured")
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(raw, claims, a.keyfunc.KeyfuncCtx(ctx),
+		jwt.WithIssuer(a.cfg.Issuer),
+		jwt.WithAudience(a.cfg.Audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "PS256"}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+
+	subject, _ := claims.GetSubject()
+	tenant, _ := claims[a.cfg.TenantClaim].(string)
+	roles := a.mapRoles(claims[a.cfg.RolesClaim])
+	return &Principal{
+		Subject: subject,
+		Roles:   roles,
+		Method:  "jwt",
+		Claims:  claims,
+		Tenant:  strings.TrimSpace(tenant),
+		Scopes:  a.scopes(claims[a.cfg.ScopesClaim], roles),
+	}, nil
+}
+
+func (a *Authenticator) verifyAPIKey(key string) (*Principal, error) {
+	for candidate, role := range a.cfg.APIKeys {
+		if subtle.ConstantTimeCompare([]byte(candidate), []byte(key)) == 1 {
+			return &Principal{
+				Subject: "api-key:" + keyFingerprint(candidate),
+				Roles:   []Role{role},
+				Method:  "api_key",
+				Scopes:  a.scopes(nil, []Role{role}),
+			}, nil
+		}
+	}
+	return nil, errors.New("invalid API key")
+}
+
+// APIKeySubject returns the subject of a valid API key, which names the
+// key without revealing it
+func (a *Authenticator) APIKeySubject(key string) (string, bool) {
+	if a == nil || key == "" {
+		return "", false
+	}
+	p, err := a.verifyAPIKey(key)
+	if err != nil {
+		return "", false
+	}
+	return p.Subject, true
+}
+
+// mapRoles converts the roles claim (string or list) into KYC roles.
+// Names present in RoleMapping are translated; otherwise names matching a
+// KYC role directly are accepted.
+func (a *Authenticator) mapRoles(claim interface{}) []Role {
+	var names []string
+	switch v := claim.(type) {
+	case string:
+		names = strings.Fields(strings.ReplaceAll(v, ",", " "))
+	case []interface{}:
+		for _, n := range v {
+			if s, ok := n.(string); ok {
+				names = append(names, s)
+			}
+		}
+	}
+
+	seen := make(map[Role]bool)
+	var roles []Role
+	for _, name := range names {
+		role, ok := a.cfg.RoleMapping[name]
+		if !ok {
+			parsed, err := ParseRole(name)
+			if err != nil {
+				continue
+			}
+			role = parsed
+		}
+		if !seen[role] {
+			seen[role] = true
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+// scopes merges the scopes claim (space-separated string or list) with the
+// scopes the roles grant, a role's scopes going to higher roles too
+func (a *Authenticator) scopes(claim interface{}, roles []Role) []string {
+	var names []string
+	switch v := claim.(type) {
+	case string:
+		names = strings.Fields(v)
+	case []interface{}:
+		for _, n := range v {
+			if s, ok := n.(string); ok {
+				names = append(names, s)
+			}
+		}
+	}
+	holder := &Principal{Roles: roles}
+	for role, granted := range a.cfg.RoleScopes {
+		if holder.HasRole(role) {
+			names = append(names, granted...)
+		}
+	}
+
+	seen := make(map[string]bool)
+	var scopes []string
+	for _, name := range names {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			scopes = append(scopes, name)
+		}
+	}
+	sort.Strings(scopes)
+	return scopes
+}
+
+// keyFingerprint identifies an API key in logs without revealing it
+func keyFingerprint(key string) string {
+	if len(key) <= 4 {
+		return "****"
+	}
+	return "****" + key[len(key)-4:]
+}
@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"fmt"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+)
+
+// Config holds authentication settings
+type Config struct {
+	// Issuer is the expected "iss" claim of JWTs
+	Issuer string
+	// Audience is the expected "aud" claim of JWTs
+	Audience string
+	// JWKSURL is where the IdP publishes its signing keys
+	JWKSURL string
+	// RolesClaim is the JWT claim carrying group/role names (default "roles")
+	RolesClaim string
+	// TenantClaim is the JWT claim naming the caller's tenant (default "tenant")
+	TenantClaim string
+	// RoleMapping maps IdP group/role names to KYC roles
+	RoleMapping map[string]Role
+	// APIKeys maps static API keys to the role they grant
+	APIKeys map[string]Role
+	// ScopesClaim is the JWT claim carrying scopes (default "scope")
+	ScopesClaim string
+	// RoleScopes grants scopes to the holders of a role or a higher one
+	RoleScopes map[Role][]string
+}
+
+// Enabled reports whether any authentication method is configured
+func (c Config) Enabled() bool {
+	return c.JWKSURL != "" || len(c.APIKeys) > 0
+}
+
+// ConfigFrom converts the auth section of the process configuration,
+// resolving role names
+func ConfigFrom(c config.AuthConfig) (Config, error) {
+	cfg := Config{
+		Issuer:      c.Issuer,
+		Audience:    c.Audience,
+		JWKSURL:     c.JWKSURL,
+		RolesClaim:  c.RolesClaim,
+		TenantClaim: c.TenantClaim,
+		ScopesClaim: c.ScopesClaim,
+	}
+	if cfg.RolesClaim == "" {
+		cfg.RolesClaim = "roles"
+	}
+	if cfg.TenantClaim == "" {
+		cfg.TenantClaim = "tenant"
+	}
+	if cfg.ScopesClaim == "" {
+		cfg.ScopesClaim = "scope"
+	}
+
+	var err error
+	if cfg.RoleMapping, err = resolveRoles(c.RoleMap); err != nil {
+		return cfg, fmt.Errorf("invalid auth role_map: %w", err)
+	}
+	if cfg.APIKeys, err = resolveRoles(c.APIKeys); err != nil {
+		return cfg, fmt.Errorf("invalid auth api_keys: %w", err)
+	}
+	cfg.RoleScopes = make(map[Role][]string, len(c.RoleScopes))
+	for name, scopes := range c.RoleScopes {
+		role, err := ParseRole(name)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid auth role_scopes: %w", err)
+		}
+		cfg.RoleScopes[role] = scopes
+	}
+
+	if cfg.JWKSURL != "" && (cfg.Issuer == "" || cfg.Audience == "") {
+		return cfg, fmt.Errorf("auth issuer and audience are required when jwks_url is set")
+	}
+
+	return cfg, nil
+}
+
+// resolveRoles maps names to parsed roles
+func resolveRoles(names map[string]string) (map[string]Role, error) {
+	out := make(map[string]Role, len(names))
+	for name, roleStr := range names {
+		role, err := ParseRole(roleStr)
+		if err != nil {
+			return nil, err
+		}
+		out[name] = role
+	}
+	return out, nil
+}
@@ -0,0 +1,145 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// gRPC metadata carrying credentials: a bearer token as in the HTTP
+// Authorization header, or an API key
+const (
+	AuthorizationMetadataKey = "authorization"
+	APIKeyMetadataKey        = "x-api-key"
+)
+
+// TenantMetadataKey is the gRPC counterpart of TenantHeader
+const TenantMetadataKey = "x-tenant-id"
+
+// publicMethods are reachable without credentials: health checks and
+// reflection, which load balancers and grpcurl call anonymously
+var publicMethods = []string{"/grpc.health.v1.Health/", "/grpc.reflection."}
+
+// methodRoles is the role a method requires of an authenticated caller, as
+// the matching REST endpoints do: analyst for feedback and for case, screening
+// and CBU writes, admin for administrative calls. Other methods accept any
+// authenticated caller.
+var methodRoles = map[string]Role{
+	"/kyc.rag.RagService/SubmitFeedback": RoleAnalyst,
+
+	"/kyc.data.CaseService/SaveCaseVersion":          RoleAnalyst,
+	"/kyc.data.CaseService/TransitionCase":           RoleAnalyst,
+	"/kyc.data.CaseService/ScoreCase":                RoleAnalyst,
+	"/kyc.data.CaseService/UploadEvidence":           RoleAnalyst,
+	"/kyc.data.CaseService/SetCaseAttributeValue":    RoleAnalyst,
+	"/kyc.data.CaseService/DeleteCaseAttributeValue": RoleAnalyst,
+	"/kyc.KycCaseService/CreateCase":                 RoleAnalyst,
+	"/kyc.KycCaseService/UpdateCase":                 RoleAnalyst,
+	"/kyc.KycCaseService/AssignCase":                 RoleAnalyst,
+	"/kyc.ontology.OntologyService/ScreenEntity":     RoleAnalyst,
+
+	"/kyc.cbu.CbuGraphService/CreateCbu":             RoleAnalyst,
+	"/kyc.cbu.CbuGraphService/DeleteCbu":             RoleAnalyst,
+	"/kyc.cbu.CbuGraphService/CreateEntity":          RoleAnalyst,
+	"/kyc.cbu.CbuGraphService/UpdateEntity":          RoleAnalyst,
+	"/kyc.cbu.CbuGraphService/DeleteEntity":          RoleAnalyst,
+	"/kyc.cbu.CbuGraphService/CreateRelationship":    RoleAnalyst,
+	"/kyc.cbu.CbuGraphService/DeleteRelationship":    RoleAnalyst,
+	"/kyc.ontology.OntologyService/CreateEntity":     RoleAnalyst,
+	"/kyc.ontology.OntologyService/UpdateEntity":     RoleAnalyst,
+	"/kyc.ontology.OntologyService/CreateCbu":        RoleAnalyst,
+	"/kyc.ontology.OntologyService/AssignCbuRole":    RoleAnalyst,
+	"/kyc.ontology.OntologyService/CreateControl":    RoleAnalyst,
+	"/kyc.ontology.OntologyService/UpdateKycProfile": RoleAnalyst,
+
+	"/kyc.KycCaseService/DeleteCase": RoleAdmin,
+}
+
+// UnaryServerInterceptor requires every call to carry valid credentials
+// (bearer token or API key metadata) and the role its method requires (see
+// methodRoles), and attaches the principal to its context. Health checks and
+// reflection stay public. When authentication is not configured calls run
+// unchanged.
+func (a *Authenticator) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := a.grpcContext(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of UnaryServerInterceptor
+func (a *Authenticator) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.grpcContext(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &principalStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func (a *Authenticator) grpcContext(ctx context.Context, method string) (context.Context, error) {
+	if !a.Enabled() {
+		return ctx, nil
+	}
+	for _, prefix := range publicMethods {
+		if strings.HasPrefix(method, prefix) {
+			return ctx, nil
+		}
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	first := func(key string) string {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+	p, err := a.Authenticate(ctx, first(AuthorizationMetadataKey), first(APIKeyMetadataKey))
+	if errors.Is(err, ErrNoCredentials) {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if role, ok := methodRoles[method]; ok && !p.HasRole(role) {
+		return nil, status.Errorf(codes.PermissionDenied, "%s requires the %s role", method, role)
+	}
+	return WithPrincipal(ctx, p), nil
+}
+
+// principalStream overrides the stream context to carry the principal
+type principalStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *principalStream) Context() context.Context {
+	return s.ctx
+}
+
+// APIKeyCredentials sends an API key with every call of a gRPC client
+// connection. The key travels in plain text over insecure transports, like
+// the rest of the call. An empty key sends nothing.
+type APIKeyCredentials string
+
+// GetRequestMetadata implements credentials.PerRPCCredentials
+func (k APIKeyCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	if k == "" {
+		return nil, nil
+	}
+	return map[string]string{APIKeyMetadataKey: string(k)}, nil
+}
+
+// RequireTransportSecurity implements credentials.PerRPCCredentials
+func (k APIKeyCredentials) RequireTransportSecurity() bool {
+	return false
+}
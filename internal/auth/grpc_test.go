@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pbCbu "github.com/adamtc007/KYC-DSL/api/pb"
+	pb "github.com/adamtc007/KYC-DSL/api/pb/kycdata"
+	pbOntology "github.com/adamtc007/KYC-DSL/api/pb/kycontology"
+)
+
+func TestUnaryServerInterceptorRoles(t *testing.T) {
+	a, err := NewAuthenticator(context.Background(), Config{APIKeys: map[string]Role{
+		"analyst-key":  RoleAnalyst,
+		"reviewer-key": RoleReviewer,
+		"admin-key":    RoleAdmin,
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	interceptor := a.UnaryServerInterceptor()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		key    string
+		want   codes.Code
+	}{
+		{"anonymous", "/kyc.data.CaseService/GetCaseVersion", "", codes.Unauthenticated},
+		{"unknown key", "/kyc.data.CaseService/GetCaseVersion", "wrong-key", codes.Unauthenticated},
+		{"public method", "/grpc.health.v1.Health/Check", "", codes.OK},
+		{"read without role requirement", "/kyc.data.CaseService/GetCaseVersion", "analyst-key", codes.OK},
+		{"analyst submits feedback", "/kyc.rag.RagService/SubmitFeedback", "analyst-key", codes.OK},
+		{"analyst saves a case", "/kyc.data.CaseService/SaveCaseVersion", "analyst-key", codes.OK},
+		{"reviewer writes the CBU graph", "/kyc.cbu.CbuGraphService/CreateEntity", "reviewer-key", codes.OK},
+		{"analyst deletes a case", "/kyc.KycCaseService/DeleteCase", "analyst-key", codes.PermissionDenied},
+		{"reviewer deletes a case", "/kyc.KycCaseService/DeleteCase", "reviewer-key", codes.PermissionDenied},
+		{"admin deletes a case", "/kyc.KycCaseService/DeleteCase", "admin-key", codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.key != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(APIKeyMetadataKey, tt.key))
+			}
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("%s with %q: got %v, want %v (%v)", tt.method, tt.key, got, tt.want, err)
+			}
+		})
+	}
+}
+
+func TestMethodRolesNameServedMethods(t *testing.T) {
+	served := map[string]bool{}
+	for _, desc := range []grpc.ServiceDesc{
+		pb.CaseService_ServiceDesc,
+		pbCbu.KycCaseService_ServiceDesc,
+		pbCbu.CbuGraphService_ServiceDesc,
+		pbCbu.RagService_ServiceDesc,
+		pbOntology.OntologyService_ServiceDesc,
+	} {
+		for _, m := range desc.Methods {
+			served["/"+desc.ServiceName+"/"+m.MethodName] = true
+		}
+		for _, s := range desc.Streams {
+			served["/"+desc.ServiceName+"/"+s.StreamName] = true
+		}
+	}
+	for method := range methodRoles {
+		if !served[method] {
+			t.Errorf("methodRoles names %s, which no dataserver service serves", method)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package auth
+
+import (
+	"context"
+	"strings"
+)
+
+// TenantHeader names the tenant of a request whose credentials carry none
+const TenantHeader = "X-Tenant-ID"
+
+// DefaultTenant is the tenant of requests that name none
+const DefaultTenant = "default"
+
+// Tenant returns the tenant a request belongs to: the tenant claim of the
+// principal's token, else the X-Tenant-ID header, else DefaultTenant. A
+// tenant named by the token cannot be overridden by the header.
+func Tenant(ctx context.Context, header string) string {
+	if p, ok := PrincipalFromContext(ctx); ok && p.Tenant != "" {
+		return p.Tenant
+	}
+	if header = strings.TrimSpace(header); header != "" {
+		return header
+	}
+	return DefaultTenant
+}
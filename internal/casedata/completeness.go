@@ -0,0 +1,254 @@
+package casedata
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/adamtc007/KYC-DSL/internal/parser"
+	"github.com/adamtc007/KYC-DSL/internal/pii"
+	"github.com/adamtc007/KYC-DSL/internal/policypack"
+)
+
+// Coverage statuses of a required attribute
+const (
+	CoveragePresent = "present"
+	CoverageMissing = "missing"
+	// CoverageStale is a value whose evidence, or the validity period of
+	// its source document since capture, has expired
+	CoverageStale = "stale"
+)
+
+// Requirement is a source requiring a case to capture an attribute:
+// "document:PASSPORT" for a document the case requires or
+// "policy-pack:AMLD5@v1" for a policy pack that applies to it
+type Requirement struct {
+	Attribute string `json:"attribute"`
+	Source    string `json:"source"`
+}
+
+// AttributeCoverage is the status of a required attribute in a case
+// version, with the value covering it when one is captured
+type AttributeCoverage struct {
+	Attribute      string     `json:"attribute"`
+	Status         string     `json:"status"`
+	RequiredBy     []string   `json:"required_by"`
+	Value          string     `json:"value,omitempty"`
+	CapturedIn     int        `json:"captured_in_version,omitempty"`
+	SourceDocument string     `json:"source_document,omitempty"`
+	EvidenceID     *int64     `json:"evidence_id,omitempty"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+}
+
+// Completeness is the coverage of a case version's required attributes by
+// its captured values. Percent is the share of required attributes present
+// (100 when nothing is required).
+type Completeness struct {
+	CaseName   string              `json:"case"`
+	Version    int                 `json:"version"`
+	Required   int                 `json:"required"`
+	Present    int                 `json:"present"`
+	Missing    int                 `json:"missing"`
+	Stale      int                 `json:"stale"`
+	Percent    float64             `json:"completeness_percent"`
+	Attributes []AttributeCoverage `json:"attributes"`
+}
+
+// coverageValues selects the values of case $1 at version $2 with the date
+// each expires: the expiry of its evidence, or the capture date plus the
+// validity of its source document
+const coverageValues = `
+	SELECT c.*, c.expires_at < CURRENT_DATE AS expired FROM (
+	SELECT v.attribute_code, v.value, v.key_id, v.case_version, v.source_document, v.evidence_id,
+	       COALESCE(e.expires_at, CASE
+	           WHEN d.validity_days > 0 THEN (v.captured_at + make_interval(days => d.validity_days))::date
+	           WHEN d.validity_years > 0 THEN (v.captured_at + make_interval(years => d.validity_years))::date
+	       END) AS expires_at
+	  FROM (` + asOf + ` ORDER BY attribute_code, case_version DESC) v
+	  LEFT JOIN kyc_evidence e ON e.id = v.evidence_id
+	  LEFT JOIN kyc_documents d ON d.code = v.source_document) c`
+
+// Completeness reports, for a case version (0: the current one), each
+// attribute its requirements call for as present, missing or stale
+func (r *Repo) Completeness(ctx context.Context, caseName string, version int) (*Completeness, error) {
+	version, _, err := r.version(ctx, caseName, version)
+	if err != nil {
+		return nil, err
+	}
+	requirements, err := r.Requirements(ctx, caseName, version)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []struct {
+		AttributeCode  string     `db:"attribute_code"`
+		Value          string     `db:"value"`
+		KeyID          string     `db:"key_id"`
+		CaseVersion    int        `db:"case_version"`
+		SourceDocument string     `db:"source_document"`
+		EvidenceID     *int64     `db:"evidence_id"`
+		ExpiresAt      *time.Time `db:"expires_at"`
+		Expired        *bool      `db:"expired"`
+	}
+	if err := r.db.SelectContext(ctx, &values, coverageValues, caseName, version); err != nil {
+		return nil, fmt.Errorf("failed to load attribute values of %s: %w", caseName, err)
+	}
+
+	report := &Completeness{CaseName: caseName, Version: version, Attributes: []AttributeCoverage{}}
+	coverage := map[string]*AttributeCoverage{}
+	for _, req := range requirements {
+		c, ok := coverage[req.Attribute]
+		if !ok {
+			c = &AttributeCoverage{Attribute: req.Attribute, Status: CoverageMissing}
+			coverage[req.Attribute] = c
+		}
+		c.RequiredBy = append(c.RequiredBy, req.Source)
+	}
+	for _, v := range values {
+		c, ok := coverage[v.AttributeCode]
+		if !ok {
+			continue
+		}
+		value, err := pii.Reveal(ctx, caseName, v.AttributeCode, v.Value, v.KeyID)
+		if err != nil {
+			return nil, err
+		}
+		c.Status = CoveragePresent
+		if v.Expired != nil && *v.Expired {
+			c.Status = CoverageStale
+		}
+		c.Value, c.CapturedIn, c.SourceDocument = value, v.CaseVersion, v.SourceDocument
+		c.EvidenceID, c.ExpiresAt = v.EvidenceID, v.ExpiresAt
+	}
+
+	for _, c := range coverage {
+		switch c.Status {
+		case CoveragePresent:
+			report.Present++
+		case CoverageStale:
+			report.Stale++
+		default:
+			report.Missing++
+		}
+		report.Attributes = append(report.Attributes, *c)
+	}
+	sort.Slice(report.Attributes, func(i, j int) bool {
+		return report.Attributes[i].Attribute < report.Attributes[j].Attribute
+	})
+	report.Required = len(report.Attributes)
+	report.Percent = 100
+	if report.Required > 0 {
+		report.Percent = math.Round(float64(report.Present)/float64(report.Required)*1000) / 10
+	}
+	return report, nil
+}
+
+// Requirements returns the attributes a case version must capture: the
+// mandatory public attributes evidenced by each document of its
+// document-requirements, for the requirement's jurisdiction, and the
+// mandatory attributes of the active policy packs of those jurisdictions
+// and of its policies (internal/policypack)
+func (r *Repo) Requirements(ctx context.Context, caseName string, version int) ([]Requirement, error) {
+	var dsl string
+	if err := r.db.GetContext(ctx, &dsl, `
+		SELECT COALESCE(dsl_snapshot, '') FROM kyc_case_versions WHERE case_name = $1 AND version = $2`,
+		caseName, version); err != nil {
+		return nil, fmt.Errorf("failed to load %s version %d: %w", caseName, version, err)
+	}
+	doc, err := parser.Parse(dsl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s version %d: %w", caseName, version, err)
+	}
+
+	// Jurisdictions each document is required for
+	jurisdictions := map[string][]string{}
+	var caseJurisdictions, policies []string
+	for _, form := range doc.Children {
+		if form.Head() != "kyc-case" {
+			continue
+		}
+		c, err := parser.Compile(form)
+		if err != nil {
+			return nil, err
+		}
+		for _, req := range c.DocumentRequirements {
+			caseJurisdictions = append(caseJurisdictions, req.Jurisdiction)
+			for _, d := range req.Documents {
+				jurisdictions[d.Code] = append(jurisdictions[d.Code], req.Jurisdiction)
+			}
+		}
+		policies = append(policies, c.Policies...)
+	}
+
+	var requirements []Requirement
+	seen := map[Requirement]bool{}
+	add := func(req Requirement) {
+		if !seen[req] {
+			seen[req] = true
+			requirements = append(requirements, req)
+		}
+	}
+
+	if len(jurisdictions) > 0 {
+		documents := make([]string, 0, len(jurisdictions))
+		for code := range jurisdictions {
+			documents = append(documents, code)
+		}
+		sort.Strings(documents)
+
+		var links []struct {
+			AttributeCode string `db:"attribute_code"`
+			DocumentCode  string `db:"document_code"`
+			Jurisdiction  string `db:"jurisdiction"`
+		}
+		if err := r.db.SelectContext(ctx, &links, `
+			SELECT DISTINCT l.attribute_code, l.document_code, COALESCE(l.jurisdiction, '') AS jurisdiction
+			  FROM kyc_attr_doc_links l
+			  JOIN kyc_attributes a ON a.code = l.attribute_code
+			 WHERE l.document_code = ANY($1) AND COALESCE(l.is_mandatory, TRUE)
+			   AND COALESCE(a.attribute_class, 'Public') <> 'Private'
+			 ORDER BY l.attribute_code, l.document_code`, pq.Array(documents)); err != nil {
+			return nil, fmt.Errorf("failed to load the attributes of the documents of %s: %w", caseName, err)
+		}
+		for _, l := range links {
+			if linkApplies(l.Jurisdiction, jurisdictions[l.DocumentCode]) {
+				add(Requirement{Attribute: l.AttributeCode, Source: "document:" + l.DocumentCode})
+			}
+		}
+	}
+
+	if len(caseJurisdictions) > 0 || len(policies) > 0 {
+		packs, err := policypack.NewRepo(r.db).ForJurisdictions(ctx, caseJurisdictions, policies)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range packs {
+			for _, a := range p.Attributes {
+				if a.Mandatory {
+					add(Requirement{Attribute: a.Code, Source: fmt.Sprintf("policy-pack:%s@v%d", p.Code, p.Version)})
+				}
+			}
+		}
+	}
+	return requirements, nil
+}
+
+// linkApplies reports whether an attribute-document link for a
+// jurisdiction (empty: every one) applies to a document required for the
+// given jurisdictions; GLOBAL requirements take every link
+func linkApplies(link string, required []string) bool {
+	if link == "" {
+		return true
+	}
+	for _, j := range required {
+		if strings.EqualFold(j, link) || strings.EqualFold(j, "GLOBAL") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,424 @@
+// Package casedata stores the public attribute values captured for a case,
+// per case version, validated against the data types and domain values of
+// the dictionary (internal/attrvalue) as the case's tenant resolves it
+// (internal/overlay).
+// The values of a version are the latest captured at or before it; the
+// values of the current version are mirrored into the case data dictionary
+// (internal/casedict) and changes queue the derived attributes that depend
+// on them for re-evaluation (internal/reeval). Values of personal data
+// attributes are stored encrypted when encryption is enabled (internal/pii)
+// and returned decrypted. Completeness compares the values of a version
+// with the attributes its documents and policy packs call for.
+package casedata
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/adamtc007/KYC-DSL/internal/actor"
+	"github.com/adamtc007/KYC-DSL/internal/attrvalue"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/overlay"
+	"github.com/adamtc007/KYC-DSL/internal/pii"
+	"github.com/adamtc007/KYC-DSL/internal/reeval"
+)
+
+var (
+	// ErrCaseNotFound is returned for a case that does not exist
+	ErrCaseNotFound = errors.New("case not found")
+	// ErrVersionNotFound is returned for a case version that does not exist
+	ErrVersionNotFound = errors.New("case version not found")
+	// ErrUnknownAttribute is returned for an attribute not in the dictionary
+	ErrUnknownAttribute = errors.New("unknown attribute")
+	// ErrDerivedAttribute is returned when capturing a private attribute,
+	// whose values are derived by lineage rules
+	ErrDerivedAttribute = errors.New("attribute is derived")
+	// ErrUnknownDocument is returned for a source document not in the
+	// dictionary
+	ErrUnknownDocument = errors.New("unknown source document")
+	// ErrUnknownEvidence is returned for evidence that is not attached to
+	// the case
+	ErrUnknownEvidence = errors.New("unknown evidence")
+	// ErrInvalidValue is wrapped by the *attrvalue.Error returned for a
+	// value that does not match the data type or domain of its attribute
+	ErrInvalidValue = attrvalue.ErrInvalid
+	// ErrValueNotFound is returned when a case version has no value for an
+	// attribute
+	ErrValueNotFound = errors.New("attribute value not found")
+)
+
+const valueColumns = `
+	id, case_name, case_version, attribute_code, value, COALESCE(key_id, '') AS key_id, value_type,
+	COALESCE(source_document, '') AS source_document, evidence_id, captured_by, captured_at, updated_at
+`
+
+// asOf selects, for case $1 and version $2, the latest value of each
+// attribute captured at or before the version
+const asOf = `
+	SELECT DISTINCT ON (attribute_code) ` + valueColumns + `
+	  FROM kyc_case_attribute_values
+	 WHERE case_name = $1 AND case_version <= $2`
+
+// Capture is a value to store for an attribute of a case
+type Capture struct {
+	CaseName string
+	// CaseVersion is the version the value applies from; 0 means the
+	// case's current version
+	CaseVersion    int
+	AttributeCode  string
+	Value          string
+	SourceDocument string
+	EvidenceID     *int64
+}
+
+// Repo captures and reads case attribute values
+type Repo struct {
+	db *sqlx.DB
+}
+
+// NewRepo creates a new case attribute value repository
+func NewRepo(db *sqlx.DB) *Repo {
+	return &Repo{db: db}
+}
+
+// dictionaryType names a data type the way the case data dictionary
+// records value types
+func dictionaryType(dataType string) string {
+	switch attrvalue.Kind(dataType) {
+	case attrvalue.KindBoolean:
+		return "boolean"
+	case attrvalue.KindInteger, attrvalue.KindFloat, attrvalue.KindPercentage:
+		return "numeric"
+	}
+	return "string"
+}
+
+// version resolves a case version (0: the current one) and returns it with
+// the case's current version
+func (r *Repo) version(ctx context.Context, caseName string, version int) (int, int, error) {
+	var exists bool
+	if err := r.db.GetContext(ctx, &exists, `SELECT EXISTS (SELECT 1 FROM kyc_cases WHERE name = $1)`, caseName); err != nil {
+		return 0, 0, fmt.Errorf("failed to look up case %s: %w", caseName, err)
+	}
+	if !exists {
+		return 0, 0, fmt.Errorf("%w: %s", ErrCaseNotFound, caseName)
+	}
+	var current int
+	if err := r.db.GetContext(ctx, &current, `
+		SELECT COALESCE(MAX(version), 0) FROM kyc_case_versions WHERE case_name = $1`, caseName); err != nil {
+		return 0, 0, fmt.Errorf("failed to find the latest version of %s: %w", caseName, err)
+	}
+	if current == 0 {
+		return 0, 0, fmt.Errorf("%w: %s has no versions", ErrVersionNotFound, caseName)
+	}
+	if version == 0 {
+		return current, current, nil
+	}
+	if version < 0 || version > current {
+		return 0, 0, fmt.Errorf("%w: %s version %d (latest is %d)", ErrVersionNotFound, caseName, version, current)
+	}
+	return version, current, nil
+}
+
+// Set validates a value against the data type and domain values of its
+// attribute and stores it for a case version, replacing the value captured
+// at that version. Refused values return an *attrvalue.Error.
+func (r *Repo) Set(ctx context.Context, c Capture) (*model.CaseAttributeValue, error) {
+	version, current, err := r.version(ctx, c.CaseName, c.CaseVersion)
+	if err != nil {
+		return nil, err
+	}
+	code := strings.ToUpper(strings.TrimSpace(c.AttributeCode))
+	// The case's tenant may add or override attributes in its overlay
+	resolver, err := overlay.ForCase(ctx, r.db, c.CaseName)
+	if err != nil {
+		return nil, err
+	}
+	specs, err := resolver.Specs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	spec, ok := specs[code]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAttribute, code)
+	}
+	if spec.Class == "Private" {
+		return nil, fmt.Errorf("%w: %s is computed by lineage rules and cannot be captured", ErrDerivedAttribute, code)
+	}
+	_, text, err := attrvalue.Validate(spec, c.Value)
+	if err != nil {
+		return nil, err
+	}
+	document := strings.ToUpper(strings.TrimSpace(c.SourceDocument))
+	if document != "" {
+		var exists bool
+		if err := r.db.GetContext(ctx, &exists, `SELECT EXISTS (SELECT 1 FROM kyc_documents WHERE code = $1)`, document); err != nil {
+			return nil, fmt.Errorf("failed to look up document %s: %w", document, err)
+		}
+		if !exists {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownDocument, document)
+		}
+	}
+	if c.EvidenceID != nil {
+		var exists bool
+		if err := r.db.GetContext(ctx, &exists, `
+			SELECT EXISTS (SELECT 1 FROM kyc_evidence WHERE id = $1 AND case_name = $2)`, *c.EvidenceID, c.CaseName); err != nil {
+			return nil, fmt.Errorf("failed to look up evidence %d: %w", *c.EvidenceID, err)
+		}
+		if !exists {
+			return nil, fmt.Errorf("%w: %d is not evidence of %s", ErrUnknownEvidence, *c.EvidenceID, c.CaseName)
+		}
+	}
+	valueType := spec.DataType
+	if valueType == "" {
+		valueType = "string"
+	}
+	stored, keyID, err := r.seal(ctx, c.CaseName, code, text)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var v model.CaseAttributeValue
+	if err := tx.GetContext(ctx, &v, `
+		INSERT INTO kyc_case_attribute_values
+		       (case_name, case_version, attribute_code, value, key_id, value_type, source_document, evidence_id, captured_by)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, NULLIF($7, ''), $8, $9)
+		ON CONFLICT (case_name, case_version, attribute_code) DO UPDATE
+		   SET value = EXCLUDED.value, key_id = EXCLUDED.key_id, value_type = EXCLUDED.value_type,
+		       source_document = EXCLUDED.source_document, evidence_id = EXCLUDED.evidence_id,
+		       captured_by = EXCLUDED.captured_by, updated_at = CURRENT_TIMESTAMP
+		RETURNING `+valueColumns,
+		c.CaseName, version, code, stored, keyID, valueType, document, c.EvidenceID, actor.FromContext(ctx).Name); err != nil {
+		return nil, fmt.Errorf("failed to capture %s of %s: %w", code, c.CaseName, err)
+	}
+	v.Value = text
+	changed, value, err := mirror(ctx, tx, c.CaseName, code, current)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit %s of %s: %w", code, c.CaseName, err)
+	}
+	if changed {
+		if _, err := reeval.NewQueue(r.db).AttributeChanged(ctx, c.CaseName, code, value); err != nil {
+			return &v, err
+		}
+	}
+	return &v, nil
+}
+
+// seal encrypts the canonical text of a value when the attribute is
+// protected, returning what to store and its key (empty for plaintext)
+func (r *Repo) seal(ctx context.Context, caseName, code, text string) (string, string, error) {
+	cipher, err := pii.Default()
+	if err != nil {
+		return "", "", err
+	}
+	if !cipher.Protects(code, false) {
+		personal, err := pii.Personal(ctx, r.db, caseName, code)
+		if err != nil {
+			return "", "", err
+		}
+		if !cipher.Protects(code, personal) {
+			return text, "", nil
+		}
+	}
+	stored, keyID, err := cipher.Seal(ctx, text, pii.FieldAAD(caseName, code))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encrypt %s of %s: %w", code, caseName, err)
+	}
+	return stored, keyID, nil
+}
+
+// reveal decrypts the values read from the store in place
+func reveal(ctx context.Context, values []model.CaseAttributeValue) error {
+	for i, v := range values {
+		plaintext, err := pii.Reveal(ctx, v.CaseName, v.AttributeCode, v.Value, v.KeyID)
+		if err != nil {
+			return err
+		}
+		values[i].Value = plaintext
+	}
+	return nil
+}
+
+// mirror writes the current value of an attribute into the case data
+// dictionary as a captured entry, or removes the captured entry when the
+// current version has no value, and reports whether the current value
+// changed and what it is. Encrypted values are mirrored as stored.
+func mirror(ctx context.Context, tx *sqlx.Tx, caseName, code string, current int) (bool, any, error) {
+	var v model.CaseAttributeValue
+	err := tx.GetContext(ctx, &v, asOf+` AND attribute_code = $3 ORDER BY attribute_code, case_version DESC`,
+		caseName, current, code)
+	if errors.Is(err, sql.ErrNoRows) {
+		res, err := tx.ExecContext(ctx, `
+			DELETE FROM kyc_case_data_dictionary
+			 WHERE case_name = $1 AND attribute_code = $2 AND NOT derived`, caseName, code)
+		if err != nil {
+			return false, nil, fmt.Errorf("failed to remove %s from the data dictionary of %s: %w", code, caseName, err)
+		}
+		n, _ := res.RowsAffected()
+		return n > 0, nil, nil
+	}
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to load current %s of %s: %w", code, caseName, err)
+	}
+
+	// Captured values take precedence over derived entries
+	var previous struct {
+		Value sql.NullString `db:"value"`
+		KeyID string         `db:"key_id"`
+	}
+	err = tx.GetContext(ctx, &previous, `
+		SELECT value, COALESCE(key_id, '') AS key_id FROM kyc_case_data_dictionary
+		 WHERE case_name = $1 AND attribute_code = $2 AND NOT derived`, caseName, code)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return false, nil, fmt.Errorf("failed to load the data dictionary of %s: %w", caseName, err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO kyc_case_data_dictionary
+		       (case_name, attribute_code, value, key_id, value_type, derived, materialized_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, FALSE, CURRENT_TIMESTAMP)
+		ON CONFLICT (case_name, attribute_code) DO UPDATE
+		   SET value = EXCLUDED.value, key_id = EXCLUDED.key_id, value_type = EXCLUDED.value_type, derived = FALSE,
+		       evaluation_id = NULL, rule = NULL, regulation_code = NULL,
+		       materialized_at = EXCLUDED.materialized_at`,
+		caseName, code, v.Value, v.KeyID, dictionaryType(v.ValueType)); err != nil {
+		return false, nil, fmt.Errorf("failed to write %s to the data dictionary of %s: %w", code, caseName, err)
+	}
+	// Each encryption of a value differs, so plaintexts are compared
+	text, err := pii.Reveal(ctx, caseName, code, v.Value, v.KeyID)
+	if err != nil {
+		return false, nil, err
+	}
+	changed := !previous.Value.Valid
+	if !changed {
+		before, err := pii.Reveal(ctx, caseName, code, previous.Value.String, previous.KeyID)
+		if err != nil {
+			return false, nil, err
+		}
+		changed = before != text
+	}
+	if v.KeyID == pii.ErasedKeyID {
+		return changed, nil, nil
+	}
+	value, err := attrvalue.Typed(v.ValueType, text)
+	if err != nil {
+		return false, nil, fmt.Errorf("stored %s of %s: %w", code, caseName, err)
+	}
+	return changed, value, nil
+}
+
+// List returns the values of a case version (0: the current one) by
+// attribute code, each the latest captured at or before the version
+func (r *Repo) List(ctx context.Context, caseName string, version int) ([]model.CaseAttributeValue, error) {
+	version, _, err := r.version(ctx, caseName, version)
+	if err != nil {
+		return nil, err
+	}
+	values := []model.CaseAttributeValue{}
+	if err := r.db.SelectContext(ctx, &values, asOf+` ORDER BY attribute_code, case_version DESC`,
+		caseName, version); err != nil {
+		return nil, fmt.Errorf("failed to load attribute values of %s: %w", caseName, err)
+	}
+	if err := reveal(ctx, values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// Get returns the value of an attribute in a case version (0: the current
+// one)
+func (r *Repo) Get(ctx context.Context, caseName string, version int, attributeCode string) (*model.CaseAttributeValue, error) {
+	version, _, err := r.version(ctx, caseName, version)
+	if err != nil {
+		return nil, err
+	}
+	code := strings.ToUpper(strings.TrimSpace(attributeCode))
+	var v model.CaseAttributeValue
+	err = r.db.GetContext(ctx, &v, asOf+` AND attribute_code = $3 ORDER BY attribute_code, case_version DESC`,
+		caseName, version, code)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s of %s version %d", ErrValueNotFound, code, caseName, version)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s of %s: %w", code, caseName, err)
+	}
+	if v.Value, err = pii.Reveal(ctx, v.CaseName, v.AttributeCode, v.Value, v.KeyID); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// Delete removes the value of an attribute captured at a case version (0:
+// the current one); the value captured at an earlier version, if any,
+// applies again
+func (r *Repo) Delete(ctx context.Context, caseName string, version int, attributeCode string) error {
+	version, current, err := r.version(ctx, caseName, version)
+	if err != nil {
+		return err
+	}
+	code := strings.ToUpper(strings.TrimSpace(attributeCode))
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.ExecContext(ctx, `
+		DELETE FROM kyc_case_attribute_values
+		 WHERE case_name = $1 AND case_version = $2 AND attribute_code = $3`, caseName, version, code)
+	if err != nil {
+		return fmt.Errorf("failed to delete %s of %s: %w", code, caseName, err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%w: %s captured at %s version %d", ErrValueNotFound, code, caseName, version)
+	}
+	changed, value, err := mirror(ctx, tx, caseName, code, current)
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit deletion of %s of %s: %w", code, caseName, err)
+	}
+	if changed {
+		if _, err := reeval.NewQueue(r.db).AttributeChanged(ctx, caseName, code, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Environment returns the typed values of a case version (0: the current
+// one) by attribute code, as lineage.NewEvaluator takes them; values
+// erased for a data subject are left out
+func (r *Repo) Environment(ctx context.Context, caseName string, version int) (map[string]any, error) {
+	values, err := r.List(ctx, caseName, version)
+	if err != nil {
+		return nil, err
+	}
+	env := make(map[string]any, len(values))
+	for _, v := range values {
+		if v.KeyID == pii.ErasedKeyID {
+			continue
+		}
+		typed, err := attrvalue.Typed(v.ValueType, v.Value)
+		if err != nil {
+			return nil, fmt.Errorf("stored %s of %s: %w", v.AttributeCode, caseName, err)
+		}
+		env[v.AttributeCode] = typed
+	}
+	return env, nil
+}
@@ -0,0 +1,136 @@
+// Package casedict stores the data dictionary values of a case and
+// materializes derived attribute results from kyc_lineage_evaluations into it,
+// so exports read public and derived values from one place.
+package casedict
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/pii"
+)
+
+// materializeEvaluations upserts successful evaluations ($1) as derived
+// entries; values captured directly (derived = FALSE) are never overwritten
+const materializeEvaluations = `
+	INSERT INTO kyc_case_data_dictionary
+	       (case_name, attribute_code, value, value_type, derived, evaluation_id, rule, regulation_code, materialized_at)
+	SELECT case_name, derived_code, value, value_type, TRUE, id, rule, regulation_code, CURRENT_TIMESTAMP
+	  FROM kyc_lineage_evaluations
+	 WHERE id = ANY($1) AND success
+	ON CONFLICT (case_name, attribute_code) DO UPDATE
+	   SET value = EXCLUDED.value,
+	       value_type = EXCLUDED.value_type,
+	       evaluation_id = EXCLUDED.evaluation_id,
+	       rule = EXCLUDED.rule,
+	       regulation_code = EXCLUDED.regulation_code,
+	       materialized_at = EXCLUDED.materialized_at
+	 WHERE kyc_case_data_dictionary.derived`
+
+const entryColumns = `
+	case_name, attribute_code, COALESCE(value, '') AS value, COALESCE(key_id, '') AS key_id,
+	COALESCE(value_type, '') AS value_type,
+	derived, evaluation_id, COALESCE(rule, '') AS rule, COALESCE(regulation_code, '') AS regulation_code,
+	materialized_at
+`
+
+// Repo reads and materializes case data dictionaries
+type Repo struct {
+	db *sqlx.DB
+}
+
+// NewRepo creates a new case dictionary repository
+func NewRepo(db *sqlx.DB) *Repo {
+	return &Repo{db: db}
+}
+
+// reveal decrypts the encrypted captured values of entries in place
+func reveal(ctx context.Context, entries []model.DictionaryEntry) error {
+	for i, e := range entries {
+		value, err := pii.Reveal(ctx, e.CaseName, e.AttributeCode, e.Value, e.KeyID)
+		if err != nil {
+			return err
+		}
+		entries[i].Value = value
+	}
+	return nil
+}
+
+// Entries returns the data dictionary of a case by attribute code
+func (r *Repo) Entries(ctx context.Context, caseName string) ([]model.DictionaryEntry, error) {
+	entries := []model.DictionaryEntry{}
+	if err := r.db.SelectContext(ctx, &entries, `
+		SELECT `+entryColumns+`
+		  FROM kyc_case_data_dictionary
+		 WHERE case_name = $1
+		 ORDER BY attribute_code`, caseName); err != nil {
+		return nil, fmt.Errorf("failed to load data dictionary of %s: %w", caseName, err)
+	}
+	if err := reveal(ctx, entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// MaterializeDerived copies the latest successful evaluation of each derived
+// attribute of a case into its data dictionary and returns the derived entries
+func (r *Repo) MaterializeDerived(ctx context.Context, caseName string) ([]model.DictionaryEntry, error) {
+	var ids []int64
+	if err := r.db.SelectContext(ctx, &ids, `
+		SELECT DISTINCT ON (derived_code) id
+		  FROM kyc_lineage_evaluations
+		 WHERE case_name = $1 AND success
+		 ORDER BY derived_code, evaluated_at DESC, id DESC`, caseName); err != nil {
+		return nil, fmt.Errorf("failed to load evaluations of %s: %w", caseName, err)
+	}
+	if err := Materialize(ctx, r.db, ids...); err != nil {
+		return nil, err
+	}
+
+	entries := []model.DictionaryEntry{}
+	if err := r.db.SelectContext(ctx, &entries, `
+		SELECT `+entryColumns+`
+		  FROM kyc_case_data_dictionary
+		 WHERE case_name = $1 AND derived
+		 ORDER BY attribute_code`, caseName); err != nil {
+		return nil, fmt.Errorf("failed to load derived values of %s: %w", caseName, err)
+	}
+	return entries, nil
+}
+
+// Materialize writes the given lineage evaluations into the data dictionaries
+// of their cases; failed evaluations are skipped. It runs on a database or
+// inside the caller's transaction.
+func Materialize(ctx context.Context, db sqlx.ExecerContext, evaluationIDs ...int64) error {
+	if len(evaluationIDs) == 0 {
+		return nil
+	}
+	if _, err := db.ExecContext(ctx, materializeEvaluations, pq.Array(evaluationIDs)); err != nil {
+		return fmt.Errorf("failed to materialize derived values: %w", err)
+	}
+	return nil
+}
+
+// Record writes a value produced outside the derivation rules (a risk
+// rating, a screening outcome) into a case's data dictionary as a derived
+// entry; source says what produced it. Like materialized results, it never
+// overwrites a captured value. It runs on a database or inside the caller's
+// transaction.
+func Record(ctx context.Context, db sqlx.ExecerContext, caseName, attribute, value, valueType, source string) error {
+	if _, err := db.ExecContext(ctx, `
+		INSERT INTO kyc_case_data_dictionary
+		       (case_name, attribute_code, value, value_type, derived, rule, materialized_at)
+		VALUES ($1, $2, $3, $4, TRUE, $5, CURRENT_TIMESTAMP)
+		ON CONFLICT (case_name, attribute_code) DO UPDATE
+		   SET value = EXCLUDED.value, value_type = EXCLUDED.value_type, evaluation_id = NULL,
+		       rule = EXCLUDED.rule, regulation_code = NULL, materialized_at = EXCLUDED.materialized_at
+		 WHERE kyc_case_data_dictionary.derived`,
+		caseName, attribute, value, valueType, source); err != nil {
+		return fmt.Errorf("failed to record %s of %s: %w", attribute, caseName, err)
+	}
+	return nil
+}
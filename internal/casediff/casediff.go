@@ -0,0 +1,227 @@
+// Package casediff compares two DSL snapshots of a case section by section.
+// Unlike a text diff it ignores layout, comments and the order of sections,
+// and reports what a reviewer cares about: which documents, attributes,
+// policies and ownership entries were added, removed or changed between two
+// versions.
+package casediff
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+// Change types
+const (
+	Added   = "added"
+	Removed = "removed"
+	Changed = "changed"
+)
+
+// Sections a change is grouped under, in report order
+const (
+	SectionDocuments  = "documents"
+	SectionAttributes = "attributes"
+	SectionPolicies   = "policies"
+	SectionOwnership  = "ownership"
+	SectionCase       = "case"
+)
+
+var sectionOrder = map[string]int{
+	SectionDocuments:  0,
+	SectionAttributes: 1,
+	SectionPolicies:   2,
+	SectionOwnership:  3,
+	SectionCase:       4,
+}
+
+// ErrVersionNotFound is returned when a compared version does not exist
+var ErrVersionNotFound = errors.New("case version not found")
+
+// Change is one entry added, removed or changed between two snapshots.
+// Before and After are the entry's DSL form on one line.
+type Change struct {
+	Type    string `json:"type"`
+	Section string `json:"section"`
+	Key     string `json:"key"`
+	Before  string `json:"before,omitempty"`
+	After   string `json:"after,omitempty"`
+}
+
+// Diff is the semantic difference between two versions of a case
+type Diff struct {
+	CaseName    string   `json:"case_name"`
+	FromVersion int      `json:"from_version"`
+	ToVersion   int      `json:"to_version"`
+	FromHash    string   `json:"from_hash"`
+	ToHash      string   `json:"to_hash"`
+	Changes     []Change `json:"changes"`
+}
+
+// Counts returns the number of changes of each type
+func (d *Diff) Counts() map[string]int {
+	counts := map[string]int{Added: 0, Removed: 0, Changed: 0}
+	for _, c := range d.Changes {
+		counts[c.Type]++
+	}
+	return counts
+}
+
+// Versions compares two stored versions of a case (kyc_case_versions). A to
+// of 0 selects the latest version and a from of 0 the version before to.
+func Versions(db *sqlx.DB, caseName string, from, to int) (*Diff, error) {
+	if to <= 0 {
+		_, latest, _, err := storage.GetLatestCaseWithMetadata(db, caseName)
+		if err != nil {
+			return nil, versionError(err, caseName, 0)
+		}
+		to = latest
+	}
+	if from <= 0 {
+		from = to - 1
+	}
+	if from <= 0 {
+		return nil, fmt.Errorf("%w: %s has no version before %d", ErrVersionNotFound, caseName, to)
+	}
+
+	fromDSL, fromHash, err := storage.GetCaseVersion(db, caseName, from)
+	if err != nil {
+		return nil, versionError(err, caseName, from)
+	}
+	toDSL, toHash, err := storage.GetCaseVersion(db, caseName, to)
+	if err != nil {
+		return nil, versionError(err, caseName, to)
+	}
+
+	changes, err := Compare(fromDSL, toDSL)
+	if err != nil {
+		return nil, err
+	}
+	return &Diff{
+		CaseName:    caseName,
+		FromVersion: from,
+		ToVersion:   to,
+		FromHash:    fromHash,
+		ToHash:      toHash,
+		Changes:     changes,
+	}, nil
+}
+
+func versionError(err error, caseName string, version int) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		if version == 0 {
+			return fmt.Errorf("%w: case %s has no versions", ErrVersionNotFound, caseName)
+		}
+		return fmt.Errorf("%w: %s version %d", ErrVersionNotFound, caseName, version)
+	}
+	return err
+}
+
+// Compare parses two DSL snapshots and returns their changes, grouped by
+// section and sorted by key within a section
+func Compare(fromDSL, toDSL string) ([]Change, error) {
+	before, err := storage.ParseCase(fromDSL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse from snapshot: %w", err)
+	}
+	after, err := storage.ParseCase(toDSL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse to snapshot: %w", err)
+	}
+
+	old, current := entries(before), entries(after)
+	changes := []Change{}
+	for id, e := range current {
+		prev, ok := old[id]
+		switch {
+		case !ok:
+			changes = append(changes, Change{Type: Added, Section: id.section, Key: id.key, After: e})
+		case prev != e:
+			changes = append(changes, Change{Type: Changed, Section: id.section, Key: id.key, Before: prev, After: e})
+		}
+	}
+	for id, e := range old {
+		if _, ok := current[id]; !ok {
+			changes = append(changes, Change{Type: Removed, Section: id.section, Key: id.key, Before: e})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		a, b := changes[i], changes[j]
+		if a.Section != b.Section {
+			return sectionOrder[a.Section] < sectionOrder[b.Section]
+		}
+		return a.Key < b.Key
+	})
+	return changes, nil
+}
+
+// entryID identifies an entry of a case across versions
+type entryID struct {
+	section string
+	key     string
+}
+
+// entries flattens a case into the entries a diff compares, each rendered
+// as its DSL form. Entries that may repeat (policies, documents, owners) are
+// keyed by their name so a renamed entry shows as removed and added.
+func entries(c storage.Section) map[entryID]string {
+	out := map[entryID]string{}
+	add := func(section, key string, s storage.Section) {
+		id := entryID{section, key}
+		if prev, ok := out[id]; ok {
+			out[id] = prev + " " + s.String()
+			return
+		}
+		out[id] = s.String()
+	}
+
+	for _, sec := range c.Children {
+		switch sec.Head {
+		case "policy", "function", "obligation":
+			add(SectionPolicies, sec.Head+" "+sec.Arg(0), sec)
+		case "document-requirements":
+			jurisdiction := ""
+			if j, ok := sec.Find("jurisdiction"); ok {
+				jurisdiction = strings.Join(j.Args, " ")
+			}
+			for _, part := range sec.Children {
+				if part.Head != "required" {
+					continue
+				}
+				for _, doc := range part.Children {
+					key := doc.Arg(0)
+					if jurisdiction != "" {
+						key = jurisdiction + " " + key
+					}
+					add(SectionDocuments, key, doc)
+				}
+			}
+		case "data-dictionary":
+			for _, attr := range sec.Children {
+				add(SectionAttributes, attr.Arg(0), attr)
+			}
+		case "derived-attributes":
+			for _, attr := range sec.Children {
+				add(SectionAttributes, "derived "+attr.Arg(0), attr)
+			}
+		case "ownership-structure":
+			for _, node := range sec.Children {
+				key := node.Head
+				if node.Head != "entity" {
+					key += " " + node.Arg(0)
+				}
+				add(SectionOwnership, key, node)
+			}
+		default:
+			add(SectionCase, sec.Head, sec)
+		}
+	}
+	return out
+}
@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adamtc007/KYC-DSL/internal/actor"
+	"github.com/adamtc007/KYC-DSL/internal/engine"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+// RunAssignCommand assigns or reassigns a case to an analyst and/or team
+func RunAssignCommand(args []string) error {
+	var to model.CaseAssignment
+	var reason string
+	var positional []string
+	for _, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "--team="):
+			to.Team = strings.TrimPrefix(arg, "--team=")
+		case strings.HasPrefix(arg, "--risk="):
+			to.RiskRating = strings.TrimPrefix(arg, "--risk=")
+		case strings.HasPrefix(arg, "--sla="):
+			sla, err := time.ParseDuration(strings.TrimPrefix(arg, "--sla="))
+			if err != nil {
+				return fmt.Errorf("invalid --sla (expected a duration such as 72h): %w", err)
+			}
+			due := time.Now().Add(sla)
+			to.SLADueAt = &due
+		case strings.HasPrefix(arg, "--due="):
+			due, err := time.Parse(time.RFC3339, strings.TrimPrefix(arg, "--due="))
+			if err != nil {
+				return fmt.Errorf("invalid --due (expected an RFC 3339 time): %w", err)
+			}
+			to.SLADueAt = &due
+		case strings.HasPrefix(arg, "--reason="):
+			reason = strings.TrimPrefix(arg, "--reason=")
+		default:
+			positional = append(positional, arg)
+		}
+	}
+	if len(positional) < 1 {
+		return fmt.Errorf("assign requires a case name")
+	}
+	if len(positional) > 1 {
+		to.Assignee = positional[1]
+	}
+
+	db, err := storage.ConnectPostgres()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	a, err := engine.NewAssignments(db).Assign(commandContext(), positional[0], to, reason)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("🗂️  Case %s assigned to %s\n", a.CaseName, assigneeLabel(*a))
+	if a.RiskRating != "" {
+		fmt.Printf("   Risk: %s\n", a.RiskRating)
+	}
+	if a.SLADueAt != nil {
+		fmt.Printf("   SLA due: %s\n", a.SLADueAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// RunAssignmentsCommand prints the assignment history of a case
+func RunAssignmentsCommand(caseName string) error {
+	db, err := storage.ConnectPostgres()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	changes, err := engine.NewAssignments(db).History(context.Background(), caseName)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		fmt.Printf("📭 Case %s has never been assigned\n", caseName)
+		return nil
+	}
+
+	fmt.Printf("📜 Assignments of case %s\n\n", caseName)
+	for _, c := range changes {
+		from := "(unassigned)"
+		if c.FromAssignee != "" || c.FromTeam != "" {
+			from = assigneeLabel(model.CaseAssignment{Assignee: c.FromAssignee, Team: c.FromTeam})
+		}
+		to := assigneeLabel(model.CaseAssignment{Assignee: c.ToAssignee, Team: c.ToTeam})
+		fmt.Printf("  %s  %s → %s  by %s", c.CreatedAt.Format("2006-01-02 15:04"), from, to, c.Actor)
+		if c.Reason != "" {
+			fmt.Printf(" — %s", c.Reason)
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+// RunQueueCommand prints the work queue of assigned cases. Without filters
+// it shows the caller's own queue.
+func RunQueueCommand(args []string) error {
+	var filter model.CaseQueueFilter
+	for _, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "--assignee="):
+			filter.Assignee = strings.TrimPrefix(arg, "--assignee=")
+		case strings.HasPrefix(arg, "--team="):
+			filter.Team = strings.TrimPrefix(arg, "--team=")
+		case strings.HasPrefix(arg, "--status="):
+			st, err := engine.ParseStatus(strings.TrimPrefix(arg, "--status="))
+			if err != nil {
+				return err
+			}
+			filter.Status = st
+		case strings.HasPrefix(arg, "--risk="):
+			filter.RiskRating = strings.TrimPrefix(arg, "--risk=")
+		case strings.HasPrefix(arg, "--due-within="):
+			within, err := time.ParseDuration(strings.TrimPrefix(arg, "--due-within="))
+			if err != nil {
+				return fmt.Errorf("invalid --due-within (expected a duration such as 24h): %w", err)
+			}
+			due := time.Now().Add(within)
+			filter.DueBefore = &due
+		case arg == "--overdue":
+			filter.OverdueOnly = true
+		case strings.HasPrefix(arg, "--limit="):
+			if n, err := strconv.Atoi(strings.TrimPrefix(arg, "--limit=")); err == nil {
+				filter.Limit = n
+			}
+		}
+	}
+	if filter.Assignee == "" && filter.Team == "" {
+		filter.Assignee = actor.CLI().Name
+	}
+
+	db, err := storage.ConnectPostgres()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	queue, err := engine.NewAssignments(db).Queue(context.Background(), filter)
+	if err != nil {
+		return err
+	}
+	if len(queue) == 0 {
+		fmt.Println("📭 No cases in the queue")
+		return nil
+	}
+
+	fmt.Printf("📥 %d case(s) in the queue\n\n", len(queue))
+	fmt.Printf("%-30s %-24s %-10s %-10s %s\n", "Case", "Assignee", "Status", "Risk", "SLA Due")
+	fmt.Println(strings.Repeat("─", 95))
+	for _, a := range queue {
+		due := "-"
+		if a.SLADueAt != nil {
+			due = a.SLADueAt.Format("2006-01-02 15:04")
+			if a.Overdue {
+				due += " ⚠️ overdue"
+			}
+		}
+		risk := a.RiskRating
+		if risk == "" {
+			risk = "-"
+		}
+		fmt.Printf("%-30s %-24s %-10s %-10s %s\n", a.CaseName, assigneeLabel(a), a.Status, risk, due)
+	}
+	return nil
+}
+
+// assigneeLabel names the owner of an assignment: analyst, team or both
+func assigneeLabel(a model.CaseAssignment) string {
+	switch {
+	case a.Assignee != "" && a.Team != "":
+		return a.Assignee + " (" + a.Team + ")"
+	case a.Assignee != "":
+		return a.Assignee
+	default:
+		return "team " + a.Team
+	}
+}
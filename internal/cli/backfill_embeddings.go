@@ -0,0 +1,199 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+	"time"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/embedspace"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/ontology"
+	"github.com/adamtc007/KYC-DSL/internal/rag"
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+// BackfillOptions controls an embedding backfill run
+type BackfillOptions struct {
+	// Kind is attributes, documents, regulations or all
+	Kind string
+	// MaxSpend stops the run before the estimated spend exceeds it (USD, 0 = no limit)
+	MaxSpend float64
+	// RequestsPerMinute rate limits OpenAI calls
+	RequestsPerMinute int
+	// Restart discards any checkpoint and starts from the first code
+	Restart bool
+	// DryRun only prints the cost estimate
+	DryRun bool
+}
+
+// RunBackfillEmbeddingsCommand embeds every ontology entry missing an
+// embedding, checkpointing after each one so an interrupted run resumes
+// where it stopped
+func RunBackfillEmbeddingsCommand(opts BackfillOptions) error {
+	fmt.Println("🧮 Embedding Backfill")
+	fmt.Println("================================================")
+
+	kinds := ontology.BackfillKinds
+	if opts.Kind != "" && opts.Kind != "all" {
+		if !slices.Contains(ontology.BackfillKinds, opts.Kind) {
+			return fmt.Errorf("unknown kind %q (expected attributes, documents, regulations or all)", opts.Kind)
+		}
+		kinds = []string{opts.Kind}
+	}
+
+	db, err := storage.ConnectPostgres()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	// Ctrl-C stops after the current entry; the checkpoint is already saved
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	repo := ontology.NewBackfillRepo(db)
+	embedder := rag.NewEmbedder()
+	modelName := string(embedder.GetModel())
+
+	// Secondary spaces written alongside the primary one (embedding_spaces.dual_write)
+	dualWrite, err := embedspace.DualWriteSpaces(ctx, db, config.Current().EmbeddingSpaces.DualWrite)
+	if err != nil {
+		return err
+	}
+	syncer := embedspace.NewSyncer(db, embedder)
+	for _, s := range dualWrite {
+		fmt.Printf("🧭 Dual-writing to space %s (%s, %d dimensions)\n", s.Name, s.Model, s.Dimensions)
+	}
+
+	// Load pending work and estimate its cost before spending anything
+	type job struct {
+		kind       string
+		checkpoint model.EmbeddingBackfill
+		targets    []model.EmbeddingTarget
+	}
+	var jobs []job
+	var estimatedTokens int64
+	for _, kind := range kinds {
+		name := kind + ":" + modelName
+		if opts.Restart && !opts.DryRun {
+			if err := repo.DeleteCheckpoint(ctx, name); err != nil {
+				return err
+			}
+		}
+
+		cp := model.EmbeddingBackfill{Job: name, Kind: kind, Model: modelName, Status: model.BackfillRunning}
+		if !opts.Restart {
+			saved, err := repo.GetCheckpoint(ctx, name)
+			if err != nil {
+				return err
+			}
+			if saved != nil && saved.Status != model.BackfillCompleted {
+				cp = *saved
+				cp.Status = model.BackfillRunning
+				fmt.Printf("↩️  Resuming %s after %q (%d done, $%.4f spent)\n", kind, cp.LastCode, cp.Processed, cp.SpendUSD)
+			}
+		}
+
+		targets, err := repo.ListPending(ctx, kind, cp.LastCode)
+		if err != nil {
+			return err
+		}
+		var tokens int64
+		for _, t := range targets {
+			tokens += int64(rag.EstimateTokens(t.Text))
+		}
+		estimatedTokens += tokens
+		fmt.Printf("📦 %-12s %5d pending  ~%d tokens  ~$%.4f\n", kind, len(targets), tokens, rag.EmbeddingCost(embedder.GetModel(), tokens))
+		jobs = append(jobs, job{kind: kind, checkpoint: cp, targets: targets})
+	}
+
+	estimate := rag.EmbeddingCost(embedder.GetModel(), estimatedTokens)
+	fmt.Printf("\n💰 Estimated cost: ~$%.4f for ~%d tokens with %s\n", estimate, estimatedTokens, modelName)
+	if opts.MaxSpend > 0 {
+		fmt.Printf("🛑 Spend limit: $%.4f", opts.MaxSpend)
+		if estimate > opts.MaxSpend {
+			fmt.Print(" (the run will stop early; rerun with a higher --max-spend to continue)")
+		}
+		fmt.Println()
+	}
+	if opts.DryRun {
+		fmt.Println("\n✅ Dry run only, nothing embedded")
+		return nil
+	}
+
+	budget := rag.NewBudget(embedder.GetModel(), opts.MaxSpend)
+	embedder.WithBudget(budget).WithRateLimit(opts.RequestsPerMinute)
+	start := time.Now()
+
+	for _, j := range jobs {
+		if len(j.targets) == 0 {
+			continue
+		}
+		fmt.Printf("\n▶️  Backfilling %s (%d entries)\n", j.kind, len(j.targets))
+		cp := j.checkpoint
+
+		for i, target := range j.targets {
+			tokensBefore := budget.Tokens()
+			embedding, err := embedder.GenerateEmbeddingFromText(ctx, target.Text)
+			if err != nil {
+				if errors.Is(err, rag.ErrSpendLimit) || ctx.Err() != nil {
+					return pauseBackfill(repo, cp, err)
+				}
+				fmt.Printf("  ❌ [%d/%d] %s: %v\n", i+1, len(j.targets), target.Code, err)
+				cp.Failed++
+			} else if err := repo.SaveEmbedding(ctx, j.kind, target.Code, ontology.ContentHash(target.Text), embedding); err != nil {
+				if ctx.Err() != nil {
+					return pauseBackfill(repo, cp, err)
+				}
+				fmt.Printf("  ❌ [%d/%d] %s: %v\n", i+1, len(j.targets), target.Code, err)
+				cp.Failed++
+			} else {
+				cp.Processed++
+				fmt.Printf("  ✅ [%d/%d] %s\n", i+1, len(j.targets), target.Code)
+				// A missed dual-write is filled in by kycctl embeddings sync
+				if _, err := syncer.Write(ctx, dualWrite, j.kind, []model.EmbeddingTarget{target}); err != nil {
+					fmt.Printf("  ⚠️  [%d/%d] %s: %v\n", i+1, len(j.targets), target.Code, err)
+				}
+			}
+
+			cp.LastCode = target.Code
+			cp.Tokens += budget.Tokens() - tokensBefore
+			cp.SpendUSD = rag.EmbeddingCost(embedder.GetModel(), cp.Tokens)
+			if err := repo.SaveCheckpoint(ctx, cp); err != nil {
+				return pauseBackfill(repo, cp, err)
+			}
+		}
+
+		cp.Status = model.BackfillCompleted
+		if err := repo.SaveCheckpoint(ctx, cp); err != nil {
+			return err
+		}
+		fmt.Printf("✅ %s complete: %d embedded, %d failed\n", j.kind, cp.Processed, cp.Failed)
+	}
+
+	fmt.Println("\n================================================")
+	fmt.Printf("⏱️  Total time: %s\n", time.Since(start).Round(time.Millisecond))
+	fmt.Printf("💰 Spent this run: $%.4f (%d tokens)\n", budget.Spent(), budget.Tokens())
+	return nil
+}
+
+// pauseBackfill marks a job paused so the next run resumes after its last code
+func pauseBackfill(repo *ontology.BackfillRepo, cp model.EmbeddingBackfill, cause error) error {
+	cp.Status = model.BackfillPaused
+	// The run context may already be cancelled; the checkpoint must still land
+	if err := repo.SaveCheckpoint(context.Background(), cp); err != nil {
+		return fmt.Errorf("%v (and failed to save checkpoint: %w)", cause, err)
+	}
+	fmt.Printf("\n⏸️  Backfill of %s paused after %q: %v\n", cp.Kind, cp.LastCode, cause)
+	fmt.Println("   Run the same command again to resume.")
+	if errors.Is(cause, rag.ErrSpendLimit) {
+		return nil
+	}
+	return cause
+}
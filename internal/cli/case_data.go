@@ -0,0 +1,154 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/casedata"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+// RunCaseDataCommand captures, lists, reads and deletes the public
+// attribute values of a case:
+//
+//	kycctl case-data set <case> <attr> <value> [--version=N] [--document=CODE] [--evidence=ID]
+//	kycctl case-data list <case> [--version=N]
+//	kycctl case-data get <case> <attr> [--version=N]
+//	kycctl case-data delete <case> <attr> [--version=N]
+//	kycctl case-data completeness <case> [--version=N]
+//
+// Values are captured under the operating system user.
+func RunCaseDataCommand(action string, args []string) error {
+	var positional []string
+	capture := casedata.Capture{}
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "--") {
+			positional = append(positional, arg)
+			continue
+		}
+		name, value, _ := strings.Cut(arg, "=")
+		switch name {
+		case "--version":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return fmt.Errorf("--version must be a positive integer, got %q", value)
+			}
+			capture.CaseVersion = n
+		case "--document":
+			capture.SourceDocument = value
+		case "--evidence":
+			id, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("--evidence must be an evidence ID, got %q", value)
+			}
+			capture.EvidenceID = &id
+		default:
+			return fmt.Errorf("unknown case-data argument %q", arg)
+		}
+	}
+
+	db, err := storage.ConnectPostgres()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	repo := casedata.NewRepo(db)
+	ctx := commandContext()
+
+	switch action {
+	case "set":
+		if len(positional) < 3 {
+			return fmt.Errorf("case-data set requires a case, an attribute and a value")
+		}
+		capture.CaseName, capture.AttributeCode, capture.Value = positional[0], positional[1], positional[2]
+		v, err := repo.Set(ctx, capture)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ %s of %s (v%d) set to %s\n", v.AttributeCode, v.CaseName, v.CaseVersion, v.Value)
+
+	case "list":
+		if len(positional) < 1 {
+			return fmt.Errorf("case-data list requires a case")
+		}
+		values, err := repo.List(ctx, positional[0], capture.CaseVersion)
+		if err != nil {
+			return err
+		}
+		if len(values) == 0 {
+			fmt.Printf("No attribute values captured for %s\n", positional[0])
+			return nil
+		}
+		fmt.Printf("📋 %d attribute values of %s\n\n", len(values), positional[0])
+		fmt.Printf("  %-30s %-30s %-8s %-4s %-12s %s\n", "ATTRIBUTE", "VALUE", "TYPE", "VER", "DOCUMENT", "CAPTURED")
+		for _, v := range values {
+			printCaseAttributeValue(v)
+		}
+
+	case "get":
+		if len(positional) < 2 {
+			return fmt.Errorf("case-data get requires a case and an attribute")
+		}
+		v, err := repo.Get(ctx, positional[0], capture.CaseVersion, positional[1])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("  %-30s %-30s %-8s %-4s %-12s %s\n", "ATTRIBUTE", "VALUE", "TYPE", "VER", "DOCUMENT", "CAPTURED")
+		printCaseAttributeValue(*v)
+
+	case "delete":
+		if len(positional) < 2 {
+			return fmt.Errorf("case-data delete requires a case and an attribute")
+		}
+		if err := repo.Delete(ctx, positional[0], capture.CaseVersion, positional[1]); err != nil {
+			return err
+		}
+		fmt.Printf("🗑️  %s of %s deleted\n", strings.ToUpper(positional[1]), positional[0])
+
+	case "completeness":
+		if len(positional) < 1 {
+			return fmt.Errorf("case-data completeness requires a case")
+		}
+		report, err := repo.Completeness(ctx, positional[0], capture.CaseVersion)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("📊 %s v%d: %.1f%% complete (%d of %d required attributes present, %d missing, %d stale)\n",
+			report.CaseName, report.Version, report.Percent, report.Present, report.Required, report.Missing, report.Stale)
+		if len(report.Attributes) == 0 {
+			fmt.Println("   No attributes are required by the case's document requirements")
+			return nil
+		}
+		fmt.Printf("\n  %-30s %-8s %-30s %-12s %s\n", "ATTRIBUTE", "STATUS", "VALUE", "EXPIRES", "REQUIRED BY")
+		for _, a := range report.Attributes {
+			expires := "-"
+			if a.ExpiresAt != nil {
+				expires = a.ExpiresAt.Format("2006-01-02")
+			}
+			value := a.Value
+			if value == "" {
+				value = "-"
+			}
+			fmt.Printf("  %-30s %-8s %-30s %-12s %s\n", a.Attribute, a.Status, value, expires, strings.Join(a.RequiredBy, ", "))
+		}
+
+	default:
+		return fmt.Errorf("unknown case-data action %q (expected set, list, get, delete or completeness)", action)
+	}
+	return nil
+}
+
+func printCaseAttributeValue(v model.CaseAttributeValue) {
+	document := v.SourceDocument
+	if document == "" {
+		document = "-"
+	}
+	if v.EvidenceID != nil {
+		document = fmt.Sprintf("%s #%d", document, *v.EvidenceID)
+	}
+	fmt.Printf("  %-30s %-30s %-8s v%-3d %-12s %s by %s\n", v.AttributeCode, v.Value, v.ValueType,
+		v.CaseVersion, document, v.CapturedAt.Format("2006-01-02 15:04"), v.CapturedBy)
+}
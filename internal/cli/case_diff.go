@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/casediff"
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+// ANSI colors of diff output
+const (
+	colorReset  = "\033[0m"
+	colorRed    = "\033[31m"
+	colorGreen  = "\033[32m"
+	colorYellow = "\033[33m"
+	colorBold   = "\033[1m"
+)
+
+// RunDiffCommand prints the section diff between two versions of a case:
+// kycctl diff CASE [FROM [TO]]. TO defaults to the latest version and FROM to
+// the one before it. Output is colored on a terminal unless NO_COLOR is set
+// or --no-color is given.
+func RunDiffCommand(args []string) error {
+	color := isTerminal(os.Stdout) && os.Getenv("NO_COLOR") == ""
+	var positional []string
+	for _, arg := range args {
+		switch {
+		case arg == "--no-color":
+			color = false
+		case strings.HasPrefix(arg, "--"):
+			return fmt.Errorf("unknown diff flag %q", arg)
+		default:
+			positional = append(positional, arg)
+		}
+	}
+	if len(positional) < 1 || len(positional) > 3 {
+		return fmt.Errorf("usage: kycctl diff <case> [from] [to]")
+	}
+	versions := make([]int, 2)
+	for i, s := range positional[1:] {
+		v, err := strconv.Atoi(s)
+		if err != nil || v <= 0 {
+			return fmt.Errorf("invalid version %q: must be a positive number", s)
+		}
+		versions[i] = v
+	}
+
+	db, err := storage.ConnectPostgres()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	diff, err := casediff.Versions(db, positional[0], versions[0], versions[1])
+	if err != nil {
+		return err
+	}
+
+	paint := func(code, s string) string {
+		if !color {
+			return s
+		}
+		return code + s + colorReset
+	}
+
+	fmt.Printf("🔀 Case: %s  v%d → v%d\n", diff.CaseName, diff.FromVersion, diff.ToVersion)
+	fmt.Printf("   %s → %s\n", truncate(diff.FromHash, 12), truncate(diff.ToHash, 12))
+	if len(diff.Changes) == 0 {
+		fmt.Println("✅ No section changes")
+		return nil
+	}
+
+	section := ""
+	for _, c := range diff.Changes {
+		if c.Section != section {
+			section = c.Section
+			fmt.Println()
+			fmt.Println(paint(colorBold, strings.ToUpper(section)))
+		}
+		switch c.Type {
+		case casediff.Added:
+			fmt.Println(paint(colorGreen, "  + "+c.Key))
+			fmt.Println(paint(colorGreen, "      "+c.After))
+		case casediff.Removed:
+			fmt.Println(paint(colorRed, "  - "+c.Key))
+			fmt.Println(paint(colorRed, "      "+c.Before))
+		case casediff.Changed:
+			fmt.Println(paint(colorYellow, "  ~ "+c.Key))
+			fmt.Println(paint(colorRed, "      - "+c.Before))
+			fmt.Println(paint(colorGreen, "      + "+c.After))
+		}
+	}
+
+	counts := diff.Counts()
+	fmt.Println()
+	fmt.Printf("📊 %d added, %d removed, %d changed\n", counts[casediff.Added], counts[casediff.Removed], counts[casediff.Changed])
+	return nil
+}
+
+// isTerminal reports whether f is a character device such as a terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
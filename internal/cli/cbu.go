@@ -0,0 +1,211 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	cbupb "github.com/adamtc007/KYC-DSL/api/pb"
+	"github.com/adamtc007/KYC-DSL/internal/dataclient"
+)
+
+// RunCbuCommand lists, shows, validates and compares Client Business Unit
+// graphs. args are the words after the action: the CBU id, then the entity id
+// for chain, the dates for diff or the relationship id for history.
+func RunCbuCommand(action string, args []string) error {
+	client, err := dataclient.NewFromEnv()
+	if err != nil {
+		return fmt.Errorf("failed to connect to data service: %w", err)
+	}
+	defer client.Close()
+
+	if action != "list" && len(args) < 1 {
+		return fmt.Errorf("cbu %s requires a CBU id", action)
+	}
+
+	switch action {
+	case "list":
+		list, err := client.ListCbus(100, 0)
+		if err != nil {
+			return err
+		}
+		if len(list.Cbus) == 0 {
+			fmt.Println("ℹ️  No CBUs found")
+			return nil
+		}
+		fmt.Printf("🏢 CBUs: %d\n\n", list.TotalCount)
+		fmt.Println("ID                                   │ Code                     │ Entities │ Edges │ Name")
+		fmt.Println("─────────────────────────────────────┼──────────────────────────┼──────────┼───────┼─────────────────")
+		for _, c := range list.Cbus {
+			fmt.Printf("%-36s │ %-24s │ %8d │ %5d │ %s\n", c.CbuId, c.Code, c.EntityCount, c.RelationshipCount, c.Name)
+		}
+		fmt.Println()
+
+	case "show":
+		var graph *cbupb.CbuGraph
+		var asOf time.Time
+		for _, arg := range args[1:] {
+			if strings.HasPrefix(arg, "--as-of=") {
+				if asOf, err = parseAsOf(strings.TrimPrefix(arg, "--as-of=")); err != nil {
+					return err
+				}
+			}
+		}
+		if asOf.IsZero() {
+			graph, err = client.GetCbuGraph(args[0])
+		} else {
+			graph, err = client.GetCbuGraphAsOf(args[0], asOf)
+		}
+		if err != nil {
+			return err
+		}
+		if !asOf.IsZero() {
+			fmt.Printf("🕰️  As of %s\n", asOf.Format(time.DateOnly))
+		}
+		names := make(map[string]string, len(graph.Entities))
+		fmt.Printf("🏢 CBU: %s (%s)\n", graph.Name, graph.CbuId)
+		if graph.Description != "" {
+			fmt.Printf("📝 %s\n", graph.Description)
+		}
+		fmt.Printf("\n👥 Entities (%d):\n", graph.EntityCount)
+		for _, e := range graph.Entities {
+			names[e.Id] = e.Name
+			fmt.Printf("  • %-40s %-12s %-4s %s\n", e.Name, e.EntityType, e.Jurisdiction, e.Id)
+		}
+		fmt.Printf("\n🔗 Relationships (%d):\n", graph.RelationshipCount)
+		for _, r := range graph.Relationships {
+			fmt.Printf("  • %s ─%s", names[r.FromId], r.RelationType)
+			if r.ControlPct > 0 {
+				fmt.Printf(" %.2f%%", r.ControlPct)
+			}
+			fmt.Printf("→ %s", names[r.ToId])
+			if r.EndDate != nil {
+				fmt.Printf("  (until %s)", r.EndDate.AsTime().Format(time.DateOnly))
+			}
+			fmt.Println()
+		}
+		fmt.Println()
+
+	case "diff":
+		if len(args) < 2 {
+			return fmt.Errorf("cbu diff requires a CBU id and a from date (and optionally a to date)")
+		}
+		from, err := parseAsOf(args[1])
+		if err != nil {
+			return err
+		}
+		to := time.Now()
+		if len(args) >= 3 {
+			if to, err = parseAsOf(args[2]); err != nil {
+				return err
+			}
+		}
+		diff, err := client.DiffCbuGraph(args[0], from, to)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("🕰️  Changes between %s and %s\n\n", from.Format(time.DateOnly), to.Format(time.DateOnly))
+		for _, e := range diff.AddedEntities {
+			fmt.Printf("  ➕ entity %s (%s)\n", e.Name, e.EntityType)
+		}
+		for _, e := range diff.RemovedEntities {
+			fmt.Printf("  ➖ entity %s (%s)\n", e.Name, e.EntityType)
+		}
+		for _, c := range diff.Changes {
+			switch c.ChangeType {
+			case "added":
+				fmt.Printf("  ➕ %s ─%s %.2f%%→ %s\n", c.FromName, c.RelationType, c.NewPct, c.ToName)
+			case "removed":
+				fmt.Printf("  ➖ %s ─%s %.2f%%→ %s\n", c.FromName, c.RelationType, c.OldPct, c.ToName)
+			default:
+				fmt.Printf("  ✏️  %s ─%s→ %s: %.2f%% → %.2f%%\n", c.FromName, c.RelationType, c.ToName, c.OldPct, c.NewPct)
+			}
+		}
+		if len(diff.Changes)+len(diff.AddedEntities)+len(diff.RemovedEntities) == 0 {
+			fmt.Println("  ✅ No ownership or control changes")
+		}
+		fmt.Println()
+
+	case "history":
+		if len(args) < 2 {
+			return fmt.Errorf("cbu history requires a CBU id and a relationship id")
+		}
+		history, err := client.GetCbuRelationshipHistory(args[0], args[1])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("🕰️  Versions of relationship %s:\n", history.RelationshipId)
+		for _, v := range history.Versions {
+			r := v.Relationship
+			fmt.Printf("  %s  %-6s %s %.2f%% from %s", v.RecordedAt.AsTime().Format("2006-01-02 15:04"), v.Operation,
+				r.RelationType, r.ControlPct, r.EffectiveDate.AsTime().Format(time.DateOnly))
+			if r.EndDate != nil {
+				fmt.Printf(" until %s", r.EndDate.AsTime().Format(time.DateOnly))
+			}
+			fmt.Println()
+		}
+
+	case "validate":
+		var tolerance float32
+		for _, arg := range args[1:] {
+			if strings.HasPrefix(arg, "--tolerance=") {
+				fmt.Sscanf(strings.TrimPrefix(arg, "--tolerance="), "%g", &tolerance)
+			}
+		}
+		result, err := client.ValidateCbuGraph(args[0], tolerance)
+		if err != nil {
+			return err
+		}
+		if result.Valid {
+			fmt.Println("✅ Graph is valid")
+		} else {
+			fmt.Println("❌ Graph has errors")
+		}
+		fmt.Printf("📊 Ownership of primary entity: %.2f%%\n", result.TotalControlPct)
+		for _, issue := range result.Issues {
+			icon := "ℹ️ "
+			switch issue.Severity {
+			case "error":
+				icon = "❌"
+			case "warning":
+				icon = "⚠️ "
+			}
+			fmt.Printf("  %s %s\n", icon, issue.Message)
+		}
+
+	case "chain":
+		if len(args) < 2 {
+			return fmt.Errorf("cbu chain requires a CBU id and an entity id")
+		}
+		chain, err := client.GetCbuControlChain(args[0], args[1])
+		if err != nil {
+			return err
+		}
+		if len(chain.Chain) == 0 {
+			fmt.Println("ℹ️  Entity has no owners or controllers in this CBU")
+			return nil
+		}
+		fmt.Println("⛓️  Control chain (ultimate owner first):")
+		for _, link := range chain.Chain {
+			fmt.Printf("  %s ─%s %.2f%%→ %s\n", link.FromEntityName, link.RelationType, link.ControlPct, link.ToEntityName)
+		}
+		fmt.Printf("📊 Effective control: %.2f%%\n", chain.EffectiveControlPct)
+
+	default:
+		return fmt.Errorf("unknown cbu action %q (expected list, show, validate, chain, diff or history)", action)
+	}
+
+	return nil
+}
+
+// parseAsOf reads a date (end of that day) or an RFC 3339 timestamp
+func parseAsOf(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseInLocation(time.DateOnly, s, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q: use YYYY-MM-DD or RFC 3339", s)
+	}
+	return d.Add(24*time.Hour - time.Nanosecond), nil
+}
@@ -1,19 +1,34 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
 	"strings"
 
+	"github.com/jmoiron/sqlx"
+
 	pb "github.com/adamtc007/KYC-DSL/api/pb"
+	"github.com/adamtc007/KYC-DSL/internal/actor"
 	"github.com/adamtc007/KYC-DSL/internal/amend"
+	"github.com/adamtc007/KYC-DSL/internal/engine"
+	"github.com/adamtc007/KYC-DSL/internal/events"
 	"github.com/adamtc007/KYC-DSL/internal/model"
 	"github.com/adamtc007/KYC-DSL/internal/ontology"
+	"github.com/adamtc007/KYC-DSL/internal/overlay"
+	"github.com/adamtc007/KYC-DSL/internal/parser"
+	"github.com/adamtc007/KYC-DSL/internal/policypack"
 	"github.com/adamtc007/KYC-DSL/internal/rustclient"
 	"github.com/adamtc007/KYC-DSL/internal/storage"
 )
 
+// commandContext is the context of a kycctl command: the changes it makes
+// are attributed to the operating system user (internal/actor)
+func commandContext() context.Context {
+	return actor.With(context.Background(), actor.CLI())
+}
+
 // RunGrammarCommand stores the current grammar definition in the database.
 func RunGrammarCommand() error {
 	// Connect to Rust DSL service to get grammar
@@ -40,17 +55,23 @@ func RunGrammarCommand() error {
 	}()
 
 	// Store grammar in database
-	err = storage.InsertGrammar(db, "KYC-DSL", grammarResp.Version, grammarResp.Ebnf)
+	err = storage.InsertGrammar(commandContext(), db, "KYC-DSL", grammarResp.Version, grammarResp.Ebnf)
 	if err != nil {
 		return fmt.Errorf("insert grammar failed: %w", err)
 	}
 
 	fmt.Printf("✅ Grammar (v%s) inserted into Postgres via Rust service.\n", grammarResp.Version)
+	if err := CheckGrammarVersion(grammarResp.Version); err != nil {
+		fmt.Printf("⚠️  %v\n", err)
+	} else if grammarResp.Version != parser.CurrentGrammarVersion {
+		fmt.Printf("⚠️  This kycctl writes grammar v%s; the Rust engine is on v%s\n", parser.CurrentGrammarVersion, grammarResp.Version)
+	}
 	return nil
 }
 
 // RunProcessCommand parses, validates, and persists a DSL file via Rust service.
-func RunProcessCommand(filePath string) error {
+// A new version is only created when the canonical form changed, unless force is set.
+func RunProcessCommand(filePath string, force bool) error {
 	// Read DSL file
 	dslContent, err := os.ReadFile(filePath)
 	if err != nil {
@@ -87,7 +108,14 @@ func RunProcessCommand(filePath string) error {
 	if !valResult.Valid {
 		return fmt.Errorf("❌ DSL validation failed: %v", valResult.Errors)
 	}
-	fmt.Println("✅ DSL validated successfully (grammar + semantics) via Rust service.")
+	if rustclient.ValidatedLocally(valResult) {
+		fmt.Println("⚠️  DSL syntax checked by the Go parser only: the Rust DSL service is unavailable.")
+	} else {
+		fmt.Println("✅ DSL validated successfully (grammar + semantics) via Rust service.")
+	}
+	if version, err := parser.DetectGrammarVersion(dslText); err == nil && version != parser.CurrentGrammarVersion {
+		fmt.Printf("⚠️  %s is written in grammar v%s; run kycctl grammar-migrate --case=NAME after storing it\n", filePath, version)
+	}
 
 	// Connect to database for persistence
 	db, err := storage.ConnectPostgres()
@@ -104,17 +132,22 @@ func RunProcessCommand(filePath string) error {
 	caseName := parseResp.Cases[0].Name
 	displayParsedCaseInfo(parseResp.Cases[0])
 
-	// Save to database
-	if err := storage.SaveCaseVersion(db, caseName, dslText); err != nil {
+	// Save to database (skipped when only formatting or section order changed)
+	created, err := storage.SaveCaseVersionIfChanged(commandContext(), db, caseName, dslText, force)
+	if err != nil {
 		return fmt.Errorf("failed to save case: %w", err)
 	}
+	if !created {
+		fmt.Printf("\nℹ️  Case %s is unchanged; use --force to store a new version anyway\n", caseName)
+		return nil
+	}
 
 	fmt.Printf("\n🧾 DSL snapshot stored and versioned successfully (case: %s)\n", caseName)
 	return nil
 }
 
 // RunValidateCommand validates an existing case and records audit trail.
-func RunValidateCommand(caseName, actor string) error {
+func RunValidateCommand(caseName, actorName string) error {
 	db, err := storage.ConnectPostgres()
 	if err != nil {
 		return fmt.Errorf("database connection failed: %w", err)
@@ -126,7 +159,7 @@ func RunValidateCommand(caseName, actor string) error {
 	}()
 
 	// Load most recent version
-	dsl, err := storage.GetLatestDSL(db, caseName)
+	dsl, version, hash, err := storage.GetLatestCaseWithMetadata(db, caseName)
 	if err != nil {
 		return fmt.Errorf("failed to load case: %w", err)
 	}
@@ -144,15 +177,146 @@ func RunValidateCommand(caseName, actor string) error {
 		return fmt.Errorf("validation error: %w", err)
 	}
 	if !valResult.Valid {
+		events.Notify(commandContext(), db, events.Event{
+			Type:     events.ValidationFailed,
+			CaseName: caseName,
+			Version:  version,
+			Hash:     hash,
+			Data:     map[string]interface{}{"errors": valResult.Errors},
+		})
 		return fmt.Errorf("validation failed: %v", valResult.Errors)
 	}
 
-	fmt.Printf("✅ Case %s validated via Rust service.\n", caseName)
+	if rustclient.ValidatedLocally(valResult) {
+		fmt.Printf("⚠️  Case %s syntax checked by the Go parser only: the Rust DSL service is unavailable.\n", caseName)
+	} else {
+		fmt.Printf("✅ Case %s validated via Rust service.\n", caseName)
+	}
+
+	// Codes and literal values must be known to the ontology
+	issues, err := checkOntologyRefs(commandContext(), db, caseName, dsl)
+	if err != nil {
+		return err
+	}
+	var ontologyErrors []string
+	for _, issue := range issues {
+		if issue.Severity == parser.SeverityWarning {
+			fmt.Printf("⚠️  %s %s: %s\n", issue.Section, issue.Ref, issue.Message)
+			continue
+		}
+		ontologyErrors = append(ontologyErrors, fmt.Sprintf("%s %s: %s", issue.Section, issue.Ref, issue.Message))
+	}
+	if len(ontologyErrors) > 0 {
+		events.Notify(commandContext(), db, events.Event{
+			Type:     events.ValidationFailed,
+			CaseName: caseName,
+			Version:  version,
+			Hash:     hash,
+			Data:     map[string]interface{}{"errors": ontologyErrors, "ontology_issues": issues},
+		})
+		return fmt.Errorf("validation failed: %v", ontologyErrors)
+	}
+	fmt.Printf("✅ Case %s references are known to the ontology.\n", caseName)
+
+	// A draft that validates moves on in its lifecycle; a syntax check by
+	// the Go parser is not enough
+	if rustclient.ValidatedLocally(valResult) {
+		return nil
+	}
+	lifecycle := engine.NewLifecycle(db)
+	if status, err := lifecycle.Status(context.Background(), caseName); err == nil && status == model.CaseDraft {
+		if _, err := lifecycle.Transition(commandContext(), caseName, model.CaseValidated, actorName, "validated via Rust service"); err != nil {
+			return fmt.Errorf("failed to mark case validated: %w", err)
+		}
+		fmt.Printf("🔀 Case %s is now %s\n", caseName, model.CaseValidated)
+	}
 	return nil
 }
 
+// casePolicyPacks returns the active policy packs of the jurisdictions
+// given or, when none are, of the jurisdictions of the latest version's
+// document requirements and the packs of its policies
+func casePolicyPacks(ctx context.Context, db *sqlx.DB, caseName string, jurisdictions []string) ([]model.PolicyPack, error) {
+	var policies []string
+	if len(jurisdictions) == 0 {
+		dsl, err := storage.GetLatestDSL(db, caseName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load case: %w", err)
+		}
+		doc, err := parser.Parse(dsl)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse case: %w", err)
+		}
+		for _, form := range doc.Children {
+			if form.Head() != "kyc-case" {
+				continue
+			}
+			c, err := parser.Compile(form)
+			if err != nil {
+				return nil, err
+			}
+			for _, req := range c.DocumentRequirements {
+				jurisdictions = append(jurisdictions, req.Jurisdiction)
+			}
+			policies = append(policies, c.Policies...)
+		}
+	}
+	packs, err := policypack.NewRepo(db).ForJurisdictions(ctx, jurisdictions, policies)
+	if err != nil {
+		return nil, err
+	}
+	if len(packs) == 0 {
+		if len(jurisdictions) == 0 && len(policies) == 0 {
+			return nil, fmt.Errorf("case %s has no jurisdiction yet: pass --jurisdiction=<code>", caseName)
+		}
+		return nil, fmt.Errorf("no active policy pack for %s (see kycctl policy-pack list)",
+			strings.Join(append(jurisdictions, policies...), ", "))
+	}
+	return packs, nil
+}
+
+// checkOntologyRefs runs parser.ValidateOntologyRefs over every kyc-case
+// form of a case's DSL against the attributes and documents its tenant
+// resolves: the tenant's overlay, then the global ontology
+func checkOntologyRefs(ctx context.Context, db *sqlx.DB, caseName, dsl string) ([]parser.OntologyIssue, error) {
+	doc, err := parser.Parse(dsl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse case: %w", err)
+	}
+	resolver, err := overlay.ForCase(ctx, db, caseName)
+	if err != nil {
+		return nil, err
+	}
+	specs, err := resolver.Specs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	documents, err := resolver.DocumentCodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	o := parser.Ontology{Attributes: specs, Documents: documents}
+
+	var issues []parser.OntologyIssue
+	for _, form := range doc.Children {
+		if form.Head() != "kyc-case" {
+			continue
+		}
+		c, err := parser.Compile(form)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, parser.ValidateOntologyRefs(c, o)...)
+	}
+	return issues, nil
+}
+
 // RunAmendCommand applies an incremental amendment to an existing case via Rust service.
-func RunAmendCommand(caseName, step string) error {
+// It is refused while someone other than holder has a live lock on the case.
+// policy-discovery and document-discovery apply the active policy packs of
+// the jurisdictions given, or else of the case's document requirements and
+// policies.
+func RunAmendCommand(caseName, step, holder string, jurisdictions []string) error {
 	db, err := storage.ConnectPostgres()
 	if err != nil {
 		return fmt.Errorf("database connection failed: %w", err)
@@ -163,16 +327,32 @@ func RunAmendCommand(caseName, step string) error {
 		}
 	}()
 
+	if err := engine.NewLocks(db).Check(context.Background(), caseName, holder); err != nil {
+		return fmt.Errorf("amendment refused: %w", err)
+	}
+
 	// Special handling for ontology-aware amendments that need DB access
-	if step == "document-discovery" {
-		// This one needs the ontology repo, so use the existing amend package
-		repo := ontology.NewRepository(db)
+	if step == "policy-discovery" || step == "document-discovery" {
+		packs, err := casePolicyPacks(commandContext(), db, caseName, jurisdictions)
+		if err != nil {
+			return err
+		}
+		// These need the policy packs and the ontology of the case's tenant,
+		// so use the amend package
+		sources, err := overlay.ForCase(commandContext(), db, caseName)
+		if err != nil {
+			return err
+		}
 		mutation := func(c *model.KycCase) {
-			if err := amend.AddDocumentDiscovery(c, repo); err != nil {
-				log.Printf("Error in document discovery: %v", err)
+			discover := func() error { return amend.AddPolicyDiscovery(c, packs) }
+			if step == "document-discovery" {
+				discover = func() error { return amend.AddDocumentDiscovery(c, sources, packs) }
+			}
+			if err := discover(); err != nil {
+				log.Printf("Error in %s: %v", step, err)
 			}
 		}
-		if err := amend.ApplyAmendment(db, caseName, step, mutation); err != nil {
+		if err := amend.ApplyAmendment(commandContext(), db, caseName, step, mutation); err != nil {
 			return fmt.Errorf("amendment failed: %w", err)
 		}
 		fmt.Printf("✅ Amendment '%s' applied successfully to case %s\n", step, caseName)
@@ -196,12 +376,12 @@ func RunAmendCommand(caseName, step string) error {
 	}
 
 	// Save new version to database
-	if err := storage.SaveCaseVersion(db, caseName, amendResp.UpdatedDsl); err != nil {
+	if err := storage.SaveCaseVersion(commandContext(), db, caseName, amendResp.UpdatedDsl); err != nil {
 		return fmt.Errorf("failed to save amended version: %w", err)
 	}
 
 	// Log amendment
-	if err := storage.InsertAmendment(db, caseName, step, "rust-applied", amendResp.Message); err != nil {
+	if err := storage.InsertAmendment(commandContext(), db, caseName, step, "rust-applied", amendResp.Message); err != nil {
 		log.Printf("Warning: failed to log amendment: %v", err)
 	}
 
@@ -258,36 +438,259 @@ func ShowUsage() {
 	fmt.Println("  kycctl grammar                          - Store grammar definition in database")
 	fmt.Println("  kycctl ontology                         - Display regulatory data ontology")
 	fmt.Println("  kycctl validate <case>                  - Validate case and record audit trail")
+	fmt.Println("  kycctl fmt <file.dsl>... [-w]           - Format DSL in the canonical layout (-w: rewrite files)")
+	fmt.Println("  kycctl compile <file> [--format=json|yaml]")
+	fmt.Println("                                          - Compile DSL cases to JSON or YAML, or a .json/.yaml")
+	fmt.Println("                                            file back to DSL")
+	fmt.Println("  kycctl grammar-migrate [--case=NAME...] [--to=V] [--dry-run]")
+	fmt.Println("                                          - Upgrade stored cases to the engine's grammar version,")
+	fmt.Println("                                            recording a grammar-migration amendment")
+	fmt.Println("  kycctl grammar-matrix                   - Grammar version compatibility matrix")
 	fmt.Println("  kycctl get <case> [--version=N]         - Retrieve and display a case")
 	fmt.Println("  kycctl versions <case>                  - List all versions of a case")
+	fmt.Println("  kycctl diff <case> [from] [to] [--no-color]")
+	fmt.Println("                                          - Section diff between two versions (default:")
+	fmt.Println("                                            latest against the one before)")
+	fmt.Println("  kycctl verify-integrity <case>|--all    - Re-validate the version hash chain; fails on tampering")
+	fmt.Println("  kycctl signature verify <case> [--version=N] [--key=PUB.pem...]")
+	fmt.Println("                                          - Verify approval signatures (--key: extra trusted keys)")
+	fmt.Println("  kycctl signature sign <case>            - Sign the latest version of an approved case")
+	fmt.Println("  kycctl signature public-key             - Print the signing public key and its fingerprint")
+	fmt.Println("  kycctl encryption [status]              - Encrypted attribute values by key, and plaintext ones to encrypt")
+	fmt.Println("  kycctl encryption rotate [--plaintext] [--batch=N]")
+	fmt.Println("                                          - Re-wrap values with the current key (--plaintext: also encrypt")
+	fmt.Println("                                            values stored before encryption was enabled)")
+	fmt.Println("  kycctl encryption generate-key [id]     - Generate a key encryption key for PII_KEYS")
 	fmt.Println("  kycctl list                             - List all cases in database")
-	fmt.Println("  kycctl <dsl-file>                       - Parse and process a DSL file")
-	fmt.Println("  kycctl amend <case> --step=<phase>      - Apply incremental amendment to case")
+	fmt.Println("  kycctl timeline <case>                  - Show the case lifecycle state and transitions")
+	fmt.Println("  kycctl transition <case> <status> [--actor=A] [--reason=R]")
+	fmt.Println("                                          - Move a case to draft, validated, in-review,")
+	fmt.Println("                                            approved, declined or archived")
+	fmt.Println("  kycctl assign <case> [assignee] [--team=T] [--risk=R] [--sla=72h|--due=TIME] [--reason=R]")
+	fmt.Println("                                          - Assign or reassign a case (R: LOW, MEDIUM, HIGH, CRITICAL)")
+	fmt.Println("  kycctl assignments <case>               - Show the assignment history of a case")
+	fmt.Println("  kycctl queue [--assignee=A] [--team=T] [--status=S] [--risk=R] [--due-within=D] [--overdue]")
+	fmt.Println("                                          - Work queue of assigned cases (default: your own)")
+	fmt.Println("  kycctl <dsl-file> [--force]             - Parse and process a DSL file (--force: version even if unchanged)")
+	fmt.Println("  kycctl amend <case> --step=<phase> [--holder=H] [--jurisdiction=J,...]")
+	fmt.Println("                                          - Apply incremental amendment to case (refused while")
+	fmt.Println("                                            another holder locks it; H defaults to $USER)")
+	fmt.Println("                                            J selects the policy packs of discovery steps")
+	fmt.Println("  kycctl lock <status|acquire|heartbeat|release> <case> [--holder=H] [--note=N] [--force]")
+	fmt.Println("                                          - Advisory case lock; expired locks can be taken over")
+	fmt.Println("  kycctl narrative <case> [--version=ID] [--template=T] [--polish]")
+	fmt.Println("                                          - Generate a review committee narrative")
+	fmt.Println("  kycctl export-pdf <case> [--version=ID] [--out=FILE] [--draft]")
+	fmt.Println("                                          - Render an approved case into a PDF review pack")
+	fmt.Println("                                            (T: committee, brief)")
 	fmt.Println()
 	fmt.Println("RAG & Vector Search Commands:")
-	fmt.Println("  kycctl seed-metadata                    - Seed attribute metadata with embeddings")
-	fmt.Println("  kycctl search-metadata <query>          - Semantic search for attributes")
+	fmt.Println("  kycctl seed-metadata [--max-spend=USD]  - Seed attribute metadata with embeddings")
+	fmt.Println("  kycctl backfill-embeddings [--kind=K] [--max-spend=USD] [--rate=N] [--restart] [--dry-run]")
+	fmt.Println("                                          - Embed ontology entries missing embeddings; resumes")
+	fmt.Println("                                            after the last checkpointed code (K: attributes,")
+	fmt.Println("                                            documents, regulations, all; N: requests/minute)")
+	fmt.Println("  kycctl reembed --stale [--kind=K] [--batch-size=N] [--unversioned] [--dry-run]")
+	fmt.Println("                                          - Re-embed entries whose text changed since they")
+	fmt.Println("                                            were embedded (--unversioned: also those embedded")
+	fmt.Println("                                            before content hashes were recorded)")
+	fmt.Println("  kycctl embeddings [list]                - Embedding spaces (model, dimensions, coverage)")
+	fmt.Println("  kycctl embeddings create <name> --model=M --dimensions=N")
+	fmt.Println("                                          - Add a secondary space to compare a model against")
+	fmt.Println("                                            the primary one")
+	fmt.Println("  kycctl embeddings sync <name> [--kind=K] [--batch-size=N]")
+	fmt.Println("                                          - Embed what the space is missing or has stale")
+	fmt.Println("  kycctl embeddings promote <name>        - Make a fully synced space primary, resizing the")
+	fmt.Println("                                            embedding columns when its dimension differs")
+	fmt.Println("  kycctl embeddings drop <name>           - Remove a secondary space and its embeddings")
+	fmt.Println("  kycctl embeddings tiers                 - Hot and archived (cold) attribute embeddings")
+	fmt.Println("  kycctl embeddings archive <code...>|--unused-for=D [--limit=N]")
+	fmt.Println("                                          - Move attribute embeddings out of the vector index")
+	fmt.Println("  kycctl embeddings unarchive <code...>|--used|--all")
+	fmt.Println("                                          - Bring archived embeddings back (--used: those")
+	fmt.Println("                                            searched or referenced again)")
+	fmt.Println("  kycctl ingest-document <file> --code=DOC [--chunk-tokens=N] [--overlap=N] [--batch-size=N] [--dry-run]")
+	fmt.Println("                                          - Split a PDF or HTML document into sections, embed")
+	fmt.Println("                                            them and replace the stored sections of DOC")
+	fmt.Println("  kycctl recompute-clusters [--k=N] [--max-k=N] [--llm-labels] [--dry-run]")
+	fmt.Println("                                          - Cluster attribute embeddings into rag_clusters")
+	fmt.Println("                                            (replaces automatic clusters, keeps curated ones)")
+	fmt.Println("  kycctl search-metadata <query> [--space=NAME]")
+	fmt.Println("                                          - Semantic search for attributes, optionally in a")
+	fmt.Println("                                            secondary embedding space")
+	fmt.Println("  kycctl search-sections <query> [--limit=N] [--case=NAME]")
+	fmt.Println("                                          - Semantic search for document sections (or a case's evidence text)")
 	fmt.Println("  kycctl similar-attributes <code>        - Find similar attributes")
 	fmt.Println("  kycctl text-search <term>               - Text-based attribute search")
 	fmt.Println("  kycctl metadata-stats                   - Display metadata statistics")
+	fmt.Println("  kycctl usage-report [--limit=N]         - Ontology hot spots and dead entries")
+	fmt.Println()
+	fmt.Println("CBU Graph Commands:")
+	fmt.Println("  kycctl cbu list                         - List Client Business Units")
+	fmt.Println("  kycctl cbu show <cbu-id> [--as-of=DATE] - Display entities and relationships of a CBU")
+	fmt.Println("  kycctl cbu validate <cbu-id> [--tolerance=PCT]")
+	fmt.Println("                                          - Check ownership totals, cycles and orphans")
+	fmt.Println("  kycctl cbu chain <cbu-id> <entity-id>   - Trace the control chain above an entity")
+	fmt.Println("  kycctl cbu diff <cbu-id> <from> [<to>]  - Ownership changes between two dates")
+	fmt.Println("  kycctl cbu history <cbu-id> <rel-id>    - Recorded versions of a relationship")
+	fmt.Println()
+	fmt.Println("Watchlist Commands:")
+	fmt.Println("  kycctl watchlist list                   - Entities pinned for re-screening")
+	fmt.Println("  kycctl watchlist alerts                 - Open alerts raised on new hits")
+	fmt.Println("  kycctl watchlist screen                 - Re-screen the entries that are due now")
+	fmt.Println()
+	fmt.Println("Screening Commands:")
+	fmt.Println("  kycctl screen <entity-id> [--kind=sanctions|pep|adverse_media] [--case=NAME] [--ubos]")
+	fmt.Println("                                          - Screen an entity (and UBOs); record it on the case")
+	fmt.Println("  kycctl screening ingest ofac <sdn.csv> [<alt.csv>]")
+	fmt.Println("                                          - Load the OFAC SDN list (aliases from alt.csv)")
+	fmt.Println("  kycctl screening ingest eu <list.xml>   - Load the EU consolidated sanctions list")
+	fmt.Println()
+	fmt.Println("Managed List Commands:")
+	fmt.Println("  kycctl lists                            - Lists referenced by rules with in_list")
+	fmt.Println("  kycctl lists show <name>                - Display a list and its members")
+	fmt.Println("  kycctl lists set <name> --members=IR,KP [--source=TEXT] [--from=DATE] [--to=DATE]")
+	fmt.Println("                                          - Update a list and re-evaluate dependent rules")
+	fmt.Println("  kycctl lists reevaluate <name>          - Re-evaluate the rules referencing a list")
+	fmt.Println("  kycctl country-risk                     - Jurisdiction risk lists (in_sanctioned_list)")
+	fmt.Println("  kycctl country-risk show <code> [--history]")
+	fmt.Println("                                          - Countries on a risk list (every listing with --history)")
+	fmt.Println("  kycctl country-risk add <code> <country> [--from=DATE] [--to=DATE] [--note=TEXT]")
+	fmt.Println("                                          - List a country and re-evaluate dependent rules")
+	fmt.Println("  kycctl country-risk end <code> <country> [--on=DATE]")
+	fmt.Println("                                          - De-list a country after a date (default today)")
+	fmt.Println("  kycctl policy-pack [list] [--jurisdiction=CODE]")
+	fmt.Println("                                          - Active jurisdiction policy packs")
+	fmt.Println("  kycctl policy-pack show <code> [--version=N]")
+	fmt.Println("                                          - Documents, attributes and thresholds of a pack")
+	fmt.Println("  kycctl policy-pack versions <code>      - Versions of a pack and their status")
+	fmt.Println("  kycctl policy-pack draft --file=<pack.json>")
+	fmt.Println("                                          - Draft the next version of a pack")
+	fmt.Println("  kycctl policy-pack activate|retire <code> <version>")
+	fmt.Println("                                          - Activate a draft (retiring the active version) or retire one")
+	fmt.Println("  kycctl overlay [list]                   - Tenants with an ontology overlay")
+	fmt.Println("  kycctl overlay show [--tenant=T]        - Attributes, documents, links and derivations of an overlay")
+	fmt.Println("  kycctl overlay apply --file=<overlay.json> [--tenant=T] [--embed]")
+	fmt.Println("                                          - Add or update overlay entries (--embed: embed new attributes)")
+	fmt.Println("  kycctl overlay embed [--tenant=T]       - Embed overlay attributes for RAG search")
+	fmt.Println("  kycctl overlay remove <kind> <code> [--tenant=T]")
+	fmt.Println("                                          - Remove an attribute, document, link (ATTR:DOC) or derivation (DERIVED:SOURCE)")
+	fmt.Println("  kycctl overlay assign <case> --tenant=T - Resolve a case through a tenant's overlay")
+	fmt.Println("  kycctl regulation update <code> [--citation=TEXT] [--summary=TEXT|--summary-file=PATH]")
+	fmt.Println("                [--title=TEXT] [--name=TEXT] [--from=DATE] [--to=DATE] [--note=TEXT]")
+	fmt.Println("                                          - Update a regulation record, recording a new version")
+	fmt.Println("  kycctl regulation versions <code>       - Versions of a regulation record")
+	fmt.Println("  kycctl regulation impact <code> [--version=N]")
+	fmt.Println("                                          - Documents, attributes, clusters and open cases a change affects")
+	fmt.Println()
+	fmt.Println("Document Evidence Commands:")
+	fmt.Println("  kycctl evidence upload <case> <file> [--document=CODE] [--attribute=CODE]")
+	fmt.Println("                [--issued=DATE] [--expires=DATE] [--validity-days=N] [--description=TEXT]")
+	fmt.Println("                                          - Store a collected document file")
+	fmt.Println("  kycctl evidence list <case>             - Evidence collected for a case")
+	fmt.Println("  kycctl evidence get <id> [--out=PATH]   - Download an evidence file")
+	fmt.Println("  kycctl evidence access <id>             - Uploads, views and downloads of evidence")
+	fmt.Println("  kycctl evidence verify <id>             - Check stored content against its sha256")
+	fmt.Println("  kycctl evidence expiring <case> [--days=N] - Evidence expiring soon or expired")
+	fmt.Println("  kycctl evidence check-expiry            - Flag expiring evidence and send the daily summary")
+	fmt.Println("  kycctl evidence extract <id>            - Extract and embed the text of evidence now")
+	fmt.Println("  kycctl evidence text <id>               - Text extracted from evidence, by page")
+	fmt.Println("  kycctl evidence propose <id> [--attributes=A,B]")
+	fmt.Println("                                          - Propose attribute values from evidence text for confirmation")
+	fmt.Println("  kycctl evidence candidates <case> [--status=pending] - Proposed attribute values of a case")
+	fmt.Println("  kycctl evidence confirm <candidate> [--value=V] [--note=N] - Confirm (or correct) a proposed value")
+	fmt.Println("  kycctl evidence reject <candidate> [--note=N] - Reject a proposed value")
+	fmt.Println()
+	fmt.Println("External Mapping Commands:")
+	fmt.Println("  kycctl mappings [list] [--attribute=CODE] [--standard=iso20022|fibo]")
+	fmt.Println("                                          - Attribute links to ISO 20022 elements, FIBO concepts")
+	fmt.Println("  kycctl mappings import <file.csv>       - Create or update mappings from CSV")
+	fmt.Println("  kycctl mappings export [--standard=S] [--format=csv|json] [--out=FILE]")
+	fmt.Println("                                          - Export mappings for a data catalog")
+	fmt.Println("  kycctl mappings remove <code> <standard> <external-id>")
+	fmt.Println("                                          - Remove a mapping")
+	fmt.Println()
+	fmt.Println("Case Data Commands:")
+	fmt.Println("  kycctl case-data set <case> <attr> <value> [--version=N] [--document=CODE] [--evidence=ID]")
+	fmt.Println("                                          - Capture a typed attribute value, queue dependents")
+	fmt.Println("  kycctl case-data list <case> [--version=N] - Captured attribute values of a case version")
+	fmt.Println("  kycctl case-data get <case> <attr> [--version=N] - One captured attribute value")
+	fmt.Println("  kycctl case-data delete <case> <attr> [--version=N] - Remove a value captured at a version")
+	fmt.Println("  kycctl case-data completeness <case> [--version=N] - Required attributes present, missing or stale")
+	fmt.Println()
+	fmt.Println("Re-evaluation Commands:")
+	fmt.Println("  kycctl reeval queue                     - Derived attributes pending re-evaluation")
+	fmt.Println("  kycctl reeval run                       - Re-evaluate a batch of queued attributes now")
+	fmt.Println("  kycctl reeval set <case> <attr> <value> - Record a new attribute value, queue dependents")
+	fmt.Println("  kycctl reeval dictionary <case> [--materialize]")
+	fmt.Println("                                          - Case data dictionary (copy in derived results)")
+	fmt.Println("  kycctl lineage-history <case> [--limit=N] [--run=ID]")
+	fmt.Println("                                          - Lineage evaluation runs of a case with their results")
+	fmt.Println("  kycctl score <case> [--latest]          - Score customer risk (--latest: stored score only)")
+	fmt.Println()
+	fmt.Println("Database Commands:")
+	fmt.Println("  kycctl migrate up                       - Apply pending schema migrations")
+	fmt.Println("  kycctl migrate down                     - Roll back the latest schema migration")
+	fmt.Println("  kycctl migrate status                   - Show applied and pending migrations")
+	fmt.Println("  kycctl doctor                           - Diagnose database, pgvector, OpenAI, Rust engine,")
+	fmt.Println("                                            grammar and embedding coverage")
+	fmt.Println("  kycctl maintenance status [--min-dead=N] - Vector indexes, hot tables, partitions, long transactions")
+	fmt.Println("  kycctl maintenance reindex [--index=I] [--dry-run] [--lock-timeout=5s]")
+	fmt.Println("                                          - REINDEX CONCURRENTLY the vector indexes")
+	fmt.Println("  kycctl maintenance vacuum [--table=T] [--min-dead=N] [--analyze-only] [--dry-run]")
+	fmt.Println("                                          - VACUUM (ANALYZE) hot tables, skipping locked ones")
+	fmt.Println("  kycctl maintenance partitions [--table=T] [--months=3] [--dry-run]")
+	fmt.Println("                                          - Create upcoming monthly partitions")
+	fmt.Println("  kycctl export-cases --out=DIR|FILE.tar.gz [--case=NAME...]")
+	fmt.Println("                                          - Export cases with versions, amendments, transitions")
+	fmt.Println("                                            and validation history as a JSONL bundle")
+	fmt.Println("  kycctl import-cases <DIR|FILE.tar.gz> [--dry-run]")
+	fmt.Println("                                          - Load a case bundle, skipping existing cases")
+	fmt.Println("  kycctl search-plan <entities|attributes> <query> [--threshold=0.3] [--analyze]")
+	fmt.Println("                                          - Query plan of entity/attribute search (index use)")
+	fmt.Println("  kycctl retention [policies]             - Retention of rag_audit_log, kyc_case_amendments, rag_feedback")
+	fmt.Println("  kycctl retention run [--table=T] [--dry-run]")
+	fmt.Println("                                          - Archive and delete expired rows now")
+	fmt.Println("  kycctl retention runs [--table=T] [--limit=N]")
+	fmt.Println("                                          - Past retention runs with rows archived and deleted")
+	fmt.Println("  kycctl dsr export <subject> [--out=FILE]")
+	fmt.Println("                                          - Export what is held about a data subject as JSON")
+	fmt.Println("  kycctl dsr erase <subject> [--dry-run]  - Erase a data subject, keeping regulatory records")
+	fmt.Println()
+	fmt.Println("Webhook Commands:")
+	fmt.Println("  kycctl webhooks deliveries [--status=S] [--limit=N]")
+	fmt.Println("                                          - Delivery log (pending, delivered or failed)")
+	fmt.Println("  kycctl webhooks show <id>               - Attempts of a delivery with response or error")
+	fmt.Println("  kycctl webhooks retry <id...>           - Make failed deliveries due again")
+	fmt.Println("  kycctl webhooks deliver [--limit=N]     - Post due deliveries now")
+	fmt.Println()
+	fmt.Println("Demo Commands:")
+	fmt.Println("  kycctl demo up [--skip-embeddings]      - Provision the demo dataset (ontology, clusters, a")
+	fmt.Println("                                            BlackRock-style CBU, cases, feedback/audit history)")
+	fmt.Println("  kycctl demo down                        - Remove the demo CBU, cases and history")
 	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println("  kycctl grammar")
 	fmt.Println("  kycctl ontology")
 	fmt.Println("  kycctl validate BLACKROCK-GLOBAL-EQUITY-FUND")
+	fmt.Println("  kycctl fmt sample_case.dsl -w")
+	fmt.Println("  kycctl compile sample_case.dsl --format=yaml")
 	fmt.Println("  kycctl get AVIVA-EU-EQUITY-FUND")
 	fmt.Println("  kycctl versions AVIVA-EU-EQUITY-FUND")
+	fmt.Println("  kycctl diff AVIVA-EU-EQUITY-FUND 3 5")
 	fmt.Println("  kycctl list")
 	fmt.Println("  kycctl sample_case.dsl")
 	fmt.Println("  kycctl amend AVIVA-EU-EQUITY-FUND --step=policy-discovery")
 	fmt.Println("  kycctl seed-metadata")
+	fmt.Println("  kycctl backfill-embeddings --kind=documents --max-spend=0.50")
+	fmt.Println("  kycctl ingest-document fatf-recommendations.pdf --code=FATF-REC --dry-run")
 	fmt.Println("  kycctl search-metadata \"tax residency\"")
 	fmt.Println("  kycctl similar-attributes UBO_NAME")
 	fmt.Println()
 	fmt.Println("Amendment steps:")
-	fmt.Println("  policy-discovery        - Add policy discovery function and policies")
+	fmt.Println("  policy-discovery        - Add policies and obligations from jurisdiction policy packs")
 	fmt.Println("  document-solicitation   - Add document solicitation and obligations")
-	fmt.Println("  document-discovery      - Auto-populate documents from regulatory ontology")
+	fmt.Println("  document-discovery      - Add required documents and attributes from policy packs")
 	fmt.Println("  ownership-discovery     - Add ownership structure and control hierarchy")
 	fmt.Println("  risk-assessment         - Add risk assessment function")
 	fmt.Println("  regulator-notify        - Add regulator notification")
@@ -295,6 +698,12 @@ func ShowUsage() {
 	fmt.Println("  decline                 - Finalize case as declined")
 	fmt.Println("  review                  - Set case to review status")
 	fmt.Println()
+	fmt.Println("Global Flags (before the command):")
+	fmt.Println("  -config=FILE            - YAML configuration file (env KYC_CONFIG)")
+	fmt.Println("  -database-url=URL       - PostgreSQL connection URL (env DATABASE_URL)")
+	fmt.Println("  -rust-dsl-addr=ADDR     - Rust DSL service address")
+	fmt.Println("  -data-service-addr=ADDR - Data service address (env DATA_SERVICE_ADDR)")
+	fmt.Println()
 	fmt.Println("Environment Variables:")
 	fmt.Println("  RUST_DSL_SERVICE_ADDR   - Rust DSL service address (default: localhost:50060)")
 	fmt.Println("  PGHOST                  - PostgreSQL host (default: localhost)")
@@ -331,11 +740,31 @@ func Run(args []string) {
 			log.Fatal("missing case name")
 		}
 		caseName := args[1]
-		actor := "System" // Default actor
+		actorName := actor.CLI().Name // Default actor
 		if len(args) >= 3 && strings.HasPrefix(args[2], "--actor=") {
-			actor = strings.TrimPrefix(args[2], "--actor=")
+			actorName = strings.TrimPrefix(args[2], "--actor=")
+		}
+		if err := RunValidateCommand(caseName, actorName); err != nil {
+			log.Fatal(err)
+		}
+
+	case "fmt":
+		if err := RunFmtCommand(args[1:]); err != nil {
+			log.Fatal(err)
+		}
+
+	case "compile":
+		if err := RunCompileCommand(args[1:]); err != nil {
+			log.Fatal(err)
 		}
-		if err := RunValidateCommand(caseName, actor); err != nil {
+
+	case "grammar-migrate":
+		if err := RunGrammarMigrateCommand(args[1:]); err != nil {
+			log.Fatal(err)
+		}
+
+	case "grammar-matrix":
+		if err := RunGrammarMatrixCommand(); err != nil {
 			log.Fatal(err)
 		}
 
@@ -365,11 +794,99 @@ func Run(args []string) {
 			log.Fatal(err)
 		}
 
+	case "diff":
+		if len(args) < 2 {
+			fmt.Println("Error: diff command requires case name")
+			ShowUsage()
+			log.Fatal("missing case name")
+		}
+		if err := RunDiffCommand(args[1:]); err != nil {
+			log.Fatal(err)
+		}
+
+	case "verify-integrity":
+		if len(args) < 2 {
+			fmt.Println("Error: verify-integrity command requires a case name or --all")
+			ShowUsage()
+			log.Fatal("missing case name")
+		}
+		if err := RunVerifyIntegrityCommand(args[1:]); err != nil {
+			log.Fatal(err)
+		}
+
+	case "signature":
+		if len(args) < 2 {
+			fmt.Println("Error: signature command requires an action")
+			ShowUsage()
+			log.Fatal("missing signature action")
+		}
+		if err := RunSignatureCommand(args[1], args[2:]); err != nil {
+			log.Fatal(err)
+		}
+
+	case "encryption":
+		action := ""
+		if len(args) >= 2 {
+			action = args[1]
+		}
+		var rest []string
+		if len(args) > 2 {
+			rest = args[2:]
+		}
+		if err := RunEncryptionCommand(action, rest); err != nil {
+			log.Fatal(err)
+		}
+
 	case "list":
 		if err := RunListAllCasesCommand(); err != nil {
 			log.Fatal(err)
 		}
 
+	case "lock":
+		if len(args) < 3 {
+			fmt.Println("Error: lock command requires an action and case name")
+			ShowUsage()
+			log.Fatal("missing arguments")
+		}
+		if err := RunLockCommand(args[1], args[2:]); err != nil {
+			log.Fatal(err)
+		}
+
+	case "timeline":
+		if len(args) < 2 {
+			fmt.Println("Error: timeline command requires case name")
+			ShowUsage()
+			log.Fatal("missing case name")
+		}
+		if err := RunTimelineCommand(args[1]); err != nil {
+			log.Fatal(err)
+		}
+
+	case "transition":
+		if err := RunTransitionCommand(args[1:]); err != nil {
+			log.Fatal(err)
+		}
+
+	case "assign":
+		if err := RunAssignCommand(args[1:]); err != nil {
+			log.Fatal(err)
+		}
+
+	case "assignments":
+		if len(args) < 2 {
+			fmt.Println("Error: assignments command requires case name")
+			ShowUsage()
+			log.Fatal("missing case name")
+		}
+		if err := RunAssignmentsCommand(args[1]); err != nil {
+			log.Fatal(err)
+		}
+
+	case "queue":
+		if err := RunQueueCommand(args[1:]); err != nil {
+			log.Fatal(err)
+		}
+
 	case "amend":
 		if len(args) < 2 {
 			fmt.Println("Error: amend command requires case name and --step flag")
@@ -383,12 +900,83 @@ func Run(args []string) {
 			log.Fatal("missing --step flag")
 		}
 		step := strings.TrimPrefix(args[2], "--step=")
-		if err := RunAmendCommand(caseName, step); err != nil {
+		holder := lockHolder()
+		var jurisdictions []string
+		for _, arg := range args[3:] {
+			switch {
+			case strings.HasPrefix(arg, "--holder="):
+				holder = strings.TrimPrefix(arg, "--holder=")
+			case strings.HasPrefix(arg, "--jurisdiction="):
+				for _, j := range strings.Split(strings.TrimPrefix(arg, "--jurisdiction="), ",") {
+					if j = strings.TrimSpace(j); j != "" {
+						jurisdictions = append(jurisdictions, j)
+					}
+				}
+			}
+		}
+		if err := RunAmendCommand(caseName, step, holder, jurisdictions); err != nil {
 			log.Fatal(err)
 		}
 
 	case "seed-metadata":
-		if err := RunSeedMetadataCommand(); err != nil {
+		maxSpend := 0.0
+		if len(args) >= 2 && strings.HasPrefix(args[1], "--max-spend=") {
+			fmt.Sscanf(strings.TrimPrefix(args[1], "--max-spend="), "%g", &maxSpend)
+		}
+		if err := RunSeedMetadataCommand(maxSpend); err != nil {
+			log.Fatal(err)
+		}
+
+	case "backfill-embeddings":
+		opts := BackfillOptions{Kind: "all", RequestsPerMinute: 300}
+		for _, arg := range args[1:] {
+			switch {
+			case strings.HasPrefix(arg, "--kind="):
+				opts.Kind = strings.TrimPrefix(arg, "--kind=")
+			case strings.HasPrefix(arg, "--max-spend="):
+				fmt.Sscanf(strings.TrimPrefix(arg, "--max-spend="), "%g", &opts.MaxSpend)
+			case strings.HasPrefix(arg, "--rate="):
+				fmt.Sscanf(strings.TrimPrefix(arg, "--rate="), "%d", &opts.RequestsPerMinute)
+			case arg == "--restart":
+				opts.Restart = true
+			case arg == "--dry-run":
+				opts.DryRun = true
+			}
+		}
+		if err := RunBackfillEmbeddingsCommand(opts); err != nil {
+			log.Fatal(err)
+		}
+
+	case "embeddings":
+		action := ""
+		if len(args) >= 2 {
+			action = args[1]
+		}
+		var rest []string
+		if len(args) > 2 {
+			rest = args[2:]
+		}
+		if err := RunEmbeddingsCommand(action, rest); err != nil {
+			log.Fatal(err)
+		}
+
+	case "reembed":
+		opts := ReembedOptions{Kind: "all"}
+		for _, arg := range args[1:] {
+			switch {
+			case arg == "--stale":
+				opts.Stale = true
+			case strings.HasPrefix(arg, "--kind="):
+				opts.Kind = strings.TrimPrefix(arg, "--kind=")
+			case strings.HasPrefix(arg, "--batch-size="):
+				fmt.Sscanf(strings.TrimPrefix(arg, "--batch-size="), "%d", &opts.BatchSize)
+			case arg == "--unversioned":
+				opts.Unversioned = true
+			case arg == "--dry-run":
+				opts.DryRun = true
+			}
+		}
+		if err := RunReembedCommand(opts); err != nil {
 			log.Fatal(err)
 		}
 
@@ -400,10 +988,80 @@ func Run(args []string) {
 		}
 		query := args[1]
 		limit := 10
-		if len(args) >= 3 && strings.HasPrefix(args[2], "--limit=") {
-			fmt.Sscanf(strings.TrimPrefix(args[2], "--limit="), "%d", &limit)
+		space := ""
+		for _, arg := range args[2:] {
+			switch {
+			case strings.HasPrefix(arg, "--limit="):
+				fmt.Sscanf(strings.TrimPrefix(arg, "--limit="), "%d", &limit)
+			case strings.HasPrefix(arg, "--space="):
+				space = strings.TrimPrefix(arg, "--space=")
+			}
+		}
+		if err := RunSearchMetadataCommand(query, limit, space); err != nil {
+			log.Fatal(err)
+		}
+
+	case "ingest-document":
+		if len(args) < 2 || strings.HasPrefix(args[1], "--") {
+			fmt.Println("Error: ingest-document command requires a file")
+			ShowUsage()
+			log.Fatal("missing document file")
+		}
+		var opts IngestOptions
+		for _, arg := range args[2:] {
+			switch {
+			case strings.HasPrefix(arg, "--code="):
+				opts.Code = strings.TrimPrefix(arg, "--code=")
+			case strings.HasPrefix(arg, "--chunk-tokens="):
+				fmt.Sscanf(strings.TrimPrefix(arg, "--chunk-tokens="), "%d", &opts.ChunkTokens)
+			case strings.HasPrefix(arg, "--overlap="):
+				fmt.Sscanf(strings.TrimPrefix(arg, "--overlap="), "%d", &opts.OverlapTokens)
+			case strings.HasPrefix(arg, "--batch-size="):
+				fmt.Sscanf(strings.TrimPrefix(arg, "--batch-size="), "%d", &opts.BatchSize)
+			case arg == "--dry-run":
+				opts.DryRun = true
+			}
+		}
+		if err := RunIngestDocumentCommand(args[1], opts); err != nil {
+			log.Fatal(err)
+		}
+
+	case "recompute-clusters":
+		var opts ClusterOptions
+		for _, arg := range args[1:] {
+			switch {
+			case strings.HasPrefix(arg, "--k="):
+				fmt.Sscanf(strings.TrimPrefix(arg, "--k="), "%d", &opts.K)
+			case strings.HasPrefix(arg, "--max-k="):
+				fmt.Sscanf(strings.TrimPrefix(arg, "--max-k="), "%d", &opts.MaxK)
+			case arg == "--llm-labels":
+				opts.LLMLabels = true
+			case arg == "--dry-run":
+				opts.DryRun = true
+			}
+		}
+		if err := RunRecomputeClustersCommand(opts); err != nil {
+			log.Fatal(err)
+		}
+
+	case "search-sections":
+		if len(args) < 2 {
+			fmt.Println("Error: search-sections command requires a query")
+			ShowUsage()
+			log.Fatal("missing search query")
 		}
-		if err := RunSearchMetadataCommand(query, limit); err != nil {
+		query := args[1]
+		limit := 10
+		caseName := ""
+		for _, arg := range args[2:] {
+			switch {
+			case strings.HasPrefix(arg, "--limit="):
+				fmt.Sscanf(strings.TrimPrefix(arg, "--limit="), "%d", &limit)
+			case strings.HasPrefix(arg, "--case="):
+				caseName = strings.TrimPrefix(arg, "--case=")
+			}
+		}
+		if err := RunSearchSectionsCommand(query, limit, caseName); err != nil {
 			log.Fatal(err)
 		}
 
@@ -438,12 +1096,315 @@ func Run(args []string) {
 			log.Fatal(err)
 		}
 
+	case "usage-report":
+		limit := 20
+		if len(args) >= 2 && strings.HasPrefix(args[1], "--limit=") {
+			fmt.Sscanf(strings.TrimPrefix(args[1], "--limit="), "%d", &limit)
+		}
+		if err := RunUsageReportCommand(limit); err != nil {
+			log.Fatal(err)
+		}
+
+	case "narrative":
+		if len(args) < 2 {
+			fmt.Println("Error: narrative command requires case name")
+			ShowUsage()
+			log.Fatal("missing case name")
+		}
+		var versionID, template string
+		polish := false
+		for _, arg := range args[2:] {
+			switch {
+			case strings.HasPrefix(arg, "--version="):
+				versionID = strings.TrimPrefix(arg, "--version=")
+			case strings.HasPrefix(arg, "--template="):
+				template = strings.TrimPrefix(arg, "--template=")
+			case arg == "--polish":
+				polish = true
+			}
+		}
+		if err := RunNarrativeCommand(args[1], versionID, template, polish); err != nil {
+			log.Fatal(err)
+		}
+
+	case "export-pdf":
+		if len(args) < 2 {
+			fmt.Println("Error: export-pdf command requires case name")
+			ShowUsage()
+			log.Fatal("missing case name")
+		}
+		var versionID, outPath string
+		draft := false
+		for _, arg := range args[2:] {
+			switch {
+			case strings.HasPrefix(arg, "--version="):
+				versionID = strings.TrimPrefix(arg, "--version=")
+			case strings.HasPrefix(arg, "--out="):
+				outPath = strings.TrimPrefix(arg, "--out=")
+			case arg == "--draft":
+				draft = true
+			}
+		}
+		if err := RunExportPDFCommand(args[1], versionID, outPath, draft); err != nil {
+			log.Fatal(err)
+		}
+
+	case "cbu":
+		if len(args) < 2 {
+			fmt.Println("Error: cbu command requires list, show, validate, chain, diff or history")
+			ShowUsage()
+			log.Fatal("missing cbu action")
+		}
+		if err := RunCbuCommand(args[1], args[2:]); err != nil {
+			log.Fatal(err)
+		}
+
+	case "watchlist":
+		if len(args) < 2 {
+			fmt.Println("Error: watchlist command requires list, alerts or screen")
+			ShowUsage()
+			log.Fatal("missing watchlist action")
+		}
+		if err := RunWatchlistCommand(args[1]); err != nil {
+			log.Fatal(err)
+		}
+
+	case "screen":
+		if len(args) < 2 {
+			fmt.Println("Error: screen command requires an entity ID")
+			ShowUsage()
+			log.Fatal("missing entity ID")
+		}
+		if err := RunScreenCommand(args[1], args[2:]); err != nil {
+			log.Fatal(err)
+		}
+
+	case "screening":
+		if len(args) < 4 || args[1] != "ingest" {
+			fmt.Println("Error: screening command requires ingest <ofac|eu> <file>")
+			ShowUsage()
+			log.Fatal("missing screening arguments")
+		}
+		if err := RunScreeningIngestCommand(args[2], args[3:]); err != nil {
+			log.Fatal(err)
+		}
+
+	case "lists":
+		action := ""
+		if len(args) >= 2 {
+			action = args[1]
+		}
+		var rest []string
+		if len(args) > 2 {
+			rest = args[2:]
+		}
+		if err := RunListsCommand(action, rest); err != nil {
+			log.Fatal(err)
+		}
+
+	case "evidence":
+		if len(args) < 2 {
+			fmt.Println("Error: evidence command requires an action (upload, list, get, access, verify, expiring, check-expiry, extract, text, propose, candidates, confirm, reject)")
+			ShowUsage()
+			log.Fatal("missing evidence action")
+		}
+		if err := RunEvidenceCommand(args[1], args[2:]); err != nil {
+			log.Fatal(err)
+		}
+
+	case "country-risk":
+		action := ""
+		if len(args) >= 2 {
+			action = args[1]
+		}
+		var rest []string
+		if len(args) > 2 {
+			rest = args[2:]
+		}
+		if err := RunCountryRiskCommand(action, rest); err != nil {
+			log.Fatal(err)
+		}
+
+	case "overlay":
+		action := ""
+		if len(args) >= 2 {
+			action = args[1]
+		}
+		var rest []string
+		if len(args) > 2 {
+			rest = args[2:]
+		}
+		if err := RunOverlayCommand(action, rest); err != nil {
+			log.Fatal(err)
+		}
+
+	case "policy-pack":
+		action := ""
+		if len(args) >= 2 {
+			action = args[1]
+		}
+		var rest []string
+		if len(args) > 2 {
+			rest = args[2:]
+		}
+		if err := RunPolicyPackCommand(action, rest); err != nil {
+			log.Fatal(err)
+		}
+
+	case "regulation":
+		if len(args) < 2 {
+			fmt.Println("Error: regulation command requires an action (update, versions, impact)")
+			ShowUsage()
+			log.Fatal("missing regulation action")
+		}
+		if err := RunRegulationCommand(args[1], args[2:]); err != nil {
+			log.Fatal(err)
+		}
+
+	case "retention":
+		action := ""
+		if len(args) >= 2 {
+			action = args[1]
+		}
+		var rest []string
+		if len(args) > 2 {
+			rest = args[2:]
+		}
+		if err := RunRetentionCommand(action, rest); err != nil {
+			log.Fatal(err)
+		}
+
+	case "dsr":
+		if len(args) < 2 {
+			fmt.Println("Error: dsr command requires an action")
+			ShowUsage()
+			log.Fatal("missing dsr action")
+		}
+		if err := RunDSRCommand(args[1], args[2:]); err != nil {
+			log.Fatal(err)
+		}
+
+	case "mappings":
+		action := ""
+		if len(args) >= 2 {
+			action = args[1]
+		}
+		var rest []string
+		if len(args) > 2 {
+			rest = args[2:]
+		}
+		if err := RunMappingsCommand(action, rest); err != nil {
+			log.Fatal(err)
+		}
+
+	case "case-data":
+		if len(args) < 2 {
+			fmt.Println("Error: case-data command requires set, list, get, delete or completeness")
+			ShowUsage()
+			log.Fatal("missing case-data action")
+		}
+		if err := RunCaseDataCommand(args[1], args[2:]); err != nil {
+			log.Fatal(err)
+		}
+
+	case "reeval":
+		if len(args) < 2 {
+			fmt.Println("Error: reeval command requires queue, run, set or dictionary")
+			ShowUsage()
+			log.Fatal("missing reeval action")
+		}
+		if err := RunReevalCommand(args[1], args[2:]); err != nil {
+			log.Fatal(err)
+		}
+
+	case "lineage-history":
+		if len(args) < 2 {
+			fmt.Println("Error: lineage-history command requires case name")
+			ShowUsage()
+			log.Fatal("missing case name")
+		}
+		if err := RunLineageHistoryCommand(args[1], args[2:]); err != nil {
+			log.Fatal(err)
+		}
+
+	case "score":
+		if len(args) < 2 {
+			fmt.Println("Error: score command requires case name")
+			ShowUsage()
+			log.Fatal("missing case name")
+		}
+		if err := RunScoreCommand(args[1], args[2:]); err != nil {
+			log.Fatal(err)
+		}
+
+	case "migrate":
+		if len(args) < 2 {
+			fmt.Println("Error: migrate command requires up, down or status")
+			ShowUsage()
+			log.Fatal("missing migrate action")
+		}
+		if err := RunMigrateCommand(args[1]); err != nil {
+			log.Fatal(err)
+		}
+
+	case "doctor":
+		if err := RunDoctorCommand(); err != nil {
+			log.Fatal(err)
+		}
+
+	case "maintenance":
+		if len(args) < 2 {
+			fmt.Println("Error: maintenance command requires an action")
+			ShowUsage()
+			log.Fatal("missing maintenance action")
+		}
+		if err := RunMaintenanceCommand(args[1], args[2:]); err != nil {
+			log.Fatal(err)
+		}
+
+	case "webhooks":
+		if len(args) < 2 {
+			fmt.Println("Error: webhooks command requires an action")
+			ShowUsage()
+			log.Fatal("missing webhooks action")
+		}
+		if err := RunWebhooksCommand(args[1], args[2:]); err != nil {
+			log.Fatal(err)
+		}
+
+	case "export-cases":
+		if err := RunExportCasesCommand(args[1:]); err != nil {
+			log.Fatal(err)
+		}
+
+	case "import-cases":
+		if err := RunImportCasesCommand(args[1:]); err != nil {
+			log.Fatal(err)
+		}
+
+	case "search-plan":
+		if err := RunSearchPlanCommand(args[1:]); err != nil {
+			log.Fatal(err)
+		}
+
+	case "demo":
+		if len(args) < 2 {
+			fmt.Println("Error: demo command requires up or down")
+			ShowUsage()
+			log.Fatal("missing demo action")
+		}
+		skipEmbeddings := len(args) >= 3 && args[2] == "--skip-embeddings"
+		if err := RunDemoCommand(args[1], skipEmbeddings); err != nil {
+			log.Fatal(err)
+		}
+
 	case "help", "-h", "--help":
 		ShowUsage()
 
 	default:
 		// Treat as DSL file path
-		if err := RunProcessCommand(command); err != nil {
+		force := len(args) >= 2 && args[1] == "--force"
+		if err := RunProcessCommand(command, force); err != nil {
 			log.Fatal(err)
 		}
 	}
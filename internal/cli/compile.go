@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/adamtc007/KYC-DSL/internal/parser"
+)
+
+// RunCompileCommand converts the cases of a DSL file to JSON or YAML
+// (--format), or a compiled .json/.yaml file back to DSL. A file with several
+// cases compiles to a JSON array or to one YAML document per case.
+func RunCompileCommand(args []string) error {
+	var (
+		path   string
+		format = "json"
+	)
+	for _, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "--format="):
+			format = strings.TrimPrefix(arg, "--format=")
+		case strings.HasPrefix(arg, "--"):
+			return fmt.Errorf("unknown compile flag %q", arg)
+		case path == "":
+			path = arg
+		default:
+			return fmt.Errorf("compile takes one file, got %q and %q", path, arg)
+		}
+	}
+	if path == "" {
+		return fmt.Errorf("compile requires a .dsl, .json or .yaml file")
+	}
+	if format != "json" && format != "yaml" {
+		return fmt.Errorf("unknown --format %q (expected json or yaml)", format)
+	}
+
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return printCasesFromJSON(src)
+	case ".yaml", ".yml":
+		return printCasesFromYAML(src)
+	}
+
+	doc, err := parser.Parse(string(src))
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	var cases []*parser.Case
+	for _, form := range doc.Children {
+		if form.Head() != "kyc-case" {
+			continue
+		}
+		c, err := parser.Compile(form)
+		if err != nil {
+			return err
+		}
+		cases = append(cases, c)
+	}
+	if len(cases) == 0 {
+		return fmt.Errorf("no kyc-case form found in %s", path)
+	}
+
+	if format == "yaml" {
+		for i, c := range cases {
+			out, err := yaml.Marshal(c)
+			if err != nil {
+				return fmt.Errorf("failed to encode case %s: %w", c.Name, err)
+			}
+			if i > 0 {
+				fmt.Println("---")
+			}
+			fmt.Print(string(out))
+		}
+		return nil
+	}
+
+	var v interface{} = cases
+	if len(cases) == 1 {
+		v = cases[0]
+	}
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cases: %w", err)
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+func printCasesFromJSON(src []byte) error {
+	items := []json.RawMessage{src}
+	if trimmed := bytes.TrimSpace(src); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &items); err != nil {
+			return fmt.Errorf("invalid case JSON: %w", err)
+		}
+	}
+	for i, item := range items {
+		dsl, err := parser.FromJSON(item)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Print(dsl)
+	}
+	return nil
+}
+
+func printCasesFromYAML(src []byte) error {
+	dec := yaml.NewDecoder(bytes.NewReader(src))
+	for i := 0; ; i++ {
+		var c parser.Case
+		if err := dec.Decode(&c); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("invalid case YAML: %w", err)
+		}
+		dsl, err := c.DSL()
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Print(dsl)
+	}
+}
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/adamtc007/KYC-DSL/internal/lists"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+// RunCountryRiskCommand shows the jurisdiction risk lists that derivation
+// rules reference with in_sanctioned_list, or lists and de-lists countries
+func RunCountryRiskCommand(action string, args []string) error {
+	db, err := storage.ConnectPostgres()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := lists.NewRepo(db)
+
+	switch action {
+	case "", "list":
+		all, err := repo.CountryRiskLists(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("🌍 Jurisdiction risk lists: %d\n\n", len(all))
+		for _, l := range all {
+			codes := make([]string, 0, len(l.Countries))
+			for _, c := range l.Countries {
+				codes = append(codes, c.CountryCode)
+			}
+			fmt.Printf("  %-30s %-20s %3d countries  %s\n", l.Code, l.Authority, len(codes), strings.Join(codes, ","))
+		}
+		fmt.Println()
+		return nil
+
+	case "show":
+		if len(args) < 1 {
+			return fmt.Errorf("country-risk show requires a list code")
+		}
+		history := len(args) > 1 && args[1] == "--history"
+		l, err := repo.CountryRiskList(ctx, args[0], history)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("🌍 %s - %s\n", l.Code, l.Name)
+		if l.Authority != "" {
+			fmt.Printf("   Authority: %s\n", l.Authority)
+		}
+		if l.Description != "" {
+			fmt.Printf("   %s\n", l.Description)
+		}
+		fmt.Println()
+		for _, c := range l.Countries {
+			fmt.Printf("  %-3s %s  %s\n", c.CountryCode, listingRange(c), c.Note)
+		}
+		return nil
+
+	case "add":
+		if len(args) < 2 {
+			return fmt.Errorf("country-risk add requires a list code and a country")
+		}
+		listing := model.CountryRiskEntry{ListCode: args[0], CountryCode: args[1]}
+		for _, arg := range args[2:] {
+			switch {
+			case strings.HasPrefix(arg, "--from="):
+				t, err := time.Parse("2006-01-02", strings.TrimPrefix(arg, "--from="))
+				if err != nil {
+					return fmt.Errorf("--from must be YYYY-MM-DD")
+				}
+				listing.EffectiveFrom = t
+			case strings.HasPrefix(arg, "--to="):
+				t, err := time.Parse("2006-01-02", strings.TrimPrefix(arg, "--to="))
+				if err != nil {
+					return fmt.Errorf("--to must be YYYY-MM-DD")
+				}
+				listing.EffectiveTo = &t
+			case strings.HasPrefix(arg, "--note="):
+				listing.Note = strings.TrimPrefix(arg, "--note=")
+			}
+		}
+		e, err := repo.PutCountryRisk(ctx, listing, os.Getenv("USER"))
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ %s listed on %s %s\n", e.CountryCode, e.ListCode, listingRange(*e))
+
+	case "end":
+		if len(args) < 2 {
+			return fmt.Errorf("country-risk end requires a list code and a country")
+		}
+		var on time.Time
+		for _, arg := range args[2:] {
+			if strings.HasPrefix(arg, "--on=") {
+				t, err := time.Parse("2006-01-02", strings.TrimPrefix(arg, "--on="))
+				if err != nil {
+					return fmt.Errorf("--on must be YYYY-MM-DD")
+				}
+				on = t
+			}
+		}
+		e, err := repo.EndCountryRisk(ctx, args[0], args[1], on, os.Getenv("USER"))
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ %s de-listed from %s %s\n", e.CountryCode, e.ListCode, listingRange(*e))
+
+	default:
+		return fmt.Errorf("unknown country-risk action %q (expected list, show, add or end)", action)
+	}
+
+	return reevaluateList(ctx, db, args[0])
+}
+
+func listingRange(e model.CountryRiskEntry) string {
+	to := "open"
+	if e.EffectiveTo != nil {
+		to = e.EffectiveTo.Format("2006-01-02")
+	}
+	return e.EffectiveFrom.Format("2006-01-02") + " → " + to
+}
@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/demo"
+	"github.com/adamtc007/KYC-DSL/internal/rag"
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+// RunDemoCommand provisions (up) or removes (down) the demo dataset. up
+// applies pending migrations first so an empty database is ready in one
+// command; skipEmbeddings leaves ontology embeddings and clusters as they are.
+func RunDemoCommand(action string, skipEmbeddings bool) error {
+	db, err := storage.OpenPostgres()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	switch action {
+	case "up":
+		fmt.Println("🎬 Provisioning Demo Environment")
+		fmt.Println("================================================")
+		start := time.Now()
+
+		results, err := storage.MigrateUp(ctx, db)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ Schema: %d migration(s) applied\n", len(results))
+
+		var embedder *rag.Embedder
+		switch {
+		case skipEmbeddings:
+			fmt.Println("⏭️  Embeddings and clusters skipped (--skip-embeddings)")
+		case config.Current().OpenAI.APIKey == "":
+			fmt.Println("⚠️  OPENAI_API_KEY not set: embeddings and clusters skipped, semantic search will return nothing")
+		default:
+			embedder = rag.NewEmbedder()
+		}
+
+		report, err := demo.Up(ctx, db, embedder)
+		if err != nil {
+			return err
+		}
+
+		for _, seed := range report.SeedsApplied {
+			fmt.Printf("✅ Ontology seed: %s\n", seed)
+		}
+		if embedder != nil {
+			fmt.Printf("✅ Embeddings: %d generated\n", report.Embedded)
+			fmt.Printf("✅ Clusters: %d\n", report.Clusters)
+		}
+		fmt.Printf("✅ CBU: %s with %d entities\n", report.CBU, report.Entities)
+		fmt.Printf("✅ Cases: %d (%d versions, %d validations)\n", report.Cases, report.Versions, report.Validations)
+		fmt.Printf("✅ History: %d feedback entries, %d audited searches\n", report.Feedback, report.Searches)
+		for _, s := range report.Skipped {
+			fmt.Printf("ℹ️  %s\n", s)
+		}
+
+		fmt.Println("\n================================================")
+		fmt.Printf("⏱️  Total time: %s\n", time.Since(start).Round(time.Millisecond))
+		fmt.Println("👉 Try: kycctl list, kycctl get " + demo.Prefix + "BLACKROCK-GLOBAL-EQUITY-FUND, kycctl demo down")
+
+	case "down":
+		fmt.Println("🧹 Removing Demo Environment")
+		fmt.Println("================================================")
+		report, err := demo.Down(ctx, db)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ Removed %d cases (%d versions, %d validations), %d entities, %d feedback entries, %d audited searches\n",
+			report.Cases, report.Versions, report.Validations, report.Entities, report.Feedback, report.Searches)
+		fmt.Println("ℹ️  Ontology, embeddings and clusters are kept")
+
+	default:
+		return fmt.Errorf("unknown demo action %q (expected up or down)", action)
+	}
+	return nil
+}
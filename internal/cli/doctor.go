@@ -0,0 +1,367 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pressly/goose/v3"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/ontology"
+	"github.com/adamtc007/KYC-DSL/internal/rag"
+	"github.com/adamtc007/KYC-DSL/internal/rustclient"
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+// doctorTimeout bounds each check that talks to the database or OpenAI
+const doctorTimeout = 20 * time.Second
+
+// vectorIndexes are the pgvector indexes created by the migrations
+var vectorIndexes = []string{
+	"idx_attribute_metadata_embedding",
+	"idx_documents_embedding",
+	"idx_regulations_embedding",
+	"idx_doc_sections_embedding",
+	"idx_clusters_centroid",
+}
+
+type doctorStatus int
+
+const (
+	doctorOK doctorStatus = iota
+	doctorWarn
+	doctorFail
+)
+
+// doctorCheck is the outcome of one diagnostic with the step that fixes it
+type doctorCheck struct {
+	name   string
+	status doctorStatus
+	detail string
+	fix    string
+}
+
+func (c doctorCheck) print() {
+	icon := "✅"
+	switch c.status {
+	case doctorWarn:
+		icon = "⚠️ "
+	case doctorFail:
+		icon = "❌"
+	}
+	fmt.Printf("%s %-20s %s\n", icon, c.name, c.detail)
+	if c.fix != "" && c.status != doctorOK {
+		fmt.Printf("   👉 %s\n", c.fix)
+	}
+}
+
+// RunDoctorCommand checks the database, schema, pgvector, OpenAI, the Rust
+// engine, the stored grammar and embedding coverage, printing a remediation
+// step for every problem. It fails when any check fails; warnings pass.
+func RunDoctorCommand() error {
+	fmt.Println("🩺 KYC-DSL Doctor")
+	fmt.Println("================================================")
+
+	var checks []doctorCheck
+	run := func(c doctorCheck) {
+		c.print()
+		checks = append(checks, c)
+	}
+
+	db, dbCheck := checkDatabase()
+	run(dbCheck)
+	if db != nil {
+		defer db.Close()
+		run(checkSchema(db))
+		run(checkPgvector(db))
+		for _, c := range checkVectorIndexes(db) {
+			run(c)
+		}
+	}
+	run(checkOpenAI())
+	rustCheck, rustVersion := checkRustEngine()
+	run(rustCheck)
+	if db != nil {
+		run(checkGrammar(db, rustVersion))
+		for _, c := range checkEmbeddingCoverage(db) {
+			run(c)
+		}
+	}
+
+	failed, warned := 0, 0
+	for _, c := range checks {
+		switch c.status {
+		case doctorFail:
+			failed++
+		case doctorWarn:
+			warned++
+		}
+	}
+
+	fmt.Println("================================================")
+	if failed > 0 {
+		fmt.Printf("❌ %d check(s) failed, %d warning(s)\n", failed, warned)
+		return fmt.Errorf("doctor found %d failing check(s)", failed)
+	}
+	if warned > 0 {
+		fmt.Printf("⚠️  All checks passed with %d warning(s)\n", warned)
+		return nil
+	}
+	fmt.Println("✅ All checks passed")
+	return nil
+}
+
+func checkDatabase() (*sqlx.DB, doctorCheck) {
+	c := doctorCheck{name: "Database"}
+	dbCfg := config.Current().Database
+
+	db, err := storage.OpenPostgres()
+	if err == nil {
+		ctx, cancel := context.WithTimeout(context.Background(), doctorTimeout)
+		defer cancel()
+		if err = db.PingContext(ctx); err != nil {
+			db.Close()
+		}
+	}
+	if err != nil {
+		c.status = doctorFail
+		c.detail = err.Error()
+		c.fix = "start PostgreSQL and check -database-url / DATABASE_URL or PGHOST, PGPORT, PGUSER, PGDATABASE " +
+			"(database, schema, grammar and embedding checks are skipped)"
+		return nil, c
+	}
+	c.detail = "connected to " + dbCfg.Describe()
+	return db, c
+}
+
+func checkSchema(db *sqlx.DB) doctorCheck {
+	c := doctorCheck{name: "Schema"}
+	ctx, cancel := context.WithTimeout(context.Background(), doctorTimeout)
+	defer cancel()
+
+	statuses, err := storage.MigrationStatus(ctx, db)
+	if err != nil {
+		c.status = doctorFail
+		c.detail = err.Error()
+		c.fix = "check the database user can read " + storage.MigrationsTable + ", then run `kycctl migrate status`"
+		return c
+	}
+
+	var pending []string
+	latest := "none"
+	for _, s := range statuses {
+		if s.State == goose.StatePending {
+			pending = append(pending, filepath.Base(s.Source.Path))
+			continue
+		}
+		latest = filepath.Base(s.Source.Path)
+	}
+	if len(pending) > 0 {
+		c.status = doctorFail
+		c.detail = fmt.Sprintf("%d pending migration(s), first %s (latest applied: %s)", len(pending), pending[0], latest)
+		c.fix = "run `kycctl migrate up` (or set database.auto_migrate)"
+		return c
+	}
+	c.detail = fmt.Sprintf("%d migrations applied, latest %s", len(statuses), latest)
+	return c
+}
+
+func checkPgvector(db *sqlx.DB) doctorCheck {
+	c := doctorCheck{name: "pgvector"}
+	ctx, cancel := context.WithTimeout(context.Background(), doctorTimeout)
+	defer cancel()
+
+	var versions []string
+	if err := db.SelectContext(ctx, &versions, `SELECT extversion FROM pg_extension WHERE extname = 'vector'`); err != nil {
+		c.status = doctorFail
+		c.detail = err.Error()
+		return c
+	}
+	if len(versions) == 0 {
+		c.status = doctorFail
+		c.detail = "vector extension is not installed in this database"
+		c.fix = "install pgvector on the server and run `CREATE EXTENSION vector;` as a superuser, then `kycctl migrate up`"
+		return c
+	}
+	c.detail = "vector extension " + versions[0]
+	return c
+}
+
+func checkVectorIndexes(db *sqlx.DB) []doctorCheck {
+	ctx, cancel := context.WithTimeout(context.Background(), doctorTimeout)
+	defer cancel()
+
+	var rows []struct {
+		Name  string `db:"name"`
+		Valid bool   `db:"valid"`
+		Ready bool   `db:"ready"`
+	}
+	err := db.SelectContext(ctx, &rows, `
+		SELECT c.relname AS name, i.indisvalid AS valid, i.indisready AS ready
+		FROM pg_index i
+		JOIN pg_class c ON c.oid = i.indexrelid
+		WHERE c.relname = ANY($1)`, pq.Array(vectorIndexes))
+	if err != nil {
+		return []doctorCheck{{name: "Vector indexes", status: doctorFail, detail: err.Error()}}
+	}
+
+	found := make(map[string]bool, len(rows))
+	var checks []doctorCheck
+	for _, r := range rows {
+		found[r.Name] = true
+		if !r.Valid || !r.Ready {
+			checks = append(checks, doctorCheck{
+				name:   "Vector index",
+				status: doctorFail,
+				detail: r.Name + " is invalid (a concurrent build or reindex was interrupted)",
+				fix:    fmt.Sprintf("run `kycctl maintenance reindex --index=%s`", r.Name),
+			})
+		}
+	}
+	var missing []string
+	for _, name := range vectorIndexes {
+		if !found[name] {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		checks = append(checks, doctorCheck{
+			name:   "Vector indexes",
+			status: doctorWarn,
+			detail: "missing " + strings.Join(missing, ", ") + "; vector searches fall back to sequential scans",
+			fix:    "run `kycctl migrate up`, or recreate the index from internal/storage/migrations",
+		})
+	}
+	if len(checks) == 0 {
+		checks = append(checks, doctorCheck{
+			name:   "Vector indexes",
+			detail: fmt.Sprintf("%d/%d present and valid", len(rows), len(vectorIndexes)),
+		})
+	}
+	return checks
+}
+
+func checkOpenAI() doctorCheck {
+	c := doctorCheck{name: "OpenAI"}
+	if config.Current().OpenAI.APIKey == "" {
+		c.status = doctorFail
+		c.detail = "no API key configured"
+		c.fix = "set OPENAI_API_KEY (or openai.api_key in the config file); RAG search and embeddings need it"
+		return c
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), doctorTimeout)
+	defer cancel()
+	embedder := rag.NewEmbedderWithConfig(rag.EmbedderConfig{RetryDelay: time.Second})
+	start := time.Now()
+	embedding, err := embedder.GenerateEmbeddingFromText(ctx, "kycctl doctor")
+	if err != nil {
+		c.status = doctorFail
+		c.detail = err.Error()
+		c.fix = "check OPENAI_API_KEY is valid and has quota, and that api.openai.com is reachable"
+		return c
+	}
+	c.detail = fmt.Sprintf("key valid, %s returned %d dimensions in %s",
+		embedder.GetModel(), len(embedding), time.Since(start).Round(time.Millisecond))
+	return c
+}
+
+func checkRustEngine() (doctorCheck, string) {
+	c := doctorCheck{name: "Rust DSL engine"}
+	addr := config.Current().RustDSL.Addr
+
+	client, err := rustclient.NewDslClient("")
+	if err != nil {
+		c.status = doctorFail
+		c.detail = err.Error()
+		c.fix = "point -rust-dsl-addr / RUST_DSL_SERVICE_ADDR at the service"
+		return c, ""
+	}
+	defer client.Close()
+
+	grammar, err := client.GetGrammar()
+	if errors.Is(err, rustclient.ErrUnavailable) {
+		c.status = doctorFail
+		c.detail = "unreachable at " + addr
+		c.fix = "start it with `cd rust && cargo run -p kyc_dsl_service`, or point -rust-dsl-addr / RUST_DSL_SERVICE_ADDR at it"
+		return c, ""
+	}
+	if err != nil {
+		c.status = doctorFail
+		c.detail = err.Error()
+		c.fix = "check the service at " + addr + " is the KYC DSL service and its logs for errors"
+		return c, ""
+	}
+	c.detail = fmt.Sprintf("reachable at %s, grammar v%s", addr, grammar.Version)
+	return c, grammar.Version
+}
+
+func checkGrammar(db *sqlx.DB, rustVersion string) doctorCheck {
+	c := doctorCheck{name: "Grammar"}
+	ctx, cancel := context.WithTimeout(context.Background(), doctorTimeout)
+	defer cancel()
+
+	var version string
+	err := db.GetContext(ctx, &version, `SELECT COALESCE(version, '') FROM kyc_grammar WHERE name = $1`, "KYC-DSL")
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		c.status = doctorFail
+		c.detail = "no KYC-DSL grammar stored in kyc_grammar"
+		c.fix = "run `kycctl grammar` with the Rust DSL engine running"
+		return c
+	case err != nil:
+		c.status = doctorFail
+		c.detail = err.Error()
+		return c
+	}
+
+	if rustVersion != "" && version != rustVersion {
+		c.status = doctorWarn
+		c.detail = fmt.Sprintf("stored v%s differs from the Rust engine's v%s", version, rustVersion)
+		c.fix = "run `kycctl grammar` to store the current grammar"
+		return c
+	}
+	c.detail = "KYC-DSL v" + version + " stored"
+	return c
+}
+
+func checkEmbeddingCoverage(db *sqlx.DB) []doctorCheck {
+	ctx, cancel := context.WithTimeout(context.Background(), doctorTimeout)
+	defer cancel()
+
+	repo := ontology.NewBackfillRepo(db)
+	var checks []doctorCheck
+	for _, kind := range ontology.BackfillKinds {
+		c := doctorCheck{name: "Embeddings " + kind}
+		total, embedded, err := repo.Coverage(ctx, kind)
+		switch {
+		case err != nil:
+			c.status = doctorFail
+			c.detail = err.Error()
+		case total == 0:
+			c.status = doctorWarn
+			c.detail = "no entries"
+			if kind == "attributes" {
+				c.fix = "run `kycctl seed-metadata` to load attribute metadata"
+			} else {
+				c.fix = "load the ontology seed data (`psql -f internal/ontology/seeds/ontology_seed.sql`)"
+			}
+		case embedded < total:
+			c.status = doctorWarn
+			c.detail = fmt.Sprintf("%d/%d embedded (%.1f%%); unembedded entries never match semantic search",
+				embedded, total, 100*float64(embedded)/float64(total))
+			c.fix = fmt.Sprintf("run `kycctl backfill-embeddings --kind=%s --dry-run` to estimate the cost, then without --dry-run", kind)
+		default:
+			c.detail = fmt.Sprintf("%d/%d embedded", embedded, total)
+		}
+		checks = append(checks, c)
+	}
+	return checks
+}
@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/dsr"
+	"github.com/adamtc007/KYC-DSL/internal/evidence"
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+// RunDSRCommand serves a data subject request: exports what is held about
+// a person or erases it, keeping the records regulation obliges us to keep
+func RunDSRCommand(action string, args []string) error {
+	out, dryRun := "", false
+	var words []string
+	for _, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "--out="):
+			out = strings.TrimPrefix(arg, "--out=")
+		case arg == "--dry-run":
+			dryRun = true
+		case strings.HasPrefix(arg, "--"):
+			return fmt.Errorf("unknown dsr option %q", arg)
+		default:
+			words = append(words, arg)
+		}
+	}
+	if action != "export" && action != "erase" {
+		return fmt.Errorf("unknown dsr action %q (expected export or erase)", action)
+	}
+	if len(words) == 0 {
+		return fmt.Errorf("dsr %s requires the name of the data subject", action)
+	}
+	subject := strings.Join(words, " ")
+
+	cfg := config.Current()
+	db, err := storage.ConnectPostgres()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+	ev, err := evidence.NewService(db, cfg.Evidence)
+	if err != nil {
+		return err
+	}
+	service := dsr.NewService(db, cfg.DSR, ev)
+	ctx := commandContext()
+
+	if action == "export" {
+		export, err := service.Export(ctx, subject)
+		if err != nil {
+			return err
+		}
+		data, err := json.MarshalIndent(export, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode export: %w", err)
+		}
+		if out == "" {
+			fmt.Println(string(data))
+			return nil
+		}
+		// The export holds personal data: readable by its owner only
+		if err := os.WriteFile(out, append(data, '\n'), 0o600); err != nil {
+			return fmt.Errorf("failed to write %s: %w", out, err)
+		}
+		fmt.Printf("📦 Exported %d records (request #%d) to %s\n", len(export.Records), export.RequestID, out)
+		return nil
+	}
+
+	req, err := service.Erase(ctx, subject, dryRun)
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		fmt.Printf("🔍 Erasure dry run (request #%d): %d records found, %d would be erased, %d retained\n\n",
+			req.ID, req.Located, req.Located-req.Retained, req.Retained)
+	} else {
+		fmt.Printf("🧹 Erasure (request #%d): %d records found, %d erased, %d retained\n\n",
+			req.ID, req.Located, req.Erased, req.Retained)
+	}
+	for _, c := range req.Sources {
+		fmt.Printf("  %-16s %4d found %4d erased %4d retained\n", c.Source, c.Located, c.Erased, c.Retained)
+		for _, reason := range c.Reasons {
+			fmt.Printf("  %-16s      ↳ %s\n", "", reason)
+		}
+	}
+	fmt.Println()
+	for _, r := range req.Records {
+		mark := "🗑️ "
+		if r.Disposition == dsr.DispositionRetained {
+			mark = "🔒"
+		}
+		where := r.CaseName
+		if where == "" {
+			where = "-"
+		}
+		fmt.Printf("  %s %-16s %-12s %-28s %s\n", mark, r.Source, r.ID, where, r.Field)
+	}
+	fmt.Println()
+	return nil
+}
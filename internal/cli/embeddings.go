@@ -0,0 +1,251 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/embedspace"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/ontology"
+	"github.com/adamtc007/KYC-DSL/internal/rag"
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+// RunEmbeddingsCommand manages embedding spaces: secondary sets of ontology
+// embeddings from another model or dimension that can be searched alongside
+// the primary space and promoted to replace it. tiers, archive and unarchive
+// move attribute embeddings between the indexed hot tier and the cold tier.
+func RunEmbeddingsCommand(action string, args []string) error {
+	db, err := storage.ConnectPostgres()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	repo := ontology.NewSpaceRepo(db)
+
+	switch action {
+	case "", "list":
+		spaces, err := repo.List(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("🧭 Embedding spaces: %d\n\n", len(spaces))
+		for _, s := range spaces {
+			marker := " "
+			if s.IsPrimary {
+				marker = "*"
+			}
+			counts := make([]string, 0, len(ontology.BackfillKinds))
+			for _, kind := range ontology.BackfillKinds {
+				counts = append(counts, fmt.Sprintf("%s %d", kind, s.Embedded[kind]))
+			}
+			fmt.Printf(" %s %-16s %-26s %5d dims  %s\n", marker, s.Name, s.Model, s.Dimensions, strings.Join(counts, ", "))
+		}
+		fmt.Println("\n   * primary space, stored in the ontology tables")
+		return nil
+
+	case "create":
+		if len(args) < 1 || strings.HasPrefix(args[0], "--") {
+			return fmt.Errorf("embeddings create requires a space name, --model=M and --dimensions=N")
+		}
+		space := model.EmbeddingSpace{Name: args[0]}
+		for _, arg := range args[1:] {
+			switch {
+			case strings.HasPrefix(arg, "--model="):
+				space.Model = strings.TrimPrefix(arg, "--model=")
+			case strings.HasPrefix(arg, "--dimensions="):
+				fmt.Sscanf(strings.TrimPrefix(arg, "--dimensions="), "%d", &space.Dimensions)
+			case strings.HasPrefix(arg, "--description="):
+				space.Description = strings.TrimPrefix(arg, "--description=")
+			}
+		}
+		if err := repo.Create(ctx, space); err != nil {
+			return err
+		}
+		fmt.Printf("✅ Created embedding space %s (%s, %d dimensions)\n", space.Name, space.Model, space.Dimensions)
+		if space.Dimensions > ontology.MaxIndexedDimensions {
+			fmt.Printf("⚠️  More than %d dimensions cannot be indexed; searches of %s scan every embedding\n",
+				ontology.MaxIndexedDimensions, space.Name)
+		}
+		fmt.Printf("   Next: kycctl embeddings sync %s\n", space.Name)
+		return nil
+
+	case "sync":
+		if len(args) < 1 || strings.HasPrefix(args[0], "--") {
+			return fmt.Errorf("embeddings sync requires a space name")
+		}
+		kinds := ontology.BackfillKinds
+		batchSize := 50
+		for _, arg := range args[1:] {
+			switch {
+			case strings.HasPrefix(arg, "--kind="):
+				kind := strings.TrimPrefix(arg, "--kind=")
+				if kind != "all" {
+					if !slices.Contains(ontology.BackfillKinds, kind) {
+						return fmt.Errorf("unknown kind %q (expected attributes, documents, regulations or all)", kind)
+					}
+					kinds = []string{kind}
+				}
+			case strings.HasPrefix(arg, "--batch-size="):
+				fmt.Sscanf(strings.TrimPrefix(arg, "--batch-size="), "%d", &batchSize)
+			}
+		}
+		space, err := repo.Get(ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("🧭 Syncing embedding space %s (%s, %d dimensions)\n", space.Name, space.Model, space.Dimensions)
+		fmt.Println("================================================")
+		start := time.Now()
+		embedder := rag.NewEmbedder()
+		summary, err := embedspace.NewSyncer(db, embedder).Sync(ctx, *space, kinds, batchSize, 0,
+			func(kind string, done, total int) {
+				fmt.Printf("  ✅ %-12s %d/%d\n", kind, done, total)
+			})
+		fmt.Println("\n================================================")
+		fmt.Printf("📊 %d pending, %d embedded, %d failed\n", summary.Pending, summary.Embedded, summary.Failed)
+		fmt.Printf("⏱️  Total time: %s\n", time.Since(start).Round(time.Millisecond))
+		return err
+
+	case "promote":
+		if len(args) < 1 {
+			return fmt.Errorf("embeddings promote requires a space name")
+		}
+		result, err := repo.Promote(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ %s is now the primary embedding space (%s, %d dimensions)\n", result.To, result.Model, result.Dimensions)
+		fmt.Printf("   %d ontology embeddings moved into the ontology tables\n", result.Embeddings)
+		fmt.Printf("   Previous embeddings kept as space %s; promote it to switch back\n", result.From)
+		if result.Resized {
+			fmt.Printf("📐 Embedding columns resized to vector(%d)\n", result.Dimensions)
+		}
+		if result.Unindexed {
+			fmt.Printf("⚠️  More than %d dimensions cannot be indexed; vector searches scan every row\n",
+				ontology.MaxIndexedDimensions)
+		}
+		fmt.Println("\nNext steps:")
+		fmt.Printf("  • Set OPENAI_EMBEDDING_MODEL=%s and OPENAI_EMBEDDING_DIMENSIONS=%d\n", result.Model, result.Dimensions)
+		if result.SectionsCleared > 0 {
+			fmt.Printf("  • Re-ingest documents: %d section embeddings of the previous model were cleared\n",
+				result.SectionsCleared)
+		}
+		fmt.Println("  • Restart kycserver so queries are embedded with the new model")
+		return nil
+
+	case "drop":
+		if len(args) < 1 {
+			return fmt.Errorf("embeddings drop requires a space name")
+		}
+		if err := repo.Drop(ctx, args[0]); err != nil {
+			return err
+		}
+		fmt.Printf("🗑️  Dropped embedding space %s\n", args[0])
+		return nil
+
+	case "tiers":
+		tiers := ontology.NewTierRepo(db)
+		stats, err := tiers.Stats(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Println("🧊 Attribute embedding tiers")
+		fmt.Printf("   Hot (indexed):   %d\n", stats.Hot)
+		fmt.Printf("   Cold (archived): %d\n", stats.Cold)
+		fmt.Printf("   Missing:         %d\n", stats.Missing)
+		if stats.Cold == 0 {
+			return nil
+		}
+		cold, err := tiers.ListCold(ctx, 50)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("\n%-40s %-17s %-17s %s\n", "Attribute", "Last used", "Archived", "Hits since")
+		fmt.Println(strings.Repeat("─", 90))
+		for _, c := range cold {
+			lastUsed := "never searched"
+			if c.LastUsedAt != nil {
+				lastUsed = c.LastUsedAt.Format("2006-01-02 15:04")
+			}
+			fmt.Printf("%-40s %-17s %-17s %d\n", c.AttributeCode, lastUsed, c.ArchivedAt.Format("2006-01-02 15:04"), c.HitsSinceArchive)
+		}
+		if stats.Cold > len(cold) {
+			fmt.Printf("   ... and %d more\n", stats.Cold-len(cold))
+		}
+		return nil
+
+	case "archive":
+		var codes []string
+		var unusedFor time.Duration
+		limit := config.Current().EmbeddingTiers.BatchSize
+		for _, arg := range args {
+			switch {
+			case strings.HasPrefix(arg, "--unused-for="):
+				d, err := time.ParseDuration(strings.TrimPrefix(arg, "--unused-for="))
+				if err != nil {
+					return fmt.Errorf("invalid --unused-for (expected a duration such as 2160h): %w", err)
+				}
+				unusedFor = d
+			case strings.HasPrefix(arg, "--limit="):
+				fmt.Sscanf(strings.TrimPrefix(arg, "--limit="), "%d", &limit)
+			default:
+				codes = append(codes, arg)
+			}
+		}
+		tiers := ontology.NewTierRepo(db)
+		var archived []string
+		switch {
+		case len(codes) > 0:
+			archived, err = tiers.Archive(ctx, codes)
+		case unusedFor > 0:
+			archived, err = tiers.ArchiveUnused(ctx, unusedFor, limit)
+		default:
+			return fmt.Errorf("embeddings archive requires attribute codes or --unused-for=D")
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("🧊 Archived %d attribute embedding(s) to the cold tier\n", len(archived))
+		for _, code := range archived {
+			fmt.Printf("   %s\n", code)
+		}
+		return nil
+
+	case "unarchive":
+		tiers := ontology.NewTierRepo(db)
+		var promoted []string
+		switch {
+		case len(args) == 1 && args[0] == "--used":
+			promoted, err = tiers.PromoteUsed(ctx, config.Current().EmbeddingTiers.PromoteHits)
+		case len(args) == 1 && args[0] == "--all":
+			promoted, err = tiers.Promote(ctx, nil)
+		case len(args) > 0:
+			promoted, err = tiers.Promote(ctx, args)
+		default:
+			return fmt.Errorf("embeddings unarchive requires attribute codes, --used or --all")
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("🔥 Promoted %d attribute embedding(s) to the hot tier\n", len(promoted))
+		for _, code := range promoted {
+			fmt.Printf("   %s\n", code)
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unknown embeddings action %q (expected list, create, sync, promote, drop, tiers, archive or unarchive)", action)
+	}
+}
@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/pii"
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+// RunEncryptionCommand reports which keys encrypt the stored attribute
+// values, re-wraps them with the current key and generates new keys
+func RunEncryptionCommand(action string, args []string) error {
+	plaintext, batchSize := false, 500
+	var positional []string
+	for _, arg := range args {
+		switch {
+		case arg == "--plaintext":
+			plaintext = true
+		case strings.HasPrefix(arg, "--batch="):
+			n, err := strconv.Atoi(strings.TrimPrefix(arg, "--batch="))
+			if err != nil || n < 1 {
+				return fmt.Errorf("--batch must be a positive integer")
+			}
+			batchSize = n
+		case strings.HasPrefix(arg, "--"):
+			return fmt.Errorf("unknown encryption option %q", arg)
+		default:
+			positional = append(positional, arg)
+		}
+	}
+
+	if action == "generate-key" {
+		id := time.Now().UTC().Format("kek-20060102")
+		if len(positional) > 0 {
+			id = positional[0]
+		}
+		key, err := pii.GenerateKey()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("🔑 New key %s; add it to PII_KEYS and make it current with PII_KEY_ID=%s:\n\n%s:%s\n", id, id, id, key)
+		return nil
+	}
+	if action != "" && action != "status" && action != "rotate" {
+		return fmt.Errorf("unknown encryption action %q (expected status, rotate or generate-key)", action)
+	}
+
+	cfg := config.Current().Encryption
+	cipher, err := pii.New(cfg)
+	if err != nil {
+		return err
+	}
+	db, err := storage.ConnectPostgres()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+	ctx := context.Background()
+
+	if action == "rotate" {
+		rotations, err := cipher.Rotate(ctx, db, plaintext, batchSize)
+		for _, r := range rotations {
+			fmt.Printf("🔄 %-28s %6d re-wrapped, %6d encrypted\n", r.Table, r.Rewrapped, r.Encrypted)
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ Values are encrypted under %s\n", cipher.KeyID())
+		return nil
+	}
+
+	usage, err := cipher.Status(ctx, db)
+	if err != nil {
+		return err
+	}
+	state := "disabled"
+	if cfg.Enabled {
+		state = "enabled, current key " + cipher.KeyID()
+	}
+	fmt.Printf("🔐 PII encryption: %s (%s driver)\n", state, cfg.Driver)
+	if attrs := cipher.Attributes(); len(attrs) > 0 {
+		fmt.Printf("   Encrypted besides personal data: %s\n", strings.Join(attrs, ", "))
+	}
+	fmt.Println()
+	for _, u := range usage {
+		key := u.KeyID
+		if key == "" {
+			key = "(plaintext)"
+		}
+		fmt.Printf("  %-28s %-20s %6d values\n", u.Table, key, u.Values)
+	}
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,376 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/evidence"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/modellog"
+	"github.com/adamtc007/KYC-DSL/internal/rag"
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+// RunEvidenceCommand uploads, lists, downloads and verifies the document
+// files collected for cases. Views and downloads are recorded in the
+// evidence access log under the operating system user.
+func RunEvidenceCommand(action string, args []string) error {
+	db, err := storage.ConnectPostgres()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	svc, err := evidence.NewService(db, config.Current().Evidence)
+	if err != nil {
+		return err
+	}
+	ctx := commandContext()
+
+	switch action {
+	case "upload":
+		if len(args) < 2 {
+			return fmt.Errorf("evidence upload requires a case name and a file")
+		}
+		upload := evidence.Upload{CaseName: args[0], FileName: filepath.Base(args[1])}
+		for _, arg := range args[2:] {
+			name, value, _ := strings.Cut(arg, "=")
+			switch name {
+			case "--document":
+				upload.DocumentCode = value
+			case "--attribute":
+				upload.AttributeCode = value
+			case "--description":
+				upload.Description = value
+			case "--validity-days":
+				days, err := strconv.Atoi(value)
+				if err != nil || days <= 0 {
+					return fmt.Errorf("--validity-days must be a positive number of days, got %q", value)
+				}
+				upload.ValidityDays = days
+			case "--issued", "--expires":
+				t, err := time.Parse("2006-01-02", value)
+				if err != nil {
+					return fmt.Errorf("%s must be YYYY-MM-DD, got %q", name, value)
+				}
+				if name == "--issued" {
+					upload.IssuedAt = &t
+				} else {
+					upload.ExpiresAt = &t
+				}
+			default:
+				return fmt.Errorf("unknown evidence upload argument %q", arg)
+			}
+		}
+		f, err := os.Open(args[1])
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", args[1], err)
+		}
+		defer f.Close()
+
+		e, err := svc.Upload(ctx, upload, f)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ Evidence #%d: %s (%s, %d bytes) for %s\n", e.ID, e.FileName, e.ContentType, e.SizeBytes, e.CaseName)
+		fmt.Printf("   sha256 %s, stored by %s\n", e.SHA256, e.StorageDriver)
+		if e.ExpiresAt != nil {
+			fmt.Printf("   Expires %s\n", e.ExpiresAt.Format("2006-01-02"))
+		}
+		return nil
+
+	case "list":
+		if len(args) < 1 {
+			return fmt.Errorf("evidence list requires a case name")
+		}
+		all, err := svc.List(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("📎 Evidence for %s: %d\n\n", args[0], len(all))
+		for _, e := range all {
+			expires := ""
+			if e.ExpiresAt != nil {
+				expires = "expires " + e.ExpiresAt.Format("2006-01-02")
+			}
+			fmt.Printf("  #%-5d %-30s %-15s %10d  %s  %s\n", e.ID, e.FileName, e.DocumentCode, e.SizeBytes,
+				e.UploadedAt.Format("2006-01-02 15:04"), expires)
+		}
+		fmt.Println()
+		return nil
+
+	case "get":
+		if len(args) < 1 {
+			return fmt.Errorf("evidence get requires an evidence id")
+		}
+		id, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid evidence id %q", args[0])
+		}
+		out := ""
+		for _, arg := range args[1:] {
+			if strings.HasPrefix(arg, "--out=") {
+				out = strings.TrimPrefix(arg, "--out=")
+			}
+		}
+		e, content, err := svc.Open(ctx, id)
+		if err != nil {
+			return err
+		}
+		defer content.Close()
+		if out == "" {
+			out = e.FileName
+		}
+		f, err := os.OpenFile(out, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", out, err)
+		}
+		if _, err := io.Copy(f, content); err != nil {
+			f.Close()
+			return fmt.Errorf("failed to write %s: %w", out, err)
+		}
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("failed to write %s: %w", out, err)
+		}
+		fmt.Printf("✅ Evidence #%d saved to %s (%d bytes)\n", e.ID, out, e.SizeBytes)
+		return nil
+
+	case "access":
+		if len(args) < 1 {
+			return fmt.Errorf("evidence access requires an evidence id")
+		}
+		id, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid evidence id %q", args[0])
+		}
+		accesses, err := svc.AccessLog(ctx, id)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("🔍 Access log of evidence #%d: %d entries\n\n", id, len(accesses))
+		for _, a := range accesses {
+			fmt.Printf("  %s  %-8s %-20s %-5s %s\n", a.AccessedAt.Format("2006-01-02 15:04:05"), a.Action, a.Actor, a.ActorSource, a.ClientIP)
+		}
+		fmt.Println()
+		return nil
+
+	case "verify":
+		if len(args) < 1 {
+			return fmt.Errorf("evidence verify requires an evidence id")
+		}
+		id, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid evidence id %q", args[0])
+		}
+		ok, err := svc.Verify(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("evidence #%d does not match its recorded sha256", id)
+		}
+		fmt.Printf("✅ Evidence #%d matches its recorded sha256\n", id)
+		return nil
+
+	case "expiring":
+		if len(args) < 1 {
+			return fmt.Errorf("evidence expiring requires a case name")
+		}
+		days := config.Current().Evidence.Expiry.WarningDays
+		for _, arg := range args[1:] {
+			if strings.HasPrefix(arg, "--days=") {
+				days, err = strconv.Atoi(strings.TrimPrefix(arg, "--days="))
+				if err != nil || days < 0 {
+					return fmt.Errorf("invalid --days value %q", arg)
+				}
+			}
+		}
+		expiring, err := evidence.Expiring(ctx, db, args[0], days)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("📅 Evidence of %s expiring within %d days: %d\n\n", args[0], days, len(expiring))
+		for _, e := range expiring {
+			fmt.Printf("  #%-5d %-30s %-15s %-8s %s (%+d days)\n", e.ID, e.FileName, e.DocumentCode, e.Status,
+				e.ExpiresAt.Format("2006-01-02"), e.DaysLeft)
+		}
+		fmt.Println()
+		return nil
+
+	case "check-expiry":
+		summary, err := evidence.NewChecker(db, config.Current().Evidence.Expiry).RunOnce(ctx, time.Now().UTC())
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ Expiry check: %d expiring, %d expired (%d newly flagged, %d cleared)\n",
+			summary.Expiring, summary.Expired, summary.Flagged, summary.Cleared)
+		if summary.SummarySent {
+			fmt.Println("   Daily summary queued as a documents.expiry.summary event")
+		}
+		return nil
+
+	case "extract":
+		if len(args) < 1 {
+			return fmt.Errorf("evidence extract requires an evidence id")
+		}
+		id, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid evidence id %q", args[0])
+		}
+		cfg := config.Current()
+		worker, err := evidence.NewTextWorker(db, rag.NewEmbedder(), cfg.Evidence, cfg.Ingestion)
+		if err != nil {
+			return err
+		}
+		res, err := worker.Extract(ctx, id)
+		if err != nil {
+			return err
+		}
+		switch res.Status {
+		case model.EvidenceTextExtracted:
+			fmt.Printf("✅ Evidence #%d: %d pages extracted by %s, %d sections embedded\n", id, res.Pages, res.Extractor, res.Sections)
+			if res.Error != "" {
+				fmt.Printf("   %s\n", res.Error)
+			}
+		case model.EvidenceTextUnsupported:
+			fmt.Printf("⚠️  Evidence #%d: no configured extractor reads its content type\n", id)
+		default:
+			return fmt.Errorf("evidence #%d not extracted (%s): %s", id, res.Status, res.Error)
+		}
+		return nil
+
+	case "text":
+		if len(args) < 1 {
+			return fmt.Errorf("evidence text requires an evidence id")
+		}
+		id, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid evidence id %q", args[0])
+		}
+		e, pages, err := svc.Pages(ctx, id)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("📄 Text of evidence #%d (%s): %s, %d pages\n", id, e.FileName, e.TextStatus, len(pages))
+		if e.TextError != "" {
+			fmt.Printf("   %s\n", e.TextError)
+		}
+		for _, p := range pages {
+			fmt.Printf("\n--- page %d ---\n%s\n", p.PageNumber, p.Text)
+		}
+		return nil
+
+	case "propose":
+		if len(args) < 1 {
+			return fmt.Errorf("evidence propose requires an evidence id")
+		}
+		id, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid evidence id %q", args[0])
+		}
+		var attributes []string
+		for _, arg := range args[1:] {
+			if strings.HasPrefix(arg, "--attributes=") {
+				attributes = strings.Split(strings.TrimPrefix(arg, "--attributes="), ",")
+			}
+		}
+		extractor, err := evidence.NewAttributeExtractor(db, rag.NewEmbedder())
+		if err != nil {
+			return err
+		}
+		extractor.WithLog(modellog.NewLogger(db, config.Current().ModelLog))
+		proposal, err := extractor.Propose(ctx, id, attributes)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("🔎 Evidence #%d (%s): %d values proposed by %s for confirmation\n\n", id, proposal.CaseName,
+			len(proposal.Candidates), proposal.Model)
+		for _, c := range proposal.Candidates {
+			printCandidate(c)
+		}
+		if len(proposal.NotFound) > 0 {
+			fmt.Printf("Not found: %s\n", strings.Join(proposal.NotFound, ", "))
+		}
+		if proposal.Superseded > 0 {
+			fmt.Printf("%d earlier pending candidates superseded\n", proposal.Superseded)
+		}
+		return nil
+
+	case "candidates":
+		if len(args) < 1 {
+			return fmt.Errorf("evidence candidates requires a case name")
+		}
+		status := ""
+		for _, arg := range args[1:] {
+			if strings.HasPrefix(arg, "--status=") {
+				status = strings.TrimPrefix(arg, "--status=")
+			}
+		}
+		candidates, err := svc.Candidates(ctx, args[0], status)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("🔎 Attribute candidates of %s: %d\n\n", args[0], len(candidates))
+		for _, c := range candidates {
+			printCandidate(c)
+		}
+		return nil
+
+	case "confirm", "reject":
+		if len(args) < 1 {
+			return fmt.Errorf("evidence %s requires a candidate id", action)
+		}
+		id, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid candidate id %q", args[0])
+		}
+		var value, note string
+		for _, arg := range args[1:] {
+			name, v, _ := strings.Cut(arg, "=")
+			switch {
+			case name == "--value" && action == "confirm":
+				value = v
+			case name == "--note":
+				note = v
+			default:
+				return fmt.Errorf("unknown evidence %s argument %q", action, arg)
+			}
+		}
+		c, err := svc.DecideCandidate(ctx, id, action == "confirm", value, note)
+		if err != nil {
+			return err
+		}
+		if c.Status == model.CandidateConfirmed {
+			fmt.Printf("✅ Candidate #%d confirmed: %s = %s\n", c.ID, c.AttributeCode, c.ConfirmedValue)
+		} else {
+			fmt.Printf("✅ Candidate #%d rejected: %s = %s\n", c.ID, c.AttributeCode, c.Value)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown evidence action %q (expected upload, list, get, access, verify, expiring, check-expiry, extract, text, propose, candidates, confirm or reject)", action)
+}
+
+// printCandidate prints a proposed attribute value with its citations
+func printCandidate(c model.AttributeCandidate) {
+	verified := ""
+	if !c.QuoteVerified {
+		verified = ", quote not found in excerpts"
+	}
+	fmt.Printf("  #%-5d %-28s %q (%.2f%s) %s\n", c.ID, c.AttributeCode, c.Value, c.Confidence, verified, c.Status)
+	if c.ConfirmedValue != "" && c.ConfirmedValue != c.Value {
+		fmt.Printf("         confirmed as %q\n", c.ConfirmedValue)
+	}
+	for _, cit := range c.Citations {
+		excerpt := cit.Excerpt
+		if len(excerpt) > 100 {
+			excerpt = excerpt[:100] + "..."
+		}
+		fmt.Printf("         [%d] page %d: %s\n", cit.Ref, cit.Page, excerpt)
+	}
+}
@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/adamtc007/KYC-DSL/internal/dataclient"
+)
+
+// RunExportPDFCommand renders a case version into a PDF review pack and
+// writes it to outPath (the pack's suggested file name when empty).
+func RunExportPDFCommand(caseName, versionID, outPath string, allowDraft bool) error {
+	client, err := dataclient.NewFromEnv()
+	if err != nil {
+		return fmt.Errorf("failed to connect to data service: %w", err)
+	}
+	defer client.Close()
+
+	pack, err := client.GenerateReviewPack(caseName, versionID, allowDraft)
+	if err != nil {
+		return err
+	}
+
+	if outPath == "" {
+		outPath = pack.Filename
+	}
+	if err := os.WriteFile(outPath, pack.Pdf, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outPath, err)
+	}
+
+	fmt.Printf("📑 Review pack for case %s (version %s, status %s)\n", pack.CaseId, pack.VersionId, pack.Status)
+	if pack.Draft {
+		fmt.Println("⚠️  Version is not approved: pack is marked DRAFT")
+	}
+	fmt.Printf("🔐 Version hash: %s\n", pack.VersionHash)
+	fmt.Printf("✅ Wrote %s (%d pages, %d bytes)\n", outPath, pack.Pages, len(pack.Pdf))
+
+	return nil
+}
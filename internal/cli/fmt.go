@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/parser"
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+// RunFmtCommand formats DSL files in the canonical layout (parser.Format).
+// Formatted DSL is printed, or with -w written back to files that change.
+func RunFmtCommand(args []string) error {
+	var (
+		files []string
+		write bool
+	)
+	for _, arg := range args {
+		switch {
+		case arg == "-w" || arg == "--write":
+			write = true
+		case strings.HasPrefix(arg, "-"):
+			return fmt.Errorf("unknown fmt flag %q", arg)
+		default:
+			files = append(files, arg)
+		}
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("fmt requires at least one DSL file")
+	}
+
+	for _, path := range files {
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		formatted, err := parser.Format(string(src))
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		// Formatting only moves whitespace, comments and sections, none of
+		// which are part of the canonical hash; a different hash is a bug
+		if storage.CanonicalHash(formatted) != storage.CanonicalHash(string(src)) {
+			return fmt.Errorf("formatting %s would change its content; file left unchanged", path)
+		}
+
+		if !write {
+			if len(files) > 1 {
+				fmt.Printf("; %s\n", path)
+			}
+			fmt.Print(formatted)
+			continue
+		}
+		if formatted == string(src) {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("failed to stat %s: %w", path, err)
+		}
+		if err := os.WriteFile(path, []byte(formatted), info.Mode().Perm()); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		fmt.Printf("✏️  Formatted %s\n", path)
+	}
+	return nil
+}
@@ -10,7 +10,7 @@ import (
 // RunGetCaseCommand retrieves and displays DSL from the database.
 func RunGetCaseCommand(caseName string, version int) error {
 	// Connect to data service
-	client, err := dataclient.NewDataClient("")
+	client, err := dataclient.NewFromEnv()
 	if err != nil {
 		return fmt.Errorf("failed to connect to data service: %w", err)
 	}
@@ -46,7 +46,7 @@ func RunGetCaseCommand(caseName string, version int) error {
 // RunListCaseVersionsCommand lists all versions of a case.
 func RunListCaseVersionsCommand(caseName string) error {
 	// Connect to data service
-	client, err := dataclient.NewDataClient("")
+	client, err := dataclient.NewFromEnv()
 	if err != nil {
 		return fmt.Errorf("failed to connect to data service: %w", err)
 	}
@@ -85,7 +85,7 @@ func RunListCaseVersionsCommand(caseName string) error {
 // RunListAllCasesCommand lists all cases in the database.
 func RunListAllCasesCommand() error {
 	// Connect to data service
-	client, err := dataclient.NewDataClient("")
+	client, err := dataclient.NewFromEnv()
 	if err != nil {
 		return fmt.Errorf("failed to connect to data service: %w", err)
 	}
@@ -104,16 +104,21 @@ func RunListAllCasesCommand() error {
 
 	// Display header
 	fmt.Printf("📋 Total Cases: %d\n\n", len(cases))
-	fmt.Println("Case Name                        │ Versions │ Status    │ Last Updated")
-	fmt.Println("─────────────────────────────────┼──────────┼───────────┼─────────────────────")
+	fmt.Println("Case Name                        │ Versions │ Status    │ Last Updated         │ Locked By")
+	fmt.Println("─────────────────────────────────┼──────────┼───────────┼──────────────────────┼──────────────")
 
 	// Display each case
 	for _, c := range cases {
-		fmt.Printf("%-32s │ %-8d │ %-9s │ %s\n",
+		lockedBy := "-"
+		if c.LockedBy != "" {
+			lockedBy = "🔒 " + c.LockedBy
+		}
+		fmt.Printf("%-32s │ %-8d │ %-9s │ %-20s │ %s\n",
 			truncate(c.CaseId, 32),
 			c.VersionCount,
 			c.Status,
-			c.LastUpdated)
+			c.LastUpdated,
+			lockedBy)
 	}
 	fmt.Println()
 
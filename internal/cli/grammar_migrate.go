@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/parser"
+	"github.com/adamtc007/KYC-DSL/internal/rustclient"
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+// RunGrammarMigrateCommand upgrades stored cases to a grammar version:
+// kycctl grammar-migrate [--case=NAME...] [--to=V] [--dry-run]. Without
+// --case every case whose latest version is behind the target is migrated.
+// The target defaults to the version of the Rust engine, which must be one
+// the Go tools know.
+func RunGrammarMigrateCommand(args []string) error {
+	var (
+		cases  []string
+		target string
+		dryRun bool
+	)
+	for _, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "--case="):
+			cases = append(cases, strings.TrimPrefix(arg, "--case="))
+		case strings.HasPrefix(arg, "--to="):
+			target = strings.TrimPrefix(arg, "--to=")
+		case arg == "--dry-run":
+			dryRun = true
+		default:
+			return fmt.Errorf("unknown grammar-migrate argument %q", arg)
+		}
+	}
+
+	if target == "" {
+		var err error
+		if target, err = engineGrammarVersion(); err != nil {
+			return err
+		}
+	}
+	if err := CheckGrammarVersion(target); err != nil {
+		return fmt.Errorf("%w (known: %s)", err, strings.Join(parser.GrammarVersions, ", "))
+	}
+
+	db, err := storage.ConnectPostgres()
+	if err != nil {
+		return fmt.Errorf("database connection failed: %w", err)
+	}
+	defer db.Close()
+
+	ctx := commandContext()
+	if len(cases) == 0 {
+		if cases, err = storage.CasesBehindGrammar(ctx, db, target); err != nil {
+			return err
+		}
+	}
+	if len(cases) == 0 {
+		fmt.Printf("✅ All cases are on grammar v%s\n", target)
+		return nil
+	}
+
+	failed := 0
+	for _, name := range cases {
+		m, err := storage.MigrateCaseGrammar(ctx, db, name, target, dryRun)
+		if err != nil {
+			fmt.Printf("❌ %s: %v\n", name, err)
+			failed++
+			continue
+		}
+		switch {
+		case len(m.Changes) == 0:
+			fmt.Printf("🏷️  %s v%d: v%s → v%s, no rewrite needed\n", name, m.Version, m.From, m.To)
+		case dryRun:
+			fmt.Printf("🔍 %s v%d: v%s → v%s would rewrite\n", name, m.Version, m.From, m.To)
+		default:
+			fmt.Printf("⬆️  %s v%d → v%d: v%s → v%s\n", name, m.Version, m.NewVersion, m.From, m.To)
+		}
+		for _, change := range m.Changes {
+			fmt.Printf("     %s\n", change)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d cases could not be migrated", failed, len(cases))
+	}
+	return nil
+}
+
+// RunGrammarMatrixCommand prints which grammar versions an engine of each
+// version reads as is, reads after migration, or cannot read
+func RunGrammarMatrixCommand() error {
+	matrix := parser.CompatibilityMatrix()
+	fmt.Printf("%-12s", "case\\engine")
+	for _, e := range parser.GrammarVersions {
+		fmt.Printf(" │ %-12s", "v"+e)
+	}
+	fmt.Println()
+	for _, c := range parser.GrammarVersions {
+		fmt.Printf("%-12s", "v"+c)
+		for _, e := range parser.GrammarVersions {
+			fmt.Printf(" │ %-12s", matrix[c][e])
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+// CheckGrammarVersion returns an error unless version is a released grammar
+// version the Go tools can migrate to
+func CheckGrammarVersion(version string) error {
+	if parser.CheckCompatibility(version, parser.CurrentGrammarVersion) == parser.Incompatible {
+		return fmt.Errorf("%w: v%s is not supported by this kycctl (v%s)",
+			parser.ErrIncompatibleGrammar, version, parser.CurrentGrammarVersion)
+	}
+	return nil
+}
+
+// engineGrammarVersion negotiates the grammar version with the Rust engine:
+// its version, when this kycctl knows it
+func engineGrammarVersion() (string, error) {
+	rustClient, err := rustclient.NewDslClient("")
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to Rust DSL service: %w", err)
+	}
+	defer rustClient.Close()
+
+	grammarResp, err := rustClient.GetGrammar()
+	if err != nil {
+		return "", fmt.Errorf("failed to get grammar from Rust service: %w", err)
+	}
+	if err := CheckGrammarVersion(grammarResp.Version); err != nil {
+		return "", fmt.Errorf("rust engine grammar: %w", err)
+	}
+	return grammarResp.Version, nil
+}
@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/ingest"
+	"github.com/adamtc007/KYC-DSL/internal/rag"
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+// IngestOptions controls a document ingestion; zero values take the
+// ingestion configuration
+type IngestOptions struct {
+	Code          string
+	ChunkTokens   int
+	OverlapTokens int
+	BatchSize     int
+	// DryRun prints the sections without embedding or storing them
+	DryRun bool
+}
+
+// RunIngestDocumentCommand splits a PDF or HTML regulatory document into
+// sections, embeds them and replaces the stored sections of the document
+func RunIngestDocumentCommand(path string, opts IngestOptions) error {
+	if opts.Code == "" {
+		return fmt.Errorf("--code=<DOC_CODE> is required")
+	}
+	cfg := config.Current().Ingestion
+	if opts.ChunkTokens > 0 {
+		cfg.ChunkTokens = opts.ChunkTokens
+	}
+	if opts.OverlapTokens > 0 {
+		cfg.OverlapTokens = opts.OverlapTokens
+	}
+	if opts.BatchSize > 0 {
+		cfg.BatchSize = opts.BatchSize
+	}
+	if cfg.OverlapTokens >= cfg.ChunkTokens {
+		return fmt.Errorf("overlap (%d) must be smaller than the chunk size (%d)", cfg.OverlapTokens, cfg.ChunkTokens)
+	}
+
+	fmt.Printf("📥 Document Ingestion: %s → %s\n", path, opts.Code)
+	fmt.Println("================================================")
+	fmt.Printf("⚙️  Chunks of ~%d tokens, %d overlap, %d per embedding batch\n", cfg.ChunkTokens, cfg.OverlapTokens, cfg.BatchSize)
+
+	if opts.DryRun {
+		res, err := ingest.NewPipeline(nil, nil, cfg).Split(opts.Code, path)
+		if err != nil {
+			return err
+		}
+		printIngestSummary(res)
+		fmt.Println()
+		for i, s := range res.Sections {
+			heading := strings.TrimSpace(s.SectionNumber + " " + s.SectionTitle)
+			if heading == "" {
+				heading = "(untitled)"
+			}
+			excerpt := s.TextExcerpt
+			if len(excerpt) > 100 {
+				excerpt = excerpt[:100] + "..."
+			}
+			fmt.Printf("  %3d. p%-3d %-40s %s\n", i+1, s.PageNumber, truncate(heading, 40), excerpt)
+		}
+		fmt.Printf("\n✅ Dry run only, nothing embedded (~%d tokens, ~$%.4f)\n",
+			res.Tokens, rag.EmbeddingCost(rag.NewEmbedder().GetModel(), int64(res.Tokens)))
+		return nil
+	}
+
+	db, err := storage.ConnectPostgres()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	start := time.Now()
+	res, err := ingest.NewPipeline(db, rag.NewEmbedder(), cfg).Ingest(ctx, opts.Code, path, func(done, total int) {
+		fmt.Printf("  ⚡ embedded %d/%d sections\n", done, total)
+	})
+	if err != nil {
+		return err
+	}
+
+	printIngestSummary(res)
+	fmt.Printf("💾 Replaced the sections of %s with %d new sections\n", res.DocumentCode, len(res.Sections))
+	fmt.Printf("⏱️  Total time: %s\n", time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+func printIngestSummary(res *ingest.Result) {
+	fmt.Printf("📄 Format:   %s\n", res.Format)
+	if res.Pages > 0 {
+		fmt.Printf("📃 Pages:    %d\n", res.Pages)
+	}
+	fmt.Printf("🧱 Blocks:   %d\n", res.Blocks)
+	fmt.Printf("📑 Sections: %d (~%d tokens)\n", len(res.Sections), res.Tokens)
+}
@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+// RunVerifyIntegrityCommand re-validates the version hash chain of one case,
+// or of every case with --all, and fails when any chain is broken
+func RunVerifyIntegrityCommand(args []string) error {
+	var names []string
+	all := false
+	for _, arg := range args {
+		switch {
+		case arg == "--all":
+			all = true
+		case strings.HasPrefix(arg, "--"):
+			return fmt.Errorf("unknown verify-integrity option %q", arg)
+		default:
+			names = append(names, arg)
+		}
+	}
+	if all == (len(names) > 0) {
+		return fmt.Errorf("verify-integrity needs a case name or --all")
+	}
+
+	db, err := storage.ConnectPostgres()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	if all {
+		if err := db.SelectContext(ctx, &names,
+			`SELECT DISTINCT case_name FROM kyc_case_versions ORDER BY case_name`); err != nil {
+			return fmt.Errorf("failed to list cases: %w", err)
+		}
+		if len(names) == 0 {
+			fmt.Println("No case versions stored")
+			return nil
+		}
+	}
+
+	fmt.Printf("🔗 Verifying version chains of %d case(s)\n\n", len(names))
+	broken := 0
+	for _, name := range names {
+		report, err := storage.VerifyCaseIntegrity(ctx, db, name)
+		if err != nil {
+			return err
+		}
+		if report.Valid {
+			fmt.Printf("✅ %-36s %3d version(s)  head %s\n", report.CaseName, report.Versions, report.HeadHash)
+			continue
+		}
+		broken++
+		fmt.Printf("🚨 %-36s %3d version(s)  TAMPERING DETECTED\n", report.CaseName, report.Versions)
+		for _, issue := range report.Issues {
+			fmt.Printf("     v%-4d %-12s %s\n", issue.Version, issue.Problem, issue.Detail)
+		}
+	}
+	fmt.Println()
+
+	if broken > 0 {
+		return fmt.Errorf("%d of %d case version chain(s) failed verification", broken, len(names))
+	}
+	fmt.Println("All version chains intact")
+	return nil
+}
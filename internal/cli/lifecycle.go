@@ -0,0 +1,172 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/adamtc007/KYC-DSL/internal/actor"
+	"github.com/adamtc007/KYC-DSL/internal/engine"
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+// RunTimelineCommand prints the lifecycle state of a case and every
+// transition it went through
+func RunTimelineCommand(caseName string) error {
+	db, err := storage.ConnectPostgres()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	timeline, err := engine.NewLifecycle(db).Timeline(context.Background(), caseName)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🕰️  Case %s is %s\n\n", timeline.CaseName, timeline.Status)
+	for _, t := range timeline.Transitions {
+		from := "(created)"
+		if t.From != "" {
+			from = string(t.From)
+		}
+		fmt.Printf("  %s  %-10s → %-10s  %s", t.CreatedAt.Format("2006-01-02 15:04"), from, t.To, t.Actor)
+		if t.Reason != "" {
+			fmt.Printf(" — %s", t.Reason)
+		}
+		fmt.Println()
+	}
+	fmt.Println()
+	if len(timeline.Allowed) == 0 {
+		fmt.Println("🔒 No further transitions")
+	} else {
+		next := make([]string, len(timeline.Allowed))
+		for i, s := range timeline.Allowed {
+			next[i] = string(s)
+		}
+		fmt.Printf("➡️  Next: %s\n", strings.Join(next, ", "))
+	}
+	return nil
+}
+
+// RunTransitionCommand moves a case to another lifecycle state
+func RunTransitionCommand(args []string) error {
+	actorName, reason := actor.CLI().Name, ""
+	var positional []string
+	for _, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "--actor="):
+			actorName = strings.TrimPrefix(arg, "--actor=")
+		case strings.HasPrefix(arg, "--reason="):
+			reason = strings.TrimPrefix(arg, "--reason=")
+		default:
+			positional = append(positional, arg)
+		}
+	}
+	if len(positional) < 2 {
+		return fmt.Errorf("transition requires a case name and a status")
+	}
+	to, err := engine.ParseStatus(positional[1])
+	if err != nil {
+		return err
+	}
+
+	db, err := storage.ConnectPostgres()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	t, err := engine.NewLifecycle(db).Transition(commandContext(), positional[0], to, actorName, reason)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ Case %s moved from %s to %s by %s\n", t.CaseName, t.From, t.To, t.Actor)
+	return nil
+}
+
+// lockHolder is the default holder of case locks taken from the CLI: the
+// operating system user
+func lockHolder() string {
+	return actor.CLI().Name
+}
+
+// RunLockCommand shows, acquires, heartbeats or releases the advisory lock
+// on a case
+func RunLockCommand(action string, args []string) error {
+	holder, note, force := lockHolder(), "", false
+	var positional []string
+	for _, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "--holder="):
+			holder = strings.TrimPrefix(arg, "--holder=")
+		case strings.HasPrefix(arg, "--note="):
+			note = strings.TrimPrefix(arg, "--note=")
+		case arg == "--force":
+			force = true
+		default:
+			positional = append(positional, arg)
+		}
+	}
+	if len(positional) < 1 {
+		return fmt.Errorf("lock %s requires a case name", action)
+	}
+	caseName := positional[0]
+
+	db, err := storage.ConnectPostgres()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	locks := engine.NewLocks(db)
+	switch action {
+	case "status":
+		lock, err := locks.Get(ctx, caseName)
+		if err != nil {
+			return err
+		}
+		switch {
+		case lock == nil:
+			fmt.Printf("🔓 Case %s is not locked\n", caseName)
+		case lock.Stale:
+			fmt.Printf("⌛ Case %s has an expired lock of %s (since %s); it can be taken over\n",
+				caseName, lock.Holder, lock.ExpiresAt.Format(time.RFC3339))
+		default:
+			fmt.Printf("🔒 Case %s is locked by %s until %s\n", caseName, lock.Holder, lock.ExpiresAt.Format(time.RFC3339))
+			if lock.Note != "" {
+				fmt.Printf("   Note: %s\n", lock.Note)
+			}
+		}
+
+	case "acquire":
+		lock, err := locks.Acquire(ctx, caseName, holder, note)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("🔒 Locked case %s for %s until %s\n", caseName, lock.Holder, lock.ExpiresAt.Format(time.RFC3339))
+		if lock.TakenOverFrom != "" {
+			fmt.Printf("   Took over the expired lock of %s\n", lock.TakenOverFrom)
+		}
+		fmt.Printf("   Heartbeat within %s to keep it: kycctl lock heartbeat %s\n", locks.TTL(), caseName)
+
+	case "heartbeat":
+		lock, err := locks.Heartbeat(ctx, caseName, holder)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("💓 Lock on case %s extended until %s\n", caseName, lock.ExpiresAt.Format(time.RFC3339))
+
+	case "release":
+		if err := locks.Release(ctx, caseName, holder, force); err != nil {
+			return err
+		}
+		fmt.Printf("🔓 Released lock on case %s\n", caseName)
+
+	default:
+		return fmt.Errorf("unknown lock action %q (expected status, acquire, heartbeat or release)", action)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/lineage"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+// RunLineageHistoryCommand prints the lineage evaluation runs of a case,
+// newest first: kycctl lineage-history CASE [--limit=N] [--run=ID]. With
+// --run only that run is printed, with the inputs of each derivation.
+func RunLineageHistoryCommand(caseName string, args []string) error {
+	limit := 20
+	var runID int64
+	for _, arg := range args {
+		var err error
+		switch {
+		case strings.HasPrefix(arg, "--limit="):
+			limit, err = strconv.Atoi(strings.TrimPrefix(arg, "--limit="))
+		case strings.HasPrefix(arg, "--run="):
+			runID, err = strconv.ParseInt(strings.TrimPrefix(arg, "--run="), 10, 64)
+		default:
+			return fmt.Errorf("unknown lineage-history argument %q", arg)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", arg, err)
+		}
+	}
+
+	db, err := storage.ConnectPostgres()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	repo := lineage.NewRunRepo(db)
+	ctx := context.Background()
+	if runID != 0 {
+		run, err := repo.Get(ctx, runID)
+		if err != nil {
+			return err
+		}
+		if run.CaseName != caseName {
+			return fmt.Errorf("%w: #%d is a run of %s", lineage.ErrRunNotFound, runID, run.CaseName)
+		}
+		printLineageRun(*run, true)
+		return nil
+	}
+
+	runs, err := repo.History(ctx, caseName, limit)
+	if err != nil {
+		return err
+	}
+	if len(runs) == 0 {
+		fmt.Printf("ℹ️  No lineage evaluations recorded for %s\n", caseName)
+		return nil
+	}
+	fmt.Printf("🧬 Lineage evaluation runs of %s:\n\n", caseName)
+	for _, run := range runs {
+		printLineageRun(run, false)
+	}
+	return nil
+}
+
+func printLineageRun(run model.EvaluationRun, withInputs bool) {
+	version := "-"
+	if run.CaseVersion != nil {
+		version = fmt.Sprintf("v%d", *run.CaseVersion)
+	}
+	hash := run.InputsHash
+	if len(hash) > 12 {
+		hash = hash[:12]
+	}
+	fmt.Printf("  #%-6d %s  %-4s %-10s %d evaluated, %d failed", run.ID, run.StartedAt.Format("2006-01-02 15:04:05"),
+		version, run.Source, run.Evaluations, run.Failed)
+	if hash != "" {
+		fmt.Printf("  inputs %s", hash)
+	}
+	if run.Actor != "" {
+		fmt.Printf("  by %s", run.Actor)
+	}
+	fmt.Println()
+
+	for _, r := range run.Results {
+		if r.Success {
+			fmt.Printf("     ✅ %-30s = %s\n", r.DerivedCode, r.Value)
+		} else {
+			fmt.Printf("     ❌ %-30s %s\n", r.DerivedCode, r.Error)
+		}
+		if withInputs {
+			fmt.Printf("        rule:   %s\n", r.Rule)
+			if len(r.Inputs) > 0 {
+				fmt.Printf("        inputs: %s\n", r.Inputs)
+			}
+		}
+	}
+	fmt.Println()
+}
@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/lists"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/reeval"
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+// RunListsCommand shows, updates or re-evaluates the managed lists that
+// derivation rules reference with in_list
+func RunListsCommand(action string, args []string) error {
+	db, err := storage.ConnectPostgres()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := lists.NewRepo(db)
+
+	switch action {
+	case "", "list":
+		all, err := repo.List(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("🌍 Managed lists: %d\n\n", len(all))
+		for _, l := range all {
+			fmt.Printf("  %-26s v%-3d %3d members  %s  %s\n", l.Name, l.Version, len(l.Members),
+				effectiveRange(l), l.Source)
+		}
+		fmt.Println()
+		return nil
+
+	case "show":
+		if len(args) < 1 {
+			return fmt.Errorf("lists show requires a list name")
+		}
+		l, err := repo.Get(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("🌍 %s (version %d)\n", l.Name, l.Version)
+		if l.Description != "" {
+			fmt.Printf("   %s\n", l.Description)
+		}
+		fmt.Printf("   Source:    %s\n", l.Source)
+		fmt.Printf("   Effective: %s\n", effectiveRange(*l))
+		fmt.Printf("   Updated:   %s by %s\n", l.UpdatedAt.Format("2006-01-02 15:04"), l.UpdatedBy)
+		fmt.Printf("   Members:   %s\n", strings.Join(l.Members, ", "))
+		return nil
+
+	case "set":
+		if len(args) < 2 {
+			return fmt.Errorf("lists set requires a list name and --members=CODE,CODE,...")
+		}
+		update := model.ManagedList{Name: args[0]}
+		for _, arg := range args[1:] {
+			switch {
+			case strings.HasPrefix(arg, "--members="):
+				update.Members = strings.Split(strings.TrimPrefix(arg, "--members="), ",")
+			case strings.HasPrefix(arg, "--source="):
+				update.Source = strings.TrimPrefix(arg, "--source=")
+			case strings.HasPrefix(arg, "--from="):
+				t, err := time.Parse("2006-01-02", strings.TrimPrefix(arg, "--from="))
+				if err != nil {
+					return fmt.Errorf("--from must be YYYY-MM-DD")
+				}
+				update.EffectiveFrom = t
+			case strings.HasPrefix(arg, "--to="):
+				t, err := time.Parse("2006-01-02", strings.TrimPrefix(arg, "--to="))
+				if err != nil {
+					return fmt.Errorf("--to must be YYYY-MM-DD")
+				}
+				update.EffectiveTo = &t
+			}
+		}
+		if update.Members == nil {
+			return fmt.Errorf("lists set requires --members=CODE,CODE,...")
+		}
+
+		l, err := repo.Put(ctx, update, os.Getenv("USER"))
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ %s saved as version %d (%d members)\n", l.Name, l.Version, len(l.Members))
+
+	case "reevaluate":
+		if len(args) < 1 {
+			return fmt.Errorf("lists reevaluate requires a list name")
+		}
+		if _, err := reeval.NewQueue(db).ListChanged(ctx, args[0]); err != nil {
+			return err
+		}
+
+	default:
+		return fmt.Errorf("unknown lists action %q (expected list, show, set or reevaluate)", action)
+	}
+
+	return reevaluateList(ctx, db, args[0])
+}
+
+// reevaluateList processes the re-evaluations queued by a change to a list
+// and prints the derived values that changed
+func reevaluateList(ctx context.Context, db *sqlx.DB, name string) error {
+	results, err := reeval.NewWorker(db, config.Current().Reevaluation).RunFor(ctx, reeval.ReasonList, name)
+	if err != nil {
+		return err
+	}
+	changed := 0
+	for _, r := range results {
+		if !r.Changed {
+			continue
+		}
+		changed++
+		if r.Success {
+			fmt.Printf("   🔁 %s %s: %s → %s\n", r.CaseName, r.DerivedCode, r.PreviousValue, r.Value)
+		} else {
+			fmt.Printf("   ❌ %s %s: %s\n", r.CaseName, r.DerivedCode, r.Error)
+		}
+	}
+	fmt.Printf("✅ Re-evaluated %d derived attributes referencing %s, %d changed\n", len(results), name, changed)
+	return nil
+}
+
+func effectiveRange(l model.ManagedList) string {
+	to := "open"
+	if l.EffectiveTo != nil {
+		to = l.EffectiveTo.Format("2006-01-02")
+	}
+	return l.EffectiveFrom.Format("2006-01-02") + " → " + to
+}
@@ -0,0 +1,259 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+// maintenanceMinDead is the default dead tuple count that makes a table a
+// vacuum candidate
+const maintenanceMinDead = 1000
+
+// RunMaintenanceCommand runs database maintenance: status, reindex of the
+// vector indexes, vacuum/analyze of hot tables and partition upkeep. Every
+// operation refuses to start while other sessions hold conflicting locks and
+// gives up on a lock after --lock-timeout instead of blocking the service.
+func RunMaintenanceCommand(action string, args []string) error {
+	var (
+		only        string
+		dryRun      bool
+		analyzeOnly bool
+		minDead     int64 = maintenanceMinDead
+		months            = 3
+		lockTimeout       = 5 * time.Second
+	)
+	for _, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "--index="):
+			only = strings.TrimPrefix(arg, "--index=")
+		case strings.HasPrefix(arg, "--table="):
+			only = strings.TrimPrefix(arg, "--table=")
+		case arg == "--dry-run":
+			dryRun = true
+		case arg == "--analyze-only":
+			analyzeOnly = true
+		case strings.HasPrefix(arg, "--min-dead="):
+			n, err := strconv.ParseInt(strings.TrimPrefix(arg, "--min-dead="), 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid --min-dead: %w", err)
+			}
+			minDead = n
+		case strings.HasPrefix(arg, "--months="):
+			n, err := strconv.Atoi(strings.TrimPrefix(arg, "--months="))
+			if err != nil || n < 0 {
+				return fmt.Errorf("invalid --months: %q", strings.TrimPrefix(arg, "--months="))
+			}
+			months = n
+		case strings.HasPrefix(arg, "--lock-timeout="):
+			d, err := time.ParseDuration(strings.TrimPrefix(arg, "--lock-timeout="))
+			if err != nil {
+				return fmt.Errorf("invalid --lock-timeout: %w", err)
+			}
+			lockTimeout = d
+		default:
+			return fmt.Errorf("unknown maintenance flag %q", arg)
+		}
+	}
+
+	db, err := storage.OpenPostgres()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	m := storage.NewMaintenance(db)
+	m.LockTimeout = lockTimeout
+	m.Progress = printMaintenanceProgress
+
+	switch action {
+	case "status":
+		return printMaintenanceStatus(ctx, m, minDead)
+
+	case "reindex":
+		indexes, err := m.VectorIndexes(ctx)
+		if err != nil {
+			return err
+		}
+		var names []string
+		for _, idx := range indexes {
+			if only == "" || idx.Name == only {
+				names = append(names, idx.Name)
+			}
+		}
+		if only != "" && len(names) == 0 {
+			return fmt.Errorf("%s is not a vector index", only)
+		}
+		return runMaintenance("🔧", "Reindexing", names, dryRun, func(name string) error {
+			return m.Reindex(ctx, name)
+		})
+
+	case "vacuum":
+		var tables []string
+		if only != "" {
+			tables = []string{only}
+		} else {
+			hot, err := m.HotTables(ctx, minDead, 20)
+			if err != nil {
+				return err
+			}
+			for _, t := range hot {
+				tables = append(tables, t.Name)
+			}
+		}
+		verb := "Vacuuming"
+		if analyzeOnly {
+			verb = "Analyzing"
+		}
+		return runMaintenance("🧹", verb, tables, dryRun, func(name string) error {
+			return m.Vacuum(ctx, name, analyzeOnly)
+		})
+
+	case "partitions":
+		tables, err := m.PartitionedTables(ctx)
+		if err != nil {
+			return err
+		}
+		if len(tables) == 0 {
+			fmt.Println("📭 No partitioned tables")
+			return nil
+		}
+		for _, t := range tables {
+			if only != "" && t.Name != only {
+				continue
+			}
+			created, err := m.EnsurePartitions(ctx, t.Name, months, dryRun)
+			if err != nil {
+				fmt.Printf("❌ %s: %v\n", t.Name, err)
+				continue
+			}
+			switch {
+			case len(created) == 0:
+				fmt.Printf("✅ %s: partitions exist through the next %d month(s)\n", t.Name, months)
+			case dryRun:
+				fmt.Printf("📝 %s: would create %s\n", t.Name, strings.Join(created, ", "))
+			default:
+				fmt.Printf("✅ %s: created %s\n", t.Name, strings.Join(created, ", "))
+			}
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unknown maintenance action %q (expected status, reindex, vacuum or partitions)", action)
+	}
+}
+
+// runMaintenance applies op to each object in turn, skipping busy ones so
+// one locked table does not stop the run
+func runMaintenance(icon, verb string, objects []string, dryRun bool, op func(string) error) error {
+	if len(objects) == 0 {
+		fmt.Println("✅ Nothing to do")
+		return nil
+	}
+	if dryRun {
+		fmt.Printf("📝 Dry run: %s %s\n", strings.ToLower(verb), strings.Join(objects, ", "))
+		return nil
+	}
+
+	var skipped, failed int
+	for _, name := range objects {
+		fmt.Printf("%s %s %s...\n", icon, verb, name)
+		start := time.Now()
+		err := op(name)
+		switch {
+		case errors.Is(err, storage.ErrMaintenanceRunning):
+			return err
+		case errors.Is(err, storage.ErrTableBusy):
+			fmt.Printf("   ⏭️  Skipped: %v\n", err)
+			skipped++
+		case err != nil:
+			fmt.Printf("   ❌ %v\n", err)
+			failed++
+		default:
+			fmt.Printf("   ✅ Done in %s\n", time.Since(start).Round(time.Millisecond))
+		}
+	}
+
+	fmt.Printf("\n📊 %d done, %d skipped (busy), %d failed\n", len(objects)-skipped-failed, skipped, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d maintenance operation(s) failed", failed)
+	}
+	return nil
+}
+
+func printMaintenanceProgress(p storage.MaintenanceProgress) {
+	line := "   ⏳ " + p.Phase
+	if pct := p.Percent(); pct >= 0 {
+		line += fmt.Sprintf(" %.0f%% (%d/%d)", pct, p.Done, p.Total)
+	}
+	if p.Waiting > 0 {
+		line += fmt.Sprintf(", waiting for %d session(s)", p.Waiting)
+	}
+	fmt.Println(line)
+}
+
+func printMaintenanceStatus(ctx context.Context, m *storage.Maintenance, minDead int64) error {
+	indexes, err := m.VectorIndexes(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Println("🧭 Vector indexes")
+	for _, idx := range indexes {
+		state := "✅"
+		if !idx.Valid {
+			state = "❌ invalid"
+		}
+		fmt.Printf("   %-40s %-8s %-28s %10s  %s\n", idx.Name, idx.Method, idx.Table, idx.Size, state)
+	}
+
+	hot, err := m.HotTables(ctx, minDead, 10)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\n🔥 Tables with at least %d dead tuples or changes since analyze\n", minDead)
+	if len(hot) == 0 {
+		fmt.Println("   none")
+	}
+	for _, t := range hot {
+		fmt.Printf("   %-40s live %-10d dead %-10d changed %-10d vacuumed %s, analyzed %s\n",
+			t.Name, t.LiveTuples, t.DeadTuples, t.ModsSinceAnalyze, maintenanceTime(t.LastVacuum), maintenanceTime(t.LastAnalyze))
+	}
+
+	partitioned, err := m.PartitionedTables(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Println("\n🧱 Partitioned tables")
+	if len(partitioned) == 0 {
+		fmt.Println("   none")
+	}
+	for _, t := range partitioned {
+		fmt.Printf("   %-40s %-30s %d partition(s)\n", t.Name, t.Key, t.Partitions)
+	}
+
+	long, err := m.LongTransactions(ctx, 5*time.Minute)
+	if err != nil {
+		return err
+	}
+	fmt.Println("\n🐢 Transactions open longer than 5m (REINDEX CONCURRENTLY waits for them)")
+	if len(long) == 0 {
+		fmt.Println("   none")
+	}
+	for _, h := range long {
+		fmt.Printf("   pid %-8d %-20s since %s  %s\n", h.PID, h.State, h.Since.Format(time.RFC3339), h.Query)
+	}
+	return nil
+}
+
+func maintenanceTime(t *time.Time) string {
+	if t == nil {
+		return "never"
+	}
+	return t.Format("2006-01-02 15:04")
+}
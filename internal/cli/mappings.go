@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/mappings"
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+// RunMappingsCommand lists, imports, exports or removes the mappings of
+// attribute codes to ISO 20022 elements and FIBO concepts
+func RunMappingsCommand(action string, args []string) error {
+	db, err := storage.ConnectPostgres()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := mappings.NewRepo(db)
+
+	attribute, standard, format, out := "", "", "csv", ""
+	var positional []string
+	for _, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "--attribute="):
+			attribute = strings.TrimPrefix(arg, "--attribute=")
+		case strings.HasPrefix(arg, "--standard="):
+			standard = strings.TrimPrefix(arg, "--standard=")
+		case strings.HasPrefix(arg, "--format="):
+			format = strings.TrimPrefix(arg, "--format=")
+		case strings.HasPrefix(arg, "--out="):
+			out = strings.TrimPrefix(arg, "--out=")
+		default:
+			positional = append(positional, arg)
+		}
+	}
+
+	switch action {
+	case "", "list":
+		all, err := repo.List(ctx, attribute, standard)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("🔗 External mappings: %d\n\n", len(all))
+		for _, m := range all {
+			fmt.Printf("  %-28s %-9s %-7s %s", m.AttributeCode, m.Standard, m.MatchType, m.ExternalID)
+			if m.ExternalName != "" {
+				fmt.Printf(" (%s)", m.ExternalName)
+			}
+			fmt.Println()
+		}
+		fmt.Println()
+		return nil
+
+	case "import":
+		if len(positional) < 1 {
+			return fmt.Errorf("mappings import requires a CSV file")
+		}
+		f, err := os.Open(positional[0])
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", positional[0], err)
+		}
+		defer f.Close()
+		imported, err := mappings.ReadCSV(f)
+		if err != nil {
+			return err
+		}
+		if err := repo.Put(ctx, imported, os.Getenv("USER")); err != nil {
+			return err
+		}
+		fmt.Printf("✅ Imported %d mappings from %s\n", len(imported), positional[0])
+		return nil
+
+	case "export":
+		all, err := repo.List(ctx, attribute, standard)
+		if err != nil {
+			return err
+		}
+		var w io.Writer = os.Stdout
+		if out != "" {
+			f, err := os.Create(out)
+			if err != nil {
+				return fmt.Errorf("failed to create %s: %w", out, err)
+			}
+			defer f.Close()
+			w = f
+		}
+		switch format {
+		case "csv":
+			err = mappings.WriteCSV(w, all)
+		case "json":
+			enc := json.NewEncoder(w)
+			enc.SetIndent("", "  ")
+			err = enc.Encode(all)
+		default:
+			return fmt.Errorf("unknown format %q (expected csv or json)", format)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to export mappings: %w", err)
+		}
+		if out != "" {
+			fmt.Printf("✅ Exported %d mappings to %s\n", len(all), out)
+		}
+		return nil
+
+	case "remove":
+		if len(positional) < 3 {
+			return fmt.Errorf("mappings remove requires an attribute code, standard and external id")
+		}
+		if err := repo.Delete(ctx, positional[0], positional[1], positional[2]); err != nil {
+			return err
+		}
+		fmt.Printf("🗑️  Removed %s → %s %s\n", positional[0], positional[1], positional[2])
+		return nil
+
+	default:
+		return fmt.Errorf("unknown mappings action %q (expected list, import, export or remove)", action)
+	}
+}
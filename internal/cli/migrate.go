@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+	"github.com/pressly/goose/v3"
+)
+
+// RunMigrateCommand applies, rolls back or reports the embedded schema migrations
+func RunMigrateCommand(action string) error {
+	db, err := storage.OpenPostgres()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+
+	switch action {
+	case "up":
+		fmt.Println("⬆️  Applying schema migrations...")
+		results, err := storage.MigrateUp(ctx, db)
+		for _, r := range results {
+			printMigrationResult(r)
+		}
+		if err != nil {
+			return err
+		}
+		if len(results) == 0 {
+			fmt.Println("✅ Schema is up to date")
+		} else {
+			fmt.Printf("✅ Applied %d migration(s)\n", len(results))
+		}
+
+	case "down":
+		fmt.Println("⬇️  Rolling back the latest schema migration...")
+		result, err := storage.MigrateDown(ctx, db)
+		if result != nil {
+			printMigrationResult(result)
+		}
+		if err != nil {
+			return err
+		}
+
+	case "status":
+		statuses, err := storage.MigrationStatus(ctx, db)
+		if err != nil {
+			return err
+		}
+		fmt.Println("📋 Schema Migrations")
+		fmt.Println("================================================")
+		pending := 0
+		for _, s := range statuses {
+			if s.State == goose.StatePending {
+				pending++
+				fmt.Printf("  ⏳ %-40s pending\n", filepath.Base(s.Source.Path))
+				continue
+			}
+			fmt.Printf("  ✅ %-40s applied %s\n", filepath.Base(s.Source.Path), s.AppliedAt.Format("2006-01-02 15:04:05"))
+		}
+		fmt.Println()
+		fmt.Printf("%d applied, %d pending (table %s)\n", len(statuses)-pending, pending, storage.MigrationsTable)
+
+	default:
+		return fmt.Errorf("unknown migrate action %q (expected up, down or status)", action)
+	}
+
+	return nil
+}
+
+func printMigrationResult(r *goose.MigrationResult) {
+	name := filepath.Base(r.Source.Path)
+	if r.Error != nil {
+		fmt.Printf("  ❌ %s %s: %v\n", r.Direction, name, r.Error)
+		return
+	}
+	fmt.Printf("  ✅ %s %s (%s)\n", r.Direction, name, r.Duration.Round(time.Millisecond))
+}
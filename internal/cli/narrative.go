@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/adamtc007/KYC-DSL/internal/dataclient"
+)
+
+// RunNarrativeCommand generates, stores and prints a review committee
+// narrative for a case version.
+func RunNarrativeCommand(caseName, versionID, template string, polish bool) error {
+	client, err := dataclient.NewFromEnv()
+	if err != nil {
+		return fmt.Errorf("failed to connect to data service: %w", err)
+	}
+	defer client.Close()
+
+	n, err := client.GenerateCaseNarrative(caseName, versionID, template, polish)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("📝 Narrative %s for case %s (version %s, template %s)\n", n.Id, n.CaseId, n.VersionId, n.Template)
+	if n.Polished {
+		fmt.Printf("✨ Polished with %s\n", n.Model)
+	} else if n.PolishError != "" {
+		fmt.Printf("⚠️  Polishing skipped: %s\n", n.PolishError)
+	}
+	fmt.Println("─────────────────────────────────────────────")
+	fmt.Println()
+	fmt.Print(n.Narrative)
+	fmt.Println()
+
+	return nil
+}
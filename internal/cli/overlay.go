@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/actor"
+	"github.com/adamtc007/KYC-DSL/internal/auth"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/overlay"
+	"github.com/adamtc007/KYC-DSL/internal/rag"
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+// RunOverlayCommand lists and shows the ontology overlays of tenants,
+// applies and removes overlay entries, embeds overlay attributes for RAG
+// search and assigns cases to tenants
+func RunOverlayCommand(action string, args []string) error {
+	tenant, file, embed := "", "", false
+	var positional []string
+	for _, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "--tenant="):
+			tenant = strings.TrimPrefix(arg, "--tenant=")
+		case strings.HasPrefix(arg, "--file="):
+			file = strings.TrimPrefix(arg, "--file=")
+		case arg == "--embed":
+			embed = true
+		case strings.HasPrefix(arg, "--"):
+			return fmt.Errorf("unknown overlay option %q", arg)
+		default:
+			positional = append(positional, arg)
+		}
+	}
+
+	db, err := storage.ConnectPostgres()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := overlay.NewRepo(db)
+	if tenant == "" && action != "apply" {
+		tenant = auth.DefaultTenant
+	}
+
+	switch action {
+	case "", "list":
+		tenants, err := repo.Tenants(ctx)
+		if err != nil {
+			return err
+		}
+		names := make([]string, 0, len(tenants))
+		for name := range tenants {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Printf("🧩 Ontology overlays: %d\n\n", len(names))
+		for _, name := range names {
+			fmt.Printf("  %-24s %3d entries\n", name, tenants[name])
+		}
+		fmt.Println()
+
+	case "show":
+		o, err := repo.Get(ctx, tenant)
+		if err != nil {
+			return err
+		}
+		printOverlay(*o)
+
+	case "apply":
+		if file == "" {
+			return fmt.Errorf("overlay apply requires --file=<overlay.json>")
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		var o model.OntologyOverlay
+		if err := json.Unmarshal(data, &o); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		// --tenant wins over the tenant of the file
+		if tenant != "" {
+			o.Tenant = tenant
+		}
+		applied, err := repo.Apply(ctx, o, actor.CLI().Name)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ Applied overlay of %s: %d attributes, %d documents, %d links, %d derivations\n",
+			applied.Tenant, len(applied.Attributes), len(applied.Documents), len(applied.Links), len(applied.Derivations))
+		if embed {
+			return embedOverlay(ctx, overlay.NewResolver(db, applied.Tenant))
+		}
+		fmt.Printf("   Embed new attributes for RAG search with: kycctl overlay embed --tenant=%s\n", applied.Tenant)
+
+	case "embed":
+		return embedOverlay(ctx, overlay.NewResolver(db, tenant))
+
+	case "remove":
+		if len(positional) != 2 {
+			return fmt.Errorf("overlay remove requires a kind (attribute, document, link or derivation) and a code")
+		}
+		if err := repo.Remove(ctx, tenant, positional[0], positional[1]); err != nil {
+			return err
+		}
+		fmt.Printf("🗑️  Removed %s %s from the overlay of %s\n", positional[0], positional[1], tenant)
+
+	case "assign":
+		if len(positional) != 1 {
+			return fmt.Errorf("overlay assign requires a case name")
+		}
+		if err := repo.SetCaseTenant(ctx, positional[0], tenant); err != nil {
+			return err
+		}
+		fmt.Printf("✅ Case %s belongs to %s\n", positional[0], tenant)
+
+	default:
+		return fmt.Errorf("unknown overlay action %q (expected list, show, apply, embed, remove or assign)", action)
+	}
+	return nil
+}
+
+func embedOverlay(ctx context.Context, resolver *overlay.Resolver) error {
+	n, err := resolver.EmbedPending(ctx, rag.NewEmbedder())
+	if err != nil {
+		return err
+	}
+	fmt.Printf("🧠 Embedded %d overlay attributes of %s\n", n, resolver.Tenant())
+	return nil
+}
+
+func printOverlay(o model.OntologyOverlay) {
+	fmt.Printf("🧩 Ontology overlay of %s\n", o.Tenant)
+
+	fmt.Println("\n  Attributes:")
+	for _, a := range o.Attributes {
+		flags := a.AttributeClass
+		if a.Overrides {
+			flags += ", overrides global"
+		}
+		if !a.Embedded {
+			flags += ", not embedded"
+		}
+		fmt.Printf("    %-26s %-10s %s (%s)\n", a.Code, a.DataType, a.Name, flags)
+	}
+	fmt.Println("\n  Documents:")
+	for _, d := range o.Documents {
+		overrides := ""
+		if d.Overrides {
+			overrides = " (overrides global)"
+		}
+		fmt.Printf("    %-26s %-4s %s%s\n", d.Code, d.Jurisdiction, d.Name, overrides)
+	}
+	fmt.Println("\n  Links:")
+	for _, l := range o.Links {
+		required := "optional"
+		if l.IsMandatory {
+			required = "mandatory"
+		}
+		fmt.Printf("    %-26s %-22s %-9s %s\n", l.AttributeCode, l.DocumentCode, l.SourceTier, required)
+	}
+	fmt.Println("\n  Derivations:")
+	for _, d := range o.Derivations {
+		fmt.Printf("    %-26s <- %-22s %-8s %s\n", d.DerivedAttributeCode, d.SourceAttributeCode, d.RuleType, d.RuleExpression)
+	}
+	fmt.Println()
+}
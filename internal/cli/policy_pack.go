@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/policypack"
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+// RunPolicyPackCommand shows the jurisdiction policy packs read by the
+// discovery amendments, drafts new versions and activates or retires them
+func RunPolicyPackCommand(action string, args []string) error {
+	db, err := storage.ConnectPostgres()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := policypack.NewRepo(db)
+
+	switch action {
+	case "", "list":
+		jurisdiction := ""
+		for _, arg := range args {
+			if strings.HasPrefix(arg, "--jurisdiction=") {
+				jurisdiction = strings.TrimPrefix(arg, "--jurisdiction=")
+			}
+		}
+		packs, err := repo.List(ctx, jurisdiction)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("📜 Active policy packs: %d\n\n", len(packs))
+		for _, p := range packs {
+			fmt.Printf("  %-10s v%-3d %-4s %-16s %2d documents  %s\n",
+				p.Code, p.Version, p.Jurisdiction, p.PolicyCode, len(p.Documents), p.Name)
+		}
+		fmt.Println()
+
+	case "show":
+		if len(args) < 1 {
+			return fmt.Errorf("policy-pack show requires a pack code")
+		}
+		version := 0
+		for _, arg := range args[1:] {
+			if strings.HasPrefix(arg, "--version=") {
+				n, err := strconv.Atoi(strings.TrimPrefix(arg, "--version="))
+				if err != nil || n < 1 {
+					return fmt.Errorf("--version must be a positive integer")
+				}
+				version = n
+			}
+		}
+		p, err := repo.Get(ctx, args[0], version)
+		if err != nil {
+			return err
+		}
+		printPolicyPack(*p)
+
+	case "versions":
+		if len(args) < 1 {
+			return fmt.Errorf("policy-pack versions requires a pack code")
+		}
+		versions, err := repo.Versions(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("📜 %s versions: %d\n\n", strings.ToUpper(args[0]), len(versions))
+		for _, p := range versions {
+			changed := ""
+			if p.StatusChangedAt != nil {
+				changed = fmt.Sprintf("%s by %s", p.StatusChangedAt.Format("2006-01-02"), p.StatusChangedBy)
+			}
+			fmt.Printf("  v%-3d %-8s drafted %s by %-12s %s\n",
+				p.Version, p.Status, p.CreatedAt.Format("2006-01-02"), p.CreatedBy, changed)
+		}
+		fmt.Println()
+
+	case "draft":
+		file := ""
+		for _, arg := range args {
+			if strings.HasPrefix(arg, "--file=") {
+				file = strings.TrimPrefix(arg, "--file=")
+			}
+		}
+		if file == "" {
+			return fmt.Errorf("policy-pack draft requires --file=<pack.json>")
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		var pack model.PolicyPack
+		if err := json.Unmarshal(data, &pack); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		p, err := repo.Draft(ctx, pack, os.Getenv("USER"))
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ Drafted %s v%d (activate with: kycctl policy-pack activate %s %d)\n", p.Code, p.Version, p.Code, p.Version)
+
+	case "activate", "retire":
+		if len(args) < 2 {
+			return fmt.Errorf("policy-pack %s requires a pack code and a version", action)
+		}
+		version, err := strconv.Atoi(args[1])
+		if err != nil || version < 1 {
+			return fmt.Errorf("version must be a positive integer")
+		}
+		var p *model.PolicyPack
+		if action == "activate" {
+			p, err = repo.Activate(ctx, args[0], version, os.Getenv("USER"))
+		} else {
+			p, err = repo.Retire(ctx, args[0], version, os.Getenv("USER"))
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ %s v%d is %s\n", p.Code, p.Version, p.Status)
+
+	default:
+		return fmt.Errorf("unknown policy-pack action %q (expected list, show, versions, draft, activate or retire)", action)
+	}
+	return nil
+}
+
+func printPolicyPack(p model.PolicyPack) {
+	fmt.Printf("📜 %s v%d - %s (%s)\n", p.Code, p.Version, p.Name, p.Status)
+	fmt.Printf("   Jurisdiction: %s\n", p.Jurisdiction)
+	if p.RegulationCode != "" {
+		fmt.Printf("   Regulation:   %s\n", p.RegulationCode)
+	}
+	if p.PolicyCode != "" {
+		fmt.Printf("   Policy:       %s\n", p.PolicyCode)
+	}
+	if len(p.Obligations) > 0 {
+		fmt.Printf("   Obligations:  %s\n", strings.Join(p.Obligations, ", "))
+	}
+	if p.Description != "" {
+		fmt.Printf("   %s\n", p.Description)
+	}
+
+	fmt.Println("\n  Documents:")
+	for _, d := range p.Documents {
+		required := "optional"
+		if d.Mandatory {
+			required = "mandatory"
+		}
+		fmt.Printf("    %-22s %-9s %s\n", d.Code, required, d.Name)
+	}
+	fmt.Println("\n  Attributes:")
+	for _, a := range p.Attributes {
+		required := "optional"
+		if a.Mandatory {
+			required = "mandatory"
+		}
+		fmt.Printf("    %-26s %s\n", a.Code, required)
+	}
+	if len(p.Thresholds) > 0 {
+		fmt.Println("\n  Thresholds:")
+		for _, t := range p.Thresholds {
+			fmt.Printf("    %-26s %g %s  %s\n", t.Name, t.Value, t.Unit, t.Description)
+		}
+	}
+	fmt.Println()
+}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/adamtc007/KYC-DSL/internal/clustering"
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+// ClusterOptions overrides the clustering configuration; zero values keep it
+type ClusterOptions struct {
+	K         int
+	MaxK      int
+	LLMLabels bool
+	// DryRun prints the clusters without replacing the stored ones
+	DryRun bool
+}
+
+// RunRecomputeClustersCommand clusters the attribute embeddings and replaces
+// the automatic clusters in rag_clusters
+func RunRecomputeClustersCommand(opts ClusterOptions) error {
+	cfg := config.Current().Clustering
+	if opts.K > 0 {
+		cfg.K = opts.K
+	}
+	if opts.MaxK > 0 {
+		cfg.MaxK = opts.MaxK
+	}
+	if opts.LLMLabels {
+		cfg.LLMLabels = true
+	}
+
+	fmt.Println("🧩 Recompute Attribute Clusters")
+	fmt.Println("================================================")
+	if cfg.K > 0 {
+		fmt.Printf("⚙️  k = %d\n", cfg.K)
+	} else {
+		fmt.Printf("⚙️  k chosen by silhouette in [2, %d]\n", cfg.MaxK)
+	}
+
+	db, err := storage.ConnectPostgres()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	start := time.Now()
+	job := clustering.NewJob(db, cfg)
+	var plan *clustering.Plan
+	if opts.DryRun {
+		plan, err = job.Compute(ctx)
+	} else {
+		plan, err = job.RunOnce(ctx, "kycctl")
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("📊 Attributes: %d\n", plan.Attributes)
+	fmt.Printf("🧮 Clusters:   %d (silhouette %.3f)\n", plan.K, plan.Silhouette)
+	fmt.Printf("🏷️  Labels:     %s\n", plan.Labeler)
+	fmt.Println()
+	for _, c := range plan.Clusters {
+		reused := ""
+		if c.Reused {
+			reused = " (kept code)"
+		}
+		fmt.Printf("  %s%s\n", c.Code, reused)
+		fmt.Printf("     %s — %d members, quality %.3f, silhouette %.3f\n", c.Name, len(c.Members), c.Quality, c.Silhouette)
+		fmt.Printf("     %s\n", truncate(strings.Join(c.Members, ", "), 100))
+	}
+	fmt.Println()
+
+	if opts.DryRun {
+		fmt.Println("✅ Dry run only, clusters not stored")
+		return nil
+	}
+	fmt.Printf("💾 Run #%d replaced %d automatic clusters with %d\n", plan.RunID, plan.Replaced, len(plan.Clusters))
+	fmt.Printf("⏱️  Total time: %s\n", time.Since(start).Round(time.Millisecond))
+	return nil
+}
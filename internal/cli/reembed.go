@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+	"time"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/ontology"
+	"github.com/adamtc007/KYC-DSL/internal/rag"
+	"github.com/adamtc007/KYC-DSL/internal/reembed"
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+// ReembedOptions configures kycctl reembed
+type ReembedOptions struct {
+	// Stale re-embeds entries whose embedding text changed
+	Stale bool
+	// Kind is attributes, documents, regulations or all
+	Kind string
+	// BatchSize caps the entries re-embedded per kind and run (0 = all)
+	BatchSize int
+	// Unversioned also re-embeds embeddings without a recorded content hash
+	Unversioned bool
+	DryRun      bool
+}
+
+// RunReembedCommand re-generates the embeddings whose embedding text no
+// longer matches the content hash recorded when they were generated
+func RunReembedCommand(opts ReembedOptions) error {
+	if !opts.Stale {
+		return fmt.Errorf("reembed requires --stale")
+	}
+
+	fmt.Println("♻️  Stale Embedding Re-generation")
+	fmt.Println("================================================")
+
+	kinds := ontology.BackfillKinds
+	if opts.Kind != "" && opts.Kind != "all" {
+		if !slices.Contains(ontology.BackfillKinds, opts.Kind) {
+			return fmt.Errorf("unknown kind %q (expected attributes, documents, regulations or all)", opts.Kind)
+		}
+		kinds = []string{opts.Kind}
+	}
+
+	db, err := storage.ConnectPostgres()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var embedder *rag.Embedder
+	if !opts.DryRun {
+		embedder = rag.NewEmbedder()
+	}
+	worker := reembed.NewWorker(db, embedder, config.Current().Reembedding).
+		WithDualWrite(config.Current().EmbeddingSpaces.DualWrite)
+
+	start := time.Now()
+	summary, err := worker.RunOnce(ctx, reembed.Options{
+		Kinds:       kinds,
+		BatchSize:   opts.BatchSize,
+		Unversioned: opts.Unversioned,
+		DryRun:      opts.DryRun,
+		Progress: func(kind string, t model.EmbeddingTarget, action string, err error) {
+			verb := "re-embedded"
+			if action == reembed.ActionAdopt {
+				verb = "hash recorded (predates versioning)"
+			}
+			switch {
+			case err != nil:
+				fmt.Printf("  ❌ %-12s %s: %v\n", kind, t.Code, err)
+			case opts.DryRun:
+				fmt.Printf("  🔍 %-12s %s: would be %s\n", kind, t.Code, verb)
+			default:
+				fmt.Printf("  ✅ %-12s %s: %s\n", kind, t.Code, verb)
+			}
+		},
+	})
+
+	fmt.Println("\n================================================")
+	fmt.Printf("📊 %d stale, %d re-embedded, %d adopted, %d failed, %d left for the next run\n",
+		summary.Stale, summary.Reembedded, summary.Adopted, summary.Failed, summary.Remaining)
+	if summary.DualWritten > 0 {
+		fmt.Printf("🧭 %d embeddings also written to %v\n", summary.DualWritten, config.Current().EmbeddingSpaces.DualWrite)
+	}
+	fmt.Printf("⏱️  Total time: %s\n", time.Since(start).Round(time.Millisecond))
+	if opts.DryRun {
+		fmt.Println("🔍 Dry run: nothing was changed")
+	}
+	return err
+}
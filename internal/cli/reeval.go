@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/casedict"
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/reeval"
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+// RunReevalCommand shows the re-evaluation queue, drains it now, records a
+// new case attribute value and queues its dependents, or shows (and
+// materializes derived results into) a case data dictionary
+func RunReevalCommand(action string, args []string) error {
+	db, err := storage.ConnectPostgres()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+
+	switch action {
+	case "queue":
+		items, err := reeval.NewQueue(db).List(ctx, reeval.StatusPending, 100)
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			fmt.Println("✅ Re-evaluation queue is empty")
+			return nil
+		}
+		fmt.Printf("🔁 Pending re-evaluations: %d\n\n", len(items))
+		for _, it := range items {
+			caseName := it.CaseName
+			if caseName == "" {
+				caseName = "(all cases)"
+			}
+			fmt.Printf("  #%-5d %s  %-30s %-28s %s %s\n", it.ID, it.EnqueuedAt.Format("2006-01-02 15:04"),
+				caseName, it.DerivedCode, it.Reason, it.Ref)
+			if it.LastError != "" {
+				fmt.Printf("         ⚠️  attempt %d failed: %s\n", it.Attempts, it.LastError)
+			}
+		}
+		fmt.Println()
+
+	case "run":
+		fmt.Println("🔁 Re-evaluating queued derived attributes...")
+		summary, err := reeval.NewWorker(db, config.Current().Reevaluation).RunOnce(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ Processed %d items: %d evaluations, %d changed, %d failed, %d dependents queued\n",
+			summary.Items, summary.Evaluations, summary.Changed, summary.Failed, summary.CascadeQueued)
+
+	case "set":
+		if len(args) < 3 {
+			return fmt.Errorf("reeval set requires a case, an attribute and a value")
+		}
+		// Values are JSON when they parse as JSON (true, 42, ["IR","KP"]), strings otherwise
+		var value any = args[2]
+		var parsed any
+		if err := json.Unmarshal([]byte(args[2]), &parsed); err == nil {
+			value = parsed
+		}
+		attribute := strings.ToUpper(args[1])
+		queued, err := reeval.NewQueue(db).AttributeChanged(ctx, args[0], attribute, value)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ %s of %s set to %v: %d derived attributes queued\n", attribute, args[0], value, queued)
+
+	case "dictionary":
+		if len(args) < 1 {
+			return fmt.Errorf("reeval dictionary requires a case")
+		}
+		repo := casedict.NewRepo(db)
+		if len(args) > 1 && args[1] == "--materialize" {
+			derived, err := repo.MaterializeDerived(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Printf("✅ Materialized %d derived values into the data dictionary of %s\n\n", len(derived), args[0])
+		}
+		entries, err := repo.Entries(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			fmt.Printf("ℹ️  No data dictionary values for %s\n", args[0])
+			return nil
+		}
+		fmt.Printf("📒 Data dictionary of %s:\n\n", args[0])
+		for _, e := range entries {
+			origin := "captured"
+			if e.Derived && e.EvaluationID != nil {
+				origin = fmt.Sprintf("derived (evaluation #%d)", *e.EvaluationID)
+			} else if e.Derived {
+				origin = "derived"
+			}
+			fmt.Printf("  %-30s %-12s %s\n", e.AttributeCode, e.Value, origin)
+		}
+		fmt.Println()
+
+	default:
+		return fmt.Errorf("unknown reeval action %q (expected queue, run, set or dictionary)", action)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/regchange"
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+// RunRegulationCommand updates regulation records, lists their versions and
+// reports what a change affects
+func RunRegulationCommand(action string, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("regulation %s requires a regulation code", action)
+	}
+	code := args[0]
+
+	db, err := storage.ConnectPostgres()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := regchange.NewRepo(db)
+
+	switch action {
+	case "update":
+		var u model.RegulationUpdate
+		for _, arg := range args[1:] {
+			name, value, _ := strings.Cut(strings.TrimPrefix(arg, "--"), "=")
+			switch name {
+			case "name":
+				u.Name = &value
+			case "title":
+				u.Title = &value
+			case "citation":
+				u.Citation = &value
+			case "summary":
+				u.Summary = &value
+			case "summary-file":
+				data, err := os.ReadFile(value)
+				if err != nil {
+					return fmt.Errorf("failed to read %s: %w", value, err)
+				}
+				summary := strings.TrimSpace(string(data))
+				u.Summary = &summary
+			case "description":
+				u.Description = &value
+			case "from", "to":
+				t, err := time.Parse("2006-01-02", value)
+				if err != nil {
+					return fmt.Errorf("--%s must be YYYY-MM-DD", name)
+				}
+				if name == "from" {
+					u.EffectiveFrom = &t
+				} else {
+					u.EffectiveTo = &t
+				}
+			case "note":
+				u.Note = value
+			default:
+				return fmt.Errorf("unknown regulation update option %q", arg)
+			}
+		}
+		v, err := repo.Update(ctx, code, u, os.Getenv("USER"))
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ %s is now version %d\n", v.RegulationCode, v.Version)
+		fmt.Printf("   Impact: kycctl regulation impact %s\n", v.RegulationCode)
+
+	case "versions":
+		versions, err := repo.Versions(ctx, code)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("⚖️  %s versions: %d\n\n", code, len(versions))
+		for _, v := range versions {
+			fmt.Printf("  v%-3d %s  %-12s %s\n", v.Version, v.ChangedAt.Format("2006-01-02 15:04"), v.ChangedBy, v.Citation)
+			if v.ChangeNote != "" {
+				fmt.Printf("       %s\n", v.ChangeNote)
+			}
+		}
+		fmt.Println()
+
+	case "impact":
+		version := 0
+		for _, arg := range args[1:] {
+			if strings.HasPrefix(arg, "--version=") {
+				n, err := strconv.Atoi(strings.TrimPrefix(arg, "--version="))
+				if err != nil || n < 1 {
+					return fmt.Errorf("--version must be a positive integer")
+				}
+				version = n
+			}
+		}
+		impact, err := repo.Impact(ctx, code, version)
+		if err != nil {
+			return err
+		}
+		printRegulationImpact(impact)
+
+	default:
+		return fmt.Errorf("unknown regulation action %q (expected update, versions or impact)", action)
+	}
+	return nil
+}
+
+func printRegulationImpact(impact *regchange.Impact) {
+	fmt.Printf("⚖️  %s v%d - %s\n", impact.RegulationCode, impact.Version, impact.Name)
+	fmt.Printf("   Changed %s", impact.ChangedAt.Format("2006-01-02 15:04"))
+	if impact.ChangedBy != "" {
+		fmt.Printf(" by %s", impact.ChangedBy)
+	}
+	fmt.Println()
+	if impact.ChangeNote != "" {
+		fmt.Printf("   %s\n", impact.ChangeNote)
+	}
+	for _, c := range impact.Changes {
+		fmt.Printf("   %-14s %q → %q\n", c.Field, truncate(c.From, 60), truncate(c.To, 60))
+	}
+
+	fmt.Printf("\n  Policy packs: %d\n", len(impact.PolicyPacks))
+	for _, p := range impact.PolicyPacks {
+		fmt.Printf("    %s v%d (%s) %s\n", p.Code, p.Version, p.Jurisdiction, p.PolicyCode)
+	}
+	fmt.Printf("\n  Documents: %d\n", len(impact.Documents))
+	for _, d := range impact.Documents {
+		fmt.Printf("    %-22s %s\n", d.Code, strings.Join(d.Via, ", "))
+	}
+	fmt.Printf("\n  Attributes: %d\n", len(impact.Attributes))
+	for _, a := range impact.Attributes {
+		fmt.Printf("    %-30s %s\n", a.Code, strings.Join(a.Via, ", "))
+	}
+	fmt.Printf("\n  Clusters: %d\n", len(impact.Clusters))
+	for _, c := range impact.Clusters {
+		fmt.Printf("    %-24s %s\n", c.Code, strings.Join(c.Attributes, ", "))
+	}
+	fmt.Printf("\n  Open cases to review: %d\n", len(impact.Cases))
+	for _, c := range impact.Cases {
+		fmt.Printf("    %-30s v%-3d %-10s %s\n", c.Name, c.Version, c.Status, strings.Join(c.Reasons, ", "))
+	}
+	fmt.Println()
+}
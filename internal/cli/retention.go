@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/retention"
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+// RunRetentionCommand shows the retention policies, applies them now or
+// lists past runs
+func RunRetentionCommand(action string, args []string) error {
+	cfg := config.Current().Retention
+
+	if action == "" || action == "policies" {
+		fmt.Println("🗄️  Retention policies")
+		for _, t := range retention.Tables {
+			fmt.Printf("  %-22s %s\n", t.Name, retention.Describe(cfg, t))
+		}
+		if cfg.Archive.Enabled {
+			where := cfg.Archive.Dir
+			if cfg.Archive.Driver != "local" {
+				where = cfg.Archive.S3.Bucket
+			}
+			fmt.Printf("\n  Archive: %s %s/%s\n", cfg.Archive.Driver, where, cfg.Archive.Prefix)
+		}
+		fmt.Println()
+		return nil
+	}
+
+	table, dryRun, limit := "", cfg.DryRun, 20
+	for _, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "--table="):
+			table = strings.TrimPrefix(arg, "--table=")
+		case arg == "--dry-run":
+			dryRun = true
+		case strings.HasPrefix(arg, "--limit="):
+			n, err := strconv.Atoi(strings.TrimPrefix(arg, "--limit="))
+			if err != nil || n < 1 {
+				return fmt.Errorf("--limit must be a positive integer")
+			}
+			limit = n
+		default:
+			return fmt.Errorf("unknown retention option %q", arg)
+		}
+	}
+
+	db, err := storage.ConnectPostgres()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	job, err := retention.NewJob(db, cfg)
+	if err != nil {
+		return err
+	}
+
+	switch action {
+	case "run":
+		runs, err := job.RunOnce(ctx, table, dryRun, "kycctl")
+		for _, r := range runs {
+			printRetentionRun(r)
+		}
+		if len(runs) == 0 && err == nil {
+			fmt.Println("Nothing to do: no table has a max_age (retention.policies)")
+		}
+		return err
+
+	case "runs":
+		runs, err := job.Runs(ctx, table, limit)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("🗄️  Retention runs: %d\n\n", len(runs))
+		for _, r := range runs {
+			mode := ""
+			if r.DryRun {
+				mode = " (dry run)"
+			}
+			fmt.Printf("  #%-5d %s  %-20s %-9s matched %d, archived %d, deleted %d%s\n",
+				r.ID, r.StartedAt.Format("2006-01-02 15:04"), r.Table, r.Status, r.Matched, r.Archived, r.Deleted, mode)
+			if r.Error != "" {
+				fmt.Printf("         %s\n", r.Error)
+			}
+		}
+		fmt.Println()
+
+	default:
+		return fmt.Errorf("unknown retention action %q (expected policies, run or runs)", action)
+	}
+	return nil
+}
+
+func printRetentionRun(r retention.Run) {
+	if r.DryRun {
+		fmt.Printf("🔍 %s: %d rows created before %s would be archived and deleted", r.Table, r.Matched, r.Cutoff.Format("2006-01-02 15:04"))
+	} else {
+		fmt.Printf("🗄️  %s: archived %d and deleted %d rows created before %s", r.Table, r.Archived, r.Deleted, r.Cutoff.Format("2006-01-02 15:04"))
+	}
+	if r.Oldest != nil {
+		fmt.Printf(" (oldest %s)", r.Oldest.Format("2006-01-02"))
+	}
+	fmt.Println()
+	for _, key := range r.ArchiveKeys {
+		fmt.Printf("   %s\n", key)
+	}
+	if r.Error != "" {
+		fmt.Printf("   ❌ %s\n", r.Error)
+	}
+}
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/risk"
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+// RunScoreCommand scores the customer risk of a case and prints the score
+// with its factor breakdown: kycctl score CASE [--latest]. With --latest
+// the stored score of the latest scored version is printed instead.
+func RunScoreCommand(caseName string, args []string) error {
+	latest := false
+	for _, arg := range args {
+		switch arg {
+		case "--latest":
+			latest = true
+		default:
+			return fmt.Errorf("unknown score argument %q", arg)
+		}
+	}
+
+	db, err := storage.ConnectPostgres()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	scorer := risk.NewScorer(db, config.Current().Risk)
+	var score *model.RiskScore
+	if latest {
+		score, err = scorer.Latest(commandContext(), caseName)
+	} else {
+		score, err = scorer.Score(commandContext(), caseName)
+	}
+	if err != nil {
+		return err
+	}
+
+	version := "-"
+	if score.CaseVersion > 0 {
+		version = fmt.Sprintf("v%d", score.CaseVersion)
+	}
+	fmt.Printf("⚖️  Customer risk of %s (%s): %.2f / 100 → %s\n", score.CaseName, version, score.Score, score.Rating)
+	fmt.Printf("   scored %s", score.ScoredAt.Format("2006-01-02 15:04:05"))
+	if score.Actor != "" {
+		fmt.Printf(" by %s", score.Actor)
+	}
+	fmt.Println()
+	fmt.Println()
+	fmt.Printf("  %-30s %-10s %7s %8s  %s\n", "FACTOR", "VALUE", "WEIGHT", "POINTS", "SOURCE")
+	for _, f := range score.Factors {
+		value := f.Value
+		if value == "" {
+			value = "-"
+		}
+		source := f.Source
+		if f.EvaluationID != nil {
+			source = fmt.Sprintf("%s #%d", source, *f.EvaluationID)
+		}
+		fmt.Printf("  %-30s %-10s %7.2f %8.2f  %s\n", f.Attribute, value, f.Weight, f.Contribution, source)
+		if f.Note != "" {
+			fmt.Printf("  %-30s ⚠️  %s\n", "", f.Note)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/screening"
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+// RunScreenCommand screens an entity for sanctions, PEPs or adverse media
+// and prints the hits: kycctl screen ENTITY-ID [--kind=KIND] [--case=NAME]
+// [--ubos]. With --case the outcome is written to the case.
+func RunScreenCommand(entityID string, args []string) error {
+	caseName := ""
+	includeUBOs := false
+	kind := screening.KindSanctions
+	for _, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "--kind="):
+			k, err := screening.ParseKind(strings.TrimPrefix(arg, "--kind="))
+			if err != nil {
+				return err
+			}
+			kind = k
+		case strings.HasPrefix(arg, "--case="):
+			caseName = strings.TrimPrefix(arg, "--case=")
+		case arg == "--ubos":
+			includeUBOs = true
+		default:
+			return fmt.Errorf("unknown screen argument %q", arg)
+		}
+	}
+
+	db, err := storage.ConnectPostgres()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	result, err := screening.NewScreener(db, config.Current().Screening).ScreenEntity(commandContext(), kind, entityID, caseName, includeUBOs)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🛡️  %s screening #%d of %s: %s\n", strings.ReplaceAll(result.Kind, "_", " "), result.ID, result.EntityName, result.Status)
+	fmt.Printf("   %d subject(s) screened with %s, %d hit(s)\n", result.Subjects, strings.Join(result.Providers, ", "), result.HitCount)
+	if result.Errors != "" {
+		fmt.Printf("   ⚠️  %s\n", result.Errors)
+	}
+	if caseName != "" {
+		fmt.Printf("   Outcome recorded on %s\n", caseName)
+	}
+	if len(result.Hits) > 0 {
+		fmt.Println()
+	}
+	for _, h := range result.Hits {
+		fmt.Printf("  %.2f  %-30s ≈ %-30s %s %s\n", h.Score, h.SubjectName, h.MatchedName, h.Provider, h.Reference)
+		if h.Detail != "" {
+			fmt.Printf("        %s\n", h.Detail)
+		}
+		if h.URL != "" {
+			fmt.Printf("        %s\n", h.URL)
+		}
+	}
+	return nil
+}
+
+// RunScreeningIngestCommand loads a sanctions list file into the local
+// screening lists: kycctl screening ingest ofac SDN.CSV [ALT.CSV] or
+// kycctl screening ingest eu FILE.XML
+func RunScreeningIngestCommand(format string, files []string) error {
+	if len(files) == 0 {
+		return fmt.Errorf("screening ingest %s requires a list file", format)
+	}
+	open := func(path string) (*os.File, error) {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", path, err)
+		}
+		return f, nil
+	}
+
+	var listName string
+	var entries []screening.ListEntry
+	switch format {
+	case "ofac":
+		sdn, err := open(files[0])
+		if err != nil {
+			return err
+		}
+		defer sdn.Close()
+		var alt io.Reader
+		if len(files) > 1 {
+			f, err := open(files[1])
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			alt = f
+		}
+		listName = screening.ListOFACSDN
+		if entries, err = screening.ParseOFACSDN(sdn, alt); err != nil {
+			return err
+		}
+	case "eu":
+		f, err := open(files[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		listName = screening.ListEUConsolidated
+		if entries, err = screening.ParseEUConsolidated(f); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown list format %q (expected ofac or eu)", format)
+	}
+
+	db, err := storage.ConnectPostgres()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	ingest, err := screening.Ingest(commandContext(), db, listName, screening.KindSanctions, entries,
+		strings.Join(files, ", "), os.Getenv("USER"))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ %s: %d entries (%d added, %d updated, %d removed)\n",
+		ingest.ListName, ingest.Entries, ingest.Added, ingest.Updated, ingest.Removed)
+	return nil
+}
@@ -5,13 +5,15 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/adamtc007/KYC-DSL/internal/model"
 	"github.com/adamtc007/KYC-DSL/internal/ontology"
 	"github.com/adamtc007/KYC-DSL/internal/rag"
 	"github.com/adamtc007/KYC-DSL/internal/storage"
 )
 
-// RunSearchMetadataCommand performs semantic search on attribute metadata
-func RunSearchMetadataCommand(query string, limit int) error {
+// RunSearchMetadataCommand performs semantic search on attribute metadata,
+// in the named embedding space when space is set
+func RunSearchMetadataCommand(query string, limit int, space string) error {
 	if query == "" {
 		return fmt.Errorf("search query cannot be empty")
 	}
@@ -35,6 +37,19 @@ func RunSearchMetadataCommand(query string, limit int) error {
 	embedder := rag.NewEmbedder()
 	ctx := context.Background()
 
+	var secondary *model.EmbeddingSpace
+	if space != "" {
+		s, err := ontology.NewSpaceRepo(db).Get(ctx, space)
+		if err != nil {
+			return err
+		}
+		if !s.IsPrimary {
+			secondary = s
+			embedder = embedder.ForSpace(s.Model, s.Dimensions)
+		}
+		fmt.Printf("🧭 Embedding space: %s (%s, %d dimensions)\n", s.Name, s.Model, s.Dimensions)
+	}
+
 	// Generate embedding for the query
 	fmt.Println("\n⚡ Generating query embedding...")
 	queryEmbedding, err := embedder.GenerateEmbeddingFromText(ctx, query)
@@ -44,7 +59,12 @@ func RunSearchMetadataCommand(query string, limit int) error {
 
 	// Perform vector search
 	fmt.Printf("🔎 Searching for top %d matches...\n\n", limit)
-	results, err := repo.SearchByVector(ctx, queryEmbedding, limit)
+	var results []model.AttributeSearchResult
+	if secondary != nil {
+		results, err = ontology.NewSpaceRepo(db).SearchAttributes(ctx, *secondary, queryEmbedding, limit)
+	} else {
+		results, err = repo.SearchByVector(ctx, queryEmbedding, limit)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to search: %w", err)
 	}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/dataservice"
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+// RunSearchPlanCommand prints the query plan of an entity or attribute
+// search, to check that it uses the trigram and full-text indexes
+func RunSearchPlanCommand(args []string) error {
+	threshold, analyze := 0.0, false
+	var positional []string
+	for _, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "--threshold="):
+			t, err := strconv.ParseFloat(strings.TrimPrefix(arg, "--threshold="), 64)
+			if err != nil {
+				return fmt.Errorf("invalid --threshold: %w", err)
+			}
+			threshold = t
+		case arg == "--analyze":
+			analyze = true
+		default:
+			positional = append(positional, arg)
+		}
+	}
+	if len(positional) < 2 {
+		return fmt.Errorf("search-plan requires entities or attributes and a query")
+	}
+
+	db, err := storage.ConnectPostgres()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	query := strings.Join(positional[1:], " ")
+	plan, err := dataservice.ExplainSearch(context.Background(), db, positional[0], query, threshold, analyze)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🔍 Query plan: %s search for %q\n\n", positional[0], query)
+	usesIndex := false
+	for _, line := range plan {
+		fmt.Println("  " + line)
+		if strings.Contains(line, "Bitmap Index Scan") {
+			usesIndex = true
+		}
+	}
+	fmt.Println()
+	if usesIndex {
+		fmt.Println("✅ Search uses the trigram/full-text indexes")
+	} else {
+		fmt.Println("⚠️  No index scan: run `kycctl migrate up`, or the table is small enough that Postgres prefers a scan")
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/ontology"
+	"github.com/adamtc007/KYC-DSL/internal/rag"
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+// RunSearchSectionsCommand performs semantic search on document sections and
+// prints each snippet with its document and regulation; with a case name it
+// searches the text extracted from the case's evidence
+func RunSearchSectionsCommand(query string, limit int, caseName string) error {
+	if query == "" {
+		return fmt.Errorf("search query cannot be empty")
+	}
+
+	if limit <= 0 {
+		limit = 10
+	}
+
+	fmt.Printf("🔍 Section Search: \"%s\"\n", query)
+	fmt.Println("================================================")
+
+	db, err := storage.ConnectPostgres()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	repo := ontology.NewEnhancementsRepo(db)
+	embedder := rag.NewEmbedder()
+	ctx := context.Background()
+
+	fmt.Println("\n⚡ Generating query embedding...")
+	queryEmbedding, err := embedder.GenerateEmbeddingFromText(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to generate query embedding: %w", err)
+	}
+
+	fmt.Printf("🔎 Searching for top %d sections...\n\n", limit)
+	results, err := repo.SearchSectionsWithContext(ctx, queryEmbedding, limit, caseName)
+	if err != nil {
+		return fmt.Errorf("failed to search: %w", err)
+	}
+
+	if len(results) == 0 {
+		fmt.Println("❌ No results found.")
+		return nil
+	}
+
+	fmt.Printf("📊 Found %d sections:\n\n", len(results))
+
+	for i, result := range results {
+		fmt.Printf("─────────────────────────────────────────────────\n")
+		fmt.Printf("Rank #%d  (similarity %.4f)\n", i+1, result.SimilarityScore)
+		fmt.Printf("─────────────────────────────────────────────────\n")
+		fmt.Printf("📄 Document:        %s - %s\n", result.DocumentCode, result.DocumentTitle)
+		if result.EvidenceID != 0 {
+			fmt.Printf("📎 Evidence:        #%d (%s)\n", result.EvidenceID, result.CaseName)
+		}
+
+		section := strings.TrimSpace(result.SectionNumber + " " + result.SectionTitle)
+		if section != "" {
+			fmt.Printf("📑 Section:         %s\n", section)
+		}
+		if result.PageNumber > 0 {
+			fmt.Printf("📃 Page:            %d\n", result.PageNumber)
+		}
+		if result.RegulationCode != "" {
+			fmt.Printf("⚖️  Regulation:      %s - %s\n", result.RegulationCode, result.RegulationTitle)
+		}
+		if result.Jurisdiction != "" {
+			fmt.Printf("🌍 Jurisdiction:    %s\n", result.Jurisdiction)
+		}
+
+		excerpt := strings.TrimSpace(result.TextExcerpt)
+		if len(excerpt) > 300 {
+			excerpt = excerpt[:300] + "..."
+		}
+		fmt.Printf("📖 Excerpt:         %s\n\n", excerpt)
+	}
+
+	return nil
+}
@@ -1,7 +1,7 @@
 package cli
 
 import (
-	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -11,8 +11,9 @@ import (
 	"github.com/adamtc007/KYC-DSL/internal/storage"
 )
 
-// RunSeedMetadataCommand seeds attribute metadata with embeddings
-func RunSeedMetadataCommand() error {
+// RunSeedMetadataCommand seeds attribute metadata with embeddings, stopping
+// before the embedding spend would exceed maxSpend USD (0 = no limit)
+func RunSeedMetadataCommand(maxSpend float64) error {
 	fmt.Println("🌱 Seeding Attribute Metadata with Embeddings...")
 	fmt.Println("================================================")
 
@@ -26,7 +27,7 @@ func RunSeedMetadataCommand() error {
 	// Initialize repositories and embedder
 	repo := ontology.NewMetadataRepo(db)
 	embedder := rag.NewEmbedder()
-	ctx := context.Background()
+	ctx := commandContext()
 
 	// Sample metadata to seed
 	sampleMetadata := []model.AttributeMetadata{
@@ -60,7 +61,7 @@ func RunSeedMetadataCommand() error {
 		{
 			AttributeCode:       "UBO_OWNERSHIP_PERCENT",
 			Synonyms:            []string{"Ownership Percentage", "Beneficial Ownership %", "Control Percentage"},
-			DataType:            "float",
+			DataType:            "percentage",
 			RiskLevel:           "CRITICAL",
 			RegulatoryCitations: []string{"AMLD5 Article 3(6)", "FATF Recommendation 10"},
 			ExampleValues:       []string{"26.5", "50.0", "100.0"},
@@ -212,6 +213,18 @@ func RunSeedMetadataCommand() error {
 		},
 	}
 
+	var estimatedTokens int64
+	for _, m := range sampleMetadata {
+		estimatedTokens += int64(rag.EstimateTokens(m.ToEmbeddingText()))
+	}
+	fmt.Printf("\n💰 Estimated cost: ~$%.4f for ~%d tokens with %s\n",
+		rag.EmbeddingCost(embedder.GetModel(), estimatedTokens), estimatedTokens, embedder.GetModel())
+	if maxSpend > 0 {
+		fmt.Printf("🛑 Spend limit: $%.4f\n", maxSpend)
+	}
+	budget := rag.NewBudget(embedder.GetModel(), maxSpend)
+	embedder.WithBudget(budget)
+
 	fmt.Printf("\n📊 Processing %d attributes...\n\n", len(sampleMetadata))
 
 	successCount := 0
@@ -223,6 +236,10 @@ func RunSeedMetadataCommand() error {
 
 		// Generate embedding
 		embedding, err := embedder.GenerateEmbedding(ctx, metadata)
+		if errors.Is(err, rag.ErrSpendLimit) {
+			fmt.Printf("  🛑 %v; stopping\n", err)
+			break
+		}
 		if err != nil {
 			fmt.Printf("  ❌ Failed to generate embedding: %v\n", err)
 			errorCount++
@@ -257,6 +274,7 @@ func RunSeedMetadataCommand() error {
 	fmt.Printf("✅ Successfully seeded: %d attributes\n", successCount)
 	fmt.Printf("❌ Failed: %d attributes\n", errorCount)
 	fmt.Printf("⏱️  Total time: %s\n", elapsed.Round(time.Millisecond))
+	fmt.Printf("💰 Spent: $%.4f (%d tokens)\n", budget.Spent(), budget.Tokens())
 	fmt.Printf("🚀 Average time per attribute: %s\n", (elapsed / time.Duration(len(sampleMetadata))).Round(time.Millisecond))
 
 	// Get stats
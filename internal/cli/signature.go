@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/actor"
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/engine"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/signing"
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+// RunSignatureCommand prints the signing public key, signs the approved
+// version of a case or verifies the signatures of a case
+func RunSignatureCommand(action string, args []string) error {
+	cfg := config.Current().Signing
+
+	version := 0
+	var keyFiles, positional []string
+	for _, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "--version="):
+			n, err := strconv.Atoi(strings.TrimPrefix(arg, "--version="))
+			if err != nil || n < 1 {
+				return fmt.Errorf("--version must be a positive integer")
+			}
+			version = n
+		case strings.HasPrefix(arg, "--key="):
+			keyFiles = append(keyFiles, strings.TrimPrefix(arg, "--key="))
+		case strings.HasPrefix(arg, "--"):
+			return fmt.Errorf("unknown signature option %q", arg)
+		default:
+			positional = append(positional, arg)
+		}
+	}
+
+	if action == "public-key" {
+		signer, err := signing.New(cfg)
+		if err != nil {
+			return err
+		}
+		pem, err := signing.EncodePublicKey(signer.PublicKey())
+		if err != nil {
+			return err
+		}
+		fp, err := signing.Fingerprint(signer.PublicKey())
+		if err != nil {
+			return err
+		}
+		fmt.Printf("🔑 Key %s (%s)\n   Fingerprint: %s\n\n%s", signer.KeyID(), signer.Algorithm(), fp, pem)
+		return nil
+	}
+
+	if action != "sign" && action != "verify" {
+		return fmt.Errorf("unknown signature action %q (expected public-key, sign or verify)", action)
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("signature %s requires a case name", action)
+	}
+	caseName := positional[0]
+
+	db, err := storage.ConnectPostgres()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+	ctx := context.Background()
+
+	if action == "sign" {
+		// Approvals are signed as they happen; this signs cases approved
+		// before signing was enabled
+		status, err := engine.NewLifecycle(db).Status(ctx, caseName)
+		if err != nil {
+			return err
+		}
+		if status != model.CaseApproved {
+			return fmt.Errorf("case %s is %s; only approved cases are signed", caseName, status)
+		}
+		signer, err := signing.New(cfg)
+		if err != nil {
+			return err
+		}
+		s, err := signing.SignLatestVersion(ctx, db, signer, caseName, 0, actor.CLI().Name)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✍️  Signed version %d of %s with key %s (%s)\n", s.Version, s.CaseName, s.KeyID, s.Algorithm)
+		return nil
+	}
+
+	trusted, err := signing.TrustedKeys(cfg)
+	if err != nil {
+		return err
+	}
+	for _, path := range keyFiles {
+		pub, err := signing.LoadPublicKey(path)
+		if err != nil {
+			return err
+		}
+		fp, err := signing.Fingerprint(pub)
+		if err != nil {
+			return err
+		}
+		trusted[fp] = true
+	}
+
+	verifications, err := signing.VerifyCase(ctx, db, caseName, version, trusted)
+	if err != nil {
+		return err
+	}
+	failed := 0
+	for _, v := range verifications {
+		s := v.Signature
+		if v.Valid {
+			fmt.Printf("✅ v%-4d signed %s by %s with key %s (%s)\n",
+				s.Version, s.SignedAt.Format("2006-01-02 15:04"), s.SignedBy, s.KeyID, s.Algorithm)
+			continue
+		}
+		failed++
+		fmt.Printf("🚨 v%-4d signed %s by %s with key %s (%s)\n",
+			s.Version, s.SignedAt.Format("2006-01-02 15:04"), s.SignedBy, s.KeyID, s.Algorithm)
+		for _, p := range v.Problems {
+			fmt.Printf("     %s\n", p)
+		}
+	}
+	fmt.Println()
+	if failed > 0 {
+		return fmt.Errorf("%d of %d signature(s) of %s failed verification", failed, len(verifications), caseName)
+	}
+	fmt.Println("All signatures verified")
+	return nil
+}
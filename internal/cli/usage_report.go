@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/ontology"
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+// RunUsageReportCommand prints ontology hot spots, dead entries and embedding refresh priorities
+func RunUsageReportCommand(limit int) error {
+	if limit <= 0 {
+		limit = 20
+	}
+
+	fmt.Println("📊 Ontology Usage Report")
+	fmt.Println("================================================")
+
+	db, err := storage.ConnectPostgres()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	repo := ontology.NewUsageRepo(db)
+	report, err := repo.GetUsageReport(context.Background(), limit, 90*24*time.Hour)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("\n📈 Overview:")
+	fmt.Printf("  %-12s %8s %12s %10s %8s\n", "Type", "Total", "In Cases", "Searched", "Dead")
+	for _, s := range report.Summary {
+		fmt.Printf("  %-12s %8d %12d %10d %8d\n", s.TermType, s.Total, s.Referenced, s.Searched, s.Dead)
+	}
+
+	fmt.Printf("\n🔥 Hot Spots (top %d):\n", limit)
+	printTermUsage(report.HotSpots)
+
+	fmt.Printf("\n💀 Dead Entries (first %d):\n", limit)
+	printTermUsage(report.DeadEntries)
+	if len(report.DeadEntries) > 0 {
+		fmt.Println("   Candidates for dictionary cleanup: not referenced by any case or search")
+	}
+
+	fmt.Println("\n♻️  Embedding Refresh Priorities:")
+	printTermUsage(report.RefreshPriorities)
+
+	fmt.Println("\n================================================")
+	fmt.Println("✅ Usage report generated.")
+
+	return nil
+}
+
+func printTermUsage(terms []model.TermUsage) {
+	if len(terms) == 0 {
+		fmt.Println("   (none)")
+		return
+	}
+	for _, t := range terms {
+		note := ""
+		if t.MissingEmbedding && t.TermType == model.TermTypeAttribute {
+			note = "  [no embedding]"
+		}
+		fmt.Printf("   %-10s %-30s cases=%-4d searches(30d)=%-5d agents=%d%s\n",
+			t.TermType, t.TermCode, t.CaseCount, t.SearchHits30d, t.DistinctAgents, note)
+	}
+}
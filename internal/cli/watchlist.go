@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/screening"
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+	"github.com/adamtc007/KYC-DSL/internal/watchlist"
+)
+
+// RunWatchlistCommand lists watched entities and open alerts, or runs one
+// re-screening pass over the entries that are due
+func RunWatchlistCommand(action string) error {
+	db, err := storage.ConnectPostgres()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := watchlist.NewRepo(db)
+
+	switch action {
+	case "list":
+		entries, err := repo.List(ctx, false)
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			fmt.Println("ℹ️  Watchlist is empty")
+			return nil
+		}
+		fmt.Printf("👁️  Watched entities: %d\n\n", len(entries))
+		fmt.Println("ID   │ Entity                         │ Cadence │ UBOs │ Alerts │ Next screening   │ Reason")
+		fmt.Println("─────┼────────────────────────────────┼─────────┼──────┼────────┼──────────────────┼─────────────────")
+		for _, e := range entries {
+			ubos := "no"
+			if e.IncludeUBOs {
+				ubos = "yes"
+			}
+			fmt.Printf("%-4d │ %-30.30s │ %7s │ %-4s │ %6d │ %s │ %s\n", e.ID, e.EntityName,
+				time.Duration(e.CadenceMinutes)*time.Minute, ubos, e.OpenAlerts,
+				e.NextScreeningAt.Format("2006-01-02 15:04"), e.Reason)
+			if e.LastError != "" {
+				fmt.Printf("     ⚠️  last run failed: %s\n", e.LastError)
+			}
+		}
+		fmt.Println()
+
+	case "alerts":
+		alerts, err := repo.ListAlerts(ctx, model.WatchlistAlertOpen, 100)
+		if err != nil {
+			return err
+		}
+		if len(alerts) == 0 {
+			fmt.Println("✅ No open watchlist alerts")
+			return nil
+		}
+		fmt.Printf("🚨 Open alerts: %d\n\n", len(alerts))
+		for _, a := range alerts {
+			fmt.Printf("  #%-4d %s  [%s] %s\n", a.ID, a.CreatedAt.Format("2006-01-02 15:04"), a.Kind, a.Message)
+		}
+		fmt.Println()
+
+	case "screen":
+		fmt.Println("🔎 Re-screening due watchlist entries...")
+		scheduler := watchlist.NewScheduler(db, config.Current().Watchlist)
+		for _, p := range screening.ExternalProviders(config.Current().Screening) {
+			scheduler.AddProvider(p)
+		}
+		summary, err := scheduler.RunOnce(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ Screened %d entries (%d subjects): %d hits, %d new alerts, %d failed\n",
+			summary.Screened, summary.Subjects, summary.Hits, summary.NewAlerts, summary.Failed)
+
+	default:
+		return fmt.Errorf("unknown watchlist action %q (expected list, alerts or screen)", action)
+	}
+	return nil
+}
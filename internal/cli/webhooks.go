@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/events"
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+// RunWebhooksCommand inspects and drives event deliveries (webhooks, Kafka,
+// NATS): list the delivery log, show the attempts of a delivery, retry a
+// failed one, or publish due deliveries now instead of waiting for
+// kycserver's dispatcher
+func RunWebhooksCommand(action string, args []string) error {
+	var (
+		status string
+		limit  = 20
+		ids    []int64
+	)
+	for _, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "--status="):
+			status = strings.TrimPrefix(arg, "--status=")
+		case strings.HasPrefix(arg, "--limit="):
+			n, err := strconv.Atoi(strings.TrimPrefix(arg, "--limit="))
+			if err != nil || n <= 0 {
+				return fmt.Errorf("invalid --limit: %q", strings.TrimPrefix(arg, "--limit="))
+			}
+			limit = n
+		default:
+			id, err := strconv.ParseInt(arg, 10, 64)
+			if err != nil {
+				return fmt.Errorf("unknown webhooks argument %q", arg)
+			}
+			ids = append(ids, id)
+		}
+	}
+	switch status {
+	case "", events.StatusPending, events.StatusDelivered, events.StatusFailed:
+	default:
+		return fmt.Errorf("invalid --status %q (expected pending, delivered or failed)", status)
+	}
+
+	db, err := storage.ConnectPostgres()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	cfg := config.Current()
+	d := events.NewDispatcher(db, cfg.Webhooks, cfg.Events)
+	defer d.Close() //nolint:errcheck
+
+	switch action {
+	case "deliveries":
+		if !events.Enabled(cfg) {
+			fmt.Println("ℹ️  No event sink configured (webhooks.endpoints, events.sinks); no new events are queued")
+		}
+		deliveries, err := d.List(ctx, status, limit)
+		if err != nil {
+			return err
+		}
+		if len(deliveries) == 0 {
+			fmt.Println("📭 No webhook deliveries")
+			return nil
+		}
+		fmt.Printf("%-8s %-20s %-24s %-8s %-14s %-10s %-8s %s\n", "ID", "EVENT", "SUBJECT", "SINK", "ENDPOINT", "STATUS", "TRIES", "CREATED")
+		for _, dl := range deliveries {
+			fmt.Printf("%-8d %-20s %-24s %-8s %-14s %-10s %-8d %s\n", dl.ID, dl.EventType, truncate(dl.Subject, 24), dl.Sink,
+				truncate(dl.Endpoint, 14), dl.Status, dl.Attempts, dl.CreatedAt.Format("2006-01-02 15:04:05"))
+		}
+		return nil
+
+	case "show":
+		if len(ids) != 1 {
+			return fmt.Errorf("webhooks show requires one delivery id")
+		}
+		attempts, err := d.Attempts(ctx, ids[0])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("📨 Delivery %d: %d attempt(s)\n", ids[0], len(attempts))
+		for _, a := range attempts {
+			outcome := "no response"
+			if a.StatusCode != nil {
+				outcome = fmt.Sprintf("HTTP %d", *a.StatusCode)
+			}
+			if a.Error != nil {
+				outcome += ": " + *a.Error
+			}
+			fmt.Printf("   #%-3d %s  %6s  %s\n", a.Attempt, a.AttemptedAt.Format(time.RFC3339),
+				(time.Duration(a.DurationMS) * time.Millisecond).String(), outcome)
+		}
+		return nil
+
+	case "retry":
+		if len(ids) == 0 {
+			return fmt.Errorf("webhooks retry requires at least one delivery id")
+		}
+		for _, id := range ids {
+			if err := d.Retry(ctx, id); err != nil {
+				return err
+			}
+			fmt.Printf("🔁 Delivery %d is due again\n", id)
+		}
+		return nil
+
+	case "deliver":
+		delivered, failed, err := d.DeliverDue(ctx, limit)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("📨 %d delivered, %d failed attempt(s)\n", delivered, failed)
+		return nil
+
+	default:
+		return fmt.Errorf("unknown webhooks action %q (expected deliveries, show, retry or deliver)", action)
+	}
+}
@@ -0,0 +1,405 @@
+// Package clustering computes semantic clusters of KYC attributes from their
+// embeddings (spherical k-means with a silhouette-chosen k), names them from
+// their members and stores them in rag_clusters alongside the curated ones.
+package clustering
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/modellog"
+)
+
+const (
+	// Algorithm is recorded on every run
+	Algorithm = "kmeans"
+	// seed keeps recomputation deterministic for unchanged embeddings
+	seed = 2273
+	// lockKey serializes runs across kycserver replicas and kycctl
+	lockKey = 0x6b7963_2273
+	// reuseOverlap is the member overlap (Jaccard) above which a new cluster
+	// keeps the code of a cluster from the previous run
+	reuseOverlap = 0.5
+)
+
+// ErrBusy is returned when another run holds the clustering lock
+var ErrBusy = errors.New("another clustering run is in progress")
+
+// Attribute is an embedded attribute to cluster
+type Attribute struct {
+	Code      string
+	Synonyms  string
+	Context   string
+	Embedding []float32
+}
+
+// Cluster is a computed cluster ready to store
+type Cluster struct {
+	Code        string   `json:"cluster_code"`
+	Name        string   `json:"cluster_name"`
+	Description string   `json:"description"`
+	Members     []string `json:"member_attribute_codes"`
+	// Quality is the mean cosine similarity of the members to the centroid
+	Quality    float64   `json:"quality_score"`
+	Silhouette float64   `json:"silhouette"`
+	Keywords   []string  `json:"keywords,omitempty"`
+	Reused     bool      `json:"reused_code"`
+	Centroid   []float32 `json:"-"`
+}
+
+// Plan is the outcome of clustering, before or after it is stored
+type Plan struct {
+	RunID      int       `json:"run_id,omitempty"`
+	Algorithm  string    `json:"algorithm"`
+	K          int       `json:"k"`
+	Attributes int       `json:"attribute_count"`
+	Silhouette float64   `json:"silhouette"`
+	Labeler    string    `json:"labeler"`
+	Clusters   []Cluster `json:"clusters"`
+	// Replaced is the number of automatic clusters of the previous run
+	Replaced int `json:"replaced"`
+}
+
+// Job computes and stores automatic clusters
+type Job struct {
+	db  *sqlx.DB
+	cfg config.ClusteringConfig
+}
+
+// NewJob creates a clustering job
+func NewJob(db *sqlx.DB, cfg config.ClusteringConfig) *Job {
+	return &Job{db: db, cfg: cfg}
+}
+
+// Run recomputes clusters every interval until ctx is cancelled
+func (j *Job) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		plan, err := j.RunOnce(ctx, "scheduler")
+		switch {
+		case errors.Is(err, ErrBusy):
+			slog.Info("⏭️  Clustering skipped", "reason", err)
+		case err != nil:
+			slog.Warn("⚠️  Clustering run failed", "error", err)
+		default:
+			slog.Info("🧩 Clustering run", "run_id", plan.RunID, "k", plan.K,
+				"attributes", plan.Attributes, "silhouette", plan.Silhouette, "labeler", plan.Labeler)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Compute clusters the current embeddings without storing anything
+func (j *Job) Compute(ctx context.Context) (*Plan, error) {
+	attrs, err := j.loadAttributes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(attrs) < 2 {
+		return nil, fmt.Errorf("need at least 2 embedded attributes to cluster, found %d (run seed-metadata first)", len(attrs))
+	}
+
+	embeddings := make([][]float32, len(attrs))
+	for i, a := range attrs {
+		embeddings[i] = a.Embedding
+	}
+	res := Partition(embeddings, j.cfg.K, j.cfg.MaxK, seed)
+
+	groups := make([][]Attribute, res.K)
+	for i, c := range res.Assign {
+		groups[c] = append(groups[c], attrs[i])
+	}
+
+	var labeler Labeler = KeywordLabeler{}
+	if j.cfg.LLMLabels {
+		llm, err := NewLLMLabeler()
+		if err != nil {
+			slog.Warn("⚠️  LLM cluster labels unavailable, using keywords", "error", err)
+		} else {
+			labeler = llm.WithLog(modellog.NewLogger(j.db, config.Current().ModelLog))
+		}
+	}
+	labels, err := labeler.Label(ctx, groups)
+	if err != nil {
+		return nil, fmt.Errorf("failed to label clusters: %w", err)
+	}
+
+	existing, err := j.loadExisting(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{
+		Algorithm:  Algorithm,
+		K:          res.K,
+		Attributes: len(attrs),
+		Silhouette: res.Silhouette,
+		Labeler:    labeler.Name(),
+	}
+	for _, e := range existing {
+		if e.Source == "auto" {
+			plan.Replaced++
+		}
+	}
+	for c, members := range groups {
+		if len(members) == 0 {
+			continue
+		}
+		codes := make([]string, len(members))
+		for i, a := range members {
+			codes[i] = a.Code
+		}
+		sort.Strings(codes)
+		centroid := make([]float32, len(res.Centroids[c]))
+		for i, x := range res.Centroids[c] {
+			centroid[i] = float32(x)
+		}
+		plan.Clusters = append(plan.Clusters, Cluster{
+			Name:        labels[c].Name,
+			Description: labels[c].Description,
+			Members:     codes,
+			Quality:     res.Cohesion[c],
+			Silhouette:  res.ClusterSilhouette[c],
+			Keywords:    labels[c].Keywords,
+			Centroid:    centroid,
+		})
+	}
+	assignCodes(plan.Clusters, existing)
+	return plan, nil
+}
+
+// RunOnce computes clusters and replaces the automatic clusters of the
+// previous run in one transaction; curated clusters are left alone
+func (j *Job) RunOnce(ctx context.Context, triggeredBy string) (*Plan, error) {
+	plan, err := j.Compute(ctx)
+	if err == nil {
+		err = j.store(ctx, plan, triggeredBy)
+	}
+	if err != nil {
+		if !errors.Is(err, ErrBusy) {
+			j.recordFailure(ctx, triggeredBy, err)
+		}
+		return nil, err
+	}
+	return plan, nil
+}
+
+// store records a completed run with its clusters; the transaction-scoped
+// advisory lock keeps concurrent runs from interleaving
+func (j *Job) store(ctx context.Context, plan *Plan, triggeredBy string) error {
+	tx, err := j.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	var locked bool
+	if err := tx.GetContext(ctx, &locked, `SELECT pg_try_advisory_xact_lock($1)`, lockKey); err != nil {
+		return fmt.Errorf("failed to acquire clustering lock: %w", err)
+	}
+	if !locked {
+		return ErrBusy
+	}
+
+	err = tx.GetContext(ctx, &plan.RunID, `
+		INSERT INTO rag_cluster_runs
+			(algorithm, k, attribute_count, silhouette, labeler, status, triggered_by, finished_at)
+		VALUES ($1, $2, $3, $4, $5, 'completed', $6, CURRENT_TIMESTAMP)
+		RETURNING id`,
+		plan.Algorithm, plan.K, plan.Attributes, plan.Silhouette, plan.Labeler, triggeredBy)
+	if err != nil {
+		return fmt.Errorf("failed to record clustering run: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM rag_clusters WHERE source = 'auto'`); err != nil {
+		return fmt.Errorf("failed to remove previous clusters: %w", err)
+	}
+	for _, c := range plan.Clusters {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO rag_clusters
+				(cluster_code, cluster_name, description, centroid, member_attribute_codes,
+				 quality_score, last_computed, source, run_id)
+			VALUES ($1, $2, $3, $4, $5, $6, CURRENT_TIMESTAMP, 'auto', $7)`,
+			c.Code, c.Name, c.Description, pq.Array(c.Centroid), pq.Array(c.Members), c.Quality, plan.RunID)
+		if err != nil {
+			return fmt.Errorf("failed to store cluster %s: %w", c.Code, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit clusters: %w", err)
+	}
+	return nil
+}
+
+// recordFailure records a failed run; nothing of it was stored
+func (j *Job) recordFailure(ctx context.Context, triggeredBy string, cause error) {
+	_, err := j.db.ExecContext(ctx, `
+		INSERT INTO rag_cluster_runs (algorithm, status, error_message, triggered_by, finished_at)
+		VALUES ($1, 'failed', $2, $3, CURRENT_TIMESTAMP)`,
+		Algorithm, cause.Error(), triggeredBy)
+	if err != nil {
+		slog.Warn("⚠️  Failed to record clustering run", "error", err)
+	}
+}
+
+type attributeRow struct {
+	Code      string         `db:"attribute_code"`
+	Synonyms  pq.StringArray `db:"synonyms"`
+	Context   string         `db:"business_context"`
+	Embedding string         `db:"embedding"`
+}
+
+func (j *Job) loadAttributes(ctx context.Context) ([]Attribute, error) {
+	var rows []attributeRow
+	err := j.db.SelectContext(ctx, &rows, `
+		SELECT attribute_code, synonyms, COALESCE(business_context, '') AS business_context,
+			embedding::text AS embedding
+		FROM kyc_attribute_metadata
+		WHERE embedding IS NOT NULL
+		ORDER BY attribute_code`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load attribute embeddings: %w", err)
+	}
+
+	attrs := make([]Attribute, 0, len(rows))
+	for _, r := range rows {
+		vec, err := parseVector(r.Embedding)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse embedding of %s: %w", r.Code, err)
+		}
+		attrs = append(attrs, Attribute{
+			Code:      r.Code,
+			Synonyms:  strings.Join(r.Synonyms, " "),
+			Context:   r.Context,
+			Embedding: vec,
+		})
+	}
+	return attrs, nil
+}
+
+// parseVector parses the text form of a pgvector value, e.g. [0.1,-0.2]
+func parseVector(s string) ([]float32, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "[") || !strings.HasSuffix(s, "]") {
+		return nil, fmt.Errorf("not a vector: %.20q", s)
+	}
+	parts := strings.Split(s[1:len(s)-1], ",")
+	vec := make([]float32, len(parts))
+	for i, p := range parts {
+		f, err := strconv.ParseFloat(strings.TrimSpace(p), 32)
+		if err != nil {
+			return nil, err
+		}
+		vec[i] = float32(f)
+	}
+	return vec, nil
+}
+
+// existingCluster is a stored cluster whose code a new one may keep or must avoid
+type existingCluster struct {
+	Code    string         `db:"cluster_code"`
+	Source  string         `db:"source"`
+	Members pq.StringArray `db:"member_attribute_codes"`
+}
+
+func (j *Job) loadExisting(ctx context.Context) ([]existingCluster, error) {
+	var rows []existingCluster
+	err := j.db.SelectContext(ctx, &rows, `
+		SELECT cluster_code, source, COALESCE(member_attribute_codes, '{}') AS member_attribute_codes
+		FROM rag_clusters
+		ORDER BY cluster_code`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load clusters: %w", err)
+	}
+	return rows, nil
+}
+
+// assignCodes gives every cluster a code: the code of the previous automatic
+// cluster it overlaps most (if by at least reuseOverlap), otherwise one made
+// from its keywords that clashes with no other cluster
+func assignCodes(clusters []Cluster, existing []existingCluster) {
+	taken := map[string]bool{}
+	for _, e := range existing {
+		if e.Source != "auto" {
+			taken[e.Code] = true
+		}
+	}
+
+	type match struct {
+		cluster, previous int
+		overlap           float64
+	}
+	var matches []match
+	for c := range clusters {
+		for p, e := range existing {
+			if e.Source != "auto" {
+				continue
+			}
+			if o := jaccard(clusters[c].Members, e.Members); o >= reuseOverlap {
+				matches = append(matches, match{c, p, o})
+			}
+		}
+	}
+	sort.SliceStable(matches, func(a, b int) bool { return matches[a].overlap > matches[b].overlap })
+	for _, m := range matches {
+		code := existing[m.previous].Code
+		if clusters[m.cluster].Code != "" || taken[code] {
+			continue
+		}
+		clusters[m.cluster].Code = code
+		clusters[m.cluster].Reused = true
+		taken[code] = true
+	}
+
+	for c := range clusters {
+		if clusters[c].Code != "" {
+			continue
+		}
+		base := "AUTO"
+		for _, k := range clusters[c].Keywords[:min(2, len(clusters[c].Keywords))] {
+			base += "_" + strings.ToUpper(k)
+		}
+		code := base
+		for n := 2; taken[code]; n++ {
+			code = fmt.Sprintf("%s_%d", base, n)
+		}
+		clusters[c].Code = code
+		taken[code] = true
+	}
+}
+
+func jaccard(a, b []string) float64 {
+	set := make(map[string]bool, len(a))
+	for _, x := range a {
+		set[x] = true
+	}
+	inter := 0
+	for _, x := range b {
+		if set[x] {
+			inter++
+		}
+	}
+	union := len(a) + len(b) - inter
+	if union == 0 {
+		return 0
+	}
+	return float64(inter) / float64(union)
+}
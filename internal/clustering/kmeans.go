@@ -0,0 +1,272 @@
+package clustering
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Result is a partition of the points into K clusters
+type Result struct {
+	K int
+	// Assign is the cluster of each point
+	Assign []int
+	// Centroids are the unit-length mean directions of the clusters
+	Centroids [][]float64
+	// Silhouette is the mean silhouette of all points (-1..1) using cosine
+	// distance; ClusterSilhouette is the mean per cluster
+	Silhouette        float64
+	ClusterSilhouette []float64
+	// Cohesion is the mean cosine similarity of each cluster's members to
+	// its centroid (0..1 for embeddings)
+	Cohesion []float64
+}
+
+const (
+	maxIterations = 100
+	restarts      = 5
+)
+
+// normalize returns unit-length float64 copies of the vectors
+func normalize(points [][]float32) [][]float64 {
+	out := make([][]float64, len(points))
+	for i, p := range points {
+		v := make([]float64, len(p))
+		norm := 0.0
+		for j, x := range p {
+			v[j] = float64(x)
+			norm += v[j] * v[j]
+		}
+		if norm = math.Sqrt(norm); norm > 0 {
+			for j := range v {
+				v[j] /= norm
+			}
+		}
+		out[i] = v
+	}
+	return out
+}
+
+func dot(a, b []float64) float64 {
+	s := 0.0
+	for i := range a {
+		s += a[i] * b[i]
+	}
+	return s
+}
+
+// distances is the pairwise cosine distance matrix
+func distances(points [][]float64) [][]float64 {
+	n := len(points)
+	d := make([][]float64, n)
+	for i := range d {
+		d[i] = make([]float64, n)
+	}
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			d[i][j] = 1 - dot(points[i], points[j])
+			d[j][i] = d[i][j]
+		}
+	}
+	return d
+}
+
+// kmeans runs spherical k-means (cosine similarity) from k-means++ seeds,
+// keeping the best of several restarts
+func kmeans(points [][]float64, k int, rng *rand.Rand) ([]int, [][]float64) {
+	var bestAssign []int
+	var bestCentroids [][]float64
+	bestScore := math.Inf(-1)
+	for r := 0; r < restarts; r++ {
+		assign, centroids, score := kmeansOnce(points, k, rng)
+		if score > bestScore {
+			bestAssign, bestCentroids, bestScore = assign, centroids, score
+		}
+	}
+	return bestAssign, bestCentroids
+}
+
+func kmeansOnce(points [][]float64, k int, rng *rand.Rand) ([]int, [][]float64, float64) {
+	n, dim := len(points), len(points[0])
+
+	// k-means++: each next seed is drawn with probability proportional to
+	// its squared distance from the nearest seed so far
+	centroids := [][]float64{append([]float64(nil), points[rng.Intn(n)]...)}
+	nearest := make([]float64, n)
+	for i := range nearest {
+		nearest[i] = math.Inf(1)
+	}
+	for len(centroids) < k {
+		total := 0.0
+		last := centroids[len(centroids)-1]
+		for i, p := range points {
+			d := 1 - dot(p, last)
+			if d*d < nearest[i] {
+				nearest[i] = d * d
+			}
+			total += nearest[i]
+		}
+		target := rng.Float64() * total
+		pick := n - 1
+		for i, w := range nearest {
+			if target -= w; target <= 0 {
+				pick = i
+				break
+			}
+		}
+		centroids = append(centroids, append([]float64(nil), points[pick]...))
+	}
+
+	assign := make([]int, n)
+	score := 0.0
+	for iter := 0; iter < maxIterations; iter++ {
+		changed := iter == 0
+		score = 0
+		for i, p := range points {
+			best, bestSim := 0, math.Inf(-1)
+			for c, centroid := range centroids {
+				if sim := dot(p, centroid); sim > bestSim {
+					best, bestSim = c, sim
+				}
+			}
+			if assign[i] != best {
+				assign[i] = best
+				changed = true
+			}
+			score += bestSim
+		}
+		if !changed {
+			break
+		}
+
+		sums := make([][]float64, k)
+		counts := make([]int, k)
+		for c := range sums {
+			sums[c] = make([]float64, dim)
+		}
+		for i, p := range points {
+			c := assign[i]
+			counts[c]++
+			for j, x := range p {
+				sums[c][j] += x
+			}
+		}
+		for c := range centroids {
+			if counts[c] == 0 {
+				// Reseed an empty cluster with the point worst served by its centroid
+				worst, worstSim := 0, math.Inf(1)
+				for i, p := range points {
+					if sim := dot(p, centroids[assign[i]]); sim < worstSim {
+						worst, worstSim = i, sim
+					}
+				}
+				centroids[c] = append([]float64(nil), points[worst]...)
+				assign[worst] = c
+				continue
+			}
+			centroids[c] = unit(sums[c])
+		}
+	}
+	return assign, centroids, score
+}
+
+func unit(v []float64) []float64 {
+	norm := math.Sqrt(dot(v, v))
+	if norm == 0 {
+		return v
+	}
+	for i := range v {
+		v[i] /= norm
+	}
+	return v
+}
+
+// silhouette returns the mean silhouette overall and per cluster
+func silhouette(dist [][]float64, assign []int, k int) (float64, []float64) {
+	n := len(assign)
+	sizes := make([]int, k)
+	for _, c := range assign {
+		sizes[c]++
+	}
+	perCluster := make([]float64, k)
+	total := 0.0
+	for i := 0; i < n; i++ {
+		if sizes[assign[i]] == 1 {
+			continue // silhouette of a singleton is 0
+		}
+		sums := make([]float64, k)
+		for j := 0; j < n; j++ {
+			if j != i {
+				sums[assign[j]] += dist[i][j]
+			}
+		}
+		a := sums[assign[i]] / float64(sizes[assign[i]]-1)
+		b := math.Inf(1)
+		for c := 0; c < k; c++ {
+			if c != assign[i] && sizes[c] > 0 {
+				b = math.Min(b, sums[c]/float64(sizes[c]))
+			}
+		}
+		s := 0.0
+		if m := math.Max(a, b); m > 0 && !math.IsInf(b, 1) {
+			s = (b - a) / m
+		}
+		perCluster[assign[i]] += s
+		total += s
+	}
+	for c := range perCluster {
+		if sizes[c] > 0 {
+			perCluster[c] /= float64(sizes[c])
+		}
+	}
+	return total / float64(n), perCluster
+}
+
+// Partition partitions embeddings into k clusters with spherical k-means; k = 0
+// tries every k in [2, maxK] and keeps the one with the best silhouette.
+// The seed makes runs reproducible.
+func Partition(embeddings [][]float32, k, maxK int, seed int64) Result {
+	points := normalize(embeddings)
+	n := len(points)
+	if n < 2 {
+		res := Result{K: n, Assign: make([]int, n), Centroids: points}
+		if n == 1 {
+			res.ClusterSilhouette, res.Cohesion = []float64{0}, []float64{1}
+		}
+		return res
+	}
+
+	dist := distances(points)
+	candidates := []int{min(k, n)}
+	if k <= 0 {
+		candidates = nil
+		for c := 2; c <= min(maxK, n-1); c++ {
+			candidates = append(candidates, c)
+		}
+		if len(candidates) == 0 {
+			candidates = []int{min(2, n)}
+		}
+	}
+
+	var best Result
+	best.Silhouette = math.Inf(-1)
+	for _, c := range candidates {
+		assign, centroids := kmeans(points, c, rand.New(rand.NewSource(seed)))
+		s, perCluster := silhouette(dist, assign, c)
+		if s > best.Silhouette {
+			best = Result{K: c, Assign: assign, Centroids: centroids, Silhouette: s, ClusterSilhouette: perCluster}
+		}
+	}
+
+	best.Cohesion = make([]float64, best.K)
+	sizes := make([]int, best.K)
+	for i, c := range best.Assign {
+		best.Cohesion[c] += dot(points[i], best.Centroids[c])
+		sizes[c]++
+	}
+	for c := range best.Cohesion {
+		if sizes[c] > 0 {
+			best.Cohesion[c] = math.Max(0, math.Min(1, best.Cohesion[c]/float64(sizes[c])))
+		}
+	}
+	return best
+}
@@ -0,0 +1,234 @@
+package clustering
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	openai "github.com/sashabaranov/go-openai"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+	kycconfig "github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/modellog"
+)
+
+// Label names a cluster
+type Label struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Keywords are the most distinctive terms of the members
+	Keywords []string `json:"-"`
+}
+
+// Labeler names clusters from their members
+type Labeler interface {
+	// Name identifies the labeler in run records
+	Name() string
+	Label(ctx context.Context, clusters [][]Attribute) ([]Label, error)
+}
+
+// stopwords are ignored when picking keywords
+var stopwords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true, "by": true,
+	"for": true, "from": true, "has": true, "in": true, "is": true, "it": true, "its": true, "of": true,
+	"on": true, "or": true, "that": true, "the": true, "this": true, "to": true, "was": true, "with": true,
+	"which": true, "who": true, "about": true, "any": true, "all": true, "per": true, "used": true, "under": true,
+	"code": true, "value": true, "attribute": true, "data": true, "e.g": true, "etc": true,
+}
+
+func terms(a Attribute) []string {
+	text := strings.ReplaceAll(a.Code, "_", " ") + " " + a.Synonyms + " " + a.Context
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	out := words[:0]
+	for _, w := range words {
+		if len(w) > 2 && !stopwords[w] {
+			out = append(out, w)
+		}
+	}
+	return out
+}
+
+// KeywordLabeler names clusters after the terms that set their members apart
+// from the other clusters (tf-idf over codes, synonyms and business context)
+type KeywordLabeler struct{}
+
+// Name implements Labeler
+func (KeywordLabeler) Name() string { return "keywords" }
+
+// Label implements Labeler
+func (KeywordLabeler) Label(_ context.Context, clusters [][]Attribute) ([]Label, error) {
+	df := map[string]int{}
+	tfs := make([]map[string]int, len(clusters))
+	for i, members := range clusters {
+		tfs[i] = map[string]int{}
+		for _, a := range members {
+			for _, t := range terms(a) {
+				tfs[i][t]++
+			}
+		}
+		for t := range tfs[i] {
+			df[t]++
+		}
+	}
+
+	labels := make([]Label, len(clusters))
+	for i, members := range clusters {
+		type scored struct {
+			term  string
+			score float64
+		}
+		var ranked []scored
+		for t, tf := range tfs[i] {
+			if len(clusters) > 1 && df[t] == len(clusters) {
+				continue // shared by every cluster, so it names none
+			}
+			idf := math.Log(float64(len(clusters)+1) / float64(df[t]))
+			ranked = append(ranked, scored{t, float64(tf) * (idf + 0.1)})
+		}
+		sort.Slice(ranked, func(a, b int) bool {
+			if ranked[a].score != ranked[b].score {
+				return ranked[a].score > ranked[b].score
+			}
+			return ranked[a].term < ranked[b].term
+		})
+		var keywords []string
+		for _, s := range ranked[:min(3, len(ranked))] {
+			keywords = append(keywords, s.term)
+		}
+
+		name := "Cluster"
+		if len(keywords) > 0 {
+			titled := make([]string, len(keywords))
+			for j, k := range keywords {
+				titled[j] = strings.ToUpper(k[:1]) + k[1:]
+			}
+			name = strings.Join(titled, " / ")
+		}
+		labels[i] = Label{
+			Name:        name,
+			Description: describe(members),
+			Keywords:    keywords,
+		}
+	}
+	return labels, nil
+}
+
+// describe lists the first members of a cluster
+func describe(members []Attribute) string {
+	codes := make([]string, 0, 5)
+	for _, a := range members[:min(5, len(members))] {
+		codes = append(codes, a.Code)
+	}
+	desc := fmt.Sprintf("%d attributes: %s", len(members), strings.Join(codes, ", "))
+	if len(members) > 5 {
+		desc += ", ..."
+	}
+	return desc
+}
+
+// labelPrompt asks for a short JSON label per cluster
+const labelPrompt = `You name groups of KYC (know your customer) data attributes for a compliance ontology.
+For the group of attributes you are given, reply with JSON only:
+{"name": "<2-5 word title case name>", "description": "<one sentence on what the group covers>"}`
+
+// LLMLabeler names clusters with the chat model, falling back to keywords
+// for a cluster the model cannot label
+type LLMLabeler struct {
+	client   *openai.Client
+	model    string
+	fallback KeywordLabeler
+	log      *modellog.Logger
+}
+
+// NewLLMLabeler creates a labeler from the OpenAI section of config.Current
+func NewLLMLabeler() (*LLMLabeler, error) {
+	cfg := kycconfig.Current().OpenAI
+	if cfg.APIKey == "" {
+		return nil, fmt.Errorf("OPENAI_API_KEY not set")
+	}
+	config := openai.DefaultConfig(cfg.APIKey)
+	config.HTTPClient = &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)}
+	return &LLMLabeler{client: openai.NewClientWithConfig(config), model: cfg.ChatModel}, nil
+}
+
+// WithLog records the prompts and responses of the labeler
+func (l *LLMLabeler) WithLog(log *modellog.Logger) *LLMLabeler {
+	l.log = log
+	return l
+}
+
+// Name implements Labeler
+func (l *LLMLabeler) Name() string { return l.model }
+
+// Label implements Labeler
+func (l *LLMLabeler) Label(ctx context.Context, clusters [][]Attribute) ([]Label, error) {
+	labels, err := l.fallback.Label(ctx, clusters)
+	if err != nil {
+		return nil, err
+	}
+	for i, members := range clusters {
+		var sb strings.Builder
+		for _, a := range members[:min(25, len(members))] {
+			fmt.Fprintf(&sb, "- %s", a.Code)
+			if a.Context != "" {
+				context := a.Context
+				if len(context) > 200 {
+					context = context[:200] + "..."
+				}
+				fmt.Fprintf(&sb, ": %s", context)
+			}
+			sb.WriteByte('\n')
+		}
+		start := time.Now()
+		resp, err := l.client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+			Model:          l.model,
+			Temperature:    0.2,
+			ResponseFormat: &openai.ChatCompletionResponseFormat{Type: openai.ChatCompletionResponseFormatTypeJSONObject},
+			Messages: []openai.ChatCompletionMessage{
+				{Role: openai.ChatMessageRoleSystem, Content: labelPrompt},
+				{Role: openai.ChatMessageRoleUser, Content: sb.String()},
+			},
+		})
+		ex := modellog.Exchange{
+			Feature:          model.ModelFeatureClusterLabels,
+			Model:            l.model,
+			SystemPrompt:     labelPrompt,
+			Prompt:           sb.String(),
+			PromptTokens:     resp.Usage.PromptTokens,
+			CompletionTokens: resp.Usage.CompletionTokens,
+			Latency:          time.Since(start),
+			Err:              err,
+		}
+		if err == nil && len(resp.Choices) > 0 {
+			ex.Response = resp.Choices[0].Message.Content
+		}
+		l.log.Record(ctx, ex)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			continue // keep the keyword label
+		}
+		if len(resp.Choices) == 0 {
+			continue
+		}
+		var label Label
+		if err := json.Unmarshal([]byte(resp.Choices[0].Message.Content), &label); err != nil || strings.TrimSpace(label.Name) == "" {
+			continue
+		}
+		labels[i].Name = strings.TrimSpace(label.Name)
+		if d := strings.TrimSpace(label.Description); d != "" {
+			labels[i].Description = d
+		}
+	}
+	return labels, nil
+}
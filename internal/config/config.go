@@ -0,0 +1,1531 @@
+// Package config holds the typed configuration shared by kycctl, kycserver
+// and dataserver. Values are resolved in order of increasing precedence:
+// built-in defaults, an optional YAML file (-config or KYC_CONFIG),
+// environment variables, then command-line flags.
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the full configuration of a KYC-DSL process
+type Config struct {
+	Database        DatabaseConfig        `yaml:"database"`
+	Server          ServerConfig          `yaml:"server"`
+	DataService     DataServiceConfig     `yaml:"data_service"`
+	GRPC            GRPCConfig            `yaml:"grpc"`
+	Drain           DrainConfig           `yaml:"drain"`
+	Health          HealthConfig          `yaml:"health"`
+	RustDSL         RustDSLConfig         `yaml:"rust_dsl"`
+	OpenAI          OpenAIConfig          `yaml:"openai"`
+	Region          RegionConfig          `yaml:"region"`
+	Auth            AuthConfig            `yaml:"auth"`
+	RateLimit       RateLimitConfig       `yaml:"rate_limit"`
+	Shadow          ShadowConfig          `yaml:"shadow"`
+	Watchlist       WatchlistConfig       `yaml:"watchlist"`
+	MaterialChange  MaterialChangeConfig  `yaml:"material_change"`
+	Reevaluation    ReevaluationConfig    `yaml:"reevaluation"`
+	Risk            RiskConfig            `yaml:"risk"`
+	Screening       ScreeningConfig       `yaml:"screening"`
+	Evidence        EvidenceConfig        `yaml:"evidence"`
+	Ingestion       IngestionConfig       `yaml:"ingestion"`
+	Clustering      ClusteringConfig      `yaml:"clustering"`
+	Reembedding     ReembeddingConfig     `yaml:"reembedding"`
+	ModelLog        ModelLogConfig        `yaml:"model_log"`
+	AuditLog        AuditLogConfig        `yaml:"audit_log"`
+	Retention       RetentionConfig       `yaml:"retention"`
+	CaseLock        CaseLockConfig        `yaml:"case_lock"`
+	Signing         SigningConfig         `yaml:"signing"`
+	Encryption      EncryptionConfig      `yaml:"encryption"`
+	DSR             DSRConfig             `yaml:"dsr"`
+	Ranking         RankingConfig         `yaml:"ranking"`
+	Search          SearchConfig          `yaml:"search"`
+	EmbeddingCache  EmbeddingCacheConfig  `yaml:"embedding_cache"`
+	ResponseCache   ResponseCacheConfig   `yaml:"response_cache"`
+	EmbeddingSpaces EmbeddingSpacesConfig `yaml:"embedding_spaces"`
+	EmbeddingTiers  EmbeddingTierConfig   `yaml:"embedding_tiers"`
+	Webhooks        WebhookConfig         `yaml:"webhooks"`
+	Events          EventsConfig          `yaml:"events"`
+	Log             LogConfig             `yaml:"log"`
+
+	// File is the YAML file the configuration was read from, if any
+	File string `yaml:"-"`
+}
+
+// DatabaseConfig locates the PostgreSQL database
+type DatabaseConfig struct {
+	// URL is a full connection string; when set it overrides the fields below
+	URL      string `yaml:"url"`
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+	Name     string `yaml:"name"`
+	SSLMode  string `yaml:"sslmode"`
+	// AutoMigrate applies pending schema migrations on connect instead of
+	// only warning about them
+	AutoMigrate bool `yaml:"auto_migrate"`
+
+	// Connection pool shared by every component of a process (internal/db)
+	MaxConns          int           `yaml:"max_conns"`
+	MinConns          int           `yaml:"min_conns"`
+	MaxConnLifetime   time.Duration `yaml:"max_conn_lifetime"`
+	MaxConnIdleTime   time.Duration `yaml:"max_conn_idle_time"`
+	HealthCheckPeriod time.Duration `yaml:"health_check_period"`
+}
+
+// ServerConfig configures the kycserver HTTP API
+type ServerConfig struct {
+	Port         int           `yaml:"port"`
+	ReadTimeout  time.Duration `yaml:"read_timeout"`
+	WriteTimeout time.Duration `yaml:"write_timeout"`
+	IdleTimeout  time.Duration `yaml:"idle_timeout"`
+	// TrustedProxies are the addresses (IPs or CIDRs) of load balancers and
+	// proxies whose X-Forwarded-For, X-Real-IP and x-forwarded-for metadata
+	// name the client, for kycserver and dataserver. Forwarded headers from
+	// any other peer are ignored.
+	TrustedProxies []string `yaml:"trusted_proxies"`
+}
+
+// DataServiceConfig configures the dataserver and its clients
+type DataServiceConfig struct {
+	// ListenAddr is where dataserver accepts gRPC connections
+	ListenAddr string `yaml:"listen_addr"`
+	// Addr is where clients reach the data service
+	Addr string `yaml:"addr"`
+	// MetricsAddr serves dataserver's Prometheus metrics
+	MetricsAddr string `yaml:"metrics_addr"`
+	// APIKey is sent by clients as x-api-key when dataserver requires
+	// authentication (one of auth.api_keys)
+	APIKey string `yaml:"api_key"`
+}
+
+// GRPCConfig configures the interceptors shared by the gRPC servers
+type GRPCConfig struct {
+	// DefaultTimeout bounds unary calls sent without a deadline
+	DefaultTimeout time.Duration `yaml:"default_timeout"`
+	// StreamTimeout bounds streaming calls sent without a deadline
+	StreamTimeout time.Duration `yaml:"stream_timeout"`
+	// MaxTimeout caps the deadline a client may ask for
+	MaxTimeout time.Duration `yaml:"max_timeout"`
+	// MaxFieldBytes rejects requests with a longer string or bytes field
+	MaxFieldBytes int `yaml:"max_field_bytes"`
+	// MaxLimit rejects requests whose limit or page_size is larger
+	MaxLimit int `yaml:"max_limit"`
+}
+
+// DrainConfig configures how kycserver and dataserver shut down during a
+// rolling deploy
+type DrainConfig struct {
+	// ReusePort binds listeners with SO_REUSEPORT so a new process can listen
+	// on the same port while the old one drains
+	ReusePort bool `yaml:"reuse_port"`
+	// Delay keeps serving after SIGTERM, reporting unhealthy, so load
+	// balancers stop routing before listeners close
+	Delay time.Duration `yaml:"delay"`
+	// Timeout bounds waiting for in-flight requests and streams; the rest
+	// are then cut off
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// HealthConfig configures the /readyz dependency checks of kycserver and
+// dataserver
+type HealthConfig struct {
+	// Timeout bounds each dependency check
+	Timeout time.Duration `yaml:"timeout"`
+	// CacheTTL reuses a readiness report for this long, so frequent probes
+	// do not each call the embeddings provider
+	CacheTTL time.Duration `yaml:"cache_ttl"`
+	// Optional checks are reported but do not make a server unready
+	// (database, pgvector, embeddings, rust_dsl)
+	Optional []string `yaml:"optional"`
+}
+
+// RustDSLConfig locates the Rust DSL gRPC service and sets how its clients
+// ride out outages
+type RustDSLConfig struct {
+	Addr string `yaml:"addr"`
+	// APIKey is sent as x-api-key; the service requires it when started
+	// with RUST_DSL_API_KEY
+	APIKey string `yaml:"api_key"`
+	// DialTimeout bounds each attempt to connect
+	DialTimeout time.Duration `yaml:"dial_timeout"`
+	// Timeout bounds each attempt of a call; AmendTimeout those of amend and
+	// execute calls
+	Timeout      time.Duration `yaml:"timeout"`
+	AmendTimeout time.Duration `yaml:"amend_timeout"`
+	// MaxAttempts is how many times a call failing with a transient error
+	// (unavailable, deadline exceeded) is tried
+	MaxAttempts int `yaml:"max_attempts"`
+	// RetryBackoff is the wait after the first failed attempt, doubled after
+	// each further one
+	RetryBackoff time.Duration `yaml:"retry_backoff"`
+	// BreakerThreshold consecutive transient failures open the circuit
+	// breaker: calls then fail fast for BreakerCooldown, after which one
+	// call probes the service
+	BreakerThreshold int           `yaml:"breaker_threshold"`
+	BreakerCooldown  time.Duration `yaml:"breaker_cooldown"`
+	// Fallback parses and validates with the in-process Go parser while the
+	// service is unavailable (syntax and case structure only)
+	Fallback bool `yaml:"fallback"`
+}
+
+// OpenAIConfig configures the embedding and chat clients
+type OpenAIConfig struct {
+	APIKey string `yaml:"api_key"`
+	// ChatModel polishes generated text such as case narratives
+	ChatModel string `yaml:"chat_model"`
+	// EmbeddingModel and EmbeddingDimensions generate the embeddings of the
+	// primary embedding space; they must match it (kycctl embeddings list)
+	EmbeddingModel      string `yaml:"embedding_model"`
+	EmbeddingDimensions int    `yaml:"embedding_dimensions"`
+}
+
+// RegionConfig describes the multi-region deployment topology
+type RegionConfig struct {
+	// Self is the region of this process; empty means single-region
+	Self string `yaml:"self"`
+	// Primary is the region that accepts writes (default Self)
+	Primary string `yaml:"primary"`
+	// Endpoints maps region name to its gRPC address
+	Endpoints map[string]string `yaml:"endpoints"`
+	// Fallbacks maps a region to the regions tried when it has no endpoint
+	Fallbacks map[string][]string `yaml:"fallbacks"`
+}
+
+// AuthConfig configures JWT/OIDC and API key authentication
+type AuthConfig struct {
+	Issuer     string `yaml:"issuer"`
+	Audience   string `yaml:"audience"`
+	JWKSURL    string `yaml:"jwks_url"`
+	RolesClaim string `yaml:"roles_claim"`
+	// TenantClaim is the JWT claim naming the caller's tenant
+	TenantClaim string `yaml:"tenant_claim"`
+	// RoleMap maps IdP group/role names to KYC roles
+	RoleMap map[string]string `yaml:"role_map"`
+	// APIKeys maps static API keys to the role they grant
+	APIKeys map[string]string `yaml:"api_keys"`
+	// ScopesClaim is the JWT claim carrying OAuth scopes (space-separated
+	// string or list)
+	ScopesClaim string `yaml:"scopes_claim"`
+	// RoleScopes grants scopes to the callers holding a role or a higher
+	// one, on top of the scopes in their token; API keys get scopes only
+	// this way
+	RoleScopes map[string][]string `yaml:"role_scopes"`
+}
+
+// RateLimitConfig limits how often each caller, by API key or else client
+// IP, may call the RAG endpoints, whose searches call the embedding provider
+type RateLimitConfig struct {
+	// Enabled applies the limits in kycserver
+	Enabled bool `yaml:"enabled"`
+	// RequestsPerMinute is the sustained rate of a caller
+	RequestsPerMinute float64 `yaml:"requests_per_minute"`
+	// Burst is how many requests a caller may make at once
+	Burst int `yaml:"burst"`
+	// DailyQuota caps a caller's requests per UTC day; 0 is unlimited
+	DailyQuota int `yaml:"daily_quota"`
+	// Paths are the path prefixes limited; the health check and usage
+	// endpoint never are
+	Paths []string `yaml:"paths"`
+}
+
+// ShadowConfig configures the optional shadow ranking experiment
+type ShadowConfig struct {
+	Ranker string `yaml:"ranker"`
+	// SampleRate is the fraction of searches shadowed; 0 disables the experiment
+	SampleRate float64 `yaml:"sample_rate"`
+}
+
+// WatchlistConfig configures proactive re-screening of watched entities by dataserver
+type WatchlistConfig struct {
+	// Enabled runs the scheduler in dataserver
+	Enabled bool `yaml:"enabled"`
+	// Interval is how often the scheduler looks for due entries
+	Interval time.Duration `yaml:"interval"`
+	// DefaultCadence is how often an entry is re-screened unless pinned with its own cadence
+	DefaultCadence time.Duration `yaml:"default_cadence"`
+	// BatchSize caps the entries screened per tick
+	BatchSize int `yaml:"batch_size"`
+	// MatchThreshold is the name similarity (0..1) from which a list record is a hit
+	MatchThreshold float64 `yaml:"match_threshold"`
+}
+
+// MaterialChangeConfig configures the detectors that open review amendments
+// on cases when the entity graph changes materially
+type MaterialChangeConfig struct {
+	// Enabled runs the detectors in dataserver
+	Enabled bool `yaml:"enabled"`
+	// Interval is how often logged changes are processed
+	Interval time.Duration `yaml:"interval"`
+	// OwnershipThreshold is the stake (percent) whose crossing is material
+	OwnershipThreshold float64 `yaml:"ownership_threshold"`
+	// OwnershipDelta is the change in a stake (percentage points) that is material on its own
+	OwnershipDelta float64 `yaml:"ownership_delta"`
+}
+
+// ReevaluationConfig configures the worker that re-evaluates derived
+// attributes queued when their inputs, rules or lists change
+type ReevaluationConfig struct {
+	// Enabled runs the worker in dataserver
+	Enabled bool `yaml:"enabled"`
+	// Interval is how often the queue is polled
+	Interval time.Duration `yaml:"interval"`
+	// BatchSize caps the queue items processed per tick
+	BatchSize int `yaml:"batch_size"`
+	// Materialize writes successful results into the case data dictionary
+	// (kyc_case_data_dictionary), marked as derived with their evaluation ID
+	Materialize bool `yaml:"materialize"`
+}
+
+// RiskConfig configures how kycctl score and the ScoreCase RPC combine
+// derived attributes into a case's CUSTOMER_RISK_RATING (internal/risk)
+type RiskConfig struct {
+	// Weights are the relative weights of the scored attributes
+	Weights map[string]float64 `yaml:"weights"`
+	// Scales are the values at which numeric attributes contribute their
+	// full weight; unscaled numeric attributes are read as fractions (0-1)
+	Scales map[string]float64 `yaml:"scales"`
+	// Bands are the minimum scores (0-100) of each rating; a score below
+	// every band is LOW
+	Bands map[string]float64 `yaml:"bands"`
+}
+
+// ScreeningConfig configures sanctions, PEP and adverse media screening of
+// entities and their UBOs by the ScreenEntity RPC and kycctl screen
+// (internal/screening)
+type ScreeningConfig struct {
+	// MatchThreshold is the name similarity (0..1) from which a list record is a hit
+	MatchThreshold float64 `yaml:"match_threshold"`
+	// ConfirmedThreshold is the similarity from which a hit makes the
+	// screening a Match rather than a Potential Match
+	ConfirmedThreshold float64 `yaml:"confirmed_threshold"`
+	// API is an optional commercial screening service queried alongside the
+	// ingested OFAC and EU lists
+	API ScreeningAPIConfig `yaml:"api"`
+	// PEP is an optional PEP database searched alongside the local PEP lists
+	PEP ScreeningAPIConfig `yaml:"pep"`
+	// AdverseMedia is an optional news search service for adverse media
+	AdverseMedia AdverseMediaConfig `yaml:"adverse_media"`
+}
+
+// ScreeningAPIConfig configures a commercial screening API; screening uses
+// it when URL is set
+type ScreeningAPIConfig struct {
+	URL     string        `yaml:"url"`
+	APIKey  string        `yaml:"api_key"`
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// AdverseMediaConfig configures the news search service used for adverse
+// media screening; screening uses it when URL is set
+type AdverseMediaConfig struct {
+	URL     string        `yaml:"url"`
+	APIKey  string        `yaml:"api_key"`
+	Timeout time.Duration `yaml:"timeout"`
+	// Terms are the negative news keywords searched alongside the name
+	Terms []string `yaml:"terms"`
+	// MaxArticles caps the articles requested per subject
+	MaxArticles int `yaml:"max_articles"`
+}
+
+// EvidenceConfig configures where collected document evidence is stored
+// (internal/evidence)
+type EvidenceConfig struct {
+	// Driver is local, s3 or minio (S3 with path-style addressing)
+	Driver string `yaml:"driver"`
+	// Dir is the root directory of the local driver
+	Dir string `yaml:"dir"`
+	// MaxSizeMB caps the size of an uploaded file
+	MaxSizeMB int `yaml:"max_size_mb"`
+	// S3 locates the bucket of the s3 and minio drivers
+	S3 S3Config `yaml:"s3"`
+	// Expiry configures the checker that flags expiring evidence
+	Expiry EvidenceExpiryConfig `yaml:"expiry"`
+	// Extraction configures text extraction from uploaded evidence
+	Extraction TextExtractionConfig `yaml:"extraction"`
+	// Attributes configures the attribute values proposed from evidence text
+	Attributes AttributeExtractionConfig `yaml:"attributes"`
+}
+
+// AttributeExtractionConfig configures the proposal of attribute values from
+// the extracted text of evidence with the chat model
+type AttributeExtractionConfig struct {
+	// SectionsPerAttribute is how many excerpts of the evidence are retrieved
+	// for each attribute and quoted to the model
+	SectionsPerAttribute int `yaml:"sections_per_attribute"`
+	// MaxAttributes caps the attributes extracted in one request
+	MaxAttributes int `yaml:"max_attributes"`
+}
+
+// TextExtractionConfig configures the worker that extracts the text of
+// uploaded evidence page by page and embeds it into kyc_document_sections
+type TextExtractionConfig struct {
+	// Enabled runs the worker in kycserver
+	Enabled bool `yaml:"enabled"`
+	// Interval is how often pending evidence is looked for
+	Interval time.Duration `yaml:"interval"`
+	// BatchSize caps the evidence extracted per tick
+	BatchSize int `yaml:"batch_size"`
+	// MaxAttempts is how many times extraction is tried before the evidence
+	// is marked failed
+	MaxAttempts int `yaml:"max_attempts"`
+	// Extractors are tried in order on the extractors handling the content
+	// type until one finds text: builtin (the text layer of PDFs),
+	// pdftotext, tesseract (OCR of images) and api
+	Extractors []string `yaml:"extractors"`
+	// PDFToText and Tesseract are the commands run by those extractors
+	PDFToText string `yaml:"pdftotext"`
+	Tesseract string `yaml:"tesseract"`
+	// Languages are the Tesseract languages, e.g. eng+deu
+	Languages string `yaml:"languages"`
+	// CommandTimeout bounds each pdftotext or tesseract run
+	CommandTimeout time.Duration `yaml:"command_timeout"`
+	// API is the external OCR service of the api extractor
+	API OCRAPIConfig `yaml:"api"`
+}
+
+// OCRAPIConfig locates an external OCR service
+type OCRAPIConfig struct {
+	URL     string        `yaml:"url"`
+	APIKey  string        `yaml:"api_key"`
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// EvidenceExpiryConfig configures the checker that flags evidence nearing
+// or past its expiry date and sends a daily summary as an event
+type EvidenceExpiryConfig struct {
+	// Enabled runs the checker in dataserver
+	Enabled bool `yaml:"enabled"`
+	// Interval is how often expiry dates are checked
+	Interval time.Duration `yaml:"interval"`
+	// WarningDays is how many days before its expiry date evidence is flagged
+	WarningDays int `yaml:"warning_days"`
+	// SummaryHour is the hour of the day (UTC) from which the daily summary
+	// is sent
+	SummaryHour int `yaml:"summary_hour"`
+}
+
+// S3Config locates an S3 or S3-compatible (minio) bucket
+type S3Config struct {
+	// Endpoint is the service URL; empty uses AWS S3 in Region
+	Endpoint  string `yaml:"endpoint"`
+	Region    string `yaml:"region"`
+	Bucket    string `yaml:"bucket"`
+	AccessKey string `yaml:"access_key"`
+	SecretKey string `yaml:"secret_key"`
+	// PathStyle addresses the bucket in the path rather than the host name
+	PathStyle bool `yaml:"path_style"`
+}
+
+// IngestionConfig configures how regulatory documents are split into
+// sections and embedded by kycctl ingest-document
+type IngestionConfig struct {
+	// ChunkTokens is the target size of a section excerpt (estimated tokens)
+	ChunkTokens int `yaml:"chunk_tokens"`
+	// OverlapTokens is repeated from the end of one excerpt at the start of the next
+	OverlapTokens int `yaml:"overlap_tokens"`
+	// BatchSize is the number of excerpts embedded per API request
+	BatchSize int `yaml:"batch_size"`
+}
+
+// ClusteringConfig configures the automatic clustering of attribute
+// embeddings into rag_clusters (kycctl recompute-clusters, or scheduled in
+// kycserver)
+type ClusteringConfig struct {
+	// Enabled runs the clustering job on a schedule in kycserver
+	Enabled bool `yaml:"enabled"`
+	// Interval is how often the scheduled job recomputes clusters
+	Interval time.Duration `yaml:"interval"`
+	// K is the number of clusters; 0 picks the best silhouette in [2, MaxK]
+	K int `yaml:"k"`
+	// MaxK bounds the automatic choice of K
+	MaxK int `yaml:"max_k"`
+	// LLMLabels names clusters with the chat model instead of keywords
+	LLMLabels bool `yaml:"llm_labels"`
+}
+
+// ReembeddingConfig configures the worker that re-generates ontology
+// embeddings whose text changed since they were generated
+type ReembeddingConfig struct {
+	// Enabled runs the worker in kycserver
+	Enabled bool `yaml:"enabled"`
+	// Interval is how often stale embeddings are looked for
+	Interval time.Duration `yaml:"interval"`
+	// BatchSize caps the entries of each kind re-embedded per tick (one
+	// embedding request per kind)
+	BatchSize int `yaml:"batch_size"`
+	// Unversioned re-embeds embeddings that predate content hashes instead
+	// of recording the current hash for them
+	Unversioned bool `yaml:"unversioned"`
+}
+
+// ModelLogConfig configures the logging of prompts and model responses of
+// the generation features. Model I/O may contain client data, so it is off by
+// default, redacted, expired after Retention and kept apart from the audit log.
+type ModelLogConfig struct {
+	// Enabled stores prompts and responses in model_io_log
+	Enabled bool `yaml:"enabled"`
+	// Responses stores model responses as well as prompts
+	Responses bool `yaml:"responses"`
+	// Redact masks e-mail addresses, phone numbers, IBANs and long numbers
+	// before storing
+	Redact bool `yaml:"redact"`
+	// Retention is how long entries are kept before they are purged
+	Retention time.Duration `yaml:"retention"`
+	// MaxChars truncates each stored prompt and response
+	MaxChars int `yaml:"max_chars"`
+}
+
+// AuditLogConfig configures how search requests are logged in
+// rag_audit_log and how query embeddings are kept there. The audit
+// analytics (popular queries, agent performance, audit stats) read query
+// text and timings only, so they work whatever is kept.
+type AuditLogConfig struct {
+	// QuerySampleRate is the fraction of successful search requests logged
+	// (0-1); failed searches are always logged
+	QuerySampleRate float64 `yaml:"query_sample_rate"`
+	// BatchSize is the most logged searches written per insert
+	BatchSize int `yaml:"batch_size"`
+	// FlushInterval is the longest a logged search waits to be written
+	FlushInterval time.Duration `yaml:"flush_interval"`
+	// QueueSize bounds the searches waiting to be written; searches logged
+	// while it is full are dropped rather than delaying the response
+	QueueSize int `yaml:"queue_size"`
+
+	// EmbeddingStorage is "full" (the whole vector), "reduced" (the first
+	// ReducedDimensions components, renormalized), "hash" (only a digest
+	// identifying repeated embeddings) or "none"
+	EmbeddingStorage string `yaml:"embedding_storage"`
+	// ReducedDimensions is the length of reduced embeddings
+	ReducedDimensions int `yaml:"reduced_dimensions"`
+	// CompactAfter converts full embeddings older than this to
+	// EmbeddingStorage; 0 leaves stored embeddings as they are
+	CompactAfter time.Duration `yaml:"compact_after"`
+}
+
+// RetentionConfig configures the retention job that archives and deletes
+// old rows of the audit tables (internal/retention, kycctl retention)
+type RetentionConfig struct {
+	// Enabled runs the job on a schedule in kycserver
+	Enabled bool `yaml:"enabled"`
+	// Interval is how often expired rows are looked for
+	Interval time.Duration `yaml:"interval"`
+	// DryRun counts and records what would be archived and deleted without
+	// touching it
+	DryRun bool `yaml:"dry_run"`
+	// BatchSize is the most rows archived to one object and deleted per
+	// transaction
+	BatchSize int `yaml:"batch_size"`
+	// Archive configures where expired rows are written before deletion
+	Archive RetentionArchiveConfig `yaml:"archive"`
+	// Policies sets how long the rows of each table are kept
+	Policies RetentionPolicies `yaml:"policies"`
+}
+
+// RetentionArchiveConfig locates the archive of expired rows, written as
+// gzipped JSON lines
+type RetentionArchiveConfig struct {
+	// Enabled archives rows before they are deleted; without it they are
+	// deleted outright
+	Enabled bool `yaml:"enabled"`
+	// Driver is local, s3 or minio (S3 with path-style addressing)
+	Driver string `yaml:"driver"`
+	// Dir is the root directory of the local driver
+	Dir string `yaml:"dir"`
+	// S3 locates the bucket of the s3 and minio drivers
+	S3 S3Config `yaml:"s3"`
+	// Prefix starts the key of every archive object
+	Prefix string `yaml:"prefix"`
+}
+
+// RetentionPolicies are the retention policies of the tables the job
+// manages
+type RetentionPolicies struct {
+	AuditLog   RetentionPolicy `yaml:"rag_audit_log"`
+	Amendments RetentionPolicy `yaml:"kyc_case_amendments"`
+	Feedback   RetentionPolicy `yaml:"rag_feedback"`
+}
+
+// RetentionPolicy is how long the rows of a table are kept
+type RetentionPolicy struct {
+	// MaxAge is the age after which rows expire; 0 keeps them forever
+	MaxAge time.Duration `yaml:"max_age"`
+	// KeepErrors keeps failed searches whatever their age (rag_audit_log
+	// only, as cleanup_old_audit_logs does)
+	KeepErrors bool `yaml:"keep_errors"`
+}
+
+// CaseLockConfig configures the advisory locks analysts take on a case
+// while amending it
+type CaseLockConfig struct {
+	// TTL is how long a lock lasts without a heartbeat; an expired lock can
+	// be taken over by another holder
+	TTL time.Duration `yaml:"ttl"`
+}
+
+// SigningConfig configures the digital signatures of approved case
+// versions (internal/signing, kycctl signature)
+type SigningConfig struct {
+	// Enabled signs the latest version of a case when it is approved; an
+	// approval that cannot be signed is refused
+	Enabled bool `yaml:"enabled"`
+	// Driver is local (a PEM private key file); KMS and HSM drivers are
+	// added with signing.RegisterDriver
+	Driver string `yaml:"driver"`
+	// KeyFile is the PEM private key (Ed25519, ECDSA or RSA) of the local
+	// driver
+	KeyFile string `yaml:"key_file"`
+	// KeyID names the key in signatures; empty uses its fingerprint
+	KeyID string `yaml:"key_id"`
+	// TrustedKeys are PEM public key files of retired signing keys, whose
+	// signatures still verify as trusted after a key rotation
+	TrustedKeys []string `yaml:"trusted_keys"`
+}
+
+// EncryptionConfig configures the envelope encryption of personal data in
+// the case attribute value store (internal/pii, kycctl encryption)
+type EncryptionConfig struct {
+	// Enabled encrypts captured values of personal data attributes
+	// (is_personal_data) and of Attributes as they are stored. Encrypted
+	// values are decrypted on read whether or not it is set.
+	Enabled bool `yaml:"enabled"`
+	// Driver is env (the keys below); KMS drivers are added with
+	// pii.RegisterDriver
+	Driver string `yaml:"driver"`
+	// KeyID is the key that wraps the data keys of new values; kycctl
+	// encryption rotate re-wraps values under other keys with it
+	KeyID string `yaml:"key_id"`
+	// Keys are the key encryption keys of the env driver as
+	// id:base64-of-32-bytes; retired keys stay listed until rotated out
+	Keys []string `yaml:"keys"`
+	// Attributes are encrypted in addition to personal data attributes
+	Attributes []string `yaml:"attributes"`
+}
+
+// DSRConfig configures data subject requests (internal/dsr, kycctl dsr)
+type DSRConfig struct {
+	// RecordRetention is how long the records of a case are kept after the
+	// business relationship ends (the case is declined or archived), as AML
+	// rules require; erasure leaves the records of open cases and of cases
+	// ended more recently untouched
+	RecordRetention time.Duration `yaml:"record_retention"`
+}
+
+// RankingConfig configures how attribute search orders its results
+type RankingConfig struct {
+	// FeedbackWeight blends aggregated feedback with vector similarity:
+	// (1-w)*similarity + w*feedback score; 0 ranks by similarity alone
+	FeedbackWeight float64 `yaml:"feedback_weight"`
+}
+
+// SearchConfig configures the trigram and full-text search of entities and
+// attributes (OntologyService SearchEntities and SearchAttributes)
+type SearchConfig struct {
+	// MatchThreshold is the minimum trigram word similarity (0..1) of a
+	// match; requests may override it with similarity_threshold
+	MatchThreshold float64 `yaml:"match_threshold"`
+}
+
+// EmbeddingCacheConfig configures the cache of query embeddings, keyed by
+// normalized query text and model, in memory and optionally in Postgres
+type EmbeddingCacheConfig struct {
+	// Enabled serves repeated queries without calling the embedding API
+	Enabled bool `yaml:"enabled"`
+	// Size is the number of embeddings kept in memory (least recently used
+	// are evicted)
+	Size int `yaml:"size"`
+	// TTL is how long a cached embedding is used
+	TTL time.Duration `yaml:"ttl"`
+	// Persist shares the cache across processes and restarts in
+	// rag_query_embedding_cache
+	Persist bool `yaml:"persist"`
+}
+
+// ResponseCacheConfig caches the responses of hot read endpoints of
+// kycserver (attribute search, stats) to absorb dashboard polling
+type ResponseCacheConfig struct {
+	// Enabled serves repeated requests from the cache until TTL
+	Enabled bool `yaml:"enabled"`
+	// Driver is memory (per process) or redis (shared by replicas)
+	Driver string `yaml:"driver"`
+	// TTL is how long a response is served from the cache
+	TTL time.Duration `yaml:"ttl"`
+	// Size is the number of responses the memory driver keeps (least
+	// recently used are evicted)
+	Size int `yaml:"size"`
+	// Redis locates the server of the redis driver
+	Redis RedisConfig `yaml:"redis"`
+}
+
+// RedisConfig locates a Redis server
+type RedisConfig struct {
+	Addr     string `yaml:"addr"`
+	Password string `yaml:"password"`
+	DB       int    `yaml:"db"`
+	// Prefix starts every key written
+	Prefix string `yaml:"prefix"`
+	// Timeout bounds connecting and each command
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// EmbeddingSpacesConfig configures the secondary embedding spaces kept
+// alongside the primary one for comparing embedding models
+type EmbeddingSpacesConfig struct {
+	// DualWrite names the spaces that receive an embedding whenever the
+	// primary space is written (backfill, re-embedding); the re-embedding
+	// worker also fills in what they are missing
+	DualWrite []string `yaml:"dual_write"`
+}
+
+// EmbeddingTierConfig configures the archiving of rarely used attribute
+// embeddings to a cold tier outside the vector index
+type EmbeddingTierConfig struct {
+	// ArchiveAfter archives the embedding of an attribute no case references
+	// and no search has returned for this long; 0 disables archiving
+	ArchiveAfter time.Duration `yaml:"archive_after"`
+	// PromoteHits is how many search hits since archiving bring an embedding
+	// back to the hot tier
+	PromoteHits int `yaml:"promote_hits"`
+	// Interval is how often kycserver archives and promotes embeddings
+	Interval time.Duration `yaml:"interval"`
+	// BatchSize bounds the embeddings archived per run
+	BatchSize int `yaml:"batch_size"`
+}
+
+// WebhookConfig configures the event notifications posted to downstream
+// systems (internal/events). Events are queued in webhook_deliveries by the
+// process that raises them and posted by the dispatcher in kycserver.
+type WebhookConfig struct {
+	// Endpoints maps endpoint name to the URL events are posted to
+	Endpoints map[string]string `yaml:"endpoints"`
+	// Secrets maps endpoint name to the key payloads are signed with
+	// (HMAC-SHA256); endpoints without one receive unsigned payloads
+	Secrets map[string]string `yaml:"secrets"`
+	// Events maps endpoint name to the event types it receives; an endpoint
+	// not listed receives every event
+	Events map[string][]string `yaml:"events"`
+	// MaxAttempts is how many times a delivery is tried before it fails;
+	// the retry settings apply to the Kafka and NATS sinks as well
+	MaxAttempts int `yaml:"max_attempts"`
+	// RetryBackoff is the wait after the first failed attempt, doubled after
+	// each further one
+	RetryBackoff time.Duration `yaml:"retry_backoff"`
+	// Timeout bounds each HTTP attempt
+	Timeout time.Duration `yaml:"timeout"`
+	// Interval is how often the dispatcher looks for due deliveries
+	Interval time.Duration `yaml:"interval"`
+}
+
+// EventsConfig selects the sinks events are published to. Every sink
+// receives the same JSON envelope through the delivery outbox.
+type EventsConfig struct {
+	// Sinks lists the enabled sinks: webhook (the webhooks endpoints),
+	// kafka, nats and siem
+	Sinks []string    `yaml:"sinks"`
+	Kafka KafkaConfig `yaml:"kafka"`
+	NATS  NATSConfig  `yaml:"nats"`
+	SIEM  SIEMConfig  `yaml:"siem"`
+}
+
+// KafkaConfig locates the Kafka topic events are produced to, keyed by
+// subject (usually the case name) so a case's events stay in order
+type KafkaConfig struct {
+	Brokers []string `yaml:"brokers"`
+	Topic   string   `yaml:"topic"`
+}
+
+// NATSConfig locates the NATS server events are published to, under
+// SubjectPrefix followed by the event type (kyc.events.case.approved)
+type NATSConfig struct {
+	URL           string `yaml:"url"`
+	SubjectPrefix string `yaml:"subject_prefix"`
+}
+
+// SIEMConfig locates the syslog receiver compliance-relevant events are
+// sent to for a SOC (Splunk, QRadar, ...), one syslog message per event
+type SIEMConfig struct {
+	// Network is udp, tcp or tls; tcp and tls messages are newline framed
+	Network string `yaml:"network"`
+	// Addr is the host:port of the syslog receiver
+	Addr string `yaml:"addr"`
+	// Format of the syslog message body: cef (ArcSight Common Event
+	// Format) or json (the event envelope)
+	Format string `yaml:"format"`
+	// Facility is the syslog facility (13 is log audit, 16-23 local0-7)
+	Facility int `yaml:"facility"`
+	// AppName identifies the sender in the syslog header
+	AppName string `yaml:"app_name"`
+	// Events enables each event type; types not listed are not sent
+	Events map[string]bool `yaml:"events"`
+}
+
+// Sends reports whether events of a type are sent to the SIEM
+func (s SIEMConfig) Sends(eventType string) bool {
+	return s.Events[eventType]
+}
+
+// HasSink reports whether events are published to a sink
+func (e EventsConfig) HasSink(sink string) bool {
+	for _, s := range e.Sinks {
+		if s == sink {
+			return true
+		}
+	}
+	return false
+}
+
+// LogConfig configures structured logging
+type LogConfig struct {
+	// Format is "text" or "json"
+	Format string `yaml:"format"`
+	// Level is "debug", "info", "warn" or "error"
+	Level string `yaml:"level"`
+}
+
+// Default returns the built-in defaults
+func Default() *Config {
+	return &Config{
+		Database: DatabaseConfig{
+			Host:    "localhost",
+			Port:    5432,
+			User:    "postgres",
+			Name:    "kyc_dsl",
+			SSLMode: "disable",
+
+			MaxConns:          25,
+			MinConns:          5,
+			MaxConnLifetime:   time.Hour,
+			MaxConnIdleTime:   30 * time.Minute,
+			HealthCheckPeriod: time.Minute,
+		},
+		Server: ServerConfig{
+			Port:         8080,
+			ReadTimeout:  15 * time.Second,
+			WriteTimeout: 15 * time.Second,
+			IdleTimeout:  60 * time.Second,
+		},
+		DataService: DataServiceConfig{
+			ListenAddr:  ":50070",
+			Addr:        "localhost:50070",
+			MetricsAddr: ":9170",
+		},
+		GRPC: GRPCConfig{
+			DefaultTimeout: 30 * time.Second,
+			StreamTimeout:  10 * time.Minute,
+			MaxTimeout:     5 * time.Minute,
+			MaxFieldBytes:  1 << 20,
+			MaxLimit:       10000,
+		},
+		Drain: DrainConfig{
+			Timeout: 30 * time.Second,
+		},
+		Health: HealthConfig{
+			Timeout:  2 * time.Second,
+			CacheTTL: 5 * time.Second,
+			Optional: []string{"embeddings", "rust_dsl"},
+		},
+		RustDSL: RustDSLConfig{
+			Addr:             "localhost:50060",
+			DialTimeout:      5 * time.Second,
+			Timeout:          10 * time.Second,
+			AmendTimeout:     30 * time.Second,
+			MaxAttempts:      3,
+			RetryBackoff:     200 * time.Millisecond,
+			BreakerThreshold: 5,
+			BreakerCooldown:  30 * time.Second,
+			Fallback:         true,
+		},
+		OpenAI: OpenAIConfig{
+			ChatModel:           "gpt-4o-mini",
+			EmbeddingModel:      "text-embedding-3-large",
+			EmbeddingDimensions: 1536,
+		},
+		Auth: AuthConfig{
+			RolesClaim:  "roles",
+			TenantClaim: "tenant",
+			ScopesClaim: "scope",
+			RoleScopes:  map[string][]string{"reviewer": {"pii:read"}},
+		},
+		RateLimit: RateLimitConfig{
+			RequestsPerMinute: 60,
+			Burst:             20,
+			Paths:             []string{"/rag/"},
+		},
+		Watchlist: WatchlistConfig{
+			Enabled:        true,
+			Interval:       time.Minute,
+			DefaultCadence: 24 * time.Hour,
+			BatchSize:      50,
+			MatchThreshold: 0.85,
+		},
+		MaterialChange: MaterialChangeConfig{
+			Enabled:            true,
+			Interval:           30 * time.Second,
+			OwnershipThreshold: 25,
+			OwnershipDelta:     10,
+		},
+		Reevaluation: ReevaluationConfig{
+			Enabled:   true,
+			Interval:  10 * time.Second,
+			BatchSize: 50,
+		},
+		Risk: RiskConfig{
+			Weights: map[string]float64{
+				"SANCTIONED_COUNTRY_FLAG":     30,
+				"HIGH_RISK_JURISDICTION_FLAG": 20,
+				"PEP_EXPOSURE_FLAG":           20,
+				"JURISDICTION_RISK_SCORE":     10,
+				"UBO_CONCENTRATION_SCORE":     10,
+				"COMPLEX_STRUCTURE_FLAG":      10,
+			},
+			Scales: map[string]float64{
+				"JURISDICTION_RISK_SCORE": 100,
+				"UBO_CONCENTRATION_SCORE": 100,
+			},
+			Bands: map[string]float64{
+				"CRITICAL": 70,
+				"HIGH":     45,
+				"MEDIUM":   20,
+			},
+		},
+		Screening: ScreeningConfig{
+			MatchThreshold:     0.85,
+			ConfirmedThreshold: 0.97,
+			API:                ScreeningAPIConfig{Timeout: 10 * time.Second},
+			PEP:                ScreeningAPIConfig{Timeout: 10 * time.Second},
+			AdverseMedia: AdverseMediaConfig{
+				Timeout: 10 * time.Second,
+				Terms: []string{
+					"fraud", "money laundering", "bribery", "corruption", "embezzlement",
+					"sanctions", "terrorism", "indicted", "convicted", "investigation",
+				},
+				MaxArticles: 20,
+			},
+		},
+		Evidence: EvidenceConfig{
+			Driver:    "local",
+			Dir:       "./evidence",
+			MaxSizeMB: 25,
+			S3:        S3Config{Region: "us-east-1"},
+			Expiry: EvidenceExpiryConfig{
+				Interval:    time.Hour,
+				WarningDays: 30,
+				SummaryHour: 8,
+			},
+			Extraction: TextExtractionConfig{
+				Interval:       30 * time.Second,
+				BatchSize:      10,
+				MaxAttempts:    3,
+				Extractors:     []string{"builtin"},
+				PDFToText:      "pdftotext",
+				Tesseract:      "tesseract",
+				Languages:      "eng",
+				CommandTimeout: 2 * time.Minute,
+				API:            OCRAPIConfig{Timeout: time.Minute},
+			},
+			Attributes: AttributeExtractionConfig{
+				SectionsPerAttribute: 4,
+				MaxAttributes:        20,
+			},
+		},
+		Ingestion: IngestionConfig{
+			ChunkTokens:   400,
+			OverlapTokens: 50,
+			BatchSize:     16,
+		},
+		Clustering: ClusteringConfig{
+			Interval: 24 * time.Hour,
+			MaxK:     12,
+		},
+		Reembedding: ReembeddingConfig{
+			Interval:  time.Hour,
+			BatchSize: 50,
+		},
+		ModelLog: ModelLogConfig{
+			Responses: true,
+			Redact:    true,
+			Retention: 30 * 24 * time.Hour,
+			MaxChars:  20000,
+		},
+		AuditLog: AuditLogConfig{
+			QuerySampleRate:   1,
+			BatchSize:         100,
+			FlushInterval:     2 * time.Second,
+			QueueSize:         10000,
+			EmbeddingStorage:  "full",
+			ReducedDimensions: 256,
+		},
+		Retention: RetentionConfig{
+			Interval:  24 * time.Hour,
+			BatchSize: 5000,
+			Archive: RetentionArchiveConfig{
+				Enabled: true,
+				Driver:  "local",
+				Dir:     "./archive",
+				S3:      S3Config{Region: "us-east-1"},
+				Prefix:  "retention",
+			},
+			Policies: RetentionPolicies{
+				AuditLog: RetentionPolicy{MaxAge: 90 * 24 * time.Hour, KeepErrors: true},
+			},
+		},
+		CaseLock: CaseLockConfig{
+			TTL: 15 * time.Minute,
+		},
+		Signing: SigningConfig{
+			Driver: "local",
+		},
+		Encryption: EncryptionConfig{
+			Driver: "env",
+		},
+		DSR: DSRConfig{
+			RecordRetention: 5 * 365 * 24 * time.Hour,
+		},
+		Ranking: RankingConfig{
+			FeedbackWeight: 0.2,
+		},
+		Search: SearchConfig{
+			MatchThreshold: 0.3,
+		},
+		EmbeddingCache: EmbeddingCacheConfig{
+			Enabled: true,
+			Size:    10000,
+			TTL:     7 * 24 * time.Hour,
+			Persist: true,
+		},
+		ResponseCache: ResponseCacheConfig{
+			Driver: "memory",
+			TTL:    30 * time.Second,
+			Size:   1000,
+			Redis: RedisConfig{
+				Addr:    "localhost:6379",
+				Prefix:  "kyc:response:",
+				Timeout: 500 * time.Millisecond,
+			},
+		},
+		EmbeddingTiers: EmbeddingTierConfig{
+			PromoteHits: 1,
+			Interval:    15 * time.Minute,
+			BatchSize:   500,
+		},
+		Webhooks: WebhookConfig{
+			MaxAttempts:  8,
+			RetryBackoff: 30 * time.Second,
+			Timeout:      10 * time.Second,
+			Interval:     5 * time.Second,
+		},
+		Events: EventsConfig{
+			Sinks: []string{"webhook"},
+			Kafka: KafkaConfig{Topic: "kyc.events"},
+			NATS:  NATSConfig{URL: "nats://localhost:4222", SubjectPrefix: "kyc.events"},
+			SIEM: SIEMConfig{
+				Network:  "udp",
+				Addr:     "localhost:514",
+				Format:   "cef",
+				Facility: 13,
+				AppName:  "kyc-dsl",
+				Events: map[string]bool{
+					"validation.failed": true,
+					"case.approved":     true,
+					"case.declined":     true,
+					"evidence.accessed": true,
+					"admin.action":      true,
+				},
+			},
+		},
+		Log: LogConfig{
+			Format: "text",
+			Level:  "info",
+		},
+	}
+}
+
+var (
+	currentMu sync.Mutex
+	current   *Config
+)
+
+// Load resolves the configuration for a binary from defaults, the YAML file,
+// the environment and args. It returns the arguments left after flag parsing
+// and makes the result available through Current.
+func Load(name string, args []string) (*Config, []string, error) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	configFile := fs.String("config", "", "YAML configuration file (env KYC_CONFIG)")
+	port := fs.Int("port", 0, "HTTP port for kycserver (env PORT)")
+	databaseURL := fs.String("database-url", "", "PostgreSQL connection URL (env DATABASE_URL)")
+	listenAddr := fs.String("listen", "", "gRPC listen address for dataserver (env DATA_SERVICE_LISTEN_ADDR)")
+	dataServiceAddr := fs.String("data-service-addr", "", "data service address (env DATA_SERVICE_ADDR)")
+	rustDSLAddr := fs.String("rust-dsl-addr", "", "Rust DSL service address (env RUST_DSL_SERVICE_ADDR)")
+	metricsAddr := fs.String("metrics-addr", "", "dataserver metrics address (env METRICS_ADDR)")
+	regionName := fs.String("region", "", "region of this process (env KYC_REGION)")
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	path := *configFile
+	if path == "" {
+		path = os.Getenv("KYC_CONFIG")
+	}
+	cfg, err := build(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Only flags given explicitly override lower layers
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "port":
+			cfg.Server.Port = *port
+		case "database-url":
+			cfg.Database.URL = *databaseURL
+		case "listen":
+			cfg.DataService.ListenAddr = *listenAddr
+		case "data-service-addr":
+			cfg.DataService.Addr = *dataServiceAddr
+		case "rust-dsl-addr":
+			cfg.RustDSL.Addr = *rustDSLAddr
+		case "metrics-addr":
+			cfg.DataService.MetricsAddr = *metricsAddr
+		case "region":
+			cfg.Region.Self = strings.ToLower(*regionName)
+		}
+	})
+
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	currentMu.Lock()
+	current = cfg
+	currentMu.Unlock()
+
+	return cfg, fs.Args(), nil
+}
+
+// Current returns the configuration loaded by the running binary. Packages
+// used outside a binary that called Load get defaults, KYC_CONFIG and the
+// environment.
+func Current() *Config {
+	currentMu.Lock()
+	defer currentMu.Unlock()
+
+	if current == nil {
+		cfg, err := build(os.Getenv("KYC_CONFIG"))
+		if err == nil {
+			err = cfg.Validate()
+		}
+		if err != nil {
+			log.Printf("⚠️  Invalid configuration: %v", err)
+			if cfg == nil {
+				cfg = Default()
+			}
+		}
+		current = cfg
+	}
+	return current
+}
+
+// build layers defaults, the YAML file at path (if any) and the environment
+func build(path string) (*Config, error) {
+	cfg := Default()
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config file: %w", err)
+		}
+		if err := yaml.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+		cfg.File = path
+	}
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Validate checks the configuration for missing or inconsistent values
+func (c *Config) Validate() error {
+	var errs []error
+
+	if c.Database.URL == "" && (c.Database.Host == "" || c.Database.Name == "") {
+		errs = append(errs, errors.New("database: url or host and name are required"))
+	}
+	if c.Database.Port <= 0 || c.Database.Port > 65535 {
+		errs = append(errs, fmt.Errorf("database: invalid port %d", c.Database.Port))
+	}
+	if c.Database.MaxConns <= 0 {
+		errs = append(errs, fmt.Errorf("database: max_conns must be positive, got %d", c.Database.MaxConns))
+	}
+	if c.Database.MinConns < 0 || c.Database.MinConns > c.Database.MaxConns {
+		errs = append(errs, fmt.Errorf("database: min_conns must be between 0 and max_conns, got %d", c.Database.MinConns))
+	}
+	if c.Server.Port <= 0 || c.Server.Port > 65535 {
+		errs = append(errs, fmt.Errorf("server: invalid port %d", c.Server.Port))
+	}
+	for _, proxy := range c.Server.TrustedProxies {
+		if _, _, err := net.ParseCIDR(proxy); err != nil && net.ParseIP(proxy) == nil {
+			errs = append(errs, fmt.Errorf("server: trusted proxy %q is not an IP or CIDR", proxy))
+		}
+	}
+	if c.DataService.ListenAddr == "" || c.DataService.Addr == "" {
+		errs = append(errs, errors.New("data_service: listen_addr and addr are required"))
+	}
+	if c.RustDSL.Addr == "" {
+		errs = append(errs, errors.New("rust_dsl: addr is required"))
+	}
+	if r := c.RustDSL; r.DialTimeout <= 0 || r.Timeout <= 0 || r.AmendTimeout <= 0 || r.RetryBackoff <= 0 || r.BreakerCooldown <= 0 {
+		errs = append(errs, errors.New("rust_dsl: dial_timeout, timeout, amend_timeout, retry_backoff and breaker_cooldown must be positive"))
+	}
+	if c.RustDSL.MaxAttempts <= 0 || c.RustDSL.BreakerThreshold <= 0 {
+		errs = append(errs, errors.New("rust_dsl: max_attempts and breaker_threshold must be positive"))
+	}
+	if c.Shadow.SampleRate < 0 || c.Shadow.SampleRate > 1 {
+		errs = append(errs, fmt.Errorf("shadow: sample_rate must be between 0 and 1, got %g", c.Shadow.SampleRate))
+	}
+	if c.Watchlist.Interval <= 0 || c.Watchlist.DefaultCadence < time.Minute {
+		errs = append(errs, errors.New("watchlist: interval must be positive and default_cadence at least 1m"))
+	}
+	if c.Watchlist.BatchSize <= 0 {
+		errs = append(errs, fmt.Errorf("watchlist: batch_size must be positive, got %d", c.Watchlist.BatchSize))
+	}
+	if c.Watchlist.MatchThreshold <= 0 || c.Watchlist.MatchThreshold > 1 {
+		errs = append(errs, fmt.Errorf("watchlist: match_threshold must be in (0, 1], got %g", c.Watchlist.MatchThreshold))
+	}
+	if c.MaterialChange.Interval <= 0 {
+		errs = append(errs, errors.New("material_change: interval must be positive"))
+	}
+	if c.MaterialChange.OwnershipThreshold <= 0 || c.MaterialChange.OwnershipThreshold > 100 || c.MaterialChange.OwnershipDelta <= 0 {
+		errs = append(errs, errors.New("material_change: ownership_threshold must be in (0, 100] and ownership_delta positive"))
+	}
+	if c.Reevaluation.Interval <= 0 || c.Reevaluation.BatchSize <= 0 {
+		errs = append(errs, errors.New("reevaluation: interval and batch_size must be positive"))
+	}
+	var riskWeight float64
+	for attr, w := range c.Risk.Weights {
+		if w < 0 {
+			errs = append(errs, fmt.Errorf("risk: weight of %s must not be negative, got %g", attr, w))
+		}
+		riskWeight += w
+	}
+	if riskWeight <= 0 {
+		errs = append(errs, errors.New("risk: at least one weight must be positive"))
+	}
+	for attr, max := range c.Risk.Scales {
+		if max <= 0 {
+			errs = append(errs, fmt.Errorf("risk: scale of %s must be positive, got %g", attr, max))
+		}
+	}
+	for rating, min := range c.Risk.Bands {
+		if min < 0 || min > 100 {
+			errs = append(errs, fmt.Errorf("risk: band %s must be between 0 and 100, got %g", rating, min))
+		}
+	}
+	if c.Screening.MatchThreshold <= 0 || c.Screening.MatchThreshold > 1 {
+		errs = append(errs, fmt.Errorf("screening: match_threshold must be in (0, 1], got %g", c.Screening.MatchThreshold))
+	}
+	if c.Screening.ConfirmedThreshold < c.Screening.MatchThreshold || c.Screening.ConfirmedThreshold > 1 {
+		errs = append(errs, fmt.Errorf("screening: confirmed_threshold must be in [match_threshold, 1], got %g", c.Screening.ConfirmedThreshold))
+	}
+	if c.Screening.API.URL != "" && c.Screening.API.Timeout <= 0 {
+		errs = append(errs, errors.New("screening: api.timeout must be positive"))
+	}
+	if c.Screening.PEP.URL != "" && c.Screening.PEP.Timeout <= 0 {
+		errs = append(errs, errors.New("screening: pep.timeout must be positive"))
+	}
+	if c.Screening.AdverseMedia.URL != "" {
+		if c.Screening.AdverseMedia.Timeout <= 0 || c.Screening.AdverseMedia.MaxArticles <= 0 {
+			errs = append(errs, errors.New("screening: adverse_media.timeout and max_articles must be positive"))
+		}
+		if len(c.Screening.AdverseMedia.Terms) == 0 {
+			errs = append(errs, errors.New("screening: adverse_media.terms must not be empty"))
+		}
+	}
+	switch c.Evidence.Driver {
+	case "local":
+		if c.Evidence.Dir == "" {
+			errs = append(errs, errors.New("evidence: dir is required by the local driver"))
+		}
+	case "s3", "minio":
+		if c.Evidence.S3.Bucket == "" || c.Evidence.S3.Region == "" {
+			errs = append(errs, fmt.Errorf("evidence: s3.bucket and s3.region are required by the %s driver", c.Evidence.Driver))
+		}
+		if c.Evidence.Driver == "minio" && c.Evidence.S3.Endpoint == "" {
+			errs = append(errs, errors.New("evidence: s3.endpoint is required by the minio driver"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("evidence: unknown driver %q (expected local, s3 or minio)", c.Evidence.Driver))
+	}
+	if c.Evidence.MaxSizeMB <= 0 {
+		errs = append(errs, fmt.Errorf("evidence: max_size_mb must be positive, got %d", c.Evidence.MaxSizeMB))
+	}
+	if x := c.Evidence.Expiry; x.Interval <= 0 || x.WarningDays < 0 || x.SummaryHour < 0 || x.SummaryHour > 23 {
+		errs = append(errs, errors.New("evidence: expiry needs a positive interval, warning_days >= 0 and summary_hour 0-23"))
+	}
+	if x := c.Evidence.Extraction; x.Interval <= 0 || x.BatchSize <= 0 || x.MaxAttempts <= 0 || x.CommandTimeout <= 0 {
+		errs = append(errs, errors.New("evidence: extraction interval, batch_size, max_attempts and command_timeout must be positive"))
+	}
+	for _, name := range c.Evidence.Extraction.Extractors {
+		switch name {
+		case "builtin", "pdftotext", "tesseract":
+		case "api":
+			if c.Evidence.Extraction.API.URL == "" {
+				errs = append(errs, errors.New("evidence: extraction.api.url is required by the api extractor"))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("evidence: unknown extractor %q (expected builtin, pdftotext, tesseract or api)", name))
+		}
+	}
+	if x := c.Evidence.Attributes; x.SectionsPerAttribute <= 0 || x.MaxAttributes <= 0 {
+		errs = append(errs, errors.New("evidence: attributes.sections_per_attribute and max_attributes must be positive"))
+	}
+	if c.Ingestion.ChunkTokens <= 0 || c.Ingestion.BatchSize <= 0 {
+		errs = append(errs, errors.New("ingestion: chunk_tokens and batch_size must be positive"))
+	}
+	if c.Ingestion.OverlapTokens < 0 || c.Ingestion.OverlapTokens >= c.Ingestion.ChunkTokens {
+		errs = append(errs, fmt.Errorf("ingestion: overlap_tokens must be in [0, chunk_tokens), got %d", c.Ingestion.OverlapTokens))
+	}
+	if c.Clustering.Interval <= 0 || c.Clustering.K < 0 || c.Clustering.MaxK < 2 {
+		errs = append(errs, errors.New("clustering: interval must be positive, k at least 0 and max_k at least 2"))
+	}
+	if c.Reembedding.Interval <= 0 || c.Reembedding.BatchSize <= 0 {
+		errs = append(errs, errors.New("reembedding: interval and batch_size must be positive"))
+	}
+	if c.ModelLog.Retention <= 0 || c.ModelLog.MaxChars <= 0 {
+		errs = append(errs, errors.New("model_log: retention and max_chars must be positive"))
+	}
+	if c.OpenAI.EmbeddingModel == "" || c.OpenAI.EmbeddingDimensions <= 0 {
+		errs = append(errs, errors.New("openai: embedding_model must be set and embedding_dimensions must be positive"))
+	}
+	if c.EmbeddingCache.Size <= 0 || c.EmbeddingCache.TTL <= 0 {
+		errs = append(errs, errors.New("embedding_cache: size and ttl must be positive"))
+	}
+	if c.ResponseCache.Enabled {
+		switch {
+		case c.ResponseCache.TTL <= 0:
+			errs = append(errs, errors.New("response_cache: ttl must be positive"))
+		case c.ResponseCache.Driver == "memory" && c.ResponseCache.Size <= 0:
+			errs = append(errs, errors.New("response_cache: size must be positive"))
+		case c.ResponseCache.Driver == "redis" && c.ResponseCache.Redis.Addr == "":
+			errs = append(errs, errors.New("response_cache: redis driver requires redis.addr"))
+		case c.ResponseCache.Driver != "memory" && c.ResponseCache.Driver != "redis":
+			errs = append(errs, fmt.Errorf("response_cache: driver must be memory or redis, got %q", c.ResponseCache.Driver))
+		}
+	}
+	if c.GRPC.DefaultTimeout <= 0 || c.GRPC.StreamTimeout <= 0 || c.GRPC.MaxTimeout <= 0 {
+		errs = append(errs, errors.New("grpc: default_timeout, stream_timeout and max_timeout must be positive"))
+	}
+	if c.GRPC.MaxFieldBytes <= 0 || c.GRPC.MaxLimit <= 0 {
+		errs = append(errs, errors.New("grpc: max_field_bytes and max_limit must be positive"))
+	}
+	if c.Drain.Delay < 0 || c.Drain.Timeout <= 0 {
+		errs = append(errs, errors.New("drain: delay must not be negative and timeout must be positive"))
+	}
+	if c.Health.Timeout <= 0 || c.Health.CacheTTL < 0 {
+		errs = append(errs, errors.New("health: timeout must be positive and cache_ttl must not be negative"))
+	}
+	for _, name := range c.Health.Optional {
+		switch name {
+		case "database", "pgvector", "embeddings", "rust_dsl":
+		default:
+			errs = append(errs, fmt.Errorf("health: unknown optional check %q (expected database, pgvector, embeddings or rust_dsl)", name))
+		}
+	}
+	if c.Ranking.FeedbackWeight < 0 || c.Ranking.FeedbackWeight > 1 {
+		errs = append(errs, fmt.Errorf("ranking: feedback_weight must be between 0 and 1, got %g", c.Ranking.FeedbackWeight))
+	}
+	switch c.AuditLog.EmbeddingStorage {
+	case "full", "reduced", "hash", "none":
+	default:
+		errs = append(errs, fmt.Errorf("audit_log: embedding_storage must be full, reduced, hash or none, got %q", c.AuditLog.EmbeddingStorage))
+	}
+	if c.AuditLog.ReducedDimensions <= 0 || c.AuditLog.CompactAfter < 0 {
+		errs = append(errs, errors.New("audit_log: reduced_dimensions must be positive and compact_after must not be negative"))
+	}
+	if c.AuditLog.QuerySampleRate < 0 || c.AuditLog.QuerySampleRate > 1 {
+		errs = append(errs, fmt.Errorf("audit_log: query_sample_rate must be between 0 and 1, got %g", c.AuditLog.QuerySampleRate))
+	}
+	if c.AuditLog.BatchSize <= 0 || c.AuditLog.FlushInterval <= 0 || c.AuditLog.QueueSize <= 0 {
+		errs = append(errs, errors.New("audit_log: batch_size, flush_interval and queue_size must be positive"))
+	}
+	if c.Retention.Interval <= 0 || c.Retention.BatchSize <= 0 {
+		errs = append(errs, errors.New("retention: interval and batch_size must be positive"))
+	}
+	for name, p := range map[string]RetentionPolicy{
+		"rag_audit_log":       c.Retention.Policies.AuditLog,
+		"kyc_case_amendments": c.Retention.Policies.Amendments,
+		"rag_feedback":        c.Retention.Policies.Feedback,
+	} {
+		if p.MaxAge < 0 {
+			errs = append(errs, fmt.Errorf("retention: policies.%s.max_age must not be negative", name))
+		}
+	}
+	if a := c.Retention.Archive; a.Enabled {
+		switch a.Driver {
+		case "local":
+			if a.Dir == "" {
+				errs = append(errs, errors.New("retention: archive.dir is required by the local driver"))
+			}
+		case "s3", "minio":
+			if a.S3.Bucket == "" || a.S3.Region == "" {
+				errs = append(errs, fmt.Errorf("retention: archive.s3.bucket and archive.s3.region are required by the %s driver", a.Driver))
+			}
+			if a.Driver == "minio" && a.S3.Endpoint == "" {
+				errs = append(errs, errors.New("retention: archive.s3.endpoint is required by the minio driver"))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("retention: unknown archive driver %q (expected local, s3 or minio)", a.Driver))
+		}
+	}
+	if c.CaseLock.TTL <= 0 {
+		errs = append(errs, errors.New("case_lock: ttl must be positive"))
+	}
+	if c.Signing.Enabled {
+		if c.Signing.Driver == "" {
+			errs = append(errs, errors.New("signing: driver is required"))
+		}
+		if c.Signing.Driver == "local" && c.Signing.KeyFile == "" {
+			errs = append(errs, errors.New("signing: key_file is required by the local driver"))
+		}
+	}
+	if c.Encryption.Enabled {
+		if c.Encryption.Driver == "" || c.Encryption.KeyID == "" {
+			errs = append(errs, errors.New("encryption: driver and key_id are required"))
+		}
+		if c.Encryption.Driver == "env" && len(c.Encryption.Keys) == 0 {
+			errs = append(errs, errors.New("encryption: keys are required by the env driver"))
+		}
+	}
+	if c.DSR.RecordRetention < 0 {
+		errs = append(errs, errors.New("dsr: record_retention must not be negative"))
+	}
+	if c.EmbeddingTiers.ArchiveAfter < 0 || c.EmbeddingTiers.PromoteHits <= 0 ||
+		c.EmbeddingTiers.Interval <= 0 || c.EmbeddingTiers.BatchSize <= 0 {
+		errs = append(errs, errors.New("embedding_tiers: archive_after must not be negative; promote_hits, interval and batch_size must be positive"))
+	}
+	if c.Webhooks.MaxAttempts <= 0 || c.Webhooks.RetryBackoff <= 0 ||
+		c.Webhooks.Timeout <= 0 || c.Webhooks.Interval <= 0 {
+		errs = append(errs, errors.New("webhooks: max_attempts, retry_backoff, timeout and interval must be positive"))
+	}
+	for name, u := range c.Webhooks.Endpoints {
+		if parsed, err := url.Parse(u); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			errs = append(errs, fmt.Errorf("webhooks: endpoint %q must be an http(s) URL, got %q", name, u))
+		}
+	}
+	for name := range c.Webhooks.Events {
+		if _, ok := c.Webhooks.Endpoints[name]; !ok {
+			errs = append(errs, fmt.Errorf("webhooks: events listed for unknown endpoint %q", name))
+		}
+	}
+	for _, sink := range c.Events.Sinks {
+		switch sink {
+		case "webhook", "kafka", "nats", "siem":
+		default:
+			errs = append(errs, fmt.Errorf("events: unknown sink %q (expected webhook, kafka, nats or siem)", sink))
+		}
+	}
+	if c.Events.HasSink("kafka") && (len(c.Events.Kafka.Brokers) == 0 || c.Events.Kafka.Topic == "") {
+		errs = append(errs, errors.New("events: kafka sink requires kafka.brokers and kafka.topic"))
+	}
+	if c.Events.HasSink("nats") && (c.Events.NATS.URL == "" || c.Events.NATS.SubjectPrefix == "") {
+		errs = append(errs, errors.New("events: nats sink requires nats.url and nats.subject_prefix"))
+	}
+	if c.Events.HasSink("siem") {
+		x := c.Events.SIEM
+		switch {
+		case x.Network != "udp" && x.Network != "tcp" && x.Network != "tls":
+			errs = append(errs, fmt.Errorf("events: siem.network must be udp, tcp or tls, got %q", x.Network))
+		case x.Addr == "":
+			errs = append(errs, errors.New("events: siem sink requires siem.addr"))
+		case x.Format != "cef" && x.Format != "json":
+			errs = append(errs, fmt.Errorf("events: siem.format must be cef or json, got %q", x.Format))
+		case x.Facility < 0 || x.Facility > 23:
+			errs = append(errs, fmt.Errorf("events: siem.facility must be 0-23, got %d", x.Facility))
+		}
+	}
+	if c.Search.MatchThreshold <= 0 || c.Search.MatchThreshold > 1 {
+		errs = append(errs, fmt.Errorf("search: match_threshold must be in (0, 1], got %g", c.Search.MatchThreshold))
+	}
+	if c.Auth.JWKSURL != "" && (c.Auth.Issuer == "" || c.Auth.Audience == "") {
+		errs = append(errs, errors.New("auth: issuer and audience are required when jwks_url is set"))
+	}
+	if c.RateLimit.Enabled {
+		if c.RateLimit.RequestsPerMinute <= 0 {
+			errs = append(errs, fmt.Errorf("rate_limit: requests_per_minute must be positive, got %g", c.RateLimit.RequestsPerMinute))
+		}
+		if c.RateLimit.Burst < 1 {
+			errs = append(errs, fmt.Errorf("rate_limit: burst must be at least 1, got %d", c.RateLimit.Burst))
+		}
+	}
+	if c.RateLimit.DailyQuota < 0 {
+		errs = append(errs, fmt.Errorf("rate_limit: daily_quota must not be negative, got %d", c.RateLimit.DailyQuota))
+	}
+	switch c.Log.Format {
+	case "text", "json":
+	default:
+		errs = append(errs, fmt.Errorf("log: format must be text or json, got %q", c.Log.Format))
+	}
+	switch strings.ToLower(c.Log.Level) {
+	case "debug", "info", "warn", "error":
+	default:
+		errs = append(errs, fmt.Errorf("log: unknown level %q", c.Log.Level))
+	}
+	if len(c.Region.Endpoints) > 0 {
+		primary := c.Region.Primary
+		if primary == "" {
+			primary = c.Region.Self
+		}
+		if _, ok := c.Region.Endpoints[primary]; primary != "" && !ok {
+			errs = append(errs, fmt.Errorf("region: primary region %q has no endpoint", primary))
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+	return nil
+}
+
+// PostgresURL returns the connection URL, building it from the individual
+// fields unless URL is set
+func (d DatabaseConfig) PostgresURL() string {
+	if d.URL != "" {
+		return d.URL
+	}
+	u := url.URL{
+		Scheme:   "postgres",
+		Host:     d.Host + ":" + strconv.Itoa(d.Port),
+		Path:     "/" + d.Name,
+		RawQuery: "sslmode=" + url.QueryEscape(d.SSLMode),
+	}
+	if d.Password != "" {
+		u.User = url.UserPassword(d.User, d.Password)
+	} else if d.User != "" {
+		u.User = url.User(d.User)
+	}
+	return u.String()
+}
+
+// Describe returns the connection target without credentials, for logs and errors
+func (d DatabaseConfig) Describe() string {
+	if d.URL != "" {
+		if u, err := url.Parse(d.URL); err == nil {
+			return fmt.Sprintf("host=%s, dbname=%s", u.Host, strings.TrimPrefix(u.Path, "/"))
+		}
+		return "DATABASE_URL"
+	}
+	return fmt.Sprintf("host=%s, port=%d, dbname=%s", d.Host, d.Port, d.Name)
+}
@@ -0,0 +1,485 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// applyEnv overlays environment variables:
+//
+//	DATABASE_URL, PGHOST, PGPORT, PGUSER, PGPASSWORD, PGDATABASE, PGSSLMODE
+//	DB_AUTO_MIGRATE (true|false)
+//	DB_MAX_CONNS, DB_MIN_CONNS, DB_MAX_CONN_LIFETIME, DB_MAX_CONN_IDLE_TIME,
+//	DB_HEALTH_CHECK_PERIOD
+//	PORT, HTTP_READ_TIMEOUT, HTTP_WRITE_TIMEOUT, HTTP_IDLE_TIMEOUT
+//	TRUSTED_PROXIES (comma-separated IPs or CIDRs)
+//	DATA_SERVICE_LISTEN_ADDR, DATA_SERVICE_ADDR, METRICS_ADDR, DATA_SERVICE_API_KEY
+//	GRPC_DEFAULT_TIMEOUT, GRPC_STREAM_TIMEOUT, GRPC_MAX_TIMEOUT,
+//	GRPC_MAX_FIELD_BYTES, GRPC_MAX_LIMIT
+//	DRAIN_REUSE_PORT (true|false), DRAIN_DELAY, DRAIN_TIMEOUT
+//	HEALTH_TIMEOUT, HEALTH_CACHE_TTL, HEALTH_OPTIONAL (comma-separated checks)
+//	RUST_DSL_SERVICE_ADDR, RUST_DSL_API_KEY, RUST_DSL_DIAL_TIMEOUT, RUST_DSL_RPC_TIMEOUT,
+//	RUST_DSL_AMEND_TIMEOUT, RUST_DSL_MAX_ATTEMPTS, RUST_DSL_RETRY_BACKOFF,
+//	RUST_DSL_BREAKER_THRESHOLD, RUST_DSL_BREAKER_COOLDOWN, RUST_DSL_FALLBACK (true|false)
+//	OPENAI_API_KEY, OPENAI_CHAT_MODEL
+//	OPENAI_EMBEDDING_MODEL, OPENAI_EMBEDDING_DIMENSIONS
+//	KYC_REGION, KYC_PRIMARY_REGION
+//	KYC_REGION_ENDPOINTS  e.g. "us=kyc-us:50070,eu=kyc-eu:50070"
+//	KYC_REGION_FALLBACKS  e.g. "apac=eu|us,eu=us"
+//	AUTH_ISSUER, AUTH_AUDIENCE, AUTH_JWKS_URL, AUTH_ROLES_CLAIM, AUTH_TENANT_CLAIM
+//	AUTH_ROLE_MAP         e.g. "kyc-analysts=analyst,kyc-admins=admin"
+//	AUTH_API_KEYS         e.g. "key1=analyst,key2=admin"
+//	AUTH_SCOPES_CLAIM
+//	AUTH_ROLE_SCOPES      e.g. "admin=pii:read" (replaces the defaults; scopes separated by |)
+//	RATE_LIMIT_ENABLED (true|false), RATE_LIMIT_RPM, RATE_LIMIT_BURST,
+//	RATE_LIMIT_DAILY_QUOTA, RATE_LIMIT_PATHS (comma-separated prefixes)
+//	SHADOW_RANKER, SHADOW_SAMPLE_RATE
+//	WATCHLIST_ENABLED (true|false), WATCHLIST_INTERVAL, WATCHLIST_DEFAULT_CADENCE,
+//	WATCHLIST_BATCH_SIZE, WATCHLIST_MATCH_THRESHOLD
+//	MATERIAL_CHANGE_ENABLED (true|false), MATERIAL_CHANGE_INTERVAL,
+//	MATERIAL_CHANGE_OWNERSHIP_THRESHOLD, MATERIAL_CHANGE_OWNERSHIP_DELTA
+//	REEVALUATION_ENABLED (true|false), REEVALUATION_INTERVAL, REEVALUATION_BATCH_SIZE,
+//	REEVALUATION_MATERIALIZE (true|false)
+//	RISK_WEIGHTS, RISK_SCALES, RISK_BANDS  e.g. "PEP_EXPOSURE_FLAG=25,UBO_CONCENTRATION_SCORE=5"
+//	SCREENING_MATCH_THRESHOLD, SCREENING_CONFIRMED_THRESHOLD,
+//	SCREENING_API_URL, SCREENING_API_KEY, SCREENING_API_TIMEOUT,
+//	SCREENING_PEP_URL, SCREENING_PEP_API_KEY, SCREENING_PEP_TIMEOUT,
+//	SCREENING_ADVERSE_MEDIA_URL, SCREENING_ADVERSE_MEDIA_API_KEY,
+//	SCREENING_ADVERSE_MEDIA_TIMEOUT, SCREENING_ADVERSE_MEDIA_TERMS,
+//	SCREENING_ADVERSE_MEDIA_MAX_ARTICLES
+//	EVIDENCE_DRIVER (local|s3|minio), EVIDENCE_DIR, EVIDENCE_MAX_SIZE_MB,
+//	EVIDENCE_S3_ENDPOINT, EVIDENCE_S3_REGION, EVIDENCE_S3_BUCKET,
+//	EVIDENCE_S3_ACCESS_KEY, EVIDENCE_S3_SECRET_KEY, EVIDENCE_S3_PATH_STYLE (true|false)
+//	EVIDENCE_EXPIRY_ENABLED (true|false), EVIDENCE_EXPIRY_INTERVAL,
+//	EVIDENCE_EXPIRY_WARNING_DAYS, EVIDENCE_EXPIRY_SUMMARY_HOUR
+//	EVIDENCE_EXTRACTION_ENABLED (true|false), EVIDENCE_EXTRACTION_INTERVAL,
+//	EVIDENCE_EXTRACTION_BATCH_SIZE, EVIDENCE_EXTRACTION_MAX_ATTEMPTS,
+//	EVIDENCE_EXTRACTORS (comma-separated: builtin, pdftotext, tesseract, api),
+//	EVIDENCE_PDFTOTEXT, EVIDENCE_TESSERACT, EVIDENCE_OCR_LANGUAGES,
+//	EVIDENCE_OCR_API_URL, EVIDENCE_OCR_API_KEY, EVIDENCE_OCR_API_TIMEOUT
+//	EVIDENCE_ATTRIBUTES_SECTIONS, EVIDENCE_ATTRIBUTES_MAX
+//	INGEST_CHUNK_TOKENS, INGEST_OVERLAP_TOKENS, INGEST_BATCH_SIZE
+//	CLUSTERING_ENABLED (true|false), CLUSTERING_INTERVAL, CLUSTERING_K, CLUSTERING_MAX_K,
+//	CLUSTERING_LLM_LABELS (true|false)
+//	REEMBED_ENABLED (true|false), REEMBED_INTERVAL, REEMBED_BATCH_SIZE,
+//	REEMBED_UNVERSIONED (true|false)
+//	MODEL_LOG_ENABLED (true|false), MODEL_LOG_RESPONSES (true|false),
+//	MODEL_LOG_REDACT (true|false), MODEL_LOG_RETENTION, MODEL_LOG_MAX_CHARS
+//	RANKING_FEEDBACK_WEIGHT
+//	EMBEDDING_CACHE_ENABLED (true|false), EMBEDDING_CACHE_SIZE, EMBEDDING_CACHE_TTL,
+//	EMBEDDING_CACHE_PERSIST (true|false)
+//	RESPONSE_CACHE_ENABLED (true|false), RESPONSE_CACHE_DRIVER (memory|redis),
+//	RESPONSE_CACHE_TTL, RESPONSE_CACHE_SIZE, RESPONSE_CACHE_PREFIX,
+//	REDIS_ADDR, REDIS_PASSWORD, REDIS_DB
+//	EMBEDDING_DUAL_WRITE  e.g. "small,large"
+//	LOG_FORMAT (text|json), LOG_LEVEL (debug|info|warn|error)
+func (c *Config) applyEnv() error {
+	var errs []error
+	check := func(err error) {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	envString(&c.Database.URL, "DATABASE_URL")
+	envString(&c.Database.Host, "PGHOST")
+	check(envInt(&c.Database.Port, "PGPORT"))
+	envString(&c.Database.User, "PGUSER")
+	envString(&c.Database.Password, "PGPASSWORD")
+	envString(&c.Database.Name, "PGDATABASE")
+	envString(&c.Database.SSLMode, "PGSSLMODE")
+	check(envBool(&c.Database.AutoMigrate, "DB_AUTO_MIGRATE"))
+	check(envInt(&c.Database.MaxConns, "DB_MAX_CONNS"))
+	check(envInt(&c.Database.MinConns, "DB_MIN_CONNS"))
+	check(envDuration(&c.Database.MaxConnLifetime, "DB_MAX_CONN_LIFETIME"))
+	check(envDuration(&c.Database.MaxConnIdleTime, "DB_MAX_CONN_IDLE_TIME"))
+	check(envDuration(&c.Database.HealthCheckPeriod, "DB_HEALTH_CHECK_PERIOD"))
+
+	check(envInt(&c.Server.Port, "PORT"))
+	check(envDuration(&c.Server.ReadTimeout, "HTTP_READ_TIMEOUT"))
+	check(envDuration(&c.Server.WriteTimeout, "HTTP_WRITE_TIMEOUT"))
+	check(envDuration(&c.Server.IdleTimeout, "HTTP_IDLE_TIMEOUT"))
+	envList(&c.Server.TrustedProxies, "TRUSTED_PROXIES")
+
+	envString(&c.DataService.ListenAddr, "DATA_SERVICE_LISTEN_ADDR")
+	envString(&c.DataService.Addr, "DATA_SERVICE_ADDR")
+	envString(&c.DataService.MetricsAddr, "METRICS_ADDR")
+	envString(&c.DataService.APIKey, "DATA_SERVICE_API_KEY")
+	check(envDuration(&c.GRPC.DefaultTimeout, "GRPC_DEFAULT_TIMEOUT"))
+	check(envDuration(&c.GRPC.StreamTimeout, "GRPC_STREAM_TIMEOUT"))
+	check(envDuration(&c.GRPC.MaxTimeout, "GRPC_MAX_TIMEOUT"))
+	check(envInt(&c.GRPC.MaxFieldBytes, "GRPC_MAX_FIELD_BYTES"))
+	check(envInt(&c.GRPC.MaxLimit, "GRPC_MAX_LIMIT"))
+
+	check(envBool(&c.Drain.ReusePort, "DRAIN_REUSE_PORT"))
+	check(envDuration(&c.Drain.Delay, "DRAIN_DELAY"))
+	check(envDuration(&c.Drain.Timeout, "DRAIN_TIMEOUT"))
+	check(envDuration(&c.Health.Timeout, "HEALTH_TIMEOUT"))
+	check(envDuration(&c.Health.CacheTTL, "HEALTH_CACHE_TTL"))
+	envList(&c.Health.Optional, "HEALTH_OPTIONAL")
+
+	envString(&c.RustDSL.Addr, "RUST_DSL_SERVICE_ADDR")
+	envString(&c.RustDSL.APIKey, "RUST_DSL_API_KEY")
+	check(envDuration(&c.RustDSL.DialTimeout, "RUST_DSL_DIAL_TIMEOUT"))
+	check(envDuration(&c.RustDSL.Timeout, "RUST_DSL_RPC_TIMEOUT"))
+	check(envDuration(&c.RustDSL.AmendTimeout, "RUST_DSL_AMEND_TIMEOUT"))
+	check(envInt(&c.RustDSL.MaxAttempts, "RUST_DSL_MAX_ATTEMPTS"))
+	check(envDuration(&c.RustDSL.RetryBackoff, "RUST_DSL_RETRY_BACKOFF"))
+	check(envInt(&c.RustDSL.BreakerThreshold, "RUST_DSL_BREAKER_THRESHOLD"))
+	check(envDuration(&c.RustDSL.BreakerCooldown, "RUST_DSL_BREAKER_COOLDOWN"))
+	check(envBool(&c.RustDSL.Fallback, "RUST_DSL_FALLBACK"))
+	envString(&c.OpenAI.APIKey, "OPENAI_API_KEY")
+	envString(&c.OpenAI.ChatModel, "OPENAI_CHAT_MODEL")
+	envString(&c.OpenAI.EmbeddingModel, "OPENAI_EMBEDDING_MODEL")
+	check(envInt(&c.OpenAI.EmbeddingDimensions, "OPENAI_EMBEDDING_DIMENSIONS"))
+
+	envString(&c.Region.Self, "KYC_REGION")
+	envString(&c.Region.Primary, "KYC_PRIMARY_REGION")
+	c.Region.Self = strings.ToLower(c.Region.Self)
+	c.Region.Primary = strings.ToLower(c.Region.Primary)
+	check(envPairs(&c.Region.Endpoints, "KYC_REGION_ENDPOINTS", true))
+	var fallbacks map[string]string
+	check(envPairs(&fallbacks, "KYC_REGION_FALLBACKS", true))
+	if fallbacks != nil {
+		c.Region.Fallbacks = make(map[string][]string, len(fallbacks))
+		for name, order := range fallbacks {
+			c.Region.Fallbacks[name] = strings.Split(strings.ToLower(order), "|")
+		}
+	}
+
+	envString(&c.Auth.Issuer, "AUTH_ISSUER")
+	envString(&c.Auth.Audience, "AUTH_AUDIENCE")
+	envString(&c.Auth.JWKSURL, "AUTH_JWKS_URL")
+	envString(&c.Auth.RolesClaim, "AUTH_ROLES_CLAIM")
+	envString(&c.Auth.TenantClaim, "AUTH_TENANT_CLAIM")
+	check(envPairs(&c.Auth.RoleMap, "AUTH_ROLE_MAP", false))
+	check(envPairs(&c.Auth.APIKeys, "AUTH_API_KEYS", false))
+	envString(&c.Auth.ScopesClaim, "AUTH_SCOPES_CLAIM")
+	var roleScopes map[string]string
+	check(envPairs(&roleScopes, "AUTH_ROLE_SCOPES", true))
+	if roleScopes != nil {
+		c.Auth.RoleScopes = make(map[string][]string, len(roleScopes))
+		for role, scopes := range roleScopes {
+			c.Auth.RoleScopes[role] = strings.Split(scopes, "|")
+		}
+	}
+
+	check(envBool(&c.RateLimit.Enabled, "RATE_LIMIT_ENABLED"))
+	check(envFloat(&c.RateLimit.RequestsPerMinute, "RATE_LIMIT_RPM"))
+	check(envInt(&c.RateLimit.Burst, "RATE_LIMIT_BURST"))
+	check(envInt(&c.RateLimit.DailyQuota, "RATE_LIMIT_DAILY_QUOTA"))
+	envList(&c.RateLimit.Paths, "RATE_LIMIT_PATHS")
+
+	envString(&c.Shadow.Ranker, "SHADOW_RANKER")
+	check(envFloat(&c.Shadow.SampleRate, "SHADOW_SAMPLE_RATE"))
+
+	check(envBool(&c.Watchlist.Enabled, "WATCHLIST_ENABLED"))
+	check(envDuration(&c.Watchlist.Interval, "WATCHLIST_INTERVAL"))
+	check(envDuration(&c.Watchlist.DefaultCadence, "WATCHLIST_DEFAULT_CADENCE"))
+	check(envInt(&c.Watchlist.BatchSize, "WATCHLIST_BATCH_SIZE"))
+	check(envFloat(&c.Watchlist.MatchThreshold, "WATCHLIST_MATCH_THRESHOLD"))
+
+	check(envBool(&c.MaterialChange.Enabled, "MATERIAL_CHANGE_ENABLED"))
+	check(envDuration(&c.MaterialChange.Interval, "MATERIAL_CHANGE_INTERVAL"))
+	check(envFloat(&c.MaterialChange.OwnershipThreshold, "MATERIAL_CHANGE_OWNERSHIP_THRESHOLD"))
+	check(envFloat(&c.MaterialChange.OwnershipDelta, "MATERIAL_CHANGE_OWNERSHIP_DELTA"))
+
+	check(envBool(&c.Reevaluation.Enabled, "REEVALUATION_ENABLED"))
+	check(envDuration(&c.Reevaluation.Interval, "REEVALUATION_INTERVAL"))
+	check(envInt(&c.Reevaluation.BatchSize, "REEVALUATION_BATCH_SIZE"))
+	check(envBool(&c.Reevaluation.Materialize, "REEVALUATION_MATERIALIZE"))
+
+	check(envFloats(&c.Risk.Weights, "RISK_WEIGHTS"))
+	check(envFloats(&c.Risk.Scales, "RISK_SCALES"))
+	check(envFloats(&c.Risk.Bands, "RISK_BANDS"))
+
+	check(envFloat(&c.Screening.MatchThreshold, "SCREENING_MATCH_THRESHOLD"))
+	check(envFloat(&c.Screening.ConfirmedThreshold, "SCREENING_CONFIRMED_THRESHOLD"))
+	envString(&c.Screening.API.URL, "SCREENING_API_URL")
+	envString(&c.Screening.API.APIKey, "SCREENING_API_KEY")
+	check(envDuration(&c.Screening.API.Timeout, "SCREENING_API_TIMEOUT"))
+	envString(&c.Screening.PEP.URL, "SCREENING_PEP_URL")
+	envString(&c.Screening.PEP.APIKey, "SCREENING_PEP_API_KEY")
+	check(envDuration(&c.Screening.PEP.Timeout, "SCREENING_PEP_TIMEOUT"))
+	envString(&c.Screening.AdverseMedia.URL, "SCREENING_ADVERSE_MEDIA_URL")
+	envString(&c.Screening.AdverseMedia.APIKey, "SCREENING_ADVERSE_MEDIA_API_KEY")
+	check(envDuration(&c.Screening.AdverseMedia.Timeout, "SCREENING_ADVERSE_MEDIA_TIMEOUT"))
+	envList(&c.Screening.AdverseMedia.Terms, "SCREENING_ADVERSE_MEDIA_TERMS")
+	check(envInt(&c.Screening.AdverseMedia.MaxArticles, "SCREENING_ADVERSE_MEDIA_MAX_ARTICLES"))
+
+	envString(&c.Evidence.Driver, "EVIDENCE_DRIVER")
+	envString(&c.Evidence.Dir, "EVIDENCE_DIR")
+	check(envInt(&c.Evidence.MaxSizeMB, "EVIDENCE_MAX_SIZE_MB"))
+	envString(&c.Evidence.S3.Endpoint, "EVIDENCE_S3_ENDPOINT")
+	envString(&c.Evidence.S3.Region, "EVIDENCE_S3_REGION")
+	envString(&c.Evidence.S3.Bucket, "EVIDENCE_S3_BUCKET")
+	envString(&c.Evidence.S3.AccessKey, "EVIDENCE_S3_ACCESS_KEY")
+	envString(&c.Evidence.S3.SecretKey, "EVIDENCE_S3_SECRET_KEY")
+	check(envBool(&c.Evidence.S3.PathStyle, "EVIDENCE_S3_PATH_STYLE"))
+	check(envBool(&c.Evidence.Expiry.Enabled, "EVIDENCE_EXPIRY_ENABLED"))
+	check(envDuration(&c.Evidence.Expiry.Interval, "EVIDENCE_EXPIRY_INTERVAL"))
+	check(envInt(&c.Evidence.Expiry.WarningDays, "EVIDENCE_EXPIRY_WARNING_DAYS"))
+	check(envInt(&c.Evidence.Expiry.SummaryHour, "EVIDENCE_EXPIRY_SUMMARY_HOUR"))
+	check(envBool(&c.Evidence.Extraction.Enabled, "EVIDENCE_EXTRACTION_ENABLED"))
+	check(envDuration(&c.Evidence.Extraction.Interval, "EVIDENCE_EXTRACTION_INTERVAL"))
+	check(envInt(&c.Evidence.Extraction.BatchSize, "EVIDENCE_EXTRACTION_BATCH_SIZE"))
+	check(envInt(&c.Evidence.Extraction.MaxAttempts, "EVIDENCE_EXTRACTION_MAX_ATTEMPTS"))
+	envList(&c.Evidence.Extraction.Extractors, "EVIDENCE_EXTRACTORS")
+	envString(&c.Evidence.Extraction.PDFToText, "EVIDENCE_PDFTOTEXT")
+	envString(&c.Evidence.Extraction.Tesseract, "EVIDENCE_TESSERACT")
+	envString(&c.Evidence.Extraction.Languages, "EVIDENCE_OCR_LANGUAGES")
+	envString(&c.Evidence.Extraction.API.URL, "EVIDENCE_OCR_API_URL")
+	envString(&c.Evidence.Extraction.API.APIKey, "EVIDENCE_OCR_API_KEY")
+	check(envDuration(&c.Evidence.Extraction.API.Timeout, "EVIDENCE_OCR_API_TIMEOUT"))
+	check(envInt(&c.Evidence.Attributes.SectionsPerAttribute, "EVIDENCE_ATTRIBUTES_SECTIONS"))
+	check(envInt(&c.Evidence.Attributes.MaxAttributes, "EVIDENCE_ATTRIBUTES_MAX"))
+	check(envInt(&c.Ingestion.ChunkTokens, "INGEST_CHUNK_TOKENS"))
+	check(envInt(&c.Ingestion.OverlapTokens, "INGEST_OVERLAP_TOKENS"))
+	check(envInt(&c.Ingestion.BatchSize, "INGEST_BATCH_SIZE"))
+
+	check(envBool(&c.Clustering.Enabled, "CLUSTERING_ENABLED"))
+	check(envDuration(&c.Clustering.Interval, "CLUSTERING_INTERVAL"))
+	check(envInt(&c.Clustering.K, "CLUSTERING_K"))
+	check(envInt(&c.Clustering.MaxK, "CLUSTERING_MAX_K"))
+	check(envBool(&c.Clustering.LLMLabels, "CLUSTERING_LLM_LABELS"))
+
+	check(envBool(&c.Reembedding.Enabled, "REEMBED_ENABLED"))
+	check(envDuration(&c.Reembedding.Interval, "REEMBED_INTERVAL"))
+	check(envInt(&c.Reembedding.BatchSize, "REEMBED_BATCH_SIZE"))
+	check(envBool(&c.Reembedding.Unversioned, "REEMBED_UNVERSIONED"))
+
+	check(envBool(&c.ModelLog.Enabled, "MODEL_LOG_ENABLED"))
+	check(envBool(&c.ModelLog.Responses, "MODEL_LOG_RESPONSES"))
+	check(envBool(&c.ModelLog.Redact, "MODEL_LOG_REDACT"))
+	check(envDuration(&c.ModelLog.Retention, "MODEL_LOG_RETENTION"))
+	check(envInt(&c.ModelLog.MaxChars, "MODEL_LOG_MAX_CHARS"))
+
+	check(envFloat(&c.AuditLog.QuerySampleRate, "AUDIT_QUERY_SAMPLE_RATE"))
+	check(envInt(&c.AuditLog.BatchSize, "AUDIT_BATCH_SIZE"))
+	check(envDuration(&c.AuditLog.FlushInterval, "AUDIT_FLUSH_INTERVAL"))
+	check(envInt(&c.AuditLog.QueueSize, "AUDIT_QUEUE_SIZE"))
+	envString(&c.AuditLog.EmbeddingStorage, "AUDIT_EMBEDDING_STORAGE")
+	check(envInt(&c.AuditLog.ReducedDimensions, "AUDIT_EMBEDDING_REDUCED_DIMENSIONS"))
+	check(envDuration(&c.AuditLog.CompactAfter, "AUDIT_EMBEDDING_COMPACT_AFTER"))
+
+	check(envBool(&c.Retention.Enabled, "RETENTION_ENABLED"))
+	check(envDuration(&c.Retention.Interval, "RETENTION_INTERVAL"))
+	check(envBool(&c.Retention.DryRun, "RETENTION_DRY_RUN"))
+	check(envInt(&c.Retention.BatchSize, "RETENTION_BATCH_SIZE"))
+	check(envBool(&c.Retention.Archive.Enabled, "RETENTION_ARCHIVE_ENABLED"))
+	envString(&c.Retention.Archive.Driver, "RETENTION_ARCHIVE_DRIVER")
+	envString(&c.Retention.Archive.Dir, "RETENTION_ARCHIVE_DIR")
+	envString(&c.Retention.Archive.Prefix, "RETENTION_ARCHIVE_PREFIX")
+	envString(&c.Retention.Archive.S3.Endpoint, "RETENTION_ARCHIVE_S3_ENDPOINT")
+	envString(&c.Retention.Archive.S3.Region, "RETENTION_ARCHIVE_S3_REGION")
+	envString(&c.Retention.Archive.S3.Bucket, "RETENTION_ARCHIVE_S3_BUCKET")
+	envString(&c.Retention.Archive.S3.AccessKey, "RETENTION_ARCHIVE_S3_ACCESS_KEY")
+	envString(&c.Retention.Archive.S3.SecretKey, "RETENTION_ARCHIVE_S3_SECRET_KEY")
+	check(envBool(&c.Retention.Archive.S3.PathStyle, "RETENTION_ARCHIVE_S3_PATH_STYLE"))
+	check(envDuration(&c.Retention.Policies.AuditLog.MaxAge, "RETENTION_AUDIT_LOG_MAX_AGE"))
+	check(envBool(&c.Retention.Policies.AuditLog.KeepErrors, "RETENTION_AUDIT_LOG_KEEP_ERRORS"))
+	check(envDuration(&c.Retention.Policies.Amendments.MaxAge, "RETENTION_AMENDMENTS_MAX_AGE"))
+	check(envDuration(&c.Retention.Policies.Feedback.MaxAge, "RETENTION_FEEDBACK_MAX_AGE"))
+
+	check(envDuration(&c.CaseLock.TTL, "CASE_LOCK_TTL"))
+
+	check(envBool(&c.Signing.Enabled, "SIGNING_ENABLED"))
+	envString(&c.Signing.Driver, "SIGNING_DRIVER")
+	envString(&c.Signing.KeyFile, "SIGNING_KEY_FILE")
+	envString(&c.Signing.KeyID, "SIGNING_KEY_ID")
+	envList(&c.Signing.TrustedKeys, "SIGNING_TRUSTED_KEYS")
+
+	check(envBool(&c.Encryption.Enabled, "PII_ENCRYPTION_ENABLED"))
+	envString(&c.Encryption.Driver, "PII_KEY_DRIVER")
+	envString(&c.Encryption.KeyID, "PII_KEY_ID")
+	envList(&c.Encryption.Keys, "PII_KEYS")
+	envList(&c.Encryption.Attributes, "PII_ENCRYPTED_ATTRIBUTES")
+	check(envDuration(&c.DSR.RecordRetention, "DSR_RECORD_RETENTION"))
+
+	check(envFloat(&c.Ranking.FeedbackWeight, "RANKING_FEEDBACK_WEIGHT"))
+	check(envFloat(&c.Search.MatchThreshold, "SEARCH_MATCH_THRESHOLD"))
+
+	check(envBool(&c.EmbeddingCache.Enabled, "EMBEDDING_CACHE_ENABLED"))
+	check(envInt(&c.EmbeddingCache.Size, "EMBEDDING_CACHE_SIZE"))
+	check(envDuration(&c.EmbeddingCache.TTL, "EMBEDDING_CACHE_TTL"))
+	check(envBool(&c.EmbeddingCache.Persist, "EMBEDDING_CACHE_PERSIST"))
+	check(envBool(&c.ResponseCache.Enabled, "RESPONSE_CACHE_ENABLED"))
+	envString(&c.ResponseCache.Driver, "RESPONSE_CACHE_DRIVER")
+	check(envDuration(&c.ResponseCache.TTL, "RESPONSE_CACHE_TTL"))
+	check(envInt(&c.ResponseCache.Size, "RESPONSE_CACHE_SIZE"))
+	envString(&c.ResponseCache.Redis.Addr, "REDIS_ADDR")
+	envString(&c.ResponseCache.Redis.Password, "REDIS_PASSWORD")
+	check(envInt(&c.ResponseCache.Redis.DB, "REDIS_DB"))
+	envString(&c.ResponseCache.Redis.Prefix, "RESPONSE_CACHE_PREFIX")
+	envList(&c.EmbeddingSpaces.DualWrite, "EMBEDDING_DUAL_WRITE")
+	check(envDuration(&c.EmbeddingTiers.ArchiveAfter, "EMBEDDING_ARCHIVE_AFTER"))
+	check(envInt(&c.EmbeddingTiers.PromoteHits, "EMBEDDING_PROMOTE_HITS"))
+	check(envDuration(&c.EmbeddingTiers.Interval, "EMBEDDING_TIER_INTERVAL"))
+	check(envInt(&c.EmbeddingTiers.BatchSize, "EMBEDDING_TIER_BATCH_SIZE"))
+
+	check(envPairs(&c.Webhooks.Endpoints, "WEBHOOK_ENDPOINTS", false))
+	check(envPairs(&c.Webhooks.Secrets, "WEBHOOK_SECRETS", false))
+	var webhookEvents map[string]string
+	check(envPairs(&webhookEvents, "WEBHOOK_EVENTS", false))
+	if webhookEvents != nil {
+		c.Webhooks.Events = make(map[string][]string, len(webhookEvents))
+		for name, types := range webhookEvents {
+			c.Webhooks.Events[name] = strings.Split(types, "|")
+		}
+	}
+	check(envInt(&c.Webhooks.MaxAttempts, "WEBHOOK_MAX_ATTEMPTS"))
+	check(envDuration(&c.Webhooks.RetryBackoff, "WEBHOOK_RETRY_BACKOFF"))
+	check(envDuration(&c.Webhooks.Timeout, "WEBHOOK_TIMEOUT"))
+	check(envDuration(&c.Webhooks.Interval, "WEBHOOK_INTERVAL"))
+	envList(&c.Events.Sinks, "EVENT_SINKS")
+	envList(&c.Events.Kafka.Brokers, "KAFKA_BROKERS")
+	envString(&c.Events.Kafka.Topic, "KAFKA_EVENTS_TOPIC")
+	envString(&c.Events.NATS.URL, "NATS_URL")
+	envString(&c.Events.NATS.SubjectPrefix, "NATS_EVENTS_SUBJECT_PREFIX")
+	envString(&c.Events.SIEM.Network, "SIEM_NETWORK")
+	envString(&c.Events.SIEM.Addr, "SIEM_ADDR")
+	envString(&c.Events.SIEM.Format, "SIEM_FORMAT")
+	check(envInt(&c.Events.SIEM.Facility, "SIEM_FACILITY"))
+	envString(&c.Events.SIEM.AppName, "SIEM_APP_NAME")
+	var siemEvents []string
+	envList(&siemEvents, "SIEM_EVENTS")
+	if siemEvents != nil {
+		c.Events.SIEM.Events = make(map[string]bool, len(siemEvents))
+		for _, t := range siemEvents {
+			c.Events.SIEM.Events[t] = true
+		}
+	}
+
+	envString(&c.Log.Format, "LOG_FORMAT")
+	envString(&c.Log.Level, "LOG_LEVEL")
+
+	return errors.Join(errs...)
+}
+
+func envString(dst *string, key string) {
+	if v := os.Getenv(key); v != "" {
+		*dst = v
+	}
+}
+
+func envInt(dst *int, key string) error {
+	v := os.Getenv(key)
+	if v == "" {
+		return nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return fmt.Errorf("invalid %s %q: %w", key, v, err)
+	}
+	*dst = n
+	return nil
+}
+
+func envFloat(dst *float64, key string) error {
+	v := os.Getenv(key)
+	if v == "" {
+		return nil
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return fmt.Errorf("invalid %s %q: %w", key, v, err)
+	}
+	*dst = f
+	return nil
+}
+
+func envBool(dst *bool, key string) error {
+	v := os.Getenv(key)
+	if v == "" {
+		return nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return fmt.Errorf("invalid %s %q: %w", key, v, err)
+	}
+	*dst = b
+	return nil
+}
+
+func envDuration(dst *time.Duration, key string) error {
+	v := os.Getenv(key)
+	if v == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return fmt.Errorf("invalid %s %q: %w", key, v, err)
+	}
+	*dst = d
+	return nil
+}
+
+// envList replaces dst with the comma-separated list in key, if set
+func envList(dst *[]string, key string) {
+	v := os.Getenv(key)
+	if v == "" {
+		return
+	}
+	var items []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	*dst = items
+}
+
+// envPairs replaces dst with the "name=value,name=value" list in key, if set
+func envPairs(dst *map[string]string, key string, lowerNames bool) error {
+	v := os.Getenv(key)
+	if v == "" {
+		return nil
+	}
+	pairs := make(map[string]string)
+	if err := parsePairs(pairs, v, lowerNames); err != nil {
+		return fmt.Errorf("invalid %s: %w", key, err)
+	}
+	*dst = pairs
+	return nil
+}
+
+// envFloats is envPairs with numeric values, keyed by upper-case names
+func envFloats(dst *map[string]float64, key string) error {
+	var pairs map[string]string
+	if err := envPairs(&pairs, key, false); err != nil || pairs == nil {
+		return err
+	}
+	values := make(map[string]float64, len(pairs))
+	for name, v := range pairs {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %s=%q is not a number", key, name, v)
+		}
+		values[strings.ToUpper(name)] = f
+	}
+	*dst = values
+	return nil
+}
+
+func parsePairs(dst map[string]string, s string, lowerNames bool) error {
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(pair, "=")
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if !ok || name == "" || value == "" {
+			return fmt.Errorf("expected name=value, got %q", pair)
+		}
+		if lowerNames {
+			name = strings.ToLower(name)
+		}
+		dst[name] = value
+	}
+	return nil
+}
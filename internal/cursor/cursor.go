@@ -0,0 +1,43 @@
+// Package cursor encodes keyset pagination positions as opaque tokens. A
+// list endpoint orders by a unique key (code, or name then id), returns the
+// key of its last row as next_cursor, and the next page selects the rows
+// after it, so pages stay consistent while rows are inserted or deleted,
+// unlike limit/offset.
+package cursor
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalid is returned for a token that was not produced by Encode or
+// that has the wrong number of keys for the list it is used with
+var ErrInvalid = errors.New("invalid cursor")
+
+// Encode returns the token for the position after a row with these keys
+func Encode(keys ...string) string {
+	data, _ := json.Marshal(keys)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Decode returns the n keys of a token; an empty token decodes to nil (the
+// first page)
+func Decode(token string, n int) ([]string, error) {
+	if token == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	var keys []string
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	if len(keys) != n {
+		return nil, fmt.Errorf("%w: expected %d keys, got %d", ErrInvalid, n, len(keys))
+	}
+	return keys, nil
+}
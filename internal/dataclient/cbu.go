@@ -0,0 +1,106 @@
+package dataclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cbupb "github.com/adamtc007/KYC-DSL/api/pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ListCbus retrieves Client Business Units with entity and relationship counts
+func (c *DataClient) ListCbus(limit, offset int32) (*cbupb.CbuList, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.defaultTimeout)
+	defer cancel()
+
+	resp, err := c.cbuClient.ListCbus(ctx, &cbupb.ListCbusRequest{Limit: limit, Offset: offset})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cbus: %w", err)
+	}
+
+	return resp, nil
+}
+
+// GetCbuGraph retrieves the entities, roles and relationships of a CBU
+func (c *DataClient) GetCbuGraph(cbuID string) (*cbupb.CbuGraph, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.defaultTimeout)
+	defer cancel()
+
+	resp, err := c.cbuClient.GetGraph(ctx, &cbupb.GetCbuRequest{CbuId: cbuID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cbu graph %s: %w", cbuID, err)
+	}
+
+	return resp, nil
+}
+
+// GetCbuGraphAsOf retrieves a CBU graph as it was on record at a point in time
+func (c *DataClient) GetCbuGraphAsOf(cbuID string, asOf time.Time) (*cbupb.CbuGraph, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.defaultTimeout)
+	defer cancel()
+
+	resp, err := c.cbuClient.GetGraph(ctx, &cbupb.GetCbuRequest{CbuId: cbuID, AsOf: timestamppb.New(asOf)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cbu graph %s as of %s: %w", cbuID, asOf.Format(time.DateOnly), err)
+	}
+
+	return resp, nil
+}
+
+// DiffCbuGraph reports the ownership and control changes of a CBU between two points in time
+func (c *DataClient) DiffCbuGraph(cbuID string, from, to time.Time) (*cbupb.GraphDiff, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.defaultTimeout)
+	defer cancel()
+
+	resp, err := c.cbuClient.DiffGraph(ctx, &cbupb.DiffGraphRequest{
+		CbuId: cbuID,
+		From:  timestamppb.New(from),
+		To:    timestamppb.New(to),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff cbu graph %s: %w", cbuID, err)
+	}
+
+	return resp, nil
+}
+
+// GetCbuRelationshipHistory lists the recorded versions of a CBU edge
+func (c *DataClient) GetCbuRelationshipHistory(cbuID, relationshipID string) (*cbupb.RelationshipHistory, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.defaultTimeout)
+	defer cancel()
+
+	resp, err := c.cbuClient.GetRelationshipHistory(ctx, &cbupb.RelationshipHistoryRequest{CbuId: cbuID, RelationshipId: relationshipID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get history of relationship %s: %w", relationshipID, err)
+	}
+
+	return resp, nil
+}
+
+// ValidateCbuGraph checks a CBU graph for structural and ownership issues.
+// tolerance is the percentage points an entity may be owned above 100% (0 for the default).
+func (c *DataClient) ValidateCbuGraph(cbuID string, tolerance float32) (*cbupb.ValidationResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.defaultTimeout)
+	defer cancel()
+
+	resp, err := c.cbuClient.ValidateGraph(ctx, &cbupb.ValidateGraphRequest{CbuId: cbuID, OwnershipTolerance: tolerance})
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate cbu graph %s: %w", cbuID, err)
+	}
+
+	return resp, nil
+}
+
+// GetCbuControlChain traces the ownership/control chain above an entity of a CBU
+func (c *DataClient) GetCbuControlChain(cbuID, entityID string) (*cbupb.ControlChainResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.defaultTimeout)
+	defer cancel()
+
+	resp, err := c.cbuClient.GetControlChain(ctx, &cbupb.GetEntityRequest{CbuId: cbuID, EntityId: entityID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get control chain for %s: %w", entityID, err)
+	}
+
+	return resp, nil
+}
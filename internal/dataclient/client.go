@@ -5,46 +5,148 @@ import (
 	"fmt"
 	"time"
 
+	cbupb "github.com/adamtc007/KYC-DSL/api/pb"
 	pb "github.com/adamtc007/KYC-DSL/api/pb/kycdata"
+	"github.com/adamtc007/KYC-DSL/internal/actor"
+	"github.com/adamtc007/KYC-DSL/internal/auth"
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/logging"
+	"github.com/adamtc007/KYC-DSL/internal/region"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 )
 
 // DataClient wraps the gRPC connection to the Data Service
 type DataClient struct {
 	conn           *grpc.ClientConn
+	writeConn      *grpc.ClientConn
 	dictClient     pb.DictionaryServiceClient
 	caseClient     pb.CaseServiceClient
+	writeClient    pb.CaseServiceClient
+	cbuClient      cbupb.CbuGraphServiceClient
+	endpoints      *pb.RegionEndpoints
 	defaultTimeout time.Duration
 }
 
 // NewDataClient creates a new data service client
 func NewDataClient(addr string) (*DataClient, error) {
 	if addr == "" {
-		addr = "localhost:50070"
+		addr = config.Current().DataService.Addr
+	}
+
+	conn, err := dial(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	caseClient := pb.NewCaseServiceClient(conn)
+	return &DataClient{
+		conn:           conn,
+		dictClient:     pb.NewDictionaryServiceClient(conn),
+		caseClient:     caseClient,
+		writeClient:    caseClient,
+		cbuClient:      cbupb.NewCbuGraphServiceClient(conn),
+		defaultTimeout: 30 * time.Second,
+	}, nil
+}
+
+// NewRegionalDataClient connects to any data service (addr), asks it for the
+// nearest read endpoint for regionHint, and reads from that region while
+// sending writes to the primary region. An empty hint uses the configured region.
+func NewRegionalDataClient(addr, regionHint string) (*DataClient, error) {
+	if addr == "" {
+		addr = config.Current().DataService.Addr
+	}
+	if regionHint == "" {
+		regionHint = config.Current().Region.Self
+	}
+
+	bootstrap, err := dial(addr)
+	if err != nil {
+		return nil, err
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	ctx = metadata.AppendToOutgoingContext(ctx, region.MetadataKey, regionHint)
+	endpoints, err := pb.NewRegionServiceClient(bootstrap).ResolveEndpoints(ctx, &pb.ResolveEndpointsRequest{
+		RegionHint: regionHint,
+	})
+	if err != nil {
+		bootstrap.Close()
+		return nil, fmt.Errorf("failed to resolve regional endpoints via %s: %w", addr, err)
+	}
+
+	readConn := bootstrap
+	if endpoints.ReadAddress != "" && endpoints.ReadAddress != addr {
+		if readConn, err = dial(endpoints.ReadAddress); err != nil {
+			bootstrap.Close()
+			return nil, err
+		}
+		bootstrap.Close()
+	}
+
+	writeConn := readConn
+	if endpoints.WriteAddress != "" && endpoints.WriteAddress != endpoints.ReadAddress {
+		if writeConn, err = dial(endpoints.WriteAddress); err != nil {
+			readConn.Close()
+			return nil, err
+		}
+	}
+
+	return &DataClient{
+		conn:           readConn,
+		writeConn:      writeConn,
+		dictClient:     pb.NewDictionaryServiceClient(readConn),
+		caseClient:     pb.NewCaseServiceClient(readConn),
+		writeClient:    pb.NewCaseServiceClient(writeConn),
+		cbuClient:      cbupb.NewCbuGraphServiceClient(readConn),
+		endpoints:      endpoints,
+		defaultTimeout: 30 * time.Second,
+	}, nil
+}
+
+// NewFromEnv creates a client for the configured data service address,
+// routing reads to the nearest region when a region is configured
+func NewFromEnv() (*DataClient, error) {
+	cfg := config.Current()
+	if cfg.Region.Self != "" {
+		return NewRegionalDataClient(cfg.DataService.Addr, "")
+	}
+	return NewDataClient(cfg.DataService.Addr)
+}
+
+// dial opens a blocking connection to a data service endpoint
+func dial(addr string) (*grpc.ClientConn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
 	conn, err := grpc.DialContext(ctx, addr,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		grpc.WithChainUnaryInterceptor(logging.UnaryClientInterceptor(), actor.UnaryClientInterceptor()),
+		grpc.WithPerRPCCredentials(auth.APIKeyCredentials(config.Current().DataService.APIKey)),
 		grpc.WithBlock(),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to data service at %s: %w", addr, err)
 	}
+	return conn, nil
+}
 
-	return &DataClient{
-		conn:           conn,
-		dictClient:     pb.NewDictionaryServiceClient(conn),
-		caseClient:     pb.NewCaseServiceClient(conn),
-		defaultTimeout: 30 * time.Second,
-	}, nil
+// Endpoints returns the resolved regional endpoints (nil for single-endpoint clients)
+func (c *DataClient) Endpoints() *pb.RegionEndpoints {
+	return c.endpoints
 }
 
-// Close closes the gRPC connection
+// Close closes the gRPC connections
 func (c *DataClient) Close() error {
+	if c.writeConn != nil && c.writeConn != c.conn {
+		c.writeConn.Close()
+	}
 	if c.conn != nil {
 		return c.conn.Close()
 	}
@@ -93,7 +195,7 @@ func (c *DataClient) SaveCaseVersion(caseName, dslText string) (*pb.CaseVersionR
 		DslSource: dslText,
 	}
 
-	resp, err := c.caseClient.SaveCaseVersion(ctx, req)
+	resp, err := c.writeClient.SaveCaseVersion(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to save case version for %s: %w", caseName, err)
 	}
@@ -158,3 +260,44 @@ func (c *DataClient) ListAllCases(limit, offset int32, statusFilter string) ([]*
 
 	return resp.Cases, nil
 }
+
+// GenerateCaseNarrative renders and stores a due-diligence summary of a case
+// version (the latest when versionID is empty)
+func (c *DataClient) GenerateCaseNarrative(caseName, versionID, template string, polish bool) (*pb.CaseNarrative, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.defaultTimeout)
+	defer cancel()
+
+	req := &pb.GenerateNarrativeRequest{
+		CaseId:    caseName,
+		VersionId: versionID,
+		Template:  template,
+		Polish:    polish,
+	}
+
+	resp, err := c.writeClient.GenerateCaseNarrative(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate narrative for %s: %w", caseName, err)
+	}
+
+	return resp, nil
+}
+
+// GenerateReviewPack renders a case version (the latest when versionID is
+// empty) into a PDF review pack; allowDraft accepts versions not yet approved
+func (c *DataClient) GenerateReviewPack(caseName, versionID string, allowDraft bool) (*pb.ReviewPack, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.defaultTimeout)
+	defer cancel()
+
+	req := &pb.GenerateReviewPackRequest{
+		CaseId:     caseName,
+		VersionId:  versionID,
+		AllowDraft: allowDraft,
+	}
+
+	resp, err := c.caseClient.GenerateReviewPack(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate review pack for %s: %w", caseName, err)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,158 @@
+package dataservice
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/adamtc007/KYC-DSL/api/pb"
+	"github.com/adamtc007/KYC-DSL/internal/engine"
+	"github.com/adamtc007/KYC-DSL/internal/logging"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+// KycCaseService serves case creation and version history on the
+// kyc_case_versions store, case assignment and the analyst work queue
+// (internal/engine Assignments). The remaining KycCaseService RPCs are
+// served by CaseService.
+type KycCaseService struct {
+	pb.UnimplementedKycCaseServiceServer
+
+	// primary forwards assignments to the primary region when this server is a read replica
+	primary pb.KycCaseServiceClient
+}
+
+// NewKycCaseService creates a new KycCaseService instance
+func NewKycCaseService() *KycCaseService {
+	return &KycCaseService{}
+}
+
+// ForwardWritesTo makes CreateCase and AssignCase forward to the primary
+// region's KycCaseService
+func (s *KycCaseService) ForwardWritesTo(primary pb.KycCaseServiceClient) {
+	s.primary = primary
+}
+
+// AssignCase assigns or reassigns a case on behalf of the caller (x-actor),
+// recording the change in the case's assignment history
+func (s *KycCaseService) AssignCase(ctx context.Context, req *pb.AssignCaseRequest) (*pb.CaseAssignment, error) {
+	if s.primary != nil {
+		logging.FromContext(ctx).Info("↪️  AssignCase: forwarding to primary region", "case_name", req.CaseName)
+		return s.primary.AssignCase(ctx, req)
+	}
+
+	logging.FromContext(ctx).Info("🗂️  AssignCase", "case_name", req.CaseName, "assignee", req.Assignee, "team", req.Team)
+
+	to := model.CaseAssignment{Assignee: req.Assignee, Team: req.Team, RiskRating: req.RiskRating}
+	if req.SlaDueAt != nil {
+		due := req.SlaDueAt.AsTime()
+		to.SLADueAt = &due
+	}
+	assignment, err := engine.NewAssignments(DBX).Assign(ctx, req.CaseName, to, req.Reason)
+	if err != nil {
+		return nil, assignmentError(err)
+	}
+
+	logging.FromContext(ctx).Info("✅ Case assigned", "case_name", req.CaseName,
+		"assignee", assignment.Assignee, "team", assignment.Team)
+	return caseAssignmentToProto(*assignment), nil
+}
+
+// GetCaseQueue returns the assigned cases matching the request, soonest SLA
+// due date and highest risk first
+func (s *KycCaseService) GetCaseQueue(ctx context.Context, req *pb.CaseQueueRequest) (*pb.CaseQueue, error) {
+	logging.FromContext(ctx).Info("📥 GetCaseQueue", "assignee", req.Assignee, "team", req.Team, "status", req.Status)
+
+	filter := model.CaseQueueFilter{
+		Assignee:    req.Assignee,
+		Team:        req.Team,
+		RiskRating:  req.RiskRating,
+		OverdueOnly: req.OverdueOnly,
+		Limit:       int(req.Limit),
+	}
+	if req.Status != "" {
+		st, err := engine.ParseStatus(req.Status)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		filter.Status = st
+	}
+	if req.DueBefore != nil {
+		due := req.DueBefore.AsTime()
+		filter.DueBefore = &due
+	}
+
+	queue, err := engine.NewAssignments(DBX).Queue(ctx, filter)
+	if err != nil {
+		return nil, assignmentError(err)
+	}
+	out := &pb.CaseQueue{}
+	for _, a := range queue {
+		out.Cases = append(out.Cases, caseAssignmentToProto(a))
+	}
+	return out, nil
+}
+
+// GetAssignmentHistory returns every assignment of a case, oldest first
+func (s *KycCaseService) GetAssignmentHistory(ctx context.Context, req *pb.GetAssignmentHistoryRequest) (*pb.AssignmentHistory, error) {
+	logging.FromContext(ctx).Info("📜 GetAssignmentHistory", "case_name", req.CaseName)
+
+	changes, err := engine.NewAssignments(DBX).History(ctx, req.CaseName)
+	if err != nil {
+		return nil, err
+	}
+	out := &pb.AssignmentHistory{CaseName: req.CaseName}
+	for _, c := range changes {
+		out.Changes = append(out.Changes, &pb.CaseAssignmentChange{
+			Id:           int32(c.ID), //nolint:gosec
+			CaseName:     c.CaseName,
+			FromAssignee: c.FromAssignee,
+			FromTeam:     c.FromTeam,
+			ToAssignee:   c.ToAssignee,
+			ToTeam:       c.ToTeam,
+			RiskRating:   c.RiskRating,
+			SlaDueAt:     optionalTimestamp(c.SLADueAt),
+			Actor:        c.Actor,
+			Reason:       c.Reason,
+			CreatedAt:    timestamppb.New(c.CreatedAt),
+		})
+	}
+	return out, nil
+}
+
+// assignmentError maps case assignment errors to gRPC status codes
+func assignmentError(err error) error {
+	switch {
+	case errors.Is(err, engine.ErrCaseNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, engine.ErrInvalidAssignment):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return err
+}
+
+func caseAssignmentToProto(a model.CaseAssignment) *pb.CaseAssignment {
+	return &pb.CaseAssignment{
+		CaseName:   a.CaseName,
+		Assignee:   a.Assignee,
+		Team:       a.Team,
+		RiskRating: a.RiskRating,
+		SlaDueAt:   optionalTimestamp(a.SLADueAt),
+		AssignedBy: a.AssignedBy,
+		AssignedAt: timestamppb.New(a.AssignedAt),
+		Status:     string(a.Status),
+		Overdue:    a.Overdue,
+	}
+}
+
+// optionalTimestamp converts a nullable time to a protobuf timestamp
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
@@ -0,0 +1,138 @@
+package dataservice
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/adamtc007/KYC-DSL/api/pb/kycdata"
+	"github.com/adamtc007/KYC-DSL/internal/attrvalue"
+	"github.com/adamtc007/KYC-DSL/internal/casedata"
+	"github.com/adamtc007/KYC-DSL/internal/logging"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+// SetCaseAttributeValue captures a public attribute value for a case
+// version. Values that do not match the attribute's data type or domain are
+// refused with InvalidArgument carrying BadRequest and ErrorInfo details
+// (reason: the broken rule).
+func (s *DataService) SetCaseAttributeValue(ctx context.Context, req *pb.SetCaseAttributeValueRequest) (*pb.CaseAttributeValue, error) {
+	if s.primaryCases != nil {
+		logging.FromContext(ctx).Info("↪️  SetCaseAttributeValue: forwarding to primary region", "case_id", req.CaseId)
+		return s.primaryCases.SetCaseAttributeValue(ctx, req)
+	}
+
+	logging.FromContext(ctx).Info("✍️  SetCaseAttributeValue", "case_id", req.CaseId, "version", req.CaseVersion, "attribute", req.AttributeCode)
+
+	if req.CaseId == "" || req.AttributeCode == "" {
+		return nil, status.Error(codes.InvalidArgument, "case_id and attribute_code are required")
+	}
+	capture := casedata.Capture{
+		CaseName:       req.CaseId,
+		CaseVersion:    int(req.CaseVersion),
+		AttributeCode:  req.AttributeCode,
+		Value:          req.Value,
+		SourceDocument: req.SourceDocument,
+	}
+	if req.EvidenceId != 0 {
+		capture.EvidenceID = &req.EvidenceId
+	}
+	v, err := casedata.NewRepo(DBX).Set(ctx, capture)
+	if err != nil {
+		return nil, caseDataStatus(err)
+	}
+	return caseAttributeValueToProto(*v), nil
+}
+
+// ListCaseAttributeValues returns the captured values of a case version
+func (s *DataService) ListCaseAttributeValues(ctx context.Context, req *pb.ListCaseAttributeValuesRequest) (*pb.CaseAttributeValueList, error) {
+	logging.FromContext(ctx).Info("📋 ListCaseAttributeValues", "case_id", req.CaseId, "version", req.CaseVersion)
+
+	if req.CaseId == "" {
+		return nil, status.Error(codes.InvalidArgument, "case_id is required")
+	}
+	values, err := casedata.NewRepo(DBX).List(ctx, req.CaseId, int(req.CaseVersion))
+	if err != nil {
+		return nil, caseDataStatus(err)
+	}
+	out := &pb.CaseAttributeValueList{}
+	for _, v := range values {
+		out.Values = append(out.Values, caseAttributeValueToProto(v))
+	}
+	return out, nil
+}
+
+// DeleteCaseAttributeValue removes the value of an attribute captured at a
+// case version
+func (s *DataService) DeleteCaseAttributeValue(ctx context.Context, req *pb.DeleteCaseAttributeValueRequest) (*pb.DeleteCaseAttributeValueResponse, error) {
+	if s.primaryCases != nil {
+		logging.FromContext(ctx).Info("↪️  DeleteCaseAttributeValue: forwarding to primary region", "case_id", req.CaseId)
+		return s.primaryCases.DeleteCaseAttributeValue(ctx, req)
+	}
+
+	logging.FromContext(ctx).Info("🗑️  DeleteCaseAttributeValue", "case_id", req.CaseId, "version", req.CaseVersion, "attribute", req.AttributeCode)
+
+	if req.CaseId == "" || req.AttributeCode == "" {
+		return nil, status.Error(codes.InvalidArgument, "case_id and attribute_code are required")
+	}
+	if err := casedata.NewRepo(DBX).Delete(ctx, req.CaseId, int(req.CaseVersion), req.AttributeCode); err != nil {
+		return nil, caseDataStatus(err)
+	}
+	return &pb.DeleteCaseAttributeValueResponse{Deleted: true}, nil
+}
+
+// caseDataStatus maps case attribute value errors to gRPC status codes
+func caseDataStatus(err error) error {
+	var invalid *attrvalue.Error
+	switch {
+	case errors.As(err, &invalid):
+		st, detailErr := status.New(codes.InvalidArgument, invalid.Error()).WithDetails(
+			&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "value", Description: invalid.Message},
+			}},
+			&errdetails.ErrorInfo{
+				Reason: strings.ToUpper(invalid.Rule),
+				Domain: "kyc.data",
+				Metadata: map[string]string{
+					"attribute": invalid.Attribute,
+					"data_type": invalid.DataType,
+					"allowed":   strings.Join(invalid.Allowed, ","),
+				},
+			})
+		if detailErr != nil {
+			return status.Error(codes.InvalidArgument, invalid.Error())
+		}
+		return st.Err()
+	case errors.Is(err, casedata.ErrCaseNotFound), errors.Is(err, casedata.ErrVersionNotFound),
+		errors.Is(err, casedata.ErrValueNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, casedata.ErrUnknownAttribute), errors.Is(err, casedata.ErrDerivedAttribute),
+		errors.Is(err, casedata.ErrUnknownDocument), errors.Is(err, casedata.ErrUnknownEvidence):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return err
+}
+
+func caseAttributeValueToProto(v model.CaseAttributeValue) *pb.CaseAttributeValue {
+	out := &pb.CaseAttributeValue{
+		Id:             v.ID,
+		CaseId:         v.CaseName,
+		CaseVersion:    int32(v.CaseVersion), //nolint:gosec
+		AttributeCode:  v.AttributeCode,
+		Value:          v.Value,
+		ValueType:      v.ValueType,
+		SourceDocument: v.SourceDocument,
+		CapturedBy:     v.CapturedBy,
+		CapturedAt:     v.CapturedAt.Format(time.RFC3339),
+		UpdatedAt:      v.UpdatedAt.Format(time.RFC3339),
+	}
+	if v.EvidenceID != nil {
+		out.EvidenceId = *v.EvidenceID
+	}
+	return out
+}
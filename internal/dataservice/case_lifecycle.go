@@ -0,0 +1,83 @@
+package dataservice
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/adamtc007/KYC-DSL/api/pb/kycdata"
+	"github.com/adamtc007/KYC-DSL/internal/engine"
+	"github.com/adamtc007/KYC-DSL/internal/logging"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+// TransitionCase moves a case to another lifecycle state, refusing moves the
+// lifecycle does not allow with FailedPrecondition
+func (s *DataService) TransitionCase(ctx context.Context, req *pb.TransitionCaseRequest) (*pb.CaseTransition, error) {
+	if s.primaryCases != nil {
+		logging.FromContext(ctx).Info("↪️  TransitionCase: forwarding to primary region", "case_id", req.CaseId)
+		return s.primaryCases.TransitionCase(ctx, req)
+	}
+
+	logging.FromContext(ctx).Info("🔀 TransitionCase", "case_id", req.CaseId, "to", req.ToStatus, "actor", req.Actor)
+
+	to, err := engine.ParseStatus(req.ToStatus)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	t, err := engine.NewLifecycle(DBX).Transition(ctx, req.CaseId, to, req.Actor, req.Reason)
+	if err != nil {
+		return nil, lifecycleError(err)
+	}
+
+	logging.FromContext(ctx).Info("✅ Case transitioned", "case_id", req.CaseId, "from", t.From, "to", t.To)
+	return caseTransitionToProto(*t), nil
+}
+
+// GetCaseTimeline returns a case's lifecycle state, the states it may move
+// to and every transition it went through
+func (s *DataService) GetCaseTimeline(ctx context.Context, req *pb.GetCaseTimelineRequest) (*pb.CaseTimeline, error) {
+	logging.FromContext(ctx).Info("🕰️  GetCaseTimeline", "case_id", req.CaseId)
+
+	timeline, err := engine.NewLifecycle(DBX).Timeline(ctx, req.CaseId)
+	if err != nil {
+		return nil, lifecycleError(err)
+	}
+
+	out := &pb.CaseTimeline{CaseId: timeline.CaseName, Status: string(timeline.Status)}
+	for _, a := range timeline.Allowed {
+		out.AllowedTransitions = append(out.AllowedTransitions, string(a))
+	}
+	for _, t := range timeline.Transitions {
+		out.Transitions = append(out.Transitions, caseTransitionToProto(t))
+	}
+	return out, nil
+}
+
+// lifecycleError maps case lifecycle errors to gRPC status codes
+func lifecycleError(err error) error {
+	switch {
+	case errors.Is(err, engine.ErrCaseNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, engine.ErrInvalidTransition):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, engine.ErrUnknownStatus):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return err
+}
+
+func caseTransitionToProto(t model.CaseTransition) *pb.CaseTransition {
+	return &pb.CaseTransition{
+		Id:         int32(t.ID), //nolint:gosec
+		CaseId:     t.CaseName,
+		FromStatus: string(t.From),
+		ToStatus:   string(t.To),
+		Actor:      t.Actor,
+		Reason:     t.Reason,
+		CreatedAt:  t.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,189 @@
+package dataservice
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	pb "github.com/adamtc007/KYC-DSL/api/pb"
+	"github.com/adamtc007/KYC-DSL/internal/logging"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// cbuMembersAsOf selects the ids of the entities in CBU $1 at time $2: every
+// entity holding a role on that date plus the sponsor
+const cbuMembersAsOf = `
+	SELECT entity_id FROM cbu_role
+	 WHERE cbu_id = $1 AND start_date <= $2::date AND (end_date IS NULL OR end_date >= $2::date)
+	UNION
+	SELECT sponsor_entity_id FROM cbu
+	 WHERE id = $1 AND sponsor_entity_id IS NOT NULL`
+
+// edgeOnRecordAsOf restricts entity_control_history ec to the version of each
+// edge on record at time $2 that is effective on that date
+const edgeOnRecordAsOf = `ec.operation <> 'DELETE'
+	     AND ec.recorded_at <= $2 AND (ec.superseded_at IS NULL OR ec.superseded_at > $2)
+	     AND ec.start_date <= $2::date AND (ec.end_date IS NULL OR ec.end_date >= $2::date)`
+
+// Change types reported by DiffGraph
+const (
+	changeAdded   = "added"
+	changeRemoved = "removed"
+	changeChanged = "changed"
+)
+
+// GetRelationshipHistory lists every recorded version of an edge, oldest first
+func (s *CbuGraphService) GetRelationshipHistory(ctx context.Context, req *pb.RelationshipHistoryRequest) (*pb.RelationshipHistory, error) {
+	logging.FromContext(ctx).Info("🕰️  GetRelationshipHistory", "cbu_id", req.CbuId, "relationship_id", req.RelationshipId)
+
+	rows, err := DB.Query(ctx, `SELECT`+relationshipColumns+`,
+	       ec.operation, ec.recorded_at, ec.superseded_at
+	    FROM entity_control_history ec
+	   WHERE ec.id = $2
+	     AND EXISTS (SELECT 1 FROM cbu_role cr
+	                  WHERE cr.cbu_id = $1 AND cr.entity_id IN (ec.controller_entity_id, ec.controlled_entity_id))
+	   ORDER BY ec.recorded_at, ec.history_id`, req.CbuId, req.RelationshipId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load relationship history: %w", err)
+	}
+	defer rows.Close()
+
+	history := &pb.RelationshipHistory{RelationshipId: req.RelationshipId}
+	for rows.Next() {
+		var r pb.CbuRelationship
+		var ctype, basis string
+		var pct float64
+		var effective, recorded time.Time
+		var end, superseded *time.Time
+		v := &pb.RelationshipVersion{Relationship: &r}
+		if err := rows.Scan(&r.Id, &r.FromId, &r.ToId, &ctype, &basis, &pct, &effective, &r.RoleId, &end,
+			&v.Operation, &recorded, &superseded); err != nil {
+			return nil, fmt.Errorf("failed to scan relationship version: %w", err)
+		}
+		r.RelationType, r.IsBeneficial = relationType(ctype, basis)
+		r.ControlPct = float32(pct)
+		r.EffectiveDate = timestamppb.New(effective)
+		if end != nil {
+			r.EndDate = timestamppb.New(*end)
+		}
+		v.RecordedAt = timestamppb.New(recorded)
+		if superseded != nil {
+			v.SupersededAt = timestamppb.New(*superseded)
+		}
+		history.Versions = append(history.Versions, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to load relationship history: %w", err)
+	}
+	if len(history.Versions) == 0 {
+		return nil, fmt.Errorf("relationship %s not found in cbu %s", req.RelationshipId, req.CbuId)
+	}
+	return history, nil
+}
+
+// DiffGraph reports the ownership and control changes of a CBU between two dates
+func (s *CbuGraphService) DiffGraph(ctx context.Context, req *pb.DiffGraphRequest) (*pb.GraphDiff, error) {
+	if req.From == nil {
+		return nil, fmt.Errorf("from is required")
+	}
+	from := req.From.AsTime()
+	to := time.Now()
+	if req.To != nil {
+		to = req.To.AsTime()
+	}
+	logging.FromContext(ctx).Info("🕰️  DiffGraph", "cbu_id", req.CbuId, "from", from, "to", to)
+	if !from.Before(to) {
+		return nil, fmt.Errorf("from must be before to")
+	}
+
+	before, err := loadGraphAsOf(ctx, req.CbuId, &from)
+	if err != nil {
+		return nil, err
+	}
+	after, err := loadGraphAsOf(ctx, req.CbuId, &to)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := diffGraphs(before, after)
+	diff.From = timestamppb.New(from)
+	diff.To = timestamppb.New(to)
+	logging.FromContext(ctx).Info("✅ Diffed CBU graph", "changes", len(diff.Changes),
+		"added_entities", len(diff.AddedEntities), "removed_entities", len(diff.RemovedEntities))
+	return diff, nil
+}
+
+// diffGraphs compares two versions of a CBU graph edge by edge
+func diffGraphs(before, after *pb.CbuGraph) *pb.GraphDiff {
+	diff := &pb.GraphDiff{CbuId: after.CbuId}
+
+	names := make(map[string]string)
+	beforeEntities := make(map[string]bool, len(before.Entities))
+	for _, e := range before.Entities {
+		names[e.Id] = e.Name
+		beforeEntities[e.Id] = true
+	}
+	afterEntities := make(map[string]bool, len(after.Entities))
+	for _, e := range after.Entities {
+		names[e.Id] = e.Name
+		afterEntities[e.Id] = true
+		if !beforeEntities[e.Id] {
+			diff.AddedEntities = append(diff.AddedEntities, e)
+		}
+	}
+	for _, e := range before.Entities {
+		if !afterEntities[e.Id] {
+			diff.RemovedEntities = append(diff.RemovedEntities, e)
+		}
+	}
+
+	change := func(kind string, r *pb.CbuRelationship) *pb.OwnershipChange {
+		return &pb.OwnershipChange{
+			ChangeType:     kind,
+			RelationshipId: r.Id,
+			FromId:         r.FromId,
+			FromName:       names[r.FromId],
+			ToId:           r.ToId,
+			ToName:         names[r.ToId],
+			RelationType:   r.RelationType,
+			IsBeneficial:   r.IsBeneficial,
+		}
+	}
+
+	old := make(map[string]*pb.CbuRelationship, len(before.Relationships))
+	for _, r := range before.Relationships {
+		old[r.Id] = r
+	}
+	seen := make(map[string]bool, len(after.Relationships))
+	for _, r := range after.Relationships {
+		seen[r.Id] = true
+		prev, ok := old[r.Id]
+		switch {
+		case !ok:
+			c := change(changeAdded, r)
+			c.NewPct = r.ControlPct
+			diff.Changes = append(diff.Changes, c)
+		case prev.ControlPct != r.ControlPct || prev.RelationType != r.RelationType || prev.IsBeneficial != r.IsBeneficial:
+			c := change(changeChanged, r)
+			c.OldPct, c.NewPct = prev.ControlPct, r.ControlPct
+			diff.Changes = append(diff.Changes, c)
+		}
+	}
+	for _, r := range before.Relationships {
+		if !seen[r.Id] {
+			c := change(changeRemoved, r)
+			c.OldPct = r.ControlPct
+			diff.Changes = append(diff.Changes, c)
+		}
+	}
+
+	sort.SliceStable(diff.Changes, func(i, j int) bool {
+		a, b := diff.Changes[i], diff.Changes[j]
+		if a.ToName != b.ToName {
+			return a.ToName < b.ToName
+		}
+		return a.FromName < b.FromName
+	})
+	return diff
+}
@@ -0,0 +1,639 @@
+package dataservice
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	pb "github.com/adamtc007/KYC-DSL/api/pb"
+	"github.com/adamtc007/KYC-DSL/internal/logging"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CbuGraphService serves Client Business Unit graphs from the entity, cbu,
+// cbu_role and entity_control tables
+type CbuGraphService struct {
+	pb.UnimplementedCbuGraphServiceServer
+
+	// primary forwards graph writes to the primary region when this server is a read replica
+	primary pb.CbuGraphServiceClient
+}
+
+// NewCbuGraphService creates a new CbuGraphService instance
+func NewCbuGraphService() *CbuGraphService {
+	return &CbuGraphService{}
+}
+
+// ForwardWritesTo makes the RPCs that create, update or delete CBUs,
+// entities and relationships forward to the primary region's
+// CbuGraphService
+func (s *CbuGraphService) ForwardWritesTo(primary pb.CbuGraphServiceClient) {
+	s.primary = primary
+}
+
+// cbuMembers selects the ids of the entities in CBU $1: every entity holding
+// a current role plus the sponsor
+const cbuMembers = `
+	SELECT entity_id FROM cbu_role
+	 WHERE cbu_id = $1 AND (end_date IS NULL OR end_date >= CURRENT_DATE)
+	UNION
+	SELECT sponsor_entity_id FROM cbu
+	 WHERE id = $1 AND sponsor_entity_id IS NOT NULL`
+
+// entityColumns selects a CbuEntity from entity e
+const entityColumns = `
+	e.id, e.name, e.entity_type, COALESCE(e.jurisdiction, ''), COALESCE(e.lei_code, ''),
+	COALESCE(e.registration_number, ''), COALESCE(e.created_at, now()),
+	COALESCE((e.metadata->>'x')::real, 0), COALESCE((e.metadata->>'y')::real, 0)`
+
+// relationshipColumns selects a CbuRelationship from entity_control ec (or
+// entity_control_history, which has the same columns); role_id is the
+// controller's role in CBU $1
+const relationshipColumns = `
+	ec.id, ec.controller_entity_id, ec.controlled_entity_id, ec.control_type::text,
+	COALESCE(ec.control_basis, ''), COALESCE(ec.control_percentage, 0)::float8, ec.start_date,
+	COALESCE((SELECT rt.code FROM cbu_role cr JOIN role_type rt ON rt.id = cr.role_type_id
+	           WHERE cr.cbu_id = $1 AND cr.entity_id = ec.controller_entity_id
+	           ORDER BY cr.is_primary DESC LIMIT 1), ''),
+	ec.end_date`
+
+// Relation types of the CbuGraph vocabulary
+const (
+	relationOwns      = "owns"
+	relationControls  = "controls"
+	relationDelegates = "delegates"
+	relationReportsTo = "reports_to"
+	relationCustodies = "custodies"
+)
+
+// relationType maps an entity_control row to the CbuGraph relation vocabulary.
+// Operational relations other than plain control keep their name in control_basis.
+func relationType(controlType, basis string) (relation string, beneficial bool) {
+	switch controlType {
+	case "BENEFICIAL_OWNERSHIP":
+		return relationOwns, true
+	case "LEGAL_OWNERSHIP", "ECONOMIC_INTEREST":
+		return relationOwns, false
+	case "OPERATIONAL_CONTROL":
+		switch basis {
+		case relationDelegates, relationReportsTo, relationCustodies:
+			return basis, false
+		}
+	}
+	return relationControls, false
+}
+
+// controlType maps a CbuGraph relation to an entity_control type and basis
+func controlType(relation string, beneficial bool) (ctype, basis string, err error) {
+	switch relation {
+	case relationOwns:
+		if beneficial {
+			return "BENEFICIAL_OWNERSHIP", "", nil
+		}
+		return "LEGAL_OWNERSHIP", "", nil
+	case relationControls:
+		return "VOTING_CONTROL", "", nil
+	case relationDelegates, relationReportsTo, relationCustodies:
+		return "OPERATIONAL_CONTROL", relation, nil
+	}
+	return "", "", fmt.Errorf("unknown relation type %q (expected owns, controls, delegates, reports_to or custodies)", relation)
+}
+
+func scanEntity(row pgx.Row) (*pb.CbuEntity, error) {
+	var e pb.CbuEntity
+	var createdAt time.Time
+	if err := row.Scan(&e.Id, &e.Name, &e.EntityType, &e.Jurisdiction, &e.LeiCode,
+		&e.TaxId, &createdAt, &e.X, &e.Y); err != nil {
+		return nil, err
+	}
+	e.CreatedAt = timestamppb.New(createdAt)
+	return &e, nil
+}
+
+func scanRelationship(row pgx.Row) (*pb.CbuRelationship, error) {
+	var r pb.CbuRelationship
+	var ctype, basis string
+	var pct float64
+	var effective time.Time
+	var end *time.Time
+	if err := row.Scan(&r.Id, &r.FromId, &r.ToId, &ctype, &basis, &pct, &effective, &r.RoleId, &end); err != nil {
+		return nil, err
+	}
+	r.RelationType, r.IsBeneficial = relationType(ctype, basis)
+	r.ControlPct = float32(pct)
+	r.EffectiveDate = timestamppb.New(effective)
+	if end != nil {
+		r.EndDate = timestamppb.New(*end)
+	}
+	return &r, nil
+}
+
+// loadGraph reads a CBU with its entities, roles and the current
+// relationships between its entities
+func loadGraph(ctx context.Context, cbuID string) (*pb.CbuGraph, error) {
+	return loadGraphAsOf(ctx, cbuID, nil)
+}
+
+// loadGraphAsOf reads a CBU as of a point in time: the entities holding a
+// role on that date and the relationships effective on that date in the
+// version on record at that time. Entity details are the current ones.
+// A nil asOf reads the current graph.
+func loadGraphAsOf(ctx context.Context, cbuID string, asOf *time.Time) (*pb.CbuGraph, error) {
+	members, edges, args := cbuMembers, `
+	    FROM entity_control ec
+	   WHERE ec.controller_entity_id IN (`+cbuMembers+`)
+	     AND ec.controlled_entity_id IN (`+cbuMembers+`)
+	     AND (ec.end_date IS NULL OR ec.end_date >= CURRENT_DATE)`, []any{cbuID}
+	if asOf != nil {
+		members, edges, args = cbuMembersAsOf, `
+	    FROM entity_control_history ec
+	   WHERE ec.controller_entity_id IN (`+cbuMembersAsOf+`)
+	     AND ec.controlled_entity_id IN (`+cbuMembersAsOf+`)
+	     AND `+edgeOnRecordAsOf, []any{cbuID, *asOf}
+	}
+
+	graph := &pb.CbuGraph{}
+	var createdAt, updatedAt time.Time
+	err := DB.QueryRow(ctx, `
+	  SELECT id, name, COALESCE(description, ''), COALESCE(created_at, now()), COALESCE(updated_at, now())
+	    FROM cbu WHERE id = $1`, cbuID).
+		Scan(&graph.CbuId, &graph.Name, &graph.Description, &createdAt, &updatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("cbu not found: %s", cbuID)
+		}
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	graph.CreatedAt = timestamppb.New(createdAt)
+	graph.UpdatedAt = timestamppb.New(updatedAt)
+
+	rows, err := DB.Query(ctx, `SELECT`+entityColumns+`
+	    FROM entity e WHERE e.id IN (`+members+`)
+	   ORDER BY e.name`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load cbu entities: %w", err)
+	}
+	for rows.Next() {
+		e, err := scanEntity(rows)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan entity: %w", err)
+		}
+		graph.Entities = append(graph.Entities, e)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to load cbu entities: %w", err)
+	}
+
+	rows, err = DB.Query(ctx, `
+	  SELECT DISTINCT rt.code, rt.name, COALESCE(rt.description, ''), COALESCE(rt.category, '')
+	    FROM cbu_role cr JOIN role_type rt ON rt.id = cr.role_type_id
+	   WHERE cr.cbu_id = $1
+	   ORDER BY rt.code`, cbuID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load cbu roles: %w", err)
+	}
+	for rows.Next() {
+		var role pb.CbuRole
+		if err := rows.Scan(&role.Id, &role.Name, &role.Description, &role.RegulatoryClassification); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan role: %w", err)
+		}
+		graph.Roles = append(graph.Roles, &role)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to load cbu roles: %w", err)
+	}
+
+	rows, err = DB.Query(ctx, `SELECT`+relationshipColumns+edges+`
+	   ORDER BY ec.start_date, ec.id`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load cbu relationships: %w", err)
+	}
+	for rows.Next() {
+		r, err := scanRelationship(rows)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan relationship: %w", err)
+		}
+		graph.Relationships = append(graph.Relationships, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to load cbu relationships: %w", err)
+	}
+
+	graph.EntityCount = int32(len(graph.Entities))            //nolint:gosec
+	graph.RelationshipCount = int32(len(graph.Relationships)) //nolint:gosec
+	return graph, nil
+}
+
+// GetGraph retrieves the complete organizational graph for a CBU, as of
+// req.AsOf when set
+func (s *CbuGraphService) GetGraph(ctx context.Context, req *pb.GetCbuRequest) (*pb.CbuGraph, error) {
+	log := logging.FromContext(ctx)
+	var asOf *time.Time
+	if req.AsOf != nil {
+		t := req.AsOf.AsTime()
+		asOf = &t
+		log = log.With("as_of", t)
+	}
+	log.Info("🕸️  GetGraph", "cbu_id", req.CbuId)
+
+	graph, err := loadGraphAsOf(ctx, req.CbuId, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("✅ Loaded CBU graph", "name", graph.Name,
+		"entities", graph.EntityCount, "relationships", graph.RelationshipCount)
+	return graph, nil
+}
+
+// GetEntity retrieves a single entity of a CBU
+func (s *CbuGraphService) GetEntity(ctx context.Context, req *pb.GetEntityRequest) (*pb.CbuEntity, error) {
+	logging.FromContext(ctx).Info("📦 GetEntity", "cbu_id", req.CbuId, "entity_id", req.EntityId)
+
+	e, err := scanEntity(DB.QueryRow(ctx, `SELECT`+entityColumns+`
+	    FROM entity e WHERE e.id = $2 AND e.id IN (`+cbuMembers+`)`, req.CbuId, req.EntityId))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("entity %s not found in cbu %s", req.EntityId, req.CbuId)
+		}
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	return e, nil
+}
+
+// ListEntities streams all entities in a CBU
+func (s *CbuGraphService) ListEntities(req *pb.GetCbuRequest, stream pb.CbuGraphService_ListEntitiesServer) error {
+	ctx := stream.Context()
+	logging.FromContext(ctx).Info("📦 ListEntities", "cbu_id", req.CbuId)
+
+	members, args := cbuMembers, []any{req.CbuId}
+	if req.AsOf != nil {
+		members, args = cbuMembersAsOf, []any{req.CbuId, req.AsOf.AsTime()}
+	}
+	rows, err := DB.Query(ctx, `SELECT`+entityColumns+`
+	    FROM entity e WHERE e.id IN (`+members+`)
+	   ORDER BY e.name`, args...)
+	if err != nil {
+		return fmt.Errorf("failed to list cbu entities: %w", err)
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		e, err := scanEntity(rows)
+		if err != nil {
+			return fmt.Errorf("failed to scan entity: %w", err)
+		}
+		if err := stream.Send(e); err != nil {
+			return err
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to list cbu entities: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("✅ Streamed CBU entities", "count", count)
+	return nil
+}
+
+// GetRelationships retrieves the inbound and outbound relationships of an entity
+func (s *CbuGraphService) GetRelationships(ctx context.Context, req *pb.GetEntityRequest) (*pb.RelationshipResponse, error) {
+	logging.FromContext(ctx).Info("🔗 GetRelationships", "cbu_id", req.CbuId, "entity_id", req.EntityId)
+
+	graph, err := loadGraph(ctx, req.CbuId)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.RelationshipResponse{EntityId: req.EntityId}
+	for _, r := range graph.Relationships {
+		if r.ToId == req.EntityId {
+			resp.Inbound = append(resp.Inbound, r)
+		}
+		if r.FromId == req.EntityId {
+			resp.Outbound = append(resp.Outbound, r)
+		}
+	}
+	return resp, nil
+}
+
+// defaultOwnershipTolerance absorbs rounding in recorded stakes: an entity may
+// be owned up to 100% plus this many percentage points
+const defaultOwnershipTolerance = 0.01
+
+// Cycle enumeration bounds for densely cross-held graphs
+const (
+	maxReportedCycles = 50
+	maxCycleSteps     = 100000
+)
+
+// ValidateGraph validates the graph structure and control percentages
+func (s *CbuGraphService) ValidateGraph(ctx context.Context, req *pb.ValidateGraphRequest) (*pb.ValidationResponse, error) {
+	logging.FromContext(ctx).Info("🔍 ValidateGraph", "cbu_id", req.CbuId, "tolerance", req.OwnershipTolerance)
+
+	graph, err := loadGraph(ctx, req.CbuId)
+	if err != nil {
+		return nil, err
+	}
+
+	var primaryID string
+	err = DB.QueryRow(ctx, `
+	  SELECT entity_id FROM cbu_role WHERE cbu_id = $1 AND is_primary
+	   ORDER BY created_at LIMIT 1`, req.CbuId).Scan(&primaryID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	stored, err := loadUpstreamStakes(ctx, req.CbuId)
+	if err != nil {
+		return nil, err
+	}
+
+	tolerance := req.OwnershipTolerance
+	if tolerance <= 0 {
+		tolerance = defaultOwnershipTolerance
+	}
+	resp := validateGraph(graph, primaryID, stored, tolerance)
+	logging.FromContext(ctx).Info("✅ Validated CBU graph", "valid", resp.Valid, "issues", len(resp.Issues))
+	return resp, nil
+}
+
+// storedStake is a current ownership or control edge from entity_control,
+// which may lead outside the CBU
+type storedStake struct {
+	id, from, to, fromName, controlType, relation string
+	pct                                           float32
+}
+
+// loadUpstreamStakes returns the current ownership and control edges into the
+// entities of a CBU and, transitively, into their holders, so totals include
+// holders outside the CBU and cycles through them are found
+func loadUpstreamStakes(ctx context.Context, cbuID string) ([]storedStake, error) {
+	rows, err := DB.Query(ctx, `
+	  WITH RECURSIVE up AS (
+	      SELECT ec.id, ec.controller_entity_id, ec.controlled_entity_id, 1 AS depth
+	        FROM entity_control ec
+	       WHERE ec.controlled_entity_id IN (`+cbuMembers+`)
+	         AND (ec.end_date IS NULL OR ec.end_date >= CURRENT_DATE)
+	      UNION
+	      SELECT ec.id, ec.controller_entity_id, ec.controlled_entity_id, up.depth + 1
+	        FROM up
+	        JOIN entity_control ec ON ec.controlled_entity_id = up.controller_entity_id
+	       WHERE up.depth < $2
+	         AND (ec.end_date IS NULL OR ec.end_date >= CURRENT_DATE)
+	  )
+	  SELECT DISTINCT ec.id::text, ec.controller_entity_id::text, ec.controlled_entity_id::text, e.name,
+	         ec.control_type::text, COALESCE(ec.control_basis, ''), COALESCE(ec.control_percentage, 0)::float8
+	    FROM up
+	    JOIN entity_control ec ON ec.id = up.id
+	    JOIN entity e ON e.id = ec.controller_entity_id`, cbuID, maxChainDepth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load stored ownership: %w", err)
+	}
+	defer rows.Close()
+
+	var out []storedStake
+	for rows.Next() {
+		var st storedStake
+		var basis string
+		var pct float64
+		if err := rows.Scan(&st.id, &st.from, &st.to, &st.fromName, &st.controlType, &basis, &pct); err != nil {
+			return nil, fmt.Errorf("failed to scan stored ownership: %w", err)
+		}
+		st.relation, _ = relationType(st.controlType, basis)
+		st.pct = float32(pct)
+		out = append(out, st)
+	}
+	return out, rows.Err()
+}
+
+// validateGraph checks ownership totals per ownership type against the
+// stored stakes, connectivity to the primary entity and circular ownership or
+// control. total_control_pct is the direct ownership in the primary entity
+// held within the CBU.
+func validateGraph(graph *pb.CbuGraph, primaryID string, stored []storedStake, tolerance float32) *pb.ValidationResponse {
+	resp := &pb.ValidationResponse{Valid: true}
+	issue := func(severity, code, msg, entityID, relID string) *pb.CbuValidationIssue {
+		if severity == "error" {
+			resp.Valid = false
+		}
+		i := &pb.CbuValidationIssue{
+			Severity: severity, Code: code, Message: msg, EntityId: entityID, RelationshipId: relID,
+		}
+		resp.Issues = append(resp.Issues, i)
+		return i
+	}
+
+	names := make(map[string]string, len(graph.Entities))
+	for _, e := range graph.Entities {
+		names[e.Id] = e.Name
+	}
+	for _, st := range stored {
+		if _, ok := names[st.from]; !ok {
+			names[st.from] = st.fromName
+		}
+	}
+
+	// Ownership totals: legal, beneficial and economic stakes are separate
+	// layers, each of which may add up to 100%
+	owned := make(map[string]map[string]float32)
+	edges := make(map[string][]string)
+	for _, st := range stored {
+		if st.relation == relationOwns || st.relation == relationControls {
+			edges[st.from] = append(edges[st.from], st.to)
+		}
+		if st.relation != relationOwns {
+			continue
+		}
+		if owned[st.to] == nil {
+			owned[st.to] = make(map[string]float32)
+		}
+		owned[st.to][st.controlType] += st.pct
+	}
+	for _, e := range graph.Entities {
+		types := make([]string, 0, len(owned[e.Id]))
+		for ctype := range owned[e.Id] {
+			types = append(types, ctype)
+		}
+		sort.Strings(types)
+		for _, ctype := range types {
+			if pct := owned[e.Id][ctype]; pct > 100+tolerance {
+				issue("error", "ownership_exceeded", fmt.Sprintf("%s is %.2f%% owned by %s (exceeds 100%% by more than %.2f points)",
+					e.Name, pct, strings.ToLower(ctype), tolerance), e.Id, "")
+			}
+		}
+	}
+
+	// Connectivity within the CBU, ignoring direction
+	adjacent := make(map[string][]string)
+	for _, r := range graph.Relationships {
+		adjacent[r.FromId] = append(adjacent[r.FromId], r.ToId)
+		adjacent[r.ToId] = append(adjacent[r.ToId], r.FromId)
+		if r.RelationType == relationOwns && r.ToId == primaryID {
+			resp.TotalControlPct += r.ControlPct
+		}
+	}
+	reachable := make(map[string]bool)
+	if primaryID != "" {
+		queue := []string{primaryID}
+		reachable[primaryID] = true
+		for len(queue) > 0 {
+			id := queue[0]
+			queue = queue[1:]
+			for _, next := range adjacent[id] {
+				if !reachable[next] {
+					reachable[next] = true
+					queue = append(queue, next)
+				}
+			}
+		}
+	}
+	for _, e := range graph.Entities {
+		switch {
+		case len(graph.Entities) > 1 && len(adjacent[e.Id]) == 0:
+			issue("warning", "no_relationships", fmt.Sprintf("%s has no relationships in this CBU", e.Name), e.Id, "")
+		case primaryID != "" && !reachable[e.Id]:
+			issue("warning", "orphan", fmt.Sprintf("%s is not connected to the primary entity %s", e.Name, names[primaryID]), e.Id, "")
+		}
+	}
+
+	cycles, truncated := findCycles(edges, maxReportedCycles)
+	for _, cycle := range cycles {
+		labels := make([]string, len(cycle))
+		for i, id := range cycle {
+			labels[i] = names[id]
+		}
+		i := issue("error", "cycle", "circular ownership: "+strings.Join(labels, " → "), cycle[0], "")
+		i.Path = cycle
+	}
+	if truncated {
+		issue("warning", "cycle", fmt.Sprintf("cycle search stopped after %d cycles; the graph may contain more", len(cycles)), "", "")
+	}
+	if primaryID == "" {
+		issue("info", "no_primary", "no primary entity is set for this CBU", "", "")
+	}
+	return resp
+}
+
+// findCycles enumerates the elementary cycles of edges, at most limit of them
+// within maxCycleSteps. Each cycle starts at its smallest entity id and
+// repeats it at the end, so every cycle is reported once whatever entity the
+// search entered it from.
+func findCycles(edges map[string][]string, limit int) (cycles [][]string, truncated bool) {
+	steps := 0
+	ids := make([]string, 0, len(edges))
+	for id := range edges {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, start := range ids {
+		path := []string{start}
+		onPath := map[string]bool{start: true}
+		var walk func(id string) bool
+		walk = func(id string) bool {
+			next := append([]string(nil), edges[id]...)
+			sort.Strings(next)
+			for i, to := range next {
+				if i > 0 && to == next[i-1] {
+					continue // parallel edges (e.g. legal and beneficial) form one cycle
+				}
+				switch {
+				case to == start:
+					if len(cycles) == limit {
+						return false
+					}
+					cycle := append(append([]string(nil), path...), start)
+					cycles = append(cycles, cycle)
+				case to > start && !onPath[to]:
+					if steps++; steps > maxCycleSteps {
+						return false
+					}
+					path = append(path, to)
+					onPath[to] = true
+					ok := walk(to)
+					onPath[to] = false
+					path = path[:len(path)-1]
+					if !ok {
+						return false
+					}
+				}
+			}
+			return true
+		}
+		if !walk(start) {
+			return cycles, true
+		}
+	}
+	return cycles, false
+}
+
+// GetControlChain traces the control chain from root to a specific entity
+func (s *CbuGraphService) GetControlChain(ctx context.Context, req *pb.GetEntityRequest) (*pb.ControlChainResponse, error) {
+	logging.FromContext(ctx).Info("⛓️  GetControlChain", "cbu_id", req.CbuId, "entity_id", req.EntityId)
+
+	graph, err := loadGraph(ctx, req.CbuId)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := tracePath(graph, req.EntityId)
+	logging.FromContext(ctx).Info("✅ Traced control chain", "hops", len(resp.Chain), "effective_pct", resp.EffectiveControlPct)
+	return resp, nil
+}
+
+// tracePath walks up from target along its strongest ownership or control
+// edge until it reaches an entity nobody controls, returning the chain root
+// first. Effective control is the product of the percentages along the chain.
+func tracePath(graph *pb.CbuGraph, target string) *pb.ControlChainResponse {
+	names := make(map[string]string, len(graph.Entities))
+	for _, e := range graph.Entities {
+		names[e.Id] = e.Name
+	}
+
+	strongest := make(map[string]*pb.CbuRelationship)
+	for _, r := range graph.Relationships {
+		if r.RelationType != relationOwns && r.RelationType != relationControls {
+			continue
+		}
+		if best, ok := strongest[r.ToId]; !ok || r.ControlPct > best.ControlPct {
+			strongest[r.ToId] = r
+		}
+	}
+
+	resp := &pb.ControlChainResponse{TargetEntityId: target}
+	visited := map[string]bool{target: true}
+	effective := float32(100)
+	for current := target; ; {
+		r, ok := strongest[current]
+		if !ok || visited[r.FromId] {
+			break
+		}
+		visited[r.FromId] = true
+		resp.Chain = append([]*pb.ControlLink{{
+			FromEntityId:   r.FromId,
+			FromEntityName: names[r.FromId],
+			ToEntityId:     r.ToId,
+			ToEntityName:   names[r.ToId],
+			RelationType:   r.RelationType,
+			ControlPct:     r.ControlPct,
+			RoleId:         r.RoleId,
+		}}, resp.Chain...)
+		effective = effective * r.ControlPct / 100
+		current = r.FromId
+	}
+	if len(resp.Chain) > 0 {
+		resp.EffectiveControlPct = effective
+	}
+	return resp
+}
@@ -0,0 +1,353 @@
+package dataservice
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	pb "github.com/adamtc007/KYC-DSL/api/pb"
+	"github.com/adamtc007/KYC-DSL/internal/logging"
+	"github.com/jackc/pgx/v5"
+)
+
+// entityTypes are the values allowed by the entity_type_check constraint
+var entityTypes = []string{"COMPANY", "FUND", "PERSON", "PARTNERSHIP", "TRUST", "OTHER"}
+
+// normalizeEntityType upper-cases t and checks it against entityTypes
+func normalizeEntityType(t string) (string, error) {
+	t = strings.ToUpper(strings.TrimSpace(t))
+	for _, allowed := range entityTypes {
+		if t == allowed {
+			return t, nil
+		}
+	}
+	return "", fmt.Errorf("invalid entity_type %q (expected one of %s)", t, strings.Join(entityTypes, ", "))
+}
+
+// ListCbus lists the CBUs with their entity and relationship counts
+func (s *CbuGraphService) ListCbus(ctx context.Context, req *pb.ListCbusRequest) (*pb.CbuList, error) {
+	logging.FromContext(ctx).Info("🏢 ListCbus", "limit", req.Limit, "offset", req.Offset)
+
+	limit := req.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+
+	rows, err := DB.Query(ctx, `
+	  SELECT c.id, c.name, COALESCE(c.code, ''), COALESCE(c.domicile, ''),
+	         (SELECT COUNT(DISTINCT cr.entity_id) FROM cbu_role cr
+	           WHERE cr.cbu_id = c.id AND (cr.end_date IS NULL OR cr.end_date >= CURRENT_DATE)),
+	         (SELECT COUNT(*) FROM entity_control ec
+	           WHERE ec.controlled_entity_id IN (SELECT entity_id FROM cbu_role WHERE cbu_id = c.id)
+	             AND ec.controller_entity_id IN (SELECT entity_id FROM cbu_role WHERE cbu_id = c.id)
+	             AND (ec.end_date IS NULL OR ec.end_date >= CURRENT_DATE))
+	    FROM cbu c ORDER BY c.name LIMIT $1 OFFSET $2`, limit, req.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cbus: %w", err)
+	}
+	defer rows.Close()
+
+	list := &pb.CbuList{}
+	for rows.Next() {
+		var c pb.CbuSummary
+		if err := rows.Scan(&c.CbuId, &c.Name, &c.Code, &c.Domicile, &c.EntityCount, &c.RelationshipCount); err != nil {
+			return nil, fmt.Errorf("failed to scan cbu: %w", err)
+		}
+		list.Cbus = append(list.Cbus, &c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list cbus: %w", err)
+	}
+
+	if err := DB.QueryRow(ctx, "SELECT COUNT(*) FROM cbu").Scan(&list.TotalCount); err != nil {
+		list.TotalCount = int32(len(list.Cbus)) //nolint:gosec
+	}
+
+	logging.FromContext(ctx).Info("✅ Listed CBUs", "count", len(list.Cbus), "total", list.TotalCount)
+	return list, nil
+}
+
+// CreateCbu creates an empty CBU graph
+func (s *CbuGraphService) CreateCbu(ctx context.Context, req *pb.CreateCbuRequest) (*pb.CbuGraph, error) {
+	if s.primary != nil {
+		logging.FromContext(ctx).Info("↪️  CreateCbu: forwarding to primary region", "name", req.Name)
+		return s.primary.CreateCbu(ctx, req)
+	}
+
+	logging.FromContext(ctx).Info("🏢 CreateCbu", "name", req.Name, "code", req.Code)
+
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, fmt.Errorf("cbu name is required")
+	}
+
+	var id string
+	err := DB.QueryRow(ctx, `
+	  INSERT INTO cbu (name, code, description, domicile, sponsor_entity_id)
+	  VALUES ($1, NULLIF($2, ''), NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, '')::uuid)
+	  RETURNING id`, req.Name, req.Code, req.Description, req.Domicile, req.SponsorEntityId).Scan(&id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cbu: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("✅ Created CBU", "cbu_id", id)
+	return loadGraph(ctx, id)
+}
+
+// DeleteCbu deletes a CBU and its role assignments; entities are kept
+func (s *CbuGraphService) DeleteCbu(ctx context.Context, req *pb.GetCbuRequest) (*pb.DeleteResponse, error) {
+	if s.primary != nil {
+		logging.FromContext(ctx).Info("↪️  DeleteCbu: forwarding to primary region", "cbu_id", req.CbuId)
+		return s.primary.DeleteCbu(ctx, req)
+	}
+
+	logging.FromContext(ctx).Info("🗑️  DeleteCbu", "cbu_id", req.CbuId)
+
+	tag, err := DB.Exec(ctx, `DELETE FROM cbu WHERE id = $1`, req.CbuId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete cbu: %w", err)
+	}
+	return &pb.DeleteResponse{Id: req.CbuId, Deleted: tag.RowsAffected() > 0}, nil
+}
+
+// CreateEntity creates an entity and adds it to the CBU with a role
+func (s *CbuGraphService) CreateEntity(ctx context.Context, req *pb.UpsertEntityRequest) (*pb.CbuEntity, error) {
+	if s.primary != nil {
+		logging.FromContext(ctx).Info("↪️  CreateEntity: forwarding to primary region", "cbu_id", req.CbuId)
+		return s.primary.CreateEntity(ctx, req)
+	}
+
+	e := req.GetEntity()
+	logging.FromContext(ctx).Info("📦 CreateEntity", "cbu_id", req.CbuId, "name", e.GetName(), "role", req.RoleId)
+
+	if e == nil || strings.TrimSpace(e.Name) == "" {
+		return nil, fmt.Errorf("entity name is required")
+	}
+	if req.RoleId == "" {
+		return nil, fmt.Errorf("role_id is required to add an entity to a cbu")
+	}
+	entityType, err := normalizeEntityType(e.EntityType)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := DB.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	var id string
+	err = tx.QueryRow(ctx, `
+	  INSERT INTO entity (name, entity_type, jurisdiction, lei_code, registration_number, metadata)
+	  VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, ''), jsonb_build_object('x', $6::real, 'y', $7::real))
+	  RETURNING id`, e.Name, entityType, e.Jurisdiction, e.LeiCode, e.TaxId, e.X, e.Y).Scan(&id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create entity: %w", err)
+	}
+
+	if err := assignRole(ctx, tx, req.CbuId, id, req.RoleId, req.IsPrimary); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit entity: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("✅ Created entity", "entity_id", id)
+	return s.GetEntity(ctx, &pb.GetEntityRequest{CbuId: req.CbuId, EntityId: id})
+}
+
+// UpdateEntity updates an entity's details and, if given, its role in the CBU
+func (s *CbuGraphService) UpdateEntity(ctx context.Context, req *pb.UpsertEntityRequest) (*pb.CbuEntity, error) {
+	if s.primary != nil {
+		logging.FromContext(ctx).Info("↪️  UpdateEntity: forwarding to primary region", "cbu_id", req.CbuId)
+		return s.primary.UpdateEntity(ctx, req)
+	}
+
+	e := req.GetEntity()
+	logging.FromContext(ctx).Info("📦 UpdateEntity", "cbu_id", req.CbuId, "entity_id", e.GetId())
+
+	if e == nil || e.Id == "" {
+		return nil, fmt.Errorf("entity id is required")
+	}
+	entityType, err := normalizeEntityType(e.EntityType)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := DB.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	tag, err := tx.Exec(ctx, `
+	  UPDATE entity
+	     SET name = $2, entity_type = $3, jurisdiction = NULLIF($4, ''),
+	         lei_code = NULLIF($5, ''), registration_number = NULLIF($6, ''),
+	         metadata = COALESCE(metadata, '{}'::jsonb) || jsonb_build_object('x', $7::real, 'y', $8::real)
+	   WHERE id = $1`, e.Id, e.Name, entityType, e.Jurisdiction, e.LeiCode, e.TaxId, e.X, e.Y)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update entity: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, fmt.Errorf("entity not found: %s", e.Id)
+	}
+
+	if req.RoleId != "" {
+		if _, err := tx.Exec(ctx, `DELETE FROM cbu_role WHERE cbu_id = $1 AND entity_id = $2`, req.CbuId, e.Id); err != nil {
+			return nil, fmt.Errorf("failed to replace entity role: %w", err)
+		}
+		if err := assignRole(ctx, tx, req.CbuId, e.Id, req.RoleId, req.IsPrimary); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit entity: %w", err)
+	}
+
+	return s.GetEntity(ctx, &pb.GetEntityRequest{CbuId: req.CbuId, EntityId: e.Id})
+}
+
+// assignRole gives an entity a role (by role_type code) in a CBU
+func assignRole(ctx context.Context, tx pgx.Tx, cbuID, entityID, roleCode string, primary bool) error {
+	var roleTypeID int
+	err := tx.QueryRow(ctx, `SELECT id FROM role_type WHERE code = $1`, strings.ToUpper(roleCode)).Scan(&roleTypeID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("unknown role: %s", roleCode)
+		}
+		return fmt.Errorf("database error: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+	  INSERT INTO cbu_role (cbu_id, entity_id, role_type_id, is_primary)
+	  VALUES ($1, $2, $3, $4)`, cbuID, entityID, roleTypeID, primary)
+	if err != nil {
+		return fmt.Errorf("failed to assign role %s: %w", roleCode, err)
+	}
+	return nil
+}
+
+// DeleteEntity removes an entity from the CBU; the entity record is deleted
+// once no CBU references it
+func (s *CbuGraphService) DeleteEntity(ctx context.Context, req *pb.GetEntityRequest) (*pb.DeleteResponse, error) {
+	if s.primary != nil {
+		logging.FromContext(ctx).Info("↪️  DeleteEntity: forwarding to primary region", "cbu_id", req.CbuId)
+		return s.primary.DeleteEntity(ctx, req)
+	}
+
+	logging.FromContext(ctx).Info("🗑️  DeleteEntity", "cbu_id", req.CbuId, "entity_id", req.EntityId)
+
+	tx, err := DB.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	tag, err := tx.Exec(ctx, `DELETE FROM cbu_role WHERE cbu_id = $1 AND entity_id = $2`, req.CbuId, req.EntityId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove entity from cbu: %w", err)
+	}
+	removed := tag.RowsAffected() > 0
+
+	if _, err := tx.Exec(ctx, `
+	  DELETE FROM entity e
+	   WHERE e.id = $1
+	     AND NOT EXISTS (SELECT 1 FROM cbu_role WHERE entity_id = e.id)
+	     AND NOT EXISTS (SELECT 1 FROM cbu WHERE sponsor_entity_id = e.id)`, req.EntityId); err != nil {
+		return nil, fmt.Errorf("failed to delete entity: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit entity removal: %w", err)
+	}
+	return &pb.DeleteResponse{Id: req.EntityId, Deleted: removed}, nil
+}
+
+// CreateRelationship adds an ownership or control edge between two entities of the CBU
+func (s *CbuGraphService) CreateRelationship(ctx context.Context, req *pb.CreateRelationshipRequest) (*pb.CbuRelationship, error) {
+	if s.primary != nil {
+		logging.FromContext(ctx).Info("↪️  CreateRelationship: forwarding to primary region", "cbu_id", req.CbuId)
+		return s.primary.CreateRelationship(ctx, req)
+	}
+
+	r := req.GetRelationship()
+	logging.FromContext(ctx).Info("🔗 CreateRelationship", "cbu_id", req.CbuId,
+		"from", r.GetFromId(), "to", r.GetToId(), "type", r.GetRelationType())
+
+	if r == nil || r.FromId == "" || r.ToId == "" {
+		return nil, fmt.Errorf("from_id and to_id are required")
+	}
+	if r.FromId == r.ToId {
+		return nil, fmt.Errorf("an entity cannot control itself")
+	}
+	if r.ControlPct < 0 || r.ControlPct > 100 {
+		return nil, fmt.Errorf("control_pct must be between 0 and 100, got %.2f", r.ControlPct)
+	}
+	ctype, basis, err := controlType(r.RelationType, r.IsBeneficial)
+	if err != nil {
+		return nil, err
+	}
+
+	var members int
+	err = DB.QueryRow(ctx, `SELECT COUNT(*) FROM (`+cbuMembers+`) m WHERE m.entity_id IN ($2, $3)`,
+		req.CbuId, r.FromId, r.ToId).Scan(&members)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	if members != 2 {
+		return nil, fmt.Errorf("both entities must belong to cbu %s", req.CbuId)
+	}
+
+	effective := time.Now()
+	if r.EffectiveDate != nil {
+		effective = r.EffectiveDate.AsTime()
+	}
+	var end *time.Time
+	if r.EndDate != nil {
+		t := r.EndDate.AsTime()
+		if t.Format(time.DateOnly) < effective.Format(time.DateOnly) {
+			return nil, fmt.Errorf("end_date must not be before effective_date")
+		}
+		end = &t
+	}
+
+	var id string
+	err = DB.QueryRow(ctx, `
+	  INSERT INTO entity_control
+	         (controller_entity_id, controlled_entity_id, control_type, control_basis, control_percentage, start_date, end_date)
+	  VALUES ($1, $2, $3::control_type, NULLIF($4, ''), $5, $6::date, $7::date)
+	  RETURNING id`, r.FromId, r.ToId, ctype, basis, r.ControlPct, effective, end).Scan(&id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create relationship: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("✅ Created relationship", "relationship_id", id)
+	created, err := scanRelationship(DB.QueryRow(ctx, `SELECT`+relationshipColumns+`
+	    FROM entity_control ec WHERE ec.id = $2`, req.CbuId, id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load relationship: %w", err)
+	}
+	return created, nil
+}
+
+// DeleteRelationship removes an ownership or control edge of the CBU
+func (s *CbuGraphService) DeleteRelationship(ctx context.Context, req *pb.DeleteRelationshipRequest) (*pb.DeleteResponse, error) {
+	if s.primary != nil {
+		logging.FromContext(ctx).Info("↪️  DeleteRelationship: forwarding to primary region", "cbu_id", req.CbuId)
+		return s.primary.DeleteRelationship(ctx, req)
+	}
+
+	logging.FromContext(ctx).Info("🗑️  DeleteRelationship", "cbu_id", req.CbuId, "relationship_id", req.RelationshipId)
+
+	tag, err := DB.Exec(ctx, `
+	  DELETE FROM entity_control
+	   WHERE id = $2 AND controlled_entity_id IN (`+cbuMembers+`)`, req.CbuId, req.RelationshipId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete relationship: %w", err)
+	}
+	return &pb.DeleteResponse{Id: req.RelationshipId, Deleted: tag.RowsAffected() > 0}, nil
+}
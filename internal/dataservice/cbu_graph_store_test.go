@@ -0,0 +1,105 @@
+package dataservice
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	pb "github.com/adamtc007/KYC-DSL/api/pb"
+)
+
+// fakePrimaryGraph stands in for the primary region's CbuGraphService and
+// records which writes reached it
+type fakePrimaryGraph struct {
+	pb.CbuGraphServiceClient
+	calls []string
+}
+
+func (f *fakePrimaryGraph) CreateCbu(ctx context.Context, req *pb.CreateCbuRequest, _ ...grpc.CallOption) (*pb.CbuGraph, error) {
+	f.calls = append(f.calls, "CreateCbu")
+	return &pb.CbuGraph{}, nil
+}
+
+func (f *fakePrimaryGraph) DeleteCbu(ctx context.Context, req *pb.GetCbuRequest, _ ...grpc.CallOption) (*pb.DeleteResponse, error) {
+	f.calls = append(f.calls, "DeleteCbu")
+	return &pb.DeleteResponse{Deleted: true}, nil
+}
+
+func (f *fakePrimaryGraph) CreateEntity(ctx context.Context, req *pb.UpsertEntityRequest, _ ...grpc.CallOption) (*pb.CbuEntity, error) {
+	f.calls = append(f.calls, "CreateEntity")
+	return &pb.CbuEntity{}, nil
+}
+
+func (f *fakePrimaryGraph) UpdateEntity(ctx context.Context, req *pb.UpsertEntityRequest, _ ...grpc.CallOption) (*pb.CbuEntity, error) {
+	f.calls = append(f.calls, "UpdateEntity")
+	return &pb.CbuEntity{}, nil
+}
+
+func (f *fakePrimaryGraph) DeleteEntity(ctx context.Context, req *pb.GetEntityRequest, _ ...grpc.CallOption) (*pb.DeleteResponse, error) {
+	f.calls = append(f.calls, "DeleteEntity")
+	return &pb.DeleteResponse{Deleted: true}, nil
+}
+
+func (f *fakePrimaryGraph) CreateRelationship(ctx context.Context, req *pb.CreateRelationshipRequest, _ ...grpc.CallOption) (*pb.CbuRelationship, error) {
+	f.calls = append(f.calls, "CreateRelationship")
+	return &pb.CbuRelationship{}, nil
+}
+
+func (f *fakePrimaryGraph) DeleteRelationship(ctx context.Context, req *pb.DeleteRelationshipRequest, _ ...grpc.CallOption) (*pb.DeleteResponse, error) {
+	f.calls = append(f.calls, "DeleteRelationship")
+	return &pb.DeleteResponse{Deleted: true}, nil
+}
+
+func TestCbuGraphWritesForwardToPrimary(t *testing.T) {
+	primary := &fakePrimaryGraph{}
+	s := NewCbuGraphService()
+	s.ForwardWritesTo(primary)
+	ctx := context.Background()
+
+	// Valid-looking requests; validation is the primary's job
+	writes := []struct {
+		name string
+		call func() error
+	}{
+		{"CreateCbu", func() error {
+			_, err := s.CreateCbu(ctx, &pb.CreateCbuRequest{Name: "Fund"})
+			return err
+		}},
+		{"DeleteCbu", func() error {
+			_, err := s.DeleteCbu(ctx, &pb.GetCbuRequest{CbuId: "cbu-1"})
+			return err
+		}},
+		{"CreateEntity", func() error {
+			_, err := s.CreateEntity(ctx, &pb.UpsertEntityRequest{CbuId: "cbu-1", RoleId: "FUND", Entity: &pb.CbuEntity{Name: "Fund"}})
+			return err
+		}},
+		{"UpdateEntity", func() error {
+			_, err := s.UpdateEntity(ctx, &pb.UpsertEntityRequest{CbuId: "cbu-1", Entity: &pb.CbuEntity{Id: "ent-1", Name: "Fund"}})
+			return err
+		}},
+		{"DeleteEntity", func() error {
+			_, err := s.DeleteEntity(ctx, &pb.GetEntityRequest{CbuId: "cbu-1", EntityId: "ent-1"})
+			return err
+		}},
+		{"CreateRelationship", func() error {
+			_, err := s.CreateRelationship(ctx, &pb.CreateRelationshipRequest{CbuId: "cbu-1", Relationship: &pb.CbuRelationship{FromId: "ent-1", ToId: "ent-2"}})
+			return err
+		}},
+		{"DeleteRelationship", func() error {
+			_, err := s.DeleteRelationship(ctx, &pb.DeleteRelationshipRequest{CbuId: "cbu-1", RelationshipId: "rel-1"})
+			return err
+		}},
+	}
+	for _, w := range writes {
+		t.Run(w.name, func(t *testing.T) {
+			if err := w.call(); err != nil {
+				t.Fatalf("%s: %v", w.name, err)
+			}
+			if !slices.Contains(primary.calls, w.name) {
+				t.Errorf("%s did not reach the primary; primary saw %v", w.name, primary.calls)
+			}
+		})
+	}
+}
@@ -2,11 +2,22 @@ package dataservice
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
+	"strings"
 	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	pb "github.com/adamtc007/KYC-DSL/api/pb/kycdata"
+	"github.com/adamtc007/KYC-DSL/internal/actor"
+	"github.com/adamtc007/KYC-DSL/internal/cursor"
+	"github.com/adamtc007/KYC-DSL/internal/engine"
+	"github.com/adamtc007/KYC-DSL/internal/events"
+	"github.com/adamtc007/KYC-DSL/internal/logging"
+	"github.com/adamtc007/KYC-DSL/internal/provenance"
+	"github.com/adamtc007/KYC-DSL/internal/storage"
 	"github.com/jackc/pgx/v5"
 )
 
@@ -14,11 +25,24 @@ import (
 type DataService struct {
 	pb.UnimplementedDictionaryServiceServer
 	pb.UnimplementedCaseServiceServer
+
+	// primaryCases forwards writes to the primary region when this server is a read replica
+	primaryCases pb.CaseServiceClient
+	// checkLock refuses saves while someone else holds the case lock
+	checkLock func(ctx context.Context, caseName, holder string) error
 }
 
 // NewDataService creates a new DataService instance
 func NewDataService() *DataService {
-	return &DataService{}
+	return &DataService{checkLock: func(ctx context.Context, caseName, holder string) error {
+		return engine.NewLocks(DBX).Check(ctx, caseName, holder)
+	}}
+}
+
+// ForwardWritesTo makes SaveCaseVersion and the other CaseService writes
+// forward to the primary region's CaseService
+func (s *DataService) ForwardWritesTo(primary pb.CaseServiceClient) {
+	s.primaryCases = primary
 }
 
 // ============================================================================
@@ -27,7 +51,7 @@ func NewDataService() *DataService {
 
 // GetAttribute retrieves a single attribute by ID
 func (s *DataService) GetAttribute(ctx context.Context, req *pb.GetAttributeRequest) (*pb.Attribute, error) {
-	log.Printf("📖 GetAttribute: id=%s", req.Id)
+	logging.FromContext(ctx).Info("📖 GetAttribute", "id", req.Id)
 
 	query := `
 		SELECT
@@ -57,26 +81,28 @@ func (s *DataService) GetAttribute(ctx context.Context, req *pb.GetAttributeRequ
 		if err == pgx.ErrNoRows {
 			return nil, fmt.Errorf("attribute not found: %s", req.Id)
 		}
-		log.Printf("❌ GetAttribute error: %v", err)
+		logging.FromContext(ctx).Error("❌ GetAttribute error", "error", err)
 		return nil, fmt.Errorf("database error: %w", err)
 	}
 
-	log.Printf("✅ Found attribute: %s", attr.Name)
+	if req.IncludeProvenance {
+		attr.Provenance = provenanceOf(ctx, provenance.TableAttributes, []string{attr.Id})[attr.Id]
+	}
+
+	logging.FromContext(ctx).Info("✅ Found attribute", "name", attr.Name)
 	return &attr, nil
 }
 
-// ListAttributes retrieves a paginated list of attributes
+// ListAttributes retrieves a page of attributes by code. A cursor (the
+// next_cursor of the previous page) takes precedence over offset.
 func (s *DataService) ListAttributes(ctx context.Context, req *pb.ListAttributesRequest) (*pb.AttributeList, error) {
-	log.Printf("📖 ListAttributes: limit=%d, offset=%d", req.Limit, req.Offset)
+	logging.FromContext(ctx).Info("📖 ListAttributes", "limit", req.Limit, "offset", req.Offset, "cursor", req.Cursor)
 
 	// Default pagination
-	limit := req.Limit
-	if limit <= 0 || limit > 100 {
-		limit = 50
-	}
-	offset := req.Offset
-	if offset < 0 {
-		offset = 0
+	limit := pageLimit(req.Limit)
+	after, offset, err := codeCursor(req.Cursor, max(req.Offset, 0))
+	if err != nil {
+		return nil, err
 	}
 
 	// Query for attributes
@@ -89,13 +115,15 @@ func (s *DataService) ListAttributes(ctx context.Context, req *pb.ListAttributes
 			jurisdiction,
 			regulation_code
 		FROM kyc_attributes
+		WHERE ($3 = '' OR attribute_code > $3)
 		ORDER BY attribute_code
 		LIMIT $1 OFFSET $2
 	`
 
-	rows, err := DB.Query(ctx, query, limit, offset)
+	// One extra row tells whether there is a next page
+	rows, err := DB.Query(ctx, query, limit+1, offset, after)
 	if err != nil {
-		log.Printf("❌ ListAttributes query error: %v", err)
+		logging.FromContext(ctx).Error("❌ ListAttributes query error", "error", err)
 		return nil, fmt.Errorf("database error: %w", err)
 	}
 	defer rows.Close()
@@ -112,14 +140,14 @@ func (s *DataService) ListAttributes(ctx context.Context, req *pb.ListAttributes
 			&attr.Regulation,
 		)
 		if err != nil {
-			log.Printf("❌ ListAttributes scan error: %v", err)
+			logging.FromContext(ctx).Error("❌ ListAttributes scan error", "error", err)
 			return nil, fmt.Errorf("scan error: %w", err)
 		}
 		attributes = append(attributes, &attr)
 	}
 
 	if err := rows.Err(); err != nil {
-		log.Printf("❌ ListAttributes rows error: %v", err)
+		logging.FromContext(ctx).Error("❌ ListAttributes rows error", "error", err)
 		return nil, fmt.Errorf("rows error: %w", err)
 	}
 
@@ -128,21 +156,38 @@ func (s *DataService) ListAttributes(ctx context.Context, req *pb.ListAttributes
 	countQuery := `SELECT COUNT(*) FROM kyc_attributes`
 	err = DB.QueryRow(ctx, countQuery).Scan(&totalCount)
 	if err != nil {
-		log.Printf("⚠️ ListAttributes count error: %v", err)
+		logging.FromContext(ctx).Warn("⚠️ ListAttributes count error", "error", err)
 		totalCount = int32(len(attributes)) //nolint:gosec
 	}
 
-	log.Printf("✅ Listed %d attributes (total: %d)", len(attributes), totalCount)
+	nextCursor := ""
+	if len(attributes) > int(limit) {
+		attributes = attributes[:limit]
+		nextCursor = cursor.Encode(attributes[limit-1].Id)
+	}
+	if req.IncludeProvenance {
+		codes := make([]string, len(attributes))
+		for i, attr := range attributes {
+			codes[i] = attr.Id
+		}
+		records := provenanceOf(ctx, provenance.TableAttributes, codes)
+		for _, attr := range attributes {
+			attr.Provenance = records[attr.Id]
+		}
+	}
+
+	logging.FromContext(ctx).Info("✅ Listed attributes", "count", len(attributes), "total", totalCount)
 
 	return &pb.AttributeList{
 		Attributes: attributes,
 		TotalCount: totalCount,
+		NextCursor: nextCursor,
 	}, nil
 }
 
 // GetDocument retrieves a single document by ID
 func (s *DataService) GetDocument(ctx context.Context, req *pb.GetDocumentRequest) (*pb.Document, error) {
-	log.Printf("📄 GetDocument: id=%s", req.Id)
+	logging.FromContext(ctx).Info("📄 GetDocument", "id", req.Id)
 
 	query := `
 		SELECT
@@ -172,27 +217,33 @@ func (s *DataService) GetDocument(ctx context.Context, req *pb.GetDocumentReques
 		if err == pgx.ErrNoRows {
 			return nil, fmt.Errorf("document not found: %s", req.Id)
 		}
-		log.Printf("❌ GetDocument error: %v", err)
+		logging.FromContext(ctx).Error("❌ GetDocument error", "error", err)
 		return nil, fmt.Errorf("database error: %w", err)
 	}
 
-	log.Printf("✅ Found document: %s", doc.Title)
+	if req.IncludeProvenance {
+		doc.Provenance = provenanceOf(ctx, provenance.TableDocuments, []string{doc.Id})[doc.Id]
+	}
+
+	logging.FromContext(ctx).Info("✅ Found document", "title", doc.Title)
 	return &doc, nil
 }
 
-// ListDocuments retrieves a paginated list of documents with optional jurisdiction filter
+// ListDocuments retrieves a page of documents by code with optional
+// jurisdiction filter. A cursor takes precedence over offset.
 func (s *DataService) ListDocuments(ctx context.Context, req *pb.ListDocumentsRequest) (*pb.DocumentList, error) {
-	log.Printf("📄 ListDocuments: limit=%d, offset=%d, jurisdiction=%s", req.Limit, req.Offset, req.Jurisdiction)
+	logging.FromContext(ctx).Info("📄 ListDocuments", "limit", req.Limit, "offset", req.Offset, "cursor", req.Cursor, "jurisdiction", req.Jurisdiction)
 
 	// Default pagination
-	limit := req.Limit
-	if limit <= 0 || limit > 100 {
-		limit = 50
-	}
+	limit := pageLimit(req.Limit)
 	offset := req.Offset
 	if offset < 0 {
 		offset = 0
 	}
+	keys, err := decodeCursor(req.Cursor, 1)
+	if err != nil {
+		return nil, err
+	}
 
 	// Build query with optional jurisdiction filter
 	query := `
@@ -207,20 +258,31 @@ func (s *DataService) ListDocuments(ctx context.Context, req *pb.ListDocumentsRe
 	`
 
 	var args []interface{}
+	var conditions []string
 	argPosition := 1
 
 	if req.Jurisdiction != "" {
-		query += fmt.Sprintf(" WHERE jurisdiction = $%d", argPosition)
+		conditions = append(conditions, fmt.Sprintf("jurisdiction = $%d", argPosition))
 		args = append(args, req.Jurisdiction)
 		argPosition++
 	}
+	if keys != nil {
+		conditions = append(conditions, fmt.Sprintf("document_code > $%d", argPosition))
+		args = append(args, keys[0])
+		argPosition++
+		offset = 0
+	}
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
 
+	// One extra row tells whether there is a next page
 	query += fmt.Sprintf(" ORDER BY document_code LIMIT $%d OFFSET $%d", argPosition, argPosition+1)
-	args = append(args, limit, offset)
+	args = append(args, limit+1, offset)
 
 	rows, err := DB.Query(ctx, query, args...)
 	if err != nil {
-		log.Printf("❌ ListDocuments query error: %v", err)
+		logging.FromContext(ctx).Error("❌ ListDocuments query error", "error", err)
 		return nil, fmt.Errorf("database error: %w", err)
 	}
 	defer rows.Close()
@@ -237,14 +299,14 @@ func (s *DataService) ListDocuments(ctx context.Context, req *pb.ListDocumentsRe
 			&doc.Url,
 		)
 		if err != nil {
-			log.Printf("❌ ListDocuments scan error: %v", err)
+			logging.FromContext(ctx).Error("❌ ListDocuments scan error", "error", err)
 			return nil, fmt.Errorf("scan error: %w", err)
 		}
 		documents = append(documents, &doc)
 	}
 
 	if err := rows.Err(); err != nil {
-		log.Printf("❌ ListDocuments rows error: %v", err)
+		logging.FromContext(ctx).Error("❌ ListDocuments rows error", "error", err)
 		return nil, fmt.Errorf("rows error: %w", err)
 	}
 
@@ -258,15 +320,32 @@ func (s *DataService) ListDocuments(ctx context.Context, req *pb.ListDocumentsRe
 		err = DB.QueryRow(ctx, countQuery).Scan(&totalCount)
 	}
 	if err != nil {
-		log.Printf("⚠️ ListDocuments count error: %v", err)
+		logging.FromContext(ctx).Warn("⚠️ ListDocuments count error", "error", err)
 		totalCount = int32(len(documents)) //nolint:gosec
 	}
 
-	log.Printf("✅ Listed %d documents (total: %d)", len(documents), totalCount)
+	nextCursor := ""
+	if len(documents) > int(limit) {
+		documents = documents[:limit]
+		nextCursor = cursor.Encode(documents[limit-1].Id)
+	}
+	if req.IncludeProvenance {
+		codes := make([]string, len(documents))
+		for i, doc := range documents {
+			codes[i] = doc.Id
+		}
+		records := provenanceOf(ctx, provenance.TableDocuments, codes)
+		for _, doc := range documents {
+			doc.Provenance = records[doc.Id]
+		}
+	}
+
+	logging.FromContext(ctx).Info("✅ Listed documents", "count", len(documents), "total", totalCount)
 
 	return &pb.DocumentList{
 		Documents:  documents,
 		TotalCount: totalCount,
+		NextCursor: nextCursor,
 	}, nil
 }
 
@@ -274,13 +353,28 @@ func (s *DataService) ListDocuments(ctx context.Context, req *pb.ListDocumentsRe
 // Case Service Implementation
 // ============================================================================
 
-// SaveCaseVersion saves a new case version to the database
+// SaveCaseVersion saves a new case version to the database. It is refused
+// with FailedPrecondition while someone other than the caller holds the case
+// lock (internal/engine).
 func (s *DataService) SaveCaseVersion(ctx context.Context, req *pb.CaseVersionRequest) (*pb.CaseVersionResponse, error) {
-	log.Printf("💾 SaveCaseVersion: case_id=%s, status=%s", req.CaseId, req.Status)
+	if s.primaryCases != nil {
+		logging.FromContext(ctx).Info("↪️  SaveCaseVersion: forwarding to primary region", "case_id", req.CaseId)
+		return s.primaryCases.SaveCaseVersion(ctx, req)
+	}
+
+	logging.FromContext(ctx).Info("💾 SaveCaseVersion", "case_id", req.CaseId, "status", req.Status)
+
+	who := actor.FromContext(ctx)
+	if err := s.checkLock(ctx, req.CaseId, who.Name); err != nil {
+		if errors.Is(err, engine.ErrCaseLocked) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to check case lock: %v", err)
+	}
 
 	query := `
-		INSERT INTO case_versions (case_id, dsl_source, compiled_json, status, created_at)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO case_versions (case_id, dsl_source, compiled_json, status, created_at, actor, actor_source, client_ip)
+		VALUES ($1, $2, $3, $4, NOW(), $5, $6, NULLIF($7, ''))
 		RETURNING id
 	`
 
@@ -292,11 +386,13 @@ func (s *DataService) SaveCaseVersion(ctx context.Context, req *pb.CaseVersionRe
 		req.DslSource,
 		req.CompiledJson,
 		req.Status,
-		time.Now(),
+		who.Name,
+		who.Source,
+		who.ClientIP,
 	).Scan(&versionID)
 
 	if err != nil {
-		log.Printf("❌ SaveCaseVersion error: %v", err)
+		logging.FromContext(ctx).Error("❌ SaveCaseVersion error", "error", err)
 		return &pb.CaseVersionResponse{
 			Success:   false,
 			Error:     err.Error(),
@@ -304,7 +400,13 @@ func (s *DataService) SaveCaseVersion(ctx context.Context, req *pb.CaseVersionRe
 		}, nil
 	}
 
-	log.Printf("✅ Saved case version: %s (version_id: %s)", req.CaseId, versionID)
+	logging.FromContext(ctx).Info("✅ Saved case version", "case_id", req.CaseId, "version_id", versionID)
+	events.Notify(ctx, DBX, events.Event{
+		Type:     events.CaseVersionSaved,
+		CaseName: req.CaseId,
+		Hash:     storage.CanonicalHash(req.DslSource),
+		Data:     map[string]interface{}{"version_id": versionID, "status": req.Status},
+	})
 
 	return &pb.CaseVersionResponse{
 		Success:   true,
@@ -315,7 +417,7 @@ func (s *DataService) SaveCaseVersion(ctx context.Context, req *pb.CaseVersionRe
 
 // GetCaseVersion retrieves the latest version of a case
 func (s *DataService) GetCaseVersion(ctx context.Context, req *pb.GetCaseRequest) (*pb.CaseVersion, error) {
-	log.Printf("📦 GetCaseVersion: case_id=%s", req.CaseId)
+	logging.FromContext(ctx).Info("📦 GetCaseVersion", "case_id", req.CaseId)
 
 	query := `
 		SELECT
@@ -348,19 +450,19 @@ func (s *DataService) GetCaseVersion(ctx context.Context, req *pb.GetCaseRequest
 		if err == pgx.ErrNoRows {
 			return nil, fmt.Errorf("case version not found: %s", req.CaseId)
 		}
-		log.Printf("❌ GetCaseVersion error: %v", err)
+		logging.FromContext(ctx).Error("❌ GetCaseVersion error", "error", err)
 		return nil, fmt.Errorf("database error: %w", err)
 	}
 
 	cv.CreatedAt = createdAt.Format(time.RFC3339)
 
-	log.Printf("✅ Found case version: %s (version_id: %s)", cv.CaseId, cv.Id)
+	logging.FromContext(ctx).Info("✅ Found case version", "case_id", cv.CaseId, "version_id", cv.Id)
 	return &cv, nil
 }
 
 // ListCaseVersions retrieves all versions of a case with pagination
 func (s *DataService) ListCaseVersions(ctx context.Context, req *pb.ListCaseVersionsRequest) (*pb.CaseVersionList, error) {
-	log.Printf("📦 ListCaseVersions: case_id=%s, limit=%d, offset=%d", req.CaseId, req.Limit, req.Offset)
+	logging.FromContext(ctx).Info("📦 ListCaseVersions", "case_id", req.CaseId, "limit", req.Limit, "offset", req.Offset)
 
 	// Default pagination
 	limit := req.Limit
@@ -388,7 +490,7 @@ func (s *DataService) ListCaseVersions(ctx context.Context, req *pb.ListCaseVers
 
 	rows, err := DB.Query(ctx, query, req.CaseId, limit, offset)
 	if err != nil {
-		log.Printf("❌ ListCaseVersions query error: %v", err)
+		logging.FromContext(ctx).Error("❌ ListCaseVersions query error", "error", err)
 		return nil, fmt.Errorf("database error: %w", err)
 	}
 	defer rows.Close()
@@ -406,7 +508,7 @@ func (s *DataService) ListCaseVersions(ctx context.Context, req *pb.ListCaseVers
 			&createdAt,
 		)
 		if err != nil {
-			log.Printf("❌ ListCaseVersions scan error: %v", err)
+			logging.FromContext(ctx).Error("❌ ListCaseVersions scan error", "error", err)
 			return nil, fmt.Errorf("scan error: %w", err)
 		}
 		cv.CreatedAt = createdAt.Format(time.RFC3339)
@@ -414,7 +516,7 @@ func (s *DataService) ListCaseVersions(ctx context.Context, req *pb.ListCaseVers
 	}
 
 	if err := rows.Err(); err != nil {
-		log.Printf("❌ ListCaseVersions rows error: %v", err)
+		logging.FromContext(ctx).Error("❌ ListCaseVersions rows error", "error", err)
 		return nil, fmt.Errorf("rows error: %w", err)
 	}
 
@@ -423,11 +525,11 @@ func (s *DataService) ListCaseVersions(ctx context.Context, req *pb.ListCaseVers
 	countQuery := `SELECT COUNT(*) FROM case_versions WHERE case_id = $1`
 	err = DB.QueryRow(ctx, countQuery, req.CaseId).Scan(&totalCount)
 	if err != nil {
-		log.Printf("⚠️ ListCaseVersions count error: %v", err)
+		logging.FromContext(ctx).Warn("⚠️ ListCaseVersions count error", "error", err)
 		totalCount = int32(len(versions)) //nolint:gosec
 	}
 
-	log.Printf("✅ Listed %d versions for case %s (total: %d)", len(versions), req.CaseId, totalCount)
+	logging.FromContext(ctx).Info("✅ Listed case versions", "case_id", req.CaseId, "count", len(versions), "total", totalCount)
 
 	return &pb.CaseVersionList{
 		Versions:   versions,
@@ -437,7 +539,7 @@ func (s *DataService) ListCaseVersions(ctx context.Context, req *pb.ListCaseVers
 
 // ListAllCases retrieves all cases with summary information
 func (s *DataService) ListAllCases(ctx context.Context, req *pb.ListAllCasesRequest) (*pb.CaseList, error) {
-	log.Printf("📦 ListAllCases: limit=%d, offset=%d, status_filter=%s", req.Limit, req.Offset, req.StatusFilter)
+	logging.FromContext(ctx).Info("📦 ListAllCases", "limit", req.Limit, "offset", req.Offset, "status_filter", req.StatusFilter)
 
 	// Default pagination
 	limit := req.Limit
@@ -450,13 +552,17 @@ func (s *DataService) ListAllCases(ctx context.Context, req *pb.ListAllCasesRequ
 	}
 
 	// Build query with optional status filter
+	// Live case locks (kyc_case_locks) are shown with each case
 	query := `
 		SELECT
 			case_id,
 			COUNT(*) as version_count,
 			MAX(status) as status,
-			MAX(created_at) as last_updated
+			MAX(created_at) as last_updated,
+			COALESCE(MAX(l.holder), '') as locked_by,
+			MAX(l.expires_at) as lock_expires_at
 		FROM case_versions
+		LEFT JOIN kyc_case_locks l ON l.case_name = case_versions.case_id AND l.expires_at >= NOW()
 	`
 
 	var args []interface{}
@@ -473,7 +579,7 @@ func (s *DataService) ListAllCases(ctx context.Context, req *pb.ListAllCasesRequ
 
 	rows, err := DB.Query(ctx, query, args...)
 	if err != nil {
-		log.Printf("❌ ListAllCases query error: %v", err)
+		logging.FromContext(ctx).Error("❌ ListAllCases query error", "error", err)
 		return nil, fmt.Errorf("database error: %w", err)
 	}
 	defer rows.Close()
@@ -482,22 +588,28 @@ func (s *DataService) ListAllCases(ctx context.Context, req *pb.ListAllCasesRequ
 	for rows.Next() {
 		var cs pb.CaseSummary
 		var lastUpdated time.Time
+		var lockExpiresAt *time.Time
 		err := rows.Scan(
 			&cs.CaseId,
 			&cs.VersionCount,
 			&cs.Status,
 			&lastUpdated,
+			&cs.LockedBy,
+			&lockExpiresAt,
 		)
 		if err != nil {
-			log.Printf("❌ ListAllCases scan error: %v", err)
+			logging.FromContext(ctx).Error("❌ ListAllCases scan error", "error", err)
 			return nil, fmt.Errorf("scan error: %w", err)
 		}
 		cs.LastUpdated = lastUpdated.Format(time.RFC3339)
+		if lockExpiresAt != nil {
+			cs.LockExpiresAt = lockExpiresAt.Format(time.RFC3339)
+		}
 		cases = append(cases, &cs)
 	}
 
 	if err := rows.Err(); err != nil {
-		log.Printf("❌ ListAllCases rows error: %v", err)
+		logging.FromContext(ctx).Error("❌ ListAllCases rows error", "error", err)
 		return nil, fmt.Errorf("rows error: %w", err)
 	}
 
@@ -511,11 +623,11 @@ func (s *DataService) ListAllCases(ctx context.Context, req *pb.ListAllCasesRequ
 		err = DB.QueryRow(ctx, countQuery).Scan(&totalCount)
 	}
 	if err != nil {
-		log.Printf("⚠️ ListAllCases count error: %v", err)
+		logging.FromContext(ctx).Warn("⚠️ ListAllCases count error", "error", err)
 		totalCount = int32(len(cases))
 	}
 
-	log.Printf("✅ Listed %d cases (total: %d)", len(cases), totalCount)
+	logging.FromContext(ctx).Info("✅ Listed cases", "count", len(cases), "total", totalCount)
 
 	return &pb.CaseList{
 		Cases:      cases,
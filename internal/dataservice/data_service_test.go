@@ -0,0 +1,45 @@
+package dataservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/adamtc007/KYC-DSL/api/pb/kycdata"
+	"github.com/adamtc007/KYC-DSL/internal/actor"
+	"github.com/adamtc007/KYC-DSL/internal/engine"
+)
+
+func TestSaveCaseVersionRefusedWhileLocked(t *testing.T) {
+	s := NewDataService()
+	var checkedHolder string
+	s.checkLock = func(ctx context.Context, caseName, holder string) error {
+		checkedHolder = holder
+		return fmt.Errorf("%w: %s is held by bob", engine.ErrCaseLocked, caseName)
+	}
+
+	ctx := actor.With(context.Background(), actor.Actor{Name: "alice", Source: actor.SourceGRPC})
+	_, err := s.SaveCaseVersion(ctx, &pb.CaseVersionRequest{CaseId: "CASE-1", DslSource: "(kyc-case CASE-1)"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("got %v, want FailedPrecondition", err)
+	}
+	if checkedHolder != "alice" {
+		t.Errorf("lock checked for %q, want the caller alice", checkedHolder)
+	}
+}
+
+func TestSaveCaseVersionLockCheckFailure(t *testing.T) {
+	s := NewDataService()
+	s.checkLock = func(ctx context.Context, caseName, holder string) error {
+		return errors.New("connection refused")
+	}
+
+	_, err := s.SaveCaseVersion(context.Background(), &pb.CaseVersionRequest{CaseId: "CASE-1"})
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("got %v, want Internal", err)
+	}
+}
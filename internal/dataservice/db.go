@@ -3,96 +3,37 @@ package dataservice
 import (
 	"context"
 	"fmt"
-	"log"
-	"os"
-	"time"
 
+	kycdb "github.com/adamtc007/KYC-DSL/internal/db"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jmoiron/sqlx"
 )
 
-// DB is the global connection pool for the Data Service
+// DB is the connection pool of the Data Service: the process-wide pool from
+// internal/db, sized by the database section of the configuration
 var DB *pgxpool.Pool
 
-// InitDB initializes the PostgreSQL connection pool
-// Environment variables:
-//   - DATABASE_URL: full connection string (default: localhost:5432/kyc_dsl)
-//   - PGHOST: PostgreSQL host (default: localhost)
-//   - PGPORT: PostgreSQL port (default: 5432)
-//   - PGUSER: PostgreSQL user (default: postgres)
-//   - PGPASSWORD: PostgreSQL password (default: postgres)
-//   - PGDATABASE: PostgreSQL database (default: kyc_dsl)
-func InitDB() error {
-	dsn := getDatabaseURL()
-
-	// Parse the connection string
-	cfg, err := pgxpool.ParseConfig(dsn)
-	if err != nil {
-		return fmt.Errorf("failed to parse database config: %w", err)
-	}
-
-	// Configure connection pool
-	cfg.MaxConns = 20                      // Maximum number of connections
-	cfg.MinConns = 5                       // Minimum number of connections
-	cfg.MaxConnLifetime = time.Hour        // Maximum connection lifetime
-	cfg.MaxConnIdleTime = 30 * time.Minute // Maximum idle time
-	cfg.HealthCheckPeriod = time.Minute    // Health check interval
-
-	// Create the pool with a timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+// DBX is a database/sql view of DB for packages written against sqlx
+var DBX *sqlx.DB
 
-	DB, err = pgxpool.NewWithConfig(ctx, cfg)
+// InitDB opens the shared connection pool
+func InitDB() error {
+	pool, err := kycdb.Shared(context.Background())
 	if err != nil {
-		return fmt.Errorf("failed to create connection pool: %w", err)
-	}
-
-	// Verify the connection
-	if err := DB.Ping(ctx); err != nil {
-		DB.Close()
-		return fmt.Errorf("failed to ping database: %w", err)
+		return err
 	}
-
-	log.Println("✅ Connected to PostgreSQL")
-	log.Printf("📊 Connection pool: max=%d, min=%d", cfg.MaxConns, cfg.MinConns)
-
+	DB = pool.Pool
+	DBX = pool.SQLX()
 	return nil
 }
 
 // CloseDB closes the database connection pool gracefully
 func CloseDB() {
-	if DB != nil {
-		DB.Close()
-		log.Println("🔒 Database connection pool closed")
+	if DBX != nil {
+		DBX.Close()
 	}
-}
-
-// getDatabaseURL constructs the database connection string from environment variables
-func getDatabaseURL() string {
-	// Check for full DATABASE_URL first
-	if dsn := os.Getenv("DATABASE_URL"); dsn != "" {
-		return dsn
-	}
-
-	// Build from individual components
-	host := getEnv("PGHOST", "localhost")
-	port := getEnv("PGPORT", "5432")
-	user := getEnv("PGUSER", "postgres")
-	password := getEnv("PGPASSWORD", "postgres")
-	database := getEnv("PGDATABASE", "kyc_dsl")
-	sslmode := getEnv("PGSSLMODE", "disable")
-
-	return fmt.Sprintf(
-		"postgres://%s:%s@%s:%s/%s?sslmode=%s",
-		user, password, host, port, database, sslmode,
-	)
-}
-
-// getEnv returns the value of an environment variable or a default value
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return defaultValue
+	kycdb.CloseShared()
+	DB, DBX = nil, nil
 }
 
 // HealthCheck verifies the database connection is alive
@@ -100,5 +41,9 @@ func HealthCheck(ctx context.Context) error {
 	if DB == nil {
 		return fmt.Errorf("database pool not initialized")
 	}
-	return DB.Ping(ctx)
+	pool, err := kycdb.Shared(ctx)
+	if err != nil {
+		return err
+	}
+	return pool.HealthCheck(ctx)
 }
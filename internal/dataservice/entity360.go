@@ -0,0 +1,244 @@
+package dataservice
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/adamtc007/KYC-DSL/api/pb/kycontology"
+	"github.com/adamtc007/KYC-DSL/internal/logging"
+	"github.com/jackc/pgx/v5"
+)
+
+// entityCases selects the latest version of every case whose DSL names one of
+// the CBUs entity $1 holds a current role in (or sponsors) as its
+// (client-business-unit CODE)
+const entityCases = `
+	WITH entity_cbus AS (
+	  SELECT c.code FROM cbu c JOIN cbu_role cr ON cr.cbu_id = c.id
+	   WHERE cr.entity_id = $1 AND (cr.end_date IS NULL OR cr.end_date >= CURRENT_DATE)
+	  UNION
+	  SELECT code FROM cbu WHERE sponsor_entity_id = $1
+	), latest AS (
+	  SELECT DISTINCT ON (case_id) case_id, status, created_at,
+	         substring(dsl_source from '\(client-business-unit\s+([^\s)]+)\)') AS cbu_code
+	    FROM case_versions
+	   ORDER BY case_id, created_at DESC, id DESC
+	)
+	SELECT l.case_id, l.status, l.cbu_code, l.created_at
+	  FROM latest l JOIN entity_cbus ec ON ec.code = l.cbu_code`
+
+// closedCaseStatuses are case_versions statuses that end a case
+var closedCaseStatuses = []string{"approved", "declined", "rejected", "closed"}
+
+// GetEntity360 aggregates an entity's ontology record, CBU roles, control
+// relationships, KYC profile, screening status, open cases and recent rule
+// evaluations for the relationship-manager view
+func (s *OntologyService) GetEntity360(ctx context.Context, req *pb.GetEntity360Request) (*pb.Entity360, error) {
+	logging.FromContext(ctx).Info("🔭 GetEntity360", "entity_id", req.EntityId)
+
+	entity, err := s.GetEntity(ctx, &pb.GetEntityRequest{Id: req.EntityId})
+	if err != nil {
+		return nil, err
+	}
+	view := &pb.Entity360{Entity: entity}
+
+	if view.CbuRoles, view.Cbus, err = loadEntityRoles(ctx, req.EntityId); err != nil {
+		return nil, err
+	}
+	if view.Controllers, err = loadEntityControls(ctx, "controlled_entity_id", req.EntityId); err != nil {
+		return nil, err
+	}
+	if view.Controlled, err = loadEntityControls(ctx, "controller_entity_id", req.EntityId); err != nil {
+		return nil, err
+	}
+
+	view.KycProfile, err = loadKycProfile(ctx, req.EntityId)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, fmt.Errorf("failed to load kyc profile: %w", err)
+	}
+	view.Screening = &pb.ScreeningSummary{}
+	if p := view.KycProfile; p != nil {
+		view.Screening = &pb.ScreeningSummary{
+			SanctionsCheckStatus: p.SanctionsCheckStatus,
+			PepStatus:            p.PepStatus,
+			AdverseMediaStatus:   p.AdverseMediaStatus,
+			LastReviewDate:       p.LastReviewDate,
+			NextReviewDate:       p.NextReviewDate,
+		}
+		if err := DB.QueryRow(ctx, `
+		  SELECT COALESCE(next_review_date < CURRENT_DATE, false)
+		    FROM entity_kyc_profile WHERE entity_id = $1`, req.EntityId).
+			Scan(&view.Screening.ReviewOverdue); err != nil {
+			return nil, fmt.Errorf("failed to check review date: %w", err)
+		}
+	}
+
+	if view.OpenCases, err = loadOpenCases(ctx, req.EntityId); err != nil {
+		return nil, err
+	}
+
+	limit := req.EvaluationLimit
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if view.RecentEvaluations, err = loadEntityEvaluations(ctx, req.EntityId, limit); err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Info("✅ Built entity 360", "name", entity.Name,
+		"roles", len(view.CbuRoles), "controllers", len(view.Controllers), "controlled", len(view.Controlled),
+		"open_cases", len(view.OpenCases), "evaluations", len(view.RecentEvaluations))
+	return view, nil
+}
+
+// loadEntityRoles returns the current CBU roles of an entity and the CBUs they belong to
+func loadEntityRoles(ctx context.Context, entityID string) ([]*pb.CbuRole, []*pb.Cbu, error) {
+	rows, err := DB.Query(ctx, `
+	  SELECT cr.id, cr.cbu_id, cr.entity_id,
+	         rt.id, rt.code, rt.name, COALESCE(rt.description,''), COALESCE(rt.category,''),
+	         cr.start_date::text, COALESCE(cr.end_date::text,''), COALESCE(cr.jurisdiction,''),
+	         COALESCE(cr.is_primary, false), COALESCE(cr.status,''),
+	         c.name, COALESCE(c.code,''), COALESCE(c.domicile,''), COALESCE(c.status,'')
+	    FROM cbu_role cr
+	    JOIN role_type rt ON cr.role_type_id = rt.id
+	    JOIN cbu c ON c.id = cr.cbu_id
+	   WHERE cr.entity_id = $1 AND (cr.end_date IS NULL OR cr.end_date >= CURRENT_DATE)
+	   ORDER BY c.name, rt.code`, entityID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load cbu roles: %w", err)
+	}
+	defer rows.Close()
+
+	var roles []*pb.CbuRole
+	var cbus []*pb.Cbu
+	seen := make(map[string]bool)
+	for rows.Next() {
+		role := &pb.CbuRole{RoleType: &pb.RoleType{}}
+		var c pb.Cbu
+		if err := rows.Scan(&role.Id, &role.CbuId, &role.EntityId,
+			&role.RoleType.Id, &role.RoleType.Code, &role.RoleType.Name,
+			&role.RoleType.Description, &role.RoleType.Category,
+			&role.StartDate, &role.EndDate, &role.Jurisdiction, &role.IsPrimary, &role.Status,
+			&c.Name, &c.Code, &c.Domicile, &c.Status); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan cbu role: %w", err)
+		}
+		roles = append(roles, role)
+		if !seen[role.CbuId] {
+			seen[role.CbuId] = true
+			c.Id = role.CbuId
+			cbus = append(cbus, &c)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to load cbu roles: %w", err)
+	}
+	return roles, cbus, nil
+}
+
+// loadEntityControls returns the current control edges whose column (controller_entity_id
+// or controlled_entity_id) is the entity
+func loadEntityControls(ctx context.Context, column, entityID string) ([]*pb.EntityControl, error) {
+	rows, err := DB.Query(ctx, `
+	  SELECT id, controller_entity_id, controlled_entity_id, control_type::text,
+	         COALESCE(control_basis,''), COALESCE(control_percentage, 0)::float8,
+	         COALESCE(effective_percentage, 0)::float8, start_date::text, COALESCE(end_date::text,''),
+	         COALESCE(is_indirect, false), COALESCE(remarks,''), COALESCE(source_document,''),
+	         COALESCE(verified_at::text,''), COALESCE(verified_by,'')
+	    FROM entity_control
+	   WHERE `+column+` = $1 AND (end_date IS NULL OR end_date >= CURRENT_DATE)
+	   ORDER BY control_percentage DESC NULLS LAST, start_date`, entityID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load control edges: %w", err)
+	}
+	defer rows.Close()
+
+	var edges []*pb.EntityControl
+	for rows.Next() {
+		var e pb.EntityControl
+		if err := rows.Scan(&e.Id, &e.ControllerEntityId, &e.ControlledEntityId, &e.ControlType,
+			&e.ControlBasis, &e.ControlPercentage, &e.EffectivePercentage, &e.StartDate, &e.EndDate,
+			&e.IsIndirect, &e.Remarks, &e.SourceDocument, &e.VerifiedAt, &e.VerifiedBy); err != nil {
+			return nil, fmt.Errorf("failed to scan control edge: %w", err)
+		}
+		edges = append(edges, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to load control edges: %w", err)
+	}
+	return edges, nil
+}
+
+// loadKycProfile reads the KYC profile of an entity; pgx.ErrNoRows when it has none
+func loadKycProfile(ctx context.Context, entityID string) (*pb.KycProfile, error) {
+	var p pb.KycProfile
+	err := DB.QueryRow(ctx, `
+	  SELECT entity_id, COALESCE(risk_rating,''), COALESCE(kyc_status,''),
+	         COALESCE(last_review_date::text,''), COALESCE(next_review_date::text,''),
+	         COALESCE(policy_id::text,''), COALESCE(kyc_token,''), COALESCE(sanctions_check_status,''),
+	         COALESCE(pep_status, false), COALESCE(adverse_media_status,''), COALESCE(remarks,''),
+	         COALESCE(metadata::text,'')
+	    FROM entity_kyc_profile WHERE entity_id = $1`, entityID).
+		Scan(&p.EntityId, &p.RiskRating, &p.KycStatus, &p.LastReviewDate, &p.NextReviewDate,
+			&p.PolicyId, &p.KycToken, &p.SanctionsCheckStatus, &p.PepStatus, &p.AdverseMediaStatus,
+			&p.Remarks, &p.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// loadOpenCases returns the entity's cases that are not yet closed, newest first
+func loadOpenCases(ctx context.Context, entityID string) ([]*pb.OpenCase, error) {
+	rows, err := DB.Query(ctx, `
+	  SELECT c.case_id, c.status, c.cbu_code, c.created_at::text,
+	         (SELECT COUNT(*) FROM case_versions v WHERE v.case_id = c.case_id)::int
+	    FROM (`+entityCases+`) c
+	   WHERE lower(c.status) <> ALL($2)
+	   ORDER BY c.created_at DESC`, entityID, closedCaseStatuses)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load open cases: %w", err)
+	}
+	defer rows.Close()
+
+	var cases []*pb.OpenCase
+	for rows.Next() {
+		var c pb.OpenCase
+		if err := rows.Scan(&c.CaseName, &c.Status, &c.CbuCode, &c.LastUpdated, &c.Version); err != nil {
+			return nil, fmt.Errorf("failed to scan case: %w", err)
+		}
+		cases = append(cases, &c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to load open cases: %w", err)
+	}
+	return cases, nil
+}
+
+// loadEntityEvaluations returns the latest derived attribute evaluations of the entity's cases
+func loadEntityEvaluations(ctx context.Context, entityID string, limit int32) ([]*pb.RuleEvaluation, error) {
+	rows, err := DB.Query(ctx, `
+	  SELECT e.case_name, e.derived_code, COALESCE(e.value,''), e.success, COALESCE(e.error,''),
+	         COALESCE(e.regulation_code,''), COALESCE(e.evaluated_at::text,'')
+	    FROM kyc_lineage_evaluations e
+	   WHERE e.case_name IN (SELECT case_id FROM (`+entityCases+`) c)
+	   ORDER BY e.evaluated_at DESC NULLS LAST
+	   LIMIT $2`, entityID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load evaluations: %w", err)
+	}
+	defer rows.Close()
+
+	var evals []*pb.RuleEvaluation
+	for rows.Next() {
+		var e pb.RuleEvaluation
+		if err := rows.Scan(&e.CaseName, &e.DerivedCode, &e.Value, &e.Success, &e.Error,
+			&e.RegulationCode, &e.EvaluatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan evaluation: %w", err)
+		}
+		evals = append(evals, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to load evaluations: %w", err)
+	}
+	return evals, nil
+}
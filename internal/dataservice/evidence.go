@@ -0,0 +1,224 @@
+package dataservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/adamtc007/KYC-DSL/api/pb/kycdata"
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/evidence"
+	"github.com/adamtc007/KYC-DSL/internal/logging"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+// evidenceChunkSize is the size of the content chunks DownloadEvidence sends
+const evidenceChunkSize = 64 << 10
+
+// UploadEvidence stores a document file streamed by the client: the first
+// message carries the metadata and every message a chunk of the content
+func (s *DataService) UploadEvidence(stream grpc.ClientStreamingServer[pb.UploadEvidenceRequest, pb.Evidence]) error {
+	ctx := stream.Context()
+	if s.primaryCases != nil {
+		logging.FromContext(ctx).Info("↪️  UploadEvidence: forwarding to primary region")
+		return forwardEvidenceUpload(ctx, stream, s.primaryCases)
+	}
+
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return status.Error(codes.InvalidArgument, "upload stream is empty")
+	}
+	if err != nil {
+		return err
+	}
+	meta := first.GetMetadata()
+	if meta == nil {
+		return status.Error(codes.InvalidArgument, "the first message must carry the metadata")
+	}
+	logging.FromContext(ctx).Info("📎 UploadEvidence", "case_id", meta.CaseId, "file_name", meta.FileName, "document_code", meta.DocumentCode)
+
+	upload := evidence.Upload{
+		CaseName:      meta.CaseId,
+		DocumentCode:  meta.DocumentCode,
+		AttributeCode: meta.AttributeCode,
+		FileName:      meta.FileName,
+		ContentType:   meta.ContentType,
+		ValidityDays:  int(meta.ValidityDays),
+		Description:   meta.Description,
+	}
+	if upload.IssuedAt, err = parseEvidenceDate(meta.IssuedAt, "issued_at"); err != nil {
+		return err
+	}
+	if upload.ExpiresAt, err = parseEvidenceDate(meta.ExpiresAt, "expires_at"); err != nil {
+		return err
+	}
+
+	svc, err := evidence.NewService(DBX, config.Current().Evidence)
+	if err != nil {
+		return err
+	}
+	e, err := svc.Upload(ctx, upload, &evidenceStreamReader{stream: stream, buf: first.Chunk})
+	if err != nil {
+		return evidenceStatus(err)
+	}
+	logging.FromContext(ctx).Info("✅ Evidence stored", "id", e.ID, "sha256", e.SHA256, "size_bytes", e.SizeBytes)
+	return stream.SendAndClose(evidenceToProto(e))
+}
+
+// DownloadEvidence streams the content of evidence, preceded by its
+// metadata, and records the download
+func (s *DataService) DownloadEvidence(req *pb.GetEvidenceRequest, stream grpc.ServerStreamingServer[pb.EvidenceChunk]) error {
+	ctx := stream.Context()
+	logging.FromContext(ctx).Info("📎 DownloadEvidence", "id", req.Id)
+
+	svc, err := evidence.NewService(DBX, config.Current().Evidence)
+	if err != nil {
+		return err
+	}
+	e, content, err := svc.Open(ctx, req.Id)
+	if err != nil {
+		return evidenceStatus(err)
+	}
+	defer content.Close()
+
+	if err := stream.Send(&pb.EvidenceChunk{Metadata: evidenceToProto(e)}); err != nil {
+		return err
+	}
+	buf := make([]byte, evidenceChunkSize)
+	for {
+		n, err := content.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&pb.EvidenceChunk{Data: buf[:n]}); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read evidence %d: %w", req.Id, err)
+		}
+	}
+}
+
+// ListEvidence returns the evidence collected for a case, newest first
+func (s *DataService) ListEvidence(ctx context.Context, req *pb.ListEvidenceRequest) (*pb.EvidenceList, error) {
+	logging.FromContext(ctx).Info("📎 ListEvidence", "case_id", req.CaseId)
+
+	if req.CaseId == "" {
+		return nil, status.Error(codes.InvalidArgument, "case_id is required")
+	}
+	svc, err := evidence.NewService(DBX, config.Current().Evidence)
+	if err != nil {
+		return nil, err
+	}
+	all, err := svc.List(ctx, req.CaseId)
+	if err != nil {
+		return nil, err
+	}
+	out := &pb.EvidenceList{}
+	for i := range all {
+		out.Evidence = append(out.Evidence, evidenceToProto(&all[i]))
+	}
+	return out, nil
+}
+
+// evidenceStreamReader reads the content chunks of an upload stream
+type evidenceStreamReader struct {
+	stream grpc.ClientStreamingServer[pb.UploadEvidenceRequest, pb.Evidence]
+	buf    []byte
+}
+
+func (r *evidenceStreamReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		msg, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = msg.Chunk
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// forwardEvidenceUpload relays an upload stream to the primary region
+func forwardEvidenceUpload(ctx context.Context, in grpc.ClientStreamingServer[pb.UploadEvidenceRequest, pb.Evidence], primary pb.CaseServiceClient) error {
+	out, err := primary.UploadEvidence(ctx)
+	if err != nil {
+		return err
+	}
+	for {
+		msg, err := in.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err := out.Send(msg); err != nil {
+			// The primary's status is returned by CloseAndRecv
+			break
+		}
+	}
+	e, err := out.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+	return in.SendAndClose(e)
+}
+
+func parseEvidenceDate(s, field string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s must be YYYY-MM-DD, got %q", field, s)
+	}
+	return &t, nil
+}
+
+func evidenceStatus(err error) error {
+	switch {
+	case errors.Is(err, evidence.ErrNotFound), errors.Is(err, evidence.ErrCaseNotFound), errors.Is(err, evidence.ErrObjectNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, evidence.ErrInvalid):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, evidence.ErrTooLarge):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	return err
+}
+
+func evidenceToProto(e *model.Evidence) *pb.Evidence {
+	out := &pb.Evidence{
+		Id:            e.ID,
+		CaseId:        e.CaseName,
+		DocumentCode:  e.DocumentCode,
+		AttributeCode: e.AttributeCode,
+		FileName:      e.FileName,
+		ContentType:   e.ContentType,
+		SizeBytes:     e.SizeBytes,
+		Sha256:        e.SHA256,
+		StorageDriver: e.StorageDriver,
+		ValidityDays:  int32(e.ValidityDays),
+		TextStatus:    e.TextStatus,
+		Description:   e.Description,
+		Actor:         e.Actor,
+		UploadedAt:    e.UploadedAt.Format(time.RFC3339),
+	}
+	if e.IssuedAt != nil {
+		out.IssuedAt = e.IssuedAt.Format("2006-01-02")
+	}
+	if e.ExpiresAt != nil {
+		out.ExpiresAt = e.ExpiresAt.Format("2006-01-02")
+	}
+	return out
+}
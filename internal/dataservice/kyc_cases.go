@@ -0,0 +1,182 @@
+package dataservice
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/adamtc007/KYC-DSL/api/pb"
+	"github.com/adamtc007/KYC-DSL/internal/casediff"
+	"github.com/adamtc007/KYC-DSL/internal/logging"
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+// CreateCase stores DSL as the first version of a new case in kyc_cases and
+// kyc_case_versions, the store kycctl amend and validate and the case
+// lifecycle work on. The case name is read from the (kyc-case NAME ...) form.
+func (s *KycCaseService) CreateCase(ctx context.Context, req *pb.CreateCaseRequest) (*pb.KycCase, error) {
+	if s.primary != nil {
+		logging.FromContext(ctx).Info("↪️  CreateCase: forwarding to primary region")
+		return s.primary.CreateCase(ctx, req)
+	}
+
+	sec, err := storage.ParseCase(req.Dsl)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid DSL: %v", err)
+	}
+	name := sec.Arg(0)
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, "kyc-case form has no name")
+	}
+
+	logging.FromContext(ctx).Info("🆕 CreateCase", "case_name", name)
+
+	exists, err := storage.CaseExists(DBX, name)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, status.Errorf(codes.AlreadyExists, "case %s already exists", name)
+	}
+	if err := storage.SaveCaseVersion(ctx, DBX, name, req.Dsl); err != nil {
+		return nil, err
+	}
+
+	dsl, version, hash, err := storage.GetLatestCaseWithMetadata(DBX, name)
+	if err != nil {
+		return nil, err
+	}
+	summary, err := storage.GetCaseByName(DBX, name)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &pb.KycCase{
+		Id:         name,
+		Name:       name,
+		Dsl:        dsl,
+		Version:    int32(version), //nolint:gosec
+		Sha256Hash: hash,
+		CreatedAt:  timestamppb.New(summary.LastUpdated),
+		UpdatedAt:  timestamppb.New(summary.LastUpdated),
+	}
+	if j, ok := sec.Find("jurisdiction"); ok {
+		out.Jurisdiction = strings.Join(j.Args, " ")
+	}
+	if p, ok := sec.Find("policy"); ok {
+		out.Policy = p.Arg(0)
+	}
+
+	logging.FromContext(ctx).Info("✅ Case created", "case_name", name, "version", version)
+	return out, nil
+}
+
+// GetCaseVersions streams every version of a case, oldest first
+func (s *KycCaseService) GetCaseVersions(req *pb.GetCaseVersionsRequest, stream pb.KycCaseService_GetCaseVersionsServer) error {
+	ctx := stream.Context()
+	logging.FromContext(ctx).Info("📜 GetCaseVersions", "case_name", req.CaseName)
+
+	versions, err := storage.ListCaseVersions(DBX, req.CaseName)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if len(versions) == 0 {
+		return status.Errorf(codes.NotFound, "case %s not found", req.CaseName)
+	}
+
+	for _, v := range versions {
+		dsl, _, err := storage.GetCaseVersion(DBX, req.CaseName, v.Version)
+		if err != nil {
+			return err
+		}
+		err = stream.Send(&pb.KycCaseVersion{
+			CaseName:    req.CaseName,
+			Version:     int32(v.Version), //nolint:gosec
+			DslSnapshot: dsl,
+			Sha256Hash:  v.Hash,
+			CreatedAt:   timestamppb.New(v.CreatedAt),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetCaseVersionDiff compares two versions of a case section by section.
+// to_version defaults to the latest version and from_version to the one
+// before it.
+func (s *KycCaseService) GetCaseVersionDiff(ctx context.Context, req *pb.GetCaseVersionDiffRequest) (*pb.CaseVersionDiff, error) {
+	logging.FromContext(ctx).Info("🔀 GetCaseVersionDiff", "case_name", req.CaseName, "from", req.FromVersion, "to", req.ToVersion)
+	if req.CaseName == "" {
+		return nil, status.Error(codes.InvalidArgument, "case_name is required")
+	}
+
+	diff, err := casediff.Versions(DBX, req.CaseName, int(req.FromVersion), int(req.ToVersion))
+	if errors.Is(err, casediff.ErrVersionNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	out := &pb.CaseVersionDiff{
+		CaseName:    diff.CaseName,
+		FromVersion: int32(diff.FromVersion), //nolint:gosec
+		ToVersion:   int32(diff.ToVersion),   //nolint:gosec
+		FromHash:    diff.FromHash,
+		ToHash:      diff.ToHash,
+	}
+	for _, c := range diff.Changes {
+		out.Changes = append(out.Changes, &pb.CaseSectionChange{
+			ChangeType: c.Type,
+			Section:    c.Section,
+			Key:        c.Key,
+			Before:     c.Before,
+			After:      c.After,
+		})
+	}
+	logging.FromContext(ctx).Info("✅ Diffed case versions", "case_name", diff.CaseName, "changes", len(diff.Changes))
+	return out, nil
+}
+
+// VerifyCaseIntegrity re-computes the hash chain of a case's versions from
+// their snapshots and reports where it breaks
+func (s *KycCaseService) VerifyCaseIntegrity(ctx context.Context, req *pb.VerifyCaseIntegrityRequest) (*pb.CaseIntegrityReport, error) {
+	logging.FromContext(ctx).Info("🔗 VerifyCaseIntegrity", "case_name", req.CaseName)
+	if req.CaseName == "" {
+		return nil, status.Error(codes.InvalidArgument, "case_name is required")
+	}
+
+	report, err := storage.VerifyCaseIntegrity(ctx, DBX, req.CaseName)
+	if errors.Is(err, storage.ErrNoVersions) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	out := &pb.CaseIntegrityReport{
+		CaseName: report.CaseName,
+		Versions: int32(report.Versions), //nolint:gosec
+		Valid:    report.Valid,
+		HeadHash: report.HeadHash,
+	}
+	for _, issue := range report.Issues {
+		out.Issues = append(out.Issues, &pb.CaseIntegrityIssue{
+			Version: int32(issue.Version), //nolint:gosec
+			Problem: issue.Problem,
+			Detail:  issue.Detail,
+		})
+	}
+	if report.Valid {
+		logging.FromContext(ctx).Info("✅ Case chain intact", "case_name", report.CaseName, "versions", report.Versions)
+	} else {
+		logging.FromContext(ctx).Warn("🚨 Case chain broken", "case_name", report.CaseName, "issues", len(report.Issues))
+	}
+	return out, nil
+}
@@ -0,0 +1,54 @@
+package dataservice
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/adamtc007/KYC-DSL/api/pb/kycontology"
+	"github.com/adamtc007/KYC-DSL/internal/lineage"
+	"github.com/adamtc007/KYC-DSL/internal/logging"
+)
+
+// GetLineageGraph returns the derivation DAG around an attribute, with the
+// rule, jurisdiction and regulation of every edge, for provenance views
+func (s *OntologyService) GetLineageGraph(ctx context.Context, req *pb.GetLineageGraphRequest) (*pb.LineageGraph, error) {
+	logging.FromContext(ctx).Info("🧬 GetLineageGraph", "attribute", req.Attribute, "max_depth", req.MaxDepth)
+
+	if req.Attribute == "" {
+		return nil, status.Error(codes.InvalidArgument, "attribute is required")
+	}
+	graph, err := lineage.Graph(ctx, DBX, strings.ToUpper(req.Attribute), int(req.MaxDepth))
+	if errors.Is(err, lineage.ErrUnknownAttribute) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	out := &pb.LineageGraph{Attribute: graph.Attribute, Truncated: graph.Truncated}
+	for _, n := range graph.Nodes {
+		out.Nodes = append(out.Nodes, &pb.LineageNode{
+			Code:           n.Code,
+			Name:           n.Name,
+			AttributeClass: n.Class,
+			Role:           n.Role,
+			Depth:          int32(n.Depth), //nolint:gosec
+		})
+	}
+	for _, e := range graph.Edges {
+		out.Edges = append(out.Edges, &pb.LineageEdge{
+			Id:             int32(e.ID), //nolint:gosec
+			Source:         e.Source,
+			Target:         e.Target,
+			Rule:           e.Rule,
+			RuleType:       e.RuleType,
+			Jurisdiction:   e.Jurisdiction,
+			RegulationCode: e.RegulationCode,
+		})
+	}
+	return out, nil
+}
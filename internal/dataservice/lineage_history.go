@@ -0,0 +1,66 @@
+package dataservice
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/adamtc007/KYC-DSL/api/pb/kycdata"
+	"github.com/adamtc007/KYC-DSL/internal/lineage"
+	"github.com/adamtc007/KYC-DSL/internal/logging"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+// GetLineageHistory returns the lineage evaluation runs of a case, newest
+// first, with the result of every derivation they evaluated
+func (s *DataService) GetLineageHistory(ctx context.Context, req *pb.GetLineageHistoryRequest) (*pb.LineageHistory, error) {
+	logging.FromContext(ctx).Info("🧬 GetLineageHistory", "case_id", req.CaseId, "limit", req.Limit)
+
+	if req.CaseId == "" {
+		return nil, status.Error(codes.InvalidArgument, "case_id is required")
+	}
+	runs, err := lineage.NewRunRepo(DBX).History(ctx, req.CaseId, int(req.Limit))
+	if err != nil {
+		return nil, err
+	}
+
+	out := &pb.LineageHistory{CaseId: req.CaseId}
+	for _, run := range runs {
+		out.Runs = append(out.Runs, lineageRunToProto(run))
+	}
+	return out, nil
+}
+
+func lineageRunToProto(run model.EvaluationRun) *pb.LineageRun {
+	out := &pb.LineageRun{
+		Id:          run.ID,
+		CaseId:      run.CaseName,
+		InputsHash:  run.InputsHash,
+		Source:      run.Source,
+		Actor:       run.Actor,
+		Evaluations: int32(run.Evaluations), //nolint:gosec
+		Failed:      int32(run.Failed),      //nolint:gosec
+		StartedAt:   run.StartedAt.Format(time.RFC3339),
+	}
+	if run.CaseVersion != nil {
+		out.CaseVersion = int32(*run.CaseVersion) //nolint:gosec
+	}
+	for _, r := range run.Results {
+		out.Results = append(out.Results, &pb.DerivationResult{
+			EvaluationId:   r.EvaluationID,
+			DerivedCode:    r.DerivedCode,
+			Value:          r.Value,
+			ValueType:      r.ValueType,
+			Success:        r.Success,
+			Error:          r.Error,
+			Rule:           r.Rule,
+			InputsJson:     string(r.Inputs),
+			Jurisdiction:   r.Jurisdiction,
+			RegulationCode: r.RegulationCode,
+			EvaluatedAt:    r.EvaluatedAt.Format(time.RFC3339),
+		})
+	}
+	return out
+}
@@ -0,0 +1,226 @@
+package dataservice
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	pb "github.com/adamtc007/KYC-DSL/api/pb/kycdata"
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/logging"
+	"github.com/adamtc007/KYC-DSL/internal/modellog"
+	"github.com/adamtc007/KYC-DSL/internal/narrative"
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+	"github.com/jackc/pgx/v5"
+)
+
+// GenerateCaseNarrative composes a due-diligence summary of a case version
+// from its DSL, CBU graph, rule evaluations and validation findings, optionally
+// polishes it, and stores it with the version it describes
+func (s *DataService) GenerateCaseNarrative(ctx context.Context, req *pb.GenerateNarrativeRequest) (*pb.CaseNarrative, error) {
+	if s.primaryCases != nil {
+		logging.FromContext(ctx).Info("↪️  GenerateCaseNarrative: forwarding to primary region", "case_id", req.CaseId)
+		return s.primaryCases.GenerateCaseNarrative(ctx, req)
+	}
+
+	logger := logging.FromContext(ctx)
+	logger.Info("📝 GenerateCaseNarrative", "case_id", req.CaseId, "version_id", req.VersionId,
+		"template", req.Template, "polish", req.Polish)
+
+	facts, versionID, err := loadNarrativeFacts(ctx, req.CaseId, req.VersionId)
+	if err != nil {
+		return nil, err
+	}
+
+	template := req.Template
+	if template == "" {
+		template = narrative.DefaultTemplate
+	}
+	draft, err := narrative.Render(template, facts)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &pb.CaseNarrative{
+		CaseId:    facts.CaseID,
+		VersionId: facts.VersionID,
+		Template:  template,
+		Draft:     draft,
+		Narrative: draft,
+	}
+	if req.Polish {
+		polisher, err := narrative.NewPolisher()
+		if err == nil {
+			polisher.WithLog(modellog.NewLogger(DBX, config.Current().ModelLog))
+			var polished string
+			if polished, err = polisher.Polish(ctx, draft); err == nil {
+				out.Narrative, out.Polished, out.Model = polished, true, polisher.Model()
+			}
+		}
+		if err != nil {
+			logger.Warn("⚠️  Narrative polishing skipped; storing draft", "error", err)
+			out.PolishError = err.Error()
+		}
+	}
+
+	factsJSON, err := facts.JSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode narrative facts: %w", err)
+	}
+	var createdAt time.Time
+	err = DB.QueryRow(ctx, `
+		INSERT INTO case_narratives (case_id, case_version_id, template, draft, narrative, polished, model, facts)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), $8)
+		RETURNING id::text, created_at`,
+		out.CaseId, versionID, out.Template, out.Draft, out.Narrative, out.Polished, out.Model, factsJSON,
+	).Scan(&out.Id, &createdAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store narrative: %w", err)
+	}
+	out.CreatedAt = createdAt.Format(time.RFC3339)
+
+	logger.Info("✅ Stored case narrative", "case_id", out.CaseId, "narrative_id", out.Id,
+		"risks", len(facts.RaisedRisks()), "gaps", len(facts.Gaps), "polished", out.Polished)
+	return out, nil
+}
+
+// loadNarrativeFacts gathers the facts of a case version (the latest when
+// versionID is empty) and returns them with the numeric version id
+func loadNarrativeFacts(ctx context.Context, caseID, versionID string) (*narrative.Facts, int, error) {
+	query := `
+		SELECT id, dsl_source, status, created_at FROM case_versions
+		 WHERE case_id = $1 ORDER BY created_at DESC, id DESC LIMIT 1`
+	args := []any{caseID}
+	if versionID != "" {
+		id, err := strconv.Atoi(versionID)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid version id %q", versionID)
+		}
+		query = `SELECT id, dsl_source, status, created_at FROM case_versions WHERE case_id = $1 AND id = $2`
+		args = append(args, id)
+	}
+
+	var id int
+	var dsl, status string
+	var createdAt time.Time
+	if err := DB.QueryRow(ctx, query, args...).Scan(&id, &dsl, &status, &createdAt); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, 0, fmt.Errorf("case version not found: %s", caseID)
+		}
+		return nil, 0, fmt.Errorf("database error: %w", err)
+	}
+
+	facts, err := narrative.FromDSL(dsl)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read case DSL: %w", err)
+	}
+	facts.CaseID = caseID
+	facts.VersionID = strconv.Itoa(id)
+	facts.Status = status
+	facts.VersionDate = createdAt.Format("2006-01-02")
+	facts.VersionHash = storage.CanonicalHash(dsl)
+	facts.GeneratedAt = time.Now().UTC()
+
+	if facts.CBU != "" {
+		if facts.Participants, err = loadParticipants(ctx, facts.CBU); err != nil {
+			return nil, 0, err
+		}
+	}
+	if facts.RiskFactors, err = loadRiskFactors(ctx, facts); err != nil {
+		return nil, 0, err
+	}
+	facts.DeriveGaps()
+	if err := addValidationGaps(ctx, facts); err != nil {
+		return nil, 0, err
+	}
+	return facts, id, nil
+}
+
+// loadParticipants returns the entities holding current roles in the CBU with the given code
+func loadParticipants(ctx context.Context, cbuCode string) ([]narrative.Participant, error) {
+	rows, err := DB.Query(ctx, `
+		SELECT e.name, e.entity_type, COALESCE(e.jurisdiction, ''), rt.name
+		  FROM cbu c
+		  JOIN cbu_role cr ON cr.cbu_id = c.id
+		  JOIN entity e ON e.id = cr.entity_id
+		  JOIN role_type rt ON rt.id = cr.role_type_id
+		 WHERE c.code = $1 AND (cr.end_date IS NULL OR cr.end_date >= CURRENT_DATE)
+		 ORDER BY cr.is_primary DESC, e.name`, cbuCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load cbu participants: %w", err)
+	}
+	defer rows.Close()
+
+	var out []narrative.Participant
+	for rows.Next() {
+		var p narrative.Participant
+		if err := rows.Scan(&p.Name, &p.EntityType, &p.Jurisdiction, &p.Role); err != nil {
+			return nil, fmt.Errorf("failed to scan participant: %w", err)
+		}
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
+
+// loadRiskFactors explains the latest evaluation of each derived attribute of
+// the case; failed evaluations are recorded as gaps
+func loadRiskFactors(ctx context.Context, facts *narrative.Facts) ([]narrative.RiskFactor, error) {
+	rows, err := DB.Query(ctx, `
+		SELECT DISTINCT ON (derived_code)
+		       derived_code, COALESCE(value, ''), success, COALESCE(error, ''), rule,
+		       COALESCE(regulation_code, ''), COALESCE(inputs, '{}'::jsonb)
+		  FROM kyc_lineage_evaluations
+		 WHERE case_name = $1
+		 ORDER BY derived_code, evaluated_at DESC`, facts.CaseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rule evaluations: %w", err)
+	}
+	defer rows.Close()
+
+	var out []narrative.RiskFactor
+	for rows.Next() {
+		var code, value, evalErr, rule, regulation string
+		var success bool
+		var inputsJSON []byte
+		if err := rows.Scan(&code, &value, &success, &evalErr, &rule, &regulation, &inputsJSON); err != nil {
+			return nil, fmt.Errorf("failed to scan rule evaluation: %w", err)
+		}
+		if !success {
+			facts.AddGap("Rule %s could not be evaluated: %s", code, evalErr)
+			continue
+		}
+		var inputs map[string]any
+		_ = json.Unmarshal(inputsJSON, &inputs)
+		out = append(out, narrative.NewRiskFactor(code, value, rule, regulation, inputs))
+	}
+	return out, rows.Err()
+}
+
+// addValidationGaps records the failed and warning checks of the case's latest validation
+func addValidationGaps(ctx context.Context, facts *narrative.Facts) error {
+	rows, err := DB.Query(ctx, `
+		SELECT f.check_status, f.check_name, COALESCE(f.check_message, '')
+		  FROM kyc_validation_findings f
+		 WHERE f.check_status IN ('FAIL', 'WARN')
+		   AND f.validation_id = (SELECT id FROM kyc_case_validations
+		                           WHERE case_name = $1 ORDER BY validation_time DESC LIMIT 1)
+		 ORDER BY f.check_status, f.id`, facts.CaseID)
+	if err != nil {
+		return fmt.Errorf("failed to load validation findings: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var status, name, message string
+		if err := rows.Scan(&status, &name, &message); err != nil {
+			return fmt.Errorf("failed to scan validation finding: %w", err)
+		}
+		if message == "" {
+			message = "check " + status
+		}
+		facts.AddGap("Validation check %s: %s", name, message)
+	}
+	return rows.Err()
+}
@@ -3,25 +3,36 @@ package dataservice
 import (
 	"context"
 	"fmt"
-	"log"
 
 	pb "github.com/adamtc007/KYC-DSL/api/pb/kycontology"
+	"github.com/adamtc007/KYC-DSL/internal/cursor"
+	"github.com/adamtc007/KYC-DSL/internal/logging"
+	"github.com/jackc/pgx/v5"
 )
 
 type OntologyService struct {
 	pb.UnimplementedOntologyServiceServer
+
+	// primary forwards screenings to the primary region when this server is a read replica
+	primary pb.OntologyServiceClient
 }
 
 func NewOntologyService() *OntologyService {
 	return &OntologyService{}
 }
 
+// ForwardWritesTo makes ScreenEntity forward to the primary region's
+// OntologyService
+func (s *OntologyService) ForwardWritesTo(primary pb.OntologyServiceClient) {
+	s.primary = primary
+}
+
 // ============================================================================
 // Entities
 // ============================================================================
 
 func (s *OntologyService) GetEntity(ctx context.Context, req *pb.GetEntityRequest) (*pb.Entity, error) {
-	log.Printf("📦 GetEntity: id=%s", req.Id)
+	logging.FromContext(ctx).Info("📦 GetEntity", "id", req.Id)
 	row := DB.QueryRow(ctx, `
 	  SELECT id, name, entity_type, COALESCE(legal_form,''), jurisdiction,
 	         COALESCE(registration_number,''), COALESCE(lei_code,''), status, COALESCE(description,'')
@@ -32,22 +43,30 @@ func (s *OntologyService) GetEntity(ctx context.Context, req *pb.GetEntityReques
 		&e.RegistrationNumber, &e.LeiCode, &e.Status, &e.Description); err != nil {
 		return nil, fmt.Errorf("entity not found: %w", err)
 	}
-	log.Printf("✅ Found entity: %s", e.Name)
+	logging.FromContext(ctx).Info("✅ Found entity", "name", e.Name)
 	return &e, nil
 }
 
 func (s *OntologyService) ListEntities(ctx context.Context, req *pb.ListEntitiesRequest) (*pb.EntityList, error) {
-	log.Printf("📦 ListEntities: limit=%d, offset=%d", req.Limit, req.Offset)
+	logging.FromContext(ctx).Info("📦 ListEntities", "limit", req.Limit, "offset", req.Offset, "cursor", req.Cursor)
 
-	limit := req.Limit
-	if limit <= 0 || limit > 100 {
-		limit = 50
+	limit := pageLimit(req.Limit)
+	keys, err := decodeCursor(req.Cursor, 2)
+	if err != nil {
+		return nil, err
 	}
 
-	rows, err := DB.Query(ctx, `
+	// Names are not unique, so pages are keyed on (name, id)
+	query := `
 	  SELECT id, name, entity_type, COALESCE(legal_form,''), jurisdiction,
 	         COALESCE(registration_number,''), COALESCE(lei_code,''), status, COALESCE(description,'')
-	    FROM entity ORDER BY name LIMIT $1 OFFSET $2`, limit, req.Offset)
+	    FROM entity`
+	args := []interface{}{limit + 1, req.Offset}
+	if keys != nil {
+		query += ` WHERE (name, id) > ($3, $4::uuid)`
+		args = []interface{}{limit + 1, 0, keys[0], keys[1]}
+	}
+	rows, err := DB.Query(ctx, query+` ORDER BY name, id LIMIT $1 OFFSET $2`, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -68,27 +87,31 @@ func (s *OntologyService) ListEntities(ctx context.Context, req *pb.ListEntities
 		total = int32(len(list.Entities)) //nolint:gosec
 	}
 	list.TotalCount = total
+	if len(list.Entities) > int(limit) {
+		list.Entities = list.Entities[:limit]
+		last := list.Entities[limit-1]
+		list.NextCursor = cursor.Encode(last.Name, last.Id)
+	}
 
-	log.Printf("✅ Listed %d entities (total: %d)", len(list.Entities), total)
+	logging.FromContext(ctx).Info("✅ Listed entities", "count", len(list.Entities), "total", total)
 	return list, nil
 }
 
 func (s *OntologyService) SearchEntities(ctx context.Context, req *pb.SearchRequest) (*pb.EntityList, error) {
-	log.Printf("🔍 SearchEntities: query=%s", req.Query)
+	logging.FromContext(ctx).Info("🔍 SearchEntities", "query", req.Query)
 
 	limit := req.Limit
 	if limit <= 0 || limit > 100 {
 		limit = 20
 	}
 
-	rows, err := DB.Query(ctx, `
-	  SELECT id, name, entity_type, COALESCE(legal_form,''), jurisdiction,
-	         COALESCE(registration_number,''), COALESCE(lei_code,''), status, COALESCE(description,'')
-	    FROM entity 
-	   WHERE name ILIKE '%' || $1 || '%' 
-	      OR lei_code ILIKE '%' || $1 || '%'
-	      OR description ILIKE '%' || $1 || '%'
-	   ORDER BY name LIMIT $2`, req.Query, limit)
+	tx, err := searchTx(ctx, matchThreshold(req.SimilarityThreshold))
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	rows, err := tx.Query(ctx, entitySearchSQL, req.Query, limit, max(req.Offset, 0))
 	if err != nil {
 		return nil, err
 	}
@@ -98,14 +121,14 @@ func (s *OntologyService) SearchEntities(ctx context.Context, req *pb.SearchRequ
 	for rows.Next() {
 		var e pb.Entity
 		if err := rows.Scan(&e.Id, &e.Name, &e.EntityType, &e.LegalForm, &e.Jurisdiction,
-			&e.RegistrationNumber, &e.LeiCode, &e.Status, &e.Description); err != nil {
+			&e.RegistrationNumber, &e.LeiCode, &e.Status, &e.Description, &e.MatchRank); err != nil {
 			continue
 		}
 		list.Entities = append(list.Entities, &e)
 	}
 	list.TotalCount = int32(len(list.Entities)) //nolint:gosec
 
-	log.Printf("✅ Found %d entities matching '%s'", len(list.Entities), req.Query)
+	logging.FromContext(ctx).Info("✅ Found matching entities", "count", len(list.Entities), "query", req.Query)
 	return list, nil
 }
 
@@ -114,7 +137,7 @@ func (s *OntologyService) SearchEntities(ctx context.Context, req *pb.SearchRequ
 // ============================================================================
 
 func (s *OntologyService) GetCbu(ctx context.Context, req *pb.GetCbuRequest) (*pb.Cbu, error) {
-	log.Printf("🏢 GetCbu: id=%s", req.Id)
+	logging.FromContext(ctx).Info("🏢 GetCbu", "id", req.Id)
 
 	row := DB.QueryRow(ctx, `
 	  SELECT id, name, COALESCE(sponsor_entity_id::text,''), COALESCE(domicile,''), COALESCE(description,'')
@@ -125,12 +148,12 @@ func (s *OntologyService) GetCbu(ctx context.Context, req *pb.GetCbuRequest) (*p
 		return nil, fmt.Errorf("cbu not found: %w", err)
 	}
 
-	log.Printf("✅ Found CBU: %s", c.Name)
+	logging.FromContext(ctx).Info("✅ Found CBU", "name", c.Name)
 	return &c, nil
 }
 
 func (s *OntologyService) GetCbuRoles(ctx context.Context, req *pb.GetCbuRolesRequest) (*pb.CbuRoleList, error) {
-	log.Printf("👥 GetCbuRoles: cbu_id=%s", req.CbuId)
+	logging.FromContext(ctx).Info("👥 GetCbuRoles", "cbu_id", req.CbuId)
 
 	rows, err := DB.Query(ctx, `
 	  SELECT cr.id, cr.cbu_id, cr.entity_id,
@@ -159,7 +182,7 @@ func (s *OntologyService) GetCbuRoles(ctx context.Context, req *pb.GetCbuRolesRe
 	}
 	out.TotalCount = int32(len(out.Roles)) //nolint:gosec
 
-	log.Printf("✅ Found %d roles for CBU", len(out.Roles))
+	logging.FromContext(ctx).Info("✅ Found CBU roles", "count", len(out.Roles))
 	return out, nil
 }
 
@@ -168,7 +191,7 @@ func (s *OntologyService) GetCbuRoles(ctx context.Context, req *pb.GetCbuRolesRe
 // ============================================================================
 
 func (s *OntologyService) GetAttribute(ctx context.Context, req *pb.GetAttributeRequest) (*pb.Attribute, error) {
-	log.Printf("📖 GetAttribute: id=%s", req.Id)
+	logging.FromContext(ctx).Info("📖 GetAttribute", "id", req.Id)
 
 	row := DB.QueryRow(ctx, `
 	  SELECT id, code, name, COALESCE(description,''), attr_type, 
@@ -182,16 +205,17 @@ func (s *OntologyService) GetAttribute(ctx context.Context, req *pb.GetAttribute
 		return nil, fmt.Errorf("attribute not found: %w", err)
 	}
 
-	log.Printf("✅ Found attribute: %s", a.Name)
+	logging.FromContext(ctx).Info("✅ Found attribute", "name", a.Name)
 	return &a, nil
 }
 
 func (s *OntologyService) ListAttributes(ctx context.Context, req *pb.ListAttributesRequest) (*pb.AttributeList, error) {
-	log.Printf("📖 ListAttributes: limit=%d, offset=%d", req.Limit, req.Offset)
+	logging.FromContext(ctx).Info("📖 ListAttributes", "limit", req.Limit, "offset", req.Offset, "cursor", req.Cursor)
 
-	limit := req.Limit
-	if limit <= 0 || limit > 100 {
-		limit = 50
+	limit := pageLimit(req.Limit)
+	after, offset, err := codeCursor(req.Cursor, req.Offset)
+	if err != nil {
+		return nil, err
 	}
 
 	rows, err := DB.Query(ctx, `
@@ -199,7 +223,8 @@ func (s *OntologyService) ListAttributes(ctx context.Context, req *pb.ListAttrib
 	         COALESCE(jurisdiction,''), COALESCE(sink_table,''),
 	         COALESCE(sink_column,''), COALESCE(source_priority::text,'{}')
 	    FROM dictionary_attribute
-	    ORDER BY code LIMIT $1 OFFSET $2`, limit, req.Offset)
+	   WHERE ($3 = '' OR code > $3)
+	    ORDER BY code LIMIT $1 OFFSET $2`, limit+1, offset, after)
 	if err != nil {
 		return nil, err
 	}
@@ -220,28 +245,30 @@ func (s *OntologyService) ListAttributes(ctx context.Context, req *pb.ListAttrib
 		total = int32(len(list.Attributes)) //nolint:gosec
 	}
 	list.TotalCount = total
+	if len(list.Attributes) > int(limit) {
+		list.Attributes = list.Attributes[:limit]
+		list.NextCursor = cursor.Encode(list.Attributes[limit-1].Code)
+	}
 
-	log.Printf("✅ Listed %d attributes (total: %d)", len(list.Attributes), total)
+	logging.FromContext(ctx).Info("✅ Listed attributes", "count", len(list.Attributes), "total", total)
 	return list, nil
 }
 
 func (s *OntologyService) SearchAttributes(ctx context.Context, req *pb.SearchRequest) (*pb.AttributeList, error) {
-	log.Printf("🔍 SearchAttributes: query=%s", req.Query)
+	logging.FromContext(ctx).Info("🔍 SearchAttributes", "query", req.Query)
 
 	limit := req.Limit
 	if limit <= 0 || limit > 100 {
 		limit = 20
 	}
 
-	rows, err := DB.Query(ctx, `
-	  SELECT id, code, name, COALESCE(description,''), attr_type,
-	         COALESCE(jurisdiction,''), COALESCE(sink_table,''),
-	         COALESCE(sink_column,''), COALESCE(source_priority::text,'{}')
-	    FROM dictionary_attribute
-	   WHERE name ILIKE '%' || $1 || '%' 
-	      OR code ILIKE '%' || $1 || '%'
-	      OR description ILIKE '%' || $1 || '%'
-	   ORDER BY code LIMIT $2`, req.Query, limit)
+	tx, err := searchTx(ctx, matchThreshold(req.SimilarityThreshold))
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	rows, err := tx.Query(ctx, attributeSearchSQL, req.Query, limit, max(req.Offset, 0))
 	if err != nil {
 		return nil, err
 	}
@@ -251,14 +278,14 @@ func (s *OntologyService) SearchAttributes(ctx context.Context, req *pb.SearchRe
 	for rows.Next() {
 		var a pb.Attribute
 		if err := rows.Scan(&a.Id, &a.Code, &a.Name, &a.Description, &a.AttrType,
-			&a.Jurisdiction, &a.SinkTable, &a.SinkColumn, &a.SourcePriority); err != nil {
+			&a.Jurisdiction, &a.SinkTable, &a.SinkColumn, &a.SourcePriority, &a.MatchRank); err != nil {
 			continue
 		}
 		out.Attributes = append(out.Attributes, &a)
 	}
 	out.TotalCount = int32(len(out.Attributes)) //nolint:gosec
 
-	log.Printf("✅ Found %d attributes matching '%s'", len(out.Attributes), req.Query)
+	logging.FromContext(ctx).Info("✅ Found matching attributes", "count", len(out.Attributes), "query", req.Query)
 	return out, nil
 }
 
@@ -267,7 +294,7 @@ func (s *OntologyService) SearchAttributes(ctx context.Context, req *pb.SearchRe
 // ============================================================================
 
 func (s *OntologyService) GetConcept(ctx context.Context, req *pb.GetConceptRequest) (*pb.Concept, error) {
-	log.Printf("💡 GetConcept: id=%s", req.Id)
+	logging.FromContext(ctx).Info("💡 GetConcept", "id", req.Id)
 
 	row := DB.QueryRow(ctx, `
 	  SELECT id, code, name, COALESCE(description,''), COALESCE(domain,''), synonyms
@@ -278,12 +305,12 @@ func (s *OntologyService) GetConcept(ctx context.Context, req *pb.GetConceptRequ
 		return nil, fmt.Errorf("concept not found: %w", err)
 	}
 
-	log.Printf("✅ Found concept: %s", c.Name)
+	logging.FromContext(ctx).Info("✅ Found concept", "name", c.Name)
 	return &c, nil
 }
 
 func (s *OntologyService) SearchConcepts(ctx context.Context, req *pb.SearchRequest) (*pb.ConceptList, error) {
-	log.Printf("🔍 SearchConcepts: query=%s", req.Query)
+	logging.FromContext(ctx).Info("🔍 SearchConcepts", "query", req.Query)
 
 	limit := req.Limit
 	if limit <= 0 || limit > 100 {
@@ -311,7 +338,7 @@ func (s *OntologyService) SearchConcepts(ctx context.Context, req *pb.SearchRequ
 	}
 	out.TotalCount = int32(len(out.Concepts)) //nolint:gosec
 
-	log.Printf("✅ Found %d concepts matching '%s'", len(out.Concepts), req.Query)
+	logging.FromContext(ctx).Info("✅ Found matching concepts", "count", len(out.Concepts), "query", req.Query)
 	return out, nil
 }
 
@@ -320,7 +347,7 @@ func (s *OntologyService) SearchConcepts(ctx context.Context, req *pb.SearchRequ
 // ============================================================================
 
 func (s *OntologyService) GetRegulation(ctx context.Context, req *pb.GetRegulationRequest) (*pb.Regulation, error) {
-	log.Printf("📜 GetRegulation: id=%s", req.Id)
+	logging.FromContext(ctx).Info("📜 GetRegulation", "id", req.Id)
 
 	row := DB.QueryRow(ctx, `
 	  SELECT id, code, name, jurisdiction, COALESCE(authority,''), COALESCE(description,'')
@@ -334,16 +361,18 @@ func (s *OntologyService) GetRegulation(ctx context.Context, req *pb.GetRegulati
 }
 
 func (s *OntologyService) ListRegulations(ctx context.Context, req *pb.ListRegulationsRequest) (*pb.RegulationList, error) {
-	log.Printf("📜 ListRegulations: limit=%d", req.Limit)
+	logging.FromContext(ctx).Info("📜 ListRegulations", "limit", req.Limit, "cursor", req.Cursor)
 
-	limit := req.Limit
-	if limit <= 0 || limit > 100 {
-		limit = 50
+	limit := pageLimit(req.Limit)
+	after, offset, err := codeCursor(req.Cursor, req.Offset)
+	if err != nil {
+		return nil, err
 	}
 
 	rows, err := DB.Query(ctx, `
 	  SELECT id, code, name, jurisdiction, COALESCE(authority,''), COALESCE(description,'')
-	    FROM dictionary_regulation ORDER BY code LIMIT $1 OFFSET $2`, limit, req.Offset)
+	    FROM dictionary_regulation WHERE ($3 = '' OR code > $3)
+	   ORDER BY code LIMIT $1 OFFSET $2`, limit+1, offset, after)
 	if err != nil {
 		return nil, err
 	}
@@ -363,11 +392,15 @@ func (s *OntologyService) ListRegulations(ctx context.Context, req *pb.ListRegul
 		total = int32(len(list.Regulations)) //nolint:gosec
 	}
 	list.TotalCount = total
+	if len(list.Regulations) > int(limit) {
+		list.Regulations = list.Regulations[:limit]
+		list.NextCursor = cursor.Encode(list.Regulations[limit-1].Code)
+	}
 	return list, nil
 }
 
 func (s *OntologyService) GetDocument(ctx context.Context, req *pb.GetDocumentRequest) (*pb.Document, error) {
-	log.Printf("📄 GetDocument: id=%s", req.Id)
+	logging.FromContext(ctx).Info("📄 GetDocument", "id", req.Id)
 
 	row := DB.QueryRow(ctx, `
 	  SELECT id, code, title, COALESCE(jurisdiction,''), COALESCE(category,''), COALESCE(description,'')
@@ -381,16 +414,18 @@ func (s *OntologyService) GetDocument(ctx context.Context, req *pb.GetDocumentRe
 }
 
 func (s *OntologyService) ListDocuments(ctx context.Context, req *pb.ListDocumentsRequest) (*pb.DocumentList, error) {
-	log.Printf("📄 ListDocuments: limit=%d", req.Limit)
+	logging.FromContext(ctx).Info("📄 ListDocuments", "limit", req.Limit, "cursor", req.Cursor)
 
-	limit := req.Limit
-	if limit <= 0 || limit > 100 {
-		limit = 50
+	limit := pageLimit(req.Limit)
+	after, offset, err := codeCursor(req.Cursor, req.Offset)
+	if err != nil {
+		return nil, err
 	}
 
 	rows, err := DB.Query(ctx, `
 	  SELECT id, code, title, COALESCE(jurisdiction,''), COALESCE(category,''), COALESCE(description,'')
-	    FROM dictionary_document ORDER BY code LIMIT $1 OFFSET $2`, limit, req.Offset)
+	    FROM dictionary_document WHERE ($3 = '' OR code > $3)
+	   ORDER BY code LIMIT $1 OFFSET $2`, limit+1, offset, after)
 	if err != nil {
 		return nil, err
 	}
@@ -410,6 +445,10 @@ func (s *OntologyService) ListDocuments(ctx context.Context, req *pb.ListDocumen
 		total = int32(len(list.Documents)) //nolint:gosec
 	}
 	list.TotalCount = total
+	if len(list.Documents) > int(limit) {
+		list.Documents = list.Documents[:limit]
+		list.NextCursor = cursor.Encode(list.Documents[limit-1].Code)
+	}
 	return list, nil
 }
 
@@ -418,7 +457,7 @@ func (s *OntologyService) ListDocuments(ctx context.Context, req *pb.ListDocumen
 // ============================================================================
 
 func (s *OntologyService) GetEntityControlGraph(ctx context.Context, req *pb.GetEntityControlRequest) (*pb.EntityControlGraph, error) {
-	log.Printf("🕸️  GetEntityControlGraph: entity=%s", req.EntityId)
+	logging.FromContext(ctx).Info("🕸️  GetEntityControlGraph", "entity", req.EntityId)
 
 	rows, err := DB.Query(ctx, `
 	  SELECT id, controller_entity_id, controlled_entity_id, control_type::text,
@@ -441,10 +480,130 @@ func (s *OntologyService) GetEntityControlGraph(ctx context.Context, req *pb.Get
 	}
 	graph.TotalEdges = int32(len(graph.Edges)) //nolint:gosec
 
-	log.Printf("✅ Found %d control edges", len(graph.Edges))
+	logging.FromContext(ctx).Info("✅ Found control edges", "count", len(graph.Edges))
 	return graph, nil
 }
 
+// maxChainDepth bounds the recursive control-chain walk
+const maxChainDepth = 20
+
+// controlChainPaths walks entity_control upwards from entity $1 and returns
+// the strongest acyclic path (edge ids, target first) with its cumulative
+// percentage. Paths must reach controller $2, or an ultimate controller when
+// $2 is empty; $3 filters by control_type, defaulting to ownership and control
+// without operational delegation/custody; $4 is the maximum depth.
+const controlChainPaths = `
+	WITH RECURSIVE edges AS (
+	  SELECT id, controller_entity_id, controlled_entity_id,
+	         COALESCE(control_percentage, 0)::float8 AS pct
+	    FROM entity_control
+	   WHERE (end_date IS NULL OR end_date >= CURRENT_DATE)
+	     AND CASE WHEN $3 = '' THEN NOT (control_type = 'OPERATIONAL_CONTROL'
+	                                     AND COALESCE(control_basis, '') IN ('delegates', 'reports_to', 'custodies'))
+	              ELSE control_type::text = $3 END
+	), chain AS (
+	  SELECT e.controller_entity_id AS top, ARRAY[e.id] AS path,
+	         ARRAY[e.controlled_entity_id, e.controller_entity_id] AS visited,
+	         e.pct AS effective, 1 AS depth
+	    FROM edges e WHERE e.controlled_entity_id = $1
+	  UNION ALL
+	  SELECT e.controller_entity_id, c.path || e.id, c.visited || e.controller_entity_id,
+	         c.effective * e.pct / 100, c.depth + 1
+	    FROM chain c JOIN edges e ON e.controlled_entity_id = c.top
+	   WHERE NOT e.controller_entity_id = ANY(c.visited) AND c.depth < $4
+	)
+	SELECT path::text[], effective FROM chain
+	 WHERE CASE WHEN $2 = '' THEN NOT EXISTS (SELECT 1 FROM edges up WHERE up.controlled_entity_id = chain.top)
+	            ELSE top::text = $2 END
+	 ORDER BY effective DESC, depth
+	 LIMIT 1`
+
+// GetControlChain traces the strongest direct or indirect ownership/control
+// path into end_entity_id, from start_entity_id when given or else from an
+// ultimate controller. The chain runs controller first; each link carries the
+// cumulative percentage from its controller down to the target.
+func (s *OntologyService) GetControlChain(ctx context.Context, req *pb.GetControlChainRequest) (*pb.ControlChain, error) {
+	logging.FromContext(ctx).Info("⛓️  GetControlChain", "start", req.StartEntityId, "end", req.EndEntityId,
+		"control_type", req.ControlType)
+
+	if req.EndEntityId == "" {
+		return nil, fmt.Errorf("end_entity_id is required")
+	}
+
+	var path []string
+	var effective float64
+	err := DB.QueryRow(ctx, controlChainPaths, req.EndEntityId, req.StartEntityId, req.ControlType, maxChainDepth).
+		Scan(&path, &effective)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			logging.FromContext(ctx).Info("ℹ️  No control chain found", "end", req.EndEntityId)
+			return &pb.ControlChain{}, nil
+		}
+		return nil, fmt.Errorf("failed to trace control chain: %w", err)
+	}
+
+	rows, err := DB.Query(ctx, `
+	  SELECT id, controller_entity_id, controlled_entity_id, control_type::text,
+	         COALESCE(control_basis,''), COALESCE(control_percentage, 0)::float8,
+	         start_date::text, COALESCE(end_date::text,''), COALESCE(is_indirect, false),
+	         COALESCE(indirect_via_entity_id::text,''), COALESCE(remarks,''),
+	         COALESCE(source_document,''), COALESCE(verified_at::text,''), COALESCE(verified_by,'')
+	    FROM entity_control WHERE id::text = ANY($1)`, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load control chain: %w", err)
+	}
+	defer rows.Close()
+
+	byID := make(map[string]*pb.EntityControl, len(path))
+	for rows.Next() {
+		var edge pb.EntityControl
+		if err := rows.Scan(&edge.Id, &edge.ControllerEntityId, &edge.ControlledEntityId,
+			&edge.ControlType, &edge.ControlBasis, &edge.ControlPercentage,
+			&edge.StartDate, &edge.EndDate, &edge.IsIndirect, &edge.IndirectViaEntityId,
+			&edge.Remarks, &edge.SourceDocument, &edge.VerifiedAt, &edge.VerifiedBy); err != nil {
+			return nil, fmt.Errorf("failed to scan control edge: %w", err)
+		}
+		byID[edge.Id] = &edge
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to load control chain: %w", err)
+	}
+
+	// path runs target first; the chain runs controller first
+	chain := &pb.ControlChain{TotalEffectivePercentage: effective}
+	cumulative := 100.0
+	for _, id := range path {
+		edge, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("control edge %s disappeared while tracing", id)
+		}
+		cumulative = cumulative * edge.ControlPercentage / 100
+		edge.EffectivePercentage = cumulative
+		chain.Chain = append([]*pb.EntityControl{edge}, chain.Chain...)
+	}
+
+	logging.FromContext(ctx).Info("✅ Traced control chain", "links", len(chain.Chain),
+		"effective_pct", chain.TotalEffectivePercentage)
+	return chain, nil
+}
+
+// ============================================================================
+// KYC Profile
+// ============================================================================
+
+func (s *OntologyService) GetKycProfile(ctx context.Context, req *pb.GetKycProfileRequest) (*pb.KycProfile, error) {
+	logging.FromContext(ctx).Info("🛂 GetKycProfile", "entity_id", req.EntityId)
+
+	p, err := loadKycProfile(ctx, req.EntityId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("kyc profile not found: %s", req.EntityId)
+		}
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	return p, nil
+}
+
 // Stub implementations for unimplemented methods
 func (s *OntologyService) CreateEntity(ctx context.Context, req *pb.CreateEntityRequest) (*pb.EntityResponse, error) {
 	return &pb.EntityResponse{Success: false, Error: "not implemented"}, nil
@@ -474,14 +633,6 @@ func (s *OntologyService) CreateControl(ctx context.Context, req *pb.CreateContr
 	return &pb.ControlResponse{Success: false, Error: "not implemented"}, nil
 }
 
-func (s *OntologyService) GetControlChain(ctx context.Context, req *pb.GetControlChainRequest) (*pb.ControlChain, error) {
-	return &pb.ControlChain{}, nil
-}
-
-func (s *OntologyService) GetKycProfile(ctx context.Context, req *pb.GetKycProfileRequest) (*pb.KycProfile, error) {
-	return nil, fmt.Errorf("not implemented")
-}
-
 func (s *OntologyService) UpdateKycProfile(ctx context.Context, req *pb.UpdateKycProfileRequest) (*pb.KycProfileResponse, error) {
 	return &pb.KycProfileResponse{Success: false, Error: "not implemented"}, nil
 }
@@ -0,0 +1,41 @@
+package dataservice
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/adamtc007/KYC-DSL/internal/cursor"
+)
+
+// decodeCursor decodes the cursor of a list request into its n keys (nil for
+// the first page), rejecting a malformed cursor as InvalidArgument
+func decodeCursor(token string, n int) ([]string, error) {
+	keys, err := cursor.Decode(token, n)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return keys, nil
+}
+
+// pageLimit returns the page size of a list request: 50 by default, at
+// most 100
+func pageLimit(limit int32) int32 {
+	if limit <= 0 || limit > 100 {
+		return 50
+	}
+	return limit
+}
+
+// codeCursor returns the code to list after and the offset of a list keyed
+// on code: the cursor's code and no offset, or no code and the request's
+// offset for the first page
+func codeCursor(token string, offset int32) (string, int32, error) {
+	keys, err := decodeCursor(token, 1)
+	if err != nil {
+		return "", 0, err
+	}
+	if keys != nil {
+		return keys[0], 0, nil
+	}
+	return "", offset, nil
+}
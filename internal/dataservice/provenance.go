@@ -0,0 +1,39 @@
+package dataservice
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	pb "github.com/adamtc007/KYC-DSL/api/pb/kycdata"
+	"github.com/adamtc007/KYC-DSL/internal/logging"
+	"github.com/adamtc007/KYC-DSL/internal/provenance"
+)
+
+// provenanceOf looks up the provenance of records by code for a read that
+// sets include_provenance, and sends the ontology version as the
+// x-ontology-version response header. A lookup failure is logged and
+// returns nil, leaving the records without provenance.
+func provenanceOf(ctx context.Context, table string, codes []string) map[string]*pb.Provenance {
+	records, err := provenance.Lookup(ctx, DBX, table, codes)
+	if err != nil {
+		logging.FromContext(ctx).Warn("⚠️  Provenance unavailable", "table", table, "error", err)
+		return nil
+	}
+	byCode := make(map[string]*pb.Provenance, len(records))
+	for code, rec := range records {
+		byCode[code] = &pb.Provenance{
+			SourceTable:     rec.SourceTable,
+			LastModified:    rec.LastModified.Format(time.RFC3339),
+			ModifiedBy:      rec.ModifiedBy,
+			OntologyVersion: rec.OntologyVersion,
+		}
+	}
+	for _, p := range byCode {
+		_ = grpc.SetHeader(ctx, metadata.Pairs("x-ontology-version", p.OntologyVersion))
+		break
+	}
+	return byCode
+}
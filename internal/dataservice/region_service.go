@@ -0,0 +1,46 @@
+package dataservice
+
+import (
+	"context"
+	"github.com/adamtc007/KYC-DSL/internal/logging"
+
+	pb "github.com/adamtc007/KYC-DSL/api/pb/kycdata"
+	"github.com/adamtc007/KYC-DSL/internal/region"
+	"google.golang.org/grpc/metadata"
+)
+
+// RegionService tells clients which regional endpoint to read from and
+// where writes are accepted
+type RegionService struct {
+	pb.UnimplementedRegionServiceServer
+	topology *region.Topology
+}
+
+// NewRegionService creates a RegionService for the given topology
+func NewRegionService(topology *region.Topology) *RegionService {
+	return &RegionService{topology: topology}
+}
+
+// ResolveEndpoints returns the nearest read endpoint for the client's region hint
+func (s *RegionService) ResolveEndpoints(ctx context.Context, req *pb.ResolveEndpointsRequest) (*pb.RegionEndpoints, error) {
+	hint := req.RegionHint
+	if hint == "" {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(region.MetadataKey); len(values) > 0 {
+				hint = values[0]
+			}
+		}
+	}
+
+	readRegion, readAddr := s.topology.Resolve(hint)
+	logging.FromContext(ctx).Info("🌍 ResolveEndpoints", "hint", hint, "read_region", readRegion,
+		"read_addr", readAddr, "primary", s.topology.Primary)
+
+	return &pb.RegionEndpoints{
+		Region:        readRegion,
+		ReadAddress:   readAddr,
+		PrimaryRegion: s.topology.Primary,
+		WriteAddress:  s.topology.PrimaryAddress(),
+		ServerRegion:  s.topology.Self,
+	}, nil
+}
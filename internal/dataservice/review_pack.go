@@ -0,0 +1,45 @@
+package dataservice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/adamtc007/KYC-DSL/api/pb/kycdata"
+	"github.com/adamtc007/KYC-DSL/internal/logging"
+	"github.com/adamtc007/KYC-DSL/internal/reviewpack"
+)
+
+// GenerateReviewPack renders a case version (the latest by default) into a
+// PDF review pack. Versions that are not approved are refused unless
+// allow_draft is set, in which case the pack is marked DRAFT.
+func (s *DataService) GenerateReviewPack(ctx context.Context, req *pb.GenerateReviewPackRequest) (*pb.ReviewPack, error) {
+	logger := logging.FromContext(ctx)
+	logger.Info("📑 GenerateReviewPack", "case_id", req.CaseId, "version_id", req.VersionId, "allow_draft", req.AllowDraft)
+
+	facts, _, err := loadNarrativeFacts(ctx, req.CaseId, req.VersionId)
+	if err != nil {
+		return nil, err
+	}
+	draft := facts.Status != reviewpack.ApprovedStatus
+	if draft && !req.AllowDraft {
+		return nil, fmt.Errorf("case %s version %s is %q, not %s (allow_draft renders a draft pack)",
+			facts.CaseID, facts.VersionID, facts.Status, reviewpack.ApprovedStatus)
+	}
+
+	out, pages := reviewpack.Render(facts)
+
+	logger.Info("✅ Rendered review pack", "case_id", facts.CaseID, "version_id", facts.VersionID,
+		"pages", pages, "bytes", len(out), "draft", draft)
+	return &pb.ReviewPack{
+		CaseId:      facts.CaseID,
+		VersionId:   facts.VersionID,
+		Status:      facts.Status,
+		VersionHash: facts.VersionHash,
+		Filename:    reviewpack.Filename(facts),
+		Pdf:         out,
+		Pages:       int32(pages),
+		Draft:       draft,
+		CreatedAt:   facts.GeneratedAt.Format(time.RFC3339),
+	}, nil
+}
@@ -0,0 +1,84 @@
+package dataservice
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/adamtc007/KYC-DSL/api/pb/kycdata"
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/logging"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/risk"
+)
+
+// ScoreCase scores the latest version of a case, stores the score with its
+// factor breakdown and records the rating as CUSTOMER_RISK_RATING
+func (s *DataService) ScoreCase(ctx context.Context, req *pb.ScoreCaseRequest) (*pb.RiskScore, error) {
+	if s.primaryCases != nil {
+		logging.FromContext(ctx).Info("↪️  ScoreCase: forwarding to primary region", "case_id", req.CaseId)
+		return s.primaryCases.ScoreCase(ctx, req)
+	}
+
+	logging.FromContext(ctx).Info("⚖️  ScoreCase", "case_id", req.CaseId)
+
+	if req.CaseId == "" {
+		return nil, status.Error(codes.InvalidArgument, "case_id is required")
+	}
+	score, err := risk.NewScorer(DBX, config.Current().Risk).Score(ctx, req.CaseId)
+	if err != nil {
+		return nil, riskStatus(err)
+	}
+	return riskScoreToProto(score), nil
+}
+
+// GetRiskScore returns the stored score of a case's latest scored version
+func (s *DataService) GetRiskScore(ctx context.Context, req *pb.GetRiskScoreRequest) (*pb.RiskScore, error) {
+	logging.FromContext(ctx).Info("⚖️  GetRiskScore", "case_id", req.CaseId)
+
+	if req.CaseId == "" {
+		return nil, status.Error(codes.InvalidArgument, "case_id is required")
+	}
+	score, err := risk.NewScorer(DBX, config.Current().Risk).Latest(ctx, req.CaseId)
+	if err != nil {
+		return nil, riskStatus(err)
+	}
+	return riskScoreToProto(score), nil
+}
+
+func riskStatus(err error) error {
+	if errors.Is(err, risk.ErrCaseNotFound) || errors.Is(err, risk.ErrNotScored) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return err
+}
+
+func riskScoreToProto(score *model.RiskScore) *pb.RiskScore {
+	out := &pb.RiskScore{
+		Id:          score.ID,
+		CaseId:      score.CaseName,
+		CaseVersion: int32(score.CaseVersion), //nolint:gosec
+		Score:       score.Score,
+		Rating:      score.Rating,
+		Actor:       score.Actor,
+		ScoredAt:    score.ScoredAt.Format(time.RFC3339),
+	}
+	for _, f := range score.Factors {
+		factor := &pb.RiskFactor{
+			Attribute:    f.Attribute,
+			Value:        f.Value,
+			Weight:       f.Weight,
+			Contribution: f.Contribution,
+			Source:       f.Source,
+			Note:         f.Note,
+		}
+		if f.EvaluationID != nil {
+			factor.EvaluationId = *f.EvaluationID
+		}
+		out.Factors = append(out.Factors, factor)
+	}
+	return out
+}
@@ -0,0 +1,39 @@
+package dataservice
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	pb "github.com/adamtc007/KYC-DSL/api/pb/kycdata"
+)
+
+// fakePrimaryCases stands in for the primary region's CaseService; calls
+// it does not override panic through the nil embedded client
+type fakePrimaryCases struct {
+	pb.CaseServiceClient
+	scored []string
+}
+
+func (f *fakePrimaryCases) ScoreCase(ctx context.Context, req *pb.ScoreCaseRequest, _ ...grpc.CallOption) (*pb.RiskScore, error) {
+	f.scored = append(f.scored, req.CaseId)
+	return &pb.RiskScore{CaseId: req.CaseId, Rating: "HIGH"}, nil
+}
+
+func TestScoreCaseForwardsToPrimary(t *testing.T) {
+	primary := &fakePrimaryCases{}
+	s := NewDataService()
+	s.ForwardWritesTo(primary)
+
+	score, err := s.ScoreCase(context.Background(), &pb.ScoreCaseRequest{CaseId: "CASE-1"})
+	if err != nil {
+		t.Fatalf("ScoreCase: %v", err)
+	}
+	if score.Rating != "HIGH" {
+		t.Errorf("rating = %q, want the primary's HIGH", score.Rating)
+	}
+	if len(primary.scored) != 1 || primary.scored[0] != "CASE-1" {
+		t.Errorf("primary scored %v, want [CASE-1]", primary.scored)
+	}
+}
@@ -0,0 +1,46 @@
+package dataservice
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/adamtc007/KYC-DSL/api/pb/kycontology"
+	"github.com/adamtc007/KYC-DSL/internal/lineage"
+	"github.com/adamtc007/KYC-DSL/internal/lists"
+	"github.com/adamtc007/KYC-DSL/internal/logging"
+)
+
+// ValidateRule checks a candidate derivation rule against the attribute
+// types and managed lists in effect, reporting compile errors, type
+// mismatches and unknown names before the derivation is stored
+func (s *OntologyService) ValidateRule(ctx context.Context, req *pb.ValidateRuleRequest) (*pb.RuleValidation, error) {
+	logging.FromContext(ctx).Info("🧪 ValidateRule", "rule", req.Rule, "rule_type", req.RuleType)
+
+	if strings.TrimSpace(req.Rule) == "" {
+		return nil, status.Error(codes.InvalidArgument, "rule is required")
+	}
+	types, err := lineage.AttributeTypes(ctx, DBX)
+	if err != nil {
+		return nil, err
+	}
+	inEffect, err := lists.NewRepo(DBX).InEffect(ctx, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	v := lineage.ValidateRule(req.Rule, types, inEffect, req.SourceAttributes, req.RuleType)
+	return &pb.RuleValidation{
+		Rule:              v.Rule,
+		Valid:             v.Valid,
+		ResultType:        v.ResultType,
+		CompileErrors:     v.CompileErrors,
+		TypeErrors:        v.TypeErrors,
+		UnknownAttributes: v.UnknownAttributes,
+		UnknownLists:      v.UnknownLists,
+		UndeclaredSources: v.UndeclaredSources,
+	}, nil
+}
@@ -0,0 +1,71 @@
+package dataservice
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/adamtc007/KYC-DSL/api/pb/kycontology"
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/logging"
+	"github.com/adamtc007/KYC-DSL/internal/screening"
+)
+
+// ScreenEntity screens an entity, and optionally its UBOs, for sanctions,
+// PEPs or adverse media, records the hits and, given a case, writes the
+// outcome to it as SANCTIONS_SCREENING_STATUS, PEP_STATUS or
+// ADVERSE_MEDIA_FLAG
+func (s *OntologyService) ScreenEntity(ctx context.Context, req *pb.ScreenEntityRequest) (*pb.Screening, error) {
+	if s.primary != nil {
+		logging.FromContext(ctx).Info("↪️  ScreenEntity: forwarding to primary region", "entity_id", req.EntityId, "case_id", req.CaseId)
+		return s.primary.ScreenEntity(ctx, req)
+	}
+
+	logging.FromContext(ctx).Info("🛡️  ScreenEntity", "kind", req.Kind, "entity_id", req.EntityId, "case_id", req.CaseId, "include_ubos", req.IncludeUbos)
+
+	if req.EntityId == "" {
+		return nil, status.Error(codes.InvalidArgument, "entity_id is required")
+	}
+	kind, err := screening.ParseKind(req.Kind)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	result, err := screening.NewScreener(DBX, config.Current().Screening).ScreenEntity(ctx, kind, req.EntityId, req.CaseId, req.IncludeUbos)
+	if errors.Is(err, screening.ErrEntityNotFound) || errors.Is(err, screening.ErrCaseNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	out := &pb.Screening{
+		Id:         int32(result.ID), //nolint:gosec
+		Kind:       result.Kind,
+		EntityId:   result.EntityID,
+		EntityName: result.EntityName,
+		CaseId:     result.CaseName,
+		Status:     result.Status,
+		Subjects:   int32(result.Subjects), //nolint:gosec
+		Providers:  result.Providers,
+		Errors:     result.Errors,
+		Actor:      result.Actor,
+		ScreenedAt: result.ScreenedAt.Format(time.RFC3339),
+	}
+	for _, h := range result.Hits {
+		out.Hits = append(out.Hits, &pb.ScreeningHit{
+			SubjectEntityId: h.SubjectEntityID,
+			SubjectName:     h.SubjectName,
+			Provider:        h.Provider,
+			Kind:            h.Kind,
+			Reference:       h.Reference,
+			MatchedName:     h.MatchedName,
+			Score:           h.Score,
+			Detail:          h.Detail,
+			Url:             h.URL,
+		})
+	}
+	return out, nil
+}
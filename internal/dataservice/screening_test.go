@@ -0,0 +1,38 @@
+package dataservice
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	pb "github.com/adamtc007/KYC-DSL/api/pb/kycontology"
+)
+
+// fakePrimaryOntology stands in for the primary region's OntologyService
+type fakePrimaryOntology struct {
+	pb.OntologyServiceClient
+	screened []string
+}
+
+func (f *fakePrimaryOntology) ScreenEntity(ctx context.Context, req *pb.ScreenEntityRequest, _ ...grpc.CallOption) (*pb.Screening, error) {
+	f.screened = append(f.screened, req.EntityId)
+	return &pb.Screening{EntityId: req.EntityId, Status: "CLEAR"}, nil
+}
+
+func TestScreenEntityForwardsToPrimary(t *testing.T) {
+	primary := &fakePrimaryOntology{}
+	s := NewOntologyService()
+	s.ForwardWritesTo(primary)
+
+	result, err := s.ScreenEntity(context.Background(), &pb.ScreenEntityRequest{Kind: "sanctions", EntityId: "ENT-1"})
+	if err != nil {
+		t.Fatalf("ScreenEntity: %v", err)
+	}
+	if result.Status != "CLEAR" {
+		t.Errorf("status = %q, want the primary's CLEAR", result.Status)
+	}
+	if len(primary.screened) != 1 || primary.screened[0] != "ENT-1" {
+		t.Errorf("primary screened %v, want [ENT-1]", primary.screened)
+	}
+}
@@ -0,0 +1,102 @@
+package dataservice
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+)
+
+// Entity and attribute search match names and codes by pg_trgm word
+// similarity (the query against the closest-matching words) and
+// descriptions by full-text search, so each predicate can use the GIN
+// indexes of migration 034 instead of scanning. The <% operator compares
+// against pg_trgm.word_similarity_threshold, which each search sets for its
+// own transaction. $1 is the query, $2 the limit and $3 the offset.
+const (
+	entitySearchSQL = `
+	  SELECT id, name, entity_type, COALESCE(legal_form,''), jurisdiction,
+	         COALESCE(registration_number,''), COALESCE(lei_code,''), status, COALESCE(description,''),
+	         GREATEST(word_similarity($1, name), word_similarity($1, COALESCE(lei_code,'')),
+	                  ts_rank(to_tsvector('simple', COALESCE(description,'')), plainto_tsquery('simple', $1)))::float8 AS match_rank
+	    FROM entity
+	   WHERE $1 <% name
+	      OR $1 <% lei_code
+	      OR to_tsvector('simple', COALESCE(description,'')) @@ plainto_tsquery('simple', $1)
+	   ORDER BY match_rank DESC, name LIMIT $2 OFFSET $3`
+
+	attributeSearchSQL = `
+	  SELECT id, code, name, COALESCE(description,''), attr_type,
+	         COALESCE(jurisdiction,''), COALESCE(sink_table,''),
+	         COALESCE(sink_column,''), COALESCE(source_priority::text,'{}'),
+	         GREATEST(word_similarity($1, name), word_similarity($1, code),
+	                  ts_rank(to_tsvector('simple', COALESCE(description,'')), plainto_tsquery('simple', $1)))::float8 AS match_rank
+	    FROM dictionary_attribute
+	   WHERE $1 <% name
+	      OR $1 <% code
+	      OR to_tsvector('simple', COALESCE(description,'')) @@ plainto_tsquery('simple', $1)
+	   ORDER BY match_rank DESC, code LIMIT $2 OFFSET $3`
+
+	setThresholdSQL = `SELECT set_config('pg_trgm.word_similarity_threshold', $1, true)`
+)
+
+// matchThreshold is the minimum word similarity of a search: the request's
+// similarity_threshold when set, otherwise search.match_threshold
+func matchThreshold(requested float64) float64 {
+	if requested > 0 && requested <= 1 {
+		return requested
+	}
+	return config.Current().Search.MatchThreshold
+}
+
+// searchTx begins the read-only transaction of a search with its match
+// threshold set
+func searchTx(ctx context.Context, threshold float64) (pgx.Tx, error) {
+	tx, err := DB.BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(ctx, setThresholdSQL, strconv.FormatFloat(threshold, 'f', -1, 64)); err != nil {
+		tx.Rollback(ctx) //nolint:errcheck
+		return nil, fmt.Errorf("failed to set match threshold: %w", err)
+	}
+	return tx, nil
+}
+
+// ExplainSearch returns the query plan of an entity or attribute search, to
+// check that it uses the trigram and full-text indexes. With analyze the
+// search is run and the plan includes actual row counts and timings.
+func ExplainSearch(ctx context.Context, db *sqlx.DB, kind, query string, threshold float64, analyze bool) ([]string, error) {
+	var search string
+	switch kind {
+	case "entities":
+		search = entitySearchSQL
+	case "attributes":
+		search = attributeSearchSQL
+	default:
+		return nil, fmt.Errorf("unknown search %q (expected entities or attributes)", kind)
+	}
+	explain := "EXPLAIN (COSTS)"
+	if analyze {
+		explain = "EXPLAIN (ANALYZE, BUFFERS)"
+	}
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if _, err := tx.ExecContext(ctx, setThresholdSQL, strconv.FormatFloat(matchThreshold(threshold), 'f', -1, 64)); err != nil {
+		return nil, fmt.Errorf("failed to set match threshold: %w", err)
+	}
+	var plan []string
+	if err := tx.SelectContext(ctx, &plan, explain+search, query, 20, 0); err != nil {
+		return nil, fmt.Errorf("failed to explain %s search: %w", kind, err)
+	}
+	return plan, nil
+}
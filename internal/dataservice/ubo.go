@@ -0,0 +1,46 @@
+package dataservice
+
+import (
+	"context"
+
+	pb "github.com/adamtc007/KYC-DSL/api/pb/kycontology"
+	"github.com/adamtc007/KYC-DSL/internal/graph"
+	"github.com/adamtc007/KYC-DSL/internal/logging"
+)
+
+// ComputeUbo rolls up effective ownership of an entity per ultimate owner and
+// flags the owners at or above the UBO threshold
+func (s *OntologyService) ComputeUbo(ctx context.Context, req *pb.ComputeUboRequest) (*pb.UboRollup, error) {
+	logging.FromContext(ctx).Info("🧮 ComputeUbo", "entity_id", req.EntityId, "threshold", req.Threshold)
+
+	rollup, err := graph.NewRepo(DBX).ComputeUbo(ctx, req.EntityId, req.Threshold)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &pb.UboRollup{
+		EntityId:               rollup.EntityID,
+		Threshold:              rollup.Threshold,
+		AttributedPercentage:   rollup.AttributedPercent,
+		UnattributedPercentage: rollup.UnattributedPercent,
+		Truncated:              rollup.Truncated,
+	}
+	for _, o := range rollup.Owners {
+		owner := &pb.UboOwner{
+			EntityId:            o.ID,
+			Name:                o.Name,
+			EntityType:          o.EntityType,
+			EffectivePercentage: o.EffectivePercent,
+			IsUbo:               o.IsUBO,
+			Circular:            o.Circular,
+		}
+		for _, p := range o.Paths {
+			owner.Paths = append(owner.Paths, &pb.OwnershipPath{EntityIds: p.EntityIDs, Percentage: p.Percent})
+		}
+		out.Owners = append(out.Owners, owner)
+	}
+
+	logging.FromContext(ctx).Info("✅ Computed UBO rollup", "owners", len(out.Owners),
+		"ubos", len(rollup.UBOs()), "unattributed_pct", out.UnattributedPercentage)
+	return out, nil
+}
@@ -0,0 +1,105 @@
+// Package db owns the PostgreSQL connection pool shared by every component
+// of a process. The pool is a pgxpool; code written against sqlx (storage,
+// ontology, rag) gets a database/sql handle backed by the same connections
+// through SQLX, so a process never holds two pools to one database.
+package db
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/exaring/otelpgx"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+)
+
+// connectTimeout bounds pool creation and the initial ping
+const connectTimeout = 10 * time.Second
+
+// Pool is a pgx connection pool with sqlx compatibility
+type Pool struct {
+	*pgxpool.Pool
+	describe string
+}
+
+// Open creates a pool sized by cfg and verifies the database answers
+func Open(ctx context.Context, cfg config.DatabaseConfig) (*Pool, error) {
+	poolCfg, err := pgxpool.ParseConfig(cfg.PostgresURL())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse database config (%s): %w", cfg.Describe(), err)
+	}
+
+	poolCfg.MaxConns = int32(cfg.MaxConns)
+	poolCfg.MinConns = int32(cfg.MinConns)
+	poolCfg.MaxConnLifetime = cfg.MaxConnLifetime
+	poolCfg.MaxConnIdleTime = cfg.MaxConnIdleTime
+	poolCfg.HealthCheckPeriod = cfg.HealthCheckPeriod
+	// otelpgx emits a span per query, parented to the caller's trace context
+	poolCfg.ConnConfig.Tracer = otelpgx.NewTracer()
+
+	ctx, cancel := context.WithTimeout(ctx, connectTimeout)
+	defer cancel()
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create connection pool (%s): %w", cfg.Describe(), err)
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("postgres ping failed (%s): %w", cfg.Describe(), err)
+	}
+
+	slog.Info("✅ Connected to PostgreSQL", "target", cfg.Describe(),
+		"max_conns", poolCfg.MaxConns, "min_conns", poolCfg.MinConns)
+	return &Pool{Pool: pool, describe: cfg.Describe()}, nil
+}
+
+// SQLX returns a database/sql handle that borrows connections from the
+// pool. Closing the handle does not close the pool.
+func (p *Pool) SQLX() *sqlx.DB {
+	return sqlx.NewDb(stdlib.OpenDBFromPool(p.Pool), "pgx")
+}
+
+// String describes the pool target without credentials
+func (p *Pool) String() string {
+	return p.describe
+}
+
+var (
+	sharedMu sync.Mutex
+	shared   *Pool
+)
+
+// Shared returns the process-wide pool, opening it from the database section
+// of config.Current on first use
+func Shared(ctx context.Context) (*Pool, error) {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+
+	if shared == nil {
+		pool, err := Open(ctx, config.Current().Database)
+		if err != nil {
+			return nil, err
+		}
+		shared = pool
+	}
+	return shared, nil
+}
+
+// CloseShared closes the process-wide pool if it was opened
+func CloseShared() {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+
+	if shared != nil {
+		shared.Close()
+		shared = nil
+		slog.Info("🔒 Database connection pool closed")
+	}
+}
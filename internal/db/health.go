@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// healthTimeout bounds a health check ping
+const healthTimeout = 2 * time.Second
+
+// Health is the state of a pool as reported by health endpoints
+type Health struct {
+	Status        string `json:"status"` // "up" or "down"
+	LatencyMs     int64  `json:"latency_ms"`
+	TotalConns    int32  `json:"total_conns"`
+	IdleConns     int32  `json:"idle_conns"`
+	AcquiredConns int32  `json:"acquired_conns"`
+	MaxConns      int32  `json:"max_conns"`
+	Error         string `json:"error,omitempty"`
+}
+
+// OK reports whether the database answered
+func (h Health) OK() bool {
+	return h.Status == "up"
+}
+
+// Check pings the database and reports pool statistics
+func (p *Pool) Check(ctx context.Context) Health {
+	ctx, cancel := context.WithTimeout(ctx, healthTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := p.Ping(ctx)
+	stat := p.Stat()
+
+	h := Health{
+		Status:        "up",
+		LatencyMs:     time.Since(start).Milliseconds(),
+		TotalConns:    stat.TotalConns(),
+		IdleConns:     stat.IdleConns(),
+		AcquiredConns: stat.AcquiredConns(),
+		MaxConns:      stat.MaxConns(),
+	}
+	if err != nil {
+		h.Status = "down"
+		h.Error = err.Error()
+	}
+	return h
+}
+
+// HealthCheck returns an error when the database does not answer a ping
+func (p *Pool) HealthCheck(ctx context.Context) error {
+	if h := p.Check(ctx); !h.OK() {
+		return fmt.Errorf("database unhealthy (%s): %s", p.describe, h.Error)
+	}
+	return nil
+}
+
+// CheckShared reports the health of the process-wide pool without opening it
+func CheckShared(ctx context.Context) Health {
+	sharedMu.Lock()
+	pool := shared
+	sharedMu.Unlock()
+
+	if pool == nil {
+		return Health{Status: "down", Error: "database pool not initialized"}
+	}
+	return pool.Check(ctx)
+}
@@ -0,0 +1,438 @@
+// Package demo provisions a self-contained demo dataset for sales and
+// training environments: the bundled ontology, attribute clusters, a
+// BlackRock-style CBU with its ownership and control graph, cases at
+// different workflow stages with version and validation history, and
+// synthetic agent feedback and search audit entries.
+//
+// Everything it creates is marked (DEMO- case and CBU codes, demo entity
+// metadata, the demo session) so Down removes exactly the demo data. The
+// ontology and clusters are reference data and are left in place.
+package demo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/adamtc007/KYC-DSL/internal/clustering"
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/ontology"
+	"github.com/adamtc007/KYC-DSL/internal/rag"
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+const (
+	// Prefix marks demo case names and the demo CBU code
+	Prefix = "DEMO-"
+	// Session marks demo feedback and audit log entries
+	Session = "demo"
+	// embedBatch is the number of texts per embedding request
+	embedBatch = 50
+)
+
+// Report summarizes what Up created or Down removed
+type Report struct {
+	SeedsApplied []string `json:"seeds_applied"`
+	Embedded     int      `json:"embedded"`
+	Clusters     int      `json:"clusters"`
+	Entities     int      `json:"entities"`
+	CBU          string   `json:"cbu"`
+	Cases        int      `json:"cases"`
+	Versions     int      `json:"versions"`
+	Validations  int      `json:"validations"`
+	Feedback     int      `json:"feedback"`
+	Searches     int      `json:"searches"`
+	// Skipped explains steps that did not run
+	Skipped []string `json:"skipped,omitempty"`
+}
+
+// Up removes any previous demo data and provisions the scenario. Embeddings
+// and clusters need embedder; with a nil embedder they are skipped and the
+// clusters shipped with the migrations remain.
+func Up(ctx context.Context, db *sqlx.DB, embedder *rag.Embedder) (*Report, error) {
+	report := &Report{CBU: cbuCode}
+
+	if _, err := Down(ctx, db); err != nil {
+		return report, err
+	}
+
+	for _, s := range ontology.Seeds {
+		applied, err := ontology.ApplySeed(ctx, db, s)
+		if err != nil {
+			return report, err
+		}
+		if applied {
+			report.SeedsApplied = append(report.SeedsApplied, s.File)
+		} else {
+			report.Skipped = append(report.Skipped, fmt.Sprintf("%s: %s already populated", s.File, s.Table))
+		}
+	}
+
+	if embedder == nil {
+		report.Skipped = append(report.Skipped, "embeddings and clusters: no OpenAI API key")
+	} else {
+		n, err := embedMissing(ctx, db, embedder)
+		report.Embedded = n
+		if err != nil {
+			return report, err
+		}
+		plan, err := clustering.NewJob(db, config.Current().Clustering).RunOnce(ctx, "demo")
+		if err != nil {
+			report.Skipped = append(report.Skipped, "clusters: "+err.Error())
+		} else {
+			report.Clusters = len(plan.Clusters)
+		}
+	}
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return report, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if err := provisionCBU(ctx, tx, report); err != nil {
+		return report, err
+	}
+	if err := provisionCases(ctx, tx, report); err != nil {
+		return report, err
+	}
+	if err := provisionHistory(ctx, tx, report); err != nil {
+		return report, err
+	}
+	if err := tx.Commit(); err != nil {
+		return report, fmt.Errorf("failed to commit demo data: %w", err)
+	}
+
+	slog.Info("🎬 Demo environment provisioned", "cbu", report.CBU, "cases", report.Cases,
+		"feedback", report.Feedback, "embedded", report.Embedded, "clusters", report.Clusters)
+	return report, nil
+}
+
+// Down removes the demo data; it is a no-op when none exists
+func Down(ctx context.Context, db *sqlx.DB) (*Report, error) {
+	report := &Report{CBU: cbuCode}
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return report, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	exec := func(counter *int, query string, arg any) error {
+		res, err := tx.ExecContext(ctx, query, arg)
+		if err != nil {
+			return fmt.Errorf("failed to remove demo data: %w", err)
+		}
+		if counter != nil {
+			n, _ := res.RowsAffected()
+			*counter += int(n)
+		}
+		return nil
+	}
+
+	casePattern := Prefix + "%"
+	steps := []struct {
+		counter *int
+		query   string
+		arg     any
+	}{
+		{&report.Feedback, `DELETE FROM rag_feedback WHERE session_id = $1`, Session},
+		{&report.Searches, `DELETE FROM rag_audit_log WHERE session_id = $1`, Session},
+		{&report.Validations, `DELETE FROM kyc_case_validations WHERE case_name LIKE $1`, casePattern},
+		{nil, `DELETE FROM kyc_lineage_evaluations WHERE case_name LIKE $1`, casePattern},
+		{nil, `DELETE FROM kyc_lineage_runs WHERE case_name LIKE $1`, casePattern},
+		{nil, `DELETE FROM kyc_reevaluation_queue WHERE case_name LIKE $1`, casePattern},
+		{nil, `DELETE FROM kyc_case_data_dictionary WHERE case_name LIKE $1`, casePattern},
+		{nil, `DELETE FROM kyc_case_attribute_values WHERE case_name LIKE $1`, casePattern},
+		{nil, `DELETE FROM kyc_risk_scores WHERE case_name LIKE $1`, casePattern},
+		{nil, `DELETE FROM kyc_screenings WHERE case_name LIKE $1`, casePattern},
+		{nil, `DELETE FROM kyc_evidence WHERE case_name LIKE $1`, casePattern},
+		{nil, `DELETE FROM kyc_case_amendments WHERE case_name LIKE $1`, casePattern},
+		{nil, `DELETE FROM kyc_case_transitions WHERE case_name LIKE $1`, casePattern},
+		{nil, `DELETE FROM kyc_case_assignment_history WHERE case_name LIKE $1`, casePattern},
+		{nil, `DELETE FROM kyc_case_assignments WHERE case_name LIKE $1`, casePattern},
+		{&report.Versions, `DELETE FROM kyc_case_versions WHERE case_name LIKE $1`, casePattern},
+		{nil, `DELETE FROM case_versions WHERE case_id LIKE $1`, casePattern},
+		{&report.Cases, `DELETE FROM kyc_cases WHERE name LIKE $1`, casePattern},
+		{nil, `DELETE FROM cbu WHERE code = $1`, cbuCode},
+		{&report.Entities, `DELETE FROM entity WHERE metadata->>'demo' = $1`, "true"},
+	}
+	for _, s := range steps {
+		if err := exec(s.counter, s.query, s.arg); err != nil {
+			return report, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return report, fmt.Errorf("failed to commit demo removal: %w", err)
+	}
+	return report, nil
+}
+
+// embedMissing embeds every ontology entry without an embedding
+func embedMissing(ctx context.Context, db *sqlx.DB, embedder *rag.Embedder) (int, error) {
+	repo := ontology.NewBackfillRepo(db)
+	embedded := 0
+	for _, kind := range ontology.BackfillKinds {
+		targets, err := repo.ListPending(ctx, kind, "")
+		if err != nil {
+			return embedded, err
+		}
+		for start := 0; start < len(targets); start += embedBatch {
+			batch := targets[start:min(start+embedBatch, len(targets))]
+			texts := make([]string, len(batch))
+			for i, t := range batch {
+				texts[i] = t.Text
+			}
+			embeddings, err := embedder.GenerateEmbeddingsFromTexts(ctx, texts)
+			if err != nil {
+				return embedded, fmt.Errorf("failed to embed demo %s: %w", kind, err)
+			}
+			for i, t := range batch {
+				if err := repo.SaveEmbedding(ctx, kind, t.Code, ontology.ContentHash(t.Text), embeddings[i]); err != nil {
+					return embedded, err
+				}
+				embedded++
+			}
+		}
+	}
+	return embedded, nil
+}
+
+// provisionCBU creates the demo entities, the CBU, its roles and the
+// ownership and control edges between the entities
+func provisionCBU(ctx context.Context, tx *sqlx.Tx, report *Report) error {
+	ids := make(map[string]string, len(entities))
+	for _, e := range entities {
+		meta, _ := json.Marshal(map[string]any{"demo": true, "key": e.Key})
+		var id string
+		err := tx.GetContext(ctx, &id, `
+			INSERT INTO entity (name, entity_type, legal_form, jurisdiction, status, description, metadata)
+			VALUES ($1, $2, NULLIF($3, ''), $4, 'ACTIVE', 'Demo entity', $5::jsonb)
+			RETURNING id`, e.Name, e.Type, e.LegalForm, e.Jurisdiction, string(meta))
+		if err != nil {
+			return fmt.Errorf("failed to create demo entity %s: %w", e.Key, err)
+		}
+		ids[e.Key] = id
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO entity_kyc_profile (entity_id, risk_rating, kyc_status, last_review_date, next_review_date,
+				sanctions_check_status, pep_status, adverse_media_status)
+			VALUES ($1, $2, $3, CURRENT_DATE - 30, CURRENT_DATE + 335, 'CLEAR', $4, 'CLEAR')`,
+			id, e.Risk, e.KYCStatus, e.PEP)
+		if err != nil {
+			return fmt.Errorf("failed to create KYC profile of %s: %w", e.Key, err)
+		}
+		report.Entities++
+	}
+
+	var cbuID string
+	err := tx.GetContext(ctx, &cbuID, `
+		INSERT INTO cbu (name, code, sponsor_entity_id, domicile, description, status, metadata)
+		VALUES ('BlackRock Global Funds (Demo)', $1, $2, 'LU',
+			'Demo Luxembourg SICAV umbrella with its management company, delegates and service providers',
+			'ACTIVE', '{"demo": true}'::jsonb)
+		RETURNING id`, cbuCode, ids["PARENT"])
+	if err != nil {
+		return fmt.Errorf("failed to create demo CBU: %w", err)
+	}
+
+	for _, r := range roles {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO cbu_role (cbu_id, entity_id, role_type_id, start_date, jurisdiction, is_primary)
+			SELECT $1, $2, id, CURRENT_DATE - 365, $4, $5 FROM role_type WHERE code = $3`,
+			cbuID, ids[r.Entity], r.Role, "LU", r.Primary)
+		if err != nil {
+			return fmt.Errorf("failed to assign %s role %s: %w", r.Entity, r.Role, err)
+		}
+	}
+
+	for _, c := range controls {
+		var pct *float64
+		if c.Percentage > 0 {
+			pct = &c.Percentage
+		}
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO entity_control (controller_entity_id, controlled_entity_id, control_type, control_basis,
+				control_percentage, effective_percentage, start_date, verified_at, verified_by)
+			VALUES ($1, $2, $3::control_type, $4, $5, $5, CURRENT_DATE - 365, NOW(), 'demo')`,
+			ids[c.Controller], ids[c.Controlled], c.Type, c.Basis, pct)
+		if err != nil {
+			return fmt.Errorf("failed to link %s to %s: %w", c.Controller, c.Controlled, err)
+		}
+	}
+	return nil
+}
+
+// provisionCases stores each case with one version per workflow stage it
+// went through, the matching amendments and its latest validation
+func provisionCases(ctx context.Context, tx *sqlx.Tx, report *Report) error {
+	for _, c := range cases {
+		// Lifecycle states the case went through (internal/engine)
+		lifecycle := []string{"draft"}
+		switch c.Outcome {
+		case "approve":
+			lifecycle = append(lifecycle, "validated", "in-review", "approved")
+		case "decline":
+			lifecycle = append(lifecycle, "validated", "in-review", "declined")
+		case "review":
+			lifecycle = append(lifecycle, "validated", "in-review")
+		}
+		status := lifecycle[len(lifecycle)-1]
+
+		// Stages are spread over the life of the case, newest last
+		ageOf := func(step int) float64 {
+			steps := c.Stage + 1
+			if c.Outcome != "" {
+				steps++
+			}
+			return float64(c.AgeDays) * float64(steps-step) / float64(steps)
+		}
+
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO kyc_cases (name, version, status, last_updated)
+			VALUES ($1, $2, $3, NOW() - make_interval(days => $4))`,
+			c.Name, c.Stage+1, status, int(ageOf(c.Stage)))
+		if err != nil {
+			return fmt.Errorf("failed to create demo case %s: %w", c.Name, err)
+		}
+		report.Cases++
+
+		// The case was created with its first stage and moved on after its last
+		for i, to := range lifecycle {
+			var from interface{}
+			age := ageOf(0)
+			if i > 0 {
+				from = lifecycle[i-1]
+				age = ageOf(c.Stage) * float64(len(lifecycle)-i) / float64(len(lifecycle))
+			}
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO kyc_case_transitions (case_name, from_status, to_status, actor, reason, created_at)
+				VALUES ($1, $2, $3, 'demo', 'demo case history', NOW() - make_interval(secs => $4))`,
+				c.Name, from, to, age*86400)
+			if err != nil {
+				return fmt.Errorf("failed to record %s transition of %s: %w", to, c.Name, err)
+			}
+		}
+
+		version := 0
+		addVersion := func(step string, dsl, previous string, age float64) error {
+			version++
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO kyc_case_versions (case_name, version, dsl_snapshot, hash, created_at)
+				VALUES ($1, $2, $3, $4, NOW() - make_interval(secs => $5))`,
+				c.Name, version, dsl, storage.CanonicalHash(dsl), age*86400)
+			if err != nil {
+				return fmt.Errorf("failed to store %s version %d: %w", c.Name, version, err)
+			}
+			report.Versions++
+			if previous == "" {
+				return nil
+			}
+			_, err = tx.ExecContext(ctx, `
+				INSERT INTO kyc_case_amendments (case_name, step, change_type, diff, created_at)
+				VALUES ($1, $2, $2, $3, NOW() - make_interval(secs => $4))`,
+				c.Name, step, "- "+previous+"\n+ "+dsl, age*86400)
+			if err != nil {
+				return fmt.Errorf("failed to log %s amendment of %s: %w", step, c.Name, err)
+			}
+			return nil
+		}
+
+		previous := ""
+		for stage := 0; stage <= c.Stage; stage++ {
+			dsl := c.dsl(stage, false)
+			if err := addVersion(stages[stage], dsl, previous, ageOf(stage)); err != nil {
+				return err
+			}
+			previous = dsl
+		}
+		if c.Outcome != "" {
+			if err := addVersion(c.Outcome, c.dsl(c.Stage, true), previous, 0); err != nil {
+				return err
+			}
+		}
+
+		if len(c.Findings) == 0 {
+			continue
+		}
+		if err := recordValidation(ctx, tx, c, version); err != nil {
+			return err
+		}
+		report.Validations++
+	}
+	return nil
+}
+
+// recordValidation stores the latest validation of a case with its findings
+func recordValidation(ctx context.Context, tx *sqlx.Tx, c demoCase, version int) error {
+	passed, failed := 0, 0
+	for _, f := range c.Findings {
+		if f.Status == "FAIL" {
+			failed++
+		} else {
+			passed++
+		}
+	}
+	status, message := "PASS", ""
+	if failed > 0 {
+		status, message = "FAIL", fmt.Sprintf("%d check(s) failed", failed)
+	}
+
+	var id int
+	err := tx.GetContext(ctx, &id, `
+		INSERT INTO kyc_case_validations
+			(case_name, version, validation_time, grammar_version, ontology_version, validator_actor,
+			 validation_status, error_message, total_checks, passed_checks, failed_checks, metadata)
+		VALUES ($1, $2, NOW() - interval '1 hour', '1.0', '1.0', 'Agent:demo-validator',
+			$3, NULLIF($4, ''), $5, $6, $7, '{"demo": true}'::jsonb)
+		RETURNING id`,
+		c.Name, version, status, message, len(c.Findings), passed, failed)
+	if err != nil {
+		return fmt.Errorf("failed to record validation of %s: %w", c.Name, err)
+	}
+	for _, f := range c.Findings {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO kyc_validation_findings
+				(validation_id, check_type, check_name, check_status, check_message, entity_ref, severity)
+			VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7)`,
+			id, f.Type, f.Name, f.Status, f.Message, f.Ref, f.Severity)
+		if err != nil {
+			return fmt.Errorf("failed to record finding %s of %s: %w", f.Name, c.Name, err)
+		}
+	}
+	return nil
+}
+
+// provisionHistory adds synthetic agent feedback and search audit entries
+func provisionHistory(ctx context.Context, tx *sqlx.Tx, report *Report) error {
+	for _, f := range feedback {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO rag_feedback (query_text, attribute_code, feedback, confidence, agent_name, agent_type,
+				session_id, notes, created_at)
+			SELECT $1, code, $3, $4, $5, 'demo', $6, 'Synthetic demo feedback',
+				NOW() - make_interval(days => $7)
+			FROM kyc_attributes WHERE code = $2`,
+			f.Query, f.Attribute, f.Sentiment, f.Confidence, f.Agent, Session, f.AgeDays)
+		if err != nil {
+			return fmt.Errorf("failed to add demo feedback: %w", err)
+		}
+		report.Feedback++
+	}
+
+	for _, s := range searches {
+		response, _ := json.Marshal(map[string]any{"query": s.Query, "count": s.Results, "demo": true})
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO rag_audit_log (query_text, response, result_count, agent_name, session_id, endpoint,
+				latency_ms, created_at)
+			VALUES ($1, $2::jsonb, $3, $4, $5, $6, $7, NOW() - make_interval(days => $8))`,
+			s.Query, string(response), s.Results, s.Agent, Session, s.Endpoint, s.Latency, s.AgeDays)
+		if err != nil {
+			return fmt.Errorf("failed to add demo search: %w", err)
+		}
+		report.Searches++
+	}
+	return nil
+}
@@ -0,0 +1,294 @@
+package demo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Workflow stages of a demo case, in order; each adds sections to the DSL
+// and is recorded as an amendment with the kycctl amend step name
+var stages = []string{
+	"intake",
+	"policy-discovery",
+	"document-solicitation",
+	"ownership-discovery",
+	"risk-assessment",
+}
+
+// demoEntity is a legal entity or person of the demo CBU
+type demoEntity struct {
+	Key          string
+	Name         string
+	Type         string
+	LegalForm    string
+	Jurisdiction string
+	Risk         string
+	KYCStatus    string
+	PEP          bool
+}
+
+// demoRole places an entity in the CBU
+type demoRole struct {
+	Entity  string
+	Role    string
+	Primary bool
+}
+
+// demoControl is an ownership or control edge between two entities
+type demoControl struct {
+	Controller string
+	Controlled string
+	Type       string
+	Basis      string
+	Percentage float64
+}
+
+// demoCase is a case with the stage it has reached and how it ended
+type demoCase struct {
+	Name    string
+	CBU     string
+	Nature  string
+	Purpose string
+	// Stage is the index in stages the case has reached
+	Stage int
+	// Outcome is approve, decline or review once risk-assessment is done
+	Outcome      string
+	Jurisdiction string
+	Policies     []string
+	Documents    [][2]string
+	Obligations  []string
+	Owners       [][2]string
+	UBOs         [][2]string
+	Controllers  [][2]string
+	// AgeDays is how long ago the case was opened
+	AgeDays int
+	// Findings are the checks of the latest validation
+	Findings []demoFinding
+}
+
+type demoFinding struct {
+	Type, Name, Status, Message, Ref, Severity string
+}
+
+// demoFeedback is a synthetic agent feedback on a search result
+type demoFeedback struct {
+	Query      string
+	Attribute  string
+	Sentiment  string
+	Confidence float64
+	Agent      string
+	AgeDays    int
+}
+
+// demoSearch is a synthetic RAG audit log entry
+type demoSearch struct {
+	Query    string
+	Endpoint string
+	Results  int
+	Latency  int
+	Agent    string
+	AgeDays  int
+}
+
+const cbuCode = Prefix + "BLACKROCK-GLOBAL"
+
+var entities = []demoEntity{
+	{Key: "PARENT", Name: "BlackRock, Inc. (Demo)", Type: "COMPANY", LegalForm: "Inc", Jurisdiction: "US", Risk: "LOW", KYCStatus: "APPROVED"},
+	{Key: "HOLDCO", Name: "BlackRock Holdco 2, Inc. (Demo)", Type: "COMPANY", LegalForm: "Inc", Jurisdiction: "US", Risk: "LOW", KYCStatus: "APPROVED"},
+	{Key: "MANCO", Name: "BlackRock (Luxembourg) S.A. (Demo)", Type: "COMPANY", LegalForm: "SA", Jurisdiction: "LU", Risk: "LOW", KYCStatus: "APPROVED"},
+	{Key: "FUND", Name: "BlackRock Global Funds SICAV (Demo)", Type: "FUND", LegalForm: "SICAV", Jurisdiction: "LU", Risk: "MEDIUM", KYCStatus: "IN_PROGRESS"},
+	{Key: "IM", Name: "BlackRock Investment Management (UK) Limited (Demo)", Type: "COMPANY", LegalForm: "Ltd", Jurisdiction: "UK", Risk: "LOW", KYCStatus: "APPROVED"},
+	{Key: "DEPOSITARY", Name: "Demo Depositary Bank Luxembourg S.A.", Type: "COMPANY", LegalForm: "SA", Jurisdiction: "LU", Risk: "LOW", KYCStatus: "APPROVED"},
+	{Key: "ADMIN", Name: "Demo Fund Administration S.a r.l.", Type: "COMPANY", LegalForm: "Sarl", Jurisdiction: "LU", Risk: "LOW", KYCStatus: "APPROVED"},
+	{Key: "AUDITOR", Name: "Demo Audit Partners S.A.", Type: "COMPANY", LegalForm: "SA", Jurisdiction: "LU", Risk: "LOW", KYCStatus: "APPROVED"},
+	{Key: "SMO", Name: "Jane Doe", Type: "PERSON", Jurisdiction: "UK", Risk: "MEDIUM", KYCStatus: "APPROVED"},
+	{Key: "DIRECTOR", Name: "John Smith", Type: "PERSON", Jurisdiction: "LU", Risk: "HIGH", KYCStatus: "IN_PROGRESS", PEP: true},
+}
+
+var roles = []demoRole{
+	{Entity: "PARENT", Role: "PARENT", Primary: true},
+	{Entity: "FUND", Role: "FUND", Primary: true},
+	{Entity: "MANCO", Role: "MANCO", Primary: true},
+	{Entity: "IM", Role: "INVESTMENT_MANAGER", Primary: true},
+	{Entity: "DEPOSITARY", Role: "DEPOSITARY", Primary: true},
+	{Entity: "ADMIN", Role: "ADMINISTRATOR", Primary: true},
+	{Entity: "AUDITOR", Role: "AUDITOR", Primary: true},
+}
+
+var controls = []demoControl{
+	{Controller: "PARENT", Controlled: "HOLDCO", Type: "LEGAL_OWNERSHIP", Basis: "Direct shareholding", Percentage: 100},
+	{Controller: "HOLDCO", Controlled: "MANCO", Type: "LEGAL_OWNERSHIP", Basis: "Direct shareholding", Percentage: 100},
+	{Controller: "HOLDCO", Controlled: "IM", Type: "LEGAL_OWNERSHIP", Basis: "Direct shareholding", Percentage: 100},
+	{Controller: "MANCO", Controlled: "FUND", Type: "MANAGEMENT_CONTROL", Basis: "Management company agreement"},
+	{Controller: "IM", Controlled: "FUND", Type: "OPERATIONAL_CONTROL", Basis: "Investment management delegation"},
+	{Controller: "SMO", Controlled: "FUND", Type: "OPERATIONAL_CONTROL", Basis: "Senior managing official"},
+	{Controller: "DIRECTOR", Controlled: "MANCO", Type: "MANAGEMENT_CONTROL", Basis: "Board director"},
+}
+
+var euDocuments = [][2]string{
+	{"CERT-INC", "Certificate of Incorporation"},
+	{"UBO-DECL", "Ultimate Beneficial Owner Declaration"},
+	{"SHARE-REGISTER", "Share Register"},
+	{"AUDITED-FINANCIALS", "Audited Financial Statements"},
+}
+
+var cases = []demoCase{
+	{
+		Name:    Prefix + "BLACKROCK-GLOBAL-EQUITY-FUND",
+		CBU:     cbuCode,
+		Nature:  "Institutional investment management vehicle",
+		Purpose: "Operate a SICAV with multi-jurisdictional sub-funds",
+		Stage:   4, Outcome: "approve", Jurisdiction: "EU",
+		Policies:    []string{"KYCPOL-EU-2025", "AML-GLOBAL-BASE"},
+		Documents:   euDocuments,
+		Obligations: []string{"OBL-UBO-DECLARATION", "OBL-PEP-001"},
+		Owners:      [][2]string{{"BLACKROCK-HOLDCO-2", "100"}},
+		UBOs:        [][2]string{{"INSTITUTIONAL-INVESTORS", "45"}},
+		Controllers: [][2]string{{"JANE-DOE", "Senior Managing Official"}, {"JOHN-SMITH", "Director, Risk Oversight"}},
+		AgeDays:     60,
+		Findings: []demoFinding{
+			{"ontology_document", "Required documents", "PASS", "All EU documents are in the regulatory ontology", "", "INFO"},
+			{"ownership_sum", "Ownership totals", "PASS", "Direct ownership sums to 100%", "", "INFO"},
+			{"semantic", "PEP screening", "WARN", "Controller JOHN-SMITH is a politically exposed person", "JOHN-SMITH", "WARNING"},
+		},
+	},
+	{
+		Name:    Prefix + "BLACKROCK-EURO-BOND-FUND",
+		CBU:     cbuCode,
+		Nature:  "UCITS fixed income fund",
+		Purpose: "Invest in investment-grade euro denominated bonds",
+		Stage:   4, Outcome: "review", Jurisdiction: "EU",
+		Policies:    []string{"KYCPOL-EU-2025"},
+		Documents:   euDocuments,
+		Obligations: []string{"OBL-UBO-DECLARATION"},
+		Owners:      [][2]string{{"BLACKROCK-HOLDCO-2", "100"}},
+		Controllers: [][2]string{{"JANE-DOE", "Senior Managing Official"}},
+		AgeDays:     21,
+		Findings: []demoFinding{
+			{"ontology_document", "Required documents", "PASS", "All EU documents are in the regulatory ontology", "", "INFO"},
+			{"ownership_sum", "Ownership totals", "WARN", "No beneficial owner above 25%; senior managing official recorded", "UBO_NAME", "WARNING"},
+		},
+	},
+	{
+		Name:    Prefix + "BLACKROCK-ASIA-GROWTH-FUND",
+		CBU:     cbuCode,
+		Nature:  "Regional equity sub-fund",
+		Purpose: "Growth equity exposure across Asia Pacific markets",
+		Stage:   2, Jurisdiction: "SG",
+		Policies:    []string{"KYCPOL-SG-2025", "AML-GLOBAL-BASE"},
+		Documents:   [][2]string{{"ACRA-PROFILE", "ACRA Business Profile"}, {"CERT-INC", "Certificate of Incorporation"}, {"UBO-DECL", "Ultimate Beneficial Owner Declaration"}},
+		Obligations: []string{"OBL-UBO-DECLARATION"},
+		AgeDays:     9,
+	},
+	{
+		Name:    Prefix + "BLACKROCK-US-INCOME-FUND",
+		CBU:     cbuCode,
+		Nature:  "US registered income fund",
+		Purpose: "Distribute income from US dividend paying equities",
+		Stage:   0, Jurisdiction: "US",
+		AgeDays: 2,
+	},
+	{
+		Name:    Prefix + "OFFSHORE-HOLDINGS-LTD",
+		CBU:     cbuCode,
+		Nature:  "Private investment holding company",
+		Purpose: "Hold third party co-investments alongside the SICAV",
+		Stage:   4, Outcome: "decline", Jurisdiction: "EU",
+		Policies:    []string{"KYCPOL-EU-2025", "AML-GLOBAL-BASE"},
+		Documents:   [][2]string{{"CERT-INC", "Certificate of Incorporation"}, {"UBO-DECL", "Ultimate Beneficial Owner Declaration"}, {"SOURCE-WEALTH-LETTER", "Source of Wealth Letter"}},
+		Obligations: []string{"OBL-UBO-DECLARATION", "OBL-PEP-001"},
+		Owners:      [][2]string{{"NOMINEE-SERVICES-KY", "60"}, {"UNDISCLOSED-TRUST", "40"}},
+		AgeDays:     45,
+		Findings: []demoFinding{
+			{"ownership_sum", "Beneficial ownership", "FAIL", "Beneficial owners of NOMINEE-SERVICES-KY could not be identified", "UBO_NAME", "CRITICAL"},
+			{"ontology_document", "Source of wealth", "FAIL", "Source of wealth letter not provided", "SOURCE-WEALTH-LETTER", "ERROR"},
+		},
+	},
+}
+
+var feedback = []demoFeedback{
+	{Query: "who ultimately owns the fund", Attribute: "UBO_NAME", Sentiment: "positive", Confidence: 0.95, Agent: "demo-onboarding-agent", AgeDays: 30},
+	{Query: "who ultimately owns the fund", Attribute: "SHAREHOLDER_NAME", Sentiment: "negative", Confidence: 0.8, Agent: "demo-onboarding-agent", AgeDays: 30},
+	{Query: "beneficial ownership percentage", Attribute: "UBO_PERCENT", Sentiment: "positive", Confidence: 0.9, Agent: "demo-onboarding-agent", AgeDays: 25},
+	{Query: "tax residency for CRS reporting", Attribute: "TAX_RESIDENCY_COUNTRY", Sentiment: "positive", Confidence: 0.92, Agent: "demo-tax-agent", AgeDays: 20},
+	{Query: "tax residency for CRS reporting", Attribute: "CRS_CLASSIFICATION", Sentiment: "positive", Confidence: 0.7, Agent: "demo-tax-agent", AgeDays: 20},
+	{Query: "FATCA classification of the entity", Attribute: "FATCA_STATUS", Sentiment: "positive", Confidence: 0.88, Agent: "demo-tax-agent", AgeDays: 14},
+	{Query: "FATCA classification of the entity", Attribute: "US_TAX_STATUS", Sentiment: "negative", Confidence: 0.6, Agent: "demo-tax-agent", AgeDays: 14},
+	{Query: "company legal name", Attribute: "REGISTERED_NAME", Sentiment: "positive", Confidence: 1.0, Agent: "demo-onboarding-agent", AgeDays: 10},
+	{Query: "where was the company incorporated", Attribute: "INCORPORATION_JURISDICTION", Sentiment: "positive", Confidence: 0.85, Agent: "demo-onboarding-agent", AgeDays: 7},
+	{Query: "where was the company incorporated", Attribute: "REGISTERED_ADDRESS", Sentiment: "negative", Confidence: 0.75, Agent: "demo-onboarding-agent", AgeDays: 7},
+	{Query: "origin of the client's money", Attribute: "SOURCE_OF_FUNDS", Sentiment: "positive", Confidence: 0.9, Agent: "demo-risk-agent", AgeDays: 3},
+	{Query: "person with significant control", Attribute: "CONTROL_PERSON", Sentiment: "positive", Confidence: 0.93, Agent: "demo-risk-agent", AgeDays: 1},
+}
+
+var searches = []demoSearch{
+	{Query: "who ultimately owns the fund", Endpoint: "/rag/attribute_search", Results: 5, Latency: 412, Agent: "demo-onboarding-agent", AgeDays: 30},
+	{Query: "beneficial ownership percentage", Endpoint: "/rag/attribute_search", Results: 5, Latency: 388, Agent: "demo-onboarding-agent", AgeDays: 25},
+	{Query: "tax residency for CRS reporting", Endpoint: "/rag/attribute_search_enriched", Results: 5, Latency: 655, Agent: "demo-tax-agent", AgeDays: 20},
+	{Query: "FATCA classification of the entity", Endpoint: "/rag/attribute_search", Results: 4, Latency: 301, Agent: "demo-tax-agent", AgeDays: 14},
+	{Query: "company legal name", Endpoint: "/rag/text_search", Results: 3, Latency: 12, Agent: "demo-onboarding-agent", AgeDays: 10},
+	{Query: "where was the company incorporated", Endpoint: "/rag/attribute_search", Results: 5, Latency: 356, Agent: "demo-onboarding-agent", AgeDays: 7},
+	{Query: "origin of the client's money", Endpoint: "/rag/attribute_search", Results: 5, Latency: 420, Agent: "demo-risk-agent", AgeDays: 3},
+	{Query: "person with significant control", Endpoint: "/rag/attribute_search_enriched", Results: 5, Latency: 590, Agent: "demo-risk-agent", AgeDays: 1},
+}
+
+// dsl renders a case as it stood after stage; final adds the outcome token
+func (c demoCase) dsl(stage int, final bool) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "(kyc-case %s\n", c.Name)
+	fmt.Fprintf(&b, "  (nature-purpose\n    (nature %q)\n    (purpose %q))\n", c.Nature, c.Purpose)
+	fmt.Fprintf(&b, "  (client-business-unit %s)\n", c.CBU)
+
+	if stage >= 1 {
+		b.WriteString("  (function DISCOVER-POLICIES)\n")
+		for _, p := range c.Policies {
+			fmt.Fprintf(&b, "  (policy %s)\n", p)
+		}
+	}
+	if stage >= 2 {
+		b.WriteString("  (function SOLICIT-DOCUMENTS)\n")
+		fmt.Fprintf(&b, "  (document-requirements\n    (jurisdiction %s)\n    (required", c.Jurisdiction)
+		for _, d := range c.Documents {
+			fmt.Fprintf(&b, "\n      (document %s %q)", d[0], d[1])
+		}
+		b.WriteString("))\n")
+		for _, o := range c.Obligations {
+			fmt.Fprintf(&b, "  (obligation %s)\n", o)
+		}
+	}
+	if stage >= 3 {
+		b.WriteString("  (function BUILD-OWNERSHIP-TREE)\n  (ownership-structure")
+		for _, o := range c.Owners {
+			fmt.Fprintf(&b, "\n    (owner %s %s)", o[0], o[1])
+		}
+		for _, u := range c.UBOs {
+			fmt.Fprintf(&b, "\n    (beneficial-owner %s %s)", u[0], u[1])
+		}
+		for _, ctl := range c.Controllers {
+			fmt.Fprintf(&b, "\n    (controller %s %q)", ctl[0], ctl[1])
+		}
+		b.WriteString(")\n")
+	}
+	if stage >= 4 {
+		b.WriteString("  (function ASSESS-RISK)\n")
+	}
+
+	fmt.Fprintf(&b, "  (kyc-token %q))\n", c.token(final))
+	return b.String()
+}
+
+// token is the kyc-token of the case, with the outcome once final
+func (c demoCase) token(final bool) string {
+	if !final {
+		return "pending"
+	}
+	switch c.Outcome {
+	case "approve":
+		return "approved"
+	case "decline":
+		return "declined"
+	case "review":
+		return "review"
+	}
+	return "pending"
+}
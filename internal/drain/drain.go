@@ -0,0 +1,148 @@
+// Package drain coordinates zero-downtime shutdown for rolling deploys. On
+// SIGTERM a server reports unhealthy for a grace delay so load balancers stop
+// routing to it, stops accepting connections, lets in-flight requests and
+// streams (ListCases, graph streams) finish within a deadline, and only then
+// runs the remaining shutdown steps such as stopping background jobs and
+// closing the database. With SO_REUSEPORT the replacement process binds the
+// same port while the old one drains.
+package drain
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+)
+
+// Step is one stage of a shutdown. It should return once its work is done
+// or ctx, which carries the drain deadline, expires.
+type Step func(ctx context.Context) error
+
+type namedStep struct {
+	name string
+	fn   Step
+}
+
+// Drainer runs a server's shutdown steps in the order they were added
+type Drainer struct {
+	cfg      config.DrainConfig
+	draining atomic.Bool
+	notify   []func()
+	steps    []namedStep
+}
+
+// New creates a drainer for the drain configuration
+func New(cfg config.DrainConfig) *Drainer {
+	return &Drainer{cfg: cfg}
+}
+
+// Listen opens a TCP listener, with SO_REUSEPORT when reuse_port is set
+func (d *Drainer) Listen(ctx context.Context, addr string) (net.Listener, error) {
+	lc := net.ListenConfig{}
+	if d.cfg.ReusePort {
+		lc.Control = reusePort
+	}
+	return lc.Listen(ctx, "tcp", addr)
+}
+
+// Draining reports whether shutdown has begun; health checks fail from then on
+func (d *Drainer) Draining() bool {
+	return d.draining.Load()
+}
+
+// OnDraining registers a function called as soon as draining begins, before
+// the delay; it flips health states that are pushed rather than polled
+func (d *Drainer) OnDraining(fn func()) {
+	d.notify = append(d.notify, fn)
+}
+
+// OnDrain adds a shutdown step. Steps run in order, after draining began.
+func (d *Drainer) OnDrain(name string, fn Step) {
+	d.steps = append(d.steps, namedStep{name: name, fn: fn})
+}
+
+// WaitForSignal blocks until SIGINT or SIGTERM, then drains
+func (d *Drainer) WaitForSignal() {
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	sig := <-quit
+	signal.Stop(quit)
+	slog.Info("🛑 Draining", "signal", sig.String(), "delay", d.cfg.Delay, "timeout", d.cfg.Timeout)
+	d.Drain()
+}
+
+// Drain marks the server as draining, waits out the delay, then runs every
+// step. All steps share the drain timeout; a step that fails or overruns is
+// logged and the next one still runs, so resources are always released.
+func (d *Drainer) Drain() {
+	d.draining.Store(true)
+	for _, fn := range d.notify {
+		fn()
+	}
+	if d.cfg.Delay > 0 {
+		time.Sleep(d.cfg.Delay)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.cfg.Timeout)
+	defer cancel()
+	for _, s := range d.steps {
+		start := time.Now()
+		if err := s.fn(ctx); err != nil {
+			slog.Warn("⚠️  Drain step failed", "step", s.name, "error", err)
+			continue
+		}
+		slog.Info("✅ Drained", "step", s.name, "duration", time.Since(start).Round(time.Millisecond))
+	}
+}
+
+// HTTP returns a step that stops an HTTP server accepting connections and
+// waits for in-flight requests, closing the remaining connections at the
+// deadline
+func HTTP(srv *http.Server) Step {
+	return func(ctx context.Context) error {
+		if err := srv.Shutdown(ctx); err != nil {
+			_ = srv.Close()
+			return err
+		}
+		return nil
+	}
+}
+
+// GRPC returns a step that stops a gRPC server accepting connections and
+// RPCs and waits for in-flight RPCs, including long-running streams, then
+// cancels those still running at the deadline
+func GRPC(srv *grpc.Server) Step {
+	return func(ctx context.Context) error {
+		done := make(chan struct{})
+		go func() {
+			srv.GracefulStop()
+			close(done)
+		}()
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			srv.Stop()
+			<-done
+			return errors.New("in-flight RPCs cancelled at the drain deadline")
+		}
+	}
+}
+
+// Func adapts a shutdown function that cannot fail or be cut short
+func Func(fn func()) Step {
+	return func(context.Context) error {
+		fn()
+		return nil
+	}
+}
@@ -0,0 +1,14 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly)
+
+package drain
+
+import (
+	"fmt"
+	"runtime"
+	"syscall"
+)
+
+// reusePort is unavailable on this platform
+func reusePort(network, address string, c syscall.RawConn) error {
+	return fmt.Errorf("drain.reuse_port is not supported on %s", runtime.GOOS)
+}
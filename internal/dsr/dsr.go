@@ -0,0 +1,488 @@
+// Package dsr serves GDPR data subject requests for the natural persons
+// named in cases: UBOs, directors, signatories.
+//
+// A subject is located by name across captured case values (decrypting
+// encrypted ones, internal/pii), the metadata and extracted text of
+// evidence, case amendments, case versions and the audit logs of search
+// and generation. Export returns what was found as portable JSON; Erase
+// crypto-shreds or redacts it, and drops cached embeddings of queries
+// naming the subject. Records AML rules oblige us to keep are retained:
+// everything of a case whose business relationship is open or ended less
+// than dsr.record_retention ago, and the hash-chained case versions, which
+// are immutable. Every request is recorded in
+// kyc_dsr_requests by the hash of the subject.
+package dsr
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/evidence"
+	"github.com/adamtc007/KYC-DSL/internal/pii"
+)
+
+// ErrInvalidSubject is returned for a subject too short to locate safely
+var ErrInvalidSubject = errors.New("invalid data subject")
+
+// minSubjectLength keeps a request from matching every record
+const minSubjectLength = 3
+
+// Sources of the records that mention a subject
+const (
+	SourceCaseValue   = "case_value"      // kyc_case_attribute_values
+	SourceDictionary  = "case_dictionary" // captured kyc_case_data_dictionary entries
+	SourceEvidence    = "evidence"        // kyc_evidence and its extracted text
+	SourceAmendment   = "case_amendment"  // kyc_case_amendments
+	SourceCaseVersion = "case_version"    // kyc_case_versions
+	SourceRAGAudit    = "rag_audit"       // rag_audit_log
+	SourceSearchHits  = "search_hits"     // kyc_ontology_search_hits
+	SourceModelIO     = "model_io"        // model_io_log
+)
+
+// Sources lists the sources in the order they are searched and erased
+var Sources = []string{
+	SourceCaseValue, SourceDictionary, SourceEvidence, SourceAmendment,
+	SourceCaseVersion, SourceRAGAudit, SourceSearchHits, SourceModelIO,
+}
+
+// Dispositions of a record in an erasure
+const (
+	DispositionErased   = "erased"
+	DispositionRetained = "retained"
+)
+
+// erasedText replaces the subject in redacted text
+const erasedText = "[erased]"
+
+// Record is a record that mentions the subject
+type Record struct {
+	Source string `json:"source"`
+	// ID identifies the row within its source: its id, or case/attribute
+	// for data dictionary entries
+	ID       string `json:"id"`
+	CaseName string `json:"case_name,omitempty"`
+	// Field is the attribute code or column that mentions the subject
+	Field string `json:"field"`
+	// Content is the value, or the lines of text, mentioning the subject;
+	// exports only
+	Content    string     `json:"content,omitempty"`
+	RecordedAt *time.Time `json:"recorded_at,omitempty"`
+	// Disposition and Reason say what an erasure does with the record
+	Disposition string `json:"disposition"`
+	Reason      string `json:"reason,omitempty"`
+
+	rowID int64
+}
+
+// Service locates, exports and erases the records of data subjects
+type Service struct {
+	db       *sqlx.DB
+	cfg      config.DSRConfig
+	evidence *evidence.Service
+}
+
+// NewService creates a data subject request service; ev erases evidence
+// content from the configured evidence store
+func NewService(db *sqlx.DB, cfg config.DSRConfig, ev *evidence.Service) *Service {
+	return &Service{db: db, cfg: cfg, evidence: ev}
+}
+
+// subject is a located data subject: the name as given, its normalized
+// form and how it is matched in SQL and Go
+type subject struct {
+	name       string
+	normalized string
+	like       string
+	pattern    *regexp.Regexp
+}
+
+// newSubject normalizes a subject: lower case, single spaces
+func newSubject(name string) (*subject, error) {
+	words := strings.Fields(strings.ToLower(name))
+	normalized := strings.Join(words, " ")
+	if utf8.RuneCountInString(normalized) < minSubjectLength {
+		return nil, fmt.Errorf("%w: at least %d characters are required", ErrInvalidSubject, minSubjectLength)
+	}
+	quoted := make([]string, len(words))
+	for i, w := range words {
+		quoted[i] = regexp.QuoteMeta(w)
+	}
+	escape := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return &subject{
+		name:       strings.TrimSpace(name),
+		normalized: normalized,
+		like:       "%" + escape.Replace(normalized) + "%",
+		pattern:    regexp.MustCompile(`(?i)` + strings.Join(quoted, `\s+`)),
+	}, nil
+}
+
+// SubjectHash identifies a subject in kyc_dsr_requests without naming it
+func SubjectHash(name string) string {
+	sum := sha256.Sum256([]byte(strings.Join(strings.Fields(strings.ToLower(name)), " ")))
+	return hex.EncodeToString(sum[:])
+}
+
+// matches is the SQL condition that column mentions the subject ($1)
+func matches(column string) string {
+	return `regexp_replace(lower(COALESCE(` + column + `, '')), '\s+', ' ', 'g') LIKE $1`
+}
+
+// excerpt returns the lines of text that mention the subject
+func (s *subject) excerpt(text string) string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if s.pattern.MatchString(line) {
+			lines = append(lines, strings.TrimSpace(line))
+		}
+	}
+	if len(lines) == 0 {
+		// The name is split across lines
+		return strings.TrimSpace(text)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// redact replaces every mention of the subject in text
+func (s *subject) redact(text string) string {
+	return s.pattern.ReplaceAllString(text, erasedText)
+}
+
+// Locate returns the records that mention a subject, each with what an
+// erasure does with it
+func (s *Service) Locate(ctx context.Context, name string) ([]Record, error) {
+	subj, err := newSubject(name)
+	if err != nil {
+		return nil, err
+	}
+	return s.locate(ctx, subj)
+}
+
+func (s *Service) locate(ctx context.Context, subj *subject) ([]Record, error) {
+	var records []Record
+	var evidenceIDs []int64
+
+	values, linked, err := s.locateValues(ctx, subj)
+	if err != nil {
+		return nil, err
+	}
+	records = append(records, values...)
+	evidenceIDs = append(evidenceIDs, linked...)
+
+	entries, err := s.locateDictionary(ctx, subj)
+	if err != nil {
+		return nil, err
+	}
+	records = append(records, entries...)
+
+	var ev []struct {
+		ID          int64     `db:"id"`
+		CaseName    string    `db:"case_name"`
+		Field       string    `db:"field"`
+		FileName    string    `db:"file_name"`
+		Document    string    `db:"document_code"`
+		Description string    `db:"description"`
+		UploadedAt  time.Time `db:"uploaded_at"`
+	}
+	if err := s.db.SelectContext(ctx, &ev, `
+		SELECT e.id, e.case_name,
+		       CASE WHEN `+matches("e.file_name")+` THEN 'file_name'
+		            WHEN `+matches("e.description")+` THEN 'description'
+		            WHEN e.id = ANY($2) THEN 'evidence_id'
+		            ELSE 'text' END AS field,
+		       e.file_name, COALESCE(e.document_code, '') AS document_code,
+		       COALESCE(e.description, '') AS description, e.uploaded_at
+		  FROM kyc_evidence e
+		 WHERE e.erased_at IS NULL
+		   AND (`+matches("e.file_name")+` OR `+matches("e.description")+` OR e.id = ANY($2)
+		        OR EXISTS (SELECT 1 FROM kyc_evidence_pages p WHERE p.evidence_id = e.id AND `+matches("p.text")+`))
+		 ORDER BY e.id`, subj.like, pq.Array(evidenceIDs)); err != nil {
+		return nil, fmt.Errorf("failed to search evidence: %w", err)
+	}
+	for _, e := range ev {
+		uploaded := e.UploadedAt
+		content := "file_name: " + e.FileName
+		if e.Document != "" {
+			content += "\ndocument: " + e.Document
+		}
+		if e.Description != "" {
+			content += "\ndescription: " + e.Description
+		}
+		records = append(records, Record{
+			Source: SourceEvidence, ID: fmt.Sprint(e.ID), CaseName: e.CaseName, Field: e.Field,
+			Content: content, RecordedAt: &uploaded, rowID: e.ID,
+		})
+	}
+
+	texts := []struct {
+		source, query string
+	}{
+		{SourceAmendment, `
+			SELECT id, case_name, 'diff' AS field, COALESCE(diff, '') AS text, created_at
+			  FROM kyc_case_amendments WHERE ` + matches("diff") + ` ORDER BY id`},
+		{SourceCaseVersion, `
+			SELECT id, case_name, 'dsl_snapshot' AS field, COALESCE(dsl_snapshot, '') AS text, created_at
+			  FROM kyc_case_versions WHERE ` + matches("dsl_snapshot") + ` ORDER BY id`},
+		{SourceRAGAudit, `
+			SELECT id, '' AS case_name,
+			       CASE WHEN ` + matches("query_text") + ` THEN 'query_text' ELSE 'response' END AS field,
+			       CASE WHEN ` + matches("query_text") + ` THEN query_text ELSE response::text END AS text, created_at
+			  FROM rag_audit_log WHERE ` + matches("query_text") + ` OR ` + matches("response::text") + ` ORDER BY id`},
+		{SourceSearchHits, `
+			SELECT id, '' AS case_name, 'query_text' AS field, query_text AS text, created_at
+			  FROM kyc_ontology_search_hits WHERE ` + matches("query_text") + ` ORDER BY id`},
+		{SourceModelIO, `
+			SELECT id, '' AS case_name, 'prompt' AS field,
+			       concat_ws(E'\n', system_prompt, prompt, response) AS text, created_at
+			  FROM model_io_log
+			 WHERE ` + matches("system_prompt") + ` OR ` + matches("prompt") + ` OR ` + matches("response") + `
+			 ORDER BY id`},
+	}
+	for _, t := range texts {
+		var rows []struct {
+			ID        int64      `db:"id"`
+			CaseName  string     `db:"case_name"`
+			Field     string     `db:"field"`
+			Text      string     `db:"text"`
+			CreatedAt *time.Time `db:"created_at"`
+		}
+		if err := s.db.SelectContext(ctx, &rows, t.query, subj.like); err != nil {
+			return nil, fmt.Errorf("failed to search %s records: %w", t.source, err)
+		}
+		for _, r := range rows {
+			records = append(records, Record{
+				Source: t.source, ID: fmt.Sprint(r.ID), CaseName: r.CaseName, Field: r.Field,
+				Content: subj.excerpt(r.Text), RecordedAt: r.CreatedAt, rowID: r.ID,
+			})
+		}
+	}
+
+	if err := s.dispose(ctx, records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// storedValue is a captured value as it is stored, possibly encrypted
+type storedValue struct {
+	ID            int64     `db:"id"`
+	CaseName      string    `db:"case_name"`
+	AttributeCode string    `db:"attribute_code"`
+	Value         string    `db:"value"`
+	KeyID         string    `db:"key_id"`
+	EvidenceID    *int64    `db:"evidence_id"`
+	RecordedAt    time.Time `db:"recorded_at"`
+}
+
+// reveal selects the plaintext values with query and the encrypted ones
+// with encrypted, and keeps those that mention the subject, decrypted
+func (s *Service) reveal(ctx context.Context, subj *subject, plaintext, encrypted string) ([]storedValue, error) {
+	var candidates, sealed []storedValue
+	if err := s.db.SelectContext(ctx, &candidates, plaintext, subj.like); err != nil {
+		return nil, err
+	}
+	if err := s.db.SelectContext(ctx, &sealed, encrypted, pii.ErasedKeyID); err != nil {
+		return nil, err
+	}
+	for _, v := range sealed {
+		value, err := pii.Reveal(ctx, v.CaseName, v.AttributeCode, v.Value, v.KeyID)
+		if err != nil {
+			return nil, err
+		}
+		if subj.pattern.MatchString(value) {
+			v.Value = value
+			candidates = append(candidates, v)
+		}
+	}
+	return candidates, nil
+}
+
+// locateValues finds the captured values mentioning the subject and
+// returns them with the evidence they were read from
+func (s *Service) locateValues(ctx context.Context, subj *subject) ([]Record, []int64, error) {
+	const columns = `id, case_name, attribute_code, value, COALESCE(key_id, '') AS key_id, evidence_id,
+		captured_at AS recorded_at FROM kyc_case_attribute_values`
+	values, err := s.reveal(ctx, subj,
+		`SELECT `+columns+` WHERE key_id IS NULL AND `+matches("value"),
+		`SELECT `+columns+` WHERE key_id IS NOT NULL AND key_id <> $1`)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to search case values: %w", err)
+	}
+	records := make([]Record, 0, len(values))
+	var evidenceIDs []int64
+	for _, v := range values {
+		recorded := v.RecordedAt
+		records = append(records, Record{
+			Source: SourceCaseValue, ID: fmt.Sprint(v.ID), CaseName: v.CaseName, Field: v.AttributeCode,
+			Content: v.Value, RecordedAt: &recorded, rowID: v.ID,
+		})
+		if v.EvidenceID != nil {
+			evidenceIDs = append(evidenceIDs, *v.EvidenceID)
+		}
+	}
+	return records, evidenceIDs, nil
+}
+
+// locateDictionary finds the captured data dictionary entries mentioning
+// the subject
+func (s *Service) locateDictionary(ctx context.Context, subj *subject) ([]Record, error) {
+	const columns = `0 AS id, case_name, attribute_code, COALESCE(value, '') AS value, COALESCE(key_id, '') AS key_id,
+		NULL::bigint AS evidence_id, materialized_at AS recorded_at FROM kyc_case_data_dictionary`
+	entries, err := s.reveal(ctx, subj,
+		`SELECT `+columns+` WHERE NOT derived AND key_id IS NULL AND `+matches("value"),
+		`SELECT `+columns+` WHERE NOT derived AND key_id IS NOT NULL AND key_id <> $1`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search case data dictionaries: %w", err)
+	}
+	records := make([]Record, 0, len(entries))
+	for _, e := range entries {
+		recorded := e.RecordedAt
+		records = append(records, Record{
+			Source: SourceDictionary, ID: e.CaseName + "/" + e.AttributeCode, CaseName: e.CaseName,
+			Field: e.AttributeCode, Content: e.Value, RecordedAt: &recorded,
+		})
+	}
+	return records, nil
+}
+
+// dispose decides what an erasure does with each record: case versions
+// are immutable, the records of cases under retention are kept and the
+// rest is erased
+func (s *Service) dispose(ctx context.Context, records []Record) error {
+	var caseNames []string
+	for _, r := range records {
+		if r.CaseName != "" {
+			caseNames = append(caseNames, r.CaseName)
+		}
+	}
+	retained, err := s.retainedCases(ctx, caseNames)
+	if err != nil {
+		return err
+	}
+	for i := range records {
+		r := &records[i]
+		switch {
+		case r.Source == SourceCaseVersion:
+			r.Disposition, r.Reason = DispositionRetained, "case versions are immutable, hash-chained regulatory records"
+		case retained[r.CaseName] != "":
+			r.Disposition, r.Reason = DispositionRetained, retained[r.CaseName]
+		default:
+			r.Disposition = DispositionErased
+		}
+	}
+	return nil
+}
+
+// retainedCases returns, for the cases whose records must be kept, why
+func (s *Service) retainedCases(ctx context.Context, caseNames []string) (map[string]string, error) {
+	reasons := map[string]string{}
+	if len(caseNames) == 0 {
+		return reasons, nil
+	}
+	var cases []struct {
+		Name    string     `db:"name"`
+		Status  string     `db:"status"`
+		EndedAt *time.Time `db:"ended_at"`
+	}
+	if err := s.db.SelectContext(ctx, &cases, `
+		SELECT c.name, c.status,
+		       (SELECT MAX(t.created_at) FROM kyc_case_transitions t
+		         WHERE t.case_name = c.name AND t.to_status IN ('declined', 'archived')) AS ended_at
+		  FROM (SELECT DISTINCT ON (name) name, status FROM kyc_cases
+		         WHERE name = ANY($1) ORDER BY name, id DESC) c`, pq.Array(caseNames)); err != nil {
+		return nil, fmt.Errorf("failed to load case statuses: %w", err)
+	}
+	now := time.Now()
+	for _, c := range cases {
+		switch {
+		case c.Status != "declined" && c.Status != "archived":
+			reasons[c.Name] = fmt.Sprintf("case is %s: records are kept while the relationship is open", c.Status)
+		case c.EndedAt == nil:
+			reasons[c.Name] = fmt.Sprintf("case is %s with no recorded end: records are kept", c.Status)
+		case c.EndedAt.Add(s.cfg.RecordRetention).After(now):
+			reasons[c.Name] = fmt.Sprintf("case %s on %s: records are kept until %s", c.Status,
+				c.EndedAt.Format("2006-01-02"), c.EndedAt.Add(s.cfg.RecordRetention).Format("2006-01-02"))
+		}
+	}
+	return reasons, nil
+}
+
+// Export is the portable export of what is held about a subject
+type Export struct {
+	Subject     string    `json:"subject"`
+	RequestID   int64     `json:"request_id"`
+	GeneratedAt time.Time `json:"generated_at"`
+	Records     []Record  `json:"records"`
+}
+
+// Export returns the records that mention a subject, with their content,
+// and records the request
+func (s *Service) Export(ctx context.Context, name string) (*Export, error) {
+	subj, err := newSubject(name)
+	if err != nil {
+		return nil, err
+	}
+	records, err := s.locate(ctx, subj)
+	if err != nil {
+		return nil, err
+	}
+	req, err := s.record(ctx, "export", subj, false, records)
+	if err != nil {
+		return nil, err
+	}
+	return &Export{Subject: subj.name, RequestID: req.ID, GeneratedAt: req.CreatedAt, Records: records}, nil
+}
+
+// SourceCount sums the records of one source in a request
+type SourceCount struct {
+	Source   string   `json:"source"`
+	Located  int      `json:"located"`
+	Erased   int      `json:"erased"`
+	Retained int      `json:"retained"`
+	Reasons  []string `json:"reasons,omitempty"`
+}
+
+// count sums records by source, in the order of Sources; with erased, the
+// records to erase count as erased
+func count(records []Record, erased bool) []SourceCount {
+	bySource := map[string]*SourceCount{}
+	reasons := map[string]map[string]bool{}
+	for _, r := range records {
+		c, ok := bySource[r.Source]
+		if !ok {
+			c = &SourceCount{Source: r.Source}
+			bySource[r.Source] = c
+			reasons[r.Source] = map[string]bool{}
+		}
+		c.Located++
+		switch {
+		case r.Disposition == DispositionRetained:
+			c.Retained++
+			if !reasons[r.Source][r.Reason] {
+				reasons[r.Source][r.Reason] = true
+				c.Reasons = append(c.Reasons, r.Reason)
+			}
+		case erased:
+			c.Erased++
+		}
+	}
+	counts := []SourceCount{}
+	for _, source := range Sources {
+		if c, ok := bySource[source]; ok {
+			sort.Strings(c.Reasons)
+			counts = append(counts, *c)
+		}
+	}
+	return counts
+}
@@ -0,0 +1,196 @@
+package dsr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/adamtc007/KYC-DSL/internal/actor"
+	"github.com/adamtc007/KYC-DSL/internal/pii"
+)
+
+// Request is a recorded export or erasure (kyc_dsr_requests). Records are
+// the erasure's records without their content.
+type Request struct {
+	ID          int64         `db:"id" json:"id"`
+	Kind        string        `db:"kind" json:"kind"`
+	SubjectHash string        `db:"subject_hash" json:"subject_hash"`
+	DryRun      bool          `db:"dry_run" json:"dry_run"`
+	Located     int           `db:"located" json:"located"`
+	Erased      int           `db:"erased" json:"erased"`
+	Retained    int           `db:"retained" json:"retained"`
+	Sources     []SourceCount `db:"-" json:"sources"`
+	RequestedBy string        `db:"requested_by" json:"requested_by"`
+	CreatedAt   time.Time     `db:"created_at" json:"created_at"`
+	Records     []Record      `db:"-" json:"records,omitempty"`
+}
+
+// record stores a request by the hash of its subject, never the subject
+func (s *Service) record(ctx context.Context, kind string, subj *subject, dryRun bool, records []Record) (*Request, error) {
+	req := &Request{
+		Kind: kind, SubjectHash: SubjectHash(subj.normalized), DryRun: dryRun,
+		Sources: count(records, kind == "erase" && !dryRun), RequestedBy: actor.FromContext(ctx).Name,
+	}
+	for _, c := range req.Sources {
+		req.Located += c.Located
+		req.Erased += c.Erased
+		req.Retained += c.Retained
+	}
+	sources, err := json.Marshal(req.Sources)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request sources: %w", err)
+	}
+	if err := s.db.GetContext(ctx, req, `
+		INSERT INTO kyc_dsr_requests (kind, subject_hash, dry_run, located, erased, retained, sources, requested_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, kind, subject_hash, dry_run, located, erased, retained, requested_by, created_at`,
+		req.Kind, req.SubjectHash, req.DryRun, req.Located, req.Erased, req.Retained, sources, req.RequestedBy); err != nil {
+		return nil, fmt.Errorf("failed to record %s request: %w", kind, err)
+	}
+	return req, nil
+}
+
+// Erase crypto-shreds or redacts the records that mention a subject,
+// except those retained as regulatory records, and records the request.
+// Captured values lose their value and, when encrypted, their data key;
+// evidence loses its content, text and file name; amendments and audit
+// logs have the subject redacted from their text. With dryRun it only
+// reports what it would do.
+func (s *Service) Erase(ctx context.Context, name string, dryRun bool) (*Request, error) {
+	subj, err := newSubject(name)
+	if err != nil {
+		return nil, err
+	}
+	records, err := s.locate(ctx, subj)
+	if err != nil {
+		return nil, err
+	}
+	if !dryRun {
+		if err := s.erase(ctx, subj, records); err != nil {
+			return nil, err
+		}
+	}
+	req, err := s.record(ctx, "erase", subj, dryRun, records)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range records {
+		r.Content = ""
+		req.Records = append(req.Records, r)
+	}
+	return req, nil
+}
+
+// erase applies the erasure to the records to erase: the database in one
+// transaction, then the evidence, which also removes stored content
+func (s *Service) erase(ctx context.Context, subj *subject, records []Record) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	var evidenceIDs []int64
+	for _, r := range records {
+		if r.Disposition != DispositionErased {
+			continue
+		}
+		switch r.Source {
+		case SourceCaseValue:
+			_, err = tx.ExecContext(ctx, `
+				UPDATE kyc_case_attribute_values SET value = '', key_id = $2, updated_at = CURRENT_TIMESTAMP
+				 WHERE id = $1`, r.rowID, pii.ErasedKeyID)
+		case SourceDictionary:
+			_, err = tx.ExecContext(ctx, `
+				UPDATE kyc_case_data_dictionary SET value = NULL, key_id = $3
+				 WHERE case_name = $1 AND attribute_code = $2 AND NOT derived`, r.CaseName, r.Field, pii.ErasedKeyID)
+		case SourceEvidence:
+			evidenceIDs = append(evidenceIDs, r.rowID)
+		case SourceAmendment:
+			err = redactColumns(ctx, tx, subj, "kyc_case_amendments", r.rowID, "diff")
+		case SourceRAGAudit:
+			err = redactAudit(ctx, tx, subj, r.rowID)
+		case SourceSearchHits:
+			err = redactColumns(ctx, tx, subj, "kyc_ontology_search_hits", r.rowID, "query_text")
+		case SourceModelIO:
+			if err = redactColumns(ctx, tx, subj, "model_io_log", r.rowID, "system_prompt", "prompt", "response"); err == nil {
+				_, err = tx.ExecContext(ctx, `UPDATE model_io_log SET redacted = TRUE WHERE id = $1`, r.rowID)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("failed to erase %s record %s: %w", r.Source, r.ID, err)
+		}
+	}
+	// Cache keys are normalized query texts
+	if _, err := tx.ExecContext(ctx, `DELETE FROM rag_query_embedding_cache WHERE `+matches("query_key"), subj.like); err != nil {
+		return fmt.Errorf("failed to drop cached query embeddings: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit erasure: %w", err)
+	}
+
+	for _, id := range evidenceIDs {
+		if err := s.evidence.Erase(ctx, id); err != nil {
+			return fmt.Errorf("failed to erase evidence %d: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// redactColumns redacts the subject from text columns of a row
+func redactColumns(ctx context.Context, tx *sqlx.Tx, subj *subject, table string, id int64, columns ...string) error {
+	selects := make([]string, len(columns))
+	for i, c := range columns {
+		selects[i] = "COALESCE(" + c + ", '')"
+	}
+	row := tx.QueryRowxContext(ctx, `SELECT `+strings.Join(selects, ", ")+` FROM `+table+` WHERE id = $1 FOR UPDATE`, id)
+	values, err := row.SliceScan()
+	if err != nil {
+		return err
+	}
+	sets := make([]string, len(columns))
+	args := []interface{}{id}
+	for i, c := range columns {
+		text := ""
+		switch v := values[i].(type) {
+		case string:
+			text = v
+		case []byte:
+			text = string(v)
+		}
+		args = append(args, subj.redact(text))
+		sets[i] = c + " = NULLIF($" + strconv.Itoa(i+2) + ", '')"
+	}
+	_, err = tx.ExecContext(ctx, `UPDATE `+table+` SET `+strings.Join(sets, ", ")+` WHERE id = $1`, args...)
+	return err
+}
+
+// redactAudit redacts the subject from the query and response of a RAG
+// audit entry and drops the query embedding, which encodes the query, in
+// whatever form it is stored
+func redactAudit(ctx context.Context, tx *sqlx.Tx, subj *subject, id int64) error {
+	var entry struct {
+		Query    string `db:"query_text"`
+		Response string `db:"response"`
+	}
+	if err := tx.GetContext(ctx, &entry, `
+		SELECT query_text, response::text AS response FROM rag_audit_log WHERE id = $1 FOR UPDATE`, id); err != nil {
+		return err
+	}
+	response := subj.redact(entry.Response)
+	if !json.Valid([]byte(response)) {
+		response = `{"erased": true}`
+	}
+	_, err := tx.ExecContext(ctx, `
+		UPDATE rag_audit_log
+		   SET query_text = $2, response = $3::jsonb, query_embedding = NULL, query_embedding_reduced = NULL,
+		       query_embedding_hash = NULL, embedding_storage = 'none'
+		 WHERE id = $1`,
+		id, subj.redact(entry.Query), response)
+	return err
+}
@@ -0,0 +1,127 @@
+// Package embedspace fills secondary embedding spaces. Each space pairs an
+// embedding model with a dimension; the primary space lives in the ontology
+// tables and secondary ones in ontology_embeddings, so a candidate model can
+// be embedded and compared alongside the current one before it is promoted.
+package embedspace
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/ontology"
+	"github.com/adamtc007/KYC-DSL/internal/rag"
+)
+
+// Summary counts the outcome of syncing one space
+type Summary struct {
+	Pending  int `json:"pending"`
+	Embedded int `json:"embedded"`
+	Failed   int `json:"failed"`
+	// Remaining are pending entries left for later by the limit
+	Remaining int `json:"remaining"`
+}
+
+// Syncer embeds ontology entries into secondary spaces
+type Syncer struct {
+	repo     *ontology.SpaceRepo
+	embedder *rag.Embedder
+}
+
+// NewSyncer creates a syncer; embedder supplies the API client, budget and
+// rate limit, and is re-targeted at each space's model and dimension
+func NewSyncer(db *sqlx.DB, embedder *rag.Embedder) *Syncer {
+	return &Syncer{repo: ontology.NewSpaceRepo(db), embedder: embedder}
+}
+
+// Sync embeds the entries of kinds that space has no current embedding for,
+// batchSize texts per request and at most limit entries per kind (0 = all).
+// progress, when set, is called after every batch.
+func (s *Syncer) Sync(ctx context.Context, space model.EmbeddingSpace, kinds []string, batchSize, limit int,
+	progress func(kind string, done, total int)) (Summary, error) {
+	var summary Summary
+	if space.IsPrimary {
+		return summary, fmt.Errorf("%s is the primary space; use kycctl backfill-embeddings", space.Name)
+	}
+	if batchSize <= 0 {
+		batchSize = 50
+	}
+
+	for _, kind := range kinds {
+		pending, err := s.repo.ListPending(ctx, space.Name, kind)
+		if err != nil {
+			return summary, err
+		}
+		summary.Pending += len(pending)
+		if limit > 0 && len(pending) > limit {
+			summary.Remaining += len(pending) - limit
+			pending = pending[:limit]
+		}
+
+		for start := 0; start < len(pending); start += batchSize {
+			batch := pending[start:min(start+batchSize, len(pending))]
+			saved, err := s.Write(ctx, []model.EmbeddingSpace{space}, kind, batch)
+			summary.Embedded += saved
+			summary.Failed += len(batch) - saved
+			if err != nil {
+				return summary, err
+			}
+			if progress != nil {
+				progress(kind, start+len(batch), len(pending))
+			}
+		}
+	}
+	return summary, nil
+}
+
+// Write embeds targets of kind into each space and returns how many
+// embeddings were saved. It is the dual-write path: callers that have just
+// written the primary embeddings pass the same targets.
+func (s *Syncer) Write(ctx context.Context, spaces []model.EmbeddingSpace, kind string, targets []model.EmbeddingTarget) (int, error) {
+	if len(targets) == 0 {
+		return 0, nil
+	}
+	texts := make([]string, len(targets))
+	for i, t := range targets {
+		texts[i] = t.Text
+	}
+
+	saved := 0
+	for _, space := range spaces {
+		embeddings, err := s.embedder.ForSpace(space.Model, space.Dimensions).GenerateEmbeddingsFromTexts(ctx, texts)
+		if err != nil {
+			return saved, fmt.Errorf("failed to embed %d %s for space %s: %w", len(targets), kind, space.Name, err)
+		}
+		for i, t := range targets {
+			if len(embeddings[i]) != space.Dimensions {
+				return saved, fmt.Errorf("%s returned %d dimensions for space %s, expected %d",
+					space.Model, len(embeddings[i]), space.Name, space.Dimensions)
+			}
+			err := s.repo.SaveEmbedding(ctx, space.Name, kind, t.Code, ontology.ContentHash(t.Text), embeddings[i])
+			if err != nil {
+				return saved, err
+			}
+			saved++
+		}
+	}
+	return saved, nil
+}
+
+// DualWriteSpaces resolves the configured dual-write space names, skipping
+// the primary space
+func DualWriteSpaces(ctx context.Context, db *sqlx.DB, names []string) ([]model.EmbeddingSpace, error) {
+	repo := ontology.NewSpaceRepo(db)
+	var spaces []model.EmbeddingSpace
+	for _, name := range names {
+		space, err := repo.Get(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if !space.IsPrimary {
+			spaces = append(spaces, *space)
+		}
+	}
+	return spaces, nil
+}
@@ -0,0 +1,237 @@
+package engine
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/adamtc007/KYC-DSL/internal/actor"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+// ErrInvalidAssignment is returned for an assignment without an assignee or
+// team, or with an unknown risk rating
+var ErrInvalidAssignment = errors.New("invalid case assignment")
+
+// RiskRatings are the risk ratings a case can be assigned with, highest first
+var RiskRatings = []string{"CRITICAL", "HIGH", "MEDIUM", "LOW"}
+
+// defaultQueueLimit caps a work queue query without a limit
+const defaultQueueLimit = 100
+
+// assignmentColumns selects a kyc_case_assignments row a, with the latest
+// status of its case c, as a model.CaseAssignment
+const assignmentColumns = `a.case_name, COALESCE(a.assignee, '') AS assignee, COALESCE(a.team, '') AS team,
+	COALESCE(a.risk_rating, '') AS risk_rating, a.sla_due_at, a.assigned_by, a.assigned_at,
+	COALESCE(c.status, 'draft') AS status, COALESCE(a.sla_due_at < NOW(), false) AS overdue`
+
+// assignmentFrom joins each assignment to the latest row of its case
+const assignmentFrom = `kyc_case_assignments a
+	LEFT JOIN LATERAL (
+		SELECT status FROM kyc_cases WHERE name = a.case_name ORDER BY id DESC LIMIT 1
+	) c ON true`
+
+// ParseRiskRating converts a string to a case risk rating; empty stays empty
+func ParseRiskRating(s string) (string, error) {
+	rating := strings.ToUpper(strings.TrimSpace(s))
+	if rating == "" {
+		return "", nil
+	}
+	for _, r := range RiskRatings {
+		if r == rating {
+			return rating, nil
+		}
+	}
+	return "", fmt.Errorf("%w: unknown risk rating %q", ErrInvalidAssignment, s)
+}
+
+// Assignments assigns cases to analysts and teams and serves the work queue
+// built from those assignments
+type Assignments struct {
+	db *sqlx.DB
+}
+
+// NewAssignments creates an assignment manager on the case store
+func NewAssignments(db *sqlx.DB) *Assignments {
+	return &Assignments{db: db}
+}
+
+// Get returns the assignment of a case, or nil when it is unassigned
+func (a *Assignments) Get(ctx context.Context, caseName string) (*model.CaseAssignment, error) {
+	var assignment model.CaseAssignment
+	err := a.db.GetContext(ctx, &assignment,
+		`SELECT `+assignmentColumns+` FROM `+assignmentFrom+` WHERE a.case_name = $1`, caseName)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assignment of case %s: %w", caseName, err)
+	}
+	return &assignment, nil
+}
+
+// Assign assigns a case to the assignee and team of to, on behalf of the
+// actor of ctx, and records the change. Fields left empty in to keep their
+// current value, so a case can be handed to another analyst of the same team
+// without restating its team, risk rating or SLA.
+func (a *Assignments) Assign(ctx context.Context, caseName string, to model.CaseAssignment, reason string) (*model.CaseAssignment, error) {
+	rating, err := ParseRiskRating(to.RiskRating)
+	if err != nil {
+		return nil, err
+	}
+	who := actor.FromContext(ctx)
+
+	tx, err := a.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	var exists bool
+	if err := tx.GetContext(ctx, &exists, `SELECT EXISTS (SELECT 1 FROM kyc_cases WHERE name = $1)`, caseName); err != nil {
+		return nil, fmt.Errorf("failed to look up case %s: %w", caseName, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrCaseNotFound, caseName)
+	}
+
+	var current struct {
+		Assignee   string     `db:"assignee"`
+		Team       string     `db:"team"`
+		RiskRating string     `db:"risk_rating"`
+		SLADueAt   *time.Time `db:"sla_due_at"`
+	}
+	err = tx.GetContext(ctx, &current, `
+		SELECT COALESCE(assignee, '') AS assignee, COALESCE(team, '') AS team,
+		       COALESCE(risk_rating, '') AS risk_rating, sla_due_at
+		FROM kyc_case_assignments WHERE case_name = $1 FOR UPDATE`, caseName)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get assignment of case %s: %w", caseName, err)
+	}
+
+	assignee, team, due := to.Assignee, to.Team, to.SLADueAt
+	if assignee == "" {
+		assignee = current.Assignee
+	}
+	if team == "" {
+		team = current.Team
+	}
+	if rating == "" {
+		rating = current.RiskRating
+	}
+	if due == nil {
+		due = current.SLADueAt
+	}
+	if assignee == "" && team == "" {
+		return nil, fmt.Errorf("%w: an assignee or team is required", ErrInvalidAssignment)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO kyc_case_assignments (case_name, assignee, team, risk_rating, sla_due_at, assigned_by)
+		VALUES ($1, NULLIF($2, ''), NULLIF($3, ''), NULLIF($4, ''), $5, $6)
+		ON CONFLICT (case_name) DO UPDATE SET
+			assignee = EXCLUDED.assignee,
+			team = EXCLUDED.team,
+			risk_rating = EXCLUDED.risk_rating,
+			sla_due_at = EXCLUDED.sla_due_at,
+			assigned_by = EXCLUDED.assigned_by,
+			assigned_at = NOW()`,
+		caseName, assignee, team, rating, due, who.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to assign case %s: %w", caseName, err)
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO kyc_case_assignment_history
+			(case_name, from_assignee, from_team, to_assignee, to_team, risk_rating, sla_due_at,
+			 actor, actor_source, client_ip, reason)
+		VALUES ($1, NULLIF($2, ''), NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, ''), $7,
+			$8, $9, NULLIF($10, ''), NULLIF($11, ''))`,
+		caseName, current.Assignee, current.Team, assignee, team, rating, due,
+		who.Name, who.Source, who.ClientIP, reason)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record assignment of case %s: %w", caseName, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit assignment of case %s: %w", caseName, err)
+	}
+	return a.Get(ctx, caseName)
+}
+
+// History returns the assignments of a case, oldest first
+func (a *Assignments) History(ctx context.Context, caseName string) ([]model.CaseAssignmentChange, error) {
+	changes := []model.CaseAssignmentChange{}
+	err := a.db.SelectContext(ctx, &changes, `
+		SELECT id, case_name, COALESCE(from_assignee, '') AS from_assignee, COALESCE(from_team, '') AS from_team,
+		       COALESCE(to_assignee, '') AS to_assignee, COALESCE(to_team, '') AS to_team,
+		       COALESCE(risk_rating, '') AS risk_rating, sla_due_at, actor,
+		       COALESCE(reason, '') AS reason, created_at
+		FROM kyc_case_assignment_history
+		WHERE case_name = $1
+		ORDER BY created_at, id`, caseName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assignment history of case %s: %w", caseName, err)
+	}
+	return changes, nil
+}
+
+// Queue returns the assigned cases matching a filter, the soonest SLA due
+// date and highest risk first. Without a status filter, cases already
+// approved, declined or archived are left out.
+func (a *Assignments) Queue(ctx context.Context, f model.CaseQueueFilter) ([]model.CaseAssignment, error) {
+	var where []string
+	var args []interface{}
+	add := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		where = append(where, fmt.Sprintf(cond, len(args)))
+	}
+	if f.Assignee != "" {
+		add("a.assignee = $%d", f.Assignee)
+	}
+	if f.Team != "" {
+		add("a.team = $%d", f.Team)
+	}
+	if f.Status != "" {
+		add("COALESCE(c.status, 'draft') = $%d", f.Status)
+	} else {
+		where = append(where, "COALESCE(c.status, 'draft') NOT IN ('approved', 'declined', 'archived')")
+	}
+	if f.RiskRating != "" {
+		rating, err := ParseRiskRating(f.RiskRating)
+		if err != nil {
+			return nil, err
+		}
+		add("a.risk_rating = $%d", rating)
+	}
+	if f.DueBefore != nil {
+		add("a.sla_due_at < $%d", *f.DueBefore)
+	}
+	if f.OverdueOnly {
+		where = append(where, "a.sla_due_at < NOW()")
+	}
+	limit := f.Limit
+	if limit <= 0 {
+		limit = defaultQueueLimit
+	}
+	args = append(args, limit)
+
+	query := `SELECT ` + assignmentColumns + ` FROM ` + assignmentFrom
+	if len(where) > 0 {
+		query += ` WHERE ` + strings.Join(where, " AND ")
+	}
+	query += fmt.Sprintf(`
+		ORDER BY a.sla_due_at NULLS LAST,
+		         CASE a.risk_rating WHEN 'CRITICAL' THEN 0 WHEN 'HIGH' THEN 1 WHEN 'MEDIUM' THEN 2 WHEN 'LOW' THEN 3 ELSE 4 END,
+		         a.assigned_at
+		LIMIT $%d`, len(args))
+
+	queue := []model.CaseAssignment{}
+	if err := a.db.SelectContext(ctx, &queue, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to query case queue: %w", err)
+	}
+	return queue, nil
+}
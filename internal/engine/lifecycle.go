@@ -0,0 +1,244 @@
+// Package engine drives KYC cases through their lifecycle:
+//
+//	draft → validated → in-review → approved | declined → archived
+//
+// A validated or in-review case can also be sent back to draft for rework.
+// Each transition is checked against the allowed moves, recorded with its
+// actor and time in kyc_case_transitions, and passed to the hooks
+// registered for the state it enters. Entering approved emits a
+// case.approved event (internal/events) and, with signing enabled, signs
+// the approved version (internal/signing).
+package engine
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/adamtc007/KYC-DSL/internal/actor"
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/events"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/signing"
+)
+
+var (
+	// ErrCaseNotFound is returned for a case that was never created
+	ErrCaseNotFound = errors.New("case not found")
+	// ErrInvalidTransition is returned for a move the lifecycle does not allow
+	ErrInvalidTransition = errors.New("invalid case transition")
+	// ErrUnknownStatus is returned for a status that is not a lifecycle state
+	ErrUnknownStatus = errors.New("unknown case status")
+)
+
+// transitions lists the states each state may move to
+var transitions = map[model.LifecycleState][]model.LifecycleState{
+	model.CaseDraft:     {model.CaseValidated},
+	model.CaseValidated: {model.CaseInReview, model.CaseDraft},
+	model.CaseInReview:  {model.CaseApproved, model.CaseDeclined, model.CaseDraft},
+	model.CaseApproved:  {model.CaseArchived},
+	model.CaseDeclined:  {model.CaseArchived},
+	model.CaseArchived:  {},
+}
+
+// ParseStatus converts a string to a lifecycle state
+func ParseStatus(s string) (model.LifecycleState, error) {
+	status := model.LifecycleState(strings.ToLower(strings.TrimSpace(s)))
+	if _, ok := transitions[status]; !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownStatus, s)
+	}
+	return status, nil
+}
+
+// Allowed returns the states a case in a state may move to
+func Allowed(from model.LifecycleState) []model.LifecycleState {
+	return append([]model.LifecycleState{}, transitions[from]...)
+}
+
+// CanTransition reports whether the lifecycle allows moving from one state
+// to another
+func CanTransition(from, to model.LifecycleState) bool {
+	for _, s := range transitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Hook runs inside the transaction of a transition, after it is recorded.
+// An error aborts the transition.
+type Hook func(ctx context.Context, tx *sqlx.Tx, t model.CaseTransition) error
+
+// Lifecycle moves cases between states
+type Lifecycle struct {
+	db    *sqlx.DB
+	hooks map[model.LifecycleState][]Hook
+}
+
+// NewLifecycle creates a lifecycle on the case store. Approvals and
+// declines are announced to event subscribers (internal/events) with the
+// transition; with signing.enabled approvals also sign the approved version.
+func NewLifecycle(db *sqlx.DB) *Lifecycle {
+	l := &Lifecycle{db: db, hooks: make(map[model.LifecycleState][]Hook)}
+	if cfg := config.Current().Signing; cfg.Enabled {
+		l.OnEnter(model.CaseApproved, signApproval(cfg))
+	}
+	l.OnEnter(model.CaseApproved, emitDecision(events.CaseApproved))
+	l.OnEnter(model.CaseDeclined, emitDecision(events.CaseDeclined))
+	return l
+}
+
+// emitDecision returns a hook queueing an event of eventType for the
+// decided version in the transition's transaction
+func emitDecision(eventType string) Hook {
+	return func(ctx context.Context, tx *sqlx.Tx, t model.CaseTransition) error {
+		var latest struct {
+			Version int    `db:"version"`
+			Hash    string `db:"hash"`
+		}
+		err := tx.GetContext(ctx, &latest, `
+			SELECT version, hash FROM kyc_case_versions WHERE case_name = $1 ORDER BY version DESC LIMIT 1`, t.CaseName)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("failed to get %s version of case %s: %w", t.To, t.CaseName, err)
+		}
+		return events.Emit(ctx, tx, events.Event{
+			Type:     eventType,
+			CaseName: t.CaseName,
+			Version:  latest.Version,
+			Hash:     latest.Hash,
+			Data: map[string]interface{}{
+				"from_status":   t.From,
+				"actor":         t.Actor,
+				"reason":        t.Reason,
+				"transition_id": t.ID,
+			},
+		})
+	}
+}
+
+// signApproval returns a hook signing the approved version, so an approval
+// that cannot be signed is refused. The signer is created per approval and
+// picks up a rotated key file.
+func signApproval(cfg config.SigningConfig) Hook {
+	return func(ctx context.Context, tx *sqlx.Tx, t model.CaseTransition) error {
+		signer, err := signing.New(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to load signing key: %w", err)
+		}
+		_, err = signing.SignLatestVersion(ctx, tx, signer, t.CaseName, t.ID, t.Actor)
+		return err
+	}
+}
+
+// OnEnter registers a hook run whenever a case enters a state
+func (l *Lifecycle) OnEnter(status model.LifecycleState, hook Hook) {
+	l.hooks[status] = append(l.hooks[status], hook)
+}
+
+// OnTransition registers a hook run on every transition
+func (l *Lifecycle) OnTransition(hook Hook) {
+	for status := range transitions {
+		l.OnEnter(status, hook)
+	}
+}
+
+// Status returns the current state of a case
+func (l *Lifecycle) Status(ctx context.Context, caseName string) (model.LifecycleState, error) {
+	var status model.LifecycleState
+	err := l.db.GetContext(ctx, &status, `
+		SELECT status FROM kyc_cases WHERE name = $1 ORDER BY id DESC LIMIT 1`, caseName)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("%w: %s", ErrCaseNotFound, caseName)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get status of case %s: %w", caseName, err)
+	}
+	return status, nil
+}
+
+// Transition moves a case to a new state on behalf of an actor, recording
+// the move and running the hooks of the state entered. An empty actor name
+// defaults to the actor of ctx. It fails with ErrInvalidTransition when the
+// lifecycle does not allow the move.
+func (l *Lifecycle) Transition(ctx context.Context, caseName string, to model.LifecycleState, actorName, reason string) (*model.CaseTransition, error) {
+	if _, ok := transitions[to]; !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownStatus, to)
+	}
+	who := actor.FromContext(ctx)
+	if actorName == "" {
+		actorName = who.Name
+	}
+
+	tx, err := l.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	// Lock the case so concurrent transitions are checked one after another
+	var from model.LifecycleState
+	err = tx.GetContext(ctx, &from, `
+		SELECT status FROM kyc_cases WHERE name = $1 ORDER BY id DESC LIMIT 1 FOR UPDATE`, caseName)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ErrCaseNotFound, caseName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status of case %s: %w", caseName, err)
+	}
+	if !CanTransition(from, to) {
+		return nil, fmt.Errorf("%w: %s cannot move from %s to %s", ErrInvalidTransition, caseName, from, to)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE kyc_cases SET status = $2, last_updated = NOW() WHERE name = $1`, caseName, to); err != nil {
+		return nil, fmt.Errorf("failed to update status of case %s: %w", caseName, err)
+	}
+	t := model.CaseTransition{CaseName: caseName, From: from, To: to, Actor: actorName, Reason: reason}
+	err = tx.QueryRowxContext(ctx, `
+		INSERT INTO kyc_case_transitions (case_name, from_status, to_status, actor, reason, actor_source, client_ip)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, NULLIF($7, ''))
+		RETURNING id, created_at`, caseName, from, to, actorName, reason, who.Source, who.ClientIP).Scan(&t.ID, &t.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record transition of case %s: %w", caseName, err)
+	}
+
+	for _, hook := range l.hooks[to] {
+		if err := hook(ctx, tx, t); err != nil {
+			return nil, fmt.Errorf("transition of case %s to %s aborted: %w", caseName, to, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transition of case %s: %w", caseName, err)
+	}
+	return &t, nil
+}
+
+// Timeline returns a case's current state, the states it may move to and
+// its transitions, oldest first
+func (l *Lifecycle) Timeline(ctx context.Context, caseName string) (*model.CaseTimeline, error) {
+	status, err := l.Status(ctx, caseName)
+	if err != nil {
+		return nil, err
+	}
+	timeline := &model.CaseTimeline{
+		CaseName:    caseName,
+		Status:      status,
+		Allowed:     Allowed(status),
+		Transitions: []model.CaseTransition{},
+	}
+	err = l.db.SelectContext(ctx, &timeline.Transitions, `
+		SELECT id, case_name, COALESCE(from_status, '') AS from_status, to_status,
+		       actor, COALESCE(reason, '') AS reason, created_at
+		FROM kyc_case_transitions
+		WHERE case_name = $1
+		ORDER BY created_at, id`, caseName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get timeline of case %s: %w", caseName, err)
+	}
+	return timeline, nil
+}
@@ -0,0 +1,154 @@
+package engine
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+var (
+	// ErrCaseLocked is returned when another holder has a live lock on a case
+	ErrCaseLocked = errors.New("case is locked")
+	// ErrLockNotHeld is returned when heartbeating or releasing a lock held
+	// by someone else, or not held at all
+	ErrLockNotHeld = errors.New("case lock not held")
+)
+
+// lockColumns selects a kyc_case_locks row as a model.CaseLock
+const lockColumns = `case_name, holder, COALESCE(note, '') AS note, acquired_at, heartbeat_at,
+	expires_at, COALESCE(taken_over_from, '') AS taken_over_from, expires_at < NOW() AS stale`
+
+// Locks manages the advisory locks analysts hold on cases while amending
+// them. A lock lasts for its TTL after the last heartbeat; once it has
+// expired another holder may take it over. Amendments check the lock with
+// Check, so two analysts cannot interleave versions of one case.
+type Locks struct {
+	db  *sqlx.DB
+	ttl time.Duration
+}
+
+// NewLocks creates a lock manager with the configured TTL (case_lock.ttl)
+func NewLocks(db *sqlx.DB) *Locks {
+	return &Locks{db: db, ttl: config.Current().CaseLock.TTL}
+}
+
+// TTL is how long a lock lasts without a heartbeat
+func (l *Locks) TTL() time.Duration {
+	return l.ttl
+}
+
+// Get returns the lock on a case, or nil when it is not locked. An expired
+// lock is returned with Stale set until it is taken over or released.
+func (l *Locks) Get(ctx context.Context, caseName string) (*model.CaseLock, error) {
+	var lock model.CaseLock
+	err := l.db.GetContext(ctx, &lock, `SELECT `+lockColumns+` FROM kyc_case_locks WHERE case_name = $1`, caseName)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lock of case %s: %w", caseName, err)
+	}
+	return &lock, nil
+}
+
+// Acquire locks a case for a holder. A holder acquiring its own lock again
+// renews it; an expired lock of another holder is taken over. A live lock of
+// another holder fails with ErrCaseLocked and is returned alongside it.
+func (l *Locks) Acquire(ctx context.Context, caseName, holder, note string) (*model.CaseLock, error) {
+	if holder == "" {
+		return nil, fmt.Errorf("a lock holder is required")
+	}
+	var lock model.CaseLock
+	err := l.db.GetContext(ctx, &lock, `
+		INSERT INTO kyc_case_locks (case_name, holder, note, expires_at)
+		VALUES ($1, $2, NULLIF($3, ''), NOW() + make_interval(secs => $4))
+		ON CONFLICT (case_name) DO UPDATE SET
+			holder = EXCLUDED.holder,
+			note = COALESCE(EXCLUDED.note, kyc_case_locks.note),
+			acquired_at = CASE WHEN kyc_case_locks.holder = EXCLUDED.holder
+				THEN kyc_case_locks.acquired_at ELSE NOW() END,
+			heartbeat_at = NOW(),
+			expires_at = EXCLUDED.expires_at,
+			taken_over_from = CASE WHEN kyc_case_locks.holder = EXCLUDED.holder
+				THEN kyc_case_locks.taken_over_from ELSE kyc_case_locks.holder END
+		WHERE kyc_case_locks.holder = EXCLUDED.holder OR kyc_case_locks.expires_at < NOW()
+		RETURNING `+lockColumns, caseName, holder, note, l.ttl.Seconds())
+	if errors.Is(err, sql.ErrNoRows) {
+		current, err := l.Get(ctx, caseName)
+		if err != nil {
+			return nil, err
+		}
+		if current == nil {
+			// Released between the insert and the lookup: try again
+			return l.Acquire(ctx, caseName, holder, note)
+		}
+		return current, lockedError(current)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock case %s: %w", caseName, err)
+	}
+	return &lock, nil
+}
+
+// Heartbeat extends a holder's lock by the TTL. It fails with ErrLockNotHeld
+// when the lock was released or taken over.
+func (l *Locks) Heartbeat(ctx context.Context, caseName, holder string) (*model.CaseLock, error) {
+	var lock model.CaseLock
+	err := l.db.GetContext(ctx, &lock, `
+		UPDATE kyc_case_locks
+		SET heartbeat_at = NOW(), expires_at = NOW() + make_interval(secs => $3)
+		WHERE case_name = $1 AND holder = $2
+		RETURNING `+lockColumns, caseName, holder, l.ttl.Seconds())
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s does not hold the lock on %s", ErrLockNotHeld, holder, caseName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to heartbeat lock of case %s: %w", caseName, err)
+	}
+	return &lock, nil
+}
+
+// Release removes a holder's lock on a case. Expired locks may be released
+// by anyone, and force releases a live lock of another holder. Releasing a
+// case that is not locked does nothing.
+func (l *Locks) Release(ctx context.Context, caseName, holder string, force bool) error {
+	res, err := l.db.ExecContext(ctx, `
+		DELETE FROM kyc_case_locks
+		WHERE case_name = $1 AND (holder = $2 OR $3 OR expires_at < NOW())`, caseName, holder, force)
+	if err != nil {
+		return fmt.Errorf("failed to release lock of case %s: %w", caseName, err)
+	}
+	if n, _ := res.RowsAffected(); n > 0 {
+		return nil
+	}
+	current, err := l.Get(ctx, caseName)
+	if err != nil || current == nil {
+		return err
+	}
+	return fmt.Errorf("%w: %s is held by %s", ErrLockNotHeld, caseName, current.Holder)
+}
+
+// Check returns ErrCaseLocked when a holder other than the given one has a
+// live lock on the case. Amendments call it before saving a new version.
+func (l *Locks) Check(ctx context.Context, caseName, holder string) error {
+	lock, err := l.Get(ctx, caseName)
+	if err != nil {
+		return err
+	}
+	if lock == nil || lock.Stale || lock.Holder == holder {
+		return nil
+	}
+	return lockedError(lock)
+}
+
+func lockedError(lock *model.CaseLock) error {
+	return fmt.Errorf("%w: %s is held by %s until %s", ErrCaseLocked, lock.CaseName, lock.Holder,
+		lock.ExpiresAt.Format(time.RFC3339))
+}
@@ -0,0 +1,275 @@
+package events
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+)
+
+// Delivery states
+const (
+	StatusPending   = "pending"
+	StatusDelivered = "delivered"
+	StatusFailed    = "failed"
+)
+
+// ErrDeliveryNotFound is returned when retrying an unknown delivery
+var ErrDeliveryNotFound = errors.New("event delivery not found")
+
+// Delivery is one event queued for one target (webhook_deliveries)
+type Delivery struct {
+	ID             int64      `db:"id" json:"id"`
+	EventID        string     `db:"event_id" json:"event_id"`
+	EventType      string     `db:"event_type" json:"event_type"`
+	Subject        string     `db:"subject" json:"subject"`
+	Sink           string     `db:"sink" json:"sink"`
+	Endpoint       string     `db:"endpoint" json:"endpoint"`
+	URL            string     `db:"url" json:"url"`
+	Payload        []byte     `db:"payload" json:"-"`
+	Status         string     `db:"status" json:"status"`
+	Attempts       int        `db:"attempts" json:"attempts"`
+	NextAttemptAt  time.Time  `db:"next_attempt_at" json:"next_attempt_at"`
+	LastStatusCode *int       `db:"last_status_code" json:"last_status_code,omitempty"`
+	LastError      *string    `db:"last_error" json:"last_error,omitempty"`
+	CreatedAt      time.Time  `db:"created_at" json:"created_at"`
+	DeliveredAt    *time.Time `db:"delivered_at" json:"delivered_at,omitempty"`
+}
+
+// Attempt is one publishing attempt of a delivery (webhook_delivery_attempts)
+type Attempt struct {
+	Attempt     int       `db:"attempt" json:"attempt"`
+	StatusCode  *int      `db:"status_code" json:"status_code,omitempty"`
+	Error       *string   `db:"error" json:"error,omitempty"`
+	DurationMS  int       `db:"duration_ms" json:"duration_ms"`
+	AttemptedAt time.Time `db:"attempted_at" json:"attempted_at"`
+}
+
+// Dispatcher publishes queued deliveries through their sink. Retry
+// settings come from the webhooks config whatever the sink.
+type Dispatcher struct {
+	db    *sqlx.DB
+	cfg   config.WebhookConfig
+	sinks map[string]Sink
+}
+
+// NewDispatcher creates a dispatcher over webhook_deliveries with the
+// webhook sink and, when enabled in eventsCfg, the Kafka, NATS and SIEM
+// sinks
+func NewDispatcher(db *sqlx.DB, webhooks config.WebhookConfig, eventsCfg config.EventsConfig) *Dispatcher {
+	sinks := map[string]Sink{SinkWebhook: NewWebhookSink(webhooks)}
+	if eventsCfg.HasSink(SinkKafka) {
+		sinks[SinkKafka] = NewKafkaSink(eventsCfg.Kafka, webhooks.Timeout)
+	}
+	if eventsCfg.HasSink(SinkNATS) {
+		sinks[SinkNATS] = NewNATSSink(eventsCfg.NATS, webhooks.Timeout)
+	}
+	if eventsCfg.HasSink(SinkSIEM) {
+		sinks[SinkSIEM] = NewSIEMSink(eventsCfg.SIEM, webhooks.Timeout)
+	}
+	return &Dispatcher{db: db, cfg: webhooks, sinks: sinks}
+}
+
+// Close closes the sinks
+func (d *Dispatcher) Close() error {
+	var errs []error
+	for _, sink := range d.sinks {
+		errs = append(errs, sink.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// List returns the most recent deliveries, optionally only those in a status
+func (d *Dispatcher) List(ctx context.Context, status string, limit int) ([]Delivery, error) {
+	var deliveries []Delivery
+	err := d.db.SelectContext(ctx, &deliveries, `
+		SELECT id, event_id, event_type, subject, sink, endpoint, url, payload, status, attempts,
+		       next_attempt_at, last_status_code, last_error, created_at, delivered_at
+		FROM webhook_deliveries
+		WHERE $1 = '' OR status = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2`, status, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+// Attempts returns the attempts of a delivery, oldest first
+func (d *Dispatcher) Attempts(ctx context.Context, deliveryID int64) ([]Attempt, error) {
+	var attempts []Attempt
+	err := d.db.SelectContext(ctx, &attempts, `
+		SELECT attempt, status_code, error, duration_ms, attempted_at
+		FROM webhook_delivery_attempts
+		WHERE delivery_id = $1
+		ORDER BY attempt`, deliveryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attempts of webhook delivery %d: %w", deliveryID, err)
+	}
+	return attempts, nil
+}
+
+// Retry makes a failed (or pending) delivery due now with a fresh set of
+// attempts
+func (d *Dispatcher) Retry(ctx context.Context, deliveryID int64) error {
+	res, err := d.db.ExecContext(ctx, `
+		UPDATE webhook_deliveries
+		SET status = 'pending', attempts = 0, next_attempt_at = NOW()
+		WHERE id = $1 AND status <> 'delivered'`, deliveryID)
+	if err != nil {
+		return fmt.Errorf("failed to retry webhook delivery %d: %w", deliveryID, err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%w: %d (or already delivered)", ErrDeliveryNotFound, deliveryID)
+	}
+	return nil
+}
+
+// claim takes the next due delivery, pushing its next attempt past the HTTP
+// timeout so another dispatcher does not send it concurrently. It returns
+// nil when none is due.
+func (d *Dispatcher) claim(ctx context.Context) (*Delivery, error) {
+	var dl Delivery
+	err := d.db.GetContext(ctx, &dl, `
+		UPDATE webhook_deliveries
+		SET next_attempt_at = NOW() + make_interval(secs => $1)
+		WHERE id = (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at, id
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, event_id, event_type, subject, sink, endpoint, url, payload, status, attempts,
+		          next_attempt_at, last_status_code, last_error, created_at, delivered_at`,
+		(2 * d.cfg.Timeout).Seconds())
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook delivery: %w", err)
+	}
+	return &dl, nil
+}
+
+// DeliverDue publishes up to limit due deliveries and records the outcome of
+// each. Deliveries are claimed one at a time, just before they are sent, so
+// a claim never outlives the send it covers. It returns how many were
+// delivered and how many attempts failed.
+func (d *Dispatcher) DeliverDue(ctx context.Context, limit int) (delivered, failed int, err error) {
+	for range limit {
+		dl, err := d.claim(ctx)
+		if err != nil {
+			return delivered, failed, err
+		}
+		if dl == nil {
+			break
+		}
+		code, elapsed, sendErr := d.send(ctx, *dl)
+		if err := d.record(ctx, *dl, code, sendErr, elapsed); err != nil {
+			return delivered, failed, err
+		}
+		if sendErr != nil {
+			failed++
+			slog.Warn("⚠️  Event delivery failed", "delivery_id", dl.ID, "event", dl.EventType,
+				"sink", dl.Sink, "endpoint", dl.Endpoint, "attempt", dl.Attempts+1, "error", sendErr)
+			continue
+		}
+		delivered++
+	}
+	return delivered, failed, nil
+}
+
+// send publishes a delivery through its sink, returning the response
+// status (0 without one), how long it took and any error
+func (d *Dispatcher) send(ctx context.Context, dl Delivery) (int, time.Duration, error) {
+	sink, ok := d.sinks[dl.Sink]
+	if !ok {
+		return 0, 0, fmt.Errorf("sink %q is not enabled (events.sinks)", dl.Sink)
+	}
+	start := time.Now()
+	code, err := sink.Publish(ctx, dl)
+	return code, time.Since(start), err
+}
+
+// record logs an attempt and moves the delivery on: delivered, due again
+// after backoff, or failed once it has used MaxAttempts
+func (d *Dispatcher) record(ctx context.Context, dl Delivery, code int, sendErr error, elapsed time.Duration) error {
+	attempt := dl.Attempts + 1
+	var statusCode *int
+	if code > 0 {
+		statusCode = &code
+	}
+	var errText *string
+	if sendErr != nil {
+		s := sendErr.Error()
+		errText = &s
+	}
+
+	tx, err := d.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO webhook_delivery_attempts (delivery_id, attempt, status_code, error, duration_ms)
+		VALUES ($1, $2, $3, $4, $5)`, dl.ID, attempt, statusCode, errText, elapsed.Milliseconds()); err != nil {
+		return fmt.Errorf("failed to log attempt of webhook delivery %d: %w", dl.ID, err)
+	}
+
+	status := StatusPending
+	switch {
+	case sendErr == nil:
+		status = StatusDelivered
+	case attempt >= d.cfg.MaxAttempts:
+		status = StatusFailed
+	}
+	backoff := d.cfg.RetryBackoff << min(attempt-1, 16)
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = $3, last_status_code = $4, last_error = $5,
+		    next_attempt_at = NOW() + make_interval(secs => $6),
+		    delivered_at = CASE WHEN $2 = 'delivered' THEN NOW() END
+		WHERE id = $1`, dl.ID, status, attempt, statusCode, errText, backoff.Seconds()); err != nil {
+		return fmt.Errorf("failed to update webhook delivery %d: %w", dl.ID, err)
+	}
+	return tx.Commit()
+}
+
+// Run publishes due deliveries every cfg.Interval until ctx is cancelled,
+// then closes the sinks
+func (d *Dispatcher) Run(ctx context.Context) {
+	defer d.Close() //nolint:errcheck
+	ticker := time.NewTicker(d.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		// Drain the backlog in batches before waiting for the next tick
+		for {
+			delivered, failed, err := d.DeliverDue(ctx, 100)
+			if err != nil {
+				slog.Warn("⚠️  Event dispatch failed", "error", err)
+				break
+			}
+			if delivered > 0 {
+				slog.Info("📨 Events delivered", "count", delivered, "failed_attempts", failed)
+			}
+			if delivered+failed < 100 {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
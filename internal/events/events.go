@@ -0,0 +1,227 @@
+// Package events notifies downstream systems (case management, Slack,
+// stream consumers) of what happens to cases and feedback without them
+// polling. Emit queues an event in the delivery outbox, webhook_deliveries
+// (migrations 041, 042), once per target: every webhook endpoint subscribed
+// to its type (webhooks config) and the Kafka and NATS sinks when enabled
+// (events config), and the SIEM sink when enabled for its type. It is written to the same database as the change it
+// reports. The Dispatcher publishes due deliveries through their sink,
+// retries failures with backoff and logs every attempt; every sink receives
+// the same JSON envelope.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/adamtc007/KYC-DSL/internal/actor"
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+// Event types
+const (
+	CaseCreated       = "case.created"
+	CaseVersionSaved  = "case.version.saved"
+	CaseApproved      = "case.approved"
+	CaseDeclined      = "case.declined"
+	FeedbackSubmitted = "feedback.submitted"
+	ValidationFailed  = "validation.failed"
+	// DocumentsExpirySummary is the daily summary of evidence nearing or
+	// past its expiry date (internal/evidence)
+	DocumentsExpirySummary = "documents.expiry.summary"
+	// RegulationChanged is a change to the text or effective dates of a
+	// regulation record (internal/regchange)
+	RegulationChanged = "regulation.changed"
+	// EvidenceAccessed is an upload, view or download of evidence
+	EvidenceAccessed = "evidence.accessed"
+	// AdminAction is a change or read made with the admin role: agent
+	// registry changes, feedback policy changes, model log reads
+	AdminAction = "admin.action"
+)
+
+// Types lists every event type
+var Types = []string{CaseCreated, CaseVersionSaved, CaseApproved, CaseDeclined, FeedbackSubmitted, ValidationFailed,
+	DocumentsExpirySummary, RegulationChanged, EvidenceAccessed, AdminAction}
+
+// Sinks
+const (
+	SinkWebhook = "webhook"
+	SinkKafka   = "kafka"
+	SinkNATS    = "nats"
+	SinkSIEM    = "siem"
+)
+
+// Event is the envelope published to every sink. CaseName, Version and
+// Hash identify the case version an event is about, when it is about one.
+type Event struct {
+	ID         string                 `json:"id"`
+	Type       string                 `json:"type"`
+	Subject    string                 `json:"subject"`
+	CaseName   string                 `json:"case_name,omitempty"`
+	Version    int                    `json:"version,omitempty"`
+	Hash       string                 `json:"hash,omitempty"`
+	OccurredAt time.Time              `json:"occurred_at"`
+	Actor      EventActor             `json:"actor"`
+	Data       map[string]interface{} `json:"data"`
+}
+
+// EventActor is who caused an event (internal/actor), without the client IP
+type EventActor struct {
+	Name   string `json:"name"`
+	Source string `json:"source"`
+}
+
+// Target is where one delivery of an event goes
+type Target struct {
+	Sink string
+	// Endpoint is the webhook endpoint name, or the sink name
+	Endpoint string
+	// URL is the webhook URL, or a description of the topic or subject
+	URL string
+}
+
+// Targets returns where events of a type are published under cfg: the
+// subscribed webhook endpoints sorted by name, then Kafka, NATS and the
+// SIEM when it is sent events of the type
+func Targets(cfg *config.Config, eventType string) []Target {
+	var targets []Target
+	if cfg.Events.HasSink(SinkWebhook) {
+		var names []string
+		for name := range cfg.Webhooks.Endpoints {
+			types, filtered := cfg.Webhooks.Events[name]
+			if !filtered || contains(types, eventType) {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			targets = append(targets, Target{Sink: SinkWebhook, Endpoint: name, URL: cfg.Webhooks.Endpoints[name]})
+		}
+	}
+	if cfg.Events.HasSink(SinkKafka) {
+		k := cfg.Events.Kafka
+		targets = append(targets, Target{Sink: SinkKafka, Endpoint: SinkKafka,
+			URL: "kafka://" + strings.Join(k.Brokers, ",") + "/" + k.Topic})
+	}
+	if cfg.Events.HasSink(SinkNATS) {
+		n := cfg.Events.NATS
+		targets = append(targets, Target{Sink: SinkNATS, Endpoint: SinkNATS,
+			URL: strings.TrimSuffix(n.URL, "/") + "/" + n.SubjectPrefix})
+	}
+	if cfg.Events.HasSink(SinkSIEM) && cfg.Events.SIEM.Sends(eventType) {
+		x := cfg.Events.SIEM
+		targets = append(targets, Target{Sink: SinkSIEM, Endpoint: SinkSIEM, URL: x.Network + "://" + x.Addr})
+	}
+	return targets
+}
+
+// Enabled reports whether cfg publishes events to any sink
+func Enabled(cfg *config.Config) bool {
+	return (cfg.Events.HasSink(SinkWebhook) && len(cfg.Webhooks.Endpoints) > 0) ||
+		cfg.Events.HasSink(SinkKafka) || cfg.Events.HasSink(SinkNATS) || cfg.Events.HasSink(SinkSIEM)
+}
+
+// Emit queues e for every target of its type, setting its ID, time and the
+// actor of ctx; the subject defaults to the case name. It does nothing when
+// there is no target. db may be a transaction, so the event is only
+// published if the change it reports commits.
+func Emit(ctx context.Context, db sqlx.ExecerContext, e Event) error {
+	targets := Targets(config.Current(), e.Type)
+	if len(targets) == 0 {
+		return nil
+	}
+
+	a := actor.FromContext(ctx)
+	e.ID = uuid.NewString()
+	e.OccurredAt = time.Now().UTC()
+	e.Actor = EventActor{Name: a.Name, Source: a.Source}
+	if e.Subject == "" {
+		e.Subject = e.CaseName
+	}
+	if e.Data == nil {
+		e.Data = map[string]interface{}{}
+	}
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", e.Type, err)
+	}
+
+	for _, t := range targets {
+		_, err := db.ExecContext(ctx, `
+			INSERT INTO webhook_deliveries (event_id, event_type, subject, sink, endpoint, url, payload)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			e.ID, e.Type, e.Subject, t.Sink, t.Endpoint, t.URL, payload)
+		if err != nil {
+			return fmt.Errorf("failed to queue %s event for %s: %w", e.Type, t.Endpoint, err)
+		}
+	}
+	return nil
+}
+
+// Notify emits an event outside a transaction, logging instead of
+// returning a failure: the change it reports is already stored and must not
+// be reported as failed because its notification could not be queued
+func Notify(ctx context.Context, db sqlx.ExecerContext, e Event) {
+	if err := Emit(ctx, db, e); err != nil {
+		slog.Warn("⚠️  Event not queued", "event", e.Type, "case_name", e.CaseName, "subject", e.Subject, "error", err)
+	}
+}
+
+// NotifyFeedback emits feedback.submitted for stored feedback; its subject
+// is the attribute, document or regulation the feedback is about
+func NotifyFeedback(ctx context.Context, db sqlx.ExecerContext, id int, f model.Feedback) {
+	data := map[string]interface{}{
+		"feedback_id": id,
+		"query_text":  f.QueryText,
+		"feedback":    f.Feedback,
+		"confidence":  f.Confidence,
+		"agent_type":  f.AgentType,
+		"tenant":      f.Tenant,
+	}
+	var subject string
+	for _, ref := range []struct {
+		key  string
+		code *string
+	}{
+		{"regulation_code", f.RegulationCode},
+		{"document_code", f.DocumentCode},
+		{"attribute_code", f.AttributeCode},
+	} {
+		if ref.code != nil {
+			data[ref.key] = *ref.code
+			subject = *ref.code
+		}
+	}
+	if f.AgentName != nil {
+		data["agent_name"] = *f.AgentName
+	}
+	Notify(ctx, db, Event{Type: FeedbackSubmitted, Subject: subject, Data: data})
+}
+
+// NotifyAdminAction emits admin.action for something done with the admin
+// role; action names it (agent.register, model_log.read, ...) and subject
+// is what it was done to
+func NotifyAdminAction(ctx context.Context, db sqlx.ExecerContext, action, subject string, data map[string]interface{}) {
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	data["action"] = action
+	Notify(ctx, db, Event{Type: AdminAction, Subject: subject, Data: data})
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
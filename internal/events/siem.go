@@ -0,0 +1,209 @@
+package events
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+)
+
+// cefSeverities maps event types to their CEF severity (0-10)
+var cefSeverities = map[string]int{
+	ValidationFailed: 6,
+	CaseDeclined:     5,
+	AdminAction:      5,
+	CaseApproved:     3,
+	EvidenceAccessed: 3,
+}
+
+// cefNames are the CEF names of event types; others use the type
+var cefNames = map[string]string{
+	ValidationFailed: "Case validation failed",
+	CaseApproved:     "Case approved",
+	CaseDeclined:     "Case declined",
+	EvidenceAccessed: "Evidence accessed",
+	AdminAction:      "Admin action",
+}
+
+// SIEMSink sends deliveries to a syslog receiver as RFC 5424 messages
+// whose body is a CEF record or the JSON envelope
+type SIEMSink struct {
+	cfg      config.SIEMConfig
+	timeout  time.Duration
+	hostname string
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// NewSIEMSink creates the SIEM sink; the receiver is dialled on first publish
+func NewSIEMSink(cfg config.SIEMConfig, timeout time.Duration) *SIEMSink {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+	return &SIEMSink{cfg: cfg, timeout: timeout, hostname: hostname}
+}
+
+// Publish sends one syslog message. A failed connection is dropped so the
+// next attempt dials again.
+func (s *SIEMSink) Publish(ctx context.Context, d Delivery) (int, error) {
+	msg, err := s.format(d)
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		dialer := &net.Dialer{Timeout: s.timeout}
+		var conn net.Conn
+		if s.cfg.Network == "tls" {
+			conn, err = (&tls.Dialer{NetDialer: dialer}).DialContext(ctx, "tcp", s.cfg.Addr)
+		} else {
+			conn, err = dialer.DialContext(ctx, s.cfg.Network, s.cfg.Addr)
+		}
+		if err != nil {
+			return 0, fmt.Errorf("siem connect failed: %w", err)
+		}
+		s.conn = conn
+	}
+
+	if s.cfg.Network != "udp" {
+		msg = append(msg, '\n')
+	}
+	_ = s.conn.SetWriteDeadline(time.Now().Add(s.timeout))
+	if _, err := s.conn.Write(msg); err != nil {
+		s.conn.Close()
+		s.conn = nil
+		return 0, fmt.Errorf("siem write failed: %w", err)
+	}
+	return 0, nil
+}
+
+// Close closes the connection
+func (s *SIEMSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// format renders a delivery as an RFC 5424 syslog message
+func (s *SIEMSink) format(d Delivery) ([]byte, error) {
+	var e Event
+	if err := json.Unmarshal(d.Payload, &e); err != nil {
+		return nil, fmt.Errorf("failed to decode %s event: %w", d.EventType, err)
+	}
+
+	// syslog severity: 4 warning, 5 notice, 6 informational
+	severity := 6
+	switch level := cefSeverity(e.Type); {
+	case level >= 6:
+		severity = 4
+	case level >= 5:
+		severity = 5
+	}
+
+	body := string(d.Payload)
+	if s.cfg.Format == "cef" {
+		body = cef(e)
+	}
+	header := fmt.Sprintf("<%d>1 %s %s %s %d %s - ",
+		s.cfg.Facility*8+severity, e.OccurredAt.UTC().Format(time.RFC3339Nano),
+		s.hostname, syslogField(s.cfg.AppName), os.Getpid(), syslogField(e.Type))
+	return []byte(header + body), nil
+}
+
+// cefSeverity returns the CEF severity of an event type, 3 when unlisted
+func cefSeverity(eventType string) int {
+	if level, ok := cefSeverities[eventType]; ok {
+		return level
+	}
+	return 3
+}
+
+// cef renders an event as an ArcSight Common Event Format record
+func cef(e Event) string {
+	name := cefNames[e.Type]
+	if name == "" {
+		name = e.Type
+	}
+
+	ext := []string{
+		"rt=" + strconv.FormatInt(e.OccurredAt.UnixMilli(), 10),
+		"externalId=" + cefValue(e.ID),
+	}
+	if e.Actor.Name != "" {
+		ext = append(ext, "suser="+cefValue(e.Actor.Name))
+	}
+	if e.Actor.Source != "" {
+		ext = append(ext, "cs1Label=actorSource", "cs1="+cefValue(e.Actor.Source))
+	}
+	if e.Subject != "" {
+		ext = append(ext, "cs2Label=subject", "cs2="+cefValue(e.Subject))
+	}
+	if e.CaseName != "" {
+		ext = append(ext, "cs3Label=caseName", "cs3="+cefValue(e.CaseName))
+	}
+	if e.Version != 0 {
+		ext = append(ext, "cn1Label=caseVersion", "cn1="+strconv.Itoa(e.Version))
+	}
+	if e.Hash != "" {
+		ext = append(ext, "cs4Label=caseHash", "cs4="+cefValue(e.Hash))
+	}
+	if len(e.Data) > 0 {
+		keys := make([]string, 0, len(e.Data))
+		for k := range e.Data {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		parts := make([]string, len(keys))
+		for i, k := range keys {
+			v, _ := json.Marshal(e.Data[k])
+			parts[i] = k + "=" + string(v)
+		}
+		ext = append(ext, "msg="+cefValue(strings.Join(parts, " ")))
+	}
+
+	return fmt.Sprintf("CEF:0|KYC-DSL|kyc-dsl|1.0|%s|%s|%d|%s",
+		cefHeader(e.Type), cefHeader(name), cefSeverity(e.Type), strings.Join(ext, " "))
+}
+
+// cefHeader escapes a CEF header field
+func cefHeader(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\n", " ", "\r", " ").Replace(s)
+}
+
+// cefValue escapes a CEF extension value
+func cefValue(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\n", `\n`, "\r", `\r`).Replace(s)
+}
+
+// syslogField makes s a valid syslog header field: printable ASCII without
+// spaces, "-" when empty
+func syslogField(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r <= ' ' || r > '~' {
+			return '_'
+		}
+		return r
+	}, s)
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,186 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/segmentio/kafka-go"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+)
+
+// Headers sent with every delivery, as HTTP headers for webhooks and as
+// message headers for Kafka and NATS. The webhook signature is
+// "sha256=" + hex(HMAC-SHA256(secret, timestamp + "." + body)), so a
+// receiver can reject replayed payloads by their timestamp.
+const (
+	HeaderEvent     = "X-KYC-Event"
+	HeaderEventID   = "X-KYC-Event-ID"
+	HeaderDelivery  = "X-KYC-Delivery"
+	HeaderTimestamp = "X-KYC-Timestamp"
+	HeaderSignature = "X-KYC-Signature"
+)
+
+// Sink publishes deliveries to one kind of destination
+type Sink interface {
+	// Publish sends a delivery's payload, returning the response status
+	// where the transport has one (HTTP) and 0 otherwise
+	Publish(ctx context.Context, d Delivery) (int, error)
+	Close() error
+}
+
+// Sign returns the signature header value of body sent at timestamp
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// deliveryHeaders returns the headers of a delivery sent now
+func deliveryHeaders(d Delivery) map[string]string {
+	return map[string]string{
+		HeaderEvent:     d.EventType,
+		HeaderEventID:   d.EventID,
+		HeaderDelivery:  strconv.FormatInt(d.ID, 10),
+		HeaderTimestamp: strconv.FormatInt(time.Now().Unix(), 10),
+	}
+}
+
+// WebhookSink posts deliveries to their URL, signed with the secret of
+// their endpoint
+type WebhookSink struct {
+	client  *http.Client
+	secrets map[string]string
+}
+
+// NewWebhookSink creates the webhook sink
+func NewWebhookSink(cfg config.WebhookConfig) *WebhookSink {
+	return &WebhookSink{client: &http.Client{Timeout: cfg.Timeout}, secrets: cfg.Secrets}
+}
+
+// Publish posts a delivery; any answer but 2xx is an error
+func (s *WebhookSink) Publish(ctx context.Context, d Delivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, err
+	}
+	headers := deliveryHeaders(d)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "KYC-DSL-Webhooks/1.0")
+	if secret := s.secrets[d.Endpoint]; secret != "" {
+		req.Header.Set(HeaderSignature, Sign(secret, headers[HeaderTimestamp], d.Payload))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("endpoint answered %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// Close releases idle connections
+func (s *WebhookSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
+
+// KafkaSink produces deliveries to the events topic, keyed by subject so
+// the events of a case land on one partition in order
+type KafkaSink struct {
+	writer *kafka.Writer
+}
+
+// NewKafkaSink creates the Kafka sink; brokers are dialled on first publish
+func NewKafkaSink(cfg config.KafkaConfig, timeout time.Duration) *KafkaSink {
+	return &KafkaSink{writer: &kafka.Writer{
+		Addr:         kafka.TCP(cfg.Brokers...),
+		Topic:        cfg.Topic,
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+		WriteTimeout: timeout,
+		// The dispatcher retries with its own backoff and logs each attempt
+		MaxAttempts: 1,
+	}}
+}
+
+// Publish produces one message and waits for every in-sync replica
+func (s *KafkaSink) Publish(ctx context.Context, d Delivery) (int, error) {
+	msg := kafka.Message{Key: []byte(d.Subject), Value: d.Payload}
+	for k, v := range deliveryHeaders(d) {
+		msg.Headers = append(msg.Headers, kafka.Header{Key: k, Value: []byte(v)})
+	}
+	if err := s.writer.WriteMessages(ctx, msg); err != nil {
+		return 0, fmt.Errorf("kafka produce failed: %w", err)
+	}
+	return 0, nil
+}
+
+// Close flushes and closes the producer
+func (s *KafkaSink) Close() error {
+	return s.writer.Close()
+}
+
+// NATSSink publishes deliveries under the subject prefix followed by the
+// event type
+type NATSSink struct {
+	cfg     config.NATSConfig
+	timeout time.Duration
+	conn    *nats.Conn
+}
+
+// NewNATSSink creates the NATS sink; the server is connected on first publish
+func NewNATSSink(cfg config.NATSConfig, timeout time.Duration) *NATSSink {
+	return &NATSSink{cfg: cfg, timeout: timeout}
+}
+
+// Publish publishes one message and flushes it to the server
+func (s *NATSSink) Publish(ctx context.Context, d Delivery) (int, error) {
+	if s.conn == nil {
+		conn, err := nats.Connect(s.cfg.URL, nats.Name("kyc-dsl-events"), nats.Timeout(s.timeout))
+		if err != nil {
+			return 0, fmt.Errorf("nats connect failed: %w", err)
+		}
+		s.conn = conn
+	}
+	msg := nats.NewMsg(s.cfg.SubjectPrefix + "." + d.EventType)
+	msg.Data = d.Payload
+	for k, v := range deliveryHeaders(d) {
+		msg.Header.Set(k, v)
+	}
+	if err := s.conn.PublishMsg(msg); err != nil {
+		return 0, fmt.Errorf("nats publish failed: %w", err)
+	}
+	flushCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	if err := s.conn.FlushWithContext(flushCtx); err != nil {
+		return 0, fmt.Errorf("nats flush failed: %w", err)
+	}
+	return 0, nil
+}
+
+// Close drains and closes the connection
+func (s *NATSSink) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+	return s.conn.Drain()
+}
@@ -0,0 +1,587 @@
+package evidence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	openai "github.com/sashabaranov/go-openai"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+	"github.com/adamtc007/KYC-DSL/internal/actor"
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/modellog"
+	"github.com/adamtc007/KYC-DSL/internal/rag"
+)
+
+var (
+	// ErrNoText is returned when proposing values from evidence whose text
+	// has not been extracted
+	ErrNoText = errors.New("evidence text not extracted")
+	// ErrCandidateNotFound is returned for an unknown candidate id
+	ErrCandidateNotFound = errors.New("attribute candidate not found")
+	// ErrCandidateDecided is returned when confirming or rejecting a
+	// candidate that is no longer pending
+	ErrCandidateDecided = errors.New("attribute candidate already decided")
+)
+
+// attributePrompt confines the model to the quoted excerpts: every value
+// must be stated by an excerpt it cites, and nothing is guessed
+const attributePrompt = `You extract KYC attribute values from excerpts of a client document.
+You are given the attributes to extract, each with its definition and data type, and numbered excerpts of the document.
+For each attribute, give the value as the excerpts state it, normalized to its data type (dates as YYYY-MM-DD, countries as ISO 3166 alpha-2 codes, one of the allowed values when listed).
+Use only the excerpts. Never guess or use outside knowledge: when no excerpt states the value, return null for it.
+Answer with a JSON object: {"candidates": [{"attribute": "CODE", "value": "..." or null, "confidence": 0.0 to 1.0, "sources": [excerpt numbers], "quote": "the words of the excerpt stating the value"}]}.`
+
+// maxPageExcerpt caps the text of a page quoted to the model when the
+// evidence has no embedded sections
+const maxPageExcerpt = 4000
+
+// unverifiedConfidence caps the confidence of a value whose quote is not
+// found in the excerpts it cites
+const unverifiedConfidence = 0.5
+
+// targetAttribute is an attribute to extract with its definition
+type targetAttribute struct {
+	Code            string         `db:"code"`
+	Name            string         `db:"name"`
+	Description     string         `db:"description"`
+	DataType        string         `db:"data_type"`
+	BusinessContext string         `db:"business_context"`
+	Synonyms        pq.StringArray `db:"synonyms"`
+	DomainValues    pq.StringArray `db:"domain_values"`
+}
+
+// queryText is the text embedded to retrieve the excerpts of an attribute
+func (a targetAttribute) queryText() string {
+	parts := []string{a.Code, a.Name}
+	if a.BusinessContext != "" {
+		parts = append(parts, a.BusinessContext)
+	} else if a.Description != "" {
+		parts = append(parts, a.Description)
+	}
+	if len(a.Synonyms) > 0 {
+		parts = append(parts, strings.Join(a.Synonyms, ", "))
+	}
+	return strings.Join(parts, ". ")
+}
+
+// AttributeProposal is the outcome of proposing attribute values from
+// evidence
+type AttributeProposal struct {
+	EvidenceID int64                      `json:"evidence_id"`
+	CaseName   string                     `json:"case_name"`
+	Model      string                     `json:"model"`
+	Candidates []model.AttributeCandidate `json:"candidates"`
+	// NotFound are the requested attributes no excerpt gave a value for
+	NotFound []string `json:"not_found"`
+	// Superseded counts pending candidates replaced by this proposal
+	Superseded int `json:"superseded"`
+}
+
+// AttributeExtractor proposes attribute values from the extracted text of
+// evidence: it retrieves the excerpts closest to each attribute, asks the
+// chat model for values citing them and stores the answers as pending
+// candidates. Values reach the case only when an analyst confirms them.
+type AttributeExtractor struct {
+	db       *sqlx.DB
+	embedder *rag.Embedder
+	client   *openai.Client
+	model    string
+	cfg      config.AttributeExtractionConfig
+	log      *modellog.Logger
+}
+
+// NewAttributeExtractor creates an extractor from the OpenAI and evidence
+// sections of config.Current
+func NewAttributeExtractor(db *sqlx.DB, embedder *rag.Embedder) (*AttributeExtractor, error) {
+	cfg := config.Current()
+	if cfg.OpenAI.APIKey == "" {
+		return nil, fmt.Errorf("OPENAI_API_KEY not set")
+	}
+	clientConfig := openai.DefaultConfig(cfg.OpenAI.APIKey)
+	clientConfig.HTTPClient = &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)}
+	return &AttributeExtractor{
+		db:       db,
+		embedder: embedder,
+		client:   openai.NewClientWithConfig(clientConfig),
+		model:    cfg.OpenAI.ChatModel,
+		cfg:      cfg.Evidence.Attributes,
+	}, nil
+}
+
+// WithLog records the prompts and responses of the extractor
+func (x *AttributeExtractor) WithLog(l *modellog.Logger) *AttributeExtractor {
+	x.log = l
+	return x
+}
+
+// Model returns the chat model proposing values
+func (x *AttributeExtractor) Model() string {
+	return x.model
+}
+
+// excerpt is a numbered passage of evidence quoted to the model
+type excerpt struct {
+	model.CandidateCitation
+	text string
+}
+
+// Propose asks for the values of attributeCodes in the text of evidence,
+// by default the attributes its document evidences (kyc_attr_doc_links),
+// and stores them as pending candidates in place of the pending candidates
+// of the same evidence and attributes
+func (x *AttributeExtractor) Propose(ctx context.Context, evidenceID int64, attributeCodes []string) (*AttributeProposal, error) {
+	var e struct {
+		CaseName     string `db:"case_name"`
+		DocumentCode string `db:"document_code"`
+		TextStatus   string `db:"text_status"`
+	}
+	err := x.db.GetContext(ctx, &e, `
+		SELECT case_name, COALESCE(document_code, '') AS document_code, text_status
+		  FROM kyc_evidence WHERE id = $1`, evidenceID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %d", ErrNotFound, evidenceID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load evidence %d: %w", evidenceID, err)
+	}
+	if e.TextStatus != model.EvidenceTextExtracted {
+		return nil, fmt.Errorf("%w: evidence %d is %s", ErrNoText, evidenceID, e.TextStatus)
+	}
+
+	attributes, err := x.targets(ctx, e.DocumentCode, attributeCodes)
+	if err != nil {
+		return nil, err
+	}
+	excerpts, refs, err := x.retrieve(ctx, evidenceID, attributes)
+	if err != nil {
+		return nil, err
+	}
+	if len(excerpts) == 0 {
+		return nil, fmt.Errorf("%w: evidence %d has no text", ErrNoText, evidenceID)
+	}
+
+	answers, err := x.ask(ctx, attributes, excerpts, refs)
+	if err != nil {
+		return nil, err
+	}
+	proposal := &AttributeProposal{EvidenceID: evidenceID, CaseName: e.CaseName, Model: x.model}
+	candidates, notFound := groundAnswers(attributes, excerpts, answers)
+	proposal.NotFound = notFound
+
+	codes := make([]string, 0, len(attributes))
+	for _, a := range attributes {
+		codes = append(codes, a.Code)
+	}
+	tx, err := x.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE kyc_attribute_candidates SET status = 'superseded'
+		 WHERE evidence_id = $1 AND status = 'pending' AND attribute_code = ANY($2)`,
+		evidenceID, pq.Array(codes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to supersede candidates of evidence %d: %w", evidenceID, err)
+	}
+	n, _ := res.RowsAffected()
+	proposal.Superseded = int(n)
+
+	proposedBy := actor.FromContext(ctx).Name
+	for i := range candidates {
+		c := &candidates[i]
+		c.CaseName, c.EvidenceID, c.Model, c.Status, c.ProposedBy = e.CaseName, evidenceID, x.model, model.CandidatePending, proposedBy
+		citations, err := json.Marshal(c.Citations)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode citations: %w", err)
+		}
+		err = tx.QueryRowxContext(ctx, `
+			INSERT INTO kyc_attribute_candidates
+				(case_name, evidence_id, attribute_code, value, confidence, citations, quote, quote_verified, model, proposed_by)
+			VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), $8, $9, NULLIF($10, ''))
+			RETURNING id, proposed_at`,
+			c.CaseName, c.EvidenceID, c.AttributeCode, c.Value, c.Confidence, citations, c.Quote, c.QuoteVerified,
+			c.Model, c.ProposedBy).Scan(&c.ID, &c.ProposedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to store candidate for %s: %w", c.AttributeCode, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit candidates of evidence %d: %w", evidenceID, err)
+	}
+	proposal.Candidates = candidates
+	return proposal, nil
+}
+
+// targets loads the definitions of the attributes to extract
+func (x *AttributeExtractor) targets(ctx context.Context, documentCode string, codes []string) ([]targetAttribute, error) {
+	seen := map[string]bool{}
+	var wanted []string
+	for _, code := range codes {
+		if code = strings.TrimSpace(code); code != "" && !seen[code] {
+			seen[code] = true
+			wanted = append(wanted, code)
+		}
+	}
+	if len(wanted) == 0 {
+		if documentCode == "" {
+			return nil, fmt.Errorf("%w: name the attributes to extract; the evidence has no document code", ErrInvalid)
+		}
+		if err := x.db.SelectContext(ctx, &wanted, `
+			SELECT DISTINCT attribute_code FROM kyc_attr_doc_links
+			 WHERE document_code = $1 AND attribute_code IS NOT NULL ORDER BY attribute_code`, documentCode); err != nil {
+			return nil, fmt.Errorf("failed to list attributes of %s: %w", documentCode, err)
+		}
+		if len(wanted) == 0 {
+			return nil, fmt.Errorf("%w: document %s evidences no attributes; name the attributes to extract", ErrInvalid, documentCode)
+		}
+	}
+	if len(wanted) > x.cfg.MaxAttributes {
+		return nil, fmt.Errorf("%w: %d attributes requested, at most %d per request", ErrInvalid, len(wanted), x.cfg.MaxAttributes)
+	}
+
+	var attributes []targetAttribute
+	if err := x.db.SelectContext(ctx, &attributes, `
+		SELECT a.code, a.name, COALESCE(a.description, '') AS description,
+		       COALESCE(m.data_type, '') AS data_type, COALESCE(m.business_context, '') AS business_context,
+		       COALESCE(m.synonyms, '{}') AS synonyms, COALESCE(m.domain_values, '{}') AS domain_values
+		  FROM kyc_attributes a
+		  LEFT JOIN kyc_attribute_metadata m ON m.attribute_code = a.code
+		 WHERE a.code = ANY($1)
+		 ORDER BY a.code`, pq.Array(wanted)); err != nil {
+		return nil, fmt.Errorf("failed to load attributes: %w", err)
+	}
+	if len(attributes) < len(wanted) {
+		known := map[string]bool{}
+		for _, a := range attributes {
+			known[a.Code] = true
+		}
+		var unknown []string
+		for _, code := range wanted {
+			if !known[code] {
+				unknown = append(unknown, code)
+			}
+		}
+		return nil, fmt.Errorf("%w: unknown attributes %s", ErrInvalid, strings.Join(unknown, ", "))
+	}
+	return attributes, nil
+}
+
+// retrieve numbers the excerpts of evidence quoted to the model and returns
+// the excerpts of each attribute by ref: the sections closest to the
+// attribute, or every page when the evidence has no embedded sections
+func (x *AttributeExtractor) retrieve(ctx context.Context, evidenceID int64, attributes []targetAttribute) ([]excerpt, map[string][]int, error) {
+	var sectionCount int
+	if err := x.db.GetContext(ctx, &sectionCount, `
+		SELECT COUNT(*) FROM kyc_document_sections WHERE evidence_id = $1 AND embedding IS NOT NULL`, evidenceID); err != nil {
+		return nil, nil, fmt.Errorf("failed to count sections of evidence %d: %w", evidenceID, err)
+	}
+
+	var excerpts []excerpt
+	refs := map[string][]int{}
+	if sectionCount == 0 {
+		var pages []model.EvidencePage
+		if err := x.db.SelectContext(ctx, &pages, `
+			SELECT evidence_id, page_number, text FROM kyc_evidence_pages
+			 WHERE evidence_id = $1 ORDER BY page_number`, evidenceID); err != nil {
+			return nil, nil, fmt.Errorf("failed to load text of evidence %d: %w", evidenceID, err)
+		}
+		var all []int
+		for _, p := range pages {
+			text := p.Text
+			if len(text) > maxPageExcerpt {
+				text = text[:maxPageExcerpt]
+			}
+			ref := len(excerpts) + 1
+			excerpts = append(excerpts, excerpt{CandidateCitation: model.CandidateCitation{Ref: ref, Page: p.PageNumber}, text: text})
+			all = append(all, ref)
+		}
+		for _, a := range attributes {
+			refs[a.Code] = all
+		}
+		return excerpts, refs, nil
+	}
+
+	queries := make([]string, 0, len(attributes))
+	for _, a := range attributes {
+		queries = append(queries, a.queryText())
+	}
+	vectors, err := x.embedder.GenerateEmbeddingsFromTexts(ctx, queries)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to embed attributes: %w", err)
+	}
+	bySection := map[int64]int{}
+	for i, a := range attributes {
+		var sections []struct {
+			ID   int64  `db:"id"`
+			Page int    `db:"page_number"`
+			Text string `db:"text_excerpt"`
+		}
+		if err := x.db.SelectContext(ctx, &sections, `
+			SELECT id, COALESCE(page_number, 0) AS page_number, text_excerpt
+			  FROM kyc_document_sections
+			 WHERE evidence_id = $1 AND embedding IS NOT NULL
+			 ORDER BY embedding <=> $2::vector
+			 LIMIT $3`, evidenceID, pq.Array(vectors[i]), x.cfg.SectionsPerAttribute); err != nil {
+			return nil, nil, fmt.Errorf("failed to retrieve sections for %s: %w", a.Code, err)
+		}
+		for _, s := range sections {
+			ref, ok := bySection[s.ID]
+			if !ok {
+				ref = len(excerpts) + 1
+				bySection[s.ID] = ref
+				excerpts = append(excerpts, excerpt{
+					CandidateCitation: model.CandidateCitation{Ref: ref, SectionID: s.ID, Page: s.Page},
+					text:              s.Text,
+				})
+			}
+			refs[a.Code] = append(refs[a.Code], ref)
+		}
+	}
+	return excerpts, refs, nil
+}
+
+// answer is one value proposed by the model
+type answer struct {
+	Attribute  string  `json:"attribute"`
+	Value      *string `json:"value"`
+	Confidence float64 `json:"confidence"`
+	Sources    []int   `json:"sources"`
+	Quote      string  `json:"quote"`
+}
+
+// ask sends the attributes and excerpts to the chat model
+func (x *AttributeExtractor) ask(ctx context.Context, attributes []targetAttribute, excerpts []excerpt, refs map[string][]int) ([]answer, error) {
+	var sb strings.Builder
+	sb.WriteString("Attributes:\n")
+	for _, a := range attributes {
+		fmt.Fprintf(&sb, "- %s (%s", a.Code, a.Name)
+		if a.DataType != "" {
+			fmt.Fprintf(&sb, "; %s", a.DataType)
+		}
+		sb.WriteString(")")
+		if a.BusinessContext != "" {
+			fmt.Fprintf(&sb, ": %s", a.BusinessContext)
+		} else if a.Description != "" {
+			fmt.Fprintf(&sb, ": %s", a.Description)
+		}
+		if len(a.Synonyms) > 0 {
+			fmt.Fprintf(&sb, " Also called: %s.", strings.Join(a.Synonyms, ", "))
+		}
+		if len(a.DomainValues) > 0 {
+			fmt.Fprintf(&sb, " Allowed values: %s.", strings.Join(a.DomainValues, ", "))
+		}
+		nums := make([]string, 0, len(refs[a.Code]))
+		for _, ref := range refs[a.Code] {
+			nums = append(nums, fmt.Sprint(ref))
+		}
+		fmt.Fprintf(&sb, " Relevant excerpts: %s\n", strings.Join(nums, ", "))
+	}
+	sb.WriteString("\nExcerpts:\n")
+	for _, ex := range excerpts {
+		if ex.Page > 0 {
+			fmt.Fprintf(&sb, "[%d] (page %d) %s\n", ex.Ref, ex.Page, ex.text)
+		} else {
+			fmt.Fprintf(&sb, "[%d] %s\n", ex.Ref, ex.text)
+		}
+	}
+	prompt := sb.String()
+
+	start := time.Now()
+	resp, err := x.client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+		Model:          x.model,
+		Temperature:    0,
+		ResponseFormat: &openai.ChatCompletionResponseFormat{Type: openai.ChatCompletionResponseFormatTypeJSONObject},
+		Messages: []openai.ChatCompletionMessage{
+			{Role: openai.ChatMessageRoleSystem, Content: attributePrompt},
+			{Role: openai.ChatMessageRoleUser, Content: prompt},
+		},
+	})
+	ex := modellog.Exchange{
+		Feature:          model.ModelFeatureAttributeExtraction,
+		Model:            x.model,
+		SystemPrompt:     attributePrompt,
+		Prompt:           prompt,
+		PromptTokens:     resp.Usage.PromptTokens,
+		CompletionTokens: resp.Usage.CompletionTokens,
+		Latency:          time.Since(start),
+		Err:              err,
+	}
+	if err == nil && len(resp.Choices) > 0 {
+		ex.Response = resp.Choices[0].Message.Content
+	}
+	x.log.Record(ctx, ex)
+
+	if err != nil {
+		return nil, fmt.Errorf("chat completion failed: %w", err)
+	}
+	if len(resp.Choices) == 0 {
+		return nil, fmt.Errorf("chat completion returned no answer")
+	}
+	var out struct {
+		Candidates []answer `json:"candidates"`
+	}
+	if err := json.Unmarshal([]byte(resp.Choices[0].Message.Content), &out); err != nil {
+		return nil, fmt.Errorf("chat completion returned invalid JSON: %w", err)
+	}
+	return out.Candidates, nil
+}
+
+// groundAnswers keeps the answers for requested attributes that cite at
+// least one quoted excerpt, attaching the excerpts as citations. A value
+// whose quote is not found in its excerpts keeps at most
+// unverifiedConfidence.
+func groundAnswers(attributes []targetAttribute, excerpts []excerpt, answers []answer) ([]model.AttributeCandidate, []string) {
+	requested := map[string]bool{}
+	for _, a := range attributes {
+		requested[a.Code] = true
+	}
+	found := map[string]bool{}
+	seen := map[string]bool{}
+	var candidates []model.AttributeCandidate
+	for _, ans := range answers {
+		if !requested[ans.Attribute] || ans.Value == nil {
+			continue
+		}
+		value := strings.TrimSpace(*ans.Value)
+		key := ans.Attribute + "\x00" + value
+		if value == "" || seen[key] {
+			continue
+		}
+		c := model.AttributeCandidate{
+			AttributeCode: ans.Attribute,
+			Value:         value,
+			Confidence:    min(max(ans.Confidence, 0), 1),
+			Quote:         strings.TrimSpace(ans.Quote),
+		}
+		quote := normalizeText(c.Quote)
+		for _, ref := range ans.Sources {
+			if ref < 1 || ref > len(excerpts) {
+				continue
+			}
+			ex := excerpts[ref-1]
+			c.Citations = append(c.Citations, model.CandidateCitation{
+				Ref: ex.Ref, SectionID: ex.SectionID, Page: ex.Page, Excerpt: ex.text,
+			})
+			if quote != "" && strings.Contains(normalizeText(ex.text), quote) {
+				c.QuoteVerified = true
+			}
+		}
+		if len(c.Citations) == 0 {
+			continue // an uncited value is not grounded in the evidence
+		}
+		if !c.QuoteVerified {
+			c.Confidence = min(c.Confidence, unverifiedConfidence)
+		}
+		seen[key] = true
+		found[ans.Attribute] = true
+		candidates = append(candidates, c)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].AttributeCode < candidates[j].AttributeCode })
+
+	notFound := []string{}
+	for _, a := range attributes {
+		if !found[a.Code] {
+			notFound = append(notFound, a.Code)
+		}
+	}
+	return candidates, notFound
+}
+
+// normalizeText lowercases text and collapses its whitespace for quote
+// matching
+func normalizeText(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+// candidateRow is a kyc_attribute_candidates row with its citations encoded
+type candidateRow struct {
+	model.AttributeCandidate
+	CitationsJSON []byte `db:"citations"`
+}
+
+const candidateColumns = `id, case_name, evidence_id, attribute_code, value, confidence::float8 AS confidence,
+	citations, COALESCE(quote, '') AS quote, quote_verified, model, status, COALESCE(proposed_by, '') AS proposed_by,
+	proposed_at, COALESCE(confirmed_value, '') AS confirmed_value, COALESCE(decided_by, '') AS decided_by,
+	COALESCE(decision_note, '') AS decision_note, decided_at`
+
+func (r candidateRow) toModel() (model.AttributeCandidate, error) {
+	c := r.AttributeCandidate
+	c.Citations = []model.CandidateCitation{}
+	if err := json.Unmarshal(r.CitationsJSON, &c.Citations); err != nil {
+		return c, fmt.Errorf("invalid citations of candidate %d: %w", c.ID, err)
+	}
+	return c, nil
+}
+
+// Candidates returns the attribute values proposed for a case, optionally
+// only those with a status, newest first
+func (s *Service) Candidates(ctx context.Context, caseName, status string) ([]model.AttributeCandidate, error) {
+	var rows []candidateRow
+	if err := s.db.SelectContext(ctx, &rows, `
+		SELECT `+candidateColumns+` FROM kyc_attribute_candidates
+		 WHERE case_name = $1 AND ($2 = '' OR status = $2)
+		 ORDER BY proposed_at DESC, id DESC`, caseName, status); err != nil {
+		return nil, fmt.Errorf("failed to list attribute candidates of %s: %w", caseName, err)
+	}
+	out := make([]model.AttributeCandidate, 0, len(rows))
+	for _, r := range rows {
+		c, err := r.toModel()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+// DecideCandidate confirms or rejects a pending candidate for the actor in
+// ctx. A confirmation may correct the value; value is ignored on
+// rejection.
+func (s *Service) DecideCandidate(ctx context.Context, id int64, confirm bool, value, note string) (*model.AttributeCandidate, error) {
+	status := model.CandidateRejected
+	if confirm {
+		status = model.CandidateConfirmed
+	} else {
+		value = ""
+	}
+	var row candidateRow
+	err := s.db.GetContext(ctx, &row, `
+		UPDATE kyc_attribute_candidates
+		   SET status = $2,
+		       confirmed_value = CASE WHEN $2 = 'confirmed' THEN COALESCE(NULLIF($3, ''), value) END,
+		       decided_by = NULLIF($4, ''), decision_note = NULLIF($5, ''), decided_at = CURRENT_TIMESTAMP
+		 WHERE id = $1 AND status = 'pending'
+		RETURNING `+candidateColumns, id, status, strings.TrimSpace(value), actor.FromContext(ctx).Name, note)
+	if errors.Is(err, sql.ErrNoRows) {
+		var current string
+		err := s.db.GetContext(ctx, &current, `SELECT status FROM kyc_attribute_candidates WHERE id = $1`, id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %d", ErrCandidateNotFound, id)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to load attribute candidate %d: %w", id, err)
+		}
+		return nil, fmt.Errorf("%w: candidate %d is %s", ErrCandidateDecided, id, current)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decide attribute candidate %d: %w", id, err)
+	}
+	c, err := row.toModel()
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -0,0 +1,393 @@
+// Package evidence stores the documents collected for a case, such as a
+// certificate of incorporation evidencing a registered address. Files are
+// hashed as they are uploaded and stored once per sha256 in a Store (the
+// local filesystem, S3 or minio); kyc_evidence records what each upload
+// evidences and when it expires, and every upload, view and download is
+// written to kyc_evidence_access with its actor and queued as an
+// evidence.accessed event (internal/events).
+package evidence
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/adamtc007/KYC-DSL/internal/actor"
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/events"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+var (
+	// ErrNotFound is returned for an unknown evidence id
+	ErrNotFound = errors.New("evidence not found")
+	// ErrCaseNotFound is returned when uploading evidence for an unknown case
+	ErrCaseNotFound = errors.New("case not found")
+	// ErrInvalid is returned for uploads with missing or unknown metadata
+	ErrInvalid = errors.New("invalid evidence")
+	// ErrTooLarge is returned for uploads over the configured size limit
+	ErrTooLarge = errors.New("evidence file too large")
+)
+
+// Upload describes a file being uploaded
+type Upload struct {
+	CaseName      string
+	DocumentCode  string
+	AttributeCode string
+	FileName      string
+	// ContentType is sniffed from the content when empty
+	ContentType string
+	IssuedAt    *time.Time
+	// ExpiresAt defaults to IssuedAt (the upload date when unknown) plus
+	// ValidityDays, or else the document's validity_days or validity_years
+	ExpiresAt *time.Time
+	// ValidityDays is how long this document is accepted for, e.g. 90 for
+	// a proof of address
+	ValidityDays int
+	Description  string
+}
+
+// Service uploads, lists and retrieves evidence
+type Service struct {
+	db      *sqlx.DB
+	store   Store
+	maxSize int64
+}
+
+// NewService creates a service storing content in the store selected by cfg
+func NewService(db *sqlx.DB, cfg config.EvidenceConfig) (*Service, error) {
+	store, err := NewStore(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &Service{db: db, store: store, maxSize: int64(cfg.MaxSizeMB) << 20}, nil
+}
+
+// MaxSize is the largest accepted upload in bytes
+func (s *Service) MaxSize() int64 {
+	return s.maxSize
+}
+
+const evidenceColumns = `id, case_name, COALESCE(document_code, '') AS document_code,
+	COALESCE(attribute_code, '') AS attribute_code, file_name, content_type, size_bytes, sha256,
+	storage_driver, storage_key, issued_at, expires_at, COALESCE(validity_days, 0) AS validity_days,
+	COALESCE(description, '') AS description, text_status, COALESCE(text_extractor, '') AS text_extractor,
+	COALESCE(text_error, '') AS text_error,
+	COALESCE(actor, '') AS actor, COALESCE(actor_source, '') AS actor_source,
+	COALESCE(client_ip, '') AS client_ip, uploaded_at, erased_at`
+
+// Upload validates the metadata, hashes and stores the content read from r
+// and records the evidence and its upload. Content already stored under
+// the same hash for evidence not erased is not stored again.
+func (s *Service) Upload(ctx context.Context, u Upload, r io.Reader) (*model.Evidence, error) {
+	validityYears, err := s.validate(ctx, &u)
+	if err != nil {
+		return nil, err
+	}
+
+	// Spool to a temporary file to learn the size and hash before storing
+	tmp, err := os.CreateTemp("", "kyc-evidence-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to buffer upload: %w", err)
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck
+	defer tmp.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hash), io.LimitReader(r, s.maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upload: %w", err)
+	}
+	if size > s.maxSize {
+		return nil, fmt.Errorf("%w: over %d MB", ErrTooLarge, s.maxSize>>20)
+	}
+	if size == 0 {
+		return nil, fmt.Errorf("%w: %s is empty", ErrInvalid, u.FileName)
+	}
+	digest := hex.EncodeToString(hash.Sum(nil))
+	if u.ContentType == "" {
+		head := make([]byte, 512)
+		n, _ := tmp.ReadAt(head, 0)
+		u.ContentType = http.DetectContentType(head[:n])
+	}
+	if u.ExpiresAt == nil && (u.ValidityDays > 0 || validityYears > 0) {
+		issued := time.Now().UTC().Truncate(24 * time.Hour)
+		if u.IssuedAt != nil {
+			issued = *u.IssuedAt
+		}
+		expires := issued.AddDate(validityYears, 0, u.ValidityDays)
+		u.ExpiresAt = &expires
+	}
+
+	key := "sha256/" + digest[:2] + "/" + digest
+	var stored bool
+	if err := s.db.GetContext(ctx, &stored, `
+		SELECT EXISTS (SELECT 1 FROM kyc_evidence WHERE storage_driver = $1 AND storage_key = $2 AND erased_at IS NULL)`,
+		s.store.Name(), key); err != nil {
+		return nil, fmt.Errorf("failed to look up evidence content: %w", err)
+	}
+	if !stored {
+		if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+			return nil, fmt.Errorf("failed to read upload: %w", err)
+		}
+		if err := s.store.Put(ctx, key, tmp, size, u.ContentType); err != nil {
+			return nil, err
+		}
+	}
+
+	a := actor.FromContext(ctx)
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	var e model.Evidence
+	if err := tx.GetContext(ctx, &e, `
+		INSERT INTO kyc_evidence (case_name, document_code, attribute_code, file_name, content_type, size_bytes,
+		                          sha256, storage_driver, storage_key, issued_at, expires_at, validity_days,
+		                          description, actor, actor_source, client_ip)
+		VALUES ($1, NULLIF($2, ''), NULLIF($3, ''), $4, $5, $6, $7, $8, $9, $10, $11, NULLIF($12, 0),
+		        NULLIF($13, ''), NULLIF($14, ''), NULLIF($15, ''), NULLIF($16, ''))
+		RETURNING `+evidenceColumns,
+		u.CaseName, u.DocumentCode, u.AttributeCode, u.FileName, u.ContentType, size, digest,
+		s.store.Name(), key, u.IssuedAt, u.ExpiresAt, u.ValidityDays, u.Description, a.Name, a.Source, a.ClientIP); err != nil {
+		return nil, fmt.Errorf("failed to record evidence: %w", err)
+	}
+	if err := logAccess(ctx, tx, &e, model.EvidenceUpload); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit evidence: %w", err)
+	}
+	return &e, nil
+}
+
+// validate checks the upload's case, document and attribute and returns
+// the document's validity in years (0 when none is set or it is valid for
+// a number of days, which then becomes the upload's ValidityDays unless it
+// has its own)
+func (s *Service) validate(ctx context.Context, u *Upload) (int, error) {
+	u.CaseName = strings.TrimSpace(u.CaseName)
+	u.DocumentCode = strings.TrimSpace(u.DocumentCode)
+	u.AttributeCode = strings.TrimSpace(u.AttributeCode)
+	u.FileName = strings.TrimSpace(u.FileName)
+	if u.CaseName == "" || u.FileName == "" {
+		return 0, fmt.Errorf("%w: case and file name are required", ErrInvalid)
+	}
+	if u.IssuedAt != nil && u.ExpiresAt != nil && u.ExpiresAt.Before(*u.IssuedAt) {
+		return 0, fmt.Errorf("%w: expires before it was issued", ErrInvalid)
+	}
+	if u.ValidityDays < 0 || (u.ValidityDays > 0 && u.ExpiresAt != nil) {
+		return 0, fmt.Errorf("%w: give either an expiry date or a positive validity in days", ErrInvalid)
+	}
+
+	var exists bool
+	if err := s.db.GetContext(ctx, &exists, `SELECT EXISTS (SELECT 1 FROM kyc_cases WHERE name = $1)`, u.CaseName); err != nil {
+		return 0, fmt.Errorf("failed to look up case %s: %w", u.CaseName, err)
+	}
+	if !exists {
+		return 0, fmt.Errorf("%w: %s", ErrCaseNotFound, u.CaseName)
+	}
+
+	var validity struct {
+		Years int `db:"validity_years"`
+		Days  int `db:"validity_days"`
+	}
+	if u.DocumentCode != "" {
+		err := s.db.GetContext(ctx, &validity, `
+			SELECT COALESCE(validity_years, 0) AS validity_years, COALESCE(validity_days, 0) AS validity_days
+			  FROM kyc_documents WHERE code = $1`, u.DocumentCode)
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("%w: unknown document code %s", ErrInvalid, u.DocumentCode)
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to look up document %s: %w", u.DocumentCode, err)
+		}
+	}
+	if u.AttributeCode != "" {
+		if err := s.db.GetContext(ctx, &exists, `SELECT EXISTS (SELECT 1 FROM kyc_attributes WHERE code = $1)`, u.AttributeCode); err != nil {
+			return 0, fmt.Errorf("failed to look up attribute %s: %w", u.AttributeCode, err)
+		}
+		if !exists {
+			return 0, fmt.Errorf("%w: unknown attribute code %s", ErrInvalid, u.AttributeCode)
+		}
+	}
+	switch {
+	case u.ExpiresAt != nil || u.ValidityDays > 0:
+		return 0, nil
+	case validity.Days > 0:
+		u.ValidityDays = validity.Days
+		return 0, nil
+	}
+	return validity.Years, nil
+}
+
+// List returns a case's evidence, newest first
+func (s *Service) List(ctx context.Context, caseName string) ([]model.Evidence, error) {
+	out := []model.Evidence{}
+	if err := s.db.SelectContext(ctx, &out, `
+		SELECT `+evidenceColumns+` FROM kyc_evidence
+		 WHERE case_name = $1 ORDER BY uploaded_at DESC, id DESC`, caseName); err != nil {
+		return nil, fmt.Errorf("failed to list evidence of %s: %w", caseName, err)
+	}
+	return out, nil
+}
+
+// Get returns the metadata of evidence and records the view
+func (s *Service) Get(ctx context.Context, id int64) (*model.Evidence, error) {
+	e, err := s.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := logAccess(ctx, s.db, e, model.EvidenceView); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Open returns the metadata and content of evidence and records the
+// download; the caller closes the content
+func (s *Service) Open(ctx context.Context, id int64) (*model.Evidence, io.ReadCloser, error) {
+	e, err := s.load(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if e.StorageDriver != s.store.Name() {
+		return nil, nil, fmt.Errorf("evidence %d is stored by the %s driver, not the configured %s", id, e.StorageDriver, s.store.Name())
+	}
+	content, err := s.store.Get(ctx, e.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := logAccess(ctx, s.db, e, model.EvidenceDownload); err != nil {
+		content.Close()
+		return nil, nil, err
+	}
+	return e, content, nil
+}
+
+// AccessLog returns the uploads, views and downloads of evidence, newest
+// first
+func (s *Service) AccessLog(ctx context.Context, id int64) ([]model.EvidenceAccess, error) {
+	if _, err := s.load(ctx, id); err != nil {
+		return nil, err
+	}
+	out := []model.EvidenceAccess{}
+	if err := s.db.SelectContext(ctx, &out, `
+		SELECT id, evidence_id, action, COALESCE(actor, '') AS actor, COALESCE(actor_source, '') AS actor_source,
+		       COALESCE(client_ip, '') AS client_ip, accessed_at
+		  FROM kyc_evidence_access WHERE evidence_id = $1 ORDER BY accessed_at DESC, id DESC`, id); err != nil {
+		return nil, fmt.Errorf("failed to load access log of evidence %d: %w", id, err)
+	}
+	return out, nil
+}
+
+// Verify downloads the stored content of evidence and reports whether it
+// still matches its recorded hash
+func (s *Service) Verify(ctx context.Context, id int64) (bool, error) {
+	e, content, err := s.Open(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	defer content.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, content); err != nil {
+		return false, fmt.Errorf("failed to read evidence %d: %w", id, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)) == e.SHA256, nil
+}
+
+// Erase removes evidence for a data subject (internal/dsr): its extracted
+// text, file name and description, and its content unless evidence not
+// erased shares it. The row and its access log stay, so the evidence is
+// still accounted for.
+func (s *Service) Erase(ctx context.Context, id int64) error {
+	e, err := s.load(ctx, id)
+	if err != nil {
+		return err
+	}
+	if e.StorageDriver != s.store.Name() {
+		return fmt.Errorf("evidence %d is stored by the %s driver, not the configured %s", id, e.StorageDriver, s.store.Name())
+	}
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE kyc_evidence
+		   SET file_name = '[erased]', description = NULL, text_status = $2, text_extractor = NULL,
+		       text_error = NULL, text_updated_at = CURRENT_TIMESTAMP,
+		       erased_at = COALESCE(erased_at, CURRENT_TIMESTAMP)
+		 WHERE id = $1`, id, model.EvidenceTextErased); err != nil {
+		return fmt.Errorf("failed to erase evidence %d: %w", id, err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM kyc_evidence_pages WHERE evidence_id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete the text of evidence %d: %w", id, err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM kyc_document_sections WHERE evidence_id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete the sections of evidence %d: %w", id, err)
+	}
+	var shared bool
+	if err := tx.GetContext(ctx, &shared, `
+		SELECT EXISTS (SELECT 1 FROM kyc_evidence
+		                WHERE storage_driver = $1 AND storage_key = $2 AND erased_at IS NULL AND id <> $3)`,
+		e.StorageDriver, e.StorageKey, id); err != nil {
+		return fmt.Errorf("failed to look up evidence content: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit erasure of evidence %d: %w", id, err)
+	}
+	if shared {
+		return nil
+	}
+	return s.store.Delete(ctx, e.StorageKey)
+}
+
+func (s *Service) load(ctx context.Context, id int64) (*model.Evidence, error) {
+	var e model.Evidence
+	err := s.db.GetContext(ctx, &e, `SELECT `+evidenceColumns+` FROM kyc_evidence WHERE id = $1`, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %d", ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load evidence %d: %w", id, err)
+	}
+	return &e, nil
+}
+
+// logAccess records an access to evidence by the actor in ctx and queues
+// an evidence.accessed event for it
+func logAccess(ctx context.Context, db sqlx.ExecerContext, e *model.Evidence, action string) error {
+	a := actor.FromContext(ctx)
+	if _, err := db.ExecContext(ctx, `
+		INSERT INTO kyc_evidence_access (evidence_id, action, actor, actor_source, client_ip)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, ''))`,
+		e.ID, action, a.Name, a.Source, a.ClientIP); err != nil {
+		return fmt.Errorf("failed to log %s of evidence %d: %w", action, e.ID, err)
+	}
+	return events.Emit(ctx, db, events.Event{
+		Type:     events.EvidenceAccessed,
+		CaseName: e.CaseName,
+		Data: map[string]interface{}{
+			"evidence_id":   e.ID,
+			"action":        action,
+			"document_code": e.DocumentCode,
+			"file_name":     e.FileName,
+			"sha256":        e.SHA256,
+		},
+	})
+}
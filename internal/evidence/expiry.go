@@ -0,0 +1,237 @@
+package evidence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/events"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+// expiringEvidence selects the evidence of case $1 (every case when empty)
+// expiring within $2 days or already expired, leaving out evidence
+// refreshed by another upload for the same case and document (or attribute,
+// for evidence without a document code) that stays valid beyond the window
+const expiringEvidence = `
+	SELECT ` + evidenceColumns + `,
+	       CASE WHEN expires_at < CURRENT_DATE THEN 'EXPIRED' ELSE 'EXPIRING' END AS status,
+	       expires_at - CURRENT_DATE AS days_left,
+	       (SELECT f.flagged_at FROM kyc_evidence_expiry_flags f WHERE f.evidence_id = kyc_evidence.id) AS flagged_at
+	  FROM kyc_evidence
+	 WHERE expires_at IS NOT NULL AND expires_at <= CURRENT_DATE + $2::int AND erased_at IS NULL
+	   AND ($1 = '' OR case_name = $1)
+	   AND NOT EXISTS (
+	       SELECT 1 FROM kyc_evidence r
+	        WHERE r.case_name = kyc_evidence.case_name AND r.id <> kyc_evidence.id
+	          AND COALESCE(r.document_code, r.attribute_code) = COALESCE(kyc_evidence.document_code, kyc_evidence.attribute_code)
+	          AND (r.expires_at IS NULL OR r.expires_at > CURRENT_DATE + $2::int))
+	 ORDER BY expires_at, id`
+
+// Expiring returns the evidence of a case (every case when caseName is
+// empty) that expires within withinDays days or has expired and has not
+// been refreshed, soonest expiry first
+func Expiring(ctx context.Context, db sqlx.QueryerContext, caseName string, withinDays int) ([]model.ExpiringEvidence, error) {
+	out := []model.ExpiringEvidence{}
+	if err := sqlx.SelectContext(ctx, db, &out, expiringEvidence, caseName, withinDays); err != nil {
+		return nil, fmt.Errorf("failed to list expiring evidence: %w", err)
+	}
+	return out, nil
+}
+
+// ExpirySummary counts the outcome of one expiry check
+type ExpirySummary struct {
+	Expiring int `json:"expiring"`
+	Expired  int `json:"expired"`
+	// Flagged counts evidence newly flagged or whose status changed
+	Flagged int `json:"flagged"`
+	// Cleared counts flags removed because the evidence was refreshed or
+	// its expiry date moved out of the warning period
+	Cleared     int  `json:"cleared"`
+	SummarySent bool `json:"summary_sent"`
+}
+
+// Checker flags evidence nearing or past its expiry date in
+// kyc_evidence_expiry_flags and, once a day, emits a
+// documents.expiry.summary event listing the flagged evidence by case
+type Checker struct {
+	db          *sqlx.DB
+	interval    time.Duration
+	warningDays int
+	summaryHour int
+}
+
+// NewChecker creates a checker flagging evidence cfg.WarningDays days
+// before it expires
+func NewChecker(db *sqlx.DB, cfg config.EvidenceExpiryConfig) *Checker {
+	return &Checker{db: db, interval: cfg.Interval, warningDays: cfg.WarningDays, summaryHour: cfg.SummaryHour}
+}
+
+// Run checks expiry dates every interval until ctx is cancelled
+func (c *Checker) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		summary, err := c.RunOnce(ctx, time.Now().UTC())
+		if err != nil {
+			slog.Warn("⚠️  Evidence expiry check failed", "error", err)
+		} else if summary.Flagged > 0 || summary.Cleared > 0 || summary.SummarySent {
+			slog.Info("📅 Evidence expiry check", "expiring", summary.Expiring, "expired", summary.Expired,
+				"flagged", summary.Flagged, "cleared", summary.Cleared, "summary_sent", summary.SummarySent)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce brings the expiry flags up to date and sends the daily summary
+// when now is past the summary hour and none was sent today. The summary
+// is only sent when evidence is flagged.
+func (c *Checker) RunOnce(ctx context.Context, now time.Time) (ExpirySummary, error) {
+	var summary ExpirySummary
+
+	tx, err := c.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return summary, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	expiring, err := Expiring(ctx, tx, "", c.warningDays)
+	if err != nil {
+		return summary, err
+	}
+	ids := make([]int64, 0, len(expiring))
+	for _, e := range expiring {
+		ids = append(ids, e.ID)
+		if e.Status == model.EvidenceExpired {
+			summary.Expired++
+		} else {
+			summary.Expiring++
+		}
+
+		var inserted bool
+		err := tx.GetContext(ctx, &inserted, `
+			INSERT INTO kyc_evidence_expiry_flags (evidence_id, case_name, status, expires_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (evidence_id) DO UPDATE
+			   SET status = EXCLUDED.status, expires_at = EXCLUDED.expires_at,
+			       status_changed_at = CURRENT_TIMESTAMP
+			 WHERE (kyc_evidence_expiry_flags.status, kyc_evidence_expiry_flags.expires_at)
+			       IS DISTINCT FROM (EXCLUDED.status, EXCLUDED.expires_at)
+			RETURNING (xmax = 0) AS inserted`,
+			e.ID, e.CaseName, e.Status, e.ExpiresAt)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			// unchanged
+		case err != nil:
+			return summary, fmt.Errorf("failed to flag evidence %d: %w", e.ID, err)
+		default:
+			summary.Flagged++
+			slog.Warn("📅 Evidence expiry flagged", "evidence_id", e.ID, "case_name", e.CaseName,
+				"document_code", e.DocumentCode, "status", e.Status, "expires_at", e.ExpiresAt.Format("2006-01-02"),
+				"new", inserted)
+		}
+	}
+
+	cleared, err := tx.ExecContext(ctx, `
+		DELETE FROM kyc_evidence_expiry_flags WHERE NOT (evidence_id = ANY($1))`, pq.Array(ids))
+	if err != nil {
+		return summary, fmt.Errorf("failed to clear expiry flags: %w", err)
+	}
+	n, _ := cleared.RowsAffected()
+	summary.Cleared = int(n)
+
+	if len(expiring) > 0 && now.Hour() >= c.summaryHour {
+		summary.SummarySent, err = c.sendSummary(ctx, tx, now, expiring)
+		if err != nil {
+			return summary, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return summary, fmt.Errorf("failed to commit expiry flags: %w", err)
+	}
+	return summary, nil
+}
+
+// sendSummary records the summary of now's date and emits it, unless one
+// was already recorded for the date
+func (c *Checker) sendSummary(ctx context.Context, tx *sqlx.Tx, now time.Time, expiring []model.ExpiringEvidence) (bool, error) {
+	type caseExpiry struct {
+		CaseName  string                   `json:"case_name"`
+		Expiring  int                      `json:"expiring"`
+		Expired   int                      `json:"expired"`
+		Documents []map[string]interface{} `json:"documents"`
+	}
+	byCase := map[string]*caseExpiry{}
+	var expiringCount, expiredCount int
+	for _, e := range expiring {
+		ce, ok := byCase[e.CaseName]
+		if !ok {
+			ce = &caseExpiry{CaseName: e.CaseName}
+			byCase[e.CaseName] = ce
+		}
+		if e.Status == model.EvidenceExpired {
+			ce.Expired++
+			expiredCount++
+		} else {
+			ce.Expiring++
+			expiringCount++
+		}
+		ce.Documents = append(ce.Documents, map[string]interface{}{
+			"evidence_id":    e.ID,
+			"document_code":  e.DocumentCode,
+			"attribute_code": e.AttributeCode,
+			"file_name":      e.FileName,
+			"expires_at":     e.ExpiresAt.Format("2006-01-02"),
+			"days_left":      e.DaysLeft,
+			"status":         e.Status,
+		})
+	}
+	cases := make([]*caseExpiry, 0, len(byCase))
+	for _, ce := range byCase {
+		cases = append(cases, ce)
+	}
+	sort.Slice(cases, func(i, j int) bool { return cases[i].CaseName < cases[j].CaseName })
+
+	date := now.Format("2006-01-02")
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO kyc_evidence_expiry_summaries (summary_date, cases, expiring, expired)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (summary_date) DO NOTHING`,
+		date, len(cases), expiringCount, expiredCount)
+	if err != nil {
+		return false, fmt.Errorf("failed to record expiry summary: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return false, nil
+	}
+	err = events.Emit(ctx, tx, events.Event{
+		Type:    events.DocumentsExpirySummary,
+		Subject: "documents",
+		Data: map[string]interface{}{
+			"date":         date,
+			"warning_days": c.warningDays,
+			"case_count":   len(cases),
+			"expiring":     expiringCount,
+			"expired":      expiredCount,
+			"cases":        cases,
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,248 @@
+package evidence
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/ingest"
+)
+
+// Page is the text of one page of a document, numbered from 1
+type Page struct {
+	Number int
+	Text   string
+}
+
+// Extractor extracts the text of a file page by page
+type Extractor interface {
+	// Name identifies the extractor in kyc_evidence.text_extractor
+	Name() string
+	// Supports reports whether the extractor reads files of a content type
+	Supports(contentType string) bool
+	// Extract returns the text of the file at path; pages without text may
+	// be left out
+	Extract(ctx context.Context, path, contentType string) ([]Page, error)
+}
+
+// NewExtractors returns the extractors named in cfg, in order
+func NewExtractors(cfg config.TextExtractionConfig) ([]Extractor, error) {
+	var extractors []Extractor
+	for _, name := range cfg.Extractors {
+		switch name {
+		case "builtin":
+			extractors = append(extractors, BuiltinExtractor{})
+		case "pdftotext":
+			extractors = append(extractors, &CommandExtractor{
+				name:    "pdftotext",
+				command: cfg.PDFToText,
+				args:    func(path string) []string { return []string{"-layout", "-enc", "UTF-8", path, "-"} },
+				types:   []string{"application/pdf"},
+				timeout: cfg.CommandTimeout,
+			})
+		case "tesseract":
+			extractors = append(extractors, &CommandExtractor{
+				name:    "tesseract",
+				command: cfg.Tesseract,
+				args:    func(path string) []string { return []string{path, "stdout", "-l", cfg.Languages} },
+				types:   []string{"image/png", "image/jpeg", "image/tiff", "image/bmp", "image/gif", "image/webp"},
+				timeout: cfg.CommandTimeout,
+			})
+		case "api":
+			extractors = append(extractors, NewAPIExtractor(cfg.API.URL, cfg.API.APIKey, cfg.API.Timeout))
+		default:
+			return nil, fmt.Errorf("unknown text extractor %q (expected builtin, pdftotext, tesseract or api)", name)
+		}
+	}
+	return extractors, nil
+}
+
+// mediaType strips the parameters of a content type
+func mediaType(contentType string) string {
+	t, _, _ := strings.Cut(contentType, ";")
+	return strings.ToLower(strings.TrimSpace(t))
+}
+
+// BuiltinExtractor reads the text layer of PDFs with the parser of
+// kycctl ingest-document; scanned PDFs have none and need OCR
+type BuiltinExtractor struct{}
+
+// Name identifies the extractor
+func (BuiltinExtractor) Name() string {
+	return "builtin"
+}
+
+// Supports reports whether contentType is a PDF
+func (BuiltinExtractor) Supports(contentType string) bool {
+	return mediaType(contentType) == "application/pdf"
+}
+
+// Extract returns the lines of each page of the PDF at path
+func (BuiltinExtractor) Extract(_ context.Context, path, _ string) ([]Page, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	blocks, err := ingest.ExtractPDF(data)
+	if err != nil {
+		return nil, err
+	}
+	lines := map[int][]string{}
+	for _, b := range blocks {
+		lines[b.Page] = append(lines[b.Page], b.Text)
+	}
+	pages := make([]Page, 0, len(lines))
+	for n, text := range lines {
+		pages = append(pages, Page{Number: n, Text: strings.Join(text, "\n")})
+	}
+	sort.Slice(pages, func(i, j int) bool { return pages[i].Number < pages[j].Number })
+	return pages, nil
+}
+
+// CommandExtractor runs a command-line tool (pdftotext, tesseract) that
+// writes the text of a file to stdout with pages separated by form feeds
+type CommandExtractor struct {
+	name    string
+	command string
+	args    func(path string) []string
+	types   []string
+	timeout time.Duration
+}
+
+// Name identifies the extractor
+func (e *CommandExtractor) Name() string {
+	return e.name
+}
+
+// Supports reports whether the tool reads contentType
+func (e *CommandExtractor) Supports(contentType string) bool {
+	t := mediaType(contentType)
+	for _, supported := range e.types {
+		if t == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// Extract runs the tool on the file at path
+func (e *CommandExtractor) Extract(ctx context.Context, path, _ string) ([]Page, error) {
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, e.command, e.args(path)...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return nil, fmt.Errorf("%s is not installed (%s)", e.name, e.command)
+		}
+		return nil, fmt.Errorf("%s failed: %w: %s", e.name, err, strings.TrimSpace(stderr.String()))
+	}
+	return splitPages(stdout.String()), nil
+}
+
+// splitPages splits text at form feeds into numbered pages, leaving out
+// pages without text
+func splitPages(text string) []Page {
+	var pages []Page
+	for i, page := range strings.Split(text, "\f") {
+		if page = strings.TrimSpace(page); page != "" {
+			pages = append(pages, Page{Number: i + 1, Text: page})
+		}
+	}
+	return pages
+}
+
+// APIExtractor sends files to an external OCR service. It posts the
+// content with its Content-Type (and ?filename=) and expects
+// {"pages": [{"page": 1, "text": "..."}]} in return.
+type APIExtractor struct {
+	url    string
+	apiKey string
+	client *http.Client
+}
+
+// NewAPIExtractor creates an extractor for the service at url
+func NewAPIExtractor(url, apiKey string, timeout time.Duration) *APIExtractor {
+	return &APIExtractor{url: url, apiKey: apiKey, client: &http.Client{Timeout: timeout}}
+}
+
+// Name identifies the extractor
+func (e *APIExtractor) Name() string {
+	return "api"
+}
+
+// Supports reports whether contentType is a PDF or an image
+func (e *APIExtractor) Supports(contentType string) bool {
+	t := mediaType(contentType)
+	return t == "application/pdf" || (strings.HasPrefix(t, "image/") && t != "image/svg+xml")
+}
+
+// Extract posts the file at path to the service
+func (e *APIExtractor) Extract(ctx context.Context, path, contentType string) ([]Page, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	defer f.Close()
+
+	endpoint := e.url
+	if u, err := url.Parse(e.url); err == nil {
+		q := u.Query()
+		q.Set("filename", filepath.Base(path))
+		u.RawQuery = q.Encode()
+		endpoint = u.String()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OCR request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	if e.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+e.apiKey)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("OCR request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("OCR service returned %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+
+	var out struct {
+		Pages []struct {
+			Page int    `json:"page"`
+			Text string `json:"text"`
+		} `json:"pages"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("invalid OCR response: %w", err)
+	}
+	var pages []Page
+	for i, p := range out.Pages {
+		if p.Page <= 0 {
+			p.Page = i + 1
+		}
+		if text := strings.TrimSpace(p.Text); text != "" {
+			pages = append(pages, Page{Number: p.Page, Text: text})
+		}
+	}
+	return pages, nil
+}
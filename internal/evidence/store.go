@@ -0,0 +1,122 @@
+package evidence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+)
+
+// ErrObjectNotFound is returned by a Store for a key it does not hold
+var ErrObjectNotFound = errors.New("evidence object not found")
+
+// Store is a storage driver for evidence content, addressed by key
+type Store interface {
+	// Name identifies the driver in kyc_evidence.storage_driver
+	Name() string
+	// Put stores size bytes read from r under key
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get opens the content stored under key
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the content stored under key; deleting a key that is
+	// not stored is not an error
+	Delete(ctx context.Context, key string) error
+}
+
+// NewStore creates the store selected by cfg.Driver
+func NewStore(cfg config.EvidenceConfig) (Store, error) {
+	switch cfg.Driver {
+	case "", "local":
+		return NewLocalStore(cfg.Dir), nil
+	case "s3":
+		return NewS3Store("s3", cfg.S3, cfg.S3.PathStyle)
+	case "minio":
+		return NewS3Store("minio", cfg.S3, true)
+	}
+	return nil, fmt.Errorf("unknown evidence driver %q (expected local, s3 or minio)", cfg.Driver)
+}
+
+// LocalStore keeps evidence in a directory of the local filesystem
+type LocalStore struct {
+	dir string
+}
+
+// NewLocalStore creates a store rooted at dir, which is created on first use
+func NewLocalStore(dir string) *LocalStore {
+	return &LocalStore{dir: dir}
+}
+
+// Name identifies the driver
+func (s *LocalStore) Name() string {
+	return "local"
+}
+
+// path maps a key to a file under the root, rejecting keys that escape it
+func (s *LocalStore) path(key string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(key))
+	if clean == "." || filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid evidence key %q", key)
+	}
+	return filepath.Join(s.dir, clean), nil
+}
+
+// Put writes the content to a temporary file and renames it into place, so
+// readers never see a partial file
+func (s *LocalStore) Put(_ context.Context, key string, r io.Reader, _ int64, _ string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create evidence directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create evidence file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck // gone after the rename
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write evidence file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write evidence file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to store evidence file: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the file stored under key
+func (s *LocalStore) Delete(_ context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete evidence file: %w", err)
+	}
+	return nil
+}
+
+// Get opens the file stored under key
+func (s *LocalStore) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrObjectNotFound, key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open evidence file: %w", err)
+	}
+	return f, nil
+}
@@ -0,0 +1,375 @@
+package evidence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/ingest"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/rag"
+)
+
+// ErrExtracting is returned when evidence is already being extracted
+var ErrExtracting = errors.New("evidence text is being extracted")
+
+// claimedEvidence is evidence claimed for extraction
+type claimedEvidence struct {
+	model.Evidence
+	Attempts int `db:"text_attempts"`
+}
+
+// claimPending marks up to $1 pending evidence extracting and returns it.
+// Evidence whose last attempt failed waits 5 minutes per attempt, and
+// evidence left extracting by a worker that stopped is claimed again after
+// 15 minutes.
+const claimPending = `
+	UPDATE kyc_evidence
+	   SET text_status = 'extracting', text_attempts = text_attempts + 1, text_updated_at = CURRENT_TIMESTAMP
+	 WHERE id IN (
+	       SELECT id FROM kyc_evidence
+	        WHERE (text_status = 'pending'
+	               AND (text_updated_at IS NULL OR text_updated_at < CURRENT_TIMESTAMP - make_interval(mins => 5 * text_attempts)))
+	           OR (text_status = 'extracting' AND text_updated_at < CURRENT_TIMESTAMP - INTERVAL '15 minutes')
+	        ORDER BY id LIMIT $1
+	          FOR UPDATE SKIP LOCKED)
+	RETURNING ` + evidenceColumns + `, text_attempts`
+
+// TextResult is the outcome of extracting the text of one upload
+type TextResult struct {
+	EvidenceID int64  `json:"evidence_id"`
+	CaseName   string `json:"case_name"`
+	Status     string `json:"status"`
+	Extractor  string `json:"extractor,omitempty"`
+	Pages      int    `json:"pages"`
+	// Sections are the excerpts embedded into kyc_document_sections; only
+	// evidence of a known document is embedded
+	Sections int    `json:"sections"`
+	Error    string `json:"error,omitempty"`
+}
+
+// TextWorker extracts the text of uploaded evidence with the configured
+// extractors, stores it per page in kyc_evidence_pages and embeds the text
+// of evidence with a document code into kyc_document_sections, where the
+// section search of its case finds it
+type TextWorker struct {
+	db         *sqlx.DB
+	store      Store
+	embedder   *rag.Embedder
+	extractors []Extractor
+	cfg        config.TextExtractionConfig
+	ingestion  config.IngestionConfig
+}
+
+// NewTextWorker creates a worker reading content from the store selected by
+// cfg and chunking text as kycctl ingest-document does
+func NewTextWorker(db *sqlx.DB, embedder *rag.Embedder, cfg config.EvidenceConfig, ingestion config.IngestionConfig) (*TextWorker, error) {
+	store, err := NewStore(cfg)
+	if err != nil {
+		return nil, err
+	}
+	extractors, err := NewExtractors(cfg.Extraction)
+	if err != nil {
+		return nil, err
+	}
+	return &TextWorker{
+		db:         db,
+		store:      store,
+		embedder:   embedder,
+		extractors: extractors,
+		cfg:        cfg.Extraction,
+		ingestion:  ingestion,
+	}, nil
+}
+
+// Run extracts a batch of pending evidence every interval until ctx is
+// cancelled
+func (w *TextWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		results, err := w.RunOnce(ctx)
+		if err != nil {
+			slog.Warn("⚠️  Evidence text extraction failed", "error", err)
+		}
+		for _, r := range results {
+			if r.Error != "" {
+				slog.Warn("⚠️  Evidence text not extracted", "evidence_id", r.EvidenceID, "case_name", r.CaseName,
+					"status", r.Status, "error", r.Error)
+			} else {
+				slog.Info("📄 Evidence text extracted", "evidence_id", r.EvidenceID, "case_name", r.CaseName,
+					"status", r.Status, "extractor", r.Extractor, "pages", r.Pages, "sections", r.Sections)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce claims up to BatchSize pending uploads and extracts them. Uploads
+// that fail go back to pending until MaxAttempts is reached. While the
+// embeddings provider is unavailable nothing is claimed: uploads wait,
+// pending, for it to return.
+func (w *TextWorker) RunOnce(ctx context.Context) ([]TextResult, error) {
+	if !w.embedder.Available() {
+		return nil, nil
+	}
+	var claimed []claimedEvidence
+	if err := w.db.SelectContext(ctx, &claimed, claimPending, w.cfg.BatchSize); err != nil {
+		return nil, fmt.Errorf("failed to claim pending evidence: %w", err)
+	}
+	results := make([]TextResult, 0, len(claimed))
+	for _, e := range claimed {
+		r, err := w.process(ctx, e)
+		if err != nil {
+			return results, err
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+// Extract extracts the text of one upload now, whatever its status,
+// replacing the text extracted before
+func (w *TextWorker) Extract(ctx context.Context, id int64) (TextResult, error) {
+	var e claimedEvidence
+	err := w.db.GetContext(ctx, &e, `
+		UPDATE kyc_evidence
+		   SET text_status = 'extracting', text_attempts = 1, text_error = NULL, text_updated_at = CURRENT_TIMESTAMP
+		 WHERE id = $1
+		   AND (text_status <> 'extracting' OR text_updated_at < CURRENT_TIMESTAMP - INTERVAL '15 minutes')
+		RETURNING `+evidenceColumns+`, text_attempts`, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		var exists bool
+		if err := w.db.GetContext(ctx, &exists, `SELECT EXISTS (SELECT 1 FROM kyc_evidence WHERE id = $1)`, id); err != nil {
+			return TextResult{}, fmt.Errorf("failed to load evidence %d: %w", id, err)
+		}
+		if !exists {
+			return TextResult{}, fmt.Errorf("%w: %d", ErrNotFound, id)
+		}
+		return TextResult{}, fmt.Errorf("%w: %d", ErrExtracting, id)
+	}
+	if err != nil {
+		return TextResult{}, fmt.Errorf("failed to claim evidence %d: %w", id, err)
+	}
+	return w.process(ctx, e)
+}
+
+// process extracts and stores the text of claimed evidence, recording the
+// outcome on it. The error is only set when the outcome cannot be recorded.
+func (w *TextWorker) process(ctx context.Context, e claimedEvidence) (TextResult, error) {
+	res := TextResult{EvidenceID: e.ID, CaseName: e.CaseName}
+
+	var supporting []Extractor
+	for _, x := range w.extractors {
+		if x.Supports(e.ContentType) {
+			supporting = append(supporting, x)
+		}
+	}
+	if len(supporting) == 0 {
+		res.Status = model.EvidenceTextUnsupported
+		return res, w.finish(ctx, e, res, nil, nil)
+	}
+
+	extractor, pages, err := w.extract(ctx, e, supporting)
+	if err != nil {
+		return w.fail(ctx, e, res, err)
+	}
+	res.Status = model.EvidenceTextExtracted
+	res.Extractor = extractor
+	res.Pages = len(pages)
+	if len(pages) == 0 {
+		res.Error = "no text found"
+	}
+
+	var sections []model.DocumentSection
+	if e.DocumentCode != "" && len(pages) > 0 {
+		sections, err = w.sections(ctx, e.DocumentCode, pages)
+		if err != nil {
+			return w.fail(ctx, e, res, err)
+		}
+		res.Sections = len(sections)
+	}
+	return res, w.finish(ctx, e, res, pages, sections)
+}
+
+// extract copies the content of evidence to a temporary file and runs the
+// extractors on it in turn until one finds text
+func (w *TextWorker) extract(ctx context.Context, e claimedEvidence, extractors []Extractor) (string, []Page, error) {
+	if e.StorageDriver != w.store.Name() {
+		return "", nil, fmt.Errorf("evidence %d is stored by the %s driver, not the configured %s", e.ID, e.StorageDriver, w.store.Name())
+	}
+	content, err := w.store.Get(ctx, e.StorageKey)
+	if err != nil {
+		return "", nil, err
+	}
+	defer content.Close()
+
+	// Keep the extension; tesseract and OCR services go by it
+	tmp, err := os.CreateTemp("", "kyc-evidence-text-*"+filepath.Ext(e.FileName))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to buffer evidence %d: %w", e.ID, err)
+	}
+	defer os.Remove(tmp.Name())
+	_, err = io.Copy(tmp, content)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to buffer evidence %d: %w", e.ID, err)
+	}
+
+	var errs []error
+	for _, x := range extractors {
+		pages, err := x.Extract(ctx, tmp.Name(), e.ContentType)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if len(pages) > 0 {
+			return x.Name(), pages, nil
+		}
+	}
+	if len(errs) > 0 {
+		return "", nil, errors.Join(errs...)
+	}
+	return extractors[len(extractors)-1].Name(), nil, nil
+}
+
+// sections chunks the text of evidence pages as kycctl ingest-document
+// chunks regulatory text and embeds the excerpts in batches
+func (w *TextWorker) sections(ctx context.Context, documentCode string, pages []Page) ([]model.DocumentSection, error) {
+	var blocks []ingest.Block
+	for _, p := range pages {
+		for _, line := range strings.Split(p.Text, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				blocks = append(blocks, ingest.Block{Page: p.Number, Text: line})
+			}
+		}
+	}
+	sections := ingest.Sections(documentCode, blocks, w.ingestion.ChunkTokens, w.ingestion.OverlapTokens)
+	for start := 0; start < len(sections); start += w.ingestion.BatchSize {
+		end := min(start+w.ingestion.BatchSize, len(sections))
+		texts := make([]string, 0, end-start)
+		for _, s := range sections[start:end] {
+			texts = append(texts, s.ToEmbeddingText())
+		}
+		embeddings, err := w.embedder.GenerateEmbeddingsFromTexts(ctx, texts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to embed sections %d-%d: %w", start+1, end, err)
+		}
+		for i, emb := range embeddings {
+			sections[start+i].Embedding = emb
+		}
+	}
+	return sections, nil
+}
+
+// finish replaces the pages and sections of evidence and records the
+// outcome of its extraction
+func (w *TextWorker) finish(ctx context.Context, e claimedEvidence, res TextResult, pages []Page, sections []model.DocumentSection) error {
+	tx, err := w.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM kyc_evidence_pages WHERE evidence_id = $1`, e.ID); err != nil {
+		return fmt.Errorf("failed to delete pages of evidence %d: %w", e.ID, err)
+	}
+	for _, p := range pages {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO kyc_evidence_pages (evidence_id, page_number, text) VALUES ($1, $2, $3)
+			ON CONFLICT (evidence_id, page_number) DO UPDATE SET text = kyc_evidence_pages.text || E'\n' || EXCLUDED.text`,
+			e.ID, p.Number, p.Text); err != nil {
+			return fmt.Errorf("failed to store page %d of evidence %d: %w", p.Number, e.ID, err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM kyc_document_sections WHERE evidence_id = $1`, e.ID); err != nil {
+		return fmt.Errorf("failed to delete sections of evidence %d: %w", e.ID, err)
+	}
+	for _, s := range sections {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO kyc_document_sections
+				(document_code, section_number, section_title, text_excerpt, page_number, embedding, evidence_id, case_name)
+			VALUES ($1, NULLIF($2, ''), NULLIF($3, ''), $4, NULLIF($5, 0), $6, $7, $8)`,
+			s.DocumentCode, s.SectionNumber, s.SectionTitle, s.TextExcerpt, s.PageNumber,
+			pq.Array(s.Embedding), e.ID, e.CaseName); err != nil {
+			return fmt.Errorf("failed to store section of evidence %d: %w", e.ID, err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE kyc_evidence
+		   SET text_status = $2, text_extractor = NULLIF($3, ''), text_error = NULLIF($4, ''),
+		       text_updated_at = CURRENT_TIMESTAMP
+		 WHERE id = $1`,
+		e.ID, res.Status, res.Extractor, res.Error); err != nil {
+		return fmt.Errorf("failed to update evidence %d: %w", e.ID, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit text of evidence %d: %w", e.ID, err)
+	}
+	return nil
+}
+
+// fail records a failed attempt: the evidence goes back to pending, or is
+// marked failed once MaxAttempts attempts have been made
+func (w *TextWorker) fail(ctx context.Context, e claimedEvidence, res TextResult, cause error) (TextResult, error) {
+	// An embeddings outage does not use up an attempt
+	refund := 0
+	if errors.Is(cause, rag.ErrUnavailable) {
+		refund = 1
+	}
+	res.Status = model.EvidenceTextPending
+	if refund == 0 && e.Attempts >= w.cfg.MaxAttempts {
+		res.Status = model.EvidenceTextFailed
+	}
+	res.Extractor, res.Pages, res.Sections = "", 0, 0
+	res.Error = cause.Error()
+	if _, err := w.db.ExecContext(ctx, `
+		UPDATE kyc_evidence
+		   SET text_status = $2, text_error = $3, text_attempts = GREATEST(text_attempts - $4, 0),
+		       text_updated_at = CURRENT_TIMESTAMP
+		 WHERE id = $1`,
+		e.ID, res.Status, res.Error, refund); err != nil {
+		return res, fmt.Errorf("failed to update evidence %d: %w", e.ID, err)
+	}
+	return res, nil
+}
+
+// Pages returns the text extracted from evidence, page by page, and records
+// the view
+func (s *Service) Pages(ctx context.Context, id int64) (*model.Evidence, []model.EvidencePage, error) {
+	e, err := s.load(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	pages := []model.EvidencePage{}
+	if err := s.db.SelectContext(ctx, &pages, `
+		SELECT evidence_id, page_number, text FROM kyc_evidence_pages
+		 WHERE evidence_id = $1 ORDER BY page_number`, id); err != nil {
+		return nil, nil, fmt.Errorf("failed to load text of evidence %d: %w", id, err)
+	}
+	if err := logAccess(ctx, s.db, e, model.EvidenceView); err != nil {
+		return nil, nil, err
+	}
+	return e, pages, nil
+}
@@ -0,0 +1,150 @@
+package feedbackpolicy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/adamtc007/KYC-DSL/internal/agents"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+// Apply runs a tenant's policy on a stored feedback. Feedback that passes the
+// gates is marked gated; it demotes its attribute for the query and flags the
+// attribute for metadata review once FlagAfter gated feedbacks accumulated
+// since the last flag was resolved. The outcome explains what was done.
+func (r *Repo) Apply(ctx context.Context, feedbackID int, fb model.Feedback) (model.FeedbackOutcome, error) {
+	outcome := model.FeedbackOutcome{Tenant: fb.Tenant, Actions: []model.FeedbackAction{}}
+	policy, err := r.Policy(ctx, fb.Tenant)
+	if err != nil {
+		return outcome, err
+	}
+
+	if outcome.Reason = r.gate(ctx, policy, fb); outcome.Reason != "" {
+		return outcome, nil
+	}
+	outcome.Gated = true
+	if fb.AttributeCode == nil {
+		outcome.Reason = "gated; actions apply to attribute results only"
+		return outcome, nil
+	}
+	attribute := *fb.AttributeCode
+	queryKey := QueryKey(fb.QueryText)
+	agentName := ""
+	if fb.AgentName != nil {
+		agentName = *fb.AgentName
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return outcome, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if _, err := tx.ExecContext(ctx, `UPDATE rag_feedback SET gated = TRUE WHERE id = $1`, feedbackID); err != nil {
+		return outcome, fmt.Errorf("failed to mark feedback gated: %w", err)
+	}
+
+	record := func(action string, penalty *float64) error {
+		var row actionRow
+		err := tx.GetContext(ctx, &row, `
+			INSERT INTO rag_feedback_actions
+				(feedback_id, tenant, action, query_key, attribute_code, document_code, regulation_code,
+				 agent_name, confidence, penalty)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), $9, $10)
+			RETURNING `+actionColumns,
+			feedbackID, fb.Tenant, action, queryKey, fb.AttributeCode, fb.DocumentCode, fb.RegulationCode,
+			agentName, fb.Confidence, penalty)
+		if err != nil {
+			return fmt.Errorf("failed to record %s action: %w", action, err)
+		}
+		outcome.Actions = append(outcome.Actions, row.toModel())
+		return nil
+	}
+
+	if policy.Demote && policy.MaxPenalty > 0 {
+		var penalty float64
+		err := tx.GetContext(ctx, &penalty, `
+			INSERT INTO rag_ranking_penalties (tenant, query_key, attribute_code, penalty)
+			VALUES ($1, $2, $3, LEAST($4::float, $5::float))
+			ON CONFLICT (tenant, query_key, attribute_code) DO UPDATE SET
+				penalty = LEAST(rag_ranking_penalties.penalty + $4::float, $5::float),
+				updated_at = CURRENT_TIMESTAMP
+			RETURNING penalty`,
+			fb.Tenant, queryKey, attribute, policy.DemotePenalty, policy.MaxPenalty)
+		if err != nil {
+			return outcome, fmt.Errorf("failed to demote %s: %w", attribute, err)
+		}
+		if err := record(model.FeedbackActionDemote, &penalty); err != nil {
+			return outcome, err
+		}
+	}
+
+	if policy.Flag {
+		var open bool
+		err := tx.GetContext(ctx, &open, `
+			SELECT EXISTS (
+				SELECT 1 FROM rag_feedback_actions
+				WHERE tenant = $1 AND attribute_code = $2 AND action = 'flag' AND status = 'open'
+			)`, fb.Tenant, attribute)
+		if err != nil {
+			return outcome, fmt.Errorf("failed to check review flags: %w", err)
+		}
+		if !open {
+			var gated int
+			err := tx.GetContext(ctx, &gated, `
+				SELECT COUNT(*) FROM rag_feedback
+				WHERE tenant = $1 AND attribute_code = $2 AND gated
+				  AND created_at > COALESCE((
+					SELECT MAX(resolved_at) FROM rag_feedback_actions
+					WHERE tenant = $1 AND attribute_code = $2 AND action = 'flag' AND status = 'resolved'
+				  ), '-infinity'::timestamp)`,
+				fb.Tenant, attribute)
+			if err != nil {
+				return outcome, fmt.Errorf("failed to count gated feedback: %w", err)
+			}
+			if gated >= policy.FlagAfter {
+				if err := record(model.FeedbackActionFlag, nil); err != nil {
+					return outcome, err
+				}
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return outcome, fmt.Errorf("failed to commit feedback actions: %w", err)
+	}
+	outcome.Reason = "gated"
+	if len(outcome.Actions) == 0 {
+		outcome.Reason = "gated; no action enabled or review already flagged"
+	}
+	return outcome, nil
+}
+
+// gate returns why a feedback only accumulates, or "" when it may act
+func (r *Repo) gate(ctx context.Context, policy model.FeedbackPolicy, fb model.Feedback) string {
+	if fb.Feedback != model.FeedbackSentimentNegative {
+		return "only negative feedback triggers actions"
+	}
+	if fb.Confidence < policy.MinConfidence {
+		return fmt.Sprintf("confidence %.2f is below the threshold %.2f", fb.Confidence, policy.MinConfidence)
+	}
+	if fb.AgentName == nil || *fb.AgentName == "" {
+		return "feedback without an agent only accumulates"
+	}
+	name := *fb.AgentName
+	if len(policy.TrustedAgents) > 0 && !slices.Contains(policy.TrustedAgents, name) {
+		return fmt.Sprintf("agent %q is not trusted by tenant %q", name, policy.Tenant)
+	}
+	agent, err := agents.NewRepo(r.db).Get(ctx, name)
+	switch {
+	case errors.Is(err, agents.ErrNotFound):
+		return fmt.Sprintf("agent %q is not registered", name)
+	case err != nil:
+		return "agent registry unavailable: " + err.Error()
+	case agent.Status != model.AgentStatusActive:
+		return fmt.Sprintf("agent %q is %s", name, agent.Status)
+	}
+	return ""
+}
@@ -0,0 +1,300 @@
+// Package feedbackpolicy gates the effect of RAG feedback per tenant: negative
+// feedback that is confident enough and comes from a trusted agent demotes the
+// result for its query and, once it accumulates on an attribute, flags the
+// attribute's metadata for review. Other feedback only accumulates.
+package feedbackpolicy
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/adamtc007/KYC-DSL/internal/auth"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+var (
+	// ErrNotFound is returned for an unknown action
+	ErrNotFound = errors.New("feedback action not found")
+	// ErrInvalid is returned for a policy that cannot be saved
+	ErrInvalid = errors.New("invalid feedback policy")
+)
+
+// Repo stores policies, actions and ranking penalties
+type Repo struct {
+	db *sqlx.DB
+}
+
+// NewRepo creates a new feedback policy repository
+func NewRepo(db *sqlx.DB) *Repo {
+	return &Repo{db: db}
+}
+
+// QueryKey normalizes query text so that demotions apply to the same query
+// however it is cased or spaced
+func QueryKey(query string) string {
+	return strings.Join(strings.Fields(strings.ToLower(query)), " ")
+}
+
+type policyRow struct {
+	Tenant        string         `db:"tenant"`
+	MinConfidence float64        `db:"min_confidence"`
+	TrustedAgents pq.StringArray `db:"trusted_agents"`
+	Demote        bool           `db:"demote"`
+	DemotePenalty float64        `db:"demote_penalty"`
+	MaxPenalty    float64        `db:"max_penalty"`
+	Flag          bool           `db:"flag"`
+	FlagAfter     int            `db:"flag_after"`
+	UpdatedBy     string         `db:"updated_by"`
+	UpdatedAt     time.Time      `db:"updated_at"`
+}
+
+func (r policyRow) toModel() model.FeedbackPolicy {
+	trusted := []string(r.TrustedAgents)
+	if trusted == nil {
+		trusted = []string{}
+	}
+	return model.FeedbackPolicy{
+		Tenant:        r.Tenant,
+		MinConfidence: r.MinConfidence,
+		TrustedAgents: trusted,
+		Demote:        r.Demote,
+		DemotePenalty: r.DemotePenalty,
+		MaxPenalty:    r.MaxPenalty,
+		Flag:          r.Flag,
+		FlagAfter:     r.FlagAfter,
+		UpdatedBy:     r.UpdatedBy,
+		UpdatedAt:     r.UpdatedAt,
+	}
+}
+
+const policyColumns = `
+	tenant, min_confidence, trusted_agents, demote, demote_penalty, max_penalty,
+	flag, flag_after, COALESCE(updated_by, '') AS updated_by, updated_at
+`
+
+// defaultPolicy applies when even the default row is missing
+var defaultPolicy = model.FeedbackPolicy{
+	Tenant:        auth.DefaultTenant,
+	MinConfidence: 0.8,
+	TrustedAgents: []string{},
+	Demote:        true,
+	DemotePenalty: 0.05,
+	MaxPenalty:    0.3,
+	Flag:          true,
+	FlagAfter:     3,
+}
+
+// Policy returns the policy of a tenant, falling back to the default policy
+func (r *Repo) Policy(ctx context.Context, tenant string) (model.FeedbackPolicy, error) {
+	var rows []policyRow
+	err := r.db.SelectContext(ctx, &rows,
+		`SELECT `+policyColumns+` FROM feedback_action_policies WHERE tenant IN ($1, $2)`,
+		tenant, auth.DefaultTenant)
+	if err != nil {
+		return model.FeedbackPolicy{}, fmt.Errorf("failed to get feedback policy: %w", err)
+	}
+	policy := defaultPolicy
+	for _, row := range rows {
+		if row.Tenant == tenant {
+			return row.toModel(), nil
+		}
+		policy = row.toModel()
+	}
+	policy.Tenant = tenant
+	policy.Inherited = true
+	return policy, nil
+}
+
+// ListPolicies returns every tenant's policy
+func (r *Repo) ListPolicies(ctx context.Context) ([]model.FeedbackPolicy, error) {
+	var rows []policyRow
+	err := r.db.SelectContext(ctx, &rows, `SELECT `+policyColumns+` FROM feedback_action_policies ORDER BY tenant`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feedback policies: %w", err)
+	}
+	policies := make([]model.FeedbackPolicy, len(rows))
+	for i, row := range rows {
+		policies[i] = row.toModel()
+	}
+	return policies, nil
+}
+
+// SavePolicy creates or replaces a tenant's policy
+func (r *Repo) SavePolicy(ctx context.Context, p model.FeedbackPolicy) (model.FeedbackPolicy, error) {
+	if strings.TrimSpace(p.Tenant) == "" {
+		return p, fmt.Errorf("%w: tenant is required", ErrInvalid)
+	}
+	if p.MinConfidence < 0 || p.MinConfidence > 1 {
+		return p, fmt.Errorf("%w: min_confidence must be between 0 and 1", ErrInvalid)
+	}
+	if p.DemotePenalty < 0 || p.DemotePenalty > 1 || p.MaxPenalty < 0 || p.MaxPenalty > 1 {
+		return p, fmt.Errorf("%w: demote_penalty and max_penalty must be between 0 and 1", ErrInvalid)
+	}
+	if p.FlagAfter < 1 {
+		return p, fmt.Errorf("%w: flag_after must be at least 1", ErrInvalid)
+	}
+	if p.TrustedAgents == nil {
+		p.TrustedAgents = []string{}
+	}
+
+	var row policyRow
+	err := r.db.GetContext(ctx, &row, `
+		INSERT INTO feedback_action_policies
+			(tenant, min_confidence, trusted_agents, demote, demote_penalty, max_penalty,
+			 flag, flag_after, updated_by, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''), CURRENT_TIMESTAMP)
+		ON CONFLICT (tenant) DO UPDATE SET
+			min_confidence = EXCLUDED.min_confidence,
+			trusted_agents = EXCLUDED.trusted_agents,
+			demote = EXCLUDED.demote,
+			demote_penalty = EXCLUDED.demote_penalty,
+			max_penalty = EXCLUDED.max_penalty,
+			flag = EXCLUDED.flag,
+			flag_after = EXCLUDED.flag_after,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = EXCLUDED.updated_at
+		RETURNING `+policyColumns,
+		p.Tenant, p.MinConfidence, pq.Array(p.TrustedAgents), p.Demote, p.DemotePenalty, p.MaxPenalty,
+		p.Flag, p.FlagAfter, p.UpdatedBy)
+	if err != nil {
+		return p, fmt.Errorf("failed to save feedback policy: %w", err)
+	}
+	return row.toModel(), nil
+}
+
+type actionRow struct {
+	ID             int        `db:"id"`
+	FeedbackID     int        `db:"feedback_id"`
+	Tenant         string     `db:"tenant"`
+	Action         string     `db:"action"`
+	QueryKey       string     `db:"query_key"`
+	AttributeCode  *string    `db:"attribute_code"`
+	DocumentCode   *string    `db:"document_code"`
+	RegulationCode *string    `db:"regulation_code"`
+	AgentName      string     `db:"agent_name"`
+	Confidence     float64    `db:"confidence"`
+	Penalty        *float64   `db:"penalty"`
+	Status         string     `db:"status"`
+	ResolvedBy     string     `db:"resolved_by"`
+	ResolvedAt     *time.Time `db:"resolved_at"`
+	CreatedAt      time.Time  `db:"created_at"`
+}
+
+func (r actionRow) toModel() model.FeedbackAction {
+	return model.FeedbackAction(r)
+}
+
+const actionColumns = `
+	id, feedback_id, tenant, action, query_key, attribute_code, document_code, regulation_code,
+	COALESCE(agent_name, '') AS agent_name, confidence, penalty, status,
+	COALESCE(resolved_by, '') AS resolved_by, resolved_at, created_at
+`
+
+// ActionFilter narrows a listing of actions
+type ActionFilter struct {
+	Tenant string
+	Action string
+	Status string
+	Limit  int
+}
+
+// ListActions returns actions, newest first
+func (r *Repo) ListActions(ctx context.Context, f ActionFilter) ([]model.FeedbackAction, error) {
+	if f.Limit <= 0 {
+		f.Limit = 50
+	}
+	var rows []actionRow
+	err := r.db.SelectContext(ctx, &rows, `
+		SELECT `+actionColumns+`
+		FROM rag_feedback_actions
+		WHERE ($1 = '' OR tenant = $1)
+		  AND ($2 = '' OR action = $2)
+		  AND ($3 = '' OR status = $3)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $4`,
+		f.Tenant, f.Action, f.Status, f.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feedback actions: %w", err)
+	}
+	actions := make([]model.FeedbackAction, len(rows))
+	for i, row := range rows {
+		actions[i] = row.toModel()
+	}
+	return actions, nil
+}
+
+// Resolve closes an open action. Resolving a demotion lifts the penalty of
+// its attribute for its query; resolving a flag restarts the count towards
+// the next flag.
+func (r *Repo) Resolve(ctx context.Context, id int, resolvedBy string) (model.FeedbackAction, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return model.FeedbackAction{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	var row actionRow
+	err = tx.GetContext(ctx, &row, `
+		UPDATE rag_feedback_actions
+		SET status = 'resolved', resolved_by = NULLIF($2, ''), resolved_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING `+actionColumns, id, resolvedBy)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.FeedbackAction{}, fmt.Errorf("%w: %d", ErrNotFound, id)
+	}
+	if err != nil {
+		return model.FeedbackAction{}, fmt.Errorf("failed to resolve feedback action: %w", err)
+	}
+
+	if row.Action == model.FeedbackActionDemote && row.AttributeCode != nil {
+		_, err = tx.ExecContext(ctx, `
+			DELETE FROM rag_ranking_penalties
+			WHERE tenant = $1 AND query_key = $2 AND attribute_code = $3`,
+			row.Tenant, row.QueryKey, *row.AttributeCode)
+		if err != nil {
+			return model.FeedbackAction{}, fmt.Errorf("failed to lift ranking penalty: %w", err)
+		}
+		_, err = tx.ExecContext(ctx, `
+			UPDATE rag_feedback_actions
+			SET status = 'resolved', resolved_by = NULLIF($4, ''), resolved_at = CURRENT_TIMESTAMP
+			WHERE tenant = $1 AND query_key = $2 AND attribute_code = $3
+			  AND action = 'demote' AND status = 'open'`,
+			row.Tenant, row.QueryKey, *row.AttributeCode, resolvedBy)
+		if err != nil {
+			return model.FeedbackAction{}, fmt.Errorf("failed to resolve demotions: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return model.FeedbackAction{}, fmt.Errorf("failed to commit: %w", err)
+	}
+	return row.toModel(), nil
+}
+
+// Penalties returns the demotion penalty per attribute for a tenant's query
+func (r *Repo) Penalties(ctx context.Context, tenant, query string) (map[string]float64, error) {
+	var rows []struct {
+		AttributeCode string  `db:"attribute_code"`
+		Penalty       float64 `db:"penalty"`
+	}
+	err := r.db.SelectContext(ctx, &rows, `
+		SELECT attribute_code, penalty
+		FROM rag_ranking_penalties
+		WHERE tenant = $1 AND query_key = $2`,
+		tenant, QueryKey(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ranking penalties: %w", err)
+	}
+	penalties := make(map[string]float64, len(rows))
+	for _, row := range rows {
+		penalties[row.AttributeCode] = row.Penalty
+	}
+	return penalties, nil
+}
@@ -0,0 +1,241 @@
+// Package grammar documents the KYC-DSL grammar constructs. The EBNF served
+// by the Rust engine gives the syntax; annotations.yaml adds each
+// construct's purpose, fields and examples. The merged reference backs
+// /dsl/grammar/help, which editor hover providers query by keyword.
+package grammar
+
+import (
+	_ "embed"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Name is the grammar name stored in kyc_grammar
+const Name = "KYC-DSL"
+
+// RuleSimpleForm marks constructs the EBNF parses through its generic
+// simple-form rule rather than a production of their own
+const RuleSimpleForm = "simple-form"
+
+//go:embed annotations.yaml
+var annotationsYAML []byte
+
+// Field is a positional argument or nested form of a construct
+type Field struct {
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description" json:"description,omitempty"`
+	Required    bool   `yaml:"required" json:"required"`
+	Repeated    bool   `yaml:"repeated" json:"repeated"`
+}
+
+// annotations are the parsed annotations.yaml, keywords in file order
+type annotations struct {
+	keywords  []string
+	byKeyword map[string]annotation
+}
+
+type annotation struct {
+	Purpose  string   `yaml:"purpose"`
+	Parents  []string `yaml:"parents"`
+	Fields   []Field  `yaml:"fields"`
+	Examples []string `yaml:"examples"`
+}
+
+// Construct documents one form of the grammar
+type Construct struct {
+	// Keyword is the name after the opening parenthesis, e.g. kyc-case
+	Keyword string `json:"keyword"`
+	// Production is the EBNF of the construct
+	Production string `json:"production"`
+	// Rule is the EBNF rule defining it, or RuleSimpleForm
+	Rule     string   `json:"rule"`
+	Purpose  string   `json:"purpose,omitempty"`
+	Parents  []string `json:"parents,omitempty"`
+	Fields   []Field  `json:"fields,omitempty"`
+	Examples []string `json:"examples,omitempty"`
+	// Documented is false for constructs in the EBNF without annotations
+	Documented bool `json:"documented"`
+	// Hover is the construct rendered as Markdown for editor hovers
+	Hover string `json:"hover"`
+}
+
+// Reference is the documentation of a grammar version
+type Reference struct {
+	Grammar    string      `json:"grammar"`
+	Version    string      `json:"version"`
+	Constructs []Construct `json:"constructs"`
+	// Terminals are the lexical rules, e.g. IDENT and PERCENT
+	Terminals map[string]string `json:"terminals"`
+}
+
+// Rule is a production of the EBNF
+type Rule struct {
+	Name         string
+	Alternatives []string
+}
+
+var (
+	ruleLine    = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9_-]*)\s+=\s*(.*)$`)
+	keywordLine = regexp.MustCompile(`"\(([a-z][a-z0-9-]*)"`)
+	terminal    = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+)
+
+// ParseEBNF splits the grammar text into rules. Alternatives continue on
+// indented lines starting with |; other lines (titles, comments) are ignored.
+func ParseEBNF(ebnf string) []Rule {
+	var rules []Rule
+	for _, line := range strings.Split(ebnf, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if m := ruleLine.FindStringSubmatch(trimmed); m != nil {
+			rules = append(rules, Rule{Name: m[1], Alternatives: []string{strings.TrimSpace(m[2])}})
+			continue
+		}
+		if strings.HasPrefix(trimmed, "|") && len(rules) > 0 {
+			last := &rules[len(rules)-1]
+			last.Alternatives = append(last.Alternatives, strings.TrimSpace(strings.TrimPrefix(trimmed, "|")))
+		}
+	}
+	return rules
+}
+
+// Build merges the EBNF with the annotations. Constructs appear in the
+// order of the annotations file, followed by undocumented EBNF constructs.
+func Build(ebnf, version string) (*Reference, error) {
+	notes, err := loadAnnotations()
+	if err != nil {
+		return nil, err
+	}
+	rules := ParseEBNF(ebnf)
+	if len(rules) == 0 {
+		return nil, fmt.Errorf("grammar %s v%s has no EBNF rules", Name, version)
+	}
+
+	ref := &Reference{Grammar: Name, Version: version, Terminals: map[string]string{}}
+	defined := map[string]Construct{}
+	var undocumented []string
+	for _, rule := range rules {
+		if terminal.MatchString(rule.Name) {
+			ref.Terminals[rule.Name] = strings.Join(rule.Alternatives, " | ")
+			continue
+		}
+		for _, alt := range rule.Alternatives {
+			m := keywordLine.FindStringSubmatch(alt)
+			if m == nil || !strings.HasPrefix(alt, m[0]) {
+				continue
+			}
+			if _, seen := defined[m[1]]; seen {
+				continue
+			}
+			defined[m[1]] = Construct{Keyword: m[1], Production: alt, Rule: rule.Name}
+			if _, ok := notes.byKeyword[m[1]]; !ok {
+				undocumented = append(undocumented, m[1])
+			}
+		}
+	}
+
+	for _, keyword := range slices.Concat(notes.keywords, undocumented) {
+		c, ok := defined[keyword]
+		if !ok {
+			c = Construct{Keyword: keyword, Rule: RuleSimpleForm}
+		}
+		if a, ok := notes.byKeyword[keyword]; ok {
+			c.Purpose, c.Parents, c.Fields, c.Examples = a.Purpose, a.Parents, a.Fields, a.Examples
+			c.Documented = true
+			if c.Production == "" {
+				c.Production = synthesize(keyword, a.Fields)
+			}
+		}
+		c.Hover = hover(c)
+		ref.Constructs = append(ref.Constructs, c)
+	}
+	return ref, nil
+}
+
+// Construct returns the documentation of keyword
+func (r *Reference) Construct(keyword string) (Construct, bool) {
+	for _, c := range r.Constructs {
+		if c.Keyword == keyword {
+			return c, true
+		}
+	}
+	return Construct{}, false
+}
+
+var loadAnnotations = sync.OnceValues(func() (*annotations, error) {
+	// Decode through a node to keep the file's order
+	var doc yaml.Node
+	if err := yaml.Unmarshal(annotationsYAML, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse grammar annotations: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("grammar annotations must be a mapping of keywords")
+	}
+	root := doc.Content[0]
+	notes := &annotations{byKeyword: make(map[string]annotation, len(root.Content)/2)}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		keyword := root.Content[i].Value
+		var a annotation
+		if err := root.Content[i+1].Decode(&a); err != nil {
+			return nil, fmt.Errorf("failed to parse annotation of %s: %w", keyword, err)
+		}
+		notes.keywords = append(notes.keywords, keyword)
+		notes.byKeyword[keyword] = a
+	}
+	return notes, nil
+})
+
+// synthesize writes the production of a simple-form construct from its fields
+func synthesize(keyword string, fields []Field) string {
+	parts := []string{fmt.Sprintf(`"(%s"`, keyword)}
+	for _, f := range fields {
+		switch {
+		case f.Repeated && f.Required:
+			parts = append(parts, f.Name+"+")
+		case f.Repeated:
+			parts = append(parts, f.Name+"*")
+		case f.Required:
+			parts = append(parts, f.Name)
+		default:
+			parts = append(parts, "["+f.Name+"]")
+		}
+	}
+	return strings.Join(append(parts, `")"`), " ")
+}
+
+func hover(c Construct) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**(%s …)**\n\n", c.Keyword)
+	if c.Purpose != "" {
+		b.WriteString(c.Purpose + "\n\n")
+	}
+	fmt.Fprintf(&b, "```ebnf\n%s\n```\n", c.Production)
+	if len(c.Fields) > 0 {
+		b.WriteString("\n")
+		for _, f := range c.Fields {
+			flags := "optional"
+			if f.Required {
+				flags = "required"
+			}
+			if f.Repeated {
+				flags += ", repeated"
+			}
+			fmt.Fprintf(&b, "- `%s` (%s)", f.Name, flags)
+			if f.Description != "" {
+				b.WriteString(": " + f.Description)
+			}
+			b.WriteString("\n")
+		}
+	}
+	if len(c.Parents) > 0 {
+		fmt.Fprintf(&b, "\nAppears in: %s\n", strings.Join(c.Parents, ", "))
+	}
+	if len(c.Examples) > 0 {
+		fmt.Fprintf(&b, "\n```lisp\n%s\n```\n", strings.TrimRight(c.Examples[0], "\n"))
+	}
+	return b.String()
+}
@@ -0,0 +1,99 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// ErrEntityNotFound is returned for an unknown entity id
+var ErrEntityNotFound = errors.New("entity not found")
+
+// OwnershipTypes are the entity_control types that carry an ownership stake
+var OwnershipTypes = []string{"LEGAL_OWNERSHIP", "BENEFICIAL_OWNERSHIP", "ECONOMIC_INTEREST"}
+
+// Repo loads ownership graphs from the entity and entity_control tables
+type Repo struct {
+	db *sqlx.DB
+}
+
+// NewRepo creates a new graph repository
+func NewRepo(db *sqlx.DB) *Repo {
+	return &Repo{db: db}
+}
+
+// UpstreamEdges returns the current ownership edges reachable upwards from
+// entityID, at most MaxDepth levels above it
+func (r *Repo) UpstreamEdges(ctx context.Context, entityID string) ([]Edge, error) {
+	var edges []Edge
+	err := r.db.SelectContext(ctx, &edges, `
+		WITH RECURSIVE owned AS (
+		    SELECT ec.id, ec.controller_entity_id, ec.controlled_entity_id,
+		           COALESCE(ec.control_percentage, 0)::float8 AS pct, 1 AS depth
+		      FROM entity_control ec
+		     WHERE ec.controlled_entity_id = $1
+		       AND ec.control_type::text = ANY($2)
+		       AND (ec.end_date IS NULL OR ec.end_date >= CURRENT_DATE)
+		    UNION
+		    SELECT ec.id, ec.controller_entity_id, ec.controlled_entity_id,
+		           COALESCE(ec.control_percentage, 0)::float8, o.depth + 1
+		      FROM owned o
+		      JOIN entity_control ec ON ec.controlled_entity_id = o.controller_entity_id
+		     WHERE o.depth < $3
+		       AND ec.control_type::text = ANY($2)
+		       AND (ec.end_date IS NULL OR ec.end_date >= CURRENT_DATE)
+		)
+		SELECT DISTINCT id::text AS id, controller_entity_id::text AS owner,
+		       controlled_entity_id::text AS owned, pct AS percent
+		  FROM owned`, entityID, pq.Array(OwnershipTypes), MaxDepth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ownership edges: %w", err)
+	}
+	return edges, nil
+}
+
+// Entities returns the names and types of the given entities keyed by id
+func (r *Repo) Entities(ctx context.Context, ids []string) (map[string]Entity, error) {
+	var rows []Entity
+	err := r.db.SelectContext(ctx, &rows, `
+		SELECT id::text AS id, name, entity_type
+		  FROM entity WHERE id::text = ANY($1)`, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load entities: %w", err)
+	}
+
+	out := make(map[string]Entity, len(rows))
+	for _, e := range rows {
+		out[e.ID] = e
+	}
+	return out, nil
+}
+
+// ComputeUbo loads the ownership graph above entityID and rolls it up per
+// ultimate owner
+func (r *Repo) ComputeUbo(ctx context.Context, entityID string, threshold float64) (*Rollup, error) {
+	var exists bool
+	if err := r.db.GetContext(ctx, &exists, `SELECT EXISTS (SELECT 1 FROM entity WHERE id::text = $1)`, entityID); err != nil {
+		return nil, fmt.Errorf("failed to look up entity: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrEntityNotFound, entityID)
+	}
+
+	edges, err := r.UpstreamEdges(ctx, entityID)
+	if err != nil {
+		return nil, err
+	}
+	ids := []string{entityID}
+	for _, e := range edges {
+		ids = append(ids, e.Owner)
+	}
+	entities, err := r.Entities(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	return ComputeRollup(entityID, edges, entities, threshold), nil
+}
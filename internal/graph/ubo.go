@@ -0,0 +1,153 @@
+// Package graph computes over the entity ownership graph (entity_control).
+// The engine works on in-memory edges so it can run against any snapshot;
+// Repo loads the edges above an entity from Postgres.
+package graph
+
+import "sort"
+
+// DefaultUBOThreshold is the effective ownership percentage from which an
+// ultimate owner is a beneficial owner (AMLD5 Art. 3(6))
+const DefaultUBOThreshold = 25.0
+
+// Limits keep pathological graphs (dense cross-holdings) bounded
+const (
+	MaxDepth = 20
+	MaxPaths = 10000
+)
+
+// Edge is an ownership stake: Owner holds Percent of Owned
+type Edge struct {
+	ID      string  `db:"id" json:"id"`
+	Owner   string  `db:"owner" json:"owner"`
+	Owned   string  `db:"owned" json:"owned"`
+	Percent float64 `db:"percent" json:"percent"`
+}
+
+// Entity names a node of the graph
+type Entity struct {
+	ID         string `db:"id" json:"id"`
+	Name       string `db:"name" json:"name"`
+	EntityType string `db:"entity_type" json:"entity_type"`
+}
+
+// Path is one chain of holdings from an ultimate owner down to the target
+type Path struct {
+	EntityIDs []string `json:"entity_ids"` // ultimate owner first, target last
+	Percent   float64  `json:"percent"`    // product of the stakes along the chain
+}
+
+// Owner is an ultimate owner with its effective stake in the target
+type Owner struct {
+	Entity
+	EffectivePercent float64 `json:"effective_percent"` // sum over all paths
+	IsUBO            bool    `json:"is_ubo"`
+	Circular         bool    `json:"circular,omitempty"` // a path ended at a cross-holding
+	Paths            []Path  `json:"paths"`
+}
+
+// Rollup is the effective ownership of an entity aggregated per ultimate owner
+type Rollup struct {
+	EntityID            string  `json:"entity_id"`
+	Threshold           float64 `json:"threshold"`
+	Owners              []Owner `json:"owners"` // strongest first
+	AttributedPercent   float64 `json:"attributed_percent"`
+	UnattributedPercent float64 `json:"unattributed_percent"` // not traced to an ultimate owner
+	Truncated           bool    `json:"truncated,omitempty"`  // MaxDepth or MaxPaths was hit
+}
+
+// UBOs returns the owners at or above the threshold
+func (r *Rollup) UBOs() []Owner {
+	var ubos []Owner
+	for _, o := range r.Owners {
+		if o.IsUBO {
+			ubos = append(ubos, o)
+		}
+	}
+	return ubos
+}
+
+// ComputeRollup multiplies percentages along every acyclic ownership path
+// into target and aggregates the products per ultimate owner (an entity with
+// no owners of its own). A threshold <= 0 uses DefaultUBOThreshold.
+func ComputeRollup(target string, edges []Edge, entities map[string]Entity, threshold float64) *Rollup {
+	if threshold <= 0 {
+		threshold = DefaultUBOThreshold
+	}
+
+	owners := make(map[string][]Edge)
+	for _, e := range edges {
+		owners[e.Owned] = append(owners[e.Owned], e)
+	}
+
+	rollup := &Rollup{EntityID: target, Threshold: threshold, Owners: []Owner{}}
+	byOwner := make(map[string]*Owner)
+	paths := 0
+
+	// chain holds the entities from target upwards
+	chain := []string{target}
+	onChain := map[string]bool{target: true}
+
+	var walk func(node string, pct float64)
+	walk = func(node string, pct float64) {
+		if paths >= MaxPaths {
+			rollup.Truncated = true
+			return
+		}
+
+		var next []Edge
+		circular := false
+		for _, e := range owners[node] {
+			if onChain[e.Owner] {
+				circular = true
+				continue
+			}
+			next = append(next, e)
+		}
+
+		if node != target && (len(next) == 0 || len(chain) > MaxDepth) {
+			if len(chain) > MaxDepth && len(next) > 0 {
+				rollup.Truncated = true
+			}
+			paths++
+			o, ok := byOwner[node]
+			if !ok {
+				o = &Owner{Entity: entities[node]}
+				o.ID = node
+				byOwner[node] = o
+			}
+			ids := make([]string, len(chain))
+			for i, id := range chain {
+				ids[len(chain)-1-i] = id
+			}
+			o.Paths = append(o.Paths, Path{EntityIDs: ids, Percent: pct})
+			o.EffectivePercent += pct
+			o.Circular = o.Circular || circular
+			return
+		}
+
+		for _, e := range next {
+			chain = append(chain, e.Owner)
+			onChain[e.Owner] = true
+			walk(e.Owner, pct*e.Percent/100)
+			onChain[e.Owner] = false
+			chain = chain[:len(chain)-1]
+		}
+	}
+	walk(target, 100)
+
+	for _, o := range byOwner {
+		o.IsUBO = o.EffectivePercent >= threshold
+		rollup.AttributedPercent += o.EffectivePercent
+		rollup.Owners = append(rollup.Owners, *o)
+	}
+	sort.Slice(rollup.Owners, func(i, j int) bool {
+		if rollup.Owners[i].EffectivePercent != rollup.Owners[j].EffectivePercent {
+			return rollup.Owners[i].EffectivePercent > rollup.Owners[j].EffectivePercent
+		}
+		return rollup.Owners[i].Name < rollup.Owners[j].Name
+	})
+	if rollup.UnattributedPercent = 100 - rollup.AttributedPercent; rollup.UnattributedPercent < 0 {
+		rollup.UnattributedPercent = 0
+	}
+	return rollup
+}
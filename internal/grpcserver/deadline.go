@@ -0,0 +1,71 @@
+package grpcserver
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/metrics"
+)
+
+// unaryDeadline gives calls without a deadline grpc.default_timeout, caps
+// longer deadlines at grpc.max_timeout and refuses calls whose deadline has
+// already passed
+func unaryDeadline(server string, cfg config.GRPCConfig) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, cancel, err := withDeadline(ctx, server, info.FullMethod, cfg.DefaultTimeout, cfg.MaxTimeout)
+		if err != nil {
+			return nil, err
+		}
+		defer cancel()
+		return handler(ctx, req)
+	}
+}
+
+// streamDeadline is the streaming counterpart of unaryDeadline, defaulting
+// to grpc.stream_timeout. Streams are not capped at grpc.max_timeout when
+// the stream timeout is longer.
+func streamDeadline(server string, cfg config.GRPCConfig) grpc.StreamServerInterceptor {
+	maxTimeout := cfg.MaxTimeout
+	if cfg.StreamTimeout > maxTimeout {
+		maxTimeout = cfg.StreamTimeout
+	}
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, cancel, err := withDeadline(ss.Context(), server, info.FullMethod, cfg.StreamTimeout, maxTimeout)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+		return handler(srv, &deadlineStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func withDeadline(ctx context.Context, server, method string, def, max time.Duration) (context.Context, context.CancelFunc, error) {
+	deadline, ok := ctx.Deadline()
+	switch {
+	case !ok:
+		ctx, cancel := context.WithTimeout(ctx, def)
+		return ctx, cancel, nil
+	case time.Until(deadline) <= 0:
+		metrics.ObserveGRPCRejected(server, method, metrics.GRPCRejectedDeadline)
+		return nil, nil, status.Error(codes.DeadlineExceeded, "deadline already exceeded")
+	case time.Until(deadline) > max:
+		ctx, cancel := context.WithTimeout(ctx, max)
+		return ctx, cancel, nil
+	}
+	return ctx, func() {}, nil
+}
+
+// deadlineStream overrides the stream context to carry the deadline
+type deadlineStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *deadlineStream) Context() context.Context {
+	return s.ctx
+}
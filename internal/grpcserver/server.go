@@ -0,0 +1,53 @@
+// Package grpcserver builds the gRPC servers of KYC-DSL with a shared
+// interceptor chain, so every service gets the same panic recovery, request
+// logging, authentication, input validation, metrics and deadlines.
+package grpcserver
+
+import (
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+
+	"github.com/adamtc007/KYC-DSL/internal/actor"
+	"github.com/adamtc007/KYC-DSL/internal/auth"
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/logging"
+	"github.com/adamtc007/KYC-DSL/internal/metrics"
+)
+
+// New creates a gRPC server named name (the metrics label) with tracing and
+// the shared interceptors, outermost first:
+//
+//	logging    request ID and one log line per call
+//	metrics    request counts and latency, including refused calls
+//	recovery   handler panics become Internal errors
+//	deadline   default and maximum deadlines
+//	actor      the x-actor of the call
+//	auth       credentials, when authn is enabled
+//	validation request size and paging limits
+//
+// opts are appended to the server options.
+func New(name string, cfg config.GRPCConfig, authn *auth.Authenticator, opts ...grpc.ServerOption) *grpc.Server {
+	v := newValidator(cfg)
+	opts = append([]grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(
+			logging.UnaryServerInterceptor(),
+			metrics.UnaryServerInterceptor(name),
+			unaryRecovery(name),
+			unaryDeadline(name, cfg),
+			actor.UnaryServerInterceptor(),
+			authn.UnaryServerInterceptor(),
+			v.unary(name),
+		),
+		grpc.ChainStreamInterceptor(
+			logging.StreamServerInterceptor(),
+			metrics.StreamServerInterceptor(name),
+			streamRecovery(name),
+			streamDeadline(name, cfg),
+			actor.StreamServerInterceptor(),
+			authn.StreamServerInterceptor(),
+			v.stream(name),
+		),
+	}, opts...)
+	return grpc.NewServer(opts...)
+}
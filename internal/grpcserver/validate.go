@@ -0,0 +1,155 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/metrics"
+)
+
+// validator refuses requests the handlers should never see: oversized
+// string or bytes fields, negative offsets and limits outside 0..max_limit,
+// and requests whose own Validate method fails
+type validator struct {
+	maxFieldBytes int
+	maxLimit      int64
+}
+
+func newValidator(cfg config.GRPCConfig) *validator {
+	return &validator{maxFieldBytes: cfg.MaxFieldBytes, maxLimit: int64(cfg.MaxLimit)}
+}
+
+func (v *validator) unary(server string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := v.check(server, info.FullMethod, req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// stream validates each message received from the client
+func (v *validator) stream(server string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &validatingStream{ServerStream: ss, check: func(m interface{}) error {
+			return v.check(server, info.FullMethod, m)
+		}})
+	}
+}
+
+func (v *validator) check(server, method string, req interface{}) error {
+	err := v.validate(req)
+	if err != nil {
+		metrics.ObserveGRPCRejected(server, method, metrics.GRPCRejectedValidation)
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
+
+func (v *validator) validate(req interface{}) error {
+	if m, ok := req.(proto.Message); ok {
+		if err := v.message(m.ProtoReflect(), ""); err != nil {
+			return err
+		}
+	}
+	if r, ok := req.(interface{ Validate() error }); ok {
+		return r.Validate()
+	}
+	return nil
+}
+
+// message checks the populated fields of m and of its nested messages
+func (v *validator) message(m protoreflect.Message, prefix string) error {
+	var err error
+	m.Range(func(fd protoreflect.FieldDescriptor, val protoreflect.Value) bool {
+		path := prefix + string(fd.Name())
+		switch {
+		case fd.IsList():
+			list := val.List()
+			for i := 0; i < list.Len() && err == nil; i++ {
+				err = v.value(fd, list.Get(i), fmt.Sprintf("%s[%d]", path, i))
+			}
+		case fd.IsMap():
+			val.Map().Range(func(k protoreflect.MapKey, mv protoreflect.Value) bool {
+				if s, ok := k.Interface().(string); ok && len(s) > v.maxFieldBytes {
+					err = fmt.Errorf("%s: key longer than %d bytes", path, v.maxFieldBytes)
+				} else {
+					err = v.value(fd.MapValue(), mv, fmt.Sprintf("%s[%v]", path, k.Interface()))
+				}
+				return err == nil
+			})
+		default:
+			err = v.value(fd, val, path)
+			if err == nil {
+				err = v.paging(fd, val, path)
+			}
+		}
+		return err == nil
+	})
+	return err
+}
+
+func (v *validator) value(fd protoreflect.FieldDescriptor, val protoreflect.Value, path string) error {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		if len(val.String()) > v.maxFieldBytes {
+			return fmt.Errorf("%s: longer than %d bytes", path, v.maxFieldBytes)
+		}
+	case protoreflect.BytesKind:
+		if len(val.Bytes()) > v.maxFieldBytes {
+			return fmt.Errorf("%s: longer than %d bytes", path, v.maxFieldBytes)
+		}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return v.message(val.Message(), path+".")
+	}
+	return nil
+}
+
+// paging checks the limit, page_size and offset fields of list requests
+func (v *validator) paging(fd protoreflect.FieldDescriptor, val protoreflect.Value, path string) error {
+	var n int64
+	switch fd.Kind() {
+	case protoreflect.Int32Kind, protoreflect.Int64Kind, protoreflect.Sint32Kind, protoreflect.Sint64Kind,
+		protoreflect.Sfixed32Kind, protoreflect.Sfixed64Kind:
+		n = val.Int()
+	case protoreflect.Uint32Kind, protoreflect.Uint64Kind, protoreflect.Fixed32Kind, protoreflect.Fixed64Kind:
+		if val.Uint() > uint64(v.maxLimit) {
+			n = v.maxLimit + 1
+		} else {
+			n = int64(val.Uint())
+		}
+	default:
+		return nil
+	}
+	switch fd.Name() {
+	case "limit", "page_size":
+		if n < 0 || n > v.maxLimit {
+			return fmt.Errorf("%s: must be between 0 and %d, got %d", path, v.maxLimit, n)
+		}
+	case "offset":
+		if n < 0 {
+			return fmt.Errorf("%s: must not be negative, got %d", path, n)
+		}
+	}
+	return nil
+}
+
+// validatingStream validates the messages a handler receives
+type validatingStream struct {
+	grpc.ServerStream
+	check func(m interface{}) error
+}
+
+func (s *validatingStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.check(m)
+}
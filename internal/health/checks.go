@@ -0,0 +1,82 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	kycdb "github.com/adamtc007/KYC-DSL/internal/db"
+	"github.com/adamtc007/KYC-DSL/internal/rag"
+)
+
+// rustDSLService is the service name the Rust DSL engine reports health for
+const rustDSLService = "kyc.dsl.DslService"
+
+// Database pings the shared connection pool
+func Database() CheckFunc {
+	return func(ctx context.Context) (string, error) {
+		h := kycdb.CheckShared(ctx)
+		if !h.OK() {
+			return "", errors.New(h.Error)
+		}
+		return fmt.Sprintf("%d/%d connections in use", h.AcquiredConns, h.MaxConns), nil
+	}
+}
+
+// PGVector checks the vector extension is installed
+func PGVector(db *sqlx.DB) CheckFunc {
+	return func(ctx context.Context) (string, error) {
+		var versions []string
+		if err := db.SelectContext(ctx, &versions, `SELECT extversion FROM pg_extension WHERE extname = 'vector'`); err != nil {
+			return "", err
+		}
+		if len(versions) == 0 {
+			return "", errors.New("pgvector extension not installed")
+		}
+		return "vector " + versions[0], nil
+	}
+}
+
+// Embeddings checks the embeddings provider answers for the embedder's model
+// and that query embeddings have not failed recently. A nil embedder (no API
+// key) is down.
+func Embeddings(embedder *rag.Embedder) CheckFunc {
+	return func(ctx context.Context) (string, error) {
+		if err := embedder.Ping(ctx); err != nil {
+			return "", err
+		}
+		if !embedder.Available() {
+			return "", errors.New("query embeddings failed recently; searches fall back to text search")
+		}
+		return string(embedder.GetModel()), nil
+	}
+}
+
+// RustDSL checks the Rust DSL service at addr with the standard gRPC health
+// protocol. A service without it that answers Unimplemented is reachable.
+func RustDSL(addr string) CheckFunc {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return func(context.Context) (string, error) { return "", err }
+	}
+	client := healthpb.NewHealthClient(conn)
+	return func(ctx context.Context) (string, error) {
+		resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: rustDSLService})
+		switch {
+		case status.Code(err) == codes.Unimplemented:
+			return addr + " (no health service)", nil
+		case err != nil:
+			return "", fmt.Errorf("rust DSL service at %s: %w", addr, err)
+		case resp.GetStatus() != healthpb.HealthCheckResponse_SERVING:
+			return "", fmt.Errorf("rust DSL service at %s is %s", addr, resp.GetStatus())
+		}
+		return addr, nil
+	}
+}
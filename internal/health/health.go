@@ -0,0 +1,207 @@
+// Package health serves the liveness (/healthz) and readiness (/readyz)
+// probes of kycserver and dataserver. Liveness only reports the process is
+// up; readiness runs the dependency checks (database, pgvector, embeddings
+// provider, Rust DSL service) and fails while draining or when a required
+// check is down.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+)
+
+// Check names
+const (
+	CheckDatabase   = "database"
+	CheckPGVector   = "pgvector"
+	CheckEmbeddings = "embeddings"
+	CheckRustDSL    = "rust_dsl"
+)
+
+// Report statuses
+const (
+	StatusUp       = "up"
+	StatusDown     = "down"
+	StatusReady    = "ready"
+	StatusDegraded = "degraded"
+	StatusUnready  = "unready"
+	StatusDraining = "draining"
+)
+
+// CheckFunc checks a dependency, returning a short description when up
+type CheckFunc func(ctx context.Context) (string, error)
+
+// Result is the outcome of one dependency check
+type Result struct {
+	Status    string `json:"status"` // "up" or "down"
+	Required  bool   `json:"required"`
+	LatencyMs int64  `json:"latency_ms"`
+	Detail    string `json:"detail,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Report is the readiness of a server: ready when every required check is
+// up, degraded when only optional checks are down
+type Report struct {
+	Service   string            `json:"service"`
+	Status    string            `json:"status"`
+	Checks    map[string]Result `json:"checks"`
+	CheckedAt time.Time         `json:"checked_at"`
+}
+
+// Ready reports whether the server should receive traffic
+func (r Report) Ready() bool {
+	return r.Status == StatusReady || r.Status == StatusDegraded
+}
+
+type check struct {
+	name string
+	fn   CheckFunc
+}
+
+// Checker runs the dependency checks of a server
+type Checker struct {
+	service  string
+	cfg      config.HealthConfig
+	optional map[string]bool
+	started  time.Time
+	checks   []check
+
+	// Draining, when set, makes the server unready once shutdown begins
+	Draining func() bool
+
+	mu     sync.Mutex
+	last   *Report
+	lastAt time.Time
+}
+
+// New creates a checker for a service with no checks
+func New(service string, cfg config.HealthConfig) *Checker {
+	optional := make(map[string]bool, len(cfg.Optional))
+	for _, name := range cfg.Optional {
+		optional[name] = true
+	}
+	return &Checker{service: service, cfg: cfg, optional: optional, started: time.Now()}
+}
+
+// Add registers a dependency check; it is required unless health.optional
+// names it
+func (c *Checker) Add(name string, fn CheckFunc) {
+	c.checks = append(c.checks, check{name: name, fn: fn})
+}
+
+// Check runs the dependency checks concurrently, each bounded by
+// health.timeout. A report younger than health.cache_ttl is reused.
+func (c *Checker) Check(ctx context.Context) Report {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.last != nil && time.Since(c.lastAt) < c.cfg.CacheTTL {
+		return *c.last
+	}
+
+	results := make([]Result, len(c.checks))
+	var wg sync.WaitGroup
+	for i, chk := range c.checks {
+		wg.Add(1)
+		go func(i int, chk check) {
+			defer wg.Done()
+			results[i] = c.run(ctx, chk)
+		}(i, chk)
+	}
+	wg.Wait()
+
+	report := Report{Service: c.service, Status: StatusReady, Checks: make(map[string]Result, len(c.checks)), CheckedAt: time.Now().UTC()}
+	for i, chk := range c.checks {
+		r := results[i]
+		report.Checks[chk.name] = r
+		switch {
+		case r.Status == StatusUp:
+		case r.Required:
+			report.Status = StatusUnready
+		case report.Status == StatusReady:
+			report.Status = StatusDegraded
+		}
+	}
+	c.last, c.lastAt = &report, time.Now()
+	return report
+}
+
+// run runs one check under the timeout
+func (c *Checker) run(ctx context.Context, chk check) Result {
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	detail, err := chk.fn(ctx)
+	r := Result{
+		Status:    StatusUp,
+		Required:  !c.optional[chk.name],
+		LatencyMs: time.Since(start).Milliseconds(),
+		Detail:    detail,
+	}
+	if err != nil {
+		r.Status = StatusDown
+		r.Error = err.Error()
+	}
+	return r
+}
+
+// Liveness reports the process is up; it checks no dependencies, so an
+// outage elsewhere does not get the process restarted
+// GET /healthz
+func (c *Checker) Liveness(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"status":         "ok",
+		"service":        c.service,
+		"uptime_seconds": int64(time.Since(c.started).Seconds()),
+	})
+}
+
+// Readiness reports whether the server should receive traffic: 503 while
+// draining or when a required dependency check is down
+// GET /readyz
+func (c *Checker) Readiness(w http.ResponseWriter, r *http.Request) {
+	if c.Draining != nil && c.Draining() {
+		writeJSON(w, http.StatusServiceUnavailable, Report{Service: c.service, Status: StatusDraining, CheckedAt: time.Now().UTC()})
+		return
+	}
+	report := c.Check(r.Context())
+	status := http.StatusOK
+	if !report.Ready() {
+		status = http.StatusServiceUnavailable
+	}
+	writeJSON(w, status, report)
+}
+
+// Watch re-checks readiness every interval until ctx is done, calling set
+// with the outcome, e.g. to drive a gRPC health server's serving status
+func (c *Checker) Watch(ctx context.Context, interval time.Duration, set func(ready bool)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		set(c.Check(ctx).Ready())
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Register adds the liveness and readiness probes to a mux
+func (c *Checker) Register(mux *http.ServeMux) {
+	mux.HandleFunc("/healthz", c.Liveness)
+	mux.HandleFunc("/readyz", c.Readiness)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
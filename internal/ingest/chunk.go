@@ -0,0 +1,213 @@
+package ingest
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/rag"
+)
+
+// Block is a line (PDF) or paragraph (HTML) of extracted text
+type Block struct {
+	Page    int
+	Text    string
+	Heading bool // marked up as a heading by the source
+}
+
+// headingPattern matches numbered headings such as "Article 13 Customer due
+// diligence", "Section 4.2.1 - Scope" or "3.1 Definitions"
+var headingPattern = regexp.MustCompile(
+	`^(?i:(article|section|chapter|part|annex|schedule|regulation|rule)\s+)?` +
+		`((?:[0-9]+|[IVXLC]+)(?:\.[0-9]+)*[a-z]?)([.):]?)\s*(?:[-–—:]\s*)?(\S.*)?$`)
+
+// tocLine matches table of contents entries: a title, leader dots and a page
+var tocLine = regexp.MustCompile(`(\.{4,}|:{4,}|…{2,}|(\. ){4,})\s*[0-9ivxlcIVXLC]+$`)
+
+// pageNumberLine matches a line holding only a page number
+var pageNumberLine = regexp.MustCompile(`^(?i:page\s+)?([0-9]+|[ivxlc]+)(\s+(of|/)\s+[0-9]+)?$`)
+
+// Limits on the lines taken for headings
+const (
+	maxHeadingLength = 120
+	maxHeadingWords  = 12
+)
+
+// section is the text under one heading
+type section struct {
+	number, title string
+	words         []string
+	pages         []int // page of each word
+}
+
+// heading reports whether a block starts a section and returns its number and
+// title. Unmarked lines count only when numbered with a keyword, numbered
+// like "3.1" or "2." and followed by a short capitalized title, or written in
+// capitals.
+func heading(b Block) (number, title string, ok bool) {
+	text := strings.TrimSpace(b.Text)
+	if text == "" || len(text) > maxHeadingLength {
+		return "", "", false
+	}
+	if m := headingPattern.FindStringSubmatch(text); m != nil {
+		keyword, num, sep, rest := m[1], m[2], m[3], strings.TrimSpace(m[4])
+		switch {
+		case keyword != "":
+			return num, rest, true
+		case b.Heading:
+			return num, rest, true
+		case (strings.Contains(num, ".") || sep == ".") && startsUpper(rest) &&
+			!strings.HasSuffix(rest, ".") && len(strings.Fields(rest)) <= maxHeadingWords:
+			// "3.1 Definitions" or "2. Scope" but not "3.5 million customers were..."
+			return num, rest, true
+		}
+	}
+	if b.Heading {
+		return "", text, true
+	}
+	if len(text) >= 4 && len(text) <= 80 && isCapitals(text) {
+		return "", text, true
+	}
+	return "", "", false
+}
+
+func startsUpper(s string) bool {
+	for _, r := range s {
+		return unicode.IsUpper(r)
+	}
+	return false
+}
+
+// isCapitals reports whether s reads like a title in capitals: at least three
+// letters, all upper case, and no punctuation beyond what titles use
+func isCapitals(s string) bool {
+	letters := 0
+	for i, r := range s {
+		switch {
+		case i == 0 && !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			return false
+		case unicode.IsLetter(r):
+			if !unicode.IsUpper(r) {
+				return false
+			}
+			letters++
+		case unicode.IsDigit(r), unicode.IsSpace(r), strings.ContainsRune("-–&,'()", r):
+		default:
+			return false
+		}
+	}
+	return letters >= 3
+}
+
+// Sections groups blocks under their headings and splits the text of each
+// section into excerpts of about chunkTokens estimated tokens, repeating
+// overlapTokens from the end of one excerpt at the start of the next.
+// Excerpts of a section share its number and title; each carries the page on
+// which it starts.
+func Sections(documentCode string, blocks []Block, chunkTokens, overlapTokens int) []model.DocumentSection {
+	var sections []*section
+	cur := &section{}
+	for _, b := range dropPageFurniture(blocks) {
+		if number, title, ok := heading(b); ok {
+			sections = append(sections, cur)
+			cur = &section{number: number, title: title}
+			continue
+		}
+		for _, w := range strings.Fields(b.Text) {
+			cur.words = append(cur.words, w)
+			cur.pages = append(cur.pages, b.Page)
+		}
+	}
+	sections = append(sections, cur)
+
+	var out []model.DocumentSection
+	for _, s := range sections {
+		// Headings without text, such as a part title followed by its first
+		// article, produce no excerpts
+		for _, span := range chunk(s.words, chunkTokens, overlapTokens) {
+			out = append(out, model.DocumentSection{
+				DocumentCode:  documentCode,
+				SectionNumber: s.number,
+				SectionTitle:  s.title,
+				TextExcerpt:   strings.Join(s.words[span[0]:span[1]], " "),
+				PageNumber:    s.pages[span[0]],
+			})
+		}
+	}
+	return out
+}
+
+// dropPageFurniture removes page numbers, table of contents entries and the
+// running headers and footers of a paginated document: lines that, ignoring
+// digits, repeat on at least half of the pages (and at least three)
+func dropPageFurniture(blocks []Block) []Block {
+	strip := func(s string) string {
+		return strings.Map(func(r rune) rune {
+			if unicode.IsDigit(r) {
+				return -1
+			}
+			return r
+		}, s)
+	}
+	pagesOf := map[string]map[int]bool{}
+	pages := 0
+	for _, b := range blocks {
+		if b.Page == 0 {
+			continue
+		}
+		pages = max(pages, b.Page)
+		key := strip(b.Text)
+		if pagesOf[key] == nil {
+			pagesOf[key] = map[int]bool{}
+		}
+		pagesOf[key][b.Page] = true
+	}
+	threshold := max(3, (pages+1)/2)
+
+	out := make([]Block, 0, len(blocks))
+	for _, b := range blocks {
+		text := strings.TrimSpace(b.Text)
+		if pageNumberLine.MatchString(text) || tocLine.MatchString(text) {
+			continue
+		}
+		if b.Page > 0 && len(pagesOf[strip(b.Text)]) >= threshold {
+			continue
+		}
+		out = append(out, b)
+	}
+	return out
+}
+
+// chunk splits words into spans [start, end) of at most chunkTokens
+// estimated tokens that overlap by about overlapTokens
+func chunk(words []string, chunkTokens, overlapTokens int) [][2]int {
+	var spans [][2]int
+	start := 0
+	for start < len(words) {
+		end, size := start, 0
+		for end < len(words) {
+			size += rag.EstimateTokens(words[end] + " ")
+			if size > chunkTokens && end > start {
+				break
+			}
+			end++
+		}
+		spans = append(spans, [2]int{start, end})
+		if end == len(words) {
+			break
+		}
+
+		// Step back over overlapTokens worth of words, always moving forward
+		next, back := end, 0
+		for next > start+1 {
+			back += rag.EstimateTokens(words[next-1] + " ")
+			if back > overlapTokens {
+				break
+			}
+			next--
+		}
+		start = next
+	}
+	return spans
+}
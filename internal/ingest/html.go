@@ -0,0 +1,95 @@
+package ingest
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// ExtractHTML returns the headings and paragraphs of an HTML document as
+// blocks. Scripts, styles and navigation are skipped. HTML has no pages, so
+// blocks carry page 0.
+func ExtractHTML(data []byte) ([]Block, error) {
+	root, err := html.Parse(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	var blocks []Block
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "script", "style", "noscript", "nav", "header", "footer", "head":
+				return
+			case "h1", "h2", "h3", "h4", "h5", "h6":
+				if text := nodeText(n); text != "" {
+					blocks = append(blocks, Block{Text: text, Heading: true})
+				}
+				return
+			case "p", "li", "td", "th", "dt", "dd", "pre", "caption", "blockquote":
+				if !hasBlockChild(n) {
+					if text := nodeText(n); text != "" {
+						blocks = append(blocks, Block{Text: text})
+					}
+					return
+				}
+			}
+		}
+		if n.Type == html.TextNode {
+			// Loose text outside the elements above, e.g. directly in a div
+			if text := strings.Join(strings.Fields(n.Data), " "); text != "" {
+				blocks = append(blocks, Block{Text: text})
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(root)
+	return blocks, nil
+}
+
+// blockElements are elements that start their own block
+var blockElements = map[string]bool{
+	"p": true, "li": true, "ul": true, "ol": true, "table": true, "div": true, "section": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true, "blockquote": true, "pre": true,
+}
+
+func hasBlockChild(n *html.Node) bool {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && (blockElements[c.Data] || hasBlockChild(c)) {
+			return true
+		}
+	}
+	return false
+}
+
+// nodeText returns the whitespace-normalized text of a node
+func nodeText(n *html.Node) string {
+	var sb strings.Builder
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		switch n.Type {
+		case html.TextNode:
+			sb.WriteString(n.Data)
+		case html.ElementNode:
+			if n.Data == "script" || n.Data == "style" {
+				return
+			}
+			if n.Data == "br" {
+				sb.WriteByte(' ')
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+		if n.Type == html.ElementNode && (n.Data == "td" || n.Data == "th") {
+			sb.WriteByte(' ')
+		}
+	}
+	walk(n)
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
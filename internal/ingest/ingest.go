@@ -0,0 +1,161 @@
+// Package ingest turns regulatory source documents (PDF or HTML) into
+// kyc_document_sections rows: text is extracted, grouped under its numbered
+// headings, split into overlapping excerpts, embedded in batches and stored
+// in place of the sections previously ingested for the document.
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/ontology"
+	"github.com/adamtc007/KYC-DSL/internal/rag"
+)
+
+// Format is the type of a source document
+type Format string
+
+const (
+	FormatPDF  Format = "pdf"
+	FormatHTML Format = "html"
+)
+
+// DetectFormat tells PDF from HTML by the file header, falling back to the
+// file extension
+func DetectFormat(path string, data []byte) (Format, error) {
+	head := bytes.TrimLeft(data[:min(len(data), 1024)], " \r\n\t\xef\xbb\xbf")
+	lower := bytes.ToLower(head)
+	switch {
+	case bytes.HasPrefix(head, []byte("%PDF-")):
+		return FormatPDF, nil
+	case bytes.HasPrefix(lower, []byte("<!doctype html")), bytes.HasPrefix(lower, []byte("<html")):
+		return FormatHTML, nil
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".pdf":
+		return FormatPDF, nil
+	case ".html", ".htm", ".xhtml":
+		return FormatHTML, nil
+	}
+	return "", fmt.Errorf("cannot tell the format of %s: expected a PDF or HTML file", path)
+}
+
+// Result summarizes an ingestion
+type Result struct {
+	DocumentCode string
+	Format       Format
+	Pages        int // 0 for HTML
+	Blocks       int
+	Sections     []model.DocumentSection
+	Tokens       int // estimated tokens embedded
+	Embedded     bool
+}
+
+// Pipeline ingests documents into kyc_document_sections
+type Pipeline struct {
+	db       *sqlx.DB
+	embedder *rag.Embedder
+	cfg      config.IngestionConfig
+}
+
+// NewPipeline creates a pipeline; embedder may be nil for dry runs
+func NewPipeline(db *sqlx.DB, embedder *rag.Embedder, cfg config.IngestionConfig) *Pipeline {
+	return &Pipeline{db: db, embedder: embedder, cfg: cfg}
+}
+
+// Split extracts and chunks a document without embedding or storing it
+func (p *Pipeline) Split(documentCode, path string) (*Result, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	format, err := DetectFormat(path, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var blocks []Block
+	switch format {
+	case FormatPDF:
+		blocks, err = ExtractPDF(data)
+	case FormatHTML:
+		blocks, err = ExtractHTML(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract text from %s: %w", path, err)
+	}
+	if len(blocks) == 0 {
+		return nil, fmt.Errorf("no text found in %s (scanned PDFs need OCR first)", path)
+	}
+
+	res := &Result{DocumentCode: documentCode, Format: format, Blocks: len(blocks)}
+	for _, b := range blocks {
+		res.Pages = max(res.Pages, b.Page)
+	}
+	res.Sections = Sections(documentCode, blocks, p.cfg.ChunkTokens, p.cfg.OverlapTokens)
+	for _, s := range res.Sections {
+		res.Tokens += rag.EstimateTokens(s.ToEmbeddingText())
+	}
+	return res, nil
+}
+
+// Ingest splits a document, embeds its sections in batches and replaces the
+// stored sections of the document. Nothing is written unless every batch is
+// embedded. progress, if set, is called after each batch.
+func (p *Pipeline) Ingest(ctx context.Context, documentCode, path string, progress func(done, total int)) (*Result, error) {
+	if err := p.checkDocument(ctx, documentCode); err != nil {
+		return nil, err
+	}
+	res, err := p.Split(documentCode, path)
+	if err != nil {
+		return nil, err
+	}
+
+	sections := res.Sections
+	for start := 0; start < len(sections); start += p.cfg.BatchSize {
+		end := min(start+p.cfg.BatchSize, len(sections))
+		texts := make([]string, 0, end-start)
+		for _, s := range sections[start:end] {
+			texts = append(texts, s.ToEmbeddingText())
+		}
+		embeddings, err := p.embedder.GenerateEmbeddingsFromTexts(ctx, texts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to embed sections %d-%d: %w", start+1, end, err)
+		}
+		for i, e := range embeddings {
+			sections[start+i].Embedding = e
+		}
+		if progress != nil {
+			progress(end, len(sections))
+		}
+	}
+
+	if err := ontology.NewEnhancementsRepo(p.db).ReplaceDocumentSections(ctx, documentCode, sections); err != nil {
+		return nil, err
+	}
+	res.Embedded = true
+	return res, nil
+}
+
+// checkDocument verifies the document exists; sections reference it
+func (p *Pipeline) checkDocument(ctx context.Context, code string) error {
+	var exists int
+	err := p.db.GetContext(ctx, &exists, `SELECT 1 FROM kyc_documents WHERE code = $1`, code)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("document %s not found in kyc_documents; seed it first (kycctl seed-metadata)", code)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to look up document %s: %w", code, err)
+	}
+	return nil
+}
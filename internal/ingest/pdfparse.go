@@ -0,0 +1,527 @@
+package ingest
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// This file reads just enough of the PDF object syntax to walk the page tree
+// and decode page content streams: indirect objects (including those packed
+// in object streams), FlateDecode streams and ToUnicode maps. It does not
+// handle encryption.
+
+// pdfName is a /Name
+type pdfName string
+
+// pdfRef is an indirect reference "n g R"
+type pdfRef int
+
+// pdfDict is a << ... >> dictionary keyed by name without the slash
+type pdfDict map[string]any
+
+// pdfStream is a dictionary followed by stream data (still encoded)
+type pdfStream struct {
+	dict pdfDict
+	raw  []byte
+}
+
+// pdfKeyword is a bare token such as an operator in a content stream
+type pdfKeyword string
+
+// lexer tokenizes PDF syntax
+type lexer struct {
+	data []byte
+	pos  int
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\r' || c == '\t' || c == '\f' || c == 0
+}
+
+func isDelim(c byte) bool {
+	return c == '(' || c == ')' || c == '<' || c == '>' || c == '[' || c == ']' || c == '{' || c == '}' || c == '/' || c == '%'
+}
+
+func (l *lexer) skipSpace() {
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		if isSpace(c) {
+			l.pos++
+		} else if c == '%' {
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+		} else {
+			return
+		}
+	}
+}
+
+// errEOF ends tokenizing
+var errEOF = fmt.Errorf("unexpected end of PDF data")
+
+// token returns the next raw token: a delimiter sequence, a name, a string or
+// a regular word
+func (l *lexer) token() (any, error) {
+	l.skipSpace()
+	if l.pos >= len(l.data) {
+		return nil, errEOF
+	}
+	c := l.data[l.pos]
+	switch c {
+	case '(':
+		return l.literalString(), nil
+	case '<':
+		if l.pos+1 < len(l.data) && l.data[l.pos+1] == '<' {
+			l.pos += 2
+			return pdfKeyword("<<"), nil
+		}
+		return l.hexString(), nil
+	case '>':
+		if l.pos+1 < len(l.data) && l.data[l.pos+1] == '>' {
+			l.pos += 2
+			return pdfKeyword(">>"), nil
+		}
+		l.pos++
+		return pdfKeyword(">"), nil
+	case '[', ']', '{', '}', ')':
+		l.pos++
+		return pdfKeyword(string(c)), nil
+	case '/':
+		l.pos++
+		start := l.pos
+		for l.pos < len(l.data) && !isSpace(l.data[l.pos]) && !isDelim(l.data[l.pos]) {
+			l.pos++
+		}
+		return pdfName(unescapeName(l.data[start:l.pos])), nil
+	}
+
+	start := l.pos
+	for l.pos < len(l.data) && !isSpace(l.data[l.pos]) && !isDelim(l.data[l.pos]) {
+		l.pos++
+	}
+	word := string(l.data[start:l.pos])
+	if f, err := strconv.ParseFloat(word, 64); err == nil {
+		return f, nil
+	}
+	switch word {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	return pdfKeyword(word), nil
+}
+
+func unescapeName(b []byte) string {
+	if !bytes.Contains(b, []byte("#")) {
+		return string(b)
+	}
+	var out []byte
+	for i := 0; i < len(b); i++ {
+		if b[i] == '#' && i+2 < len(b) {
+			if v, err := strconv.ParseUint(string(b[i+1:i+3]), 16, 8); err == nil {
+				out = append(out, byte(v))
+				i += 2
+				continue
+			}
+		}
+		out = append(out, b[i])
+	}
+	return string(out)
+}
+
+// literalString reads a (...) string with escapes and balanced parentheses
+func (l *lexer) literalString() []byte {
+	l.pos++ // (
+	var out []byte
+	depth := 1
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return out
+			}
+		case '\\':
+			if l.pos >= len(l.data) {
+				return out
+			}
+			e := l.data[l.pos]
+			l.pos++
+			switch e {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case '\r', '\n':
+				if e == '\r' && l.pos < len(l.data) && l.data[l.pos] == '\n' {
+					l.pos++
+				}
+				continue
+			default:
+				if e >= '0' && e <= '7' {
+					v := int(e - '0')
+					for i := 0; i < 2 && l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '7'; i++ {
+						v = v*8 + int(l.data[l.pos]-'0')
+						l.pos++
+					}
+					c = byte(v)
+				} else {
+					c = e
+				}
+			}
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// hexString reads a <...> string
+func (l *lexer) hexString() []byte {
+	l.pos++ // <
+	var digits []byte
+	for l.pos < len(l.data) && l.data[l.pos] != '>' {
+		if c := l.data[l.pos]; !isSpace(c) {
+			digits = append(digits, c)
+		}
+		l.pos++
+	}
+	l.pos++ // >
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+	out := make([]byte, 0, len(digits)/2)
+	for i := 0; i+1 < len(digits); i += 2 {
+		v, _ := strconv.ParseUint(string(digits[i:i+2]), 16, 8)
+		out = append(out, byte(v))
+	}
+	return out
+}
+
+// value parses the next object, resolving "n g R" into a pdfRef
+func (l *lexer) value() (any, error) {
+	tok, err := l.token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := tok.(type) {
+	case pdfKeyword:
+		switch t {
+		case "<<":
+			d := pdfDict{}
+			for {
+				l.skipSpace()
+				if bytes.HasPrefix(l.data[l.pos:], []byte(">>")) {
+					l.pos += 2
+					return d, nil
+				}
+				key, err := l.token()
+				if err != nil {
+					return nil, err
+				}
+				name, ok := key.(pdfName)
+				if !ok {
+					return nil, fmt.Errorf("expected dictionary key at offset %d", l.pos)
+				}
+				v, err := l.value()
+				if err != nil {
+					return nil, err
+				}
+				d[string(name)] = v
+			}
+		case "[":
+			var arr []any
+			for {
+				l.skipSpace()
+				if l.pos < len(l.data) && l.data[l.pos] == ']' {
+					l.pos++
+					return arr, nil
+				}
+				v, err := l.value()
+				if err != nil {
+					return nil, err
+				}
+				arr = append(arr, v)
+			}
+		}
+		return t, nil
+	case float64:
+		// Look ahead for "g R"
+		save := l.pos
+		if gen, err := l.token(); err == nil {
+			if _, ok := gen.(float64); ok {
+				if r, err := l.token(); err == nil && r == pdfKeyword("R") {
+					return pdfRef(int(t)), nil
+				}
+			}
+		}
+		l.pos = save
+		return t, nil
+	}
+	return tok, nil
+}
+
+// pdfDocument holds the parsed objects of a file
+type pdfDocument struct {
+	objects map[int]any
+}
+
+var objHeader = regexp.MustCompile(`(\d+)\s+(\d+)\s+obj\b`)
+
+// parsePDF indexes the objects of a PDF file
+func parsePDF(data []byte) (*pdfDocument, error) {
+	if !bytes.HasPrefix(bytes.TrimLeft(data, " \r\n\t"), []byte("%PDF-")) {
+		return nil, fmt.Errorf("not a PDF file")
+	}
+	if bytes.Contains(data, []byte("/Encrypt")) {
+		return nil, fmt.Errorf("encrypted PDFs are not supported")
+	}
+
+	doc := &pdfDocument{objects: map[int]any{}}
+	for _, m := range objHeader.FindAllSubmatchIndex(data, -1) {
+		num, _ := strconv.Atoi(string(data[m[2]:m[3]]))
+		l := &lexer{data: data, pos: m[1]}
+		v, err := l.value()
+		if err != nil {
+			continue
+		}
+		if d, ok := v.(pdfDict); ok {
+			if s, ok := l.stream(d); ok {
+				v = s
+			}
+		}
+		doc.objects[num] = v
+	}
+
+	// Unpack object streams (PDF 1.5+)
+	for _, v := range doc.objects {
+		s, ok := v.(*pdfStream)
+		if !ok || s.dict["Type"] != pdfName("ObjStm") {
+			continue
+		}
+		if err := doc.unpackObjectStream(s); err != nil {
+			return nil, err
+		}
+	}
+	return doc, nil
+}
+
+// stream reads the data following a stream dictionary, if any
+func (l *lexer) stream(d pdfDict) (*pdfStream, bool) {
+	l.skipSpace()
+	if !bytes.HasPrefix(l.data[l.pos:], []byte("stream")) {
+		return nil, false
+	}
+	start := l.pos + len("stream")
+	if start < len(l.data) && l.data[start] == '\r' {
+		start++
+	}
+	if start < len(l.data) && l.data[start] == '\n' {
+		start++
+	}
+	end := -1
+	if n, ok := d["Length"].(float64); ok && start+int(n) <= len(l.data) &&
+		bytes.Contains(l.data[start+int(n):min(len(l.data), start+int(n)+20)], []byte("endstream")) {
+		end = start + int(n)
+	} else if i := bytes.Index(l.data[start:], []byte("endstream")); i >= 0 {
+		end = start + i
+	}
+	if end < 0 {
+		return nil, false
+	}
+	return &pdfStream{dict: d, raw: l.data[start:end]}, true
+}
+
+func (doc *pdfDocument) unpackObjectStream(s *pdfStream) error {
+	data, err := doc.decode(s)
+	if err != nil {
+		return err
+	}
+	n, _ := s.dict["N"].(float64)
+	first, _ := s.dict["First"].(float64)
+	header := &lexer{data: data}
+	type entry struct{ num, off int }
+	var entries []entry
+	for i := 0; i < int(n); i++ {
+		num, err1 := header.token()
+		off, err2 := header.token()
+		if err1 != nil || err2 != nil {
+			break
+		}
+		nf, ok1 := num.(float64)
+		of, ok2 := off.(float64)
+		if !ok1 || !ok2 {
+			break
+		}
+		entries = append(entries, entry{int(nf), int(of)})
+	}
+	for _, e := range entries {
+		pos := int(first) + e.off
+		if pos >= len(data) {
+			continue
+		}
+		l := &lexer{data: data, pos: pos}
+		if v, err := l.value(); err == nil {
+			if _, exists := doc.objects[e.num]; !exists {
+				doc.objects[e.num] = v
+			}
+		}
+	}
+	return nil
+}
+
+// resolve follows indirect references
+func (doc *pdfDocument) resolve(v any) any {
+	for i := 0; i < 32; i++ {
+		r, ok := v.(pdfRef)
+		if !ok {
+			return v
+		}
+		v = doc.objects[int(r)]
+	}
+	return nil
+}
+
+func (doc *pdfDocument) dict(v any) pdfDict {
+	switch t := doc.resolve(v).(type) {
+	case pdfDict:
+		return t
+	case *pdfStream:
+		return t.dict
+	}
+	return nil
+}
+
+// decode returns the decoded data of a stream; only FlateDecode is supported
+func (doc *pdfDocument) decode(s *pdfStream) ([]byte, error) {
+	var filters []any
+	switch f := doc.resolve(s.dict["Filter"]).(type) {
+	case nil:
+	case pdfName:
+		filters = []any{f}
+	case []any:
+		filters = f
+	}
+	data := s.raw
+	for _, f := range filters {
+		switch doc.resolve(f) {
+		case pdfName("FlateDecode"):
+			r, err := zlib.NewReader(bytes.NewReader(data))
+			if err != nil {
+				return nil, fmt.Errorf("failed to inflate stream: %w", err)
+			}
+			out, err := io.ReadAll(r)
+			if err != nil && len(out) == 0 {
+				return nil, fmt.Errorf("failed to inflate stream: %w", err)
+			}
+			data = out
+		default:
+			return nil, fmt.Errorf("unsupported stream filter %v", f)
+		}
+	}
+	return data, nil
+}
+
+// pages returns the page dictionaries in document order
+func (doc *pdfDocument) pages() []pdfDict {
+	var catalog pdfDict
+	nums := make([]int, 0, len(doc.objects))
+	for num := range doc.objects {
+		nums = append(nums, num)
+	}
+	sort.Ints(nums)
+	for _, num := range nums {
+		if d := doc.dict(pdfRef(num)); d != nil && d["Type"] == pdfName("Catalog") {
+			catalog = d
+			break
+		}
+	}
+
+	var out []pdfDict
+	if catalog != nil {
+		seen := map[pdfRef]bool{}
+		var walk func(node any, depth int)
+		walk = func(node any, depth int) {
+			if r, ok := node.(pdfRef); ok {
+				if seen[r] {
+					return
+				}
+				seen[r] = true
+			}
+			d := doc.dict(node)
+			if d == nil || depth > 64 {
+				return
+			}
+			if d["Type"] == pdfName("Page") {
+				out = append(out, d)
+				return
+			}
+			if kids, ok := doc.resolve(d["Kids"]).([]any); ok {
+				for _, k := range kids {
+					walk(k, depth+1)
+				}
+			}
+		}
+		walk(catalog["Pages"], 0)
+	}
+	if len(out) > 0 {
+		return out
+	}
+
+	// No usable page tree: fall back to object order
+	for _, num := range nums {
+		if d := doc.dict(pdfRef(num)); d != nil && d["Type"] == pdfName("Page") {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// inherited looks a page attribute up the page tree
+func (doc *pdfDocument) inherited(page pdfDict, key string) any {
+	for d, i := page, 0; d != nil && i < 64; d, i = doc.dict(d["Parent"]), i+1 {
+		if v, ok := d[key]; ok {
+			return v
+		}
+	}
+	return nil
+}
+
+// contents returns the decoded content streams of a page, concatenated
+func (doc *pdfDocument) contents(page pdfDict) []byte {
+	var streams []any
+	switch c := doc.resolve(page["Contents"]).(type) {
+	case *pdfStream:
+		streams = []any{c}
+	case []any:
+		streams = c
+	}
+	var buf bytes.Buffer
+	for _, s := range streams {
+		if st, ok := doc.resolve(s).(*pdfStream); ok {
+			if data, err := doc.decode(st); err == nil {
+				buf.Write(data)
+				buf.WriteByte('\n')
+			}
+		}
+	}
+	return buf.Bytes()
+}
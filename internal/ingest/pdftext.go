@@ -0,0 +1,306 @@
+package ingest
+
+import (
+	"math"
+	"strings"
+	"unicode/utf16"
+)
+
+// ExtractPDF returns the text lines of a PDF document as blocks, one per line
+// of text, in page order. Only text drawn by the page content streams is
+// read; text in images (scans) cannot be extracted.
+func ExtractPDF(data []byte) ([]Block, error) {
+	doc, err := parsePDF(data)
+	if err != nil {
+		return nil, err
+	}
+	var blocks []Block
+	for i, page := range doc.pages() {
+		fonts := doc.fonts(page)
+		for _, line := range pageLines(doc.contents(page), fonts) {
+			blocks = append(blocks, Block{Page: i + 1, Text: line})
+		}
+	}
+	return blocks, nil
+}
+
+// font decodes the character codes of a text string
+type font struct {
+	width int               // bytes per code; 2 for composite fonts
+	cmap  map[uint32]string // ToUnicode map, if the font has one
+}
+
+func (f *font) decode(s []byte) string {
+	if f == nil {
+		return latin1(s)
+	}
+	if f.cmap == nil {
+		if f.width == 2 {
+			return "" // composite font without a ToUnicode map: no text
+		}
+		return latin1(s)
+	}
+	var sb strings.Builder
+	for i := 0; i+f.width <= len(s); i += f.width {
+		var code uint32
+		for j := 0; j < f.width; j++ {
+			code = code<<8 | uint32(s[i+j])
+		}
+		if u, ok := f.cmap[code]; ok {
+			sb.WriteString(u)
+		} else if f.width == 1 {
+			sb.WriteString(latin1(s[i : i+1]))
+		}
+	}
+	return sb.String()
+}
+
+// latin1 approximates WinAnsi and standard encodings by Latin-1
+func latin1(s []byte) string {
+	r := make([]rune, 0, len(s))
+	for _, c := range s {
+		switch c {
+		case 0x91, 0x92:
+			r = append(r, '\'')
+		case 0x93, 0x94:
+			r = append(r, '"')
+		case 0x95:
+			r = append(r, '•')
+		case 0x96, 0x97:
+			r = append(r, '-')
+		default:
+			if c >= 0x20 {
+				r = append(r, rune(c))
+			}
+		}
+	}
+	return string(r)
+}
+
+// fonts loads the fonts of a page by resource name
+func (doc *pdfDocument) fonts(page pdfDict) map[string]*font {
+	out := map[string]*font{}
+	resources := doc.dict(doc.inherited(page, "Resources"))
+	if resources == nil {
+		return out
+	}
+	for name, ref := range doc.dict(resources["Font"]) {
+		fd := doc.dict(ref)
+		if fd == nil {
+			continue
+		}
+		f := &font{width: 1}
+		if fd["Subtype"] == pdfName("Type0") {
+			f.width = 2
+		}
+		if s, ok := doc.resolve(fd["ToUnicode"]).(*pdfStream); ok {
+			if data, err := doc.decode(s); err == nil {
+				f.cmap, f.width = parseCMap(data, f.width)
+			}
+		}
+		out[name] = f
+	}
+	return out
+}
+
+// parseCMap reads the bfchar and bfrange mappings of a ToUnicode CMap
+func parseCMap(data []byte, width int) (map[uint32]string, int) {
+	m := map[uint32]string{}
+	l := &lexer{data: data}
+	var operands []any
+	for {
+		tok, err := l.value()
+		if err != nil {
+			break
+		}
+		kw, ok := tok.(pdfKeyword)
+		if !ok {
+			operands = append(operands, tok)
+			continue
+		}
+		switch kw {
+		case "endcodespacerange":
+			if len(operands) > 0 {
+				if b, ok := operands[0].([]byte); ok && len(b) > 0 {
+					width = len(b)
+				}
+			}
+		case "endbfchar":
+			for i := 0; i+1 < len(operands); i += 2 {
+				src, ok1 := operands[i].([]byte)
+				dst, ok2 := operands[i+1].([]byte)
+				if ok1 && ok2 {
+					m[code(src)] = utf16BE(dst)
+				}
+			}
+		case "endbfrange":
+			for i := 0; i+2 < len(operands); i += 3 {
+				lo, ok1 := operands[i].([]byte)
+				hi, ok2 := operands[i+1].([]byte)
+				if !ok1 || !ok2 {
+					continue
+				}
+				start, end := code(lo), code(hi)
+				if end < start || end-start > 0xffff {
+					continue
+				}
+				switch dst := operands[i+2].(type) {
+				case []byte:
+					base := []rune(utf16BE(dst))
+					if len(base) == 0 {
+						continue
+					}
+					for c := start; c <= end; c++ {
+						r := append([]rune{}, base...)
+						r[len(r)-1] += rune(c - start)
+						m[c] = string(r)
+					}
+				case []any:
+					for j, d := range dst {
+						if b, ok := d.([]byte); ok && start+uint32(j) <= end {
+							m[start+uint32(j)] = utf16BE(b)
+						}
+					}
+				}
+			}
+		}
+		operands = operands[:0]
+	}
+	return m, width
+}
+
+func code(b []byte) uint32 {
+	var c uint32
+	for _, x := range b {
+		c = c<<8 | uint32(x)
+	}
+	return c
+}
+
+func utf16BE(b []byte) string {
+	u := make([]uint16, 0, len(b)/2)
+	for i := 0; i+1 < len(b); i += 2 {
+		u = append(u, uint16(b[i])<<8|uint16(b[i+1]))
+	}
+	return string(utf16.Decode(u))
+}
+
+// pageLines interprets the text operators of a content stream. A line ends
+// whenever the text position moves vertically; large horizontal moves and
+// kerning gaps become spaces.
+func pageLines(content []byte, fonts map[string]*font) []string {
+	var lines []string
+	var cur strings.Builder
+	flush := func() {
+		if s := strings.Join(strings.Fields(cur.String()), " "); s != "" {
+			lines = append(lines, s)
+		}
+		cur.Reset()
+	}
+	space := func() {
+		if s := cur.String(); s != "" && !strings.HasSuffix(s, " ") {
+			cur.WriteByte(' ')
+		}
+	}
+
+	var f *font
+	lineY, haveY := 0.0, false // baseline of the current output line
+	textY, leading := 0.0, 0.0 // text line matrix y and TL leading
+	moveTo := func(y float64) {
+		if haveY && math.Abs(y-lineY) > 0.5 {
+			flush()
+		} else {
+			space()
+		}
+		lineY, haveY = y, true
+	}
+
+	l := &lexer{data: content}
+	var operands []any
+	for {
+		tok, err := l.value()
+		if err != nil {
+			break
+		}
+		op, ok := tok.(pdfKeyword)
+		if !ok {
+			operands = append(operands, tok)
+			continue
+		}
+		switch op {
+		case "BI":
+			// Skip inline image data
+			if i := strings.Index(string(content[l.pos:]), "EI"); i >= 0 {
+				l.pos += i + 2
+			}
+		case "Tf":
+			if len(operands) >= 2 {
+				if name, ok := operands[0].(pdfName); ok {
+					f = fonts[string(name)]
+				}
+			}
+		case "BT":
+			textY = 0
+		case "TL":
+			if len(operands) >= 1 {
+				leading, _ = operands[0].(float64)
+			}
+		case "Td", "TD":
+			if len(operands) >= 2 {
+				tx, _ := operands[0].(float64)
+				ty, _ := operands[1].(float64)
+				if op == "TD" {
+					leading = -ty
+				}
+				if ty != 0 || !haveY {
+					textY += ty
+					moveTo(textY)
+				} else if tx != 0 {
+					space()
+				}
+			}
+		case "Tm":
+			if len(operands) >= 6 {
+				textY, _ = operands[5].(float64)
+				moveTo(textY)
+			}
+		case "T*":
+			flush()
+			textY -= leading
+			lineY, haveY = textY, true
+		case "Tj":
+			if len(operands) >= 1 {
+				if s, ok := operands[0].([]byte); ok {
+					cur.WriteString(f.decode(s))
+				}
+			}
+		case "'", "\"":
+			flush()
+			textY -= leading
+			lineY, haveY = textY, true
+			if len(operands) >= 1 {
+				if s, ok := operands[len(operands)-1].([]byte); ok {
+					cur.WriteString(f.decode(s))
+				}
+			}
+		case "TJ":
+			if len(operands) >= 1 {
+				if arr, ok := operands[0].([]any); ok {
+					for _, item := range arr {
+						switch v := item.(type) {
+						case []byte:
+							cur.WriteString(f.decode(v))
+						case float64:
+							if v < -200 {
+								space()
+							}
+						}
+					}
+				}
+			}
+		}
+		operands = operands[:0]
+	}
+	flush()
+	return lines
+}
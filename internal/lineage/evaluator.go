@@ -25,6 +25,7 @@ type Evaluator struct {
 	env     map[string]any
 	program map[string]*vm.Program
 	results []EvaluationResult
+	options []expr.Option
 }
 
 // NewEvaluator builds an evaluator with known public attributes.
@@ -39,7 +40,7 @@ func NewEvaluator(attrValues map[string]any) *Evaluator {
 // CompileDerivations compiles all rule expressions ahead of time.
 func (e *Evaluator) CompileDerivations(derivations []model.DerivedAttribute) error {
 	for _, d := range derivations {
-		prog, err := expr.Compile(d.RuleExpression, expr.Env(e.env))
+		prog, err := expr.Compile(d.RuleExpression, append([]expr.Option{expr.Env(e.env)}, e.options...)...)
 		if err != nil {
 			return fmt.Errorf("compile error for %s: %w", d.DerivedAttribute, err)
 		}
@@ -0,0 +1,119 @@
+package lineage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+// ErrUnknownAttribute is returned for a lineage graph of an attribute that
+// is not in the dictionary
+var ErrUnknownAttribute = errors.New("attribute not found")
+
+// DefaultGraphDepth bounds the walk of a lineage graph in each direction
+const DefaultGraphDepth = 10
+
+// Graph builds the derivation DAG around an attribute: the rules producing
+// it and, transitively, their sources (upstream), and the rules reading it
+// and, transitively, what they produce (downstream), each at most maxDepth
+// steps away (DefaultGraphDepth when zero or less). An attribute reached
+// both ways, through a cycle, is reported downstream.
+func Graph(ctx context.Context, db sqlx.ExtContext, attribute string, maxDepth int) (*model.LineageGraph, error) {
+	if maxDepth <= 0 {
+		maxDepth = DefaultGraphDepth
+	}
+
+	var edges []model.LineageEdge
+	if err := sqlx.SelectContext(ctx, db, &edges, `
+		SELECT id, source_attribute_code, derived_attribute_code, rule_expression,
+		       COALESCE(rule_type, '') AS rule_type, COALESCE(jurisdiction, '') AS jurisdiction,
+		       COALESCE(regulation_code, '') AS regulation_code
+		  FROM kyc_attribute_derivations
+		 ORDER BY id`); err != nil {
+		return nil, fmt.Errorf("failed to load derivations: %w", err)
+	}
+
+	graph := &model.LineageGraph{Attribute: attribute, Nodes: []model.LineageNode{}, Edges: []model.LineageEdge{}}
+	bySource := map[string][]model.LineageEdge{}
+	byTarget := map[string][]model.LineageEdge{}
+	for _, e := range edges {
+		bySource[e.Source] = append(bySource[e.Source], e)
+		byTarget[e.Target] = append(byTarget[e.Target], e)
+	}
+
+	nodes := map[string]model.LineageNode{attribute: {Code: attribute, Role: model.LineageRoot}}
+	included := map[int]bool{}
+	walk := func(next map[string][]model.LineageEdge, far func(model.LineageEdge) string, role string) {
+		frontier := []string{attribute}
+		for depth := 1; len(frontier) > 0; depth++ {
+			var reached []string
+			for _, code := range frontier {
+				for _, e := range next[code] {
+					if depth > maxDepth {
+						graph.Truncated = true
+						break
+					}
+					if !included[e.ID] {
+						included[e.ID] = true
+						graph.Edges = append(graph.Edges, e)
+					}
+					other := far(e)
+					if n, seen := nodes[other]; seen && (n.Role == role || n.Role == model.LineageRoot) {
+						continue
+					}
+					nodes[other] = model.LineageNode{Code: other, Role: role, Depth: depth}
+					reached = append(reached, other)
+				}
+			}
+			frontier = reached
+		}
+	}
+	walk(byTarget, func(e model.LineageEdge) string { return e.Source }, model.LineageUpstream)
+	walk(bySource, func(e model.LineageEdge) string { return e.Target }, model.LineageDownstream)
+
+	codes := make([]string, 0, len(nodes))
+	for code := range nodes {
+		codes = append(codes, code)
+	}
+	query, args, err := sqlx.In(`
+		SELECT code, name, COALESCE(attribute_class, '') AS attribute_class
+		  FROM kyc_attributes WHERE code IN (?)`, codes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build attribute query: %w", err)
+	}
+	var attrs []model.LineageNode
+	if err := sqlx.SelectContext(ctx, db, &attrs, db.Rebind(query), args...); err != nil {
+		return nil, fmt.Errorf("failed to load attributes: %w", err)
+	}
+	for _, a := range attrs {
+		n := nodes[a.Code]
+		n.Name, n.Class = a.Name, a.Class
+		nodes[a.Code] = n
+	}
+	if nodes[attribute].Name == "" {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAttribute, attribute)
+	}
+
+	for _, n := range nodes {
+		graph.Nodes = append(graph.Nodes, n)
+	}
+	sort.Slice(graph.Nodes, func(i, j int) bool {
+		a, b := graph.Nodes[i], graph.Nodes[j]
+		if a.Role != b.Role {
+			return roleOrder[a.Role] < roleOrder[b.Role]
+		}
+		if a.Depth != b.Depth {
+			return a.Depth < b.Depth
+		}
+		return a.Code < b.Code
+	})
+	sort.Slice(graph.Edges, func(i, j int) bool { return graph.Edges[i].ID < graph.Edges[j].ID })
+	return graph, nil
+}
+
+var roleOrder = map[string]int{model.LineageUpstream: 0, model.LineageRoot: 1, model.LineageDownstream: 2}
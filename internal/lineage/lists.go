@@ -0,0 +1,98 @@
+package lineage
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/conf"
+)
+
+// InListFunc is the rule expression function that tests membership of a
+// managed list: in_list(TAX_RESIDENCY_COUNTRY, "EU_HIGH_RISK")
+const InListFunc = "in_list"
+
+// InSanctionedListFunc is the rule expression function that tests whether a
+// country is on a jurisdiction risk list:
+// in_sanctioned_list(TAX_RESIDENCY_COUNTRY, "FATF_GREY_LIST")
+const InSanctionedListFunc = "in_sanctioned_list"
+
+// inListRef matches the list argument of in_list and in_sanctioned_list
+// calls in a rule expression
+var inListRef = regexp.MustCompile(`in_(?:sanctioned_)?list\s*\([^,()]+,\s*["']([A-Za-z0-9_]+)["']\s*\)`)
+
+// Lists are the managed lists and jurisdiction risk lists in effect, keyed
+// by name, with upper-case members
+type Lists map[string][]string
+
+// Contains reports whether value, or any element of it when it is a list, is
+// a member of the named list; members compare case-insensitively
+func (l Lists) Contains(name string, value any) (bool, error) {
+	members, ok := l[name]
+	if !ok {
+		return false, fmt.Errorf("unknown list %q", name)
+	}
+
+	var candidates []string
+	switch v := value.(type) {
+	case nil:
+		return false, nil
+	case string:
+		candidates = []string{v}
+	case []string:
+		candidates = v
+	case []any:
+		for _, item := range v {
+			if item != nil {
+				candidates = append(candidates, fmt.Sprint(item))
+			}
+		}
+	default:
+		candidates = []string{fmt.Sprint(v)}
+	}
+
+	for _, c := range candidates {
+		c = strings.ToUpper(strings.TrimSpace(c))
+		for _, m := range members {
+			if c == m {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// ReferencedLists returns the names of the lists a rule expression references
+// through in_list or in_sanctioned_list, in order of first use
+func ReferencedLists(rule string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, m := range inListRef.FindAllStringSubmatch(rule, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			names = append(names, m[1])
+		}
+	}
+	return names
+}
+
+// WithLists makes in_list and in_sanctioned_list available to the rules
+// compiled afterwards
+func (e *Evaluator) WithLists(lists Lists) *Evaluator {
+	e.options = append(e.options, inListOption(lists))
+	return e
+}
+
+// inListOption declares in_list and in_sanctioned_list over lists to the
+// rule compiler
+func inListOption(lists Lists) expr.Option {
+	contains := func(params ...any) (any, error) {
+		name, _ := params[1].(string)
+		return lists.Contains(name, params[0])
+	}
+	return func(c *conf.Config) {
+		expr.Function(InListFunc, contains, new(func(any, string) bool))(c)
+		expr.Function(InSanctionedListFunc, contains, new(func(any, string) bool))(c)
+	}
+}
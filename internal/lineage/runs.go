@@ -0,0 +1,181 @@
+package lineage
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+// ErrRunNotFound is returned for an evaluation run that was never recorded
+var ErrRunNotFound = errors.New("lineage evaluation run not found")
+
+const runColumns = `
+	id, case_name, case_version, COALESCE(inputs_hash, '') AS inputs_hash, source,
+	COALESCE(actor, '') AS actor, evaluations, failed, started_at
+`
+
+const resultColumns = `
+	id, run_id, derived_code, COALESCE(value, '') AS value, COALESCE(value_type, '') AS value_type,
+	success, COALESCE(error, '') AS error, rule, inputs, COALESCE(jurisdiction, '') AS jurisdiction,
+	COALESCE(regulation_code, '') AS regulation_code, evaluated_at
+`
+
+// RunRepo reads recorded evaluation runs
+type RunRepo struct {
+	db *sqlx.DB
+}
+
+// NewRunRepo creates a new evaluation run repository
+func NewRunRepo(db *sqlx.DB) *RunRepo {
+	return &RunRepo{db: db}
+}
+
+// History returns the runs of a case, newest first, with their results. A
+// limit of zero or less returns every run.
+func (r *RunRepo) History(ctx context.Context, caseName string, limit int) ([]model.EvaluationRun, error) {
+	query := `SELECT ` + runColumns + ` FROM kyc_lineage_runs WHERE case_name = $1 ORDER BY started_at DESC, id DESC`
+	args := []any{caseName}
+	if limit > 0 {
+		query += ` LIMIT $2`
+		args = append(args, limit)
+	}
+
+	runs := []model.EvaluationRun{}
+	if err := r.db.SelectContext(ctx, &runs, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to load lineage runs of %s: %w", caseName, err)
+	}
+	if len(runs) == 0 {
+		return runs, nil
+	}
+
+	ids := make([]int64, len(runs))
+	byID := make(map[int64]*model.EvaluationRun, len(runs))
+	for i := range runs {
+		ids[i] = runs[i].ID
+		byID[runs[i].ID] = &runs[i]
+	}
+	query, qargs, err := sqlx.In(`SELECT `+resultColumns+` FROM kyc_lineage_evaluations WHERE run_id IN (?) ORDER BY derived_code, id`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build lineage result query: %w", err)
+	}
+	var results []model.DerivationResult
+	if err := r.db.SelectContext(ctx, &results, r.db.Rebind(query), qargs...); err != nil {
+		return nil, fmt.Errorf("failed to load lineage results of %s: %w", caseName, err)
+	}
+	for _, res := range results {
+		run := byID[res.RunID]
+		run.Results = append(run.Results, res)
+	}
+	return runs, nil
+}
+
+// Get returns a run with its results
+func (r *RunRepo) Get(ctx context.Context, id int64) (*model.EvaluationRun, error) {
+	var run model.EvaluationRun
+	err := r.db.GetContext(ctx, &run, `SELECT `+runColumns+` FROM kyc_lineage_runs WHERE id = $1`, id)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: #%d", ErrRunNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load lineage run #%d: %w", id, err)
+	}
+	if err := r.db.SelectContext(ctx, &run.Results, `
+		SELECT `+resultColumns+` FROM kyc_lineage_evaluations
+		 WHERE run_id = $1 ORDER BY derived_code, id`, id); err != nil {
+		return nil, fmt.Errorf("failed to load results of lineage run #%d: %w", id, err)
+	}
+	return &run, nil
+}
+
+// RecordRun stores a run and its results, which become the run's rows in
+// the kyc_lineage_evaluations audit trail. The IDs, counts and times are
+// filled in on run.
+func RecordRun(ctx context.Context, db sqlx.QueryerContext, run *model.EvaluationRun) error {
+	run.Evaluations, run.Failed = len(run.Results), 0
+	for _, res := range run.Results {
+		if !res.Success {
+			run.Failed++
+		}
+	}
+
+	if err := sqlx.GetContext(ctx, db, run, `
+		INSERT INTO kyc_lineage_runs (case_name, case_version, inputs_hash, source, actor, evaluations, failed)
+		VALUES ($1, $2, NULLIF($3, ''), $4, NULLIF($5, ''), $6, $7)
+		RETURNING `+runColumns,
+		run.CaseName, run.CaseVersion, run.InputsHash, run.Source, run.Actor, run.Evaluations, run.Failed); err != nil {
+		return fmt.Errorf("failed to record lineage run of %s: %w", run.CaseName, err)
+	}
+
+	for i := range run.Results {
+		res := &run.Results[i]
+		inputs := res.Inputs
+		if len(inputs) == 0 {
+			inputs = nil
+		}
+		if err := sqlx.GetContext(ctx, db, res, `
+			INSERT INTO kyc_lineage_evaluations
+			(run_id, case_name, case_version, derived_code, value, value_type, success, error, inputs, rule, jurisdiction, regulation_code)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), $9, $10, NULLIF($11, ''), NULLIF($12, ''))
+			RETURNING `+resultColumns,
+			run.ID, run.CaseName, run.CaseVersion, res.DerivedCode, res.Value, res.ValueType, res.Success, res.Error,
+			[]byte(inputs), res.Rule, res.Jurisdiction, res.RegulationCode); err != nil {
+			return fmt.Errorf("failed to record evaluation (case=%s, derived=%s): %w", run.CaseName, res.DerivedCode, err)
+		}
+	}
+	return nil
+}
+
+// NewDerivationResult converts an evaluator result into the form a run
+// records
+func NewDerivationResult(res EvaluationResult) (model.DerivationResult, error) {
+	inputs, err := json.Marshal(res.Inputs)
+	if err != nil {
+		return model.DerivationResult{}, fmt.Errorf("failed to encode inputs of %s: %w", res.DerivedCode, err)
+	}
+	value, valueType := FormatValue(res.Value)
+	return model.DerivationResult{
+		DerivedCode: res.DerivedCode,
+		Value:       value,
+		ValueType:   valueType,
+		Success:     res.Success,
+		Error:       res.Error,
+		Rule:        res.Rule,
+		Inputs:      inputs,
+	}, nil
+}
+
+// InputsHash identifies a set of inputs: the sha256 of their JSON, whose
+// object keys encoding/json sorts
+func InputsHash(inputs map[string]any) (string, error) {
+	raw, err := json.Marshal(inputs)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode inputs: %w", err)
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// FormatValue stringifies a rule result the way kyc_lineage_evaluations
+// stores it, with its value type
+func FormatValue(v any) (string, string) {
+	switch v := v.(type) {
+	case nil:
+		return "", "string"
+	case bool:
+		return fmt.Sprintf("%v", v), "boolean"
+	case int, int64, float64:
+		return fmt.Sprintf("%v", v), "numeric"
+	case string:
+		return v, "string"
+	default:
+		return fmt.Sprintf("%v", v), "string"
+	}
+}
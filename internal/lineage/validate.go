@@ -0,0 +1,167 @@
+package lineage
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/ast"
+	"github.com/expr-lang/expr/parser"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/adamtc007/KYC-DSL/internal/attrvalue"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+// RuleTypes maps derivation rule types (kyc_attribute_derivations.rule_type)
+// to the result type a rule of that type must evaluate to
+var RuleTypes = map[string]string{
+	"Boolean": "boolean",
+	"Numeric": "numeric",
+	"String":  "string",
+}
+
+// AttributeTypes returns the value type of every dictionary attribute: the
+// data_type of its metadata, or for a derived attribute without one the
+// type of its rules
+func AttributeTypes(ctx context.Context, db sqlx.QueryerContext) (map[string]model.AttributeType, error) {
+	var rows []model.AttributeType
+	if err := sqlx.SelectContext(ctx, db, &rows, `
+		SELECT a.code, COALESCE(a.attribute_class, '') AS attribute_class,
+		       COALESCE(NULLIF(m.data_type, ''),
+		                CASE d.rule_type WHEN 'Boolean' THEN 'boolean' WHEN 'Numeric' THEN 'float'
+		                                 WHEN 'String' THEN 'string' END, '') AS data_type
+		  FROM kyc_attributes a
+		  LEFT JOIN kyc_attribute_metadata m ON m.attribute_code = a.code
+		  LEFT JOIN LATERAL (
+		        SELECT rule_type FROM kyc_attribute_derivations
+		         WHERE derived_attribute_code = a.code ORDER BY id LIMIT 1) d ON TRUE`); err != nil {
+		return nil, fmt.Errorf("failed to load attribute types: %w", err)
+	}
+	types := make(map[string]model.AttributeType, len(rows))
+	for _, t := range rows {
+		types[t.Code] = t
+	}
+	return types, nil
+}
+
+// ValidateRule compiles a candidate rule against typed stand-ins for the
+// attributes, so syntax errors, type mismatches and unknown attribute or
+// list names surface when the rule is authored rather than when it is
+// first evaluated. sources are the declared source attributes (none: not
+// checked) and ruleType the declared rule type (empty: any result).
+func ValidateRule(rule string, types map[string]model.AttributeType, lists Lists, sources []string, ruleType string) model.RuleValidation {
+	v := model.RuleValidation{
+		Rule:              rule,
+		CompileErrors:     []string{},
+		TypeErrors:        []string{},
+		UnknownAttributes: []string{},
+		UnknownLists:      []string{},
+		UndeclaredSources: []string{},
+	}
+
+	tree, err := parser.Parse(rule)
+	if err != nil {
+		v.CompileErrors = append(v.CompileErrors, err.Error())
+		return v
+	}
+
+	// Attributes of unknown type and unknown names are left out of the
+	// environment and typed as any, so they don't mask type errors
+	env := make(map[string]any, len(types))
+	for code, t := range types {
+		if zero := attrvalue.Zero(t.DataType); zero != nil {
+			env[code] = zero
+		}
+	}
+	declared := make(map[string]bool, len(sources))
+	for _, s := range sources {
+		declared[strings.ToUpper(s)] = true
+	}
+	for _, name := range referencedNames(tree.Node) {
+		if _, ok := types[name]; !ok {
+			v.UnknownAttributes = append(v.UnknownAttributes, name)
+		} else if len(sources) > 0 && !declared[name] {
+			v.UndeclaredSources = append(v.UndeclaredSources, name)
+		}
+	}
+	for _, name := range ReferencedLists(rule) {
+		if _, ok := lists[name]; !ok {
+			v.UnknownLists = append(v.UnknownLists, name)
+		}
+	}
+
+	program, err := expr.Compile(rule, expr.Env(env), expr.AllowUndefinedVariables(), inListOption(lists))
+	if err != nil {
+		v.TypeErrors = append(v.TypeErrors, err.Error())
+	} else {
+		v.ResultType = resultType(program.Node().Type())
+		if want, ok := RuleTypes[ruleType]; ok && v.ResultType != "any" && v.ResultType != want {
+			v.TypeErrors = append(v.TypeErrors,
+				fmt.Sprintf("rule type %s expects a %s result, the rule returns %s", ruleType, want, v.ResultType))
+		}
+	}
+
+	v.Valid = len(v.CompileErrors) == 0 && len(v.TypeErrors) == 0 &&
+		len(v.UnknownAttributes) == 0 && len(v.UnknownLists) == 0
+	return v
+}
+
+// referencedNames returns the variables a rule reads, sorted: identifiers
+// that are neither called as functions nor bound by let
+func referencedNames(node ast.Node) []string {
+	c := &nameCollector{refs: map[string]int{}, bound: map[string]bool{}}
+	ast.Walk(&node, c)
+	var names []string
+	for name, n := range c.refs {
+		if n > 0 && !c.bound[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// nameCollector counts identifier uses. The walk visits a call after its
+// callee, so a callee is counted and then discounted.
+type nameCollector struct {
+	refs  map[string]int
+	bound map[string]bool
+}
+
+func (c *nameCollector) Visit(node *ast.Node) {
+	switch n := (*node).(type) {
+	case *ast.IdentifierNode:
+		c.refs[n.Value]++
+	case *ast.CallNode:
+		if id, ok := n.Callee.(*ast.IdentifierNode); ok {
+			c.refs[id.Value]--
+		}
+	case *ast.VariableDeclaratorNode:
+		c.bound[n.Name] = true
+	}
+}
+
+// resultType names a compiled rule's result type the way value types are
+// recorded
+func resultType(t reflect.Type) string {
+	if t == nil {
+		return "any"
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "numeric"
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	}
+	return "any"
+}
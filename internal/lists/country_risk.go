@@ -0,0 +1,256 @@
+package lists
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+const countryListColumns = `
+	code, name, COALESCE(authority, '') AS authority, COALESCE(description, '') AS description,
+	COALESCE(updated_by, '') AS updated_by, updated_at
+`
+
+const countryEntryColumns = `
+	id, list_code, country_code, effective_from, effective_to, COALESCE(note, '') AS note,
+	COALESCE(updated_by, '') AS updated_by, updated_at
+`
+
+// inEffectOn filters kyc_country_risk rows to the listings in effect on $1
+const inEffectOn = `effective_from <= $1 AND (effective_to IS NULL OR effective_to >= $1)`
+
+// CountryRiskLists returns the jurisdiction risk lists by code, each with
+// the countries listed today
+func (r *Repo) CountryRiskLists(ctx context.Context) ([]model.CountryRiskList, error) {
+	var all []model.CountryRiskList
+	if err := r.db.SelectContext(ctx, &all, `SELECT `+countryListColumns+` FROM kyc_country_risk_lists ORDER BY code`); err != nil {
+		return nil, fmt.Errorf("failed to list country risk lists: %w", err)
+	}
+	var entries []model.CountryRiskEntry
+	if err := r.db.SelectContext(ctx, &entries, `
+		SELECT `+countryEntryColumns+` FROM kyc_country_risk
+		 WHERE `+inEffectOn+` ORDER BY list_code, country_code`, today()); err != nil {
+		return nil, fmt.Errorf("failed to load country risk listings: %w", err)
+	}
+	byList := map[string][]model.CountryRiskEntry{}
+	for _, e := range entries {
+		byList[e.ListCode] = append(byList[e.ListCode], e)
+	}
+	for i := range all {
+		all[i].Countries = byList[all[i].Code]
+		if all[i].Countries == nil {
+			all[i].Countries = []model.CountryRiskEntry{}
+		}
+	}
+	return all, nil
+}
+
+// CountryRiskList returns a jurisdiction risk list with the countries listed
+// today or, with history, every listing past and future, newest first
+func (r *Repo) CountryRiskList(ctx context.Context, code string, history bool) (*model.CountryRiskList, error) {
+	var list model.CountryRiskList
+	err := r.db.GetContext(ctx, &list, `SELECT `+countryListColumns+` FROM kyc_country_risk_lists WHERE code = $1`, code)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, code)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get country risk list: %w", err)
+	}
+
+	list.Countries = []model.CountryRiskEntry{}
+	if history {
+		err = r.db.SelectContext(ctx, &list.Countries, `
+			SELECT `+countryEntryColumns+` FROM kyc_country_risk
+			 WHERE list_code = $1 ORDER BY effective_from DESC, country_code`, code)
+	} else {
+		err = r.db.SelectContext(ctx, &list.Countries, `
+			SELECT `+countryEntryColumns+` FROM kyc_country_risk
+			 WHERE list_code = $2 AND `+inEffectOn+` ORDER BY country_code`, today(), code)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load country risk listings: %w", err)
+	}
+	return &list, nil
+}
+
+// PutCountryRiskList creates a jurisdiction risk list or updates its name,
+// authority and description. Its code must not be taken by a managed list,
+// as rules reference both by name.
+func (r *Repo) PutCountryRiskList(ctx context.Context, list model.CountryRiskList, changedBy string) (*model.CountryRiskList, error) {
+	if !validName.MatchString(list.Code) {
+		return nil, fmt.Errorf("%w: code %q must use upper-case letters, digits and underscores", ErrInvalid, list.Code)
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if err := checkNameFree(ctx, tx, `SELECT EXISTS (SELECT 1 FROM kyc_managed_lists WHERE name = $1)`, list.Code); err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO kyc_country_risk_lists (code, name, authority, description, updated_by)
+		VALUES ($1, COALESCE(NULLIF($2, ''), $1), NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, ''))
+		ON CONFLICT (code) DO UPDATE
+		   SET name = COALESCE(NULLIF($2, ''), kyc_country_risk_lists.name),
+		       authority = COALESCE(EXCLUDED.authority, kyc_country_risk_lists.authority),
+		       description = COALESCE(EXCLUDED.description, kyc_country_risk_lists.description),
+		       updated_by = EXCLUDED.updated_by,
+		       updated_at = CURRENT_TIMESTAMP`,
+		list.Code, list.Name, list.Authority, list.Description, changedBy); err != nil {
+		return nil, fmt.Errorf("failed to save country risk list: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit country risk list: %w", err)
+	}
+	return r.CountryRiskList(ctx, list.Code, false)
+}
+
+// PutCountryRisk lists a country on a jurisdiction risk list from a date
+// (default today), until an optional end date. A listing with the same
+// start date is replaced; a listing overlapping another period of the
+// country on the list is refused.
+func (r *Repo) PutCountryRisk(ctx context.Context, entry model.CountryRiskEntry, changedBy string) (*model.CountryRiskEntry, error) {
+	entry.CountryCode = strings.ToUpper(strings.TrimSpace(entry.CountryCode))
+	if !validCountry(entry.CountryCode) {
+		return nil, fmt.Errorf("%w: country %q must be an ISO 3166 alpha-2 code", ErrInvalid, entry.CountryCode)
+	}
+	if entry.EffectiveFrom.IsZero() {
+		entry.EffectiveFrom = today()
+	}
+	if entry.EffectiveTo != nil && entry.EffectiveTo.Before(entry.EffectiveFrom) {
+		return nil, fmt.Errorf("%w: effective_to is before effective_from", ErrInvalid)
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	var exists bool
+	if err := tx.GetContext(ctx, &exists, `SELECT EXISTS (SELECT 1 FROM kyc_country_risk_lists WHERE code = $1)`, entry.ListCode); err != nil {
+		return nil, fmt.Errorf("failed to look up country risk list: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, entry.ListCode)
+	}
+
+	var overlapping bool
+	if err := tx.GetContext(ctx, &overlapping, `
+		SELECT EXISTS (
+		    SELECT 1 FROM kyc_country_risk
+		     WHERE list_code = $1 AND country_code = $2 AND effective_from <> $3
+		       AND effective_from <= COALESCE($4, 'infinity'::date)
+		       AND COALESCE(effective_to, 'infinity'::date) >= $3)`,
+		entry.ListCode, entry.CountryCode, entry.EffectiveFrom, entry.EffectiveTo); err != nil {
+		return nil, fmt.Errorf("failed to check listings of %s: %w", entry.CountryCode, err)
+	}
+	if overlapping {
+		return nil, fmt.Errorf("%w: %s already has a listing on %s overlapping these dates", ErrInvalid, entry.CountryCode, entry.ListCode)
+	}
+
+	var saved model.CountryRiskEntry
+	if err := tx.GetContext(ctx, &saved, `
+		INSERT INTO kyc_country_risk (list_code, country_code, effective_from, effective_to, note, updated_by)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''))
+		ON CONFLICT (list_code, country_code, effective_from) DO UPDATE
+		   SET effective_to = EXCLUDED.effective_to,
+		       note = COALESCE(EXCLUDED.note, kyc_country_risk.note),
+		       updated_by = EXCLUDED.updated_by,
+		       updated_at = CURRENT_TIMESTAMP
+		RETURNING `+countryEntryColumns,
+		entry.ListCode, entry.CountryCode, entry.EffectiveFrom, entry.EffectiveTo, entry.Note, changedBy); err != nil {
+		return nil, fmt.Errorf("failed to save country risk listing: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit country risk listing: %w", err)
+	}
+	return &saved, nil
+}
+
+// EndCountryRisk de-lists a country from a jurisdiction risk list after a
+// date (default today) by ending the listing in effect on that date
+func (r *Repo) EndCountryRisk(ctx context.Context, code, country string, on time.Time, changedBy string) (*model.CountryRiskEntry, error) {
+	if on.IsZero() {
+		on = today()
+	}
+	var ended model.CountryRiskEntry
+	err := r.db.GetContext(ctx, &ended, `
+		UPDATE kyc_country_risk
+		   SET effective_to = $1, updated_by = NULLIF($4, ''), updated_at = CURRENT_TIMESTAMP
+		 WHERE list_code = $2 AND country_code = $3 AND `+inEffectOn+`
+		RETURNING `+countryEntryColumns,
+		on, code, strings.ToUpper(country), changedBy)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %s is not on %s on %s", ErrNotFound, strings.ToUpper(country), code, on.Format("2006-01-02"))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to end country risk listing: %w", err)
+	}
+	return &ended, nil
+}
+
+// countryListsInEffect returns the countries on each jurisdiction risk list
+// as of a date; lists without listings in effect are empty
+func (r *Repo) countryListsInEffect(ctx context.Context, asOf time.Time) (map[string][]string, error) {
+	var rows []struct {
+		Code    string         `db:"code"`
+		Country sql.NullString `db:"country_code"`
+	}
+	if err := r.db.SelectContext(ctx, &rows, `
+		SELECT l.code, c.country_code
+		  FROM kyc_country_risk_lists l
+		  LEFT JOIN kyc_country_risk c
+		         ON c.list_code = l.code AND c.effective_from <= $1
+		        AND (c.effective_to IS NULL OR c.effective_to >= $1)
+		 ORDER BY l.code, c.country_code`, asOf); err != nil {
+		return nil, fmt.Errorf("failed to load country risk lists: %w", err)
+	}
+	lists := map[string][]string{}
+	for _, row := range rows {
+		if _, ok := lists[row.Code]; !ok {
+			lists[row.Code] = []string{}
+		}
+		if row.Country.Valid {
+			lists[row.Code] = append(lists[row.Code], row.Country.String)
+		}
+	}
+	return lists, nil
+}
+
+// checkNameFree fails with ErrInvalid when query finds name taken
+func checkNameFree(ctx context.Context, db sqlx.QueryerContext, query, name string) error {
+	var taken bool
+	if err := sqlx.GetContext(ctx, db, &taken, query, name); err != nil {
+		return fmt.Errorf("failed to check list name: %w", err)
+	}
+	if taken {
+		return fmt.Errorf("%w: %s is already the name of another list", ErrInvalid, name)
+	}
+	return nil
+}
+
+func validCountry(code string) bool {
+	if len(code) != 2 {
+		return false
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+func today() time.Time {
+	return time.Now().UTC().Truncate(24 * time.Hour)
+}
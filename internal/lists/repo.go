@@ -0,0 +1,230 @@
+// Package lists manages the named lists (sanctioned and high-risk
+// jurisdictions...) that derivation rules reference with in_list, the
+// jurisdiction risk lists (FATF, EU) they reference with in_sanctioned_list,
+// and re-evaluates the derived attributes that depend on a list when it
+// changes.
+package lists
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/adamtc007/KYC-DSL/internal/lineage"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+var (
+	// ErrNotFound is returned for an unknown list
+	ErrNotFound = errors.New("list not found")
+	// ErrInvalid is returned for a list that cannot be saved
+	ErrInvalid = errors.New("invalid list")
+)
+
+// validName matches list names usable from rule expressions
+var validName = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+// Repo stores managed lists and their history
+type Repo struct {
+	db *sqlx.DB
+}
+
+// NewRepo creates a new managed list repository
+func NewRepo(db *sqlx.DB) *Repo {
+	return &Repo{db: db}
+}
+
+// listRow scans a kyc_managed_lists or kyc_managed_list_history row
+type listRow struct {
+	Name          string         `db:"name"`
+	Description   string         `db:"description"`
+	Members       pq.StringArray `db:"members"`
+	Source        string         `db:"source"`
+	EffectiveFrom time.Time      `db:"effective_from"`
+	EffectiveTo   *time.Time     `db:"effective_to"`
+	Version       int            `db:"version"`
+	UpdatedBy     string         `db:"updated_by"`
+	UpdatedAt     time.Time      `db:"updated_at"`
+}
+
+func (r listRow) toModel() model.ManagedList {
+	members := []string(r.Members)
+	if members == nil {
+		members = []string{}
+	}
+	return model.ManagedList{
+		Name:          r.Name,
+		Description:   r.Description,
+		Members:       members,
+		Source:        r.Source,
+		EffectiveFrom: r.EffectiveFrom,
+		EffectiveTo:   r.EffectiveTo,
+		Version:       r.Version,
+		UpdatedBy:     r.UpdatedBy,
+		UpdatedAt:     r.UpdatedAt,
+	}
+}
+
+const listColumns = `
+	name, COALESCE(description, '') AS description, members, COALESCE(source, '') AS source,
+	effective_from, effective_to, version, COALESCE(updated_by, '') AS updated_by, updated_at
+`
+
+// List returns all managed lists by name
+func (r *Repo) List(ctx context.Context) ([]model.ManagedList, error) {
+	var rows []listRow
+	if err := r.db.SelectContext(ctx, &rows, `SELECT `+listColumns+` FROM kyc_managed_lists ORDER BY name`); err != nil {
+		return nil, fmt.Errorf("failed to list managed lists: %w", err)
+	}
+	lists := make([]model.ManagedList, 0, len(rows))
+	for _, row := range rows {
+		lists = append(lists, row.toModel())
+	}
+	return lists, nil
+}
+
+// Get returns a managed list by name
+func (r *Repo) Get(ctx context.Context, name string) (*model.ManagedList, error) {
+	var row listRow
+	err := r.db.GetContext(ctx, &row, `SELECT `+listColumns+` FROM kyc_managed_lists WHERE name = $1`, name)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get managed list: %w", err)
+	}
+	list := row.toModel()
+	return &list, nil
+}
+
+// History returns every version of a list, newest first
+func (r *Repo) History(ctx context.Context, name string) ([]model.ManagedList, error) {
+	var rows []listRow
+	err := r.db.SelectContext(ctx, &rows, `
+		SELECT h.list_name AS name, COALESCE(l.description, '') AS description, h.members,
+		       COALESCE(h.source, '') AS source, h.effective_from, h.effective_to, h.version,
+		       COALESCE(h.changed_by, '') AS updated_by, h.changed_at AS updated_at
+		  FROM kyc_managed_list_history h
+		  JOIN kyc_managed_lists l ON l.name = h.list_name
+		 WHERE h.list_name = $1
+		 ORDER BY h.version DESC`, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get list history: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	lists := make([]model.ManagedList, 0, len(rows))
+	for _, row := range rows {
+		lists = append(lists, row.toModel())
+	}
+	return lists, nil
+}
+
+// Put creates a list or replaces its members, source and effective dates,
+// bumping its version and recording the new version in the history
+func (r *Repo) Put(ctx context.Context, list model.ManagedList, changedBy string) (*model.ManagedList, error) {
+	if !validName.MatchString(list.Name) {
+		return nil, fmt.Errorf("%w: name %q must use upper-case letters, digits and underscores", ErrInvalid, list.Name)
+	}
+	if list.EffectiveFrom.IsZero() {
+		list.EffectiveFrom = time.Now().UTC().Truncate(24 * time.Hour)
+	}
+	if list.EffectiveTo != nil && list.EffectiveTo.Before(list.EffectiveFrom) {
+		return nil, fmt.Errorf("%w: effective_to is before effective_from", ErrInvalid)
+	}
+	members := NormalizeMembers(list.Members)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if err := checkNameFree(ctx, tx, `SELECT EXISTS (SELECT 1 FROM kyc_country_risk_lists WHERE code = $1)`, list.Name); err != nil {
+		return nil, err
+	}
+
+	var version int
+	err = tx.GetContext(ctx, &version, `
+		INSERT INTO kyc_managed_lists (name, description, members, source, effective_from, effective_to, updated_by)
+		VALUES ($1, NULLIF($2, ''), $3, NULLIF($4, ''), $5, $6, NULLIF($7, ''))
+		ON CONFLICT (name) DO UPDATE
+		   SET description = COALESCE(EXCLUDED.description, kyc_managed_lists.description),
+		       members = EXCLUDED.members,
+		       source = COALESCE(EXCLUDED.source, kyc_managed_lists.source),
+		       effective_from = EXCLUDED.effective_from,
+		       effective_to = EXCLUDED.effective_to,
+		       version = kyc_managed_lists.version + 1,
+		       updated_by = EXCLUDED.updated_by,
+		       updated_at = CURRENT_TIMESTAMP
+		RETURNING version`,
+		list.Name, list.Description, pq.Array(members), list.Source, list.EffectiveFrom, list.EffectiveTo, changedBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save managed list: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO kyc_managed_list_history (list_name, version, members, source, effective_from, effective_to, changed_by)
+		SELECT name, version, members, source, effective_from, effective_to, updated_by
+		  FROM kyc_managed_lists WHERE name = $1`, list.Name); err != nil {
+		return nil, fmt.Errorf("failed to record list history: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit managed list: %w", err)
+	}
+	return r.Get(ctx, list.Name)
+}
+
+// InEffect returns the members of every list as of a date, managed lists
+// and jurisdiction risk lists alike; lists outside their effective dates
+// are empty, so rules referencing them evaluate to false
+func (r *Repo) InEffect(ctx context.Context, asOf time.Time) (lineage.Lists, error) {
+	var rows []listRow
+	err := r.db.SelectContext(ctx, &rows, `
+		SELECT name,
+		       CASE WHEN effective_from <= $1 AND (effective_to IS NULL OR effective_to >= $1)
+		            THEN members ELSE '{}' END AS members,
+		       effective_from, effective_to, version, updated_at
+		  FROM kyc_managed_lists`, asOf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load managed lists: %w", err)
+	}
+	lists := make(lineage.Lists, len(rows))
+	for _, row := range rows {
+		lists[row.Name] = []string(row.Members)
+	}
+
+	countries, err := r.countryListsInEffect(ctx, asOf)
+	if err != nil {
+		return nil, err
+	}
+	for code, members := range countries {
+		lists[code] = members
+	}
+	return lists, nil
+}
+
+// NormalizeMembers upper-cases, de-duplicates and sorts list members
+func NormalizeMembers(members []string) []string {
+	seen := make(map[string]bool, len(members))
+	out := []string{}
+	for _, m := range members {
+		m = strings.ToUpper(strings.TrimSpace(m))
+		if m != "" && !seen[m] {
+			seen[m] = true
+			out = append(out, m)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,98 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor assigns a request ID to each call (reusing the
+// caller's x-request-id metadata), returns it in the response header, logs
+// the call and appends the ID to error messages.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, id := serverRequestID(ctx)
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logCall(ctx, info.FullMethod, start, err)
+		return resp, annotateError(err, id)
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of UnaryServerInterceptor
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, id := serverRequestID(ss.Context())
+		start := time.Now()
+		err := handler(srv, &requestIDStream{ServerStream: ss, ctx: ctx})
+		logCall(ctx, info.FullMethod, start, err)
+		return annotateError(err, id)
+	}
+}
+
+// UnaryClientInterceptor forwards the request ID in ctx to the called service
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if id := RequestIDFromContext(ctx); id != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, RequestIDMetadataKey, id)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func serverRequestID(ctx context.Context) (context.Context, string) {
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(RequestIDMetadataKey); len(values) > 0 && validRequestID(values[0]) {
+			id = values[0]
+		}
+	}
+	if id == "" {
+		id = NewRequestID()
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDMetadataKey, id))
+	return WithRequestID(ctx, id), id
+}
+
+func logCall(ctx context.Context, method string, start time.Time, err error) {
+	code := status.Code(err)
+	level := slog.LevelInfo
+	switch code {
+	case codes.OK, codes.NotFound, codes.InvalidArgument, codes.AlreadyExists, codes.Canceled:
+	default:
+		level = slog.LevelError
+	}
+	attrs := []any{"method", method, "code", code.String(), "duration_ms", time.Since(start).Milliseconds()}
+	if err != nil {
+		attrs = append(attrs, "error", err)
+	}
+	FromContext(ctx).Log(ctx, level, "grpc call", attrs...)
+}
+
+// annotateError appends the request ID to a gRPC error message so clients
+// can quote it when reporting a problem
+func annotateError(err error, id string) error {
+	if err == nil {
+		return nil
+	}
+	// Rebuild from the proto so status details survive
+	p := status.Convert(err).Proto()
+	p.Message = fmt.Sprintf("%s (request_id=%s)", p.Message, id)
+	return status.ErrorProto(p)
+}
+
+// requestIDStream overrides the stream context to carry the request ID
+type requestIDStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *requestIDStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,95 @@
+// Package logging configures slog-based structured logging and carries a
+// per-request ID through HTTP handlers, gRPC calls and their logs so a
+// client-reported request ID can be matched to server log lines.
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+)
+
+// RequestIDHeader is the HTTP header carrying the request ID
+const RequestIDHeader = "X-Request-ID"
+
+// RequestIDMetadataKey is the gRPC metadata key carrying the request ID
+const RequestIDMetadataKey = "x-request-id"
+
+type requestIDKey struct{}
+
+// Init installs the default slog logger for a service. Output from the
+// standard log package is routed through the same handler.
+func Init(service string, cfg config.LogConfig) *slog.Logger {
+	logger := New(os.Stderr, cfg).With("service", service)
+	slog.SetDefault(logger)
+	return logger
+}
+
+// New builds a logger writing to w in the configured format and level
+func New(w io.Writer, cfg config.LogConfig) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: parseLevel(cfg.Level)}
+	if cfg.Format == "json" {
+		return slog.New(slog.NewJSONHandler(w, opts))
+	}
+	return slog.New(slog.NewTextHandler(w, opts))
+}
+
+func parseLevel(level string) slog.Level {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// NewRequestID returns a random 16-byte hex request ID
+func NewRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
+
+// WithRequestID returns a context carrying the request ID
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID in ctx, or ""
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// FromContext returns the default logger annotated with the request ID in ctx
+func FromContext(ctx context.Context) *slog.Logger {
+	if id := RequestIDFromContext(ctx); id != "" {
+		return slog.Default().With("request_id", id)
+	}
+	return slog.Default()
+}
+
+// validRequestID accepts caller-supplied IDs that are safe to log and echo
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+	for _, r := range id {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,76 @@
+package mappings
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+// csvHeader is the column order of exported and imported mappings
+var csvHeader = []string{"attribute_code", "standard", "external_id", "external_name", "uri", "match_type", "note"}
+
+// WriteCSV writes mappings as CSV with a header row
+func WriteCSV(w io.Writer, mappings []model.ExternalMapping) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, m := range mappings {
+		if err := cw.Write([]string{m.AttributeCode, m.Standard, m.ExternalID, m.ExternalName, m.URI, m.MatchType, m.Note}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ReadCSV reads mappings written by WriteCSV. Columns are matched by the
+// header, so they may come in any order and optional ones may be left out.
+func ReadCSV(r io.Reader) ([]model.ExternalMapping, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"attribute_code", "standard", "external_id"} {
+		if _, ok := index[required]; !ok {
+			return nil, fmt.Errorf("%w: CSV has no %s column", ErrInvalid, required)
+		}
+	}
+
+	var mappings []model.ExternalMapping
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return mappings, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV line %d: %w", line, err)
+		}
+		field := func(name string) string {
+			if i, ok := index[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		mappings = append(mappings, model.ExternalMapping{
+			AttributeCode: field("attribute_code"),
+			Standard:      field("standard"),
+			ExternalID:    field("external_id"),
+			ExternalName:  field("external_name"),
+			URI:           field("uri"),
+			MatchType:     field("match_type"),
+			Note:          field("note"),
+		})
+	}
+}
@@ -0,0 +1,166 @@
+// Package mappings links attribute codes to identifiers of external
+// standards (ISO 20022 message elements, FIBO concepts), so search results
+// and exported dictionaries can be lined up with core banking message
+// schemas and data-governance catalogs.
+package mappings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+var (
+	// ErrNotFound is returned when deleting a mapping that does not exist
+	ErrNotFound = errors.New("external mapping not found")
+	// ErrInvalid is returned for a mapping that cannot be saved
+	ErrInvalid = errors.New("invalid external mapping")
+)
+
+// Standards are the external standards attributes can be mapped to
+var Standards = []string{model.StandardISO20022, model.StandardFIBO}
+
+// MatchTypes are the SKOS mapping relations a mapping can have
+var MatchTypes = []string{"exact", "close", "broad", "narrow", "related"}
+
+const mappingColumns = `attribute_code, standard, external_id, COALESCE(external_name, '') AS external_name,
+	COALESCE(uri, '') AS uri, match_type, COALESCE(note, '') AS note,
+	COALESCE(updated_by, '') AS updated_by, updated_at`
+
+// Repo stores external mappings
+type Repo struct {
+	db *sqlx.DB
+}
+
+// NewRepo creates a new external mapping repository
+func NewRepo(db *sqlx.DB) *Repo {
+	return &Repo{db: db}
+}
+
+// List returns the mappings of an attribute and/or standard (all when
+// empty), by attribute code
+func (r *Repo) List(ctx context.Context, attributeCode, standard string) ([]model.ExternalMapping, error) {
+	mappings := []model.ExternalMapping{}
+	err := r.db.SelectContext(ctx, &mappings, `
+		SELECT `+mappingColumns+`
+		FROM attribute_external_mappings
+		WHERE ($1 = '' OR attribute_code = $1) AND ($2 = '' OR standard = $2)
+		ORDER BY attribute_code, standard, external_id`, attributeCode, standard)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list external mappings: %w", err)
+	}
+	return mappings, nil
+}
+
+// ForAttributes returns the mappings of the given attribute codes by code
+func (r *Repo) ForAttributes(ctx context.Context, codes []string) (map[string][]model.ExternalMapping, error) {
+	byCode := make(map[string][]model.ExternalMapping)
+	if len(codes) == 0 {
+		return byCode, nil
+	}
+	var mappings []model.ExternalMapping
+	err := r.db.SelectContext(ctx, &mappings, `
+		SELECT `+mappingColumns+`
+		FROM attribute_external_mappings
+		WHERE attribute_code = ANY($1)
+		ORDER BY standard, external_id`, pq.Array(codes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load external mappings: %w", err)
+	}
+	for _, m := range mappings {
+		byCode[m.AttributeCode] = append(byCode[m.AttributeCode], m)
+	}
+	return byCode, nil
+}
+
+// Put creates or replaces mappings, all or none. A mapping is identified by
+// its attribute code, standard and external id.
+func (r *Repo) Put(ctx context.Context, mappings []model.ExternalMapping, updatedBy string) error {
+	for i := range mappings {
+		if err := normalize(&mappings[i]); err != nil {
+			return err
+		}
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	for _, m := range mappings {
+		var known bool
+		if err := tx.GetContext(ctx, &known,
+			`SELECT EXISTS (SELECT 1 FROM kyc_attributes WHERE code = $1)`, m.AttributeCode); err != nil {
+			return fmt.Errorf("failed to check attribute %s: %w", m.AttributeCode, err)
+		}
+		if !known {
+			return fmt.Errorf("%w: unknown attribute %s", ErrInvalid, m.AttributeCode)
+		}
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO attribute_external_mappings
+			       (attribute_code, standard, external_id, external_name, uri, match_type, note, updated_by)
+			VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6, NULLIF($7, ''), NULLIF($8, ''))
+			ON CONFLICT (attribute_code, standard, external_id) DO UPDATE
+			   SET external_name = EXCLUDED.external_name,
+			       uri = EXCLUDED.uri,
+			       match_type = EXCLUDED.match_type,
+			       note = EXCLUDED.note,
+			       updated_by = EXCLUDED.updated_by,
+			       updated_at = NOW()`,
+			m.AttributeCode, m.Standard, m.ExternalID, m.ExternalName, m.URI, m.MatchType, m.Note, updatedBy)
+		if err != nil {
+			return fmt.Errorf("failed to save mapping %s → %s %s: %w", m.AttributeCode, m.Standard, m.ExternalID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit external mappings: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a mapping
+func (r *Repo) Delete(ctx context.Context, attributeCode, standard, externalID string) error {
+	res, err := r.db.ExecContext(ctx, `
+		DELETE FROM attribute_external_mappings
+		WHERE attribute_code = $1 AND standard = $2 AND external_id = $3`,
+		attributeCode, strings.ToLower(standard), externalID)
+	if err != nil {
+		return fmt.Errorf("failed to delete external mapping: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%w: %s → %s %s", ErrNotFound, attributeCode, standard, externalID)
+	}
+	return nil
+}
+
+// normalize trims a mapping, defaults its match type and validates it
+func normalize(m *model.ExternalMapping) error {
+	m.AttributeCode = strings.TrimSpace(m.AttributeCode)
+	m.Standard = strings.ToLower(strings.TrimSpace(m.Standard))
+	m.ExternalID = strings.TrimSpace(m.ExternalID)
+	m.MatchType = strings.ToLower(strings.TrimSpace(m.MatchType))
+	if m.MatchType == "" {
+		m.MatchType = "exact"
+	}
+
+	switch {
+	case m.AttributeCode == "":
+		return fmt.Errorf("%w: attribute_code is required", ErrInvalid)
+	case !slices.Contains(Standards, m.Standard):
+		return fmt.Errorf("%w: standard %q (expected %s)", ErrInvalid, m.Standard, strings.Join(Standards, " or "))
+	case m.ExternalID == "":
+		return fmt.Errorf("%w: external_id is required", ErrInvalid)
+	case !slices.Contains(MatchTypes, m.MatchType):
+		return fmt.Errorf("%w: match_type %q (expected %s)", ErrInvalid, m.MatchType, strings.Join(MatchTypes, ", "))
+	}
+	return nil
+}
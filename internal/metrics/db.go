@@ -0,0 +1,27 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// RegisterPgxPoolStats exposes statistics of the shared pgxpool (internal/db)
+func RegisterPgxPoolStats(pool string, p *pgxpool.Pool) {
+	labels := prometheus.Labels{"pool": pool}
+	gauge := func(name, help string, value func(*pgxpool.Stat) float64) {
+		prometheus.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "db_pool",
+			Name:        name,
+			Help:        help,
+			ConstLabels: labels,
+		}, func() float64 { return value(p.Stat()) }))
+	}
+
+	gauge("open_connections", "Open connections in the pool.", func(s *pgxpool.Stat) float64 { return float64(s.TotalConns()) })
+	gauge("in_use_connections", "Connections currently in use.", func(s *pgxpool.Stat) float64 { return float64(s.AcquiredConns()) })
+	gauge("idle_connections", "Idle connections in the pool.", func(s *pgxpool.Stat) float64 { return float64(s.IdleConns()) })
+	gauge("max_connections", "Maximum connections allowed.", func(s *pgxpool.Stat) float64 { return float64(s.MaxConns()) })
+	gauge("wait_count", "Total connections waited for.", func(s *pgxpool.Stat) float64 { return float64(s.EmptyAcquireCount()) })
+	gauge("wait_seconds", "Total time waited for connections.", func(s *pgxpool.Stat) float64 { return s.AcquireDuration().Seconds() })
+}
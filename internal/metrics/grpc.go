@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor records request counts and latency for unary RPCs
+func UnaryServerInterceptor(server string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		observeGRPC(server, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor records request counts and latency for streaming RPCs
+func StreamServerInterceptor(server string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		observeGRPC(server, info.FullMethod, start, err)
+		return err
+	}
+}
+
+func observeGRPC(server, method string, start time.Time, err error) {
+	grpcRequests.WithLabelValues(server, method, status.Code(err).String()).Inc()
+	grpcDuration.WithLabelValues(server, method).Observe(time.Since(start).Seconds())
+}
+
+// Reasons a gRPC request is refused before its handler runs
+const (
+	GRPCRejectedValidation = "validation"
+	GRPCRejectedDeadline   = "deadline"
+)
+
+// ObserveGRPCPanic counts a panic recovered from a gRPC handler
+func ObserveGRPCPanic(server, method string) {
+	grpcPanics.WithLabelValues(server, method).Inc()
+}
+
+// ObserveGRPCRejected counts a gRPC request refused before its handler
+func ObserveGRPCRejected(server, method, reason string) {
+	grpcRejected.WithLabelValues(server, method, reason).Inc()
+}
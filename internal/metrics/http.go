@@ -0,0 +1,38 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// HTTPMiddleware records request counts and latency for every request
+// handled by next. The route label is the ServeMux pattern that matched,
+// which keeps label cardinality bounded for paths like /rag/attribute/{code}.
+// next must pass the request through unchanged to the mux, since the mux
+// records the pattern on the *Request it receives.
+func HTTPMiddleware(server string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rec, r)
+
+		route := r.Pattern
+		if route == "" {
+			route = "unmatched"
+		}
+		httpRequests.WithLabelValues(server, r.Method, route, strconv.Itoa(rec.status)).Inc()
+		httpDuration.WithLabelValues(server, r.Method, route).Observe(time.Since(start).Seconds())
+	})
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (s *statusRecorder) WriteHeader(code int) {
+	s.status = code
+	s.ResponseWriter.WriteHeader(code)
+}
@@ -0,0 +1,185 @@
+// Package metrics exposes Prometheus metrics shared by all KYC-DSL servers:
+// HTTP and gRPC request counts and latencies, embedding call durations and
+// cache hits, response cache hits, vector search result counts, search
+// audit logging, rate limiting, and database pool statistics.
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "kyc"
+
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by server, method, route and status code.",
+	}, []string{"server", "method", "route", "code"})
+
+	httpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by server, method and route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"server", "method", "route"})
+
+	grpcRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "grpc_requests_total",
+		Help:      "gRPC requests by server, full method and status code.",
+	}, []string{"server", "method", "code"})
+
+	grpcDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "grpc_request_duration_seconds",
+		Help:      "gRPC request latency by server and full method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"server", "method"})
+
+	grpcPanics = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "grpc_panics_total",
+		Help:      "gRPC handler panics recovered, by server and full method.",
+	}, []string{"server", "method"})
+
+	grpcRejected = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "grpc_rejected_requests_total",
+		Help:      "gRPC requests refused before their handler, by server, full method and reason (validation, deadline).",
+	}, []string{"server", "method", "reason"})
+
+	embeddingDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "embedding_request_duration_seconds",
+		Help:      "Embedding provider call latency by model and outcome.",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2, 5, 10, 30},
+	}, []string{"model", "outcome"})
+
+	embeddingCacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "embedding_cache_lookups_total",
+		Help:      "Query embedding cache lookups by model and result (memory_hit, postgres_hit, miss).",
+	}, []string{"model", "result"})
+
+	vectorSearchResults = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "vector_search_results",
+		Help:      "Number of results returned by pgvector searches.",
+		Buckets:   []float64{0, 1, 2, 5, 10, 20, 50, 100},
+	}, []string{"search"})
+
+	vectorSearchDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "vector_search_duration_seconds",
+		Help:      "pgvector query latency by search type.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"search"})
+
+	auditLogEntries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rag_audit_log_entries_total",
+		Help:      "Search requests logged to rag_audit_log by outcome (written, dropped, failed).",
+	}, []string{"outcome"})
+
+	responseCacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "response_cache_lookups_total",
+		Help:      "Response cache lookups by endpoint and result (hit, miss, bypass).",
+	}, []string{"endpoint", "result"})
+
+	rateLimited = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limited_requests_total",
+		Help:      "Requests refused by the rate limiter by reason (rate, quota).",
+	}, []string{"reason"})
+)
+
+// Handler returns the Prometheus scrape handler
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// ListenAndServe serves /metrics, and any routes registered by routes, on
+// its own address (used by gRPC-only servers)
+func ListenAndServe(addr string, routes ...func(*http.ServeMux)) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+	for _, register := range routes {
+		register(mux)
+	}
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return srv.ListenAndServe()
+}
+
+// ObserveEmbedding records the duration of an embedding provider call
+func ObserveEmbedding(model string, start time.Time, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+	embeddingDuration.WithLabelValues(model, outcome).Observe(time.Since(start).Seconds())
+}
+
+// Embedding cache lookup results
+const (
+	EmbeddingCacheMemoryHit   = "memory_hit"
+	EmbeddingCachePostgresHit = "postgres_hit"
+	EmbeddingCacheMiss        = "miss"
+)
+
+// ObserveEmbeddingCache counts a query embedding cache lookup
+func ObserveEmbeddingCache(model, result string) {
+	embeddingCacheLookups.WithLabelValues(model, result).Inc()
+}
+
+// Response cache lookup results
+const (
+	ResponseCacheHit    = "hit"
+	ResponseCacheMiss   = "miss"
+	ResponseCacheBypass = "bypass"
+)
+
+// ObserveResponseCache counts a response cache lookup
+func ObserveResponseCache(endpoint, result string) {
+	responseCacheLookups.WithLabelValues(endpoint, result).Inc()
+}
+
+// ObserveVectorSearch records the latency and result count of a vector search
+func ObserveVectorSearch(search string, start time.Time, results int) {
+	vectorSearchDuration.WithLabelValues(search).Observe(time.Since(start).Seconds())
+	vectorSearchResults.WithLabelValues(search).Observe(float64(results))
+}
+
+// Audit log entry outcomes
+const (
+	AuditLogWritten = "written"
+	AuditLogDropped = "dropped"
+	AuditLogFailed  = "failed"
+)
+
+// ObserveAuditLog counts logged searches written to, dropped before or
+// failing to reach rag_audit_log
+func ObserveAuditLog(outcome string, n int) {
+	auditLogEntries.WithLabelValues(outcome).Add(float64(n))
+}
+
+// Reasons the rate limiter refuses a request
+const (
+	RateLimitRate  = "rate"
+	RateLimitQuota = "quota"
+)
+
+// ObserveRateLimited counts a request refused by the rate limiter
+func ObserveRateLimited(reason string) {
+	rateLimited.WithLabelValues(reason).Inc()
+}
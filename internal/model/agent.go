@@ -0,0 +1,63 @@
+package model
+
+import "time"
+
+// Agent quota tiers
+const (
+	QuotaTierBasic     = "basic"
+	QuotaTierStandard  = "standard"
+	QuotaTierPremium   = "premium"
+	QuotaTierUnlimited = "unlimited"
+)
+
+// Agent statuses
+const (
+	AgentStatusActive    = "active"
+	AgentStatusSuspended = "suspended"
+)
+
+// Agent is a registered API caller (person, AI assistant or automated job).
+// Requests act as an agent through the X-Agent-Name header or, when the
+// agent is bound to a subject, by authenticating as that subject.
+type Agent struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	Type        AgentType `json:"agent_type"`
+	Owner       string    `json:"owner"`
+	Description string    `json:"description,omitempty"`
+	// Subject is the authenticated principal allowed to act as the agent;
+	// empty lets any authenticated principal use the name
+	Subject string `json:"subject,omitempty"`
+	// AllowedEndpoints are the path prefixes the agent may call; empty allows all
+	AllowedEndpoints []string   `json:"allowed_endpoints"`
+	ModelProvider    string     `json:"model_provider,omitempty"`
+	ModelName        string     `json:"model_name,omitempty"`
+	ModelVersion     string     `json:"model_version,omitempty"`
+	QuotaTier        string     `json:"quota_tier"`
+	Status           string     `json:"status"`
+	RegisteredBy     string     `json:"registered_by,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+	LastSeenAt       *time.Time `json:"last_seen_at,omitempty"`
+}
+
+// AgentAnalytics is the feedback, audit and search activity of an agent name
+// (registered or not) from v_agent_analytics
+type AgentAnalytics struct {
+	AgentName        string     `db:"agent_name" json:"agent_name"`
+	Registered       bool       `db:"registered" json:"registered"`
+	AgentType        *string    `db:"agent_type" json:"agent_type,omitempty"`
+	Owner            *string    `db:"owner" json:"owner,omitempty"`
+	QuotaTier        *string    `db:"quota_tier" json:"quota_tier,omitempty"`
+	Status           *string    `db:"status" json:"status,omitempty"`
+	ModelName        *string    `db:"model_name" json:"model_name,omitempty"`
+	FeedbackCount    int        `db:"feedback_count" json:"feedback_count"`
+	PositiveFeedback int        `db:"positive_feedback" json:"positive_feedback"`
+	NegativeFeedback int        `db:"negative_feedback" json:"negative_feedback"`
+	AvgConfidence    *float64   `db:"avg_confidence" json:"avg_confidence,omitempty"`
+	QueryCount       int        `db:"query_count" json:"query_count"`
+	ErrorCount       int        `db:"error_count" json:"error_count"`
+	AvgLatencyMs     *float64   `db:"avg_latency_ms" json:"avg_latency_ms,omitempty"`
+	SearchHits       int        `db:"search_hits" json:"search_hits"`
+	LastActivityAt   *time.Time `db:"last_activity_at" json:"last_activity_at,omitempty"`
+}
@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+// AmendmentProposalStatus is the review state of a proposed case amendment
+type AmendmentProposalStatus string
+
+const (
+	AmendmentPending  AmendmentProposalStatus = "pending"
+	AmendmentAccepted AmendmentProposalStatus = "accepted"
+	AmendmentRejected AmendmentProposalStatus = "rejected"
+)
+
+// AmendmentProposal is edited case DSL awaiting review
+type AmendmentProposal struct {
+	ID       int    `db:"id" json:"id"`
+	CaseName string `db:"case_name" json:"case_name"`
+	// BaseVersion is the case version the DSL was edited from (0 = new case)
+	BaseVersion     int                     `db:"base_version" json:"base_version"`
+	DSL             string                  `db:"dsl" json:"dsl"`
+	Diff            string                  `db:"diff" json:"diff"`
+	Note            string                  `db:"note" json:"note,omitempty"`
+	Status          AmendmentProposalStatus `db:"status" json:"status"`
+	ProposedBy      string                  `db:"proposed_by" json:"proposed_by,omitempty"`
+	ReviewedBy      string                  `db:"reviewed_by" json:"reviewed_by,omitempty"`
+	ReviewComment   string                  `db:"review_comment" json:"review_comment,omitempty"`
+	AcceptedVersion *int                    `db:"accepted_version" json:"accepted_version,omitempty"`
+	CreatedAt       time.Time               `db:"created_at" json:"created_at"`
+	ReviewedAt      *time.Time              `db:"reviewed_at" json:"reviewed_at,omitempty"`
+}
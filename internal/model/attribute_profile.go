@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+// AttributeDerivationLink is one edge of the derivation graph around an attribute
+type AttributeDerivationLink struct {
+	DerivedAttributeCode string `db:"derived_attribute_code" json:"derived_attribute_code"`
+	SourceAttributeCode  string `db:"source_attribute_code" json:"source_attribute_code"`
+	RuleExpression       string `db:"rule_expression" json:"rule_expression"`
+}
+
+// Cluster sources: curated clusters (kyc_attribute_clusters) and
+// embedding-computed clusters (rag_clusters)
+const (
+	ClusterSourceCurated  = "curated"
+	ClusterSourceSemantic = "semantic"
+)
+
+// ClusterMembership names a cluster that contains an attribute
+type ClusterMembership struct {
+	Source      string `db:"source" json:"source"`
+	ClusterCode string `db:"cluster_code" json:"cluster_code"`
+	ClusterName string `db:"cluster_name" json:"cluster_name"`
+	Description string `db:"description" json:"description,omitempty"`
+	MemberCount int    `db:"member_count" json:"member_count"`
+}
+
+// AttributeFeedbackStats totals the feedback given on an attribute
+type AttributeFeedbackStats struct {
+	Total         int        `db:"total" json:"total"`
+	Positive      int        `db:"positive" json:"positive"`
+	Negative      int        `db:"negative" json:"negative"`
+	Neutral       int        `db:"neutral" json:"neutral"`
+	AvgConfidence float64    `db:"avg_confidence" json:"avg_confidence"`
+	LastFeedback  *time.Time `db:"last_feedback" json:"last_feedback,omitempty"`
+}
@@ -0,0 +1,48 @@
+package model
+
+import "time"
+
+// CaseAssignment is the current owner of a case in the work queue
+type CaseAssignment struct {
+	CaseName   string     `db:"case_name" json:"case_name"`
+	Assignee   string     `db:"assignee" json:"assignee,omitempty"`
+	Team       string     `db:"team" json:"team,omitempty"`
+	RiskRating string     `db:"risk_rating" json:"risk_rating,omitempty"`
+	SLADueAt   *time.Time `db:"sla_due_at" json:"sla_due_at,omitempty"`
+	AssignedBy string     `db:"assigned_by" json:"assigned_by"`
+	AssignedAt time.Time  `db:"assigned_at" json:"assigned_at"`
+	// Status is the lifecycle state of the case
+	Status LifecycleState `db:"status" json:"status"`
+	// Overdue is set when the SLA due date has passed
+	Overdue bool `db:"overdue" json:"overdue"`
+}
+
+// CaseAssignmentChange is one assignment or reassignment of a case
+type CaseAssignmentChange struct {
+	ID       int    `db:"id" json:"id"`
+	CaseName string `db:"case_name" json:"case_name"`
+	// FromAssignee and FromTeam are empty for the first assignment
+	FromAssignee string     `db:"from_assignee" json:"from_assignee,omitempty"`
+	FromTeam     string     `db:"from_team" json:"from_team,omitempty"`
+	ToAssignee   string     `db:"to_assignee" json:"to_assignee,omitempty"`
+	ToTeam       string     `db:"to_team" json:"to_team,omitempty"`
+	RiskRating   string     `db:"risk_rating" json:"risk_rating,omitempty"`
+	SLADueAt     *time.Time `db:"sla_due_at" json:"sla_due_at,omitempty"`
+	Actor        string     `db:"actor" json:"actor"`
+	Reason       string     `db:"reason" json:"reason,omitempty"`
+	CreatedAt    time.Time  `db:"created_at" json:"created_at"`
+}
+
+// CaseQueueFilter selects cases from the work queue. Empty fields match
+// every case.
+type CaseQueueFilter struct {
+	Assignee   string
+	Team       string
+	Status     LifecycleState
+	RiskRating string
+	// DueBefore keeps cases whose SLA falls due before it
+	DueBefore *time.Time
+	// OverdueOnly keeps cases past their SLA due date
+	OverdueOnly bool
+	Limit       int
+}
@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// CaseAttributeValue is a public attribute value captured for a case
+// version (kyc_case_attribute_values). Value is the canonical text of the
+// value, ValueType the dictionary data type it was validated against.
+// Repositories return values decrypted; KeyID names the key the stored
+// value is encrypted under (internal/pii), empty for plaintext.
+type CaseAttributeValue struct {
+	ID             int64     `db:"id" json:"id"`
+	CaseName       string    `db:"case_name" json:"case_name"`
+	CaseVersion    int       `db:"case_version" json:"case_version"`
+	AttributeCode  string    `db:"attribute_code" json:"attribute_code"`
+	Value          string    `db:"value" json:"value"`
+	KeyID          string    `db:"key_id" json:"key_id,omitempty"`
+	ValueType      string    `db:"value_type" json:"value_type"`
+	SourceDocument string    `db:"source_document" json:"source_document,omitempty"`
+	EvidenceID     *int64    `db:"evidence_id" json:"evidence_id,omitempty"`
+	CapturedBy     string    `db:"captured_by" json:"captured_by"`
+	CapturedAt     time.Time `db:"captured_at" json:"captured_at"`
+	UpdatedAt      time.Time `db:"updated_at" json:"updated_at"`
+}
@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// DictionaryEntry is an attribute value in a case's data dictionary. Derived
+// entries are materialized from a lineage evaluation and carry its ID.
+// KeyID names the key an encrypted captured value is stored under.
+type DictionaryEntry struct {
+	CaseName       string    `db:"case_name" json:"case_name"`
+	AttributeCode  string    `db:"attribute_code" json:"attribute_code"`
+	Value          string    `db:"value" json:"value"`
+	KeyID          string    `db:"key_id" json:"key_id,omitempty"`
+	ValueType      string    `db:"value_type" json:"value_type"`
+	Derived        bool      `db:"derived" json:"derived"`
+	EvaluationID   *int      `db:"evaluation_id" json:"evaluation_id,omitempty"`
+	Rule           string    `db:"rule" json:"rule,omitempty"`
+	RegulationCode string    `db:"regulation_code" json:"regulation_code,omitempty"`
+	MaterializedAt time.Time `db:"materialized_at" json:"materialized_at"`
+}
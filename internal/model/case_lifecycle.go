@@ -0,0 +1,49 @@
+package model
+
+import "time"
+
+// LifecycleState is a state of the case lifecycle (kyc_cases.status)
+type LifecycleState string
+
+const (
+	CaseDraft     LifecycleState = "draft"
+	CaseValidated LifecycleState = "validated"
+	CaseInReview  LifecycleState = "in-review"
+	CaseApproved  LifecycleState = "approved"
+	CaseDeclined  LifecycleState = "declined"
+	CaseArchived  LifecycleState = "archived"
+)
+
+// CaseTransition is one move of a case between lifecycle states
+type CaseTransition struct {
+	ID       int    `db:"id" json:"id"`
+	CaseName string `db:"case_name" json:"case_name"`
+	// From is empty for the transition that created the case
+	From      LifecycleState `db:"from_status" json:"from,omitempty"`
+	To        LifecycleState `db:"to_status" json:"to"`
+	Actor     string         `db:"actor" json:"actor"`
+	Reason    string         `db:"reason" json:"reason,omitempty"`
+	CreatedAt time.Time      `db:"created_at" json:"created_at"`
+}
+
+// CaseTimeline is a case's current state and the transitions that led to it
+type CaseTimeline struct {
+	CaseName    string           `json:"case_name"`
+	Status      LifecycleState   `json:"status"`
+	Allowed     []LifecycleState `json:"allowed_transitions"`
+	Transitions []CaseTransition `json:"transitions"`
+}
+
+// CaseLock is an advisory lock held on a case while it is amended
+type CaseLock struct {
+	CaseName    string    `db:"case_name" json:"case_name"`
+	Holder      string    `db:"holder" json:"holder"`
+	Note        string    `db:"note" json:"note,omitempty"`
+	AcquiredAt  time.Time `db:"acquired_at" json:"acquired_at"`
+	HeartbeatAt time.Time `db:"heartbeat_at" json:"heartbeat_at"`
+	ExpiresAt   time.Time `db:"expires_at" json:"expires_at"`
+	// TakenOverFrom is the holder of the expired lock this one replaced
+	TakenOverFrom string `db:"taken_over_from" json:"taken_over_from,omitempty"`
+	// Stale is set when the lock has expired and can be taken over
+	Stale bool `db:"stale" json:"stale"`
+}
@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+// CountryRiskList is a jurisdiction risk list (FATF black or grey list, EU
+// high-risk third countries...) read by rules with in_sanctioned_list
+type CountryRiskList struct {
+	Code        string `db:"code" json:"code"`
+	Name        string `db:"name" json:"name"`
+	Authority   string `db:"authority" json:"authority,omitempty"`
+	Description string `db:"description" json:"description,omitempty"`
+	// Countries are the listings: those in effect, or every listing
+	// including past ones when history is requested
+	Countries []CountryRiskEntry `db:"-" json:"countries"`
+	UpdatedBy string             `db:"updated_by" json:"updated_by,omitempty"`
+	UpdatedAt time.Time          `db:"updated_at" json:"updated_at"`
+}
+
+// CountryRiskEntry is a period during which a country is on a jurisdiction
+// risk list (kyc_country_risk)
+type CountryRiskEntry struct {
+	ID            int64      `db:"id" json:"id"`
+	ListCode      string     `db:"list_code" json:"list_code"`
+	CountryCode   string     `db:"country_code" json:"country_code"`
+	EffectiveFrom time.Time  `db:"effective_from" json:"effective_from"`
+	EffectiveTo   *time.Time `db:"effective_to" json:"effective_to,omitempty"`
+	Note          string     `db:"note" json:"note,omitempty"`
+	UpdatedBy     string     `db:"updated_by" json:"updated_by,omitempty"`
+	UpdatedAt     time.Time  `db:"updated_at" json:"updated_at"`
+}
@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// EmbeddingBackfill is the checkpoint of an embedding backfill job
+type EmbeddingBackfill struct {
+	Job       string    `db:"job" json:"job"`
+	Kind      string    `db:"kind" json:"kind"`
+	Model     string    `db:"model" json:"model"`
+	LastCode  string    `db:"last_code" json:"last_code"`
+	Processed int       `db:"processed" json:"processed"`
+	Failed    int       `db:"failed" json:"failed"`
+	Tokens    int64     `db:"tokens" json:"tokens"`
+	SpendUSD  float64   `db:"spend_usd" json:"spend_usd"`
+	Status    string    `db:"status" json:"status"`
+	StartedAt time.Time `db:"started_at" json:"started_at"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// Embedding backfill job statuses
+const (
+	BackfillRunning   = "running"
+	BackfillPaused    = "paused"
+	BackfillCompleted = "completed"
+)
+
+// EmbeddingTarget is an ontology entry that needs a new embedding
+type EmbeddingTarget struct {
+	Code string
+	Text string
+	// StoredHash is the content hash of the text the current embedding was
+	// generated from; empty when there is no embedding or it predates versioning
+	StoredHash string
+}
@@ -0,0 +1,53 @@
+package model
+
+import "time"
+
+// EmbeddingSpace is an embedding model and size with its own set of
+// ontology embeddings. The primary space is stored in the ontology tables;
+// the others in ontology_embeddings.
+type EmbeddingSpace struct {
+	Name        string     `db:"name" json:"name"`
+	Model       string     `db:"model" json:"model"`
+	Dimensions  int        `db:"dimensions" json:"dimensions"`
+	IsPrimary   bool       `db:"is_primary" json:"is_primary"`
+	Description string     `db:"description" json:"description,omitempty"`
+	CreatedAt   time.Time  `db:"created_at" json:"created_at"`
+	PromotedAt  *time.Time `db:"promoted_at" json:"promoted_at,omitempty"`
+	// Embedded counts the space's embeddings per ontology kind
+	Embedded map[string]int `db:"-" json:"embedded,omitempty"`
+}
+
+// EmbeddingPromotion is the outcome of making a space the primary one
+type EmbeddingPromotion struct {
+	From       string `json:"from"`
+	To         string `json:"to"`
+	Model      string `json:"model"`
+	Dimensions int    `json:"dimensions"`
+	// Resized is true when the embedding columns changed dimension
+	Resized bool `json:"resized"`
+	// Embeddings is the number of ontology embeddings moved into the tables
+	Embeddings int `json:"embeddings"`
+	// SectionsCleared are document section embeddings from the previous
+	// model, which must be re-generated by re-ingesting their documents
+	SectionsCleared int `json:"sections_cleared"`
+	// Unindexed is true when the dimension is too large for an ivfflat index
+	Unindexed bool `json:"unindexed"`
+}
+
+// EmbeddingTierStats counts attribute embeddings per tier
+type EmbeddingTierStats struct {
+	Hot  int `db:"hot" json:"hot"`
+	Cold int `db:"cold" json:"cold"`
+	// Missing counts hot attributes without an embedding
+	Missing       int        `db:"missing" json:"missing"`
+	OldestArchive *time.Time `db:"oldest_archive" json:"oldest_archive,omitempty"`
+}
+
+// ColdEmbedding is an attribute whose embedding is archived to the cold tier
+type ColdEmbedding struct {
+	AttributeCode string     `db:"attribute_code" json:"attribute_code"`
+	LastUsedAt    *time.Time `db:"last_used_at" json:"last_used_at,omitempty"`
+	ArchivedAt    time.Time  `db:"archived_at" json:"archived_at"`
+	// HitsSinceArchive counts search hits since the embedding was archived
+	HitsSinceArchive int `db:"hits_since_archive" json:"hits_since_archive"`
+}
@@ -0,0 +1,131 @@
+package model
+
+import "time"
+
+// Evidence access actions recorded in kyc_evidence_access
+const (
+	EvidenceUpload   = "upload"
+	EvidenceView     = "view"
+	EvidenceDownload = "download"
+)
+
+// Text extraction statuses of evidence (kyc_evidence.text_status)
+const (
+	EvidenceTextPending     = "pending"
+	EvidenceTextExtracting  = "extracting"
+	EvidenceTextExtracted   = "extracted"
+	EvidenceTextUnsupported = "unsupported"
+	EvidenceTextFailed      = "failed"
+	EvidenceTextErased      = "erased"
+)
+
+// Expiry statuses of evidence flagged by the expiry checker
+// (kyc_evidence_expiry_flags)
+const (
+	EvidenceExpiring = "EXPIRING"
+	EvidenceExpired  = "EXPIRED"
+)
+
+// Evidence is a collected document file attached to a case (kyc_evidence).
+// The content lives in the evidence store under StorageKey, until the
+// evidence is erased for a data subject (ErasedAt).
+type Evidence struct {
+	ID            int64      `db:"id" json:"id"`
+	CaseName      string     `db:"case_name" json:"case_name"`
+	DocumentCode  string     `db:"document_code" json:"document_code,omitempty"`
+	AttributeCode string     `db:"attribute_code" json:"attribute_code,omitempty"`
+	FileName      string     `db:"file_name" json:"file_name"`
+	ContentType   string     `db:"content_type" json:"content_type"`
+	SizeBytes     int64      `db:"size_bytes" json:"size_bytes"`
+	SHA256        string     `db:"sha256" json:"sha256"`
+	StorageDriver string     `db:"storage_driver" json:"storage_driver"`
+	StorageKey    string     `db:"storage_key" json:"storage_key"`
+	IssuedAt      *time.Time `db:"issued_at" json:"issued_at,omitempty"`
+	ExpiresAt     *time.Time `db:"expires_at" json:"expires_at,omitempty"`
+	ValidityDays  int        `db:"validity_days" json:"validity_days,omitempty"`
+	Description   string     `db:"description" json:"description,omitempty"`
+	TextStatus    string     `db:"text_status" json:"text_status"`
+	TextExtractor string     `db:"text_extractor" json:"text_extractor,omitempty"`
+	TextError     string     `db:"text_error" json:"text_error,omitempty"`
+	Actor         string     `db:"actor" json:"actor,omitempty"`
+	ActorSource   string     `db:"actor_source" json:"actor_source,omitempty"`
+	ClientIP      string     `db:"client_ip" json:"client_ip,omitempty"`
+	UploadedAt    time.Time  `db:"uploaded_at" json:"uploaded_at"`
+	ErasedAt      *time.Time `db:"erased_at" json:"erased_at,omitempty"`
+}
+
+// EvidenceAccess is an upload, view or download of evidence
+// (kyc_evidence_access)
+type EvidenceAccess struct {
+	ID          int64     `db:"id" json:"id"`
+	EvidenceID  int64     `db:"evidence_id" json:"evidence_id"`
+	Action      string    `db:"action" json:"action"`
+	Actor       string    `db:"actor" json:"actor,omitempty"`
+	ActorSource string    `db:"actor_source" json:"actor_source,omitempty"`
+	ClientIP    string    `db:"client_ip" json:"client_ip,omitempty"`
+	AccessedAt  time.Time `db:"accessed_at" json:"accessed_at"`
+}
+
+// ExpiringEvidence is evidence expiring within the warning period, or
+// expired, that has not been refreshed by a later upload of the same
+// document
+type ExpiringEvidence struct {
+	Evidence
+	Status string `db:"status" json:"status"`
+	// DaysLeft is the number of days until the expiry date, negative once
+	// it has passed
+	DaysLeft int `db:"days_left" json:"days_left"`
+	// FlaggedAt is when the expiry checker first flagged the evidence
+	FlaggedAt *time.Time `db:"flagged_at" json:"flagged_at,omitempty"`
+}
+
+// EvidencePage is the text extracted from one page of evidence
+// (kyc_evidence_pages)
+type EvidencePage struct {
+	EvidenceID int64  `db:"evidence_id" json:"evidence_id"`
+	PageNumber int    `db:"page_number" json:"page_number"`
+	Text       string `db:"text" json:"text"`
+}
+
+// Statuses of attribute values proposed from evidence
+// (kyc_attribute_candidates.status)
+const (
+	CandidatePending    = "pending"
+	CandidateConfirmed  = "confirmed"
+	CandidateRejected   = "rejected"
+	CandidateSuperseded = "superseded"
+)
+
+// CandidateCitation is an excerpt of evidence quoted to the model when a
+// value was proposed
+type CandidateCitation struct {
+	Ref       int    `json:"ref"`
+	SectionID int64  `json:"section_id,omitempty"`
+	Page      int    `json:"page,omitempty"`
+	Excerpt   string `json:"excerpt"`
+}
+
+// AttributeCandidate is an attribute value proposed from the text of
+// evidence, awaiting or after analyst confirmation (kyc_attribute_candidates)
+type AttributeCandidate struct {
+	ID            int64   `db:"id" json:"id"`
+	CaseName      string  `db:"case_name" json:"case_name"`
+	EvidenceID    int64   `db:"evidence_id" json:"evidence_id"`
+	AttributeCode string  `db:"attribute_code" json:"attribute_code"`
+	Value         string  `db:"value" json:"value"`
+	Confidence    float64 `db:"confidence" json:"confidence"`
+	// Citations are the excerpts the model cited for the value
+	Citations     []CandidateCitation `db:"-" json:"citations"`
+	Quote         string              `db:"quote" json:"quote,omitempty"`
+	QuoteVerified bool                `db:"quote_verified" json:"quote_verified"`
+	Model         string              `db:"model" json:"model"`
+	Status        string              `db:"status" json:"status"`
+	ProposedBy    string              `db:"proposed_by" json:"proposed_by,omitempty"`
+	ProposedAt    time.Time           `db:"proposed_at" json:"proposed_at"`
+	// ConfirmedValue is the value the analyst confirmed, which may correct
+	// Value
+	ConfirmedValue string     `db:"confirmed_value" json:"confirmed_value,omitempty"`
+	DecidedBy      string     `db:"decided_by" json:"decided_by,omitempty"`
+	DecisionNote   string     `db:"decision_note" json:"decision_note,omitempty"`
+	DecidedAt      *time.Time `db:"decided_at" json:"decided_at,omitempty"`
+}
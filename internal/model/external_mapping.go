@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// External standards attribute codes can be mapped to
+const (
+	StandardISO20022 = "iso20022"
+	StandardFIBO     = "fibo"
+)
+
+// ExternalMapping links an attribute code to an element or concept of an
+// external standard. MatchType is a SKOS mapping relation: exact, close,
+// broad (the external concept is broader), narrow or related.
+type ExternalMapping struct {
+	AttributeCode string    `db:"attribute_code" json:"attribute_code"`
+	Standard      string    `db:"standard" json:"standard"`
+	ExternalID    string    `db:"external_id" json:"external_id"`
+	ExternalName  string    `db:"external_name" json:"external_name,omitempty"`
+	URI           string    `db:"uri" json:"uri,omitempty"`
+	MatchType     string    `db:"match_type" json:"match_type"`
+	Note          string    `db:"note" json:"note,omitempty"`
+	UpdatedBy     string    `db:"updated_by" json:"updated_by,omitempty"`
+	UpdatedAt     time.Time `db:"updated_at" json:"updated_at"`
+}
@@ -0,0 +1,65 @@
+package model
+
+import "time"
+
+// Feedback actions raised by confidence-gated feedback
+const (
+	FeedbackActionDemote = "demote"
+	FeedbackActionFlag   = "flag"
+)
+
+// Feedback action statuses
+const (
+	FeedbackActionOpen     = "open"
+	FeedbackActionResolved = "resolved"
+)
+
+// FeedbackPolicy decides which feedback of a tenant triggers actions.
+// Negative feedback with at least MinConfidence from a trusted agent demotes
+// the result for its query and, after FlagAfter such feedbacks on an
+// attribute, flags the attribute's metadata for review; everything else
+// only accumulates.
+type FeedbackPolicy struct {
+	Tenant        string  `json:"tenant"`
+	MinConfidence float64 `json:"min_confidence"`
+	// TrustedAgents are the agents whose feedback may act; empty trusts every
+	// active registered agent
+	TrustedAgents []string  `json:"trusted_agents"`
+	Demote        bool      `json:"demote"`
+	DemotePenalty float64   `json:"demote_penalty"`
+	MaxPenalty    float64   `json:"max_penalty"`
+	Flag          bool      `json:"flag"`
+	FlagAfter     int       `json:"flag_after"`
+	UpdatedBy     string    `json:"updated_by,omitempty"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	// Inherited is set when the tenant has no policy of its own
+	Inherited bool `json:"inherited,omitempty"`
+}
+
+// FeedbackAction is a demotion or review flag raised by a feedback
+type FeedbackAction struct {
+	ID             int        `json:"id"`
+	FeedbackID     int        `json:"feedback_id"`
+	Tenant         string     `json:"tenant"`
+	Action         string     `json:"action"`
+	QueryKey       string     `json:"query_key"`
+	AttributeCode  *string    `json:"attribute_code,omitempty"`
+	DocumentCode   *string    `json:"document_code,omitempty"`
+	RegulationCode *string    `json:"regulation_code,omitempty"`
+	AgentName      string     `json:"agent_name,omitempty"`
+	Confidence     float64    `json:"confidence"`
+	Penalty        *float64   `json:"penalty,omitempty"`
+	Status         string     `json:"status"`
+	ResolvedBy     string     `json:"resolved_by,omitempty"`
+	ResolvedAt     *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// FeedbackOutcome is what the policy did with a feedback
+type FeedbackOutcome struct {
+	Tenant string `json:"tenant"`
+	// Gated is set when the feedback passed the confidence and trust gates
+	Gated   bool             `json:"gated"`
+	Reason  string           `json:"reason"`
+	Actions []FeedbackAction `json:"actions"`
+}
@@ -0,0 +1,39 @@
+package model
+
+// LineageGraph is the derivation DAG around an attribute: the attributes it
+// is derived from, transitively, and those derived from it
+type LineageGraph struct {
+	Attribute string        `json:"attribute"`
+	Nodes     []LineageNode `json:"nodes"`
+	Edges     []LineageEdge `json:"edges"`
+	// Truncated is set when the walk stopped at the depth limit
+	Truncated bool `json:"truncated"`
+}
+
+// Roles of a node in a lineage graph
+const (
+	LineageRoot       = "root"
+	LineageUpstream   = "upstream"
+	LineageDownstream = "downstream"
+)
+
+// LineageNode is an attribute of a lineage graph
+type LineageNode struct {
+	Code  string `db:"code" json:"code"`
+	Name  string `db:"name" json:"name"`
+	Class string `db:"attribute_class" json:"attribute_class"`
+	Role  string `json:"role"`
+	// Depth is the number of derivation steps from the root
+	Depth int `json:"depth"`
+}
+
+// LineageEdge is a derivation rule reading Source to produce Target
+type LineageEdge struct {
+	ID             int    `db:"id" json:"id"`
+	Source         string `db:"source_attribute_code" json:"source"`
+	Target         string `db:"derived_attribute_code" json:"target"`
+	Rule           string `db:"rule_expression" json:"rule"`
+	RuleType       string `db:"rule_type" json:"rule_type,omitempty"`
+	Jurisdiction   string `db:"jurisdiction" json:"jurisdiction,omitempty"`
+	RegulationCode string `db:"regulation_code" json:"regulation_code,omitempty"`
+}
@@ -0,0 +1,38 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// EvaluationRun is one evaluation of a case's derived attributes from one
+// set of inputs (kyc_lineage_runs)
+type EvaluationRun struct {
+	ID          int64  `db:"id" json:"id"`
+	CaseName    string `db:"case_name" json:"case_name"`
+	CaseVersion *int   `db:"case_version" json:"case_version,omitempty"`
+	// InputsHash is the sha256 of the canonical JSON of the inputs; empty for legacy runs
+	InputsHash  string             `db:"inputs_hash" json:"inputs_hash,omitempty"`
+	Source      string             `db:"source" json:"source"`
+	Actor       string             `db:"actor" json:"actor,omitempty"`
+	Evaluations int                `db:"evaluations" json:"evaluations"`
+	Failed      int                `db:"failed" json:"failed"`
+	StartedAt   time.Time          `db:"started_at" json:"started_at"`
+	Results     []DerivationResult `json:"results"`
+}
+
+// DerivationResult is the result of one rule of a run (kyc_lineage_evaluations)
+type DerivationResult struct {
+	EvaluationID   int64           `db:"id" json:"evaluation_id"`
+	RunID          int64           `db:"run_id" json:"run_id"`
+	DerivedCode    string          `db:"derived_code" json:"derived_code"`
+	Value          string          `db:"value" json:"value"`
+	ValueType      string          `db:"value_type" json:"value_type"`
+	Success        bool            `db:"success" json:"success"`
+	Error          string          `db:"error" json:"error,omitempty"`
+	Rule           string          `db:"rule" json:"rule"`
+	Inputs         json.RawMessage `db:"inputs" json:"inputs,omitempty"`
+	Jurisdiction   string          `db:"jurisdiction" json:"jurisdiction,omitempty"`
+	RegulationCode string          `db:"regulation_code" json:"regulation_code,omitempty"`
+	EvaluatedAt    time.Time       `db:"evaluated_at" json:"evaluated_at"`
+}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// ManagedList is a named list (sanctioned or high-risk jurisdictions...)
+// referenced from derivation rules with in_list
+type ManagedList struct {
+	Name          string     `json:"name"`
+	Description   string     `json:"description,omitempty"`
+	Members       []string   `json:"members"`
+	Source        string     `json:"source,omitempty"`
+	EffectiveFrom time.Time  `json:"effective_from"`
+	EffectiveTo   *time.Time `json:"effective_to,omitempty"`
+	Version       int        `json:"version"`
+	UpdatedBy     string     `json:"updated_by,omitempty"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
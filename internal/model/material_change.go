@@ -0,0 +1,21 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// MaterialChangeEvent links a material change of the entity graph to the
+// review amendment it opened on an affected case
+type MaterialChangeEvent struct {
+	ID          int             `db:"id" json:"id"`
+	ChangeID    int64           `db:"change_id" json:"change_id"`
+	Kind        string          `db:"kind" json:"kind"`
+	EntityID    string          `db:"entity_id" json:"entity_id"`
+	EntityName  string          `db:"entity_name" json:"entity_name"`
+	CaseID      string          `db:"case_id" json:"case_id"`
+	Summary     string          `db:"summary" json:"summary"`
+	Evidence    json.RawMessage `db:"evidence" json:"evidence"`
+	AmendmentID *int            `db:"amendment_id" json:"amendment_id,omitempty"`
+	CreatedAt   time.Time       `db:"created_at" json:"created_at"`
+}
@@ -0,0 +1,143 @@
+package model
+
+import (
+	"reflect"
+	"time"
+)
+
+// MetadataProposalSource identifies where a proposed metadata change came from
+type MetadataProposalSource string
+
+const (
+	ProposalSourceLLM    MetadataProposalSource = "llm_enrichment"
+	ProposalSourceImport MetadataProposalSource = "import"
+	ProposalSourceManual MetadataProposalSource = "manual"
+)
+
+// MetadataProposalStatus is the review state of a proposal
+type MetadataProposalStatus string
+
+const (
+	ProposalPending          MetadataProposalStatus = "pending"
+	ProposalApplied          MetadataProposalStatus = "applied"
+	ProposalPartiallyApplied MetadataProposalStatus = "partially_applied"
+	ProposalRejected         MetadataProposalStatus = "rejected"
+)
+
+// Reviewable metadata fields
+const (
+	FieldSynonyms            = "synonyms"
+	FieldDataType            = "data_type"
+	FieldDomainValues        = "domain_values"
+	FieldRiskLevel           = "risk_level"
+	FieldExampleValues       = "example_values"
+	FieldRegulatoryCitations = "regulatory_citations"
+	FieldBusinessContext     = "business_context"
+)
+
+// MetadataProposal is a set of proposed field values for one attribute
+type MetadataProposal struct {
+	ID            int                    `db:"id" json:"id"`
+	BatchID       string                 `db:"batch_id" json:"batch_id"`
+	AttributeCode string                 `db:"attribute_code" json:"attribute_code"`
+	Source        MetadataProposalSource `db:"source" json:"source"`
+	Proposed      MetadataFields         `db:"-" json:"proposed"`
+	Status        MetadataProposalStatus `db:"status" json:"status"`
+	SubmittedBy   string                 `db:"submitted_by" json:"submitted_by,omitempty"`
+	CreatedAt     time.Time              `db:"created_at" json:"created_at"`
+}
+
+// MetadataFields holds proposed values; nil fields are not part of the proposal
+type MetadataFields struct {
+	Synonyms            []string `json:"synonyms,omitempty"`
+	DataType            *string  `json:"data_type,omitempty"`
+	DomainValues        []string `json:"domain_values,omitempty"`
+	RiskLevel           *string  `json:"risk_level,omitempty"`
+	ExampleValues       []string `json:"example_values,omitempty"`
+	RegulatoryCitations []string `json:"regulatory_citations,omitempty"`
+	BusinessContext     *string  `json:"business_context,omitempty"`
+}
+
+// FieldDiff is a single field-level change awaiting a decision
+type FieldDiff struct {
+	Field    string      `json:"field"`
+	OldValue interface{} `json:"old_value"`
+	NewValue interface{} `json:"new_value"`
+}
+
+// MetadataReviewItem is a proposal presented with its field-level diffs
+type MetadataReviewItem struct {
+	MetadataProposal
+	Diffs []FieldDiff `json:"diffs"`
+}
+
+// FieldDecision is a reviewer's accept/reject for one field
+type FieldDecision struct {
+	Field   string `json:"field"`
+	Accept  bool   `json:"accept"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// MetadataReviewBatch summarises review progress for an enrichment/import batch
+type MetadataReviewBatch struct {
+	BatchID       string    `db:"batch_id" json:"batch_id"`
+	Source        string    `db:"source" json:"source"`
+	ProposalCount int       `db:"proposal_count" json:"proposal_count"`
+	PendingCount  int       `db:"pending_count" json:"pending_count"`
+	AppliedCount  int       `db:"applied_count" json:"applied_count"`
+	RejectedCount int       `db:"rejected_count" json:"rejected_count"`
+	SubmittedAt   time.Time `db:"submitted_at" json:"submitted_at"`
+}
+
+// Diff compares proposed fields with the current metadata and returns only
+// the fields whose value would change
+func (f MetadataFields) Diff(current *AttributeMetadata) []FieldDiff {
+	if current == nil {
+		current = &AttributeMetadata{}
+	}
+
+	var diffs []FieldDiff
+	addList := func(field string, old, proposed []string) {
+		if proposed != nil && !reflect.DeepEqual(old, proposed) {
+			diffs = append(diffs, FieldDiff{Field: field, OldValue: old, NewValue: proposed})
+		}
+	}
+	addString := func(field, old string, proposed *string) {
+		if proposed != nil && old != *proposed {
+			diffs = append(diffs, FieldDiff{Field: field, OldValue: old, NewValue: *proposed})
+		}
+	}
+
+	addList(FieldSynonyms, current.Synonyms, f.Synonyms)
+	addString(FieldDataType, current.DataType, f.DataType)
+	addList(FieldDomainValues, current.DomainValues, f.DomainValues)
+	addString(FieldRiskLevel, current.RiskLevel, f.RiskLevel)
+	addList(FieldExampleValues, current.ExampleValues, f.ExampleValues)
+	addList(FieldRegulatoryCitations, current.RegulatoryCitations, f.RegulatoryCitations)
+	addString(FieldBusinessContext, current.BusinessContext, f.BusinessContext)
+
+	return diffs
+}
+
+// ApplyField copies one proposed field onto the metadata record
+func (f MetadataFields) ApplyField(field string, m *AttributeMetadata) bool {
+	switch {
+	case field == FieldSynonyms && f.Synonyms != nil:
+		m.Synonyms = f.Synonyms
+	case field == FieldDataType && f.DataType != nil:
+		m.DataType = *f.DataType
+	case field == FieldDomainValues && f.DomainValues != nil:
+		m.DomainValues = f.DomainValues
+	case field == FieldRiskLevel && f.RiskLevel != nil:
+		m.RiskLevel = *f.RiskLevel
+	case field == FieldExampleValues && f.ExampleValues != nil:
+		m.ExampleValues = f.ExampleValues
+	case field == FieldRegulatoryCitations && f.RegulatoryCitations != nil:
+		m.RegulatoryCitations = f.RegulatoryCitations
+	case field == FieldBusinessContext && f.BusinessContext != nil:
+		m.BusinessContext = *f.BusinessContext
+	default:
+		return false
+	}
+	return true
+}
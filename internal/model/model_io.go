@@ -0,0 +1,32 @@
+package model
+
+import "time"
+
+// Generation features whose model I/O is logged
+const (
+	ModelFeatureNarrativePolish = "narrative_polish"
+	ModelFeatureClusterLabels   = "cluster_labels"
+	// ModelFeatureAttributeExtraction proposes attribute values from evidence
+	ModelFeatureAttributeExtraction = "attribute_extraction"
+)
+
+// ModelIOEntry is a logged prompt and model response (model_io_log)
+type ModelIOEntry struct {
+	ID               int64     `json:"id"`
+	Feature          string    `json:"feature"`
+	Model            string    `json:"model"`
+	AgentName        string    `json:"agent_name,omitempty"`
+	Subject          string    `json:"subject,omitempty"`
+	RequestID        string    `json:"request_id,omitempty"`
+	SystemPrompt     string    `json:"system_prompt,omitempty"`
+	Prompt           string    `json:"prompt"`
+	Response         *string   `json:"response,omitempty"`
+	Redacted         bool      `json:"redacted"`
+	Truncated        bool      `json:"truncated"`
+	PromptTokens     int       `json:"prompt_tokens,omitempty"`
+	CompletionTokens int       `json:"completion_tokens,omitempty"`
+	LatencyMs        int       `json:"latency_ms"`
+	Error            string    `json:"error,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+	ExpiresAt        time.Time `json:"expires_at"`
+}
@@ -0,0 +1,69 @@
+package model
+
+import "time"
+
+// OntologyOverlay is a tenant's extension of the global ontology: the
+// attributes, documents, attribute-document links and derivation rules
+// resolved before the global ones for the tenant's cases and searches
+type OntologyOverlay struct {
+	Tenant      string              `json:"tenant"`
+	Attributes  []OverlayAttribute  `json:"attributes"`
+	Documents   []OverlayDocument   `json:"documents"`
+	Links       []OverlayLink       `json:"links"`
+	Derivations []OverlayDerivation `json:"derivations"`
+}
+
+// OverlayAttribute is an attribute a tenant adds, or a global attribute
+// it overrides, with the metadata validation and search read
+type OverlayAttribute struct {
+	Code            string   `json:"code"`
+	Name            string   `json:"name"`
+	Domain          string   `json:"domain,omitempty"`
+	Description     string   `json:"description,omitempty"`
+	RiskCategory    string   `json:"risk_category,omitempty"`
+	IsPersonalData  bool     `json:"is_personal_data"`
+	AttributeClass  string   `json:"attribute_class"` // Public or Private
+	DataType        string   `json:"data_type,omitempty"`
+	DomainValues    []string `json:"domain_values,omitempty"`
+	Synonyms        []string `json:"synonyms,omitempty"`
+	BusinessContext string   `json:"business_context,omitempty"`
+	// Overrides is set when the code is also a global attribute
+	Overrides bool       `json:"overrides"`
+	Embedded  bool       `json:"embedded"`
+	UpdatedBy string     `json:"updated_by,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// OverlayDocument is a document a tenant adds or overrides
+type OverlayDocument struct {
+	Code           string     `json:"code"`
+	Name           string     `json:"name"`
+	Domain         string     `json:"domain,omitempty"`
+	Jurisdiction   string     `json:"jurisdiction,omitempty"`
+	RegulationCode string     `json:"regulation_code,omitempty"`
+	Description    string     `json:"description,omitempty"`
+	ValidityYears  int        `json:"validity_years,omitempty"`
+	Overrides      bool       `json:"overrides"`
+	UpdatedBy      string     `json:"updated_by,omitempty"`
+	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
+}
+
+// OverlayLink names a document that evidences an attribute for a tenant
+type OverlayLink struct {
+	AttributeCode string `db:"attribute_code" json:"attribute_code"`
+	DocumentCode  string `db:"document_code" json:"document_code"`
+	SourceTier    string `db:"source_tier" json:"source_tier"` // Primary, Secondary or Tertiary
+	IsMandatory   bool   `db:"is_mandatory" json:"is_mandatory"`
+	Jurisdiction  string `db:"jurisdiction" json:"jurisdiction,omitempty"`
+	Notes         string `db:"notes" json:"notes,omitempty"`
+}
+
+// OverlayDerivation is a tenant's rule deriving a private attribute from a
+// source attribute
+type OverlayDerivation struct {
+	DerivedAttributeCode string `db:"derived_attribute_code" json:"derived_attribute_code"`
+	SourceAttributeCode  string `db:"source_attribute_code" json:"source_attribute_code"`
+	RuleExpression       string `db:"rule_expression" json:"rule_expression"`
+	RuleType             string `db:"rule_type" json:"rule_type"` // Boolean, Numeric, String, Lookup
+	Description          string `db:"description" json:"description,omitempty"`
+}
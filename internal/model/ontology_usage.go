@@ -0,0 +1,62 @@
+package model
+
+import "time"
+
+// OntologyTermType identifies which ontology table a term belongs to
+type OntologyTermType string
+
+const (
+	TermTypeAttribute  OntologyTermType = "attribute"
+	TermTypeDocument   OntologyTermType = "document"
+	TermTypeRegulation OntologyTermType = "regulation"
+)
+
+// SearchHit records one ontology term returned to a caller by a search endpoint
+type SearchHit struct {
+	TermType  OntologyTermType `db:"term_type" json:"term_type"`
+	TermCode  string           `db:"term_code" json:"term_code"`
+	Endpoint  string           `db:"endpoint" json:"endpoint"`
+	AgentName *string          `db:"agent_name" json:"agent_name,omitempty"`
+	QueryText *string          `db:"query_text" json:"query_text,omitempty"`
+	Rank      int              `db:"rank" json:"rank"`
+	CreatedAt time.Time        `db:"created_at" json:"created_at"`
+}
+
+// TermUsage summarises how often an ontology term is referenced by cases and
+// returned by searches
+type TermUsage struct {
+	TermType          OntologyTermType `db:"term_type" json:"term_type"`
+	TermCode          string           `db:"term_code" json:"term_code"`
+	Name              string           `db:"name" json:"name"`
+	CaseCount         int              `db:"case_count" json:"case_count"`
+	SearchHits        int              `db:"search_hits" json:"search_hits"`
+	SearchHits30d     int              `db:"search_hits_30d" json:"search_hits_30d"`
+	DistinctAgents    int              `db:"distinct_agents" json:"distinct_agents"`
+	LastSearched      *time.Time       `db:"last_searched" json:"last_searched,omitempty"`
+	MetadataUpdatedAt *time.Time       `db:"metadata_updated_at" json:"metadata_updated_at,omitempty"`
+	MissingEmbedding  bool             `db:"missing_embedding" json:"missing_embedding"`
+}
+
+// IsDead reports whether no case references the term and no search has returned it
+func (u TermUsage) IsDead() bool {
+	return u.CaseCount == 0 && u.SearchHits == 0
+}
+
+// UsageTypeSummary counts used and dead terms for one term type
+type UsageTypeSummary struct {
+	TermType   OntologyTermType `db:"term_type" json:"term_type"`
+	Total      int              `db:"total" json:"total"`
+	Referenced int              `db:"referenced" json:"referenced"`
+	Searched   int              `db:"searched" json:"searched"`
+	Dead       int              `db:"dead" json:"dead"`
+}
+
+// UsageReport guides dictionary cleanup (dead entries) and embedding refresh
+// priorities (hot attributes with stale or missing embeddings)
+type UsageReport struct {
+	Summary           []UsageTypeSummary `json:"summary"`
+	HotSpots          []TermUsage        `json:"hot_spots"`
+	DeadEntries       []TermUsage        `json:"dead_entries"`
+	RefreshPriorities []TermUsage        `json:"refresh_priorities"`
+	GeneratedAt       time.Time          `json:"generated_at"`
+}
@@ -0,0 +1,59 @@
+package model
+
+import "time"
+
+// Policy pack statuses. A version is drafted, then activated (retiring the
+// active version of the pack) or retired; it is never edited.
+const (
+	PolicyPackDraft   = "draft"
+	PolicyPackActive  = "active"
+	PolicyPackRetired = "retired"
+)
+
+// PolicyPack is a version of the requirements a regulation places on the
+// cases of a jurisdiction (kyc_policy_packs): the policy and obligations
+// policy-discovery adds, the documents document-discovery requires and the
+// attributes and thresholds that apply
+type PolicyPack struct {
+	ID              int64                 `json:"id"`
+	Code            string                `json:"code"`
+	Version         int                   `json:"version"`
+	Name            string                `json:"name"`
+	Jurisdiction    string                `json:"jurisdiction"`
+	RegulationCode  string                `json:"regulation_code,omitempty"`
+	PolicyCode      string                `json:"policy_code,omitempty"`
+	Obligations     []string              `json:"obligations"`
+	Description     string                `json:"description,omitempty"`
+	Status          string                `json:"status"`
+	Documents       []PolicyPackDocument  `json:"documents"`
+	Attributes      []PolicyPackAttribute `json:"attributes"`
+	Thresholds      []PolicyPackThreshold `json:"thresholds"`
+	CreatedBy       string                `json:"created_by,omitempty"`
+	CreatedAt       time.Time             `json:"created_at"`
+	StatusChangedBy string                `json:"status_changed_by,omitempty"`
+	StatusChangedAt *time.Time            `json:"status_changed_at,omitempty"`
+}
+
+// PolicyPackDocument is a document a policy pack requires; optional
+// documents are listed but not required
+type PolicyPackDocument struct {
+	Code      string `db:"document_code" json:"code"`
+	Name      string `db:"name" json:"name,omitempty"`
+	Mandatory bool   `db:"mandatory" json:"mandatory"`
+}
+
+// PolicyPackAttribute is an attribute a policy pack requires cases to
+// capture
+type PolicyPackAttribute struct {
+	Code      string `db:"attribute_code" json:"code"`
+	Mandatory bool   `db:"mandatory" json:"mandatory"`
+}
+
+// PolicyPackThreshold is a named limit of a policy pack, e.g. the
+// ownership percentage above which a beneficial owner is identified
+type PolicyPackThreshold struct {
+	Name        string  `db:"name" json:"name"`
+	Value       float64 `db:"value" json:"value"`
+	Unit        string  `db:"unit" json:"unit,omitempty"`
+	Description string  `db:"description" json:"description,omitempty"`
+}
@@ -85,6 +85,18 @@ type DocumentSectionContext struct {
 	DocType         string `db:"doc_type" json:"doc_type,omitempty"`
 	RegulationCode  string `db:"regulation_code" json:"regulation_code,omitempty"`
 	RegulationTitle string `db:"regulation_title" json:"regulation_title,omitempty"`
+	// EvidenceID and CaseName identify the uploaded evidence the text was
+	// extracted from; both are empty for regulatory text
+	EvidenceID int64  `db:"evidence_id" json:"evidence_id,omitempty"`
+	CaseName   string `db:"case_name" json:"case_name,omitempty"`
+}
+
+// SectionContextSearchResult is a section search hit with its document and
+// regulation context
+type SectionContextSearchResult struct {
+	DocumentSectionContext
+	SimilarityScore float64 `db:"similarity_score" json:"similarity_score"`
+	Distance        float64 `db:"distance" json:"distance"`
 }
 
 // ToEmbeddingText converts document section to text suitable for embedding
@@ -34,6 +34,8 @@ type Feedback struct {
 	Confidence     float64           `db:"confidence" json:"confidence"`
 	AgentName      *string           `db:"agent_name" json:"agent_name,omitempty"`
 	AgentType      AgentType         `db:"agent_type" json:"agent_type"`
+	Tenant         string            `db:"tenant" json:"tenant,omitempty"`
+	QueryCluster   *string           `db:"query_cluster" json:"query_cluster,omitempty"`
 	CreatedAt      time.Time         `db:"created_at" json:"created_at"`
 }
 
@@ -76,6 +78,8 @@ type FeedbackResponse struct {
 	Feedback  FeedbackSentiment `json:"feedback"`
 	AgentName *string           `json:"agent_name,omitempty"`
 	CreatedAt time.Time         `json:"created_at"`
+	// Outcome is what the tenant's feedback policy did with the feedback
+	Outcome *FeedbackOutcome `json:"outcome,omitempty"`
 }
 
 // RecentFeedbackResponse represents a list of recent feedback entries
@@ -0,0 +1,58 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ReevaluationItem is a derived attribute queued for re-evaluation after one
+// of its inputs, its rule or a list it references changed
+type ReevaluationItem struct {
+	ID          int64           `db:"id" json:"id"`
+	CaseName    string          `db:"case_name" json:"case_name,omitempty"` // empty: every case evaluated before
+	DerivedCode string          `db:"derived_code" json:"derived_code"`
+	Reason      string          `db:"reason" json:"reason"`
+	Ref         string          `db:"ref" json:"ref,omitempty"`
+	Inputs      json.RawMessage `db:"inputs" json:"inputs,omitempty"`
+	Depth       int             `db:"depth" json:"depth"`
+	Status      string          `db:"status" json:"status"`
+	Attempts    int             `db:"attempts" json:"attempts"`
+	LastError   string          `db:"last_error" json:"last_error,omitempty"`
+	EnqueuedAt  time.Time       `db:"enqueued_at" json:"enqueued_at"`
+	ProcessedAt *time.Time      `db:"processed_at" json:"processed_at,omitempty"`
+}
+
+// Reevaluation is the fresh result of a derived attribute for a case
+type Reevaluation struct {
+	CaseName      string `json:"case_name"`
+	DerivedCode   string `json:"derived_code"`
+	Reason        string `json:"reason"`
+	PreviousValue string `json:"previous_value"`
+	Value         string `json:"value"`
+	Success       bool   `json:"success"`
+	Error         string `json:"error,omitempty"`
+	Changed       bool   `json:"changed"`
+}
+
+// Simulation is a what-if evaluation of a case's derived attributes with
+// some attribute values overridden; nothing is recorded
+type Simulation struct {
+	CaseName  string                `json:"case_name"`
+	Overrides map[string]any        `json:"overrides"`
+	Changed   int                   `json:"changed"`
+	Results   []SimulatedDerivation `json:"results"`
+}
+
+// SimulatedDerivation compares the result of a rule with the case's current
+// values (baseline) to its result with the overrides
+type SimulatedDerivation struct {
+	DerivedCode     string `json:"derived_code"`
+	Rule            string `json:"rule"`
+	Jurisdiction    string `json:"jurisdiction,omitempty"`
+	BaselineValue   string `json:"baseline_value"`
+	BaselineSuccess bool   `json:"baseline_success"`
+	Value           string `json:"value"`
+	Success         bool   `json:"success"`
+	Error           string `json:"error,omitempty"`
+	Changed         bool   `json:"changed"`
+}
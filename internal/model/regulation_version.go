@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+// RegulationVersion is a version of a regulation record
+// (kyc_regulation_versions), recorded whenever its text or effective dates
+// change
+type RegulationVersion struct {
+	RegulationCode string     `db:"regulation_code" json:"regulation_code"`
+	Version        int        `db:"version" json:"version"`
+	Name           string     `db:"name" json:"name"`
+	Title          string     `db:"title" json:"title,omitempty"`
+	Citation       string     `db:"citation" json:"citation,omitempty"`
+	Summary        string     `db:"summary" json:"summary,omitempty"`
+	Description    string     `db:"description" json:"description,omitempty"`
+	EffectiveFrom  *time.Time `db:"effective_from" json:"effective_from,omitempty"`
+	EffectiveTo    *time.Time `db:"effective_to" json:"effective_to,omitempty"`
+	ChangeNote     string     `db:"change_note" json:"change_note,omitempty"`
+	ChangedBy      string     `db:"changed_by" json:"changed_by,omitempty"`
+	ChangedAt      time.Time  `db:"changed_at" json:"changed_at"`
+}
+
+// RegulationUpdate is a change to a regulation record; nil fields are left
+// as they are
+type RegulationUpdate struct {
+	Name          *string
+	Title         *string
+	Citation      *string
+	Summary       *string
+	Description   *string
+	EffectiveFrom *time.Time
+	EffectiveTo   *time.Time
+	// Note says why the record changed
+	Note string
+}
@@ -0,0 +1,32 @@
+package model
+
+import "time"
+
+// RiskScore is the customer risk score of a case version: its derived
+// attributes weighted into a score from 0 to 100 and a rating
+// (kyc_risk_scores)
+type RiskScore struct {
+	ID          int64        `db:"id" json:"id"`
+	CaseName    string       `db:"case_name" json:"case_name"`
+	CaseVersion int          `db:"case_version" json:"case_version"`
+	Score       float64      `db:"score" json:"score"`
+	Rating      string       `db:"rating" json:"rating"`
+	Factors     []RiskFactor `db:"-" json:"factors"`
+	Actor       string       `db:"actor" json:"actor,omitempty"`
+	ScoredAt    time.Time    `db:"scored_at" json:"scored_at"`
+}
+
+// RiskFactor is the part a derived attribute plays in a risk score
+type RiskFactor struct {
+	Attribute string  `json:"attribute"`
+	Value     string  `json:"value,omitempty"`
+	Weight    float64 `json:"weight"`
+	// Contribution is the points (of 100) the factor adds to the score;
+	// the contributions add up to the score
+	Contribution float64 `json:"contribution"`
+	// Source is where the value came from: evaluation, captured, or
+	// missing when the case has no value
+	Source       string `json:"source"`
+	EvaluationID *int64 `json:"evaluation_id,omitempty"`
+	Note         string `json:"note,omitempty"`
+}
@@ -0,0 +1,25 @@
+package model
+
+// RuleValidation is the outcome of checking a candidate derivation rule
+// against the attribute types of the ontology before it is stored
+type RuleValidation struct {
+	Rule  string `json:"rule"`
+	Valid bool   `json:"valid"`
+	// ResultType is the type the rule evaluates to: boolean, numeric,
+	// string, array or any
+	ResultType        string   `json:"result_type,omitempty"`
+	CompileErrors     []string `json:"compile_errors"`
+	TypeErrors        []string `json:"type_errors"`
+	UnknownAttributes []string `json:"unknown_attributes"`
+	UnknownLists      []string `json:"unknown_lists"`
+	// UndeclaredSources are attributes the rule reads that are missing from
+	// the declared sources; they are not recorded as evaluation inputs
+	UndeclaredSources []string `json:"undeclared_sources"`
+}
+
+// AttributeType is the value type of an attribute as rules see it
+type AttributeType struct {
+	Code     string `db:"code" json:"code"`
+	Class    string `db:"attribute_class" json:"attribute_class"`
+	DataType string `db:"data_type" json:"data_type"`
+}
@@ -0,0 +1,60 @@
+package model
+
+import "time"
+
+// Screening outcomes; a sanctions screening's is recorded as the case's
+// SANCTIONS_SCREENING_STATUS
+const (
+	ScreeningClear          = "Clear"
+	ScreeningPotentialMatch = "Potential Match"
+	ScreeningMatch          = "Match"
+	ScreeningUnderReview    = "Under Review"
+)
+
+// Screening is one sanctions, PEP or adverse media screening of an entity
+// and its beneficial owners (kyc_screenings)
+type Screening struct {
+	ID         int       `db:"id" json:"id"`
+	Kind       string    `db:"kind" json:"kind"` // sanctions, pep or adverse_media
+	EntityID   string    `db:"entity_id" json:"entity_id"`
+	EntityName string    `db:"entity_name" json:"entity_name"`
+	CaseName   string    `db:"case_name" json:"case_name,omitempty"`
+	Status     string    `db:"status" json:"status"`
+	Subjects   int       `db:"subjects" json:"subjects"`
+	HitCount   int       `db:"hits" json:"hit_count"`
+	Providers  []string  `db:"-" json:"providers"`
+	Errors     string    `db:"errors" json:"errors,omitempty"`
+	Actor      string    `db:"actor" json:"actor,omitempty"`
+	ScreenedAt time.Time `db:"screened_at" json:"screened_at"`
+	// Hits are the matches, best first
+	Hits []ScreeningHit `db:"-" json:"hits"`
+}
+
+// ScreeningHit is a list record or article matching the screened entity or
+// one of its beneficial owners (kyc_screening_hits)
+type ScreeningHit struct {
+	ID              int     `db:"id" json:"id"`
+	SubjectEntityID string  `db:"subject_entity_id" json:"subject_entity_id"`
+	SubjectName     string  `db:"subject_name" json:"subject_name"`
+	Provider        string  `db:"provider" json:"provider"`
+	Kind            string  `db:"kind" json:"kind"`
+	Reference       string  `db:"reference" json:"reference"`
+	MatchedName     string  `db:"matched_name" json:"matched_name"`
+	Score           float64 `db:"score" json:"score"`
+	Detail          string  `db:"detail" json:"detail,omitempty"`
+	URL             string  `db:"evidence_url" json:"url,omitempty"`
+}
+
+// ScreeningListIngest records the load of a sanctions list file into the
+// local screening lists (screening_list_ingests)
+type ScreeningListIngest struct {
+	ID         int       `db:"id" json:"id"`
+	ListName   string    `db:"list_name" json:"list_name"`
+	Source     string    `db:"source" json:"source,omitempty"`
+	Entries    int       `db:"entries" json:"entries"`
+	Added      int       `db:"added" json:"added"`
+	Updated    int       `db:"updated" json:"updated"`
+	Removed    int       `db:"removed" json:"removed"`
+	IngestedBy string    `db:"ingested_by" json:"ingested_by,omitempty"`
+	IngestedAt time.Time `db:"ingested_at" json:"ingested_at"`
+}
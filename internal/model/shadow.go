@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+// ShadowKind identifies what a shadow experiment compares
+type ShadowKind string
+
+const (
+	ShadowKindRanking ShadowKind = "ranking"
+)
+
+// ShadowDivergence records a behavioural difference between the live version
+// and a candidate version executed in shadow mode
+type ShadowDivergence struct {
+	ID               int        `db:"id" json:"id"`
+	Experiment       string     `db:"experiment" json:"experiment"`
+	Kind             ShadowKind `db:"kind" json:"kind"`
+	Subject          string     `db:"subject" json:"subject"`
+	PrimaryVersion   string     `db:"primary_version" json:"primary_version"`
+	CandidateVersion string     `db:"candidate_version" json:"candidate_version"`
+	PrimaryResult    string     `db:"primary_result" json:"primary_result,omitempty"`
+	CandidateResult  string     `db:"candidate_result" json:"candidate_result,omitempty"`
+	DivergenceScore  float64    `db:"divergence_score" json:"divergence_score"`
+	Details          string     `db:"details" json:"details,omitempty"`
+	CreatedAt        time.Time  `db:"created_at" json:"created_at"`
+}
+
+// ShadowExperimentSummary aggregates divergences per experiment
+type ShadowExperimentSummary struct {
+	Experiment       string     `db:"experiment" json:"experiment"`
+	Kind             ShadowKind `db:"kind" json:"kind"`
+	PrimaryVersion   string     `db:"primary_version" json:"primary_version"`
+	CandidateVersion string     `db:"candidate_version" json:"candidate_version"`
+	DivergenceCount  int        `db:"divergence_count" json:"divergence_count"`
+	AvgDivergence    float64    `db:"avg_divergence" json:"avg_divergence"`
+	MaxDivergence    float64    `db:"max_divergence" json:"max_divergence"`
+	FirstSeen        time.Time  `db:"first_seen" json:"first_seen"`
+	LastSeen         time.Time  `db:"last_seen" json:"last_seen"`
+}
@@ -0,0 +1,66 @@
+package model
+
+import "time"
+
+// WatchlistAlertStatus is the triage state of a watchlist alert
+type WatchlistAlertStatus string
+
+const (
+	WatchlistAlertOpen         WatchlistAlertStatus = "OPEN"
+	WatchlistAlertAcknowledged WatchlistAlertStatus = "ACKNOWLEDGED"
+	WatchlistAlertDismissed    WatchlistAlertStatus = "DISMISSED"
+)
+
+// WatchlistEntry is an entity pinned for heightened monitoring
+type WatchlistEntry struct {
+	ID              int        `db:"id" json:"id"`
+	EntityID        string     `db:"entity_id" json:"entity_id"`
+	EntityName      string     `db:"entity_name" json:"entity_name"`
+	EntityType      string     `db:"entity_type" json:"entity_type"`
+	Jurisdiction    string     `db:"jurisdiction" json:"jurisdiction,omitempty"`
+	Reason          string     `db:"reason" json:"reason"`
+	PinnedBy        string     `db:"pinned_by" json:"pinned_by,omitempty"`
+	CadenceMinutes  int        `db:"cadence_minutes" json:"cadence_minutes"`
+	IncludeUBOs     bool       `db:"include_ubos" json:"include_ubos"`
+	Active          bool       `db:"active" json:"active"`
+	LastScreenedAt  *time.Time `db:"last_screened_at" json:"last_screened_at,omitempty"`
+	LastError       string     `db:"last_error" json:"last_error,omitempty"`
+	NextScreeningAt time.Time  `db:"next_screening_at" json:"next_screening_at"`
+	OpenAlerts      int        `db:"open_alerts" json:"open_alerts"`
+	CreatedAt       time.Time  `db:"created_at" json:"created_at"`
+}
+
+// WatchlistHit is a screening match recorded for a watched entity or one of
+// its beneficial owners
+type WatchlistHit struct {
+	ID              int       `db:"id" json:"id"`
+	WatchlistID     int       `db:"watchlist_id" json:"watchlist_id"`
+	SubjectEntityID string    `db:"subject_entity_id" json:"subject_entity_id"`
+	SubjectName     string    `db:"subject_name" json:"subject_name"`
+	Provider        string    `db:"provider" json:"provider"`
+	Kind            string    `db:"kind" json:"kind"`
+	Reference       string    `db:"reference" json:"reference"`
+	MatchedName     string    `db:"matched_name" json:"matched_name"`
+	Score           float64   `db:"score" json:"score"`
+	Detail          string    `db:"detail" json:"detail,omitempty"`
+	FirstSeenAt     time.Time `db:"first_seen_at" json:"first_seen_at"`
+	LastSeenAt      time.Time `db:"last_seen_at" json:"last_seen_at"`
+}
+
+// WatchlistAlert is raised when re-screening finds a hit not seen before
+type WatchlistAlert struct {
+	ID          int                  `db:"id" json:"id"`
+	WatchlistID int                  `db:"watchlist_id" json:"watchlist_id"`
+	HitID       int                  `db:"hit_id" json:"hit_id"`
+	EntityID    string               `db:"entity_id" json:"entity_id"`
+	EntityName  string               `db:"entity_name" json:"entity_name"`
+	SubjectName string               `db:"subject_name" json:"subject_name"`
+	Kind        string               `db:"kind" json:"kind"`
+	MatchedName string               `db:"matched_name" json:"matched_name"`
+	Score       float64              `db:"score" json:"score"`
+	Message     string               `db:"message" json:"message"`
+	Status      WatchlistAlertStatus `db:"status" json:"status"`
+	ResolvedBy  string               `db:"resolved_by" json:"resolved_by,omitempty"`
+	ResolvedAt  *time.Time           `db:"resolved_at" json:"resolved_at,omitempty"`
+	CreatedAt   time.Time            `db:"created_at" json:"created_at"`
+}
@@ -0,0 +1,202 @@
+// Package modellog records the prompts and model responses of the generation
+// features in model_io_log, redacted and with a retention period, apart from
+// the audit log because model I/O may contain client data.
+package modellog
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/adamtc007/KYC-DSL/internal/auth"
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/logging"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+// writeTimeout bounds recording an exchange, which outlives its request
+const writeTimeout = 5 * time.Second
+
+// Exchange is one round trip to a model
+type Exchange struct {
+	Feature          string
+	Model            string
+	SystemPrompt     string
+	Prompt           string
+	Response         string
+	PromptTokens     int
+	CompletionTokens int
+	Latency          time.Duration
+	Err              error
+}
+
+// Logger writes exchanges to model_io_log. A nil Logger, or one without a
+// database or with logging disabled, records nothing.
+type Logger struct {
+	db  *sqlx.DB
+	cfg config.ModelLogConfig
+}
+
+// NewLogger creates a logger for the model_log configuration
+func NewLogger(db *sqlx.DB, cfg config.ModelLogConfig) *Logger {
+	return &Logger{db: db, cfg: cfg}
+}
+
+// Enabled reports whether exchanges are recorded
+func (l *Logger) Enabled() bool {
+	return l != nil && l.db != nil && l.cfg.Enabled
+}
+
+// Record stores an exchange with the caller, agent and request ID of ctx.
+// Failures are logged and never reach the generation feature.
+func (l *Logger) Record(ctx context.Context, ex Exchange) {
+	if !l.Enabled() {
+		return
+	}
+
+	redacted, truncated := false, false
+	prepare := func(s string) string {
+		if l.cfg.Redact {
+			var changed bool
+			s, changed = Redact(s)
+			redacted = redacted || changed
+		}
+		if len(s) > l.cfg.MaxChars {
+			cut := l.cfg.MaxChars
+			for cut > 0 && !utf8.RuneStart(s[cut]) {
+				cut--
+			}
+			s = s[:cut]
+			truncated = true
+		}
+		return s
+	}
+	systemPrompt := prepare(ex.SystemPrompt)
+	prompt := prepare(ex.Prompt)
+	var response *string
+	if l.cfg.Responses && ex.Response != "" {
+		r := prepare(ex.Response)
+		response = &r
+	}
+	errMsg := ""
+	if ex.Err != nil {
+		errMsg = ex.Err.Error()
+	}
+	subject := ""
+	if p, ok := auth.PrincipalFromContext(ctx); ok {
+		subject = p.Subject
+	}
+
+	writeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), writeTimeout)
+	defer cancel()
+	_, err := l.db.ExecContext(writeCtx, `
+		INSERT INTO model_io_log
+			(feature, model, agent_name, subject, request_id, system_prompt, prompt, response,
+			 redacted, truncated, prompt_tokens, completion_tokens, latency_ms, error_message, expires_at)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, ''), $7, $8,
+			$9, $10, NULLIF($11, 0), NULLIF($12, 0), $13, NULLIF($14, ''), $15)`,
+		ex.Feature, ex.Model, auth.AgentName(ctx, ""), subject, logging.RequestIDFromContext(ctx),
+		systemPrompt, prompt, response, redacted, truncated, ex.PromptTokens, ex.CompletionTokens,
+		ex.Latency.Milliseconds(), errMsg, time.Now().UTC().Add(l.cfg.Retention))
+	if err != nil {
+		slog.Warn("⚠️  Failed to record model exchange", "feature", ex.Feature, "error", err)
+	}
+}
+
+// Filter narrows a listing of the log
+type Filter struct {
+	Feature string
+	Agent   string
+	Since   *time.Time
+	Limit   int
+}
+
+type entryRow struct {
+	ID               int64     `db:"id"`
+	Feature          string    `db:"feature"`
+	Model            string    `db:"model"`
+	AgentName        string    `db:"agent_name"`
+	Subject          string    `db:"subject"`
+	RequestID        string    `db:"request_id"`
+	SystemPrompt     string    `db:"system_prompt"`
+	Prompt           string    `db:"prompt"`
+	Response         *string   `db:"response"`
+	Redacted         bool      `db:"redacted"`
+	Truncated        bool      `db:"truncated"`
+	PromptTokens     int       `db:"prompt_tokens"`
+	CompletionTokens int       `db:"completion_tokens"`
+	LatencyMs        int       `db:"latency_ms"`
+	Error            string    `db:"error_message"`
+	CreatedAt        time.Time `db:"created_at"`
+	ExpiresAt        time.Time `db:"expires_at"`
+}
+
+func (r entryRow) toModel() model.ModelIOEntry {
+	return model.ModelIOEntry(r)
+}
+
+// List returns the most recent unexpired entries, newest first
+func List(ctx context.Context, db *sqlx.DB, f Filter) ([]model.ModelIOEntry, error) {
+	if f.Limit <= 0 {
+		f.Limit = 50
+	}
+	var rows []entryRow
+	err := db.SelectContext(ctx, &rows, `
+		SELECT id, feature, model, COALESCE(agent_name, '') AS agent_name, COALESCE(subject, '') AS subject,
+			COALESCE(request_id, '') AS request_id, COALESCE(system_prompt, '') AS system_prompt,
+			prompt, response, redacted, truncated,
+			COALESCE(prompt_tokens, 0) AS prompt_tokens, COALESCE(completion_tokens, 0) AS completion_tokens,
+			COALESCE(latency_ms, 0) AS latency_ms, COALESCE(error_message, '') AS error_message,
+			created_at, expires_at
+		FROM model_io_log
+		WHERE expires_at > CURRENT_TIMESTAMP
+		  AND ($1 = '' OR feature = $1)
+		  AND ($2 = '' OR agent_name = $2)
+		  AND ($3::timestamp IS NULL OR created_at >= $3)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $4`,
+		f.Feature, f.Agent, f.Since, f.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list model exchanges: %w", err)
+	}
+	entries := make([]model.ModelIOEntry, len(rows))
+	for i, r := range rows {
+		entries[i] = r.toModel()
+	}
+	return entries, nil
+}
+
+// Purge deletes expired entries and returns how many were removed
+func Purge(ctx context.Context, db *sqlx.DB) (int64, error) {
+	res, err := db.ExecContext(ctx, `DELETE FROM model_io_log WHERE expires_at <= CURRENT_TIMESTAMP`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge model exchanges: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+// RunPurger purges expired entries every interval until ctx is cancelled. It
+// runs whether or not logging is enabled, so entries written before logging
+// was turned off still expire.
+func RunPurger(ctx context.Context, db *sqlx.DB, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if n, err := Purge(ctx, db); err != nil {
+			slog.Warn("⚠️  Model log purge failed", "error", err)
+		} else if n > 0 {
+			slog.Info("🧹 Purged expired model exchanges", "count", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
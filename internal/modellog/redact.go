@@ -0,0 +1,30 @@
+package modellog
+
+import "regexp"
+
+// redactions mask identifiers that commonly appear in client data. They are
+// applied in order, so IBANs are masked before their digits look like a
+// long number.
+var redactions = []struct {
+	pattern *regexp.Regexp
+	marker  string
+}{
+	{regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`), "[EMAIL]"},
+	{regexp.MustCompile(`\b[A-Z]{2}[0-9]{2}(?: ?[A-Z0-9]{4}){2,7}(?: ?[A-Z0-9]{1,4})?\b`), "[IBAN]"},
+	{regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`), "[TAX_ID]"},
+	{regexp.MustCompile(`\+?\(?\d{1,4}\)?[ .-]?\d{2,4}[ .-]\d{3,4}[ .-]?\d{3,4}\b`), "[PHONE]"},
+	{regexp.MustCompile(`\b\d{8,}\b`), "[NUMBER]"},
+}
+
+// Redact masks e-mail addresses, IBANs, phone numbers, US tax identifiers and
+// numbers of eight or more digits, and reports whether anything was masked
+func Redact(s string) (string, bool) {
+	changed := false
+	for _, r := range redactions {
+		if r.pattern.MatchString(s) {
+			s = r.pattern.ReplaceAllString(s, r.marker)
+			changed = true
+		}
+	}
+	return s, changed
+}
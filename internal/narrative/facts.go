@@ -0,0 +1,255 @@
+// Package narrative composes human-readable due-diligence summaries of a
+// case for review committees. A narrative is rendered by a text/template
+// from Facts gathered from structured data only (the case DSL, the CBU
+// graph, rule evaluations and validation findings); an optional polishing
+// pass may rephrase the draft but never adds to it.
+package narrative
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+// UBOThreshold is the beneficial ownership percentage from which an owner
+// must be identified and verified (AMLD5 Art. 3(6))
+const UBOThreshold = 25.0
+
+// Facts is the structured input a narrative is rendered from
+type Facts struct {
+	CaseID      string    `json:"case_id"`
+	VersionID   string    `json:"version_id"`
+	Status      string    `json:"status"`
+	VersionDate string    `json:"version_date"`
+	VersionHash string    `json:"version_hash,omitempty"` // canonical SHA-256 of the version's DSL
+	GeneratedAt time.Time `json:"generated_at"`
+
+	Nature      string   `json:"nature,omitempty"`
+	Purpose     string   `json:"purpose,omitempty"`
+	CBU         string   `json:"cbu,omitempty"`
+	Policies    []string `json:"policies,omitempty"`
+	Obligations []string `json:"obligations,omitempty"`
+	Token       string   `json:"kyc_token,omitempty"`
+
+	Entity       string        `json:"entity,omitempty"`
+	Owners       []Holder      `json:"owners,omitempty"`
+	UBOs         []Holder      `json:"ubos,omitempty"`
+	Controllers  []Controller  `json:"controllers,omitempty"`
+	Participants []Participant `json:"participants,omitempty"`
+
+	Documents   []RequiredDocument `json:"documents,omitempty"`
+	RiskFactors []RiskFactor       `json:"risk_factors,omitempty"`
+	Gaps        []string           `json:"gaps,omitempty"`
+
+	documentedOwnership bool
+}
+
+// Holder is an owner or beneficial owner with its percentage
+type Holder struct {
+	Name    string  `json:"name"`
+	Percent float64 `json:"percent"`
+}
+
+// AboveThreshold reports whether the holding reaches the UBO threshold
+func (h Holder) AboveThreshold() bool {
+	return h.Percent >= UBOThreshold
+}
+
+// Controller is a person exercising control other than through ownership
+type Controller struct {
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+// Participant is an entity of the case's CBU in the ontology, with its role
+type Participant struct {
+	Name         string `json:"name"`
+	EntityType   string `json:"entity_type"`
+	Jurisdiction string `json:"jurisdiction,omitempty"`
+	Role         string `json:"role"`
+}
+
+// RequiredDocument is a document the case requires for a jurisdiction.
+// Evidenced documents are cited as a source in the case's data dictionary.
+type RequiredDocument struct {
+	Code         string `json:"code"`
+	Name         string `json:"name,omitempty"`
+	Jurisdiction string `json:"jurisdiction,omitempty"`
+	Evidenced    bool   `json:"evidenced"`
+}
+
+// RiskFactor is the latest outcome of a derived attribute rule
+type RiskFactor struct {
+	Code        string `json:"code"`
+	Value       string `json:"value"`
+	Regulation  string `json:"regulation,omitempty"`
+	Rule        string `json:"rule"`
+	Explanation string `json:"explanation"`
+	Raised      bool   `json:"raised"` // the rule flagged a risk (value true)
+}
+
+// FromDSL extracts the case sections a narrative describes
+func FromDSL(dsl string) (*Facts, error) {
+	c, err := storage.ParseCase(dsl)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &Facts{CaseID: c.Arg(0)}
+	sources := map[string]bool{}
+	for _, sec := range c.Children {
+		switch sec.Head {
+		case "nature-purpose":
+			if n, ok := sec.Find("nature"); ok {
+				f.Nature = n.Arg(0)
+			}
+			if p, ok := sec.Find("purpose"); ok {
+				f.Purpose = p.Arg(0)
+			}
+		case "client-business-unit":
+			f.CBU = sec.Arg(0)
+		case "policy":
+			f.Policies = append(f.Policies, sec.Arg(0))
+		case "obligation":
+			f.Obligations = append(f.Obligations, sec.Arg(0))
+		case "kyc-token":
+			f.Token = sec.Arg(0)
+		case "ownership-structure":
+			f.documentedOwnership = true
+			for _, node := range sec.Children {
+				switch node.Head {
+				case "entity":
+					f.Entity = node.Arg(0)
+				case "owner":
+					f.Owners = append(f.Owners, holder(node))
+				case "beneficial-owner":
+					f.UBOs = append(f.UBOs, holder(node))
+				case "controller":
+					f.Controllers = append(f.Controllers, Controller{Name: node.Arg(0), Role: node.Arg(1)})
+				}
+			}
+		case "document-requirements":
+			jurisdiction := ""
+			if j, ok := sec.Find("jurisdiction"); ok {
+				jurisdiction = j.Arg(0)
+			}
+			if req, ok := sec.Find("required"); ok {
+				for _, doc := range req.Children {
+					if doc.Head == "document" {
+						f.Documents = append(f.Documents, RequiredDocument{
+							Code: doc.Arg(0), Name: doc.Arg(1), Jurisdiction: jurisdiction,
+						})
+					}
+				}
+			}
+		case "data-dictionary":
+			for _, attr := range sec.Children {
+				for _, src := range attr.Children {
+					if doc, ok := src.Find("document"); ok {
+						sources[doc.Arg(0)] = true
+					}
+				}
+			}
+		}
+	}
+	for i := range f.Documents {
+		f.Documents[i].Evidenced = sources[f.Documents[i].Code]
+	}
+	return f, nil
+}
+
+func holder(node storage.Section) Holder {
+	pct, _ := strconv.ParseFloat(strings.TrimSuffix(node.Arg(1), "%"), 64)
+	return Holder{Name: node.Arg(0), Percent: pct}
+}
+
+// NewRiskFactor explains a recorded rule evaluation from its rule and inputs
+func NewRiskFactor(code, value, rule, regulation string, inputs map[string]any) RiskFactor {
+	rf := RiskFactor{
+		Code:       code,
+		Value:      value,
+		Regulation: regulation,
+		Rule:       rule,
+		Raised:     value == "true",
+	}
+
+	names := make([]string, 0, len(inputs))
+	for name := range inputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s = %v", name, inputs[name])
+	}
+
+	rf.Explanation = fmt.Sprintf("%s evaluated to %s", code, value)
+	if regulation != "" {
+		rf.Explanation += " under " + regulation
+	}
+	if rule != "" {
+		rf.Explanation += fmt.Sprintf(" by rule %s", rule)
+	}
+	if len(parts) > 0 {
+		rf.Explanation += " with " + strings.Join(parts, ", ")
+	}
+	return rf
+}
+
+// DirectOwnership is the sum of the documented direct ownership percentages
+func (f *Facts) DirectOwnership() float64 {
+	total := 0.0
+	for _, o := range f.Owners {
+		total += o.Percent
+	}
+	return total
+}
+
+// RaisedRisks returns the risk factors whose rule flagged a risk
+func (f *Facts) RaisedRisks() []RiskFactor {
+	var raised []RiskFactor
+	for _, rf := range f.RiskFactors {
+		if rf.Raised {
+			raised = append(raised, rf)
+		}
+	}
+	return raised
+}
+
+// AddGap records an outstanding item
+func (f *Facts) AddGap(format string, args ...any) {
+	f.Gaps = append(f.Gaps, fmt.Sprintf(format, args...))
+}
+
+// DeriveGaps records the outstanding items that follow from the case structure
+func (f *Facts) DeriveGaps() {
+	if !f.documentedOwnership {
+		f.AddGap("Ownership structure has not been documented")
+	} else {
+		if total := f.DirectOwnership(); len(f.Owners) > 0 && total != 100 {
+			f.AddGap("Documented direct ownership totals %.2f%%, not 100%%", total)
+		}
+		if len(f.UBOs) == 0 {
+			f.AddGap("No ultimate beneficial owner has been identified")
+		}
+		if len(f.Controllers) == 0 {
+			f.AddGap("No controlling person (senior managing official) has been identified")
+		}
+	}
+	if len(f.Policies) == 0 {
+		f.AddGap("No KYC policy has been applied")
+	}
+	if f.Token == "" || f.Token == "pending" {
+		f.AddGap("KYC token has not been issued")
+	}
+}
+
+// JSON encodes the facts for storage next to the narrative
+func (f *Facts) JSON() ([]byte, error) {
+	return json.Marshal(f)
+}
@@ -0,0 +1,88 @@
+package narrative
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	openai "github.com/sashabaranov/go-openai"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+	kycconfig "github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/modellog"
+)
+
+// polishPrompt constrains the model to rephrasing: the draft is the only
+// source of facts and the reviewed record of the case
+const polishPrompt = `You edit due-diligence summaries written for a KYC review committee.
+Rewrite the summary you are given as clear, formal prose.
+Keep every section heading, name, percentage, rule code and regulation exactly as written.
+Do not add, infer, soften or omit any fact, risk or gap. Do not give recommendations.`
+
+// Polisher rewrites a rendered draft into fluent prose with a chat model
+type Polisher struct {
+	client *openai.Client
+	model  string
+	log    *modellog.Logger
+}
+
+// NewPolisher creates a polisher from the OpenAI section of config.Current
+func NewPolisher() (*Polisher, error) {
+	cfg := kycconfig.Current().OpenAI
+	if cfg.APIKey == "" {
+		return nil, fmt.Errorf("OPENAI_API_KEY not set")
+	}
+
+	config := openai.DefaultConfig(cfg.APIKey)
+	config.HTTPClient = &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)}
+	return &Polisher{client: openai.NewClientWithConfig(config), model: cfg.ChatModel}, nil
+}
+
+// Model returns the chat model used for polishing
+func (p *Polisher) Model() string {
+	return p.model
+}
+
+// WithLog records the prompts and responses of the polisher
+func (p *Polisher) WithLog(l *modellog.Logger) *Polisher {
+	p.log = l
+	return p
+}
+
+// Polish rewrites draft without changing its content
+func (p *Polisher) Polish(ctx context.Context, draft string) (string, error) {
+	start := time.Now()
+	resp, err := p.client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+		Model:       p.model,
+		Temperature: 0.2,
+		Messages: []openai.ChatCompletionMessage{
+			{Role: openai.ChatMessageRoleSystem, Content: polishPrompt},
+			{Role: openai.ChatMessageRoleUser, Content: draft},
+		},
+	})
+	ex := modellog.Exchange{
+		Feature:          model.ModelFeatureNarrativePolish,
+		Model:            p.model,
+		SystemPrompt:     polishPrompt,
+		Prompt:           draft,
+		PromptTokens:     resp.Usage.PromptTokens,
+		CompletionTokens: resp.Usage.CompletionTokens,
+		Latency:          time.Since(start),
+		Err:              err,
+	}
+	if err == nil && len(resp.Choices) > 0 {
+		ex.Response = resp.Choices[0].Message.Content
+	}
+	p.log.Record(ctx, ex)
+
+	if err != nil {
+		return "", fmt.Errorf("chat completion failed: %w", err)
+	}
+	if len(resp.Choices) == 0 || strings.TrimSpace(resp.Choices[0].Message.Content) == "" {
+		return "", fmt.Errorf("chat completion returned no text")
+	}
+	return strings.TrimSpace(resp.Choices[0].Message.Content) + "\n", nil
+}
@@ -0,0 +1,51 @@
+package narrative
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// DefaultTemplate is used when a request names no template
+const DefaultTemplate = "committee"
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+var templates = template.Must(template.New("narrative").Funcs(template.FuncMap{
+	"join":      strings.Join,
+	"pct":       func(v float64) string { return fmt.Sprintf("%.2f%%", v) },
+	"threshold": func() string { return fmt.Sprintf("%.0f%%", UBOThreshold) },
+}).ParseFS(templateFS, "templates/*.tmpl"))
+
+// Templates lists the available template names
+func Templates() []string {
+	var names []string
+	for _, t := range templates.Templates() {
+		if name, ok := strings.CutSuffix(t.Name(), ".tmpl"); ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Render renders facts with the named template
+func Render(name string, f *Facts) (string, error) {
+	if name == "" {
+		name = DefaultTemplate
+	}
+	t := templates.Lookup(name + ".tmpl")
+	if t == nil {
+		return "", fmt.Errorf("unknown narrative template %q (available: %s)", name, strings.Join(Templates(), ", "))
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, f); err != nil {
+		return "", fmt.Errorf("failed to render narrative: %w", err)
+	}
+	return strings.TrimSpace(buf.String()) + "\n", nil
+}
@@ -0,0 +1,159 @@
+package ontology
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+)
+
+// Ways rag_audit_log can keep a query embedding (audit_log.embedding_storage)
+const (
+	AuditEmbeddingFull    = "full"
+	AuditEmbeddingReduced = "reduced"
+	AuditEmbeddingHash    = "hash"
+	AuditEmbeddingNone    = "none"
+)
+
+// compactBatchSize is the number of audit rows compacted per statement
+const compactBatchSize = 500
+
+// auditEmbedding is what is stored of a query embedding
+type auditEmbedding struct {
+	full    []float32
+	reduced []float32
+	hash    string
+	storage string
+}
+
+// encodeAuditEmbedding reduces a query embedding to what cfg keeps. Every
+// mode but none keeps the hash, so repeated queries can still be grouped.
+func encodeAuditEmbedding(vec []float32, cfg config.AuditLogConfig) auditEmbedding {
+	if len(vec) == 0 || cfg.EmbeddingStorage == AuditEmbeddingNone {
+		return auditEmbedding{storage: AuditEmbeddingNone}
+	}
+	e := auditEmbedding{hash: embeddingHash(vec), storage: cfg.EmbeddingStorage}
+	switch cfg.EmbeddingStorage {
+	case AuditEmbeddingReduced:
+		e.reduced = reduceEmbedding(vec, cfg.ReducedDimensions)
+	case AuditEmbeddingHash:
+	default:
+		e.full, e.storage = vec, AuditEmbeddingFull
+	}
+	return e
+}
+
+// reduceEmbedding keeps the first n components of an embedding, rescaled to
+// unit length. OpenAI text-embedding-3 vectors are trained so that such
+// prefixes remain usable for cosine similarity.
+func reduceEmbedding(vec []float32, n int) []float32 {
+	if n <= 0 || n > len(vec) {
+		n = len(vec)
+	}
+	reduced := make([]float32, n)
+	copy(reduced, vec[:n])
+
+	var norm float64
+	for _, v := range reduced {
+		norm += float64(v) * float64(v)
+	}
+	if norm = math.Sqrt(norm); norm > 0 {
+		for i := range reduced {
+			reduced[i] = float32(float64(reduced[i]) / norm)
+		}
+	}
+	return reduced
+}
+
+// embeddingHash is the hex SHA-256 of an embedding's little-endian float32
+// components
+func embeddingHash(vec []float32) string {
+	buf := make([]byte, 4*len(vec))
+	for i, v := range vec {
+		binary.LittleEndian.PutUint32(buf[4*i:], math.Float32bits(v))
+	}
+	sum := sha256.Sum256(buf)
+	return hex.EncodeToString(sum[:])
+}
+
+// CompactAuditEmbeddings converts full query embeddings logged before a time
+// to the configured storage and returns how many rows were converted. It
+// does nothing when full embeddings are configured.
+func (r *EnhancementsRepo) CompactAuditEmbeddings(ctx context.Context, before time.Time) (int, error) {
+	if r.audit.EmbeddingStorage == AuditEmbeddingFull {
+		return 0, nil
+	}
+
+	compacted := 0
+	for {
+		var rows []struct {
+			ID        int             `db:"id"`
+			Embedding pq.Float32Array `db:"embedding"`
+		}
+		err := r.db.SelectContext(ctx, &rows, `
+			SELECT id, query_embedding::real[] AS embedding
+			FROM rag_audit_log
+			WHERE embedding_storage = 'full' AND created_at < $1
+			ORDER BY created_at
+			LIMIT $2`, before, compactBatchSize)
+		if err != nil {
+			return compacted, fmt.Errorf("failed to read audit embeddings: %w", err)
+		}
+		if len(rows) == 0 {
+			return compacted, nil
+		}
+
+		for _, row := range rows {
+			e := encodeAuditEmbedding(row.Embedding, r.audit)
+			_, err := r.db.ExecContext(ctx, `
+				UPDATE rag_audit_log
+				SET query_embedding = NULL,
+				    query_embedding_reduced = $2,
+				    query_embedding_hash = NULLIF($3, ''),
+				    embedding_storage = $4
+				WHERE id = $1`,
+				row.ID, nullFloatArray(e.reduced), e.hash, e.storage)
+			if err != nil {
+				return compacted, fmt.Errorf("failed to compact audit embedding %d: %w", row.ID, err)
+			}
+			compacted++
+		}
+	}
+}
+
+// RunAuditCompactor compacts audit embeddings older than maxAge every
+// interval until ctx is cancelled
+func (r *EnhancementsRepo) RunAuditCompactor(ctx context.Context, maxAge, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if n, err := r.CompactAuditEmbeddings(ctx, time.Now().Add(-maxAge)); err != nil {
+			slog.Warn("⚠️  Audit embedding compaction failed", "error", err)
+		} else if n > 0 {
+			slog.Info("🗜️  Compacted audit query embeddings", "count", n, "storage", r.audit.EmbeddingStorage)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// nullFloatArray returns nil for an empty slice, so it is stored as NULL
+func nullFloatArray(v []float32) interface{} {
+	if len(v) == 0 {
+		return nil
+	}
+	return pq.Array(v)
+}
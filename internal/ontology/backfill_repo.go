@@ -0,0 +1,284 @@
+package ontology
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+// BackfillKinds are the ontology tables an embedding backfill can cover
+var BackfillKinds = []string{"attributes", "documents", "regulations"}
+
+// BackfillRepo loads entries missing embeddings and persists backfill checkpoints
+type BackfillRepo struct {
+	db *sqlx.DB
+}
+
+// NewBackfillRepo creates a new backfill repository
+func NewBackfillRepo(db *sqlx.DB) *BackfillRepo {
+	return &BackfillRepo{db: db}
+}
+
+// ContentHash returns the hash recorded with an embedding generated from text
+func ContentHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+// ListPending returns entries of kind without an embedding whose code sorts
+// after afterCode, in code order. Attributes archived to the cold tier have
+// an embedding and are not pending.
+func (r *BackfillRepo) ListPending(ctx context.Context, kind, afterCode string) ([]model.EmbeddingTarget, error) {
+	condition := "embedding IS NULL"
+	if kind == "attributes" {
+		condition += " AND embedding_tier = 'hot'"
+	}
+	targets, err := r.listTargets(ctx, kind, condition, afterCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s without embeddings: %w", kind, err)
+	}
+	return targets, nil
+}
+
+// ListStale returns the embedded entries of kind whose embedding text no
+// longer hashes to the recorded content hash, in code order. Entries without
+// a recorded hash are included with an empty StoredHash.
+func (r *BackfillRepo) ListStale(ctx context.Context, kind string) ([]model.EmbeddingTarget, error) {
+	targets, err := r.listTargets(ctx, kind, "embedding IS NOT NULL", "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list embedded %s: %w", kind, err)
+	}
+	stale := targets[:0]
+	for _, t := range targets {
+		if t.StoredHash != ContentHash(t.Text) {
+			stale = append(stale, t)
+		}
+	}
+	return stale, nil
+}
+
+// ListAll returns every entry of kind with its embedding text, in code order
+func (r *BackfillRepo) ListAll(ctx context.Context, kind string) ([]model.EmbeddingTarget, error) {
+	targets, err := r.listTargets(ctx, kind, "TRUE", "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", kind, err)
+	}
+	return targets, nil
+}
+
+// listTargets loads the entries of kind matching condition whose code sorts
+// after afterCode, with their embedding text and recorded content hash
+func (r *BackfillRepo) listTargets(ctx context.Context, kind, condition, afterCode string) ([]model.EmbeddingTarget, error) {
+	var targets []model.EmbeddingTarget
+
+	switch kind {
+	case "attributes":
+		query := `
+			SELECT attribute_code, synonyms, regulatory_citations, example_values,
+			       COALESCE(business_context, '') AS business_context,
+			       COALESCE(embedding_content_hash, '') AS embedding_content_hash
+			FROM kyc_attribute_metadata
+			WHERE ` + condition + ` AND attribute_code > $1
+			ORDER BY attribute_code
+		`
+		rows, err := r.db.QueryContext(ctx, query, afterCode)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var m model.AttributeMetadata
+			var hash string
+			if err := rows.Scan(&m.AttributeCode, pq.Array(&m.Synonyms), pq.Array(&m.RegulatoryCitations),
+				pq.Array(&m.ExampleValues), &m.BusinessContext, &hash); err != nil {
+				return nil, fmt.Errorf("failed to scan attribute metadata: %w", err)
+			}
+			targets = append(targets, model.EmbeddingTarget{Code: m.AttributeCode, Text: m.ToEmbeddingText(), StoredHash: hash})
+		}
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+
+	case "documents":
+		query := `
+			SELECT code, name, COALESCE(title, '') AS title, COALESCE(domain, '') AS domain,
+			       COALESCE(jurisdiction, '') AS jurisdiction, COALESCE(doc_type, '') AS doc_type,
+			       COALESCE(description, '') AS description,
+			       COALESCE(embedding_content_hash, '') AS embedding_content_hash
+			FROM kyc_documents
+			WHERE ` + condition + ` AND code > $1
+			ORDER BY code
+		`
+		var docs []struct {
+			model.Document
+			Hash string `db:"embedding_content_hash"`
+		}
+		if err := r.db.SelectContext(ctx, &docs, query, afterCode); err != nil {
+			return nil, err
+		}
+		for i := range docs {
+			targets = append(targets, model.EmbeddingTarget{Code: docs[i].Code, Text: docs[i].ToEmbeddingText(), StoredHash: docs[i].Hash})
+		}
+
+	case "regulations":
+		query := `
+			SELECT code, name, COALESCE(title, '') AS title, COALESCE(region, '') AS region,
+			       COALESCE(jurisdiction, '') AS jurisdiction, COALESCE(authority, '') AS authority,
+			       COALESCE(citation, '') AS citation, COALESCE(summary, '') AS summary,
+			       COALESCE(description, '') AS description,
+			       COALESCE(embedding_content_hash, '') AS embedding_content_hash
+			FROM kyc_regulations
+			WHERE ` + condition + ` AND code > $1
+			ORDER BY code
+		`
+		var regs []struct {
+			model.Regulation
+			Hash string `db:"embedding_content_hash"`
+		}
+		if err := r.db.SelectContext(ctx, &regs, query, afterCode); err != nil {
+			return nil, err
+		}
+		for i := range regs {
+			targets = append(targets, model.EmbeddingTarget{Code: regs[i].Code, Text: regs[i].ToEmbeddingText(), StoredHash: regs[i].Hash})
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown backfill kind: %s", kind)
+	}
+
+	return targets, nil
+}
+
+// SaveEmbedding stores the embedding of a single entry with the content hash
+// of the text it was generated from; it is recorded as generated by the
+// primary embedding space's model
+func (r *BackfillRepo) SaveEmbedding(ctx context.Context, kind, code, contentHash string, embedding []float32) error {
+	var query string
+	switch kind {
+	case "attributes":
+		query = `UPDATE kyc_attribute_metadata SET embedding = $2, embedding_content_hash = $3,
+			embedding_model = (` + primaryModel + `), updated_at = NOW() WHERE attribute_code = $1`
+	case "documents":
+		query = `UPDATE kyc_documents SET embedding = $2, embedding_content_hash = $3,
+			embedding_model = (` + primaryModel + `) WHERE code = $1`
+	case "regulations":
+		query = `UPDATE kyc_regulations SET embedding = $2, embedding_content_hash = $3,
+			embedding_model = (` + primaryModel + `) WHERE code = $1`
+	default:
+		return fmt.Errorf("unknown backfill kind: %s", kind)
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, code, pq.Array(embedding), contentHash); err != nil {
+		return fmt.Errorf("failed to save embedding for %s: %w", code, err)
+	}
+	return nil
+}
+
+// AdoptContentHash records contentHash for an embedding that predates
+// versioning, assuming it was generated from the current text
+func (r *BackfillRepo) AdoptContentHash(ctx context.Context, kind, code, contentHash string) error {
+	var query string
+	switch kind {
+	case "attributes":
+		query = `UPDATE kyc_attribute_metadata SET embedding_content_hash = $2 WHERE attribute_code = $1 AND embedding_content_hash IS NULL`
+	case "documents":
+		query = `UPDATE kyc_documents SET embedding_content_hash = $2 WHERE code = $1 AND embedding_content_hash IS NULL`
+	case "regulations":
+		query = `UPDATE kyc_regulations SET embedding_content_hash = $2 WHERE code = $1 AND embedding_content_hash IS NULL`
+	default:
+		return fmt.Errorf("unknown backfill kind: %s", kind)
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, code, contentHash); err != nil {
+		return fmt.Errorf("failed to record content hash for %s: %w", code, err)
+	}
+	return nil
+}
+
+// Coverage returns how many entries of kind exist and how many have an
+// embedding, counting archived attribute embeddings
+func (r *BackfillRepo) Coverage(ctx context.Context, kind string) (total, embedded int, err error) {
+	var table string
+	count := "COUNT(embedding)"
+	switch kind {
+	case "attributes":
+		table = "kyc_attribute_metadata"
+		count = "COUNT(*) FILTER (WHERE embedding IS NOT NULL OR embedding_tier = 'cold')"
+	case "documents":
+		table = "kyc_documents"
+	case "regulations":
+		table = "kyc_regulations"
+	default:
+		return 0, 0, fmt.Errorf("unknown backfill kind: %s", kind)
+	}
+
+	var row struct {
+		Total    int `db:"total"`
+		Embedded int `db:"embedded"`
+	}
+	query := `SELECT COUNT(*) AS total, ` + count + ` AS embedded FROM ` + table
+	if err := r.db.GetContext(ctx, &row, query); err != nil {
+		return 0, 0, fmt.Errorf("failed to count %s embeddings: %w", kind, err)
+	}
+	return row.Total, row.Embedded, nil
+}
+
+// GetCheckpoint returns the checkpoint of a job, or nil if it has never run
+func (r *BackfillRepo) GetCheckpoint(ctx context.Context, job string) (*model.EmbeddingBackfill, error) {
+	query := `
+		SELECT job, kind, model, COALESCE(last_code, '') AS last_code, processed, failed,
+		       tokens, spend_usd, status, started_at, updated_at
+		FROM kyc_embedding_backfills
+		WHERE job = $1
+	`
+
+	var cp model.EmbeddingBackfill
+	err := r.db.GetContext(ctx, &cp, query, job)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get backfill checkpoint: %w", err)
+	}
+	return &cp, nil
+}
+
+// SaveCheckpoint records the progress of a job
+func (r *BackfillRepo) SaveCheckpoint(ctx context.Context, cp model.EmbeddingBackfill) error {
+	query := `
+		INSERT INTO kyc_embedding_backfills
+			(job, kind, model, last_code, processed, failed, tokens, spend_usd, status)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7, $8, $9)
+		ON CONFLICT (job)
+		DO UPDATE SET
+			last_code = EXCLUDED.last_code,
+			processed = EXCLUDED.processed,
+			failed = EXCLUDED.failed,
+			tokens = EXCLUDED.tokens,
+			spend_usd = EXCLUDED.spend_usd,
+			status = EXCLUDED.status,
+			updated_at = NOW()
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		cp.Job, cp.Kind, cp.Model, cp.LastCode, cp.Processed, cp.Failed, cp.Tokens, cp.SpendUSD, cp.Status)
+	if err != nil {
+		return fmt.Errorf("failed to save backfill checkpoint: %w", err)
+	}
+	return nil
+}
+
+// DeleteCheckpoint forgets a job so the next run starts from the first code
+func (r *BackfillRepo) DeleteCheckpoint(ctx context.Context, job string) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM kyc_embedding_backfills WHERE job = $1`, job); err != nil {
+		return fmt.Errorf("failed to delete backfill checkpoint: %w", err)
+	}
+	return nil
+}
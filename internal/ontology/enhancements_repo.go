@@ -4,21 +4,27 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
 
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/metrics"
 	"github.com/adamtc007/KYC-DSL/internal/model"
 )
 
 // EnhancementsRepo handles RAG enhancement operations
 type EnhancementsRepo struct {
 	db *sqlx.DB
+	// audit decides how query embeddings are kept in rag_audit_log
+	audit config.AuditLogConfig
 }
 
 // NewEnhancementsRepo creates a new enhancements repository
 func NewEnhancementsRepo(db *sqlx.DB) *EnhancementsRepo {
-	return &EnhancementsRepo{db: db}
+	return &EnhancementsRepo{db: db, audit: config.Current().AuditLog}
 }
 
 // ==================== Enhancement A: Feedback Loop ====================
@@ -145,38 +151,121 @@ func (r *EnhancementsRepo) InsertDocumentSection(ctx context.Context, section mo
 	return id, nil
 }
 
-// SearchDocumentSections performs semantic search on document sections
+// ReplaceDocumentSections deletes the sections of a document and inserts
+// the given ones in order, in one transaction. Sections extracted from
+// uploaded evidence of the document are kept.
+func (r *EnhancementsRepo) ReplaceDocumentSections(ctx context.Context, documentCode string, sections []model.DocumentSection) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM kyc_document_sections WHERE document_code = $1 AND evidence_id IS NULL`, documentCode); err != nil {
+		return fmt.Errorf("failed to delete sections of %s: %w", documentCode, err)
+	}
+	for _, section := range sections {
+		var embedding interface{}
+		if len(section.Embedding) > 0 {
+			embedding = pq.Array(section.Embedding)
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO kyc_document_sections
+				(document_code, section_number, section_title, text_excerpt, page_number, embedding)
+			VALUES ($1, $2, $3, $4, $5, $6)`,
+			documentCode,
+			nullString(section.SectionNumber),
+			nullString(section.SectionTitle),
+			section.TextExcerpt,
+			nullInt(section.PageNumber),
+			embedding,
+		); err != nil {
+			return fmt.Errorf("failed to insert document section: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit sections of %s: %w", documentCode, err)
+	}
+	return nil
+}
+
+// sectionColumns selects a kyc_document_sections row (s) without its embedding
+const sectionColumns = `
+	s.id, s.document_code, COALESCE(s.section_number, '') AS section_number,
+	COALESCE(s.section_title, '') AS section_title, s.text_excerpt,
+	COALESCE(s.page_number, 0) AS page_number, s.created_at
+`
+
+// sectionContextColumns selects a document_section_context row (c)
+const sectionContextColumns = `
+	c.section_id, COALESCE(c.section_number, '') AS section_number,
+	COALESCE(c.section_title, '') AS section_title, c.text_excerpt,
+	COALESCE(c.page_number, 0) AS page_number, c.document_code, c.document_title,
+	COALESCE(c.jurisdiction, '') AS jurisdiction, COALESCE(c.doc_type, '') AS doc_type,
+	COALESCE(c.regulation_code, '') AS regulation_code, COALESCE(c.regulation_title, '') AS regulation_title,
+	COALESCE(c.evidence_id, 0) AS evidence_id, COALESCE(c.case_name, '') AS case_name
+`
+
+// SearchDocumentSections performs semantic search on the sections of
+// regulatory documents
 func (r *EnhancementsRepo) SearchDocumentSections(ctx context.Context, vec []float32, limit int) ([]model.DocumentSectionSearchResult, error) {
 	query := `
-		SELECT
-			id, document_code, section_number, section_title, text_excerpt, page_number,
-			embedding, created_at,
-			1 - (embedding <=> $1::vector) as similarity_score,
-			embedding <=> $1::vector as distance
-		FROM kyc_document_sections
-		WHERE embedding IS NOT NULL
-		ORDER BY embedding <=> $1::vector
+		SELECT ` + sectionColumns + `,
+			1 - (s.embedding <=> $1::vector) as similarity_score,
+			s.embedding <=> $1::vector as distance
+		FROM kyc_document_sections s
+		WHERE s.embedding IS NOT NULL AND s.evidence_id IS NULL
+		ORDER BY s.embedding <=> $1::vector
 		LIMIT $2
 	`
 
 	var results []model.DocumentSectionSearchResult
+	start := time.Now()
 	err := r.db.SelectContext(ctx, &results, query, pq.Array(vec), limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search document sections: %w", err)
 	}
+	metrics.ObserveVectorSearch("document_section", start, len(results))
 
 	return results, nil
 }
 
-// GetSectionsByDocument retrieves all sections for a document
+// SearchSectionsWithContext performs semantic search on document sections and
+// returns each hit with its document and regulation. Without a case name it
+// searches regulatory text; with one, the text extracted from the case's
+// uploaded evidence.
+func (r *EnhancementsRepo) SearchSectionsWithContext(ctx context.Context, vec []float32, limit int, caseName string) ([]model.SectionContextSearchResult, error) {
+	query := `
+		SELECT ` + sectionContextColumns + `,
+			1 - (s.embedding <=> $1::vector) as similarity_score,
+			s.embedding <=> $1::vector as distance
+		FROM kyc_document_sections s
+		JOIN document_section_context c ON c.section_id = s.id
+		WHERE s.embedding IS NOT NULL
+		  AND (($3 = '' AND s.evidence_id IS NULL) OR ($3 <> '' AND s.case_name = $3))
+		ORDER BY s.embedding <=> $1::vector
+		LIMIT $2
+	`
+
+	var results []model.SectionContextSearchResult
+	start := time.Now()
+	err := r.db.SelectContext(ctx, &results, query, pq.Array(vec), limit, caseName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search document sections: %w", err)
+	}
+	metrics.ObserveVectorSearch("document_section", start, len(results))
+
+	return results, nil
+}
+
+// GetSectionsByDocument retrieves all sections for a regulatory document
 func (r *EnhancementsRepo) GetSectionsByDocument(ctx context.Context, documentCode string) ([]model.DocumentSection, error) {
 	query := `
-		SELECT
-			id, document_code, section_number, section_title, text_excerpt,
-			page_number, embedding, created_at
-		FROM kyc_document_sections
-		WHERE document_code = $1
-		ORDER BY section_number, page_number
+		SELECT ` + sectionColumns + `
+		FROM kyc_document_sections s
+		WHERE s.document_code = $1 AND s.evidence_id IS NULL
+		ORDER BY s.section_number, s.page_number
 	`
 
 	var sections []model.DocumentSection
@@ -191,12 +280,9 @@ func (r *EnhancementsRepo) GetSectionsByDocument(ctx context.Context, documentCo
 // GetSectionContext retrieves section with full document and regulation context
 func (r *EnhancementsRepo) GetSectionContext(ctx context.Context, sectionID int) (*model.DocumentSectionContext, error) {
 	query := `
-		SELECT
-			section_id, section_number, section_title, text_excerpt, page_number,
-			document_code, document_title, jurisdiction, doc_type,
-			regulation_code, regulation_title
-		FROM document_section_context
-		WHERE section_id = $1
+		SELECT ` + sectionContextColumns + `
+		FROM document_section_context c
+		WHERE c.section_id = $1
 	`
 
 	var context model.DocumentSectionContext
@@ -208,6 +294,25 @@ func (r *EnhancementsRepo) GetSectionContext(ctx context.Context, sectionID int)
 	return &context, nil
 }
 
+// GetSectionContextsByDocument retrieves the sections of a regulatory
+// document, each with the document and regulation context
+func (r *EnhancementsRepo) GetSectionContextsByDocument(ctx context.Context, documentCode string) ([]model.DocumentSectionContext, error) {
+	query := `
+		SELECT ` + sectionContextColumns + `
+		FROM document_section_context c
+		WHERE c.document_code = $1 AND c.evidence_id IS NULL
+		ORDER BY c.section_number, c.page_number
+	`
+
+	sections := []model.DocumentSectionContext{}
+	err := r.db.SelectContext(ctx, &sections, query, documentCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sections for document %s: %w", documentCode, err)
+	}
+
+	return sections, nil
+}
+
 // CountSections returns total count of document sections
 func (r *EnhancementsRepo) CountSections(ctx context.Context) (int, error) {
 	var count int
@@ -306,10 +411,12 @@ func (r *EnhancementsRepo) SearchWithinCluster(ctx context.Context, clusterCode
 	`
 
 	var results []model.AttributeSearchResult
+	start := time.Now()
 	err = r.db.SelectContext(ctx, &results, query, pq.Array(vec), pq.Array(cluster.MemberAttributeCodes), limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search within cluster: %w", err)
 	}
+	metrics.ObserveVectorSearch("cluster", start, len(results))
 
 	return results, nil
 }
@@ -374,7 +481,7 @@ func (r *EnhancementsRepo) ComputeClusterCentroid(ctx context.Context, clusterCo
 	query := `
 		UPDATE rag_clusters
 		SET centroid = (
-			SELECT AVG(embedding)::vector(1536)
+			SELECT AVG(embedding)
 			FROM kyc_attribute_metadata
 			WHERE attribute_code = ANY(rag_clusters.member_attribute_codes)
 			  AND embedding IS NOT NULL
@@ -396,7 +503,7 @@ func (r *EnhancementsRepo) ComputeAllClusterCentroids(ctx context.Context) (int,
 	query := `
 		UPDATE rag_clusters
 		SET centroid = (
-			SELECT AVG(embedding)::vector(1536)
+			SELECT AVG(embedding)
 			FROM kyc_attribute_metadata
 			WHERE attribute_code = ANY(rag_clusters.member_attribute_codes)
 			  AND embedding IS NOT NULL
@@ -416,20 +523,29 @@ func (r *EnhancementsRepo) ComputeAllClusterCentroids(ctx context.Context) (int,
 
 // ==================== Enhancement E: RAG Audit Trail ====================
 
-// LogQuery records a RAG query in the audit log
-func (r *EnhancementsRepo) LogQuery(ctx context.Context, log model.RAGAuditLog) (int, error) {
-	query := `
-		INSERT INTO rag_audit_log
-			(query_text, query_embedding, response, result_count, agent_name,
-			 session_id, endpoint, latency_ms, error_message, ip_address, user_agent)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-		RETURNING id
-	`
+// auditColumns are the rag_audit_log columns written by LogQuery and
+// LogQueries, in the order of auditArgs
+const auditColumns = `(query_text, query_embedding, response, result_count, agent_name,
+	 session_id, endpoint, latency_ms, error_message, ip_address, user_agent,
+	 query_embedding_reduced, query_embedding_hash, embedding_storage)`
 
-	var id int
-	err := r.db.QueryRowContext(ctx, query,
+// auditPlaceholders returns the VALUES tuple of the nth logged query
+func auditPlaceholders(n int) string {
+	p := make([]string, 14)
+	for i := range p {
+		p[i] = fmt.Sprintf("$%d", n*14+i+1)
+	}
+	p[12] = "NULLIF(" + p[12] + ", '')"
+	return "(" + strings.Join(p, ", ") + ")"
+}
+
+// auditArgs returns the values of a logged query, keeping its embedding as
+// audit_log.embedding_storage says
+func (r *EnhancementsRepo) auditArgs(log model.RAGAuditLog) []interface{} {
+	e := encodeAuditEmbedding(log.QueryEmbedding, r.audit)
+	return []interface{}{
 		log.QueryText,
-		pq.Array(log.QueryEmbedding),
+		nullFloatArray(e.full),
 		log.Response,
 		log.ResultCount,
 		nullString(log.AgentName),
@@ -439,15 +555,45 @@ func (r *EnhancementsRepo) LogQuery(ctx context.Context, log model.RAGAuditLog)
 		nullString(log.ErrorMessage),
 		nullString(log.IPAddress),
 		nullString(log.UserAgent),
-	).Scan(&id)
+		nullFloatArray(e.reduced),
+		e.hash,
+		e.storage,
+	}
+}
 
-	if err != nil {
+// LogQuery records a RAG query in the audit log. The query embedding is
+// kept as audit_log.embedding_storage says: whole, reduced, as a hash or not
+// at all.
+func (r *EnhancementsRepo) LogQuery(ctx context.Context, log model.RAGAuditLog) (int, error) {
+	query := `INSERT INTO rag_audit_log ` + auditColumns + ` VALUES ` + auditPlaceholders(0) + ` RETURNING id`
+
+	var id int
+	if err := r.db.QueryRowContext(ctx, query, r.auditArgs(log)...).Scan(&id); err != nil {
 		return 0, fmt.Errorf("failed to log query: %w", err)
 	}
 
 	return id, nil
 }
 
+// LogQueries records a batch of RAG queries in the audit log with one
+// insert
+func (r *EnhancementsRepo) LogQueries(ctx context.Context, logs []model.RAGAuditLog) error {
+	if len(logs) == 0 {
+		return nil
+	}
+	values := make([]string, len(logs))
+	args := make([]interface{}, 0, 14*len(logs))
+	for i, log := range logs {
+		values[i] = auditPlaceholders(i)
+		args = append(args, r.auditArgs(log)...)
+	}
+	query := `INSERT INTO rag_audit_log ` + auditColumns + ` VALUES ` + strings.Join(values, ", ")
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to log %d queries: %w", len(logs), err)
+	}
+	return nil
+}
+
 // LogQueryWithJSON is a convenience method that accepts an interface{} for response
 func (r *EnhancementsRepo) LogQueryWithJSON(ctx context.Context, queryText string, response interface{}, agentName string, latencyMs int, endpoint string) (int, error) {
 	// Marshal response to JSON string
@@ -638,6 +784,21 @@ func (r *EnhancementsRepo) GetAuditStats(ctx context.Context) (map[string]interf
 	}
 	stats["unique_agents"] = uniqueAgents
 
+	// How query embeddings are kept (audit_log.embedding_storage)
+	var storage []struct {
+		Storage string `db:"embedding_storage"`
+		Count   int    `db:"count"`
+	}
+	err = r.db.SelectContext(ctx, &storage, "SELECT embedding_storage, COUNT(*) AS count FROM rag_audit_log GROUP BY embedding_storage")
+	if err != nil {
+		return nil, err
+	}
+	byStorage := make(map[string]int, len(storage))
+	for _, s := range storage {
+		byStorage[s.Storage] = s.Count
+	}
+	stats["embedding_storage"] = byStorage
+
 	return stats, nil
 }
 
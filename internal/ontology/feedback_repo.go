@@ -23,8 +23,8 @@ func (r *FeedbackRepo) InsertFeedback(f model.Feedback) (int, error) {
 	query := `
 		INSERT INTO rag_feedback
 			(query_text, attribute_code, document_code, regulation_code,
-			 feedback, confidence, agent_name, agent_type)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			 feedback, confidence, agent_name, agent_type, tenant, query_cluster)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, COALESCE(NULLIF($9, ''), 'default'), $10)
 		RETURNING id`
 
 	var id int
@@ -37,6 +37,8 @@ func (r *FeedbackRepo) InsertFeedback(f model.Feedback) (int, error) {
 		f.Confidence,
 		f.AgentName,
 		f.AgentType,
+		f.Tenant,
+		f.QueryCluster,
 	).Scan(&id)
 
 	if err != nil {
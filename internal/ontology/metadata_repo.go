@@ -4,10 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
 
+	"github.com/adamtc007/KYC-DSL/internal/actor"
+	"github.com/adamtc007/KYC-DSL/internal/metrics"
 	"github.com/adamtc007/KYC-DSL/internal/model"
 )
 
@@ -21,13 +24,24 @@ func NewMetadataRepo(db *sqlx.DB) *MetadataRepo {
 	return &MetadataRepo{db: db}
 }
 
-// UpsertMetadata inserts or updates attribute metadata with embedding
+// UpsertMetadata inserts or updates attribute metadata with embedding, which
+// is assumed to be generated from the metadata's embedding text. The change
+// is attributed to the actor of ctx.
 func (r *MetadataRepo) UpsertMetadata(ctx context.Context, m model.AttributeMetadata) error {
+	var contentHash *string
+	if m.Embedding != nil {
+		h := ContentHash(m.ToEmbeddingText())
+		contentHash = &h
+	}
+
 	query := `
 		INSERT INTO kyc_attribute_metadata
 			(attribute_code, synonyms, data_type, domain_values, risk_level,
-			 example_values, regulatory_citations, business_context, embedding)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			 example_values, regulatory_citations, business_context, embedding, embedding_content_hash,
+			 embedding_model, updated_by, actor_source, client_ip)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
+			CASE WHEN $10::text IS NOT NULL THEN (` + primaryModel + `) END,
+			$11, $12, NULLIF($13, ''))
 		ON CONFLICT (attribute_code)
 		DO UPDATE SET
 			synonyms = EXCLUDED.synonyms,
@@ -38,10 +52,16 @@ func (r *MetadataRepo) UpsertMetadata(ctx context.Context, m model.AttributeMeta
 			regulatory_citations = EXCLUDED.regulatory_citations,
 			business_context = EXCLUDED.business_context,
 			embedding = EXCLUDED.embedding,
+			embedding_content_hash = EXCLUDED.embedding_content_hash,
+			embedding_model = EXCLUDED.embedding_model,
+			updated_by = EXCLUDED.updated_by,
+			actor_source = EXCLUDED.actor_source,
+			client_ip = EXCLUDED.client_ip,
 			updated_at = NOW()
 		RETURNING id
 	`
 
+	who := actor.FromContext(ctx)
 	var id int
 	err := r.db.QueryRowContext(ctx, query,
 		m.AttributeCode,
@@ -53,6 +73,10 @@ func (r *MetadataRepo) UpsertMetadata(ctx context.Context, m model.AttributeMeta
 		pq.Array(m.RegulatoryCitations),
 		m.BusinessContext,
 		pq.Array(m.Embedding),
+		contentHash,
+		who.Name,
+		who.Source,
+		who.ClientIP,
 	).Scan(&id)
 
 	if err != nil {
@@ -62,13 +86,16 @@ func (r *MetadataRepo) UpsertMetadata(ctx context.Context, m model.AttributeMeta
 	return nil
 }
 
-// GetMetadata retrieves metadata for a specific attribute
+// GetMetadata retrieves metadata for a specific attribute, with its
+// embedding even when archived to the cold tier
 func (r *MetadataRepo) GetMetadata(ctx context.Context, attributeCode string) (*model.AttributeMetadata, error) {
 	query := `
-		SELECT id, attribute_code, synonyms, data_type, domain_values, risk_level,
-		       example_values, regulatory_citations, business_context, embedding, created_at
-		FROM kyc_attribute_metadata
-		WHERE attribute_code = $1
+		SELECT m.id, m.attribute_code, m.synonyms, m.data_type, m.domain_values, m.risk_level,
+		       m.example_values, m.regulatory_citations, m.business_context,
+		       COALESCE(m.embedding, c.embedding) AS embedding, m.created_at
+		FROM kyc_attribute_metadata m
+		LEFT JOIN kyc_attribute_embeddings_cold c ON c.attribute_code = m.attribute_code
+		WHERE m.attribute_code = $1
 	`
 
 	var m model.AttributeMetadata
@@ -116,14 +143,57 @@ func (r *MetadataRepo) SearchByVector(ctx context.Context, vec []float32, limit
 	`
 
 	var results []model.AttributeSearchResult
+	start := time.Now()
 	err := r.db.SelectContext(ctx, &results, query, pq.Array(vec), limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search by vector: %w", err)
 	}
+	metrics.ObserveVectorSearch("attribute", start, len(results))
 
 	return results, nil
 }
 
+// StreamByVector performs the search of SearchByVector, passing each result
+// to fn as it is read instead of collecting them, closest first. Embeddings
+// are not loaded. An error from fn stops the scan and is returned.
+func (r *MetadataRepo) StreamByVector(ctx context.Context, vec []float32, limit int, fn func(model.AttributeSearchResult) error) error {
+	query := `
+		SELECT
+			id, attribute_code, synonyms, data_type, domain_values, risk_level,
+			example_values, regulatory_citations, business_context, created_at,
+			1 - (embedding <=> $1::vector) as similarity_score,
+			embedding <=> $1::vector as distance
+		FROM kyc_attribute_metadata
+		WHERE embedding IS NOT NULL
+		ORDER BY embedding <=> $1::vector
+		LIMIT $2
+	`
+
+	start := time.Now()
+	rows, err := r.db.QueryxContext(ctx, query, pq.Array(vec), limit)
+	if err != nil {
+		return fmt.Errorf("failed to search by vector: %w", err)
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		var result model.AttributeSearchResult
+		if err := rows.StructScan(&result); err != nil {
+			return fmt.Errorf("failed to scan search result: %w", err)
+		}
+		if err := fn(result); err != nil {
+			return err
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to search by vector: %w", err)
+	}
+	metrics.ObserveVectorSearch("attribute", start, count)
+	return nil
+}
+
 // SearchByText searches for attributes by synonym or keyword
 func (r *MetadataRepo) SearchByText(ctx context.Context, searchTerm string) ([]model.AttributeMetadata, error) {
 	query := `
@@ -148,14 +218,15 @@ func (r *MetadataRepo) SearchByText(ctx context.Context, searchTerm string) ([]m
 	return results, nil
 }
 
-// GetAttributesWithoutEmbeddings returns attributes that don't have embeddings yet
+// GetAttributesWithoutEmbeddings returns attributes that don't have embeddings
+// yet; archived (cold) embeddings are not missing
 func (r *MetadataRepo) GetAttributesWithoutEmbeddings(ctx context.Context) ([]model.AttributeMetadata, error) {
 	query := `
 		SELECT
 			id, attribute_code, synonyms, data_type, domain_values, risk_level,
 			example_values, regulatory_citations, business_context, embedding, created_at
 		FROM kyc_attribute_metadata
-		WHERE embedding IS NULL
+		WHERE embedding IS NULL AND embedding_tier = 'hot'
 		ORDER BY attribute_code
 	`
 
@@ -215,10 +286,12 @@ func (r *MetadataRepo) FindSimilarAttributes(ctx context.Context, attributeCode
 	`
 
 	var results []model.AttributeSearchResult
+	start := time.Now()
 	err = r.db.SelectContext(ctx, &results, query, pq.Array(metadata.Embedding), attributeCode, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find similar attributes: %w", err)
 	}
+	metrics.ObserveVectorSearch("similar_attribute", start, len(results))
 
 	return results, nil
 }
@@ -0,0 +1,265 @@
+package ontology
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/adamtc007/KYC-DSL/internal/actor"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+// MetadataReviewRepo stores proposed metadata changes and reviewer decisions
+type MetadataReviewRepo struct {
+	db *sqlx.DB
+}
+
+// NewMetadataReviewRepo creates a new metadata review repository
+func NewMetadataReviewRepo(db *sqlx.DB) *MetadataReviewRepo {
+	return &MetadataReviewRepo{db: db}
+}
+
+// proposalRow is the database shape of a proposal (proposed fields as JSON text)
+type proposalRow struct {
+	model.MetadataProposal
+	ProposedJSON string `db:"proposed_json"`
+}
+
+const proposalColumns = `
+	id, batch_id, attribute_code, source, proposed::text AS proposed_json,
+	status, COALESCE(submitted_by, '') AS submitted_by, created_at
+`
+
+// CreateProposal queues a proposed metadata change for review
+func (r *MetadataReviewRepo) CreateProposal(ctx context.Context, p model.MetadataProposal) (int, error) {
+	proposed, err := json.Marshal(p.Proposed)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal proposed fields: %w", err)
+	}
+
+	query := `
+		INSERT INTO kyc_metadata_proposals (batch_id, attribute_code, source, proposed, submitted_by)
+		VALUES ($1, $2, $3, $4::jsonb, $5)
+		RETURNING id
+	`
+
+	var id int
+	err = r.db.QueryRowContext(ctx, query,
+		p.BatchID, p.AttributeCode, p.Source, string(proposed), nullString(p.SubmittedBy),
+	).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create metadata proposal for %s: %w", p.AttributeCode, err)
+	}
+
+	return id, nil
+}
+
+// ListPendingProposals returns proposals awaiting review, oldest first
+func (r *MetadataReviewRepo) ListPendingProposals(ctx context.Context, batchID string, limit int) ([]model.MetadataProposal, error) {
+	query := `SELECT ` + proposalColumns + `
+		FROM kyc_metadata_proposals
+		WHERE status = 'pending'
+		  AND ($1 = '' OR batch_id = $1)
+		ORDER BY created_at, id
+		LIMIT $2
+	`
+
+	var rows []proposalRow
+	if err := r.db.SelectContext(ctx, &rows, query, batchID, limit); err != nil {
+		return nil, fmt.Errorf("failed to list pending proposals: %w", err)
+	}
+
+	results := make([]model.MetadataProposal, 0, len(rows))
+	for _, row := range rows {
+		p, err := row.decode()
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, p)
+	}
+	return results, nil
+}
+
+// GetProposal retrieves a single proposal
+func (r *MetadataReviewRepo) GetProposal(ctx context.Context, id int) (*model.MetadataProposal, error) {
+	query := `SELECT ` + proposalColumns + ` FROM kyc_metadata_proposals WHERE id = $1`
+
+	var row proposalRow
+	err := r.db.GetContext(ctx, &row, query, id)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("metadata proposal not found: %d", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metadata proposal: %w", err)
+	}
+
+	p, err := row.decode()
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// GetDecidedFields returns the fields of a proposal that already have a decision
+func (r *MetadataReviewRepo) GetDecidedFields(ctx context.Context, proposalID int) ([]string, error) {
+	var fields []string
+	err := r.db.SelectContext(ctx, &fields,
+		`SELECT field_name FROM kyc_metadata_field_decisions WHERE proposal_id = $1`, proposalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get decided fields: %w", err)
+	}
+	return fields, nil
+}
+
+// ListBatches returns review progress per batch
+func (r *MetadataReviewRepo) ListBatches(ctx context.Context) ([]model.MetadataReviewBatch, error) {
+	query := `
+		SELECT batch_id, source, proposal_count, pending_count, applied_count, rejected_count, submitted_at
+		FROM metadata_review_batches
+	`
+
+	var results []model.MetadataReviewBatch
+	if err := r.db.SelectContext(ctx, &results, query); err != nil {
+		return nil, fmt.Errorf("failed to list review batches: %w", err)
+	}
+	return results, nil
+}
+
+// ApplyDecisions records per-field decisions and, in the same transaction,
+// writes the accepted fields to kyc_attribute_metadata. diffs are the
+// still-undecided changes of the proposal. updated is the
+// metadata with accepted fields applied (nil when nothing was accepted);
+// its embedding replaces the stored one only when non-nil.
+func (r *MetadataReviewRepo) ApplyDecisions(ctx context.Context, proposalID int, diffs []model.FieldDiff,
+	decisions []model.FieldDecision, reviewer string, updated *model.AttributeMetadata) (model.MetadataProposalStatus, error) {
+
+	diffByField := make(map[string]model.FieldDiff, len(diffs))
+	for _, d := range diffs {
+		diffByField[d.Field] = d
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	accepted, rejected := 0, 0
+	for _, dec := range decisions {
+		diff, ok := diffByField[dec.Field]
+		if !ok {
+			return "", fmt.Errorf("field %s has no pending change in proposal %d", dec.Field, proposalID)
+		}
+
+		oldJSON, _ := json.Marshal(diff.OldValue)
+		newJSON, _ := json.Marshal(diff.NewValue)
+		decision := "rejected"
+		if dec.Accept {
+			decision = "accepted"
+			accepted++
+		} else {
+			rejected++
+		}
+
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO kyc_metadata_field_decisions
+				(proposal_id, field_name, old_value, new_value, decision, reviewer, comment)
+			VALUES ($1, $2, $3::jsonb, $4::jsonb, $5, $6, $7)
+		`, proposalID, dec.Field, string(oldJSON), string(newJSON), decision, reviewer, nullString(dec.Comment))
+		if err != nil {
+			return "", fmt.Errorf("failed to record decision for %s: %w", dec.Field, err)
+		}
+	}
+
+	if updated != nil && accepted > 0 {
+		who := actor.FromContext(ctx)
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO kyc_attribute_metadata
+				(attribute_code, synonyms, data_type, domain_values, risk_level,
+				 example_values, regulatory_citations, business_context, embedding, updated_by,
+				 actor_source, client_ip)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9::vector, $10, $11, NULLIF($12, ''))
+			ON CONFLICT (attribute_code)
+			DO UPDATE SET
+				synonyms = EXCLUDED.synonyms,
+				data_type = EXCLUDED.data_type,
+				domain_values = EXCLUDED.domain_values,
+				risk_level = EXCLUDED.risk_level,
+				example_values = EXCLUDED.example_values,
+				regulatory_citations = EXCLUDED.regulatory_citations,
+				business_context = EXCLUDED.business_context,
+				embedding = COALESCE(EXCLUDED.embedding, kyc_attribute_metadata.embedding),
+				updated_by = EXCLUDED.updated_by,
+				actor_source = EXCLUDED.actor_source,
+				client_ip = EXCLUDED.client_ip,
+				updated_at = NOW()
+		`,
+			updated.AttributeCode,
+			pq.Array(updated.Synonyms),
+			updated.DataType,
+			pq.Array(updated.DomainValues),
+			updated.RiskLevel,
+			pq.Array(updated.ExampleValues),
+			pq.Array(updated.RegulatoryCitations),
+			updated.BusinessContext,
+			pq.Array(updated.Embedding),
+			reviewer,
+			who.Source,
+			who.ClientIP,
+		)
+		if err != nil {
+			return "", fmt.Errorf("failed to apply accepted fields for %s: %w", updated.AttributeCode, err)
+		}
+	}
+
+	// Fields without a decision keep the proposal pending; otherwise the
+	// status reflects every decision made on the proposal so far
+	status := model.ProposalPending
+	if accepted+rejected >= len(diffs) {
+		var totalAccepted, totalRejected int
+		err := tx.QueryRowContext(ctx, `
+			SELECT COUNT(*) FILTER (WHERE decision = 'accepted'),
+			       COUNT(*) FILTER (WHERE decision = 'rejected')
+			FROM kyc_metadata_field_decisions
+			WHERE proposal_id = $1
+		`, proposalID).Scan(&totalAccepted, &totalRejected)
+		if err != nil {
+			return "", fmt.Errorf("failed to count decisions: %w", err)
+		}
+
+		switch {
+		case totalAccepted == 0:
+			status = model.ProposalRejected
+		case totalRejected == 0:
+			status = model.ProposalApplied
+		default:
+			status = model.ProposalPartiallyApplied
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE kyc_metadata_proposals
+		SET status = $2, reviewed_at = CASE WHEN $2 = 'pending' THEN NULL ELSE NOW() END
+		WHERE id = $1
+	`, proposalID, status)
+	if err != nil {
+		return "", fmt.Errorf("failed to update proposal status: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("failed to commit review decisions: %w", err)
+	}
+	return status, nil
+}
+
+func (row proposalRow) decode() (model.MetadataProposal, error) {
+	p := row.MetadataProposal
+	if err := json.Unmarshal([]byte(row.ProposedJSON), &p.Proposed); err != nil {
+		return p, fmt.Errorf("failed to decode proposal %d: %w", row.ID, err)
+	}
+	return p, nil
+}
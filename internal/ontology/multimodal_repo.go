@@ -3,10 +3,12 @@ package ontology
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
 
+	"github.com/adamtc007/KYC-DSL/internal/metrics"
 	"github.com/adamtc007/KYC-DSL/internal/model"
 )
 
@@ -34,10 +36,12 @@ func (r *MultiModalRepo) SearchAttributesAndDocs(ctx context.Context, vec []floa
 	`
 
 	var attrs []model.AttributeMetadata
+	start := time.Now()
 	err := r.db.SelectContext(ctx, &attrs, query, pq.Array(vec), limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search attributes: %w", err)
 	}
+	metrics.ObserveVectorSearch("multimodal", start, len(attrs))
 
 	results := make([]model.MultiModalResult, 0, len(attrs))
 
@@ -123,10 +127,12 @@ func (r *MultiModalRepo) SearchDocuments(ctx context.Context, vec []float32, lim
 	`
 
 	var results []model.DocumentSearchResult
+	start := time.Now()
 	err := r.db.SelectContext(ctx, &results, query, pq.Array(vec), limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search documents: %w", err)
 	}
+	metrics.ObserveVectorSearch("document", start, len(results))
 
 	return results, nil
 }
@@ -151,10 +157,12 @@ func (r *MultiModalRepo) SearchRegulations(ctx context.Context, vec []float32, l
 	`
 
 	var results []model.RegulationSearchResult
+	start := time.Now()
 	err := r.db.SelectContext(ctx, &results, query, pq.Array(vec), limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search regulations: %w", err)
 	}
+	metrics.ObserveVectorSearch("regulation", start, len(results))
 
 	return results, nil
 }
@@ -210,6 +218,55 @@ func (r *MultiModalRepo) GetRegulationsByAttribute(ctx context.Context, attribut
 	return regs, nil
 }
 
+// ListDocuments returns up to limit documents by code, starting after the
+// given code (from the first document when empty)
+func (r *MultiModalRepo) ListDocuments(ctx context.Context, after string, limit int) ([]model.Document, error) {
+	query := `
+		SELECT
+			id, code, name,
+			COALESCE(title, name) as title,
+			domain, jurisdiction,
+			COALESCE(doc_type, '') as doc_type,
+			COALESCE(description, '') as description,
+			created_at
+		FROM kyc_documents
+		WHERE ($1 = '' OR code > $1)
+		ORDER BY code
+		LIMIT $2
+	`
+
+	var docs []model.Document
+	if err := r.db.SelectContext(ctx, &docs, query, after, limit); err != nil {
+		return nil, fmt.Errorf("failed to list documents: %w", err)
+	}
+	return docs, nil
+}
+
+// ListRegulations returns up to limit regulations by code, starting after
+// the given code (from the first regulation when empty)
+func (r *MultiModalRepo) ListRegulations(ctx context.Context, after string, limit int) ([]model.Regulation, error) {
+	query := `
+		SELECT
+			id, code, name,
+			COALESCE(title, name) as title,
+			COALESCE(region, jurisdiction) as region,
+			jurisdiction, authority,
+			COALESCE(citation, '') as citation,
+			COALESCE(summary, description) as summary,
+			description, created_at
+		FROM kyc_regulations
+		WHERE ($1 = '' OR code > $1)
+		ORDER BY code
+		LIMIT $2
+	`
+
+	var regs []model.Regulation
+	if err := r.db.SelectContext(ctx, &regs, query, after, limit); err != nil {
+		return nil, fmt.Errorf("failed to list regulations: %w", err)
+	}
+	return regs, nil
+}
+
 // GetAttributesByDocument retrieves all attributes linked to a document
 func (r *MultiModalRepo) GetAttributesByDocument(ctx context.Context, documentCode string) ([]model.AttributeMetadata, error) {
 	query := `
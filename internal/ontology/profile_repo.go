@@ -0,0 +1,83 @@
+package ontology
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+// ProfileRepo loads the parts of an attribute profile not covered by the
+// metadata, multi-modal and usage repositories
+type ProfileRepo struct {
+	db *sqlx.DB
+}
+
+// NewProfileRepo creates a new profile repository
+func NewProfileRepo(db *sqlx.DB) *ProfileRepo {
+	return &ProfileRepo{db: db}
+}
+
+// GetDerivationLinks returns every derivation the attribute takes part in,
+// either as the derived attribute or as a source
+func (r *ProfileRepo) GetDerivationLinks(ctx context.Context, attributeCode string) ([]model.AttributeDerivationLink, error) {
+	query := `
+		SELECT derived_attribute_code, source_attribute_code, rule_expression
+		FROM kyc_attribute_derivations
+		WHERE derived_attribute_code = $1 OR source_attribute_code = $1
+		ORDER BY derived_attribute_code, source_attribute_code
+	`
+
+	var links []model.AttributeDerivationLink
+	if err := r.db.SelectContext(ctx, &links, query, attributeCode); err != nil {
+		return nil, fmt.Errorf("failed to get derivations for attribute %s: %w", attributeCode, err)
+	}
+	return links, nil
+}
+
+// GetClusterMemberships returns the curated and semantic clusters containing the attribute
+func (r *ProfileRepo) GetClusterMemberships(ctx context.Context, attributeCode string) ([]model.ClusterMembership, error) {
+	query := `
+		SELECT 'curated' AS source, cluster_code, cluster_name,
+		       COALESCE(description, '') AS description,
+		       COALESCE(array_length(attribute_codes, 1), 0) AS member_count
+		FROM kyc_attribute_clusters
+		WHERE $1 = ANY(attribute_codes)
+		UNION ALL
+		SELECT 'semantic', cluster_code, cluster_name,
+		       COALESCE(description, ''),
+		       COALESCE(member_count, 0)
+		FROM rag_clusters
+		WHERE $1 = ANY(member_attribute_codes)
+		ORDER BY source, cluster_code
+	`
+
+	var clusters []model.ClusterMembership
+	if err := r.db.SelectContext(ctx, &clusters, query, attributeCode); err != nil {
+		return nil, fmt.Errorf("failed to get clusters for attribute %s: %w", attributeCode, err)
+	}
+	return clusters, nil
+}
+
+// GetFeedbackStats totals the feedback recorded against the attribute
+func (r *ProfileRepo) GetFeedbackStats(ctx context.Context, attributeCode string) (*model.AttributeFeedbackStats, error) {
+	query := `
+		SELECT
+			COUNT(*) AS total,
+			COUNT(*) FILTER (WHERE feedback::text = 'positive') AS positive,
+			COUNT(*) FILTER (WHERE feedback::text = 'negative') AS negative,
+			COUNT(*) FILTER (WHERE feedback::text = 'neutral') AS neutral,
+			COALESCE(AVG(confidence), 0) AS avg_confidence,
+			MAX(created_at) AS last_feedback
+		FROM rag_feedback
+		WHERE attribute_code = $1
+	`
+
+	var stats model.AttributeFeedbackStats
+	if err := r.db.GetContext(ctx, &stats, query, attributeCode); err != nil {
+		return nil, fmt.Errorf("failed to get feedback stats for attribute %s: %w", attributeCode, err)
+	}
+	return &stats, nil
+}
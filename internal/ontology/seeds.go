@@ -0,0 +1,56 @@
+package ontology
+
+import (
+	"context"
+	"embed"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+//go:embed seeds/*.sql
+var seedFiles embed.FS
+
+// Seed is a bundled seed script with the table whose rows it provides
+type Seed struct {
+	File  string
+	Table string
+}
+
+// Seeds are the bundled ontology seed scripts in dependency order
+var Seeds = []Seed{
+	{File: "ontology_seed.sql", Table: "kyc_regulations"},
+	{File: "derived_attributes_seed.sql", Table: "kyc_attribute_derivations"},
+	{File: "attribute_metadata_seed.sql", Table: "kyc_attribute_metadata"},
+}
+
+// ApplySeed runs a seed script unless its table already has rows; the
+// scripts are plain inserts and cannot be applied twice. It reports whether
+// the script ran.
+func ApplySeed(ctx context.Context, db *sqlx.DB, s Seed) (bool, error) {
+	var populated bool
+	if err := db.GetContext(ctx, &populated, `SELECT EXISTS (SELECT 1 FROM `+s.Table+`)`); err != nil {
+		return false, fmt.Errorf("failed to check %s: %w", s.Table, err)
+	}
+	if populated {
+		return false, nil
+	}
+
+	script, err := seedFiles.ReadFile("seeds/" + s.File)
+	if err != nil {
+		return false, fmt.Errorf("failed to read seed %s: %w", s.File, err)
+	}
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if _, err := tx.ExecContext(ctx, string(script)); err != nil {
+		return false, fmt.Errorf("failed to apply seed %s: %w", s.File, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit seed %s: %w", s.File, err)
+	}
+	return true, nil
+}
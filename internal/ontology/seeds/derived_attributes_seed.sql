@@ -35,22 +35,22 @@ VALUES
 -- ==================== Derivation Rules ====================
 
 -- HIGH_RISK_JURISDICTION_FLAG
--- Rule: TRUE if TaxResidencyCountry is in high-risk list
+-- Rule: TRUE if TaxResidencyCountry is in the HIGH_RISK_JURISDICTIONS managed list
 INSERT INTO kyc_attribute_derivations (derived_attribute_code, source_attribute_code, rule_expression, jurisdiction, regulation_code)
 VALUES
 ('HIGH_RISK_JURISDICTION_FLAG', 'TAX_RESIDENCY_COUNTRY',
- '(if (in TaxResidencyCountry [''IR'' ''KP'' ''SY'' ''YE'' ''AF'' ''MM'']) true false)',
+ 'in_list(TAX_RESIDENCY_COUNTRY, "HIGH_RISK_JURISDICTIONS")',
  'GLOBAL', 'AMLD5');
 
 -- SANCTIONED_COUNTRY_FLAG
--- Rule: TRUE if any jurisdiction field matches sanctioned countries
+-- Rule: TRUE if any jurisdiction field is in the SANCTIONED_COUNTRIES managed list
 INSERT INTO kyc_attribute_derivations (derived_attribute_code, source_attribute_code, rule_expression, jurisdiction, regulation_code)
 VALUES
 ('SANCTIONED_COUNTRY_FLAG', 'TAX_RESIDENCY_COUNTRY',
- '(if (in TaxResidencyCountry [''IR'' ''KP'' ''SY'' ''CU'' ''RU'']) true false)',
+ 'in_list(TAX_RESIDENCY_COUNTRY, "SANCTIONED_COUNTRIES") || in_list(INCORPORATION_JURISDICTION, "SANCTIONED_COUNTRIES")',
  'GLOBAL', 'BSAAML'),
 ('SANCTIONED_COUNTRY_FLAG', 'INCORPORATION_JURISDICTION',
- '(if (in IncorporationJurisdiction [''IR'' ''KP'' ''SY'' ''CU'' ''RU'']) true false)',
+ 'in_list(TAX_RESIDENCY_COUNTRY, "SANCTIONED_COUNTRIES") || in_list(INCORPORATION_JURISDICTION, "SANCTIONED_COUNTRIES")',
  'GLOBAL', 'BSAAML');
 
 -- PEP_EXPOSURE_FLAG
@@ -62,6 +62,9 @@ VALUES
 ('POA', 'Power of Attorney', 'Control', 'GLOBAL', 'AMLD5', 'Official', 3, 'Legal power of attorney document'),
 ('DIRECTOR-LIST', 'List of Directors', 'Control', 'GLOBAL', 'AMLD5', 'Official', 1, 'Official list of company directors');
 
+-- Proof of address is accepted for 90 days from its issue date
+UPDATE kyc_documents SET validity_days = 90 WHERE code IN ('UTILITY-BILL', 'BANK-STATEMENT', 'COUNCIL-TAX-BILL');
+
 -- ==================== Attributes ====================
 
 INSERT INTO kyc_attributes (code, name, domain, description, risk_category, is_personal_data)
@@ -0,0 +1,91 @@
+package ontology
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+// ShadowRepo persists divergences found by shadow (canary) execution
+type ShadowRepo struct {
+	db *sqlx.DB
+}
+
+// NewShadowRepo creates a new shadow divergence repository
+func NewShadowRepo(db *sqlx.DB) *ShadowRepo {
+	return &ShadowRepo{db: db}
+}
+
+// RecordDivergence stores a single divergence between primary and candidate
+func (r *ShadowRepo) RecordDivergence(ctx context.Context, d model.ShadowDivergence) (int, error) {
+	query := `
+		INSERT INTO shadow_divergences
+			(experiment, kind, subject, primary_version, candidate_version,
+			 primary_result, candidate_result, divergence_score, details)
+		VALUES ($1, $2, $3, $4, $5, $6::jsonb, $7::jsonb, $8, $9::jsonb)
+		RETURNING id
+	`
+
+	var id int
+	err := r.db.QueryRowContext(ctx, query,
+		d.Experiment,
+		d.Kind,
+		d.Subject,
+		d.PrimaryVersion,
+		d.CandidateVersion,
+		nullString(d.PrimaryResult),
+		nullString(d.CandidateResult),
+		d.DivergenceScore,
+		nullString(d.Details),
+	).Scan(&id)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to record shadow divergence: %w", err)
+	}
+
+	return id, nil
+}
+
+// ListDivergences returns the most recent divergences, optionally filtered by experiment
+func (r *ShadowRepo) ListDivergences(ctx context.Context, experiment string, limit int) ([]model.ShadowDivergence, error) {
+	query := `
+		SELECT id, experiment, kind, subject, primary_version, candidate_version,
+		       COALESCE(primary_result::text, '') AS primary_result,
+		       COALESCE(candidate_result::text, '') AS candidate_result,
+		       divergence_score,
+		       COALESCE(details::text, '') AS details,
+		       created_at
+		FROM shadow_divergences
+		WHERE ($1 = '' OR experiment = $1)
+		ORDER BY created_at DESC
+		LIMIT $2
+	`
+
+	var results []model.ShadowDivergence
+	err := r.db.SelectContext(ctx, &results, query, experiment, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list shadow divergences: %w", err)
+	}
+
+	return results, nil
+}
+
+// GetExperimentSummaries returns divergence rollups for every experiment
+func (r *ShadowRepo) GetExperimentSummaries(ctx context.Context) ([]model.ShadowExperimentSummary, error) {
+	query := `
+		SELECT experiment, kind, primary_version, candidate_version,
+		       divergence_count, avg_divergence, max_divergence, first_seen, last_seen
+		FROM shadow_experiment_summary
+	`
+
+	var results []model.ShadowExperimentSummary
+	err := r.db.SelectContext(ctx, &results, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shadow experiment summaries: %w", err)
+	}
+
+	return results, nil
+}
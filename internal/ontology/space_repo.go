@@ -0,0 +1,490 @@
+package ontology
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/adamtc007/KYC-DSL/internal/metrics"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+// primaryModel is the model of the primary embedding space, recorded with
+// every embedding written to the ontology tables
+const primaryModel = `SELECT model FROM embedding_spaces WHERE is_primary`
+
+// MaxIndexedDimensions is the largest vector pgvector can index
+const MaxIndexedDimensions = 2000
+
+var (
+	// ErrSpaceNotFound is returned for an unknown embedding space
+	ErrSpaceNotFound = errors.New("embedding space not found")
+	// ErrSpaceIncomplete is returned when promoting a space that lacks
+	// current embeddings for some ontology entries
+	ErrSpaceIncomplete = errors.New("embedding space is incomplete")
+
+	spaceName = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+)
+
+// spaceTables are the ontology tables of the primary space with the code
+// column and the vector index of each
+var spaceTables = map[string]struct{ table, code, index string }{
+	"attributes":  {"kyc_attribute_metadata", "attribute_code", "idx_attribute_metadata_embedding"},
+	"documents":   {"kyc_documents", "code", "idx_documents_embedding"},
+	"regulations": {"kyc_regulations", "code", "idx_regulations_embedding"},
+}
+
+// SpaceRepo manages embedding spaces and the embeddings of secondary spaces
+type SpaceRepo struct {
+	db *sqlx.DB
+}
+
+// NewSpaceRepo creates a new embedding space repository
+func NewSpaceRepo(db *sqlx.DB) *SpaceRepo {
+	return &SpaceRepo{db: db}
+}
+
+const spaceColumns = `name, model, dimensions, is_primary, COALESCE(description, '') AS description,
+	created_at, promoted_at`
+
+// List returns every space, primary first, with its embedding counts
+func (r *SpaceRepo) List(ctx context.Context) ([]model.EmbeddingSpace, error) {
+	var spaces []model.EmbeddingSpace
+	query := `SELECT ` + spaceColumns + ` FROM embedding_spaces ORDER BY is_primary DESC, name`
+	if err := r.db.SelectContext(ctx, &spaces, query); err != nil {
+		return nil, fmt.Errorf("failed to list embedding spaces: %w", err)
+	}
+
+	var counts []struct {
+		Space string `db:"space"`
+		Kind  string `db:"kind"`
+		Count int    `db:"count"`
+	}
+	err := r.db.SelectContext(ctx, &counts, `
+		SELECT space, kind, COUNT(*) AS count FROM ontology_embeddings GROUP BY space, kind`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count space embeddings: %w", err)
+	}
+	backfill := NewBackfillRepo(r.db)
+	for i := range spaces {
+		spaces[i].Embedded = map[string]int{}
+		if !spaces[i].IsPrimary {
+			continue
+		}
+		for _, kind := range BackfillKinds {
+			_, embedded, err := backfill.Coverage(ctx, kind)
+			if err != nil {
+				return nil, err
+			}
+			spaces[i].Embedded[kind] = embedded
+		}
+	}
+	for _, c := range counts {
+		for i := range spaces {
+			if spaces[i].Name == c.Space {
+				spaces[i].Embedded[c.Kind] = c.Count
+			}
+		}
+	}
+	return spaces, nil
+}
+
+// Get returns a space by name
+func (r *SpaceRepo) Get(ctx context.Context, name string) (*model.EmbeddingSpace, error) {
+	var s model.EmbeddingSpace
+	err := r.db.GetContext(ctx, &s, `SELECT `+spaceColumns+` FROM embedding_spaces WHERE name = $1`, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ErrSpaceNotFound, name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get embedding space %s: %w", name, err)
+	}
+	return &s, nil
+}
+
+// Primary returns the space stored in the ontology tables
+func (r *SpaceRepo) Primary(ctx context.Context) (*model.EmbeddingSpace, error) {
+	var s model.EmbeddingSpace
+	err := r.db.GetContext(ctx, &s, `SELECT `+spaceColumns+` FROM embedding_spaces WHERE is_primary`)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: no primary space", ErrSpaceNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get primary embedding space: %w", err)
+	}
+	return &s, nil
+}
+
+// Create registers a secondary space with a vector index for its dimension
+func (r *SpaceRepo) Create(ctx context.Context, s model.EmbeddingSpace) error {
+	if !spaceName.MatchString(s.Name) {
+		return fmt.Errorf("invalid space name %q: use lower-case letters, digits and _", s.Name)
+	}
+	if s.Model == "" || s.Dimensions <= 0 {
+		return fmt.Errorf("space %s needs a model and a positive dimension", s.Name)
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO embedding_spaces (name, model, dimensions, description)
+		VALUES ($1, $2, $3, NULLIF($4, ''))`, s.Name, s.Model, s.Dimensions, s.Description)
+	if err != nil {
+		return fmt.Errorf("failed to create embedding space %s: %w", s.Name, err)
+	}
+	if err := createSpaceIndex(ctx, tx, s.Name, s.Dimensions); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Drop removes a secondary space with its embeddings
+func (r *SpaceRepo) Drop(ctx context.Context, name string) error {
+	s, err := r.Get(ctx, name)
+	if err != nil {
+		return err
+	}
+	if s.IsPrimary {
+		return fmt.Errorf("cannot drop the primary embedding space %s; promote another space first", name)
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if _, err := tx.ExecContext(ctx, `DROP INDEX IF EXISTS `+spaceIndex(name)); err != nil {
+		return fmt.Errorf("failed to drop index of space %s: %w", name, err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM embedding_spaces WHERE name = $1`, name); err != nil {
+		return fmt.Errorf("failed to drop embedding space %s: %w", name, err)
+	}
+	return tx.Commit()
+}
+
+// SaveEmbedding stores the embedding of an entry in a secondary space
+func (r *SpaceRepo) SaveEmbedding(ctx context.Context, space, kind, code, contentHash string, embedding []float32) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO ontology_embeddings (space, kind, code, embedding, content_hash)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (space, kind, code) DO UPDATE SET
+			embedding = EXCLUDED.embedding,
+			content_hash = EXCLUDED.content_hash,
+			created_at = NOW()`,
+		space, kind, code, pq.Array(embedding), contentHash)
+	if err != nil {
+		return fmt.Errorf("failed to save %s embedding for %s: %w", space, code, err)
+	}
+	return nil
+}
+
+// ListPending returns the entries of kind a secondary space has no current
+// embedding for: never embedded, or embedded from text that has changed
+func (r *SpaceRepo) ListPending(ctx context.Context, space, kind string) ([]model.EmbeddingTarget, error) {
+	targets, err := NewBackfillRepo(r.db).ListAll(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		Code string `db:"code"`
+		Hash string `db:"content_hash"`
+	}
+	err = r.db.SelectContext(ctx, &rows, `
+		SELECT code, content_hash FROM ontology_embeddings WHERE space = $1 AND kind = $2`, space, kind)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s embeddings of %s: %w", space, kind, err)
+	}
+	hashes := make(map[string]string, len(rows))
+	for _, row := range rows {
+		hashes[row.Code] = row.Hash
+	}
+
+	pending := targets[:0]
+	for _, t := range targets {
+		if stored, ok := hashes[t.Code]; ok {
+			t.StoredHash = stored
+			if stored == ContentHash(t.Text) {
+				continue
+			}
+		} else {
+			t.StoredHash = ""
+		}
+		pending = append(pending, t)
+	}
+	return pending, nil
+}
+
+// SearchAttributes performs attribute semantic search in a secondary space
+func (r *SpaceRepo) SearchAttributes(ctx context.Context, space model.EmbeddingSpace, vec []float32, limit int) ([]model.AttributeSearchResult, error) {
+	// The cast matches the space's partial index expression
+	distance := fmt.Sprintf(`e.embedding::vector(%d) <=> $2::vector(%d)`, space.Dimensions, space.Dimensions)
+	query := `
+		SELECT
+			m.id, m.attribute_code, m.synonyms, m.data_type, m.domain_values, m.risk_level,
+			m.example_values, m.regulatory_citations, m.business_context, m.created_at,
+			1 - (` + distance + `) as similarity_score,
+			` + distance + ` as distance
+		FROM ontology_embeddings e
+		JOIN kyc_attribute_metadata m ON m.attribute_code = e.code
+		WHERE e.space = $1 AND e.kind = 'attributes'
+		ORDER BY ` + distance + `
+		LIMIT $3
+	`
+
+	var results []model.AttributeSearchResult
+	start := time.Now()
+	if err := r.db.SelectContext(ctx, &results, query, space.Name, pq.Array(vec), limit); err != nil {
+		return nil, fmt.Errorf("failed to search space %s: %w", space.Name, err)
+	}
+	metrics.ObserveVectorSearch("attribute", start, len(results))
+	return results, nil
+}
+
+// Promote makes a fully embedded secondary space the primary one: its
+// embeddings move into the ontology tables and the previous primary
+// embeddings are kept as a secondary space, so promoting it back restores
+// them. When the dimension changes, the embedding columns of the ontology,
+// document sections, cluster centroids and audit log are resized. Section
+// embeddings of the previous model are cleared (re-ingest the documents) and
+// cluster centroids are recomputed from the new embeddings.
+func (r *SpaceRepo) Promote(ctx context.Context, name string) (*model.EmbeddingPromotion, error) {
+	target, err := r.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if target.IsPrimary {
+		return nil, fmt.Errorf("embedding space %s is already primary", name)
+	}
+	current, err := r.Primary(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, kind := range BackfillKinds {
+		pending, err := r.ListPending(ctx, name, kind)
+		if err != nil {
+			return nil, err
+		}
+		if len(pending) > 0 {
+			return nil, fmt.Errorf("%w: %s lacks current embeddings for %d %s; run `kycctl embeddings sync %s`",
+				ErrSpaceIncomplete, name, len(pending), kind, name)
+		}
+	}
+
+	result := &model.EmbeddingPromotion{
+		From:       current.Name,
+		To:         name,
+		Model:      target.Model,
+		Dimensions: target.Dimensions,
+		Resized:    target.Dimensions != current.Dimensions,
+		Unindexed:  target.Dimensions > MaxIndexedDimensions,
+	}
+	modelChanged := result.Resized || target.Model != current.Model
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	// Archived attribute embeddings belong to the current model: bring them
+	// back so they are kept with the rest and replaced by the new space's
+	_, err = tx.ExecContext(ctx, `
+		WITH promoted AS (
+			DELETE FROM kyc_attribute_embeddings_cold RETURNING attribute_code, embedding
+		)
+		UPDATE kyc_attribute_metadata m
+		SET embedding = COALESCE(m.embedding, p.embedding), embedding_tier = 'hot'
+		FROM promoted p
+		WHERE m.attribute_code = p.attribute_code`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore archived attribute embeddings: %w", err)
+	}
+
+	// Keep the current primary embeddings as a secondary space
+	for _, kind := range BackfillKinds {
+		t := spaceTables[kind]
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO ontology_embeddings (space, kind, code, embedding, content_hash)
+			SELECT $1, $2, `+t.code+`, embedding, COALESCE(embedding_content_hash, '')
+			FROM `+t.table+`
+			WHERE embedding IS NOT NULL
+			ON CONFLICT (space, kind, code) DO UPDATE SET
+				embedding = EXCLUDED.embedding,
+				content_hash = EXCLUDED.content_hash,
+				created_at = NOW()`, current.Name, kind)
+		if err != nil {
+			return nil, fmt.Errorf("failed to keep %s embeddings of %s: %w", kind, current.Name, err)
+		}
+	}
+	if err := createSpaceIndex(ctx, tx, current.Name, current.Dimensions); err != nil {
+		return nil, err
+	}
+
+	if modelChanged {
+		err := tx.GetContext(ctx, &result.SectionsCleared,
+			`SELECT COUNT(*) FROM kyc_document_sections WHERE embedding IS NOT NULL`)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count section embeddings: %w", err)
+		}
+	}
+	if result.Resized {
+		if err := resizeEmbeddingColumns(ctx, tx, target.Dimensions); err != nil {
+			return nil, err
+		}
+	} else if modelChanged {
+		if _, err := tx.ExecContext(ctx, `UPDATE kyc_document_sections SET embedding = NULL`); err != nil {
+			return nil, fmt.Errorf("failed to clear section embeddings: %w", err)
+		}
+		// Overlay attributes are re-embedded with kycctl overlay embed
+		if _, err := tx.ExecContext(ctx, `UPDATE kyc_overlay_attributes SET embedding = NULL`); err != nil {
+			return nil, fmt.Errorf("failed to clear overlay attribute embeddings: %w", err)
+		}
+	}
+
+	for _, kind := range BackfillKinds {
+		t := spaceTables[kind]
+		res, err := tx.ExecContext(ctx, `
+			UPDATE `+t.table+` o
+			SET embedding = e.embedding, embedding_content_hash = e.content_hash, embedding_model = $3
+			FROM ontology_embeddings e
+			WHERE e.space = $1 AND e.kind = $2 AND e.code = o.`+t.code, name, kind, target.Model)
+		if err != nil {
+			return nil, fmt.Errorf("failed to move %s embeddings of %s: %w", kind, name, err)
+		}
+		n, _ := res.RowsAffected()
+		result.Embeddings += int(n)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE rag_clusters
+		SET centroid = (
+			SELECT AVG(embedding)
+			FROM kyc_attribute_metadata
+			WHERE attribute_code = ANY(rag_clusters.member_attribute_codes)
+			  AND embedding IS NOT NULL
+		),
+		last_computed = NOW()
+		WHERE member_attribute_codes IS NOT NULL`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recompute cluster centroids: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM ontology_embeddings WHERE space = $1`, name); err != nil {
+		return nil, fmt.Errorf("failed to clear secondary embeddings of %s: %w", name, err)
+	}
+	if _, err := tx.ExecContext(ctx, `DROP INDEX IF EXISTS `+spaceIndex(name)); err != nil {
+		return nil, fmt.Errorf("failed to drop index of space %s: %w", name, err)
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE embedding_spaces SET is_primary = false WHERE is_primary`); err != nil {
+		return nil, fmt.Errorf("failed to demote %s: %w", current.Name, err)
+	}
+	_, err = tx.ExecContext(ctx, `UPDATE embedding_spaces SET is_primary = true, promoted_at = NOW() WHERE name = $1`, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to promote %s: %w", name, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit promotion of %s: %w", name, err)
+	}
+	return result, nil
+}
+
+func spaceIndex(name string) string {
+	return "idx_ontology_embeddings_" + name
+}
+
+// createSpaceIndex indexes the embeddings of one space on the expression
+// SearchAttributes orders by; larger vectors are searched sequentially
+func createSpaceIndex(ctx context.Context, tx *sqlx.Tx, name string, dimensions int) error {
+	if dimensions > MaxIndexedDimensions {
+		return nil
+	}
+	_, err := tx.ExecContext(ctx, fmt.Sprintf(`
+		CREATE INDEX IF NOT EXISTS %s ON ontology_embeddings
+		USING hnsw ((embedding::vector(%d)) vector_cosine_ops)
+		WHERE space = '%s'`, spaceIndex(name), dimensions, name))
+	if err != nil {
+		return fmt.Errorf("failed to index embedding space %s: %w", name, err)
+	}
+	return nil
+}
+
+// resizeEmbeddingColumns changes the dimension of every column compared with
+// query embeddings, dropping their values; views on them are recreated
+func resizeEmbeddingColumns(ctx context.Context, tx *sqlx.Tx, dimensions int) error {
+	columns := []struct{ table, column, index string }{
+		{"kyc_attribute_metadata", "embedding", "idx_attribute_metadata_embedding"},
+		{"kyc_documents", "embedding", "idx_documents_embedding"},
+		{"kyc_regulations", "embedding", "idx_regulations_embedding"},
+		{"kyc_document_sections", "embedding", "idx_doc_sections_embedding"},
+		{"rag_clusters", "centroid", "idx_clusters_centroid"},
+		{"rag_audit_log", "query_embedding", ""},
+		{"kyc_overlay_attributes", "embedding", ""},
+	}
+
+	var views []struct {
+		Name       string `db:"name"`
+		Definition string `db:"definition"`
+	}
+	err := tx.SelectContext(ctx, &views, `
+		SELECT DISTINCT v.oid::regclass::text AS name, pg_get_viewdef(v.oid) AS definition
+		FROM pg_depend d
+		JOIN pg_rewrite rw ON rw.oid = d.objid
+		JOIN pg_class v ON v.oid = rw.ev_class AND v.relkind = 'v'
+		JOIN pg_attribute a ON a.attrelid = d.refobjid AND a.attnum = d.refobjsubid
+		WHERE d.refobjid::regclass::text = ANY($1)
+		  AND a.attname IN ('embedding', 'centroid', 'query_embedding')`,
+		pq.Array([]string{"kyc_attribute_metadata", "kyc_documents", "kyc_regulations",
+			"kyc_document_sections", "rag_clusters", "rag_audit_log", "kyc_overlay_attributes"}))
+	if err != nil {
+		return fmt.Errorf("failed to find views on embedding columns: %w", err)
+	}
+	for _, v := range views {
+		if _, err := tx.ExecContext(ctx, `DROP VIEW `+v.Name); err != nil {
+			return fmt.Errorf("failed to drop view %s: %w", v.Name, err)
+		}
+	}
+
+	for _, c := range columns {
+		if c.index != "" {
+			if _, err := tx.ExecContext(ctx, `DROP INDEX IF EXISTS `+c.index); err != nil {
+				return fmt.Errorf("failed to drop index %s: %w", c.index, err)
+			}
+		}
+		_, err := tx.ExecContext(ctx, fmt.Sprintf(`ALTER TABLE %s ALTER COLUMN %s TYPE vector(%d) USING NULL`,
+			c.table, c.column, dimensions))
+		if err != nil {
+			return fmt.Errorf("failed to resize %s.%s: %w", c.table, c.column, err)
+		}
+		if c.index != "" && dimensions <= MaxIndexedDimensions {
+			lists := 100
+			if c.table == "rag_clusters" {
+				lists = 50
+			}
+			_, err := tx.ExecContext(ctx, fmt.Sprintf(`CREATE INDEX %s ON %s USING ivfflat (%s vector_cosine_ops) WITH (lists = %d)`,
+				c.index, c.table, c.column, lists))
+			if err != nil {
+				return fmt.Errorf("failed to recreate index %s: %w", c.index, err)
+			}
+		}
+	}
+
+	for _, v := range views {
+		if _, err := tx.ExecContext(ctx, `CREATE VIEW `+v.Name+` AS `+v.Definition); err != nil {
+			return fmt.Errorf("failed to recreate view %s: %w", v.Name, err)
+		}
+	}
+	return nil
+}
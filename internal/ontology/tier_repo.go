@@ -0,0 +1,208 @@
+package ontology
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+// Embedding tiers of attribute metadata (kyc_attribute_metadata.embedding_tier).
+// Hot embeddings are stored with the metadata and searched through the ANN
+// index; cold ones are archived to kyc_attribute_embeddings_cold, outside
+// vector search but still returned by GetMetadata.
+const (
+	EmbeddingTierHot  = "hot"
+	EmbeddingTierCold = "cold"
+)
+
+// referencedAttributes lists the attributes the latest version of a case references
+const referencedAttributes = `
+	SELECT DISTINCT term_code FROM ontology_case_references WHERE term_type = 'attribute'`
+
+// TierRepo moves attribute embeddings between the hot and cold tiers
+type TierRepo struct {
+	db *sqlx.DB
+}
+
+// NewTierRepo creates a new embedding tier repository
+func NewTierRepo(db *sqlx.DB) *TierRepo {
+	return &TierRepo{db: db}
+}
+
+// Stats counts attribute embeddings per tier
+func (r *TierRepo) Stats(ctx context.Context) (*model.EmbeddingTierStats, error) {
+	var stats model.EmbeddingTierStats
+	err := r.db.GetContext(ctx, &stats, `
+		SELECT
+			COUNT(*) FILTER (WHERE embedding_tier = 'hot' AND embedding IS NOT NULL) AS hot,
+			COUNT(*) FILTER (WHERE embedding_tier = 'cold') AS cold,
+			COUNT(*) FILTER (WHERE embedding_tier = 'hot' AND embedding IS NULL) AS missing,
+			(SELECT MIN(archived_at) FROM kyc_attribute_embeddings_cold) AS oldest_archive
+		FROM kyc_attribute_metadata`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count embedding tiers: %w", err)
+	}
+	return &stats, nil
+}
+
+// ListCold returns the archived attributes with the search hits they have
+// had since archiving, most recently archived first
+func (r *TierRepo) ListCold(ctx context.Context, limit int) ([]model.ColdEmbedding, error) {
+	var cold []model.ColdEmbedding
+	err := r.db.SelectContext(ctx, &cold, `
+		SELECT c.attribute_code, c.last_used_at, c.archived_at,
+		       (SELECT COUNT(*) FROM kyc_ontology_search_hits h
+		        WHERE h.term_type = 'attribute' AND h.term_code = c.attribute_code
+		          AND h.created_at > c.archived_at) AS hits_since_archive
+		FROM kyc_attribute_embeddings_cold c
+		ORDER BY c.archived_at DESC, c.attribute_code
+		LIMIT $1`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cold embeddings: %w", err)
+	}
+	return cold, nil
+}
+
+// ArchiveUnused archives the embeddings of up to limit attributes that no
+// case references and no search has returned within idleFor (attributes
+// never searched count from their creation). It returns the archived codes.
+func (r *TierRepo) ArchiveUnused(ctx context.Context, idleFor time.Duration, limit int) ([]string, error) {
+	return r.archive(ctx, `
+		SELECT m.attribute_code, s.last_searched AS last_used_at
+		FROM kyc_attribute_metadata m
+		LEFT JOIN (
+			SELECT term_code, MAX(created_at) AS last_searched
+			FROM kyc_ontology_search_hits
+			WHERE term_type = 'attribute'
+			GROUP BY term_code
+		) s ON s.term_code = m.attribute_code
+		WHERE m.embedding_tier = 'hot' AND m.embedding IS NOT NULL
+		  AND COALESCE(s.last_searched, m.created_at) < NOW() - make_interval(secs => $1)
+		  AND m.attribute_code NOT IN (`+referencedAttributes+`)
+		ORDER BY COALESCE(s.last_searched, m.created_at), m.attribute_code
+		LIMIT $2`, idleFor.Seconds(), limit)
+}
+
+// Archive archives the embeddings of the given attributes whatever their
+// usage. Attributes without an embedding or already cold are skipped.
+func (r *TierRepo) Archive(ctx context.Context, codes []string) ([]string, error) {
+	return r.archive(ctx, `
+		SELECT m.attribute_code, s.last_searched AS last_used_at
+		FROM kyc_attribute_metadata m
+		LEFT JOIN (
+			SELECT term_code, MAX(created_at) AS last_searched
+			FROM kyc_ontology_search_hits
+			WHERE term_type = 'attribute' AND term_code = ANY($1)
+			GROUP BY term_code
+		) s ON s.term_code = m.attribute_code
+		WHERE m.attribute_code = ANY($1)`, pq.Array(codes))
+}
+
+// archive moves the embeddings of the candidates (attribute_code,
+// last_used_at) to the cold table and clears them from the metadata, so the
+// ANN index no longer holds them. Content hash and model stay with the
+// metadata.
+func (r *TierRepo) archive(ctx context.Context, candidates string, args ...interface{}) ([]string, error) {
+	var codes []string
+	err := r.db.SelectContext(ctx, &codes, `
+		WITH candidates AS (`+candidates+`),
+		moved AS (
+			INSERT INTO kyc_attribute_embeddings_cold (attribute_code, embedding, last_used_at)
+			SELECT m.attribute_code, m.embedding, c.last_used_at
+			FROM kyc_attribute_metadata m
+			JOIN candidates c ON c.attribute_code = m.attribute_code
+			WHERE m.embedding_tier = 'hot' AND m.embedding IS NOT NULL
+			ON CONFLICT (attribute_code) DO UPDATE SET
+				embedding = EXCLUDED.embedding,
+				last_used_at = EXCLUDED.last_used_at,
+				archived_at = NOW()
+			RETURNING attribute_code
+		)
+		UPDATE kyc_attribute_metadata m
+		SET embedding = NULL, embedding_tier = 'cold'
+		FROM moved
+		WHERE m.attribute_code = moved.attribute_code
+		RETURNING m.attribute_code`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to archive attribute embeddings: %w", err)
+	}
+	return codes, nil
+}
+
+// PromoteUsed brings back to the hot tier the archived embeddings of
+// attributes searched at least minHits times since archiving or referenced
+// by a case, and of attributes that have been re-embedded while cold. It
+// returns the promoted codes.
+func (r *TierRepo) PromoteUsed(ctx context.Context, minHits int) ([]string, error) {
+	return r.promote(ctx, `
+		m.embedding IS NOT NULL
+		OR c.attribute_code IN (`+referencedAttributes+`)
+		OR (SELECT COUNT(*) FROM kyc_ontology_search_hits h
+		    WHERE h.term_type = 'attribute' AND h.term_code = c.attribute_code
+		      AND h.created_at > c.archived_at) >= $1`, minHits)
+}
+
+// Promote brings back to the hot tier the archived embeddings of the given
+// attributes, or of every archived attribute when codes is empty
+func (r *TierRepo) Promote(ctx context.Context, codes []string) ([]string, error) {
+	if len(codes) == 0 {
+		return r.promote(ctx, `TRUE`)
+	}
+	return r.promote(ctx, `c.attribute_code = ANY($1)`, pq.Array(codes))
+}
+
+// promote restores the cold embeddings matching condition (over cold table
+// c and metadata m) into the metadata and deletes them from the cold table.
+// An embedding written to the metadata while cold wins over the archived one.
+func (r *TierRepo) promote(ctx context.Context, condition string, args ...interface{}) ([]string, error) {
+	var codes []string
+	err := r.db.SelectContext(ctx, &codes, `
+		WITH promoted AS (
+			DELETE FROM kyc_attribute_embeddings_cold c
+			USING kyc_attribute_metadata m
+			WHERE m.attribute_code = c.attribute_code AND (`+condition+`)
+			RETURNING c.attribute_code, c.embedding
+		)
+		UPDATE kyc_attribute_metadata m
+		SET embedding = COALESCE(m.embedding, p.embedding), embedding_tier = 'hot'
+		FROM promoted p
+		WHERE m.attribute_code = p.attribute_code
+		RETURNING m.attribute_code`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to promote attribute embeddings: %w", err)
+	}
+	return codes, nil
+}
+
+// RunTiering promotes archived embeddings that are used again, then archives
+// those unused for cfg.ArchiveAfter, every cfg.Interval until ctx is cancelled
+func (r *TierRepo) RunTiering(ctx context.Context, cfg config.EmbeddingTierConfig) {
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		if codes, err := r.PromoteUsed(ctx, cfg.PromoteHits); err != nil {
+			slog.Warn("⚠️  Embedding promotion failed", "error", err)
+		} else if len(codes) > 0 {
+			slog.Info("🔥 Promoted attribute embeddings to the hot tier", "count", len(codes), "codes", codes)
+		}
+		if codes, err := r.ArchiveUnused(ctx, cfg.ArchiveAfter, cfg.BatchSize); err != nil {
+			slog.Warn("⚠️  Embedding archiving failed", "error", err)
+		} else if len(codes) > 0 {
+			slog.Info("🧊 Archived unused attribute embeddings", "count", len(codes), "after", cfg.ArchiveAfter)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
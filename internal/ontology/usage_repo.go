@@ -0,0 +1,135 @@
+package ontology
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+// UsageRepo tracks and reports how ontology terms are used by cases and agents
+type UsageRepo struct {
+	db *sqlx.DB
+}
+
+// NewUsageRepo creates a new ontology usage repository
+func NewUsageRepo(db *sqlx.DB) *UsageRepo {
+	return &UsageRepo{db: db}
+}
+
+const usageColumns = `
+	term_type, term_code, name, case_count, search_hits, search_hits_30d,
+	distinct_agents, last_searched, metadata_updated_at, missing_embedding
+`
+
+// RecordSearchHits stores the terms returned by one search response
+func (r *UsageRepo) RecordSearchHits(ctx context.Context, hits []model.SearchHit) error {
+	if len(hits) == 0 {
+		return nil
+	}
+
+	_, err := r.db.NamedExecContext(ctx, `
+		INSERT INTO kyc_ontology_search_hits
+			(term_type, term_code, endpoint, agent_name, query_text, rank)
+		VALUES (:term_type, :term_code, :endpoint, :agent_name, :query_text, :rank)
+	`, hits)
+	if err != nil {
+		return fmt.Errorf("failed to record search hits: %w", err)
+	}
+
+	return nil
+}
+
+// ListUsage returns per-term usage ordered by activity, optionally filtered by term type
+func (r *UsageRepo) ListUsage(ctx context.Context, termType model.OntologyTermType, limit int) ([]model.TermUsage, error) {
+	query := `SELECT` + usageColumns + `
+		FROM ontology_usage_report
+		WHERE ($1 = '' OR term_type = $1)
+		ORDER BY case_count + search_hits_30d DESC, term_type, term_code
+		LIMIT $2
+	`
+
+	var results []model.TermUsage
+	err := r.db.SelectContext(ctx, &results, query, termType, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ontology usage: %w", err)
+	}
+
+	return results, nil
+}
+
+// GetTermUsage returns usage for a single ontology term
+func (r *UsageRepo) GetTermUsage(ctx context.Context, termType model.OntologyTermType, code string) (*model.TermUsage, error) {
+	query := `SELECT` + usageColumns + `
+		FROM ontology_usage_report
+		WHERE term_type = $1 AND term_code = $2
+	`
+
+	var usage model.TermUsage
+	err := r.db.GetContext(ctx, &usage, query, termType, code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get usage for %s %s: %w", termType, code, err)
+	}
+
+	return &usage, nil
+}
+
+// GetUsageReport builds the cleanup/refresh report.
+// Hot spots are the most referenced terms; dead entries have no case references
+// and no search hits; refresh priorities are searched attributes whose embedding
+// is missing or whose metadata has not been updated within staleAfter.
+func (r *UsageRepo) GetUsageReport(ctx context.Context, limit int, staleAfter time.Duration) (*model.UsageReport, error) {
+	report := &model.UsageReport{GeneratedAt: time.Now()}
+
+	err := r.db.SelectContext(ctx, &report.Summary, `
+		SELECT
+			term_type,
+			COUNT(*) AS total,
+			COUNT(*) FILTER (WHERE case_count > 0) AS referenced,
+			COUNT(*) FILTER (WHERE search_hits > 0) AS searched,
+			COUNT(*) FILTER (WHERE case_count = 0 AND search_hits = 0) AS dead
+		FROM ontology_usage_report
+		GROUP BY term_type
+		ORDER BY term_type
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarise ontology usage: %w", err)
+	}
+
+	err = r.db.SelectContext(ctx, &report.HotSpots, `SELECT`+usageColumns+`
+		FROM ontology_usage_report
+		WHERE case_count > 0 OR search_hits_30d > 0
+		ORDER BY case_count + search_hits_30d DESC, term_code
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get usage hot spots: %w", err)
+	}
+
+	err = r.db.SelectContext(ctx, &report.DeadEntries, `SELECT`+usageColumns+`
+		FROM ontology_usage_report
+		WHERE case_count = 0 AND search_hits = 0
+		ORDER BY term_type, term_code
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dead ontology entries: %w", err)
+	}
+
+	err = r.db.SelectContext(ctx, &report.RefreshPriorities, `SELECT`+usageColumns+`
+		FROM ontology_usage_report
+		WHERE term_type = 'attribute'
+		  AND (case_count > 0 OR search_hits_30d > 0)
+		  AND (missing_embedding OR metadata_updated_at < NOW() - $2 * INTERVAL '1 second')
+		ORDER BY missing_embedding DESC, case_count + search_hits_30d DESC, term_code
+		LIMIT $1
+	`, limit, staleAfter.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get embedding refresh priorities: %w", err)
+	}
+
+	return report, nil
+}
@@ -0,0 +1,541 @@
+// Package overlay stores ontology overlays: the attributes, documents,
+// attribute-document links and derivation rules a tenant or business unit
+// adds to the global ontology without changing the shared tables.
+//
+// A Resolver answers ontology questions for one tenant, looking in its
+// overlay first and then in the global ontology, so an overlay entry with a
+// global code overrides it for that tenant only. Case validation (kycctl
+// validate), value capture (internal/casedata), the discovery amendments
+// (internal/amend) and RAG attribute search resolve through it: cases by
+// the tenant they belong to (kyc_cases.tenant), searches by the tenant of
+// the request. Re-evaluation (internal/reeval) evaluates the overlay's
+// derivation rules for its tenant's cases in place of the global rules of
+// the same derived attribute.
+package overlay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/adamtc007/KYC-DSL/internal/auth"
+	"github.com/adamtc007/KYC-DSL/internal/lineage"
+	"github.com/adamtc007/KYC-DSL/internal/lists"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+var (
+	// ErrInvalid is returned for overlay entries that cannot be saved
+	ErrInvalid = errors.New("invalid ontology overlay")
+	// ErrNotFound is returned when removing an entry the overlay does not have
+	ErrNotFound = errors.New("overlay entry not found")
+	// ErrCaseNotFound is returned when assigning an unknown case to a tenant
+	ErrCaseNotFound = errors.New("case not found")
+)
+
+// Kinds of overlay entry, as removed by Remove
+const (
+	KindAttribute  = "attribute"
+	KindDocument   = "document"
+	KindLink       = "link"
+	KindDerivation = "derivation"
+)
+
+var (
+	// validCode matches attribute and document codes
+	validCode = regexp.MustCompile(`^[A-Z][A-Z0-9_-]*$`)
+	// sourceTiers are the tiers of attribute-document links
+	sourceTiers = map[string]bool{"Primary": true, "Secondary": true, "Tertiary": true}
+	// ruleTypes are the types of derivation rules
+	ruleTypes = map[string]bool{"Boolean": true, "Numeric": true, "String": true, "Lookup": true}
+)
+
+// Repo stores ontology overlays
+type Repo struct {
+	db *sqlx.DB
+}
+
+// NewRepo creates a new overlay repository
+func NewRepo(db *sqlx.DB) *Repo {
+	return &Repo{db: db}
+}
+
+// attributeRow scans a kyc_overlay_attributes row
+type attributeRow struct {
+	Code            string         `db:"code"`
+	Name            string         `db:"name"`
+	Domain          string         `db:"domain"`
+	Description     string         `db:"description"`
+	RiskCategory    string         `db:"risk_category"`
+	IsPersonalData  bool           `db:"is_personal_data"`
+	AttributeClass  string         `db:"attribute_class"`
+	DataType        string         `db:"data_type"`
+	DomainValues    pq.StringArray `db:"domain_values"`
+	Synonyms        pq.StringArray `db:"synonyms"`
+	BusinessContext string         `db:"business_context"`
+	Overrides       bool           `db:"overrides"`
+	Embedded        bool           `db:"embedded"`
+	UpdatedBy       string         `db:"updated_by"`
+	UpdatedAt       time.Time      `db:"updated_at"`
+}
+
+// documentRow scans a kyc_overlay_documents row
+type documentRow struct {
+	Code           string    `db:"code"`
+	Name           string    `db:"name"`
+	Domain         string    `db:"domain"`
+	Jurisdiction   string    `db:"jurisdiction"`
+	RegulationCode string    `db:"regulation_code"`
+	Description    string    `db:"description"`
+	ValidityYears  int       `db:"validity_years"`
+	Overrides      bool      `db:"overrides"`
+	UpdatedBy      string    `db:"updated_by"`
+	UpdatedAt      time.Time `db:"updated_at"`
+}
+
+// Tenants returns the tenants that have an overlay, with the number of
+// entries of each
+func (r *Repo) Tenants(ctx context.Context) (map[string]int, error) {
+	var rows []struct {
+		Tenant  string `db:"tenant"`
+		Entries int    `db:"entries"`
+	}
+	err := r.db.SelectContext(ctx, &rows, `
+		SELECT tenant, COUNT(*) AS entries FROM (
+		    SELECT tenant FROM kyc_overlay_attributes
+		    UNION ALL SELECT tenant FROM kyc_overlay_documents
+		    UNION ALL SELECT tenant FROM kyc_overlay_attr_doc_links
+		    UNION ALL SELECT tenant FROM kyc_overlay_derivations
+		) o
+		GROUP BY tenant`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list overlay tenants: %w", err)
+	}
+	tenants := make(map[string]int, len(rows))
+	for _, row := range rows {
+		tenants[row.Tenant] = row.Entries
+	}
+	return tenants, nil
+}
+
+// Get returns the overlay of a tenant; a tenant without one has an empty
+// overlay
+func (r *Repo) Get(ctx context.Context, tenant string) (*model.OntologyOverlay, error) {
+	tenant = normalizeTenant(tenant)
+	o := &model.OntologyOverlay{
+		Tenant:      tenant,
+		Attributes:  []model.OverlayAttribute{},
+		Documents:   []model.OverlayDocument{},
+		Links:       []model.OverlayLink{},
+		Derivations: []model.OverlayDerivation{},
+	}
+
+	var attrs []attributeRow
+	if err := r.db.SelectContext(ctx, &attrs, `
+		SELECT o.code, o.name, COALESCE(o.domain, '') AS domain, COALESCE(o.description, '') AS description,
+		       COALESCE(o.risk_category, '') AS risk_category, o.is_personal_data, o.attribute_class,
+		       COALESCE(o.data_type, '') AS data_type, o.domain_values, o.synonyms,
+		       COALESCE(o.business_context, '') AS business_context,
+		       EXISTS (SELECT 1 FROM kyc_attributes a WHERE a.code = o.code) AS overrides,
+		       o.embedding IS NOT NULL AS embedded, COALESCE(o.updated_by, '') AS updated_by, o.updated_at
+		  FROM kyc_overlay_attributes o
+		 WHERE o.tenant = $1
+		 ORDER BY o.code`, tenant); err != nil {
+		return nil, fmt.Errorf("failed to load overlay attributes of %s: %w", tenant, err)
+	}
+	for _, a := range attrs {
+		updatedAt := a.UpdatedAt
+		o.Attributes = append(o.Attributes, model.OverlayAttribute{
+			Code: a.Code, Name: a.Name, Domain: a.Domain, Description: a.Description,
+			RiskCategory: a.RiskCategory, IsPersonalData: a.IsPersonalData, AttributeClass: a.AttributeClass,
+			DataType: a.DataType, DomainValues: a.DomainValues, Synonyms: a.Synonyms,
+			BusinessContext: a.BusinessContext, Overrides: a.Overrides, Embedded: a.Embedded,
+			UpdatedBy: a.UpdatedBy, UpdatedAt: &updatedAt,
+		})
+	}
+
+	var docs []documentRow
+	if err := r.db.SelectContext(ctx, &docs, `
+		SELECT o.code, o.name, COALESCE(o.domain, '') AS domain, COALESCE(o.jurisdiction, '') AS jurisdiction,
+		       COALESCE(o.regulation_code, '') AS regulation_code, COALESCE(o.description, '') AS description,
+		       COALESCE(o.validity_years, 0) AS validity_years,
+		       EXISTS (SELECT 1 FROM kyc_documents d WHERE d.code = o.code) AS overrides,
+		       COALESCE(o.updated_by, '') AS updated_by, o.updated_at
+		  FROM kyc_overlay_documents o
+		 WHERE o.tenant = $1
+		 ORDER BY o.code`, tenant); err != nil {
+		return nil, fmt.Errorf("failed to load overlay documents of %s: %w", tenant, err)
+	}
+	for _, d := range docs {
+		updatedAt := d.UpdatedAt
+		o.Documents = append(o.Documents, model.OverlayDocument{
+			Code: d.Code, Name: d.Name, Domain: d.Domain, Jurisdiction: d.Jurisdiction,
+			RegulationCode: d.RegulationCode, Description: d.Description, ValidityYears: d.ValidityYears,
+			Overrides: d.Overrides, UpdatedBy: d.UpdatedBy, UpdatedAt: &updatedAt,
+		})
+	}
+
+	if err := r.db.SelectContext(ctx, &o.Links, `
+		SELECT attribute_code, document_code, source_tier, is_mandatory,
+		       COALESCE(jurisdiction, '') AS jurisdiction, COALESCE(notes, '') AS notes
+		  FROM kyc_overlay_attr_doc_links
+		 WHERE tenant = $1
+		 ORDER BY attribute_code, source_tier, document_code`, tenant); err != nil {
+		return nil, fmt.Errorf("failed to load overlay links of %s: %w", tenant, err)
+	}
+	if err := r.db.SelectContext(ctx, &o.Derivations, `
+		SELECT derived_attribute_code, source_attribute_code, rule_expression, rule_type,
+		       COALESCE(description, '') AS description
+		  FROM kyc_overlay_derivations
+		 WHERE tenant = $1
+		 ORDER BY derived_attribute_code, source_attribute_code`, tenant); err != nil {
+		return nil, fmt.Errorf("failed to load overlay derivations of %s: %w", tenant, err)
+	}
+	return o, nil
+}
+
+// Apply adds the entries of an overlay to its tenant's overlay, replacing
+// entries with the same codes; entries it does not name are kept. Links
+// and derivations may name attributes and documents of the overlay itself
+// or of the global ontology, and derivation rules are type checked against
+// the attributes the tenant resolves. It returns the whole overlay.
+func (r *Repo) Apply(ctx context.Context, o model.OntologyOverlay, updatedBy string) (*model.OntologyOverlay, error) {
+	tenant := normalizeTenant(o.Tenant)
+	if err := normalize(&o); err != nil {
+		return nil, err
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	// Serialize changes to the same overlay
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext('kyc_overlay:' || $1))`, tenant); err != nil {
+		return nil, fmt.Errorf("failed to lock overlay of %s: %w", tenant, err)
+	}
+
+	for _, a := range o.Attributes {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO kyc_overlay_attributes (tenant, code, name, domain, description, risk_category,
+			    is_personal_data, attribute_class, data_type, domain_values, synonyms, business_context, updated_by)
+			VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, ''), $7, $8, NULLIF($9, ''), $10, $11,
+			        NULLIF($12, ''), NULLIF($13, ''))
+			ON CONFLICT (tenant, code) DO UPDATE SET
+			    name = EXCLUDED.name, domain = EXCLUDED.domain, description = EXCLUDED.description,
+			    risk_category = EXCLUDED.risk_category, is_personal_data = EXCLUDED.is_personal_data,
+			    attribute_class = EXCLUDED.attribute_class, data_type = EXCLUDED.data_type,
+			    domain_values = EXCLUDED.domain_values, synonyms = EXCLUDED.synonyms,
+			    business_context = EXCLUDED.business_context, updated_by = EXCLUDED.updated_by,
+			    updated_at = NOW(),
+			    -- changed text is embedded again
+			    embedding = CASE WHEN kyc_overlay_attributes.synonyms = EXCLUDED.synonyms
+			                      AND kyc_overlay_attributes.business_context IS NOT DISTINCT FROM EXCLUDED.business_context
+			                     THEN kyc_overlay_attributes.embedding END`,
+			tenant, a.Code, a.Name, a.Domain, a.Description, a.RiskCategory, a.IsPersonalData, a.AttributeClass,
+			a.DataType, pq.Array(nonNil(a.DomainValues)), pq.Array(nonNil(a.Synonyms)), a.BusinessContext,
+			updatedBy); err != nil {
+			return nil, fmt.Errorf("failed to save overlay attribute %s: %w", a.Code, err)
+		}
+	}
+	for _, d := range o.Documents {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO kyc_overlay_documents (tenant, code, name, domain, jurisdiction, regulation_code,
+			    description, validity_years, updated_by)
+			VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, ''), NULLIF($8, 0),
+			        NULLIF($9, ''))
+			ON CONFLICT (tenant, code) DO UPDATE SET
+			    name = EXCLUDED.name, domain = EXCLUDED.domain, jurisdiction = EXCLUDED.jurisdiction,
+			    regulation_code = EXCLUDED.regulation_code, description = EXCLUDED.description,
+			    validity_years = EXCLUDED.validity_years, updated_by = EXCLUDED.updated_by, updated_at = NOW()`,
+			tenant, d.Code, d.Name, d.Domain, d.Jurisdiction, d.RegulationCode, d.Description, d.ValidityYears,
+			updatedBy); err != nil {
+			return nil, fmt.Errorf("failed to save overlay document %s: %w", d.Code, err)
+		}
+	}
+
+	// Links and derivations are checked against the overlay as saved so far
+	res := &Resolver{db: r.db, q: tx, tenant: tenant}
+	if len(o.Links) > 0 {
+		attrs, err := res.attributeCodes(ctx)
+		if err != nil {
+			return nil, err
+		}
+		docs, err := res.DocumentCodes(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var unknown []string
+		for _, l := range o.Links {
+			if !attrs[l.AttributeCode] {
+				unknown = append(unknown, "attribute "+l.AttributeCode)
+			}
+			if !docs[l.DocumentCode] {
+				unknown = append(unknown, "document "+l.DocumentCode)
+			}
+		}
+		if len(unknown) > 0 {
+			return nil, fmt.Errorf("%w: links name unknown %s", ErrInvalid, strings.Join(dedupe(unknown), ", "))
+		}
+		for _, l := range o.Links {
+			if _, err := tx.ExecContext(ctx, `
+				INSERT INTO kyc_overlay_attr_doc_links (tenant, attribute_code, document_code, source_tier,
+				    is_mandatory, jurisdiction, notes)
+				VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), NULLIF($7, ''))
+				ON CONFLICT (tenant, attribute_code, document_code) DO UPDATE SET
+				    source_tier = EXCLUDED.source_tier, is_mandatory = EXCLUDED.is_mandatory,
+				    jurisdiction = EXCLUDED.jurisdiction, notes = EXCLUDED.notes`,
+				tenant, l.AttributeCode, l.DocumentCode, l.SourceTier, l.IsMandatory, l.Jurisdiction, l.Notes); err != nil {
+				return nil, fmt.Errorf("failed to save overlay link %s → %s: %w", l.AttributeCode, l.DocumentCode, err)
+			}
+		}
+	}
+
+	if len(o.Derivations) > 0 {
+		types, err := res.AttributeTypes(ctx)
+		if err != nil {
+			return nil, err
+		}
+		inEffect, err := lists.NewRepo(r.db).InEffect(ctx, time.Now().UTC())
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range o.Derivations {
+			derived, ok := types[d.DerivedAttributeCode]
+			switch {
+			case !ok:
+				return nil, fmt.Errorf("%w: unknown derived attribute %s", ErrInvalid, d.DerivedAttributeCode)
+			case derived.Class != "Private":
+				return nil, fmt.Errorf("%w: derived attribute %s must be Private, it is %s",
+					ErrInvalid, d.DerivedAttributeCode, derived.Class)
+			}
+			if _, ok := types[d.SourceAttributeCode]; !ok {
+				return nil, fmt.Errorf("%w: unknown source attribute %s of %s",
+					ErrInvalid, d.SourceAttributeCode, d.DerivedAttributeCode)
+			}
+			if d.RuleType != "Lookup" {
+				v := lineage.ValidateRule(d.RuleExpression, types, inEffect, []string{d.SourceAttributeCode}, d.RuleType)
+				if !v.Valid {
+					problems := append(append(append(v.CompileErrors, v.TypeErrors...),
+						prefixed("unknown attribute ", v.UnknownAttributes)...), prefixed("unknown list ", v.UnknownLists)...)
+					return nil, fmt.Errorf("%w: rule of %s: %s", ErrInvalid, d.DerivedAttributeCode, strings.Join(problems, "; "))
+				}
+			}
+			if _, err := tx.ExecContext(ctx, `
+				INSERT INTO kyc_overlay_derivations (tenant, derived_attribute_code, source_attribute_code,
+				    rule_expression, rule_type, description)
+				VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''))
+				ON CONFLICT (tenant, derived_attribute_code, source_attribute_code) DO UPDATE SET
+				    rule_expression = EXCLUDED.rule_expression, rule_type = EXCLUDED.rule_type,
+				    description = EXCLUDED.description`,
+				tenant, d.DerivedAttributeCode, d.SourceAttributeCode, d.RuleExpression, d.RuleType, d.Description); err != nil {
+				return nil, fmt.Errorf("failed to save overlay derivation of %s: %w", d.DerivedAttributeCode, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit overlay of %s: %w", tenant, err)
+	}
+	return r.Get(ctx, tenant)
+}
+
+// Remove deletes an entry of a tenant's overlay, leaving the global entry
+// with the same code, if any, in effect again. Links are named
+// ATTRIBUTE:DOCUMENT and derivations DERIVED:SOURCE. Removing an attribute
+// or document also removes the overlay links and derivations that name it.
+func (r *Repo) Remove(ctx context.Context, tenant, kind, code string) error {
+	tenant = normalizeTenant(tenant)
+	code = strings.ToUpper(strings.TrimSpace(code))
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	var stmts []string
+	var args []interface{}
+	switch kind {
+	case KindAttribute:
+		stmts = []string{
+			`DELETE FROM kyc_overlay_attributes WHERE tenant = $1 AND code = $2`,
+			`DELETE FROM kyc_overlay_attr_doc_links WHERE tenant = $1 AND attribute_code = $2`,
+			`DELETE FROM kyc_overlay_derivations WHERE tenant = $1 AND (derived_attribute_code = $2 OR source_attribute_code = $2)`,
+		}
+		args = []interface{}{tenant, code}
+	case KindDocument:
+		stmts = []string{
+			`DELETE FROM kyc_overlay_documents WHERE tenant = $1 AND code = $2`,
+			`DELETE FROM kyc_overlay_attr_doc_links WHERE tenant = $1 AND document_code = $2`,
+		}
+		args = []interface{}{tenant, code}
+	case KindLink, KindDerivation:
+		first, second, ok := strings.Cut(code, ":")
+		if !ok || first == "" || second == "" {
+			return fmt.Errorf("%w: a %s is named FIRST:SECOND, got %q", ErrInvalid, kind, code)
+		}
+		stmt := `DELETE FROM kyc_overlay_attr_doc_links WHERE tenant = $1 AND attribute_code = $2 AND document_code = $3`
+		if kind == KindDerivation {
+			stmt = `DELETE FROM kyc_overlay_derivations WHERE tenant = $1 AND derived_attribute_code = $2 AND source_attribute_code = $3`
+		}
+		stmts = []string{stmt}
+		args = []interface{}{tenant, first, second}
+	default:
+		return fmt.Errorf("%w: unknown kind %q (expected attribute, document, link or derivation)", ErrInvalid, kind)
+	}
+
+	for i, stmt := range stmts {
+		res, err := tx.ExecContext(ctx, stmt, args...)
+		if err != nil {
+			return fmt.Errorf("failed to remove overlay %s %s: %w", kind, code, err)
+		}
+		if n, _ := res.RowsAffected(); i == 0 && n == 0 {
+			return fmt.Errorf("%w: %s %s of %s", ErrNotFound, kind, code, tenant)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit overlay of %s: %w", tenant, err)
+	}
+	return nil
+}
+
+// CaseTenant returns the tenant a case belongs to
+func (r *Repo) CaseTenant(ctx context.Context, caseName string) (string, error) {
+	var tenant string
+	err := r.db.GetContext(ctx, &tenant, `
+		SELECT COALESCE((SELECT tenant FROM kyc_cases WHERE name = $1 ORDER BY id DESC LIMIT 1), $2)`,
+		caseName, auth.DefaultTenant)
+	if err != nil {
+		return "", fmt.Errorf("failed to get tenant of case %s: %w", caseName, err)
+	}
+	return tenant, nil
+}
+
+// SetCaseTenant moves a case to a tenant, whose overlay applies when it is
+// next validated or amended
+func (r *Repo) SetCaseTenant(ctx context.Context, caseName, tenant string) error {
+	res, err := r.db.ExecContext(ctx, `UPDATE kyc_cases SET tenant = $2 WHERE name = $1`, caseName, normalizeTenant(tenant))
+	if err != nil {
+		return fmt.Errorf("failed to set tenant of case %s: %w", caseName, err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%w: %s", ErrCaseNotFound, caseName)
+	}
+	return nil
+}
+
+// normalize checks the entries of an overlay and puts their codes and
+// enumerations in canonical form
+func normalize(o *model.OntologyOverlay) error {
+	code := func(kind, c string) (string, error) {
+		c = strings.ToUpper(strings.TrimSpace(c))
+		if !validCode.MatchString(c) {
+			return "", fmt.Errorf("%w: %s code %q must use upper-case letters, digits, hyphens and underscores", ErrInvalid, kind, c)
+		}
+		return c, nil
+	}
+	var err error
+	for i := range o.Attributes {
+		a := &o.Attributes[i]
+		if a.Code, err = code("attribute", a.Code); err != nil {
+			return err
+		}
+		if strings.TrimSpace(a.Name) == "" {
+			a.Name = a.Code
+		}
+		switch strings.ToLower(a.AttributeClass) {
+		case "", "public":
+			a.AttributeClass = "Public"
+		case "private":
+			a.AttributeClass = "Private"
+		default:
+			return fmt.Errorf("%w: attribute %s has class %q (expected Public or Private)", ErrInvalid, a.Code, a.AttributeClass)
+		}
+	}
+	for i := range o.Documents {
+		d := &o.Documents[i]
+		if d.Code, err = code("document", d.Code); err != nil {
+			return err
+		}
+		if strings.TrimSpace(d.Name) == "" {
+			d.Name = d.Code
+		}
+		d.Jurisdiction = strings.ToUpper(strings.TrimSpace(d.Jurisdiction))
+	}
+	for i := range o.Links {
+		l := &o.Links[i]
+		if l.AttributeCode, err = code("attribute", l.AttributeCode); err != nil {
+			return err
+		}
+		if l.DocumentCode, err = code("document", l.DocumentCode); err != nil {
+			return err
+		}
+		if l.SourceTier == "" {
+			l.SourceTier = "Primary"
+		}
+		if !sourceTiers[l.SourceTier] {
+			return fmt.Errorf("%w: link %s → %s has tier %q (expected Primary, Secondary or Tertiary)",
+				ErrInvalid, l.AttributeCode, l.DocumentCode, l.SourceTier)
+		}
+	}
+	for i := range o.Derivations {
+		d := &o.Derivations[i]
+		if d.DerivedAttributeCode, err = code("attribute", d.DerivedAttributeCode); err != nil {
+			return err
+		}
+		if d.SourceAttributeCode, err = code("attribute", d.SourceAttributeCode); err != nil {
+			return err
+		}
+		if strings.TrimSpace(d.RuleExpression) == "" {
+			return fmt.Errorf("%w: derivation of %s has no rule", ErrInvalid, d.DerivedAttributeCode)
+		}
+		if !ruleTypes[d.RuleType] {
+			return fmt.Errorf("%w: derivation of %s has rule type %q (expected Boolean, Numeric, String or Lookup)",
+				ErrInvalid, d.DerivedAttributeCode, d.RuleType)
+		}
+	}
+	return nil
+}
+
+// normalizeTenant returns the tenant name overlays are stored under
+func normalizeTenant(tenant string) string {
+	if tenant = strings.TrimSpace(tenant); tenant == "" {
+		return auth.DefaultTenant
+	}
+	return tenant
+}
+
+func prefixed(prefix string, names []string) []string {
+	out := make([]string, len(names))
+	for i, n := range names {
+		out[i] = prefix + n
+	}
+	return out
+}
+
+func dedupe(s []string) []string {
+	sort.Strings(s)
+	out := s[:0]
+	for i, v := range s {
+		if i == 0 || v != s[i-1] {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,273 @@
+package overlay
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/adamtc007/KYC-DSL/internal/attrvalue"
+	"github.com/adamtc007/KYC-DSL/internal/lineage"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/ontology"
+	"github.com/adamtc007/KYC-DSL/internal/rag"
+)
+
+// Resolver resolves the ontology of one tenant: its overlay first, then
+// the global ontology
+type Resolver struct {
+	db *sqlx.DB
+	// q reads the overlay; Apply resolves inside its transaction
+	q      sqlx.QueryerContext
+	tenant string
+}
+
+// NewResolver creates a resolver for a tenant; an empty tenant is the
+// default tenant
+func NewResolver(db *sqlx.DB, tenant string) *Resolver {
+	return &Resolver{db: db, q: db, tenant: normalizeTenant(tenant)}
+}
+
+// ForCase creates a resolver for the tenant a case belongs to
+func ForCase(ctx context.Context, db *sqlx.DB, caseName string) (*Resolver, error) {
+	tenant, err := NewRepo(db).CaseTenant(ctx, caseName)
+	if err != nil {
+		return nil, err
+	}
+	return NewResolver(db, tenant), nil
+}
+
+// Tenant is the tenant whose overlay is resolved
+func (r *Resolver) Tenant() string {
+	return r.tenant
+}
+
+// Specs returns the spec of every attribute the tenant resolves by code,
+// as attrvalue.Specs does for the global ontology. An overlay attribute
+// without a data type keeps the data type and domain values of the global
+// attribute it overrides.
+func (r *Resolver) Specs(ctx context.Context) (map[string]attrvalue.Spec, error) {
+	specs, err := attrvalue.Specs(ctx, r.q)
+	if err != nil {
+		return nil, err
+	}
+	var rows []attrvalue.Spec
+	if err := sqlx.SelectContext(ctx, r.q, &rows, `
+		SELECT code, attribute_class, COALESCE(data_type, '') AS data_type, domain_values
+		  FROM kyc_overlay_attributes
+		 WHERE tenant = $1`, r.tenant); err != nil {
+		return nil, fmt.Errorf("failed to load overlay attribute specs of %s: %w", r.tenant, err)
+	}
+	for _, s := range rows {
+		if global, ok := specs[s.Code]; ok && s.DataType == "" {
+			s.DataType, s.DomainValues = global.DataType, global.DomainValues
+		}
+		specs[s.Code] = s
+	}
+	return specs, nil
+}
+
+// AttributeTypes returns the value type of every attribute the tenant
+// resolves, as lineage.AttributeTypes does for the global ontology; an
+// overlay derived attribute without a data type takes the type of its
+// overlay rules
+func (r *Resolver) AttributeTypes(ctx context.Context) (map[string]model.AttributeType, error) {
+	types, err := lineage.AttributeTypes(ctx, r.q)
+	if err != nil {
+		return nil, err
+	}
+	var rows []model.AttributeType
+	if err := sqlx.SelectContext(ctx, r.q, &rows, `
+		SELECT o.code, o.attribute_class,
+		       COALESCE(NULLIF(o.data_type, ''),
+		                CASE d.rule_type WHEN 'Boolean' THEN 'boolean' WHEN 'Numeric' THEN 'float'
+		                                 WHEN 'String' THEN 'string' END, '') AS data_type
+		  FROM kyc_overlay_attributes o
+		  LEFT JOIN LATERAL (
+		        SELECT rule_type FROM kyc_overlay_derivations
+		         WHERE tenant = o.tenant AND derived_attribute_code = o.code ORDER BY id LIMIT 1) d ON TRUE
+		 WHERE o.tenant = $1`, r.tenant); err != nil {
+		return nil, fmt.Errorf("failed to load overlay attribute types of %s: %w", r.tenant, err)
+	}
+	for _, t := range rows {
+		if global, ok := types[t.Code]; ok && t.DataType == "" {
+			t.DataType = global.DataType
+		}
+		types[t.Code] = t
+	}
+	return types, nil
+}
+
+// DocumentCodes returns the document codes the tenant resolves
+func (r *Resolver) DocumentCodes(ctx context.Context) (map[string]bool, error) {
+	return r.codes(ctx, "documents", `
+		SELECT code FROM kyc_documents
+		UNION SELECT code FROM kyc_overlay_documents WHERE tenant = $1`)
+}
+
+// attributeCodes returns the attribute codes the tenant resolves
+func (r *Resolver) attributeCodes(ctx context.Context) (map[string]bool, error) {
+	return r.codes(ctx, "attributes", `
+		SELECT code FROM kyc_attributes
+		UNION SELECT code FROM kyc_overlay_attributes WHERE tenant = $1`)
+}
+
+func (r *Resolver) codes(ctx context.Context, kind, query string) (map[string]bool, error) {
+	var codes []string
+	if err := sqlx.SelectContext(ctx, r.q, &codes, query, r.tenant); err != nil {
+		return nil, fmt.Errorf("failed to load %s of %s: %w", kind, r.tenant, err)
+	}
+	known := make(map[string]bool, len(codes))
+	for _, c := range codes {
+		known[c] = true
+	}
+	return known, nil
+}
+
+// GetDocumentSources returns the documents that evidence an attribute for
+// the tenant, by tier: the overlay's links, then the global links to other
+// documents. It has the signature of ontology.Repository's, so document
+// discovery (internal/amend) takes either.
+func (r *Resolver) GetDocumentSources(attributeCode string) ([]ontology.AttributeDocumentLink, error) {
+	var links []ontology.AttributeDocumentLink
+	err := sqlx.SelectContext(context.Background(), r.q, &links, `
+		SELECT id, attribute_code, document_code, source_tier, is_mandatory, jurisdiction, regulation_code, notes
+		  FROM (
+		        SELECT 0 AS overlay_rank, id, attribute_code, document_code, source_tier, is_mandatory,
+		               COALESCE(jurisdiction, '') AS jurisdiction, '' AS regulation_code, COALESCE(notes, '') AS notes
+		          FROM kyc_overlay_attr_doc_links
+		         WHERE tenant = $1 AND attribute_code = $2
+		        UNION ALL
+		        SELECT 1, l.id, l.attribute_code, l.document_code, l.source_tier, l.is_mandatory,
+		               COALESCE(l.jurisdiction, ''), COALESCE(l.regulation_code, ''), COALESCE(l.notes, '')
+		          FROM kyc_attr_doc_links l
+		         WHERE l.attribute_code = $2
+		           AND NOT EXISTS (SELECT 1 FROM kyc_overlay_attr_doc_links o
+		                            WHERE o.tenant = $1 AND o.attribute_code = l.attribute_code
+		                              AND o.document_code = l.document_code)
+		  ) links
+		 ORDER BY source_tier, overlay_rank, document_code`, r.tenant, attributeCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document sources of %s for %s: %w", attributeCode, r.tenant, err)
+	}
+	return links, nil
+}
+
+// SearchByVector searches the tenant's attributes by vector similarity:
+// the global metadata (ontology.MetadataRepo.SearchByVector) merged with
+// the overlay's embedded attributes, which replace global attributes with
+// the same code. Overlays are small, so all of a tenant's embedded
+// attributes are ranked.
+func (r *Resolver) SearchByVector(ctx context.Context, vec []float32, limit int) ([]model.AttributeSearchResult, error) {
+	var overlay []model.AttributeSearchResult
+	if err := sqlx.SelectContext(ctx, r.q, &overlay, `
+		SELECT id, code AS attribute_code, synonyms, COALESCE(data_type, '') AS data_type, domain_values,
+		       COALESCE(risk_category, '') AS risk_level, '{}'::text[] AS example_values,
+		       '{}'::text[] AS regulatory_citations,
+		       COALESCE(NULLIF(business_context, ''), description, '') AS business_context, created_at,
+		       1 - (embedding <=> $2::vector) AS similarity_score,
+		       embedding <=> $2::vector AS distance
+		  FROM kyc_overlay_attributes
+		 WHERE tenant = $1 AND embedding IS NOT NULL`, r.tenant, pq.Array(vec)); err != nil {
+		return nil, fmt.Errorf("failed to search overlay of %s: %w", r.tenant, err)
+	}
+	global, err := ontology.NewMetadataRepo(r.db).SearchByVector(ctx, vec, limit+len(overlay))
+	if err != nil {
+		return nil, err
+	}
+	if len(overlay) == 0 {
+		if len(global) > limit {
+			global = global[:limit]
+		}
+		return global, nil
+	}
+
+	replaced := make(map[string]bool, len(overlay))
+	for _, o := range overlay {
+		replaced[o.AttributeCode] = true
+	}
+	results := overlay
+	for _, g := range global {
+		if !replaced[g.AttributeCode] {
+			results = append(results, g)
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Distance < results[j].Distance })
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// SearchByText searches the tenant's attributes by code, synonym or
+// keyword, overlay attributes replacing global ones with the same code
+func (r *Resolver) SearchByText(ctx context.Context, searchTerm string) ([]model.AttributeMetadata, error) {
+	var overlay []model.AttributeMetadata
+	if err := sqlx.SelectContext(ctx, r.q, &overlay, `
+		SELECT id, code AS attribute_code, synonyms, COALESCE(data_type, '') AS data_type, domain_values,
+		       COALESCE(risk_category, '') AS risk_level, '{}'::text[] AS example_values,
+		       '{}'::text[] AS regulatory_citations,
+		       COALESCE(NULLIF(business_context, ''), description, '') AS business_context, created_at
+		  FROM kyc_overlay_attributes
+		 WHERE tenant = $1
+		   AND (code ILIKE $2 OR name ILIKE $2 OR business_context ILIKE $2 OR $3 = ANY(synonyms))`,
+		r.tenant, "%"+searchTerm+"%", searchTerm); err != nil {
+		return nil, fmt.Errorf("failed to search overlay of %s: %w", r.tenant, err)
+	}
+	global, err := ontology.NewMetadataRepo(r.db).SearchByText(ctx, searchTerm)
+	if err != nil || len(overlay) == 0 {
+		return global, err
+	}
+
+	// A global match the overlay overrides but does not match is dropped too
+	var overridden []string
+	if err := sqlx.SelectContext(ctx, r.q, &overridden, `
+		SELECT code FROM kyc_overlay_attributes WHERE tenant = $1`, r.tenant); err != nil {
+		return nil, fmt.Errorf("failed to load overlay attributes of %s: %w", r.tenant, err)
+	}
+	replaced := make(map[string]bool, len(overridden))
+	for _, code := range overridden {
+		replaced[code] = true
+	}
+	results := overlay
+	for _, g := range global {
+		if !replaced[g.AttributeCode] {
+			results = append(results, g)
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].AttributeCode < results[j].AttributeCode })
+	return results, nil
+}
+
+// EmbedPending embeds the overlay attributes of the tenant that have no
+// embedding yet, as added or changed by Apply, with the primary space
+// model. It returns how many were embedded.
+func (r *Resolver) EmbedPending(ctx context.Context, embedder *rag.Embedder) (int, error) {
+	var pending []model.AttributeMetadata
+	if err := sqlx.SelectContext(ctx, r.q, &pending, `
+		SELECT id, code AS attribute_code, synonyms, COALESCE(data_type, '') AS data_type, domain_values,
+		       COALESCE(risk_category, '') AS risk_level, '{}'::text[] AS example_values,
+		       '{}'::text[] AS regulatory_citations,
+		       COALESCE(NULLIF(business_context, ''), description, name) AS business_context, created_at
+		  FROM kyc_overlay_attributes
+		 WHERE tenant = $1 AND embedding IS NULL
+		 ORDER BY code`, r.tenant); err != nil {
+		return 0, fmt.Errorf("failed to load unembedded overlay attributes of %s: %w", r.tenant, err)
+	}
+	embedded := 0
+	for _, m := range pending {
+		vec, err := embedder.GenerateEmbedding(ctx, m)
+		if err != nil {
+			return embedded, fmt.Errorf("failed to embed overlay attribute %s: %w", m.AttributeCode, err)
+		}
+		if _, err := r.db.ExecContext(ctx, `
+			UPDATE kyc_overlay_attributes SET embedding = $3::vector WHERE tenant = $1 AND code = $2`,
+			r.tenant, m.AttributeCode, pq.Array(vec)); err != nil {
+			return embedded, fmt.Errorf("failed to save embedding of overlay attribute %s: %w", m.AttributeCode, err)
+		}
+		embedded++
+	}
+	return embedded, nil
+}
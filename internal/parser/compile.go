@@ -0,0 +1,532 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Case is a kyc-case as JSON or YAML, for systems that cannot parse
+// S-expressions. The grammar's sections have typed fields; any section the
+// typed fields cannot hold exactly (unknown forms, extra arguments, repeated
+// single sections) is kept in Sections as a nested array, e.g.
+// ["screening", "WORLD-CHECK", ["frequency", "daily"]], so converting back
+// gives the same case (storage.CanonicalHash).
+type Case struct {
+	Name                 string                `json:"name" yaml:"name"`
+	Nature               string                `json:"nature,omitempty" yaml:"nature,omitempty"`
+	Purpose              string                `json:"purpose,omitempty" yaml:"purpose,omitempty"`
+	ClientBusinessUnit   string                `json:"client_business_unit,omitempty" yaml:"client_business_unit,omitempty"`
+	Functions            []string              `json:"functions,omitempty" yaml:"functions,omitempty"`
+	Policies             []string              `json:"policies,omitempty" yaml:"policies,omitempty"`
+	Obligations          []string              `json:"obligations,omitempty" yaml:"obligations,omitempty"`
+	DocumentRequirements []DocumentRequirement `json:"document_requirements,omitempty" yaml:"document_requirements,omitempty"`
+	Ownership            *Ownership            `json:"ownership,omitempty" yaml:"ownership,omitempty"`
+	DataDictionary       []Attribute           `json:"data_dictionary,omitempty" yaml:"data_dictionary,omitempty"`
+	DerivedAttributes    []DerivedAttribute    `json:"derived_attributes,omitempty" yaml:"derived_attributes,omitempty"`
+	KycToken             string                `json:"kyc_token,omitempty" yaml:"kyc_token,omitempty"`
+	Sections             []interface{}         `json:"sections,omitempty" yaml:"sections,omitempty"`
+}
+
+// DocumentRequirement is a (document-requirements ...) section
+type DocumentRequirement struct {
+	Jurisdiction string     `json:"jurisdiction" yaml:"jurisdiction"`
+	Documents    []Document `json:"documents" yaml:"documents"`
+}
+
+// Document is a required (document CODE "Name")
+type Document struct {
+	Code string `json:"code" yaml:"code"`
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+}
+
+// Ownership is the (ownership-structure ...) section
+type Ownership struct {
+	Entity           string       `json:"entity,omitempty" yaml:"entity,omitempty"`
+	Owners           []Holding    `json:"owners,omitempty" yaml:"owners,omitempty"`
+	BeneficialOwners []Holding    `json:"beneficial_owners,omitempty" yaml:"beneficial_owners,omitempty"`
+	Controllers      []Controller `json:"controllers,omitempty" yaml:"controllers,omitempty"`
+}
+
+// Holding is an owner or beneficial owner. Percent is kept as written,
+// e.g. "35%" or "100".
+type Holding struct {
+	Name    string `json:"name" yaml:"name"`
+	Percent string `json:"percent" yaml:"percent"`
+}
+
+// Controller is a person exercising control other than through ownership
+type Controller struct {
+	Name string `json:"name" yaml:"name"`
+	Role string `json:"role" yaml:"role"`
+}
+
+// Attribute is a data-dictionary attribute with its sources
+type Attribute struct {
+	Code    string   `json:"code" yaml:"code"`
+	Sources []Source `json:"sources,omitempty" yaml:"sources,omitempty"`
+}
+
+// Source is a (primary-source (document CODE)) or (tertiary-source "text")
+type Source struct {
+	Tier     string `json:"tier" yaml:"tier"` // primary, secondary or tertiary
+	Document string `json:"document,omitempty" yaml:"document,omitempty"`
+	Text     string `json:"text,omitempty" yaml:"text,omitempty"`
+}
+
+// DerivedAttribute is a derived-attributes attribute with its lineage
+type DerivedAttribute struct {
+	Code         string   `json:"code" yaml:"code"`
+	Sources      []string `json:"sources,omitempty" yaml:"sources,omitempty"`
+	Rule         string   `json:"rule,omitempty" yaml:"rule,omitempty"`
+	Jurisdiction string   `json:"jurisdiction,omitempty" yaml:"jurisdiction,omitempty"`
+	Regulation   string   `json:"regulation,omitempty" yaml:"regulation,omitempty"`
+}
+
+// ParseCase returns the first (kyc-case NAME ...) form of dsl
+func ParseCase(dsl string) (*Node, error) {
+	doc, err := Parse(dsl)
+	if err != nil {
+		return nil, err
+	}
+	for _, form := range doc.Children {
+		if form.Head() == "kyc-case" {
+			return form, nil
+		}
+	}
+	return nil, fmt.Errorf("no kyc-case form found")
+}
+
+// Compile converts a parsed (kyc-case ...) form into a Case. Comments are
+// not carried over.
+func Compile(kycCase *Node) (*Case, error) {
+	if kycCase.Head() != "kyc-case" {
+		return nil, fmt.Errorf("expected a kyc-case form, got (%s ...)", kycCase.Head())
+	}
+	if len(kycCase.Children) < 2 || kycCase.Children[1].List {
+		return nil, fmt.Errorf("kyc-case form has no name")
+	}
+
+	c := &Case{Name: kycCase.Children[1].Atom}
+	for _, sec := range kycCase.Children[2:] {
+		if !c.addTyped(sec) {
+			c.Sections = append(c.Sections, toValue(sec))
+		}
+	}
+	return c, nil
+}
+
+// CompileToJSON converts a parsed (kyc-case ...) form to indented JSON
+func CompileToJSON(kycCase *Node) ([]byte, error) {
+	c, err := Compile(kycCase)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(c, "", "  ")
+}
+
+// CompileToYAML converts a parsed (kyc-case ...) form to YAML
+func CompileToYAML(kycCase *Node) ([]byte, error) {
+	c, err := Compile(kycCase)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(c)
+}
+
+// FromJSON converts a case compiled to JSON back to formatted DSL
+func FromJSON(data []byte) (string, error) {
+	var c Case
+	if err := json.Unmarshal(data, &c); err != nil {
+		return "", fmt.Errorf("invalid case JSON: %w", err)
+	}
+	return c.DSL()
+}
+
+// FromYAML converts a case compiled to YAML back to formatted DSL
+func FromYAML(data []byte) (string, error) {
+	var c Case
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return "", fmt.Errorf("invalid case YAML: %w", err)
+	}
+	return c.DSL()
+}
+
+// DSL renders the case as formatted DSL (see Format)
+func (c *Case) DSL() (string, error) {
+	form, err := c.Node()
+	if err != nil {
+		return "", err
+	}
+	sortSections(form)
+	var b strings.Builder
+	writeNode(&b, form, 0, true)
+	b.WriteString("\n")
+	return b.String(), nil
+}
+
+// Node builds the (kyc-case ...) form of the case
+func (c *Case) Node() (*Node, error) {
+	if c.Name == "" {
+		return nil, fmt.Errorf("case has no name")
+	}
+	form := list(ident("kyc-case"), ident(c.Name))
+	form.Children = append(form.Children, c.typedSections()...)
+	for i, v := range c.Sections {
+		sec, err := fromValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("sections[%d]: %w", i, err)
+		}
+		form.Children = append(form.Children, sec)
+	}
+	return form, nil
+}
+
+// addTyped decodes sec into the typed fields. It reports false, leaving c
+// unchanged, when they cannot reproduce sec exactly.
+func (c *Case) addTyped(sec *Node) bool {
+	var part Case
+	if !part.decode(sec) {
+		return false
+	}
+	rendered := part.typedSections()
+	if len(rendered) != 1 || plainText(rendered[0]) != plainText(sec) {
+		return false
+	}
+	return c.merge(&part)
+}
+
+// decode reads one section into an empty Case
+func (c *Case) decode(sec *Node) bool {
+	if sec.Head() == "" {
+		return false
+	}
+	args := sec.Children[1:]
+	switch sec.Head() {
+	case "nature-purpose":
+		for _, n := range args {
+			switch n.Head() {
+			case "nature":
+				c.Nature = arg(n, 0)
+			case "purpose":
+				c.Purpose = arg(n, 0)
+			}
+		}
+	case "client-business-unit":
+		c.ClientBusinessUnit = arg(sec, 0)
+	case "function":
+		c.Functions = []string{arg(sec, 0)}
+	case "policy":
+		c.Policies = []string{arg(sec, 0)}
+	case "obligation":
+		c.Obligations = []string{arg(sec, 0)}
+	case "kyc-token":
+		c.KycToken = arg(sec, 0)
+	case "document-requirements":
+		var req DocumentRequirement
+		for _, n := range args {
+			switch n.Head() {
+			case "jurisdiction":
+				req.Jurisdiction = arg(n, 0)
+			case "required":
+				for _, doc := range n.Children[1:] {
+					req.Documents = append(req.Documents, Document{Code: arg(doc, 0), Name: arg(doc, 1)})
+				}
+			}
+		}
+		c.DocumentRequirements = []DocumentRequirement{req}
+	case "ownership-structure":
+		o := &Ownership{}
+		for _, n := range args {
+			switch n.Head() {
+			case "entity":
+				o.Entity = arg(n, 0)
+			case "owner":
+				o.Owners = append(o.Owners, Holding{Name: arg(n, 0), Percent: arg(n, 1)})
+			case "beneficial-owner":
+				o.BeneficialOwners = append(o.BeneficialOwners, Holding{Name: arg(n, 0), Percent: arg(n, 1)})
+			case "controller":
+				o.Controllers = append(o.Controllers, Controller{Name: arg(n, 0), Role: arg(n, 1)})
+			}
+		}
+		c.Ownership = o
+	case "data-dictionary":
+		if len(args) == 0 {
+			return false
+		}
+		c.DataDictionary = []Attribute{}
+		for _, n := range args {
+			attr := Attribute{Code: arg(n, 0)}
+			for _, src := range n.Children[min(2, len(n.Children)):] {
+				tier, _ := strings.CutSuffix(src.Head(), "-source")
+				s := Source{Tier: tier}
+				if doc := child(src, 1); doc != nil && doc.Head() == "document" {
+					s.Document = arg(doc, 0)
+				} else {
+					s.Text = arg(src, 0)
+				}
+				attr.Sources = append(attr.Sources, s)
+			}
+			c.DataDictionary = append(c.DataDictionary, attr)
+		}
+	case "derived-attributes":
+		if len(args) == 0 {
+			return false
+		}
+		c.DerivedAttributes = []DerivedAttribute{}
+		for _, n := range args {
+			attr := DerivedAttribute{Code: arg(n, 0)}
+			for _, field := range n.Children[min(2, len(n.Children)):] {
+				switch field.Head() {
+				case "sources":
+					if names := child(field, 1); names != nil && names.List {
+						for _, name := range names.Children {
+							attr.Sources = append(attr.Sources, name.Atom)
+						}
+					}
+				case "rule":
+					attr.Rule = arg(field, 0)
+				case "jurisdiction":
+					attr.Jurisdiction = arg(field, 0)
+				case "regulation":
+					attr.Regulation = arg(field, 0)
+				}
+			}
+			c.DerivedAttributes = append(c.DerivedAttributes, attr)
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// merge adds the sections of part to c, failing when a section that occurs
+// once in the typed fields is already set
+func (c *Case) merge(part *Case) bool {
+	if (part.Nature != "" || part.Purpose != "") && (c.Nature != "" || c.Purpose != "") ||
+		part.ClientBusinessUnit != "" && c.ClientBusinessUnit != "" ||
+		part.KycToken != "" && c.KycToken != "" ||
+		part.Ownership != nil && c.Ownership != nil ||
+		part.DataDictionary != nil && c.DataDictionary != nil ||
+		part.DerivedAttributes != nil && c.DerivedAttributes != nil {
+		return false
+	}
+
+	if part.Nature != "" || part.Purpose != "" {
+		c.Nature, c.Purpose = part.Nature, part.Purpose
+	}
+	if part.ClientBusinessUnit != "" {
+		c.ClientBusinessUnit = part.ClientBusinessUnit
+	}
+	if part.KycToken != "" {
+		c.KycToken = part.KycToken
+	}
+	if part.Ownership != nil {
+		c.Ownership = part.Ownership
+	}
+	if part.DataDictionary != nil {
+		c.DataDictionary = part.DataDictionary
+	}
+	if part.DerivedAttributes != nil {
+		c.DerivedAttributes = part.DerivedAttributes
+	}
+	c.Functions = append(c.Functions, part.Functions...)
+	c.Policies = append(c.Policies, part.Policies...)
+	c.Obligations = append(c.Obligations, part.Obligations...)
+	c.DocumentRequirements = append(c.DocumentRequirements, part.DocumentRequirements...)
+	return true
+}
+
+// typedSections renders the typed fields as sections, in grammar order
+func (c *Case) typedSections() []*Node {
+	var out []*Node
+	if c.Nature != "" || c.Purpose != "" {
+		np := list(ident("nature-purpose"))
+		if c.Nature != "" {
+			np.Children = append(np.Children, list(ident("nature"), str(c.Nature)))
+		}
+		if c.Purpose != "" {
+			np.Children = append(np.Children, list(ident("purpose"), str(c.Purpose)))
+		}
+		out = append(out, np)
+	}
+	if c.ClientBusinessUnit != "" {
+		out = append(out, list(ident("client-business-unit"), ident(c.ClientBusinessUnit)))
+	}
+	for _, f := range c.Functions {
+		out = append(out, list(ident("function"), ident(f)))
+	}
+	for _, p := range c.Policies {
+		out = append(out, list(ident("policy"), ident(p)))
+	}
+	for _, o := range c.Obligations {
+		out = append(out, list(ident("obligation"), ident(o)))
+	}
+	for _, req := range c.DocumentRequirements {
+		sec := list(ident("document-requirements"))
+		if req.Jurisdiction != "" {
+			sec.Children = append(sec.Children, list(ident("jurisdiction"), ident(req.Jurisdiction)))
+		}
+		if req.Documents != nil {
+			required := list(ident("required"))
+			for _, d := range req.Documents {
+				doc := list(ident("document"), ident(d.Code))
+				if d.Name != "" {
+					doc.Children = append(doc.Children, str(d.Name))
+				}
+				required.Children = append(required.Children, doc)
+			}
+			sec.Children = append(sec.Children, required)
+		}
+		out = append(out, sec)
+	}
+	if o := c.Ownership; o != nil {
+		sec := list(ident("ownership-structure"))
+		if o.Entity != "" {
+			sec.Children = append(sec.Children, list(ident("entity"), ident(o.Entity)))
+		}
+		for _, h := range o.Owners {
+			sec.Children = append(sec.Children, holding("owner", h))
+		}
+		for _, h := range o.BeneficialOwners {
+			sec.Children = append(sec.Children, holding("beneficial-owner", h))
+		}
+		for _, ctl := range o.Controllers {
+			sec.Children = append(sec.Children, list(ident("controller"), ident(ctl.Name), str(ctl.Role)))
+		}
+		out = append(out, sec)
+	}
+	if c.DataDictionary != nil {
+		sec := list(ident("data-dictionary"))
+		for _, a := range c.DataDictionary {
+			attr := list(ident("attribute"), ident(a.Code))
+			for _, s := range a.Sources {
+				src := list(ident(s.Tier + "-source"))
+				if s.Document != "" {
+					src.Children = append(src.Children, list(ident("document"), ident(s.Document)))
+				} else {
+					src.Children = append(src.Children, str(s.Text))
+				}
+				attr.Children = append(attr.Children, src)
+			}
+			sec.Children = append(sec.Children, attr)
+		}
+		out = append(out, sec)
+	}
+	if c.DerivedAttributes != nil {
+		sec := list(ident("derived-attributes"))
+		for _, a := range c.DerivedAttributes {
+			attr := list(ident("attribute"), ident(a.Code))
+			if a.Sources != nil {
+				names := list()
+				for _, s := range a.Sources {
+					names.Children = append(names.Children, ident(s))
+				}
+				attr.Children = append(attr.Children, list(ident("sources"), names))
+			}
+			if a.Rule != "" {
+				attr.Children = append(attr.Children, list(ident("rule"), str(a.Rule)))
+			}
+			if a.Jurisdiction != "" {
+				attr.Children = append(attr.Children, list(ident("jurisdiction"), ident(a.Jurisdiction)))
+			}
+			if a.Regulation != "" {
+				attr.Children = append(attr.Children, list(ident("regulation"), ident(a.Regulation)))
+			}
+			sec.Children = append(sec.Children, attr)
+		}
+		out = append(out, sec)
+	}
+	if c.KycToken != "" {
+		out = append(out, list(ident("kyc-token"), str(c.KycToken)))
+	}
+	return out
+}
+
+func holding(head string, h Holding) *Node {
+	n := list(ident(head), ident(h.Name))
+	if h.Percent != "" {
+		n.Children = append(n.Children, ident(h.Percent))
+	}
+	return n
+}
+
+// toValue converts a form to nested arrays of strings
+func toValue(n *Node) interface{} {
+	if !n.List {
+		return n.Atom
+	}
+	values := make([]interface{}, len(n.Children))
+	for i, c := range n.Children {
+		values[i] = toValue(c)
+	}
+	return values
+}
+
+// fromValue converts nested arrays back to a form; scalars that are not
+// strings (YAML numbers and booleans) become atoms as written
+func fromValue(v interface{}) (*Node, error) {
+	switch v := v.(type) {
+	case []interface{}:
+		n := list()
+		for _, item := range v {
+			c, err := fromValue(item)
+			if err != nil {
+				return nil, err
+			}
+			n.Children = append(n.Children, c)
+		}
+		return n, nil
+	case string:
+		return ident(v), nil
+	case float64, int, bool:
+		return ident(fmt.Sprint(v)), nil
+	default:
+		return nil, fmt.Errorf("unsupported value %v (%T)", v, v)
+	}
+}
+
+// plainText renders a form on one line with every atom quoted, so forms
+// differing only in quoting compare equal
+func plainText(n *Node) string {
+	if !n.List {
+		return `"` + n.Atom + `"`
+	}
+	parts := make([]string, len(n.Children))
+	for i, c := range n.Children {
+		parts[i] = plainText(c)
+	}
+	return "(" + strings.Join(parts, " ") + ")"
+}
+
+func list(children ...*Node) *Node {
+	return &Node{List: true, Children: children}
+}
+
+// ident is an atom quoted only when it is not a valid identifier
+func ident(s string) *Node {
+	return &Node{Atom: s, Quoted: !isBare(s)}
+}
+
+// str is an atom always written as a "string"
+func str(s string) *Node {
+	return &Node{Atom: s, Quoted: true}
+}
+
+// child returns the i-th element of a list, or nil
+func child(n *Node, i int) *Node {
+	if i < len(n.Children) {
+		return n.Children[i]
+	}
+	return nil
+}
+
+// arg returns the i-th atom argument of a list (after its head), or ""
+func arg(n *Node, i int) string {
+	if c := child(n, i+1); c != nil && !c.List {
+		return c.Atom
+	}
+	return ""
+}
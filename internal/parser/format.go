@@ -0,0 +1,174 @@
+package parser
+
+import (
+	"sort"
+	"strings"
+)
+
+// indentWidth is the indentation of each nesting level
+const indentWidth = 2
+
+// maxLineWidth is the widest a list is written on one line
+const maxLineWidth = 80
+
+// sectionOrder is the order of the sections of a kyc-case in formatted DSL,
+// following the grammar documentation. Other sections keep their relative
+// order after these; kyc-token always comes last.
+var sectionOrder = map[string]int{
+	"nature-purpose":        1,
+	"client-business-unit":  2,
+	"function":              3,
+	"policy":                4,
+	"obligation":            5,
+	"document-requirements": 6,
+	"ownership-structure":   7,
+	"data-dictionary":       8,
+	"derived-attributes":    9,
+	"kyc-token":             100,
+}
+
+// unknownSection ranks sections missing from sectionOrder
+const unknownSection = 50
+
+// Format rewrites DSL in the canonical layout: two-space indentation, one
+// nested form per line, short forms on one line, closing parentheses on the
+// last line of their form, and the sections of each kyc-case in grammar
+// order. Comments and quoting are kept. Formatting formatted DSL returns it
+// unchanged.
+func Format(dsl string) (string, error) {
+	doc, err := Parse(dsl)
+	if err != nil {
+		return "", err
+	}
+	return formatDoc(doc), nil
+}
+
+// formatDoc writes a parsed document in the canonical layout
+func formatDoc(doc *Node) string {
+	var b strings.Builder
+	for i, form := range doc.Children {
+		if form.Head() == "kyc-case" {
+			sortSections(form)
+		}
+		if i > 0 {
+			b.WriteString("\n\n")
+		}
+		writeComments(&b, form.Comments, 0)
+		writeNode(&b, form, 0, true)
+	}
+	if len(doc.Trailing) > 0 {
+		b.WriteString("\n\n")
+		writeComments(&b, doc.Trailing, 0)
+	}
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// sortSections orders the list elements of a form after its leading atoms
+func sortSections(form *Node) {
+	leading := 0
+	for leading < len(form.Children) && !form.Children[leading].List {
+		leading++
+	}
+	sections := form.Children[leading:]
+	sort.SliceStable(sections, func(i, j int) bool {
+		return sectionRank(sections[i]) < sectionRank(sections[j])
+	})
+}
+
+func sectionRank(n *Node) int {
+	if rank, ok := sectionOrder[n.Head()]; ok {
+		return rank
+	}
+	return unknownSection
+}
+
+// writeNode writes n starting at the current position, which is already
+// indented to indent. Top-level forms are never written on one line.
+func writeNode(b *strings.Builder, n *Node, indent int, topLevel bool) {
+	if !n.List {
+		b.WriteString(atomText(n))
+		return
+	}
+	if flat, ok := flatText(n); ok && !(topLevel && hasList(n)) && indent+len(flat) <= maxLineWidth {
+		b.WriteString(flat)
+		return
+	}
+
+	b.WriteString("(")
+	rest := n.Children
+	for len(rest) > 0 && !rest[0].List && len(rest[0].Comments) == 0 {
+		if len(rest) < len(n.Children) {
+			b.WriteString(" ")
+		}
+		b.WriteString(atomText(rest[0]))
+		rest = rest[1:]
+	}
+
+	inner := indent + indentWidth
+	for i, child := range rest {
+		b.WriteString("\n")
+		if len(child.Comments) > 0 && (i > 0 || len(rest) < len(n.Children)) {
+			b.WriteString("\n")
+		}
+		writeComments(b, child.Comments, inner)
+		b.WriteString(strings.Repeat(" ", inner))
+		writeNode(b, child, inner, false)
+	}
+	if len(n.Trailing) > 0 {
+		b.WriteString("\n")
+		writeComments(b, n.Trailing, inner)
+		b.WriteString(strings.Repeat(" ", indent))
+	}
+	b.WriteString(")")
+}
+
+// flatText renders n on one line, which is possible when no comment is
+// inside it and it holds at most one nested list, itself flat
+func flatText(n *Node) (string, bool) {
+	if !n.List {
+		return atomText(n), len(n.Comments) == 0
+	}
+	if len(n.Trailing) > 0 {
+		return "", false
+	}
+	parts := make([]string, len(n.Children))
+	lists := 0
+	for i, c := range n.Children {
+		if len(c.Comments) > 0 {
+			return "", false
+		}
+		if c.List {
+			lists++
+		}
+		text, ok := flatText(c)
+		if !ok || lists > 1 {
+			return "", false
+		}
+		parts[i] = text
+	}
+	return "(" + strings.Join(parts, " ") + ")", true
+}
+
+func hasList(n *Node) bool {
+	for _, c := range n.Children {
+		if c.List {
+			return true
+		}
+	}
+	return false
+}
+
+func writeComments(b *strings.Builder, comments []string, indent int) {
+	for _, c := range comments {
+		b.WriteString(strings.Repeat(" ", indent))
+		b.WriteString(c)
+		b.WriteString("\n")
+	}
+}
+
+func atomText(n *Node) string {
+	if n.Quoted {
+		return `"` + n.Atom + `"`
+	}
+	return n.Atom
+}
@@ -0,0 +1,87 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// seedCases are the sample cases of the repository and the round-trip
+// cases of the Rust parser, relative to this package
+var seedCases = []string{"../../*.dsl", "../../rust/kyc_dsl_core/testdata/roundtrip/*.dsl"}
+
+// addSeedCases adds every seed case to the corpus of f
+func addSeedCases(f *testing.F) {
+	for _, pattern := range seedCases {
+		paths, err := filepath.Glob(pattern)
+		if err != nil {
+			f.Fatal(err)
+		}
+		for _, path := range paths {
+			src, err := os.ReadFile(path)
+			if err != nil {
+				f.Fatal(err)
+			}
+			f.Add(string(src))
+		}
+	}
+}
+
+// FuzzParse parses arbitrary DSL. Parse errors are expected; panics are
+// not, and DSL that parses must format to DSL that formats unchanged. Seeds
+// are the sample cases; the corpus lives in testdata/fuzz/FuzzParse.
+//
+//	go test ./internal/parser -run '^$' -fuzz FuzzParse -fuzztime 5m
+func FuzzParse(f *testing.F) {
+	addSeedCases(f)
+	f.Fuzz(func(t *testing.T, src string) {
+		if _, err := Parse(src); err != nil {
+			return
+		}
+		formatted, err := Format(src)
+		if err != nil {
+			t.Fatalf("Format failed on DSL that parses: %v", err)
+		}
+		again, err := Format(formatted)
+		if err != nil {
+			t.Fatalf("formatted DSL does not parse: %v\n%s", err, formatted)
+		}
+		if again != formatted {
+			t.Fatalf("formatting is not stable:\n--- once ---\n%s\n--- twice ---\n%s", formatted, again)
+		}
+	})
+}
+
+// FuzzCompile compiles the kyc-case of arbitrary DSL and writes it back.
+// Compile errors are expected; panics are not, and a case that compiles
+// must write DSL that compiles to the same case name.
+//
+//	go test ./internal/parser -run '^$' -fuzz FuzzCompile -fuzztime 5m
+func FuzzCompile(f *testing.F) {
+	addSeedCases(f)
+	f.Fuzz(func(t *testing.T, src string) {
+		form, err := ParseCase(src)
+		if err != nil {
+			return
+		}
+		c, err := Compile(form)
+		if err != nil {
+			return
+		}
+		dsl, err := c.DSL()
+		if err != nil {
+			return
+		}
+		form, err = ParseCase(dsl)
+		if err != nil {
+			t.Fatalf("written case does not parse: %v\n%s", err, dsl)
+		}
+		back, err := Compile(form)
+		if err != nil {
+			t.Fatalf("written case does not compile: %v\n%s", err, dsl)
+		}
+		if back.Name != c.Name {
+			t.Fatalf("case name changed from %q to %q", c.Name, back.Name)
+		}
+	})
+}
@@ -0,0 +1,204 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+)
+
+// CurrentGrammarVersion is the grammar version the Go tools write. It
+// matches the version reported by the Rust engine's GetGrammar.
+const CurrentGrammarVersion = "1.2"
+
+// GrammarVersions are the released grammar versions, oldest first
+var GrammarVersions = []string{"1.0", "1.1", CurrentGrammarVersion}
+
+// Compatibility is how a grammar engine handles cases of a grammar version
+type Compatibility string
+
+const (
+	// Compatible cases are read unchanged
+	Compatible Compatibility = "compatible"
+	// Migratable cases must be upgraded with MigrateCase first
+	Migratable Compatibility = "migratable"
+	// Incompatible cases are newer than the engine or of an unknown version
+	Incompatible Compatibility = "incompatible"
+)
+
+// ErrIncompatibleGrammar is returned when a case cannot be migrated to the
+// requested grammar version
+var ErrIncompatibleGrammar = errors.New("incompatible grammar version")
+
+// grammarStep upgrades the kyc-case forms of a document by one version and
+// returns a description of each change made
+type grammarStep func(kycCase *Node) []string
+
+// grammarSteps[v] upgrades a case from the version before v to v
+var grammarSteps = map[string]grammarStep{
+	"1.1": upgradeNaturePurpose,
+	"1.2": upgradeDocumentRequirements,
+}
+
+// CheckCompatibility returns how an engine of engineVersion handles a case
+// written in caseVersion
+func CheckCompatibility(caseVersion, engineVersion string) Compatibility {
+	c, e := versionIndex(caseVersion), versionIndex(engineVersion)
+	switch {
+	case c < 0 || e < 0 || c > e:
+		return Incompatible
+	case c == e:
+		return Compatible
+	default:
+		return Migratable
+	}
+}
+
+// CompatibilityMatrix returns CheckCompatibility for every pair of released
+// versions, indexed by case version and then engine version
+func CompatibilityMatrix() map[string]map[string]Compatibility {
+	matrix := make(map[string]map[string]Compatibility, len(GrammarVersions))
+	for _, c := range GrammarVersions {
+		matrix[c] = make(map[string]Compatibility, len(GrammarVersions))
+		for _, e := range GrammarVersions {
+			matrix[c][e] = CheckCompatibility(c, e)
+		}
+	}
+	return matrix
+}
+
+func versionIndex(version string) int {
+	for i, v := range GrammarVersions {
+		if v == version {
+			return i
+		}
+	}
+	return -1
+}
+
+// DetectGrammarVersion returns the grammar version dsl is written in: the
+// oldest version whose retired forms it uses, or CurrentGrammarVersion when
+// it uses none
+func DetectGrammarVersion(dsl string) (string, error) {
+	doc, err := Parse(dsl)
+	if err != nil {
+		return "", err
+	}
+	return detectVersion(doc), nil
+}
+
+func detectVersion(doc *Node) string {
+	version := CurrentGrammarVersion
+	for _, form := range doc.Children {
+		if form.Head() != "kyc-case" {
+			continue
+		}
+		for _, sec := range form.Children {
+			switch sec.Head() {
+			case "nature", "purpose", "token":
+				return "1.0"
+			case "documents":
+				version = "1.1"
+			}
+		}
+	}
+	return version
+}
+
+// Migration is the result of upgrading a case to a newer grammar version
+type Migration struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	// DSL is the upgraded case in the canonical layout, or the input
+	// unchanged when no form needed rewriting
+	DSL string `json:"dsl"`
+	// Changes describe each rewrite, prefixed with the version it upgrades to
+	Changes []string `json:"changes"`
+}
+
+// MigrateCase upgrades the kyc-case forms of dsl from grammar version from
+// to version to, one version at a time. An empty from is detected from the
+// DSL and an empty to means CurrentGrammarVersion. Comments are kept.
+func MigrateCase(dsl, from, to string) (*Migration, error) {
+	doc, err := Parse(dsl)
+	if err != nil {
+		return nil, err
+	}
+	if from == "" {
+		from = detectVersion(doc)
+	}
+	if to == "" {
+		to = CurrentGrammarVersion
+	}
+	if CheckCompatibility(from, to) == Incompatible {
+		return nil, fmt.Errorf("%w: cannot migrate a v%s case to v%s", ErrIncompatibleGrammar, from, to)
+	}
+
+	m := &Migration{From: from, To: to, DSL: dsl, Changes: []string{}}
+	for _, version := range GrammarVersions[versionIndex(from)+1 : versionIndex(to)+1] {
+		for _, form := range doc.Children {
+			if form.Head() != "kyc-case" {
+				continue
+			}
+			for _, change := range grammarSteps[version](form) {
+				m.Changes = append(m.Changes, version+": "+change)
+			}
+		}
+	}
+	if len(m.Changes) > 0 {
+		m.DSL = formatDoc(doc)
+	}
+	return m, nil
+}
+
+// upgradeNaturePurpose moves the separate (nature ...) and (purpose ...)
+// sections of 1.0 into one (nature-purpose ...) section, and renames
+// (token ...) to (kyc-token ...)
+func upgradeNaturePurpose(kycCase *Node) []string {
+	var changes []string
+	var wrapper *Node
+	kept := make([]*Node, 0, len(kycCase.Children))
+	for _, sec := range kycCase.Children {
+		switch sec.Head() {
+		case "nature", "purpose":
+			if wrapper == nil {
+				wrapper = &Node{List: true, Children: []*Node{{Atom: "nature-purpose"}}, Comments: sec.Comments}
+				sec.Comments = nil
+				kept = append(kept, wrapper)
+			}
+			wrapper.Children = append(wrapper.Children, sec)
+			changes = append(changes, fmt.Sprintf("moved (%s) into (nature-purpose)", sec.Head()))
+			continue
+		case "token":
+			sec.Children[0].Atom = "kyc-token"
+			changes = append(changes, "renamed (token) to (kyc-token)")
+		}
+		kept = append(kept, sec)
+	}
+	kycCase.Children = kept
+	return changes
+}
+
+// upgradeDocumentRequirements rewrites the 1.1 (documents [JURISDICTION]
+// (document ...)...) section as (document-requirements (jurisdiction J)
+// (required (document ...)...)). Lists without a jurisdiction apply to
+// GLOBAL.
+func upgradeDocumentRequirements(kycCase *Node) []string {
+	var changes []string
+	for _, sec := range kycCase.Children {
+		if sec.Head() != "documents" {
+			continue
+		}
+		jurisdiction := &Node{Atom: "GLOBAL"}
+		docs := sec.Children[1:]
+		if len(docs) > 0 && !docs[0].List {
+			jurisdiction, docs = docs[0], docs[1:]
+		}
+		sec.Children = []*Node{
+			{Atom: "document-requirements"},
+			{List: true, Children: []*Node{{Atom: "jurisdiction"}, jurisdiction}},
+			{List: true, Children: append([]*Node{{Atom: "required"}}, docs...), Trailing: sec.Trailing},
+		}
+		sec.Trailing = nil
+		changes = append(changes, fmt.Sprintf("rewrote (documents) as (document-requirements (jurisdiction %s))", jurisdiction.Atom))
+	}
+	return changes
+}
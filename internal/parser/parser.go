@@ -0,0 +1,155 @@
+// Package parser reads KYC-DSL text into a syntax tree on the Go side and
+// writes it back in a canonical layout. It knows the S-expression syntax
+// only; the grammar itself (which forms are valid where) is checked by the
+// Rust engine. Comments and string quoting are kept, so formatted DSL
+// parses to the same cases as its source.
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Node is an atom or a list of the DSL
+type Node struct {
+	Atom     string  // atom text, without quotes
+	Quoted   bool    // the atom was written as a "string"
+	List     bool    // the node is a (list ...)
+	Children []*Node // list elements, in order
+	// Comments are the ; comment lines written before the node
+	Comments []string
+	// Trailing are the comment lines of a list after its last element
+	Trailing []string
+}
+
+// Head returns the leading atom of a list, e.g. policy for (policy X)
+func (n *Node) Head() string {
+	if n.List && len(n.Children) > 0 && !n.Children[0].List {
+		return n.Children[0].Atom
+	}
+	return ""
+}
+
+// Parse reads every top-level form of src. The returned node is a list of
+// those forms; comments after the last form are its Trailing comments.
+func Parse(src string) (*Node, error) {
+	r := &reader{src: []rune(src)}
+	doc := &Node{List: true}
+	for {
+		comments := r.skipSpace()
+		if r.pos >= len(r.src) {
+			doc.Trailing = comments
+			break
+		}
+		form, err := r.read()
+		if err != nil {
+			return nil, err
+		}
+		form.Comments = comments
+		doc.Children = append(doc.Children, form)
+	}
+	if len(doc.Children) == 0 {
+		return nil, fmt.Errorf("empty DSL")
+	}
+	return doc, nil
+}
+
+type reader struct {
+	src []rune
+	pos int
+}
+
+// skipSpace skips whitespace and returns the comments it passed
+func (r *reader) skipSpace() []string {
+	var comments []string
+	for r.pos < len(r.src) {
+		switch c := r.src[r.pos]; {
+		case unicode.IsSpace(c):
+			r.pos++
+		case c == ';':
+			start := r.pos
+			for r.pos < len(r.src) && r.src[r.pos] != '\n' {
+				r.pos++
+			}
+			comments = append(comments, strings.TrimRightFunc(string(r.src[start:r.pos]), unicode.IsSpace))
+		default:
+			return comments
+		}
+	}
+	return comments
+}
+
+func (r *reader) read() (*Node, error) {
+	comments := r.skipSpace()
+	if r.pos >= len(r.src) {
+		return nil, fmt.Errorf("unexpected end of input")
+	}
+
+	switch r.src[r.pos] {
+	case '(':
+		r.pos++
+		list := &Node{List: true, Comments: comments}
+		for {
+			pending := r.skipSpace()
+			if r.pos >= len(r.src) {
+				return nil, fmt.Errorf("unbalanced parentheses")
+			}
+			if r.src[r.pos] == ')' {
+				r.pos++
+				list.Trailing = pending
+				return list, nil
+			}
+			child, err := r.read()
+			if err != nil {
+				return nil, err
+			}
+			child.Comments = append(pending, child.Comments...)
+			list.Children = append(list.Children, child)
+		}
+	case ')':
+		return nil, fmt.Errorf("unexpected ')' at offset %d", r.pos)
+	case '"':
+		start := r.pos + 1
+		end := start
+		for end < len(r.src) && r.src[end] != '"' {
+			end++
+		}
+		if end >= len(r.src) {
+			return nil, fmt.Errorf("unterminated string at offset %d", r.pos)
+		}
+		r.pos = end + 1
+		return &Node{Atom: string(r.src[start:end]), Quoted: true, Comments: comments}, nil
+	default:
+		start := r.pos
+		for r.pos < len(r.src) {
+			c := r.src[r.pos]
+			if unicode.IsSpace(c) || c == '(' || c == ')' || c == '"' || c == ';' {
+				break
+			}
+			r.pos++
+		}
+		return &Node{Atom: string(r.src[start:r.pos]), Comments: comments}, nil
+	}
+}
+
+// Quote returns s as written in DSL: bare when it is a valid identifier,
+// number or percentage, otherwise as a "string"
+func Quote(s string) string {
+	if isBare(s) {
+		return s
+	}
+	return `"` + s + `"`
+}
+
+func isBare(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("_-%.", r) {
+			return false
+		}
+	}
+	return true
+}
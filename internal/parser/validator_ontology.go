@@ -0,0 +1,267 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/adamtc007/KYC-DSL/internal/attrvalue"
+)
+
+// Severities of ontology issues
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// OntologyIssue is a code or literal value of a case the ontology does not
+// accept
+type OntologyIssue struct {
+	Severity string `json:"severity"`
+	// Section is the form the issue was found in, e.g. data-dictionary
+	Section string `json:"section"`
+	// Ref is the attribute, document, derived attribute or holder concerned
+	Ref     string `json:"ref"`
+	Message string `json:"message"`
+	// Violation details a literal value refused by attribute validation
+	Violation *attrvalue.Error `json:"violation,omitempty"`
+}
+
+// Ontology is what ValidateOntologyRefs checks a case against: the
+// dictionary attributes with their data types and domain values, and the
+// document codes
+type Ontology struct {
+	Attributes map[string]attrvalue.Spec
+	Documents  map[string]bool
+}
+
+// ValidateOntologyRefs checks the attribute and document codes a case
+// references against the ontology, and the literal values it embeds with
+// the validator used for captured values (internal/attrvalue): ownership
+// percentages as percentages, and literals a derived-attribute rule compares
+// an attribute with ((= PEP_STATUS true), (in TAX_RESIDENCY_COUNTRY ['IR'
+// 'KP']), (case ATTR (['IR'] 100) ...)) against that attribute. Literals
+// outside an attribute's domain values are warnings; every other issue is
+// an error.
+func ValidateOntologyRefs(c *Case, o Ontology) []OntologyIssue {
+	var issues []OntologyIssue
+	add := func(section, ref, format string, args ...any) {
+		issues = append(issues, OntologyIssue{Severity: SeverityError, Section: section, Ref: ref, Message: fmt.Sprintf(format, args...)})
+	}
+	literal := func(section, ref string, spec attrvalue.Spec, value string) {
+		if _, _, err := attrvalue.Validate(spec, value); err != nil {
+			issue := OntologyIssue{Severity: SeverityError, Section: section, Ref: ref, Message: err.Error()}
+			var invalid *attrvalue.Error
+			if errors.As(err, &invalid) {
+				issue.Violation = invalid
+				if invalid.Rule == attrvalue.RuleDomain {
+					issue.Severity = SeverityWarning
+				}
+			}
+			issues = append(issues, issue)
+		}
+	}
+
+	for _, req := range c.DocumentRequirements {
+		for _, d := range req.Documents {
+			if !o.Documents[d.Code] {
+				add("document-requirements", d.Code, "unknown document %s required for %s", d.Code, req.Jurisdiction)
+			}
+		}
+	}
+
+	for _, a := range c.DataDictionary {
+		if _, ok := o.Attributes[a.Code]; !ok {
+			add("data-dictionary", a.Code, "unknown attribute %s", a.Code)
+		}
+		for _, s := range a.Sources {
+			if s.Document != "" && !o.Documents[s.Document] {
+				add("data-dictionary", a.Code, "unknown %s-source document %s of %s", s.Tier, s.Document, a.Code)
+			}
+		}
+	}
+
+	for _, d := range c.DerivedAttributes {
+		if _, ok := o.Attributes[d.Code]; !ok {
+			add("derived-attributes", d.Code, "unknown derived attribute %s", d.Code)
+		}
+		for _, s := range d.Sources {
+			if _, ok := o.Attributes[s]; !ok {
+				add("derived-attributes", d.Code, "unknown source attribute %s of %s", s, d.Code)
+			}
+		}
+		for _, l := range ruleLiterals(d.Rule, o.Attributes) {
+			literal("derived-attributes", d.Code, o.Attributes[l.attribute], l.value)
+		}
+	}
+
+	if c.Ownership != nil {
+		for _, h := range c.Ownership.Owners {
+			if h.Percent != "" {
+				literal("ownership-structure", h.Name, attrvalue.Spec{Code: "owner " + h.Name, DataType: attrvalue.KindPercentage}, h.Percent)
+			}
+		}
+		for _, h := range c.Ownership.BeneficialOwners {
+			if h.Percent != "" {
+				literal("ownership-structure", h.Name, attrvalue.Spec{Code: "beneficial-owner " + h.Name, DataType: attrvalue.KindPercentage}, h.Percent)
+			}
+		}
+	}
+	return issues
+}
+
+// ruleLiteral is a literal value a rule compares an attribute with
+type ruleLiteral struct {
+	attribute string
+	value     string
+}
+
+// ruleTerm is a term of a derived-attribute rule: an atom, a quoted
+// string, a (call) or a [list]
+type ruleTerm struct {
+	atom    string
+	quoted  bool
+	list    bool
+	bracket bool
+	items   []ruleTerm
+}
+
+// comparisons are the rule forms whose attribute operand is compared with
+// literal operands
+var comparisons = map[string]bool{
+	"=": true, "==": true, "!=": true, "not=": true, "<": true, ">": true, "<=": true, ">=": true,
+	"in": true, "not-in": true,
+}
+
+// ruleLiterals returns the literals a rule compares dictionary attributes
+// with. Rules that do not tokenize have none; their syntax is checked when
+// they are compiled.
+func ruleLiterals(rule string, attributes map[string]attrvalue.Spec) []ruleLiteral {
+	terms, ok := tokenizeRule(rule)
+	if !ok {
+		return nil
+	}
+	var out []ruleLiteral
+	var walk func(t ruleTerm)
+	walk = func(t ruleTerm) {
+		if !t.list {
+			return
+		}
+		if !t.bracket && len(t.items) > 1 && !t.items[0].list && !t.items[0].quoted {
+			head, args := t.items[0].atom, t.items[1:]
+			isAttr := func(a ruleTerm) bool {
+				_, ok := attributes[a.atom]
+				return !a.list && !a.quoted && ok
+			}
+			switch {
+			case head == "case" && isAttr(args[0]):
+				for _, clause := range args[1:] {
+					if clause.list && !clause.bracket && len(clause.items) > 0 {
+						out = append(out, literalsOf(args[0].atom, clause.items[0], isAttr)...)
+					}
+				}
+			case comparisons[head]:
+				for i, a := range args {
+					if !isAttr(a) {
+						continue
+					}
+					for j, other := range args {
+						if j != i {
+							out = append(out, literalsOf(a.atom, other, isAttr)...)
+						}
+					}
+					break
+				}
+			}
+		}
+		for _, item := range t.items {
+			walk(item)
+		}
+	}
+	for _, t := range terms {
+		walk(t)
+	}
+	return out
+}
+
+// literalsOf returns a literal term, or the literals of a [list] term, as
+// values of an attribute; calls and attributes are not literals
+func literalsOf(attribute string, t ruleTerm, isAttr func(ruleTerm) bool) []ruleLiteral {
+	switch {
+	case t.list && t.bracket:
+		var out []ruleLiteral
+		for _, item := range t.items {
+			out = append(out, literalsOf(attribute, item, isAttr)...)
+		}
+		return out
+	case t.list, isAttr(t), !t.quoted && t.atom == "else":
+		return nil
+	case !t.quoted && !isLiteralAtom(t.atom):
+		return nil
+	}
+	return []ruleLiteral{{attribute: attribute, value: t.atom}}
+}
+
+// isLiteralAtom reports whether an unquoted atom is a boolean or a number
+func isLiteralAtom(atom string) bool {
+	if atom == "true" || atom == "false" {
+		return true
+	}
+	digits := 0
+	for i, r := range atom {
+		switch {
+		case unicode.IsDigit(r):
+			digits++
+		case (r == '-' || r == '+') && i == 0, r == '.', r == '%' && i == len(atom)-1:
+		default:
+			return false
+		}
+	}
+	return digits > 0
+}
+
+// tokenizeRule reads the terms of a rule
+func tokenizeRule(rule string) ([]ruleTerm, bool) {
+	var stack [][]ruleTerm
+	var brackets []bool
+	var cur []ruleTerm
+	for i := 0; i < len(rule); {
+		ch := rule[i]
+		switch {
+		case ch == '(' || ch == '[':
+			stack = append(stack, cur)
+			brackets = append(brackets, ch == '[')
+			cur = nil
+			i++
+		case ch == ')' || ch == ']':
+			if len(stack) == 0 || brackets[len(brackets)-1] != (ch == ']') {
+				return nil, false
+			}
+			t := ruleTerm{list: true, bracket: ch == ']', items: cur}
+			cur = append(stack[len(stack)-1], t)
+			stack, brackets = stack[:len(stack)-1], brackets[:len(brackets)-1]
+			i++
+		case ch == '\'' || ch == '"':
+			end := strings.IndexByte(rule[i+1:], ch)
+			if end < 0 {
+				return nil, false
+			}
+			cur = append(cur, ruleTerm{atom: rule[i+1 : i+1+end], quoted: true})
+			i += end + 2
+		case ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r' || ch == ',':
+			i++
+		default:
+			j := i
+			for j < len(rule) && !strings.ContainsRune("()[]'\" \t\n\r,", rune(rule[j])) {
+				j++
+			}
+			cur = append(cur, ruleTerm{atom: rule[i:j]})
+			i = j
+		}
+	}
+	if len(stack) != 0 {
+		return nil, false
+	}
+	return cur, true
+}
@@ -0,0 +1,88 @@
+package pdf
+
+import "strings"
+
+// Glyph widths (1/1000 em) of the printable ASCII characters 0x20-0x7e in
+// Helvetica and Helvetica-Bold, from the Adobe font metrics
+var (
+	regularWidths = [95]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}
+	boldWidths = [95]int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}
+)
+
+// TextWidth returns the width of s in points; characters outside ASCII are
+// measured as digits
+func TextWidth(s string, size float64, font Font) float64 {
+	widths := &regularWidths
+	if font == Bold {
+		widths = &boldWidths
+	}
+	total := 0
+	for _, c := range encode(s) {
+		if c >= 0x20 && c < 0x7f {
+			total += widths[c-0x20]
+		} else {
+			total += 556
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// Wrap breaks s into lines no wider than width; words longer than a line are
+// split
+func Wrap(s string, width, size float64, font Font) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(s) {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+		if TextWidth(candidate, size, font) <= width {
+			line = candidate
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+		runes := []rune(word)
+		for TextWidth(string(runes), size, font) > width && len(runes) > 1 {
+			cut := len(runes) - 1
+			for cut > 1 && TextWidth(string(runes[:cut]), size, font) > width {
+				cut--
+			}
+			lines = append(lines, string(runes[:cut]))
+			runes = runes[cut:]
+		}
+		line = string(runes)
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// Truncate shortens s with "..." so that it fits width
+func Truncate(s string, width, size float64, font Font) string {
+	if TextWidth(s, size, font) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && TextWidth(string(runes)+"...", size, font) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
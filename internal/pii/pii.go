@@ -0,0 +1,341 @@
+// Package pii encrypts personal data attribute values at rest with
+// envelope encryption.
+//
+// Each value is sealed with AES-256-GCM under its own random data key,
+// bound to the case and attribute it belongs to. The data key is wrapped
+// by a key encryption key from a KeyProvider and stored with the
+// ciphertext; the row records which key wrapped it (key_id). Rotating keys
+// re-wraps data keys without touching the ciphertexts.
+//
+// Keys come from a KeyProvider. The env driver reads them from the
+// configuration (PII_KEYS); a KMS is added by registering a Driver whose
+// provider asks the service to wrap and unwrap data keys.
+package pii
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+)
+
+// keySize is the size of data and key encryption keys (AES-256)
+const keySize = 32
+
+// envelopePrefix marks the format of stored values
+const envelopePrefix = "v1."
+
+// ErasedKeyID is the key_id of a value erased for a data subject
+// (internal/dsr): its data key and ciphertext, or its plaintext, are gone
+// and it reads as empty
+const ErasedKeyID = "erased"
+
+var (
+	// ErrNoKeys is returned when a value must be encrypted or decrypted and
+	// no encryption key is configured
+	ErrNoKeys = errors.New("no encryption keys configured")
+	// ErrUnknownKey is returned for a value wrapped by a key the provider
+	// does not have
+	ErrUnknownKey = errors.New("unknown encryption key")
+	// ErrMalformed is returned for a stored value that is not an envelope
+	ErrMalformed = errors.New("malformed encrypted value")
+)
+
+// KeyProvider wraps and unwraps data keys with key encryption keys it may
+// never expose, as a KMS does
+type KeyProvider interface {
+	// KeyID names the key that wraps new data keys
+	KeyID() string
+	// WrapKey encrypts a data key with the KeyID key
+	WrapKey(ctx context.Context, dataKey []byte) ([]byte, error)
+	// UnwrapKey decrypts a data key wrapped by the key named keyID
+	UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+}
+
+// Driver creates the KeyProvider configured by cfg
+type Driver func(cfg config.EncryptionConfig) (KeyProvider, error)
+
+var (
+	driversMu sync.RWMutex
+	drivers   = map[string]Driver{"env": newEnvProvider}
+)
+
+// RegisterDriver makes a key provider driver available as
+// encryption.driver, e.g. a KMS client. It replaces a driver registered
+// under the same name.
+func RegisterDriver(name string, driver Driver) {
+	driversMu.Lock()
+	defer driversMu.Unlock()
+	drivers[name] = driver
+}
+
+// Cipher seals and opens attribute values
+type Cipher struct {
+	// keys is nil when no key is configured
+	keys       KeyProvider
+	enabled    bool
+	attributes map[string]bool
+}
+
+// New creates the cipher configured by cfg. Without keys it opens and
+// seals nothing, returning ErrNoKeys.
+func New(cfg config.EncryptionConfig) (*Cipher, error) {
+	c := &Cipher{enabled: cfg.Enabled, attributes: make(map[string]bool, len(cfg.Attributes))}
+	for _, code := range cfg.Attributes {
+		c.attributes[strings.ToUpper(strings.TrimSpace(code))] = true
+	}
+	if !cfg.Enabled && len(cfg.Keys) == 0 && (cfg.Driver == "env" || cfg.Driver == "") {
+		return c, nil
+	}
+
+	driversMu.RLock()
+	driver, ok := drivers[cfg.Driver]
+	names := make([]string, 0, len(drivers))
+	for name := range drivers {
+		names = append(names, name)
+	}
+	driversMu.RUnlock()
+	if !ok {
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown encryption driver %q (registered: %v)", cfg.Driver, names)
+	}
+	keys, err := driver(cfg)
+	if err != nil {
+		return nil, err
+	}
+	c.keys = keys
+	return c, nil
+}
+
+var (
+	defaultOnce   sync.Once
+	defaultCipher *Cipher
+	defaultErr    error
+)
+
+// Default returns the cipher of the current configuration (config.Current),
+// created on first use
+func Default() (*Cipher, error) {
+	defaultOnce.Do(func() {
+		defaultCipher, defaultErr = New(config.Current().Encryption)
+	})
+	return defaultCipher, defaultErr
+}
+
+// KeyID is the key that wraps the data keys of new values; empty without
+// keys
+func (c *Cipher) KeyID() string {
+	if c.keys == nil {
+		return ""
+	}
+	return c.keys.KeyID()
+}
+
+// Protects reports whether new values of an attribute are encrypted: when
+// encryption is enabled, for personal data and configured attributes
+func (c *Cipher) Protects(attributeCode string, personal bool) bool {
+	return c.enabled && (personal || c.attributes[attributeCode])
+}
+
+// Attributes are the attributes encrypted in addition to personal data
+func (c *Cipher) Attributes() []string {
+	codes := make([]string, 0, len(c.attributes))
+	for code := range c.attributes {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// FieldAAD binds a value to the case and attribute it belongs to, so a
+// ciphertext copied to another row does not open
+func FieldAAD(caseName, attributeCode string) []byte {
+	return []byte("kyc-attribute-value\x00" + caseName + "\x00" + attributeCode)
+}
+
+// Seal encrypts a value under a new data key and returns the envelope to
+// store and the key that wrapped the data key
+func (c *Cipher) Seal(ctx context.Context, plaintext string, aad []byte) (string, string, error) {
+	if c.keys == nil {
+		return "", "", ErrNoKeys
+	}
+	dataKey := make([]byte, keySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return "", "", fmt.Errorf("failed to generate data key: %w", err)
+	}
+	sealed, err := seal(dataKey, []byte(plaintext), aad)
+	if err != nil {
+		return "", "", err
+	}
+	keyID := c.keys.KeyID()
+	wrapped, err := c.keys.WrapKey(ctx, dataKey)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to wrap data key with %s: %w", keyID, err)
+	}
+	return envelope(wrapped, sealed), keyID, nil
+}
+
+// Open decrypts an envelope whose data key was wrapped by keyID
+func (c *Cipher) Open(ctx context.Context, value, keyID string, aad []byte) (string, error) {
+	if c.keys == nil {
+		return "", ErrNoKeys
+	}
+	wrapped, sealed, err := parseEnvelope(value)
+	if err != nil {
+		return "", err
+	}
+	dataKey, err := c.keys.UnwrapKey(ctx, keyID, wrapped)
+	if err != nil {
+		return "", fmt.Errorf("failed to unwrap data key with %s: %w", keyID, err)
+	}
+	plaintext, err := open(dataKey, sealed, aad)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// Rewrap re-wraps the data key of an envelope with the current key,
+// leaving the ciphertext as it is, and returns the new envelope and key
+func (c *Cipher) Rewrap(ctx context.Context, value, keyID string) (string, string, error) {
+	if c.keys == nil {
+		return "", "", ErrNoKeys
+	}
+	wrapped, sealed, err := parseEnvelope(value)
+	if err != nil {
+		return "", "", err
+	}
+	dataKey, err := c.keys.UnwrapKey(ctx, keyID, wrapped)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to unwrap data key with %s: %w", keyID, err)
+	}
+	newKeyID := c.keys.KeyID()
+	rewrapped, err := c.keys.WrapKey(ctx, dataKey)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to wrap data key with %s: %w", newKeyID, err)
+	}
+	return envelope(rewrapped, sealed), newKeyID, nil
+}
+
+// GenerateKey returns a new random key encryption key in the base64 form
+// the env driver reads
+func GenerateKey() (string, error) {
+	key := make([]byte, keySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return "", fmt.Errorf("failed to generate key: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+func envelope(wrapped, sealed []byte) string {
+	return envelopePrefix + base64.StdEncoding.EncodeToString(wrapped) + "." + base64.StdEncoding.EncodeToString(sealed)
+}
+
+func parseEnvelope(value string) ([]byte, []byte, error) {
+	rest, ok := strings.CutPrefix(value, envelopePrefix)
+	if !ok {
+		return nil, nil, ErrMalformed
+	}
+	w, s, ok := strings.Cut(rest, ".")
+	if !ok {
+		return nil, nil, ErrMalformed
+	}
+	wrapped, err := base64.StdEncoding.DecodeString(w)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	return wrapped, sealed, nil
+}
+
+// seal encrypts with AES-GCM, prefixing the random nonce
+func seal(key, plaintext, aad []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return gcm.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+// open decrypts what seal returned
+func open(key, sealed, aad []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, ErrMalformed
+	}
+	plaintext, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], aad)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt value: %w", err)
+	}
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// envProvider holds key encryption keys read from the configuration
+type envProvider struct {
+	keyID string
+	keys  map[string][]byte
+}
+
+// newEnvProvider reads encryption.keys, each id:base64 of 32 bytes
+func newEnvProvider(cfg config.EncryptionConfig) (KeyProvider, error) {
+	p := &envProvider{keyID: cfg.KeyID, keys: make(map[string][]byte, len(cfg.Keys))}
+	for i, entry := range cfg.Keys {
+		id, encoded, ok := strings.Cut(entry, ":")
+		if !ok || id == "" {
+			// The entry may be a bare key; it is not repeated in the error
+			return nil, fmt.Errorf("encryption key %d must be id:base64", i+1)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(key) != keySize {
+			return nil, fmt.Errorf("encryption key %s must be %d base64-encoded bytes", id, keySize)
+		}
+		p.keys[id] = key
+	}
+	if _, ok := p.keys[p.keyID]; !ok {
+		return nil, fmt.Errorf("%w: key_id %q is not among the encryption keys", ErrUnknownKey, p.keyID)
+	}
+	return p, nil
+}
+
+func (p *envProvider) KeyID() string {
+	return p.keyID
+}
+
+func (p *envProvider) WrapKey(_ context.Context, dataKey []byte) ([]byte, error) {
+	return seal(p.keys[p.keyID], dataKey, []byte("kyc-data-key\x00"+p.keyID))
+}
+
+func (p *envProvider) UnwrapKey(_ context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	key, ok := p.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, keyID)
+	}
+	return open(key, wrapped, []byte("kyc-data-key\x00"+keyID))
+}
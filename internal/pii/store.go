@@ -0,0 +1,192 @@
+package pii
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// Tables holding encrypted attribute values, with the rows that can be:
+// captured values and their data dictionary mirror, not derived entries
+var tables = []struct{ name, where string }{
+	{"kyc_case_attribute_values", "TRUE"},
+	{"kyc_case_data_dictionary", "NOT derived"},
+}
+
+// personalData is true when attribute $2 of case $1 is personal data in
+// the global ontology or in the overlay of the case's tenant; an overlay
+// cannot declassify a global personal data attribute
+const personalData = `
+	EXISTS (SELECT 1 FROM kyc_attributes WHERE code = $2 AND is_personal_data)
+	OR EXISTS (SELECT 1 FROM kyc_overlay_attributes o
+	            WHERE o.code = $2 AND o.is_personal_data
+	              AND o.tenant = (SELECT tenant FROM kyc_cases WHERE name = $1 ORDER BY id DESC LIMIT 1))`
+
+// Personal reports whether an attribute of a case is personal data
+func Personal(ctx context.Context, db sqlx.QueryerContext, caseName, attributeCode string) (bool, error) {
+	var personal bool
+	if err := sqlx.GetContext(ctx, db, &personal, `SELECT `+personalData, caseName, attributeCode); err != nil {
+		return false, fmt.Errorf("failed to look up whether %s is personal data: %w", attributeCode, err)
+	}
+	return personal, nil
+}
+
+// Reveal returns the plaintext of a stored value of a case attribute:
+// value itself when keyID is empty, nothing when it was erased, else the
+// decrypted envelope
+func Reveal(ctx context.Context, caseName, attributeCode, value, keyID string) (string, error) {
+	switch keyID {
+	case "":
+		return value, nil
+	case ErasedKeyID:
+		return "", nil
+	}
+	c, err := Default()
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := c.Open(ctx, value, keyID, FieldAAD(caseName, attributeCode))
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt %s of %s: %w", attributeCode, caseName, err)
+	}
+	return plaintext, nil
+}
+
+// KeyUsage counts the stored values of a table under one key; an empty
+// KeyID counts plaintext values of attributes the cipher protects. Erased
+// values are not counted.
+type KeyUsage struct {
+	Table  string `db:"table_name" json:"table"`
+	KeyID  string `db:"key_id" json:"key_id"`
+	Values int    `db:"value_count" json:"values"`
+}
+
+// Status counts the encrypted values of each table by key, and the
+// plaintext values of protected attributes that rotation with plaintext
+// would encrypt
+func (c *Cipher) Status(ctx context.Context, db *sqlx.DB) ([]KeyUsage, error) {
+	usage := []KeyUsage{}
+	for _, t := range tables {
+		var rows []KeyUsage
+		if err := db.SelectContext(ctx, &rows, `
+			SELECT $1::text AS table_name, COALESCE(key_id, '') AS key_id, COUNT(*) AS value_count
+			  FROM `+t.name+` v
+			 WHERE `+t.where+`
+			   AND (key_id IS NOT NULL OR v.attribute_code = ANY($2) OR `+rowPersonal+`)
+			   AND key_id IS DISTINCT FROM $3
+			 GROUP BY key_id
+			 ORDER BY key_id NULLS FIRST`, t.name, pq.Array(c.Attributes()), ErasedKeyID); err != nil {
+			return nil, fmt.Errorf("failed to count encrypted values of %s: %w", t.name, err)
+		}
+		usage = append(usage, rows...)
+	}
+	return usage, nil
+}
+
+// rowPersonal is personalData for the row v of a value table
+const rowPersonal = `(
+	EXISTS (SELECT 1 FROM kyc_attributes WHERE code = v.attribute_code AND is_personal_data)
+	OR EXISTS (SELECT 1 FROM kyc_overlay_attributes o
+	            WHERE o.code = v.attribute_code AND o.is_personal_data
+	              AND o.tenant = (SELECT tenant FROM kyc_cases WHERE name = v.case_name ORDER BY id DESC LIMIT 1)))`
+
+// Rotation counts what Rotate changed per table
+type Rotation struct {
+	Table     string `json:"table"`
+	Rewrapped int    `json:"rewrapped"`
+	Encrypted int    `json:"encrypted"`
+}
+
+// storedValue is a row of a value table as Rotate reads it; ref is its
+// ctid, stable while the row is locked
+type storedValue struct {
+	Ref           string `db:"ref"`
+	CaseName      string `db:"case_name"`
+	AttributeCode string `db:"attribute_code"`
+	Value         string `db:"value"`
+	KeyID         string `db:"key_id"`
+}
+
+// Rotate re-wraps the data keys of values under other keys with the
+// current key, batchSize rows per transaction. With plaintext it also
+// encrypts the plaintext values of protected attributes, as stored before
+// encryption was enabled. Retired keys can be removed once no value uses
+// them (Status).
+func (c *Cipher) Rotate(ctx context.Context, db *sqlx.DB, plaintext bool, batchSize int) ([]Rotation, error) {
+	if c.keys == nil {
+		return nil, ErrNoKeys
+	}
+	keyID := c.keys.KeyID()
+	rotations := make([]Rotation, 0, len(tables))
+	for _, t := range tables {
+		r := Rotation{Table: t.name}
+		rewrap := func(v storedValue) (string, string, error) { return c.Rewrap(ctx, v.Value, v.KeyID) }
+		n, err := rewrite(ctx, db, t.name, `
+			SELECT ctid::text AS ref, case_name, attribute_code, COALESCE(value, '') AS value, key_id
+			  FROM `+t.name+`
+			 WHERE `+t.where+` AND key_id IS NOT NULL AND key_id NOT IN ($1, $3)
+			 LIMIT $2`, []interface{}{keyID, batchSize, ErasedKeyID}, rewrap)
+		r.Rewrapped = n
+		if err != nil {
+			return append(rotations, r), err
+		}
+
+		if plaintext {
+			encrypt := func(v storedValue) (string, string, error) {
+				return c.Seal(ctx, v.Value, FieldAAD(v.CaseName, v.AttributeCode))
+			}
+			n, err := rewrite(ctx, db, t.name, `
+				SELECT ctid::text AS ref, case_name, attribute_code, value, '' AS key_id
+				  FROM `+t.name+` v
+				 WHERE `+t.where+` AND key_id IS NULL AND value IS NOT NULL
+				   AND (v.attribute_code = ANY($1) OR `+rowPersonal+`)
+				 LIMIT $2`, []interface{}{pq.Array(c.Attributes()), batchSize}, encrypt)
+			r.Encrypted = n
+			if err != nil {
+				return append(rotations, r), err
+			}
+		}
+		rotations = append(rotations, r)
+	}
+	return rotations, nil
+}
+
+// rewrite replaces, batch by batch until none is left, the value and key
+// of the rows query selects with what fn returns for them. Rows locked by
+// a concurrent capture are skipped.
+func rewrite(ctx context.Context, db *sqlx.DB, table, query string, args []interface{}, fn func(storedValue) (string, string, error)) (int, error) {
+	total := 0
+	for {
+		tx, err := db.BeginTxx(ctx, nil)
+		if err != nil {
+			return total, fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		var batch []storedValue
+		if err := tx.SelectContext(ctx, &batch, query+` FOR UPDATE SKIP LOCKED`, args...); err != nil {
+			_ = tx.Rollback()
+			return total, fmt.Errorf("failed to read values of %s: %w", table, err)
+		}
+		if len(batch) == 0 {
+			_ = tx.Rollback()
+			return total, nil
+		}
+		for _, v := range batch {
+			value, keyID, err := fn(v)
+			if err != nil {
+				_ = tx.Rollback()
+				return total, fmt.Errorf("%s of %s in %s: %w", v.AttributeCode, v.CaseName, table, err)
+			}
+			if _, err := tx.ExecContext(ctx, `
+				UPDATE `+table+` SET value = $2, key_id = $3 WHERE ctid = $1::tid`, v.Ref, value, keyID); err != nil {
+				_ = tx.Rollback()
+				return total, fmt.Errorf("failed to update %s of %s in %s: %w", v.AttributeCode, v.CaseName, table, err)
+			}
+		}
+		if err := tx.Commit(); err != nil {
+			return total, fmt.Errorf("failed to commit values of %s: %w", table, err)
+		}
+		total += len(batch)
+	}
+}
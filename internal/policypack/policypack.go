@@ -0,0 +1,451 @@
+// Package policypack stores jurisdiction policy packs: versioned sets of
+// the documents, attributes, thresholds, policy and obligations a
+// regulation (AMLD5, MAS 626, BSA) requires of the cases of a jurisdiction.
+// The policy-discovery and document-discovery amendments (internal/amend)
+// and the completeness report (internal/casedata) read the active version
+// of each pack instead of hard-coding requirements.
+package policypack
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+var (
+	// ErrNotFound is returned for an unknown pack or pack version
+	ErrNotFound = errors.New("policy pack not found")
+	// ErrInvalid is returned for a pack version that cannot be saved
+	ErrInvalid = errors.New("invalid policy pack")
+	// ErrStatus is returned for a status change the version's status does
+	// not allow
+	ErrStatus = errors.New("policy pack status does not allow this change")
+)
+
+// validCode matches pack codes
+var validCode = regexp.MustCompile(`^[A-Z][A-Z0-9_-]*$`)
+
+// Repo stores policy packs
+type Repo struct {
+	db *sqlx.DB
+}
+
+// NewRepo creates a new policy pack repository
+func NewRepo(db *sqlx.DB) *Repo {
+	return &Repo{db: db}
+}
+
+// packRow scans a kyc_policy_packs row
+type packRow struct {
+	ID              int64          `db:"id"`
+	Code            string         `db:"code"`
+	Version         int            `db:"version"`
+	Name            string         `db:"name"`
+	Jurisdiction    string         `db:"jurisdiction"`
+	RegulationCode  string         `db:"regulation_code"`
+	PolicyCode      string         `db:"policy_code"`
+	Obligations     pq.StringArray `db:"obligations"`
+	Description     string         `db:"description"`
+	Status          string         `db:"status"`
+	CreatedBy       string         `db:"created_by"`
+	CreatedAt       time.Time      `db:"created_at"`
+	StatusChangedBy string         `db:"status_changed_by"`
+	StatusChangedAt *time.Time     `db:"status_changed_at"`
+}
+
+func (r packRow) toModel() model.PolicyPack {
+	obligations := []string(r.Obligations)
+	if obligations == nil {
+		obligations = []string{}
+	}
+	return model.PolicyPack{
+		ID:              r.ID,
+		Code:            r.Code,
+		Version:         r.Version,
+		Name:            r.Name,
+		Jurisdiction:    r.Jurisdiction,
+		RegulationCode:  r.RegulationCode,
+		PolicyCode:      r.PolicyCode,
+		Obligations:     obligations,
+		Description:     r.Description,
+		Status:          r.Status,
+		Documents:       []model.PolicyPackDocument{},
+		Attributes:      []model.PolicyPackAttribute{},
+		Thresholds:      []model.PolicyPackThreshold{},
+		CreatedBy:       r.CreatedBy,
+		CreatedAt:       r.CreatedAt,
+		StatusChangedBy: r.StatusChangedBy,
+		StatusChangedAt: r.StatusChangedAt,
+	}
+}
+
+const packColumns = `
+	id, code, version, name, jurisdiction, COALESCE(regulation_code, '') AS regulation_code,
+	COALESCE(policy_code, '') AS policy_code, obligations, COALESCE(description, '') AS description,
+	status, COALESCE(created_by, '') AS created_by, created_at,
+	COALESCE(status_changed_by, '') AS status_changed_by, status_changed_at
+`
+
+// List returns the active version of every pack, or of the packs for a
+// jurisdiction when one is given, by code
+func (r *Repo) List(ctx context.Context, jurisdiction string) ([]model.PolicyPack, error) {
+	var rows []packRow
+	if err := r.db.SelectContext(ctx, &rows, `
+		SELECT `+packColumns+` FROM kyc_policy_packs
+		 WHERE status = 'active' AND ($1 = '' OR upper(jurisdiction) = upper($1))
+		 ORDER BY code`, jurisdiction); err != nil {
+		return nil, fmt.Errorf("failed to list policy packs: %w", err)
+	}
+	return r.load(ctx, rows)
+}
+
+// ForJurisdictions returns the active packs of any of the jurisdictions
+// or whose policy is one of the policy codes, by code
+func (r *Repo) ForJurisdictions(ctx context.Context, jurisdictions, policies []string) ([]model.PolicyPack, error) {
+	upper := make([]string, 0, len(jurisdictions))
+	for _, j := range jurisdictions {
+		upper = append(upper, strings.ToUpper(strings.TrimSpace(j)))
+	}
+	var rows []packRow
+	if err := r.db.SelectContext(ctx, &rows, `
+		SELECT `+packColumns+` FROM kyc_policy_packs
+		 WHERE status = 'active' AND (upper(jurisdiction) = ANY($1) OR policy_code = ANY($2))
+		 ORDER BY code`, pq.Array(upper), pq.Array(policies)); err != nil {
+		return nil, fmt.Errorf("failed to load policy packs: %w", err)
+	}
+	return r.load(ctx, rows)
+}
+
+// Get returns a version of a pack; version 0 is the active version, or
+// the latest when none is active
+func (r *Repo) Get(ctx context.Context, code string, version int) (*model.PolicyPack, error) {
+	var row packRow
+	var err error
+	if version == 0 {
+		err = r.db.GetContext(ctx, &row, `
+			SELECT `+packColumns+` FROM kyc_policy_packs WHERE code = $1
+			 ORDER BY status = 'active' DESC, version DESC LIMIT 1`, code)
+	} else {
+		err = r.db.GetContext(ctx, &row, `
+			SELECT `+packColumns+` FROM kyc_policy_packs WHERE code = $1 AND version = $2`, code, version)
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		if version == 0 {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, code)
+		}
+		return nil, fmt.Errorf("%w: %s version %d", ErrNotFound, code, version)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get policy pack %s: %w", code, err)
+	}
+	packs, err := r.load(ctx, []packRow{row})
+	if err != nil {
+		return nil, err
+	}
+	return &packs[0], nil
+}
+
+// Versions returns every version of a pack, newest first, without their
+// documents, attributes and thresholds
+func (r *Repo) Versions(ctx context.Context, code string) ([]model.PolicyPack, error) {
+	var rows []packRow
+	if err := r.db.SelectContext(ctx, &rows, `
+		SELECT `+packColumns+` FROM kyc_policy_packs WHERE code = $1 ORDER BY version DESC`, code); err != nil {
+		return nil, fmt.Errorf("failed to list versions of policy pack %s: %w", code, err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, code)
+	}
+	versions := make([]model.PolicyPack, 0, len(rows))
+	for _, row := range rows {
+		versions = append(versions, row.toModel())
+	}
+	return versions, nil
+}
+
+// Draft saves a pack as a new draft version of its code (version 1 for a
+// new pack). Its documents and attributes must be in the ontology.
+func (r *Repo) Draft(ctx context.Context, p model.PolicyPack, createdBy string) (*model.PolicyPack, error) {
+	p.Code = strings.ToUpper(strings.TrimSpace(p.Code))
+	p.Jurisdiction = strings.ToUpper(strings.TrimSpace(p.Jurisdiction))
+	switch {
+	case !validCode.MatchString(p.Code):
+		return nil, fmt.Errorf("%w: code %q must use upper-case letters, digits, hyphens and underscores", ErrInvalid, p.Code)
+	case p.Jurisdiction == "":
+		return nil, fmt.Errorf("%w: jurisdiction is required", ErrInvalid)
+	case strings.TrimSpace(p.Name) == "":
+		p.Name = p.Code
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	documents := make([]string, 0, len(p.Documents))
+	for _, d := range p.Documents {
+		documents = append(documents, strings.ToUpper(strings.TrimSpace(d.Code)))
+	}
+	if err := checkCodes(ctx, tx, "document", `SELECT code FROM kyc_documents WHERE code = ANY($1)`, documents); err != nil {
+		return nil, err
+	}
+	attributes := make([]string, 0, len(p.Attributes))
+	for _, a := range p.Attributes {
+		attributes = append(attributes, strings.ToUpper(strings.TrimSpace(a.Code)))
+	}
+	if err := checkCodes(ctx, tx, "attribute", `SELECT code FROM kyc_attributes WHERE code = ANY($1)`, attributes); err != nil {
+		return nil, err
+	}
+	for _, t := range p.Thresholds {
+		if strings.TrimSpace(t.Name) == "" {
+			return nil, fmt.Errorf("%w: thresholds need a name", ErrInvalid)
+		}
+	}
+	if p.RegulationCode != "" {
+		var exists bool
+		if err := tx.GetContext(ctx, &exists, `SELECT EXISTS (SELECT 1 FROM kyc_regulations WHERE code = $1)`, p.RegulationCode); err != nil {
+			return nil, fmt.Errorf("failed to look up regulation %s: %w", p.RegulationCode, err)
+		}
+		if !exists {
+			return nil, fmt.Errorf("%w: unknown regulation %s", ErrInvalid, p.RegulationCode)
+		}
+	}
+
+	// Serialize drafts of the same pack
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext('kyc_policy_packs:' || $1))`, p.Code); err != nil {
+		return nil, fmt.Errorf("failed to lock policy pack %s: %w", p.Code, err)
+	}
+	var id int64
+	var version int
+	if err := tx.QueryRowxContext(ctx, `
+		INSERT INTO kyc_policy_packs (code, version, name, jurisdiction, regulation_code, policy_code,
+		                              obligations, description, created_by)
+		SELECT $1, COALESCE(MAX(version), 0) + 1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6, NULLIF($7, ''), NULLIF($8, '')
+		  FROM kyc_policy_packs WHERE code = $1
+		RETURNING id, version`,
+		p.Code, p.Name, p.Jurisdiction, p.RegulationCode, p.PolicyCode, pq.Array(nonNil(p.Obligations)),
+		p.Description, createdBy).Scan(&id, &version); err != nil {
+		return nil, fmt.Errorf("failed to save policy pack %s: %w", p.Code, err)
+	}
+	for i, d := range p.Documents {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO kyc_policy_pack_documents (pack_id, document_code, mandatory) VALUES ($1, $2, $3)
+			ON CONFLICT (pack_id, document_code) DO UPDATE SET mandatory = EXCLUDED.mandatory`,
+			id, documents[i], d.Mandatory); err != nil {
+			return nil, fmt.Errorf("failed to save document %s of policy pack %s: %w", documents[i], p.Code, err)
+		}
+	}
+	for i, a := range p.Attributes {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO kyc_policy_pack_attributes (pack_id, attribute_code, mandatory) VALUES ($1, $2, $3)
+			ON CONFLICT (pack_id, attribute_code) DO UPDATE SET mandatory = EXCLUDED.mandatory`,
+			id, attributes[i], a.Mandatory); err != nil {
+			return nil, fmt.Errorf("failed to save attribute %s of policy pack %s: %w", attributes[i], p.Code, err)
+		}
+	}
+	for _, t := range p.Thresholds {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO kyc_policy_pack_thresholds (pack_id, name, value, unit, description)
+			VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''))
+			ON CONFLICT (pack_id, name) DO UPDATE
+			   SET value = EXCLUDED.value, unit = EXCLUDED.unit, description = EXCLUDED.description`,
+			id, strings.TrimSpace(t.Name), t.Value, t.Unit, t.Description); err != nil {
+			return nil, fmt.Errorf("failed to save threshold %s of policy pack %s: %w", t.Name, p.Code, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit policy pack %s: %w", p.Code, err)
+	}
+	return r.Get(ctx, p.Code, version)
+}
+
+// Activate makes a draft version of a pack the active one, retiring the
+// version that was active
+func (r *Repo) Activate(ctx context.Context, code string, version int, changedBy string) (*model.PolicyPack, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if err := checkStatus(ctx, tx, code, version, model.PolicyPackDraft); err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE kyc_policy_packs
+		   SET status = 'retired', status_changed_by = NULLIF($2, ''), status_changed_at = CURRENT_TIMESTAMP
+		 WHERE code = $1 AND status = 'active'`, code, changedBy); err != nil {
+		return nil, fmt.Errorf("failed to retire the active version of %s: %w", code, err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE kyc_policy_packs
+		   SET status = 'active', status_changed_by = NULLIF($3, ''), status_changed_at = CURRENT_TIMESTAMP
+		 WHERE code = $1 AND version = $2`, code, version, changedBy); err != nil {
+		return nil, fmt.Errorf("failed to activate %s version %d: %w", code, version, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit activation of %s: %w", code, err)
+	}
+	return r.Get(ctx, code, version)
+}
+
+// Retire retires a draft or active version of a pack; retiring the active
+// version leaves the pack without requirements until another is activated
+func (r *Repo) Retire(ctx context.Context, code string, version int, changedBy string) (*model.PolicyPack, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if err := checkStatus(ctx, tx, code, version, model.PolicyPackDraft, model.PolicyPackActive); err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE kyc_policy_packs
+		   SET status = 'retired', status_changed_by = NULLIF($3, ''), status_changed_at = CURRENT_TIMESTAMP
+		 WHERE code = $1 AND version = $2`, code, version, changedBy); err != nil {
+		return nil, fmt.Errorf("failed to retire %s version %d: %w", code, version, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit retirement of %s: %w", code, err)
+	}
+	return r.Get(ctx, code, version)
+}
+
+// Threshold returns the value of a named threshold of a pack and whether
+// the pack sets it
+func Threshold(p model.PolicyPack, name string) (float64, bool) {
+	for _, t := range p.Thresholds {
+		if strings.EqualFold(t.Name, name) {
+			return t.Value, true
+		}
+	}
+	return 0, false
+}
+
+// load fills in the documents, attributes and thresholds of packs
+func (r *Repo) load(ctx context.Context, rows []packRow) ([]model.PolicyPack, error) {
+	packs := make([]model.PolicyPack, 0, len(rows))
+	if len(rows) == 0 {
+		return packs, nil
+	}
+	ids := make([]int64, 0, len(rows))
+	byID := map[int64]int{}
+	for i, row := range rows {
+		packs = append(packs, row.toModel())
+		ids = append(ids, row.ID)
+		byID[row.ID] = i
+	}
+
+	var documents []struct {
+		PackID int64 `db:"pack_id"`
+		model.PolicyPackDocument
+	}
+	if err := r.db.SelectContext(ctx, &documents, `
+		SELECT pd.pack_id, pd.document_code, COALESCE(d.name, '') AS name, pd.mandatory
+		  FROM kyc_policy_pack_documents pd
+		  LEFT JOIN kyc_documents d ON d.code = pd.document_code
+		 WHERE pd.pack_id = ANY($1)
+		 ORDER BY pd.pack_id, pd.mandatory DESC, pd.document_code`, pq.Array(ids)); err != nil {
+		return nil, fmt.Errorf("failed to load policy pack documents: %w", err)
+	}
+	for _, d := range documents {
+		p := &packs[byID[d.PackID]]
+		p.Documents = append(p.Documents, d.PolicyPackDocument)
+	}
+
+	var attributes []struct {
+		PackID int64 `db:"pack_id"`
+		model.PolicyPackAttribute
+	}
+	if err := r.db.SelectContext(ctx, &attributes, `
+		SELECT pack_id, attribute_code, mandatory FROM kyc_policy_pack_attributes
+		 WHERE pack_id = ANY($1) ORDER BY pack_id, attribute_code`, pq.Array(ids)); err != nil {
+		return nil, fmt.Errorf("failed to load policy pack attributes: %w", err)
+	}
+	for _, a := range attributes {
+		p := &packs[byID[a.PackID]]
+		p.Attributes = append(p.Attributes, a.PolicyPackAttribute)
+	}
+
+	var thresholds []struct {
+		PackID int64 `db:"pack_id"`
+		model.PolicyPackThreshold
+	}
+	if err := r.db.SelectContext(ctx, &thresholds, `
+		SELECT pack_id, name, value, COALESCE(unit, '') AS unit, COALESCE(description, '') AS description
+		  FROM kyc_policy_pack_thresholds
+		 WHERE pack_id = ANY($1) ORDER BY pack_id, name`, pq.Array(ids)); err != nil {
+		return nil, fmt.Errorf("failed to load policy pack thresholds: %w", err)
+	}
+	for _, t := range thresholds {
+		p := &packs[byID[t.PackID]]
+		p.Thresholds = append(p.Thresholds, t.PolicyPackThreshold)
+	}
+	return packs, nil
+}
+
+// checkCodes fails with ErrInvalid naming the codes query does not find
+func checkCodes(ctx context.Context, tx *sqlx.Tx, kind, query string, codes []string) error {
+	if len(codes) == 0 {
+		return nil
+	}
+	var found []string
+	if err := tx.SelectContext(ctx, &found, query, pq.Array(codes)); err != nil {
+		return fmt.Errorf("failed to look up %ss: %w", kind, err)
+	}
+	known := map[string]bool{}
+	for _, c := range found {
+		known[c] = true
+	}
+	var unknown []string
+	for _, c := range codes {
+		if !known[c] {
+			unknown = append(unknown, c)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("%w: unknown %ss %s", ErrInvalid, kind, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// checkStatus locks a pack version and fails unless it has one of the
+// statuses
+func checkStatus(ctx context.Context, tx *sqlx.Tx, code string, version int, allowed ...string) error {
+	var status string
+	err := tx.GetContext(ctx, &status, `
+		SELECT status FROM kyc_policy_packs WHERE code = $1 AND version = $2 FOR UPDATE`, code, version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: %s version %d", ErrNotFound, code, version)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to look up %s version %d: %w", code, version, err)
+	}
+	for _, s := range allowed {
+		if status == s {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s version %d is %s", ErrStatus, code, version, status)
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
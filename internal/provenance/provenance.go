@@ -0,0 +1,129 @@
+// Package provenance describes where an API record came from: its source
+// table, when and by whom it was last modified, and the version of the
+// ontology it was read from. Reads return it only when asked
+// (include_provenance), so consumers can cache records and reconcile them
+// against later reads.
+package provenance
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// Source tables records can be traced to
+const (
+	TableAttributes        = "kyc_attributes"
+	TableAttributeMetadata = "kyc_attribute_metadata"
+	TableDocuments         = "kyc_documents"
+	TableRegulations       = "kyc_regulations"
+)
+
+// keyColumns are the columns identifying a record of each source table
+var keyColumns = map[string]string{
+	TableAttributes:        "code",
+	TableAttributeMetadata: "attribute_code",
+	TableDocuments:         "code",
+	TableRegulations:       "code",
+}
+
+// Record is the provenance of one record
+type Record struct {
+	SourceTable     string    `json:"source_table"`
+	LastModified    time.Time `json:"last_modified"`
+	ModifiedBy      string    `json:"modified_by,omitempty"`
+	OntologyVersion string    `json:"ontology_version"`
+}
+
+// Lookup returns the provenance of records of a source table by key. Keys
+// without a row are left out.
+func Lookup(ctx context.Context, db *sqlx.DB, table string, keys []string) (map[string]Record, error) {
+	keyColumn, ok := keyColumns[table]
+	if !ok {
+		return nil, fmt.Errorf("no provenance for table %s", table)
+	}
+	records := make(map[string]Record, len(keys))
+	if len(keys) == 0 {
+		return records, nil
+	}
+
+	version, err := OntologyVersion(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		Key          string    `db:"key"`
+		LastModified time.Time `db:"last_modified"`
+		ModifiedBy   string    `db:"modified_by"`
+	}
+	// table and keyColumn come from keyColumns, never from the request
+	err = db.SelectContext(ctx, &rows, `
+		SELECT `+keyColumn+` AS key,
+		       COALESCE(updated_at, created_at, NOW()) AS last_modified,
+		       COALESCE(updated_by, '') AS modified_by
+		FROM `+table+`
+		WHERE `+keyColumn+` = ANY($1)`, pq.Array(keys))
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up provenance in %s: %w", table, err)
+	}
+	for _, row := range rows {
+		records[row.Key] = Record{
+			SourceTable:     table,
+			LastModified:    row.LastModified.UTC(),
+			ModifiedBy:      row.ModifiedBy,
+			OntologyVersion: version,
+		}
+	}
+	return records, nil
+}
+
+// OntologyVersion identifies the current state of the ontology: a digest of
+// the row count and latest change of each ontology table, so it changes
+// whenever a record is added, modified or removed
+func OntologyVersion(ctx context.Context, db *sqlx.DB) (string, error) {
+	var version string
+	err := db.GetContext(ctx, &version, `
+		SELECT LEFT(md5(string_agg(t || ':' || n || ':' || COALESCE(m, ''), ',' ORDER BY t)), 12)
+		FROM (
+			SELECT 'attributes' AS t, COUNT(*) AS n, MAX(updated_at)::text AS m FROM kyc_attributes
+			UNION ALL
+			SELECT 'metadata', COUNT(*), MAX(updated_at)::text FROM kyc_attribute_metadata
+			UNION ALL
+			SELECT 'documents', COUNT(*), MAX(updated_at)::text FROM kyc_documents
+			UNION ALL
+			SELECT 'regulations', COUNT(*), MAX(updated_at)::text FROM kyc_regulations
+			UNION ALL
+			SELECT 'links', COUNT(*), MAX(id)::text FROM kyc_attr_doc_links
+		) tables`)
+	if err != nil {
+		return "", fmt.Errorf("failed to compute ontology version: %w", err)
+	}
+	return version, nil
+}
+
+// Requested reports whether an HTTP read asked for provenance
+// (include_provenance=true)
+func Requested(r *http.Request) bool {
+	switch r.URL.Query().Get("include_provenance") {
+	case "true", "1", "yes":
+		return true
+	}
+	return false
+}
+
+// SetHeaders describes the provenance of a single-record response in its
+// headers, including Last-Modified for HTTP caches
+func SetHeaders(h http.Header, rec Record) {
+	h.Set("Last-Modified", rec.LastModified.Format(http.TimeFormat))
+	h.Set("X-Provenance-Source", rec.SourceTable)
+	h.Set("X-Provenance-Last-Modified", rec.LastModified.Format(time.RFC3339))
+	if rec.ModifiedBy != "" {
+		h.Set("X-Provenance-Modified-By", rec.ModifiedBy)
+	}
+	h.Set("X-Ontology-Version", rec.OntologyVersion)
+}
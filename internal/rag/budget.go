@@ -0,0 +1,83 @@
+package rag
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// ErrSpendLimit is returned when an embedding request would exceed the budget
+var ErrSpendLimit = errors.New("embedding spend limit reached")
+
+// embeddingPricePerMillion is the OpenAI list price in USD per million tokens
+var embeddingPricePerMillion = map[openai.EmbeddingModel]float64{
+	openai.LargeEmbedding3: 0.13,
+	openai.SmallEmbedding3: 0.02,
+	openai.AdaEmbeddingV2:  0.10,
+}
+
+// EstimateTokens approximates the token count of text (about 4 characters per token)
+func EstimateTokens(text string) int {
+	if text == "" {
+		return 0
+	}
+	return (len(text) + 3) / 4
+}
+
+// EmbeddingCost returns the list price in USD of embedding tokens with model
+func EmbeddingCost(model openai.EmbeddingModel, tokens int64) float64 {
+	price, ok := embeddingPricePerMillion[model]
+	if !ok {
+		price = embeddingPricePerMillion[openai.LargeEmbedding3]
+	}
+	return float64(tokens) * price / 1_000_000
+}
+
+// Budget caps the spend of a run of embedding requests. A zero MaxSpend
+// means unlimited; usage is still tracked.
+type Budget struct {
+	MaxSpend float64
+
+	mu     sync.Mutex
+	model  openai.EmbeddingModel
+	tokens int64
+}
+
+// NewBudget creates a budget of maxSpend USD for model
+func NewBudget(model openai.EmbeddingModel, maxSpend float64) *Budget {
+	return &Budget{MaxSpend: maxSpend, model: model}
+}
+
+// reserve checks that a request of about estimate tokens fits in the budget
+func (b *Budget) reserve(estimate int) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.MaxSpend <= 0 {
+		return nil
+	}
+	if EmbeddingCost(b.model, b.tokens+int64(estimate)) > b.MaxSpend {
+		return fmt.Errorf("%w: $%.4f spent of $%.4f", ErrSpendLimit, EmbeddingCost(b.model, b.tokens), b.MaxSpend)
+	}
+	return nil
+}
+
+// record adds the tokens reported for a completed request
+func (b *Budget) record(tokens int) {
+	b.mu.Lock()
+	b.tokens += int64(tokens)
+	b.mu.Unlock()
+}
+
+// Tokens returns the tokens consumed so far
+func (b *Budget) Tokens() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.tokens
+}
+
+// Spent returns the spend so far in USD
+func (b *Budget) Spent() float64 {
+	return EmbeddingCost(b.model, b.Tokens())
+}
@@ -0,0 +1,224 @@
+package rag
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	kycconfig "github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/metrics"
+)
+
+// cacheWriteTimeout bounds a Postgres cache write, which outlives its request
+const cacheWriteTimeout = 2 * time.Second
+
+// EmbeddingCache keeps query embeddings by model and normalized query text in
+// a size-bounded LRU, backed by rag_query_embedding_cache when a database is
+// set. Postgres failures are logged and treated as misses, so the cache never
+// fails a search.
+type EmbeddingCache struct {
+	db  *sqlx.DB
+	ttl time.Duration
+	max int
+
+	mu      sync.Mutex
+	lru     *list.List
+	entries map[string]*list.Element
+
+	memoryHits, postgresHits, misses atomic.Int64
+}
+
+type cacheEntry struct {
+	key       string
+	embedding []float32
+	expires   time.Time
+}
+
+// CacheStats summarizes the cache since the process started
+type CacheStats struct {
+	Entries      int     `json:"entries"`
+	Size         int     `json:"size"`
+	TTL          string  `json:"ttl"`
+	Persist      bool    `json:"persist"`
+	MemoryHits   int64   `json:"memory_hits"`
+	PostgresHits int64   `json:"postgres_hits"`
+	Misses       int64   `json:"misses"`
+	HitRate      float64 `json:"hit_rate"`
+}
+
+// NewEmbeddingCache creates a cache for the embedding_cache configuration. db
+// is used when Persist is set and may be nil otherwise.
+func NewEmbeddingCache(db *sqlx.DB, cfg kycconfig.EmbeddingCacheConfig) *EmbeddingCache {
+	c := &EmbeddingCache{
+		ttl:     cfg.TTL,
+		max:     cfg.Size,
+		lru:     list.New(),
+		entries: make(map[string]*list.Element),
+	}
+	if cfg.Persist {
+		c.db = db
+	}
+	return c
+}
+
+// NormalizeQuery lower-cases text and collapses whitespace, so queries that
+// differ only in case or spacing share an embedding
+func NormalizeQuery(text string) string {
+	return strings.Join(strings.Fields(strings.ToLower(text)), " ")
+}
+
+func cacheKey(model, query string) string {
+	return model + "\x00" + query
+}
+
+// Get returns the cached embedding of a query for a model
+func (c *EmbeddingCache) Get(ctx context.Context, model, text string) ([]float32, bool) {
+	query := NormalizeQuery(text)
+	key := cacheKey(model, query)
+
+	c.mu.Lock()
+	if el, ok := c.entries[key]; ok {
+		e := el.Value.(*cacheEntry)
+		if time.Now().Before(e.expires) {
+			c.lru.MoveToFront(el)
+			c.mu.Unlock()
+			c.memoryHits.Add(1)
+			metrics.ObserveEmbeddingCache(model, metrics.EmbeddingCacheMemoryHit)
+			return e.embedding, true
+		}
+		c.lru.Remove(el)
+		delete(c.entries, key)
+	}
+	c.mu.Unlock()
+
+	if c.db != nil {
+		var row struct {
+			Embedding pq.Float32Array `db:"embedding"`
+			ExpiresAt time.Time       `db:"expires_at"`
+		}
+		err := c.db.GetContext(ctx, &row, `
+			UPDATE rag_query_embedding_cache
+			SET hits = hits + 1, last_hit_at = CURRENT_TIMESTAMP
+			WHERE model = $1 AND query_key = $2 AND expires_at > CURRENT_TIMESTAMP
+			RETURNING embedding, expires_at`, model, query)
+		switch {
+		case err == nil:
+			c.remember(key, row.Embedding, row.ExpiresAt)
+			c.postgresHits.Add(1)
+			metrics.ObserveEmbeddingCache(model, metrics.EmbeddingCachePostgresHit)
+			return row.Embedding, true
+		case !errors.Is(err, sql.ErrNoRows):
+			slog.Warn("⚠️  Embedding cache lookup failed", "error", err)
+		}
+	}
+
+	c.misses.Add(1)
+	metrics.ObserveEmbeddingCache(model, metrics.EmbeddingCacheMiss)
+	return nil, false
+}
+
+// Put caches the embedding of a query for a model
+func (c *EmbeddingCache) Put(ctx context.Context, model, text string, embedding []float32) {
+	query := NormalizeQuery(text)
+	expires := time.Now().Add(c.ttl)
+	c.remember(cacheKey(model, query), embedding, expires)
+
+	if c.db == nil {
+		return
+	}
+	writeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cacheWriteTimeout)
+	defer cancel()
+	_, err := c.db.ExecContext(writeCtx, `
+		INSERT INTO rag_query_embedding_cache (model, query_key, embedding, expires_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (model, query_key) DO UPDATE SET
+			embedding = EXCLUDED.embedding,
+			created_at = CURRENT_TIMESTAMP,
+			expires_at = EXCLUDED.expires_at`,
+		model, query, pq.Array(embedding), expires.UTC())
+	if err != nil {
+		slog.Warn("⚠️  Embedding cache write failed", "error", err)
+	}
+}
+
+// remember stores an entry in memory, evicting the least recently used
+func (c *EmbeddingCache) remember(key string, embedding []float32, expires time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		e := el.Value.(*cacheEntry)
+		e.embedding, e.expires = embedding, expires
+		c.lru.MoveToFront(el)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(&cacheEntry{key: key, embedding: embedding, expires: expires})
+	for c.lru.Len() > c.max {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// Stats returns the size, settings and hit rate of the cache
+func (c *EmbeddingCache) Stats() CacheStats {
+	c.mu.Lock()
+	entries := c.lru.Len()
+	c.mu.Unlock()
+
+	s := CacheStats{
+		Entries:      entries,
+		Size:         c.max,
+		TTL:          c.ttl.String(),
+		Persist:      c.db != nil,
+		MemoryHits:   c.memoryHits.Load(),
+		PostgresHits: c.postgresHits.Load(),
+		Misses:       c.misses.Load(),
+	}
+	if lookups := s.MemoryHits + s.PostgresHits + s.Misses; lookups > 0 {
+		s.HitRate = float64(s.MemoryHits+s.PostgresHits) / float64(lookups)
+	}
+	return s
+}
+
+// Purge deletes expired Postgres entries and returns how many were removed
+func (c *EmbeddingCache) Purge(ctx context.Context) (int64, error) {
+	if c.db == nil {
+		return 0, nil
+	}
+	res, err := c.db.ExecContext(ctx, `DELETE FROM rag_query_embedding_cache WHERE expires_at <= CURRENT_TIMESTAMP`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge embedding cache: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+// RunPurger purges expired Postgres entries every interval until ctx is cancelled
+func (c *EmbeddingCache) RunPurger(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if n, err := c.Purge(ctx); err != nil {
+			slog.Warn("⚠️  Embedding cache purge failed", "error", err)
+		} else if n > 0 {
+			slog.Info("🧹 Purged expired query embeddings", "count", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
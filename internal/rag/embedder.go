@@ -2,13 +2,22 @@ package rag
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"os"
+	"net/http"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	openai "github.com/sashabaranov/go-openai"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/time/rate"
 
+	kycconfig "github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/metrics"
 	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/tracing"
 )
 
 // Embedder handles generation of vector embeddings using OpenAI API
@@ -18,39 +27,81 @@ type Embedder struct {
 	maxRetries int
 	retryDelay time.Duration
 	dimensions int
+	budget     *Budget
+	limiter    *rate.Limiter
+	cache      *EmbeddingCache
+	outage     *outage
+}
+
+// ErrUnavailable is returned while the embeddings provider is down or not
+// configured; searches then degrade to text search and background embedding
+// work waits for the provider
+var ErrUnavailable = errors.New("embeddings provider unavailable")
+
+// outageCooldown is how long embedding requests fail fast after the provider
+// stopped answering, so degraded searches do not each wait out the retries
+const outageCooldown = 30 * time.Second
+
+// outage records until when the provider is considered down
+type outage struct {
+	until atomic.Int64 // unix nanoseconds
+}
+
+func (o *outage) down() bool {
+	return o != nil && time.Now().UnixNano() < o.until.Load()
+}
+
+func (o *outage) record() {
+	if o != nil {
+		o.until.Store(time.Now().Add(outageCooldown).UnixNano())
+	}
 }
 
 // EmbedderConfig configures the embedder
 type EmbedderConfig struct {
-	APIKey     string
-	Model      openai.EmbeddingModel
+	APIKey string
+	Model  openai.EmbeddingModel
+	// Dimensions shortens text-embedding-3 embeddings to this size
+	Dimensions int
 	MaxRetries int
 	RetryDelay time.Duration
 }
 
-// NewEmbedder creates a new embedder with OpenAI client
+// NewEmbedder creates a new embedder with OpenAI client for the configured
+// primary embedding space (openai.embedding_model, default text-embedding-3-large)
 func NewEmbedder() *Embedder {
-	apiKey := os.Getenv("OPENAI_API_KEY")
-	if apiKey == "" {
+	cfg := kycconfig.Current().OpenAI
+	if cfg.APIKey == "" {
 		panic("OPENAI_API_KEY environment variable not set")
 	}
 
 	return &Embedder{
-		client:     openai.NewClient(apiKey),
-		model:      openai.LargeEmbedding3, // text-embedding-3-large
+		client:     newOpenAIClient(cfg.APIKey),
+		model:      openai.EmbeddingModel(cfg.EmbeddingModel),
 		maxRetries: 3,
 		retryDelay: 2 * time.Second,
-		dimensions: 1536,
+		dimensions: cfg.EmbeddingDimensions,
+		outage:     &outage{},
 	}
 }
 
+// newOpenAIClient creates an OpenAI client whose HTTP calls are traced
+func newOpenAIClient(apiKey string) *openai.Client {
+	config := openai.DefaultConfig(apiKey)
+	config.HTTPClient = &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)}
+	return openai.NewClientWithConfig(config)
+}
+
 // NewEmbedderWithConfig creates an embedder with custom configuration
 func NewEmbedderWithConfig(config EmbedderConfig) *Embedder {
 	if config.APIKey == "" {
-		config.APIKey = os.Getenv("OPENAI_API_KEY")
+		config.APIKey = kycconfig.Current().OpenAI.APIKey
 	}
 	if config.Model == "" {
-		config.Model = openai.LargeEmbedding3
+		config.Model = openai.EmbeddingModel(kycconfig.Current().OpenAI.EmbeddingModel)
+	}
+	if config.Dimensions == 0 {
+		config.Dimensions = kycconfig.Current().OpenAI.EmbeddingDimensions
 	}
 	if config.MaxRetries == 0 {
 		config.MaxRetries = 3
@@ -60,16 +111,69 @@ func NewEmbedderWithConfig(config EmbedderConfig) *Embedder {
 	}
 
 	return &Embedder{
-		client:     openai.NewClient(config.APIKey),
+		client:     newOpenAIClient(config.APIKey),
 		model:      config.Model,
 		maxRetries: config.MaxRetries,
 		retryDelay: config.RetryDelay,
-		dimensions: 1536,
+		dimensions: config.Dimensions,
+		outage:     &outage{},
+	}
+}
+
+// ForSpace returns an embedder for another embedding model and size that
+// shares this one's client, budget, rate limit and query cache
+func (e *Embedder) ForSpace(model string, dimensions int) *Embedder {
+	if e == nil {
+		return nil
+	}
+	space := *e
+	space.model = openai.EmbeddingModel(model)
+	space.dimensions = dimensions
+	return &space
+}
+
+// WithBudget stops requests once the budget's spend limit would be exceeded
+// and records the token usage of every request against it
+func (e *Embedder) WithBudget(b *Budget) *Embedder {
+	e.budget = b
+	return e
+}
+
+// WithRateLimit caps the embedder at requestsPerMinute API calls
+func (e *Embedder) WithRateLimit(requestsPerMinute int) *Embedder {
+	if requestsPerMinute > 0 {
+		e.limiter = rate.NewLimiter(rate.Limit(float64(requestsPerMinute)/60), 1)
 	}
+	return e
+}
+
+// WithCache serves GenerateEmbeddingFromText from the cache when it can and
+// caches what it generates; use it for query text, not metadata
+func (e *Embedder) WithCache(c *EmbeddingCache) *Embedder {
+	e.cache = c
+	return e
+}
+
+// Cache returns the query embedding cache, or nil
+func (e *Embedder) Cache() *EmbeddingCache {
+	if e == nil {
+		return nil
+	}
+	return e.cache
+}
+
+// Available reports whether query embeddings can be generated: an embedder
+// is configured and the provider has not failed within the last 30s. A nil
+// embedder (no API key) is never available.
+func (e *Embedder) Available() bool {
+	return e != nil && !e.outage.down()
 }
 
 // GenerateEmbedding generates a vector embedding for attribute metadata
 func (e *Embedder) GenerateEmbedding(ctx context.Context, m model.AttributeMetadata) ([]float32, error) {
+	if e == nil {
+		return nil, fmt.Errorf("%w: no API key configured", ErrUnavailable)
+	}
 	input := m.ToEmbeddingText()
 
 	if input == "" {
@@ -84,12 +188,12 @@ func (e *Embedder) GenerateEmbedding(ctx context.Context, m model.AttributeMetad
 				m.AttributeCode, attempt, e.maxRetries)
 		}
 
-		resp, err := e.client.CreateEmbeddings(ctx, openai.EmbeddingRequest{
-			Model: e.model,
-			Input: []string{input},
-		})
+		resp, err := e.createEmbeddings(ctx, e.request([]string{input}))
 
 		if err != nil {
+			if !retryable(ctx, err) {
+				return nil, err
+			}
 			lastErr = err
 			continue
 		}
@@ -102,15 +206,29 @@ func (e *Embedder) GenerateEmbedding(ctx context.Context, m model.AttributeMetad
 		return resp.Data[0].Embedding, nil
 	}
 
-	return nil, fmt.Errorf("failed to generate embedding after %d attempts: %w",
-		e.maxRetries, lastErr)
+	e.outage.record()
+	return nil, fmt.Errorf("%w: failed to generate embedding after %d attempts: %w",
+		ErrUnavailable, e.maxRetries, lastErr)
 }
 
-// GenerateEmbeddingFromText generates an embedding from raw text
+// GenerateEmbeddingFromText generates an embedding from raw text. A nil
+// embedder, or one whose provider failed within the last 30s, returns
+// ErrUnavailable unless the text's embedding is cached.
 func (e *Embedder) GenerateEmbeddingFromText(ctx context.Context, text string) ([]float32, error) {
+	if e == nil {
+		return nil, fmt.Errorf("%w: no API key configured", ErrUnavailable)
+	}
 	if text == "" {
 		return nil, fmt.Errorf("cannot generate embedding for empty text")
 	}
+	if e.cache != nil {
+		if embedding, ok := e.cache.Get(ctx, e.cacheModel(), text); ok {
+			return embedding, nil
+		}
+	}
+	if e.outage.down() {
+		return nil, fmt.Errorf("%w: retrying after a recent failure", ErrUnavailable)
+	}
 
 	var lastErr error
 	for attempt := 0; attempt <= e.maxRetries; attempt++ {
@@ -118,12 +236,12 @@ func (e *Embedder) GenerateEmbeddingFromText(ctx context.Context, text string) (
 			time.Sleep(e.retryDelay)
 		}
 
-		resp, err := e.client.CreateEmbeddings(ctx, openai.EmbeddingRequest{
-			Model: e.model,
-			Input: []string{text},
-		})
+		resp, err := e.createEmbeddings(ctx, e.request([]string{text}))
 
 		if err != nil {
+			if !retryable(ctx, err) {
+				return nil, err
+			}
 			lastErr = err
 			continue
 		}
@@ -133,11 +251,119 @@ func (e *Embedder) GenerateEmbeddingFromText(ctx context.Context, text string) (
 			continue
 		}
 
+		if e.cache != nil {
+			e.cache.Put(ctx, e.cacheModel(), text, resp.Data[0].Embedding)
+		}
 		return resp.Data[0].Embedding, nil
 	}
 
-	return nil, fmt.Errorf("failed to generate embedding after %d attempts: %w",
-		e.maxRetries, lastErr)
+	e.outage.record()
+	return nil, fmt.Errorf("%w: failed to generate embedding after %d attempts: %w",
+		ErrUnavailable, e.maxRetries, lastErr)
+}
+
+// GenerateEmbeddingsFromTexts embeds several texts in one request and
+// returns the embeddings in the order of texts
+func (e *Embedder) GenerateEmbeddingsFromTexts(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+	if e == nil {
+		return nil, fmt.Errorf("%w: no API key configured", ErrUnavailable)
+	}
+	if e.outage.down() {
+		return nil, fmt.Errorf("%w: retrying after a recent failure", ErrUnavailable)
+	}
+	for i, text := range texts {
+		if text == "" {
+			return nil, fmt.Errorf("cannot generate embedding for empty text (input %d)", i)
+		}
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= e.maxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(e.retryDelay)
+		}
+
+		resp, err := e.createEmbeddings(ctx, e.request(texts))
+		if err != nil {
+			if !retryable(ctx, err) {
+				return nil, err
+			}
+			lastErr = err
+			continue
+		}
+
+		if len(resp.Data) != len(texts) {
+			lastErr = fmt.Errorf("expected %d embeddings, got %d", len(texts), len(resp.Data))
+			continue
+		}
+
+		embeddings := make([][]float32, len(texts))
+		for _, d := range resp.Data {
+			if d.Index < 0 || d.Index >= len(texts) {
+				return nil, fmt.Errorf("embedding index %d out of range", d.Index)
+			}
+			embeddings[d.Index] = d.Embedding
+		}
+		return embeddings, nil
+	}
+
+	e.outage.record()
+	return nil, fmt.Errorf("%w: failed to generate %d embeddings after %d attempts: %w",
+		ErrUnavailable, len(texts), e.maxRetries, lastErr)
+}
+
+// request builds an embedding request; only the text-embedding-3 models
+// accept a dimensions parameter
+func (e *Embedder) request(input []string) openai.EmbeddingRequest {
+	req := openai.EmbeddingRequest{Model: e.model, Input: input}
+	if strings.HasPrefix(string(e.model), "text-embedding-3") {
+		req.Dimensions = e.dimensions
+	}
+	return req
+}
+
+// cacheModel keys cached query embeddings by model and size, since one
+// model can serve spaces of different dimensions
+func (e *Embedder) cacheModel() string {
+	return fmt.Sprintf("%s/%d", e.model, e.dimensions)
+}
+
+// retryable reports whether a failed request is worth retrying
+func retryable(ctx context.Context, err error) bool {
+	return ctx.Err() == nil && !errors.Is(err, ErrSpendLimit)
+}
+
+// createEmbeddings calls the embedding API and records its latency
+func (e *Embedder) createEmbeddings(ctx context.Context, req openai.EmbeddingRequest) (openai.EmbeddingResponse, error) {
+	if e.budget != nil {
+		estimate := 0
+		if inputs, ok := req.Input.([]string); ok {
+			for _, in := range inputs {
+				estimate += EstimateTokens(in)
+			}
+		}
+		if err := e.budget.reserve(estimate); err != nil {
+			return openai.EmbeddingResponse{}, err
+		}
+	}
+	if e.limiter != nil {
+		if err := e.limiter.Wait(ctx); err != nil {
+			return openai.EmbeddingResponse{}, err
+		}
+	}
+
+	ctx, span := tracing.Start(ctx, "embedding.create", attribute.String("embedding.model", string(req.Model)))
+	start := time.Now()
+	resp, err := e.client.CreateEmbeddings(ctx, req)
+	metrics.ObserveEmbedding(string(req.Model), start, err)
+	tracing.End(span, err)
+	if err == nil && e.budget != nil {
+		e.budget.record(resp.Usage.TotalTokens)
+	}
+	return resp, err
 }
 
 // GenerateBatchEmbeddings generates embeddings for multiple attributes
@@ -170,3 +396,15 @@ func (e *Embedder) GetDimensions() int {
 func (e *Embedder) GetModel() openai.EmbeddingModel {
 	return e.model
 }
+
+// Ping checks the embeddings provider answers and knows the model, without
+// generating (and paying for) an embedding
+func (e *Embedder) Ping(ctx context.Context) error {
+	if e == nil {
+		return fmt.Errorf("%w: no API key configured", ErrUnavailable)
+	}
+	if _, err := e.client.GetModel(ctx, string(e.model)); err != nil {
+		return fmt.Errorf("embeddings provider unreachable: %w", err)
+	}
+	return nil
+}
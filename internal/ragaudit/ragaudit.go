@@ -0,0 +1,125 @@
+// Package ragaudit writes search requests to the RAG audit log
+// (rag_audit_log) off the request path. Handlers hand each logged search to
+// a Writer, which queues it without blocking and inserts queued searches in
+// batches every audit_log.flush_interval, or sooner once audit_log.batch_size
+// are waiting. Successful searches are sampled (audit_log.query_sample_rate);
+// failures are always kept. When the queue is full, searches are dropped and
+// counted rather than delaying responses.
+package ragaudit
+
+import (
+	"context"
+	"log/slog"
+	"math/rand"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/metrics"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/ontology"
+)
+
+// flushTimeout bounds each batch insert, including the last one on shutdown
+const flushTimeout = 10 * time.Second
+
+// Writer queues logged searches and writes them in batches
+type Writer struct {
+	repo  *ontology.EnhancementsRepo
+	cfg   config.AuditLogConfig
+	queue chan model.RAGAuditLog
+	done  chan struct{}
+}
+
+// NewWriter creates a writer to rag_audit_log configured by cfg; Run must
+// be started for queued searches to be written
+func NewWriter(db *sqlx.DB, cfg config.AuditLogConfig) *Writer {
+	return &Writer{
+		repo:  ontology.NewEnhancementsRepo(db),
+		cfg:   cfg,
+		queue: make(chan model.RAGAuditLog, cfg.QueueSize),
+		done:  make(chan struct{}),
+	}
+}
+
+// Sampled reports whether a successful search should be logged
+func (w *Writer) Sampled() bool {
+	return w.cfg.QuerySampleRate >= 1 || rand.Float64() < w.cfg.QuerySampleRate
+}
+
+// Record queues a search to be written, dropping it when the queue is full
+func (w *Writer) Record(entry model.RAGAuditLog) {
+	select {
+	case w.queue <- entry:
+	default:
+		metrics.ObserveAuditLog(metrics.AuditLogDropped, 1)
+	}
+}
+
+// Run writes queued searches until ctx is cancelled, then writes those
+// still queued
+func (w *Writer) Run(ctx context.Context) {
+	defer close(w.done)
+	ticker := time.NewTicker(w.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]model.RAGAuditLog, 0, w.cfg.BatchSize)
+	for {
+		select {
+		case entry := <-w.queue:
+			batch = append(batch, entry)
+			if len(batch) >= w.cfg.BatchSize {
+				batch = w.flush(batch)
+			}
+		case <-ticker.C:
+			batch = w.flush(batch)
+		case <-ctx.Done():
+			for {
+				select {
+				case entry := <-w.queue:
+					batch = append(batch, entry)
+					if len(batch) >= w.cfg.BatchSize {
+						batch = w.flush(batch)
+					}
+				default:
+					w.flush(batch)
+					return
+				}
+			}
+		}
+	}
+}
+
+// Wait blocks until Run has written the searches queued when its context
+// was cancelled
+func (w *Writer) Wait() {
+	<-w.done
+}
+
+// flush writes a batch, falling back to one insert per search when the
+// batch insert fails so one bad entry does not lose the others, and returns
+// the emptied batch
+func (w *Writer) flush(batch []model.RAGAuditLog) []model.RAGAuditLog {
+	if len(batch) == 0 {
+		return batch
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+	defer cancel()
+
+	err := w.repo.LogQueries(ctx, batch)
+	if err == nil {
+		metrics.ObserveAuditLog(metrics.AuditLogWritten, len(batch))
+		return batch[:0]
+	}
+	slog.Warn("⚠️  Audit log batch failed, writing entries one at a time", "entries", len(batch), "error", err)
+	for _, entry := range batch {
+		if _, err := w.repo.LogQuery(ctx, entry); err != nil {
+			metrics.ObserveAuditLog(metrics.AuditLogFailed, 1)
+			slog.Warn("⚠️  Audit log entry not written", "endpoint", entry.Endpoint, "error", err)
+			continue
+		}
+		metrics.ObserveAuditLog(metrics.AuditLogWritten, 1)
+	}
+	return batch[:0]
+}
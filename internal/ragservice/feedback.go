@@ -0,0 +1,246 @@
+package ragservice
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/adamtc007/KYC-DSL/api/pb"
+	"github.com/adamtc007/KYC-DSL/internal/auth"
+	"github.com/adamtc007/KYC-DSL/internal/events"
+	"github.com/adamtc007/KYC-DSL/internal/feedbackpolicy"
+	"github.com/adamtc007/KYC-DSL/internal/logging"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/ontology"
+	"github.com/adamtc007/KYC-DSL/internal/ranking"
+)
+
+// tenant returns the tenant of a call: the X-Tenant-ID metadata, else the
+// default tenant
+func tenant(ctx context.Context) string {
+	header := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(strings.ToLower(auth.TenantHeader)); len(values) > 0 {
+			header = values[0]
+		}
+	}
+	return auth.Tenant(ctx, header)
+}
+
+// optional returns a pointer to s, or nil when it is empty
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// SubmitFeedback records feedback on a search result and applies the
+// tenant's feedback policy, as POST /rag/feedback does
+func (s *Server) SubmitFeedback(ctx context.Context, req *pb.RagFeedbackRequest) (*pb.RagFeedbackResponse, error) {
+	log := logging.FromContext(ctx)
+	if req.QueryText == "" {
+		return nil, status.Error(codes.InvalidArgument, "query_text is required")
+	}
+	if req.AttributeCode == "" && req.DocumentCode == "" && req.RegulationCode == "" {
+		return nil, status.Error(codes.InvalidArgument,
+			"at least one of attribute_code, document_code, or regulation_code must be provided")
+	}
+
+	feedback := model.Feedback{
+		QueryText:      req.QueryText,
+		AttributeCode:  optional(req.AttributeCode),
+		DocumentCode:   optional(req.DocumentCode),
+		RegulationCode: optional(req.RegulationCode),
+		Feedback:       model.FeedbackSentiment(req.Feedback),
+		Confidence:     float64(req.Confidence),
+		AgentName:      optional(req.AgentName),
+		AgentType:      model.AgentType(req.AgentType),
+		Tenant:         tenant(ctx),
+	}
+
+	// Same defaults as the REST API
+	if feedback.Feedback == "" {
+		feedback.Feedback = model.FeedbackSentimentPositive
+	}
+	if feedback.AgentType == "" {
+		feedback.AgentType = model.AgentTypeHuman
+	}
+	if feedback.Confidence == 0 {
+		feedback.Confidence = 1.0
+	}
+	if feedback.Confidence < 0 || feedback.Confidence > 1 {
+		return nil, status.Error(codes.InvalidArgument, "confidence must be between 0 and 1")
+	}
+	log.Info("👍 SubmitFeedback", "feedback", feedback.Feedback, "agent_name", req.AgentName, "tenant", feedback.Tenant)
+
+	// Group attribute feedback by the cluster of its query for ranking
+	if feedback.AttributeCode != nil {
+		if vec, err := s.embedder.GenerateEmbeddingFromText(ctx, req.QueryText); err != nil {
+			log.Warn("⚠️  Feedback stored without a query cluster", "error", err)
+		} else if cluster, err := ranking.QueryCluster(ctx, s.db, vec); err != nil {
+			log.Warn("⚠️  Query cluster unavailable", "error", err)
+		} else if cluster != "" {
+			feedback.QueryCluster = &cluster
+		}
+	}
+
+	id, err := ontology.NewFeedbackRepo(s.db).InsertFeedback(feedback)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to save feedback: %v", err)
+	}
+	events.NotifyFeedback(ctx, s.db, id, feedback)
+
+	resp := &pb.RagFeedbackResponse{
+		Status:    "ok",
+		Id:        int32(id),
+		Feedback:  string(feedback.Feedback),
+		AgentName: req.AgentName,
+		CreatedAt: timestamppb.Now(),
+	}
+
+	// The feedback is stored either way; a policy failure only loses its actions
+	if outcome, err := feedbackpolicy.NewRepo(s.db).Apply(ctx, id, feedback); err != nil {
+		log.Warn("⚠️  Feedback policy not applied", "feedback_id", id, "error", err)
+	} else {
+		resp.Outcome = &pb.FeedbackOutcome{Tenant: outcome.Tenant, Gated: outcome.Gated, Reason: outcome.Reason}
+		for _, a := range outcome.Actions {
+			resp.Outcome.Actions = append(resp.Outcome.Actions, a.Action)
+		}
+	}
+	return resp, nil
+}
+
+// GetRecentFeedback streams the most recent feedback entries, newest first
+func (s *Server) GetRecentFeedback(req *pb.GetRecentFeedbackRequest, stream grpc.ServerStreamingServer[pb.RagFeedback]) error {
+	limit := limitOr(req.Limit, defaultFeedbackLimit)
+	logging.FromContext(stream.Context()).Info("📜 GetRecentFeedback", "limit", limit)
+
+	feedbacks, err := ontology.NewFeedbackRepo(s.db).GetRecentFeedback(limit)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get recent feedback: %v", err)
+	}
+	for _, f := range feedbacks {
+		if err := stream.Send(feedbackProto(f)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetFeedbackAnalytics retrieves feedback totals, per-agent-type counts and
+// the attributes with the most feedback
+func (s *Server) GetFeedbackAnalytics(ctx context.Context, req *pb.GetFeedbackAnalyticsRequest) (*pb.FeedbackAnalytics, error) {
+	top := limitOr(req.Top, defaultTop)
+	logging.FromContext(ctx).Info("📈 GetFeedbackAnalytics", "top", top)
+
+	analytics, err := ontology.NewFeedbackRepo(s.db).GetFeedbackAnalytics(top)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get feedback analytics: %v", err)
+	}
+
+	resp := &pb.FeedbackAnalytics{
+		TotalFeedback: int32(analytics.TotalFeedback),
+		PositiveCount: int32(analytics.PositiveCount),
+		NegativeCount: int32(analytics.NegativeCount),
+		NeutralCount:  int32(analytics.NeutralCount),
+		AvgConfidence: float32(analytics.AvgConfidence),
+		ByAgentType:   make(map[string]int32, len(analytics.ByAgentType)),
+	}
+	for agentType, count := range analytics.ByAgentType {
+		resp.ByAgentType[string(agentType)] = int32(count)
+	}
+	for _, a := range analytics.TopAttributes {
+		resp.TopAttributes = append(resp.TopAttributes, &pb.AttributeFeedbackSummary{
+			AttributeCode: a.AttributeCode,
+			Feedback:      string(a.Feedback),
+			FeedbackCount: int32(a.FeedbackCount),
+			AvgConfidence: float32(a.AvgConfidence),
+			AgentTypes:    a.AgentTypes,
+		})
+	}
+	for _, f := range analytics.RecentFeedback {
+		resp.RecentFeedback = append(resp.RecentFeedback, feedbackProto(f))
+	}
+	return resp, nil
+}
+
+// GetAuditStats retrieves RAG query audit statistics with the most popular
+// queries and per-agent performance
+func (s *Server) GetAuditStats(ctx context.Context, req *pb.GetAuditStatsRequest) (*pb.AuditStats, error) {
+	top := limitOr(req.Top, defaultTop)
+	logging.FromContext(ctx).Info("🧾 GetAuditStats", "top", top)
+
+	repo := ontology.NewEnhancementsRepo(s.db)
+	stats, err := repo.GetAuditStats(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get audit stats: %v", err)
+	}
+	resp := &pb.AuditStats{
+		TotalQueries:     int32(stats["total_queries"].(int)),
+		QueriesToday:     int32(stats["queries_today"].(int)),
+		ErrorCount:       int32(stats["error_count"].(int)),
+		ErrorRatePercent: float32(stats["error_rate"].(float64)),
+		AvgLatencyMs:     float32(stats["avg_latency_ms"].(float64)),
+		UniqueAgents:     int32(stats["unique_agents"].(int)),
+	}
+
+	popular, err := repo.GetPopularQueries(ctx, top)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	for _, q := range popular {
+		resp.PopularQueries = append(resp.PopularQueries, &pb.PopularQuery{
+			QueryText:    q.QueryText,
+			QueryCount:   int32(q.QueryCount),
+			AvgLatencyMs: float32(q.AvgLatencyMs),
+			AvgResults:   float32(q.AvgResults),
+			LastQueried:  timestamppb.New(q.LastQueried),
+		})
+	}
+
+	agents, err := repo.GetAgentPerformance(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	for _, a := range agents {
+		resp.Agents = append(resp.Agents, &pb.AgentPerformance{
+			AgentName:    a.AgentName,
+			Sessions:     int32(a.Sessions),
+			TotalQueries: int32(a.TotalQueries),
+			AvgLatencyMs: float32(a.AvgLatencyMs),
+			ErrorCount:   int32(a.ErrorCount),
+			SuccessRate:  float32(a.SuccessRate),
+		})
+	}
+	return resp, nil
+}
+
+// feedbackProto converts a stored feedback
+func feedbackProto(f model.Feedback) *pb.RagFeedback {
+	return &pb.RagFeedback{
+		Id:             int32(f.ID),
+		QueryText:      f.QueryText,
+		AttributeCode:  deref(f.AttributeCode),
+		DocumentCode:   deref(f.DocumentCode),
+		RegulationCode: deref(f.RegulationCode),
+		Feedback:       string(f.Feedback),
+		Confidence:     float32(f.Confidence),
+		AgentName:      deref(f.AgentName),
+		AgentType:      string(f.AgentType),
+		CreatedAt:      timestamppb.New(f.CreatedAt),
+	}
+}
+
+// deref returns *s, or "" when s is nil
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
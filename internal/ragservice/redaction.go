@@ -0,0 +1,108 @@
+package ragservice
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/adamtc007/KYC-DSL/api/pb"
+	"github.com/adamtc007/KYC-DSL/internal/auth"
+	"github.com/adamtc007/KYC-DSL/internal/redact"
+)
+
+// redaction masks the example values of sensitive attributes in the
+// responses of one call for callers without the pii:read scope, as the REST
+// API does, and audits the values it reveals otherwise
+type redaction struct {
+	s      *Server
+	x      *redact.Response
+	tenant string
+}
+
+// example is the attribute and example values of one result
+type example struct {
+	code   string
+	risk   string
+	values *[]string
+}
+
+// redactResults masks the example values of the search results of a
+// unary call and audits the ones it reveals
+func (s *Server) redactResults(ctx context.Context, results ...*pb.RagResult) error {
+	r := s.redaction(ctx)
+	if err := r.results(ctx, results...); err != nil {
+		return err
+	}
+	return r.audit(ctx)
+}
+
+func (s *Server) redaction(ctx context.Context) *redaction {
+	method, _ := grpc.Method(ctx)
+	var tenant string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(auth.TenantMetadataKey); len(values) > 0 {
+			tenant = values[0]
+		}
+	}
+	return &redaction{
+		s:      s,
+		x:      redact.NewCall(ctx, s.db, method, ""),
+		tenant: auth.Tenant(ctx, tenant),
+	}
+}
+
+// results masks the example values of search results
+func (r *redaction) results(ctx context.Context, results ...*pb.RagResult) error {
+	examples := make([]example, len(results))
+	for i, res := range results {
+		examples[i] = example{code: res.AttributeCode, risk: res.RiskLevel, values: &res.ExampleValues}
+	}
+	return r.mask(ctx, examples)
+}
+
+// mask masks the example values of attributes that are personal data in
+// the caller's tenant or rated CRITICAL
+func (r *redaction) mask(ctx context.Context, examples []example) error {
+	var attributes []string
+	for _, e := range examples {
+		if len(*e.values) > 0 {
+			attributes = append(attributes, e.code)
+		}
+	}
+	if len(attributes) == 0 {
+		return nil
+	}
+	sensitive, err := redact.Sensitive(ctx, r.s.db, r.tenant, attributes)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to redact results: %v", err)
+	}
+	for _, e := range examples {
+		if len(*e.values) == 0 || !(sensitive[e.code] || e.risk == "CRITICAL") {
+			continue
+		}
+		if !r.x.Field(e.code) {
+			*e.values = maskAll(*e.values)
+		}
+	}
+	return nil
+}
+
+// audit records the sensitive values the call revealed
+func (r *redaction) audit(ctx context.Context) error {
+	if err := r.x.Audit(ctx); err != nil {
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+	return nil
+}
+
+// maskAll replaces every value with redact.Marker
+func maskAll(values []string) []string {
+	masked := make([]string, len(values))
+	for i := range masked {
+		masked[i] = redact.Marker
+	}
+	return masked
+}
@@ -0,0 +1,408 @@
+// Package ragservice implements the kyc.rag.RagService gRPC service: the
+// semantic search, section and cluster search, feedback and audit operations
+// of the REST RAG API, for gRPC consumers such as the Rust engine and the UI.
+package ragservice
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/adamtc007/KYC-DSL/api/pb"
+	kycdb "github.com/adamtc007/KYC-DSL/internal/db"
+	"github.com/adamtc007/KYC-DSL/internal/logging"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/ontology"
+	"github.com/adamtc007/KYC-DSL/internal/rag"
+)
+
+// Default result counts, as in the REST API
+const (
+	defaultLimit         = 10
+	defaultStreamLimit   = 100
+	maxStreamLimit       = 5000
+	defaultClusters      = 3
+	defaultFeedbackLimit = 50
+	defaultTop           = 10
+)
+
+// Server implements the RagService gRPC service
+type Server struct {
+	pb.UnimplementedRagServiceServer
+	db       *sqlx.DB
+	embedder *rag.Embedder
+}
+
+// NewServer creates a RagService; embedder must match the primary embedding
+// space the queries are compared with
+func NewServer(db *sqlx.DB, embedder *rag.Embedder) *Server {
+	return &Server{db: db, embedder: embedder}
+}
+
+// limitOr returns limit, or def when it is not positive
+func limitOr(limit int32, def int) int {
+	if limit > 0 {
+		return int(limit)
+	}
+	return def
+}
+
+// embedQuery embeds a search query
+func (s *Server) embedQuery(ctx context.Context, query string) ([]float32, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, status.Error(codes.InvalidArgument, "query is required")
+	}
+	vec, err := s.embedder.GenerateEmbeddingFromText(ctx, query)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to generate query embedding: %v", err)
+	}
+	return vec, nil
+}
+
+// AttributeSearch performs semantic vector search on attributes
+func (s *Server) AttributeSearch(ctx context.Context, req *pb.RagSearchRequest) (*pb.RagSearchResponse, error) {
+	limit := limitOr(req.Limit, defaultLimit)
+	logging.FromContext(ctx).Info("🔍 AttributeSearch", "query", req.Query, "limit", limit)
+
+	vec, err := s.embedQuery(ctx, req.Query)
+	if err != nil {
+		return nil, err
+	}
+	results, err := ontology.NewMetadataRepo(s.db).SearchByVector(ctx, vec, limit)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to search: %v", err)
+	}
+	resp := searchResponse(req.Query, limit, results)
+	if err := s.redactResults(ctx, resp.Results...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// SearchAttributesStream performs semantic vector search on attributes and
+// sends each result as soon as it is read from the database, so a client
+// asking for many results can render the closest ones first
+func (s *Server) SearchAttributesStream(req *pb.RagSearchRequest, stream grpc.ServerStreamingServer[pb.RagResult]) error {
+	ctx := stream.Context()
+	limit := min(limitOr(req.Limit, defaultStreamLimit), maxStreamLimit)
+	logging.FromContext(ctx).Info("🔍 SearchAttributesStream", "query", req.Query, "limit", limit)
+
+	vec, err := s.embedQuery(ctx, req.Query)
+	if err != nil {
+		return err
+	}
+	sent := 0
+	redaction := s.redaction(ctx)
+	err = ontology.NewMetadataRepo(s.db).StreamByVector(ctx, vec, limit, func(r model.AttributeSearchResult) error {
+		res := ragResult(r.AttributeMetadata, r.SimilarityScore, r.Distance)
+		if err := redaction.results(ctx, res); err != nil {
+			return err
+		}
+		sent++
+		return stream.Send(res)
+	})
+	// Audit what was revealed even when the stream was cut short
+	if auditErr := redaction.audit(ctx); auditErr != nil && err == nil {
+		return auditErr
+	}
+	if err != nil {
+		if sent > 0 {
+			// Results already sent; the stream status tells the client it was cut short
+			return status.Errorf(codes.Aborted, "search interrupted after %d results: %v", sent, err)
+		}
+		return status.Errorf(codes.Internal, "failed to search: %v", err)
+	}
+	return nil
+}
+
+// SimilarAttributes finds attributes similar to a given attribute
+func (s *Server) SimilarAttributes(ctx context.Context, req *pb.SimilarAttributesRequest) (*pb.RagSearchResponse, error) {
+	if req.AttributeCode == "" {
+		return nil, status.Error(codes.InvalidArgument, "attribute_code is required")
+	}
+	limit := limitOr(req.Limit, defaultLimit)
+	logging.FromContext(ctx).Info("🔗 SimilarAttributes", "attribute_code", req.AttributeCode, "limit", limit)
+
+	results, err := ontology.NewMetadataRepo(s.db).FindSimilarAttributes(ctx, req.AttributeCode, limit)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to find similar attributes: %v", err)
+	}
+	resp := searchResponse(req.AttributeCode, limit, results)
+	if err := s.redactResults(ctx, resp.Results...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// TextSearch performs keyword search on attribute codes, definitions and synonyms
+func (s *Server) TextSearch(ctx context.Context, req *pb.TextSearchRequest) (*pb.RagSearchResponse, error) {
+	if req.Term == "" {
+		return nil, status.Error(codes.InvalidArgument, "term is required")
+	}
+	limit := limitOr(req.Limit, defaultLimit)
+	logging.FromContext(ctx).Info("🔤 TextSearch", "term", req.Term, "limit", limit)
+
+	results, err := ontology.NewMetadataRepo(s.db).SearchByText(ctx, req.Term)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to search: %v", err)
+	}
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	resp := &pb.RagSearchResponse{Query: req.Term, Limit: int32(limit), Count: int32(len(results))}
+	for _, m := range results {
+		resp.Results = append(resp.Results, ragResult(m, 0, 0))
+	}
+	if err := s.redactResults(ctx, resp.Results...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetAttribute retrieves complete metadata for an attribute
+func (s *Server) GetAttribute(ctx context.Context, req *pb.RagGetAttributeRequest) (*pb.AttributeMetadata, error) {
+	if req.AttributeCode == "" {
+		return nil, status.Error(codes.InvalidArgument, "attribute_code is required")
+	}
+	logging.FromContext(ctx).Info("📄 GetAttribute", "attribute_code", req.AttributeCode)
+
+	m, err := ontology.NewMetadataRepo(s.db).GetMetadata(ctx, req.AttributeCode)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "attribute not found: %s", req.AttributeCode)
+	}
+	resp := &pb.AttributeMetadata{
+		Code:                m.AttributeCode,
+		RiskLevel:           m.RiskLevel,
+		DataType:            m.DataType,
+		Description:         strings.TrimSpace(m.BusinessContext),
+		Synonyms:            m.Synonyms,
+		BusinessContext:     m.BusinessContext,
+		RegulatoryCitations: m.RegulatoryCitations,
+		ExampleValues:       m.ExampleValues,
+		CreatedAt:           timestamppb.New(m.CreatedAt),
+		HasEmbedding:        len(m.Embedding) > 0,
+	}
+	r := s.redaction(ctx)
+	if err := r.mask(ctx, []example{{code: resp.Code, risk: resp.RiskLevel, values: &resp.ExampleValues}}); err != nil {
+		return nil, err
+	}
+	if err := r.audit(ctx); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetMetadataStats retrieves attribute metadata statistics
+func (s *Server) GetMetadataStats(ctx context.Context, _ *pb.GetMetadataStatsRequest) (*pb.MetadataStats, error) {
+	logging.FromContext(ctx).Info("📊 GetMetadataStats")
+
+	stats, err := ontology.NewMetadataRepo(s.db).GetMetadataStats(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get stats: %v", err)
+	}
+	resp := &pb.MetadataStats{
+		TotalAttributes:          int32(stats["total_attributes"].(int)),
+		AttributesWithEmbeddings: int32(stats["attributes_with_embeddings"].(int)),
+		EmbeddingCoveragePercent: float32(stats["embedding_coverage_percent"].(float64)),
+	}
+	if riskDist, ok := stats["risk_distribution"].([]struct {
+		RiskLevel string `db:"risk_level"`
+		Count     int    `db:"count"`
+	}); ok {
+		for _, rd := range riskDist {
+			resp.RiskDistribution = append(resp.RiskDistribution, &pb.RiskDistribution{
+				RiskLevel: rd.RiskLevel,
+				Count:     int32(rd.Count),
+			})
+		}
+	}
+	return resp, nil
+}
+
+// EnrichedAttributeSearch performs attribute search with the documents and
+// regulations of each result
+func (s *Server) EnrichedAttributeSearch(ctx context.Context, req *pb.RagSearchRequest) (*pb.EnrichedSearchResponse, error) {
+	limit := limitOr(req.Limit, defaultLimit)
+	logging.FromContext(ctx).Info("🔍 EnrichedAttributeSearch", "query", req.Query, "limit", limit)
+
+	vec, err := s.embedQuery(ctx, req.Query)
+	if err != nil {
+		return nil, err
+	}
+	results, err := ontology.NewMultiModalRepo(s.db).SearchAttributesAndDocs(ctx, vec, limit)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to search: %v", err)
+	}
+
+	resp := &pb.EnrichedSearchResponse{Query: req.Query, Limit: int32(limit), Count: int32(len(results))}
+	for _, r := range results {
+		enriched := &pb.EnrichedResult{Attribute: ragResult(r.Attribute, 0, 0)}
+		for _, d := range r.Documents {
+			enriched.Documents = append(enriched.Documents, &pb.DocumentInfo{
+				Code:         d.Code,
+				Title:        d.Title,
+				Jurisdiction: d.Jurisdiction,
+				DocType:      d.DocType,
+				Description:  strings.TrimSpace(d.Description),
+			})
+		}
+		for _, reg := range r.Regulations {
+			enriched.Regulations = append(enriched.Regulations, &pb.RegulationInfo{
+				Code:     reg.Code,
+				Title:    reg.Title,
+				Citation: reg.Citation,
+				Summary:  strings.TrimSpace(reg.Summary),
+				Region:   reg.Region,
+			})
+		}
+		resp.Results = append(resp.Results, enriched)
+	}
+	attributes := make([]*pb.RagResult, len(resp.Results))
+	for i, r := range resp.Results {
+		attributes[i] = r.Attribute
+	}
+	if err := s.redactResults(ctx, attributes...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// SectionSearch performs semantic search over document sections and returns
+// each matching snippet with its document and regulation; with a case ID it
+// searches the text extracted from the case's evidence
+func (s *Server) SectionSearch(ctx context.Context, req *pb.SectionSearchRequest) (*pb.SectionSearchResponse, error) {
+	limit := limitOr(req.Limit, defaultLimit)
+	logging.FromContext(ctx).Info("📑 SectionSearch", "query", req.Query, "limit", limit, "case_id", req.CaseId)
+
+	vec, err := s.embedQuery(ctx, req.Query)
+	if err != nil {
+		return nil, err
+	}
+	results, err := ontology.NewEnhancementsRepo(s.db).SearchSectionsWithContext(ctx, vec, limit, req.CaseId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to search: %v", err)
+	}
+
+	resp := &pb.SectionSearchResponse{Query: req.Query, Limit: int32(limit), Count: int32(len(results))}
+	for _, r := range results {
+		resp.Results = append(resp.Results, &pb.SectionResult{
+			SectionId:       int32(r.SectionID),
+			SectionNumber:   r.SectionNumber,
+			SectionTitle:    r.SectionTitle,
+			TextExcerpt:     r.TextExcerpt,
+			PageNumber:      int32(r.PageNumber),
+			DocumentCode:    r.DocumentCode,
+			DocumentTitle:   r.DocumentTitle,
+			Jurisdiction:    r.Jurisdiction,
+			DocType:         r.DocType,
+			RegulationCode:  r.RegulationCode,
+			RegulationTitle: r.RegulationTitle,
+			SimilarityScore: float32(r.SimilarityScore),
+			Distance:        float32(r.Distance),
+			EvidenceId:      r.EvidenceID,
+			CaseId:          r.CaseName,
+		})
+	}
+	return resp, nil
+}
+
+// ClusterRecommend returns the attribute clusters closest to a query and,
+// optionally, the closest members of each
+func (s *Server) ClusterRecommend(ctx context.Context, req *pb.ClusterRecommendRequest) (*pb.ClusterRecommendResponse, error) {
+	limit := limitOr(req.Limit, defaultClusters)
+	logging.FromContext(ctx).Info("🧩 ClusterRecommend", "query", req.Query, "limit", limit,
+		"attributes_per_cluster", req.AttributesPerCluster)
+
+	vec, err := s.embedQuery(ctx, req.Query)
+	if err != nil {
+		return nil, err
+	}
+	repo := ontology.NewEnhancementsRepo(s.db)
+	clusters, err := repo.RecommendClusters(ctx, vec, limit)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to recommend clusters: %v", err)
+	}
+
+	resp := &pb.ClusterRecommendResponse{Query: req.Query, Count: int32(len(clusters))}
+	for _, c := range clusters {
+		rec := &pb.ClusterRecommendation{
+			ClusterCode: c.ClusterCode,
+			ClusterName: c.ClusterName,
+			Similarity:  float32(c.Similarity),
+			MemberCount: int32(c.MemberCount),
+		}
+		if req.AttributesPerCluster > 0 && c.MemberCount > 0 {
+			members, err := repo.SearchWithinCluster(ctx, c.ClusterCode, vec, int(req.AttributesPerCluster))
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to search cluster %s: %v", c.ClusterCode, err)
+			}
+			for _, m := range members {
+				rec.Attributes = append(rec.Attributes, ragResult(m.AttributeMetadata, m.SimilarityScore, m.Distance))
+			}
+		}
+		resp.Clusters = append(resp.Clusters, rec)
+	}
+	var attributes []*pb.RagResult
+	for _, rec := range resp.Clusters {
+		attributes = append(attributes, rec.Attributes...)
+	}
+	if err := s.redactResults(ctx, attributes...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// HealthCheck reports database and embedding health
+func (s *Server) HealthCheck(ctx context.Context, _ *pb.HealthCheckRequest) (*pb.HealthCheckResponse, error) {
+	resp := &pb.HealthCheckResponse{
+		Status:         "healthy",
+		Model:          string(s.embedder.GetModel()),
+		Dimensions:     int32(s.embedder.GetDimensions()),
+		Timestamp:      timestamppb.New(time.Now()),
+		DatabaseStatus: "up",
+		EmbedderStatus: "ready",
+	}
+
+	if dbHealth := kycdb.CheckShared(ctx); !dbHealth.OK() {
+		resp.Status, resp.DatabaseStatus = "unhealthy", dbHealth.Status
+		return resp, nil
+	}
+	count, err := ontology.NewMetadataRepo(s.db).CountEmbeddings(ctx)
+	switch {
+	case err != nil:
+		resp.Status, resp.EmbedderStatus = "unhealthy", "failed to check embeddings: "+err.Error()
+	case count == 0:
+		resp.Status, resp.EmbedderStatus = "degraded", "no attribute embeddings"
+	}
+	return resp, nil
+}
+
+// searchResponse converts vector search results
+func searchResponse(query string, limit int, results []model.AttributeSearchResult) *pb.RagSearchResponse {
+	resp := &pb.RagSearchResponse{Query: query, Limit: int32(limit), Count: int32(len(results))}
+	for _, r := range results {
+		resp.Results = append(resp.Results, ragResult(r.AttributeMetadata, r.SimilarityScore, r.Distance))
+	}
+	return resp
+}
+
+// ragResult converts attribute metadata with its search scores
+func ragResult(m model.AttributeMetadata, similarity, distance float64) *pb.RagResult {
+	return &pb.RagResult{
+		AttributeCode:       m.AttributeCode,
+		RiskLevel:           m.RiskLevel,
+		DataType:            m.DataType,
+		Description:         strings.TrimSpace(m.BusinessContext),
+		Synonyms:            m.Synonyms,
+		RegulatoryCitations: m.RegulatoryCitations,
+		ExampleValues:       m.ExampleValues,
+		SimilarityScore:     float32(similarity),
+		Distance:            float32(distance),
+	}
+}
@@ -0,0 +1,69 @@
+// Package ranking blends vector similarity with the feedback given on
+// attributes for similar queries. Feedback is grouped by the cluster closest
+// to its query, so feedback given on "beneficial owner" searches moves the
+// attributes of ownership searches without touching unrelated ones.
+package ranking
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/adamtc007/KYC-DSL/internal/ontology"
+)
+
+// Blend combines a similarity (0..1) with a feedback score (-1..1). A weight
+// of 0 ranks by similarity alone; attributes without feedback keep their
+// relative order at any weight.
+func Blend(similarity, feedback, weight float64) float64 {
+	return (1-weight)*similarity + weight*feedback
+}
+
+// QueryCluster returns the code of the cluster closest to a query embedding,
+// or "" when no cluster has a centroid
+func QueryCluster(ctx context.Context, db *sqlx.DB, vec []float32) (string, error) {
+	recommended, err := ontology.NewEnhancementsRepo(db).RecommendClusters(ctx, vec, 1)
+	if err != nil {
+		return "", err
+	}
+	if len(recommended) == 0 {
+		return "", nil
+	}
+	return recommended[0].ClusterCode, nil
+}
+
+// FeedbackScore is the feedback aggregated on an attribute for a query cluster
+type FeedbackScore struct {
+	AttributeCode  string  `db:"attribute_code" json:"-"`
+	Count          int     `db:"feedback_count" json:"feedback_count"`
+	PositiveWeight float64 `db:"positive_weight" json:"positive_weight"`
+	NegativeWeight float64 `db:"negative_weight" json:"negative_weight"`
+	// Score is the net sentiment, from -1 (negative) to 1 (positive)
+	Score float64 `db:"score" json:"score"`
+}
+
+// FeedbackScores returns the aggregated feedback of each of the given
+// attributes for a tenant's queries in a cluster. Attributes without feedback
+// are absent.
+func FeedbackScores(ctx context.Context, db *sqlx.DB, tenant, queryCluster string, codes []string) (map[string]FeedbackScore, error) {
+	scores := make(map[string]FeedbackScore)
+	if len(codes) == 0 {
+		return scores, nil
+	}
+	var rows []FeedbackScore
+	err := db.SelectContext(ctx, &rows, `
+		SELECT attribute_code, feedback_count, COALESCE(positive_weight, 0) AS positive_weight,
+			COALESCE(negative_weight, 0) AS negative_weight, score
+		FROM rag_feedback_scores
+		WHERE tenant = $1 AND query_cluster = $2 AND attribute_code = ANY($3)`,
+		tenant, queryCluster, pq.Array(codes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feedback scores: %w", err)
+	}
+	for _, row := range rows {
+		scores[row.AttributeCode] = row
+	}
+	return scores, nil
+}
@@ -0,0 +1,250 @@
+// Package ratelimit limits how often each caller may call the RAG
+// endpoints of kycserver, whose searches call the embedding provider.
+//
+// A caller is a valid API key, or else the client IP (forwarded headers
+// count only from server.trusted_proxies). Each caller has a
+// token bucket refilled at rate_limit.requests_per_minute up to
+// rate_limit.burst tokens, and a quota of requests per UTC day. A request
+// finding the bucket empty or the quota used up is answered 429 with a
+// Retry-After header. Buckets live in memory, so each replica limits the
+// requests it serves.
+package ratelimit
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/adamtc007/KYC-DSL/internal/actor"
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/logging"
+	"github.com/adamtc007/KYC-DSL/internal/metrics"
+)
+
+// Paths that are never limited: load balancers poll the health check, and
+// callers check their usage when limited
+var exempt = []string{"/rag/health", "/rag/usage"}
+
+// sweepInterval is how often callers idle since before today are dropped
+const sweepInterval = time.Minute
+
+// Limiter keeps the token bucket and daily usage of each caller
+type Limiter struct {
+	cfg config.RateLimitConfig
+	// APIKeys resolves an X-API-Key header to the subject of a valid key;
+	// invalid keys are limited by client IP, so they cannot mint buckets
+	APIKeys func(key string) (string, bool)
+
+	mu        sync.Mutex
+	callers   map[string]*caller
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type caller struct {
+	bucket  *rate.Limiter
+	day     string
+	used    int
+	refused int
+	seen    time.Time
+}
+
+// New creates a limiter for the rate limit configuration
+func New(cfg config.RateLimitConfig) *Limiter {
+	return &Limiter{cfg: cfg, callers: make(map[string]*caller), now: time.Now}
+}
+
+// Enabled reports whether requests are limited
+func (l *Limiter) Enabled() bool {
+	return l != nil && l.cfg.Enabled
+}
+
+// Middleware limits the requests under the configured path prefixes.
+// Allowed requests carry X-RateLimit-Limit and X-RateLimit-Remaining
+// headers (the burst and the tokens left), and X-Quota-Remaining with a
+// daily quota.
+func (l *Limiter) Middleware(next http.Handler) http.Handler {
+	if !l.Enabled() {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !l.limits(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		d := l.take(l.Caller(r))
+		if !d.allowed {
+			metrics.ObserveRateLimited(d.reason)
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(d.retryAfter.Seconds()))))
+			message := "rate limit exceeded"
+			if d.reason == metrics.RateLimitQuota {
+				message = "daily quota exhausted"
+			}
+			writeError(w, http.StatusTooManyRequests, message)
+			return
+		}
+		w.Header().Set("X-RateLimit-Limit", strconv.Itoa(l.cfg.Burst))
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(d.tokens))
+		if l.cfg.DailyQuota > 0 {
+			w.Header().Set("X-Quota-Remaining", strconv.Itoa(l.cfg.DailyQuota-d.used))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// limits reports whether a path is limited
+func (l *Limiter) limits(path string) bool {
+	for _, p := range exempt {
+		if path == p || strings.HasPrefix(path, p+"/") {
+			return false
+		}
+	}
+	for _, prefix := range l.cfg.Paths {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Caller names the caller of a request: "api-key:<fingerprint>" for a
+// valid API key, else "ip:<client address>". The address is the connection's
+// peer unless that is a trusted proxy (actor.ClientIP), so clients cannot
+// mint buckets with forged X-Forwarded-For headers.
+func (l *Limiter) Caller(r *http.Request) string {
+	if key := r.Header.Get("X-API-Key"); key != "" && l.APIKeys != nil {
+		if subject, ok := l.APIKeys(key); ok {
+			return subject
+		}
+	}
+	return "ip:" + actor.ClientIP(r)
+}
+
+type decision struct {
+	allowed    bool
+	reason     string
+	retryAfter time.Duration
+	tokens     int
+	used       int
+}
+
+// take spends a token and a request of the caller's quota
+func (l *Limiter) take(name string) decision {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	c := l.caller(name, now)
+
+	if l.cfg.DailyQuota > 0 && c.used >= l.cfg.DailyQuota {
+		c.refused++
+		return decision{reason: metrics.RateLimitQuota, retryAfter: nextDay(now).Sub(now)}
+	}
+	res := c.bucket.ReserveN(now, 1)
+	if delay := res.DelayFrom(now); delay > 0 {
+		res.CancelAt(now)
+		c.refused++
+		return decision{reason: metrics.RateLimitRate, retryAfter: delay}
+	}
+	c.used++
+	return decision{allowed: true, tokens: int(c.bucket.TokensAt(now)), used: c.used}
+}
+
+// caller returns the state of a caller, starting its day over at UTC
+// midnight; the lock is held
+func (l *Limiter) caller(name string, now time.Time) *caller {
+	if now.Sub(l.lastSweep) >= sweepInterval {
+		l.sweep(now)
+	}
+	day := now.UTC().Format(time.DateOnly)
+	c, ok := l.callers[name]
+	if !ok {
+		c = &caller{bucket: rate.NewLimiter(rate.Limit(l.cfg.RequestsPerMinute/60), l.cfg.Burst), day: day}
+		l.callers[name] = c
+	}
+	if c.day != day {
+		c.day, c.used, c.refused = day, 0, 0
+	}
+	c.seen = now
+	return c
+}
+
+// sweep drops callers not seen today whose bucket has refilled, which a
+// new bucket replaces exactly; the lock is held
+func (l *Limiter) sweep(now time.Time) {
+	l.lastSweep = now
+	day := now.UTC().Format(time.DateOnly)
+	for name, c := range l.callers {
+		if c.day != day && c.bucket.TokensAt(now) >= float64(l.cfg.Burst) {
+			delete(l.callers, name)
+		}
+	}
+}
+
+// Usage is a caller's rate limit and quota usage
+type Usage struct {
+	Enabled           bool      `json:"enabled"`
+	Caller            string    `json:"caller"`
+	RequestsPerMinute float64   `json:"requests_per_minute,omitempty"`
+	Burst             int       `json:"burst,omitempty"`
+	Available         int       `json:"available"`
+	DailyQuota        int       `json:"daily_quota"`
+	UsedToday         int       `json:"used_today"`
+	RemainingToday    *int      `json:"remaining_today,omitempty"`
+	RefusedToday      int       `json:"refused_today"`
+	ResetsAt          time.Time `json:"resets_at"`
+}
+
+// Usage reports the usage of the caller of a request without spending
+// any of it; DailyQuota 0 is unlimited
+func (l *Limiter) Usage(r *http.Request) Usage {
+	now := time.Now()
+	if l != nil {
+		now = l.now()
+	}
+	u := Usage{Enabled: l.Enabled(), ResetsAt: nextDay(now)}
+	if !u.Enabled {
+		return u
+	}
+	u.Caller = l.Caller(r)
+	u.RequestsPerMinute, u.Burst, u.DailyQuota = l.cfg.RequestsPerMinute, l.cfg.Burst, l.cfg.DailyQuota
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	u.Available = l.cfg.Burst
+	if c, ok := l.callers[u.Caller]; ok {
+		u.Available = int(c.bucket.TokensAt(now))
+		if c.day == now.UTC().Format(time.DateOnly) {
+			u.UsedToday, u.RefusedToday = c.used, c.refused
+		}
+	}
+	if l.cfg.DailyQuota > 0 {
+		remaining := max(l.cfg.DailyQuota-u.UsedToday, 0)
+		u.RemainingToday = &remaining
+	}
+	return u
+}
+
+// nextDay is the next UTC midnight, when quotas start over
+func nextDay(now time.Time) time.Time {
+	y, m, d := now.UTC().Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, time.UTC)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	body := map[string]string{
+		"error":   http.StatusText(statusCode),
+		"message": message,
+	}
+	if id := w.Header().Get(logging.RequestIDHeader); id != "" {
+		body["request_id"] = id
+	}
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,140 @@
+// Package redact masks sensitive values in API responses for callers
+// without the pii:read scope and records the responses that return them
+// unredacted.
+//
+// An attribute is sensitive when it is personal data, in the global
+// ontology or in the caller's tenant overlay, or when its metadata rates it
+// CRITICAL risk. Masked values are replaced by Marker whatever their type,
+// so clients can tell a redacted value from an empty one.
+package redact
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/adamtc007/KYC-DSL/internal/actor"
+	"github.com/adamtc007/KYC-DSL/internal/auth"
+)
+
+// Marker replaces a masked value
+const Marker = "[REDACTED]"
+
+// sensitive selects the codes of $1 that are personal data, globally or in
+// the overlay of tenant $2, or CRITICAL risk
+const sensitive = `
+	SELECT c.code FROM unnest($1::text[]) AS c(code)
+	 WHERE EXISTS (SELECT 1 FROM kyc_attributes a WHERE a.code = c.code AND a.is_personal_data)
+	    OR EXISTS (SELECT 1 FROM kyc_overlay_attributes o
+	                WHERE o.code = c.code AND o.tenant = $2 AND o.is_personal_data)
+	    OR EXISTS (SELECT 1 FROM kyc_attribute_metadata m
+	                WHERE m.attribute_code = c.code AND m.risk_level = 'CRITICAL')`
+
+// Sensitive returns which of the attribute codes are sensitive for a tenant
+func Sensitive(ctx context.Context, db sqlx.QueryerContext, tenant string, codes []string) (map[string]bool, error) {
+	out := make(map[string]bool)
+	if len(codes) == 0 {
+		return out, nil
+	}
+	var found []string
+	if err := sqlx.SelectContext(ctx, db, &found, sensitive, pq.Array(codes), tenant); err != nil {
+		return nil, fmt.Errorf("failed to look up sensitive attributes: %w", err)
+	}
+	for _, code := range found {
+		out[code] = true
+	}
+	return out, nil
+}
+
+// CaseTenant returns the tenant of a case, DefaultTenant when it has none
+func CaseTenant(ctx context.Context, db sqlx.QueryerContext, caseName string) (string, error) {
+	var tenant string
+	if err := sqlx.GetContext(ctx, db, &tenant, `
+		SELECT COALESCE((SELECT tenant FROM kyc_cases WHERE name = $1 ORDER BY id DESC LIMIT 1), $2)`,
+		caseName, auth.DefaultTenant); err != nil {
+		return "", fmt.Errorf("failed to look up the tenant of case %s: %w", caseName, err)
+	}
+	return tenant, nil
+}
+
+// Response masks the sensitive values of one API response, or records
+// them as read when the caller may read them
+type Response struct {
+	db       *sqlx.DB
+	endpoint string
+	caseName string
+	reveal   bool
+	revealed []string
+}
+
+// New starts the redaction of the response to r; caseName is the case the
+// values belong to, if any
+func New(db *sqlx.DB, r *http.Request, caseName string) *Response {
+	return NewCall(r.Context(), db, r.Method+" "+r.URL.Path, caseName)
+}
+
+// NewCall starts the redaction of the response to a call that is not an
+// HTTP request, such as a gRPC method; endpoint names the call in the audit
+func NewCall(ctx context.Context, db *sqlx.DB, endpoint, caseName string) *Response {
+	return &Response{
+		db:       db,
+		endpoint: endpoint,
+		caseName: caseName,
+		reveal:   auth.CanReadPII(ctx),
+	}
+}
+
+// Reveals reports whether the caller sees sensitive values unredacted
+func (x *Response) Reveals() bool {
+	return x.reveal
+}
+
+// Field masks a sensitive field: it returns false, and the caller replaces
+// the value with Marker, when the caller may not read it; otherwise it
+// notes the field as revealed and returns true. name is an attribute code
+// or another field, such as evidence:<id>.
+func (x *Response) Field(name string) bool {
+	if !x.reveal {
+		return false
+	}
+	for _, f := range x.revealed {
+		if f == name {
+			return true
+		}
+	}
+	x.revealed = append(x.revealed, name)
+	return true
+}
+
+// Audit records the sensitive fields the response revealed, if any
+func (x *Response) Audit(ctx context.Context) error {
+	if len(x.revealed) == 0 {
+		return nil
+	}
+	if flag, ok := ctx.Value(watchKey{}).(*atomic.Bool); ok {
+		flag.Store(true)
+	}
+	a := actor.FromContext(ctx)
+	if _, err := x.db.ExecContext(ctx, `
+		INSERT INTO kyc_pii_access (endpoint, case_name, fields, actor, actor_source, client_ip)
+		VALUES ($1, NULLIF($2, ''), $3, NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, ''))`,
+		x.endpoint, x.caseName, pq.Array(x.revealed), a.Name, a.Source, a.ClientIP); err != nil {
+		return fmt.Errorf("failed to audit unredacted read: %w", err)
+	}
+	return nil
+}
+
+type watchKey struct{}
+
+// Watch returns a context in which responses note whether they revealed
+// sensitive values, and a func reporting whether one did. Response caches
+// use it to keep such responses, whose every read is audited, out of the
+// cache.
+func Watch(ctx context.Context) (context.Context, func() bool) {
+	flag := new(atomic.Bool)
+	return context.WithValue(ctx, watchKey{}, flag), flag.Load
+}
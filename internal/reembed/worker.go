@@ -0,0 +1,218 @@
+// Package reembed keeps ontology embeddings in step with the text they were
+// generated from. Every embedding records the content hash of its embedding
+// text; when business_context, synonyms or descriptions change, the hash no
+// longer matches and the worker re-generates the embedding in small batches.
+package reembed
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/embedspace"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/ontology"
+	"github.com/adamtc007/KYC-DSL/internal/rag"
+)
+
+// Options selects what one pass re-embeds
+type Options struct {
+	// Kinds are the ontology tables to check (default all BackfillKinds)
+	Kinds []string
+	// BatchSize caps the entries re-embedded per kind
+	BatchSize int
+	// Unversioned re-embeds embeddings without a recorded content hash;
+	// otherwise their current hash is recorded without calling the API
+	Unversioned bool
+	// DryRun only reports what would be done
+	DryRun bool
+	// Progress, when set, is called for every entry handled
+	Progress func(kind string, target model.EmbeddingTarget, action string, err error)
+}
+
+// Summary counts the outcome of one pass
+type Summary struct {
+	Stale      int `json:"stale"`
+	Reembedded int `json:"reembedded"`
+	Adopted    int `json:"adopted"`
+	Failed     int `json:"failed"`
+	// DualWritten are embeddings also written to the dual-write spaces
+	DualWritten int `json:"dual_written"`
+	// Remaining are stale entries left for later passes by BatchSize
+	Remaining int `json:"remaining"`
+}
+
+// Actions reported to Options.Progress
+const (
+	ActionReembed = "reembed"
+	ActionAdopt   = "adopt"
+)
+
+// Worker re-generates stale embeddings
+type Worker struct {
+	db        *sqlx.DB
+	repo      *ontology.BackfillRepo
+	embedder  *rag.Embedder
+	cfg       config.ReembeddingConfig
+	syncer    *embedspace.Syncer
+	dualWrite []string
+}
+
+// NewWorker creates a worker; embedder must not serve embeddings from a
+// query cache
+func NewWorker(db *sqlx.DB, embedder *rag.Embedder, cfg config.ReembeddingConfig) *Worker {
+	return &Worker{
+		db:       db,
+		repo:     ontology.NewBackfillRepo(db),
+		embedder: embedder,
+		cfg:      cfg,
+		syncer:   embedspace.NewSyncer(db, embedder),
+	}
+}
+
+// WithDualWrite also writes re-generated embeddings to the named secondary
+// spaces, and has every pass of Run fill in up to BatchSize entries of each
+// kind they are missing
+func (w *Worker) WithDualWrite(spaces []string) *Worker {
+	w.dualWrite = spaces
+	return w
+}
+
+// Run re-embeds a batch of stale entries every interval until ctx is
+// cancelled. Passes are skipped while the embeddings provider is
+// unavailable; stale entries wait for it.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		if w.embedder.Available() {
+			summary, err := w.RunOnce(ctx, Options{BatchSize: w.cfg.BatchSize, Unversioned: w.cfg.Unversioned})
+			if err != nil {
+				slog.Warn("⚠️  Re-embedding pass failed", "error", err)
+			} else if summary.Stale > 0 {
+				slog.Info("♻️  Re-embedding pass", "stale", summary.Stale, "reembedded", summary.Reembedded,
+					"adopted", summary.Adopted, "failed", summary.Failed, "dual_written", summary.DualWritten,
+					"remaining", summary.Remaining)
+			}
+			w.syncSpaces(ctx)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce re-embeds up to BatchSize stale entries of each kind. Entries
+// stay stale when their request fails, so the next pass retries them.
+func (w *Worker) RunOnce(ctx context.Context, opts Options) (Summary, error) {
+	var summary Summary
+	kinds := opts.Kinds
+	if len(kinds) == 0 {
+		kinds = ontology.BackfillKinds
+	}
+	spaces, err := embedspace.DualWriteSpaces(ctx, w.db, w.dualWrite)
+	if err != nil {
+		return summary, err
+	}
+
+	for _, kind := range kinds {
+		stale, err := w.repo.ListStale(ctx, kind)
+		if err != nil {
+			return summary, err
+		}
+
+		var batch []model.EmbeddingTarget
+		for _, t := range stale {
+			summary.Stale++
+			if t.StoredHash == "" && !opts.Unversioned {
+				var err error
+				if !opts.DryRun {
+					err = w.repo.AdoptContentHash(ctx, kind, t.Code, ontology.ContentHash(t.Text))
+				}
+				w.report(opts, kind, t, ActionAdopt, err)
+				if err != nil {
+					summary.Failed++
+					continue
+				}
+				summary.Adopted++
+				continue
+			}
+			if opts.BatchSize > 0 && len(batch) == opts.BatchSize {
+				summary.Remaining++
+				continue
+			}
+			batch = append(batch, t)
+		}
+		if len(batch) == 0 {
+			continue
+		}
+
+		if opts.DryRun {
+			for _, t := range batch {
+				w.report(opts, kind, t, ActionReembed, nil)
+			}
+			continue
+		}
+
+		texts := make([]string, len(batch))
+		for i, t := range batch {
+			texts[i] = t.Text
+		}
+		embeddings, err := w.embedder.GenerateEmbeddingsFromTexts(ctx, texts)
+		if err != nil {
+			summary.Failed += len(batch)
+			return summary, fmt.Errorf("failed to re-embed %d %s: %w", len(batch), kind, err)
+		}
+		for i, t := range batch {
+			err := w.repo.SaveEmbedding(ctx, kind, t.Code, ontology.ContentHash(t.Text), embeddings[i])
+			w.report(opts, kind, t, ActionReembed, err)
+			if err != nil {
+				summary.Failed++
+				continue
+			}
+			summary.Reembedded++
+		}
+
+		// The dual-write spaces embed the same changed text
+		written, err := w.syncer.Write(ctx, spaces, kind, batch)
+		summary.DualWritten += written
+		if err != nil {
+			return summary, err
+		}
+	}
+
+	return summary, nil
+}
+
+// syncSpaces embeds what the dual-write spaces are missing, such as entries
+// added before a space was created, a batch at a time
+func (w *Worker) syncSpaces(ctx context.Context) {
+	spaces, err := embedspace.DualWriteSpaces(ctx, w.db, w.dualWrite)
+	if err != nil {
+		slog.Warn("⚠️  Embedding space sync failed", "error", err)
+		return
+	}
+	for _, space := range spaces {
+		summary, err := w.syncer.Sync(ctx, space, ontology.BackfillKinds, w.cfg.BatchSize, w.cfg.BatchSize, nil)
+		if err != nil {
+			slog.Warn("⚠️  Embedding space sync failed", "space", space.Name, "error", err)
+		} else if summary.Pending > 0 {
+			slog.Info("🧭 Embedding space sync", "space", space.Name, "embedded", summary.Embedded,
+				"failed", summary.Failed, "remaining", summary.Remaining)
+		}
+	}
+}
+
+func (w *Worker) report(opts Options, kind string, t model.EmbeddingTarget, action string, err error) {
+	if opts.Progress != nil {
+		opts.Progress(kind, t, action, err)
+	}
+}
@@ -0,0 +1,98 @@
+// Package reeval keeps derived attributes current. Changes to a managed list
+// or a derivation rule are queued by database triggers (migration 020),
+// changes to case attribute values by the application through Queue; the
+// Worker re-evaluates the queued derived attributes against the latest
+// recorded inputs of each case and records the results in the lineage audit
+// trail, cascading to the attributes derived from values that changed.
+package reeval
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+// Reasons a derived attribute is queued
+const (
+	ReasonList      = "list"
+	ReasonRule      = "rule"
+	ReasonAttribute = "attribute"
+	ReasonCascade   = "cascade"
+)
+
+// Queue item statuses
+const (
+	StatusPending = "PENDING"
+	StatusDone    = "DONE"
+	StatusFailed  = "FAILED"
+)
+
+// enqueueDependents queues, for case $1, the derived attributes with source
+// attribute $2 with its new value $3, merging with a pending item. The
+// derivations of the overlay of the case's tenant count too.
+const enqueueDependents = `
+	INSERT INTO kyc_reevaluation_queue AS q (case_name, derived_code, reason, ref, inputs, depth)
+	SELECT DISTINCT $1, d.derived_attribute_code, $4, $2, jsonb_build_object($2::text, $3::jsonb), $5
+	  FROM (SELECT derived_attribute_code, source_attribute_code FROM kyc_attribute_derivations
+	        UNION ALL
+	        SELECT derived_attribute_code, source_attribute_code FROM kyc_overlay_derivations
+	         WHERE tenant = (SELECT tenant FROM kyc_cases WHERE name = $1 ORDER BY id DESC LIMIT 1)) d
+	 WHERE d.source_attribute_code = $2 AND d.derived_attribute_code <> $2
+	ON CONFLICT (case_name, derived_code) WHERE status = 'PENDING'
+	DO UPDATE SET inputs = q.inputs || EXCLUDED.inputs`
+
+// Queue enqueues derived attributes for re-evaluation and lists the queue
+type Queue struct {
+	db *sqlx.DB
+}
+
+// NewQueue creates a new re-evaluation queue
+func NewQueue(db *sqlx.DB) *Queue {
+	return &Queue{db: db}
+}
+
+// AttributeChanged queues the derived attributes of a case that depend on an
+// attribute, to be re-evaluated with its new value, and returns how many were
+// queued or updated
+func (q *Queue) AttributeChanged(ctx context.Context, caseName, attributeCode string, value any) (int, error) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode value of %s: %w", attributeCode, err)
+	}
+	res, err := q.db.ExecContext(ctx, enqueueDependents, caseName, attributeCode, string(raw), ReasonAttribute, 0)
+	if err != nil {
+		return 0, fmt.Errorf("failed to queue dependents of %s: %w", attributeCode, err)
+	}
+	n, _ := res.RowsAffected()
+	return int(n), nil
+}
+
+// ListChanged queues the derived attributes whose rules reference a list,
+// as the trigger on kyc_managed_lists does
+func (q *Queue) ListChanged(ctx context.Context, name string) (int, error) {
+	var n int
+	if err := q.db.GetContext(ctx, &n, `SELECT enqueue_list_dependents($1)`, name); err != nil {
+		return 0, fmt.Errorf("failed to queue dependents of list %s: %w", name, err)
+	}
+	return n, nil
+}
+
+// List returns queue items with a status (all when empty), newest first
+func (q *Queue) List(ctx context.Context, status string, limit int) ([]model.ReevaluationItem, error) {
+	items := []model.ReevaluationItem{}
+	err := q.db.SelectContext(ctx, &items, `
+		SELECT id, case_name, derived_code, reason, COALESCE(ref, '') AS ref, inputs, depth,
+		       status, attempts, COALESCE(last_error, '') AS last_error, enqueued_at, processed_at
+		  FROM kyc_reevaluation_queue
+		 WHERE $1 = '' OR status = $1
+		 ORDER BY id DESC
+		 LIMIT $2`, status, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list re-evaluation queue: %w", err)
+	}
+	return items, nil
+}
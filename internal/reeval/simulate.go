@@ -0,0 +1,137 @@
+package reeval
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/adamtc007/KYC-DSL/internal/lineage"
+	"github.com/adamtc007/KYC-DSL/internal/lists"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+// ErrUnknownCase is returned when simulating a case that does not exist
+var ErrUnknownCase = errors.New("case not found")
+
+// Simulate answers "what if" for a case: it evaluates every derivation rule
+// against the case's latest recorded values, and again with overrides
+// applied, and reports which results change. Derived values feed the rules
+// that depend on them until they settle; an overridden derived attribute
+// keeps its overridden value. Nothing is recorded or queued.
+//
+// Rules that fail in both evaluations are left out unless the case was
+// evaluated with them before, as they usually need attributes the case
+// does not have.
+func Simulate(ctx context.Context, db *sqlx.DB, caseName string, overrides map[string]any) (*model.Simulation, error) {
+	inEffect, err := lists.NewRepo(db).InEffect(ctx, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := db.BeginTxx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	var exists bool
+	if err := tx.GetContext(ctx, &exists, `SELECT EXISTS (SELECT 1 FROM kyc_cases WHERE name = $1)`, caseName); err != nil {
+		return nil, fmt.Errorf("failed to look up case %s: %w", caseName, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCase, caseName)
+	}
+
+	var rules []derivationRule
+	if err := tx.SelectContext(ctx, &rules, caseRules+`
+		ORDER BY derived_attribute_code, rule_expression`, caseName); err != nil {
+		return nil, fmt.Errorf("failed to load derivation rules: %w", err)
+	}
+
+	var evaluated []string
+	if err := tx.SelectContext(ctx, &evaluated, `
+		SELECT DISTINCT derived_code FROM kyc_lineage_evaluations WHERE case_name = $1`, caseName); err != nil {
+		return nil, fmt.Errorf("failed to load evaluations of %s: %w", caseName, err)
+	}
+	known := make(map[string]bool, len(evaluated))
+	for _, code := range evaluated {
+		known[code] = true
+	}
+
+	baselineEnv, _, err := caseEnvironment(ctx, tx, caseName, "")
+	if err != nil {
+		return nil, err
+	}
+	whatIfEnv := make(map[string]any, len(baselineEnv)+len(overrides))
+	for k, v := range baselineEnv {
+		whatIfEnv[k] = v
+	}
+	pinned := make(map[string]any, len(overrides))
+	for k, v := range overrides {
+		code := strings.ToUpper(k)
+		whatIfEnv[code] = v
+		pinned[code] = v
+	}
+
+	baseline := settle(baselineEnv, rules, inEffect, nil)
+	whatIf := settle(whatIfEnv, rules, inEffect, pinned)
+
+	sim := &model.Simulation{CaseName: caseName, Overrides: pinned, Results: []model.SimulatedDerivation{}}
+	for i, d := range rules {
+		before, after := baseline[i], whatIf[i]
+		if !before.Success && !after.Success && !known[d.DerivedCode] {
+			continue
+		}
+		beforeValue, _ := lineage.FormatValue(before.Value)
+		afterValue, _ := lineage.FormatValue(after.Value)
+		out := model.SimulatedDerivation{
+			DerivedCode:     d.DerivedCode,
+			Rule:            d.Rule,
+			Jurisdiction:    d.Jurisdiction,
+			BaselineValue:   beforeValue,
+			BaselineSuccess: before.Success,
+			Value:           afterValue,
+			Success:         after.Success,
+			Error:           after.Error,
+			Changed:         before.Success != after.Success || beforeValue != afterValue,
+		}
+		if out.Changed {
+			sim.Changed++
+		}
+		sim.Results = append(sim.Results, out)
+	}
+	return sim, nil
+}
+
+// settle evaluates every rule against env, writing successful results back
+// so dependent rules see them, until no value changes (at most
+// maxCascadeDepth passes). Rules of pinned attributes are not evaluated;
+// they report the pinned value. It returns the last result of each rule.
+func settle(env map[string]any, rules []derivationRule, lists lineage.Lists, pinned map[string]any) []lineage.EvaluationResult {
+	results := make([]lineage.EvaluationResult, len(rules))
+	for pass := 0; pass < maxCascadeDepth; pass++ {
+		changed := false
+		for i, d := range rules {
+			if v, ok := pinned[d.DerivedCode]; ok {
+				results[i] = lineage.EvaluationResult{DerivedCode: d.DerivedCode, Rule: d.Rule, Value: v, Success: true}
+				continue
+			}
+			res := evaluate(env, d, lists)
+			results[i] = res
+			if res.Success && !reflect.DeepEqual(env[d.DerivedCode], res.Value) {
+				env[d.DerivedCode] = res.Value
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+	return results
+}
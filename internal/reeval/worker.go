@@ -0,0 +1,406 @@
+package reeval
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/adamtc007/KYC-DSL/internal/actor"
+	"github.com/adamtc007/KYC-DSL/internal/casedict"
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/lineage"
+	"github.com/adamtc007/KYC-DSL/internal/lists"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+const (
+	// maxAttempts is how often an item is retried after database errors
+	maxAttempts = 5
+	// maxCascadeDepth bounds cascades through derived attributes (and cycles between them)
+	maxCascadeDepth = 10
+)
+
+// Summary counts the outcome of one worker pass
+type Summary struct {
+	Items         int `json:"items"`
+	Evaluations   int `json:"evaluations"`
+	Changed       int `json:"changed"`
+	Failed        int `json:"failed"`
+	CascadeQueued int `json:"cascade_queued"`
+}
+
+// derivationRule is a distinct rule of a derived attribute
+type derivationRule struct {
+	DerivedCode    string `db:"derived_attribute_code"`
+	Rule           string `db:"rule_expression"`
+	Jurisdiction   string `db:"jurisdiction"`
+	RegulationCode string `db:"regulation_code"`
+}
+
+// caseRules selects the rules that derive attributes for case $1: the
+// rules of its tenant's ontology overlay (internal/overlay) and the global
+// rules of the derived attributes the overlay has no rules for
+const caseRules = `
+	SELECT * FROM (
+	    WITH t AS (
+	        SELECT COALESCE((SELECT tenant FROM kyc_cases WHERE name = $1 ORDER BY id DESC LIMIT 1), 'default') AS tenant)
+	    SELECT o.derived_attribute_code, o.rule_expression, '' AS jurisdiction, '' AS regulation_code
+	      FROM kyc_overlay_derivations o, t
+	     WHERE o.tenant = t.tenant
+	    UNION
+	    SELECT g.derived_attribute_code, g.rule_expression,
+	           COALESCE(g.jurisdiction, ''), COALESCE(g.regulation_code, '')
+	      FROM kyc_attribute_derivations g
+	     WHERE NOT EXISTS (SELECT 1 FROM kyc_overlay_derivations o, t
+	                        WHERE o.tenant = t.tenant AND o.derived_attribute_code = g.derived_attribute_code)
+	) rules`
+
+// latestEvaluation is the latest recorded evaluation of a derived attribute for a case
+type latestEvaluation struct {
+	CaseName    string `db:"case_name"`
+	CaseVersion *int   `db:"case_version"`
+	DerivedCode string `db:"derived_code"`
+	Value       string `db:"value"`
+	ValueType   string `db:"value_type"`
+	Success     bool   `db:"success"`
+	Inputs      []byte `db:"inputs"`
+}
+
+// Worker drains the re-evaluation queue
+type Worker struct {
+	db          *sqlx.DB
+	interval    time.Duration
+	batchSize   int
+	materialize bool
+}
+
+// NewWorker creates a queue worker
+func NewWorker(db *sqlx.DB, cfg config.ReevaluationConfig) *Worker {
+	return &Worker{db: db, interval: cfg.Interval, batchSize: cfg.BatchSize, materialize: cfg.Materialize}
+}
+
+// Run drains the queue every interval until ctx is cancelled
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		summary, err := w.RunOnce(ctx)
+		if err != nil {
+			slog.Warn("⚠️  Re-evaluation pass failed", "error", err)
+		} else if summary.Items > 0 {
+			slog.Info("🔁 Re-evaluation pass", "items", summary.Items, "evaluations", summary.Evaluations,
+				"changed", summary.Changed, "failed", summary.Failed, "cascade_queued", summary.CascadeQueued)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce processes up to a batch of pending items
+func (w *Worker) RunOnce(ctx context.Context) (Summary, error) {
+	summary, _, err := w.drain(ctx, "", "")
+	return summary, err
+}
+
+// RunFor processes the pending items queued for a reason and reference (e.g.
+// a list name) right away and returns the fresh results
+func (w *Worker) RunFor(ctx context.Context, reason, ref string) ([]model.Reevaluation, error) {
+	_, results, err := w.drain(ctx, reason, ref)
+	return results, err
+}
+
+// drain processes pending items one transaction each, so a failure only
+// rolls back its own item; concurrent workers skip the items locked by others
+func (w *Worker) drain(ctx context.Context, reason, ref string) (Summary, []model.Reevaluation, error) {
+	var summary Summary
+	results := []model.Reevaluation{}
+
+	inEffect, err := lists.NewRepo(w.db).InEffect(ctx, time.Now().UTC())
+	if err != nil {
+		return summary, results, err
+	}
+
+	for summary.Items < w.batchSize {
+		item, out, cascaded, err := w.processNext(ctx, inEffect, reason, ref)
+		if err != nil {
+			if item != nil {
+				w.recordFailure(ctx, *item, err)
+			}
+			return summary, results, err
+		}
+		if item == nil {
+			break
+		}
+
+		summary.Items++
+		summary.CascadeQueued += cascaded
+		for _, r := range out {
+			summary.Evaluations++
+			if r.Changed {
+				summary.Changed++
+			}
+			if !r.Success {
+				summary.Failed++
+			}
+		}
+		results = append(results, out...)
+	}
+	return summary, results, nil
+}
+
+// processNext claims, evaluates and completes one pending item; it returns a
+// nil item when the queue is empty
+func (w *Worker) processNext(ctx context.Context, lists lineage.Lists, reason, ref string) (*model.ReevaluationItem, []model.Reevaluation, int, error) {
+	tx, err := w.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	var item model.ReevaluationItem
+	err = tx.GetContext(ctx, &item, `
+		SELECT id, case_name, derived_code, reason, COALESCE(ref, '') AS ref, inputs, depth,
+		       status, attempts, enqueued_at
+		  FROM kyc_reevaluation_queue
+		 WHERE status = 'PENDING'
+		   AND ($1 = '' OR (reason = $1 AND ref = $2))
+		 ORDER BY id
+		 LIMIT 1
+		   FOR UPDATE SKIP LOCKED`, reason, ref)
+	if err == sql.ErrNoRows {
+		return nil, nil, 0, nil
+	}
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("failed to claim queue item: %w", err)
+	}
+
+	results, cascaded, err := w.process(ctx, tx, item, lists)
+	if err != nil {
+		return &item, nil, 0, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE kyc_reevaluation_queue
+		   SET status = 'DONE', attempts = attempts + 1, last_error = NULL, processed_at = CURRENT_TIMESTAMP
+		 WHERE id = $1`, item.ID); err != nil {
+		return &item, nil, 0, fmt.Errorf("failed to complete queue item: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return &item, nil, 0, fmt.Errorf("failed to commit re-evaluation: %w", err)
+	}
+	return &item, results, cascaded, nil
+}
+
+// recordFailure counts a failed attempt; the item is given up after maxAttempts
+func (w *Worker) recordFailure(ctx context.Context, item model.ReevaluationItem, cause error) {
+	if _, err := w.db.ExecContext(ctx, `
+		UPDATE kyc_reevaluation_queue
+		   SET attempts = attempts + 1, last_error = $2,
+		       status = CASE WHEN attempts + 1 >= $3 THEN 'FAILED' ELSE status END,
+		       processed_at = CASE WHEN attempts + 1 >= $3 THEN CURRENT_TIMESTAMP END
+		 WHERE id = $1`, item.ID, cause.Error(), maxAttempts); err != nil {
+		slog.Warn("⚠️  Failed to record re-evaluation failure", "item", item.ID, "error", err)
+	}
+}
+
+// process evaluates the rules of the item's derived attribute for each case
+// it covers, records the results as one run per case and queues the cascade
+// for changed values
+func (w *Worker) process(ctx context.Context, tx *sqlx.Tx, item model.ReevaluationItem, lists lineage.Lists) ([]model.Reevaluation, int, error) {
+	overrides := map[string]any{}
+	if len(item.Inputs) > 0 {
+		if err := json.Unmarshal(item.Inputs, &overrides); err != nil {
+			return nil, 0, fmt.Errorf("invalid inputs on queue item %d: %w", item.ID, err)
+		}
+	}
+
+	cases := []string{item.CaseName}
+	if item.CaseName == "" {
+		cases = nil
+		if err := tx.SelectContext(ctx, &cases, `
+			SELECT DISTINCT case_name FROM kyc_lineage_evaluations
+			 WHERE derived_code = $1 ORDER BY case_name`, item.DerivedCode); err != nil {
+			return nil, 0, fmt.Errorf("failed to find cases evaluating %s: %w", item.DerivedCode, err)
+		}
+	}
+
+	results := []model.Reevaluation{}
+	cascaded := 0
+	for _, caseName := range cases {
+		// Cases of different tenants may derive the attribute differently
+		var rules []derivationRule
+		if err := tx.SelectContext(ctx, &rules, caseRules+`
+			WHERE derived_attribute_code = $2
+			ORDER BY rule_expression`, caseName, item.DerivedCode); err != nil {
+			return nil, 0, fmt.Errorf("failed to load rules of %s for %s: %w", item.DerivedCode, caseName, err)
+		}
+		if len(rules) == 0 {
+			continue
+		}
+
+		env, prev, err := caseEnvironment(ctx, tx, caseName, item.DerivedCode)
+		if err != nil {
+			return nil, 0, err
+		}
+		for k, v := range overrides {
+			env[k] = v
+		}
+
+		hash, err := lineage.InputsHash(env)
+		if err != nil {
+			return nil, 0, err
+		}
+		run := model.EvaluationRun{
+			CaseName:    caseName,
+			CaseVersion: prev.CaseVersion,
+			InputsHash:  hash,
+			Source:      item.Reason,
+			Actor:       actor.FromContext(ctx).Name,
+		}
+		values := make([]any, len(rules))
+		for i, d := range rules {
+			res := evaluate(env, d, lists)
+			r, err := lineage.NewDerivationResult(res)
+			if err != nil {
+				return nil, 0, err
+			}
+			r.Jurisdiction, r.RegulationCode = d.Jurisdiction, d.RegulationCode
+			run.Results = append(run.Results, r)
+			values[i] = res.Value
+		}
+		if err := lineage.RecordRun(ctx, tx, &run); err != nil {
+			return nil, 0, err
+		}
+
+		for i, res := range run.Results {
+			if w.materialize {
+				if err := casedict.Materialize(ctx, tx, res.EvaluationID); err != nil {
+					return nil, 0, err
+				}
+			}
+
+			out := model.Reevaluation{
+				CaseName:      caseName,
+				DerivedCode:   res.DerivedCode,
+				Reason:        item.Reason,
+				PreviousValue: prev.Value,
+				Value:         res.Value,
+				Success:       res.Success,
+				Error:         res.Error,
+				Changed:       !res.Success || res.Value != prev.Value,
+			}
+			results = append(results, out)
+			if !out.Changed || !res.Success {
+				continue
+			}
+
+			slog.Info("🔁 Derived attribute re-evaluated", "case", caseName, "derived", res.DerivedCode,
+				"reason", item.Reason, "from", prev.Value, "to", res.Value)
+			if item.Depth >= maxCascadeDepth {
+				slog.Warn("⚠️  Re-evaluation cascade depth exceeded", "case", caseName, "derived", res.DerivedCode)
+				continue
+			}
+			raw, err := json.Marshal(values[i])
+			if err != nil {
+				return nil, 0, fmt.Errorf("failed to encode value of %s: %w", res.DerivedCode, err)
+			}
+			r, err := tx.ExecContext(ctx, enqueueDependents, caseName, res.DerivedCode, string(raw), ReasonCascade, item.Depth+1)
+			if err != nil {
+				return nil, 0, fmt.Errorf("failed to queue dependents of %s: %w", res.DerivedCode, err)
+			}
+			n, _ := r.RowsAffected()
+			cascaded += int(n)
+		}
+	}
+	return results, cascaded, nil
+}
+
+// caseEnvironment rebuilds the attribute values of a case from the inputs and
+// successful results of its latest evaluations, and returns the latest
+// evaluation of the derived attribute
+func caseEnvironment(ctx context.Context, tx *sqlx.Tx, caseName, derivedCode string) (map[string]any, latestEvaluation, error) {
+	var evals []latestEvaluation
+	if err := tx.SelectContext(ctx, &evals, `
+		SELECT DISTINCT ON (derived_code) case_name, case_version, derived_code,
+		       COALESCE(value, '') AS value, COALESCE(value_type, '') AS value_type, success, inputs
+		  FROM kyc_lineage_evaluations
+		 WHERE case_name = $1
+		 ORDER BY derived_code, evaluated_at DESC, id DESC`, caseName); err != nil {
+		return nil, latestEvaluation{}, fmt.Errorf("failed to load evaluations of %s: %w", caseName, err)
+	}
+
+	env := map[string]any{}
+	prev := latestEvaluation{CaseName: caseName}
+	for _, e := range evals {
+		if len(e.Inputs) > 0 {
+			inputs := map[string]any{}
+			if err := json.Unmarshal(e.Inputs, &inputs); err != nil {
+				return nil, prev, fmt.Errorf("invalid inputs recorded for %s/%s: %w", caseName, e.DerivedCode, err)
+			}
+			for k, v := range inputs {
+				if _, ok := env[k]; !ok {
+					env[k] = v
+				}
+			}
+		}
+		if e.DerivedCode == derivedCode {
+			prev = e
+		}
+	}
+	// Derived values win over stale copies recorded as inputs
+	for _, e := range evals {
+		if e.Success && e.DerivedCode != derivedCode {
+			env[e.DerivedCode] = parseValue(e.Value, e.ValueType)
+		}
+	}
+	return env, prev, nil
+}
+
+// evaluate runs one rule in a copy of the environment
+func evaluate(env map[string]any, d derivationRule, lists lineage.Lists) lineage.EvaluationResult {
+	local := make(map[string]any, len(env))
+	for k, v := range env {
+		local[k] = v
+	}
+	derivation := model.DerivedAttribute{
+		DerivedAttribute: d.DerivedCode,
+		RuleExpression:   d.Rule,
+		Jurisdiction:     d.Jurisdiction,
+		RegulationCode:   d.RegulationCode,
+	}
+	for k := range env {
+		derivation.SourceAttributes = append(derivation.SourceAttributes, k)
+	}
+
+	ev := lineage.NewEvaluator(local).WithLists(lists)
+	if err := ev.CompileDerivations([]model.DerivedAttribute{derivation}); err != nil {
+		return lineage.EvaluationResult{DerivedCode: d.DerivedCode, Rule: d.Rule, Inputs: env, Error: err.Error(), Timestamp: time.Now()}
+	}
+	return ev.Evaluate([]model.DerivedAttribute{derivation})[0]
+}
+
+// parseValue converts a stored value back to the type it was recorded with
+func parseValue(value, valueType string) any {
+	switch valueType {
+	case "boolean":
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	case "numeric":
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	}
+	return value
+}
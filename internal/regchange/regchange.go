@@ -0,0 +1,525 @@
+// Package regchange records changes to regulation records and reports what
+// a change affects. Every change to a regulation's text or effective dates
+// is kept as a version in kyc_regulation_versions (migration 055); the
+// impact report lists the documents, attributes, attribute clusters, policy
+// packs and open cases that reference the regulation, so compliance can
+// triage which cases need refreshing.
+package regchange
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/adamtc007/KYC-DSL/internal/events"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/parser"
+)
+
+var (
+	// ErrNotFound is returned for an unknown regulation or version
+	ErrNotFound = errors.New("regulation not found")
+	// ErrInvalid is returned for an update that cannot be applied
+	ErrInvalid = errors.New("invalid regulation update")
+)
+
+// openStatuses are the case statuses a regulation change can still call
+// for a refresh of: every status but declined and archived
+var openStatuses = []string{"draft", "validated", "in-review", "approved"}
+
+// Repo records regulation changes and reports their impact
+type Repo struct {
+	db *sqlx.DB
+}
+
+// NewRepo creates a new regulation change repository
+func NewRepo(db *sqlx.DB) *Repo {
+	return &Repo{db: db}
+}
+
+const versionColumns = `
+	regulation_code, version, COALESCE(name, '') AS name, COALESCE(title, '') AS title,
+	COALESCE(citation, '') AS citation, COALESCE(summary, '') AS summary,
+	COALESCE(description, '') AS description, effective_from, effective_to,
+	COALESCE(change_note, '') AS change_note, COALESCE(changed_by, '') AS changed_by, changed_at
+`
+
+// Update applies a change to a regulation record and returns the version
+// it recorded, queueing a regulation.changed event with the changed fields;
+// an update changing nothing is invalid
+func (r *Repo) Update(ctx context.Context, code string, u model.RegulationUpdate, changedBy string) (*model.RegulationVersion, error) {
+	if u.Name != nil && strings.TrimSpace(*u.Name) == "" {
+		return nil, fmt.Errorf("%w: name cannot be empty", ErrInvalid)
+	}
+	if u.EffectiveFrom != nil && u.EffectiveTo != nil && u.EffectiveTo.Before(*u.EffectiveFrom) {
+		return nil, fmt.Errorf("%w: effective_to is before effective_from", ErrInvalid)
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	var before int
+	if err := tx.GetContext(ctx, &before, `
+		SELECT COALESCE(MAX(v.version), 0) FROM kyc_regulations r
+		  LEFT JOIN kyc_regulation_versions v ON v.regulation_code = r.code
+		 WHERE r.code = $1 GROUP BY r.code`, code); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, code)
+		}
+		return nil, fmt.Errorf("failed to load regulation %s: %w", code, err)
+	}
+
+	// The record_kyc_regulation_version trigger records the version
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE kyc_regulations
+		   SET name = COALESCE($2, name), title = COALESCE($3, title), citation = COALESCE($4, citation),
+		       summary = COALESCE($5, summary), description = COALESCE($6, description),
+		       effective_from = COALESCE($7, effective_from), effective_to = COALESCE($8, effective_to),
+		       updated_by = NULLIF($9, '')
+		 WHERE code = $1`,
+		code, u.Name, u.Title, u.Citation, u.Summary, u.Description, u.EffectiveFrom, u.EffectiveTo, changedBy); err != nil {
+		return nil, fmt.Errorf("failed to update regulation %s: %w", code, err)
+	}
+
+	var v model.RegulationVersion
+	if err := tx.GetContext(ctx, &v, `
+		UPDATE kyc_regulation_versions SET change_note = NULLIF($3, '')
+		 WHERE regulation_code = $1 AND version > $2
+		RETURNING `+versionColumns, code, before, u.Note); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: the update does not change %s", ErrInvalid, code)
+		}
+		return nil, fmt.Errorf("failed to record the version of %s: %w", code, err)
+	}
+
+	var previous *model.RegulationVersion
+	if before > 0 {
+		previous = &model.RegulationVersion{}
+		if err := tx.GetContext(ctx, previous, `SELECT `+versionColumns+` FROM kyc_regulation_versions
+			WHERE regulation_code = $1 AND version = $2`, code, before); err != nil {
+			return nil, fmt.Errorf("failed to load version %d of %s: %w", before, code, err)
+		}
+	}
+	fields := make([]string, 0)
+	for _, c := range Changes(previous, v) {
+		fields = append(fields, c.Field)
+	}
+	if err := events.Emit(ctx, tx, events.Event{
+		Type:    events.RegulationChanged,
+		Subject: code,
+		Data: map[string]interface{}{
+			"regulation_code": code,
+			"version":         v.Version,
+			"changed_fields":  fields,
+			"note":            u.Note,
+		},
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit update of %s: %w", code, err)
+	}
+	return &v, nil
+}
+
+// Versions returns the versions of a regulation record, newest first
+func (r *Repo) Versions(ctx context.Context, code string) ([]model.RegulationVersion, error) {
+	var versions []model.RegulationVersion
+	if err := r.db.SelectContext(ctx, &versions, `SELECT `+versionColumns+` FROM kyc_regulation_versions
+		WHERE regulation_code = $1 ORDER BY version DESC`, code); err != nil {
+		return nil, fmt.Errorf("failed to list versions of %s: %w", code, err)
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, code)
+	}
+	return versions, nil
+}
+
+// FieldChange is a field of a regulation record that differs between two
+// versions
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// Changes returns the fields of v that differ from previous (nil: every
+// field set in v)
+func Changes(previous *model.RegulationVersion, v model.RegulationVersion) []FieldChange {
+	var prev model.RegulationVersion
+	if previous != nil {
+		prev = *previous
+	}
+	changes := []FieldChange{}
+	for _, f := range []struct{ name, from, to string }{
+		{"name", prev.Name, v.Name},
+		{"title", prev.Title, v.Title},
+		{"citation", prev.Citation, v.Citation},
+		{"summary", prev.Summary, v.Summary},
+		{"description", prev.Description, v.Description},
+		{"effective_from", formatDate(prev.EffectiveFrom), formatDate(v.EffectiveFrom)},
+		{"effective_to", formatDate(prev.EffectiveTo), formatDate(v.EffectiveTo)},
+	} {
+		if f.from != f.to {
+			changes = append(changes, FieldChange{Field: f.name, From: f.from, To: f.to})
+		}
+	}
+	return changes
+}
+
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+// ImpactedDocument is a document the regulation requires; Via says how
+type ImpactedDocument struct {
+	Code string   `json:"code"`
+	Name string   `json:"name"`
+	Via  []string `json:"via"`
+}
+
+// ImpactedAttribute is an attribute the regulation governs, directly or
+// through a document it requires
+type ImpactedAttribute struct {
+	Code string   `json:"code"`
+	Via  []string `json:"via"`
+}
+
+// ImpactedCluster is an attribute cluster holding impacted attributes
+type ImpactedCluster struct {
+	Code       string   `json:"code"`
+	Name       string   `json:"name"`
+	Attributes []string `json:"attributes"`
+}
+
+// ImpactedPack is an active policy pack implementing the regulation
+type ImpactedPack struct {
+	Code         string `db:"code" json:"code"`
+	Version      int    `db:"version" json:"version"`
+	Jurisdiction string `db:"jurisdiction" json:"jurisdiction"`
+	PolicyCode   string `db:"policy_code" json:"policy_code,omitempty"`
+}
+
+// ImpactedCase is an open case referencing the regulation; Reasons are the
+// references found in its latest version ("document:UBO-DECL",
+// "policy:KYCPOL-EU-2025", "attribute:UBO_NAME", "derived:UBO_THRESHOLD")
+type ImpactedCase struct {
+	Name    string   `json:"name"`
+	Status  string   `json:"status"`
+	Version int      `json:"version"`
+	Reasons []string `json:"reasons"`
+}
+
+// Impact is what references a regulation, with the change between the
+// reported version and the one before it
+type Impact struct {
+	RegulationCode string                   `json:"regulation_code"`
+	Name           string                   `json:"name"`
+	Version        int                      `json:"version"`
+	ChangedAt      time.Time                `json:"changed_at"`
+	ChangedBy      string                   `json:"changed_by,omitempty"`
+	ChangeNote     string                   `json:"change_note,omitempty"`
+	Changes        []FieldChange            `json:"changes"`
+	Documents      []ImpactedDocument       `json:"documents"`
+	Attributes     []ImpactedAttribute      `json:"attributes"`
+	Clusters       []ImpactedCluster        `json:"clusters"`
+	PolicyPacks    []ImpactedPack           `json:"policy_packs"`
+	Cases          []ImpactedCase           `json:"cases"`
+	Summary        map[string]int           `json:"summary"`
+	Previous       *model.RegulationVersion `json:"previous,omitempty"`
+}
+
+// Impact reports what references a regulation and the change made by a
+// version of its record (0: the current one)
+func (r *Repo) Impact(ctx context.Context, code string, version int) (*Impact, error) {
+	var versions []model.RegulationVersion
+	query := `SELECT ` + versionColumns + ` FROM kyc_regulation_versions
+		WHERE regulation_code = $1 AND ($2 = 0 OR version <= $2) ORDER BY version DESC LIMIT 2`
+	if err := r.db.SelectContext(ctx, &versions, query, code, version); err != nil {
+		return nil, fmt.Errorf("failed to load versions of %s: %w", code, err)
+	}
+	if len(versions) == 0 || (version > 0 && versions[0].Version != version) {
+		return nil, fmt.Errorf("%w: %s version %d", ErrNotFound, code, version)
+	}
+	current := versions[0]
+	report := &Impact{
+		RegulationCode: code,
+		Name:           current.Name,
+		Version:        current.Version,
+		ChangedAt:      current.ChangedAt,
+		ChangedBy:      current.ChangedBy,
+		ChangeNote:     current.ChangeNote,
+	}
+	if len(versions) > 1 {
+		report.Previous = &versions[1]
+	}
+	report.Changes = Changes(report.Previous, current)
+
+	var err error
+	if report.PolicyPacks, err = r.packs(ctx, code); err != nil {
+		return nil, err
+	}
+	if report.Documents, err = r.documents(ctx, code); err != nil {
+		return nil, err
+	}
+	documents := make([]string, len(report.Documents))
+	for i, d := range report.Documents {
+		documents[i] = d.Code
+	}
+	if report.Attributes, err = r.attributes(ctx, code, documents); err != nil {
+		return nil, err
+	}
+	attributes := make([]string, len(report.Attributes))
+	for i, a := range report.Attributes {
+		attributes[i] = a.Code
+	}
+	if report.Clusters, err = r.clusters(ctx, attributes); err != nil {
+		return nil, err
+	}
+	policies := make([]string, 0, len(report.PolicyPacks))
+	for _, p := range report.PolicyPacks {
+		if p.PolicyCode != "" {
+			policies = append(policies, p.PolicyCode)
+		}
+	}
+	if report.Cases, err = r.cases(ctx, code, documents, attributes, policies); err != nil {
+		return nil, err
+	}
+
+	report.Summary = map[string]int{
+		"documents":    len(report.Documents),
+		"attributes":   len(report.Attributes),
+		"clusters":     len(report.Clusters),
+		"policy_packs": len(report.PolicyPacks),
+		"open_cases":   len(report.Cases),
+	}
+	return report, nil
+}
+
+func (r *Repo) packs(ctx context.Context, code string) ([]ImpactedPack, error) {
+	packs := []ImpactedPack{}
+	if err := r.db.SelectContext(ctx, &packs, `
+		SELECT code, version, jurisdiction, COALESCE(policy_code, '') AS policy_code
+		  FROM kyc_policy_packs WHERE regulation_code = $1 AND status = 'active' ORDER BY code`, code); err != nil {
+		return nil, fmt.Errorf("failed to load the policy packs of %s: %w", code, err)
+	}
+	return packs, nil
+}
+
+// documents returns the documents the regulation requires: those issued
+// under it, linked to it, or required by its active policy packs
+func (r *Repo) documents(ctx context.Context, code string) ([]ImpactedDocument, error) {
+	var rows []struct {
+		Code string `db:"code"`
+		Name string `db:"name"`
+		Via  string `db:"via"`
+	}
+	if err := r.db.SelectContext(ctx, &rows, `
+		SELECT code, name, 'regulation' AS via FROM kyc_documents WHERE regulation_code = $1
+		UNION
+		SELECT d.code, d.name, 'doc-reg-link'
+		  FROM kyc_doc_reg_links l JOIN kyc_documents d ON d.code = l.document_code
+		 WHERE l.regulation_code = $1
+		UNION
+		SELECT d.code, d.name, 'policy-pack:' || p.code
+		  FROM kyc_policy_packs p
+		  JOIN kyc_policy_pack_documents pd ON pd.pack_id = p.id
+		  JOIN kyc_documents d ON d.code = pd.document_code
+		 WHERE p.regulation_code = $1 AND p.status = 'active'
+		 ORDER BY 1, 3`, code); err != nil {
+		return nil, fmt.Errorf("failed to load the documents of %s: %w", code, err)
+	}
+	documents := []ImpactedDocument{}
+	for _, row := range rows {
+		if n := len(documents); n > 0 && documents[n-1].Code == row.Code {
+			documents[n-1].Via = append(documents[n-1].Via, row.Via)
+			continue
+		}
+		documents = append(documents, ImpactedDocument{Code: row.Code, Name: row.Name, Via: []string{row.Via}})
+	}
+	return documents, nil
+}
+
+// attributes returns the attributes the regulation governs: those linked to
+// documents under it, derived under it or required by its active policy
+// packs, and those evidenced by its documents for any regulation
+func (r *Repo) attributes(ctx context.Context, code string, documents []string) ([]ImpactedAttribute, error) {
+	var rows []struct {
+		Code string `db:"code"`
+		Via  string `db:"via"`
+	}
+	if err := r.db.SelectContext(ctx, &rows, `
+		SELECT attribute_code AS code, 'attr-doc-link' AS via FROM kyc_attr_doc_links WHERE regulation_code = $1
+		UNION
+		SELECT attribute_code, 'document:' || document_code FROM kyc_attr_doc_links
+		 WHERE document_code = ANY($2) AND (regulation_code IS NULL OR regulation_code = $1)
+		UNION
+		SELECT derived_attribute_code, 'derivation' FROM kyc_attribute_derivations WHERE regulation_code = $1
+		UNION
+		SELECT pa.attribute_code, 'policy-pack:' || p.code
+		  FROM kyc_policy_packs p JOIN kyc_policy_pack_attributes pa ON pa.pack_id = p.id
+		 WHERE p.regulation_code = $1 AND p.status = 'active'
+		 ORDER BY 1, 2`, code, pq.Array(documents)); err != nil {
+		return nil, fmt.Errorf("failed to load the attributes of %s: %w", code, err)
+	}
+	attributes := []ImpactedAttribute{}
+	for _, row := range rows {
+		if n := len(attributes); n > 0 && attributes[n-1].Code == row.Code {
+			attributes[n-1].Via = append(attributes[n-1].Via, row.Via)
+			continue
+		}
+		attributes = append(attributes, ImpactedAttribute{Code: row.Code, Via: []string{row.Via}})
+	}
+	return attributes, nil
+}
+
+func (r *Repo) clusters(ctx context.Context, attributes []string) ([]ImpactedCluster, error) {
+	clusters := []ImpactedCluster{}
+	if len(attributes) == 0 {
+		return clusters, nil
+	}
+	var rows []struct {
+		Code       string         `db:"cluster_code"`
+		Name       string         `db:"cluster_name"`
+		Attributes pq.StringArray `db:"attribute_codes"`
+	}
+	if err := r.db.SelectContext(ctx, &rows, `
+		SELECT cluster_code, cluster_name, attribute_codes FROM kyc_attribute_clusters
+		 WHERE attribute_codes && $1 ORDER BY cluster_code`, pq.Array(attributes)); err != nil {
+		return nil, fmt.Errorf("failed to load attribute clusters: %w", err)
+	}
+	impacted := toSet(attributes)
+	for _, row := range rows {
+		c := ImpactedCluster{Code: row.Code, Name: row.Name, Attributes: []string{}}
+		for _, a := range row.Attributes {
+			if impacted[a] {
+				c.Attributes = append(c.Attributes, a)
+			}
+		}
+		clusters = append(clusters, c)
+	}
+	return clusters, nil
+}
+
+// cases returns the open cases whose latest version requires one of the
+// documents, follows one of the policies, lists or has captured one of the
+// attributes, or derives a value under the regulation
+func (r *Repo) cases(ctx context.Context, code string, documents, attributes, policies []string) ([]ImpactedCase, error) {
+	var open []struct {
+		Name    string `db:"name"`
+		Status  string `db:"status"`
+		Version int    `db:"version"`
+		DSL     string `db:"dsl"`
+	}
+	if err := r.db.SelectContext(ctx, &open, `
+		SELECT DISTINCT ON (c.name) c.name, c.status, COALESCE(v.version, c.version, 0) AS version,
+		       COALESCE(v.dsl_snapshot, '') AS dsl
+		  FROM kyc_cases c
+		  LEFT JOIN LATERAL (
+		       SELECT version, dsl_snapshot FROM kyc_case_versions
+		        WHERE case_name = c.name ORDER BY version DESC LIMIT 1) v ON TRUE
+		 WHERE c.status = ANY($1)
+		 ORDER BY c.name, c.id DESC`, pq.Array(openStatuses)); err != nil {
+		return nil, fmt.Errorf("failed to load open cases: %w", err)
+	}
+
+	reasons := map[string]map[string]bool{}
+	add := func(caseName, reason string) {
+		if reasons[caseName] == nil {
+			reasons[caseName] = map[string]bool{}
+		}
+		reasons[caseName][reason] = true
+	}
+
+	var captured []struct {
+		CaseName  string `db:"case_name"`
+		Attribute string `db:"attribute_code"`
+		Derived   bool   `db:"derived"`
+	}
+	if err := r.db.SelectContext(ctx, &captured, `
+		SELECT DISTINCT case_name, attribute_code, FALSE AS derived
+		  FROM kyc_case_attribute_values WHERE attribute_code = ANY($1)
+		UNION
+		SELECT case_name, attribute_code, TRUE FROM kyc_case_data_dictionary
+		 WHERE derived AND regulation_code = $2`, pq.Array(attributes), code); err != nil {
+		return nil, fmt.Errorf("failed to load the case values of %s: %w", code, err)
+	}
+	for _, c := range captured {
+		if c.Derived {
+			add(c.CaseName, "derived:"+c.Attribute)
+		} else {
+			add(c.CaseName, "attribute:"+c.Attribute)
+		}
+	}
+
+	docSet, attrSet, policySet := toSet(documents), toSet(attributes), toSet(policies)
+	impacted := []ImpactedCase{}
+	for _, c := range open {
+		if c.DSL != "" {
+			form, err := parser.ParseCase(c.DSL)
+			var compiled *parser.Case
+			if err == nil {
+				compiled, err = parser.Compile(form)
+			}
+			if err != nil {
+				slog.Warn("⚠️  Case skipped in regulation impact", "case_name", c.Name, "version", c.Version, "error", err)
+			} else {
+				for _, req := range compiled.DocumentRequirements {
+					for _, d := range req.Documents {
+						if docSet[d.Code] {
+							add(c.Name, "document:"+d.Code)
+						}
+					}
+				}
+				for _, p := range compiled.Policies {
+					if policySet[p] {
+						add(c.Name, "policy:"+p)
+					}
+				}
+				for _, a := range compiled.DataDictionary {
+					if attrSet[a.Code] {
+						add(c.Name, "attribute:"+a.Code)
+					}
+				}
+				for _, d := range compiled.DerivedAttributes {
+					if d.Regulation == code {
+						add(c.Name, "derived:"+d.Code)
+					}
+				}
+			}
+		}
+		if len(reasons[c.Name]) == 0 {
+			continue
+		}
+		ic := ImpactedCase{Name: c.Name, Status: c.Status, Version: c.Version}
+		for reason := range reasons[c.Name] {
+			ic.Reasons = append(ic.Reasons, reason)
+		}
+		sort.Strings(ic.Reasons)
+		impacted = append(impacted, ic)
+	}
+	return impacted, nil
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
@@ -0,0 +1,105 @@
+// Package region describes the multi-region deployment topology: which
+// regions serve reads, which one is primary for writes, and how a client's
+// region hint maps to the nearest endpoint.
+package region
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+)
+
+// MetadataKey is the gRPC metadata key carrying a client's region hint
+const MetadataKey = "x-kyc-region"
+
+// Topology is the set of regional endpoints known to a server or client
+type Topology struct {
+	// Self is the region this process runs in
+	Self string
+	// Primary is the region that accepts writes
+	Primary string
+	// Endpoints maps region name to its gRPC address
+	Endpoints map[string]string
+	// Fallbacks maps a region to the order of regions tried when it has no endpoint
+	Fallbacks map[string][]string
+}
+
+// FromConfig builds the topology from the region section of the process
+// configuration. Self defaults to "us" and Primary to Self.
+func FromConfig(c config.RegionConfig) (*Topology, error) {
+	t := &Topology{
+		Self:      strings.ToLower(c.Self),
+		Primary:   strings.ToLower(c.Primary),
+		Endpoints: make(map[string]string),
+		Fallbacks: make(map[string][]string),
+	}
+	if t.Self == "" {
+		t.Self = "us"
+	}
+	if t.Primary == "" {
+		t.Primary = t.Self
+	}
+
+	for name, addr := range c.Endpoints {
+		if name == "" || addr == "" {
+			return nil, fmt.Errorf("invalid region endpoint %q=%q (expected region=host:port)", name, addr)
+		}
+		t.Endpoints[strings.ToLower(name)] = addr
+	}
+	for name, order := range c.Fallbacks {
+		regions := make([]string, len(order))
+		for i, r := range order {
+			regions[i] = strings.ToLower(r)
+		}
+		t.Fallbacks[strings.ToLower(name)] = regions
+	}
+
+	if len(t.Endpoints) > 0 {
+		if _, ok := t.Endpoints[t.Primary]; !ok {
+			return nil, fmt.Errorf("primary region %q has no entry in region endpoints", t.Primary)
+		}
+	}
+
+	return t, nil
+}
+
+// IsPrimary reports whether this process runs in the write region
+func (t *Topology) IsPrimary() bool {
+	return t.Self == t.Primary
+}
+
+// PrimaryAddress returns the write endpoint, or "" for a single-region deployment
+func (t *Topology) PrimaryAddress() string {
+	return t.Endpoints[t.Primary]
+}
+
+// Resolve returns the region and address that should serve reads for the hint.
+// Unknown hints fall back through the configured fallback order, then to the
+// server's own region, then to the primary.
+func (t *Topology) Resolve(hint string) (string, string) {
+	hint = strings.ToLower(strings.TrimSpace(hint))
+	if hint == "" {
+		hint = t.Self
+	}
+
+	candidates := append([]string{hint}, t.Fallbacks[hint]...)
+	candidates = append(candidates, t.Self, t.Primary)
+	for _, r := range candidates {
+		if addr, ok := t.Endpoints[r]; ok {
+			return r, addr
+		}
+	}
+	return t.Self, ""
+}
+
+// Regions returns the configured region names in sorted order
+func (t *Topology) Regions() []string {
+	names := make([]string, 0, len(t.Endpoints))
+	for name := range t.Endpoints {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
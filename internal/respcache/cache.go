@@ -0,0 +1,215 @@
+// Package respcache caches the responses of hot read endpoints of
+// kycserver, such as attribute search and metadata stats, so dashboards
+// polling them do not embed the same query or scan the ontology on every
+// request.
+//
+// Responses are keyed on the endpoint and its normalized query parameters
+// (search text lower-cased with whitespace collapsed, parameters sorted),
+// the caller's tenant and whether the caller reads sensitive values
+// unredacted, and served until the TTL. A request sending
+// "Cache-Control: no-cache" or "X-Cache-Bypass: true" skips the cache and
+// refreshes it. Only 200 responses are cached, and never those revealing
+// sensitive values, whose reads are audited. The memory driver keeps
+// responses per process; the redis driver shares them between replicas.
+package respcache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adamtc007/KYC-DSL/internal/auth"
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/metrics"
+	"github.com/adamtc007/KYC-DSL/internal/rag"
+	"github.com/adamtc007/KYC-DSL/internal/redact"
+)
+
+// Headers of the cache
+const (
+	// BypassHeader set to true skips the cache, as "Cache-Control: no-cache" does
+	BypassHeader = "X-Cache-Bypass"
+	// StatusHeader reports HIT, MISS or BYPASS on every cached endpoint
+	StatusHeader = "X-Cache"
+)
+
+// textParams are the query parameters holding search text, normalized
+// as query embeddings are
+var textParams = map[string]bool{"q": true, "term": true}
+
+// Store keeps cached responses by key until they expire
+type Store interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// Cache serves responses from a Store
+type Cache struct {
+	store  Store
+	ttl    time.Duration
+	driver string
+}
+
+// entry is a cached response
+type entry struct {
+	Status   int         `json:"status"`
+	Header   http.Header `json:"header"`
+	Body     []byte      `json:"body"`
+	StoredAt time.Time   `json:"stored_at"`
+}
+
+// New creates the cache of the response_cache configuration; it is nil,
+// caching nothing, when disabled
+func New(cfg config.ResponseCacheConfig) (*Cache, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	var store Store
+	switch cfg.Driver {
+	case "memory":
+		store = NewMemoryStore(cfg.Size)
+	case "redis":
+		store = NewRedisStore(cfg.Redis)
+	default:
+		return nil, fmt.Errorf("unknown response cache driver %q (expected memory or redis)", cfg.Driver)
+	}
+	return &Cache{store: store, ttl: cfg.TTL, driver: cfg.Driver}, nil
+}
+
+// Wrap caches the GET responses of an endpoint, named for the metrics.
+// Store failures are logged and treated as misses, so the cache never
+// fails a request.
+func (c *Cache) Wrap(endpoint string, next http.HandlerFunc) http.HandlerFunc {
+	if c == nil {
+		return next
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			next(w, r)
+			return
+		}
+		ctx := r.Context()
+		key := c.key(endpoint, r)
+
+		result := metrics.ResponseCacheBypass
+		if !bypass(r) {
+			result = metrics.ResponseCacheMiss
+			data, ok, err := c.store.Get(ctx, key)
+			if err != nil {
+				slog.Warn("⚠️  Response cache lookup failed", "endpoint", endpoint, "driver", c.driver, "error", err)
+			}
+			var e entry
+			if ok && json.Unmarshal(data, &e) == nil {
+				metrics.ObserveResponseCache(endpoint, metrics.ResponseCacheHit)
+				for name, values := range e.Header {
+					w.Header()[name] = values
+				}
+				w.Header().Set(StatusHeader, "HIT")
+				w.Header().Set("Age", strconv.Itoa(int(time.Since(e.StoredAt).Seconds())))
+				w.WriteHeader(e.Status)
+				w.Write(e.Body)
+				return
+			}
+		}
+		metrics.ObserveResponseCache(endpoint, result)
+
+		// Headers set before the handler (request ID, rate limits) belong
+		// to this request only
+		before := make(map[string]bool, len(w.Header()))
+		for name := range w.Header() {
+			before[name] = true
+		}
+		w.Header().Set(StatusHeader, strings.ToUpper(result))
+		ctx, revealed := redact.Watch(ctx)
+		rec := &recorder{ResponseWriter: w, status: http.StatusOK}
+		next(rec, r.WithContext(ctx))
+
+		// Responses marked no-store, such as degraded searches, are not kept
+		if rec.status != http.StatusOK || revealed() || noStore(w.Header().Get("Cache-Control")) {
+			return
+		}
+		e := entry{Status: rec.status, Header: http.Header{}, Body: rec.body, StoredAt: time.Now()}
+		for name, values := range w.Header() {
+			if !before[name] && name != StatusHeader {
+				e.Header[name] = values
+			}
+		}
+		data, err := json.Marshal(e)
+		if err == nil {
+			err = c.store.Set(context.WithoutCancel(ctx), key, data, c.ttl)
+		}
+		if err != nil {
+			slog.Warn("⚠️  Response cache write failed", "endpoint", endpoint, "driver", c.driver, "error", err)
+		}
+	}
+}
+
+// key identifies a response: the endpoint, the caller's tenant and
+// redaction, and the normalized query parameters
+func (c *Cache) key(endpoint string, r *http.Request) string {
+	params := url.Values{}
+	for name, values := range r.URL.Query() {
+		for _, v := range values {
+			if textParams[name] {
+				v = rag.NormalizeQuery(v)
+			}
+			params.Add(name, v)
+		}
+	}
+	for _, values := range params {
+		sort.Strings(values)
+	}
+	ctx := r.Context()
+	raw := strings.Join([]string{
+		endpoint, r.URL.Path,
+		auth.Tenant(ctx, r.Header.Get(auth.TenantHeader)),
+		strconv.FormatBool(auth.CanReadPII(ctx)),
+		params.Encode(), // sorted by name
+	}, "\x00")
+	sum := sha256.Sum256([]byte(raw))
+	return endpoint + ":" + hex.EncodeToString(sum[:])
+}
+
+// bypass reports whether a request asks to skip the cache
+func bypass(r *http.Request) bool {
+	if v, err := strconv.ParseBool(r.Header.Get(BypassHeader)); err == nil && v {
+		return true
+	}
+	return noStore(r.Header.Get("Cache-Control"))
+}
+
+// noStore reports whether a Cache-Control header has no-cache or no-store
+func noStore(cacheControl string) bool {
+	for _, directive := range strings.Split(cacheControl, ",") {
+		if d := strings.TrimSpace(strings.ToLower(directive)); d == "no-cache" || d == "no-store" {
+			return true
+		}
+	}
+	return false
+}
+
+// recorder passes a response through while keeping its status and body
+type recorder struct {
+	http.ResponseWriter
+	status int
+	body   []byte
+}
+
+func (r *recorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	r.body = append(r.body, b...)
+	return r.ResponseWriter.Write(b)
+}
@@ -0,0 +1,66 @@
+package respcache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps responses in a size-bounded LRU in the process
+type MemoryStore struct {
+	max int
+
+	mu      sync.Mutex
+	lru     *list.List
+	entries map[string]*list.Element
+}
+
+type memoryEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// NewMemoryStore creates a store keeping up to size responses
+func NewMemoryStore(size int) *MemoryStore {
+	return &MemoryStore{max: size, lru: list.New(), entries: make(map[string]*list.Element)}
+}
+
+// Get returns an unexpired response
+func (s *MemoryStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	el, ok := s.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	e := el.Value.(*memoryEntry)
+	if !time.Now().Before(e.expires) {
+		s.lru.Remove(el)
+		delete(s.entries, key)
+		return nil, false, nil
+	}
+	s.lru.MoveToFront(el)
+	return e.value, true, nil
+}
+
+// Set stores a response, evicting the least recently used
+func (s *MemoryStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	expires := time.Now().Add(ttl)
+	if el, ok := s.entries[key]; ok {
+		e := el.Value.(*memoryEntry)
+		e.value, e.expires = value, expires
+		s.lru.MoveToFront(el)
+		return nil
+	}
+	s.entries[key] = s.lru.PushFront(&memoryEntry{key: key, value: value, expires: expires})
+	for s.lru.Len() > s.max {
+		oldest := s.lru.Back()
+		s.lru.Remove(oldest)
+		delete(s.entries, oldest.Value.(*memoryEntry).key)
+	}
+	return nil
+}
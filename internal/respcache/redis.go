@@ -0,0 +1,162 @@
+package respcache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+)
+
+// maxIdleConns is how many idle Redis connections are kept for reuse
+const maxIdleConns = 8
+
+// RedisStore keeps responses in Redis with an expiry (SET PX), speaking
+// RESP over a small pool of connections
+type RedisStore struct {
+	cfg  config.RedisConfig
+	idle chan *redisConn
+}
+
+type redisConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+// NewRedisStore creates a store on the Redis server of cfg; connections are
+// opened on first use
+func NewRedisStore(cfg config.RedisConfig) *RedisStore {
+	return &RedisStore{cfg: cfg, idle: make(chan *redisConn, maxIdleConns)}
+}
+
+// Get returns an unexpired response
+func (s *RedisStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	reply, err := s.do(ctx, "GET", s.cfg.Prefix+key)
+	if err != nil || reply == nil {
+		return nil, false, err
+	}
+	return reply, true, nil
+}
+
+// Set stores a response until ttl
+func (s *RedisStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	_, err := s.do(ctx, "SET", s.cfg.Prefix+key, string(value), "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	return err
+}
+
+// do runs a command and returns its bulk or simple string reply, nil for
+// a nil reply
+func (s *RedisStore) do(ctx context.Context, args ...string) ([]byte, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reply, err := conn.command(s.deadline(ctx), args...)
+	var redisErr redisError
+	if err != nil && !errors.As(err, &redisErr) {
+		// The connection may hold a partial reply
+		conn.Close()
+		return nil, err
+	}
+	select {
+	case s.idle <- conn:
+	default:
+		conn.Close()
+	}
+	return reply, err
+}
+
+// conn takes an idle connection or opens one, authenticating and
+// selecting the database
+func (s *RedisStore) conn(ctx context.Context) (*redisConn, error) {
+	select {
+	case c := <-s.idle:
+		return c, nil
+	default:
+	}
+	d := net.Dialer{Timeout: s.cfg.Timeout}
+	nc, err := d.DialContext(ctx, "tcp", s.cfg.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to redis at %s: %w", s.cfg.Addr, err)
+	}
+	c := &redisConn{Conn: nc, r: bufio.NewReader(nc)}
+	deadline := s.deadline(ctx)
+	if s.cfg.Password != "" {
+		if _, err := c.command(deadline, "AUTH", s.cfg.Password); err != nil {
+			c.Close()
+			return nil, fmt.Errorf("redis AUTH failed: %w", err)
+		}
+	}
+	if s.cfg.DB != 0 {
+		if _, err := c.command(deadline, "SELECT", strconv.Itoa(s.cfg.DB)); err != nil {
+			c.Close()
+			return nil, fmt.Errorf("redis SELECT %d failed: %w", s.cfg.DB, err)
+		}
+	}
+	return c, nil
+}
+
+// deadline is the earlier of ctx's deadline and the command timeout
+func (s *RedisStore) deadline(ctx context.Context) time.Time {
+	deadline := time.Now().Add(s.cfg.Timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		return d
+	}
+	return deadline
+}
+
+// redisError is an error reply; the connection stays usable
+type redisError string
+
+func (e redisError) Error() string { return string(e) }
+
+// command writes a command as a RESP array of bulk strings and reads its reply
+func (c *redisConn) command(deadline time.Time, args ...string) ([]byte, error) {
+	if err := c.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+	buf := []byte("*" + strconv.Itoa(len(args)) + "\r\n")
+	for _, a := range args {
+		buf = append(buf, "$"+strconv.Itoa(len(a))+"\r\n"...)
+		buf = append(buf, a...)
+		buf = append(buf, "\r\n"...)
+	}
+	if _, err := c.Write(buf); err != nil {
+		return nil, err
+	}
+
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 {
+		return nil, fmt.Errorf("malformed redis reply %q", line)
+	}
+	kind, body := line[0], line[1:len(line)-2]
+	switch kind {
+	case '+', ':':
+		return []byte(body), nil
+	case '-':
+		return nil, redisError(body)
+	case '$':
+		n, err := strconv.Atoi(body)
+		if err != nil {
+			return nil, fmt.Errorf("malformed redis bulk length %q", body)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		data := make([]byte, n+2)
+		if _, err := io.ReadFull(c.r, data); err != nil {
+			return nil, err
+		}
+		return data[:n], nil
+	default:
+		return nil, fmt.Errorf("unexpected redis reply %q", line)
+	}
+}
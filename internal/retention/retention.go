@@ -0,0 +1,346 @@
+// Package retention applies the retention policies of the audit tables
+// (rag_audit_log, kyc_case_amendments, rag_feedback). Rows older than a
+// table's max_age are written to the archive as gzipped JSON lines, one
+// object per batch, and deleted in the same transaction that selected them,
+// so a row is only deleted once its archive object is stored. Every run is
+// recorded per table in kyc_retention_runs; dry runs only count.
+package retention
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/evidence"
+)
+
+// ErrUnknownTable is returned for a table without a retention policy
+var ErrUnknownTable = errors.New("no retention policy for table")
+
+// Table is a table managed by the retention job
+type Table struct {
+	Name string
+	// keep excludes rows that never expire, when the policy says so
+	keep string
+}
+
+// Tables are the managed tables, in the order they are processed
+var Tables = []Table{
+	{Name: "rag_audit_log", keep: "error_message IS NOT NULL"},
+	{Name: "kyc_case_amendments"},
+	{Name: "rag_feedback"},
+}
+
+// Policy returns the policy configured for a table
+func Policy(cfg config.RetentionConfig, table string) (config.RetentionPolicy, error) {
+	switch table {
+	case "rag_audit_log":
+		return cfg.Policies.AuditLog, nil
+	case "kyc_case_amendments":
+		return cfg.Policies.Amendments, nil
+	case "rag_feedback":
+		return cfg.Policies.Feedback, nil
+	}
+	return config.RetentionPolicy{}, fmt.Errorf("%w %q (expected rag_audit_log, kyc_case_amendments or rag_feedback)", ErrUnknownTable, table)
+}
+
+// Run is the outcome of applying the policy of one table
+type Run struct {
+	ID          int64     `db:"id" json:"id"`
+	Table       string    `db:"table_name" json:"table"`
+	Cutoff      time.Time `db:"cutoff" json:"cutoff"`
+	DryRun      bool      `db:"dry_run" json:"dry_run"`
+	Matched     int       `db:"matched" json:"matched"`
+	Archived    int       `db:"archived" json:"archived"`
+	Deleted     int       `db:"deleted" json:"deleted"`
+	ArchiveKeys []string  `db:"-" json:"archive_keys,omitempty"`
+	Status      string    `db:"status" json:"status"`
+	Error       string    `db:"error_message" json:"error,omitempty"`
+	TriggeredBy string    `db:"triggered_by" json:"triggered_by"`
+	StartedAt   time.Time `db:"started_at" json:"started_at"`
+	// Oldest is the creation time of the oldest expired row
+	Oldest *time.Time `db:"-" json:"oldest,omitempty"`
+}
+
+// Job archives and deletes expired rows
+type Job struct {
+	db    *sqlx.DB
+	cfg   config.RetentionConfig
+	store evidence.Store
+}
+
+// NewJob creates a retention job, opening the archive when it is enabled
+func NewJob(db *sqlx.DB, cfg config.RetentionConfig) (*Job, error) {
+	j := &Job{db: db, cfg: cfg}
+	if !cfg.Archive.Enabled {
+		return j, nil
+	}
+	var err error
+	switch cfg.Archive.Driver {
+	case "", "local":
+		j.store = evidence.NewLocalStore(cfg.Archive.Dir)
+	case "s3":
+		j.store, err = evidence.NewS3Store("s3", cfg.Archive.S3, cfg.Archive.S3.PathStyle)
+	case "minio":
+		j.store, err = evidence.NewS3Store("minio", cfg.Archive.S3, true)
+	default:
+		err = fmt.Errorf("unknown archive driver %q (expected local, s3 or minio)", cfg.Archive.Driver)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open retention archive: %w", err)
+	}
+	return j, nil
+}
+
+// Run applies the policies every interval until ctx is cancelled
+func (j *Job) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		runs, err := j.RunOnce(ctx, "", j.cfg.DryRun, "scheduler")
+		if err != nil {
+			slog.Warn("⚠️  Retention run failed", "error", err)
+		}
+		for _, r := range runs {
+			if r.Matched > 0 {
+				slog.Info("🗄️  Retention run", "table", r.Table, "dry_run", r.DryRun, "matched", r.Matched,
+					"archived", r.Archived, "deleted", r.Deleted, "cutoff", r.Cutoff)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce applies the policy of table, or of every table when it is empty.
+// Tables whose max_age is 0 are skipped. A failure on one table is recorded
+// and does not stop the others; the first is returned.
+func (j *Job) RunOnce(ctx context.Context, table string, dryRun bool, triggeredBy string) ([]Run, error) {
+	if table != "" {
+		if _, err := Policy(j.cfg, table); err != nil {
+			return nil, err
+		}
+	}
+
+	var runs []Run
+	var firstErr error
+	for _, t := range Tables {
+		if table != "" && t.Name != table {
+			continue
+		}
+		policy, _ := Policy(j.cfg, t.Name)
+		if policy.MaxAge <= 0 {
+			continue
+		}
+		run, err := j.apply(ctx, t, policy, dryRun, triggeredBy)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		runs = append(runs, *run)
+	}
+	return runs, firstErr
+}
+
+// apply archives and deletes the expired rows of one table in batches,
+// recording the run
+func (j *Job) apply(ctx context.Context, t Table, policy config.RetentionPolicy, dryRun bool, triggeredBy string) (*Run, error) {
+	run := &Run{
+		Table:       t.Name,
+		Cutoff:      time.Now().Add(-policy.MaxAge).UTC().Truncate(time.Second),
+		DryRun:      dryRun,
+		Status:      "running",
+		TriggeredBy: triggeredBy,
+		StartedAt:   time.Now(),
+	}
+	where := "created_at < $1"
+	if policy.KeepErrors && t.keep != "" {
+		where += " AND NOT (" + t.keep + ")"
+	}
+
+	if err := j.db.GetContext(ctx, &run.ID, `
+		INSERT INTO kyc_retention_runs (table_name, cutoff, dry_run, triggered_by)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`, run.Table, run.Cutoff, run.DryRun, run.TriggeredBy); err != nil {
+		return run, fmt.Errorf("failed to record retention run: %w", err)
+	}
+
+	var err error
+	if dryRun {
+		err = j.count(ctx, t, where, run)
+	} else {
+		for {
+			var n int
+			n, err = j.batch(ctx, t, where, run)
+			if err != nil || n < j.cfg.BatchSize {
+				break
+			}
+		}
+	}
+
+	run.Status = "completed"
+	if err != nil {
+		run.Status = "failed"
+		run.Error = err.Error()
+	}
+	// The run is finished even if ctx was cancelled mid-way
+	if _, ferr := j.db.ExecContext(context.WithoutCancel(ctx), `
+		UPDATE kyc_retention_runs
+		SET matched = $2, archived = $3, deleted = $4, archive_keys = $5,
+		    status = $6, error_message = NULLIF($7, ''), finished_at = CURRENT_TIMESTAMP
+		WHERE id = $1`,
+		run.ID, run.Matched, run.Archived, run.Deleted, pq.Array(run.ArchiveKeys), run.Status, run.Error); ferr != nil && err == nil {
+		err = fmt.Errorf("failed to record retention run: %w", ferr)
+	}
+	if err != nil {
+		return run, fmt.Errorf("retention of %s failed: %w", t.Name, err)
+	}
+	return run, nil
+}
+
+// count reports the expired rows of a dry run
+func (j *Job) count(ctx context.Context, t Table, where string, run *Run) error {
+	var res struct {
+		Matched int        `db:"matched"`
+		Oldest  *time.Time `db:"oldest"`
+	}
+	if err := j.db.GetContext(ctx, &res, `
+		SELECT COUNT(*) AS matched, MIN(created_at) AS oldest
+		FROM `+t.Name+` WHERE `+where, run.Cutoff); err != nil {
+		return fmt.Errorf("failed to count expired rows: %w", err)
+	}
+	run.Matched, run.Oldest = res.Matched, res.Oldest
+	return nil
+}
+
+// batch archives and deletes up to BatchSize expired rows in one
+// transaction and returns how many it found. Rows locked by a concurrent
+// run are skipped, so replicas never archive a row twice.
+func (j *Job) batch(ctx context.Context, t Table, where string, run *Run) (int, error) {
+	tx, err := j.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	var rows []struct {
+		ID        int64     `db:"id"`
+		CreatedAt time.Time `db:"created_at"`
+		Row       string    `db:"row"`
+	}
+	if err := tx.SelectContext(ctx, &rows, `
+		SELECT t.id, t.created_at, row_to_json(t)::text AS row
+		FROM `+t.Name+` t
+		WHERE `+where+`
+		ORDER BY t.id
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED`, run.Cutoff, j.cfg.BatchSize); err != nil {
+		return 0, fmt.Errorf("failed to select expired rows: %w", err)
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	ids := make([]int64, len(rows))
+	for i, r := range rows {
+		ids[i] = r.ID
+		if run.Oldest == nil || r.CreatedAt.Before(*run.Oldest) {
+			oldest := r.CreatedAt
+			run.Oldest = &oldest
+		}
+	}
+
+	var key string
+	if j.store != nil {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		for _, r := range rows {
+			zw.Write([]byte(r.Row)) //nolint:errcheck // writes to a buffer
+			zw.Write([]byte{'\n'})  //nolint:errcheck // writes to a buffer
+		}
+		if err := zw.Close(); err != nil {
+			return 0, fmt.Errorf("failed to compress archive: %w", err)
+		}
+		key = path.Join(j.cfg.Archive.Prefix, t.Name, run.StartedAt.UTC().Format("2006/01/02"),
+			fmt.Sprintf("%s-%d-%d.jsonl.gz", t.Name, ids[0], ids[len(ids)-1]))
+		if err := j.store.Put(ctx, key, &buf, int64(buf.Len()), "application/gzip"); err != nil {
+			return 0, fmt.Errorf("failed to archive %s: %w", key, err)
+		}
+	}
+
+	res, err := tx.ExecContext(ctx, `DELETE FROM `+t.Name+` WHERE id = ANY($1)`, pq.Array(ids))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired rows: %w", err)
+	}
+	deleted, _ := res.RowsAffected()
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	run.Matched += len(rows)
+	run.Deleted += int(deleted)
+	if key != "" {
+		run.Archived += len(rows)
+		run.ArchiveKeys = append(run.ArchiveKeys, key)
+	}
+	return len(rows), nil
+}
+
+// Runs lists the most recent runs, newest first, optionally of one table
+func (j *Job) Runs(ctx context.Context, table string, limit int) ([]Run, error) {
+	var rows []struct {
+		Run
+		Keys pq.StringArray `db:"archive_keys"`
+	}
+	query := `
+		SELECT id, table_name, cutoff, dry_run, matched, archived, deleted, archive_keys,
+		       status, COALESCE(error_message, '') AS error_message,
+		       COALESCE(triggered_by, '') AS triggered_by, started_at
+		FROM kyc_retention_runs`
+	args := []interface{}{limit}
+	if table != "" {
+		query += ` WHERE table_name = $2`
+		args = append(args, table)
+	}
+	if err := j.db.SelectContext(ctx, &rows, query+` ORDER BY started_at DESC, id DESC LIMIT $1`, args...); err != nil {
+		return nil, fmt.Errorf("failed to list retention runs: %w", err)
+	}
+	runs := make([]Run, len(rows))
+	for i, r := range rows {
+		runs[i] = r.Run
+		runs[i].ArchiveKeys = r.Keys
+	}
+	return runs, nil
+}
+
+// Describe summarises a table's policy for display
+func Describe(cfg config.RetentionConfig, t Table) string {
+	policy, _ := Policy(cfg, t.Name)
+	if policy.MaxAge <= 0 {
+		return "kept forever"
+	}
+	parts := []string{"expire after " + policy.MaxAge.String()}
+	if policy.KeepErrors && t.keep != "" {
+		parts = append(parts, "keep "+t.keep)
+	}
+	if cfg.Archive.Enabled {
+		parts = append(parts, "archived to "+cfg.Archive.Driver)
+	} else {
+		parts = append(parts, "deleted without archive")
+	}
+	return strings.Join(parts, ", ")
+}
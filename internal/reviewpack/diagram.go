@@ -0,0 +1,165 @@
+package reviewpack
+
+import (
+	"fmt"
+
+	"github.com/adamtc007/KYC-DSL/internal/narrative"
+	"github.com/adamtc007/KYC-DSL/internal/pdf"
+)
+
+// Ownership diagram geometry in points
+const (
+	boxHeight   = 30.0
+	rowGap      = 42.0
+	maxBoxWidth = 120.0
+	boxGap      = 10.0
+	maxPerRow   = 6
+)
+
+// node is a box of the diagram
+type node struct {
+	label, detail string
+	x, y, w       float64 // bottom-left corner and width
+}
+
+func (n node) top() (float64, float64)    { return n.x + n.w/2, n.y + boxHeight }
+func (n node) bottom() (float64, float64) { return n.x + n.w/2, n.y }
+
+// ownership draws the documented ownership structure: beneficial owners above
+// the direct owners, the owners above the entity and the controlling persons
+// below it
+func (w *writer) ownership(f *narrative.Facts) {
+	w.heading("2. Ownership Structure")
+	if len(f.Owners) == 0 && len(f.UBOs) == 0 && len(f.Controllers) == 0 {
+		w.paragraph("Ownership structure has not been documented.", 0, pdf.Regular)
+		return
+	}
+
+	var rows [][]node
+	var ubos, owners, controllers []node
+	for _, h := range f.UBOs {
+		ubos = append(ubos, node{label: h.Name, detail: pct(h.Percent) + " beneficial"})
+	}
+	for _, h := range f.Owners {
+		owners = append(owners, node{label: h.Name, detail: "direct owner"})
+	}
+	for _, c := range f.Controllers {
+		controllers = append(controllers, node{label: c.Name, detail: c.Role})
+	}
+	entityName := f.Entity
+	if entityName == "" {
+		entityName = f.CaseID
+	}
+	entity := []node{{label: entityName, detail: "entity under review"}}
+
+	for _, r := range [][]node{ubos, owners, entity, controllers} {
+		if len(r) > 0 {
+			rows = append(rows, r)
+		}
+	}
+	height := float64(len(rows))*boxHeight + float64(len(rows)-1)*rowGap
+	w.ensure(height + 20)
+
+	y := w.y - boxHeight
+	for i := range rows {
+		rows[i] = place(rows[i], y)
+		y -= boxHeight + rowGap
+	}
+
+	// Index the placed rows back by role
+	idx := 0
+	if len(ubos) > 0 {
+		ubos = rows[idx]
+		idx++
+	}
+	if len(owners) > 0 {
+		owners = rows[idx]
+		idx++
+	}
+	entity = rows[idx]
+	idx++
+	if len(controllers) > 0 {
+		controllers = rows[idx]
+	}
+	target := entity[0]
+
+	for _, o := range owners {
+		x1, y1 := o.bottom()
+		x2, y2 := target.top()
+		w.page.Line(x1, y1, x2, y2, 0.8)
+		w.page.Text((x1+x2)/2+3, (y1+y2)/2, 7, pdf.Bold, o.detail)
+	}
+	if len(ubos) > 0 {
+		// Beneficial owners hold through the direct owners: join them on a
+		// bus above the owner row, or straight to the entity without owners
+		busY := 0.0
+		if len(owners) > 0 {
+			_, oy := owners[0].top()
+			busY = oy + rowGap/2
+			w.page.DashedLine(owners[0].x, busY, owners[len(owners)-1].x+owners[len(owners)-1].w, busY, 0.6)
+		}
+		for _, u := range ubos {
+			x1, y1 := u.bottom()
+			if len(owners) == 0 {
+				x2, y2 := target.top()
+				w.page.DashedLine(x1, y1, x2, y2, 0.6)
+				continue
+			}
+			bx := clamp(x1, owners[0].x, owners[len(owners)-1].x+owners[len(owners)-1].w)
+			w.page.DashedLine(x1, y1, bx, busY, 0.6)
+		}
+	}
+	for _, c := range controllers {
+		x1, y1 := c.top()
+		x2, y2 := target.bottom()
+		w.page.DashedLine(x1, y1, x2, y2, 0.6)
+	}
+
+	for _, r := range rows {
+		for _, n := range r {
+			w.box(n)
+		}
+	}
+
+	w.y -= height + 12
+	w.paragraph("Solid lines: direct ownership. Dashed lines: beneficial ownership (top) and control (bottom).",
+		0, pdf.Regular)
+	if total := f.DirectOwnership(); len(f.Owners) > 0 {
+		w.paragraph(fmt.Sprintf("Documented direct ownership totals %s.", pct(total)), 0, pdf.Regular)
+	}
+}
+
+// box draws a node with its label and detail
+func (w *writer) box(n node) {
+	w.page.FillRect(n.x, n.y, n.w, boxHeight, 0.95)
+	w.page.Rect(n.x, n.y, n.w, boxHeight, 0.8)
+	label := pdf.Truncate(n.label, n.w-6, 8, pdf.Bold)
+	w.page.Text(n.x+(n.w-pdf.TextWidth(label, 8, pdf.Bold))/2, n.y+boxHeight-12, 8, pdf.Bold, label)
+	detail := pdf.Truncate(n.detail, n.w-6, 7, pdf.Regular)
+	w.page.Text(n.x+(n.w-pdf.TextWidth(detail, 7, pdf.Regular))/2, n.y+6, 7, pdf.Regular, detail)
+}
+
+// place lays out a row centred on the page at height y; rows with more than
+// maxPerRow nodes end in a summary box
+func place(row []node, y float64) []node {
+	if len(row) > maxPerRow {
+		more := len(row) - (maxPerRow - 1)
+		row = append(row[:maxPerRow-1:maxPerRow-1], node{label: fmt.Sprintf("+%d more", more), detail: "see case DSL"})
+	}
+	n := float64(len(row))
+	width := min(maxBoxWidth, (contentWidth-(n-1)*boxGap)/n)
+	x := margin + (contentWidth-(n*width+(n-1)*boxGap))/2
+	for i := range row {
+		row[i].x, row[i].y, row[i].w = x, y, width
+		x += width + boxGap
+	}
+	return row
+}
+
+func pct(v float64) string {
+	return fmt.Sprintf("%.2f%%", v)
+}
+
+func clamp(v, lo, hi float64) float64 {
+	return max(lo, min(v, hi))
+}
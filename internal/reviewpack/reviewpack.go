@@ -0,0 +1,238 @@
+// Package reviewpack renders a case version into a PDF review pack for
+// committee minutes and regulator requests: a summary, an ownership diagram,
+// the document checklist, risk factors, outstanding items and signature
+// blocks. Every page carries the canonical hash of the version's DSL so the
+// pack can be matched to the exact version it describes.
+package reviewpack
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/narrative"
+	"github.com/adamtc007/KYC-DSL/internal/pdf"
+)
+
+// ApprovedStatus is the case_versions status a pack is normally rendered for
+const ApprovedStatus = "approved"
+
+// Signatories are the roles that sign a review pack
+var Signatories = []string{"Prepared by (KYC Analyst)", "Reviewed by (KYC Reviewer)", "Approved by (Committee Chair)"}
+
+// Page layout in points
+const (
+	margin       = 50.0
+	contentWidth = pdf.PageWidth - 2*margin
+	top          = pdf.PageHeight - margin
+	bottom       = margin + 30 // leaves room for the footer
+	bodySize     = 10.0
+	lineHeight   = 14.0
+)
+
+// Filename is the suggested file name of the pack of a case version
+func Filename(f *narrative.Facts) string {
+	return fmt.Sprintf("%s-v%s-review-pack.pdf", f.CaseID, f.VersionID)
+}
+
+// Render lays out the review pack of a case version. Packs of versions that
+// are not approved are marked as drafts on every page.
+func Render(f *narrative.Facts) ([]byte, int) {
+	draft := f.Status != ApprovedStatus
+	w := &writer{doc: pdf.New(fmt.Sprintf("KYC Review Pack - %s", f.CaseID), f.GeneratedAt)}
+	w.newPage()
+
+	w.title(f, draft)
+	w.summary(f)
+	w.ownership(f)
+	w.documents(f)
+	w.risks(f)
+	w.gaps(f)
+	w.signatures(f)
+
+	w.footers(f, draft)
+	return w.doc.Bytes(), len(w.doc.Pages())
+}
+
+// writer flows content down the pages of a document
+type writer struct {
+	doc  *pdf.Document
+	page *pdf.Page
+	y    float64
+}
+
+func (w *writer) newPage() {
+	w.page = w.doc.AddPage()
+	w.y = top
+}
+
+// ensure starts a new page unless height points are left on this one
+func (w *writer) ensure(height float64) {
+	if w.y-height < bottom {
+		w.newPage()
+	}
+}
+
+func (w *writer) heading(text string) {
+	w.ensure(3 * lineHeight)
+	w.y -= 10
+	w.page.Text(margin, w.y, 13, pdf.Bold, text)
+	w.y -= 5
+	w.page.Line(margin, w.y, margin+contentWidth, w.y, 0.5)
+	w.y -= lineHeight
+}
+
+// paragraph writes wrapped text indented by indent points
+func (w *writer) paragraph(text string, indent float64, font pdf.Font) {
+	for _, line := range pdf.Wrap(text, contentWidth-indent, bodySize, font) {
+		w.ensure(lineHeight)
+		w.page.Text(margin+indent, w.y, bodySize, font, line)
+		w.y -= lineHeight
+	}
+}
+
+// field writes a label and its value on one line
+func (w *writer) field(label, value string) {
+	if value == "" {
+		value = "-"
+	}
+	w.ensure(lineHeight)
+	w.page.Text(margin, w.y, bodySize, pdf.Bold, label)
+	w.page.Text(margin+130, w.y, bodySize, pdf.Regular, pdf.Truncate(value, contentWidth-130, bodySize, pdf.Regular))
+	w.y -= lineHeight
+}
+
+func (w *writer) title(f *narrative.Facts, draft bool) {
+	w.page.Text(margin, w.y-18, 20, pdf.Bold, "KYC Review Pack")
+	w.y -= 40
+	w.page.Text(margin, w.y, 14, pdf.Regular, f.CaseID)
+	w.y -= 22
+	if draft {
+		w.page.FillRect(margin, w.y-6, contentWidth, 22, 0.85)
+		w.page.Text(margin+8, w.y, 11, pdf.Bold,
+			fmt.Sprintf("DRAFT - version status is %q, not approved", f.Status))
+		w.y -= 28
+	}
+}
+
+func (w *writer) summary(f *narrative.Facts) {
+	w.heading("1. Case Summary")
+	w.field("Case", f.CaseID)
+	w.field("Version", fmt.Sprintf("%s (%s)", f.VersionID, f.VersionDate))
+	w.field("Status", f.Status)
+	w.field("Version hash", f.VersionHash)
+	w.field("Nature", f.Nature)
+	w.field("Purpose", f.Purpose)
+	w.field("Business unit", f.CBU)
+	w.field("Policies", strings.Join(f.Policies, ", "))
+	w.field("Obligations", strings.Join(f.Obligations, ", "))
+	w.field("KYC token", f.Token)
+	w.field("Generated", f.GeneratedAt.Format("2006-01-02 15:04 MST"))
+
+	if len(f.Participants) > 0 {
+		w.y -= 6
+		w.paragraph("Participants of the business unit:", 0, pdf.Bold)
+		for _, p := range f.Participants {
+			line := fmt.Sprintf("%s - %s (%s)", p.Name, p.Role, p.EntityType)
+			if p.Jurisdiction != "" {
+				line += ", " + p.Jurisdiction
+			}
+			w.paragraph("• "+line, 10, pdf.Regular)
+		}
+	}
+}
+
+func (w *writer) documents(f *narrative.Facts) {
+	w.heading("3. Document Checklist")
+	if len(f.Documents) == 0 {
+		w.paragraph("No document requirements are recorded for this case.", 0, pdf.Regular)
+		return
+	}
+	evidenced := 0
+	for _, d := range f.Documents {
+		w.ensure(lineHeight)
+		w.page.Rect(margin, w.y-1, 8, 8, 0.7)
+		if d.Evidenced {
+			evidenced++
+			w.page.Line(margin+1.5, w.y+3, margin+3.5, w.y+0.5, 1)
+			w.page.Line(margin+3.5, w.y+0.5, margin+7, w.y+6.5, 1)
+		}
+		label := d.Code
+		if d.Name != "" {
+			label += " - " + d.Name
+		}
+		w.page.Text(margin+16, w.y, bodySize, pdf.Regular, pdf.Truncate(label, contentWidth-80, bodySize, pdf.Regular))
+		w.page.Text(margin+contentWidth-50, w.y, bodySize, pdf.Regular, d.Jurisdiction)
+		w.y -= lineHeight
+	}
+	w.y -= 4
+	w.paragraph(fmt.Sprintf("%d of %d required documents are cited as a source in the data dictionary.",
+		evidenced, len(f.Documents)), 0, pdf.Regular)
+}
+
+func (w *writer) risks(f *narrative.Facts) {
+	w.heading("4. Risk Factors")
+	if len(f.RiskFactors) == 0 {
+		w.paragraph("No derived attribute rules have been evaluated for this case.", 0, pdf.Regular)
+		return
+	}
+	for _, rf := range f.RiskFactors {
+		flag := "clear"
+		if rf.Raised {
+			flag = "RAISED"
+		}
+		w.paragraph(fmt.Sprintf("%s: %s [%s]", rf.Code, rf.Value, flag), 0, pdf.Bold)
+		w.paragraph(rf.Explanation, 10, pdf.Regular)
+		w.y -= 2
+	}
+}
+
+func (w *writer) gaps(f *narrative.Facts) {
+	w.heading("5. Outstanding Items")
+	if len(f.Gaps) == 0 {
+		w.paragraph("None.", 0, pdf.Regular)
+		return
+	}
+	for _, g := range f.Gaps {
+		w.paragraph("• "+g, 0, pdf.Regular)
+	}
+}
+
+func (w *writer) signatures(f *narrative.Facts) {
+	w.heading("6. Signatures")
+	w.paragraph(fmt.Sprintf("By signing, the signatories confirm they reviewed version %s of case %s (hash %s).",
+		f.VersionID, f.CaseID, shortHash(f.VersionHash)), 0, pdf.Regular)
+	w.y -= 8
+	for _, role := range Signatories {
+		w.ensure(70)
+		w.page.Text(margin, w.y, bodySize, pdf.Bold, role)
+		w.y -= 28
+		for i, label := range []string{"Name", "Signature", "Date"} {
+			x := margin + float64(i)*contentWidth/3
+			w.page.Line(x, w.y, x+contentWidth/3-15, w.y, 0.5)
+			w.page.Text(x, w.y-10, 8, pdf.Regular, label)
+		}
+		w.y -= 32
+	}
+}
+
+// footers stamps every page with the case, version hash and page number
+func (w *writer) footers(f *narrative.Facts, draft bool) {
+	pages := w.doc.Pages()
+	for i, p := range pages {
+		p.Line(margin, margin+12, margin+contentWidth, margin+12, 0.3)
+		left := fmt.Sprintf("%s  v%s  sha256:%s", f.CaseID, f.VersionID, f.VersionHash)
+		if draft {
+			left = "DRAFT  " + left
+		}
+		p.Text(margin, margin, 7, pdf.Regular, pdf.Truncate(left, contentWidth-60, 7, pdf.Regular))
+		right := fmt.Sprintf("Page %d of %d", i+1, len(pages))
+		p.Text(margin+contentWidth-pdf.TextWidth(right, 7, pdf.Regular), margin, 7, pdf.Regular, right)
+	}
+}
+
+func shortHash(h string) string {
+	if len(h) > 12 {
+		return h[:12]
+	}
+	return h
+}
@@ -0,0 +1,312 @@
+// Package risk combines the derived attributes of a case into a customer
+// risk score. Each scored attribute (config risk.weights) contributes its
+// weight times its strength: 1 for a true flag, the value over its scale for
+// a numeric attribute (config risk.scales, fractions when unscaled). The
+// score is the weighted share out of 100, and the rating the highest band
+// (config risk.bands) it reaches. Scores are stored per case version with
+// every factor's contribution, and the rating is written to the case data
+// dictionary as the derived CUSTOMER_RISK_RATING.
+package risk
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/adamtc007/KYC-DSL/internal/actor"
+	"github.com/adamtc007/KYC-DSL/internal/casedict"
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/pii"
+)
+
+// RatingAttribute is the data dictionary attribute the rating is written to
+const RatingAttribute = "CUSTOMER_RISK_RATING"
+
+// LowRating is the rating of a score below every band
+const LowRating = "LOW"
+
+// Factor sources
+const (
+	SourceEvaluation = "evaluation"
+	SourceCaptured   = "captured"
+	SourceMissing    = "missing"
+)
+
+var (
+	// ErrCaseNotFound is returned when scoring a case that does not exist
+	ErrCaseNotFound = errors.New("case not found")
+	// ErrNotScored is returned for the score of a case never scored
+	ErrNotScored = errors.New("case has not been scored")
+)
+
+// Value is a case's value of a scored attribute
+type Value struct {
+	Value        string
+	ValueType    string
+	Source       string
+	EvaluationID *int64
+}
+
+// Model is a scoring configuration
+type Model struct {
+	weights map[string]float64
+	scales  map[string]float64
+	bands   []band
+}
+
+type band struct {
+	rating string
+	min    float64
+}
+
+// NewModel builds a scoring model from the risk configuration; attributes
+// with a zero weight are not scored
+func NewModel(cfg config.RiskConfig) *Model {
+	m := &Model{weights: map[string]float64{}, scales: map[string]float64{}}
+	for attr, w := range cfg.Weights {
+		if w > 0 {
+			m.weights[strings.ToUpper(attr)] = w
+		}
+	}
+	for attr, s := range cfg.Scales {
+		m.scales[strings.ToUpper(attr)] = s
+	}
+	for rating, min := range cfg.Bands {
+		m.bands = append(m.bands, band{rating: strings.ToUpper(rating), min: min})
+	}
+	sort.Slice(m.bands, func(i, j int) bool { return m.bands[i].min > m.bands[j].min })
+	return m
+}
+
+// Attributes returns the scored attributes, heaviest first
+func (m *Model) Attributes() []string {
+	attrs := make([]string, 0, len(m.weights))
+	for attr := range m.weights {
+		attrs = append(attrs, attr)
+	}
+	sort.Slice(attrs, func(i, j int) bool {
+		if m.weights[attrs[i]] != m.weights[attrs[j]] {
+			return m.weights[attrs[i]] > m.weights[attrs[j]]
+		}
+		return attrs[i] < attrs[j]
+	})
+	return attrs
+}
+
+// Score weighs the values of the scored attributes into a score (0-100),
+// its rating and the factors, heaviest first. Missing and unreadable
+// values contribute nothing.
+func (m *Model) Score(values map[string]Value) (float64, string, []model.RiskFactor) {
+	var total float64
+	for _, w := range m.weights {
+		total += w
+	}
+
+	var score float64
+	factors := []model.RiskFactor{}
+	for _, attr := range m.Attributes() {
+		f := model.RiskFactor{Attribute: attr, Weight: m.weights[attr], Source: SourceMissing}
+		if v, ok := values[attr]; ok {
+			f.Value, f.Source, f.EvaluationID = v.Value, v.Source, v.EvaluationID
+			strength, note := m.strength(attr, v)
+			f.Note = note
+			if total > 0 {
+				f.Contribution = round(100 * f.Weight * strength / total)
+			}
+		}
+		score += f.Contribution
+		factors = append(factors, f)
+	}
+	score = round(math.Min(score, 100))
+	return score, m.Rating(score), factors
+}
+
+// Rating returns the highest band a score reaches
+func (m *Model) Rating(score float64) string {
+	for _, b := range m.bands {
+		if score >= b.min {
+			return b.rating
+		}
+	}
+	return LowRating
+}
+
+// strength reads a value as a share (0-1) of its attribute's weight
+func (m *Model) strength(attr string, v Value) (float64, string) {
+	if b, err := strconv.ParseBool(v.Value); err == nil && v.ValueType != "numeric" {
+		if b {
+			return 1, ""
+		}
+		return 0, ""
+	}
+	n, err := strconv.ParseFloat(v.Value, 64)
+	if err != nil {
+		return 0, fmt.Sprintf("value %q is neither a flag nor a number", v.Value)
+	}
+	if scale, ok := m.scales[attr]; ok {
+		n /= scale
+	}
+	if n < 0 || n > 1 {
+		return math.Max(0, math.Min(n, 1)), "value outside the scale, clamped"
+	}
+	return n, ""
+}
+
+func round(f float64) float64 {
+	return math.Round(f*100) / 100
+}
+
+const scoreColumns = `id, case_name, case_version, score::float8 AS score, rating, COALESCE(actor, '') AS actor, scored_at`
+
+// scoreRow is a kyc_risk_scores row with its factors still encoded
+type scoreRow struct {
+	model.RiskScore
+	FactorsJSON []byte `db:"factors"`
+}
+
+func (r scoreRow) toModel() (*model.RiskScore, error) {
+	s := r.RiskScore
+	if err := json.Unmarshal(r.FactorsJSON, &s.Factors); err != nil {
+		return nil, fmt.Errorf("invalid factors of risk score #%d: %w", s.ID, err)
+	}
+	return &s, nil
+}
+
+// Scorer scores cases and stores their scores
+type Scorer struct {
+	db    *sqlx.DB
+	model *Model
+}
+
+// NewScorer creates a scorer with a scoring configuration
+func NewScorer(db *sqlx.DB, cfg config.RiskConfig) *Scorer {
+	return &Scorer{db: db, model: NewModel(cfg)}
+}
+
+// Score scores the latest version of a case from the latest successful
+// evaluation of each scored attribute; values captured in the case data
+// dictionary take precedence. The score replaces any earlier score of the
+// version and the rating is written to the data dictionary.
+func (s *Scorer) Score(ctx context.Context, caseName string) (*model.RiskScore, error) {
+	var exists bool
+	if err := s.db.GetContext(ctx, &exists, `SELECT EXISTS (SELECT 1 FROM kyc_cases WHERE name = $1)`, caseName); err != nil {
+		return nil, fmt.Errorf("failed to look up case %s: %w", caseName, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrCaseNotFound, caseName)
+	}
+
+	result := &model.RiskScore{CaseName: caseName, Actor: actor.FromContext(ctx).Name}
+	if err := s.db.GetContext(ctx, &result.CaseVersion, `
+		SELECT COALESCE(MAX(version), 0) FROM kyc_case_versions WHERE case_name = $1`, caseName); err != nil {
+		return nil, fmt.Errorf("failed to find the latest version of %s: %w", caseName, err)
+	}
+
+	values, err := s.values(ctx, caseName)
+	if err != nil {
+		return nil, err
+	}
+	result.Score, result.Rating, result.Factors = s.model.Score(values)
+
+	factors, err := json.Marshal(result.Factors)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode risk factors: %w", err)
+	}
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if err := tx.GetContext(ctx, result, `
+		INSERT INTO kyc_risk_scores (case_name, case_version, score, rating, factors, actor)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''))
+		ON CONFLICT (case_name, case_version) DO UPDATE
+		   SET score = EXCLUDED.score, rating = EXCLUDED.rating, factors = EXCLUDED.factors,
+		       actor = EXCLUDED.actor, scored_at = CURRENT_TIMESTAMP
+		RETURNING `+scoreColumns,
+		caseName, result.CaseVersion, result.Score, result.Rating, factors, result.Actor); err != nil {
+		return nil, fmt.Errorf("failed to store risk score of %s: %w", caseName, err)
+	}
+	if err := casedict.Record(ctx, tx, caseName, RatingAttribute, result.Rating, "string",
+		fmt.Sprintf("risk score %.2f (kyc_risk_scores #%d)", result.Score, result.ID)); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit risk score: %w", err)
+	}
+	return result, nil
+}
+
+// values returns the case's values of the scored attributes
+func (s *Scorer) values(ctx context.Context, caseName string) (map[string]Value, error) {
+	var evaluations []struct {
+		ID          int64  `db:"id"`
+		DerivedCode string `db:"derived_code"`
+		Value       string `db:"value"`
+		ValueType   string `db:"value_type"`
+	}
+	if err := s.db.SelectContext(ctx, &evaluations, `
+		SELECT DISTINCT ON (derived_code) id, derived_code,
+		       COALESCE(value, '') AS value, COALESCE(value_type, '') AS value_type
+		  FROM kyc_lineage_evaluations
+		 WHERE case_name = $1 AND success
+		 ORDER BY derived_code, evaluated_at DESC, id DESC`, caseName); err != nil {
+		return nil, fmt.Errorf("failed to load evaluations of %s: %w", caseName, err)
+	}
+	var captured []struct {
+		AttributeCode string `db:"attribute_code"`
+		Value         string `db:"value"`
+		KeyID         string `db:"key_id"`
+		ValueType     string `db:"value_type"`
+	}
+	if err := s.db.SelectContext(ctx, &captured, `
+		SELECT attribute_code, COALESCE(value, '') AS value, COALESCE(key_id, '') AS key_id,
+		       COALESCE(value_type, '') AS value_type
+		  FROM kyc_case_data_dictionary
+		 WHERE case_name = $1 AND NOT derived`, caseName); err != nil {
+		return nil, fmt.Errorf("failed to load data dictionary of %s: %w", caseName, err)
+	}
+
+	values := map[string]Value{}
+	for _, e := range evaluations {
+		if _, scored := s.model.weights[e.DerivedCode]; scored {
+			id := e.ID
+			values[e.DerivedCode] = Value{Value: e.Value, ValueType: e.ValueType, Source: SourceEvaluation, EvaluationID: &id}
+		}
+	}
+	for _, c := range captured {
+		if _, scored := s.model.weights[c.AttributeCode]; scored {
+			value, err := pii.Reveal(ctx, caseName, c.AttributeCode, c.Value, c.KeyID)
+			if err != nil {
+				return nil, err
+			}
+			values[c.AttributeCode] = Value{Value: value, ValueType: c.ValueType, Source: SourceCaptured}
+		}
+	}
+	return values, nil
+}
+
+// Latest returns the score of a case's latest scored version
+func (s *Scorer) Latest(ctx context.Context, caseName string) (*model.RiskScore, error) {
+	var row scoreRow
+	err := s.db.GetContext(ctx, &row, `
+		SELECT `+scoreColumns+`, factors FROM kyc_risk_scores
+		 WHERE case_name = $1 ORDER BY case_version DESC LIMIT 1`, caseName)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %s", ErrNotScored, caseName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load risk score of %s: %w", caseName, err)
+	}
+	return row.toModel()
+}
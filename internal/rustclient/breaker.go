@@ -0,0 +1,68 @@
+package rustclient
+
+import (
+	"sync"
+	"time"
+)
+
+// breaker is a circuit breaker for one service address. It opens after
+// threshold consecutive transient failures; calls then fail fast until the
+// cooldown has passed, when a single call probes the service and either
+// closes the breaker or opens it again.
+type breaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+// breakers holds the breaker of each address, shared by every client of the
+// process since clients are usually created per command or request
+var breakers sync.Map // addr -> *breaker
+
+func breakerFor(addr string, threshold int, cooldown time.Duration) *breaker {
+	b, _ := breakers.LoadOrStore(addr, &breaker{threshold: threshold, cooldown: cooldown})
+	return b.(*breaker)
+}
+
+// allow reports whether a call may go to the service
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch {
+	case b.failures < b.threshold:
+		return true
+	case time.Now().Before(b.openUntil), b.probing:
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+// success records the service answered, closing the breaker
+func (b *breaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures, b.probing = 0, false
+}
+
+// failure records a transient failure, opening the breaker at the threshold
+func (b *breaker) failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	b.probing = false
+	if b.failures >= b.threshold {
+		b.openUntil = time.Now().Add(b.cooldown)
+	}
+}
+
+// open reports whether calls currently fail fast
+func (b *breaker) open() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.failures >= b.threshold && time.Now().Before(b.openUntil)
+}
@@ -2,48 +2,67 @@ package rustclient
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"os"
+	"log/slog"
 	"time"
 
 	pb "github.com/adamtc007/KYC-DSL/api/pb"
+	"github.com/adamtc007/KYC-DSL/internal/auth"
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 )
 
-// DslClient wraps the Rust DSL gRPC service
+// ErrUnavailable is returned when the Rust DSL service cannot be reached:
+// transient failures persisted through every attempt, or the circuit
+// breaker is open
+var ErrUnavailable = errors.New("rust DSL service unavailable")
+
+// DslClient wraps the Rust DSL gRPC service. Calls failing with a transient
+// error are retried with backoff, and a circuit breaker shared by the
+// clients of an address makes calls fail fast during an outage. Parse and
+// validate calls then fall back to the in-process Go parser.
 type DslClient struct {
-	conn   *grpc.ClientConn
-	client pb.DslServiceClient
-	addr   string
+	conn    *grpc.ClientConn
+	client  pb.DslServiceClient
+	addr    string
+	cfg     config.RustDSLConfig
+	breaker *breaker
 }
 
-// NewDslClient creates a new connection to the Rust DSL service
-// Default address is localhost:50060, can be overridden with RUST_DSL_SERVICE_ADDR env var
+// NewDslClient creates a client of the Rust DSL service. It connects on the
+// first call, so it succeeds while the service is down.
+// An empty addr uses the configured rust_dsl.addr (default localhost:50060)
 func NewDslClient(addr string) (*DslClient, error) {
+	cfg := config.Current().RustDSL
 	if addr == "" {
-		addr = os.Getenv("RUST_DSL_SERVICE_ADDR")
-		if addr == "" {
-			addr = "localhost:50060"
-		}
+		addr = cfg.Addr
 	}
 
-	// Create connection with retry logic
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	conn, err := grpc.DialContext(ctx, addr,
+	conn, err := grpc.NewClient(addr,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithBlock(),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		grpc.WithPerRPCCredentials(auth.APIKeyCredentials(cfg.APIKey)),
+		grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff:           backoff.DefaultConfig,
+			MinConnectTimeout: cfg.DialTimeout,
+		}),
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to Rust DSL service at %s: %w (is the service running?)", addr, err)
+		return nil, fmt.Errorf("invalid Rust DSL service address %s: %w", addr, err)
 	}
 
 	return &DslClient{
-		conn:   conn,
-		client: pb.NewDslServiceClient(conn),
-		addr:   addr,
+		conn:    conn,
+		client:  pb.NewDslServiceClient(conn),
+		addr:    addr,
+		cfg:     cfg,
+		breaker: breakerFor(addr, cfg.BreakerThreshold, cfg.BreakerCooldown),
 	}, nil
 }
 
@@ -55,118 +74,198 @@ func (c *DslClient) Close() error {
 	return nil
 }
 
-// ParseDSL parses DSL text into structured format
-func (c *DslClient) ParseDSL(dsl string) (*pb.ParseResponse, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+// call runs one RPC, each attempt bounded by timeout. Attempts failing with
+// a transient error are retried after rust_dsl.retry_backoff, doubled after
+// each further attempt, up to rust_dsl.max_attempts.
+func (c *DslClient) call(name string, timeout time.Duration, rpc func(ctx context.Context) error) error {
+	var err error
+	for attempt := 1; attempt <= c.cfg.MaxAttempts; attempt++ {
+		if attempt > 1 {
+			if c.breaker.open() {
+				break
+			}
+			time.Sleep(c.cfg.RetryBackoff << min(attempt-2, 16))
+		}
+		if !c.breaker.allow() {
+			if err == nil {
+				return fmt.Errorf("%s RPC failed: %w at %s (circuit breaker open)", name, ErrUnavailable, c.addr)
+			}
+			break
+		}
 
-	resp, err := c.client.Parse(ctx, &pb.ParseRequest{Dsl: dsl})
-	if err != nil {
-		return nil, fmt.Errorf("parse RPC failed: %w", err)
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		err = rpc(ctx)
+		cancel()
+		if !transient(err) {
+			c.breaker.success()
+			if err != nil {
+				return fmt.Errorf("%s RPC failed: %w", name, err)
+			}
+			return nil
+		}
+		c.breaker.failure()
+		slog.Warn("Rust DSL service call failed", "rpc", name, "addr", c.addr, "attempt", attempt, "error", err)
 	}
+	return fmt.Errorf("%s RPC failed: %w at %s: %w", name, ErrUnavailable, c.addr, err)
+}
 
-	return resp, nil
+// transient reports whether a failed call may succeed when tried again
+func transient(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Aborted:
+		return true
+	}
+	return false
 }
 
-// ValidateDSL checks if a DSL case is valid
-func (c *DslClient) ValidateDSL(dsl string) (*pb.ValidationResult, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+// fallback reports whether a failed parse or validate call should be
+// answered by the Go parser
+func (c *DslClient) fallback(name string, err error) bool {
+	if !c.cfg.Fallback || !errors.Is(err, ErrUnavailable) {
+		return false
+	}
+	slog.Warn("Rust DSL service unavailable; using the in-process Go parser", "rpc", name, "addr", c.addr)
+	return true
+}
 
-	result, err := c.client.Validate(ctx, &pb.ValidateRequest{Dsl: dsl})
+// ParseDSL parses DSL text into structured format. While the service is
+// unavailable the Go parser answers (see ParsedLocally).
+func (c *DslClient) ParseDSL(dsl string) (*pb.ParseResponse, error) {
+	var resp *pb.ParseResponse
+	err := c.call("parse", c.cfg.Timeout, func(ctx context.Context) (err error) {
+		resp, err = c.client.Parse(ctx, &pb.ParseRequest{Dsl: dsl})
+		return err
+	})
+	if c.fallback("parse", err) {
+		return parseLocally(dsl), nil
+	}
 	if err != nil {
-		return nil, fmt.Errorf("validate RPC failed: %w", err)
+		return nil, err
 	}
+	return resp, nil
+}
 
+// ValidateDSL checks if a DSL case is valid. While the service is
+// unavailable the Go parser checks syntax and case structure only, with
+// LocalParserNote as a warning.
+func (c *DslClient) ValidateDSL(dsl string) (*pb.ValidationResult, error) {
+	var result *pb.ValidationResult
+	err := c.call("validate", c.cfg.Timeout, func(ctx context.Context) (err error) {
+		result, err = c.client.Validate(ctx, &pb.ValidateRequest{Dsl: dsl})
+		return err
+	})
+	if c.fallback("validate", err) {
+		return validateLocally(dsl), nil
+	}
+	if err != nil {
+		return nil, err
+	}
 	return result, nil
 }
 
 // ValidateCaseByID validates an existing case by ID
 func (c *DslClient) ValidateCaseByID(caseID string) (*pb.ValidationResult, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	result, err := c.client.Validate(ctx, &pb.ValidateRequest{CaseId: caseID})
+	var result *pb.ValidationResult
+	err := c.call("validate", c.cfg.Timeout, func(ctx context.Context) (err error) {
+		result, err = c.client.Validate(ctx, &pb.ValidateRequest{CaseId: caseID})
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("validate RPC failed: %w", err)
+		return nil, err
 	}
-
 	return result, nil
 }
 
 // ExecuteCase runs a specific function on a KYC case
 func (c *DslClient) ExecuteCase(caseID, functionName string) (*pb.ExecuteResponse, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	resp, err := c.client.Execute(ctx, &pb.ExecuteRequest{
-		CaseId:       caseID,
-		FunctionName: functionName,
+	var resp *pb.ExecuteResponse
+	err := c.call("execute", c.cfg.AmendTimeout, func(ctx context.Context) (err error) {
+		resp, err = c.client.Execute(ctx, &pb.ExecuteRequest{
+			CaseId:       caseID,
+			FunctionName: functionName,
+		})
+		return err
 	})
 	if err != nil {
-		return nil, fmt.Errorf("execute RPC failed: %w", err)
+		return nil, err
 	}
-
 	return resp, nil
 }
 
 // AmendCase applies a predefined amendment to a case
 func (c *DslClient) AmendCase(caseName, amendmentType string) (*pb.AmendResponse, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	resp, err := c.client.Amend(ctx, &pb.AmendRequest{
-		CaseName:      caseName,
-		AmendmentType: amendmentType,
+	var resp *pb.AmendResponse
+	err := c.call("amend", c.cfg.AmendTimeout, func(ctx context.Context) (err error) {
+		resp, err = c.client.Amend(ctx, &pb.AmendRequest{
+			CaseName:      caseName,
+			AmendmentType: amendmentType,
+		})
+		return err
 	})
 	if err != nil {
-		return nil, fmt.Errorf("amend RPC failed: %w", err)
+		return nil, err
 	}
-
 	return resp, nil
 }
 
 // SerializeCase converts structured case back to DSL
 func (c *DslClient) SerializeCase(kycCase *pb.ParsedCase) (*pb.SerializeResponse, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	resp, err := c.client.Serialize(ctx, &pb.SerializeRequest{Case: kycCase})
+	var resp *pb.SerializeResponse
+	err := c.call("serialize", c.cfg.Timeout, func(ctx context.Context) (err error) {
+		resp, err = c.client.Serialize(ctx, &pb.SerializeRequest{Case: kycCase})
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("serialize RPC failed: %w", err)
+		return nil, err
 	}
+	return resp, nil
+}
 
+// CompileDSL converts a DSL case to its JSON or YAML interchange form
+func (c *DslClient) CompileDSL(dsl, format string) (*pb.CompileResponse, error) {
+	var resp *pb.CompileResponse
+	err := c.call("compile", c.cfg.Timeout, func(ctx context.Context) (err error) {
+		resp, err = c.client.Compile(ctx, &pb.CompileRequest{Dsl: dsl, Format: format})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
 	return resp, nil
 }
 
 // GetGrammar returns the current DSL grammar definition
 func (c *DslClient) GetGrammar() (*pb.GrammarResponse, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	resp, err := c.client.GetGrammar(ctx, &pb.GetGrammarRequest{})
+	var resp *pb.GrammarResponse
+	err := c.call("get grammar", c.cfg.Timeout, func(ctx context.Context) (err error) {
+		resp, err = c.client.GetGrammar(ctx, &pb.GetGrammarRequest{})
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("get grammar RPC failed: %w", err)
+		return nil, err
 	}
-
 	return resp, nil
 }
 
 // ListAmendments returns available amendment types
 func (c *DslClient) ListAmendments() (*pb.ListAmendmentsResponse, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	resp, err := c.client.ListAmendments(ctx, &pb.ListAmendmentsRequest{})
+	var resp *pb.ListAmendmentsResponse
+	err := c.call("list amendments", c.cfg.Timeout, func(ctx context.Context) (err error) {
+		resp, err = c.client.ListAmendments(ctx, &pb.ListAmendmentsRequest{})
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("list amendments RPC failed: %w", err)
+		return nil, err
 	}
-
 	return resp, nil
 }
 
-// HealthCheck verifies the Rust DSL service is responsive
+// HealthCheck verifies the Rust DSL service is responsive. It makes a single
+// attempt and fails fast while the circuit breaker is open.
 func (c *DslClient) HealthCheck() error {
+	if c.breaker.open() {
+		return fmt.Errorf("health check failed: %w at %s (circuit breaker open)", ErrUnavailable, c.addr)
+	}
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
 
@@ -0,0 +1,146 @@
+package rustclient
+
+import (
+	"strconv"
+	"strings"
+
+	pb "github.com/adamtc007/KYC-DSL/api/pb"
+	"github.com/adamtc007/KYC-DSL/internal/parser"
+)
+
+// LocalParserNote marks parse and validation results produced by the
+// in-process Go parser while the Rust DSL service is unavailable
+const LocalParserNote = "checked by the in-process Go parser: the Rust DSL service is unavailable, so grammar rules and semantic checks were skipped"
+
+// ParsedLocally reports whether a parse result came from the Go parser. Its
+// cases hold the typed sections only, so they must not be serialized back
+// in place of the source.
+func ParsedLocally(resp *pb.ParseResponse) bool {
+	return resp.GetMessage() == LocalParserNote
+}
+
+// ValidatedLocally reports whether a validation result came from the Go
+// parser
+func ValidatedLocally(result *pb.ValidationResult) bool {
+	return len(result.GetWarnings()) > 0 && result.GetWarnings()[0] == LocalParserNote
+}
+
+// parseLocally parses dsl with the Go parser into the shape of a Rust Parse
+// response
+func parseLocally(dsl string) *pb.ParseResponse {
+	resp := &pb.ParseResponse{Message: LocalParserNote}
+	doc, err := parser.Parse(dsl)
+	if err != nil {
+		resp.Errors = []string{err.Error()}
+		return resp
+	}
+	for _, form := range doc.Children {
+		if form.Head() != "kyc-case" {
+			continue
+		}
+		c, err := parser.Compile(form)
+		if err != nil {
+			resp.Errors = append(resp.Errors, err.Error())
+			continue
+		}
+		resp.Cases = append(resp.Cases, caseToProto(c))
+	}
+	if len(resp.Cases) == 0 && len(resp.Errors) == 0 {
+		resp.Errors = []string{"no kyc-case form found"}
+	}
+	resp.Success = len(resp.Errors) == 0
+	return resp
+}
+
+// validateLocally checks dsl parses into well-formed cases. The result always
+// carries LocalParserNote as a warning.
+func validateLocally(dsl string) *pb.ValidationResult {
+	parsed := parseLocally(dsl)
+	result := &pb.ValidationResult{
+		Valid:    parsed.Success,
+		Errors:   parsed.Errors,
+		Warnings: []string{LocalParserNote},
+		Issues:   []*pb.ValidationIssue{{Severity: "warning", Code: "LOCAL_VALIDATION", Message: LocalParserNote}},
+	}
+	for _, msg := range parsed.Errors {
+		result.Issues = append(result.Issues, &pb.ValidationIssue{Severity: "error", Code: "PARSE_ERROR", Message: msg})
+	}
+	return result
+}
+
+func caseToProto(c *parser.Case) *pb.ParsedCase {
+	p := &pb.ParsedCase{
+		Name:               c.Name,
+		Nature:             c.Nature,
+		Purpose:            c.Purpose,
+		ClientBusinessUnit: c.ClientBusinessUnit,
+		Policies:           c.Policies,
+		Obligations:        c.Obligations,
+		KycToken:           c.KycToken,
+	}
+	if len(c.Policies) > 0 {
+		p.Policy = c.Policies[0]
+	}
+	if len(c.Obligations) > 0 {
+		p.Obligation = c.Obligations[0]
+	}
+	for _, action := range c.Functions {
+		p.Functions = append(p.Functions, &pb.CaseFunction{Action: action})
+	}
+	if len(c.Functions) > 0 {
+		p.Function = c.Functions[0]
+	}
+
+	for _, r := range c.DocumentRequirements {
+		set := &pb.DocumentRequirements{Jurisdiction: r.Jurisdiction}
+		for _, d := range r.Documents {
+			set.Required = append(set.Required, &pb.DocumentRequirement{Code: d.Code, Name: d.Name})
+		}
+		p.DocumentRequirementSets = append(p.DocumentRequirementSets, set)
+	}
+	if len(p.DocumentRequirementSets) > 0 {
+		p.DocumentRequirements = p.DocumentRequirementSets[0]
+	}
+
+	if len(c.DataDictionary) > 0 {
+		p.DataDictionary = &pb.DataDictionary{}
+		for _, a := range c.DataDictionary {
+			def := &pb.AttributeDefinition{Code: a.Code}
+			for _, s := range a.Sources {
+				ref := s.Document
+				if ref == "" {
+					ref = s.Text
+				}
+				switch s.Tier {
+				case "primary":
+					def.PrimarySources = append(def.PrimarySources, ref)
+				case "secondary":
+					def.SecondarySources = append(def.SecondarySources, ref)
+				case "tertiary":
+					def.TertiarySources = append(def.TertiarySources, ref)
+				}
+			}
+			p.DataDictionary.Attributes = append(p.DataDictionary.Attributes, def)
+		}
+	}
+
+	if o := c.Ownership; o != nil {
+		p.Ownership = &pb.OwnershipStructure{EntityName: o.Entity}
+		for _, h := range o.Owners {
+			p.Ownership.Owners = append(p.Ownership.Owners, &pb.Owner{Name: h.Name, Percentage: percent(h.Percent)})
+		}
+		for _, h := range o.BeneficialOwners {
+			p.Ownership.BeneficialOwners = append(p.Ownership.BeneficialOwners, &pb.BeneficialOwner{Name: h.Name, Percentage: percent(h.Percent)})
+		}
+		for _, ctl := range o.Controllers {
+			p.Ownership.Controllers = append(p.Ownership.Controllers, &pb.Controller{Name: ctl.Name, Role: ctl.Role})
+		}
+	}
+	return p
+}
+
+// percent reads a holding as written, e.g. "35%" or "100"
+func percent(s string) float32 {
+	f, _ := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(s), "%"), 32)
+	return float32(f)
+}
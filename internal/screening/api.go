@@ -0,0 +1,100 @@
+package screening
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// APIProvider screens against a commercial screening service over HTTP. It
+// posts the subject as JSON ({"name", "entity_type", "jurisdiction"}) and
+// expects {"matches": [{"id", "name", "score", "detail"}]} in return, with
+// scores from 0 to 1.
+type APIProvider struct {
+	url       string
+	apiKey    string
+	threshold float64
+	client    *http.Client
+}
+
+// NewAPIProvider creates a provider for the service at url. A threshold
+// <= 0 uses DefaultMatchThreshold.
+func NewAPIProvider(url, apiKey string, timeout time.Duration, threshold float64) *APIProvider {
+	if threshold <= 0 {
+		threshold = DefaultMatchThreshold
+	}
+	return &APIProvider{url: url, apiKey: apiKey, threshold: threshold, client: &http.Client{Timeout: timeout}}
+}
+
+// Name identifies the provider in stored hits
+func (p *APIProvider) Name() string {
+	return "api"
+}
+
+// Kind returns the kind of list screened
+func (p *APIProvider) Kind() Kind {
+	return KindSanctions
+}
+
+type apiMatch struct {
+	ID     string  `json:"id"`
+	Name   string  `json:"name"`
+	Score  float64 `json:"score"`
+	Detail string  `json:"detail"`
+}
+
+// Screen returns the service's matches scoring at least the threshold
+func (p *APIProvider) Screen(ctx context.Context, s Subject) ([]Hit, error) {
+	body, err := json.Marshal(map[string]string{
+		"name":         s.Name,
+		"entity_type":  s.EntityType,
+		"jurisdiction": s.Jurisdiction,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode screening request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create screening request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("screening request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("screening service returned %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+
+	var out struct {
+		Matches []apiMatch `json:"matches"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("invalid screening response: %w", err)
+	}
+	var hits []Hit
+	for _, m := range out.Matches {
+		if m.Score < p.threshold {
+			continue
+		}
+		hits = append(hits, Hit{
+			Provider:    p.Name(),
+			Kind:        KindSanctions,
+			Reference:   m.ID,
+			MatchedName: m.Name,
+			Score:       m.Score,
+			Detail:      m.Detail,
+		})
+	}
+	return hits, nil
+}
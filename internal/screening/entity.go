@@ -0,0 +1,284 @@
+package screening
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/adamtc007/KYC-DSL/internal/actor"
+	"github.com/adamtc007/KYC-DSL/internal/casedict"
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/graph"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+// Data dictionary attributes screening outcomes are written to
+const (
+	// StatusAttribute holds the sanctions screening status
+	StatusAttribute = "SANCTIONS_SCREENING_STATUS"
+	// PEPAttribute is true when a PEP screening has hits
+	PEPAttribute = "PEP_STATUS"
+	// AdverseMediaAttribute is true when an adverse media screening has hits
+	AdverseMediaAttribute = "ADVERSE_MEDIA_FLAG"
+)
+
+// maxEvidenceLinks caps the evidence links quoted in a case attribute's source
+const maxEvidenceLinks = 5
+
+var (
+	// ErrEntityNotFound is returned when screening an unknown entity
+	ErrEntityNotFound = errors.New("entity not found")
+	// ErrCaseNotFound is returned when writing a screening outcome to an
+	// unknown case
+	ErrCaseNotFound = errors.New("case not found")
+)
+
+// Screener screens entities and their beneficial owners for sanctions, PEPs
+// or adverse media on demand and keeps each screening with its hits
+type Screener struct {
+	db        *sqlx.DB
+	graph     *graph.Repo
+	providers []Provider
+	confirmed float64
+}
+
+// NewScreener creates a screener over the local screening lists of each
+// kind (including the ingested OFAC SDN and EU consolidated lists) and the
+// configured external services
+func NewScreener(db *sqlx.DB, cfg config.ScreeningConfig) *Screener {
+	s := &Screener{
+		db:    db,
+		graph: graph.NewRepo(db),
+		providers: []Provider{
+			NewListProvider(db, KindSanctions, cfg.MatchThreshold),
+			NewListProvider(db, KindPEP, cfg.MatchThreshold),
+			NewListProvider(db, KindAdverseMedia, cfg.MatchThreshold),
+		},
+		confirmed: cfg.ConfirmedThreshold,
+	}
+	s.providers = append(s.providers, ExternalProviders(cfg)...)
+	return s
+}
+
+// ExternalProviders returns the providers for the services configured in
+// cfg: a commercial sanctions screening API, a PEP database and a news
+// search for adverse media
+func ExternalProviders(cfg config.ScreeningConfig) []Provider {
+	var providers []Provider
+	if cfg.API.URL != "" {
+		providers = append(providers, NewAPIProvider(cfg.API.URL, cfg.API.APIKey, cfg.API.Timeout, cfg.MatchThreshold))
+	}
+	if cfg.PEP.URL != "" {
+		providers = append(providers, NewPEPProvider(NewPEPAPI(cfg.PEP.URL, cfg.PEP.APIKey, cfg.PEP.Timeout), cfg.MatchThreshold))
+	}
+	if am := cfg.AdverseMedia; am.URL != "" {
+		providers = append(providers, NewAdverseMediaProvider(am.URL, am.APIKey, am.Timeout, am.Terms, am.MaxArticles, cfg.MatchThreshold))
+	}
+	return providers
+}
+
+// AddProvider registers an additional screening provider
+func (s *Screener) AddProvider(p Provider) {
+	s.providers = append(s.providers, p)
+}
+
+// ScreenEntity screens an entity, and its beneficial owners when
+// includeUBOs, with every provider of the kind and records the screening and
+// its hits. Its status is Match when a hit scores at least the confirmed
+// threshold, Potential Match for weaker hits, Under Review when a provider
+// failed and nothing matched, and Clear otherwise. With a case name the
+// outcome is written to the case: the status as SANCTIONS_SCREENING_STATUS,
+// or whether there were hits as PEP_STATUS or ADVERSE_MEDIA_FLAG.
+func (s *Screener) ScreenEntity(ctx context.Context, kind Kind, entityID, caseName string, includeUBOs bool) (*model.Screening, error) {
+	var entity struct {
+		EntityID     string `db:"entity_id"`
+		Name         string `db:"name"`
+		EntityType   string `db:"entity_type"`
+		Jurisdiction string `db:"jurisdiction"`
+	}
+	err := s.db.GetContext(ctx, &entity, `
+		SELECT id::text AS entity_id, name, entity_type, COALESCE(jurisdiction, '') AS jurisdiction
+		  FROM entity WHERE id::text = $1`, entityID)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %s", ErrEntityNotFound, entityID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load entity %s: %w", entityID, err)
+	}
+	if caseName != "" {
+		var exists bool
+		if err := s.db.GetContext(ctx, &exists, `SELECT EXISTS (SELECT 1 FROM kyc_cases WHERE name = $1)`, caseName); err != nil {
+			return nil, fmt.Errorf("failed to look up case %s: %w", caseName, err)
+		}
+		if !exists {
+			return nil, fmt.Errorf("%w: %s", ErrCaseNotFound, caseName)
+		}
+	}
+
+	subjects := []Subject{{
+		EntityID:     entity.EntityID,
+		Name:         entity.Name,
+		EntityType:   entity.EntityType,
+		Jurisdiction: entity.Jurisdiction,
+	}}
+	if includeUBOs {
+		rollup, err := s.graph.ComputeUbo(ctx, entityID, graph.DefaultUBOThreshold)
+		if err != nil {
+			return nil, err
+		}
+		for _, owner := range rollup.UBOs() {
+			if owner.ID != entityID && owner.Name != "" {
+				subjects = append(subjects, Subject{EntityID: owner.ID, Name: owner.Name, EntityType: owner.EntityType})
+			}
+		}
+	}
+
+	result := &model.Screening{
+		Kind:       string(kind),
+		EntityID:   entity.EntityID,
+		EntityName: entity.Name,
+		CaseName:   caseName,
+		Subjects:   len(subjects),
+		Providers:  []string{},
+		Hits:       []model.ScreeningHit{},
+		Actor:      actor.FromContext(ctx).Name,
+	}
+	var failures []string
+	for _, p := range s.providers {
+		if p.Kind() != kind {
+			continue
+		}
+		result.Providers = append(result.Providers, p.Name())
+		for _, subject := range subjects {
+			hits, err := p.Screen(ctx, subject)
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%s (%s): %v", p.Name(), subject.Name, err))
+				continue
+			}
+			for _, h := range hits {
+				result.Hits = append(result.Hits, model.ScreeningHit{
+					SubjectEntityID: subject.EntityID,
+					SubjectName:     subject.Name,
+					Provider:        h.Provider,
+					Kind:            string(h.Kind),
+					Reference:       h.Reference,
+					MatchedName:     h.MatchedName,
+					Score:           h.Score,
+					Detail:          h.Detail,
+				})
+			}
+		}
+	}
+	sort.SliceStable(result.Hits, func(i, j int) bool { return result.Hits[i].Score > result.Hits[j].Score })
+	result.HitCount = len(result.Hits)
+	result.Errors = strings.Join(failures, "; ")
+	result.Status = s.status(result)
+
+	if err := s.record(ctx, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *Screener) status(result *model.Screening) string {
+	switch {
+	case len(result.Hits) > 0 && result.Hits[0].Score >= s.confirmed:
+		return model.ScreeningMatch
+	case len(result.Hits) > 0:
+		return model.ScreeningPotentialMatch
+	case result.Errors != "":
+		return model.ScreeningUnderReview
+	}
+	return model.ScreeningClear
+}
+
+// record stores a screening with its hits and writes its status to the case
+func (s *Screener) record(ctx context.Context, result *model.Screening) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if err := tx.GetContext(ctx, result, `
+		INSERT INTO kyc_screenings (kind, entity_id, case_name, status, subjects, hits, providers, errors, actor)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7, NULLIF($8, ''), NULLIF($9, ''))
+		RETURNING id, screened_at`,
+		result.Kind, result.EntityID, result.CaseName, result.Status, result.Subjects, result.HitCount,
+		pq.Array(result.Providers), result.Errors, result.Actor); err != nil {
+		return fmt.Errorf("failed to record %s screening of %s: %w", result.Kind, result.EntityName, err)
+	}
+	for i := range result.Hits {
+		h := &result.Hits[i]
+		if err := tx.GetContext(ctx, &h.ID, `
+			INSERT INTO kyc_screening_hits
+			       (screening_id, subject_entity_id, subject_name, provider, kind, reference, matched_name, score, detail)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''))
+			RETURNING id`,
+			result.ID, h.SubjectEntityID, h.SubjectName, h.Provider, h.Kind, h.Reference, h.MatchedName,
+			h.Score, h.Detail); err != nil {
+			return fmt.Errorf("failed to record screening hit: %w", err)
+		}
+	}
+	if result.CaseName != "" {
+		if attribute, value, valueType, ok := caseValue(result); ok {
+			if err := casedict.Record(ctx, tx, result.CaseName, attribute, value, valueType, evidence(result)); err != nil {
+				return err
+			}
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit screening: %w", err)
+	}
+	return nil
+}
+
+// caseValue is the case attribute a screening's outcome is written to and
+// its value. PEP and adverse media screenings are flags set by any hit; when
+// a provider failed without hits (Under Review) the flag is left unchanged.
+func caseValue(result *model.Screening) (attribute, value, valueType string, ok bool) {
+	switch Kind(result.Kind) {
+	case KindSanctions:
+		return StatusAttribute, result.Status, "string", true
+	case KindPEP:
+		attribute = PEPAttribute
+	case KindAdverseMedia:
+		attribute = AdverseMediaAttribute
+	default:
+		return "", "", "", false
+	}
+	if result.Status == model.ScreeningUnderReview {
+		return "", "", "", false
+	}
+	return attribute, strconv.FormatBool(result.HitCount > 0), "boolean", true
+}
+
+// evidence is the source recorded with a case attribute: the screening, its
+// time and the links to the best hits, e.g. "pep screening of Jane Doe at
+// 2024-05-01T10:00:00Z (kyc_screenings #12); evidence: https://..."
+func evidence(result *model.Screening) string {
+	source := fmt.Sprintf("%s screening of %s at %s (kyc_screenings #%d)",
+		strings.ReplaceAll(result.Kind, "_", " "), result.EntityName,
+		result.ScreenedAt.UTC().Format(time.RFC3339), result.ID)
+	var links []string
+	for _, h := range result.Hits {
+		if h.URL != "" {
+			links = append(links, h.URL)
+		}
+	}
+	if len(links) > maxEvidenceLinks {
+		links = append(links[:maxEvidenceLinks], fmt.Sprintf("+%d more", len(links)-maxEvidenceLinks))
+	}
+	if len(links) > 0 {
+		source += "; evidence: " + strings.Join(links, ", ")
+	}
+	return source
+}
@@ -0,0 +1,119 @@
+package screening
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// euSanctionEntity is a sanctionEntity element of the EU Financial
+// Sanctions Files XML export (xmlFullSanctionsList_1_1)
+type euSanctionEntity struct {
+	LogicalID         string `xml:"logicalId,attr"`
+	EUReferenceNumber string `xml:"euReferenceNumber,attr"`
+	DesignationDate   string `xml:"designationDate,attr"`
+	Remark            string `xml:"remark"`
+	SubjectType       struct {
+		Code string `xml:"code,attr"`
+	} `xml:"subjectType"`
+	Regulations []struct {
+		Programme          string `xml:"programme,attr"`
+		EntryIntoForceDate string `xml:"entryIntoForceDate,attr"`
+		NumberTitle        string `xml:"numberTitle,attr"`
+	} `xml:"regulation"`
+	NameAliases []struct {
+		WholeName string `xml:"wholeName,attr"`
+	} `xml:"nameAlias"`
+	Citizenships []struct {
+		Country string `xml:"countryIso2Code,attr"`
+	} `xml:"citizenship"`
+	Addresses []struct {
+		Country string `xml:"countryIso2Code,attr"`
+	} `xml:"address"`
+}
+
+// ParseEUConsolidated reads the EU consolidated list of persons, groups and
+// entities subject to financial sanctions from its XML export. The first
+// name alias of each entity is its name and the others its aliases.
+func ParseEUConsolidated(r io.Reader) ([]ListEntry, error) {
+	var entries []ListEntry
+	dec := xml.NewDecoder(r)
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid EU consolidated list: %w", err)
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "sanctionEntity" {
+			continue
+		}
+		var se euSanctionEntity
+		if err := dec.DecodeElement(&se, &start); err != nil {
+			return nil, fmt.Errorf("invalid EU consolidated list entity: %w", err)
+		}
+		if e, ok := se.toEntry(); ok {
+			entries = append(entries, e)
+		}
+	}
+}
+
+func (se euSanctionEntity) toEntry() (ListEntry, bool) {
+	var names []string
+	seen := map[string]bool{}
+	for _, a := range se.NameAliases {
+		name := strings.TrimSpace(a.WholeName)
+		if name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return ListEntry{}, false
+	}
+
+	e := ListEntry{
+		Reference: se.EUReferenceNumber,
+		Name:      names[0],
+		Aliases:   names[1:],
+	}
+	if e.Reference == "" {
+		e.Reference = se.LogicalID
+	}
+	switch se.SubjectType.Code {
+	case "person":
+		e.EntityType = "PERSON"
+	case "enterprise":
+		e.EntityType = "COMPANY"
+	default:
+		e.EntityType = "OTHER"
+	}
+	for _, c := range append(se.Citizenships, se.Addresses...) {
+		if len(c.Country) == 2 && c.Country != "00" {
+			e.Country = c.Country
+			break
+		}
+	}
+
+	var details []string
+	listed := se.DesignationDate
+	for _, reg := range se.Regulations {
+		details = append(details, strings.TrimSpace(reg.Programme+" "+reg.NumberTitle))
+		if listed == "" {
+			listed = reg.EntryIntoForceDate
+		}
+	}
+	if se.Remark != "" {
+		details = append(details, strings.TrimSpace(se.Remark))
+	}
+	e.Detail = strings.Join(details, "; ")
+	if t, err := time.Parse("2006-01-02", listed); err == nil {
+		e.ListedAt = &t
+	}
+	return e, true
+}
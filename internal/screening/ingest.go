@@ -0,0 +1,124 @@
+package screening
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+// Names of the ingested sanctions lists in screening_list_entries
+const (
+	ListOFACSDN        = "OFAC-SDN"
+	ListEUConsolidated = "EU-CONSOLIDATED"
+)
+
+// ErrEmptyList is returned when ingesting a list file without records,
+// which would otherwise remove every entry of the list
+var ErrEmptyList = errors.New("list file has no records")
+
+// ListEntry is a record of a sanctions list file
+type ListEntry struct {
+	Reference  string
+	Name       string
+	Aliases    []string
+	EntityType string // PERSON, COMPANY or OTHER
+	Country    string
+	Detail     string
+	ListedAt   *time.Time
+}
+
+// Ingest replaces the entries of a list in screening_list_entries with the
+// records of a list file: new records are added, changed ones updated and
+// records no longer in the file removed, in one transaction. source names
+// the file or URL in the ingest record.
+func Ingest(ctx context.Context, db *sqlx.DB, listName string, kind Kind, entries []ListEntry, source, ingestedBy string) (*model.ScreeningListIngest, error) {
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrEmptyList, source)
+	}
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	upsert, err := tx.PreparexContext(ctx, `
+		INSERT INTO screening_list_entries
+		       (list_name, kind, reference, name, aliases, entity_type, country, detail, listed_at)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), NULLIF($7, ''), NULLIF($8, ''), $9)
+		ON CONFLICT (list_name, reference) DO UPDATE
+		   SET kind = EXCLUDED.kind, name = EXCLUDED.name, aliases = EXCLUDED.aliases,
+		       entity_type = EXCLUDED.entity_type, country = EXCLUDED.country,
+		       detail = EXCLUDED.detail, listed_at = EXCLUDED.listed_at,
+		       updated_at = CURRENT_TIMESTAMP
+		 WHERE (screening_list_entries.kind, screening_list_entries.name, screening_list_entries.aliases,
+		        screening_list_entries.entity_type, screening_list_entries.country,
+		        screening_list_entries.detail, screening_list_entries.listed_at)
+		       IS DISTINCT FROM
+		       (EXCLUDED.kind, EXCLUDED.name, EXCLUDED.aliases, EXCLUDED.entity_type,
+		        EXCLUDED.country, EXCLUDED.detail, EXCLUDED.listed_at)
+		RETURNING (xmax = 0) AS inserted`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare list upsert: %w", err)
+	}
+	defer upsert.Close()
+
+	result := &model.ScreeningListIngest{ListName: listName, Source: source, IngestedBy: ingestedBy}
+	references := make([]string, 0, len(entries))
+	seen := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		if seen[e.Reference] {
+			continue
+		}
+		seen[e.Reference] = true
+		references = append(references, e.Reference)
+		result.Entries++
+
+		aliases := e.Aliases
+		if aliases == nil {
+			aliases = []string{}
+		}
+		var inserted bool
+		err := upsert.GetContext(ctx, &inserted, listName, string(kind), e.Reference, e.Name, pq.Array(aliases),
+			e.EntityType, e.Country, e.Detail, e.ListedAt)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			// unchanged
+		case err != nil:
+			return nil, fmt.Errorf("failed to ingest %s %s: %w", listName, e.Reference, err)
+		case inserted:
+			result.Added++
+		default:
+			result.Updated++
+		}
+	}
+
+	removed, err := tx.ExecContext(ctx, `
+		DELETE FROM screening_list_entries
+		 WHERE list_name = $1 AND NOT (reference = ANY($2))`, listName, pq.Array(references))
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove delisted %s entries: %w", listName, err)
+	}
+	n, _ := removed.RowsAffected()
+	result.Removed = int(n)
+
+	if err := tx.GetContext(ctx, result, `
+		INSERT INTO screening_list_ingests (list_name, source, entries, added, updated, removed, ingested_by)
+		VALUES ($1, NULLIF($2, ''), $3, $4, $5, $6, NULLIF($7, ''))
+		RETURNING id, list_name, COALESCE(source, '') AS source, entries, added, updated, removed,
+		          COALESCE(ingested_by, '') AS ingested_by, ingested_at`,
+		listName, source, result.Entries, result.Added, result.Updated, result.Removed, ingestedBy); err != nil {
+		return nil, fmt.Errorf("failed to record %s ingest: %w", listName, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit %s ingest: %w", listName, err)
+	}
+	return result, nil
+}
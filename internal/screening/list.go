@@ -0,0 +1,100 @@
+package screening
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// ListProvider screens against the records of one kind in the local
+// screening_list_entries table, matching on name and aliases
+type ListProvider struct {
+	db        *sqlx.DB
+	kind      Kind
+	threshold float64
+}
+
+// NewListProvider creates a provider over the local lists of the given kind.
+// A threshold <= 0 uses DefaultMatchThreshold.
+func NewListProvider(db *sqlx.DB, kind Kind, threshold float64) *ListProvider {
+	if threshold <= 0 {
+		threshold = DefaultMatchThreshold
+	}
+	return &ListProvider{db: db, kind: kind, threshold: threshold}
+}
+
+// Name identifies the provider in stored hits
+func (p *ListProvider) Name() string {
+	return "lists/" + string(p.kind)
+}
+
+// Kind returns the kind of list screened
+func (p *ListProvider) Kind() Kind {
+	return p.kind
+}
+
+type listEntry struct {
+	ListName  string         `db:"list_name"`
+	Reference string         `db:"reference"`
+	Name      string         `db:"name"`
+	Aliases   pq.StringArray `db:"aliases"`
+	Detail    string         `db:"detail"`
+}
+
+// Screen returns the list records whose name or an alias scores at least the
+// threshold against the subject's name. Candidates are pre-filtered in SQL on
+// the subject's name tokens.
+func (p *ListProvider) Screen(ctx context.Context, s Subject) ([]Hit, error) {
+	var tokens []string
+	for _, t := range strings.Fields(Normalize(s.Name)) {
+		if len([]rune(t)) >= 3 {
+			tokens = append(tokens, t)
+		}
+	}
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	var candidates []listEntry
+	err := p.db.SelectContext(ctx, &candidates, `
+		SELECT list_name, reference, name, aliases, COALESCE(detail, '') AS detail
+		  FROM screening_list_entries e
+		 WHERE kind = $1
+		   AND EXISTS (SELECT 1
+		                 FROM unnest(array_append(e.aliases, e.name)) AS n(name),
+		                      unnest($2::text[]) AS t(token)
+		                WHERE lower(n.name) LIKE '%' || t.token || '%')`,
+		string(p.kind), pq.Array(tokens))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s lists: %w", p.kind, err)
+	}
+
+	var hits []Hit
+	for _, c := range candidates {
+		best, bestName := Similarity(s.Name, c.Name), c.Name
+		for _, alias := range c.Aliases {
+			if score := Similarity(s.Name, alias); score > best {
+				best, bestName = score, alias
+			}
+		}
+		if best < p.threshold {
+			continue
+		}
+		detail := c.ListName
+		if c.Detail != "" {
+			detail += ": " + c.Detail
+		}
+		hits = append(hits, Hit{
+			Provider:    p.Name(),
+			Kind:        p.kind,
+			Reference:   c.ListName + ":" + c.Reference,
+			MatchedName: bestName,
+			Score:       best,
+			Detail:      detail,
+		})
+	}
+	return hits, nil
+}
@@ -0,0 +1,152 @@
+package screening
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Article is a news article returned by an adverse media search
+type Article struct {
+	ID          string `json:"id,omitempty"`
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	Source      string `json:"source,omitempty"`
+	PublishedAt string `json:"published_at,omitempty"`
+	Snippet     string `json:"snippet,omitempty"`
+}
+
+// AdverseMediaProvider searches a news service for articles that name the
+// subject alongside negative news terms: GET <url>?q=...&limit=... returning
+// {"articles": [Article...]}. The query is the quoted name AND any of the
+// terms, e.g. "Acme Holdings" AND (fraud OR "money laundering").
+type AdverseMediaProvider struct {
+	url         string
+	apiKey      string
+	terms       []string
+	maxArticles int
+	threshold   float64
+	client      *http.Client
+}
+
+// NewAdverseMediaProvider creates a provider for the news search service at
+// url. A threshold <= 0 uses DefaultMatchThreshold.
+func NewAdverseMediaProvider(url, apiKey string, timeout time.Duration, terms []string, maxArticles int, threshold float64) *AdverseMediaProvider {
+	if threshold <= 0 {
+		threshold = DefaultMatchThreshold
+	}
+	return &AdverseMediaProvider{
+		url:         url,
+		apiKey:      apiKey,
+		terms:       terms,
+		maxArticles: maxArticles,
+		threshold:   threshold,
+		client:      &http.Client{Timeout: timeout},
+	}
+}
+
+// Name identifies the provider in stored hits
+func (p *AdverseMediaProvider) Name() string {
+	return "media/api"
+}
+
+// Kind returns the kind of source screened
+func (p *AdverseMediaProvider) Kind() Kind {
+	return KindAdverseMedia
+}
+
+// Query is the search sent for a name
+func (p *AdverseMediaProvider) Query(name string) string {
+	quoted := make([]string, len(p.terms))
+	for i, t := range p.terms {
+		if strings.ContainsRune(t, ' ') {
+			t = `"` + t + `"`
+		}
+		quoted[i] = t
+	}
+	q := `"` + strings.ReplaceAll(name, `"`, "") + `"`
+	if len(quoted) > 0 {
+		q += " AND (" + strings.Join(quoted, " OR ") + ")"
+	}
+	return q
+}
+
+// Screen returns the articles whose title or snippet mentions the subject's
+// name. An article scores the share of the name's words it contains, so
+// search results that only mention part of the name fall below the
+// threshold.
+func (p *AdverseMediaProvider) Screen(ctx context.Context, s Subject) ([]Hit, error) {
+	if strings.TrimSpace(s.Name) == "" {
+		return nil, nil
+	}
+	var out struct {
+		Articles []Article `json:"articles"`
+	}
+	query := url.Values{"q": {p.Query(s.Name)}, "limit": {strconv.Itoa(p.maxArticles)}}
+	if err := getJSON(ctx, p.client, p.url, p.apiKey, query, &out); err != nil {
+		return nil, fmt.Errorf("adverse media search failed: %w", err)
+	}
+
+	var hits []Hit
+	for _, a := range out.Articles {
+		score := mentionScore(s.Name, a.Title+" "+a.Snippet)
+		if score < p.threshold {
+			continue
+		}
+		ref := a.URL
+		if ref == "" {
+			ref = a.ID
+		}
+		if ref == "" {
+			continue
+		}
+		hits = append(hits, Hit{
+			Provider:    p.Name(),
+			Kind:        KindAdverseMedia,
+			Reference:   ref,
+			MatchedName: a.Title,
+			Score:       score,
+			Detail:      a.detail(),
+			URL:         a.URL,
+		})
+	}
+	return hits, nil
+}
+
+// detail is the article's source and date, e.g. "Reuters, 2024-03-01"
+func (a Article) detail() string {
+	var parts []string
+	for _, p := range []string{a.Source, a.PublishedAt} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// mentionScore is the share of the words of a name's normalized form that
+// occur in text
+func mentionScore(name, text string) float64 {
+	words := strings.Fields(Normalize(name))
+	if len(words) == 0 {
+		return 0
+	}
+	present := map[string]bool{}
+	for _, w := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		present[w] = true
+	}
+	found := 0
+	for _, w := range words {
+		if present[w] {
+			found++
+		}
+	}
+	return float64(found) / float64(len(words))
+}
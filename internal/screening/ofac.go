@@ -0,0 +1,106 @@
+package screening
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ofacNull is how the OFAC files mark an empty field
+const ofacNull = "-0-"
+
+// ParseOFACSDN reads the OFAC Specially Designated Nationals list in its
+// legacy CSV format: sdn.csv (ent_num, name, type, program, title, ...,
+// remarks) and, when given, alt.csv (ent_num, alt_num, type, alt_name, ...)
+// whose names become aliases. Both files have no header row.
+func ParseOFACSDN(sdn, alt io.Reader) ([]ListEntry, error) {
+	var entries []ListEntry
+	index := map[string]int{}
+	err := readOFAC(sdn, 4, func(rec []string) {
+		e := ListEntry{
+			Reference:  rec[0],
+			Name:       rec[1],
+			EntityType: ofacEntityType(rec[2]),
+			Detail:     "Program: " + strings.ReplaceAll(ofacField(rec[3]), "] [", ", "),
+		}
+		if len(rec) > 11 && ofacField(rec[11]) != "" {
+			e.Detail += "; " + ofacField(rec[11])
+		}
+		index[e.Reference] = len(entries)
+		entries = append(entries, e)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid OFAC SDN file: %w", err)
+	}
+
+	if alt != nil {
+		err := readOFAC(alt, 4, func(rec []string) {
+			if i, ok := index[rec[0]]; ok && ofacField(rec[3]) != "" {
+				entries[i].Aliases = append(entries[i].Aliases, rec[3])
+			}
+		})
+		if err != nil {
+			return nil, fmt.Errorf("invalid OFAC alternate names file: %w", err)
+		}
+	}
+	return entries, nil
+}
+
+// readOFAC calls fn with every record of an OFAC CSV file that has at least
+// minFields fields and a numeric entity number; the files end with a
+// control character line, which is skipped
+func readOFAC(r io.Reader, minFields int, fn func([]string)) error {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	for {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if len(rec) < minFields || !isDigits(strings.TrimSpace(rec[0])) {
+			continue
+		}
+		for i := range rec {
+			rec[i] = strings.TrimSpace(rec[i])
+		}
+		fn(rec)
+	}
+}
+
+func ofacField(s string) string {
+	if s == ofacNull {
+		return ""
+	}
+	return strings.Trim(s, "[] ")
+}
+
+// ofacEntityType maps the SDN type column (individual, vessel, aircraft,
+// or empty for organisations) to entity types
+func ofacEntityType(t string) string {
+	switch strings.ToLower(ofacField(t)) {
+	case "individual":
+		return "PERSON"
+	case "":
+		return "COMPANY"
+	default:
+		return "OTHER"
+	}
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,187 @@
+package screening
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// PEPRecord is a politically exposed person held by a PEP source
+type PEPRecord struct {
+	Reference string   `json:"id"`
+	Name      string   `json:"name"`
+	Aliases   []string `json:"aliases,omitempty"`
+	Position  string   `json:"position,omitempty"` // prominent public function held
+	Country   string   `json:"country,omitempty"`
+	// Tier is the source's PEP category, e.g. head of state, family member,
+	// close associate
+	Tier      string `json:"tier,omitempty"`
+	Since     string `json:"since,omitempty"`
+	Until     string `json:"until,omitempty"` // empty while in office
+	SourceURL string `json:"url,omitempty"`
+}
+
+// PEPList is a searchable source of politically exposed persons, such as a
+// commercial PEP database or a national register of public officials.
+// Search returns the candidate records for a name; matching and scoring
+// are left to PEPProvider.
+type PEPList interface {
+	Name() string
+	Search(ctx context.Context, name string) ([]PEPRecord, error)
+}
+
+// PEPProvider screens natural persons against a PEPList. Companies and
+// other subjects are not screened.
+type PEPProvider struct {
+	list      PEPList
+	threshold float64
+}
+
+// NewPEPProvider creates a provider over list. A threshold <= 0 uses
+// DefaultMatchThreshold.
+func NewPEPProvider(list PEPList, threshold float64) *PEPProvider {
+	if threshold <= 0 {
+		threshold = DefaultMatchThreshold
+	}
+	return &PEPProvider{list: list, threshold: threshold}
+}
+
+// Name identifies the provider in stored hits
+func (p *PEPProvider) Name() string {
+	return "pep/" + p.list.Name()
+}
+
+// Kind returns the kind of list screened
+func (p *PEPProvider) Kind() Kind {
+	return KindPEP
+}
+
+// Screen returns the records whose name or an alias scores at least the
+// threshold against the subject's name
+func (p *PEPProvider) Screen(ctx context.Context, s Subject) ([]Hit, error) {
+	if s.EntityType != "" && !strings.EqualFold(s.EntityType, "PERSON") {
+		return nil, nil
+	}
+	records, err := p.list.Search(ctx, s.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var hits []Hit
+	for _, r := range records {
+		best, bestName := Similarity(s.Name, r.Name), r.Name
+		for _, alias := range r.Aliases {
+			if score := Similarity(s.Name, alias); score > best {
+				best, bestName = score, alias
+			}
+		}
+		if best < p.threshold {
+			continue
+		}
+		hits = append(hits, Hit{
+			Provider:    p.Name(),
+			Kind:        KindPEP,
+			Reference:   r.Reference,
+			MatchedName: bestName,
+			Score:       best,
+			Detail:      r.detail(),
+			URL:         r.SourceURL,
+		})
+	}
+	return hits, nil
+}
+
+// detail describes the public function, e.g. "Minister of Finance (GB,
+// domestic PEP) 2019-01-10 to 2022-09-06"
+func (r PEPRecord) detail() string {
+	var qualifiers []string
+	for _, q := range []string{r.Country, r.Tier} {
+		if q != "" {
+			qualifiers = append(qualifiers, q)
+		}
+	}
+	d := r.Position
+	if len(qualifiers) > 0 {
+		d = strings.TrimSpace(d + " (" + strings.Join(qualifiers, ", ") + ")")
+	}
+	switch {
+	case r.Since != "" && r.Until != "":
+		d += " " + r.Since + " to " + r.Until
+	case r.Since != "":
+		d += " since " + r.Since
+	case r.Until != "":
+		d += " until " + r.Until
+	}
+	return strings.TrimSpace(d)
+}
+
+// PEPAPI is a PEP database searched over HTTP: GET <url>?name=... returning
+// {"records": [PEPRecord...]}
+type PEPAPI struct {
+	url    string
+	apiKey string
+	client *http.Client
+}
+
+// NewPEPAPI creates a PEP list backed by the service at url
+func NewPEPAPI(url, apiKey string, timeout time.Duration) *PEPAPI {
+	return &PEPAPI{url: url, apiKey: apiKey, client: &http.Client{Timeout: timeout}}
+}
+
+// Name identifies the PEP list in stored hits
+func (a *PEPAPI) Name() string {
+	return "api"
+}
+
+// Search returns the service's records for name
+func (a *PEPAPI) Search(ctx context.Context, name string) ([]PEPRecord, error) {
+	var out struct {
+		Records []PEPRecord `json:"records"`
+	}
+	if err := getJSON(ctx, a.client, a.url, a.apiKey, url.Values{"name": {name}}, &out); err != nil {
+		return nil, fmt.Errorf("PEP search failed: %w", err)
+	}
+	return out.Records, nil
+}
+
+// getJSON sends a GET request with query parameters and a bearer token and
+// decodes the JSON response into out
+func getJSON(ctx context.Context, client *http.Client, endpoint, apiKey string, query url.Values, out interface{}) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q: %w", endpoint, err)
+	}
+	q := u.Query()
+	for k, v := range query {
+		q[k] = v
+	}
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("service returned %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("invalid response: %w", err)
+	}
+	return nil
+}
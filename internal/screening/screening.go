@@ -0,0 +1,135 @@
+// Package screening checks entities against sanctions, PEP and adverse media
+// sources. Each source is a Provider; callers such as the watchlist scheduler
+// run every configured provider and decide what to do with the hits. PEP
+// databases plug in as a PEPList and news search services through the
+// AdverseMediaProvider. The Screener screens an entity and its UBOs on demand
+// and records the outcome on the case; the OFAC SDN and EU consolidated lists
+// are loaded into the local lists with Ingest.
+package screening
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Kind is the category of risk a provider screens for
+type Kind string
+
+const (
+	KindSanctions    Kind = "sanctions"
+	KindPEP          Kind = "pep"
+	KindAdverseMedia Kind = "adverse_media"
+)
+
+// ParseKind parses a kind of screening; an empty string is sanctions
+func ParseKind(s string) (Kind, error) {
+	switch k := Kind(strings.ToLower(strings.TrimSpace(s))); k {
+	case "":
+		return KindSanctions, nil
+	case KindSanctions, KindPEP, KindAdverseMedia:
+		return k, nil
+	}
+	return "", fmt.Errorf("unknown screening kind %q (expected sanctions, pep or adverse_media)", s)
+}
+
+// DefaultMatchThreshold is the name similarity from which a list record is
+// reported as a hit
+const DefaultMatchThreshold = 0.85
+
+// Subject is the entity being screened
+type Subject struct {
+	EntityID     string `json:"entity_id"`
+	Name         string `json:"name"`
+	EntityType   string `json:"entity_type"`
+	Jurisdiction string `json:"jurisdiction,omitempty"`
+}
+
+// Hit is a record from a provider that matches a subject
+type Hit struct {
+	Provider    string  `json:"provider"`
+	Kind        Kind    `json:"kind"`
+	Reference   string  `json:"reference"` // stable id of the record within the provider
+	MatchedName string  `json:"matched_name"`
+	Score       float64 `json:"score"` // 0..1
+	Detail      string  `json:"detail,omitempty"`
+	URL         string  `json:"url,omitempty"` // evidence: the source record or article
+}
+
+// Provider screens subjects against one source
+type Provider interface {
+	Name() string
+	Kind() Kind
+	Screen(ctx context.Context, s Subject) ([]Hit, error)
+}
+
+// legalSuffixes are dropped before comparing names so "Acme Holdings Ltd"
+// matches "ACME HOLDINGS LIMITED"
+var legalSuffixes = map[string]bool{
+	"ltd": true, "limited": true, "plc": true, "inc": true, "incorporated": true,
+	"llc": true, "llp": true, "lp": true, "corp": true, "corporation": true,
+	"co": true, "company": true, "sa": true, "ag": true, "gmbh": true,
+	"bv": true, "nv": true, "sarl": true, "spa": true, "srl": true,
+}
+
+// Normalize lowercases a name, strips punctuation and legal-form suffixes and
+// sorts the remaining tokens so word order does not affect matching
+func Normalize(name string) string {
+	fields := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	tokens := fields[:0]
+	for _, f := range fields {
+		if !legalSuffixes[f] {
+			tokens = append(tokens, f)
+		}
+	}
+	if len(tokens) == 0 {
+		tokens = fields
+	}
+	sort.Strings(tokens)
+	return strings.Join(tokens, " ")
+}
+
+// Similarity scores two names from 0 to 1 with the Dice coefficient of the
+// character bigrams of their normalized forms
+func Similarity(a, b string) float64 {
+	na, nb := Normalize(a), Normalize(b)
+	if na == "" || nb == "" {
+		return 0
+	}
+	if na == nb {
+		return 1
+	}
+
+	ba, bb := bigrams(na), bigrams(nb)
+	if len(ba) == 0 || len(bb) == 0 {
+		return 0
+	}
+	counts := make(map[string]int, len(ba))
+	for _, g := range ba {
+		counts[g]++
+	}
+	shared := 0
+	for _, g := range bb {
+		if counts[g] > 0 {
+			counts[g]--
+			shared++
+		}
+	}
+	return 2 * float64(shared) / float64(len(ba)+len(bb))
+}
+
+func bigrams(s string) []string {
+	r := []rune(s)
+	if len(r) < 2 {
+		return nil
+	}
+	out := make([]string, 0, len(r)-1)
+	for i := 0; i < len(r)-1; i++ {
+		out = append(out, string(r[i:i+2]))
+	}
+	return out
+}
@@ -0,0 +1,66 @@
+package shadow
+
+// RankingDiff describes how a candidate ordering differs from the live one
+type RankingDiff struct {
+	Overlap float64           `json:"overlap"`
+	Added   []string          `json:"added,omitempty"`
+	Removed []string          `json:"removed,omitempty"`
+	Moved   map[string][2]int `json:"moved,omitempty"`
+	Score   float64           `json:"score"`
+}
+
+// CompareRankings scores two result orderings.
+// The score blends set overlap with positional agreement: 0 means identical
+// ordering, 1 means no results in common.
+func CompareRankings(primary, candidate []string) RankingDiff {
+	diff := RankingDiff{Moved: make(map[string][2]int)}
+
+	size := len(primary)
+	if len(candidate) > size {
+		size = len(candidate)
+	}
+	if size == 0 {
+		diff.Overlap = 1
+		return diff
+	}
+
+	primaryPos := make(map[string]int, len(primary))
+	for i, code := range primary {
+		primaryPos[code] = i
+	}
+	candidatePos := make(map[string]int, len(candidate))
+	for i, code := range candidate {
+		candidatePos[code] = i
+	}
+
+	common := 0
+	for _, code := range primary {
+		if j, ok := candidatePos[code]; ok {
+			common++
+			if i := primaryPos[code]; i != j {
+				diff.Moved[code] = [2]int{i + 1, j + 1}
+			}
+		} else {
+			diff.Removed = append(diff.Removed, code)
+		}
+	}
+	for _, code := range candidate {
+		if _, ok := primaryPos[code]; !ok {
+			diff.Added = append(diff.Added, code)
+		}
+	}
+
+	positional := 0
+	for i := 0; i < size; i++ {
+		if i >= len(primary) || i >= len(candidate) || primary[i] != candidate[i] {
+			positional++
+		}
+	}
+
+	diff.Overlap = float64(common) / float64(size)
+	diff.Score = 0.5*(1-diff.Overlap) + 0.5*float64(positional)/float64(size)
+	if len(diff.Moved) == 0 {
+		diff.Moved = nil
+	}
+	return diff
+}
@@ -0,0 +1,68 @@
+package shadow
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+// Candidate ranking algorithms available for shadow evaluation
+const (
+	RankerVector        = "vector-v1"
+	RankerLexicalBoost  = "lexical-boost-v1"
+	lexicalBoostPool    = 3
+	lexicalBoostPerTerm = 0.05
+)
+
+// VectorSearcher searches attributes by vector similarity (implemented by
+// ontology.MetadataRepo and overlay.Resolver)
+type VectorSearcher interface {
+	SearchByVector(ctx context.Context, vec []float32, limit int) ([]model.AttributeSearchResult, error)
+}
+
+// LexicalBoostRanker widens the vector candidate pool and re-orders it by
+// boosting attributes whose code or synonyms share terms with the query.
+func LexicalBoostRanker(repo VectorSearcher, query string, vec []float32, limit int) RankFunc {
+	return func(ctx context.Context) ([]string, error) {
+		pool, err := repo.SearchByVector(ctx, vec, limit*lexicalBoostPool)
+		if err != nil {
+			return nil, err
+		}
+
+		terms := strings.Fields(strings.ToLower(query))
+		type scored struct {
+			code  string
+			score float64
+		}
+		ranked := make([]scored, 0, len(pool))
+		for _, r := range pool {
+			ranked = append(ranked, scored{
+				code:  r.AttributeCode,
+				score: r.SimilarityScore + lexicalBoostPerTerm*float64(termHits(terms, r)),
+			})
+		}
+		sort.SliceStable(ranked, func(i, j int) bool {
+			return ranked[i].score > ranked[j].score
+		})
+
+		codes := make([]string, 0, limit)
+		for i := 0; i < len(ranked) && i < limit; i++ {
+			codes = append(codes, ranked[i].code)
+		}
+		return codes, nil
+	}
+}
+
+// termHits counts query terms present in the attribute code or synonyms
+func termHits(terms []string, r model.AttributeSearchResult) int {
+	haystack := strings.ToLower(strings.ReplaceAll(r.AttributeCode, "_", " ") + " " + strings.Join(r.Synonyms, " "))
+	hits := 0
+	for _, t := range terms {
+		if len(t) > 2 && strings.Contains(haystack, t) {
+			hits++
+		}
+	}
+	return hits
+}
@@ -0,0 +1,131 @@
+// Package shadow runs a candidate ranking algorithm alongside the live
+// one, records behavioural divergences, and never affects the response
+// returned to the caller.
+package shadow
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+const defaultTimeout = 10 * time.Second
+
+// Recorder persists divergences (implemented by ontology.ShadowRepo)
+type Recorder interface {
+	RecordDivergence(ctx context.Context, d model.ShadowDivergence) (int, error)
+}
+
+// Experiment describes a candidate version under shadow evaluation
+type Experiment struct {
+	Name             string
+	Kind             model.ShadowKind
+	PrimaryVersion   string
+	CandidateVersion string
+	// SampleRate is the fraction of live requests that are shadowed (0-1);
+	// 0 shadows none
+	SampleRate float64
+	// MinDivergence is the score at or below which results count as equivalent
+	MinDivergence float64
+}
+
+// RankFunc produces an ordered list of result codes for the candidate ranking
+type RankFunc func(ctx context.Context) ([]string, error)
+
+// Runner executes shadow comparisons asynchronously
+type Runner struct {
+	experiment Experiment
+	recorder   Recorder
+	timeout    time.Duration
+	wg         sync.WaitGroup
+}
+
+// NewRunner creates a shadow runner for the given experiment, or returns
+// nil, which shadows nothing, when its sample rate is 0
+func NewRunner(experiment Experiment, recorder Recorder) *Runner {
+	if experiment.SampleRate <= 0 {
+		return nil
+	}
+	experiment.SampleRate = min(experiment.SampleRate, 1)
+	return &Runner{
+		experiment: experiment,
+		recorder:   recorder,
+		timeout:    defaultTimeout,
+	}
+}
+
+// Experiment returns the experiment configuration
+func (r *Runner) Experiment() Experiment {
+	return r.experiment
+}
+
+// ShadowRanking compares the live ordering with the candidate ranking in the background
+func (r *Runner) ShadowRanking(subject string, primary []string, candidate RankFunc) {
+	if r == nil || !r.sampled() {
+		return
+	}
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+
+		ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+		defer cancel()
+
+		candidateCodes, err := candidate(ctx)
+		if err != nil {
+			slog.Warn("⚠️  shadow candidate ranking failed", "experiment", r.experiment.Name, "error", err)
+			return
+		}
+
+		diff := CompareRankings(primary, candidateCodes)
+		r.record(ctx, subject, primary, candidateCodes, diff, diff.Score)
+	}()
+}
+
+// Wait blocks until all in-flight shadow executions have finished
+func (r *Runner) Wait() {
+	if r == nil {
+		return
+	}
+	r.wg.Wait()
+}
+
+func (r *Runner) sampled() bool {
+	return r.experiment.SampleRate >= 1 || rand.Float64() < r.experiment.SampleRate
+}
+
+func (r *Runner) record(ctx context.Context, subject string, primary, candidate, details interface{}, score float64) {
+	if score <= r.experiment.MinDivergence {
+		return
+	}
+
+	d := model.ShadowDivergence{
+		Experiment:       r.experiment.Name,
+		Kind:             r.experiment.Kind,
+		Subject:          subject,
+		PrimaryVersion:   r.experiment.PrimaryVersion,
+		CandidateVersion: r.experiment.CandidateVersion,
+		PrimaryResult:    toJSON(primary),
+		CandidateResult:  toJSON(candidate),
+		DivergenceScore:  score,
+		Details:          toJSON(details),
+	}
+
+	if _, err := r.recorder.RecordDivergence(ctx, d); err != nil {
+		slog.Warn("⚠️  shadow failed to record divergence", "experiment", r.experiment.Name, "error", err)
+	}
+}
+
+func toJSON(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
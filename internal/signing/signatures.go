@@ -0,0 +1,234 @@
+package signing
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/adamtc007/KYC-DSL/internal/storage"
+)
+
+var (
+	// ErrNoVersion is returned when signing a case that has no versions
+	ErrNoVersion = errors.New("case has no version to sign")
+	// ErrNotSigned is returned when verifying a case version without
+	// signatures
+	ErrNotSigned = errors.New("case version is not signed")
+)
+
+// Signature is a stored signature of a case version
+type Signature struct {
+	ID           int       `db:"id" json:"id"`
+	CaseName     string    `db:"case_name" json:"case_name"`
+	Version      int       `db:"version" json:"version"`
+	VersionHash  string    `db:"version_hash" json:"version_hash"`
+	ChainHash    string    `db:"chain_hash" json:"chain_hash"`
+	Algorithm    string    `db:"algorithm" json:"algorithm"`
+	KeyID        string    `db:"key_id" json:"key_id"`
+	Fingerprint  string    `db:"key_fingerprint" json:"key_fingerprint"`
+	PublicKey    string    `db:"public_key" json:"public_key"`
+	Signature    string    `db:"signature" json:"signature"`
+	TransitionID *int      `db:"transition_id" json:"transition_id,omitempty"`
+	SignedBy     string    `db:"signed_by" json:"signed_by"`
+	SignedAt     time.Time `db:"signed_at" json:"signed_at"`
+}
+
+// Statement returns the text the signature was made over
+func (s *Signature) Statement() []byte {
+	return Statement(s.CaseName, s.Version, s.VersionHash, s.ChainHash)
+}
+
+// Verification is the outcome of checking a stored signature
+type Verification struct {
+	Signature Signature `json:"signature"`
+	// SignatureValid is set when the signature matches its statement and
+	// public key
+	SignatureValid bool `json:"signature_valid"`
+	// SnapshotIntact is set when the signed version still has the signed
+	// hashes and its snapshot still hashes to its chain hash
+	SnapshotIntact bool `json:"snapshot_intact"`
+	// Trusted is set when the public key is the signer's or a trusted key
+	Trusted bool `json:"trusted"`
+	// Valid is set when all three hold
+	Valid    bool     `json:"valid"`
+	Problems []string `json:"problems,omitempty"`
+}
+
+// SignLatestVersion signs the latest version of a case with signer and
+// stores the signature. transitionID links it to the approval it records;
+// 0 leaves it unset.
+func SignLatestVersion(ctx context.Context, db sqlx.ExtContext, signer Signer, caseName string, transitionID int, signedBy string) (*Signature, error) {
+	var latest struct {
+		Version   int    `db:"version"`
+		Hash      string `db:"hash"`
+		ChainHash string `db:"chain_hash"`
+	}
+	err := sqlx.GetContext(ctx, db, &latest, `
+		SELECT version, COALESCE(hash, '') AS hash, COALESCE(chain_hash, '') AS chain_hash
+		FROM kyc_case_versions
+		WHERE case_name = $1
+		ORDER BY version DESC
+		LIMIT 1`, caseName)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ErrNoVersion, caseName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest version of case %s: %w", caseName, err)
+	}
+
+	pub := signer.PublicKey()
+	publicKey, err := EncodePublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+	fingerprint, err := Fingerprint(pub)
+	if err != nil {
+		return nil, err
+	}
+	s := &Signature{
+		CaseName:    caseName,
+		Version:     latest.Version,
+		VersionHash: latest.Hash,
+		ChainHash:   latest.ChainHash,
+		Algorithm:   signer.Algorithm(),
+		KeyID:       signer.KeyID(),
+		Fingerprint: fingerprint,
+		PublicKey:   publicKey,
+		SignedBy:    signedBy,
+	}
+	if transitionID != 0 {
+		s.TransitionID = &transitionID
+	}
+
+	raw, err := signer.Sign(ctx, Digest(s.Statement()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign version %d of case %s: %w", s.Version, caseName, err)
+	}
+	// A signer that returns a signature its own key rejects would store
+	// signatures no one can verify
+	if err := Verify(pub, s.Algorithm, Digest(s.Statement()), raw); err != nil {
+		return nil, fmt.Errorf("signer %s produced an invalid signature: %w", s.KeyID, err)
+	}
+	s.Signature = base64.StdEncoding.EncodeToString(raw)
+
+	err = sqlx.GetContext(ctx, db, s, `
+		INSERT INTO kyc_case_signatures
+		    (case_name, version, version_hash, chain_hash, algorithm, key_id, key_fingerprint,
+		     public_key, signature, transition_id, signed_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING *`,
+		s.CaseName, s.Version, s.VersionHash, s.ChainHash, s.Algorithm, s.KeyID, s.Fingerprint,
+		s.PublicKey, s.Signature, s.TransitionID, s.SignedBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store signature of case %s: %w", caseName, err)
+	}
+	return s, nil
+}
+
+// Signatures returns the signatures of a case, newest first; version 0
+// returns those of every version
+func Signatures(ctx context.Context, db *sqlx.DB, caseName string, version int) ([]Signature, error) {
+	signatures := []Signature{}
+	err := db.SelectContext(ctx, &signatures, `
+		SELECT * FROM kyc_case_signatures
+		WHERE case_name = $1 AND ($2 = 0 OR version = $2)
+		ORDER BY signed_at DESC, id DESC`, caseName, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signatures of case %s: %w", caseName, err)
+	}
+	return signatures, nil
+}
+
+// VerifyCase verifies the signatures of a case, or of one version of it,
+// against the stored versions. trusted holds the fingerprints of trusted
+// keys (TrustedKeys). It fails with ErrNotSigned when there are none.
+func VerifyCase(ctx context.Context, db *sqlx.DB, caseName string, version int, trusted map[string]bool) ([]Verification, error) {
+	signatures, err := Signatures(ctx, db, caseName, version)
+	if err != nil {
+		return nil, err
+	}
+	if len(signatures) == 0 {
+		if version != 0 {
+			return nil, fmt.Errorf("%w: %s version %d", ErrNotSigned, caseName, version)
+		}
+		return nil, fmt.Errorf("%w: %s", ErrNotSigned, caseName)
+	}
+
+	verifications := make([]Verification, 0, len(signatures))
+	for _, s := range signatures {
+		v, err := verify(ctx, db, s, trusted)
+		if err != nil {
+			return nil, err
+		}
+		verifications = append(verifications, *v)
+	}
+	return verifications, nil
+}
+
+// verify checks one signature
+func verify(ctx context.Context, db *sqlx.DB, s Signature, trusted map[string]bool) (*Verification, error) {
+	v := &Verification{Signature: s}
+	problem := func(format string, args ...interface{}) {
+		v.Problems = append(v.Problems, fmt.Sprintf(format, args...))
+	}
+
+	pub, err := ParsePublicKey([]byte(s.PublicKey))
+	switch {
+	case err != nil:
+		problem("stored public key: %v", err)
+	default:
+		raw, decodeErr := base64.StdEncoding.DecodeString(s.Signature)
+		if decodeErr != nil {
+			problem("stored signature is not base64: %v", decodeErr)
+			break
+		}
+		if err := Verify(pub, s.Algorithm, Digest(s.Statement()), raw); err != nil {
+			problem("%v", err)
+			break
+		}
+		v.SignatureValid = true
+		if fp, err := Fingerprint(pub); err != nil || fp != s.Fingerprint {
+			problem("public key does not match the recorded fingerprint %s", s.Fingerprint)
+			v.SignatureValid = false
+		}
+	}
+	v.Trusted = trusted[s.Fingerprint]
+	if !v.Trusted {
+		problem("key %s (%s) is not a trusted signing key", s.KeyID, s.Fingerprint)
+	}
+
+	var stored struct {
+		Hash      string  `db:"hash"`
+		Snapshot  string  `db:"dsl_snapshot"`
+		PrevHash  *string `db:"prev_hash"`
+		ChainHash *string `db:"chain_hash"`
+	}
+	err = db.GetContext(ctx, &stored, `
+		SELECT COALESCE(hash, '') AS hash, COALESCE(dsl_snapshot, '') AS dsl_snapshot, prev_hash, chain_hash
+		FROM kyc_case_versions
+		WHERE case_name = $1 AND version = $2
+		ORDER BY id
+		LIMIT 1`, s.CaseName, s.Version)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		problem("version %d no longer exists", s.Version)
+	case err != nil:
+		return nil, fmt.Errorf("failed to get version %d of case %s: %w", s.Version, s.CaseName, err)
+	case stored.Hash != s.VersionHash:
+		problem("version hash is now %s, signed %s", stored.Hash, s.VersionHash)
+	case stored.ChainHash == nil || stored.PrevHash == nil || *stored.ChainHash != s.ChainHash:
+		problem("chain hash no longer matches the signed %s", s.ChainHash)
+	case storage.ChainHash(*stored.PrevHash, stored.Snapshot) != s.ChainHash:
+		problem("snapshot no longer hashes to the signed chain hash")
+	default:
+		v.SnapshotIntact = true
+	}
+
+	v.Valid = v.SignatureValid && v.SnapshotIntact && v.Trusted
+	return v, nil
+}
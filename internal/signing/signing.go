@@ -0,0 +1,285 @@
+// Package signing signs approved case versions so regulators can confirm
+// that an approved snapshot has not been altered since.
+//
+// Approving a case (internal/engine) signs a statement naming its latest
+// version: the case name, version number, content hash and chain hash
+// (storage.ChainHash). The signature, algorithm and public key are stored
+// in kyc_case_signatures. Verification checks the signature against the
+// statement, re-hashes the stored snapshot and reports whether the key is
+// one the deployment trusts.
+//
+// Keys come from a Signer. The local driver reads a PEM private key file;
+// a KMS or HSM is added by registering a Driver whose Signer asks the
+// service to sign the statement digest.
+package signing
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+)
+
+// Signature algorithms, one per key type. ECDSA and RSA keys sign the
+// statement digest; Ed25519 keys sign the digest as their message.
+const (
+	AlgorithmEd25519 = "ed25519"
+	AlgorithmECDSA   = "ecdsa-sha256"
+	AlgorithmRSA     = "rsa-sha256"
+)
+
+var (
+	// ErrBadSignature is returned when a signature does not match its
+	// statement and public key
+	ErrBadSignature = errors.New("signature does not verify")
+	// ErrUnsupportedKey is returned for keys other than Ed25519, ECDSA and RSA
+	ErrUnsupportedKey = errors.New("unsupported signing key")
+)
+
+// Signer signs statement digests with a private key it may never expose,
+// as a KMS or HSM does
+type Signer interface {
+	// KeyID names the key in stored signatures
+	KeyID() string
+	// Algorithm is one of the Algorithm constants
+	Algorithm() string
+	// PublicKey verifies the signer's signatures
+	PublicKey() crypto.PublicKey
+	// Sign signs a SHA-256 digest
+	Sign(ctx context.Context, digest []byte) ([]byte, error)
+}
+
+// Driver creates the Signer configured by cfg
+type Driver func(cfg config.SigningConfig) (Signer, error)
+
+var (
+	driversMu sync.RWMutex
+	drivers   = map[string]Driver{"local": newLocalSigner}
+)
+
+// RegisterDriver makes a signer driver available as signing.driver, e.g. a
+// KMS or HSM client. It replaces a driver registered under the same name.
+func RegisterDriver(name string, driver Driver) {
+	driversMu.Lock()
+	defer driversMu.Unlock()
+	drivers[name] = driver
+}
+
+// New creates the signer selected by cfg.Driver
+func New(cfg config.SigningConfig) (Signer, error) {
+	driversMu.RLock()
+	driver, ok := drivers[cfg.Driver]
+	names := make([]string, 0, len(drivers))
+	for name := range drivers {
+		names = append(names, name)
+	}
+	driversMu.RUnlock()
+
+	if !ok {
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown signing driver %q (registered: %v)", cfg.Driver, names)
+	}
+	return driver(cfg)
+}
+
+// Statement is the text signed for a case version
+func Statement(caseName string, version int, versionHash, chainHash string) []byte {
+	return []byte("KYC-DSL approved case version\n" +
+		"case: " + caseName + "\n" +
+		"version: " + strconv.Itoa(version) + "\n" +
+		"hash: " + versionHash + "\n" +
+		"chain_hash: " + chainHash + "\n")
+}
+
+// Digest returns the SHA-256 digest of a statement, which signers sign
+func Digest(statement []byte) []byte {
+	sum := sha256.Sum256(statement)
+	return sum[:]
+}
+
+// Verify checks a signature over a statement digest with a public key
+func Verify(pub crypto.PublicKey, algorithm string, digest, signature []byte) error {
+	ok := false
+	switch key := pub.(type) {
+	case ed25519.PublicKey:
+		ok = algorithm == AlgorithmEd25519 && ed25519.Verify(key, digest, signature)
+	case *ecdsa.PublicKey:
+		ok = algorithm == AlgorithmECDSA && ecdsa.VerifyASN1(key, digest, signature)
+	case *rsa.PublicKey:
+		ok = algorithm == AlgorithmRSA && rsa.VerifyPKCS1v15(key, crypto.SHA256, digest, signature) == nil
+	default:
+		return fmt.Errorf("%w: %T", ErrUnsupportedKey, pub)
+	}
+	if !ok {
+		return ErrBadSignature
+	}
+	return nil
+}
+
+// algorithmOf returns the signature algorithm of a public key
+func algorithmOf(pub crypto.PublicKey) (string, error) {
+	switch pub.(type) {
+	case ed25519.PublicKey:
+		return AlgorithmEd25519, nil
+	case *ecdsa.PublicKey:
+		return AlgorithmECDSA, nil
+	case *rsa.PublicKey:
+		return AlgorithmRSA, nil
+	}
+	return "", fmt.Errorf("%w: %T", ErrUnsupportedKey, pub)
+}
+
+// Fingerprint identifies a public key: the hex SHA-256 of its DER encoding
+func Fingerprint(pub crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode public key: %w", err)
+	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// EncodePublicKey returns a public key as a PEM "PUBLIC KEY" block
+func EncodePublicKey(pub crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode public key: %w", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), nil
+}
+
+// ParsePublicKey reads a PEM "PUBLIC KEY" block
+func ParsePublicKey(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+	return pub, nil
+}
+
+// LoadPublicKey reads a PEM public key file
+func LoadPublicKey(path string) (crypto.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read public key: %w", err)
+	}
+	pub, err := ParsePublicKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return pub, nil
+}
+
+// TrustedKeys returns the fingerprints of the keys whose signatures are
+// trusted: the configured signer's and signing.trusted_keys. The signer is
+// only consulted when signing is enabled or a key file is configured.
+func TrustedKeys(cfg config.SigningConfig) (map[string]bool, error) {
+	trusted := make(map[string]bool)
+	if cfg.Enabled || cfg.KeyFile != "" {
+		signer, err := New(cfg)
+		if err != nil {
+			return nil, err
+		}
+		fp, err := Fingerprint(signer.PublicKey())
+		if err != nil {
+			return nil, err
+		}
+		trusted[fp] = true
+	}
+	for _, path := range cfg.TrustedKeys {
+		pub, err := LoadPublicKey(path)
+		if err != nil {
+			return nil, err
+		}
+		fp, err := Fingerprint(pub)
+		if err != nil {
+			return nil, err
+		}
+		trusted[fp] = true
+	}
+	return trusted, nil
+}
+
+// localSigner signs with a private key read from a PEM file
+type localSigner struct {
+	keyID     string
+	algorithm string
+	key       crypto.Signer
+}
+
+// newLocalSigner reads signing.key_file: PKCS#8, SEC 1 (EC) or PKCS#1 (RSA)
+func newLocalSigner(cfg config.SigningConfig) (Signer, error) {
+	if cfg.KeyFile == "" {
+		return nil, errors.New("signing: key_file is required by the local driver")
+	}
+	data, err := os.ReadFile(cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM block found", cfg.KeyFile)
+	}
+
+	var parsed interface{}
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		parsed, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to parse private key: %w", cfg.KeyFile, err)
+	}
+	key, ok := parsed.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("%s: %w: %T", cfg.KeyFile, ErrUnsupportedKey, parsed)
+	}
+	algorithm, err := algorithmOf(key.Public())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", cfg.KeyFile, err)
+	}
+
+	keyID := cfg.KeyID
+	if keyID == "" {
+		fp, err := Fingerprint(key.Public())
+		if err != nil {
+			return nil, err
+		}
+		keyID = fp[:16]
+	}
+	return &localSigner{keyID: keyID, algorithm: algorithm, key: key}, nil
+}
+
+func (s *localSigner) KeyID() string               { return s.keyID }
+func (s *localSigner) Algorithm() string           { return s.algorithm }
+func (s *localSigner) PublicKey() crypto.PublicKey { return s.key.Public() }
+
+// Sign signs the digest; Ed25519 keys sign it as their message
+func (s *localSigner) Sign(_ context.Context, digest []byte) ([]byte, error) {
+	var opts crypto.SignerOpts = crypto.SHA256
+	if s.algorithm == AlgorithmEd25519 {
+		opts = crypto.Hash(0)
+	}
+	return s.key.Sign(rand.Reader, digest, opts)
+}
@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/parser"
+)
+
+// CanonicalForm normalizes DSL text so that snapshots differing only in
+// whitespace, comments, quoting or the order of a case's top-level sections
+// produce identical output. Nested content keeps its order since it can be
+// significant (ownership lists, amendment steps).
+func CanonicalForm(dsl string) (string, error) {
+	forms, err := readForms(dsl)
+	if err != nil {
+		return "", err
+	}
+
+	lines := make([]string, len(forms))
+	for i, form := range forms {
+		sortSections(form)
+		lines[i] = canonicalText(form)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// CanonicalHash returns the SHA-256 of the canonical form. Unparseable text
+// falls back to hashing it with whitespace collapsed.
+func CanonicalHash(dsl string) string {
+	canonical, err := CanonicalForm(dsl)
+	if err != nil {
+		canonical = strings.Join(strings.Fields(dsl), " ")
+	}
+	return sha256Hex(canonical)
+}
+
+// sortSections orders the list children of a top-level form after its
+// leading atoms, e.g. the sections of (kyc-case NAME ...)
+func sortSections(form *parser.Node) {
+	if !form.List {
+		return
+	}
+	leading := 0
+	for leading < len(form.Children) && !form.Children[leading].List {
+		leading++
+	}
+	sections := form.Children[leading:]
+	sort.SliceStable(sections, func(i, j int) bool {
+		return canonicalText(sections[i]) < canonicalText(sections[j])
+	})
+}
+
+// canonicalText renders an expression on one line, quoting atoms only when
+// needed
+func canonicalText(n *parser.Node) string {
+	if !n.List {
+		return quoteAtom(n.Atom)
+	}
+	parts := make([]string, len(n.Children))
+	for i, c := range n.Children {
+		parts[i] = canonicalText(c)
+	}
+	return "(" + strings.Join(parts, " ") + ")"
+}
+
+func quoteAtom(s string) string {
+	return parser.Quote(s)
+}
+
+// readForms parses every top-level expression in src, skipping ; comments
+func readForms(src string) ([]*parser.Node, error) {
+	doc, err := parser.Parse(src)
+	if err != nil {
+		return nil, err
+	}
+	return doc.Children, nil
+}
+
+// sha256Hex returns the hex-encoded SHA-256 digest of input
+func sha256Hex(input string) string {
+	sum := sha256.Sum256([]byte(input))
+	return fmt.Sprintf("%x", sum)
+}
@@ -0,0 +1,426 @@
+package storage
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// CaseBundleFormat is the version of the case bundle layout written by
+// ExportCaseBundle
+const CaseBundleFormat = 1
+
+// caseBundleManifest is the file describing a bundle
+const caseBundleManifest = "manifest.json"
+
+// ErrInvalidBundle is returned for a bundle that is incomplete or was
+// written by a newer format
+var ErrInvalidBundle = errors.New("invalid case bundle")
+
+// bundleTable is a table exported to <name>.jsonl, one row_to_json object
+// per line in id order
+type bundleTable struct {
+	name string
+	// where selects the rows of the exported cases, given as $1
+	where string
+	// caseColumn names the case of a row; rows without one follow their
+	// parent (findings follow their validation)
+	caseColumn string
+}
+
+// caseBundleTables are exported and imported in this order, parents first
+var caseBundleTables = []bundleTable{
+	{"kyc_cases", "name = ANY($1)", "name"},
+	{"kyc_case_versions", "case_name = ANY($1)", "case_name"},
+	{"kyc_case_amendments", "case_name = ANY($1)", "case_name"},
+	{"kyc_case_transitions", "case_name = ANY($1)", "case_name"},
+	{"kyc_case_validations", "case_name = ANY($1)", "case_name"},
+	{"kyc_validation_findings", "validation_id IN (SELECT id FROM kyc_case_validations WHERE case_name = ANY($1))", ""},
+}
+
+// CaseBundleManifest describes a case bundle: when and from which schema
+// version it was exported, its cases and the rows of each table
+type CaseBundleManifest struct {
+	Format        int            `json:"format"`
+	ExportedAt    time.Time      `json:"exported_at"`
+	SchemaVersion int64          `json:"schema_version"`
+	Cases         []string       `json:"cases"`
+	Rows          map[string]int `json:"rows"`
+}
+
+// CaseBundleImport reports what ImportCaseBundle did
+type CaseBundleImport struct {
+	Manifest CaseBundleManifest
+	Imported []string
+	// Skipped are cases that already exist in the target database
+	Skipped []string
+	Rows    map[string]int
+}
+
+// ExportCaseBundle writes every version, amendment, transition and
+// validation (with findings) of the given cases, or of all cases when none
+// are given, as JSONL files and a manifest in dir
+func ExportCaseBundle(ctx context.Context, db *sqlx.DB, dir string, cases []string) (*CaseBundleManifest, error) {
+	if len(cases) == 0 {
+		err := db.SelectContext(ctx, &cases, `
+			SELECT name FROM kyc_cases
+			UNION
+			SELECT case_name FROM kyc_case_versions
+			ORDER BY 1`)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list cases: %w", err)
+		}
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create bundle directory: %w", err)
+	}
+
+	manifest := &CaseBundleManifest{
+		Format:     CaseBundleFormat,
+		ExportedAt: time.Now().UTC(),
+		Cases:      cases,
+		Rows:       make(map[string]int, len(caseBundleTables)),
+	}
+	if manifest.Cases == nil {
+		manifest.Cases = []string{}
+	}
+	version, err := schemaVersion(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	manifest.SchemaVersion = version
+
+	for _, t := range caseBundleTables {
+		n, err := exportBundleTable(ctx, db, dir, t, cases)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Rows[t.name] = n
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, caseBundleManifest), append(data, '\n'), 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+func exportBundleTable(ctx context.Context, db *sqlx.DB, dir string, t bundleTable, cases []string) (int, error) {
+	f, err := os.Create(filepath.Join(dir, t.name+".jsonl"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create %s.jsonl: %w", t.name, err)
+	}
+	defer f.Close()
+	w := bufio.NewWriter(f)
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT row_to_json(t)::text FROM `+t.name+` t
+		WHERE `+t.where+`
+		ORDER BY id`, pq.Array(cases))
+	if err != nil {
+		return 0, fmt.Errorf("failed to export %s: %w", t.name, err)
+	}
+	defer rows.Close()
+
+	n := 0
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return n, fmt.Errorf("failed to export %s: %w", t.name, err)
+		}
+		if _, err := w.WriteString(line + "\n"); err != nil {
+			return n, fmt.Errorf("failed to write %s.jsonl: %w", t.name, err)
+		}
+		n++
+	}
+	if err := rows.Err(); err != nil {
+		return n, fmt.Errorf("failed to export %s: %w", t.name, err)
+	}
+	if err := w.Flush(); err != nil {
+		return n, fmt.Errorf("failed to write %s.jsonl: %w", t.name, err)
+	}
+	return n, f.Close()
+}
+
+// ImportCaseBundle loads a bundle written by ExportCaseBundle in one
+// transaction. Cases that already exist are skipped whole; imported rows get
+// new ids, with findings pointed at their imported validation. Nothing is
+// committed when dryRun is set. Imports write history directly, so they do
+// not emit events.
+func ImportCaseBundle(ctx context.Context, db *sqlx.DB, dir string, dryRun bool) (*CaseBundleImport, error) {
+	data, err := os.ReadFile(filepath.Join(dir, caseBundleManifest))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+	}
+	result := &CaseBundleImport{Rows: make(map[string]int, len(caseBundleTables))}
+	if err := json.Unmarshal(data, &result.Manifest); err != nil {
+		return nil, fmt.Errorf("%w: manifest: %v", ErrInvalidBundle, err)
+	}
+	if result.Manifest.Format > CaseBundleFormat {
+		return nil, fmt.Errorf("%w: format %d is newer than this kycctl (%d)", ErrInvalidBundle, result.Manifest.Format, CaseBundleFormat)
+	}
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	var existing []string
+	err = tx.SelectContext(ctx, &existing, `
+		SELECT name FROM kyc_cases WHERE name = ANY($1)
+		UNION
+		SELECT case_name FROM kyc_case_versions WHERE case_name = ANY($1)`, pq.Array(result.Manifest.Cases))
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing cases: %w", err)
+	}
+	skip := make(map[string]bool, len(existing))
+	for _, name := range existing {
+		skip[name] = true
+	}
+	for _, name := range result.Manifest.Cases {
+		if skip[name] {
+			result.Skipped = append(result.Skipped, name)
+		} else {
+			result.Imported = append(result.Imported, name)
+		}
+	}
+
+	// Old validation ids mapped to the imported ones; findings of
+	// validations not imported are dropped
+	validationIDs := make(map[string]int64)
+	for _, t := range caseBundleTables {
+		n, err := importBundleTable(ctx, tx, dir, t, result.Manifest.Rows[t.name], skip, validationIDs)
+		if err != nil {
+			return nil, err
+		}
+		result.Rows[t.name] = n
+	}
+
+	if dryRun {
+		return result, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit case import: %w", err)
+	}
+	return result, nil
+}
+
+func importBundleTable(ctx context.Context, tx *sqlx.Tx, dir string, t bundleTable, expected int,
+	skip map[string]bool, validationIDs map[string]int64) (int, error) {
+	f, err := os.Open(filepath.Join(dir, t.name+".jsonl"))
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+	}
+	defer f.Close()
+
+	// Insert every column the target has except the id, so bundles from an
+	// older or newer schema load with defaults for what is missing
+	var columns []string
+	err = tx.SelectContext(ctx, &columns, `
+		SELECT column_name FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = $1 AND column_name <> 'id'
+		ORDER BY ordinal_position`, t.name)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read columns of %s: %w", t.name, err)
+	}
+	cols := strings.Join(columns, ", ")
+	insert := `INSERT INTO ` + t.name + ` (` + cols + `)
+		SELECT ` + cols + ` FROM json_populate_record(NULL::` + t.name + `, $1::json)
+		RETURNING id`
+
+	r := bufio.NewReader(f)
+	lines, imported := 0, 0
+	for {
+		line, err := r.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			lines++
+			n, rowErr := importBundleRow(ctx, tx, t, insert, line, skip, validationIDs)
+			if rowErr != nil {
+				return imported, fmt.Errorf("%s.jsonl line %d: %w", t.name, lines, rowErr)
+			}
+			imported += n
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return imported, fmt.Errorf("failed to read %s.jsonl: %w", t.name, err)
+		}
+	}
+	if lines != expected {
+		return imported, fmt.Errorf("%w: %s.jsonl has %d rows, manifest says %d", ErrInvalidBundle, t.name, lines, expected)
+	}
+	return imported, nil
+}
+
+// importBundleRow inserts one exported row unless its case is skipped,
+// returning how many rows it inserted
+func importBundleRow(ctx context.Context, tx *sqlx.Tx, t bundleTable, insert string, line []byte,
+	skip map[string]bool, validationIDs map[string]int64) (int, error) {
+	var row map[string]json.RawMessage
+	if err := json.Unmarshal(line, &row); err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+	}
+
+	if t.caseColumn != "" {
+		var name string
+		if err := json.Unmarshal(row[t.caseColumn], &name); err != nil {
+			return 0, fmt.Errorf("%w: %s: %v", ErrInvalidBundle, t.caseColumn, err)
+		}
+		if skip[name] {
+			return 0, nil
+		}
+	} else {
+		newID, ok := validationIDs[string(row["validation_id"])]
+		if !ok {
+			return 0, nil
+		}
+		row["validation_id"] = json.RawMessage(fmt.Sprint(newID))
+		var err error
+		if line, err = json.Marshal(row); err != nil {
+			return 0, err
+		}
+	}
+
+	var id int64
+	if err := tx.QueryRowContext(ctx, insert, string(line)).Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to insert into %s: %w", t.name, err)
+	}
+	if t.name == "kyc_case_validations" {
+		validationIDs[string(row["id"])] = id
+	}
+	return 1, nil
+}
+
+// schemaVersion returns the latest applied migration
+func schemaVersion(ctx context.Context, db *sqlx.DB) (int64, error) {
+	var version int64
+	err := db.GetContext(ctx, &version, `
+		SELECT COALESCE(MAX(version_id), 0) FROM `+MigrationsTable+` WHERE is_applied`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return version, nil
+}
+
+// IsBundleArchive reports whether path names a bundle archive (.tar,
+// .tar.gz or .tgz) rather than a bundle directory
+func IsBundleArchive(path string) bool {
+	return strings.HasSuffix(path, ".tar") || strings.HasSuffix(path, ".tar.gz") || strings.HasSuffix(path, ".tgz")
+}
+
+// ArchiveCaseBundle writes the files of the bundle in dir to a tar archive,
+// gzip-compressed unless path ends in .tar
+func ArchiveCaseBundle(dir, path string) (err error) {
+	out, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer func() {
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	var w io.Writer = out
+	if !strings.HasSuffix(path, ".tar") {
+		gz := gzip.NewWriter(out)
+		defer func() {
+			if closeErr := gz.Close(); err == nil {
+				err = closeErr
+			}
+		}()
+		w = gz
+	}
+	tw := tar.NewWriter(w)
+	defer func() {
+		if closeErr := tw.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	files := []string{caseBundleManifest}
+	for _, t := range caseBundleTables {
+		files = append(files, t.name+".jsonl")
+	}
+	for _, name := range files {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		hdr := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(data)), ModTime: time.Now()}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return fmt.Errorf("failed to archive %s: %w", name, err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			return fmt.Errorf("failed to archive %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// ExtractCaseBundle unpacks a bundle archive into dir. Only the bundle's
+// own files are extracted.
+func ExtractCaseBundle(path, dir string) error {
+	in, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer in.Close()
+
+	var r io.Reader = in
+	if !strings.HasSuffix(path, ".tar") {
+		gz, err := gzip.NewReader(in)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	known := map[string]bool{caseBundleManifest: true}
+	for _, t := range caseBundleTables {
+		known[t.name+".jsonl"] = true
+	}
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+		}
+		if !known[hdr.Name] || hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		out, err := os.Create(filepath.Join(dir, hdr.Name))
+		if err != nil {
+			return fmt.Errorf("failed to extract %s: %w", hdr.Name, err)
+		}
+		_, err = io.Copy(out, tr) //nolint:gosec // bundle files are bounded by the export
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return fmt.Errorf("failed to extract %s: %w", hdr.Name, err)
+		}
+	}
+}
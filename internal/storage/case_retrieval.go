@@ -12,6 +12,8 @@ type CaseVersionInfo struct {
 	Version   int       `db:"version"`
 	Hash      string    `db:"hash"`
 	CreatedAt time.Time `db:"created_at"`
+	// GrammarVersion is empty for versions stored before grammar tagging
+	GrammarVersion string `db:"grammar_version"`
 }
 
 // CaseSummary holds summary information about a case
@@ -20,6 +22,9 @@ type CaseSummary struct {
 	VersionCount int       `db:"version_count"`
 	Status       string    `db:"status"`
 	LastUpdated  time.Time `db:"last_updated"`
+	// LockedBy is the holder of a live case lock (kyc_case_locks), if any
+	LockedBy      string     `db:"locked_by"`
+	LockExpiresAt *time.Time `db:"lock_expires_at"`
 }
 
 // GetCaseVersion retrieves a specific version of a case
@@ -96,7 +101,7 @@ func ListCaseVersions(db *sqlx.DB, caseName string) ([]CaseVersionInfo, error) {
 	var versions []CaseVersionInfo
 
 	query := `
-		SELECT version, hash, created_at
+		SELECT version, hash, created_at, COALESCE(grammar_version, '') AS grammar_version
 		FROM kyc_case_versions
 		WHERE case_name = $1
 		ORDER BY version ASC
@@ -123,10 +128,13 @@ func ListAllCases(db *sqlx.DB) ([]CaseSummary, error) {
 			c.name,
 			COUNT(v.version) as version_count,
 			c.status,
-			c.last_updated
+			c.last_updated,
+			COALESCE(l.holder, '') AS locked_by,
+			l.expires_at AS lock_expires_at
 		FROM kyc_cases c
 		LEFT JOIN kyc_case_versions v ON c.name = v.case_name
-		GROUP BY c.name, c.status, c.last_updated
+		LEFT JOIN kyc_case_locks l ON l.case_name = c.name AND l.expires_at >= NOW()
+		GROUP BY c.name, c.status, c.last_updated, l.holder, l.expires_at
 		ORDER BY c.last_updated DESC
 	`
 
@@ -154,11 +162,14 @@ func GetCaseByName(db *sqlx.DB, caseName string) (*CaseSummary, error) {
 			c.name,
 			COUNT(v.version) as version_count,
 			c.status,
-			c.last_updated
+			c.last_updated,
+			COALESCE(l.holder, '') AS locked_by,
+			l.expires_at AS lock_expires_at
 		FROM kyc_cases c
 		LEFT JOIN kyc_case_versions v ON c.name = v.case_name
+		LEFT JOIN kyc_case_locks l ON l.case_name = c.name AND l.expires_at >= NOW()
 		WHERE c.name = $1
-		GROUP BY c.name, c.status, c.last_updated
+		GROUP BY c.name, c.status, c.last_updated, l.holder, l.expires_at
 	`
 
 	err := db.Get(&caseInfo, query, caseName)
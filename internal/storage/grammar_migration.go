@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/adamtc007/KYC-DSL/internal/parser"
+)
+
+// GrammarMigrationStep is the step recorded in kyc_case_amendments for a
+// grammar upgrade
+const GrammarMigrationStep = "grammar-migration"
+
+// GrammarMigration is the outcome of upgrading the latest version of a case
+// to a grammar version
+type GrammarMigration struct {
+	CaseName string `json:"case_name"`
+	// Version is the case version that was migrated
+	Version int `json:"version"`
+	// NewVersion is the version storing the upgraded DSL, or 0 when no
+	// form needed rewriting or the migration was a dry run
+	NewVersion int `json:"new_version,omitempty"`
+	*parser.Migration
+}
+
+// latestTaggedVersion is the latest snapshot of a case with its grammar tag
+type latestTaggedVersion struct {
+	CaseName       string `db:"case_name"`
+	Version        int    `db:"version"`
+	DslSnapshot    string `db:"dsl_snapshot"`
+	GrammarVersion string `db:"grammar_version"`
+}
+
+// CasesBehindGrammar lists the cases whose latest version is not tagged with
+// grammar version target, untagged versions included
+func CasesBehindGrammar(ctx context.Context, db *sqlx.DB, target string) ([]string, error) {
+	var names []string
+	err := db.SelectContext(ctx, &names, `
+		SELECT case_name FROM (
+			SELECT DISTINCT ON (case_name) case_name, grammar_version
+			FROM kyc_case_versions
+			ORDER BY case_name, version DESC
+		) latest
+		WHERE grammar_version IS DISTINCT FROM $1
+		ORDER BY case_name`, target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cases behind grammar v%s: %w", target, err)
+	}
+	return names, nil
+}
+
+// MigrateCaseGrammar upgrades the latest version of a case to grammar
+// version target with parser.MigrateCase. When forms were rewritten the
+// upgraded DSL is stored as a new version and the changes are recorded as a
+// grammar amendment; otherwise only the version's grammar tag is updated.
+// A dry run stores nothing.
+func MigrateCaseGrammar(ctx context.Context, db *sqlx.DB, caseName, target string, dryRun bool) (*GrammarMigration, error) {
+	var latest latestTaggedVersion
+	err := db.GetContext(ctx, &latest, `
+		SELECT case_name, version, dsl_snapshot, COALESCE(grammar_version, '') AS grammar_version
+		FROM kyc_case_versions
+		WHERE case_name = $1
+		ORDER BY version DESC
+		LIMIT 1`, caseName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest case '%s': %w", caseName, err)
+	}
+
+	// Untagged versions are detected from their DSL
+	m, err := parser.MigrateCase(latest.DslSnapshot, latest.GrammarVersion, target)
+	if err != nil {
+		return nil, fmt.Errorf("case %s version %d: %w", caseName, latest.Version, err)
+	}
+	result := &GrammarMigration{CaseName: caseName, Version: latest.Version, Migration: m}
+	if dryRun {
+		return result, nil
+	}
+
+	if len(m.Changes) == 0 {
+		if latest.GrammarVersion != m.To {
+			_, err := db.ExecContext(ctx, `UPDATE kyc_case_versions SET grammar_version = $3
+				WHERE case_name = $1 AND version = $2`, caseName, latest.Version, m.To)
+			if err != nil {
+				return nil, fmt.Errorf("failed to tag case %s version %d: %w", caseName, latest.Version, err)
+			}
+		}
+		return result, nil
+	}
+
+	next, err := GetNextVersion(db, caseName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next version: %w", err)
+	}
+	if err := insertVersion(ctx, db, caseName, next, m.DSL, m.To); err != nil {
+		return nil, fmt.Errorf("insert version failed: %w", err)
+	}
+	diff := fmt.Sprintf("grammar v%s → v%s (version %d → %d)\n%s",
+		m.From, m.To, latest.Version, next, strings.Join(m.Changes, "\n"))
+	if err := InsertAmendment(ctx, db, caseName, GrammarMigrationStep, "grammar", diff); err != nil {
+		return nil, err
+	}
+	result.NewVersion = next
+	return result, nil
+}
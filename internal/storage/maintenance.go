@@ -0,0 +1,404 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrMaintenanceRunning is returned when another maintenance run holds
+	// the maintenance advisory lock
+	ErrMaintenanceRunning = errors.New("another maintenance run is in progress")
+	// ErrTableBusy is returned when other sessions hold or wait for locks
+	// that the operation would conflict with
+	ErrTableBusy = errors.New("table is locked by other sessions")
+)
+
+// maintenanceLockKey is the session advisory lock held for the duration of
+// a maintenance operation, so two runs never work on the schema at once
+const maintenanceLockKey = 0x6b79636d61696e74 // "kycmaint"
+
+// progressInterval is how often running maintenance reports progress
+const progressInterval = 2 * time.Second
+
+// conflictingModes are the lock modes that conflict with the
+// SHARE UPDATE EXCLUSIVE lock taken by VACUUM, ANALYZE and
+// REINDEX CONCURRENTLY
+var conflictingModes = []string{
+	"ShareUpdateExclusiveLock", "ShareLock", "ShareRowExclusiveLock",
+	"ExclusiveLock", "AccessExclusiveLock",
+}
+
+// IndexInfo describes a pgvector index
+type IndexInfo struct {
+	Name   string `db:"name"`
+	Table  string `db:"table_name"`
+	Method string `db:"method"`
+	Size   string `db:"size"`
+	Valid  bool   `db:"valid"`
+}
+
+// TableStats are the vacuum statistics of a table
+type TableStats struct {
+	Name             string     `db:"name"`
+	LiveTuples       int64      `db:"live_tuples"`
+	DeadTuples       int64      `db:"dead_tuples"`
+	ModsSinceAnalyze int64      `db:"mods_since_analyze"`
+	LastVacuum       *time.Time `db:"last_vacuum"`
+	LastAnalyze      *time.Time `db:"last_analyze"`
+}
+
+// PartitionedTable describes a partitioned table and its partitions
+type PartitionedTable struct {
+	Name       string `db:"name"`
+	Key        string `db:"key"`
+	Partitions int    `db:"partitions"`
+}
+
+// LockHolder is another session holding or waiting for a lock on a table
+type LockHolder struct {
+	PID     int       `db:"pid"`
+	Mode    string    `db:"mode"`
+	Granted bool      `db:"granted"`
+	State   string    `db:"state"`
+	Query   string    `db:"query"`
+	Since   time.Time `db:"since"`
+}
+
+// MaintenanceProgress is a progress report of a running operation, read
+// from the pg_stat_progress_* views
+type MaintenanceProgress struct {
+	Object string
+	Phase  string
+	Done   int64
+	Total  int64
+	// Waiting is the number of sessions the operation still waits for
+	Waiting int64
+}
+
+// Percent is the share of the current phase done, or -1 when unknown
+func (p MaintenanceProgress) Percent() float64 {
+	if p.Total <= 0 {
+		return -1
+	}
+	return 100 * float64(p.Done) / float64(p.Total)
+}
+
+// Maintenance runs reindex, vacuum and partition maintenance safely: one run
+// at a time (advisory lock), never queueing behind other sessions' locks
+// (lock_timeout and a blocker check before starting), and reporting
+// progress while it works.
+type Maintenance struct {
+	db *sqlx.DB
+	// LockTimeout bounds the wait for each lock the operation takes
+	LockTimeout time.Duration
+	// Progress receives progress reports while an operation runs
+	Progress func(MaintenanceProgress)
+}
+
+// NewMaintenance creates a maintenance runner with a 5s lock timeout
+func NewMaintenance(db *sqlx.DB) *Maintenance {
+	return &Maintenance{db: db, LockTimeout: 5 * time.Second}
+}
+
+// VectorIndexes returns the hnsw and ivfflat indexes of the database
+func (m *Maintenance) VectorIndexes(ctx context.Context) ([]IndexInfo, error) {
+	var indexes []IndexInfo
+	err := m.db.SelectContext(ctx, &indexes, `
+		SELECT c.relname AS name, t.relname AS table_name, am.amname AS method,
+		       pg_size_pretty(pg_relation_size(c.oid)) AS size, i.indisvalid AND i.indisready AS valid
+		FROM pg_index i
+		JOIN pg_class c ON c.oid = i.indexrelid
+		JOIN pg_class t ON t.oid = i.indrelid
+		JOIN pg_am am ON am.oid = c.relam
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE am.amname IN ('hnsw', 'ivfflat') AND n.nspname = current_schema()
+		ORDER BY t.relname, c.relname`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list vector indexes: %w", err)
+	}
+	return indexes, nil
+}
+
+// HotTables returns the tables with at least minDead dead tuples or
+// modifications since their last analyze, most dead tuples first
+func (m *Maintenance) HotTables(ctx context.Context, minDead int64, limit int) ([]TableStats, error) {
+	var tables []TableStats
+	err := m.db.SelectContext(ctx, &tables, `
+		SELECT relname AS name, n_live_tup AS live_tuples, n_dead_tup AS dead_tuples,
+		       n_mod_since_analyze AS mods_since_analyze,
+		       GREATEST(last_vacuum, last_autovacuum) AS last_vacuum,
+		       GREATEST(last_analyze, last_autoanalyze) AS last_analyze
+		FROM pg_stat_user_tables
+		WHERE schemaname = current_schema()
+		  AND (n_dead_tup >= $1 OR n_mod_since_analyze >= $1)
+		ORDER BY n_dead_tup DESC, n_mod_since_analyze DESC
+		LIMIT $2`, minDead, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read table statistics: %w", err)
+	}
+	return tables, nil
+}
+
+// PartitionedTables returns the partitioned tables of the database
+func (m *Maintenance) PartitionedTables(ctx context.Context) ([]PartitionedTable, error) {
+	var tables []PartitionedTable
+	err := m.db.SelectContext(ctx, &tables, `
+		SELECT c.relname AS name, pg_get_partkeydef(c.oid) AS key,
+		       (SELECT COUNT(*) FROM pg_inherits i WHERE i.inhparent = c.oid) AS partitions
+		FROM pg_partitioned_table p
+		JOIN pg_class c ON c.oid = p.partrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = current_schema()
+		ORDER BY c.relname`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list partitioned tables: %w", err)
+	}
+	return tables, nil
+}
+
+// Blockers returns the other sessions holding or waiting for locks on a
+// table (or the table of an index) that VACUUM or REINDEX CONCURRENTLY would
+// conflict with
+func (m *Maintenance) Blockers(ctx context.Context, relation string) ([]LockHolder, error) {
+	var holders []LockHolder
+	err := m.db.SelectContext(ctx, &holders, `
+		WITH target AS (
+			SELECT COALESCE((SELECT indrelid FROM pg_index WHERE indexrelid = $1::regclass), $1::regclass) AS oid
+		)
+		SELECT l.pid, l.mode, l.granted, COALESCE(a.state, '') AS state,
+		       LEFT(COALESCE(a.query, ''), 120) AS query,
+		       COALESCE(a.xact_start, a.backend_start) AS since
+		FROM pg_locks l
+		JOIN target t ON l.relation = t.oid
+		JOIN pg_stat_activity a ON a.pid = l.pid
+		WHERE l.pid <> pg_backend_pid() AND l.mode = ANY($2)
+		ORDER BY since`, relation, pq.Array(conflictingModes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to check locks on %s: %w", relation, err)
+	}
+	return holders, nil
+}
+
+// LongTransactions returns sessions whose transaction has been open longer
+// than age; REINDEX CONCURRENTLY waits for them before it can finish
+func (m *Maintenance) LongTransactions(ctx context.Context, age time.Duration) ([]LockHolder, error) {
+	var holders []LockHolder
+	err := m.db.SelectContext(ctx, &holders, `
+		SELECT pid, '' AS mode, true AS granted, COALESCE(state, '') AS state,
+		       LEFT(COALESCE(query, ''), 120) AS query, xact_start AS since
+		FROM pg_stat_activity
+		WHERE xact_start < NOW() - make_interval(secs => $1) AND pid <> pg_backend_pid()
+		  AND datname = current_database()
+		ORDER BY xact_start`, age.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list long transactions: %w", err)
+	}
+	return holders, nil
+}
+
+// Reindex rebuilds an index with REINDEX INDEX CONCURRENTLY, reporting
+// pg_stat_progress_create_index, and checks the new index is valid
+func (m *Maintenance) Reindex(ctx context.Context, index string) error {
+	return m.run(ctx, index, `REINDEX INDEX CONCURRENTLY `+pq.QuoteIdentifier(index), `
+		SELECT phase, COALESCE(NULLIF(blocks_total, 0), tuples_total) AS total,
+		       CASE WHEN blocks_total > 0 THEN blocks_done ELSE tuples_done END AS done,
+		       lockers_total - lockers_done AS waiting
+		FROM pg_stat_progress_create_index WHERE pid = $1`, func(ctx context.Context) error {
+		var valid bool
+		err := m.db.GetContext(ctx, &valid, `
+			SELECT indisvalid AND indisready FROM pg_index WHERE indexrelid = $1::regclass`, index)
+		if err != nil {
+			return fmt.Errorf("failed to check index %s: %w", index, err)
+		}
+		if !valid {
+			return fmt.Errorf("index %s is invalid after reindex; drop the leftover *_ccnew index and retry", index)
+		}
+		return nil
+	})
+}
+
+// Vacuum runs VACUUM (ANALYZE) on a table, or only ANALYZE, reporting
+// pg_stat_progress_vacuum or pg_stat_progress_analyze
+func (m *Maintenance) Vacuum(ctx context.Context, table string, analyzeOnly bool) error {
+	if analyzeOnly {
+		return m.run(ctx, table, `ANALYZE `+pq.QuoteIdentifier(table), `
+			SELECT phase, sample_blks_total AS total, sample_blks_scanned AS done, 0 AS waiting
+			FROM pg_stat_progress_analyze WHERE pid = $1`, nil)
+	}
+	return m.run(ctx, table, `VACUUM (ANALYZE) `+pq.QuoteIdentifier(table), `
+		SELECT phase, heap_blks_total AS total, heap_blks_scanned AS done, 0 AS waiting
+		FROM pg_stat_progress_vacuum WHERE pid = $1`, nil)
+}
+
+// run executes a maintenance statement on a dedicated connection holding
+// the maintenance advisory lock, with lock_timeout set, after checking no
+// other session holds a conflicting lock. progressQuery reads the progress
+// of the connection's backend; verify, when set, runs afterwards.
+func (m *Maintenance) run(ctx context.Context, relation, statement, progressQuery string, verify func(context.Context) error) error {
+	conn, err := m.db.Connx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to open maintenance connection: %w", err)
+	}
+	defer conn.Close()
+
+	var locked bool
+	if err := conn.GetContext(ctx, &locked, `SELECT pg_try_advisory_lock($1)`, maintenanceLockKey); err != nil {
+		return fmt.Errorf("failed to take maintenance lock: %w", err)
+	}
+	if !locked {
+		return ErrMaintenanceRunning
+	}
+	defer conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, maintenanceLockKey) //nolint:errcheck
+
+	blockers, err := m.Blockers(ctx, relation)
+	if err != nil {
+		return err
+	}
+	if len(blockers) > 0 {
+		return fmt.Errorf("%w: %s has %d conflicting lock(s), oldest held by pid %d since %s",
+			ErrTableBusy, relation, len(blockers), blockers[0].PID, blockers[0].Since.Format(time.RFC3339))
+	}
+
+	if _, err := conn.ExecContext(ctx, fmt.Sprintf(`SET lock_timeout = %d`, m.LockTimeout.Milliseconds())); err != nil {
+		return fmt.Errorf("failed to set lock_timeout: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), `RESET lock_timeout`) //nolint:errcheck
+
+	var pid int
+	if err := conn.GetContext(ctx, &pid, `SELECT pg_backend_pid()`); err != nil {
+		return fmt.Errorf("failed to read backend pid: %w", err)
+	}
+
+	done := make(chan struct{})
+	if m.Progress != nil {
+		go m.reportProgress(ctx, relation, progressQuery, pid, done)
+	}
+	_, err = conn.ExecContext(ctx, statement)
+	close(done)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "55P03" { // lock_not_available
+			return fmt.Errorf("%w: %s (lock_timeout %s)", ErrTableBusy, relation, m.LockTimeout)
+		}
+		return fmt.Errorf("%s failed: %w", strings.Fields(statement)[0], err)
+	}
+	if verify != nil {
+		return verify(ctx)
+	}
+	return nil
+}
+
+// reportProgress polls a progress view for a backend until done is closed
+func (m *Maintenance) reportProgress(ctx context.Context, relation, query string, pid int, done <-chan struct{}) {
+	ticker := time.NewTicker(progressInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		var row struct {
+			Phase   string        `db:"phase"`
+			Total   sql.NullInt64 `db:"total"`
+			Done    sql.NullInt64 `db:"done"`
+			Waiting sql.NullInt64 `db:"waiting"`
+		}
+		if err := m.db.GetContext(ctx, &row, query, pid); err != nil {
+			continue
+		}
+		m.Progress(MaintenanceProgress{
+			Object:  relation,
+			Phase:   row.Phase,
+			Done:    row.Done.Int64,
+			Total:   row.Total.Int64,
+			Waiting: row.Waiting.Int64,
+		})
+	}
+}
+
+// rangeKey matches the partition key of a table range-partitioned on one column
+var rangeKey = regexp.MustCompile(`^RANGE \((\w+)\)$`)
+
+// EnsurePartitions creates the monthly partitions of a table range-partitioned
+// on a date or timestamp column for the current month and the following
+// months, named <table>_pYYYYMM. It returns the partitions created, or with
+// dryRun those it would create.
+func (m *Maintenance) EnsurePartitions(ctx context.Context, table string, months int, dryRun bool) ([]string, error) {
+	var key string
+	err := m.db.GetContext(ctx, &key, `
+		SELECT pg_get_partkeydef(c.oid) FROM pg_partitioned_table p JOIN pg_class c ON c.oid = p.partrelid
+		WHERE c.oid = $1::regclass`, table)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%s is not a partitioned table", table)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read partition key of %s: %w", table, err)
+	}
+	if !rangeKey.MatchString(key) {
+		return nil, fmt.Errorf("%s is partitioned by %s; only single-column range partitions are maintained", table, key)
+	}
+
+	var existing []string
+	err = m.db.SelectContext(ctx, &existing, `
+		SELECT c.relname FROM pg_inherits i JOIN pg_class c ON c.oid = i.inhrelid
+		WHERE i.inhparent = $1::regclass`, table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list partitions of %s: %w", table, err)
+	}
+	have := make(map[string]bool, len(existing))
+	for _, name := range existing {
+		have[name] = true
+	}
+
+	var created []string
+	start := time.Now().UTC()
+	start = time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i <= months; i++ {
+		from := start.AddDate(0, i, 0)
+		to := from.AddDate(0, 1, 0)
+		name := fmt.Sprintf("%s_p%s", table, from.Format("200601"))
+		if have[name] {
+			continue
+		}
+		if !dryRun {
+			if err := m.createPartition(ctx, table, name, from, to); err != nil {
+				return created, err
+			}
+		}
+		created = append(created, name)
+	}
+	return created, nil
+}
+
+func (m *Maintenance) createPartition(ctx context.Context, table, name string, from, to time.Time) error {
+	conn, err := m.db.Connx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to open maintenance connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, fmt.Sprintf(`SET lock_timeout = %d`, m.LockTimeout.Milliseconds())); err != nil {
+		return fmt.Errorf("failed to set lock_timeout: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), `RESET lock_timeout`) //nolint:errcheck
+
+	_, err = conn.ExecContext(ctx, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s PARTITION OF %s FOR VALUES FROM ('%s') TO ('%s')`,
+		pq.QuoteIdentifier(name), pq.QuoteIdentifier(table), from.Format("2006-01-02"), to.Format("2006-01-02")))
+	if err != nil {
+		return fmt.Errorf("failed to create partition %s: %w", name, err)
+	}
+	return nil
+}
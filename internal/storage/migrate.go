@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"log/slog"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pressly/goose/v3"
+)
+
+//go:embed migrations/*.sql
+var embeddedMigrations embed.FS
+
+// MigrationsTable records which schema migrations have been applied
+const MigrationsTable = "kyc_schema_migrations"
+
+// newMigrationProvider returns a goose provider over the embedded migrations
+func newMigrationProvider(db *sqlx.DB) (*goose.Provider, error) {
+	fsys, err := fs.Sub(embeddedMigrations, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open embedded migrations: %w", err)
+	}
+	provider, err := goose.NewProvider(goose.DialectPostgres, db.DB, fsys,
+		goose.WithTableName(MigrationsTable),
+		goose.WithDisableGlobalRegistry(true),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migration provider: %w", err)
+	}
+	return provider, nil
+}
+
+// MigrateUp applies all pending migrations in version order
+func MigrateUp(ctx context.Context, db *sqlx.DB) ([]*goose.MigrationResult, error) {
+	provider, err := newMigrationProvider(db)
+	if err != nil {
+		return nil, err
+	}
+	results, err := provider.Up(ctx)
+	if err != nil {
+		return results, fmt.Errorf("failed to apply migrations: %w", err)
+	}
+	return results, nil
+}
+
+// MigrateDown rolls back the most recently applied migration
+func MigrateDown(ctx context.Context, db *sqlx.DB) (*goose.MigrationResult, error) {
+	provider, err := newMigrationProvider(db)
+	if err != nil {
+		return nil, err
+	}
+	result, err := provider.Down(ctx)
+	if err != nil {
+		return result, fmt.Errorf("failed to roll back migration: %w", err)
+	}
+	return result, nil
+}
+
+// MigrationStatus lists every known migration with its applied state
+func MigrationStatus(ctx context.Context, db *sqlx.DB) ([]*goose.MigrationStatus, error) {
+	provider, err := newMigrationProvider(db)
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := provider.Status(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migration status: %w", err)
+	}
+	return statuses, nil
+}
+
+// checkMigrations applies pending migrations when autoMigrate is set and
+// otherwise only warns, so connecting never changes the schema implicitly
+func checkMigrations(ctx context.Context, db *sqlx.DB, autoMigrate bool) error {
+	provider, err := newMigrationProvider(db)
+	if err != nil {
+		return err
+	}
+
+	if autoMigrate {
+		results, err := provider.Up(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to apply migrations: %w", err)
+		}
+		for _, r := range results {
+			slog.Info("Applied migration", "component", "storage", "migration", r.Source.Path, "duration", r.Duration)
+		}
+		return nil
+	}
+
+	pending, err := provider.HasPending(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check pending migrations: %w", err)
+	}
+	if pending {
+		current, target, _ := provider.GetVersions(ctx)
+		slog.Warn("Database schema is behind; run `kycctl migrate up`",
+			"component", "storage", "schema_version", current, "latest_version", target)
+	}
+	return nil
+}
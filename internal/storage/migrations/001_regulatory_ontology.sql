@@ -3,6 +3,8 @@
 -- Regulatory Data Ontology Core Schema
 -- ===========================================================
 
+-- +goose Up
+
 -- Regulations: Laws, directives, and regulatory frameworks
 CREATE TABLE IF NOT EXISTS kyc_regulations (
     id SERIAL PRIMARY KEY,
@@ -72,3 +74,10 @@ CREATE INDEX IF NOT EXISTS idx_kyc_attr_doc_links_attr ON kyc_attr_doc_links(att
 CREATE INDEX IF NOT EXISTS idx_kyc_attr_doc_links_doc ON kyc_attr_doc_links(document_code);
 CREATE INDEX IF NOT EXISTS idx_kyc_doc_reg_links_doc ON kyc_doc_reg_links(document_code);
 CREATE INDEX IF NOT EXISTS idx_kyc_doc_reg_links_reg ON kyc_doc_reg_links(regulation_code);
+
+-- +goose Down
+DROP TABLE IF EXISTS kyc_doc_reg_links;
+DROP TABLE IF EXISTS kyc_attr_doc_links;
+DROP TABLE IF EXISTS kyc_attributes;
+DROP TABLE IF EXISTS kyc_documents;
+DROP TABLE IF EXISTS kyc_regulations;
@@ -5,6 +5,8 @@
 -- HKMA AML §3.6, EU AMLD6 Article 30 (record-keeping)
 -- ===========================================================
 
+-- +goose Up
+
 -- Validation audit table: Records every validation attempt
 CREATE TABLE IF NOT EXISTS kyc_case_validations (
     id SERIAL PRIMARY KEY,
@@ -87,3 +89,9 @@ GROUP BY v.id, v.case_name, v.version, v.validation_time,
          v.validator_actor, v.validation_status,
          v.grammar_version, v.ontology_version
 ORDER BY v.validation_time DESC;
+
+-- +goose Down
+DROP VIEW IF EXISTS compliance_audit_trail;
+DROP VIEW IF EXISTS validation_summary;
+DROP TABLE IF EXISTS kyc_validation_findings;
+DROP TABLE IF EXISTS kyc_case_validations;
@@ -3,6 +3,8 @@
 -- Public/Private Attribute Classification & Derivation Tracking
 -- ===========================================================
 
+-- +goose Up
+
 -- Add attribute_class column to existing kyc_attributes table
 ALTER TABLE kyc_attributes
     ADD COLUMN IF NOT EXISTS attribute_class TEXT
@@ -59,3 +61,9 @@ COMMENT ON COLUMN kyc_attribute_derivations.rule_expression IS
 
 COMMENT ON COLUMN kyc_attribute_derivations.rule_type IS
     'Classification of derivation: Boolean (flags), Numeric (scores), String (computed text), Lookup (reference data)';
+
+-- +goose Down
+DROP VIEW IF EXISTS attribute_lineage;
+DROP TABLE IF EXISTS kyc_attribute_derivations;
+DROP INDEX IF EXISTS idx_kyc_attributes_class;
+ALTER TABLE kyc_attributes DROP COLUMN IF EXISTS attribute_class;
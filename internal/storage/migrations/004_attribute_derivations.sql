@@ -3,6 +3,8 @@
 -- Public vs Private Attribute Classification + Lineage Rules
 -- ===========================================================
 
+-- +goose Up
+
 -- Add attribute_class column to existing kyc_attributes table
 ALTER TABLE kyc_attributes
 ADD COLUMN IF NOT EXISTS attribute_class TEXT
@@ -31,8 +33,14 @@ CREATE INDEX IF NOT EXISTS idx_derivations_derived
 CREATE INDEX IF NOT EXISTS idx_derivations_source
     ON kyc_attribute_derivations(source_attribute_code);
 
+-- kyc_attribute_derivations may already exist from 003 without these columns
+ALTER TABLE kyc_attribute_derivations
+ADD COLUMN IF NOT EXISTS jurisdiction TEXT,
+ADD COLUMN IF NOT EXISTS regulation_code TEXT REFERENCES kyc_regulations(code);
+
 -- View: Show all private attributes with their derivation sources
-CREATE OR REPLACE VIEW attribute_lineage AS
+DROP VIEW IF EXISTS attribute_lineage;
+CREATE VIEW attribute_lineage AS
 SELECT
     a.code as derived_attribute,
     a.name as derived_attribute_name,
@@ -57,3 +65,24 @@ COMMENT ON TABLE kyc_attribute_derivations IS
 
 COMMENT ON COLUMN kyc_attribute_derivations.rule_expression IS
     'DSL or formula describing transformation, e.g., "(if (in TaxResidencyCountry [''IR'' ''KP'']) true false)"';
+
+-- +goose Down
+-- Restore the 003 lineage view; the derivations table itself belongs to 003
+DROP VIEW IF EXISTS attribute_lineage;
+ALTER TABLE kyc_attribute_derivations
+DROP COLUMN IF EXISTS regulation_code,
+DROP COLUMN IF EXISTS jurisdiction;
+CREATE VIEW attribute_lineage AS
+SELECT
+    a.code as derived_attribute,
+    a.name as derived_attribute_name,
+    a.domain as derived_domain,
+    d.source_attribute_code as source_attribute,
+    sa.name as source_attribute_name,
+    d.rule_expression,
+    d.rule_type
+FROM kyc_attributes a
+JOIN kyc_attribute_derivations d ON d.derived_attribute_code = a.code
+JOIN kyc_attributes sa ON sa.code = d.source_attribute_code
+WHERE a.attribute_class = 'Private'
+ORDER BY a.code, d.source_attribute_code;
@@ -4,6 +4,8 @@
 -- Records the execution and results of derived attribute rules
 -- ===========================================================
 
+-- +goose Up
+
 -- Lineage Evaluations: Audit trail of rule executions
 CREATE TABLE IF NOT EXISTS kyc_lineage_evaluations (
     id SERIAL PRIMARY KEY,
@@ -77,3 +79,8 @@ COMMENT ON COLUMN kyc_lineage_evaluations.value IS
 
 COMMENT ON COLUMN kyc_lineage_evaluations.rule IS
     'The rule expression that was evaluated, for audit and debugging purposes';
+
+-- +goose Down
+DROP VIEW IF EXISTS failed_lineage_evaluations;
+DROP VIEW IF EXISTS lineage_evaluation_summary;
+DROP TABLE IF EXISTS kyc_lineage_evaluations;
@@ -4,6 +4,8 @@
 -- Enables RAG, vector search, and agent optimization
 -- ===========================================================
 
+-- +goose Up
+
 -- Enable pgvector extension for semantic search
 CREATE EXTENSION IF NOT EXISTS vector;
 
@@ -155,6 +157,7 @@ ORDER BY r.strength DESC;
 -- ==================== Functions ====================
 
 -- Function: Find attributes by synonym
+-- +goose StatementBegin
 CREATE OR REPLACE FUNCTION find_attribute_by_synonym(search_term TEXT)
 RETURNS TABLE(attribute_code TEXT, attribute_name TEXT, synonyms TEXT[]) AS $$
 BEGIN
@@ -166,8 +169,10 @@ BEGIN
     ORDER BY a.code;
 END;
 $$ LANGUAGE plpgsql;
+-- +goose StatementEnd
 
 -- Function: Get all attributes in a cluster
+-- +goose StatementBegin
 CREATE OR REPLACE FUNCTION get_cluster_attributes(cluster_name TEXT)
 RETURNS TABLE(attribute_code TEXT, attribute_name TEXT, domain TEXT) AS $$
 BEGIN
@@ -182,8 +187,10 @@ BEGIN
     ORDER BY a.code;
 END;
 $$ LANGUAGE plpgsql;
+-- +goose StatementEnd
 
 -- Function: Find related attributes (traverses relationship graph)
+-- +goose StatementBegin
 CREATE OR REPLACE FUNCTION find_related_attributes(attr_code TEXT, max_depth INT DEFAULT 2)
 RETURNS TABLE(
     related_code TEXT,
@@ -221,6 +228,7 @@ FROM attr_graph g
 JOIN kyc_attributes a ON a.code = g.related_code
 ORDER BY g.depth, g.related_code;
 $$ LANGUAGE sql;
+-- +goose StatementEnd
 
 -- ==================== COMMENT Statements ====================
 
@@ -259,3 +267,15 @@ COMMENT ON FUNCTION get_cluster_attributes IS
 
 COMMENT ON FUNCTION find_related_attributes IS
     'Traverse relationship graph to find semantically related attributes (depth-limited)';
+
+-- +goose Down
+DROP FUNCTION IF EXISTS find_related_attributes(TEXT, INT);
+DROP FUNCTION IF EXISTS get_cluster_attributes(TEXT);
+DROP FUNCTION IF EXISTS find_attribute_by_synonym(TEXT);
+DROP VIEW IF EXISTS attribute_knowledge_graph;
+DROP VIEW IF EXISTS cluster_details;
+DROP VIEW IF EXISTS attribute_profile;
+DROP TABLE IF EXISTS kyc_attribute_relationships;
+DROP TABLE IF EXISTS kyc_attribute_clusters;
+DROP TABLE IF EXISTS kyc_attribute_metadata;
+-- The vector extension is left installed; other databases may depend on it
@@ -3,8 +3,18 @@
 -- Feedback Loop for RAG Attribute/Document Relevance
 -- ===========================================================
 
+-- +goose Up
+
 -- Create feedback sentiment enum type
-CREATE TYPE feedback_sentiment AS ENUM ('positive', 'negative', 'neutral');
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'feedback_sentiment') THEN
+        CREATE TYPE feedback_sentiment AS ENUM ('positive', 'negative', 'neutral');
+    END IF;
+END
+$$;
+-- +goose StatementEnd
 
 -- Create feedback table for tracking user and AI agent feedback
 CREATE TABLE IF NOT EXISTS rag_feedback (
@@ -43,15 +53,16 @@ CREATE TABLE IF NOT EXISTS rag_feedback (
 );
 
 -- Create indexes for performance
-CREATE INDEX idx_rag_feedback_query ON rag_feedback(query_text);
-CREATE INDEX idx_rag_feedback_attribute ON rag_feedback(attribute_code);
-CREATE INDEX idx_rag_feedback_document ON rag_feedback(document_code);
-CREATE INDEX idx_rag_feedback_regulation ON rag_feedback(regulation_code);
-CREATE INDEX idx_rag_feedback_created_at ON rag_feedback(created_at DESC);
-CREATE INDEX idx_rag_feedback_agent_type ON rag_feedback(agent_type);
-CREATE INDEX idx_rag_feedback_sentiment ON rag_feedback(feedback);
+CREATE INDEX IF NOT EXISTS idx_rag_feedback_query ON rag_feedback(query_text);
+CREATE INDEX IF NOT EXISTS idx_rag_feedback_attribute ON rag_feedback(attribute_code);
+CREATE INDEX IF NOT EXISTS idx_rag_feedback_document ON rag_feedback(document_code);
+CREATE INDEX IF NOT EXISTS idx_rag_feedback_regulation ON rag_feedback(regulation_code);
+CREATE INDEX IF NOT EXISTS idx_rag_feedback_created_at ON rag_feedback(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_rag_feedback_agent_type ON rag_feedback(agent_type);
+CREATE INDEX IF NOT EXISTS idx_rag_feedback_sentiment ON rag_feedback(feedback);
 
 -- Function to adjust relevance_score in kyc_attr_doc_links
+-- +goose StatementBegin
 CREATE OR REPLACE FUNCTION update_relevance()
 RETURNS trigger AS $$
 BEGIN
@@ -75,8 +86,10 @@ BEGIN
     RETURN NEW;
 END;
 $$ LANGUAGE plpgsql;
+-- +goose StatementEnd
 
 -- Create trigger to automatically adjust relevance scores on feedback insert
+DROP TRIGGER IF EXISTS trig_feedback_relevance ON rag_feedback;
 CREATE TRIGGER trig_feedback_relevance
 AFTER INSERT ON rag_feedback
 FOR EACH ROW
@@ -113,3 +126,11 @@ COMMENT ON COLUMN rag_feedback.query_text IS 'The original search query that pro
 COMMENT ON COLUMN rag_feedback.confidence IS 'Weight factor (0.0-1.0) for how much this feedback should impact relevance scores';
 COMMENT ON COLUMN rag_feedback.agent_type IS 'Type of agent providing feedback: human, ai, or automated';
 COMMENT ON FUNCTION update_relevance() IS 'Automatically adjusts relevance scores in kyc_attr_doc_links based on feedback';
+
+-- +goose Down
+DROP VIEW IF EXISTS attribute_feedback_summary;
+DROP VIEW IF EXISTS rag_feedback_summary;
+DROP TRIGGER IF EXISTS trig_feedback_relevance ON rag_feedback;
+DROP FUNCTION IF EXISTS update_relevance();
+DROP TABLE IF EXISTS rag_feedback;
+DROP TYPE IF EXISTS feedback_sentiment;
@@ -0,0 +1,96 @@
+-- ===========================================================
+-- 008_case_store.sql
+-- Case Store: cases, DSL version snapshots, grammar and amendments
+-- Previously created inline by storage.ConnectPostgres; case_versions
+-- was created by scripts/init_data_service_tables.sql for the data service
+-- ===========================================================
+
+-- +goose Up
+
+CREATE TABLE IF NOT EXISTS kyc_cases (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    version INT DEFAULT 1,
+    status TEXT DEFAULT 'pending',
+    last_updated TIMESTAMP DEFAULT NOW()
+);
+
+-- Immutable DSL snapshots; hash is the SHA-256 of the canonical form
+CREATE TABLE IF NOT EXISTS kyc_case_versions (
+    id SERIAL PRIMARY KEY,
+    case_name TEXT NOT NULL,
+    version INT NOT NULL,
+    dsl_snapshot TEXT,
+    hash TEXT,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_case_versions_name_version
+    ON kyc_case_versions(case_name, version DESC);
+
+CREATE TABLE IF NOT EXISTS kyc_grammar (
+    id SERIAL PRIMARY KEY,
+    name TEXT UNIQUE,
+    version TEXT,
+    ebnf TEXT,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS kyc_case_amendments (
+    id SERIAL PRIMARY KEY,
+    case_name TEXT NOT NULL,
+    step TEXT NOT NULL,
+    change_type TEXT NOT NULL,
+    diff TEXT,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_case_amendments_case
+    ON kyc_case_amendments(case_name);
+
+-- Data service case versions: DSL source with compiled JSON
+CREATE TABLE IF NOT EXISTS case_versions (
+    id SERIAL PRIMARY KEY,
+    case_id VARCHAR(255) NOT NULL,
+    dsl_source TEXT NOT NULL,
+    compiled_json TEXT,
+    status VARCHAR(50) NOT NULL DEFAULT 'draft',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_case_versions_case_id ON case_versions(case_id);
+CREATE INDEX IF NOT EXISTS idx_case_versions_status ON case_versions(status);
+CREATE INDEX IF NOT EXISTS idx_case_versions_created_at ON case_versions(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_case_versions_case_id_created ON case_versions(case_id, created_at DESC);
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION update_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+DROP TRIGGER IF EXISTS update_case_versions_updated_at ON case_versions;
+CREATE TRIGGER update_case_versions_updated_at
+    BEFORE UPDATE ON case_versions
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE kyc_case_versions IS
+    'Append-only DSL snapshots per case; hash identifies the canonical form';
+
+COMMENT ON TABLE case_versions IS
+    'Case versions stored through the data service gRPC API';
+
+-- +goose Down
+DROP TRIGGER IF EXISTS update_case_versions_updated_at ON case_versions;
+DROP TABLE IF EXISTS case_versions;
+DROP TABLE IF EXISTS kyc_case_amendments;
+DROP TABLE IF EXISTS kyc_grammar;
+DROP TABLE IF EXISTS kyc_case_versions;
+DROP TABLE IF EXISTS kyc_cases;
+-- update_updated_at_column() is shared with other tables and kept
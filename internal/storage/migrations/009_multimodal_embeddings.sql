@@ -4,6 +4,8 @@
 -- Enables multi-modal RAG across attributes, documents, and regulations
 -- ===========================================================
 
+-- +goose Up
+
 -- Add embedding columns to existing tables
 ALTER TABLE kyc_documents
 ADD COLUMN IF NOT EXISTS embedding vector(1536),
@@ -96,3 +98,24 @@ ORDER BY a.code, adl.relevance_score DESC NULLS LAST;
 
 COMMENT ON VIEW multimodal_attribute_view IS
     'Combined view of attributes with linked documents and regulations for multi-modal RAG queries';
+
+-- +goose Down
+DROP VIEW IF EXISTS multimodal_attribute_view;
+DROP INDEX IF EXISTS idx_attrdoc_relevance;
+DROP INDEX IF EXISTS idx_regulations_embedding;
+DROP INDEX IF EXISTS idx_documents_embedding;
+
+ALTER TABLE kyc_attr_doc_links
+DROP COLUMN IF EXISTS relevance_score;
+
+ALTER TABLE kyc_regulations
+DROP COLUMN IF EXISTS summary,
+DROP COLUMN IF EXISTS citation,
+DROP COLUMN IF EXISTS region,
+DROP COLUMN IF EXISTS title,
+DROP COLUMN IF EXISTS embedding;
+
+ALTER TABLE kyc_documents
+DROP COLUMN IF EXISTS title,
+DROP COLUMN IF EXISTS doc_type,
+DROP COLUMN IF EXISTS embedding;
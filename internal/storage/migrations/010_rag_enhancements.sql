@@ -3,10 +3,20 @@
 -- RAG System Enhancements: Feedback Loop, Sections, Clusters, Audit
 -- ===========================================================
 
+-- +goose Up
+
 -- ==================== Enhancement A: Feedback Loop ====================
 -- Self-tuning RAG system with structured agent feedback
 
-CREATE TYPE feedback_type AS ENUM ('positive', 'negative');
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'feedback_type') THEN
+        CREATE TYPE feedback_type AS ENUM ('positive', 'negative');
+    END IF;
+END
+$$;
+-- +goose StatementEnd
 
 CREATE TABLE IF NOT EXISTS rag_feedback (
     id SERIAL PRIMARY KEY,
@@ -22,6 +32,12 @@ CREATE TABLE IF NOT EXISTS rag_feedback (
     created_at TIMESTAMP DEFAULT NOW()
 );
 
+-- rag_feedback may already exist from 007 without the agent session columns
+ALTER TABLE rag_feedback
+ADD COLUMN IF NOT EXISTS session_id TEXT,
+ADD COLUMN IF NOT EXISTS relevance_score FLOAT,
+ADD COLUMN IF NOT EXISTS notes TEXT;
+
 -- Indexes for feedback queries
 CREATE INDEX IF NOT EXISTS idx_feedback_query ON rag_feedback(query_text);
 CREATE INDEX IF NOT EXISTS idx_feedback_attribute ON rag_feedback(attribute_code);
@@ -29,6 +45,7 @@ CREATE INDEX IF NOT EXISTS idx_feedback_agent ON rag_feedback(agent_name);
 CREATE INDEX IF NOT EXISTS idx_feedback_created ON rag_feedback(created_at DESC);
 
 -- Trigger function to automatically update relevance scores based on feedback
+-- +goose StatementBegin
 CREATE OR REPLACE FUNCTION update_relevance_from_feedback()
 RETURNS trigger AS $$
 BEGIN
@@ -47,8 +64,10 @@ BEGIN
     RETURN NEW;
 END;
 $$ LANGUAGE plpgsql;
+-- +goose StatementEnd
 
--- Trigger to fire after feedback insertion
+-- Trigger to fire after feedback insertion (replaces the 007 trigger)
+DROP TRIGGER IF EXISTS trig_feedback_relevance ON rag_feedback;
 CREATE TRIGGER trig_feedback_relevance
 AFTER INSERT ON rag_feedback
 FOR EACH ROW
@@ -231,8 +250,9 @@ WHERE agent_name IS NOT NULL
 GROUP BY agent_name
 ORDER BY total_queries DESC;
 
--- View: Cluster membership details
-CREATE OR REPLACE VIEW cluster_details AS
+-- View: Cluster membership details (replaces the 006 per-cluster view)
+DROP VIEW IF EXISTS cluster_details;
+CREATE VIEW cluster_details AS
 SELECT
     c.cluster_code,
     c.cluster_name,
@@ -271,6 +291,7 @@ ORDER BY d.code, s.section_number;
 -- ==================== Functions ====================
 
 -- Function: Record audit log entry
+-- +goose StatementBegin
 CREATE OR REPLACE FUNCTION log_rag_query(
     p_query TEXT,
     p_response JSONB,
@@ -295,11 +316,13 @@ BEGIN
     RETURN v_log_id;
 END;
 $$ LANGUAGE plpgsql;
+-- +goose StatementEnd
 
 COMMENT ON FUNCTION log_rag_query IS
     'Convenience function to log RAG queries from application code';
 
 -- Function: Get cluster recommendations for a query embedding
+-- +goose StatementBegin
 CREATE OR REPLACE FUNCTION recommend_clusters(
     p_embedding vector(1536),
     p_limit INT DEFAULT 3
@@ -322,11 +345,13 @@ BEGIN
     LIMIT p_limit;
 END;
 $$ LANGUAGE plpgsql;
+-- +goose StatementEnd
 
 COMMENT ON FUNCTION recommend_clusters IS
     'Find the most relevant clusters for a given query embedding';
 
 -- Function: Search document sections by vector
+-- +goose StatementBegin
 CREATE OR REPLACE FUNCTION search_document_sections(
     p_embedding vector(1536),
     p_limit INT DEFAULT 10
@@ -351,6 +376,7 @@ BEGIN
     LIMIT p_limit;
 END;
 $$ LANGUAGE plpgsql;
+-- +goose StatementEnd
 
 COMMENT ON FUNCTION search_document_sections IS
     'Semantic search on document sections for fine-grained retrieval';
@@ -379,6 +405,7 @@ ON CONFLICT (cluster_code) DO NOTHING;
 -- ==================== Maintenance Functions ====================
 
 -- Function: Clean old audit logs (retention policy)
+-- +goose StatementBegin
 CREATE OR REPLACE FUNCTION cleanup_old_audit_logs(
     p_retention_days INT DEFAULT 90
 ) RETURNS INT AS $$
@@ -393,6 +420,7 @@ BEGIN
     RETURN v_deleted_count;
 END;
 $$ LANGUAGE plpgsql;
+-- +goose StatementEnd
 
 COMMENT ON FUNCTION cleanup_old_audit_logs IS
     'Delete audit logs older than specified days (default 90, keeps errors)';
@@ -405,3 +433,48 @@ COMMENT ON FUNCTION cleanup_old_audit_logs IS
 -- GRANT SELECT ON agent_performance TO app_user;
 -- GRANT INSERT ON rag_feedback TO app_user;
 -- GRANT INSERT ON rag_audit_log TO app_user;
+
+-- +goose Down
+DROP FUNCTION IF EXISTS cleanup_old_audit_logs(INT);
+DROP FUNCTION IF EXISTS search_document_sections(vector, INT);
+DROP FUNCTION IF EXISTS recommend_clusters(vector, INT);
+DROP FUNCTION IF EXISTS log_rag_query(TEXT, JSONB, TEXT, INT, TEXT);
+DROP VIEW IF EXISTS document_section_context;
+DROP VIEW IF EXISTS cluster_details;
+DROP VIEW IF EXISTS agent_performance;
+DROP VIEW IF EXISTS popular_queries;
+DROP VIEW IF EXISTS feedback_stats_by_attribute;
+DROP TABLE IF EXISTS rag_audit_log;
+DROP TABLE IF EXISTS rag_clusters;
+DROP TABLE IF EXISTS kyc_document_sections;
+
+-- Hand rag_feedback back to the 007 trigger and drop the agent session columns
+DROP INDEX IF EXISTS idx_feedback_composite;
+DROP INDEX IF EXISTS idx_feedback_created;
+DROP INDEX IF EXISTS idx_feedback_agent;
+DROP INDEX IF EXISTS idx_feedback_attribute;
+DROP INDEX IF EXISTS idx_feedback_query;
+DROP TRIGGER IF EXISTS trig_feedback_relevance ON rag_feedback;
+DROP FUNCTION IF EXISTS update_relevance_from_feedback();
+CREATE TRIGGER trig_feedback_relevance
+AFTER INSERT ON rag_feedback
+FOR EACH ROW
+EXECUTE FUNCTION update_relevance();
+ALTER TABLE rag_feedback
+DROP COLUMN IF EXISTS notes,
+DROP COLUMN IF EXISTS relevance_score,
+DROP COLUMN IF EXISTS session_id;
+DROP TYPE IF EXISTS feedback_type;
+
+-- Restore the 006 per-cluster view
+CREATE VIEW cluster_details AS
+SELECT
+    c.cluster_code,
+    c.cluster_name,
+    c.description as cluster_description,
+    c.use_case,
+    c.priority,
+    c.attribute_codes,
+    (SELECT COUNT(*) FROM unnest(c.attribute_codes)) as attribute_count
+FROM kyc_attribute_clusters c
+ORDER BY c.priority, c.cluster_name;
@@ -0,0 +1,51 @@
+-- ===========================================================
+-- 011_shadow_divergences.sql
+-- Shadow (canary) execution: divergences between the live
+-- ranking/rule version and a candidate version run alongside it
+-- ===========================================================
+
+-- +goose Up
+
+CREATE TABLE IF NOT EXISTS shadow_divergences (
+    id SERIAL PRIMARY KEY,
+    experiment TEXT NOT NULL,
+    kind TEXT NOT NULL CHECK (kind IN ('ranking', 'rule')),
+    subject TEXT NOT NULL,
+    primary_version TEXT NOT NULL,
+    candidate_version TEXT NOT NULL,
+    primary_result JSONB,
+    candidate_result JSONB,
+    divergence_score FLOAT NOT NULL DEFAULT 0,
+    details JSONB,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_shadow_experiment ON shadow_divergences(experiment, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_shadow_kind ON shadow_divergences(kind);
+CREATE INDEX IF NOT EXISTS idx_shadow_score ON shadow_divergences(divergence_score DESC);
+
+-- Per-experiment rollup used by risk owners before promoting a candidate
+CREATE OR REPLACE VIEW shadow_experiment_summary AS
+SELECT
+    experiment,
+    kind,
+    primary_version,
+    candidate_version,
+    COUNT(*) AS divergence_count,
+    ROUND(AVG(divergence_score)::numeric, 4) AS avg_divergence,
+    ROUND(MAX(divergence_score)::numeric, 4) AS max_divergence,
+    MIN(created_at) AS first_seen,
+    MAX(created_at) AS last_seen
+FROM shadow_divergences
+GROUP BY experiment, kind, primary_version, candidate_version
+ORDER BY last_seen DESC;
+
+COMMENT ON TABLE shadow_divergences IS
+    'Divergences observed when a candidate ranking or rule version is shadow-executed against live traffic';
+
+COMMENT ON COLUMN shadow_divergences.divergence_score IS
+    '0 = identical behaviour, 1 = completely different (rank overlap or share of changed rule outcomes)';
+
+-- +goose Down
+DROP VIEW IF EXISTS shadow_experiment_summary;
+DROP TABLE IF EXISTS shadow_divergences;
@@ -0,0 +1,65 @@
+-- ===========================================================
+-- 012_metadata_review.sql
+-- Bulk review feed for attribute metadata changes
+-- Pending changes from LLM enrichment or imports are reviewed
+-- field by field before being applied to kyc_attribute_metadata
+-- ===========================================================
+
+-- +goose Up
+
+CREATE TABLE IF NOT EXISTS kyc_metadata_proposals (
+    id SERIAL PRIMARY KEY,
+    batch_id TEXT NOT NULL,
+    attribute_code TEXT NOT NULL REFERENCES kyc_attributes(code) ON DELETE CASCADE,
+    source TEXT NOT NULL CHECK (source IN ('llm_enrichment', 'import', 'manual')),
+    proposed JSONB NOT NULL,            -- field name -> proposed value
+    status TEXT NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'applied', 'partially_applied', 'rejected')),
+    submitted_by TEXT,
+    created_at TIMESTAMP DEFAULT NOW(),
+    reviewed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_metadata_proposals_status ON kyc_metadata_proposals(status, created_at);
+CREATE INDEX IF NOT EXISTS idx_metadata_proposals_batch ON kyc_metadata_proposals(batch_id);
+CREATE INDEX IF NOT EXISTS idx_metadata_proposals_attr ON kyc_metadata_proposals(attribute_code);
+
+CREATE TABLE IF NOT EXISTS kyc_metadata_field_decisions (
+    id SERIAL PRIMARY KEY,
+    proposal_id INT NOT NULL REFERENCES kyc_metadata_proposals(id) ON DELETE CASCADE,
+    field_name TEXT NOT NULL,
+    old_value JSONB,
+    new_value JSONB,
+    decision TEXT NOT NULL CHECK (decision IN ('accepted', 'rejected')),
+    reviewer TEXT NOT NULL,
+    comment TEXT,
+    decided_at TIMESTAMP DEFAULT NOW(),
+    UNIQUE (proposal_id, field_name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_metadata_decisions_reviewer ON kyc_metadata_field_decisions(reviewer);
+
+-- Review progress per batch
+CREATE OR REPLACE VIEW metadata_review_batches AS
+SELECT
+    batch_id,
+    source,
+    COUNT(*) AS proposal_count,
+    COUNT(*) FILTER (WHERE status = 'pending') AS pending_count,
+    COUNT(*) FILTER (WHERE status IN ('applied', 'partially_applied')) AS applied_count,
+    COUNT(*) FILTER (WHERE status = 'rejected') AS rejected_count,
+    MIN(created_at) AS submitted_at
+FROM kyc_metadata_proposals
+GROUP BY batch_id, source
+ORDER BY submitted_at DESC;
+
+COMMENT ON TABLE kyc_metadata_proposals IS
+    'Pending attribute metadata changes awaiting field-level reviewer approval';
+
+COMMENT ON TABLE kyc_metadata_field_decisions IS
+    'Reviewer accept/reject decisions per metadata field, with before/after values';
+
+-- +goose Down
+DROP VIEW IF EXISTS metadata_review_batches;
+DROP TABLE IF EXISTS kyc_metadata_field_decisions;
+DROP TABLE IF EXISTS kyc_metadata_proposals;
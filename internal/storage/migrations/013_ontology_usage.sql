@@ -0,0 +1,91 @@
+-- ===========================================================
+-- 013_ontology_usage.sql
+-- Ontology term usage analytics: which attributes, documents and
+-- regulations are referenced by cases and returned to agents
+-- ===========================================================
+
+-- +goose Up
+
+-- Terms returned by RAG search endpoints (one row per term per response)
+CREATE TABLE IF NOT EXISTS kyc_ontology_search_hits (
+    id SERIAL PRIMARY KEY,
+    term_type TEXT NOT NULL CHECK (term_type IN ('attribute', 'document', 'regulation')),
+    term_code TEXT NOT NULL,
+    endpoint TEXT NOT NULL,
+    agent_name TEXT,
+    query_text TEXT,
+    rank INT,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_search_hits_term ON kyc_ontology_search_hits(term_type, term_code);
+CREATE INDEX IF NOT EXISTS idx_search_hits_created ON kyc_ontology_search_hits(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_search_hits_agent ON kyc_ontology_search_hits(agent_name);
+
+-- Terms referenced by the latest DSL version of each case
+CREATE OR REPLACE VIEW ontology_case_references AS
+WITH latest AS (
+    SELECT DISTINCT ON (case_name) case_name, version, dsl_snapshot
+    FROM kyc_case_versions
+    ORDER BY case_name, version DESC
+)
+SELECT DISTINCT case_name, version, 'attribute' AS term_type, m[1] AS term_code
+FROM latest, regexp_matches(dsl_snapshot, '\(attribute\s+([A-Za-z0-9_\-]+)', 'g') AS m
+UNION
+SELECT DISTINCT case_name, version, 'document' AS term_type, m[1] AS term_code
+FROM latest, regexp_matches(dsl_snapshot, '\(document\s+([A-Za-z0-9_\-]+)', 'g') AS m
+UNION
+SELECT DISTINCT case_name, version, 'regulation' AS term_type, m[1] AS term_code
+FROM latest, regexp_matches(dsl_snapshot, '\(regulation\s+([A-Za-z0-9_\-]+)', 'g') AS m;
+
+-- Per-term usage across the whole ontology, including never-used terms
+CREATE OR REPLACE VIEW ontology_usage_report AS
+WITH terms AS (
+    SELECT 'attribute' AS term_type, code AS term_code, name FROM kyc_attributes
+    UNION ALL
+    SELECT 'document', code, name FROM kyc_documents
+    UNION ALL
+    SELECT 'regulation', code, name FROM kyc_regulations
+),
+case_refs AS (
+    SELECT term_type, term_code, COUNT(DISTINCT case_name) AS case_count
+    FROM ontology_case_references
+    GROUP BY term_type, term_code
+),
+search_refs AS (
+    SELECT
+        term_type,
+        term_code,
+        COUNT(*) AS search_hits,
+        COUNT(*) FILTER (WHERE created_at >= NOW() - INTERVAL '30 days') AS search_hits_30d,
+        COUNT(DISTINCT agent_name) AS distinct_agents,
+        MAX(created_at) AS last_searched
+    FROM kyc_ontology_search_hits
+    GROUP BY term_type, term_code
+)
+SELECT
+    t.term_type,
+    t.term_code,
+    t.name,
+    COALESCE(c.case_count, 0) AS case_count,
+    COALESCE(s.search_hits, 0) AS search_hits,
+    COALESCE(s.search_hits_30d, 0) AS search_hits_30d,
+    COALESCE(s.distinct_agents, 0) AS distinct_agents,
+    s.last_searched,
+    m.updated_at AS metadata_updated_at,
+    (t.term_type = 'attribute' AND m.embedding IS NULL) AS missing_embedding
+FROM terms t
+LEFT JOIN case_refs c ON c.term_type = t.term_type AND c.term_code = t.term_code
+LEFT JOIN search_refs s ON s.term_type = t.term_type AND s.term_code = t.term_code
+LEFT JOIN kyc_attribute_metadata m ON t.term_type = 'attribute' AND m.attribute_code = t.term_code;
+
+COMMENT ON TABLE kyc_ontology_search_hits IS
+    'Ontology terms returned to agents by RAG search endpoints, used for usage analytics';
+
+COMMENT ON VIEW ontology_usage_report IS
+    'Case references and search hits per ontology term; zero in both marks a dead entry';
+
+-- +goose Down
+DROP VIEW IF EXISTS ontology_usage_report;
+DROP VIEW IF EXISTS ontology_case_references;
+DROP TABLE IF EXISTS kyc_ontology_search_hits;
@@ -0,0 +1,31 @@
+-- ===========================================================
+-- 014_embedding_backfill.sql
+-- Checkpoints for resumable embedding backfills: the last code
+-- processed per job plus token and spend totals so far
+-- ===========================================================
+
+-- +goose Up
+
+CREATE TABLE IF NOT EXISTS kyc_embedding_backfills (
+    job TEXT PRIMARY KEY,               -- e.g. "attributes:text-embedding-3-large"
+    kind TEXT NOT NULL CHECK (kind IN ('attributes', 'documents', 'regulations')),
+    model TEXT NOT NULL,
+    last_code TEXT,                     -- last code embedded; resumed runs start after it
+    processed INT NOT NULL DEFAULT 0,
+    failed INT NOT NULL DEFAULT 0,
+    tokens BIGINT NOT NULL DEFAULT 0,
+    spend_usd NUMERIC(12, 6) NOT NULL DEFAULT 0,
+    status TEXT NOT NULL DEFAULT 'running'
+        CHECK (status IN ('running', 'paused', 'completed')),
+    started_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+COMMENT ON TABLE kyc_embedding_backfills IS
+    'Progress of embedding backfill jobs so an interrupted run resumes after last_code';
+
+COMMENT ON COLUMN kyc_embedding_backfills.spend_usd IS
+    'Estimated OpenAI spend from reported token usage at list price';
+
+-- +goose Down
+DROP TABLE IF EXISTS kyc_embedding_backfills;
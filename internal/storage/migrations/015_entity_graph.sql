@@ -0,0 +1,179 @@
+-- ===========================================================
+-- 015_entity_graph.sql
+-- Legal entities, Client Business Units and ownership/control
+-- edges backing CbuGraphService and OntologyService. Mirrors
+-- the core of scripts/kyc_ontology.sql so `kycctl migrate up`
+-- is enough to serve real graphs.
+-- ===========================================================
+
+-- +goose Up
+
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TABLE IF NOT EXISTS entity (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name TEXT NOT NULL,
+    entity_type TEXT NOT NULL,               -- COMPANY, FUND, PERSON, PARTNERSHIP, TRUST
+    legal_form TEXT,                         -- LLC, PLC, LP, GP, etc.
+    jurisdiction TEXT,                       -- US, UK, LU, IE, etc.
+    registration_number TEXT,                -- Company registration / tax number
+    lei_code TEXT UNIQUE,                    -- Legal Entity Identifier
+    incorporation_date DATE,
+    dissolution_date DATE,
+    status TEXT DEFAULT 'ACTIVE',            -- ACTIVE, INACTIVE, DISSOLVED, SUSPENDED
+    description TEXT,
+    metadata JSONB,                          -- Additional flexible data (layout hints x/y)
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now(),
+    CONSTRAINT entity_type_check CHECK (entity_type IN ('COMPANY', 'FUND', 'PERSON', 'PARTNERSHIP', 'TRUST', 'OTHER'))
+);
+
+CREATE TABLE IF NOT EXISTS cbu (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name TEXT NOT NULL,
+    code TEXT UNIQUE,                        -- e.g. BLACKROCK-GLOBAL
+    sponsor_entity_id UUID REFERENCES entity(id),
+    domicile TEXT,
+    description TEXT,
+    status TEXT DEFAULT 'ACTIVE',
+    metadata JSONB,
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS role_type (
+    id SERIAL PRIMARY KEY,
+    code TEXT UNIQUE NOT NULL,               -- MANCO, FUND, DEPOSITARY, INVESTMENT_MANAGER, TRUSTEE
+    name TEXT NOT NULL,
+    description TEXT,
+    category TEXT,                           -- SERVICE_PROVIDER, REGULATORY, OPERATIONAL
+    created_at TIMESTAMP DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS cbu_role (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    cbu_id UUID NOT NULL REFERENCES cbu(id) ON DELETE CASCADE,
+    entity_id UUID NOT NULL REFERENCES entity(id) ON DELETE CASCADE,
+    role_type_id INT NOT NULL REFERENCES role_type(id),
+    start_date DATE NOT NULL DEFAULT CURRENT_DATE,
+    end_date DATE,
+    jurisdiction TEXT,
+    is_primary BOOLEAN DEFAULT false,        -- primary role of the entity in this CBU
+    status TEXT DEFAULT 'ACTIVE',
+    metadata JSONB,
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now(),
+    CONSTRAINT cbu_role_dates_check CHECK (end_date IS NULL OR end_date >= start_date)
+);
+
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'control_type') THEN
+        CREATE TYPE control_type AS ENUM (
+            'LEGAL_OWNERSHIP',
+            'BENEFICIAL_OWNERSHIP',
+            'OPERATIONAL_CONTROL',
+            'VOTING_CONTROL',
+            'MANAGEMENT_CONTROL',
+            'ECONOMIC_INTEREST'
+        );
+    END IF;
+END
+$$;
+-- +goose StatementEnd
+
+CREATE TABLE IF NOT EXISTS entity_control (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    controller_entity_id UUID NOT NULL REFERENCES entity(id) ON DELETE CASCADE,
+    controlled_entity_id UUID NOT NULL REFERENCES entity(id) ON DELETE CASCADE,
+    control_type control_type NOT NULL,
+    control_basis TEXT,                      -- e.g. "Direct shareholding", "delegates"
+    control_percentage NUMERIC(5,2),         -- 0.00 to 100.00
+    effective_percentage NUMERIC(5,2),       -- effective control after indirect holdings
+    start_date DATE NOT NULL DEFAULT CURRENT_DATE,
+    end_date DATE,
+    is_indirect BOOLEAN DEFAULT false,
+    indirect_via_entity_id UUID REFERENCES entity(id),
+    remarks TEXT,
+    source_document TEXT,
+    verified_at TIMESTAMP,
+    verified_by TEXT,
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now(),
+    CONSTRAINT entity_control_not_self CHECK (controller_entity_id != controlled_entity_id),
+    CONSTRAINT entity_control_dates_check CHECK (end_date IS NULL OR end_date >= start_date),
+    CONSTRAINT control_percentage_check CHECK (control_percentage IS NULL OR (control_percentage >= 0 AND control_percentage <= 100)),
+    CONSTRAINT effective_percentage_check CHECK (effective_percentage IS NULL OR (effective_percentage >= 0 AND effective_percentage <= 100))
+);
+
+CREATE TABLE IF NOT EXISTS entity_kyc_profile (
+    entity_id UUID PRIMARY KEY REFERENCES entity(id) ON DELETE CASCADE,
+    risk_rating TEXT,                        -- LOW, MEDIUM, HIGH, CRITICAL
+    kyc_status TEXT DEFAULT 'PENDING',       -- PENDING, IN_PROGRESS, APPROVED, REJECTED, EXPIRED
+    last_review_date DATE,
+    next_review_date DATE,
+    policy_id UUID,
+    kyc_token TEXT,
+    sanctions_check_status TEXT,
+    pep_status BOOLEAN DEFAULT false,
+    adverse_media_status TEXT,
+    remarks TEXT,
+    metadata JSONB,
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_entity_status ON entity(status);
+CREATE INDEX IF NOT EXISTS idx_entity_lei_code ON entity(lei_code);
+CREATE INDEX IF NOT EXISTS idx_cbu_code ON cbu(code);
+CREATE INDEX IF NOT EXISTS idx_cbu_sponsor ON cbu(sponsor_entity_id);
+CREATE INDEX IF NOT EXISTS idx_cbu_role_cbu ON cbu_role(cbu_id);
+CREATE INDEX IF NOT EXISTS idx_cbu_role_entity ON cbu_role(entity_id);
+CREATE INDEX IF NOT EXISTS idx_cbu_role_type ON cbu_role(role_type_id);
+CREATE INDEX IF NOT EXISTS idx_entity_control_controller ON entity_control(controller_entity_id);
+CREATE INDEX IF NOT EXISTS idx_entity_control_controlled ON entity_control(controlled_entity_id);
+CREATE INDEX IF NOT EXISTS idx_entity_control_type ON entity_control(control_type);
+
+-- update_updated_at_column() is created by 008_case_store
+DROP TRIGGER IF EXISTS update_entity_updated_at ON entity;
+CREATE TRIGGER update_entity_updated_at BEFORE UPDATE ON entity
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_cbu_updated_at ON cbu;
+CREATE TRIGGER update_cbu_updated_at BEFORE UPDATE ON cbu
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_cbu_role_updated_at ON cbu_role;
+CREATE TRIGGER update_cbu_role_updated_at BEFORE UPDATE ON cbu_role
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_entity_control_updated_at ON entity_control;
+CREATE TRIGGER update_entity_control_updated_at BEFORE UPDATE ON entity_control
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_entity_kyc_profile_updated_at ON entity_kyc_profile;
+CREATE TRIGGER update_entity_kyc_profile_updated_at BEFORE UPDATE ON entity_kyc_profile
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+INSERT INTO role_type (code, name, description, category) VALUES
+    ('MANCO', 'Management Company', 'Entity providing management services', 'SERVICE_PROVIDER'),
+    ('FUND', 'Fund', 'Investment fund entity', 'OPERATIONAL'),
+    ('DEPOSITARY', 'Depositary', 'Entity holding fund assets', 'SERVICE_PROVIDER'),
+    ('INVESTMENT_MANAGER', 'Investment Manager', 'Entity managing investments', 'SERVICE_PROVIDER'),
+    ('TRUSTEE', 'Trustee', 'Trust management entity', 'SERVICE_PROVIDER'),
+    ('CUSTODIAN', 'Custodian', 'Asset custody provider', 'SERVICE_PROVIDER'),
+    ('ADMINISTRATOR', 'Administrator', 'Fund administration provider', 'SERVICE_PROVIDER'),
+    ('AUDITOR', 'Auditor', 'External auditor', 'REGULATORY'),
+    ('LEGAL_COUNSEL', 'Legal Counsel', 'Legal advisory services', 'REGULATORY'),
+    ('PARENT', 'Parent', 'Ultimate parent or sponsor of the CBU', 'OPERATIONAL')
+ON CONFLICT (code) DO NOTHING;
+
+-- +goose Down
+DROP TABLE IF EXISTS entity_kyc_profile;
+DROP TABLE IF EXISTS entity_control;
+DROP TYPE IF EXISTS control_type;
+DROP TABLE IF EXISTS cbu_role;
+DROP TABLE IF EXISTS role_type;
+DROP TABLE IF EXISTS cbu;
+DROP TABLE IF EXISTS entity;
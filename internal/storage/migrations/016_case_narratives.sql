@@ -0,0 +1,33 @@
+-- ===========================================================
+-- 016_case_narratives.sql
+-- Due-diligence narratives for review committees, stored with
+-- the case version they describe together with the facts and
+-- template they were rendered from
+-- ===========================================================
+
+-- +goose Up
+
+CREATE TABLE IF NOT EXISTS case_narratives (
+    id SERIAL PRIMARY KEY,
+    case_id VARCHAR(255) NOT NULL,
+    case_version_id INT NOT NULL REFERENCES case_versions(id) ON DELETE CASCADE,
+    template TEXT NOT NULL,             -- e.g. "committee", "brief"
+    draft TEXT NOT NULL,                -- rendered template output
+    narrative TEXT NOT NULL,            -- draft, or the LLM-polished text
+    polished BOOLEAN NOT NULL DEFAULT false,
+    model TEXT,                         -- chat model used for polishing
+    facts JSONB NOT NULL,               -- structured input the draft was rendered from
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_case_narratives_case
+    ON case_narratives(case_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_case_narratives_version
+    ON case_narratives(case_version_id);
+
+COMMENT ON COLUMN case_narratives.draft IS
+    'Template output before polishing; the reviewable source of every statement in narrative';
+
+-- +goose Down
+DROP TABLE IF EXISTS case_narratives;
@@ -0,0 +1,92 @@
+-- ===========================================================
+-- 017_watchlist.sql
+-- Entities pinned by analysts for heightened monitoring. The
+-- dataserver scheduler re-screens due entries (and optionally
+-- their UBOs) against screening lists, records each hit once
+-- and raises an alert when a new hit appears.
+-- ===========================================================
+
+-- +goose Up
+
+-- Local screening lists (sanctions designations, curated adverse media)
+CREATE TABLE IF NOT EXISTS screening_list_entries (
+    id SERIAL PRIMARY KEY,
+    list_name TEXT NOT NULL,                 -- e.g. OFAC-SDN, EU-CONSOLIDATED, MEDIA-WATCH
+    kind TEXT NOT NULL,                      -- sanctions, pep, adverse_media
+    reference TEXT NOT NULL,                 -- identifier within the list
+    name TEXT NOT NULL,
+    aliases TEXT[] NOT NULL DEFAULT '{}',
+    entity_type TEXT,                        -- PERSON, COMPANY, ...
+    country TEXT,
+    detail TEXT,                             -- programme, article summary, source URL
+    listed_at DATE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT screening_list_kind_check CHECK (kind IN ('sanctions', 'pep', 'adverse_media')),
+    UNIQUE (list_name, reference)
+);
+
+CREATE INDEX IF NOT EXISTS idx_screening_list_entries_kind ON screening_list_entries(kind);
+
+CREATE TABLE IF NOT EXISTS watchlist_entries (
+    id SERIAL PRIMARY KEY,
+    entity_id UUID NOT NULL REFERENCES entity(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL,
+    pinned_by TEXT,
+    cadence_minutes INT NOT NULL DEFAULT 1440,
+    include_ubos BOOLEAN NOT NULL DEFAULT false,   -- also screen the entity's beneficial owners
+    active BOOLEAN NOT NULL DEFAULT true,
+    last_screened_at TIMESTAMP,
+    last_error TEXT,
+    next_screening_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT watchlist_cadence_check CHECK (cadence_minutes > 0)
+);
+
+-- One active pin per entity; unpinned entries are kept for audit
+CREATE UNIQUE INDEX IF NOT EXISTS idx_watchlist_entries_active_entity
+    ON watchlist_entries(entity_id) WHERE active;
+
+CREATE INDEX IF NOT EXISTS idx_watchlist_entries_due
+    ON watchlist_entries(next_screening_at) WHERE active;
+
+CREATE TABLE IF NOT EXISTS watchlist_hits (
+    id SERIAL PRIMARY KEY,
+    watchlist_id INT NOT NULL REFERENCES watchlist_entries(id) ON DELETE CASCADE,
+    subject_entity_id UUID NOT NULL REFERENCES entity(id) ON DELETE CASCADE,
+    subject_name TEXT NOT NULL,
+    provider TEXT NOT NULL,                  -- screening provider that reported the hit
+    kind TEXT NOT NULL,                      -- sanctions, pep, adverse_media
+    reference TEXT NOT NULL,                 -- provider's identifier for the matched record
+    matched_name TEXT NOT NULL,
+    score NUMERIC(5,4) NOT NULL,             -- name similarity 0..1
+    detail TEXT,
+    first_seen_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (watchlist_id, subject_entity_id, provider, reference)
+);
+
+CREATE TABLE IF NOT EXISTS watchlist_alerts (
+    id SERIAL PRIMARY KEY,
+    watchlist_id INT NOT NULL REFERENCES watchlist_entries(id) ON DELETE CASCADE,
+    hit_id INT NOT NULL REFERENCES watchlist_hits(id) ON DELETE CASCADE,
+    entity_id UUID NOT NULL REFERENCES entity(id) ON DELETE CASCADE,
+    message TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'OPEN',     -- OPEN, ACKNOWLEDGED, DISMISSED
+    resolved_by TEXT,
+    resolved_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT watchlist_alert_status_check CHECK (status IN ('OPEN', 'ACKNOWLEDGED', 'DISMISSED'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_watchlist_alerts_open
+    ON watchlist_alerts(created_at DESC) WHERE status = 'OPEN';
+
+CREATE INDEX IF NOT EXISTS idx_watchlist_alerts_entity ON watchlist_alerts(entity_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS watchlist_alerts;
+DROP TABLE IF EXISTS watchlist_hits;
+DROP TABLE IF EXISTS watchlist_entries;
+DROP TABLE IF EXISTS screening_list_entries;
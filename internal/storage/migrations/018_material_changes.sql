@@ -0,0 +1,166 @@
+-- ===========================================================
+-- 018_material_changes.sql
+-- Event-driven review: database triggers log changes to the
+-- entity graph (ownership stakes, jurisdictions, directors)
+-- and adverse media alerts into entity_change_log. dataserver
+-- runs detectors over the log and opens a review amendment on
+-- every affected case, keeping the evidence in
+-- material_change_events.
+-- ===========================================================
+
+-- +goose Up
+
+INSERT INTO role_type (code, name, description, category) VALUES
+    ('DIRECTOR', 'Director', 'Board director or senior officer', 'GOVERNANCE')
+ON CONFLICT (code) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS entity_change_log (
+    id BIGSERIAL PRIMARY KEY,
+    kind TEXT NOT NULL,                      -- ownership, jurisdiction, director, adverse_media
+    entity_id UUID NOT NULL REFERENCES entity(id) ON DELETE CASCADE,
+    related_entity_id UUID REFERENCES entity(id) ON DELETE SET NULL,
+    old_value TEXT,
+    new_value TEXT,
+    details JSONB NOT NULL DEFAULT '{}',
+    changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    processed_at TIMESTAMP,
+    material BOOLEAN,                        -- set by the detectors when processed
+    CONSTRAINT entity_change_kind_check CHECK (kind IN ('ownership', 'jurisdiction', 'director', 'adverse_media'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_entity_change_log_pending
+    ON entity_change_log(id) WHERE processed_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_entity_change_log_entity ON entity_change_log(entity_id, changed_at DESC);
+
+CREATE TABLE IF NOT EXISTS material_change_events (
+    id SERIAL PRIMARY KEY,
+    change_id BIGINT NOT NULL REFERENCES entity_change_log(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL,
+    entity_id UUID NOT NULL REFERENCES entity(id) ON DELETE CASCADE,
+    case_id VARCHAR(255) NOT NULL,
+    summary TEXT NOT NULL,
+    evidence JSONB NOT NULL,
+    amendment_id INT REFERENCES kyc_case_amendments(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (change_id, case_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_material_change_events_case
+    ON material_change_events(case_id, created_at DESC);
+
+-- Ownership stakes: new, resized or ended; management control counts as a
+-- director appointment
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION log_entity_control_change()
+RETURNS TRIGGER AS $$
+DECLARE
+    old_pct NUMERIC := 0;
+    new_pct NUMERIC := COALESCE(NEW.control_percentage, 0);
+BEGIN
+    IF TG_OP = 'UPDATE' THEN
+        old_pct := COALESCE(OLD.control_percentage, 0);
+        IF OLD.end_date IS NULL AND NEW.end_date IS NOT NULL AND NEW.end_date <= CURRENT_DATE THEN
+            new_pct := 0;
+        END IF;
+    END IF;
+
+    IF NEW.control_type IN ('LEGAL_OWNERSHIP', 'BENEFICIAL_OWNERSHIP', 'ECONOMIC_INTEREST')
+       AND old_pct IS DISTINCT FROM new_pct THEN
+        INSERT INTO entity_change_log (kind, entity_id, related_entity_id, old_value, new_value, details)
+        VALUES ('ownership', NEW.controlled_entity_id, NEW.controller_entity_id, old_pct::text, new_pct::text,
+                jsonb_build_object('control_id', NEW.id, 'control_type', NEW.control_type::text,
+                                   'operation', TG_OP, 'source_document', NEW.source_document));
+    END IF;
+
+    IF TG_OP = 'INSERT' AND NEW.control_type = 'MANAGEMENT_CONTROL' THEN
+        INSERT INTO entity_change_log (kind, entity_id, related_entity_id, new_value, details)
+        VALUES ('director', NEW.controlled_entity_id, NEW.controller_entity_id, 'MANAGEMENT_CONTROL',
+                jsonb_build_object('control_id', NEW.id, 'control_basis', NEW.control_basis));
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+DROP TRIGGER IF EXISTS entity_control_change_log ON entity_control;
+CREATE TRIGGER entity_control_change_log
+    AFTER INSERT OR UPDATE OF control_percentage, end_date ON entity_control
+    FOR EACH ROW
+    EXECUTE FUNCTION log_entity_control_change();
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION log_entity_jurisdiction_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO entity_change_log (kind, entity_id, old_value, new_value)
+    VALUES ('jurisdiction', NEW.id, OLD.jurisdiction, NEW.jurisdiction);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+DROP TRIGGER IF EXISTS entity_jurisdiction_change_log ON entity;
+CREATE TRIGGER entity_jurisdiction_change_log
+    AFTER UPDATE OF jurisdiction ON entity
+    FOR EACH ROW
+    WHEN (OLD.jurisdiction IS DISTINCT FROM NEW.jurisdiction)
+    EXECUTE FUNCTION log_entity_jurisdiction_change();
+
+-- Director roles: the change is logged against the director, whose CBU is
+-- then the affected one
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION log_director_role_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM role_type WHERE id = NEW.role_type_id AND code = 'DIRECTOR') THEN
+        INSERT INTO entity_change_log (kind, entity_id, new_value, details)
+        VALUES ('director', NEW.entity_id, 'DIRECTOR',
+                jsonb_build_object('cbu_id', NEW.cbu_id, 'cbu_role_id', NEW.id, 'start_date', NEW.start_date));
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+DROP TRIGGER IF EXISTS cbu_role_director_change_log ON cbu_role;
+CREATE TRIGGER cbu_role_director_change_log
+    AFTER INSERT ON cbu_role
+    FOR EACH ROW
+    EXECUTE FUNCTION log_director_role_change();
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION log_adverse_media_alert()
+RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO entity_change_log (kind, entity_id, new_value, details)
+    SELECT 'adverse_media', h.subject_entity_id, h.matched_name,
+           jsonb_build_object('alert_id', NEW.id, 'hit_id', h.id, 'provider', h.provider,
+                              'reference', h.reference, 'score', h.score, 'detail', h.detail,
+                              'watched_entity_id', NEW.entity_id)
+      FROM watchlist_hits h
+     WHERE h.id = NEW.hit_id AND h.kind = 'adverse_media';
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+DROP TRIGGER IF EXISTS watchlist_alert_change_log ON watchlist_alerts;
+CREATE TRIGGER watchlist_alert_change_log
+    AFTER INSERT ON watchlist_alerts
+    FOR EACH ROW
+    EXECUTE FUNCTION log_adverse_media_alert();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS watchlist_alert_change_log ON watchlist_alerts;
+DROP TRIGGER IF EXISTS cbu_role_director_change_log ON cbu_role;
+DROP TRIGGER IF EXISTS entity_jurisdiction_change_log ON entity;
+DROP TRIGGER IF EXISTS entity_control_change_log ON entity_control;
+DROP FUNCTION IF EXISTS log_adverse_media_alert();
+DROP FUNCTION IF EXISTS log_director_role_change();
+DROP FUNCTION IF EXISTS log_entity_jurisdiction_change();
+DROP FUNCTION IF EXISTS log_entity_control_change();
+DROP TABLE IF EXISTS material_change_events;
+DROP TABLE IF EXISTS entity_change_log;
+DELETE FROM role_type rt
+ WHERE rt.code = 'DIRECTOR' AND NOT EXISTS (SELECT 1 FROM cbu_role cr WHERE cr.role_type_id = rt.id);
@@ -0,0 +1,57 @@
+-- ===========================================================
+-- 019_managed_lists.sql
+-- Managed lists (sanctioned and high-risk jurisdictions...)
+-- referenced from derivation rules as
+-- in_list(TAX_RESIDENCY_COUNTRY, "EU_HIGH_RISK") instead of
+-- country codes hard-coded in rule strings. Every update is
+-- kept in kyc_managed_list_history.
+-- ===========================================================
+
+-- +goose Up
+
+CREATE TABLE IF NOT EXISTS kyc_managed_lists (
+    name TEXT PRIMARY KEY,
+    description TEXT,
+    members TEXT[] NOT NULL DEFAULT '{}',    -- upper-case codes, e.g. ISO 3166 alpha-2
+    source TEXT,                             -- publisher or legal basis of the list
+    effective_from DATE NOT NULL DEFAULT CURRENT_DATE,
+    effective_to DATE,
+    version INT NOT NULL DEFAULT 1,
+    updated_by TEXT,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT managed_list_name_check CHECK (name ~ '^[A-Z][A-Z0-9_]*$'),
+    CONSTRAINT managed_list_dates_check CHECK (effective_to IS NULL OR effective_to >= effective_from)
+);
+
+CREATE TABLE IF NOT EXISTS kyc_managed_list_history (
+    id SERIAL PRIMARY KEY,
+    list_name TEXT NOT NULL REFERENCES kyc_managed_lists(name) ON DELETE CASCADE,
+    version INT NOT NULL,
+    members TEXT[] NOT NULL,
+    source TEXT,
+    effective_from DATE NOT NULL,
+    effective_to DATE,
+    changed_by TEXT,
+    changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (list_name, version)
+);
+
+-- Lists previously hard-coded in the seeded derivation rules
+INSERT INTO kyc_managed_lists (name, description, members, source, effective_from, updated_by) VALUES
+    ('HIGH_RISK_JURISDICTIONS', 'Jurisdictions requiring enhanced due diligence',
+     ARRAY['IR', 'KP', 'SY', 'YE', 'AF', 'MM'], 'AMLD5 internal policy', '2020-01-10', 'migration'),
+    ('SANCTIONED_COUNTRIES', 'Countries subject to comprehensive sanctions',
+     ARRAY['IR', 'KP', 'SY', 'CU', 'RU'], 'BSA/AML internal policy', '2022-02-24', 'migration'),
+    ('EU_HIGH_RISK', 'EU high-risk third countries with strategic AML/CFT deficiencies',
+     ARRAY['AF', 'BF', 'CD', 'HT', 'IR', 'JM', 'KP', 'ML', 'MM', 'MZ', 'NG', 'PH', 'SN', 'SS', 'SY', 'TZ', 'VU', 'YE', 'ZA'],
+     'Commission Delegated Regulation (EU) 2016/1675, as amended', '2024-03-14', 'migration')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO kyc_managed_list_history (list_name, version, members, source, effective_from, effective_to, changed_by)
+SELECT name, version, members, source, effective_from, effective_to, updated_by
+  FROM kyc_managed_lists
+ON CONFLICT (list_name, version) DO NOTHING;
+
+-- +goose Down
+DROP TABLE IF EXISTS kyc_managed_list_history;
+DROP TABLE IF EXISTS kyc_managed_lists;
@@ -0,0 +1,93 @@
+-- ===========================================================
+-- 020_reevaluation_queue.sql
+-- Queue of derived attributes to re-evaluate. Triggers enqueue
+-- the derivations depending on a managed list or rule when it
+-- changes; case attribute changes are enqueued by the
+-- application with the new values. dataserver drains the
+-- queue and records fresh results in kyc_lineage_evaluations.
+-- ===========================================================
+
+-- +goose Up
+
+CREATE TABLE IF NOT EXISTS kyc_reevaluation_queue (
+    id BIGSERIAL PRIMARY KEY,
+    case_name TEXT NOT NULL DEFAULT '',      -- '' re-evaluates every case evaluated before
+    derived_code TEXT NOT NULL,
+    reason TEXT NOT NULL,                    -- list, rule, attribute, cascade
+    ref TEXT,                                -- list name, derivation id or attribute code
+    inputs JSONB NOT NULL DEFAULT '{}',      -- attribute values overriding the recorded inputs
+    depth INT NOT NULL DEFAULT 0,            -- cascade depth from the original change
+    status TEXT NOT NULL DEFAULT 'PENDING',
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    enqueued_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    processed_at TIMESTAMP,
+    CONSTRAINT reevaluation_reason_check CHECK (reason IN ('list', 'rule', 'attribute', 'cascade')),
+    CONSTRAINT reevaluation_status_check CHECK (status IN ('PENDING', 'DONE', 'FAILED'))
+);
+
+-- One pending item per case and derived attribute; later changes merge into it
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reevaluation_queue_pending
+    ON kyc_reevaluation_queue(case_name, derived_code) WHERE status = 'PENDING';
+
+CREATE INDEX IF NOT EXISTS idx_reevaluation_queue_recent
+    ON kyc_reevaluation_queue(enqueued_at DESC);
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION enqueue_list_dependents(list_name TEXT)
+RETURNS INT AS $$
+DECLARE
+    queued INT;
+BEGIN
+    INSERT INTO kyc_reevaluation_queue (derived_code, reason, ref)
+    SELECT DISTINCT d.derived_attribute_code, 'list', list_name
+      FROM kyc_attribute_derivations d
+     WHERE d.rule_expression ~ ('in_list\s*\([^,()]+,\s*["'']' || list_name || '["'']\s*\)')
+    ON CONFLICT (case_name, derived_code) WHERE status = 'PENDING' DO NOTHING;
+    GET DIAGNOSTICS queued = ROW_COUNT;
+    RETURN queued;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION enqueue_managed_list_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM enqueue_list_dependents(NEW.name);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+DROP TRIGGER IF EXISTS managed_list_reevaluation ON kyc_managed_lists;
+CREATE TRIGGER managed_list_reevaluation
+    AFTER INSERT OR UPDATE OF members, effective_from, effective_to ON kyc_managed_lists
+    FOR EACH ROW
+    EXECUTE FUNCTION enqueue_managed_list_change();
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION enqueue_derivation_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO kyc_reevaluation_queue (derived_code, reason, ref)
+    VALUES (NEW.derived_attribute_code, 'rule', NEW.id::text)
+    ON CONFLICT (case_name, derived_code) WHERE status = 'PENDING' DO NOTHING;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+DROP TRIGGER IF EXISTS derivation_reevaluation ON kyc_attribute_derivations;
+CREATE TRIGGER derivation_reevaluation
+    AFTER INSERT OR UPDATE OF rule_expression ON kyc_attribute_derivations
+    FOR EACH ROW
+    EXECUTE FUNCTION enqueue_derivation_change();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS derivation_reevaluation ON kyc_attribute_derivations;
+DROP TRIGGER IF EXISTS managed_list_reevaluation ON kyc_managed_lists;
+DROP FUNCTION IF EXISTS enqueue_derivation_change();
+DROP FUNCTION IF EXISTS enqueue_managed_list_change();
+DROP FUNCTION IF EXISTS enqueue_list_dependents(TEXT);
+DROP TABLE IF EXISTS kyc_reevaluation_queue;
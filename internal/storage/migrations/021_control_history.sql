@@ -0,0 +1,81 @@
+-- ===========================================================
+-- 021_control_history.sql
+-- Temporal ownership graph: every version of an entity_control
+-- edge is kept in entity_control_history with the period it
+-- was on record (recorded_at .. superseded_at), so graphs can
+-- be rebuilt as of a past date and compared between dates.
+-- ===========================================================
+
+-- +goose Up
+
+CREATE TABLE IF NOT EXISTS entity_control_history (
+    history_id BIGSERIAL PRIMARY KEY,
+    id UUID NOT NULL,                        -- entity_control.id (kept after the edge is deleted)
+    controller_entity_id UUID NOT NULL,
+    controlled_entity_id UUID NOT NULL,
+    control_type control_type NOT NULL,
+    control_basis TEXT,
+    control_percentage NUMERIC(5,2),
+    start_date DATE NOT NULL,
+    end_date DATE,
+    source_document TEXT,
+    operation TEXT NOT NULL,                 -- INSERT, UPDATE, DELETE
+    recorded_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    superseded_at TIMESTAMP,
+    CONSTRAINT entity_control_history_operation_check CHECK (operation IN ('INSERT', 'UPDATE', 'DELETE'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_entity_control_history_edge
+    ON entity_control_history(id, recorded_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_entity_control_history_period
+    ON entity_control_history(recorded_at, superseded_at);
+
+-- Existing edges are on record since they were created
+INSERT INTO entity_control_history
+    (id, controller_entity_id, controlled_entity_id, control_type, control_basis, control_percentage,
+     start_date, end_date, source_document, operation, recorded_at)
+SELECT ec.id, ec.controller_entity_id, ec.controlled_entity_id, ec.control_type, ec.control_basis,
+       ec.control_percentage, ec.start_date, ec.end_date, ec.source_document, 'INSERT',
+       LEAST(COALESCE(ec.created_at, ec.start_date::timestamp), ec.start_date::timestamp)
+  FROM entity_control ec
+ WHERE NOT EXISTS (SELECT 1 FROM entity_control_history h WHERE h.id = ec.id);
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION record_entity_control_history()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE entity_control_history
+       SET superseded_at = CURRENT_TIMESTAMP
+     WHERE id = COALESCE(NEW.id, OLD.id) AND superseded_at IS NULL;
+
+    IF TG_OP = 'DELETE' THEN
+        INSERT INTO entity_control_history
+            (id, controller_entity_id, controlled_entity_id, control_type, control_basis, control_percentage,
+             start_date, end_date, source_document, operation, superseded_at)
+        VALUES (OLD.id, OLD.controller_entity_id, OLD.controlled_entity_id, OLD.control_type, OLD.control_basis,
+                OLD.control_percentage, OLD.start_date, OLD.end_date, OLD.source_document, 'DELETE',
+                CURRENT_TIMESTAMP);
+        RETURN OLD;
+    END IF;
+
+    INSERT INTO entity_control_history
+        (id, controller_entity_id, controlled_entity_id, control_type, control_basis, control_percentage,
+         start_date, end_date, source_document, operation)
+    VALUES (NEW.id, NEW.controller_entity_id, NEW.controlled_entity_id, NEW.control_type, NEW.control_basis,
+            NEW.control_percentage, NEW.start_date, NEW.end_date, NEW.source_document, TG_OP);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+DROP TRIGGER IF EXISTS entity_control_history_log ON entity_control;
+CREATE TRIGGER entity_control_history_log
+    AFTER INSERT OR UPDATE OR DELETE ON entity_control
+    FOR EACH ROW
+    EXECUTE FUNCTION record_entity_control_history();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS entity_control_history_log ON entity_control;
+DROP FUNCTION IF EXISTS record_entity_control_history();
+DROP TABLE IF EXISTS entity_control_history;
@@ -0,0 +1,34 @@
+-- ===========================================================
+-- 022_case_data_dictionary.sql
+-- Case data dictionary values. Derived attribute results can be
+-- materialized here from kyc_lineage_evaluations so that exports
+-- and attestations read one dictionary per case; derived entries
+-- are flagged and point at the evaluation that produced them.
+-- ===========================================================
+
+-- +goose Up
+
+CREATE TABLE IF NOT EXISTS kyc_case_data_dictionary (
+    case_name TEXT NOT NULL,
+    attribute_code TEXT NOT NULL,
+    value TEXT,
+    value_type TEXT,                          -- boolean, numeric, string
+    derived BOOLEAN NOT NULL DEFAULT FALSE,
+    evaluation_id INT REFERENCES kyc_lineage_evaluations(id) ON DELETE SET NULL,
+    rule TEXT,                                -- rule expression of a derived value
+    regulation_code TEXT,
+    materialized_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (case_name, attribute_code)
+);
+
+CREATE INDEX IF NOT EXISTS idx_case_dictionary_evaluation
+    ON kyc_case_data_dictionary(evaluation_id);
+
+COMMENT ON TABLE kyc_case_data_dictionary IS
+    'Attribute values of a case; derived = TRUE marks values materialized from a lineage evaluation';
+
+COMMENT ON COLUMN kyc_case_data_dictionary.evaluation_id IS
+    'kyc_lineage_evaluations row the derived value was materialized from';
+
+-- +goose Down
+DROP TABLE IF EXISTS kyc_case_data_dictionary;
@@ -0,0 +1,107 @@
+-- ===========================================================
+-- 023_agent_registry.sql
+-- Registry of the agents (people, AI assistants and automated
+-- jobs) that call the API. Feedback, audit and search hit rows
+-- still carry a free-text agent_name; registered agents add an
+-- owner, type, model and quota tier to it, can be bound to an
+-- authenticated principal and limited to endpoint prefixes.
+-- ===========================================================
+
+-- +goose Up
+
+CREATE TABLE IF NOT EXISTS kyc_agents (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    agent_type TEXT NOT NULL DEFAULT 'ai'
+        CHECK (agent_type IN ('human', 'ai', 'automated')),
+    owner TEXT NOT NULL,
+    description TEXT,
+    subject TEXT,                                 -- principal allowed to act as the agent; NULL = any
+    allowed_endpoints TEXT[] NOT NULL DEFAULT '{}', -- path prefixes; empty = all
+    model_provider TEXT,
+    model_name TEXT,
+    model_version TEXT,
+    quota_tier TEXT NOT NULL DEFAULT 'standard'
+        CHECK (quota_tier IN ('basic', 'standard', 'premium', 'unlimited')),
+    status TEXT NOT NULL DEFAULT 'active'
+        CHECK (status IN ('active', 'suspended')),
+    registered_by TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_agents_subject ON kyc_agents(subject) WHERE subject IS NOT NULL;
+
+COMMENT ON TABLE kyc_agents IS
+    'Registered API agents; agent_name columns of rag_feedback, rag_audit_log and kyc_ontology_search_hits join on name';
+
+COMMENT ON COLUMN kyc_agents.subject IS
+    'Authenticated principal subject (JWT sub or api-key:<fingerprint>) that may act as this agent';
+
+-- Activity per agent name, registered or not
+CREATE OR REPLACE VIEW v_agent_analytics AS
+WITH names AS (
+    SELECT name AS agent_name FROM kyc_agents
+    UNION SELECT agent_name FROM rag_feedback WHERE agent_name IS NOT NULL
+    UNION SELECT agent_name FROM rag_audit_log WHERE agent_name IS NOT NULL
+    UNION SELECT agent_name FROM kyc_ontology_search_hits WHERE agent_name IS NOT NULL
+),
+feedback AS (
+    SELECT agent_name,
+           COUNT(*) AS feedback_count,
+           COUNT(*) FILTER (WHERE feedback = 'positive') AS positive_feedback,
+           COUNT(*) FILTER (WHERE feedback = 'negative') AS negative_feedback,
+           AVG(confidence) AS avg_confidence,
+           MAX(created_at) AS last_feedback_at
+      FROM rag_feedback
+     WHERE agent_name IS NOT NULL
+     GROUP BY agent_name
+),
+audit AS (
+    SELECT agent_name,
+           COUNT(*) AS query_count,
+           COUNT(*) FILTER (WHERE error_message IS NOT NULL) AS error_count,
+           AVG(latency_ms) AS avg_latency_ms,
+           MAX(created_at) AS last_query_at
+      FROM rag_audit_log
+     WHERE agent_name IS NOT NULL
+     GROUP BY agent_name
+),
+hits AS (
+    SELECT agent_name,
+           COUNT(*) AS search_hits,
+           MAX(created_at) AS last_search_at
+      FROM kyc_ontology_search_hits
+     WHERE agent_name IS NOT NULL
+     GROUP BY agent_name
+)
+SELECT
+    n.agent_name,
+    a.id IS NOT NULL AS registered,
+    a.agent_type,
+    a.owner,
+    a.quota_tier,
+    a.status,
+    a.model_name,
+    COALESCE(f.feedback_count, 0) AS feedback_count,
+    COALESCE(f.positive_feedback, 0) AS positive_feedback,
+    COALESCE(f.negative_feedback, 0) AS negative_feedback,
+    f.avg_confidence,
+    COALESCE(q.query_count, 0) AS query_count,
+    COALESCE(q.error_count, 0) AS error_count,
+    q.avg_latency_ms,
+    COALESCE(h.search_hits, 0) AS search_hits,
+    GREATEST(f.last_feedback_at, q.last_query_at, h.last_search_at, a.last_seen_at) AS last_activity_at
+FROM names n
+LEFT JOIN kyc_agents a ON a.name = n.agent_name
+LEFT JOIN feedback f ON f.agent_name = n.agent_name
+LEFT JOIN audit q ON q.agent_name = n.agent_name
+LEFT JOIN hits h ON h.agent_name = n.agent_name;
+
+COMMENT ON VIEW v_agent_analytics IS
+    'Feedback, audit and search activity per agent name joined with the agent registry';
+
+-- +goose Down
+DROP VIEW IF EXISTS v_agent_analytics;
+DROP TABLE IF EXISTS kyc_agents;
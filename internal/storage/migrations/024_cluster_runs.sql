@@ -0,0 +1,42 @@
+-- ===========================================================
+-- 024_cluster_runs.sql
+-- Automatic semantic clustering of attribute embeddings. Each run
+-- of the clustering job is recorded; the clusters it computes are
+-- stored in rag_clusters with source = 'auto' and replace those of
+-- the previous run, while manually curated clusters are kept.
+-- ===========================================================
+
+-- +goose Up
+
+CREATE TABLE IF NOT EXISTS rag_cluster_runs (
+    id SERIAL PRIMARY KEY,
+    algorithm TEXT NOT NULL,                  -- kmeans
+    k INT,                                    -- clusters computed
+    attribute_count INT,                      -- embeddings clustered
+    silhouette FLOAT,                         -- mean silhouette (-1..1)
+    labeler TEXT,                             -- keywords or the chat model used
+    status TEXT NOT NULL DEFAULT 'running'
+        CHECK (status IN ('running', 'completed', 'failed')),
+    error_message TEXT,
+    triggered_by TEXT,                        -- kycctl, scheduler
+    started_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_cluster_runs_started ON rag_cluster_runs(started_at DESC);
+
+ALTER TABLE rag_clusters
+    ADD COLUMN IF NOT EXISTS source TEXT NOT NULL DEFAULT 'manual'
+        CHECK (source IN ('manual', 'auto')),
+    ADD COLUMN IF NOT EXISTS run_id INT REFERENCES rag_cluster_runs(id) ON DELETE SET NULL;
+
+COMMENT ON TABLE rag_cluster_runs IS
+    'Runs of the automatic clustering job over kyc_attribute_metadata embeddings';
+
+COMMENT ON COLUMN rag_clusters.source IS
+    'manual = curated; auto = computed by the clustering job (replaced on every run)';
+
+-- +goose Down
+ALTER TABLE rag_clusters DROP COLUMN IF EXISTS run_id;
+ALTER TABLE rag_clusters DROP COLUMN IF EXISTS source;
+DROP TABLE IF EXISTS rag_cluster_runs;
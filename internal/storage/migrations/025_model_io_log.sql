@@ -0,0 +1,43 @@
+-- ===========================================================
+-- 025_model_io_log.sql
+-- Prompts and responses of the generation features (narrative
+-- polishing, cluster labels and the coming /rag/ask). Model I/O
+-- may contain client data, so it is kept apart from rag_audit_log:
+-- entries expire, are redacted before they are written, and are
+-- only served to admins.
+-- ===========================================================
+
+-- +goose Up
+
+CREATE TABLE IF NOT EXISTS model_io_log (
+    id BIGSERIAL PRIMARY KEY,
+    feature TEXT NOT NULL,                    -- narrative_polish, cluster_labels, ...
+    model TEXT NOT NULL,
+    agent_name TEXT,
+    subject TEXT,                             -- authenticated principal
+    request_id TEXT,
+    system_prompt TEXT,
+    prompt TEXT NOT NULL,
+    response TEXT,                            -- NULL when responses are not logged
+    redacted BOOLEAN NOT NULL DEFAULT FALSE,
+    truncated BOOLEAN NOT NULL DEFAULT FALSE,
+    prompt_tokens INT,
+    completion_tokens INT,
+    latency_ms INT,
+    error_message TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_model_io_log_created ON model_io_log(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_model_io_log_expires ON model_io_log(expires_at);
+CREATE INDEX IF NOT EXISTS idx_model_io_log_feature ON model_io_log(feature, created_at DESC);
+
+-- Only the application role reads and writes model I/O
+REVOKE ALL ON model_io_log FROM PUBLIC;
+
+COMMENT ON TABLE model_io_log IS
+    'Prompts and model responses of generation features; may contain client data (see model_log config)';
+
+-- +goose Down
+DROP TABLE IF EXISTS model_io_log;
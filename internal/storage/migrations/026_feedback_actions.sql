@@ -0,0 +1,86 @@
+-- ===========================================================
+-- 026_feedback_actions.sql
+-- Confidence-gated actions on feedback. Negative feedback at or
+-- above a tenant's confidence threshold from a trusted agent demotes
+-- the result for the query it was given on and, once enough of it
+-- has accumulated on an attribute, flags the attribute's metadata
+-- for review. Other feedback only accumulates in rag_feedback.
+-- ===========================================================
+
+-- +goose Up
+
+CREATE TABLE IF NOT EXISTS feedback_action_policies (
+    tenant TEXT PRIMARY KEY,
+    min_confidence FLOAT NOT NULL DEFAULT 0.8
+        CHECK (min_confidence >= 0 AND min_confidence <= 1),
+    trusted_agents TEXT[] NOT NULL DEFAULT '{}',  -- empty = any active registered agent
+    demote BOOLEAN NOT NULL DEFAULT TRUE,
+    demote_penalty FLOAT NOT NULL DEFAULT 0.05    -- subtracted from similarity per feedback
+        CHECK (demote_penalty >= 0 AND demote_penalty <= 1),
+    max_penalty FLOAT NOT NULL DEFAULT 0.3
+        CHECK (max_penalty >= 0 AND max_penalty <= 1),
+    flag BOOLEAN NOT NULL DEFAULT TRUE,
+    flag_after INT NOT NULL DEFAULT 3             -- gated negatives on an attribute before flagging
+        CHECK (flag_after >= 1),
+    updated_by TEXT,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Feedback is recorded per tenant; gated marks feedback that passed the
+-- tenant's confidence and trust gates (counted towards review flags)
+ALTER TABLE rag_feedback
+    ADD COLUMN IF NOT EXISTS tenant TEXT NOT NULL DEFAULT 'default',
+    ADD COLUMN IF NOT EXISTS gated BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_rag_feedback_gated ON rag_feedback(tenant, attribute_code, created_at) WHERE gated;
+
+-- Tenants without a policy of their own use this one
+INSERT INTO feedback_action_policies (tenant) VALUES ('default') ON CONFLICT (tenant) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS rag_feedback_actions (
+    id SERIAL PRIMARY KEY,
+    feedback_id INT NOT NULL REFERENCES rag_feedback(id) ON DELETE CASCADE,
+    tenant TEXT NOT NULL,
+    action TEXT NOT NULL CHECK (action IN ('demote', 'flag')),
+    query_key TEXT NOT NULL,                      -- normalized query text
+    attribute_code TEXT,
+    document_code TEXT,
+    regulation_code TEXT,
+    agent_name TEXT,
+    confidence FLOAT NOT NULL,
+    penalty FLOAT,                                -- demotions: penalty after this feedback
+    status TEXT NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'resolved')),
+    resolved_by TEXT,
+    resolved_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_feedback_actions_status ON rag_feedback_actions(tenant, action, status, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_feedback_actions_attr ON rag_feedback_actions(tenant, attribute_code);
+
+-- Current demotion of an attribute for a query, applied by attribute search
+CREATE TABLE IF NOT EXISTS rag_ranking_penalties (
+    tenant TEXT NOT NULL,
+    query_key TEXT NOT NULL,
+    attribute_code TEXT NOT NULL,
+    penalty FLOAT NOT NULL CHECK (penalty >= 0 AND penalty <= 1),
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (tenant, query_key, attribute_code)
+);
+
+COMMENT ON TABLE feedback_action_policies IS
+    'Per-tenant thresholds and actions for feedback (the default row applies to other tenants)';
+
+COMMENT ON TABLE rag_feedback_actions IS
+    'Demotions and metadata review flags raised automatically by confidence-gated feedback';
+
+COMMENT ON TABLE rag_ranking_penalties IS
+    'Similarity penalties from demotions per tenant, normalized query and attribute';
+
+-- +goose Down
+DROP TABLE IF EXISTS rag_ranking_penalties;
+DROP TABLE IF EXISTS rag_feedback_actions;
+DROP TABLE IF EXISTS feedback_action_policies;
+DROP INDEX IF EXISTS idx_rag_feedback_gated;
+ALTER TABLE rag_feedback DROP COLUMN IF EXISTS gated;
+ALTER TABLE rag_feedback DROP COLUMN IF EXISTS tenant;
@@ -0,0 +1,44 @@
+-- ===========================================================
+-- 027_feedback_ranking.sql
+-- Feedback-weighted ranking. Feedback is tagged with the cluster
+-- closest to its query, and rag_feedback_scores aggregates it per
+-- tenant, query cluster and attribute into a score between -1
+-- (consistently negative) and 1 (consistently positive) that
+-- attribute search blends with vector similarity.
+-- ===========================================================
+
+-- +goose Up
+
+-- NULL when no cluster was available; such feedback scores for queries
+-- that have no cluster either
+ALTER TABLE rag_feedback ADD COLUMN IF NOT EXISTS query_cluster TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_rag_feedback_query_cluster
+    ON rag_feedback(tenant, query_cluster, attribute_code)
+    WHERE attribute_code IS NOT NULL;
+
+-- Confidence-weighted net sentiment. Neutral feedback and a prior of one
+-- unit of confidence damp the score, so a single feedback moves it at
+-- most halfway.
+CREATE OR REPLACE VIEW rag_feedback_scores AS
+SELECT
+    tenant,
+    COALESCE(query_cluster, '') AS query_cluster,
+    attribute_code,
+    COUNT(*) AS feedback_count,
+    SUM(confidence) FILTER (WHERE feedback = 'positive') AS positive_weight,
+    SUM(confidence) FILTER (WHERE feedback = 'negative') AS negative_weight,
+    (COALESCE(SUM(confidence) FILTER (WHERE feedback = 'positive'), 0)
+        - COALESCE(SUM(confidence) FILTER (WHERE feedback = 'negative'), 0))
+        / (COALESCE(SUM(confidence), 0) + 1.0) AS score
+FROM rag_feedback
+WHERE attribute_code IS NOT NULL
+GROUP BY tenant, COALESCE(query_cluster, ''), attribute_code;
+
+COMMENT ON VIEW rag_feedback_scores IS
+    'Net feedback per tenant, query cluster and attribute (-1..1), blended into attribute search ranking';
+
+-- +goose Down
+DROP VIEW IF EXISTS rag_feedback_scores;
+DROP INDEX IF EXISTS idx_rag_feedback_query_cluster;
+ALTER TABLE rag_feedback DROP COLUMN IF EXISTS query_cluster;
@@ -0,0 +1,27 @@
+-- ===========================================================
+-- 028_embedding_cache.sql
+-- Query embedding cache shared by API processes. Searches for the
+-- same normalized query text with the same model reuse the stored
+-- embedding instead of calling the embedding API again.
+-- ===========================================================
+
+-- +goose Up
+
+CREATE TABLE IF NOT EXISTS rag_query_embedding_cache (
+    model TEXT NOT NULL,
+    query_key TEXT NOT NULL,             -- lower-cased, whitespace-collapsed query text
+    embedding REAL[] NOT NULL,
+    hits BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_hit_at TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (model, query_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_query_embedding_cache_expires ON rag_query_embedding_cache(expires_at);
+
+COMMENT ON TABLE rag_query_embedding_cache IS
+    'Cached query embeddings by model and normalized query text (expired rows are purged by kycserver)';
+
+-- +goose Down
+DROP TABLE IF EXISTS rag_query_embedding_cache;
@@ -0,0 +1,26 @@
+-- ===========================================================
+-- 029_embedding_content_hash.sql
+-- Records the hash of the text each ontology embedding was generated
+-- from, so embeddings that went stale after business_context, synonyms
+-- or descriptions changed can be found and re-generated (kycctl reembed,
+-- reembedding worker in kycserver). NULL means the embedding predates
+-- versioning.
+-- ===========================================================
+
+-- +goose Up
+
+ALTER TABLE kyc_attribute_metadata ADD COLUMN IF NOT EXISTS embedding_content_hash TEXT;
+ALTER TABLE kyc_documents ADD COLUMN IF NOT EXISTS embedding_content_hash TEXT;
+ALTER TABLE kyc_regulations ADD COLUMN IF NOT EXISTS embedding_content_hash TEXT;
+
+COMMENT ON COLUMN kyc_attribute_metadata.embedding_content_hash IS
+    'SHA-256 of the embedding text the embedding was generated from';
+COMMENT ON COLUMN kyc_documents.embedding_content_hash IS
+    'SHA-256 of the embedding text the embedding was generated from';
+COMMENT ON COLUMN kyc_regulations.embedding_content_hash IS
+    'SHA-256 of the embedding text the embedding was generated from';
+
+-- +goose Down
+ALTER TABLE kyc_regulations DROP COLUMN IF EXISTS embedding_content_hash;
+ALTER TABLE kyc_documents DROP COLUMN IF EXISTS embedding_content_hash;
+ALTER TABLE kyc_attribute_metadata DROP COLUMN IF EXISTS embedding_content_hash;
@@ -0,0 +1,66 @@
+-- ===========================================================
+-- 030_embedding_spaces.sql
+-- Embedding spaces: the embedding model and dimensions behind each set of
+-- ontology embeddings. The primary space lives in the embedding columns of
+-- the ontology tables; secondary spaces (e.g. text-embedding-3-small vs
+-- -large) live in ontology_embeddings so models can be compared side by
+-- side and a new one promoted once it is fully embedded.
+-- ===========================================================
+
+-- +goose Up
+
+CREATE TABLE IF NOT EXISTS embedding_spaces (
+    name TEXT PRIMARY KEY CHECK (name ~ '^[a-z][a-z0-9_]*$'),
+    model TEXT NOT NULL,
+    dimensions INT NOT NULL CHECK (dimensions > 0),
+    -- The primary space is stored in the ontology tables' embedding columns
+    is_primary BOOLEAN NOT NULL DEFAULT false,
+    description TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    promoted_at TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_embedding_spaces_primary
+    ON embedding_spaces (is_primary) WHERE is_primary;
+
+INSERT INTO embedding_spaces (name, model, dimensions, is_primary, description)
+VALUES ('default', 'text-embedding-3-large', 1536, true, 'Embeddings in the ontology tables before embedding spaces')
+ON CONFLICT (name) DO NOTHING;
+
+-- Secondary spaces. embedding has no fixed dimension so spaces of any size
+-- share the table; each space gets a partial index on its own dimension.
+CREATE TABLE IF NOT EXISTS ontology_embeddings (
+    space TEXT NOT NULL REFERENCES embedding_spaces(name) ON DELETE CASCADE,
+    kind TEXT NOT NULL CHECK (kind IN ('attributes', 'documents', 'regulations')),
+    code TEXT NOT NULL,
+    embedding vector NOT NULL,
+    content_hash TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (space, kind, code)
+);
+
+-- The model that generated each primary embedding
+ALTER TABLE kyc_attribute_metadata ADD COLUMN IF NOT EXISTS embedding_model TEXT;
+ALTER TABLE kyc_documents ADD COLUMN IF NOT EXISTS embedding_model TEXT;
+ALTER TABLE kyc_regulations ADD COLUMN IF NOT EXISTS embedding_model TEXT;
+
+UPDATE kyc_attribute_metadata SET embedding_model = 'text-embedding-3-large'
+WHERE embedding IS NOT NULL AND embedding_model IS NULL;
+UPDATE kyc_documents SET embedding_model = 'text-embedding-3-large'
+WHERE embedding IS NOT NULL AND embedding_model IS NULL;
+UPDATE kyc_regulations SET embedding_model = 'text-embedding-3-large'
+WHERE embedding IS NOT NULL AND embedding_model IS NULL;
+
+COMMENT ON COLUMN kyc_attribute_metadata.embedding_model IS
+    'Embedding model of the primary embedding space that generated embedding';
+COMMENT ON COLUMN kyc_documents.embedding_model IS
+    'Embedding model of the primary embedding space that generated embedding';
+COMMENT ON COLUMN kyc_regulations.embedding_model IS
+    'Embedding model of the primary embedding space that generated embedding';
+
+-- +goose Down
+ALTER TABLE kyc_regulations DROP COLUMN IF EXISTS embedding_model;
+ALTER TABLE kyc_documents DROP COLUMN IF EXISTS embedding_model;
+ALTER TABLE kyc_attribute_metadata DROP COLUMN IF EXISTS embedding_model;
+DROP TABLE IF EXISTS ontology_embeddings;
+DROP TABLE IF EXISTS embedding_spaces;
@@ -0,0 +1,35 @@
+-- ===========================================================
+-- 031_amendment_proposals.sql
+-- Case amendments proposed from the web DSL editor. A proposal holds the
+-- edited DSL against the case version it was based on; a reviewer accepts
+-- it (saving it as the next case version) or rejects it.
+-- ===========================================================
+
+-- +goose Up
+
+CREATE TABLE IF NOT EXISTS case_amendment_proposals (
+    id SERIAL PRIMARY KEY,
+    case_name TEXT NOT NULL,
+    -- Case version the DSL was edited from; 0 for a new case
+    base_version INT NOT NULL DEFAULT 0,
+    dsl TEXT NOT NULL,
+    diff TEXT,
+    note TEXT,
+    status TEXT NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'accepted', 'rejected')),
+    proposed_by TEXT,
+    reviewed_by TEXT,
+    review_comment TEXT,
+    -- Case version created when the proposal was accepted
+    accepted_version INT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    reviewed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_amendment_proposals_pending
+    ON case_amendment_proposals(created_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_amendment_proposals_case
+    ON case_amendment_proposals(case_name, created_at DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS case_amendment_proposals;
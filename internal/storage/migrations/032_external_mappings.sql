@@ -0,0 +1,31 @@
+-- ===========================================================
+-- 032_external_mappings.sql
+-- Links attribute codes to identifiers of external standards: ISO 20022
+-- message elements and FIBO concepts. match_type follows the SKOS mapping
+-- relations, so a catalog can tell exact equivalents from close or broader
+-- ones.
+-- ===========================================================
+
+-- +goose Up
+
+CREATE TABLE IF NOT EXISTS attribute_external_mappings (
+    id SERIAL PRIMARY KEY,
+    attribute_code TEXT NOT NULL REFERENCES kyc_attributes(code) ON DELETE CASCADE,
+    standard TEXT NOT NULL CHECK (standard IN ('iso20022', 'fibo')),
+    -- ISO 20022 element path (PartyIdentification135/Nm) or FIBO prefixed name
+    external_id TEXT NOT NULL,
+    external_name TEXT,
+    uri TEXT,
+    match_type TEXT NOT NULL DEFAULT 'exact'
+        CHECK (match_type IN ('exact', 'close', 'broad', 'narrow', 'related')),
+    note TEXT,
+    updated_by TEXT,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (attribute_code, standard, external_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_external_mappings_standard
+    ON attribute_external_mappings(standard, external_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS attribute_external_mappings;
@@ -0,0 +1,59 @@
+-- ===========================================================
+-- 033_record_provenance.sql
+-- Last-modified time and actor on ontology records, returned as
+-- provenance by API reads that ask for it (include_provenance)
+-- ===========================================================
+
+-- +goose Up
+
+ALTER TABLE kyc_attributes
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS updated_by TEXT;
+ALTER TABLE kyc_documents
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS updated_by TEXT;
+ALTER TABLE kyc_regulations
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS updated_by TEXT;
+ALTER TABLE kyc_attribute_metadata
+    ADD COLUMN IF NOT EXISTS updated_by TEXT;
+
+-- Existing rows were last modified when they were created
+UPDATE kyc_attributes SET updated_at = COALESCE(created_at, NOW()) WHERE updated_at IS NULL;
+UPDATE kyc_documents SET updated_at = COALESCE(created_at, NOW()) WHERE updated_at IS NULL;
+UPDATE kyc_regulations SET updated_at = COALESCE(created_at, NOW()) WHERE updated_at IS NULL;
+
+ALTER TABLE kyc_attributes ALTER COLUMN updated_at SET DEFAULT NOW();
+ALTER TABLE kyc_documents ALTER COLUMN updated_at SET DEFAULT NOW();
+ALTER TABLE kyc_regulations ALTER COLUMN updated_at SET DEFAULT NOW();
+
+-- update_updated_at_column() is defined in 008_case_store.sql
+DROP TRIGGER IF EXISTS update_kyc_attributes_updated_at ON kyc_attributes;
+CREATE TRIGGER update_kyc_attributes_updated_at
+    BEFORE UPDATE ON kyc_attributes
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_kyc_documents_updated_at ON kyc_documents;
+CREATE TRIGGER update_kyc_documents_updated_at
+    BEFORE UPDATE ON kyc_documents
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_kyc_regulations_updated_at ON kyc_regulations;
+CREATE TRIGGER update_kyc_regulations_updated_at
+    BEFORE UPDATE ON kyc_regulations
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON COLUMN kyc_attribute_metadata.updated_by IS
+    'Reviewer who applied the last change; NULL for seeding and enrichment';
+
+-- +goose Down
+DROP TRIGGER IF EXISTS update_kyc_regulations_updated_at ON kyc_regulations;
+DROP TRIGGER IF EXISTS update_kyc_documents_updated_at ON kyc_documents;
+DROP TRIGGER IF EXISTS update_kyc_attributes_updated_at ON kyc_attributes;
+ALTER TABLE kyc_attribute_metadata DROP COLUMN IF EXISTS updated_by;
+ALTER TABLE kyc_regulations DROP COLUMN IF EXISTS updated_at, DROP COLUMN IF EXISTS updated_by;
+ALTER TABLE kyc_documents DROP COLUMN IF EXISTS updated_at, DROP COLUMN IF EXISTS updated_by;
+ALTER TABLE kyc_attributes DROP COLUMN IF EXISTS updated_at, DROP COLUMN IF EXISTS updated_by;
@@ -0,0 +1,41 @@
+-- ===========================================================
+-- 034_search_trigram.sql
+-- Trigram and full-text indexes for SearchEntities and
+-- SearchAttributes, which match names and codes by pg_trgm word
+-- similarity and descriptions by full-text search instead of
+-- ILIKE '%q%' sequential scans
+-- ===========================================================
+
+-- +goose Up
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_entity_name_trgm ON entity USING gin (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_entity_lei_code_trgm ON entity USING gin (lei_code gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_entity_description_fts
+    ON entity USING gin (to_tsvector('simple', COALESCE(description, '')));
+
+-- dictionary_attribute is created by scripts/kyc_ontology.sql, which has
+-- the same indexes; add them here when the script ran before this migration
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF to_regclass('dictionary_attribute') IS NOT NULL THEN
+        CREATE INDEX IF NOT EXISTS idx_attribute_name_trgm
+            ON dictionary_attribute USING gin (name gin_trgm_ops);
+        CREATE INDEX IF NOT EXISTS idx_attribute_code_trgm
+            ON dictionary_attribute USING gin (code gin_trgm_ops);
+        CREATE INDEX IF NOT EXISTS idx_attribute_description_fts
+            ON dictionary_attribute USING gin (to_tsvector('simple', COALESCE(description, '')));
+    END IF;
+END
+$$;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_attribute_description_fts;
+DROP INDEX IF EXISTS idx_attribute_code_trgm;
+DROP INDEX IF EXISTS idx_attribute_name_trgm;
+DROP INDEX IF EXISTS idx_entity_description_fts;
+DROP INDEX IF EXISTS idx_entity_lei_code_trgm;
+DROP INDEX IF EXISTS idx_entity_name_trgm;
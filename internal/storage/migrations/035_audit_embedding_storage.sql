@@ -0,0 +1,34 @@
+-- ===========================================================
+-- 035_audit_embedding_storage.sql
+-- Compact storage of query embeddings in rag_audit_log: the full
+-- vector, a reduced-dimension prefix, only a hash, or nothing
+-- (audit_log.embedding_storage). Analytics read query text and
+-- timings only, so they work whatever is kept.
+-- ===========================================================
+
+-- +goose Up
+
+ALTER TABLE rag_audit_log
+    ADD COLUMN IF NOT EXISTS query_embedding_reduced REAL[],
+    ADD COLUMN IF NOT EXISTS query_embedding_hash TEXT,
+    ADD COLUMN IF NOT EXISTS embedding_storage TEXT NOT NULL DEFAULT 'full'
+        CHECK (embedding_storage IN ('full', 'reduced', 'hash', 'none'));
+
+UPDATE rag_audit_log SET embedding_storage = 'none' WHERE query_embedding IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_audit_embedding_hash ON rag_audit_log(query_embedding_hash);
+CREATE INDEX IF NOT EXISTS idx_audit_embedding_storage
+    ON rag_audit_log(created_at) WHERE embedding_storage = 'full';
+
+COMMENT ON COLUMN rag_audit_log.query_embedding_reduced IS
+    'First audit_log.reduced_dimensions components of the query embedding, renormalized';
+COMMENT ON COLUMN rag_audit_log.query_embedding_hash IS
+    'SHA-256 of the query embedding, identifying repeated queries whatever is stored';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_audit_embedding_storage;
+DROP INDEX IF EXISTS idx_audit_embedding_hash;
+ALTER TABLE rag_audit_log
+    DROP COLUMN IF EXISTS embedding_storage,
+    DROP COLUMN IF EXISTS query_embedding_hash,
+    DROP COLUMN IF EXISTS query_embedding_reduced;
@@ -0,0 +1,56 @@
+-- ===========================================================
+-- 036_case_lifecycle.sql
+-- Case lifecycle: kyc_cases.status is restricted to the states of the
+-- lifecycle state machine (internal/engine) and every transition is
+-- recorded with its actor and time, forming the case timeline
+-- ===========================================================
+
+-- +goose Up
+
+-- Free-text statuses map onto the lifecycle; anything unrecognised
+-- starts again as a draft
+UPDATE kyc_cases SET status = CASE
+    WHEN status IN ('draft', 'validated', 'in-review', 'approved', 'declined', 'archived') THEN status
+    WHEN status IN ('review', 'in_review', 'in review') THEN 'in-review'
+    WHEN status IN ('rejected') THEN 'declined'
+    WHEN status IN ('closed') THEN 'archived'
+    ELSE 'draft'
+END;
+
+ALTER TABLE kyc_cases ALTER COLUMN status SET DEFAULT 'draft';
+ALTER TABLE kyc_cases ALTER COLUMN status SET NOT NULL;
+ALTER TABLE kyc_cases DROP CONSTRAINT IF EXISTS kyc_cases_status_check;
+ALTER TABLE kyc_cases ADD CONSTRAINT kyc_cases_status_check
+    CHECK (status IN ('draft', 'validated', 'in-review', 'approved', 'declined', 'archived'));
+
+CREATE INDEX IF NOT EXISTS idx_kyc_cases_name ON kyc_cases(name);
+
+CREATE TABLE IF NOT EXISTS kyc_case_transitions (
+    id SERIAL PRIMARY KEY,
+    case_name TEXT NOT NULL,
+    -- NULL for the transition that created the case
+    from_status TEXT,
+    to_status TEXT NOT NULL,
+    actor TEXT NOT NULL,
+    reason TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_case_transitions_case
+    ON kyc_case_transitions(case_name, created_at, id);
+
+-- Existing cases start their timeline in their current state
+INSERT INTO kyc_case_transitions (case_name, from_status, to_status, actor, reason, created_at)
+SELECT DISTINCT ON (name) name, NULL, status, 'migration', 'status before the case lifecycle', COALESCE(last_updated, NOW())
+FROM kyc_cases
+ORDER BY name, id DESC;
+
+COMMENT ON TABLE kyc_case_transitions IS
+    'Append-only case lifecycle transitions; the timeline of each case';
+
+-- +goose Down
+DROP TABLE IF EXISTS kyc_case_transitions;
+DROP INDEX IF EXISTS idx_kyc_cases_name;
+ALTER TABLE kyc_cases DROP CONSTRAINT IF EXISTS kyc_cases_status_check;
+ALTER TABLE kyc_cases ALTER COLUMN status DROP NOT NULL;
+ALTER TABLE kyc_cases ALTER COLUMN status SET DEFAULT 'pending';
@@ -0,0 +1,27 @@
+-- ===========================================================
+-- 037_case_locks.sql
+-- Advisory case locks: an analyst amending a case holds its lock and
+-- heartbeats to keep it; amendments by anyone else are refused until it
+-- is released or expires (case_lock.ttl) and is taken over
+-- ===========================================================
+
+-- +goose Up
+
+CREATE TABLE IF NOT EXISTS kyc_case_locks (
+    case_name TEXT PRIMARY KEY,
+    holder TEXT NOT NULL,
+    note TEXT,
+    acquired_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    heartbeat_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP NOT NULL,
+    -- Holder of the expired lock this one took over, if any
+    taken_over_from TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_case_locks_holder ON kyc_case_locks(holder);
+
+COMMENT ON TABLE kyc_case_locks IS
+    'Advisory locks on cases being amended; expired locks may be taken over';
+
+-- +goose Down
+DROP TABLE IF EXISTS kyc_case_locks;
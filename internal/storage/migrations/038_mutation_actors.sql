@@ -0,0 +1,52 @@
+-- ===========================================================
+-- 038_mutation_actors.sql
+-- Who made each change, through which channel (cli, grpc, http, system)
+-- and from which client address, recorded uniformly on the tables written
+-- by case, grammar and metadata mutations (internal/actor). Timestamps of
+-- these writes come from the database clock, never the client's.
+-- ===========================================================
+
+-- +goose Up
+
+ALTER TABLE kyc_cases
+    ADD COLUMN IF NOT EXISTS actor TEXT,
+    ADD COLUMN IF NOT EXISTS actor_source TEXT,
+    ADD COLUMN IF NOT EXISTS client_ip TEXT;
+ALTER TABLE kyc_case_versions
+    ADD COLUMN IF NOT EXISTS actor TEXT,
+    ADD COLUMN IF NOT EXISTS actor_source TEXT,
+    ADD COLUMN IF NOT EXISTS client_ip TEXT;
+ALTER TABLE kyc_case_amendments
+    ADD COLUMN IF NOT EXISTS actor TEXT,
+    ADD COLUMN IF NOT EXISTS actor_source TEXT,
+    ADD COLUMN IF NOT EXISTS client_ip TEXT;
+ALTER TABLE case_versions
+    ADD COLUMN IF NOT EXISTS actor TEXT,
+    ADD COLUMN IF NOT EXISTS actor_source TEXT,
+    ADD COLUMN IF NOT EXISTS client_ip TEXT;
+ALTER TABLE kyc_grammar
+    ADD COLUMN IF NOT EXISTS actor TEXT,
+    ADD COLUMN IF NOT EXISTS actor_source TEXT,
+    ADD COLUMN IF NOT EXISTS client_ip TEXT;
+-- Metadata already names its last modifier in updated_by
+ALTER TABLE kyc_attribute_metadata
+    ADD COLUMN IF NOT EXISTS actor_source TEXT,
+    ADD COLUMN IF NOT EXISTS client_ip TEXT;
+-- Transitions already name their actor
+ALTER TABLE kyc_case_transitions
+    ADD COLUMN IF NOT EXISTS actor_source TEXT,
+    ADD COLUMN IF NOT EXISTS client_ip TEXT;
+
+COMMENT ON COLUMN kyc_case_versions.actor_source IS
+    'Channel of the change: cli, grpc, http or system';
+COMMENT ON COLUMN kyc_attribute_metadata.updated_by IS
+    'Actor of the last change: reviewer, CLI user, API caller or system';
+
+-- +goose Down
+ALTER TABLE kyc_case_transitions DROP COLUMN IF EXISTS actor_source, DROP COLUMN IF EXISTS client_ip;
+ALTER TABLE kyc_attribute_metadata DROP COLUMN IF EXISTS actor_source, DROP COLUMN IF EXISTS client_ip;
+ALTER TABLE kyc_grammar DROP COLUMN IF EXISTS actor, DROP COLUMN IF EXISTS actor_source, DROP COLUMN IF EXISTS client_ip;
+ALTER TABLE case_versions DROP COLUMN IF EXISTS actor, DROP COLUMN IF EXISTS actor_source, DROP COLUMN IF EXISTS client_ip;
+ALTER TABLE kyc_case_amendments DROP COLUMN IF EXISTS actor, DROP COLUMN IF EXISTS actor_source, DROP COLUMN IF EXISTS client_ip;
+ALTER TABLE kyc_case_versions DROP COLUMN IF EXISTS actor, DROP COLUMN IF EXISTS actor_source, DROP COLUMN IF EXISTS client_ip;
+ALTER TABLE kyc_cases DROP COLUMN IF EXISTS actor, DROP COLUMN IF EXISTS actor_source, DROP COLUMN IF EXISTS client_ip;
@@ -0,0 +1,53 @@
+-- ===========================================================
+-- 039_case_assignments.sql
+-- Case assignment: each case is owned by an analyst and/or team, with a
+-- risk rating and SLA due date that drive the work queue. Every
+-- (re)assignment is recorded in kyc_case_assignment_history.
+-- ===========================================================
+
+-- +goose Up
+
+CREATE TABLE IF NOT EXISTS kyc_case_assignments (
+    case_name TEXT PRIMARY KEY,
+    assignee TEXT,
+    team TEXT,
+    risk_rating TEXT CHECK (risk_rating IN ('LOW', 'MEDIUM', 'HIGH', 'CRITICAL')),
+    sla_due_at TIMESTAMP,
+    assigned_by TEXT NOT NULL,
+    assigned_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (assignee IS NOT NULL OR team IS NOT NULL)
+);
+
+CREATE INDEX IF NOT EXISTS idx_case_assignments_assignee
+    ON kyc_case_assignments(assignee, sla_due_at);
+CREATE INDEX IF NOT EXISTS idx_case_assignments_team
+    ON kyc_case_assignments(team, sla_due_at);
+
+CREATE TABLE IF NOT EXISTS kyc_case_assignment_history (
+    id SERIAL PRIMARY KEY,
+    case_name TEXT NOT NULL,
+    -- NULL for the first assignment of the case
+    from_assignee TEXT,
+    from_team TEXT,
+    to_assignee TEXT,
+    to_team TEXT,
+    risk_rating TEXT,
+    sla_due_at TIMESTAMP,
+    actor TEXT NOT NULL,
+    actor_source TEXT,
+    client_ip TEXT,
+    reason TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_case_assignment_history_case
+    ON kyc_case_assignment_history(case_name, created_at, id);
+
+COMMENT ON TABLE kyc_case_assignments IS
+    'Current owner, risk rating and SLA due date of each assigned case';
+COMMENT ON TABLE kyc_case_assignment_history IS
+    'Append-only record of case assignments and reassignments';
+
+-- +goose Down
+DROP TABLE IF EXISTS kyc_case_assignment_history;
+DROP TABLE IF EXISTS kyc_case_assignments;
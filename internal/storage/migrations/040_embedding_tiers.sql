@@ -0,0 +1,122 @@
+-- ===========================================================
+-- 040_embedding_tiers.sql
+-- Usage-based tiering of attribute embeddings: embeddings of
+-- attributes nobody searches or references move to a cold table
+-- outside the ANN index, shrinking it, and move back when the
+-- attribute is used again (embedding_tiers config)
+-- ===========================================================
+
+-- +goose Up
+
+ALTER TABLE kyc_attribute_metadata
+    ADD COLUMN IF NOT EXISTS embedding_tier TEXT NOT NULL DEFAULT 'hot'
+        CHECK (embedding_tier IN ('hot', 'cold'));
+
+-- Archived embeddings; deliberately not indexed for vector search
+CREATE TABLE IF NOT EXISTS kyc_attribute_embeddings_cold (
+    attribute_code TEXT PRIMARY KEY
+        REFERENCES kyc_attribute_metadata(attribute_code) ON DELETE CASCADE,
+    embedding vector NOT NULL,
+    last_used_at TIMESTAMP,
+    archived_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_attribute_metadata_cold
+    ON kyc_attribute_metadata(attribute_code) WHERE embedding_tier = 'cold';
+
+COMMENT ON COLUMN kyc_attribute_metadata.embedding_tier IS
+    'hot: embedding stored here and searchable; cold: archived to kyc_attribute_embeddings_cold';
+COMMENT ON TABLE kyc_attribute_embeddings_cold IS
+    'Embeddings of rarely used attributes, excluded from vector search until promoted back';
+
+-- Archived attributes are not missing their embedding
+CREATE OR REPLACE VIEW ontology_usage_report AS
+WITH terms AS (
+    SELECT 'attribute' AS term_type, code AS term_code, name FROM kyc_attributes
+    UNION ALL
+    SELECT 'document', code, name FROM kyc_documents
+    UNION ALL
+    SELECT 'regulation', code, name FROM kyc_regulations
+),
+case_refs AS (
+    SELECT term_type, term_code, COUNT(DISTINCT case_name) AS case_count
+    FROM ontology_case_references
+    GROUP BY term_type, term_code
+),
+search_refs AS (
+    SELECT
+        term_type,
+        term_code,
+        COUNT(*) AS search_hits,
+        COUNT(*) FILTER (WHERE created_at >= NOW() - INTERVAL '30 days') AS search_hits_30d,
+        COUNT(DISTINCT agent_name) AS distinct_agents,
+        MAX(created_at) AS last_searched
+    FROM kyc_ontology_search_hits
+    GROUP BY term_type, term_code
+)
+SELECT
+    t.term_type,
+    t.term_code,
+    t.name,
+    COALESCE(c.case_count, 0) AS case_count,
+    COALESCE(s.search_hits, 0) AS search_hits,
+    COALESCE(s.search_hits_30d, 0) AS search_hits_30d,
+    COALESCE(s.distinct_agents, 0) AS distinct_agents,
+    s.last_searched,
+    m.updated_at AS metadata_updated_at,
+    (t.term_type = 'attribute' AND m.embedding IS NULL
+        AND COALESCE(m.embedding_tier, 'hot') = 'hot') AS missing_embedding
+FROM terms t
+LEFT JOIN case_refs c ON c.term_type = t.term_type AND c.term_code = t.term_code
+LEFT JOIN search_refs s ON s.term_type = t.term_type AND s.term_code = t.term_code
+LEFT JOIN kyc_attribute_metadata m ON t.term_type = 'attribute' AND m.attribute_code = t.term_code;
+
+-- +goose Down
+UPDATE kyc_attribute_metadata m
+SET embedding = c.embedding
+FROM kyc_attribute_embeddings_cold c
+WHERE c.attribute_code = m.attribute_code AND m.embedding IS NULL;
+
+CREATE OR REPLACE VIEW ontology_usage_report AS
+WITH terms AS (
+    SELECT 'attribute' AS term_type, code AS term_code, name FROM kyc_attributes
+    UNION ALL
+    SELECT 'document', code, name FROM kyc_documents
+    UNION ALL
+    SELECT 'regulation', code, name FROM kyc_regulations
+),
+case_refs AS (
+    SELECT term_type, term_code, COUNT(DISTINCT case_name) AS case_count
+    FROM ontology_case_references
+    GROUP BY term_type, term_code
+),
+search_refs AS (
+    SELECT
+        term_type,
+        term_code,
+        COUNT(*) AS search_hits,
+        COUNT(*) FILTER (WHERE created_at >= NOW() - INTERVAL '30 days') AS search_hits_30d,
+        COUNT(DISTINCT agent_name) AS distinct_agents,
+        MAX(created_at) AS last_searched
+    FROM kyc_ontology_search_hits
+    GROUP BY term_type, term_code
+)
+SELECT
+    t.term_type,
+    t.term_code,
+    t.name,
+    COALESCE(c.case_count, 0) AS case_count,
+    COALESCE(s.search_hits, 0) AS search_hits,
+    COALESCE(s.search_hits_30d, 0) AS search_hits_30d,
+    COALESCE(s.distinct_agents, 0) AS distinct_agents,
+    s.last_searched,
+    m.updated_at AS metadata_updated_at,
+    (t.term_type = 'attribute' AND m.embedding IS NULL) AS missing_embedding
+FROM terms t
+LEFT JOIN case_refs c ON c.term_type = t.term_type AND c.term_code = t.term_code
+LEFT JOIN search_refs s ON s.term_type = t.term_type AND s.term_code = t.term_code
+LEFT JOIN kyc_attribute_metadata m ON t.term_type = 'attribute' AND m.attribute_code = t.term_code;
+
+DROP TABLE IF EXISTS kyc_attribute_embeddings_cold;
+DROP INDEX IF EXISTS idx_attribute_metadata_cold;
+ALTER TABLE kyc_attribute_metadata DROP COLUMN IF EXISTS embedding_tier;
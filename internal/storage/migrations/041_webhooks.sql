@@ -0,0 +1,58 @@
+-- ===========================================================
+-- 041_webhooks.sql
+-- Outbox of webhook deliveries: every event (case.created,
+-- case.version.saved, case.approved, feedback.submitted,
+-- validation.failed) queues one delivery per subscribed endpoint
+-- (webhooks config); the dispatcher signs and posts them, retrying
+-- with backoff, and logs every attempt
+-- ===========================================================
+
+-- +goose Up
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    event_id UUID NOT NULL,
+    event_type TEXT NOT NULL,
+    subject TEXT NOT NULL DEFAULT '',
+    endpoint TEXT NOT NULL,
+    url TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'delivered', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_status_code INTEGER,
+    last_error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    delivered_at TIMESTAMP,
+    UNIQUE (event_id, endpoint)
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due
+    ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subject
+    ON webhook_deliveries(event_type, subject, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS webhook_delivery_attempts (
+    id BIGSERIAL PRIMARY KEY,
+    delivery_id BIGINT NOT NULL REFERENCES webhook_deliveries(id) ON DELETE CASCADE,
+    attempt INTEGER NOT NULL,
+    status_code INTEGER,
+    error TEXT,
+    duration_ms INTEGER NOT NULL DEFAULT 0,
+    attempted_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_attempts_delivery
+    ON webhook_delivery_attempts(delivery_id, attempt);
+
+COMMENT ON TABLE webhook_deliveries IS
+    'One row per event and webhook endpoint; pending rows are posted by the dispatcher in kycserver';
+COMMENT ON COLUMN webhook_deliveries.next_attempt_at IS
+    'When a pending delivery is next due; pushed forward while a dispatcher holds it and by retry backoff';
+COMMENT ON TABLE webhook_delivery_attempts IS
+    'Every HTTP attempt of a webhook delivery with its response status or error';
+
+-- +goose Down
+DROP TABLE IF EXISTS webhook_delivery_attempts;
+DROP TABLE IF EXISTS webhook_deliveries;
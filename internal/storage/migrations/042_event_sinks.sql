@@ -0,0 +1,21 @@
+-- ===========================================================
+-- 042_event_sinks.sql
+-- Events are published to Kafka and NATS as well as webhooks
+-- (events.sinks config); the delivery outbox records which sink
+-- each delivery goes through, so all sinks share its retries and
+-- attempt log
+-- ===========================================================
+
+-- +goose Up
+
+ALTER TABLE webhook_deliveries
+    ADD COLUMN IF NOT EXISTS sink TEXT NOT NULL DEFAULT 'webhook'
+        CHECK (sink IN ('webhook', 'kafka', 'nats'));
+
+COMMENT ON COLUMN webhook_deliveries.sink IS
+    'webhook: POST to url; kafka: produce to the topic in url; nats: publish under the subject prefix in url';
+COMMENT ON COLUMN webhook_deliveries.endpoint IS
+    'Webhook endpoint name, or the sink name for kafka and nats';
+
+-- +goose Down
+ALTER TABLE webhook_deliveries DROP COLUMN IF EXISTS sink;
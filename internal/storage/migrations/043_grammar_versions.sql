@@ -0,0 +1,23 @@
+-- ===========================================================
+-- 043_grammar_versions.sql
+-- Each case version is tagged with the grammar version its DSL is
+-- written in (parser.DetectGrammarVersion), so snapshots older than
+-- the Rust engine's grammar can be found and upgraded by
+-- kycctl grammar-migrate. Versions stored before this migration are
+-- untagged; the migration pipeline detects their version from the DSL.
+-- ===========================================================
+
+-- +goose Up
+
+ALTER TABLE kyc_case_versions
+    ADD COLUMN IF NOT EXISTS grammar_version TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_case_versions_grammar
+    ON kyc_case_versions(grammar_version);
+
+COMMENT ON COLUMN kyc_case_versions.grammar_version IS
+    'KYC-DSL grammar version of dsl_snapshot; NULL for versions stored before tagging';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_case_versions_grammar;
+ALTER TABLE kyc_case_versions DROP COLUMN IF EXISTS grammar_version;
@@ -0,0 +1,54 @@
+-- ===========================================================
+-- 044_lineage_runs.sql
+-- Groups lineage evaluations into runs: one evaluation of a case's
+-- derived attributes from one set of inputs. A run records the case
+-- version, a hash of the inputs, what triggered it and who, and each
+-- evaluation in kyc_lineage_evaluations points at its run. Evaluations
+-- recorded before this migration are grouped into one legacy run per
+-- case, version and evaluation time (the worker records an item in
+-- one transaction, so its evaluations share evaluated_at).
+-- ===========================================================
+
+-- +goose Up
+
+CREATE TABLE IF NOT EXISTS kyc_lineage_runs (
+    id BIGSERIAL PRIMARY KEY,
+    case_name TEXT NOT NULL,
+    case_version INT,
+    inputs_hash TEXT,               -- sha256 of the canonical JSON of the inputs
+    source TEXT NOT NULL,           -- what triggered the run, e.g. a re-evaluation reason
+    actor TEXT,
+    evaluations INT NOT NULL DEFAULT 0,
+    failed INT NOT NULL DEFAULT 0,
+    started_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_lineage_runs_case
+    ON kyc_lineage_runs(case_name, started_at DESC);
+
+ALTER TABLE kyc_lineage_evaluations
+    ADD COLUMN IF NOT EXISTS run_id BIGINT REFERENCES kyc_lineage_runs(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_lineage_evaluations_run
+    ON kyc_lineage_evaluations(run_id);
+
+INSERT INTO kyc_lineage_runs (case_name, case_version, source, evaluations, failed, started_at)
+SELECT case_name, case_version, 'legacy', COUNT(*), COUNT(*) FILTER (WHERE NOT success),
+       evaluated_at
+  FROM kyc_lineage_evaluations
+ WHERE run_id IS NULL AND evaluated_at IS NOT NULL
+ GROUP BY case_name, case_version, evaluated_at;
+
+UPDATE kyc_lineage_evaluations e
+   SET run_id = r.id
+  FROM kyc_lineage_runs r
+ WHERE e.run_id IS NULL
+   AND r.source = 'legacy'
+   AND r.case_name = e.case_name
+   AND r.case_version IS NOT DISTINCT FROM e.case_version
+   AND r.started_at = e.evaluated_at;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_lineage_evaluations_run;
+ALTER TABLE kyc_lineage_evaluations DROP COLUMN IF EXISTS run_id;
+DROP TABLE IF EXISTS kyc_lineage_runs;
@@ -0,0 +1,30 @@
+-- ===========================================================
+-- 045_risk_scores.sql
+-- Customer risk scores (internal/risk): the weighted combination of
+-- a case's derived attributes into a 0-100 score and a
+-- CUSTOMER_RISK_RATING, one per case version, with the contribution
+-- of every factor so the rating can be explained.
+-- ===========================================================
+
+-- +goose Up
+
+CREATE TABLE IF NOT EXISTS kyc_risk_scores (
+    id BIGSERIAL PRIMARY KEY,
+    case_name TEXT NOT NULL,
+    case_version INT NOT NULL,      -- 0 for a case without stored versions
+    score NUMERIC(5,2) NOT NULL,
+    rating TEXT NOT NULL,           -- CRITICAL, HIGH, MEDIUM, LOW
+    factors JSONB NOT NULL,         -- attribute, value, weight, contribution, source
+    actor TEXT,
+    scored_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (case_name, case_version)
+);
+
+CREATE INDEX IF NOT EXISTS idx_risk_scores_rating
+    ON kyc_risk_scores(rating);
+
+COMMENT ON TABLE kyc_risk_scores IS
+    'Latest customer risk score of each case version; rescoring a version replaces it';
+
+-- +goose Down
+DROP TABLE IF EXISTS kyc_risk_scores;
@@ -0,0 +1,128 @@
+-- ===========================================================
+-- 046_country_risk.sql
+-- Jurisdiction risk lists (FATF black and grey lists, EU
+-- high-risk third countries) kept one country per row, so each
+-- listing and de-listing carries its own effective dates.
+-- Rules read them with in_sanctioned_list(COUNTRY, "FATF_GREY_LIST");
+-- list codes share the namespace of the managed lists.
+-- ===========================================================
+
+-- +goose Up
+
+CREATE TABLE IF NOT EXISTS kyc_country_risk_lists (
+    code TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    authority TEXT,                          -- publisher, e.g. FATF, European Commission
+    description TEXT,
+    updated_by TEXT,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT country_risk_list_code_check CHECK (code ~ '^[A-Z][A-Z0-9_]*$')
+);
+
+CREATE TABLE IF NOT EXISTS kyc_country_risk (
+    id SERIAL PRIMARY KEY,
+    list_code TEXT NOT NULL REFERENCES kyc_country_risk_lists(code) ON DELETE CASCADE,
+    country_code TEXT NOT NULL,              -- ISO 3166 alpha-2
+    effective_from DATE NOT NULL DEFAULT CURRENT_DATE,
+    effective_to DATE,                       -- de-listed after this date; open if NULL
+    note TEXT,
+    updated_by TEXT,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (list_code, country_code, effective_from),
+    CONSTRAINT country_risk_country_check CHECK (country_code ~ '^[A-Z]{2}$'),
+    CONSTRAINT country_risk_dates_check CHECK (effective_to IS NULL OR effective_to >= effective_from)
+);
+
+CREATE INDEX IF NOT EXISTS idx_country_risk_country
+    ON kyc_country_risk(country_code);
+
+COMMENT ON TABLE kyc_country_risk IS
+    'Countries on jurisdiction risk lists, one row per listing period';
+
+INSERT INTO kyc_country_risk_lists (code, name, authority, description, updated_by) VALUES
+    ('FATF_BLACK_LIST', 'High-risk jurisdictions subject to a call for action', 'FATF',
+     'Jurisdictions with serious strategic deficiencies; counter-measures or enhanced due diligence apply', 'migration'),
+    ('FATF_GREY_LIST', 'Jurisdictions under increased monitoring', 'FATF',
+     'Jurisdictions actively working with the FATF to address strategic deficiencies', 'migration'),
+    ('EU_HIGH_RISK_THIRD_COUNTRIES', 'EU high-risk third countries', 'European Commission',
+     'Third countries with strategic AML/CFT deficiencies under Delegated Regulation (EU) 2016/1675', 'migration')
+ON CONFLICT (code) DO NOTHING;
+
+INSERT INTO kyc_country_risk (list_code, country_code, effective_from, note, updated_by)
+SELECT 'FATF_BLACK_LIST', c, d::date, 'FATF public statement', 'migration'
+  FROM (VALUES ('KP', '2011-02-25'), ('IR', '2020-02-21'), ('MM', '2022-10-21')) AS t(c, d)
+ON CONFLICT (list_code, country_code, effective_from) DO NOTHING;
+
+INSERT INTO kyc_country_risk (list_code, country_code, effective_from, note, updated_by)
+SELECT 'FATF_GREY_LIST', c, '2025-06-13', 'FATF plenary June 2025', 'migration'
+  FROM unnest(ARRAY['DZ', 'AO', 'BO', 'BG', 'BF', 'CM', 'CI', 'HR', 'CD', 'HT', 'KE', 'LA', 'LB',
+                    'MC', 'MZ', 'NA', 'NP', 'NG', 'ZA', 'SS', 'SY', 'VE', 'VN', 'VG', 'YE']) AS c
+ON CONFLICT (list_code, country_code, effective_from) DO NOTHING;
+
+INSERT INTO kyc_country_risk (list_code, country_code, effective_from, note, updated_by)
+SELECT 'EU_HIGH_RISK_THIRD_COUNTRIES', c, '2024-03-14', 'Commission Delegated Regulation (EU) 2016/1675, as amended', 'migration'
+  FROM unnest(ARRAY['AF', 'BF', 'CD', 'HT', 'IR', 'JM', 'KP', 'ML', 'MM', 'MZ', 'NG', 'PH', 'SN',
+                    'SS', 'SY', 'TZ', 'VU', 'YE', 'ZA']) AS c
+ON CONFLICT (list_code, country_code, effective_from) DO NOTHING;
+
+-- Rules reading a list through either function depend on it
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION enqueue_list_dependents(list_name TEXT)
+RETURNS INT AS $$
+DECLARE
+    queued INT;
+BEGIN
+    INSERT INTO kyc_reevaluation_queue (derived_code, reason, ref)
+    SELECT DISTINCT d.derived_attribute_code, 'list', list_name
+      FROM kyc_attribute_derivations d
+     WHERE d.rule_expression ~ ('in_(sanctioned_)?list\s*\([^,()]+,\s*["'']' || list_name || '["'']\s*\)')
+    ON CONFLICT (case_name, derived_code) WHERE status = 'PENDING' DO NOTHING;
+    GET DIAGNOSTICS queued = ROW_COUNT;
+    RETURN queued;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION enqueue_country_risk_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        PERFORM enqueue_list_dependents(OLD.list_code);
+        RETURN OLD;
+    END IF;
+    PERFORM enqueue_list_dependents(NEW.list_code);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+DROP TRIGGER IF EXISTS country_risk_reevaluation ON kyc_country_risk;
+CREATE TRIGGER country_risk_reevaluation
+    AFTER INSERT OR UPDATE OF country_code, effective_from, effective_to OR DELETE ON kyc_country_risk
+    FOR EACH ROW
+    EXECUTE FUNCTION enqueue_country_risk_change();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS country_risk_reevaluation ON kyc_country_risk;
+DROP FUNCTION IF EXISTS enqueue_country_risk_change();
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION enqueue_list_dependents(list_name TEXT)
+RETURNS INT AS $$
+DECLARE
+    queued INT;
+BEGIN
+    INSERT INTO kyc_reevaluation_queue (derived_code, reason, ref)
+    SELECT DISTINCT d.derived_attribute_code, 'list', list_name
+      FROM kyc_attribute_derivations d
+     WHERE d.rule_expression ~ ('in_list\s*\([^,()]+,\s*["'']' || list_name || '["'']\s*\)')
+    ON CONFLICT (case_name, derived_code) WHERE status = 'PENDING' DO NOTHING;
+    GET DIAGNOSTICS queued = ROW_COUNT;
+    RETURN queued;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+DROP TABLE IF EXISTS kyc_country_risk;
+DROP TABLE IF EXISTS kyc_country_risk_lists;
@@ -0,0 +1,63 @@
+-- ===========================================================
+-- 047_sanctions_screening.sql
+-- Sanctions screening of an entity and its UBOs on demand
+-- (ScreenEntity RPC, kycctl screen). Each screening keeps the
+-- hits with their match scores; the outcome is written to the
+-- case as SANCTIONS_SCREENING_STATUS. Ingests of the OFAC SDN
+-- and EU consolidated lists into screening_list_entries are
+-- recorded with their counts.
+-- ===========================================================
+
+-- +goose Up
+
+CREATE TABLE IF NOT EXISTS kyc_screenings (
+    id SERIAL PRIMARY KEY,
+    entity_id UUID NOT NULL REFERENCES entity(id) ON DELETE CASCADE,
+    case_name TEXT,                          -- case the status was written to, if any
+    status TEXT NOT NULL,                    -- Clear, Potential Match, Match, Under Review
+    subjects INT NOT NULL DEFAULT 0,         -- the entity and its UBOs
+    hits INT NOT NULL DEFAULT 0,
+    providers TEXT[] NOT NULL DEFAULT '{}',
+    errors TEXT,                             -- providers that failed; the status is then Under Review
+    actor TEXT,
+    screened_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT screening_status_check CHECK (status IN ('Clear', 'Potential Match', 'Match', 'Under Review'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_screenings_entity ON kyc_screenings(entity_id, screened_at DESC);
+CREATE INDEX IF NOT EXISTS idx_screenings_case ON kyc_screenings(case_name, screened_at DESC);
+
+CREATE TABLE IF NOT EXISTS kyc_screening_hits (
+    id SERIAL PRIMARY KEY,
+    screening_id INT NOT NULL REFERENCES kyc_screenings(id) ON DELETE CASCADE,
+    subject_entity_id UUID NOT NULL,
+    subject_name TEXT NOT NULL,
+    provider TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    reference TEXT NOT NULL,                 -- provider's identifier for the matched record
+    matched_name TEXT NOT NULL,
+    score NUMERIC(5,4) NOT NULL,             -- name similarity 0..1
+    detail TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_screening_hits_screening ON kyc_screening_hits(screening_id);
+
+CREATE TABLE IF NOT EXISTS screening_list_ingests (
+    id SERIAL PRIMARY KEY,
+    list_name TEXT NOT NULL,                 -- OFAC-SDN, EU-CONSOLIDATED
+    source TEXT,                             -- file or URL ingested
+    entries INT NOT NULL,
+    added INT NOT NULL,
+    updated INT NOT NULL,
+    removed INT NOT NULL,
+    ingested_by TEXT,
+    ingested_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_screening_list_ingests_list
+    ON screening_list_ingests(list_name, ingested_at DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS screening_list_ingests;
+DROP TABLE IF EXISTS kyc_screening_hits;
+DROP TABLE IF EXISTS kyc_screenings;
@@ -0,0 +1,33 @@
+-- ===========================================================
+-- 048_pep_adverse_media_screening.sql
+-- PEP and adverse media screenings are kept alongside sanctions
+-- screenings in kyc_screenings, told apart by kind. Hits carry
+-- an evidence link (the PEP record or news article) so the
+-- PEP_STATUS and ADVERSE_MEDIA_FLAG written to a case can be
+-- traced back to what raised them.
+-- ===========================================================
+
+-- +goose Up
+
+ALTER TABLE kyc_screenings
+    ADD COLUMN IF NOT EXISTS kind TEXT NOT NULL DEFAULT 'sanctions';
+
+ALTER TABLE kyc_screenings DROP CONSTRAINT IF EXISTS screening_kind_check;
+ALTER TABLE kyc_screenings
+    ADD CONSTRAINT screening_kind_check CHECK (kind IN ('sanctions', 'pep', 'adverse_media'));
+
+DROP INDEX IF EXISTS idx_screenings_entity;
+CREATE INDEX IF NOT EXISTS idx_screenings_entity ON kyc_screenings(entity_id, kind, screened_at DESC);
+
+ALTER TABLE kyc_screening_hits
+    ADD COLUMN IF NOT EXISTS evidence_url TEXT;
+
+COMMENT ON COLUMN kyc_screening_hits.evidence_url IS
+    'Link to the source record or news article behind the hit';
+
+-- +goose Down
+ALTER TABLE kyc_screening_hits DROP COLUMN IF EXISTS evidence_url;
+DROP INDEX IF EXISTS idx_screenings_entity;
+CREATE INDEX IF NOT EXISTS idx_screenings_entity ON kyc_screenings(entity_id, screened_at DESC);
+ALTER TABLE kyc_screenings DROP CONSTRAINT IF EXISTS screening_kind_check;
+ALTER TABLE kyc_screenings DROP COLUMN IF EXISTS kind;
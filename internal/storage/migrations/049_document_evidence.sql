@@ -0,0 +1,52 @@
+-- ===========================================================
+-- 049_document_evidence.sql
+-- Collected document evidence: the files behind a case's
+-- document codes, stored by content hash in a local directory,
+-- S3 or minio (internal/evidence). Each row records the file's
+-- sha256, what it evidences (case, document, attribute) and
+-- when it expires; every upload, view and download is logged
+-- with its actor in kyc_evidence_access.
+-- ===========================================================
+
+-- +goose Up
+
+CREATE TABLE IF NOT EXISTS kyc_evidence (
+    id BIGSERIAL PRIMARY KEY,
+    case_name TEXT NOT NULL,
+    document_code TEXT,                      -- kyc_documents.code
+    attribute_code TEXT,                     -- kyc_attributes.code the document evidences
+    file_name TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    sha256 TEXT NOT NULL,                    -- hex digest of the content
+    storage_driver TEXT NOT NULL,            -- local, s3, minio
+    storage_key TEXT NOT NULL,               -- object key within the driver's root or bucket
+    issued_at DATE,
+    expires_at DATE,                         -- given, or issued_at plus the document's validity_years
+    description TEXT,
+    actor TEXT,
+    actor_source TEXT,
+    client_ip TEXT,
+    uploaded_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_evidence_case ON kyc_evidence(case_name, uploaded_at DESC);
+CREATE INDEX IF NOT EXISTS idx_evidence_sha256 ON kyc_evidence(sha256);
+CREATE INDEX IF NOT EXISTS idx_evidence_expires ON kyc_evidence(expires_at) WHERE expires_at IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS kyc_evidence_access (
+    id BIGSERIAL PRIMARY KEY,
+    evidence_id BIGINT NOT NULL REFERENCES kyc_evidence(id) ON DELETE CASCADE,
+    action TEXT NOT NULL,
+    actor TEXT,
+    actor_source TEXT,
+    client_ip TEXT,
+    accessed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT evidence_access_action_check CHECK (action IN ('upload', 'view', 'download'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_evidence_access_evidence ON kyc_evidence_access(evidence_id, accessed_at DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS kyc_evidence_access;
+DROP TABLE IF EXISTS kyc_evidence;
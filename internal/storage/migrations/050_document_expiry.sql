@@ -0,0 +1,44 @@
+-- ===========================================================
+-- 050_document_expiry.sql
+-- Validity periods in days for documents whose evidence is
+-- accepted for months rather than years (proof of address for
+-- 90 days), recorded on each upload, and the flags the expiry
+-- checker (internal/evidence) raises on evidence nearing or
+-- past its expiry date. kyc_evidence_expiry_summaries records
+-- the daily summary event sent for each day.
+-- ===========================================================
+
+-- +goose Up
+
+ALTER TABLE kyc_documents ADD COLUMN IF NOT EXISTS validity_days INT;  -- takes precedence over validity_years
+
+UPDATE kyc_documents SET validity_days = 90
+ WHERE code IN ('UTILITY-BILL', 'BANK-STATEMENT', 'COUNCIL-TAX-BILL') AND validity_days IS NULL;
+
+ALTER TABLE kyc_evidence ADD COLUMN IF NOT EXISTS validity_days INT;   -- validity applied to derive expires_at
+
+CREATE TABLE IF NOT EXISTS kyc_evidence_expiry_flags (
+    evidence_id BIGINT PRIMARY KEY REFERENCES kyc_evidence(id) ON DELETE CASCADE,
+    case_name TEXT NOT NULL,
+    status TEXT NOT NULL,
+    expires_at DATE NOT NULL,
+    flagged_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    status_changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT evidence_expiry_status_check CHECK (status IN ('EXPIRING', 'EXPIRED'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_evidence_expiry_flags_case ON kyc_evidence_expiry_flags(case_name, expires_at);
+
+CREATE TABLE IF NOT EXISTS kyc_evidence_expiry_summaries (
+    summary_date DATE PRIMARY KEY,
+    cases INT NOT NULL,
+    expiring INT NOT NULL,
+    expired INT NOT NULL,
+    sent_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS kyc_evidence_expiry_summaries;
+DROP TABLE IF EXISTS kyc_evidence_expiry_flags;
+ALTER TABLE kyc_evidence DROP COLUMN IF EXISTS validity_days;
+ALTER TABLE kyc_documents DROP COLUMN IF EXISTS validity_days;
@@ -0,0 +1,90 @@
+-- ===========================================================
+-- 051_evidence_text.sql
+-- Text extracted from uploaded evidence (internal/evidence):
+-- kyc_evidence tracks each upload's extraction, the text is
+-- kept per page in kyc_evidence_pages and, for evidence of a
+-- known document, embedded into kyc_document_sections with
+-- the evidence and case it came from. Sections without an
+-- evidence_id are regulatory text from kycctl ingest-document.
+-- ===========================================================
+
+-- +goose Up
+
+ALTER TABLE kyc_evidence
+    ADD COLUMN IF NOT EXISTS text_status TEXT NOT NULL DEFAULT 'pending',
+    ADD COLUMN IF NOT EXISTS text_extractor TEXT,          -- builtin, pdftotext, tesseract, api
+    ADD COLUMN IF NOT EXISTS text_error TEXT,
+    ADD COLUMN IF NOT EXISTS text_attempts INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS text_updated_at TIMESTAMP;
+
+ALTER TABLE kyc_evidence ADD CONSTRAINT evidence_text_status_check
+    CHECK (text_status IN ('pending', 'extracting', 'extracted', 'unsupported', 'failed'));
+
+CREATE INDEX IF NOT EXISTS idx_evidence_text_pending
+    ON kyc_evidence(id) WHERE text_status IN ('pending', 'extracting');
+
+CREATE TABLE IF NOT EXISTS kyc_evidence_pages (
+    evidence_id BIGINT NOT NULL REFERENCES kyc_evidence(id) ON DELETE CASCADE,
+    page_number INT NOT NULL,
+    text TEXT NOT NULL,
+    PRIMARY KEY (evidence_id, page_number)
+);
+
+ALTER TABLE kyc_document_sections
+    ADD COLUMN IF NOT EXISTS evidence_id BIGINT REFERENCES kyc_evidence(id) ON DELETE CASCADE,
+    ADD COLUMN IF NOT EXISTS case_name TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_doc_sections_evidence
+    ON kyc_document_sections(case_name, evidence_id) WHERE evidence_id IS NOT NULL;
+
+CREATE OR REPLACE VIEW document_section_context AS
+SELECT
+    s.id as section_id,
+    s.section_number,
+    s.section_title,
+    s.text_excerpt,
+    s.page_number,
+    d.code as document_code,
+    d.title as document_title,
+    d.jurisdiction,
+    d.doc_type,
+    r.code as regulation_code,
+    r.title as regulation_title,
+    s.evidence_id,
+    s.case_name
+FROM kyc_document_sections s
+JOIN kyc_documents d ON d.code = s.document_code
+LEFT JOIN kyc_regulations r ON r.code = d.regulation_code
+ORDER BY d.code, s.section_number;
+
+-- +goose Down
+DROP VIEW IF EXISTS document_section_context;
+CREATE VIEW document_section_context AS
+SELECT
+    s.id as section_id,
+    s.section_number,
+    s.section_title,
+    s.text_excerpt,
+    s.page_number,
+    d.code as document_code,
+    d.title as document_title,
+    d.jurisdiction,
+    d.doc_type,
+    r.code as regulation_code,
+    r.title as regulation_title
+FROM kyc_document_sections s
+JOIN kyc_documents d ON d.code = s.document_code
+LEFT JOIN kyc_regulations r ON r.code = d.regulation_code
+ORDER BY d.code, s.section_number;
+DELETE FROM kyc_document_sections WHERE evidence_id IS NOT NULL;
+DROP INDEX IF EXISTS idx_doc_sections_evidence;
+ALTER TABLE kyc_document_sections DROP COLUMN IF EXISTS case_name, DROP COLUMN IF EXISTS evidence_id;
+DROP TABLE IF EXISTS kyc_evidence_pages;
+DROP INDEX IF EXISTS idx_evidence_text_pending;
+ALTER TABLE kyc_evidence DROP CONSTRAINT IF EXISTS evidence_text_status_check;
+ALTER TABLE kyc_evidence
+    DROP COLUMN IF EXISTS text_updated_at,
+    DROP COLUMN IF EXISTS text_attempts,
+    DROP COLUMN IF EXISTS text_error,
+    DROP COLUMN IF EXISTS text_extractor,
+    DROP COLUMN IF EXISTS text_status;
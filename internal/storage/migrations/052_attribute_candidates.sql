@@ -0,0 +1,42 @@
+-- ===========================================================
+-- 052_attribute_candidates.sql
+-- Attribute values proposed from the extracted text of
+-- evidence (internal/evidence): the chat model reads the
+-- excerpts retrieved for each attribute and proposes a value
+-- with a confidence and the excerpts it relied on. Nothing is
+-- committed to the case; an analyst confirms (optionally
+-- correcting the value) or rejects each candidate. Proposing
+-- again supersedes the pending candidates of the evidence.
+-- ===========================================================
+
+-- +goose Up
+
+CREATE TABLE IF NOT EXISTS kyc_attribute_candidates (
+    id BIGSERIAL PRIMARY KEY,
+    case_name TEXT NOT NULL,
+    evidence_id BIGINT NOT NULL REFERENCES kyc_evidence(id) ON DELETE CASCADE,
+    attribute_code TEXT NOT NULL,
+    value TEXT NOT NULL,
+    confidence NUMERIC(4,3) NOT NULL CHECK (confidence BETWEEN 0 AND 1),
+    -- [{"ref":1,"section_id":..,"page":..,"excerpt":".."}] quoted to the model
+    citations JSONB NOT NULL DEFAULT '[]',
+    quote TEXT,                          -- text the model cited for the value
+    quote_verified BOOLEAN NOT NULL DEFAULT FALSE,
+    model TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'confirmed', 'rejected', 'superseded')),
+    proposed_by TEXT,
+    proposed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    confirmed_value TEXT,                -- the value as confirmed, if corrected
+    decided_by TEXT,
+    decision_note TEXT,
+    decided_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_attribute_candidates_case
+    ON kyc_attribute_candidates(case_name, status, attribute_code);
+CREATE INDEX IF NOT EXISTS idx_attribute_candidates_evidence
+    ON kyc_attribute_candidates(evidence_id) WHERE status = 'pending';
+
+-- +goose Down
+DROP TABLE IF EXISTS kyc_attribute_candidates;
@@ -0,0 +1,34 @@
+-- ===========================================================
+-- 053_case_attribute_values.sql
+-- Public attribute values captured for a case (internal/casedata),
+-- per case version. A version's values are the latest captured
+-- at or before it; the values of a case's current version are
+-- mirrored into kyc_case_data_dictionary as non-derived entries
+-- so lineage evaluation and exports read them from one place.
+-- ===========================================================
+
+-- +goose Up
+
+CREATE TABLE IF NOT EXISTS kyc_case_attribute_values (
+    id BIGSERIAL PRIMARY KEY,
+    case_name TEXT NOT NULL,
+    case_version INT NOT NULL,
+    attribute_code TEXT NOT NULL,            -- kyc_attributes.code
+    value TEXT NOT NULL,                     -- canonical text of the typed value
+    value_type TEXT NOT NULL,                -- data_type the value was validated against
+    source_document TEXT,                    -- kyc_documents.code the value was read from
+    evidence_id BIGINT REFERENCES kyc_evidence(id) ON DELETE SET NULL,
+    captured_by TEXT NOT NULL,
+    captured_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (case_name, case_version, attribute_code)
+);
+
+CREATE INDEX IF NOT EXISTS idx_case_attribute_values_attribute
+    ON kyc_case_attribute_values(case_name, attribute_code, case_version DESC);
+
+COMMENT ON TABLE kyc_case_attribute_values IS
+    'Captured public attribute values per case version; a version sees the latest value at or before it';
+
+-- +goose Down
+DROP TABLE IF EXISTS kyc_case_attribute_values;
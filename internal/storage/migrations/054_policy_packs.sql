@@ -0,0 +1,125 @@
+-- ===========================================================
+-- 054_policy_packs.sql
+-- Jurisdiction policy packs: the documents, attributes and
+-- thresholds a regulation (AMLD5, MAS 626, BSA) requires of a
+-- case, kept as data rather than in the amendments. Packs are
+-- versioned; a version is drafted, activated (retiring the
+-- previous active version of the pack) and never edited, so a
+-- case's requirements can be traced to the version applied.
+-- The policy-discovery and document-discovery amendments and
+-- the completeness report read the active versions.
+-- ===========================================================
+
+-- +goose Up
+
+CREATE TABLE IF NOT EXISTS kyc_policy_packs (
+    id SERIAL PRIMARY KEY,
+    code TEXT NOT NULL,
+    version INT NOT NULL,
+    name TEXT NOT NULL,
+    jurisdiction TEXT NOT NULL,              -- EU, SG, US... as in (document-requirements (jurisdiction ...))
+    regulation_code TEXT,                    -- kyc_regulations.code
+    policy_code TEXT,                        -- (policy ...) added by policy-discovery
+    obligations TEXT[] NOT NULL DEFAULT '{}', -- (obligation ...) added by policy-discovery
+    description TEXT,
+    status TEXT NOT NULL DEFAULT 'draft',
+    created_by TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    status_changed_by TEXT,
+    status_changed_at TIMESTAMP,
+    UNIQUE (code, version),
+    CONSTRAINT policy_pack_code_check CHECK (code ~ '^[A-Z][A-Z0-9_-]*$'),
+    CONSTRAINT policy_pack_status_check CHECK (status IN ('draft', 'active', 'retired'))
+);
+
+-- At most one active version of a pack
+CREATE UNIQUE INDEX IF NOT EXISTS idx_policy_packs_active
+    ON kyc_policy_packs(code) WHERE status = 'active';
+CREATE INDEX IF NOT EXISTS idx_policy_packs_jurisdiction
+    ON kyc_policy_packs(jurisdiction) WHERE status = 'active';
+
+-- Codes are checked against kyc_documents and kyc_attributes when a
+-- version is drafted; the ontology seed may load after this migration
+CREATE TABLE IF NOT EXISTS kyc_policy_pack_documents (
+    pack_id INT NOT NULL REFERENCES kyc_policy_packs(id) ON DELETE CASCADE,
+    document_code TEXT NOT NULL,             -- kyc_documents.code
+    mandatory BOOLEAN NOT NULL DEFAULT TRUE,
+    PRIMARY KEY (pack_id, document_code)
+);
+
+CREATE TABLE IF NOT EXISTS kyc_policy_pack_attributes (
+    pack_id INT NOT NULL REFERENCES kyc_policy_packs(id) ON DELETE CASCADE,
+    attribute_code TEXT NOT NULL,            -- kyc_attributes.code
+    mandatory BOOLEAN NOT NULL DEFAULT TRUE,
+    PRIMARY KEY (pack_id, attribute_code)
+);
+
+CREATE TABLE IF NOT EXISTS kyc_policy_pack_thresholds (
+    pack_id INT NOT NULL REFERENCES kyc_policy_packs(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,                      -- e.g. UBO_OWNERSHIP_PERCENT
+    value NUMERIC NOT NULL,
+    unit TEXT,                               -- percent, EUR, days...
+    description TEXT,
+    PRIMARY KEY (pack_id, name)
+);
+
+-- The packs the amendments used to hard-code (AMLD5 for the EU), with
+-- MAS Notice 626 and the Bank Secrecy Act
+INSERT INTO kyc_policy_packs (code, version, name, jurisdiction, regulation_code, policy_code, obligations,
+                              description, status, created_by, status_changed_by, status_changed_at)
+VALUES
+    ('AMLD5', 1, 'EU 5th Anti-Money Laundering Directive', 'EU', 'AMLD5', 'KYCPOL-EU-2025',
+     ARRAY['OBL-UBO-DECLARATION', 'OBL-PEP-001'],
+     'Customer due diligence and beneficial ownership under Directive (EU) 2018/843',
+     'active', 'migration', 'migration', CURRENT_TIMESTAMP),
+    ('MAS626', 1, 'MAS Notice 626', 'SG', 'MAS626', 'KYCPOL-SG-2025',
+     ARRAY['OBL-UBO-DECLARATION'],
+     'AML/CFT customer due diligence for banks in Singapore',
+     'active', 'migration', 'migration', CURRENT_TIMESTAMP),
+    ('BSA', 1, 'Bank Secrecy Act / FinCEN CDD Rule', 'US', 'BSAAML', 'KYCPOL-US-2025',
+     ARRAY['OBL-UBO-DECLARATION', 'OBL-CIP'],
+     'Customer identification and beneficial ownership under 31 CFR 1010.230',
+     'active', 'migration', 'migration', CURRENT_TIMESTAMP)
+ON CONFLICT (code, version) DO NOTHING;
+
+INSERT INTO kyc_policy_pack_documents (pack_id, document_code, mandatory)
+SELECT p.id, d.code, d.mandatory
+  FROM kyc_policy_packs p
+  JOIN (VALUES
+        ('AMLD5', 'CERT-INC', TRUE), ('AMLD5', 'ARTICLES-ASSOC', TRUE), ('AMLD5', 'UBO-DECL', TRUE),
+        ('AMLD5', 'SHARE-REGISTER', TRUE), ('AMLD5', 'DIRECTOR-LIST', TRUE), ('AMLD5', 'PASSPORT', TRUE),
+        ('AMLD5', 'CERT-GOOD-STANDING', FALSE), ('AMLD5', 'MEMORANDUM-ASSOC', FALSE),
+        ('AMLD5', 'OWNERSHIP-CHART', FALSE), ('AMLD5', 'NATIONAL-ID', FALSE),
+        ('AMLD5', 'DRIVERS-LICENSE', FALSE), ('AMLD5', 'UTILITY-BILL', FALSE),
+        ('AMLD5', 'BANK-STATEMENT', FALSE), ('AMLD5', 'AUDITED-FINANCIALS', FALSE),
+        ('AMLD5', 'TAX-RETURN', FALSE), ('AMLD5', 'SOURCE-WEALTH-LETTER', FALSE),
+        ('AMLD5', 'BOARD-RESOLUTION', FALSE), ('AMLD5', 'POA', FALSE),
+        ('MAS626', 'ACRA-PROFILE', TRUE), ('MAS626', 'CERT-INC', TRUE), ('MAS626', 'UBO-DECL', TRUE),
+        ('MAS626', 'DIRECTOR-LIST', TRUE), ('MAS626', 'PASSPORT', TRUE), ('MAS626', 'SHARE-REGISTER', FALSE),
+        ('BSA', 'CERT-INC', TRUE), ('BSA', 'UBO-DECL', TRUE), ('BSA', 'W9', TRUE),
+        ('BSA', 'PASSPORT', TRUE), ('BSA', 'W8BENE', FALSE)
+       ) AS d(pack, code, mandatory) ON d.pack = p.code AND p.version = 1
+ON CONFLICT (pack_id, document_code) DO NOTHING;
+
+INSERT INTO kyc_policy_pack_attributes (pack_id, attribute_code, mandatory)
+SELECT p.id, a.code, TRUE
+  FROM kyc_policy_packs p
+  CROSS JOIN unnest(ARRAY['REGISTERED_NAME', 'UBO_NAME', 'TAX_RESIDENCY_COUNTRY']) AS a(code)
+ WHERE p.code IN ('AMLD5', 'MAS626', 'BSA') AND p.version = 1
+ON CONFLICT (pack_id, attribute_code) DO NOTHING;
+
+INSERT INTO kyc_policy_pack_thresholds (pack_id, name, value, unit, description)
+SELECT p.id, 'UBO_OWNERSHIP_PERCENT', 25, 'percent', t.description
+  FROM kyc_policy_packs p
+  JOIN (VALUES
+        ('AMLD5', 'Ownership above which a natural person is a beneficial owner (Art. 3(6))'),
+        ('MAS626', 'Ownership above which a natural person is a beneficial owner (para. 6.14)'),
+        ('BSA', 'Ownership at or above which a beneficial owner is identified (31 CFR 1010.230)')
+       ) AS t(pack, description) ON t.pack = p.code AND p.version = 1
+ON CONFLICT (pack_id, name) DO NOTHING;
+
+-- +goose Down
+DROP TABLE IF EXISTS kyc_policy_pack_thresholds;
+DROP TABLE IF EXISTS kyc_policy_pack_attributes;
+DROP TABLE IF EXISTS kyc_policy_pack_documents;
+DROP TABLE IF EXISTS kyc_policy_packs;
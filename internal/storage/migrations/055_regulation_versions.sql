@@ -0,0 +1,72 @@
+-- ===========================================================
+-- 055_regulation_versions.sql
+-- Versions of regulation records. Every insert into
+-- kyc_regulations, and every update changing its text (name,
+-- title, citation, summary, description) or effective dates,
+-- records a version, whichever path made the change (seed
+-- reload, embedding ingest, kycctl regulation update). The
+-- impact report (/regulations/{code}/impact, internal/regchange)
+-- compares the current version with the previous one.
+-- ===========================================================
+
+-- +goose Up
+
+CREATE TABLE IF NOT EXISTS kyc_regulation_versions (
+    id BIGSERIAL PRIMARY KEY,
+    regulation_code TEXT NOT NULL REFERENCES kyc_regulations(code) ON DELETE CASCADE,
+    version INT NOT NULL,
+    name TEXT,
+    title TEXT,
+    citation TEXT,
+    summary TEXT,
+    description TEXT,
+    effective_from DATE,
+    effective_to DATE,
+    change_note TEXT,                        -- why the record changed, when given
+    changed_by TEXT,                         -- kyc_regulations.updated_by at the change
+    changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (regulation_code, version)
+);
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION record_regulation_version()
+RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE'
+       AND NEW.name IS NOT DISTINCT FROM OLD.name
+       AND NEW.title IS NOT DISTINCT FROM OLD.title
+       AND NEW.citation IS NOT DISTINCT FROM OLD.citation
+       AND NEW.summary IS NOT DISTINCT FROM OLD.summary
+       AND NEW.description IS NOT DISTINCT FROM OLD.description
+       AND NEW.effective_from IS NOT DISTINCT FROM OLD.effective_from
+       AND NEW.effective_to IS NOT DISTINCT FROM OLD.effective_to THEN
+        RETURN NEW;
+    END IF;
+    INSERT INTO kyc_regulation_versions (regulation_code, version, name, title, citation, summary,
+                                         description, effective_from, effective_to, changed_by)
+    SELECT NEW.code, COALESCE(MAX(version), 0) + 1, NEW.name, NEW.title, NEW.citation, NEW.summary,
+           NEW.description, NEW.effective_from, NEW.effective_to, NEW.updated_by
+      FROM kyc_regulation_versions WHERE regulation_code = NEW.code;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+DROP TRIGGER IF EXISTS record_kyc_regulation_version ON kyc_regulations;
+CREATE TRIGGER record_kyc_regulation_version
+    AFTER INSERT OR UPDATE ON kyc_regulations
+    FOR EACH ROW
+    EXECUTE FUNCTION record_regulation_version();
+
+-- Existing records are their first version
+INSERT INTO kyc_regulation_versions (regulation_code, version, name, title, citation, summary,
+                                     description, effective_from, effective_to, changed_by, changed_at)
+SELECT code, 1, name, title, citation, summary, description, effective_from, effective_to,
+       updated_by, COALESCE(updated_at, created_at, CURRENT_TIMESTAMP)
+  FROM kyc_regulations
+ON CONFLICT (regulation_code, version) DO NOTHING;
+
+-- +goose Down
+DROP TRIGGER IF EXISTS record_kyc_regulation_version ON kyc_regulations;
+DROP FUNCTION IF EXISTS record_regulation_version();
+DROP TABLE IF EXISTS kyc_regulation_versions;
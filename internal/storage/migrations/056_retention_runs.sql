@@ -0,0 +1,32 @@
+-- ===========================================================
+-- 056_retention_runs.sql
+-- Runs of the retention job (internal/retention): per table,
+-- the cutoff applied, how many expired rows were found,
+-- archived and deleted, and the archive objects written.
+-- Dry runs are recorded with nothing archived or deleted.
+-- ===========================================================
+
+-- +goose Up
+
+CREATE TABLE IF NOT EXISTS kyc_retention_runs (
+    id BIGSERIAL PRIMARY KEY,
+    table_name TEXT NOT NULL,                -- rag_audit_log, kyc_case_amendments, rag_feedback
+    cutoff TIMESTAMP NOT NULL,               -- rows created before this expired
+    dry_run BOOLEAN NOT NULL DEFAULT FALSE,
+    matched INT NOT NULL DEFAULT 0,          -- expired rows found
+    archived INT NOT NULL DEFAULT 0,         -- rows written to the archive
+    deleted INT NOT NULL DEFAULT 0,          -- rows deleted
+    archive_keys TEXT[] NOT NULL DEFAULT '{}',
+    status TEXT NOT NULL DEFAULT 'running'
+        CHECK (status IN ('running', 'completed', 'failed')),
+    error_message TEXT,
+    triggered_by TEXT,                       -- kycctl, scheduler
+    started_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_retention_runs_table
+    ON kyc_retention_runs(table_name, started_at DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS kyc_retention_runs;
@@ -0,0 +1,22 @@
+-- ===========================================================
+-- 057_siem_sink.sql
+-- Compliance-relevant events (validation failures, approvals
+-- and declines, evidence access, admin actions) can be sent to
+-- a SIEM over syslog (events.siem config) through the same
+-- delivery outbox, retries and attempt log as the other sinks
+-- ===========================================================
+
+-- +goose Up
+
+ALTER TABLE webhook_deliveries DROP CONSTRAINT IF EXISTS webhook_deliveries_sink_check;
+ALTER TABLE webhook_deliveries ADD CONSTRAINT webhook_deliveries_sink_check
+    CHECK (sink IN ('webhook', 'kafka', 'nats', 'siem'));
+
+COMMENT ON COLUMN webhook_deliveries.sink IS
+    'webhook: POST to url; kafka: produce to the topic in url; nats: publish under the subject prefix in url; siem: syslog message to the receiver in url';
+
+-- +goose Down
+DELETE FROM webhook_deliveries WHERE sink = 'siem';
+ALTER TABLE webhook_deliveries DROP CONSTRAINT IF EXISTS webhook_deliveries_sink_check;
+ALTER TABLE webhook_deliveries ADD CONSTRAINT webhook_deliveries_sink_check
+    CHECK (sink IN ('webhook', 'kafka', 'nats'));
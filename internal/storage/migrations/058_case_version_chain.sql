@@ -0,0 +1,101 @@
+-- ===========================================================
+-- 058_case_version_chain.sql
+-- Hash chain over the versions of each case. Every version
+-- stores the chain hash of the version before it (prev_hash,
+-- empty for version 1) and its own chain hash, the SHA-256 of
+-- prev_hash, a newline and its DSL snapshot. Editing a snapshot,
+-- or deleting or reordering a version, breaks the chain from
+-- there on (kycctl verify-integrity, VerifyCaseIntegrity RPC).
+-- The chain is computed on insert, whichever path stores the
+-- version, and chained columns cannot be updated afterwards.
+-- ===========================================================
+
+-- +goose Up
+
+ALTER TABLE kyc_case_versions
+    ADD COLUMN IF NOT EXISTS prev_hash TEXT,
+    ADD COLUMN IF NOT EXISTS chain_hash TEXT;
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION case_version_chain_hash(prev TEXT, dsl TEXT)
+RETURNS TEXT AS $$
+    SELECT encode(sha256(convert_to(COALESCE(prev, '') || E'\n' || COALESCE(dsl, ''), 'UTF8')), 'hex')
+$$ LANGUAGE sql IMMUTABLE;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION chain_case_version()
+RETURNS trigger AS $$
+BEGIN
+    SELECT COALESCE(chain_hash, '') INTO NEW.prev_hash
+    FROM kyc_case_versions
+    WHERE case_name = NEW.case_name AND version < NEW.version
+    ORDER BY version DESC, id DESC
+    LIMIT 1;
+    NEW.prev_hash := COALESCE(NEW.prev_hash, '');
+    NEW.chain_hash := case_version_chain_hash(NEW.prev_hash, NEW.dsl_snapshot);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- Backfill existing versions in order, case by case
+-- +goose StatementBegin
+DO $$
+DECLARE
+    v RECORD;
+    prev TEXT := '';
+    current_case TEXT := NULL;
+BEGIN
+    FOR v IN SELECT id, case_name, dsl_snapshot FROM kyc_case_versions ORDER BY case_name, version, id LOOP
+        IF current_case IS DISTINCT FROM v.case_name THEN
+            prev := '';
+            current_case := v.case_name;
+        END IF;
+        UPDATE kyc_case_versions
+        SET prev_hash = prev, chain_hash = case_version_chain_hash(prev, v.dsl_snapshot)
+        WHERE id = v.id
+        RETURNING chain_hash INTO prev;
+    END LOOP;
+END;
+$$;
+-- +goose StatementEnd
+
+DROP TRIGGER IF EXISTS chain_kyc_case_version ON kyc_case_versions;
+CREATE TRIGGER chain_kyc_case_version
+    BEFORE INSERT ON kyc_case_versions
+    FOR EACH ROW EXECUTE FUNCTION chain_case_version();
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION protect_case_version_chain()
+RETURNS trigger AS $$
+BEGIN
+    IF NEW.case_name IS DISTINCT FROM OLD.case_name
+       OR NEW.version IS DISTINCT FROM OLD.version
+       OR NEW.dsl_snapshot IS DISTINCT FROM OLD.dsl_snapshot
+       OR NEW.hash IS DISTINCT FROM OLD.hash
+       OR NEW.prev_hash IS DISTINCT FROM OLD.prev_hash
+       OR NEW.chain_hash IS DISTINCT FROM OLD.chain_hash THEN
+        RAISE EXCEPTION 'case version % of % is immutable', OLD.version, OLD.case_name
+            USING ERRCODE = 'integrity_constraint_violation';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+DROP TRIGGER IF EXISTS protect_kyc_case_version ON kyc_case_versions;
+CREATE TRIGGER protect_kyc_case_version
+    BEFORE UPDATE ON kyc_case_versions
+    FOR EACH ROW EXECUTE FUNCTION protect_case_version_chain();
+
+COMMENT ON COLUMN kyc_case_versions.chain_hash IS
+    'SHA-256 of prev_hash, a newline and dsl_snapshot; prev_hash is the chain_hash of the previous version';
+
+-- +goose Down
+DROP TRIGGER IF EXISTS protect_kyc_case_version ON kyc_case_versions;
+DROP FUNCTION IF EXISTS protect_case_version_chain();
+DROP TRIGGER IF EXISTS chain_kyc_case_version ON kyc_case_versions;
+DROP FUNCTION IF EXISTS chain_case_version();
+DROP FUNCTION IF EXISTS case_version_chain_hash(TEXT, TEXT);
+ALTER TABLE kyc_case_versions DROP COLUMN IF EXISTS prev_hash, DROP COLUMN IF EXISTS chain_hash;
@@ -0,0 +1,32 @@
+-- ===========================================================
+-- 059_case_signatures.sql
+-- Digital signatures of approved case versions (internal/signing).
+-- Approving a case signs a statement of its latest version: case
+-- name, version, content hash and chain hash (058). The public key
+-- is kept with the signature so it verifies without the signer;
+-- key_id and the fingerprint say which key to trust.
+-- ===========================================================
+
+-- +goose Up
+
+CREATE TABLE IF NOT EXISTS kyc_case_signatures (
+    id SERIAL PRIMARY KEY,
+    case_name TEXT NOT NULL,
+    version INT NOT NULL,
+    version_hash TEXT NOT NULL,              -- kyc_case_versions.hash
+    chain_hash TEXT NOT NULL,                -- kyc_case_versions.chain_hash
+    algorithm TEXT NOT NULL,                 -- ed25519, ecdsa-sha256, rsa-sha256
+    key_id TEXT NOT NULL,
+    key_fingerprint TEXT NOT NULL,           -- sha256 of the DER public key
+    public_key TEXT NOT NULL,                -- PEM
+    signature TEXT NOT NULL,                 -- base64
+    transition_id INT,                       -- kyc_case_transitions.id of the approval
+    signed_by TEXT NOT NULL,
+    signed_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_case_signatures_case
+    ON kyc_case_signatures(case_name, version, signed_at DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS kyc_case_signatures;
@@ -0,0 +1,94 @@
+-- ===========================================================
+-- 060_ontology_overlays.sql
+-- Ontology overlays (internal/overlay): a tenant or business unit
+-- extends the global ontology with its own attributes, documents,
+-- attribute-document links and derivation rules without changing
+-- the shared tables. Validation, the discovery amendments and RAG
+-- attribute search resolve a code in the tenant's overlay first,
+-- then in the global ontology; an overlay entry with a global code
+-- overrides it for that tenant only.
+-- Cases belong to a tenant (kyc_cases.tenant), whose overlay
+-- applies when they are validated and amended.
+-- ===========================================================
+
+-- +goose Up
+
+ALTER TABLE kyc_cases ADD COLUMN IF NOT EXISTS tenant TEXT NOT NULL DEFAULT 'default';
+
+CREATE TABLE IF NOT EXISTS kyc_overlay_attributes (
+    id SERIAL PRIMARY KEY,
+    tenant TEXT NOT NULL,
+    code TEXT NOT NULL,
+    name TEXT NOT NULL,
+    domain TEXT,
+    description TEXT,
+    risk_category TEXT,
+    is_personal_data BOOLEAN NOT NULL DEFAULT FALSE,
+    attribute_class TEXT NOT NULL DEFAULT 'Public'
+        CHECK (attribute_class IN ('Public', 'Private')),
+    data_type TEXT,
+    domain_values TEXT[] NOT NULL DEFAULT '{}',
+    synonyms TEXT[] NOT NULL DEFAULT '{}',
+    business_context TEXT,
+    embedding vector(1536),                  -- primary space, searched with the global metadata
+    updated_by TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (tenant, code)
+);
+
+CREATE TABLE IF NOT EXISTS kyc_overlay_documents (
+    id SERIAL PRIMARY KEY,
+    tenant TEXT NOT NULL,
+    code TEXT NOT NULL,
+    name TEXT NOT NULL,
+    domain TEXT,
+    jurisdiction TEXT,
+    regulation_code TEXT,
+    description TEXT,
+    validity_years INT,
+    updated_by TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (tenant, code)
+);
+
+-- Links between overlay or global attributes and documents; for an
+-- attribute the tenant links, they come before the global links
+CREATE TABLE IF NOT EXISTS kyc_overlay_attr_doc_links (
+    id SERIAL PRIMARY KEY,
+    tenant TEXT NOT NULL,
+    attribute_code TEXT NOT NULL,
+    document_code TEXT NOT NULL,
+    source_tier TEXT NOT NULL DEFAULT 'Primary'
+        CHECK (source_tier IN ('Primary', 'Secondary', 'Tertiary')),
+    is_mandatory BOOLEAN NOT NULL DEFAULT FALSE,
+    jurisdiction TEXT,
+    notes TEXT,
+    UNIQUE (tenant, attribute_code, document_code)
+);
+
+-- Derivation rules of overlay or global private attributes; a tenant's
+-- rules for an attribute replace the global ones
+CREATE TABLE IF NOT EXISTS kyc_overlay_derivations (
+    id SERIAL PRIMARY KEY,
+    tenant TEXT NOT NULL,
+    derived_attribute_code TEXT NOT NULL,
+    source_attribute_code TEXT NOT NULL,
+    rule_expression TEXT NOT NULL,
+    rule_type TEXT NOT NULL,
+    description TEXT,
+    UNIQUE (tenant, derived_attribute_code, source_attribute_code)
+);
+
+CREATE INDEX IF NOT EXISTS idx_overlay_links_attribute
+    ON kyc_overlay_attr_doc_links(tenant, attribute_code);
+CREATE INDEX IF NOT EXISTS idx_overlay_derivations_derived
+    ON kyc_overlay_derivations(tenant, derived_attribute_code);
+
+-- +goose Down
+DROP TABLE IF EXISTS kyc_overlay_derivations;
+DROP TABLE IF EXISTS kyc_overlay_attr_doc_links;
+DROP TABLE IF EXISTS kyc_overlay_documents;
+DROP TABLE IF EXISTS kyc_overlay_attributes;
+ALTER TABLE kyc_cases DROP COLUMN IF EXISTS tenant;
@@ -0,0 +1,29 @@
+-- ===========================================================
+-- 061_pii_encryption.sql
+-- Envelope encryption of personal data attribute values
+-- (internal/pii). An encrypted value holds its wrapped data key
+-- and the AES-GCM ciphertext; key_id names the key encryption
+-- key that wrapped the data key, so rotation (kycctl encryption
+-- rotate) finds the values still under a retired key. Plaintext
+-- values have no key_id. Captured values mirrored into the case
+-- data dictionary stay encrypted there.
+-- ===========================================================
+
+-- +goose Up
+
+ALTER TABLE kyc_case_attribute_values ADD COLUMN IF NOT EXISTS key_id TEXT;
+ALTER TABLE kyc_case_data_dictionary ADD COLUMN IF NOT EXISTS key_id TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_case_attribute_values_key
+    ON kyc_case_attribute_values(key_id) WHERE key_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_case_data_dictionary_key
+    ON kyc_case_data_dictionary(key_id) WHERE key_id IS NOT NULL;
+
+COMMENT ON COLUMN kyc_case_attribute_values.key_id IS
+    'Key encryption key of an encrypted value (internal/pii); NULL for plaintext';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_case_data_dictionary_key;
+DROP INDEX IF EXISTS idx_case_attribute_values_key;
+ALTER TABLE kyc_case_data_dictionary DROP COLUMN IF EXISTS key_id;
+ALTER TABLE kyc_case_attribute_values DROP COLUMN IF EXISTS key_id;
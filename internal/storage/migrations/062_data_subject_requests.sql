@@ -0,0 +1,45 @@
+-- ===========================================================
+-- 062_data_subject_requests.sql
+-- GDPR data subject requests (internal/dsr, kycctl dsr). Every
+-- export and erasure is recorded in kyc_dsr_requests by the
+-- SHA-256 of the normalized subject, never the subject itself,
+-- with what was found, erased and retained per source. Erased
+-- evidence keeps its row, with the file name redacted and the
+-- content and extracted text removed; erased values keep their
+-- row with key_id 'erased' and no value.
+-- ===========================================================
+
+-- +goose Up
+
+CREATE TABLE IF NOT EXISTS kyc_dsr_requests (
+    id BIGSERIAL PRIMARY KEY,
+    kind TEXT NOT NULL CHECK (kind IN ('export', 'erase')),
+    subject_hash TEXT NOT NULL,              -- hex SHA-256 of the lower-cased, trimmed subject
+    dry_run BOOLEAN NOT NULL DEFAULT FALSE,
+    located INT NOT NULL DEFAULT 0,          -- records mentioning the subject
+    erased INT NOT NULL DEFAULT 0,
+    retained INT NOT NULL DEFAULT 0,         -- kept as regulatory records
+    sources JSONB NOT NULL DEFAULT '[]',     -- counts and retention reasons per table, no values
+    requested_by TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_dsr_requests_subject
+    ON kyc_dsr_requests(subject_hash, created_at DESC);
+
+ALTER TABLE kyc_evidence ADD COLUMN IF NOT EXISTS erased_at TIMESTAMP;
+
+ALTER TABLE kyc_evidence DROP CONSTRAINT IF EXISTS evidence_text_status_check;
+ALTER TABLE kyc_evidence ADD CONSTRAINT evidence_text_status_check
+    CHECK (text_status IN ('pending', 'extracting', 'extracted', 'unsupported', 'failed', 'erased'));
+
+COMMENT ON TABLE kyc_dsr_requests IS
+    'Data subject exports and erasures; the subject is identified by hash only';
+
+-- +goose Down
+UPDATE kyc_evidence SET text_status = 'unsupported' WHERE text_status = 'erased';
+ALTER TABLE kyc_evidence DROP CONSTRAINT IF EXISTS evidence_text_status_check;
+ALTER TABLE kyc_evidence ADD CONSTRAINT evidence_text_status_check
+    CHECK (text_status IN ('pending', 'extracting', 'extracted', 'unsupported', 'failed'));
+ALTER TABLE kyc_evidence DROP COLUMN IF EXISTS erased_at;
+DROP TABLE IF EXISTS kyc_dsr_requests;
@@ -0,0 +1,32 @@
+-- ===========================================================
+-- 063_pii_access_log.sql
+-- Reads of sensitive values (internal/redact). API responses
+-- mask the values of personal data and CRITICAL risk
+-- attributes, and the text of case evidence, for callers
+-- without the pii:read scope; every response returning them
+-- unredacted is recorded here with its actor and the fields
+-- revealed (attribute codes, or evidence:<id>), never the
+-- values themselves.
+-- ===========================================================
+
+-- +goose Up
+
+CREATE TABLE IF NOT EXISTS kyc_pii_access (
+    id BIGSERIAL PRIMARY KEY,
+    endpoint TEXT NOT NULL,
+    case_name TEXT,
+    fields TEXT[] NOT NULL,
+    actor TEXT,
+    actor_source TEXT,
+    client_ip TEXT,
+    accessed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_pii_access_case ON kyc_pii_access(case_name, accessed_at DESC);
+CREATE INDEX IF NOT EXISTS idx_pii_access_actor ON kyc_pii_access(actor, accessed_at DESC);
+
+COMMENT ON TABLE kyc_pii_access IS
+    'Unredacted reads of sensitive attribute values and case evidence text';
+
+-- +goose Down
+DROP TABLE IF EXISTS kyc_pii_access;
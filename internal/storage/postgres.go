@@ -1,131 +1,85 @@
 package storage
 
 import (
-	"crypto/sha256"
+	"context"
+	"database/sql"
 	"fmt"
-	"log"
-	"os"
+	"log/slog"
 	"time"
 
+	"github.com/adamtc007/KYC-DSL/internal/actor"
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	kycdb "github.com/adamtc007/KYC-DSL/internal/db"
+	"github.com/adamtc007/KYC-DSL/internal/events"
 	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/parser"
 	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq"
 )
 
 const DEBUG = true
 
 func debugLog(format string, args ...interface{}) {
 	if DEBUG {
-		log.Printf("[STORAGE DEBUG] "+format, args...)
+		slog.Debug(fmt.Sprintf(format, args...), "component", "storage")
 	}
 }
 
+// ConnectPostgres opens the database and checks the schema is migrated,
+// applying pending migrations when database.auto_migrate is set
 func ConnectPostgres() (*sqlx.DB, error) {
-	debugLog("=== STORAGE BREAKPOINT 1: ConnectPostgres called ===")
-	host := os.Getenv("PGHOST")
-	if host == "" {
-		host = "localhost"
-	}
-	port := os.Getenv("PGPORT")
-	if port == "" {
-		port = "5432"
-	}
-	user := os.Getenv("PGUSER")
-	if user == "" {
-		user = "adamtc007"
+	db, err := OpenPostgres()
+	if err != nil {
+		return nil, err
 	}
-	password := os.Getenv("PGPASSWORD")
-	dbname := os.Getenv("PGDATABASE")
-	if dbname == "" {
-		dbname = "kyc_dsl"
+
+	debugLog("=== STORAGE BREAKPOINT 3: Checking schema migrations ===")
+	// Schema is owned by the embedded migrations (kycctl migrate up)
+	if err := checkMigrations(context.Background(), db, config.Current().Database.AutoMigrate); err != nil {
+		if closeErr := db.Close(); closeErr != nil {
+			debugLog("Failed to close database after migration error: %v", closeErr)
+		}
+		debugLog("Schema migration check failed: %v", err)
+		return nil, fmt.Errorf("schema migration failed: %w", err)
 	}
+	debugLog("Schema verified successfully")
+	return db, nil
+}
 
-	debugLog("Connection parameters: host=%s, port=%s, user=%s, dbname=%s", host, port, user, dbname)
+// OpenPostgres returns a sqlx handle on the process-wide connection pool
+// (internal/db) without touching the schema. Closing the handle leaves the
+// pool open for other components.
+func OpenPostgres() (*sqlx.DB, error) {
+	debugLog("=== STORAGE BREAKPOINT 1: OpenPostgres called ===")
+	dbCfg := config.Current().Database
 
-	connStr := fmt.Sprintf("host=%s port=%s user=%s dbname=%s sslmode=disable", host, port, user, dbname)
-	if password != "" {
-		connStr = fmt.Sprintf("%s password=%s", connStr, password)
-	}
+	debugLog("Connection parameters: %s, user=%s", dbCfg.Describe(), dbCfg.User)
 
 	debugLog("=== STORAGE BREAKPOINT 2: Attempting to connect ===")
-	db, err := sqlx.Connect("postgres", connStr)
+	pool, err := kycdb.Shared(context.Background())
 	if err != nil {
 		debugLog("Connection failed: %v", err)
-		return nil, fmt.Errorf("postgres connection failed (host=%s, port=%s, dbname=%s): %w", host, port, dbname, err)
+		return nil, fmt.Errorf("postgres connection failed (%s): %w", dbCfg.Describe(), err)
 	}
 	debugLog("Connection successful")
 
-	// Test the connection
-	if err := db.Ping(); err != nil {
-		if closeErr := db.Close(); closeErr != nil {
-			debugLog("Failed to close database after ping failure: %v", closeErr)
-		}
-		debugLog("Connection ping failed: %v", err)
-		return nil, fmt.Errorf("postgres ping failed: %w", err)
-	}
-
-	// Set connection pool limits
-	db.SetMaxOpenConns(25)
-	db.SetMaxIdleConns(5)
-
-	debugLog("=== STORAGE BREAKPOINT 3: Creating schema ===")
-	schema := `
-	CREATE TABLE IF NOT EXISTS kyc_cases (
-		id SERIAL PRIMARY KEY,
-		name TEXT NOT NULL,
-		version INT DEFAULT 1,
-		status TEXT DEFAULT 'pending',
-		last_updated TIMESTAMP DEFAULT NOW()
-	);
-
-	CREATE TABLE IF NOT EXISTS kyc_case_versions (
-		id SERIAL PRIMARY KEY,
-		case_name TEXT NOT NULL,
-		version INT NOT NULL,
-		dsl_snapshot TEXT,
-		hash TEXT,
-		created_at TIMESTAMP DEFAULT NOW()
-	);
-
-	CREATE TABLE IF NOT EXISTS kyc_grammar (
-		id SERIAL PRIMARY KEY,
-		name TEXT UNIQUE,
-		version TEXT,
-		ebnf TEXT,
-		created_at TIMESTAMP DEFAULT NOW()
-	);
-
-	CREATE TABLE IF NOT EXISTS kyc_case_amendments (
-		id SERIAL PRIMARY KEY,
-		case_name TEXT NOT NULL,
-		step TEXT NOT NULL,
-		change_type TEXT NOT NULL,
-		diff TEXT,
-		created_at TIMESTAMP DEFAULT NOW()
-	);
-	`
-	if _, err := db.Exec(schema); err != nil {
-		if closeErr := db.Close(); closeErr != nil {
-			debugLog("Failed to close database after schema error: %v", closeErr)
-		}
-		debugLog("Schema creation failed: %v", err)
-		return nil, fmt.Errorf("schema creation failed: %w", err)
-	}
-	debugLog("Schema created/verified successfully")
-	return db, nil
+	return pool.SQLX(), nil
 }
 
-func InsertCase(db *sqlx.DB, name string) error {
+// InsertCase creates a case as a draft, recording the actor in ctx
+// (internal/actor) as its creator. Timestamps come from the database clock.
+func InsertCase(ctx context.Context, db *sqlx.DB, name string) error {
 	if name == "" {
 		return fmt.Errorf("case name cannot be empty")
 	}
+	a := actor.FromContext(ctx)
 
 	debugLog("=== STORAGE BREAKPOINT 4: InsertCase called with name='%s' ===", name)
-	query := `INSERT INTO kyc_cases (name, status, last_updated) VALUES ($1, 'pending', $2)`
+	query := `INSERT INTO kyc_cases (name, status, last_updated, actor, actor_source, client_ip)
+	          VALUES ($1, 'draft', NOW(), $2, $3, NULLIF($4, ''))`
 	debugLog("Executing query: %s", query)
-	debugLog("Parameters: name=%s, timestamp=%v", name, time.Now())
+	debugLog("Parameters: name=%s, actor=%s, source=%s", name, a.Name, a.Source)
 
-	result, err := db.Exec(query, name, time.Now())
+	result, err := db.ExecContext(ctx, query, name, a.Name, a.Source, a.ClientIP)
 	if err != nil {
 		debugLog("Insert failed with error: %v", err)
 		return err
@@ -133,36 +87,60 @@ func InsertCase(db *sqlx.DB, name string) error {
 
 	rowsAffected, _ := result.RowsAffected()
 	debugLog("=== STORAGE BREAKPOINT 5: Insert successful, rows affected: %d ===", rowsAffected)
+
+	// New cases start their lifecycle timeline (internal/engine) as drafts
+	_, err = db.ExecContext(ctx, `INSERT INTO kyc_case_transitions (case_name, to_status, actor, reason, actor_source, client_ip)
+	                  VALUES ($1, 'draft', $2, 'case created', $3, NULLIF($4, ''))`, name, a.Name, a.Source, a.ClientIP)
+	if err != nil {
+		debugLog("Recording case creation failed: %v", err)
+		return fmt.Errorf("record case creation failed: %w", err)
+	}
+	events.Notify(ctx, db, events.Event{
+		Type:     events.CaseCreated,
+		CaseName: name,
+		Data:     map[string]interface{}{"status": "draft"},
+	})
 	return nil
 }
 
-// InsertVersion stores a DSL snapshot with its hash for audit trail.
-func InsertVersion(db *sqlx.DB, caseName string, version int, dsl string) error {
-	hash := sha256Hex(dsl)
-	query := `INSERT INTO kyc_case_versions (case_name, version, dsl_snapshot, hash) VALUES ($1, $2, $3, $4)`
-	_, err := db.Exec(query, caseName, version, dsl, hash)
+// InsertVersion stores a DSL snapshot with its canonical hash for audit trail.
+// The snapshot is tagged with the grammar version it is written in.
+func InsertVersion(ctx context.Context, db *sqlx.DB, caseName string, version int, dsl string) error {
+	grammarVersion, _ := parser.DetectGrammarVersion(dsl)
+	return insertVersion(ctx, db, caseName, version, dsl, grammarVersion)
+}
+
+func insertVersion(ctx context.Context, db *sqlx.DB, caseName string, version int, dsl, grammarVersion string) error {
+	hash := CanonicalHash(dsl)
+	a := actor.FromContext(ctx)
+	query := `INSERT INTO kyc_case_versions (case_name, version, dsl_snapshot, hash, grammar_version, actor, actor_source, client_ip)
+	          VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, NULLIF($8, ''))`
+	_, err := db.ExecContext(ctx, query, caseName, version, dsl, hash, grammarVersion, a.Name, a.Source, a.ClientIP)
 	if err != nil {
 		debugLog("InsertVersion failed: %v", err)
 		return err
 	}
-	debugLog("Version inserted for case=%s version=%d hash=%s", caseName, version, hash)
+	debugLog("Version inserted for case=%s version=%d hash=%s grammar=%s actor=%s", caseName, version, hash, grammarVersion, a.Name)
+	events.Notify(ctx, db, events.Event{
+		Type:     events.CaseVersionSaved,
+		CaseName: caseName,
+		Version:  version,
+		Hash:     hash,
+	})
 	return nil
 }
 
-func sha256Hex(input string) string {
-	sum := sha256.Sum256([]byte(input))
-	return fmt.Sprintf("%x", sum)
-}
-
 // InsertAmendment logs a change to a case for audit trail.
-func InsertAmendment(db *sqlx.DB, caseName, step, changeType, diff string) error {
-	query := `INSERT INTO kyc_case_amendments (case_name, step, change_type, diff) VALUES ($1, $2, $3, $4)`
-	_, err := db.Exec(query, caseName, step, changeType, diff)
+func InsertAmendment(ctx context.Context, db *sqlx.DB, caseName, step, changeType, diff string) error {
+	a := actor.FromContext(ctx)
+	query := `INSERT INTO kyc_case_amendments (case_name, step, change_type, diff, actor, actor_source, client_ip)
+	          VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''))`
+	_, err := db.ExecContext(ctx, query, caseName, step, changeType, diff, a.Name, a.Source, a.ClientIP)
 	if err != nil {
 		debugLog("InsertAmendment failed: %v", err)
 		return fmt.Errorf("insert amendment failed: %w", err)
 	}
-	debugLog("Amendment logged for case=%s step=%s type=%s", caseName, step, changeType)
+	debugLog("Amendment logged for case=%s step=%s type=%s actor=%s", caseName, step, changeType, a.Name)
 	return nil
 }
 
@@ -210,19 +188,57 @@ func GetNextVersion(db *sqlx.DB, caseName string) (int, error) {
 }
 
 // SaveCaseVersion handles auto-versioning and persistence of a serialized DSL snapshot.
-func SaveCaseVersion(db *sqlx.DB, caseName, dsl string) error {
+// Snapshots whose canonical form matches the latest version are not stored again.
+func SaveCaseVersion(ctx context.Context, db *sqlx.DB, caseName, dsl string) error {
+	_, err := SaveCaseVersionIfChanged(ctx, db, caseName, dsl, false)
+	return err
+}
+
+// SaveCaseVersionIfChanged stores dsl as the next version unless its canonical
+// form is unchanged from the latest version; force always stores it. The
+// first version of a case not yet in kyc_cases creates it as a draft.
+// It reports whether a new version was created.
+func SaveCaseVersionIfChanged(ctx context.Context, db *sqlx.DB, caseName, dsl string, force bool) (bool, error) {
+	hash := CanonicalHash(dsl)
+
+	if !force {
+		// Re-hash the stored snapshot so versions saved before canonical
+		// hashing compare correctly
+		var latest struct {
+			Version     int    `db:"version"`
+			DslSnapshot string `db:"dsl_snapshot"`
+		}
+		err := db.Get(&latest, `SELECT version, dsl_snapshot FROM kyc_case_versions
+			WHERE case_name=$1 ORDER BY version DESC LIMIT 1`, caseName)
+		if err != nil && err != sql.ErrNoRows {
+			return false, fmt.Errorf("failed to load latest version: %w", err)
+		}
+		if err == nil && CanonicalHash(latest.DslSnapshot) == hash {
+			slog.Info("Case unchanged", "case_name", caseName, "version", latest.Version, "hash", hash[:12])
+			return false, nil
+		}
+	}
+
 	nextVer, err := GetNextVersion(db, caseName)
 	if err != nil {
-		return fmt.Errorf("failed to get next version: %w", err)
+		return false, fmt.Errorf("failed to get next version: %w", err)
 	}
-	hash := sha256Hex(dsl)
-	query := `INSERT INTO kyc_case_versions (case_name, version, dsl_snapshot, hash) VALUES ($1, $2, $3, $4)`
-	_, err = db.Exec(query, caseName, nextVer, dsl, hash)
-	if err != nil {
-		return fmt.Errorf("insert version failed: %w", err)
+	if nextVer == 1 {
+		exists, err := CaseExists(db, caseName)
+		if err != nil {
+			return false, err
+		}
+		if !exists {
+			if err := InsertCase(ctx, db, caseName); err != nil {
+				return false, fmt.Errorf("failed to create case: %w", err)
+			}
+		}
 	}
-	fmt.Printf("📜 Case %s saved version %d (hash=%s)\n", caseName, nextVer, hash[:12])
-	return nil
+	if err := InsertVersion(ctx, db, caseName, nextVer, dsl); err != nil {
+		return false, fmt.Errorf("insert version failed: %w", err)
+	}
+	slog.Info("Case version saved", "case_name", caseName, "version", nextVer, "hash", hash[:12])
+	return true, nil
 }
 
 // GetLatestDSL fetches the most recent serialized DSL for a case.
@@ -247,26 +263,25 @@ func GetLatestDSL(db *sqlx.DB, caseName string) (string, error) {
 }
 
 // LogAmendment records an applied mutation step.
-func LogAmendment(db *sqlx.DB, caseName, step, diff string) error {
-	_, err := db.Exec(`
-		INSERT INTO kyc_case_amendments (case_name, step, change_type, diff)
-		VALUES ($1, $2, 'mutation', $3)
-	`, caseName, step, diff)
-	return err
+func LogAmendment(ctx context.Context, db *sqlx.DB, caseName, step, diff string) error {
+	return InsertAmendment(ctx, db, caseName, step, "mutation", diff)
 }
 
-func InsertGrammar(db *sqlx.DB, name, version, ebnf string) error {
+// InsertGrammar stores a grammar definition, recording the actor in ctx
+func InsertGrammar(ctx context.Context, db *sqlx.DB, name, version, ebnf string) error {
+	a := actor.FromContext(ctx)
 	query := `
-		INSERT INTO kyc_grammar (name, version, ebnf)
-		VALUES ($1, $2, $3)
+		INSERT INTO kyc_grammar (name, version, ebnf, actor, actor_source, client_ip)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''))
 		ON CONFLICT (name) DO UPDATE
-		SET version = EXCLUDED.version, ebnf = EXCLUDED.ebnf, created_at = NOW();
+		SET version = EXCLUDED.version, ebnf = EXCLUDED.ebnf, created_at = NOW(),
+		    actor = EXCLUDED.actor, actor_source = EXCLUDED.actor_source, client_ip = EXCLUDED.client_ip;
 	`
-	_, err := db.Exec(query, name, version, ebnf)
+	_, err := db.ExecContext(ctx, query, name, version, ebnf, a.Name, a.Source, a.ClientIP)
 	if err != nil {
 		return fmt.Errorf("insert grammar failed: %w", err)
 	}
-	fmt.Printf("📘 Grammar '%s' (v%s) stored in Postgres.\n", name, version)
+	slog.Info("Grammar stored", "name", name, "version", version)
 	return nil
 }
 
@@ -305,6 +320,20 @@ func RecordValidationResult(db *sqlx.DB, v model.CaseValidation) error {
 	}
 
 	debugLog("Validation recorded: case=%s, status=%s, id=%d", v.CaseName, v.ValidationStatus, id)
+	if v.ValidationStatus == "FAIL" {
+		events.Notify(context.Background(), db, events.Event{
+			Type:     events.ValidationFailed,
+			CaseName: v.CaseName,
+			Version:  v.Version,
+			Data: map[string]interface{}{
+				"validation_id":    id,
+				"grammar_version":  v.GrammarVersion,
+				"ontology_version": v.OntologyVersion,
+				"error":            v.ErrorMessage,
+				"failed_checks":    v.FailedChecks,
+			},
+		})
+	}
 	return nil
 }
 
@@ -354,106 +383,3 @@ func GetValidationHistory(db *sqlx.DB, caseName string) ([]model.CaseValidation,
 	}
 	return validations, nil
 }
-
-// RecordLineageEvaluation persists a lineage evaluation result for audit trail.
-func RecordLineageEvaluation(db *sqlx.DB, caseName string, caseVersion int, result interface{}) error {
-	if db == nil {
-		return fmt.Errorf("database connection is nil")
-	}
-
-	// Type assertion to get evaluation result
-	type EvalResult struct {
-		DerivedCode string
-		Value       interface{}
-		Success     bool
-		Error       string
-		Rule        string
-		Inputs      map[string]interface{}
-	}
-
-	r, ok := result.(EvalResult)
-	if !ok {
-		return fmt.Errorf("invalid evaluation result type")
-	}
-
-	// Determine value type
-	valueType := "string"
-	var valueStr string
-	switch v := r.Value.(type) {
-	case bool:
-		valueType = "boolean"
-		valueStr = fmt.Sprintf("%v", v)
-	case int, int64, float64:
-		valueType = "numeric"
-		valueStr = fmt.Sprintf("%v", v)
-	case string:
-		valueType = "string"
-		valueStr = v
-	default:
-		valueStr = fmt.Sprintf("%v", v)
-	}
-
-	// Convert inputs to JSON
-	var inputsJSON interface{}
-	if r.Inputs != nil {
-		inputsJSON = r.Inputs
-	}
-
-	query := `
-		INSERT INTO kyc_lineage_evaluations
-		(case_name, case_version, derived_code, value, value_type, success, error, inputs, rule)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-	`
-	_, err := db.Exec(query,
-		caseName, caseVersion, r.DerivedCode, valueStr, valueType,
-		r.Success, r.Error, inputsJSON, r.Rule)
-
-	if err != nil {
-		debugLog("RecordLineageEvaluation failed: %v", err)
-		return fmt.Errorf("record lineage evaluation failed (case=%s, derived=%s): %w",
-			caseName, r.DerivedCode, err)
-	}
-
-	debugLog("Lineage evaluation recorded: case=%s, derived=%s, success=%v",
-		caseName, r.DerivedCode, r.Success)
-	return nil
-}
-
-// GetLineageEvaluations retrieves evaluation history for a case.
-func GetLineageEvaluations(db *sqlx.DB, caseName string) ([]map[string]interface{}, error) {
-	if db == nil {
-		return nil, fmt.Errorf("database connection is nil")
-	}
-	if caseName == "" {
-		return nil, fmt.Errorf("case name is required")
-	}
-
-	var results []map[string]interface{}
-	query := `
-		SELECT id, case_name, case_version, derived_code, value, value_type,
-		       success, error, inputs, rule, evaluated_at
-		FROM kyc_lineage_evaluations
-		WHERE case_name = $1
-		ORDER BY evaluated_at DESC
-	`
-
-	rows, err := db.Queryx(query, caseName)
-	if err != nil {
-		return nil, fmt.Errorf("get lineage evaluations failed for case %s: %w", caseName, err)
-	}
-	defer func() {
-		if closeErr := rows.Close(); closeErr != nil {
-			debugLog("Failed to close rows: %v", closeErr)
-		}
-	}()
-
-	for rows.Next() {
-		result := make(map[string]interface{})
-		if err := rows.MapScan(result); err != nil {
-			return nil, fmt.Errorf("scan lineage evaluation failed: %w", err)
-		}
-		results = append(results, result)
-	}
-
-	return results, nil
-}
@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/adamtc007/KYC-DSL/internal/parser"
+)
+
+// Section is a list form of a case, e.g. (policy KYCPOL-UK-2025) or
+// (ownership-structure (owner BLACKROCK-PLC 100) ...)
+type Section struct {
+	Head     string    // leading keyword
+	Args     []string  // atom arguments, in order
+	Children []Section // nested list arguments, in order
+}
+
+// Find returns the first child section with the given head
+func (s Section) Find(head string) (Section, bool) {
+	for _, c := range s.Children {
+		if c.Head == head {
+			return c, true
+		}
+	}
+	return Section{}, false
+}
+
+// Arg returns the i-th atom argument or "" when absent
+func (s Section) Arg(i int) string {
+	if i < len(s.Args) {
+		return s.Args[i]
+	}
+	return ""
+}
+
+// ParseCase reads the first (kyc-case NAME ...) form of dsl. The returned
+// section's Args hold the case name and its Children the case sections.
+func ParseCase(dsl string) (Section, error) {
+	forms, err := readForms(dsl)
+	if err != nil {
+		return Section{}, err
+	}
+	for _, form := range forms {
+		if sec := toSection(form); sec.Head == "kyc-case" {
+			return sec, nil
+		}
+	}
+	return Section{}, fmt.Errorf("no kyc-case form found")
+}
+
+func toSection(n *parser.Node) Section {
+	var sec Section
+	for i, c := range n.Children {
+		switch {
+		case c.List:
+			sec.Children = append(sec.Children, toSection(c))
+		case i == 0:
+			sec.Head = c.Atom
+		default:
+			sec.Args = append(sec.Args, c.Atom)
+		}
+	}
+	return sec
+}
+
+// String renders the section on one line: its head and atom arguments, then
+// its nested sections, quoting atoms only when needed
+func (s Section) String() string {
+	parts := make([]string, 0, 1+len(s.Args)+len(s.Children))
+	if s.Head != "" {
+		parts = append(parts, quoteAtom(s.Head))
+	}
+	for _, a := range s.Args {
+		parts = append(parts, quoteAtom(a))
+	}
+	for _, c := range s.Children {
+		parts = append(parts, c.String())
+	}
+	return "(" + strings.Join(parts, " ") + ")"
+}
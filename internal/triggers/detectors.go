@@ -0,0 +1,108 @@
+// Package triggers turns logged changes to the entity graph into case
+// reviews. Database triggers (migration 018) append ownership, jurisdiction,
+// director and adverse media changes to entity_change_log; a Detector per
+// kind decides whether a change is material, and the Monitor opens a review
+// amendment with the evidence on every case the change affects.
+package triggers
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Change kinds logged by the database triggers
+const (
+	KindOwnership    = "ownership"
+	KindJurisdiction = "jurisdiction"
+	KindDirector     = "director"
+	KindAdverseMedia = "adverse_media"
+)
+
+// Change is one row of entity_change_log with the names of the entities involved
+type Change struct {
+	ID              int64     `db:"id" json:"id"`
+	Kind            string    `db:"kind" json:"kind"`
+	EntityID        string    `db:"entity_id" json:"entity_id"`
+	EntityName      string    `db:"entity_name" json:"entity_name"`
+	RelatedEntityID string    `db:"related_entity_id" json:"related_entity_id,omitempty"`
+	RelatedName     string    `db:"related_name" json:"related_name,omitempty"`
+	OldValue        string    `db:"old_value" json:"old_value,omitempty"`
+	NewValue        string    `db:"new_value" json:"new_value,omitempty"`
+	Details         []byte    `db:"details" json:"-"`
+	ChangedAt       time.Time `db:"changed_at" json:"changed_at"`
+}
+
+// Detector decides whether a change of its kind is material and summarises it
+type Detector interface {
+	Kind() string
+	Detect(c Change) (summary string, material bool)
+}
+
+// OwnershipDetector flags stakes that cross Threshold in either direction or
+// move by at least Delta percentage points
+type OwnershipDetector struct {
+	Threshold float64
+	Delta     float64
+}
+
+// Kind returns KindOwnership
+func (d OwnershipDetector) Kind() string { return KindOwnership }
+
+// Detect compares the old and new stake
+func (d OwnershipDetector) Detect(c Change) (string, bool) {
+	oldPct, _ := strconv.ParseFloat(c.OldValue, 64)
+	newPct, _ := strconv.ParseFloat(c.NewValue, 64)
+
+	summary := fmt.Sprintf("Stake of %s in %s changed from %.2f%% to %.2f%%", c.RelatedName, c.EntityName, oldPct, newPct)
+	switch {
+	case (oldPct < d.Threshold) != (newPct < d.Threshold):
+		return fmt.Sprintf("%s, crossing the %.0f%% threshold", summary, d.Threshold), true
+	case math.Abs(newPct-oldPct) >= d.Delta:
+		return fmt.Sprintf("%s (%+.2f points)", summary, newPct-oldPct), true
+	}
+	return summary, false
+}
+
+// JurisdictionDetector flags every move of an entity to another jurisdiction;
+// setting a jurisdiction that was unknown is not material
+type JurisdictionDetector struct{}
+
+// Kind returns KindJurisdiction
+func (JurisdictionDetector) Kind() string { return KindJurisdiction }
+
+// Detect reports moves between jurisdictions
+func (JurisdictionDetector) Detect(c Change) (string, bool) {
+	if c.OldValue == "" {
+		return fmt.Sprintf("Jurisdiction of %s recorded as %s", c.EntityName, c.NewValue), false
+	}
+	return fmt.Sprintf("%s moved from jurisdiction %s to %s", c.EntityName, c.OldValue, c.NewValue), true
+}
+
+// DirectorDetector flags new directors: a DIRECTOR role in a CBU or
+// management control over an entity
+type DirectorDetector struct{}
+
+// Kind returns KindDirector
+func (DirectorDetector) Kind() string { return KindDirector }
+
+// Detect reports the appointment
+func (DirectorDetector) Detect(c Change) (string, bool) {
+	if c.RelatedName != "" {
+		return fmt.Sprintf("%s gained management control of %s", c.RelatedName, c.EntityName), true
+	}
+	return fmt.Sprintf("%s was appointed director", c.EntityName), true
+}
+
+// AdverseMediaDetector flags every new adverse media hit; hits are already
+// filtered by the screening match threshold
+type AdverseMediaDetector struct{}
+
+// Kind returns KindAdverseMedia
+func (AdverseMediaDetector) Kind() string { return KindAdverseMedia }
+
+// Detect reports the hit
+func (AdverseMediaDetector) Detect(c Change) (string, bool) {
+	return fmt.Sprintf("Adverse media hit for %s: %s", c.EntityName, c.NewValue), true
+}
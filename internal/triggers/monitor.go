@@ -0,0 +1,239 @@
+package triggers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/graph"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+)
+
+// ReviewStep is the amendment step recorded on cases opened for review
+const ReviewStep = "review"
+
+// closedCaseStatuses are case_versions statuses that end a case; closed cases
+// are not reopened by a material change
+var closedCaseStatuses = []string{"approved", "declined", "rejected", "closed"}
+
+// affectedCases selects the open cases whose latest DSL names a CBU in which
+// entity $1, or an entity it owns or controls (up to $2 levels down), holds a
+// current role or is the sponsor
+const affectedCases = `
+	WITH RECURSIVE downstream AS (
+	    SELECT $1::uuid AS id, 0 AS depth
+	    UNION
+	    SELECT ec.controlled_entity_id, d.depth + 1
+	      FROM downstream d
+	      JOIN entity_control ec ON ec.controller_entity_id = d.id
+	     WHERE d.depth < $2
+	       AND (ec.end_date IS NULL OR ec.end_date >= CURRENT_DATE)
+	), entity_cbus AS (
+	    SELECT c.code FROM cbu c
+	      JOIN cbu_role cr ON cr.cbu_id = c.id
+	      JOIN downstream d ON d.id = cr.entity_id
+	     WHERE cr.end_date IS NULL OR cr.end_date >= CURRENT_DATE
+	    UNION
+	    SELECT c.code FROM cbu c JOIN downstream d ON d.id = c.sponsor_entity_id
+	), latest AS (
+	    SELECT DISTINCT ON (case_id) case_id, status,
+	           substring(dsl_source from '\(client-business-unit\s+([^\s)]+)\)') AS cbu_code
+	      FROM case_versions
+	     ORDER BY case_id, created_at DESC, id DESC
+	)
+	SELECT l.case_id
+	  FROM latest l JOIN entity_cbus ec ON ec.code = l.cbu_code
+	 WHERE lower(l.status) <> ALL($3)
+	 ORDER BY l.case_id`
+
+// Summary counts the outcome of one monitor pass
+type Summary struct {
+	Changes    int `json:"changes"`
+	Material   int `json:"material"`
+	Amendments int `json:"amendments"`
+}
+
+// Monitor processes logged changes with the detectors of their kind
+type Monitor struct {
+	db        *sqlx.DB
+	detectors map[string]Detector
+	interval  time.Duration
+	batchSize int
+}
+
+// NewMonitor creates a monitor with the ownership, jurisdiction, director and
+// adverse media detectors
+func NewMonitor(db *sqlx.DB, cfg config.MaterialChangeConfig) *Monitor {
+	m := &Monitor{db: db, detectors: make(map[string]Detector), interval: cfg.Interval, batchSize: 100}
+	for _, d := range []Detector{
+		OwnershipDetector{Threshold: cfg.OwnershipThreshold, Delta: cfg.OwnershipDelta},
+		JurisdictionDetector{},
+		DirectorDetector{},
+		AdverseMediaDetector{},
+	} {
+		m.Register(d)
+	}
+	return m
+}
+
+// Register adds or replaces the detector for its kind
+func (m *Monitor) Register(d Detector) {
+	m.detectors[d.Kind()] = d
+}
+
+// Run processes pending changes every interval until ctx is cancelled
+func (m *Monitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		summary, err := m.RunOnce(ctx)
+		if err != nil {
+			slog.Warn("⚠️  Material change pass failed", "error", err)
+		} else if summary.Changes > 0 {
+			slog.Info("🔔 Material change pass", "changes", summary.Changes,
+				"material", summary.Material, "amendments", summary.Amendments)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce processes one batch of pending changes in a transaction; concurrent
+// monitors skip the rows locked by each other
+func (m *Monitor) RunOnce(ctx context.Context) (Summary, error) {
+	var summary Summary
+
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return summary, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	var changes []Change
+	err = tx.SelectContext(ctx, &changes, `
+		SELECT l.id, l.kind, l.entity_id::text AS entity_id, e.name AS entity_name,
+		       COALESCE(l.related_entity_id::text, '') AS related_entity_id, COALESCE(r.name, '') AS related_name,
+		       COALESCE(l.old_value, '') AS old_value, COALESCE(l.new_value, '') AS new_value,
+		       l.details, l.changed_at
+		  FROM entity_change_log l
+		  JOIN entity e ON e.id = l.entity_id
+		  LEFT JOIN entity r ON r.id = l.related_entity_id
+		 WHERE l.processed_at IS NULL
+		 ORDER BY l.id
+		 LIMIT $1
+		   FOR UPDATE OF l SKIP LOCKED`, m.batchSize)
+	if err != nil {
+		return summary, fmt.Errorf("failed to load pending changes: %w", err)
+	}
+
+	for _, c := range changes {
+		summary.Changes++
+		material := false
+		if d, ok := m.detectors[c.Kind]; ok {
+			var text string
+			if text, material = d.Detect(c); material {
+				summary.Material++
+				opened, err := m.openReviews(ctx, tx, c, text)
+				if err != nil {
+					return summary, err
+				}
+				summary.Amendments += opened
+			}
+		}
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE entity_change_log SET processed_at = CURRENT_TIMESTAMP, material = $2 WHERE id = $1`,
+			c.ID, material); err != nil {
+			return summary, fmt.Errorf("failed to mark change processed: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return summary, fmt.Errorf("failed to commit material changes: %w", err)
+	}
+	return summary, nil
+}
+
+// openReviews records a review amendment with the change as evidence on every
+// open case affected by it and returns how many were opened
+func (m *Monitor) openReviews(ctx context.Context, tx *sqlx.Tx, c Change, text string) (int, error) {
+	var cases []string
+	if err := tx.SelectContext(ctx, &cases, affectedCases,
+		c.EntityID, graph.MaxDepth, pq.Array(closedCaseStatuses)); err != nil {
+		return 0, fmt.Errorf("failed to find cases affected by change %d: %w", c.ID, err)
+	}
+	if len(cases) == 0 {
+		return 0, nil
+	}
+
+	evidence, err := json.Marshal(struct {
+		Change
+		Summary string          `json:"summary"`
+		Details json.RawMessage `json:"details,omitempty"`
+	}{c, text, json.RawMessage(c.Details)})
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode evidence: %w", err)
+	}
+	diff := fmt.Sprintf("Material change (%s): %s\nDetected from change #%d at %s\nEvidence: %s",
+		c.Kind, text, c.ID, c.ChangedAt.Format(time.RFC3339), evidence)
+
+	opened := 0
+	for _, caseID := range cases {
+		var amendmentID int
+		if err := tx.GetContext(ctx, &amendmentID, `
+			INSERT INTO kyc_case_amendments (case_name, step, change_type, diff)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id`, caseID, ReviewStep, "material-change:"+c.Kind, diff); err != nil {
+			return opened, fmt.Errorf("failed to open review amendment on %s: %w", caseID, err)
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO material_change_events (change_id, kind, entity_id, case_id, summary, evidence, amendment_id)
+			VALUES ($1, $2, $3::uuid, $4, $5, $6, $7)
+			ON CONFLICT (change_id, case_id) DO NOTHING`,
+			c.ID, c.Kind, c.EntityID, caseID, text, evidence, amendmentID); err != nil {
+			return opened, fmt.Errorf("failed to record material change event: %w", err)
+		}
+		opened++
+		slog.Info("🔔 Review opened on material change", "case_id", caseID, "kind", c.Kind,
+			"change_id", c.ID, "amendment_id", amendmentID)
+	}
+	return opened, nil
+}
+
+// Repo reads the material change events recorded by the monitor
+type Repo struct {
+	db *sqlx.DB
+}
+
+// NewRepo creates a new material change repository
+func NewRepo(db *sqlx.DB) *Repo {
+	return &Repo{db: db}
+}
+
+// Events returns the material change events recorded on a case (all cases
+// when caseID is empty), newest first
+func (r *Repo) Events(ctx context.Context, caseID string, limit int) ([]model.MaterialChangeEvent, error) {
+	events := []model.MaterialChangeEvent{}
+	err := r.db.SelectContext(ctx, &events, `
+		SELECT m.id, m.change_id, m.kind, m.entity_id::text AS entity_id, e.name AS entity_name,
+		       m.case_id, m.summary, m.evidence, m.amendment_id, m.created_at
+		  FROM material_change_events m
+		  JOIN entity e ON e.id = m.entity_id
+		 WHERE $1 = '' OR m.case_id = $1
+		 ORDER BY m.created_at DESC, m.id DESC
+		 LIMIT $2`, caseID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list material change events: %w", err)
+	}
+	return events, nil
+}
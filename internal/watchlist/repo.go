@@ -0,0 +1,272 @@
+// Package watchlist keeps entities pinned for heightened monitoring and
+// re-screens them on a schedule, raising an alert for every new hit.
+package watchlist
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/adamtc007/KYC-DSL/internal/graph"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/screening"
+)
+
+// ErrNotFound is returned for an unknown watchlist entry or alert
+var ErrNotFound = errors.New("not found")
+
+// Repo stores watchlist entries, their screening hits and alerts
+type Repo struct {
+	db *sqlx.DB
+}
+
+// NewRepo creates a new watchlist repository
+func NewRepo(db *sqlx.DB) *Repo {
+	return &Repo{db: db}
+}
+
+const entryColumns = `
+	w.id, w.entity_id::text AS entity_id, e.name AS entity_name, e.entity_type,
+	COALESCE(e.jurisdiction, '') AS jurisdiction, w.reason, COALESCE(w.pinned_by, '') AS pinned_by,
+	w.cadence_minutes, w.include_ubos, w.active, w.last_screened_at,
+	COALESCE(w.last_error, '') AS last_error, w.next_screening_at, w.created_at,
+	(SELECT COUNT(*) FROM watchlist_alerts a WHERE a.watchlist_id = w.id AND a.status = 'OPEN') AS open_alerts
+`
+
+// Pin adds an entity to the watchlist, or updates the reason and cadence of
+// its active entry. The entity is screened on the next scheduler tick.
+func (r *Repo) Pin(ctx context.Context, entityID, reason, pinnedBy string, cadence time.Duration, includeUBOs bool) (*model.WatchlistEntry, error) {
+	var exists bool
+	if err := r.db.GetContext(ctx, &exists, `SELECT EXISTS (SELECT 1 FROM entity WHERE id::text = $1)`, entityID); err != nil {
+		return nil, fmt.Errorf("failed to look up entity: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", graph.ErrEntityNotFound, entityID)
+	}
+
+	var id int
+	err := r.db.GetContext(ctx, &id, `
+		INSERT INTO watchlist_entries (entity_id, reason, pinned_by, cadence_minutes, include_ubos)
+		VALUES ($1::uuid, $2, NULLIF($3, ''), $4, $5)
+		ON CONFLICT (entity_id) WHERE active DO UPDATE
+		   SET reason = EXCLUDED.reason,
+		       pinned_by = EXCLUDED.pinned_by,
+		       cadence_minutes = EXCLUDED.cadence_minutes,
+		       include_ubos = EXCLUDED.include_ubos,
+		       next_screening_at = CURRENT_TIMESTAMP,
+		       updated_at = CURRENT_TIMESTAMP
+		RETURNING id`,
+		entityID, reason, pinnedBy, cadenceMinutes(cadence), includeUBOs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pin entity: %w", err)
+	}
+	return r.Get(ctx, id)
+}
+
+// Unpin stops monitoring an entry; its hits and alerts are kept
+func (r *Repo) Unpin(ctx context.Context, id int) error {
+	res, err := r.db.ExecContext(ctx, `
+		UPDATE watchlist_entries SET active = false, updated_at = CURRENT_TIMESTAMP
+		 WHERE id = $1 AND active`, id)
+	if err != nil {
+		return fmt.Errorf("failed to unpin watchlist entry: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%w: watchlist entry %d", ErrNotFound, id)
+	}
+	return nil
+}
+
+// Get returns one watchlist entry
+func (r *Repo) Get(ctx context.Context, id int) (*model.WatchlistEntry, error) {
+	var entry model.WatchlistEntry
+	err := r.db.GetContext(ctx, &entry, `
+		SELECT `+entryColumns+`
+		  FROM watchlist_entries w JOIN entity e ON e.id = w.entity_id
+		 WHERE w.id = $1`, id)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: watchlist entry %d", ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watchlist entry: %w", err)
+	}
+	return &entry, nil
+}
+
+// List returns watchlist entries, soonest due first
+func (r *Repo) List(ctx context.Context, includeInactive bool) ([]model.WatchlistEntry, error) {
+	entries := []model.WatchlistEntry{}
+	err := r.db.SelectContext(ctx, &entries, `
+		SELECT `+entryColumns+`
+		  FROM watchlist_entries w JOIN entity e ON e.id = w.entity_id
+		 WHERE w.active OR $1
+		 ORDER BY w.active DESC, w.next_screening_at, w.id`, includeInactive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list watchlist: %w", err)
+	}
+	return entries, nil
+}
+
+// ClaimDue returns up to limit active entries whose screening is due and
+// pushes their next screening one cadence ahead, so concurrent schedulers
+// never screen the same entry twice
+func (r *Repo) ClaimDue(ctx context.Context, limit int) ([]model.WatchlistEntry, error) {
+	var ids []int
+	err := r.db.SelectContext(ctx, &ids, `
+		UPDATE watchlist_entries
+		   SET next_screening_at = CURRENT_TIMESTAMP + make_interval(mins => cadence_minutes)
+		 WHERE id IN (SELECT id FROM watchlist_entries
+		               WHERE active AND next_screening_at <= CURRENT_TIMESTAMP
+		               ORDER BY next_screening_at
+		               LIMIT $1
+		               FOR UPDATE SKIP LOCKED)
+		RETURNING id`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim due watchlist entries: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var entries []model.WatchlistEntry
+	err = r.db.SelectContext(ctx, &entries, `
+		SELECT `+entryColumns+`
+		  FROM watchlist_entries w JOIN entity e ON e.id = w.entity_id
+		 WHERE w.id = ANY($1)
+		 ORDER BY w.id`, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load due watchlist entries: %w", err)
+	}
+	return entries, nil
+}
+
+// MarkScreened records the outcome of a screening run; screenErr is empty on success
+func (r *Repo) MarkScreened(ctx context.Context, id int, screenErr string) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE watchlist_entries
+		   SET last_screened_at = CURRENT_TIMESTAMP, last_error = NULLIF($2, ''), updated_at = CURRENT_TIMESTAMP
+		 WHERE id = $1`, id, screenErr)
+	if err != nil {
+		return fmt.Errorf("failed to mark watchlist entry screened: %w", err)
+	}
+	return nil
+}
+
+// RecordHit stores a hit for a subject of an entry and reports whether it is
+// new; hits seen before only have their last_seen_at refreshed
+func (r *Repo) RecordHit(ctx context.Context, watchlistID int, subject screening.Subject, hit screening.Hit) (int, bool, error) {
+	var row struct {
+		ID       int  `db:"id"`
+		Inserted bool `db:"inserted"`
+	}
+	err := r.db.GetContext(ctx, &row, `
+		INSERT INTO watchlist_hits
+			(watchlist_id, subject_entity_id, subject_name, provider, kind, reference, matched_name, score, detail)
+		VALUES ($1, $2::uuid, $3, $4, $5, $6, $7, $8, NULLIF($9, ''))
+		ON CONFLICT (watchlist_id, subject_entity_id, provider, reference) DO UPDATE
+		   SET last_seen_at = CURRENT_TIMESTAMP, score = EXCLUDED.score
+		RETURNING id, (xmax = 0) AS inserted`,
+		watchlistID, subject.EntityID, subject.Name, hit.Provider, string(hit.Kind),
+		hit.Reference, hit.MatchedName, hit.Score, hit.Detail)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to record watchlist hit: %w", err)
+	}
+	return row.ID, row.Inserted, nil
+}
+
+// RaiseAlert opens an alert for a new hit and flags the subject's KYC profile
+// for the kind of hit, so open cases and Entity360 views surface it
+func (r *Repo) RaiseAlert(ctx context.Context, watchlistID, hitID int, subject screening.Subject, hit screening.Hit) (int, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	message := fmt.Sprintf("New %s hit for %s: %s (%s, score %.2f)",
+		hit.Kind, subject.Name, hit.MatchedName, hit.Reference, hit.Score)
+
+	var id int
+	err = tx.GetContext(ctx, &id, `
+		INSERT INTO watchlist_alerts (watchlist_id, hit_id, entity_id, message)
+		VALUES ($1, $2, $3::uuid, $4)
+		RETURNING id`, watchlistID, hitID, subject.EntityID, message)
+	if err != nil {
+		return 0, fmt.Errorf("failed to raise watchlist alert: %w", err)
+	}
+
+	var flag string
+	switch hit.Kind {
+	case screening.KindSanctions:
+		flag = `sanctions_check_status = 'HIT'`
+	case screening.KindPEP:
+		flag = `pep_status = true`
+	case screening.KindAdverseMedia:
+		flag = `adverse_media_status = 'HIT'`
+	}
+	if flag != "" {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO entity_kyc_profile (entity_id) VALUES ($1::uuid)
+			ON CONFLICT (entity_id) DO NOTHING`, subject.EntityID)
+		if err == nil {
+			_, err = tx.ExecContext(ctx, `
+				UPDATE entity_kyc_profile SET `+flag+`, updated_at = now()
+				 WHERE entity_id = $1::uuid`, subject.EntityID)
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to flag kyc profile: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit watchlist alert: %w", err)
+	}
+	return id, nil
+}
+
+// ListAlerts returns alerts with the given status (all when empty), newest first
+func (r *Repo) ListAlerts(ctx context.Context, status model.WatchlistAlertStatus, limit int) ([]model.WatchlistAlert, error) {
+	alerts := []model.WatchlistAlert{}
+	err := r.db.SelectContext(ctx, &alerts, `
+		SELECT a.id, a.watchlist_id, a.hit_id, a.entity_id::text AS entity_id, e.name AS entity_name,
+		       h.subject_name, h.kind, h.matched_name, h.score::float8 AS score,
+		       a.message, a.status, COALESCE(a.resolved_by, '') AS resolved_by, a.resolved_at, a.created_at
+		  FROM watchlist_alerts a
+		  JOIN watchlist_hits h ON h.id = a.hit_id
+		  JOIN entity e ON e.id = a.entity_id
+		 WHERE $1 = '' OR a.status = $1
+		 ORDER BY a.created_at DESC, a.id DESC
+		 LIMIT $2`, string(status), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list watchlist alerts: %w", err)
+	}
+	return alerts, nil
+}
+
+// ResolveAlert acknowledges or dismisses an alert
+func (r *Repo) ResolveAlert(ctx context.Context, id int, status model.WatchlistAlertStatus, resolvedBy string) error {
+	res, err := r.db.ExecContext(ctx, `
+		UPDATE watchlist_alerts
+		   SET status = $2, resolved_by = NULLIF($3, ''), resolved_at = CURRENT_TIMESTAMP
+		 WHERE id = $1`, id, string(status), resolvedBy)
+	if err != nil {
+		return fmt.Errorf("failed to resolve watchlist alert: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%w: alert %d", ErrNotFound, id)
+	}
+	return nil
+}
+
+// cadenceMinutes converts a cadence to whole minutes, at least one
+func cadenceMinutes(d time.Duration) int {
+	if m := int(d / time.Minute); m > 0 {
+		return m
+	}
+	return 1
+}
@@ -0,0 +1,179 @@
+package watchlist
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/adamtc007/KYC-DSL/internal/config"
+	"github.com/adamtc007/KYC-DSL/internal/graph"
+	"github.com/adamtc007/KYC-DSL/internal/model"
+	"github.com/adamtc007/KYC-DSL/internal/screening"
+)
+
+// Summary counts the outcome of one scheduler pass
+type Summary struct {
+	Screened  int `json:"screened"`
+	Subjects  int `json:"subjects"`
+	Hits      int `json:"hits"`
+	NewAlerts int `json:"new_alerts"`
+	Failed    int `json:"failed"`
+}
+
+// Scheduler re-screens due watchlist entries with every configured provider
+type Scheduler struct {
+	repo      *Repo
+	graph     *graph.Repo
+	providers []screening.Provider
+	interval  time.Duration
+	batchSize int
+}
+
+// NewScheduler creates a scheduler screening against the local sanctions,
+// PEP and adverse media lists
+func NewScheduler(db *sqlx.DB, cfg config.WatchlistConfig) *Scheduler {
+	return &Scheduler{
+		repo:  NewRepo(db),
+		graph: graph.NewRepo(db),
+		providers: []screening.Provider{
+			screening.NewListProvider(db, screening.KindSanctions, cfg.MatchThreshold),
+			screening.NewListProvider(db, screening.KindPEP, cfg.MatchThreshold),
+			screening.NewListProvider(db, screening.KindAdverseMedia, cfg.MatchThreshold),
+		},
+		interval:  cfg.Interval,
+		batchSize: cfg.BatchSize,
+	}
+}
+
+// AddProvider registers an additional screening provider
+func (s *Scheduler) AddProvider(p screening.Provider) {
+	s.providers = append(s.providers, p)
+}
+
+// Run screens due entries every interval until ctx is cancelled
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		summary, err := s.RunOnce(ctx)
+		if err != nil {
+			slog.Warn("⚠️  Watchlist screening pass failed", "error", err)
+		} else if summary.Screened > 0 {
+			slog.Info("👁️  Watchlist screening pass", "screened", summary.Screened, "subjects", summary.Subjects,
+				"hits", summary.Hits, "new_alerts", summary.NewAlerts, "failed", summary.Failed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce screens the entries that are due now, up to the batch size
+func (s *Scheduler) RunOnce(ctx context.Context) (Summary, error) {
+	var summary Summary
+
+	entries, err := s.repo.ClaimDue(ctx, s.batchSize)
+	if err != nil {
+		return summary, err
+	}
+
+	for _, entry := range entries {
+		result, err := s.ScreenEntry(ctx, entry)
+		summary.Screened++
+		summary.Subjects += result.Subjects
+		summary.Hits += result.Hits
+		summary.NewAlerts += result.NewAlerts
+
+		msg := ""
+		if err != nil {
+			summary.Failed++
+			msg = err.Error()
+			slog.Warn("⚠️  Watchlist screening failed", "watchlist_id", entry.ID, "entity_id", entry.EntityID, "error", err)
+		}
+		if err := s.repo.MarkScreened(ctx, entry.ID, msg); err != nil {
+			return summary, err
+		}
+	}
+	return summary, nil
+}
+
+// ScreenEntry screens the entity of an entry (and its UBOs when requested)
+// with every provider, records the hits and raises an alert for each new one.
+// A failing provider does not stop the others; the first error is returned.
+func (s *Scheduler) ScreenEntry(ctx context.Context, entry model.WatchlistEntry) (Summary, error) {
+	summary := Summary{Screened: 1}
+
+	subjects, err := s.subjects(ctx, entry)
+	if err != nil {
+		return summary, err
+	}
+	summary.Subjects = len(subjects)
+
+	var firstErr error
+	for _, subject := range subjects {
+		for _, p := range s.providers {
+			hits, err := p.Screen(ctx, subject)
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("%s: %w", p.Name(), err)
+				}
+				continue
+			}
+			for _, hit := range hits {
+				summary.Hits++
+				hitID, isNew, err := s.repo.RecordHit(ctx, entry.ID, subject, hit)
+				if err != nil {
+					return summary, err
+				}
+				if !isNew {
+					continue
+				}
+				alertID, err := s.repo.RaiseAlert(ctx, entry.ID, hitID, subject, hit)
+				if err != nil {
+					return summary, err
+				}
+				summary.NewAlerts++
+				slog.Warn("🚨 Watchlist alert raised", "alert_id", alertID, "watchlist_id", entry.ID,
+					"entity", subject.Name, "kind", hit.Kind, "matched", hit.MatchedName, "score", hit.Score)
+			}
+		}
+	}
+	return summary, firstErr
+}
+
+// subjects returns the watched entity followed by its beneficial owners when
+// the entry includes UBOs
+func (s *Scheduler) subjects(ctx context.Context, entry model.WatchlistEntry) ([]screening.Subject, error) {
+	subjects := []screening.Subject{{
+		EntityID:     entry.EntityID,
+		Name:         entry.EntityName,
+		EntityType:   entry.EntityType,
+		Jurisdiction: entry.Jurisdiction,
+	}}
+	if !entry.IncludeUBOs {
+		return subjects, nil
+	}
+
+	rollup, err := s.graph.ComputeUbo(ctx, entry.EntityID, graph.DefaultUBOThreshold)
+	if err != nil {
+		return nil, err
+	}
+	for _, owner := range rollup.UBOs() {
+		if owner.ID == entry.EntityID || owner.Name == "" {
+			continue
+		}
+		subjects = append(subjects, screening.Subject{
+			EntityID:   owner.ID,
+			Name:       owner.Name,
+			EntityType: owner.EntityType,
+		})
+	}
+	return subjects, nil
+}
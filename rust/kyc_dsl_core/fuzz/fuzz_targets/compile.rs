@@ -0,0 +1,15 @@
+#![no_main]
+
+use kyc_dsl_core::{compile_dsl, execute_plan};
+use libfuzzer_sys::fuzz_target;
+
+// Compiling and executing analyst input may fail, but must never panic.
+fuzz_target!(|data: &[u8]| {
+    let Ok(src) = std::str::from_utf8(data) else {
+        return;
+    };
+
+    if let Ok(plan) = compile_dsl(src) {
+        let _ = execute_plan(&plan);
+    }
+});
@@ -0,0 +1,19 @@
+#![no_main]
+
+use kyc_dsl_core::{parser, roundtrip};
+use libfuzzer_sys::fuzz_target;
+
+// The parser must never panic, and anything it accepts must round-trip
+// through the canonical printer.
+fuzz_target!(|data: &[u8]| {
+    let Ok(src) = std::str::from_utf8(data) else {
+        return;
+    };
+
+    let _ = parser::parse(src);
+    if parser::parse_strict(src).is_ok() {
+        if let Err(e) = roundtrip::check(src) {
+            panic!("accepted input failed round-trip: {}", e);
+        }
+    }
+});
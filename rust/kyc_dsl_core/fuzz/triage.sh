@@ -0,0 +1,60 @@
+#!/bin/bash
+# Crash triage for the kyc_dsl_core fuzz targets.
+#
+# Replays every artifact under artifacts/<target>/, groups them by panic
+# message and location, and minimizes one reproducer per group into
+# triage/<target>/.
+#
+# Usage: ./triage.sh [target]   (default: parse)
+
+set -u
+
+TARGET="${1:-parse}"
+cd "$(dirname "$0")"
+
+ARTIFACTS="artifacts/${TARGET}"
+OUT="triage/${TARGET}"
+
+if [ ! -d "$ARTIFACTS" ] || [ -z "$(ls -A "$ARTIFACTS" 2>/dev/null)" ]; then
+    echo "✅ No artifacts for target '${TARGET}'"
+    exit 0
+fi
+
+mkdir -p "$OUT"
+declare -A SEEN
+
+echo "🔍 Triaging $(ls "$ARTIFACTS" | wc -l) artifacts for target '${TARGET}'"
+echo
+
+for artifact in "$ARTIFACTS"/*; do
+    log=$(cargo +nightly fuzz run "$TARGET" "$artifact" 2>&1)
+    # The first panic line ("panicked at src/parser.rs:12:5:") plus the
+    # message that follows identifies the bug
+    signature=$(echo "$log" | grep -A1 -m1 "panicked at" | tr '\n' ' ' | sed 's/thread .* panicked at //')
+    if [ -z "$signature" ]; then
+        signature=$(echo "$log" | grep -m1 "ERROR: libFuzzer" || echo "unknown")
+    fi
+
+    key=$(echo "$signature" | sha256sum | cut -c1-12)
+    if [ -n "${SEEN[$key]:-}" ]; then
+        continue
+    fi
+    SEEN[$key]="$artifact"
+
+    echo "💥 [$key] $signature"
+    echo "   artifact: $artifact"
+
+    cargo +nightly fuzz tmin "$TARGET" "$artifact" >/dev/null 2>&1
+    minimized=$(ls -t "$ARTIFACTS"/minimized-from-* 2>/dev/null | head -1)
+    cp "${minimized:-$artifact}" "$OUT/$key.dsl"
+    {
+        echo "signature: $signature"
+        echo "artifact: $artifact"
+        echo
+        echo "$log"
+    } > "$OUT/$key.txt"
+    echo "   reproducer: $OUT/$key.dsl"
+done
+
+echo
+echo "📋 ${#SEEN[@]} distinct crashes written to ${OUT}"
@@ -0,0 +1,587 @@
+//! JSON/YAML interchange form of a kyc-case.
+//!
+//! Systems that cannot parse S-expressions exchange cases in this form. The
+//! grammar's sections get typed fields; a section the typed fields cannot
+//! reproduce exactly (unknown forms, extra arguments, a repeated single
+//! section) is kept in `sections` as a nested array, e.g.
+//! `["screening", "WORLD-CHECK", ["frequency", "daily"]]`. The field names
+//! and fallback rules match the Go compiler (internal/parser/compile.go), so
+//! both sides produce the same document for the same case.
+
+use crate::parser::Expr;
+use serde::Serialize;
+use serde_json::Value;
+
+#[derive(Debug, Default, Serialize, PartialEq)]
+pub struct CaseJson {
+    pub name: String,
+    #[serde(skip_serializing_if = "String::is_empty")]
+    pub nature: String,
+    #[serde(skip_serializing_if = "String::is_empty")]
+    pub purpose: String,
+    #[serde(skip_serializing_if = "String::is_empty")]
+    pub client_business_unit: String,
+    #[serde(skip_serializing_if = "Vec::is_empty")]
+    pub functions: Vec<String>,
+    #[serde(skip_serializing_if = "Vec::is_empty")]
+    pub policies: Vec<String>,
+    #[serde(skip_serializing_if = "Vec::is_empty")]
+    pub obligations: Vec<String>,
+    #[serde(skip_serializing_if = "Vec::is_empty")]
+    pub document_requirements: Vec<DocumentRequirement>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub ownership: Option<Ownership>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub data_dictionary: Option<Vec<Attribute>>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub derived_attributes: Option<Vec<DerivedAttribute>>,
+    #[serde(skip_serializing_if = "String::is_empty")]
+    pub kyc_token: String,
+    #[serde(skip_serializing_if = "Vec::is_empty")]
+    pub sections: Vec<Value>,
+}
+
+#[derive(Debug, Default, Serialize, PartialEq)]
+pub struct DocumentRequirement {
+    pub jurisdiction: String,
+    pub documents: Option<Vec<Document>>,
+}
+
+#[derive(Debug, Default, Serialize, PartialEq)]
+pub struct Document {
+    pub code: String,
+    #[serde(skip_serializing_if = "String::is_empty")]
+    pub name: String,
+}
+
+#[derive(Debug, Default, Serialize, PartialEq)]
+pub struct Ownership {
+    #[serde(skip_serializing_if = "String::is_empty")]
+    pub entity: String,
+    #[serde(skip_serializing_if = "Vec::is_empty")]
+    pub owners: Vec<Holding>,
+    #[serde(skip_serializing_if = "Vec::is_empty")]
+    pub beneficial_owners: Vec<Holding>,
+    #[serde(skip_serializing_if = "Vec::is_empty")]
+    pub controllers: Vec<Controller>,
+}
+
+/// An owner or beneficial owner; the percentage is kept as written
+#[derive(Debug, Default, Serialize, PartialEq)]
+pub struct Holding {
+    pub name: String,
+    pub percent: String,
+}
+
+#[derive(Debug, Default, Serialize, PartialEq)]
+pub struct Controller {
+    pub name: String,
+    pub role: String,
+}
+
+#[derive(Debug, Default, Serialize, PartialEq)]
+pub struct Attribute {
+    pub code: String,
+    #[serde(skip_serializing_if = "Vec::is_empty")]
+    pub sources: Vec<Source>,
+}
+
+/// `(primary-source (document CODE))` or `(tertiary-source "text")`
+#[derive(Debug, Default, Serialize, PartialEq)]
+pub struct Source {
+    pub tier: String,
+    #[serde(skip_serializing_if = "String::is_empty")]
+    pub document: String,
+    #[serde(skip_serializing_if = "String::is_empty")]
+    pub text: String,
+}
+
+#[derive(Debug, Default, Serialize, PartialEq)]
+pub struct DerivedAttribute {
+    pub code: String,
+    #[serde(skip_serializing_if = "Vec::is_empty")]
+    pub sources: Vec<String>,
+    #[serde(skip_serializing_if = "String::is_empty")]
+    pub rule: String,
+    #[serde(skip_serializing_if = "String::is_empty")]
+    pub jurisdiction: String,
+    #[serde(skip_serializing_if = "String::is_empty")]
+    pub regulation: String,
+}
+
+/// Convert a parsed `(kyc-case NAME ...)` form to its interchange form
+pub fn compile_case(expr: &Expr) -> Result<CaseJson, String> {
+    let args = match expr {
+        Expr::Call(name, args) if name == "kyc-case" => args,
+        Expr::Call(name, _) => return Err(format!("expected a kyc-case form, got ({} ...)", name)),
+        Expr::Atom(_) => return Err("expected a kyc-case form".to_string()),
+    };
+    let name = match args.first() {
+        Some(Expr::Atom(name)) => name.clone(),
+        _ => return Err("kyc-case form has no name".to_string()),
+    };
+
+    let mut case = CaseJson {
+        name,
+        ..Default::default()
+    };
+    for section in &args[1..] {
+        if !case.add_typed(section) {
+            case.sections.push(to_value(section));
+        }
+    }
+    Ok(case)
+}
+
+impl CaseJson {
+    /// Decode a section into the typed fields, leaving the case unchanged and
+    /// returning false when they cannot reproduce it exactly
+    fn add_typed(&mut self, section: &Expr) -> bool {
+        let mut part = CaseJson::default();
+        if !part.decode(section) {
+            return false;
+        }
+        let rendered = part.typed_sections();
+        if rendered.len() != 1 || &rendered[0] != section {
+            return false;
+        }
+        self.merge(part)
+    }
+
+    /// Read one section into an empty case
+    fn decode(&mut self, section: &Expr) -> bool {
+        let (head, args) = match section {
+            Expr::Call(head, args) => (head.as_str(), args),
+            Expr::Atom(_) => return false,
+        };
+        match head {
+            "nature-purpose" => {
+                for n in args {
+                    match call_name(n) {
+                        Some("nature") => self.nature = arg(n, 0),
+                        Some("purpose") => self.purpose = arg(n, 0),
+                        _ => {}
+                    }
+                }
+            }
+            "client-business-unit" => self.client_business_unit = arg(section, 0),
+            "function" => self.functions = vec![arg(section, 0)],
+            "policy" => self.policies = vec![arg(section, 0)],
+            "obligation" => self.obligations = vec![arg(section, 0)],
+            "kyc-token" => self.kyc_token = arg(section, 0),
+            "document-requirements" => {
+                let mut req = DocumentRequirement::default();
+                for n in args {
+                    match call_name(n) {
+                        Some("jurisdiction") => req.jurisdiction = arg(n, 0),
+                        Some("required") => {
+                            for doc in call_args(n) {
+                                req.documents.get_or_insert_with(Vec::new).push(Document {
+                                    code: arg(doc, 0),
+                                    name: arg(doc, 1),
+                                });
+                            }
+                        }
+                        _ => {}
+                    }
+                }
+                self.document_requirements = vec![req];
+            }
+            "ownership-structure" => {
+                let mut o = Ownership::default();
+                for n in args {
+                    match call_name(n) {
+                        Some("entity") => o.entity = arg(n, 0),
+                        Some("owner") => o.owners.push(holding(n)),
+                        Some("beneficial-owner") => o.beneficial_owners.push(holding(n)),
+                        Some("controller") => o.controllers.push(Controller {
+                            name: arg(n, 0),
+                            role: arg(n, 1),
+                        }),
+                        _ => {}
+                    }
+                }
+                self.ownership = Some(o);
+            }
+            "data-dictionary" => {
+                if args.is_empty() {
+                    return false;
+                }
+                let mut attrs = Vec::new();
+                for n in args {
+                    let mut attr = Attribute {
+                        code: arg(n, 0),
+                        ..Default::default()
+                    };
+                    for src in call_args(n).iter().skip(1) {
+                        let head = call_name(src).unwrap_or("");
+                        let mut source = Source {
+                            tier: head.strip_suffix("-source").unwrap_or(head).to_string(),
+                            ..Default::default()
+                        };
+                        match call_args(src).first() {
+                            Some(doc @ Expr::Call(name, _)) if name == "document" => {
+                                source.document = arg(doc, 0)
+                            }
+                            _ => source.text = arg(src, 0),
+                        }
+                        attr.sources.push(source);
+                    }
+                    attrs.push(attr);
+                }
+                self.data_dictionary = Some(attrs);
+            }
+            "derived-attributes" => {
+                if args.is_empty() {
+                    return false;
+                }
+                let mut attrs = Vec::new();
+                for n in args {
+                    let mut attr = DerivedAttribute {
+                        code: arg(n, 0),
+                        ..Default::default()
+                    };
+                    for field in call_args(n).iter().skip(1) {
+                        match call_name(field) {
+                            // (sources (A B)) parses as a call of A with argument B
+                            Some("sources") => {
+                                if let Some(Expr::Call(first, rest)) = call_args(field).first() {
+                                    attr.sources.push(first.clone());
+                                    for name in rest {
+                                        attr.sources.push(atom(name));
+                                    }
+                                }
+                            }
+                            Some("rule") => attr.rule = arg(field, 0),
+                            Some("jurisdiction") => attr.jurisdiction = arg(field, 0),
+                            Some("regulation") => attr.regulation = arg(field, 0),
+                            _ => {}
+                        }
+                    }
+                    attrs.push(attr);
+                }
+                self.derived_attributes = Some(attrs);
+            }
+            _ => return false,
+        }
+        true
+    }
+
+    /// Add the sections of `part`, failing when a section that occurs once
+    /// in the typed fields is already set
+    fn merge(&mut self, part: CaseJson) -> bool {
+        let has_nature = |c: &CaseJson| !c.nature.is_empty() || !c.purpose.is_empty();
+        if (has_nature(&part) && has_nature(&*self))
+            || (!part.client_business_unit.is_empty() && !self.client_business_unit.is_empty())
+            || (!part.kyc_token.is_empty() && !self.kyc_token.is_empty())
+            || (part.ownership.is_some() && self.ownership.is_some())
+            || (part.data_dictionary.is_some() && self.data_dictionary.is_some())
+            || (part.derived_attributes.is_some() && self.derived_attributes.is_some())
+        {
+            return false;
+        }
+
+        if has_nature(&part) {
+            self.nature = part.nature;
+            self.purpose = part.purpose;
+        }
+        if !part.client_business_unit.is_empty() {
+            self.client_business_unit = part.client_business_unit;
+        }
+        if !part.kyc_token.is_empty() {
+            self.kyc_token = part.kyc_token;
+        }
+        if part.ownership.is_some() {
+            self.ownership = part.ownership;
+        }
+        if part.data_dictionary.is_some() {
+            self.data_dictionary = part.data_dictionary;
+        }
+        if part.derived_attributes.is_some() {
+            self.derived_attributes = part.derived_attributes;
+        }
+        self.functions.extend(part.functions);
+        self.policies.extend(part.policies);
+        self.obligations.extend(part.obligations);
+        self.document_requirements.extend(part.document_requirements);
+        true
+    }
+
+    /// Render the typed fields as sections, in grammar order
+    fn typed_sections(&self) -> Vec<Expr> {
+        let mut out = Vec::new();
+        if !self.nature.is_empty() || !self.purpose.is_empty() {
+            let mut np = Vec::new();
+            if !self.nature.is_empty() {
+                np.push(form("nature", &[&self.nature]));
+            }
+            if !self.purpose.is_empty() {
+                np.push(form("purpose", &[&self.purpose]));
+            }
+            out.push(Expr::Call("nature-purpose".to_string(), np));
+        }
+        if !self.client_business_unit.is_empty() {
+            out.push(form("client-business-unit", &[&self.client_business_unit]));
+        }
+        for f in &self.functions {
+            out.push(form("function", &[f]));
+        }
+        for p in &self.policies {
+            out.push(form("policy", &[p]));
+        }
+        for o in &self.obligations {
+            out.push(form("obligation", &[o]));
+        }
+        for req in &self.document_requirements {
+            let mut sec = Vec::new();
+            if !req.jurisdiction.is_empty() {
+                sec.push(form("jurisdiction", &[&req.jurisdiction]));
+            }
+            if let Some(documents) = &req.documents {
+                let required = documents
+                    .iter()
+                    .map(|d| {
+                        if d.name.is_empty() {
+                            form("document", &[&d.code])
+                        } else {
+                            form("document", &[&d.code, &d.name])
+                        }
+                    })
+                    .collect();
+                sec.push(Expr::Call("required".to_string(), required));
+            }
+            out.push(Expr::Call("document-requirements".to_string(), sec));
+        }
+        if let Some(o) = &self.ownership {
+            let mut sec = Vec::new();
+            if !o.entity.is_empty() {
+                sec.push(form("entity", &[&o.entity]));
+            }
+            for h in &o.owners {
+                sec.push(holding_form("owner", h));
+            }
+            for h in &o.beneficial_owners {
+                sec.push(holding_form("beneficial-owner", h));
+            }
+            for c in &o.controllers {
+                sec.push(form("controller", &[&c.name, &c.role]));
+            }
+            out.push(Expr::Call("ownership-structure".to_string(), sec));
+        }
+        if let Some(attrs) = &self.data_dictionary {
+            let sec = attrs
+                .iter()
+                .map(|a| {
+                    let mut attr = vec![Expr::Atom(a.code.clone())];
+                    for s in &a.sources {
+                        let value = if s.document.is_empty() {
+                            Expr::Atom(s.text.clone())
+                        } else {
+                            form("document", &[&s.document])
+                        };
+                        attr.push(Expr::Call(format!("{}-source", s.tier), vec![value]));
+                    }
+                    Expr::Call("attribute".to_string(), attr)
+                })
+                .collect();
+            out.push(Expr::Call("data-dictionary".to_string(), sec));
+        }
+        if let Some(attrs) = &self.derived_attributes {
+            let sec = attrs
+                .iter()
+                .map(|a| {
+                    let mut attr = vec![Expr::Atom(a.code.clone())];
+                    if let Some((first, rest)) = a.sources.split_first() {
+                        let names = Expr::Call(
+                            first.clone(),
+                            rest.iter().map(|s| Expr::Atom(s.clone())).collect(),
+                        );
+                        attr.push(Expr::Call("sources".to_string(), vec![names]));
+                    }
+                    if !a.rule.is_empty() {
+                        attr.push(form("rule", &[&a.rule]));
+                    }
+                    if !a.jurisdiction.is_empty() {
+                        attr.push(form("jurisdiction", &[&a.jurisdiction]));
+                    }
+                    if !a.regulation.is_empty() {
+                        attr.push(form("regulation", &[&a.regulation]));
+                    }
+                    Expr::Call("attribute".to_string(), attr)
+                })
+                .collect();
+            out.push(Expr::Call("derived-attributes".to_string(), sec));
+        }
+        if !self.kyc_token.is_empty() {
+            out.push(form("kyc-token", &[&self.kyc_token]));
+        }
+        out
+    }
+}
+
+/// Render a JSON value as YAML. Strings are written double-quoted with JSON
+/// escapes, which YAML reads the same way.
+pub fn to_yaml(value: &Value) -> String {
+    let mut out = String::new();
+    write_yaml(&mut out, value, 0);
+    out
+}
+
+fn write_yaml(out: &mut String, value: &Value, indent: usize) {
+    match value {
+        Value::Object(map) if !map.is_empty() => {
+            for (key, v) in map {
+                out.push_str(&" ".repeat(indent));
+                out.push_str(key);
+                out.push(':');
+                write_yaml_nested(out, v, indent);
+            }
+        }
+        Value::Array(items) if !items.is_empty() => {
+            for item in items {
+                out.push_str(&" ".repeat(indent));
+                out.push('-');
+                write_yaml_nested(out, item, indent);
+            }
+        }
+        scalar => {
+            out.push_str(&" ".repeat(indent));
+            out.push_str(&yaml_scalar(scalar));
+            out.push('\n');
+        }
+    }
+}
+
+fn write_yaml_nested(out: &mut String, value: &Value, indent: usize) {
+    match value {
+        Value::Object(map) if !map.is_empty() => {
+            out.push('\n');
+            write_yaml(out, value, indent + 2);
+        }
+        Value::Array(items) if !items.is_empty() => {
+            out.push('\n');
+            write_yaml(out, value, indent + 2);
+        }
+        scalar => {
+            out.push(' ');
+            out.push_str(&yaml_scalar(scalar));
+            out.push('\n');
+        }
+    }
+}
+
+fn yaml_scalar(value: &Value) -> String {
+    match value {
+        Value::Object(_) => "{}".to_string(),
+        Value::Array(_) => "[]".to_string(),
+        other => other.to_string(),
+    }
+}
+
+/// Convert a form to nested arrays of strings
+fn to_value(expr: &Expr) -> Value {
+    match expr {
+        Expr::Atom(s) => Value::String(s.clone()),
+        Expr::Call(name, args) => {
+            let mut items = vec![Value::String(name.clone())];
+            items.extend(args.iter().map(to_value));
+            Value::Array(items)
+        }
+    }
+}
+
+fn form(name: &str, args: &[&String]) -> Expr {
+    Expr::Call(
+        name.to_string(),
+        args.iter().map(|a| Expr::Atom((*a).clone())).collect(),
+    )
+}
+
+fn holding_form(name: &str, h: &Holding) -> Expr {
+    if h.percent.is_empty() {
+        form(name, &[&h.name])
+    } else {
+        form(name, &[&h.name, &h.percent])
+    }
+}
+
+fn holding(expr: &Expr) -> Holding {
+    Holding {
+        name: arg(expr, 0),
+        percent: arg(expr, 1),
+    }
+}
+
+fn call_name(expr: &Expr) -> Option<&str> {
+    match expr {
+        Expr::Call(name, _) => Some(name.as_str()),
+        Expr::Atom(_) => None,
+    }
+}
+
+fn call_args(expr: &Expr) -> &[Expr] {
+    match expr {
+        Expr::Call(_, args) => args,
+        Expr::Atom(_) => &[],
+    }
+}
+
+fn atom(expr: &Expr) -> String {
+    match expr {
+        Expr::Atom(s) => s.clone(),
+        Expr::Call(..) => String::new(),
+    }
+}
+
+/// The i-th atom argument of a call, or "" when absent
+fn arg(expr: &Expr, i: usize) -> String {
+    call_args(expr).get(i).map(atom).unwrap_or_default()
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+    use crate::parser;
+
+    #[test]
+    fn test_compile_typed_sections() {
+        let ast = parser::parse_strict(
+            "(kyc-case T (nature-purpose (nature \"Fund\") (purpose \"Onboarding\")) \
+             (policy P1) (policy P2) \
+             (ownership-structure (entity E) (owner A 60%) (controller J \"Director\")) \
+             (kyc-token \"pending\"))",
+        )
+        .unwrap();
+        let case = compile_case(&ast).unwrap();
+        assert_eq!(case.name, "T");
+        assert_eq!(case.nature, "Fund");
+        assert_eq!(case.policies, vec!["P1", "P2"]);
+        let ownership = case.ownership.unwrap();
+        assert_eq!(ownership.owners[0].percent, "60%");
+        assert_eq!(ownership.controllers[0].role, "Director");
+        assert!(case.sections.is_empty());
+    }
+
+    #[test]
+    fn test_unknown_and_irregular_sections_kept_as_arrays() {
+        let ast = parser::parse_strict(
+            "(kyc-case T (screening WORLD-CHECK (frequency daily)) (policy P1 EXTRA))",
+        )
+        .unwrap();
+        let case = compile_case(&ast).unwrap();
+        assert!(case.policies.is_empty());
+        assert_eq!(
+            serde_json::to_value(&case.sections).unwrap(),
+            serde_json::json!([
+                ["screening", "WORLD-CHECK", ["frequency", "daily"]],
+                ["policy", "P1", "EXTRA"]
+            ])
+        );
+    }
+
+    #[test]
+    fn test_yaml_output() {
+        let value = serde_json::json!({"name": "T", "policies": ["P1"], "sections": []});
+        assert_eq!(to_yaml(&value), "name: \"T\"\npolicies:\n  - \"P1\"\nsections: []\n");
+    }
+}
@@ -1,6 +1,9 @@
 pub mod compiler;
 pub mod executor;
+pub mod interchange;
 pub mod parser;
+pub mod printer;
+pub mod roundtrip;
 
 use serde::{Deserialize, Serialize};
 use thiserror::Error;
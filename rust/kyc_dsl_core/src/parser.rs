@@ -68,6 +68,18 @@ pub fn parse(src: &str) -> Result<Expr, nom::Err<nom::error::Error<&str>>> {
     Ok(res)
 }
 
+/// Parse a complete DSL source file, rejecting trailing input that `parse`
+/// silently ignores (unbalanced parentheses, comments, a second top-level form)
+pub fn parse_strict(src: &str) -> Result<Expr, String> {
+    let trimmed = src.trim();
+    let (rest, res) = expr(trimmed).map_err(|e| e.to_string())?;
+    if !rest.trim().is_empty() {
+        let snippet: String = rest.trim().chars().take(40).collect();
+        return Err(format!("unexpected trailing input: {}", snippet));
+    }
+    Ok(res)
+}
+
 #[cfg(test)]
 mod tests {
     use super::*;
@@ -105,6 +117,14 @@ mod tests {
         }
     }
 
+    #[test]
+    fn test_parse_strict_rejects_trailing_input() {
+        assert!(parse("(kyc-case A) (kyc-case B)").is_ok());
+        assert!(parse_strict("(kyc-case A) (kyc-case B)").is_err());
+        assert!(parse_strict("(kyc-case A (nature X)))").is_err());
+        assert!(parse_strict("  (kyc-case A)\n").is_ok());
+    }
+
     #[test]
     fn test_parse_quoted_string() {
         let result = parse("\"Hello World\"");
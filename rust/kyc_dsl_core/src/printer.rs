@@ -0,0 +1,109 @@
+use crate::parser::Expr;
+
+/// Maximum line width before a call is broken across lines
+const MAX_WIDTH: usize = 80;
+
+/// Indentation added per nesting level
+const INDENT: usize = 2;
+
+/// Print an expression as canonical DSL text.
+///
+/// Layout rules:
+/// - a call that fits within `MAX_WIDTH` is printed on one line
+/// - otherwise leading atoms stay on the head line and every remaining
+///   argument goes on its own line, indented by two spaces
+/// - atoms are quoted only when they are not valid bare identifiers
+pub fn to_dsl(expr: &Expr) -> String {
+    let mut out = String::new();
+    write_expr(&mut out, expr, 0);
+    out.push('\n');
+    out
+}
+
+fn write_expr(out: &mut String, expr: &Expr, indent: usize) {
+    match expr {
+        Expr::Atom(value) => out.push_str(&format_atom(value)),
+        Expr::Call(name, args) => {
+            let flat = flat(expr);
+            if indent + flat.chars().count() <= MAX_WIDTH {
+                out.push_str(&flat);
+                return;
+            }
+
+            out.push('(');
+            out.push_str(&format_atom(name));
+
+            let leading = args
+                .iter()
+                .take_while(|arg| matches!(arg, Expr::Atom(_)))
+                .count();
+            for arg in &args[..leading] {
+                out.push(' ');
+                write_expr(out, arg, indent);
+            }
+            for arg in &args[leading..] {
+                out.push('\n');
+                out.push_str(&" ".repeat(indent + INDENT));
+                write_expr(out, arg, indent + INDENT);
+            }
+            out.push(')');
+        }
+    }
+}
+
+/// Render an expression on a single line
+fn flat(expr: &Expr) -> String {
+    match expr {
+        Expr::Atom(value) => format_atom(value),
+        Expr::Call(name, args) => {
+            let mut parts = Vec::with_capacity(args.len() + 1);
+            parts.push(format_atom(name));
+            parts.extend(args.iter().map(flat));
+            format!("({})", parts.join(" "))
+        }
+    }
+}
+
+/// Quote an atom unless the parser accepts it as a bare identifier
+fn format_atom(value: &str) -> String {
+    if is_bare_atom(value) {
+        value.to_string()
+    } else {
+        format!("\"{}\"", value)
+    }
+}
+
+fn is_bare_atom(value: &str) -> bool {
+    !value.is_empty()
+        && value
+            .chars()
+            .all(|c| c.is_alphanumeric() || "_-%.".contains(c))
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+    use crate::parser::parse;
+
+    #[test]
+    fn test_print_short_call_on_one_line() {
+        let ast = parse("(kyc-case   TEST  (nature \"Corporate\"))").unwrap();
+        assert_eq!(to_dsl(&ast), "(kyc-case TEST (nature Corporate))\n");
+    }
+
+    #[test]
+    fn test_print_quotes_only_when_needed() {
+        let ast = parse("(controller JANE \"Chief Executive\")").unwrap();
+        assert_eq!(to_dsl(&ast), "(controller JANE \"Chief Executive\")\n");
+    }
+
+    #[test]
+    fn test_print_breaks_long_calls() {
+        let src = "(kyc-case LONG-CASE-NAME (nature \"Institutional Fund Management\") (policy KYCPOL-EU-2025))";
+        let printed = to_dsl(&parse(src).unwrap());
+        assert_eq!(
+            printed,
+            "(kyc-case LONG-CASE-NAME\n  (nature \"Institutional Fund Management\")\n  (policy KYCPOL-EU-2025))\n"
+        );
+    }
+}
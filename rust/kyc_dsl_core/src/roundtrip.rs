@@ -0,0 +1,212 @@
+//! Serialization round-trip checks.
+//!
+//! A snapshot round-trips when parse → print → parse reproduces the same AST
+//! and printing the re-parsed AST yields identical text. Any drift here shows
+//! up in production as a spurious new case version.
+
+use crate::parser::{self, Expr};
+use crate::printer;
+use thiserror::Error;
+
+#[derive(Debug, Error, PartialEq)]
+pub enum RoundTripError {
+    #[error("source does not parse: {0}")]
+    Parse(String),
+    #[error("canonical output does not re-parse: {0}")]
+    Reparse(String),
+    #[error("AST changed after print and re-parse")]
+    AstMismatch,
+    #[error("printing the re-parsed AST produced different text")]
+    NotIdempotent,
+}
+
+/// Check that `src` survives a parse → print → parse round trip and return
+/// its canonical text
+pub fn check(src: &str) -> Result<String, RoundTripError> {
+    let original = parser::parse_strict(src).map_err(RoundTripError::Parse)?;
+    check_ast(&original)
+}
+
+/// Check that an AST survives a print → parse round trip and return its
+/// canonical text
+pub fn check_ast(ast: &Expr) -> Result<String, RoundTripError> {
+    let canonical = printer::to_dsl(ast);
+    let reparsed = parser::parse_strict(&canonical).map_err(RoundTripError::Reparse)?;
+    if &reparsed != ast {
+        return Err(RoundTripError::AstMismatch);
+    }
+    if printer::to_dsl(&reparsed) != canonical {
+        return Err(RoundTripError::NotIdempotent);
+    }
+    Ok(canonical)
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+    use std::fs;
+    use std::path::{Path, PathBuf};
+
+    /// Corpus of real case snapshots; each `NAME.dsl` has a `NAME.golden`
+    /// holding its canonical form. Regenerate with
+    /// `UPDATE_GOLDEN=1 cargo test -p kyc_dsl_core roundtrip`.
+    const CORPUS_DIR: &str = concat!(env!("CARGO_MANIFEST_DIR"), "/testdata/roundtrip");
+
+    fn corpus() -> Vec<PathBuf> {
+        let mut files: Vec<PathBuf> = fs::read_dir(CORPUS_DIR)
+            .expect("roundtrip corpus directory missing")
+            .map(|entry| entry.unwrap().path())
+            .filter(|path| path.extension().is_some_and(|ext| ext == "dsl"))
+            .collect();
+        files.sort();
+        assert!(!files.is_empty(), "roundtrip corpus is empty");
+        files
+    }
+
+    fn name(path: &Path) -> String {
+        path.file_name().unwrap().to_string_lossy().into_owned()
+    }
+
+    #[test]
+    fn test_corpus_matches_golden() {
+        let update = std::env::var_os("UPDATE_GOLDEN").is_some();
+        let mut failures = Vec::new();
+
+        for path in corpus() {
+            let src = fs::read_to_string(&path).unwrap();
+            let canonical = match check(&src) {
+                Ok(canonical) => canonical,
+                Err(e) => {
+                    failures.push(format!("{}: {}", name(&path), e));
+                    continue;
+                }
+            };
+
+            let golden_path = path.with_extension("golden");
+            if update {
+                fs::write(&golden_path, &canonical).unwrap();
+                continue;
+            }
+            match fs::read_to_string(&golden_path) {
+                Ok(golden) if golden == canonical => {}
+                Ok(_) => failures.push(format!(
+                    "{}: canonical output differs from {}",
+                    name(&path),
+                    name(&golden_path)
+                )),
+                Err(_) => failures.push(format!("{}: missing {}", name(&path), name(&golden_path))),
+            }
+        }
+
+        assert!(
+            failures.is_empty(),
+            "roundtrip failures:\n  {}",
+            failures.join("\n  ")
+        );
+    }
+
+    #[test]
+    fn test_golden_files_are_fixed_points() {
+        for path in corpus() {
+            let golden = fs::read_to_string(path.with_extension("golden")).unwrap_or_default();
+            if golden.is_empty() {
+                continue;
+            }
+            assert_eq!(
+                check(&golden).as_deref(),
+                Ok(golden.as_str()),
+                "{}",
+                name(&path)
+            );
+        }
+    }
+
+    /// Small deterministic PRNG so generated cases are reproducible
+    struct XorShift(u64);
+
+    impl XorShift {
+        fn next_u64(&mut self) -> u64 {
+            let mut x = self.0;
+            x ^= x << 13;
+            x ^= x >> 7;
+            x ^= x << 17;
+            self.0 = x;
+            x
+        }
+
+        fn below(&mut self, n: usize) -> usize {
+            (self.next_u64() % n as u64) as usize
+        }
+    }
+
+    const ATOM_CHARS: &[char] = &[
+        'A', 'Z', 'k', 'y', '0', '9', '_', '-', '%', '.', ' ', '(', ')', '/', ':', '\n', 'é',
+    ];
+    const NOISE_CHARS: &[char] = &['(', ')', '"', ' ', ';', '\n', 'X', '%'];
+
+    fn random_atom(rng: &mut XorShift) -> String {
+        let len = 1 + rng.below(8);
+        (0..len)
+            .map(|_| ATOM_CHARS[rng.below(ATOM_CHARS.len())])
+            .collect()
+    }
+
+    fn random_call(rng: &mut XorShift, depth: usize) -> Expr {
+        let argc = rng.below(5);
+        let args = (0..argc)
+            .map(|_| {
+                if depth == 0 || rng.below(3) == 0 {
+                    Expr::Atom(random_atom(rng))
+                } else {
+                    random_call(rng, depth - 1)
+                }
+            })
+            .collect();
+        Expr::Call(random_atom(rng), args)
+    }
+
+    #[test]
+    fn test_generated_trees_roundtrip() {
+        let mut rng = XorShift(0x5eed_2259);
+        for i in 0..2000 {
+            let tree = random_call(&mut rng, 4);
+            if let Err(e) = check_ast(&tree) {
+                panic!("generated case {} failed: {}\n{:?}", i, e, tree);
+            }
+        }
+    }
+
+    /// Mutate real snapshots (truncate, delete, insert) and require that the
+    /// parser never panics and that anything it accepts also round-trips.
+    #[test]
+    fn test_mutated_corpus_never_breaks_roundtrip() {
+        let mut rng = XorShift(0xfeed_2259);
+        for path in corpus() {
+            let chars: Vec<char> = fs::read_to_string(&path).unwrap().chars().collect();
+            for _ in 0..300 {
+                let mut mutated = chars.clone();
+                let pos = rng.below(mutated.len().max(1));
+                match rng.below(3) {
+                    0 => mutated.truncate(pos),
+                    1 if !mutated.is_empty() => {
+                        mutated.remove(pos);
+                    }
+                    _ => mutated.insert(pos, NOISE_CHARS[rng.below(NOISE_CHARS.len())]),
+                }
+                let src: String = mutated.into_iter().collect();
+
+                let _ = parser::parse(&src);
+                if parser::parse_strict(&src).is_ok() {
+                    if let Err(e) = check(&src) {
+                        panic!(
+                            "{}: accepted mutation failed round-trip: {}\n{}",
+                            name(&path),
+                            e,
+                            src
+                        );
+                    }
+                }
+            }
+        }
+    }
+}
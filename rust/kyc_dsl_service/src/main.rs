@@ -1,6 +1,13 @@
-use kyc_dsl_core::{compile_dsl, execute_plan, parser};
+use kyc_dsl_core::{compile_dsl, execute_plan, interchange, parser};
+use std::any::Any;
+use std::time::{Duration, Instant};
+use subtle::ConstantTimeEq;
+use tokio::io::{AsyncReadExt, AsyncWriteExt};
+use tokio::net::TcpListener;
+use tonic::service::{interceptor::InterceptedService, Interceptor};
 use tonic::{transport::Server, Request, Response, Status};
 use tonic_reflection::server::Builder as ReflectionBuilder;
+use tower_http::catch_panic::CatchPanicLayer;
 
 // Include the generated protobuf code (suppress warnings from generated code)
 #[allow(dead_code, unused_imports, clippy::all)]
@@ -146,6 +153,45 @@ impl DslService for RustDslServer {
         }
     }
 
+    /// Compile a DSL case to its JSON or YAML interchange form
+    async fn compile(
+        &self,
+        request: Request<CompileRequest>,
+    ) -> Result<Response<CompileResponse>, Status> {
+        let req = request.into_inner();
+        let format = if req.format.is_empty() {
+            "json".to_string()
+        } else {
+            req.format.to_lowercase()
+        };
+
+        let result = parser::parse_strict(&req.dsl)
+            .and_then(|ast| interchange::compile_case(&ast))
+            .and_then(|case| serde_json::to_value(&case).map_err(|e| e.to_string()))
+            .and_then(|value| match format.as_str() {
+                "json" => serde_json::to_string_pretty(&value).map_err(|e| e.to_string()),
+                "yaml" => Ok(interchange::to_yaml(&value)),
+                other => Err(format!("unknown format '{}' (expected json or yaml)", other)),
+            });
+
+        match result {
+            Ok(output) => Ok(Response::new(CompileResponse {
+                success: true,
+                output,
+                format,
+                message: "Compile successful".to_string(),
+                errors: vec![],
+            })),
+            Err(e) => Ok(Response::new(CompileResponse {
+                success: false,
+                output: String::new(),
+                format,
+                message: format!("Compile failed: {}", e),
+                errors: vec![e],
+            })),
+        }
+    }
+
     /// Apply an amendment to a case
     async fn amend(
         &self,
@@ -253,7 +299,9 @@ PERCENT     = [0-9]+ "." [0-9]+ "%"
     }
 }
 
-/// Extract case information from parsed AST
+/// Extract case information from parsed AST. Repeated sections fill the
+/// repeated fields; the singular policy, function, obligation and
+/// document_requirements hold the first of each for older clients.
 fn extract_case_info(ast: &parser::Expr) -> ParsedCase {
     let mut case = ParsedCase {
         name: "UNKNOWN".to_string(),
@@ -270,35 +318,41 @@ fn extract_case_info(ast: &parser::Expr) -> ParsedCase {
             for arg in &args[1..] {
                 if let parser::Expr::Call(form_name, form_args) = arg {
                     match form_name.as_str() {
-                        "nature" => {
-                            if let Some(parser::Expr::Atom(val)) = form_args.first() {
-                                case.nature = val.clone();
-                            }
-                        }
-                        "purpose" => {
-                            if let Some(parser::Expr::Atom(val)) = form_args.first() {
-                                case.purpose = val.clone();
+                        "nature" => case.nature = atom_arg(form_args, 0),
+                        "purpose" => case.purpose = atom_arg(form_args, 0),
+                        // serialize_case nests nature and purpose in this form
+                        "nature-purpose" => {
+                            for sub in form_args {
+                                if let parser::Expr::Call(sub_name, sub_args) = sub {
+                                    match sub_name.as_str() {
+                                        "nature" => case.nature = atom_arg(sub_args, 0),
+                                        "purpose" => case.purpose = atom_arg(sub_args, 0),
+                                        _ => {}
+                                    }
+                                }
                             }
                         }
                         "client-business-unit" => {
-                            if let Some(parser::Expr::Atom(val)) = form_args.first() {
-                                case.client_business_unit = val.clone();
-                            }
+                            case.client_business_unit = atom_arg(form_args, 0)
                         }
-                        "policy" => {
-                            if let Some(parser::Expr::Atom(val)) = form_args.first() {
-                                case.policy = val.clone();
-                            }
+                        "policy" => case.policies.push(atom_arg(form_args, 0)),
+                        "function" => case.functions.push(CaseFunction {
+                            action: atom_arg(form_args, 0),
+                            status: atom_arg(form_args, 1),
+                        }),
+                        "obligation" => case.obligations.push(atom_arg(form_args, 0)),
+                        "kyc-token" => case.kyc_token = atom_arg(form_args, 0),
+                        "ownership-structure" => {
+                            case.ownership = Some(extract_ownership(form_args))
                         }
-                        "function" => {
-                            if let Some(parser::Expr::Atom(val)) = form_args.first() {
-                                case.function = val.clone();
-                            }
+                        "data-dictionary" => {
+                            case.data_dictionary = Some(extract_data_dictionary(form_args))
                         }
-                        "kyc-token" => {
-                            if let Some(parser::Expr::Atom(val)) = form_args.first() {
-                                case.kyc_token = val.clone();
-                            }
+                        "document-requirements" => case
+                            .document_requirement_sets
+                            .push(extract_document_requirements(form_args)),
+                        "derived-attributes" => {
+                            case.derived_attributes = extract_derived_attributes(form_args)
                         }
                         _ => {}
                     }
@@ -307,10 +361,150 @@ fn extract_case_info(ast: &parser::Expr) -> ParsedCase {
         }
     }
 
+    case.policy = case.policies.first().cloned().unwrap_or_default();
+    case.function = case
+        .functions
+        .first()
+        .map(|f| f.action.clone())
+        .unwrap_or_default();
+    case.obligation = case.obligations.first().cloned().unwrap_or_default();
+    case.document_requirements = case.document_requirement_sets.first().cloned();
     case
 }
 
-/// Serialize a ParsedCase back to DSL format
+/// The i-th argument of a form when it is an atom, or ""
+fn atom_arg(args: &[parser::Expr], i: usize) -> String {
+    match args.get(i) {
+        Some(parser::Expr::Atom(val)) => val.clone(),
+        _ => String::new(),
+    }
+}
+
+/// Parse a percentage written as `35%`, `35` or `35.5%`
+fn percentage(args: &[parser::Expr], i: usize) -> f32 {
+    atom_arg(args, i)
+        .trim_end_matches('%')
+        .parse()
+        .unwrap_or_default()
+}
+
+fn extract_ownership(args: &[parser::Expr]) -> OwnershipStructure {
+    let mut ownership = OwnershipStructure::default();
+    for entry in args {
+        if let parser::Expr::Call(name, entry_args) = entry {
+            match name.as_str() {
+                "entity" => ownership.entity_name = atom_arg(entry_args, 0),
+                "owner" => ownership.owners.push(Owner {
+                    name: atom_arg(entry_args, 0),
+                    percentage: percentage(entry_args, 1),
+                }),
+                "beneficial-owner" => ownership.beneficial_owners.push(BeneficialOwner {
+                    name: atom_arg(entry_args, 0),
+                    percentage: percentage(entry_args, 1),
+                }),
+                "controller" => ownership.controllers.push(Controller {
+                    name: atom_arg(entry_args, 0),
+                    role: atom_arg(entry_args, 1),
+                }),
+                _ => {}
+            }
+        }
+    }
+    ownership
+}
+
+/// `(attribute CODE (primary-source (document DOC)) (tertiary-source "text") ...)`;
+/// a source is the document code or the text
+fn extract_data_dictionary(args: &[parser::Expr]) -> DataDictionary {
+    let mut dictionary = DataDictionary::default();
+    for attr in args {
+        if let parser::Expr::Call(_, attr_args) = attr {
+            let mut def = AttributeDefinition {
+                code: atom_arg(attr_args, 0),
+                ..Default::default()
+            };
+            for source in attr_args.iter().skip(1) {
+                if let parser::Expr::Call(tier, source_args) = source {
+                    let value = match source_args.first() {
+                        Some(parser::Expr::Call(doc, doc_args)) if doc == "document" => {
+                            atom_arg(doc_args, 0)
+                        }
+                        _ => atom_arg(source_args, 0),
+                    };
+                    match tier.as_str() {
+                        "primary-source" => def.primary_sources.push(value),
+                        "secondary-source" => def.secondary_sources.push(value),
+                        "tertiary-source" => def.tertiary_sources.push(value),
+                        _ => {}
+                    }
+                }
+            }
+            dictionary.attributes.push(def);
+        }
+    }
+    dictionary
+}
+
+fn extract_document_requirements(args: &[parser::Expr]) -> DocumentRequirements {
+    let mut requirements = DocumentRequirements::default();
+    for part in args {
+        if let parser::Expr::Call(name, part_args) = part {
+            match name.as_str() {
+                "jurisdiction" => requirements.jurisdiction = atom_arg(part_args, 0),
+                "required" => {
+                    for doc in part_args {
+                        if let parser::Expr::Call(_, doc_args) = doc {
+                            requirements.required.push(DocumentRequirement {
+                                code: atom_arg(doc_args, 0),
+                                name: atom_arg(doc_args, 1),
+                            });
+                        }
+                    }
+                }
+                _ => {}
+            }
+        }
+    }
+    requirements
+}
+
+fn extract_derived_attributes(args: &[parser::Expr]) -> Vec<DerivedAttributeDefinition> {
+    let mut attrs = Vec::new();
+    for attr in args {
+        if let parser::Expr::Call(_, attr_args) = attr {
+            let mut def = DerivedAttributeDefinition {
+                code: atom_arg(attr_args, 0),
+                ..Default::default()
+            };
+            for field in attr_args.iter().skip(1) {
+                if let parser::Expr::Call(name, field_args) = field {
+                    match name.as_str() {
+                        // (sources (A B)) parses as a call of A with argument B
+                        "sources" => {
+                            if let Some(parser::Expr::Call(first, rest)) = field_args.first() {
+                                def.source_attributes.push(first.clone());
+                                for source in rest {
+                                    if let parser::Expr::Atom(source) = source {
+                                        def.source_attributes.push(source.clone());
+                                    }
+                                }
+                            }
+                        }
+                        "rule" => def.rule_expression = atom_arg(field_args, 0),
+                        "jurisdiction" => def.jurisdiction = atom_arg(field_args, 0),
+                        "regulation" => def.regulation_code = atom_arg(field_args, 0),
+                        _ => {}
+                    }
+                }
+            }
+            attrs.push(def);
+        }
+    }
+    attrs
+}
+
+/// Serialize a ParsedCase back to DSL format. The repeated fields are
+/// written when set, otherwise the singular ones from older clients.
 fn serialize_case(case: &ParsedCase) -> String {
     let mut dsl = format!("(kyc-case {}\n", case.name);
 
@@ -332,16 +526,55 @@ fn serialize_case(case: &ParsedCase) -> String {
         ));
     }
 
-    if !case.policy.is_empty() {
-        dsl.push_str(&format!("  (policy {})\n", case.policy));
+    for policy in repeated_or_single(&case.policies, &case.policy) {
+        dsl.push_str(&format!("  (policy {})\n", policy));
     }
 
-    if !case.function.is_empty() {
-        dsl.push_str(&format!("  (function {})\n", case.function));
+    if case.functions.is_empty() {
+        if !case.function.is_empty() {
+            dsl.push_str(&format!("  (function {})\n", case.function));
+        }
+    } else {
+        for function in &case.functions {
+            if function.status.is_empty() {
+                dsl.push_str(&format!("  (function {})\n", function.action));
+            } else {
+                dsl.push_str(&format!(
+                    "  (function {} {})\n",
+                    function.action, function.status
+                ));
+            }
+        }
     }
 
-    if !case.obligation.is_empty() {
-        dsl.push_str(&format!("  (obligation {})\n", case.obligation));
+    for obligation in repeated_or_single(&case.obligations, &case.obligation) {
+        dsl.push_str(&format!("  (obligation {})\n", obligation));
+    }
+
+    let requirement_sets: Vec<&DocumentRequirements> = if case.document_requirement_sets.is_empty()
+    {
+        case.document_requirements.iter().collect()
+    } else {
+        case.document_requirement_sets.iter().collect()
+    };
+    for requirements in requirement_sets {
+        dsl.push_str("  (document-requirements\n");
+        if !requirements.jurisdiction.is_empty() {
+            dsl.push_str(&format!(
+                "    (jurisdiction {})\n",
+                requirements.jurisdiction
+            ));
+        }
+        dsl.push_str("    (required\n");
+        for doc in &requirements.required {
+            if doc.name.is_empty() {
+                dsl.push_str(&format!("      (document {})\n", doc.code));
+            } else {
+                dsl.push_str(&format!("      (document {} \"{}\")\n", doc.code, doc.name));
+            }
+        }
+        dsl.push_str("    )\n");
+        dsl.push_str("  )\n");
     }
 
     // Ownership structure
@@ -371,6 +604,54 @@ fn serialize_case(case: &ParsedCase) -> String {
         dsl.push_str("  )\n");
     }
 
+    if let Some(dictionary) = &case.data_dictionary {
+        if !dictionary.attributes.is_empty() {
+            dsl.push_str("  (data-dictionary\n");
+            for attr in &dictionary.attributes {
+                dsl.push_str(&format!("    (attribute {}", attr.code));
+                for (tier, sources) in [
+                    ("primary", &attr.primary_sources),
+                    ("secondary", &attr.secondary_sources),
+                    ("tertiary", &attr.tertiary_sources),
+                ] {
+                    for source in sources {
+                        dsl.push_str(&format!(
+                            "\n      ({}-source {})",
+                            tier,
+                            source_value(source)
+                        ));
+                    }
+                }
+                dsl.push_str(")\n");
+            }
+            dsl.push_str("  )\n");
+        }
+    }
+
+    if !case.derived_attributes.is_empty() {
+        dsl.push_str("  (derived-attributes\n");
+        for attr in &case.derived_attributes {
+            dsl.push_str(&format!("    (attribute {}", attr.code));
+            if !attr.source_attributes.is_empty() {
+                dsl.push_str(&format!(
+                    "\n      (sources ({}))",
+                    attr.source_attributes.join(" ")
+                ));
+            }
+            if !attr.rule_expression.is_empty() {
+                dsl.push_str(&format!("\n      (rule \"{}\")", attr.rule_expression));
+            }
+            if !attr.jurisdiction.is_empty() {
+                dsl.push_str(&format!("\n      (jurisdiction {})", attr.jurisdiction));
+            }
+            if !attr.regulation_code.is_empty() {
+                dsl.push_str(&format!("\n      (regulation {})", attr.regulation_code));
+            }
+            dsl.push_str(")\n");
+        }
+        dsl.push_str("  )\n");
+    }
+
     if !case.kyc_token.is_empty() {
         dsl.push_str(&format!("  (kyc-token \"{}\")\n", case.kyc_token));
     }
@@ -379,10 +660,125 @@ fn serialize_case(case: &ParsedCase) -> String {
     dsl
 }
 
+/// The repeated values when set, otherwise the singular one when set
+fn repeated_or_single<'a>(repeated: &'a [String], single: &'a str) -> Vec<&'a str> {
+    if !repeated.is_empty() {
+        repeated.iter().map(String::as_str).collect()
+    } else if !single.is_empty() {
+        vec![single]
+    } else {
+        vec![]
+    }
+}
+
+/// A data-dictionary source: `(document CODE)` for a document code,
+/// otherwise quoted text
+fn source_value(source: &str) -> String {
+    let is_code = !source.is_empty()
+        && source
+            .chars()
+            .all(|c| c.is_ascii_uppercase() || c.is_ascii_digit() || "_-".contains(c));
+    if is_code {
+        format!("(document {})", source)
+    } else {
+        format!("\"{}\"", source)
+    }
+}
+
+/// Answers the HTTP liveness (/healthz) and readiness (/readyz) probes. The
+/// service has no dependencies, so it is ready once it serves gRPC.
+async fn serve_probes(addr: String) -> std::io::Result<()> {
+    let listener = TcpListener::bind(&addr).await?;
+    let started = Instant::now();
+    loop {
+        let (mut stream, _) = listener.accept().await?;
+        tokio::spawn(async move {
+            let mut buf = [0u8; 1024];
+            let n = stream.read(&mut buf).await.unwrap_or(0);
+            let request = String::from_utf8_lossy(&buf[..n]);
+            let path = request.split_whitespace().nth(1).unwrap_or("");
+            let (status, body) = match path {
+                "/healthz" => (
+                    "200 OK",
+                    format!(
+                        r#"{{"status":"ok","service":"kyc_dsl_service","uptime_seconds":{}}}"#,
+                        started.elapsed().as_secs()
+                    ),
+                ),
+                "/readyz" => (
+                    "200 OK",
+                    r#"{"service":"kyc_dsl_service","status":"ready","checks":{}}"#.to_string(),
+                ),
+                _ => ("404 Not Found", r#"{"error":"not found"}"#.to_string()),
+            };
+            let response = format!(
+                "HTTP/1.1 {}\r\nContent-Type: application/json\r\nCache-Control: no-store\r\nContent-Length: {}\r\nConnection: close\r\n\r\n{}",
+                status,
+                body.len(),
+                body
+            );
+            let _ = stream.write_all(response.as_bytes()).await;
+        });
+    }
+}
+
+/// Requires the x-api-key metadata to match RUST_DSL_API_KEY, when set, on
+/// DslService calls. Health checks and reflection stay public.
+#[derive(Clone)]
+struct ApiKeyCheck {
+    key: Option<String>,
+}
+
+impl Interceptor for ApiKeyCheck {
+    fn call(&mut self, request: Request<()>) -> Result<Request<()>, Status> {
+        let Some(key) = &self.key else {
+            return Ok(request);
+        };
+        match request.metadata().get("x-api-key").and_then(|v| v.to_str().ok()) {
+            // Constant-time, so response timing does not leak the key
+            Some(sent) if bool::from(sent.as_bytes().ct_eq(key.as_bytes())) => Ok(request),
+            Some(_) => Err(Status::unauthenticated("invalid API key")),
+            None => Err(Status::unauthenticated("authentication required")),
+        }
+    }
+}
+
+/// Answers a call whose handler panicked with an INTERNAL status, so the
+/// connection and the server survive it
+fn panic_status(
+    panic: Box<dyn Any + Send + 'static>,
+) -> tonic::codegen::http::Response<tonic::body::BoxBody> {
+    let detail = panic
+        .downcast_ref::<String>()
+        .map(String::as_str)
+        .or_else(|| panic.downcast_ref::<&str>().copied())
+        .unwrap_or("unknown panic");
+    eprintln!("Recovered from panic in gRPC handler: {}", detail);
+    Status::internal("internal error").into_http()
+}
+
+/// Reads a duration in seconds from an environment variable
+fn env_seconds(name: &str, default: u64) -> Duration {
+    Duration::from_secs(
+        std::env::var(name)
+            .ok()
+            .and_then(|v| v.parse().ok())
+            .unwrap_or(default),
+    )
+}
+
 #[tokio::main]
 async fn main() -> Result<(), Box<dyn std::error::Error>> {
     let addr = "0.0.0.0:50060".parse()?;
+    let probe_addr =
+        std::env::var("RUST_DSL_HEALTH_ADDR").unwrap_or_else(|_| "0.0.0.0:50061".to_string());
     let service = RustDslServer;
+    let api_key = std::env::var("RUST_DSL_API_KEY").ok().filter(|k| !k.is_empty());
+    let timeout = env_seconds("RUST_DSL_TIMEOUT_SECONDS", 30);
+    let max_message_bytes = std::env::var("RUST_DSL_MAX_MESSAGE_BYTES")
+        .ok()
+        .and_then(|v| v.parse().ok())
+        .unwrap_or(1 << 20);
 
     println!("🦀 Rust DSL gRPC Service");
     println!("========================");
@@ -399,6 +795,19 @@ async fn main() -> Result<(), Box<dyn std::error::Error>> {
     println!("  - ListAmendments");
     println!("  - GetGrammar");
     println!();
+    println!("Health: grpc.health.v1.Health, HTTP /healthz and /readyz on {}", probe_addr);
+    println!(
+        "Auth: {}",
+        if api_key.is_some() {
+            "x-api-key required (RUST_DSL_API_KEY)"
+        } else {
+            "disabled"
+        }
+    );
+    println!(
+        "Request timeout: {:?}, max message: {} bytes",
+        timeout, max_message_bytes
+    );
     println!("Ready to accept connections...");
 
     // Build reflection service for grpcurl compatibility
@@ -406,11 +815,168 @@ async fn main() -> Result<(), Box<dyn std::error::Error>> {
         .register_encoded_file_descriptor_set(tonic::include_file_descriptor_set!("dsl_descriptor"))
         .build_v1()?;
 
+    // Standard gRPC health checks (grpc.health.v1.Health) for kyc.dsl.DslService
+    let (mut health_reporter, health_service) = tonic_health::server::health_reporter();
+    health_reporter
+        .set_serving::<DslServiceServer<RustDslServer>>()
+        .await;
+
+    tokio::spawn(async move {
+        if let Err(e) = serve_probes(probe_addr).await {
+            eprintln!("Health probes stopped: {}", e);
+        }
+    });
+
+    let dsl_service = InterceptedService::new(
+        DslServiceServer::new(service).max_decoding_message_size(max_message_bytes),
+        ApiKeyCheck { key: api_key },
+    );
+
     Server::builder()
-        .add_service(DslServiceServer::new(service))
+        .timeout(timeout)
+        .layer(CatchPanicLayer::custom(panic_status))
+        .add_service(health_service)
+        .add_service(dsl_service)
         .add_service(reflection_service)
         .serve(addr)
         .await?;
 
     Ok(())
 }
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+    use std::fs;
+
+    const CORPUS_DIR: &str = concat!(
+        env!("CARGO_MANIFEST_DIR"),
+        "/../kyc_dsl_core/testdata/roundtrip"
+    );
+
+    /// parse → ParsedCase → serialize → parse must reproduce the same ParsedCase
+    #[test]
+    fn test_parsed_case_roundtrip() {
+        let mut checked = 0;
+        for entry in fs::read_dir(CORPUS_DIR).expect("roundtrip corpus directory missing") {
+            let path = entry.unwrap().path();
+            if !path.extension().is_some_and(|ext| ext == "dsl") {
+                continue;
+            }
+            let src = fs::read_to_string(&path).unwrap();
+            let case = extract_case_info(&parser::parse(&src).unwrap());
+
+            let serialized = serialize_case(&case);
+            let reparsed = parser::parse_strict(&serialized).unwrap_or_else(|e| {
+                panic!("{}: serialized case does not parse: {}", path.display(), e)
+            });
+            assert_eq!(
+                extract_case_info(&reparsed),
+                case,
+                "{}: case changed after serialize round-trip:\n{}",
+                path.display(),
+                serialized
+            );
+            checked += 1;
+        }
+        assert!(checked > 0, "roundtrip corpus is empty");
+    }
+
+    /// Every section the proto carries survives parse → serialize → parse
+    #[test]
+    fn test_parsed_case_roundtrip_all_sections() {
+        let src = fs::read_to_string(format!("{}/ontology_example.dsl", CORPUS_DIR)).unwrap();
+        let case = extract_case_info(&parser::parse(&src).unwrap());
+
+        assert_eq!(case.policies, vec!["KYCPOL-EU-2025", "AML-GLOBAL-BASE"]);
+        assert_eq!(case.policy, "KYCPOL-EU-2025");
+        assert_eq!(case.functions.len(), 5);
+        assert_eq!(case.function, "DISCOVER-POLICIES");
+        assert_eq!(case.obligations.len(), 3);
+        assert_eq!(case.document_requirement_sets.len(), 2);
+        assert_eq!(
+            case.document_requirements.as_ref().unwrap().required[0].name,
+            "Certificate of Incorporation"
+        );
+        let dictionary = case.data_dictionary.as_ref().unwrap();
+        assert_eq!(dictionary.attributes.len(), 4);
+        assert_eq!(
+            dictionary.attributes[3].primary_sources,
+            vec!["UBO-DECL", "SHARE-REGISTER"]
+        );
+        assert_eq!(
+            dictionary.attributes[0].tertiary_sources,
+            vec!["Ops Validation"]
+        );
+        let ownership = case.ownership.as_ref().unwrap();
+        assert_eq!(ownership.entity_name, "BLACKROCK-GLOBAL-FUNDS");
+        assert_eq!(ownership.beneficial_owners[0].percentage, 35.0);
+        assert_eq!(ownership.controllers[1].role, "Director");
+
+        let reparsed = parser::parse_strict(&serialize_case(&case)).unwrap();
+        assert_eq!(extract_case_info(&reparsed), case);
+    }
+
+    #[test]
+    fn test_parsed_case_roundtrip_derived_attributes() {
+        let ast = parser::parse(
+            r#"(kyc-case T
+                (function ASSESS-RISK complete)
+                (derived-attributes
+                  (attribute HIGH_RISK
+                    (sources (TAX_RESIDENCY_COUNTRY UBO_PERCENT))
+                    (rule "(> UBO_PERCENT 25)")
+                    (jurisdiction GLOBAL)
+                    (regulation AMLD5))))"#,
+        )
+        .unwrap();
+        let case = extract_case_info(&ast);
+        assert_eq!(case.functions[0].status, "complete");
+        let attr = &case.derived_attributes[0];
+        assert_eq!(attr.code, "HIGH_RISK");
+        assert_eq!(
+            attr.source_attributes,
+            vec!["TAX_RESIDENCY_COUNTRY", "UBO_PERCENT"]
+        );
+        assert_eq!(attr.rule_expression, "(> UBO_PERCENT 25)");
+        assert_eq!(attr.regulation_code, "AMLD5");
+
+        let reparsed = parser::parse_strict(&serialize_case(&case)).unwrap();
+        assert_eq!(extract_case_info(&reparsed), case);
+    }
+
+    /// Clients that only set the singular fields still get them serialized
+    #[test]
+    fn test_serialize_singular_fields() {
+        let case = ParsedCase {
+            name: "T".to_string(),
+            policy: "KYCPOL-UK-2025".to_string(),
+            function: "DISCOVER-POLICIES".to_string(),
+            obligation: "OBL-PEP-001".to_string(),
+            document_requirements: Some(DocumentRequirements {
+                jurisdiction: "UK".to_string(),
+                required: vec![DocumentRequirement {
+                    code: "PASSPORT".to_string(),
+                    name: String::new(),
+                }],
+            }),
+            ..Default::default()
+        };
+        let parsed = extract_case_info(&parser::parse_strict(&serialize_case(&case)).unwrap());
+        assert_eq!(parsed.policies, vec!["KYCPOL-UK-2025"]);
+        assert_eq!(parsed.function, "DISCOVER-POLICIES");
+        assert_eq!(parsed.obligation, "OBL-PEP-001");
+        assert_eq!(parsed.document_requirements, case.document_requirements);
+    }
+
+    #[test]
+    fn test_extract_reads_nature_purpose_form() {
+        let ast = parser::parse(
+            "(kyc-case T (nature-purpose (nature \"Fund\") (purpose \"Onboarding\")))",
+        )
+        .unwrap();
+        let case = extract_case_info(&ast);
+        assert_eq!(case.nature, "Fund");
+        assert_eq!(case.purpose, "Onboarding");
+    }
+}
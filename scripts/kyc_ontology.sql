@@ -13,6 +13,7 @@ BEGIN;
 
 CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
 CREATE EXTENSION IF NOT EXISTS "pgvector";
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
 
 -- ============================================================================
 -- 1. Core Entities (Legal Entities, CBU, Roles)
@@ -270,6 +271,9 @@ CREATE INDEX IF NOT EXISTS idx_entity_jurisdiction ON entity(jurisdiction);
 CREATE INDEX IF NOT EXISTS idx_entity_status ON entity(status);
 CREATE INDEX IF NOT EXISTS idx_entity_lei_code ON entity(lei_code);
 CREATE INDEX IF NOT EXISTS idx_entity_name_trgm ON entity USING gin (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_entity_lei_code_trgm ON entity USING gin (lei_code gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_entity_description_fts
+    ON entity USING gin (to_tsvector('simple', COALESCE(description, '')));
 
 -- CBU indexes
 CREATE INDEX IF NOT EXISTS idx_cbu_code ON cbu(code);
@@ -298,6 +302,10 @@ CREATE INDEX IF NOT EXISTS idx_concept_domain ON dictionary_concept(domain);
 CREATE INDEX IF NOT EXISTS idx_attribute_code ON dictionary_attribute(code);
 CREATE INDEX IF NOT EXISTS idx_attribute_jurisdiction ON dictionary_attribute(jurisdiction);
 CREATE INDEX IF NOT EXISTS idx_attribute_type ON dictionary_attribute(attr_type);
+CREATE INDEX IF NOT EXISTS idx_attribute_name_trgm ON dictionary_attribute USING gin (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_attribute_code_trgm ON dictionary_attribute USING gin (code gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_attribute_description_fts
+    ON dictionary_attribute USING gin (to_tsvector('simple', COALESCE(description, '')));
 
 -- Vector indexes for semantic search (using IVFFlat algorithm)
 CREATE INDEX IF NOT EXISTS idx_attribute_vector ON dictionary_attribute
@@ -125,6 +125,12 @@ curl -s "$BASE_URL/rag/attribute/TAX_RESIDENCY_COUNTRY" | jq '.'
 echo ""
 echo -e "${GREEN}✅ Attribute retrieved${NC}"
 echo ""
+echo "GET $BASE_URL/rag/attribute/TAX_RESIDENCY_COUNTRY/profile"
+echo ""
+curl -s "$BASE_URL/rag/attribute/TAX_RESIDENCY_COUNTRY/profile" | jq '{attribute: .attribute.code, documents: (.documents | length), regulations: (.regulations | length), clusters: [.clusters[].cluster_code], feedback, usage}'
+echo ""
+echo -e "${GREEN}✅ Attribute profile retrieved${NC}"
+echo ""
 sleep 1
 
 # Test 9: Error Handling - Missing Parameter